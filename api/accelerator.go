@@ -0,0 +1,103 @@
+package api
+
+import (
+	"sort"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetNodeAccelerators get the GPU/NPU inventory reported by node
+func (api *API) GetNodeAccelerators(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	return service.NodeAcceleratorInventory(node), nil
+}
+
+// validAcceleratorRequests checks that every node selected by the application has enough accelerators
+// for the resources requested by its services, the nodes not reporting the resource are rejected too
+func (api *API) validAcceleratorRequests(namespace string, app *models.ApplicationView) error {
+	return api.checkNodeAccelerators(namespace, app.Selector, acceleratorRequests(app))
+}
+
+// checkNodeAccelerators checks the accelerators of the nodes selected against the requests of one replica
+func (api *API) checkNodeAccelerators(namespace, selector string, requests map[string]int64) error {
+	if len(requests) == 0 || selector == "" {
+		return nil
+	}
+	nodes, err := api.Node.List(namespace, &models.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(requests))
+	for name := range requests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i := range nodes.Items {
+		inventory := service.NodeAcceleratorInventory(&nodes.Items[i])
+		for _, name := range names {
+			if capacity := inventory.Resources[name]; capacity < requests[name] {
+				return common.Error(common.ErrNodeAcceleratorShort,
+					common.Field("name", inventory.Name),
+					common.Field("resource", name),
+					common.Field("capacity", capacity),
+					common.Field("request", requests[name]))
+			}
+		}
+	}
+	return nil
+}
+
+// acceleratorRequests sums the accelerators requested by all the services of one replica,
+// the limit is taken as the request of service as extended resources can not be overcommitted
+func acceleratorRequests(app *models.ApplicationView) map[string]int64 {
+	res := map[string]int64{}
+	services := append(append([]models.ServiceView{}, app.Services...), app.InitServices...)
+	for _, svc := range services {
+		addAcceleratorRequests(res, svc.Resources)
+	}
+	return res
+}
+
+// appAcceleratorRequests sums the accelerators requested by the services of application like acceleratorRequests
+func appAcceleratorRequests(app *specV1.Application) map[string]int64 {
+	res := map[string]int64{}
+	services := append(append([]specV1.Service{}, app.Services...), app.InitServices...)
+	for _, svc := range services {
+		addAcceleratorRequests(res, svc.Resources)
+	}
+	return res
+}
+
+func addAcceleratorRequests(res map[string]int64, resources *specV1.Resources) {
+	if resources == nil {
+		return
+	}
+	values := map[string]string{}
+	for name, val := range resources.Requests {
+		values[name] = val
+	}
+	for name, val := range resources.Limits {
+		values[name] = val
+	}
+	for name, val := range values {
+		if !service.IsAcceleratorResource(name) {
+			continue
+		}
+		q, err := resource.ParseQuantity(val)
+		if err != nil {
+			continue
+		}
+		if count := q.Value(); count > 0 {
+			res[name] += count
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func genAcceleratorNode(name string, gpus int) specV1.Node {
+	devices := make([]models.AcceleratorDevice, 0, gpus)
+	for i := 0; i < gpus; i++ {
+		devices = append(devices, models.AcceleratorDevice{Resource: "nvidia.com/gpu", Index: i})
+	}
+	return specV1.Node{
+		Namespace: "default",
+		Name:      name,
+		Report:    specV1.Report{common.NodeAccelerators: devices},
+	}
+}
+
+func TestGetNodeAccelerators(t *testing.T) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	router.GET("/v1/nodes/:name/accelerators", mockIM, common.Wrapper(api.GetNodeAccelerators))
+
+	node := genAcceleratorNode("node01", 2)
+	sNode.EXPECT().Get(nil, "default", "node01").Return(&node, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/accelerators", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.NodeAccelerators
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, map[string]int64{"nvidia.com/gpu": 2}, res.Resources)
+	assert.Len(t, res.Devices, 2)
+
+	sNode.EXPECT().Get(nil, "default", "node02").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/node02/accelerators", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestValidAcceleratorRequests(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api := &API{Node: sNode, log: log.L()}
+
+	gpuService := func(limit, request string) models.ServiceView {
+		res := &specV1.Resources{Limits: map[string]string{"cpu": "1"}, Requests: map[string]string{}}
+		if limit != "" {
+			res.Limits["nvidia.com/gpu"] = limit
+		}
+		if request != "" {
+			res.Requests["nvidia.com/gpu"] = request
+		}
+		return models.ServiceView{Service: specV1.Service{Name: "svc", Resources: res}}
+	}
+	app := &models.ApplicationView{
+		Selector:     "gpu=true",
+		Services:     []models.ServiceView{gpuService("1", ""), gpuService("", "1")},
+		InitServices: []models.ServiceView{{Service: specV1.Service{Name: "init"}}},
+	}
+	assert.Equal(t, map[string]int64{"nvidia.com/gpu": 2}, acceleratorRequests(app))
+
+	opt := &models.ListOptions{LabelSelector: "gpu=true"}
+	sNode.EXPECT().List("default", opt).Return(&models.NodeList{Items: []specV1.Node{genAcceleratorNode("node01", 2)}}, nil)
+	assert.NoError(t, api.validAcceleratorRequests("default", app))
+
+	sNode.EXPECT().List("default", opt).Return(&models.NodeList{Items: []specV1.Node{
+		genAcceleratorNode("node01", 2), genAcceleratorNode("node02", 1),
+	}}, nil)
+	err := api.validAcceleratorRequests("default", app)
+	e, ok := err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, common.ErrNodeAcceleratorShort, e.Code())
+	assert.Contains(t, err.Error(), "node02")
+
+	// the nodes are not listed without accelerator requested
+	app.Services = []models.ServiceView{gpuService("", "")}
+	assert.NoError(t, api.validAcceleratorRequests("default", app))
+	app.Services = []models.ServiceView{gpuService("1", "")}
+	app.Selector = ""
+	assert.NoError(t, api.validAcceleratorRequests("default", app))
+}
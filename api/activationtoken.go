@@ -0,0 +1,126 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetActivationToken get the activation token
+func (api *API) GetActivationToken(c *common.Context) (interface{}, error) {
+	return api.ActivationToken.Get(c.GetNamespace(), c.GetNameFromParam())
+}
+
+// ListActivationToken list the activation tokens of namespace, the latest issued first
+func (api *API) ListActivationToken(c *common.Context) (interface{}, error) {
+	tokens, err := api.ActivationToken.List(c.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(tokens), tokens, ""), nil
+}
+
+// IssueActivationToken issue a token activating the node or the nodes of group, the install commands of nodes
+// carry it once it's designated by the query activationToken
+func (api *API) IssueActivationToken(c *common.Context) (interface{}, error) {
+	token := new(models.ActivationToken)
+	if err := c.LoadBody(token); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	token.Namespace = c.GetNamespace()
+	res, err := api.ActivationToken.Issue(token)
+	if err != nil {
+		return nil, err
+	}
+	// the endpoints of token are bound to the node once issued, so that the configs of node are rendered with them
+	if res.Node != "" && res.Endpoints != nil {
+		if err = api.bindNodeEndpoints(res); err != nil {
+			return nil, err
+		}
+	}
+	log.L().Info("activation token is issued", log.Any("namespace", res.Namespace), log.Any("name", res.Name),
+		log.Any("node", res.Node), log.Any("nodeGroup", res.NodeGroup), log.Any("maxUses", res.MaxUses))
+	return res, nil
+}
+
+// RevokeActivationToken revoke the activation token, the nodes not activated yet are rejected by init server
+func (api *API) RevokeActivationToken(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	if err := api.ActivationToken.Revoke(ns, name); err != nil {
+		return nil, err
+	}
+	log.L().Info("activation token is revoked", log.Any("namespace", ns), log.Any("name", name))
+	return nil, nil
+}
+
+// GetBootstrapCredential get the credential shared by the devices registering as nodes of the group of token, which
+// is presented to init server instead of the install commands of nodes
+func (api *API) GetBootstrapCredential(c *common.Context) (interface{}, error) {
+	token, err := api.ActivationToken.Get(c.GetNamespace(), c.GetNameFromParam())
+	if err != nil {
+		return nil, err
+	}
+	return api.genBootstrapCredential(token)
+}
+
+// GetProvisioningBundle get the tar.gz bundle embedded in the golden images, the devices imaged in factory register
+// by the bootstrap credential of token on the first boot and install once approved
+func (api *API) GetProvisioningBundle(c *common.Context) (interface{}, error) {
+	mode := c.DefaultQuery("mode", context.RunModeKube)
+	if mode != context.RunModeKube && mode != context.RunModeNative {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the mode should be kube or native"))
+	}
+	token, err := api.ActivationToken.Get(c.GetNamespace(), c.GetNameFromParam())
+	if err != nil {
+		return nil, err
+	}
+	credential, err := api.genBootstrapCredential(token)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{
+		"Token":      credential.Token,
+		"NodeGroup":  credential.NodeGroup,
+		"ExpireTime": credential.ExpireTime.Format(time.RFC3339),
+		"Mode":       mode,
+	}
+	endpoints, err := api.NodeEndpoints.Resolve(token.Namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+	params[service.ParamEndpoints] = service.MergeNodeEndpoints(endpoints, token.Endpoints)
+	bundle, err := service.PackProvisioningBundle(func(template string) ([]byte, error) {
+		return api.Template.ParseTemplate(template, params)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s-provisioning.tar.gz"`, token.Namespace, token.Name))
+	c.Data(http.StatusOK, "application/gzip", bundle)
+	return nil, nil
+}
+
+func (api *API) genBootstrapCredential(token *models.ActivationToken) (*models.BootstrapCredential, error) {
+	if token.NodeGroup == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the token should be bound to a node group"))
+	}
+	if token.IsExpired(time.Now()) {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the token is expired"))
+	}
+	signed, err := api.Sign.GenToken(map[string]interface{}{
+		service.InfoNamespace:       token.Namespace,
+		service.InfoExpiry:          token.ExpireTime.Unix(),
+		service.InfoActivationToken: token.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.BootstrapCredential{Token: signed, NodeGroup: token.NodeGroup, ExpireTime: token.ExpireTime}, nil
+}
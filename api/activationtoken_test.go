@@ -0,0 +1,155 @@
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initActivationTokenAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		tokens := v1.Group("/activationtokens")
+		tokens.GET("", mockIM, common.Wrapper(api.ListActivationToken))
+		tokens.GET("/:name", mockIM, common.Wrapper(api.GetActivationToken))
+		tokens.POST("", mockIM, common.Wrapper(api.IssueActivationToken))
+		tokens.DELETE("/:name", mockIM, common.Wrapper(api.RevokeActivationToken))
+		tokens.GET("/:name/bootstrap", mockIM, common.Wrapper(api.GetBootstrapCredential))
+		tokens.GET("/:name/provisioning", mockIM, common.WrapperNative(api.GetProvisioningBundle, true))
+	}
+	return api, router, mockCtl
+}
+
+func TestActivationTokenAPI(t *testing.T) {
+	api, router, mockCtl := initActivationTokenAPI(t)
+	defer mockCtl.Finish()
+	sToken := ms.NewMockActivationTokenService(mockCtl)
+	api.ActivationToken = sToken
+
+	token := &models.ActivationToken{Namespace: "default", Name: "token01", NodeGroup: "group01", MaxUses: 10, ExpireTime: time.Now().Add(time.Hour)}
+	sToken.EXPECT().Issue(&models.ActivationToken{Namespace: "default", NodeGroup: "group01", MaxUses: 10, Expiry: "1h"}).Return(token, nil)
+	body, _ := json.Marshal(&models.ActivationToken{NodeGroup: "group01", MaxUses: 10, Expiry: "1h"})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/activationtokens", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.ActivationToken
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "token01", res.Name)
+
+	for _, b := range []string{`{"nodeGroup":"group01","maxUses":-1}`, `{"node":"node01","expiry":"forever"}`} {
+		req, _ = http.NewRequest(http.MethodPost, "/v1/activationtokens", bytes.NewReader([]byte(b)))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+
+	sToken.EXPECT().Get("default", "token01").Return(token, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/activationtokens/token01", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sToken.EXPECT().List("default").Return([]models.ActivationToken{*token}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/activationtokens", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Total int                      `json:"total"`
+		Items []models.ActivationToken `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 1, list.Total)
+
+	sToken.EXPECT().Revoke("default", "token01").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/activationtokens/token01", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetProvisioningBundle(t *testing.T) {
+	api, router, mockCtl := initActivationTokenAPI(t)
+	defer mockCtl.Finish()
+	sToken, sSign, sTemplate := ms.NewMockActivationTokenService(mockCtl), ms.NewMockSignService(mockCtl), ms.NewMockTemplateService(mockCtl)
+	sEndpoints := ms.NewMockNodeEndpointsService(mockCtl)
+	api.ActivationToken, api.Sign, api.Template, api.NodeEndpoints = sToken, sSign, sTemplate, sEndpoints
+
+	// the init endpoint of token overrides the one of namespace
+	token := &models.ActivationToken{Namespace: "default", Name: "token01", NodeGroup: "group01", MaxUses: 100, ExpireTime: time.Now().Add(time.Hour),
+		Endpoints: &models.NodeEndpoints{InitServer: "https://gateway.factory:30003"}}
+	sToken.EXPECT().Get("default", "token01").Return(token, nil)
+	sEndpoints.EXPECT().Resolve("default", nil).Return(&models.NodeEndpoints{Namespace: "default", InitServer: "https://gateway.site:30003", SyncServer: "https://gateway.site:30005"}, nil)
+	sSign.EXPECT().GenToken(map[string]interface{}{
+		service.InfoNamespace:       "default",
+		service.InfoExpiry:          token.ExpireTime.Unix(),
+		service.InfoActivationToken: "token01",
+	}).Return("signed", nil)
+	sTemplate.EXPECT().ParseTemplate(gomock.Any(), gomock.Any()).DoAndReturn(func(name string, params map[string]interface{}) ([]byte, error) {
+		assert.Equal(t, "signed", params["Token"])
+		assert.Equal(t, "native", params["Mode"])
+		endpoints := params[service.ParamEndpoints].(*models.NodeEndpoints)
+		assert.Equal(t, "https://gateway.factory:30003", endpoints.InitServer)
+		assert.Equal(t, "https://gateway.site:30005", endpoints.SyncServer)
+		return []byte(name), nil
+	}).Times(3)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/activationtokens/token01/provisioning?mode=native", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "default-token01-provisioning.tar.gz")
+
+	gr, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+	assert.NoError(t, err)
+	tr := tar.NewReader(gr)
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		data, _ := ioutil.ReadAll(tr)
+		files[header.Name] = string(data) + header.Linkname
+	}
+	assert.Equal(t, map[string]string{
+		"etc/baetyl/baetyl-provision.conf":                                    service.TemplateProvisionConf,
+		"usr/local/bin/baetyl-provision.sh":                                   service.TemplateProvisionShell,
+		"etc/systemd/system/baetyl-provision.service":                         service.TemplateProvisionService,
+		"etc/systemd/system/multi-user.target.wants/baetyl-provision.service": "/etc/systemd/system/baetyl-provision.service",
+	}, files)
+
+	// the credential is bound to a node group
+	sToken.EXPECT().Get("default", "token02").Return(&models.ActivationToken{Namespace: "default", Name: "token02", Node: "node01", ExpireTime: time.Now().Add(time.Hour)}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/activationtokens/token02/provisioning", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/activationtokens/token01/provisioning?mode=android", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
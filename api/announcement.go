@@ -0,0 +1,96 @@
+package api
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetAnnouncement get the announcement
+func (api *API) GetAnnouncement(c *common.Context) (interface{}, error) {
+	return api.Announcement.Get(c.GetNameFromParam())
+}
+
+// ListAnnouncement list the announcements published, including the ones planned and expired
+func (api *API) ListAnnouncement(c *common.Context) (interface{}, error) {
+	announcements, err := api.Announcement.List()
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(announcements), announcements, ""), nil
+}
+
+// ListActiveAnnouncement list the announcements shown to the users of namespace now
+func (api *API) ListActiveAnnouncement(c *common.Context) (interface{}, error) {
+	announcements, err := api.Announcement.ListActive(c.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(announcements), announcements, ""), nil
+}
+
+// CreateAnnouncement publishes the announcement, the edge ones are delivered to nodes on their next reports
+func (api *API) CreateAnnouncement(c *common.Context) (interface{}, error) {
+	announcement, err := api.parseAnnouncement(c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = api.Announcement.Get(announcement.Name); err == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "this name is already in use"))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, err
+	}
+	return api.Announcement.Create(announcement)
+}
+
+// UpdateAnnouncement updates the announcement
+func (api *API) UpdateAnnouncement(c *common.Context) (interface{}, error) {
+	announcement, err := api.parseAnnouncement(c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = api.Announcement.Get(announcement.Name); err != nil {
+		return nil, err
+	}
+	return api.Announcement.Update(announcement)
+}
+
+// DeleteAnnouncement withdraws the announcement, the nodes shown it clear it on their next reports
+func (api *API) DeleteAnnouncement(c *common.Context) (interface{}, error) {
+	name := c.GetNameFromParam()
+	if _, err := api.Announcement.Get(name); err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return nil, api.Announcement.Delete(name)
+}
+
+func (api *API) parseAnnouncement(c *common.Context) (*models.Announcement, error) {
+	announcement := new(models.Announcement)
+	announcement.Name = c.GetNameFromParam()
+	if err := c.LoadBody(announcement); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if name := c.GetNameFromParam(); name != "" {
+		announcement.Name = name
+	}
+	if announcement.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	if announcement.StartTime.IsZero() {
+		announcement.StartTime = time.Now()
+	}
+	if !announcement.EndTime.After(announcement.StartTime) {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the end time should be after the start time"))
+	}
+	if _, err := labels.Parse(announcement.NodeSelector); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	return announcement, nil
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initAnnouncementAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		announcements := v1.Group("/announcements")
+		announcements.GET("", common.Wrapper(api.ListAnnouncement))
+		announcements.GET("/:name", common.Wrapper(api.GetAnnouncement))
+		announcements.POST("", common.Wrapper(api.CreateAnnouncement))
+		announcements.PUT("/:name", common.Wrapper(api.UpdateAnnouncement))
+		announcements.DELETE("/:name", common.Wrapper(api.DeleteAnnouncement))
+	}
+	{
+		active := v1.Group("/active-announcements")
+		active.GET("", mockIM, common.Wrapper(api.ListActiveAnnouncement))
+	}
+	return api, router, mockCtl
+}
+
+func TestCreateAnnouncement(t *testing.T) {
+	api, router, mockCtl := initAnnouncementAPI(t)
+	defer mockCtl.Finish()
+	sAnnouncement := ms.NewMockAnnouncementService(mockCtl)
+	api.Announcement = sAnnouncement
+
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	announcement := &models.Announcement{
+		Name:      "maintenance",
+		Title:     "planned maintenance",
+		Content:   "the cloud is under maintenance",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Edge:      true,
+	}
+	sAnnouncement.EXPECT().Get("maintenance").Return(nil, common.Error(common.ErrResourceNotFound))
+	sAnnouncement.EXPECT().Create(gomock.Any()).DoAndReturn(func(a *models.Announcement) (*models.Announcement, error) {
+		assert.Equal(t, announcement, a)
+		return a, nil
+	})
+	body, _ := json.Marshal(announcement)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/announcements", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "planned maintenance")
+
+	sAnnouncement.EXPECT().Get("maintenance").Return(announcement, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/announcements", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	for _, a := range []*models.Announcement{
+		{Name: "maintenance", Title: "t", Content: "c", StartTime: start, EndTime: start},
+		{Name: "maintenance", Title: "t", Content: "c", EndTime: start.Add(time.Hour), Level: "fatal"},
+		{Name: "maintenance", Title: "t", Content: "c", StartTime: start, EndTime: start.Add(time.Hour), NodeSelector: "a=="},
+		{Name: "maintenance", Content: "c", StartTime: start, EndTime: start.Add(time.Hour)},
+		{Title: "t", Content: "c", StartTime: start, EndTime: start.Add(time.Hour)},
+	} {
+		body, _ = json.Marshal(a)
+		req, _ = http.NewRequest(http.MethodPost, "/v1/announcements", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestUpdateAndDeleteAnnouncement(t *testing.T) {
+	api, router, mockCtl := initAnnouncementAPI(t)
+	defer mockCtl.Finish()
+	sAnnouncement := ms.NewMockAnnouncementService(mockCtl)
+	api.Announcement = sAnnouncement
+
+	start := time.Now().UTC()
+	announcement := &models.Announcement{Title: "planned maintenance", Content: "postponed", StartTime: start, EndTime: start.Add(time.Hour)}
+	sAnnouncement.EXPECT().Get("maintenance").Return(nil, common.Error(common.ErrResourceNotFound))
+	body, _ := json.Marshal(announcement)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/announcements/maintenance", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	sAnnouncement.EXPECT().Get("maintenance").Return(&models.Announcement{Name: "maintenance"}, nil)
+	sAnnouncement.EXPECT().Update(gomock.Any()).DoAndReturn(func(a *models.Announcement) (*models.Announcement, error) {
+		assert.Equal(t, "maintenance", a.Name)
+		assert.Equal(t, "postponed", a.Content)
+		return a, nil
+	})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/announcements/maintenance", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the announcements deleted already are ignored
+	sAnnouncement.EXPECT().Get("maintenance").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/announcements/maintenance", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sAnnouncement.EXPECT().Get("maintenance").Return(&models.Announcement{Name: "maintenance"}, nil)
+	sAnnouncement.EXPECT().Delete("maintenance").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/announcements/maintenance", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestListActiveAnnouncement(t *testing.T) {
+	api, router, mockCtl := initAnnouncementAPI(t)
+	defer mockCtl.Finish()
+	sAnnouncement := ms.NewMockAnnouncementService(mockCtl)
+	api.Announcement = sAnnouncement
+
+	sAnnouncement.EXPECT().ListActive("default").Return([]models.Announcement{{Name: "maintenance", Title: "planned maintenance"}}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/active-announcements", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "planned maintenance")
+
+	sAnnouncement.EXPECT().List().Return([]models.Announcement{{Name: "maintenance"}, {Name: "expired"}}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/announcements", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "expired")
+}
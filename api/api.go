@@ -11,25 +11,92 @@ import (
 
 // API baetyl api server
 type API struct {
-	Hooks    map[string]interface{}
-	NS       service.NamespaceService
-	Node     service.NodeService
-	Index    service.IndexService
-	Func     service.FunctionService
-	Obj      service.ObjectService
-	PKI      service.PKIService
-	Auth     service.AuthService
-	Prop     service.PropertyService
-	Module   service.ModuleService
-	Init     service.InitService
-	License  service.LicenseService
-	Template service.TemplateService
-	Task     service.TaskService
-	Locker   service.LockerService
-	SysApp   service.SystemAppService
-	Sign     service.SignService
-	Wrapper  service.WrapperService
-	Facade   facade.Facade
+	Hooks         map[string]interface{}
+	NS            service.NamespaceService
+	Node          service.NodeService
+	Index         service.IndexService
+	Func          service.FunctionService
+	Obj           service.ObjectService
+	PKI           service.PKIService
+	Auth          service.AuthService
+	Prop          service.PropertyService
+	Module        service.ModuleService
+	Init          service.InitService
+	License       service.LicenseService
+	Template      service.TemplateService
+	Task          service.TaskService
+	Locker        service.LockerService
+	SysApp        service.SystemAppService
+	Sign          service.SignService
+	Wrapper       service.WrapperService
+	Group         service.NodeGroupService
+	Exec          service.ExecService
+	Audit         service.AuditService
+	Health        service.HealthService
+	Metrics       service.NodeMetricsService
+	Heartbeat     service.HeartbeatService
+	NodeCert      service.NodeCertService
+	ClusterImport service.ClusterImportService
+	Maintenance   service.MaintenanceService
+	SyncInterval  service.SyncIntervalService
+	SyncHistory   service.SyncHistoryService
+	AppConflict   service.AppConflictService
+	NodeFilter    service.NodeFilterService
+	EdgeCluster   service.EdgeClusterService
+	AppHistory    service.AppHistoryService
+	Rollout       service.RolloutService
+	Helm          service.HelmService
+	EnvGroup      service.EnvGroupService
+	SidecarPolicy service.SidecarPolicyService
+	ImagePolicy   service.ImagePolicyService
+	ConfigObject  service.ConfigObjectService
+	// NodeEndpoints the endpoints of cloud overridden for the nodes behind reverse proxies or NAT gateways
+	NodeEndpoints service.NodeEndpointsService
+	// SecretRotation the expiry and rotation policies of secrets
+	SecretRotation service.SecretRotationService
+	// ExternalSecret checks the references of the secrets stored in external stores
+	ExternalSecret service.ExternalSecretService
+	// FunctionBuild builds the images of the function versions uploaded as code zips
+	FunctionBuild service.FunctionBuildService
+	// FunctionDraft edits the code of functions online and publishes the drafts as versions
+	FunctionDraft service.FunctionDraftService
+	// ActivationToken the tokens authorizing init server to activate nodes
+	ActivationToken service.ActivationTokenService
+	Registry        service.RegistryService
+	// Registration the devices registering as nodes by the bootstrap credentials, and the rules approving them
+	Registration service.NodeRegistrationService
+	// Fingerprint the hardware fingerprints which nodes are bound to at activation
+	Fingerprint service.NodeFingerprintService
+	// Onboarding the steps of node onboarding since the last activation
+	Onboarding service.NodeOnboardingService
+	// Preflight the checks of the hosts of nodes reported before activation
+	Preflight service.NodePreflightService
+	// ModuleArtifact the images and programs of module versions built for the platforms
+	ModuleArtifact service.ModuleArtifactService
+	// InstallTemplate the templates of install scripts and manifests overridden in namespaces
+	InstallTemplate service.InstallTemplateService
+	// ServiceRecord the discovery records derived from the ports of apps
+	ServiceRecord service.ServiceRecordService
+	Offline       service.OfflineService
+	// ResourceSearch searches the resources of all namespaces for operators
+	ResourceSearch service.ResourceSearchService
+	// Tenant the lifecycle of tenant namespaces managed by operators
+	Tenant service.TenantService
+	// Impersonation the contexts issued to operators who act as users of namespaces
+	Impersonation service.ImpersonationService
+	// PlatformStats the stats of all namespaces cached for the ops dashboard
+	PlatformStats service.PlatformStatsService
+	// Announcement the announcements of the platform published by operators
+	Announcement service.AnnouncementService
+	// Quarantine the resources quarantined platform-wide, the apps referencing them can not be deployed
+	Quarantine service.QuarantineService
+	// Garbage the reports of the resources left without owners
+	Garbage service.GarbageService
+	// ReadOnly the read-only mode of platform switched by operators
+	ReadOnly service.ReadOnlyService
+	// FeatureFlag the flags enabling the capabilities tenant by tenant
+	FeatureFlag service.FeatureFlagService
+	Facade      facade.Facade
 	*service.AppCombinedService
 	log *log.Logger
 }
@@ -80,6 +147,10 @@ func NewAPI(config *config.CloudConfig) (*API, error) {
 	if err != nil {
 		return nil, err
 	}
+	artifactService, err := service.NewModuleArtifactService(config)
+	if err != nil {
+		return nil, err
+	}
 	initService, err := service.NewInitService(config)
 	if err != nil {
 		return nil, err
@@ -90,9 +161,11 @@ func NewAPI(config *config.CloudConfig) (*API, error) {
 	}
 	templateService, err := service.NewTemplateService(config, map[string]interface{}{
 		"GetProperty":      propertyService.GetPropertyValue,
+		"GetEndpoint":      service.GetEndpointFunc(propertyService),
 		"RandString":       common.RandString,
 		"GetModuleImage":   moduleService.GetLatestModuleImage,
-		"GetModuleProgram": moduleService.GetLatestModuleProgram,
+		"GetModuleProgram": artifactService.SelectProgram,
+		"GetPlatformImage": service.GetPlatformImageFunc(moduleService, artifactService),
 	})
 	if err != nil {
 		return nil, err
@@ -113,6 +186,183 @@ func NewAPI(config *config.CloudConfig) (*API, error) {
 	if err != nil {
 		return nil, err
 	}
+	groupService, err := service.NewNodeGroupService(config)
+	if err != nil {
+		return nil, err
+	}
+	// the audit is shared by all, since the hash chain of records is extended one by one
+	auditService, err := service.NewAuditService(config)
+	if err != nil {
+		return nil, err
+	}
+	execService, err := service.NewExecService(config, auditService)
+	if err != nil {
+		return nil, err
+	}
+	healthService, err := service.NewHealthService(config)
+	if err != nil {
+		return nil, err
+	}
+	metricsService, err := service.NewNodeMetricsService(config)
+	if err != nil {
+		return nil, err
+	}
+	heartbeatService, err := service.NewHeartbeatService(config)
+	if err != nil {
+		return nil, err
+	}
+	nodeCertService, err := service.NewNodeCertService(config)
+	if err != nil {
+		return nil, err
+	}
+	clusterImportService, err := service.NewClusterImportService(config)
+	if err != nil {
+		return nil, err
+	}
+	maintenanceService, err := service.NewMaintenanceService(config)
+	if err != nil {
+		return nil, err
+	}
+	syncIntervalService, err := service.NewSyncIntervalService(config)
+	if err != nil {
+		return nil, err
+	}
+	syncHistoryService, err := service.NewSyncHistoryService(config)
+	if err != nil {
+		return nil, err
+	}
+	activationTokenService, err := service.NewActivationTokenService(config)
+	if err != nil {
+		return nil, err
+	}
+	registrationService, err := service.NewNodeRegistrationService(config)
+	if err != nil {
+		return nil, err
+	}
+	fingerprintService, err := service.NewNodeFingerprintService(config)
+	if err != nil {
+		return nil, err
+	}
+	onboardingService, err := service.NewNodeOnboardingService(config)
+	if err != nil {
+		return nil, err
+	}
+	preflightService, err := service.NewNodePreflightService(config)
+	if err != nil {
+		return nil, err
+	}
+	installTemplateService, err := service.NewInstallTemplateService(config)
+	if err != nil {
+		return nil, err
+	}
+	appConflictService, err := service.NewAppConflictService(config)
+	if err != nil {
+		return nil, err
+	}
+	nodeFilterService, err := service.NewNodeFilterService(config)
+	if err != nil {
+		return nil, err
+	}
+	edgeClusterService, err := service.NewEdgeClusterService(config)
+	if err != nil {
+		return nil, err
+	}
+	appHistoryService, err := service.NewAppHistoryService(config)
+	if err != nil {
+		return nil, err
+	}
+	rolloutService, err := service.NewRolloutService(config)
+	if err != nil {
+		return nil, err
+	}
+	helmService, err := service.NewHelmService(config)
+	if err != nil {
+		return nil, err
+	}
+	envGroupService, err := service.NewEnvGroupService(config)
+	if err != nil {
+		return nil, err
+	}
+	sidecarPolicyService, err := service.NewSidecarPolicyService(config)
+	if err != nil {
+		return nil, err
+	}
+	imagePolicyService, err := service.NewImagePolicyService(config)
+	if err != nil {
+		return nil, err
+	}
+	nodeEndpointsService, err := service.NewNodeEndpointsService(config)
+	if err != nil {
+		return nil, err
+	}
+	configObjectService, err := service.NewConfigObjectService(config)
+	if err != nil {
+		return nil, err
+	}
+	secretRotationService, err := service.NewSecretRotationService(config)
+	if err != nil {
+		return nil, err
+	}
+	externalSecretService, err := service.NewExternalSecretService(config)
+	if err != nil {
+		return nil, err
+	}
+	functionBuildService, err := service.NewFunctionBuildService(config)
+	if err != nil {
+		return nil, err
+	}
+	functionDraftService, err := service.NewFunctionDraftService(config)
+	if err != nil {
+		return nil, err
+	}
+	registryService, err := service.NewRegistryService(config)
+	if err != nil {
+		return nil, err
+	}
+	serviceRecordService, err := service.NewServiceRecordService(config)
+	if err != nil {
+		return nil, err
+	}
+	offlineService, err := service.NewOfflineService(config)
+	if err != nil {
+		return nil, err
+	}
+	resourceSearchService, err := service.NewResourceSearchService(config)
+	if err != nil {
+		return nil, err
+	}
+	tenantService, err := service.NewTenantService(config)
+	if err != nil {
+		return nil, err
+	}
+	impersonationService, err := service.NewImpersonationService(config, auditService)
+	if err != nil {
+		return nil, err
+	}
+	platformStatsService, err := service.NewPlatformStatsService(config)
+	if err != nil {
+		return nil, err
+	}
+	announcementService, err := service.NewAnnouncementService(config)
+	if err != nil {
+		return nil, err
+	}
+	quarantineService, err := service.NewQuarantineService(config)
+	if err != nil {
+		return nil, err
+	}
+	garbageService, err := service.NewGarbageService(config)
+	if err != nil {
+		return nil, err
+	}
+	readOnlyService, err := service.NewReadOnlyService(config)
+	if err != nil {
+		return nil, err
+	}
+	featureFlagService, err := service.NewFeatureFlagService(config)
+	if err != nil {
+		return nil, err
+	}
 	appFacade, err := facade.NewFacade(config)
 	if err != nil {
 		return nil, err
@@ -135,6 +385,51 @@ func NewAPI(config *config.CloudConfig) (*API, error) {
 		Locker:             lockerService,
 		SysApp:             sysApp,
 		Wrapper:            wrapper,
+		Group:              groupService,
+		Exec:               execService,
+		Audit:              auditService,
+		Health:             healthService,
+		Metrics:            metricsService,
+		Heartbeat:          heartbeatService,
+		NodeCert:           nodeCertService,
+		ClusterImport:      clusterImportService,
+		Maintenance:        maintenanceService,
+		SyncInterval:       syncIntervalService,
+		SyncHistory:        syncHistoryService,
+		AppConflict:        appConflictService,
+		ActivationToken:    activationTokenService,
+		Registration:       registrationService,
+		Fingerprint:        fingerprintService,
+		Onboarding:         onboardingService,
+		Preflight:          preflightService,
+		ModuleArtifact:     artifactService,
+		InstallTemplate:    installTemplateService,
+		NodeFilter:         nodeFilterService,
+		EdgeCluster:        edgeClusterService,
+		AppHistory:         appHistoryService,
+		Rollout:            rolloutService,
+		Helm:               helmService,
+		EnvGroup:           envGroupService,
+		SidecarPolicy:      sidecarPolicyService,
+		ImagePolicy:        imagePolicyService,
+		NodeEndpoints:      nodeEndpointsService,
+		ConfigObject:       configObjectService,
+		SecretRotation:     secretRotationService,
+		ExternalSecret:     externalSecretService,
+		FunctionBuild:      functionBuildService,
+		FunctionDraft:      functionDraftService,
+		Registry:           registryService,
+		ServiceRecord:      serviceRecordService,
+		Offline:            offlineService,
+		ResourceSearch:     resourceSearchService,
+		Tenant:             tenantService,
+		Impersonation:      impersonationService,
+		PlatformStats:      platformStatsService,
+		Announcement:       announcementService,
+		Quarantine:         quarantineService,
+		Garbage:            garbageService,
+		ReadOnly:           readOnlyService,
+		FeatureFlag:        featureFlagService,
 		AppCombinedService: acs,
 		Facade:             appFacade,
 		log:                log.L().With(log.Any("api", "admin")),
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// CloneApplication clones the application into the target namespace. The configs and secrets (including registries
+// and certificates) referenced by the application are copied too, the ones which the target namespace has already
+// are kept and not overwritten
+func (api *API) CloneApplication(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	req := new(models.AppCloneRequest)
+	if err := c.LoadBody(req); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if req.Name == "" {
+		req.Name = name
+	}
+	if req.Namespace == ns && req.Name == name {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the application can not be cloned to itself"))
+	}
+	err := api.Auth.Verify(c, &plugin.PermissionRequest{
+		Resource:   plugin.PermissionResourceApp,
+		Permission: []string{plugin.PermissionFull},
+		RequestContext: plugin.RequestContext{
+			Conditions: map[string]interface{}{common.KeyContextNamespace: req.Namespace},
+		},
+	})
+	if err != nil {
+		return nil, common.Error(common.ErrRequestAccessDenied, common.Field("error", err.Error()))
+	}
+	if _, err = api.NS.Get(req.Namespace); err != nil {
+		return nil, err
+	}
+
+	app, err := api.App.Get(ns, name, "")
+	if err != nil {
+		return nil, err
+	}
+	if common.ValidIsInvisible(app.Labels) || CheckIsSysResources(app.Labels) {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the system application can not be cloned"))
+	}
+	appView, err := api.ToApplicationView(app)
+	if err != nil {
+		return nil, err
+	}
+	appView.Name, appView.Namespace, appView.Version = req.Name, req.Namespace, ""
+	appView.CreationTimestamp = time.Time{}
+	appView.CronStatus, appView.CronTime = specV1.CronNotSet, time.Time{}
+	if req.Selector != "" {
+		appView.Selector, appView.NodeGroup, appView.EdgeCluster = req.Selector, "", ""
+	}
+
+	res := &models.AppClone{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Configs:   []string{},
+		Secrets:   []string{},
+		Existing:  []string{},
+	}
+	var copied []string
+	for _, v := range app.Volumes {
+		if v.Config != nil && !isGenConfigOfApp(v.Config.Name) {
+			ok, err := api.cloneAppConfig(ns, req.Namespace, v.Config.Name, c.IsDryRun())
+			if err != nil {
+				api.cleanClonedResources(req.Namespace, copied)
+				return nil, err
+			}
+			if !ok {
+				res.Existing = append(res.Existing, string(common.Config)+"/"+v.Config.Name)
+				continue
+			}
+			res.Configs = append(res.Configs, v.Config.Name)
+			if !c.IsDryRun() {
+				copied = append(copied, string(common.Config)+"/"+v.Config.Name)
+			}
+		}
+		if v.Secret != nil {
+			ok, err := api.cloneAppSecret(ns, req.Namespace, v.Secret.Name, c.IsDryRun())
+			if err != nil {
+				api.cleanClonedResources(req.Namespace, copied)
+				return nil, err
+			}
+			if !ok {
+				res.Existing = append(res.Existing, string(common.Secret)+"/"+v.Secret.Name)
+				continue
+			}
+			res.Secrets = append(res.Secrets, v.Secret.Name)
+			if !c.IsDryRun() {
+				copied = append(copied, string(common.Secret)+"/"+v.Secret.Name)
+			}
+		}
+	}
+	if c.IsDryRun() {
+		res.Application = appView
+		return res, nil
+	}
+
+	res.Application, err = api.createApplicationView(c, req.Namespace, appView)
+	if err != nil {
+		api.cleanClonedResources(req.Namespace, copied)
+		return nil, err
+	}
+	return res, nil
+}
+
+// isGenConfigOfApp returns whether the config is generated for the application,
+// which is generated again for the cloned application instead of being copied
+func isGenConfigOfApp(name string) bool {
+	return strings.HasPrefix(name, FunctionConfigPrefix) ||
+		strings.HasPrefix(name, FunctionProgramConfigPrefix) ||
+		strings.HasPrefix(name, CronJobConfigPrefix)
+}
+
+// cloneAppConfig copies the config to the target namespace, false is returned if the target has it already
+func (api *API) cloneAppConfig(ns, target, name string, dryRun bool) (bool, error) {
+	if _, err := api.Config.Get(target, name, ""); err == nil {
+		return false, nil
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return false, err
+	}
+	cfg, err := api.Config.Get(ns, name, "")
+	if err != nil {
+		return false, err
+	}
+	if dryRun {
+		return true, nil
+	}
+	cfg.Namespace, cfg.Version = target, ""
+	cfg.CreationTimestamp, cfg.UpdateTimestamp = time.Time{}, time.Time{}
+	if _, err = api.Config.Create(nil, target, cfg); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// cloneAppSecret copies the secret to the target namespace, false is returned if the target has it already
+func (api *API) cloneAppSecret(ns, target, name string, dryRun bool) (bool, error) {
+	if _, err := api.Secret.Get(target, name, ""); err == nil {
+		return false, nil
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return false, err
+	}
+	secret, err := api.Secret.Get(ns, name, "")
+	if err != nil {
+		return false, err
+	}
+	if dryRun {
+		return true, nil
+	}
+	secret.Namespace, secret.Version = target, ""
+	secret.CreationTimestamp, secret.UpdateTimestamp = time.Time{}, time.Time{}
+	if _, err = api.Secret.Create(nil, target, secret); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// cleanClonedResources deletes the resources copied before the clone failed
+func (api *API) cleanClonedResources(ns string, resources []string) {
+	for i := len(resources) - 1; i >= 0; i-- {
+		parts := strings.SplitN(resources[i], "/", 2)
+		kind, name := parts[0], parts[1]
+		var err error
+		if kind == string(common.Config) {
+			err = api.Config.Delete(nil, ns, name)
+		} else {
+			err = api.Secret.Delete(ns, name)
+		}
+		if err != nil {
+			common.LogDirtyData(err, log.Any("type", kind), log.Any(common.KeyContextNamespace, ns), log.Any("name", name))
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initAppCloneAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	router.POST("/v1/apps/:name/clone", mockIM, common.Wrapper(api.CloneApplication))
+	return api, router, mockCtl
+}
+
+func newCloneRequest(t *testing.T, url string, req *models.AppCloneRequest) *http.Request {
+	body, err := json.Marshal(req)
+	assert.NoError(t, err)
+	r, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	return r
+}
+
+func TestCloneApplication(t *testing.T) {
+	api, router, mockCtl := initAppCloneAPI(t)
+	defer mockCtl.Finish()
+
+	sAuth := ms.NewMockAuthService(mockCtl)
+	sNS := ms.NewMockNamespaceService(mockCtl)
+	sApp := ms.NewMockApplicationService(mockCtl)
+	sConfig := ms.NewMockConfigService(mockCtl)
+	sSecret := ms.NewMockSecretService(mockCtl)
+	api.Auth, api.NS = sAuth, sNS
+	api.AppCombinedService = &service.AppCombinedService{App: sApp, Config: sConfig, Secret: sSecret}
+
+	app := &specV1.Application{
+		Name:      "web",
+		Namespace: "default",
+		Version:   "12",
+		Mode:      context.RunModeKube,
+		Type:      common.ContainerApp,
+		Selector:  "app=web",
+		Services:  []specV1.Service{{Name: "nginx", Image: "nginx:latest"}},
+		Volumes: []specV1.Volume{
+			{Name: "conf", VolumeSource: specV1.VolumeSource{Config: &specV1.ObjectReference{Name: "web-conf"}}},
+			{Name: "shared", VolumeSource: specV1.VolumeSource{Config: &specV1.ObjectReference{Name: "shared-conf"}}},
+			{Name: "reg", VolumeSource: specV1.VolumeSource{Secret: &specV1.ObjectReference{Name: "reg"}}},
+		},
+	}
+	registry := &specV1.Secret{
+		Name:      "reg",
+		Namespace: "default",
+		Labels:    map[string]string{specV1.SecretLabel: specV1.SecretRegistry},
+		Data:      map[string][]byte{"address": []byte("hub.baidu.com"), "username": []byte("baetyl")},
+	}
+	notFound := common.Error(common.ErrResourceNotFound)
+
+	// cloned to itself
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCloneRequest(t, "/v1/apps/web/clone", &models.AppCloneRequest{Namespace: "default"}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the target namespace is not accessible
+	sAuth.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(fmt.Errorf("denied"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newCloneRequest(t, "/v1/apps/web/clone", &models.AppCloneRequest{Namespace: "prod"}))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// the system app can not be cloned
+	sys := &specV1.Application{Name: "baetyl-core", Namespace: "default", Labels: map[string]string{common.LabelSystem: "true"}}
+	sAuth.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(nil)
+	sNS.EXPECT().Get("prod").Return(&models.Namespace{Name: "prod"}, nil)
+	sApp.EXPECT().Get("default", "baetyl-core", "").Return(sys, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newCloneRequest(t, "/v1/apps/baetyl-core/clone", &models.AppCloneRequest{Namespace: "prod"}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// dry run reports the resources to copy, the ones existing in target are kept
+	sAuth.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(nil)
+	sNS.EXPECT().Get("prod").Return(&models.Namespace{Name: "prod"}, nil)
+	sApp.EXPECT().Get("default", "web", "").Return(app, nil)
+	sSecret.EXPECT().Get("default", "reg", "").Return(registry, nil).Times(2)
+	sConfig.EXPECT().Get("prod", "web-conf", "").Return(nil, notFound)
+	sConfig.EXPECT().Get("default", "web-conf", "").Return(&specV1.Configuration{Name: "web-conf", Namespace: "default"}, nil)
+	sConfig.EXPECT().Get("prod", "shared-conf", "").Return(&specV1.Configuration{Name: "shared-conf", Namespace: "prod"}, nil)
+	sSecret.EXPECT().Get("prod", "reg", "").Return(nil, notFound)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newCloneRequest(t, "/v1/apps/web/clone?dryRun=true", &models.AppCloneRequest{Namespace: "prod", Name: "web-prod", Selector: "env=prod"}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.AppClone)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, []string{"web-conf"}, res.Configs)
+	assert.Equal(t, []string{"reg"}, res.Secrets)
+	assert.Equal(t, []string{"config/shared-conf"}, res.Existing)
+	assert.Equal(t, "web-prod", res.Application.Name)
+	assert.Equal(t, "prod", res.Application.Namespace)
+	assert.Equal(t, "env=prod", res.Application.Selector)
+	assert.Empty(t, res.Application.Version)
+	assert.Len(t, res.Application.Registries, 1)
+
+	// the copied config is deleted if the clone fails
+	sAuth.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(nil)
+	sNS.EXPECT().Get("prod").Return(&models.Namespace{Name: "prod"}, nil)
+	sApp.EXPECT().Get("default", "web", "").Return(app, nil)
+	sSecret.EXPECT().Get("default", "reg", "").Return(registry, nil).Times(2)
+	sConfig.EXPECT().Get("prod", "web-conf", "").Return(nil, notFound)
+	sConfig.EXPECT().Get("default", "web-conf", "").Return(&specV1.Configuration{Name: "web-conf", Namespace: "default", Version: "3"}, nil)
+	sConfig.EXPECT().Create(nil, "prod", gomock.Any()).DoAndReturn(func(_ interface{}, _ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		assert.Equal(t, "prod", cfg.Namespace)
+		assert.Empty(t, cfg.Version)
+		return cfg, nil
+	})
+	sConfig.EXPECT().Get("prod", "shared-conf", "").Return(&specV1.Configuration{Name: "shared-conf", Namespace: "prod"}, nil)
+	sSecret.EXPECT().Get("prod", "reg", "").Return(nil, notFound)
+	sSecret.EXPECT().Create(nil, "prod", gomock.Any()).Return(nil, fmt.Errorf("error"))
+	sConfig.EXPECT().Delete(nil, "prod", "web-conf").Return(nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newCloneRequest(t, "/v1/apps/web/clone", &models.AppCloneRequest{Namespace: "prod"}))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestIsGenConfigOfApp(t *testing.T) {
+	assert.True(t, isGenConfigOfApp(FunctionConfigPrefix+"-web-abc"))
+	assert.True(t, isGenConfigOfApp(CronJobConfigPrefix+"-web-abc"))
+	assert.False(t, isGenConfigOfApp("web-conf"))
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// ListNodeAppConflicts list the apps changed on node out of band, which are withheld from node until resolved
+func (api *API) ListNodeAppConflicts(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	return service.GetNodeAppConflicts(node)
+}
+
+// ResolveNodeAppConflict adopt the version of app node reports, or enforce the desired version on node
+func (api *API) ResolveNodeAppConflict(c *common.Context) (interface{}, error) {
+	ns, n, app := c.GetNamespace(), c.GetNameFromParam(), c.Param("app")
+	resolution := new(models.AppConflictResolution)
+	if err := c.LoadBody(resolution); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	res, err := api.AppConflict.Resolve(node, app, resolution.Resolution)
+	if err != nil {
+		return nil, err
+	}
+	log.L().Info("node app conflict is resolved", log.Any("namespace", ns), log.Any("name", n), log.Any("app", app), log.Any("resolution", resolution.Resolution))
+	return res, nil
+}
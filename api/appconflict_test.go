@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initAppConflictAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		nodes := v1.Group("/nodes")
+		nodes.GET("/:name/conflicts", mockIM, common.Wrapper(api.ListNodeAppConflicts))
+		nodes.PUT("/:name/conflicts/:app", mockIM, common.Wrapper(api.ResolveNodeAppConflict))
+	}
+	return api, router, mockCtl
+}
+
+func TestListNodeAppConflicts(t *testing.T) {
+	api, router, mockCtl := initAppConflictAPI(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+
+	node := &specV1.Node{
+		Namespace: "default",
+		Name:      "node01",
+		Attributes: map[string]interface{}{common.AttributeAppConflicts: []interface{}{
+			map[string]interface{}{"app": "a", "desired": "3", "reported": "9"},
+		}},
+	}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/conflicts", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res []models.AppConflict
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Len(t, res, 1)
+	assert.Equal(t, "9", res[0].Reported)
+
+	sNode.EXPECT().Get(nil, "default", "node02").Return(&specV1.Node{Namespace: "default", Name: "node02"}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/node02/conflicts", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+}
+
+func TestResolveNodeAppConflict(t *testing.T) {
+	api, router, mockCtl := initAppConflictAPI(t)
+	defer mockCtl.Finish()
+	sNode, sConflict := ms.NewMockNodeService(mockCtl), ms.NewMockAppConflictService(mockCtl)
+	api.Node, api.AppConflict = sNode, sConflict
+
+	node := &specV1.Node{Namespace: "default", Name: "node01"}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	sConflict.EXPECT().Resolve(node, "a", models.AppConflictAdopt).Return(&models.AppConflict{App: "a", Desired: "3", Reported: "9", Resolution: models.AppConflictAdopt}, nil)
+	body, _ := json.Marshal(&models.AppConflictResolution{Resolution: models.AppConflictAdopt})
+	req, _ := http.NewRequest(http.MethodPut, "/v1/nodes/node01/conflicts/a", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	for _, b := range []string{`{}`, `{"resolution":"ignore"}`} {
+		req, _ = http.NewRequest(http.MethodPut, "/v1/nodes/node01/conflicts/a", bytes.NewReader([]byte(b)))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// applyAppDependencies marks the apps depended on in the labels of application,
+// they should exist in the same namespace and depend on the application neither directly nor indirectly
+func (api *API) applyAppDependencies(appView *models.ApplicationView) error {
+	appView.Labels = service.SetAppDependencies(appView.Labels, nil)
+	if len(appView.DependsOn) == 0 {
+		return nil
+	}
+	if appView.System {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the system app can not depend on other apps"))
+	}
+	apps, err := api.App.List(appView.Namespace, &models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	deps := map[string][]string{}
+	for _, item := range apps.Items {
+		deps[item.Name] = service.AppDependencies(item.Labels)
+	}
+	for _, dep := range appView.DependsOn {
+		if dep == appView.Name {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the app can not depend on itself"))
+		}
+		if _, ok := deps[dep]; !ok {
+			return common.Error(common.ErrResourceNotFound, common.Field("type", common.APP), common.Field("name", dep))
+		}
+	}
+	deps[appView.Name] = appView.DependsOn
+	if cycle := service.FindAppDependencyCycle(appView.Name, deps); cycle != nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "circular app dependency "+strings.Join(cycle, " -> ")))
+	}
+	appView.Labels = service.SetAppDependencies(appView.Labels, appView.DependsOn)
+	return nil
+}
+
+// checkAppDependents forbids deleting the application which other applications depend on
+func (api *API) checkAppDependents(ns, name string) error {
+	apps, err := api.App.List(ns, &models.ListOptions{LabelSelector: common.LabelPrefixDependsOn + name})
+	if err != nil {
+		return err
+	}
+	if len(apps.Items) > 0 {
+		return common.Error(common.ErrResourceDeleteForbidden, common.Field("type", common.APP), common.Field("name", name))
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func TestApplyAppDependencies(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sApp := ms.NewMockApplicationService(mockCtl)
+	api := &API{AppCombinedService: &service.AppCombinedService{App: sApp}, log: log.L()}
+
+	apps := &models.ApplicationList{Items: []models.AppItem{
+		{Name: "db"},
+		{Name: "api", Labels: map[string]string{common.LabelPrefixDependsOn + "db": "true"}},
+		{Name: "web", Labels: map[string]string{common.LabelPrefixDependsOn + "api": "true"}},
+	}}
+	sApp.EXPECT().List("default", &models.ListOptions{}).Return(apps, nil).AnyTimes()
+
+	// the dependencies removed are unmarked
+	appView := &models.ApplicationView{Namespace: "default", Name: "web", Labels: map[string]string{common.LabelPrefixDependsOn + "api": "true", "a": "b"}}
+	assert.NoError(t, api.applyAppDependencies(appView))
+	assert.Equal(t, map[string]string{"a": "b"}, appView.Labels)
+
+	appView = &models.ApplicationView{Namespace: "default", Name: "job", DependsOn: []string{"db", "api"}}
+	assert.NoError(t, api.applyAppDependencies(appView))
+	assert.Equal(t, []string{"api", "db"}, service.AppDependencies(appView.Labels))
+
+	appView = &models.ApplicationView{Namespace: "default", Name: "job", DependsOn: []string{"cache"}}
+	assert.Error(t, api.applyAppDependencies(appView))
+
+	appView = &models.ApplicationView{Namespace: "default", Name: "job", DependsOn: []string{"job"}}
+	assert.Error(t, api.applyAppDependencies(appView))
+
+	// db -> web -> api -> db
+	appView = &models.ApplicationView{Namespace: "default", Name: "db", DependsOn: []string{"web"}}
+	err := api.applyAppDependencies(appView)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db -> web -> api -> db")
+
+	appView = &models.ApplicationView{Namespace: "default", Name: "job", DependsOn: []string{"db"}, System: true}
+	assert.Error(t, api.applyAppDependencies(appView))
+}
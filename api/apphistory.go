@@ -0,0 +1,92 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+// ListAppRevision list the revisions of application, the latest first
+func (api *API) ListAppRevision(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.getVisibleApp(ns, name); err != nil {
+		return nil, err
+	}
+	items, err := api.AppHistory.List(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(items), items, ""), nil
+}
+
+// GetAppRevision get the revision of application with its spec
+func (api *API) GetAppRevision(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	revision, err := parseAppRevision(c.Param("revision"))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = api.getVisibleApp(ns, name); err != nil {
+		return nil, err
+	}
+	return api.AppHistory.Get(ns, name, revision)
+}
+
+// RollbackApplication updates the application to the spec of revision, which is saved as a new revision,
+// the cron and ota of application are kept as they are not part of the spec
+func (api *API) RollbackApplication(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	revision, err := parseAppRevision(c.Query("revision"))
+	if err != nil {
+		return nil, err
+	}
+	oldApp, err := api.getVisibleApp(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := api.AppHistory.Get(ns, name, revision)
+	if err != nil {
+		return nil, err
+	}
+	if rev.Application == nil {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "revision"), common.Field("name", name))
+	}
+	appView, err := api.ToApplicationView(rev.Application)
+	if err != nil {
+		return nil, err
+	}
+	appView.CronStatus, appView.CronTime = oldApp.CronStatus, oldApp.CronTime
+	return api.updateApplicationView(c, appView)
+}
+
+// recordAppRevision saves the application as a revision, the failure does not fail the request of application
+func (api *API) recordAppRevision(app *specV1.Application) {
+	if api.AppHistory == nil || app == nil {
+		return
+	}
+	if _, err := api.AppHistory.Record(app); err != nil {
+		log.L().Warn("failed to record the revision of application", log.Any("ns", app.Namespace), log.Any("name", app.Name), log.Error(err))
+	}
+}
+
+func (api *API) getVisibleApp(ns, name string) (*specV1.Application, error) {
+	app, err := api.App.Get(ns, name, "")
+	if err != nil {
+		return nil, err
+	}
+	if common.ValidIsInvisible(app.Labels) {
+		return nil, common.Error(common.ErrResourceInvisible, common.Field("type", common.APP), common.Field("name", name))
+	}
+	return app, nil
+}
+
+func parseAppRevision(val string) (int64, error) {
+	revision, err := strconv.ParseInt(val, 10, 64)
+	if err != nil || revision <= 0 {
+		return 0, common.Error(common.ErrRequestParamInvalid, common.Field("error", "revision should be a positive integer"))
+	}
+	return revision, nil
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mf "github.com/baetyl/baetyl-cloud/v2/mock/facade"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initAppHistoryAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { c.Set(common.KeyContextNamespace, "baetyl-cloud") }
+	v1 := router.Group("v1")
+	{
+		apps := v1.Group("/apps")
+		apps.GET("/:name/revisions", mockIM, common.Wrapper(api.ListAppRevision))
+		apps.GET("/:name/revisions/:revision", mockIM, common.Wrapper(api.GetAppRevision))
+		apps.POST("/:name/rollback", mockIM, common.Wrapper(api.RollbackApplication))
+	}
+	return api, router, mockCtl
+}
+
+func TestListAndGetAppRevision(t *testing.T) {
+	api, router, mockCtl := initAppHistoryAPI(t)
+	defer mockCtl.Finish()
+	sApp, sHistory := ms.NewMockApplicationService(mockCtl), ms.NewMockAppHistoryService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+	api.AppHistory = sHistory
+
+	mApp := getMockContainerApp()
+	revisions := []models.AppRevision{
+		{Namespace: "baetyl-cloud", Name: "abc", Revision: 2, Version: "2"},
+		{Namespace: "baetyl-cloud", Name: "abc", Revision: 1, Version: "1"},
+	}
+	sApp.EXPECT().Get("baetyl-cloud", "abc", "").Return(mApp, nil).Times(2)
+	sHistory.EXPECT().List("baetyl-cloud", "abc").Return(revisions, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/apps/abc/revisions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\"total\":2")
+
+	rev := &models.AppRevision{Namespace: "baetyl-cloud", Name: "abc", Revision: 1, Version: "1", Application: mApp}
+	sHistory.EXPECT().Get("baetyl-cloud", "abc", int64(1)).Return(rev, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/apps/abc/revisions/1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.AppRevision)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, "abc", res.Application.Name)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/apps/abc/revisions/x", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the revisions of system app are invisible
+	sysApp := getMockContainerApp()
+	sysApp.Name = "core"
+	sysApp.Labels = map[string]string{common.ResourceInvisible: "true"}
+	sApp.EXPECT().Get("baetyl-cloud", "core", "").Return(sysApp, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/apps/core/revisions", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRollbackApplication(t *testing.T) {
+	api, router, mockCtl := initAppHistoryAPI(t)
+	defer mockCtl.Finish()
+	sApp, sConfig, sSecret := ms.NewMockApplicationService(mockCtl), ms.NewMockConfigService(mockCtl), ms.NewMockSecretService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{App: sApp, Config: sConfig, Secret: sSecret}
+	sHistory, fApp := ms.NewMockAppHistoryService(mockCtl), mf.NewMockFacade(mockCtl)
+	api.AppHistory, api.Facade = sHistory, fApp
+	api.Index, api.Node = ms.NewMockIndexService(mockCtl), ms.NewMockNodeService(mockCtl)
+
+	config := &specV1.Configuration{Name: "agent-conf", Version: "123"}
+	secret := &specV1.Secret{Name: "secret01", Version: "123"}
+	sConfig.EXPECT().Get(gomock.Any(), gomock.Any(), "").Return(config, nil).AnyTimes()
+	sSecret.EXPECT().Get(gomock.Any(), secret.Name, gomock.Any()).Return(secret, nil).AnyTimes()
+
+	current := getMockContainerApp()
+	current.Version = "3"
+	current.Services[0].Image = "hub.baidubce.com/baetyl/baetyl-agent:1.0.1"
+	old := getMockContainerApp()
+	old.Version = "1"
+	sApp.EXPECT().Get("baetyl-cloud", "abc", "").Return(current, nil).AnyTimes()
+	sHistory.EXPECT().Get("baetyl-cloud", "abc", int64(1)).Return(&models.AppRevision{Revision: 1, Application: old}, nil)
+	fApp.EXPECT().UpdateApp("baetyl-cloud", current, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ string, _ *specV1.Application, app *specV1.Application, _ []specV1.Configuration) (*specV1.Application, error) {
+			assert.Equal(t, "hub.baidubce.com/baetyl/baetyl-agent:1.0.0", app.Services[0].Image)
+			assert.Equal(t, "3", app.Version)
+			return app, nil
+		})
+	sHistory.EXPECT().Record(gomock.Any()).Return(&models.AppRevision{Revision: 4}, nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/apps/abc/rollback?revision=1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the failure of recording revision does not fail the rollback
+	sHistory.EXPECT().Get("baetyl-cloud", "abc", int64(1)).Return(&models.AppRevision{Revision: 1, Application: old}, nil)
+	fApp.EXPECT().UpdateApp("baetyl-cloud", current, gomock.Any(), gomock.Any()).Return(current, nil)
+	sHistory.EXPECT().Record(current).Return(nil, fmt.Errorf("error"))
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/abc/rollback?revision=1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sHistory.EXPECT().Get("baetyl-cloud", "abc", int64(9)).Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/abc/rollback?revision=9", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/abc/rollback", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
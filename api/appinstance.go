@@ -0,0 +1,83 @@
+package api
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// ListAppInstance lists the instances of application on its nodes, with the restart counts
+// and the last terminations of their containers reported by nodes
+func (api *API) ListAppInstance(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	app, err := api.getVisibleApp(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	items, err := api.listAppInstances(app)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(items), items, ""), nil
+}
+
+// RestartAppInstance asks the node running the instance of application to restart it, the restart is
+// delivered to node by sync and kept until the node reports it done
+func (api *API) RestartAppInstance(c *common.Context) (interface{}, error) {
+	ns, name, instance := c.GetNamespace(), c.GetNameFromParam(), c.Param("instance")
+	app, err := api.getVisibleApp(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	items, err := api.listAppInstances(app)
+	if err != nil {
+		return nil, err
+	}
+	node := ""
+	for _, item := range items {
+		if item.Name == instance {
+			node = item.Node
+			break
+		}
+	}
+	if node == "" {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "instance"),
+			common.Field("name", instance), common.Field("namespace", ns))
+	}
+	restart := models.AppInstanceRestart{App: name, Instance: instance, RequestTime: time.Now().UTC()}
+	if err = api.Node.UpdateDesire(nil, ns, []string{node}, app, service.RestartAppInstance(restart)); err != nil {
+		return nil, err
+	}
+	log.L().Info("app instance is restarted", log.Any(common.KeyContextNamespace, ns), log.Any("app", name),
+		log.Any("node", node), log.Any("instance", instance))
+	restart.Node = node
+	return restart, nil
+}
+
+// listAppInstances collects the instances of application from the reports of the nodes it selects,
+// the node whose report is broken is skipped
+func (api *API) listAppInstances(app *specV1.Application) ([]models.AppInstance, error) {
+	items := []models.AppInstance{}
+	if app.Selector == "" {
+		return items, nil
+	}
+	nodes, err := api.Node.List(app.Namespace, &models.ListOptions{LabelSelector: app.Selector})
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodes.Items {
+		instances, err := service.AppInstances(&nodes.Items[i], app)
+		if err != nil {
+			log.L().Warn("failed to decode app stats of node", log.Any(common.KeyContextNamespace, app.Namespace),
+				log.Any("node", nodes.Items[i].Name), log.Error(err))
+			continue
+		}
+		items = append(items, instances...)
+	}
+	return items, nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initAppInstanceAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { c.Set(common.KeyContextNamespace, "baetyl-cloud") }
+	v1 := router.Group("v1")
+	{
+		apps := v1.Group("/apps")
+		apps.GET("/:name/instances", mockIM, common.Wrapper(api.ListAppInstance))
+		apps.POST("/:name/instances/:instance/restart", mockIM, common.Wrapper(api.RestartAppInstance))
+	}
+	return api, router, mockCtl
+}
+
+func TestAppInstance(t *testing.T) {
+	api, router, mockCtl := initAppInstanceAPI(t)
+	defer mockCtl.Finish()
+	sApp, sNode := ms.NewMockApplicationService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+	api.Node = sNode
+
+	app := &specV1.Application{Namespace: "baetyl-cloud", Name: "web", Selector: "app=web"}
+	nodes := &models.NodeList{Items: []specV1.Node{
+		{Name: "node01", Report: specV1.Report{"appstats": []interface{}{map[string]interface{}{
+			"name": "web",
+			"instances": map[string]interface{}{"web-0": map[string]interface{}{"status": "Running",
+				"containers": []interface{}{map[string]interface{}{"name": "nginx", "restartCount": 2}}}},
+		}}}},
+		{Name: "node02", Report: specV1.Report{"appstats": "broken"}},
+		{Name: "node03", Report: specV1.Report{"appstats": []interface{}{map[string]interface{}{
+			"name":      "web",
+			"instances": map[string]interface{}{"web-1": map[string]interface{}{"status": "Failed"}},
+		}}}},
+	}}
+	sApp.EXPECT().Get("baetyl-cloud", "web", "").Return(app, nil).AnyTimes()
+	sNode.EXPECT().List("baetyl-cloud", &models.ListOptions{LabelSelector: "app=web"}).Return(nodes, nil).AnyTimes()
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/apps/web/instances", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res struct {
+		Total int                  `json:"total"`
+		Items []models.AppInstance `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 2, res.Total)
+	assert.Equal(t, "node01", res.Items[0].Node)
+	assert.Equal(t, int32(2), res.Items[0].RestartCount)
+	assert.Equal(t, "node03", res.Items[1].Node)
+
+	sNode.EXPECT().UpdateDesire(nil, "baetyl-cloud", []string{"node03"}, app, gomock.Any()).DoAndReturn(
+		func(_ interface{}, _ string, _ []string, app *specV1.Application, f func(*models.Shadow, *specV1.Application)) error {
+			shadow := &models.Shadow{}
+			f(shadow, app)
+			assert.Contains(t, shadow.Desire, common.DesiredRestarts)
+			return nil
+		})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/web/instances/web-1/restart", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	restart := new(models.AppInstanceRestart)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), restart))
+	assert.Equal(t, "node03", restart.Node)
+	assert.Equal(t, "web-1", restart.Instance)
+
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/web/instances/web-9/restart", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
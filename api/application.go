@@ -16,6 +16,7 @@ import (
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
 	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
 )
 
 const (
@@ -26,6 +27,8 @@ const (
 	FunctionProgramConfigPrefix = "baetyl-function-program-config"
 	FunctionCodePrefix          = "baetyl-function-code"
 	FunctionDefaultConfigFile   = "conf.yml"
+	CronJobConfigPrefix         = "baetyl-cronjob-config"
+	CronJobConfigFile           = "cronjob.yml"
 
 	HookCreateApplicationOta = "hookCreateApplicationOta"
 	HookUpdateApplicationOta = "hookUpdateApplicationOta"
@@ -63,7 +66,7 @@ func (api *API) ListApplication(c *common.Context) (interface{}, error) {
 		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
 	}
 	api.ToApplicationListView(apps)
-	return apps, err
+	return api.ToListResponse(apps.Total, apps.Items, apps.ListOptions), nil
 }
 
 // CreateApplication create one application
@@ -72,13 +75,33 @@ func (api *API) CreateApplication(c *common.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	ns, name := c.GetNamespace(), appView.Name
+	return api.createApplicationView(c, c.GetNamespace(), appView)
+}
+
+func (api *API) createApplicationView(c *common.Context, ns string, appView *models.ApplicationView) (*models.ApplicationView, error) {
+	name := appView.Name
 	appView.Namespace = ns
 
+	err := api.applyNodeGroup(appView)
+	if err != nil {
+		return nil, err
+	}
+	if err = api.applyEdgeCluster(appView); err != nil {
+		return nil, err
+	}
+	if err = api.applyAppDependencies(appView); err != nil {
+		return nil, err
+	}
+	if err = api.applyAppEnvGroups(appView); err != nil {
+		return nil, err
+	}
 	err = api.validApplication(ns, appView)
 	if err != nil {
 		return nil, err
 	}
+	if err = api.checkAppRollout(appView); err != nil {
+		return nil, err
+	}
 
 	// TODO: remove get method, return error inside service instead
 	oldApp, err := api.App.Get(ns, name, "")
@@ -104,6 +127,13 @@ func (api *API) CreateApplication(c *common.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = api.checkAppQuarantine(ns, app); err != nil {
+		return nil, err
+	}
+	app.Labels = service.SetAppPaused(app.Labels, false)
+	if c.IsDryRun() {
+		return api.dryRunApplicationView(appView, app)
+	}
 
 	if f, exist := api.Hooks[HookCreateApplicationOta]; exist {
 		if hk, ok := f.(CreateApplicationOta); ok {
@@ -114,13 +144,22 @@ func (api *API) CreateApplication(c *common.Context) (interface{}, error) {
 		}
 	}
 
+	rollout, err := api.startAppRollout(app, appView.Rollout)
+	if err != nil {
+		return nil, err
+	}
 	log.L().Info("", log.Any("app2", app))
 	app, err = api.Facade.CreateApp(ns, baseApp, app, configs)
 	if err != nil {
+		if rollout != nil {
+			api.deleteAppRollout(ns, name)
+		}
 		return nil, err
 	}
+	api.recordAppRevision(app)
+	api.finishAppRollout(app, rollout)
 
-	return api.ToApplicationView(app)
+	return api.toApplicationViewWithWarnings(app, appView.Warnings)
 }
 
 // UpdateApplication update the application
@@ -129,33 +168,99 @@ func (api *API) UpdateApplication(c *common.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	return api.updateApplicationView(c, appView)
+}
 
+func (api *API) updateApplicationView(c *common.Context, appView *models.ApplicationView) (interface{}, error) {
 	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	oldApp, app, configs, err := api.prepareAppUpdate(ns, name, appView)
+	if err != nil {
+		return nil, err
+	}
+	if c.IsDryRun() {
+		return api.dryRunApplicationView(appView, app)
+	}
 
-	err = api.validApplication(ns, appView)
+	if f, exist := api.Hooks[HookUpdateApplicationOta]; exist {
+		if hk, ok := f.(UpdateApplicationOta); ok {
+			app, err = hk(c, app)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	rollout, err := api.startAppRollout(app, appView.Rollout)
 	if err != nil {
 		return nil, err
 	}
+	app, err = api.Facade.UpdateApp(ns, oldApp, app, configs)
+	if err != nil {
+		if rollout != nil {
+			api.deleteAppRollout(ns, name)
+		}
+		return nil, errors.Trace(err)
+	}
+	c.SetAuditDiff(oldApp, app)
+	api.recordAppRevision(app)
+	api.finishAppRollout(app, rollout)
 
-	oldApp, err := api.App.Get(ns, name, "")
+	return api.toApplicationViewWithWarnings(app, appView.Warnings)
+}
+
+// toApplicationViewWithWarnings renders the application saved, with the warnings found when it is checked
+func (api *API) toApplicationViewWithWarnings(app *specV1.Application, warnings []string) (*models.ApplicationView, error) {
+	res, err := api.ToApplicationView(app)
 	if err != nil {
 		return nil, err
 	}
+	res.Warnings = warnings
+	return res, nil
+}
+
+// prepareAppUpdate checks the application view to update and translates it to the application and
+// the generated configs, nothing is saved
+func (api *API) prepareAppUpdate(ns, name string, appView *models.ApplicationView) (*specV1.Application, *specV1.Application, []specV1.Configuration, error) {
+	err := api.applyNodeGroup(appView)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err = api.applyEdgeCluster(appView); err != nil {
+		return nil, nil, nil, err
+	}
+	if err = api.applyAppDependencies(appView); err != nil {
+		return nil, nil, nil, err
+	}
+	if err = api.applyAppEnvGroups(appView); err != nil {
+		return nil, nil, nil, err
+	}
+	err = api.validApplication(ns, appView)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err = api.checkAppRollout(appView); err != nil {
+		return nil, nil, nil, err
+	}
+
+	oldApp, err := api.App.Get(ns, name, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	// sys app: core、init、function is not visible
 	if common.ValidIsInvisible(oldApp.Labels) {
-		return nil, common.Error(common.ErrResourceInvisible, common.Field("type", common.APP), common.Field("name", oldApp.Name))
+		return nil, nil, nil, common.Error(common.ErrResourceInvisible, common.Field("type", common.APP), common.Field("name", oldApp.Name))
 	}
 
 	// labels and Selector can't be modified of sys apps
 	if CheckIsSysResources(oldApp.Labels) &&
 		(oldApp.Selector != appView.Selector || !reflect.DeepEqual(oldApp.Labels, appView.Labels) || !appView.System) {
-		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "selector，labels or system field can't be modified of sys apps"))
+		return nil, nil, nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "selector，labels or system field can't be modified of sys apps"))
 	}
 
 	if appView.CronStatus != oldApp.CronStatus {
 		if oldApp.CronStatus != specV1.CronWait || appView.CronStatus != specV1.CronNotSet {
-			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "failed to add cron, can't set up a cron job which has been deployed"))
+			return nil, nil, nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "failed to add cron, can't set up a cron job which has been deployed"))
 		}
 	} else {
 		appView.CronTime = oldApp.CronTime
@@ -165,27 +270,17 @@ func (api *API) UpdateApplication(c *common.Context) (interface{}, error) {
 	appView.CreationTimestamp = oldApp.CreationTimestamp
 	app, configs, err := api.ToApplication(appView, oldApp)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
+	}
+	if err = api.checkAppQuarantine(ns, app); err != nil {
+		return nil, nil, nil, err
 	}
 
 	// ota can not modify
 	app.Ota = oldApp.Ota
-
-	if f, exist := api.Hooks[HookUpdateApplicationOta]; exist {
-		if hk, ok := f.(UpdateApplicationOta); ok {
-			app, err = hk(c, app)
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	app, err = api.Facade.UpdateApp(ns, oldApp, app, configs)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	return api.ToApplicationView(app)
+	// the reconciliation is paused or resumed by its own api
+	app.Labels = service.SetAppPaused(app.Labels, service.IsAppPaused(oldApp.Labels))
+	return oldApp, app, configs, nil
 }
 
 // DeleteApplication delete the application
@@ -204,6 +299,9 @@ func (api *API) DeleteApplication(c *common.Context) (interface{}, error) {
 	} else if !canDelete {
 		return nil, common.Error(common.ErrAppReferencedByNode, common.Field("name", name))
 	}
+	if err = api.checkAppDependents(ns, name); err != nil {
+		return nil, err
+	}
 
 	if f, exist := api.Hooks[HookDeleteApplicationOta]; exist {
 		if hk, ok := f.(DeleteApplicationOta); ok {
@@ -214,8 +312,16 @@ func (api *API) DeleteApplication(c *common.Context) (interface{}, error) {
 		}
 	}
 
-	err = api.Facade.DeleteApp(ns, name, app)
-	return nil, err
+	if err = api.Facade.DeleteApp(ns, name, app); err != nil {
+		return nil, err
+	}
+	if api.AppHistory != nil {
+		if err = api.AppHistory.Delete(ns, name); err != nil {
+			common.LogDirtyData(err, log.Any("type", "revision"), log.Any(common.KeyContextNamespace, ns), log.Any("app", name))
+		}
+	}
+	api.deleteAppRollout(ns, name)
+	return nil, nil
 }
 
 func (api *API) GetSysAppConfigs(c *common.Context) (interface{}, error) {
@@ -279,30 +385,37 @@ func (api *API) ParseApplication(c *common.Context) (*models.ApplicationView, er
 	if app.Name == "" {
 		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
 	}
+	if err = api.checkApplicationView(app); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
 
+// checkApplicationView checks the services and workload of application view by its type and mode
+func (api *API) checkApplicationView(app *models.ApplicationView) error {
 	if app.Type == common.ContainerApp {
 		for _, v := range app.Services {
 			if v.FunctionConfig != nil || v.Functions != nil {
-				return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "add function info in container app"))
+				return common.Error(common.ErrRequestParamInvalid, common.Field("error", "add function info in container app"))
 			}
 			if app.Mode == context.RunModeKube && v.Image == "" {
-				return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "image is required in kube mode"))
+				return common.Error(common.ErrRequestParamInvalid, common.Field("error", "image is required in kube mode"))
 			}
 			if app.Mode == context.RunModeNative && v.ProgramConfig == "" {
-				return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "program config is required in native mode"))
+				return common.Error(common.ErrRequestParamInvalid, common.Field("error", "program config is required in native mode"))
 			}
 		}
 	} else if app.Type == common.FunctionApp {
 		for _, v := range app.Services {
 			if v.FunctionConfig == nil {
-				return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "function config can't be empty in function app"))
+				return common.Error(common.ErrRequestParamInvalid, common.Field("error", "function config can't be empty in function app"))
 			}
 		}
 		if len(app.Registries) != 0 {
-			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "registries should be empty in function app"))
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "registries should be empty in function app"))
 		}
 	} else {
-		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "type is invalid"))
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "type is invalid"))
 	}
 
 	// multi-container compatibility
@@ -311,11 +424,15 @@ func (api *API) ParseApplication(c *common.Context) (*models.ApplicationView, er
 	if app.Workload != specV1.WorkloadDeployment &&
 		app.Workload != specV1.WorkloadDaemonSet &&
 		app.Workload != specV1.WorkloadStatefulSet &&
-		app.Workload != specV1.WorkloadJob {
-		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error",
-			"failed to parse service type, service type should be deployment / daemonset / statefulset / job"))
+		app.Workload != specV1.WorkloadJob &&
+		app.Workload != common.WorkloadCronJob {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error",
+			"failed to parse service type, service type should be deployment / daemonset / statefulset / job / cronjob"))
 	}
-	return app, nil
+	if err := checkFunctionScale(app); err != nil {
+		return err
+	}
+	return checkAppCronJob(app)
 }
 
 func (api *API) getBaseAppIfSet(c *common.Context) (*specV1.Application, error) {
@@ -335,9 +452,27 @@ func (api *API) ParseListOptions(c *common.Context) (*models.ListOptions, error)
 	if err := c.Bind(params); err != nil {
 		return nil, err
 	}
+	pagination, err := c.LoadPagination()
+	if err != nil {
+		return nil, err
+	}
+	params.SetPagination(pagination)
+	params.SetContext(c.RequestContext())
 	return params, nil
 }
 
+// ToListResponse wraps the items of list into the common list envelope along with pageNo and pageSize
+func (api *API) ToListResponse(total int, items interface{}, params *models.ListOptions) *models.ListOptionsResponse {
+	res := &models.ListOptionsResponse{
+		ListResponse: common.NewListResponse(total, items, params.GetNextToken(total)),
+	}
+	if params != nil && params.PageSize > 0 {
+		res.PageNo = params.GetLimitOffset()/params.PageSize + 1
+		res.PageSize = params.PageSize
+	}
+	return res
+}
+
 func (api *API) ParseListOptionsAppendSystemLabel(c *common.Context) (*models.ListOptions, error) {
 	opt, err := api.ParseListOptions(c)
 	if err != nil {
@@ -377,9 +512,17 @@ func (api *API) ToApplicationView(app *specV1.Application) (*models.ApplicationV
 	if err != nil {
 		return nil, err
 	}
+	if err = api.translateToCronJobView(appView); err != nil {
+		return nil, err
+	}
 
 	api.compatibleAppDeprecatedFiled(appView)
 	populateAppDefaultField(appView)
+	appView.NodeGroup = appView.Labels[common.LabelNodeGroup]
+	appView.EdgeCluster = appView.Labels[common.LabelEdgeCluster]
+	appView.DependsOn = service.AppDependencies(appView.Labels)
+	appView.EnvGroups = service.AppEnvGroups(appView.Labels)
+	appView.Paused = service.IsAppPaused(appView.Labels)
 
 	if app.Type != common.FunctionApp {
 		delete(appView.Labels, common.LabelAppMode)
@@ -411,6 +554,9 @@ func (api *API) ToApplicationView(app *specV1.Application) (*models.ApplicationV
 				return nil, err
 			}
 			service.Functions = serviceFunctions.Functions
+			if serviceFunctions.Scale != nil {
+				appView.FunctionScale = serviceFunctions.Scale
+			}
 		}
 
 		if app.Mode == context.RunModeNative {
@@ -442,9 +588,24 @@ func (api *API) ToApplicationView(app *specV1.Application) (*models.ApplicationV
 		}
 	}
 	delete(appView.Labels, common.LabelAppMode)
+	clearFunctionEnvSecrets(appView.Labels)
 	return appView, nil
 }
 
+// dryRunApplicationView renders the application which is not stored,
+// the services of function app are taken from the request since the generated configs do not exist
+func (api *API) dryRunApplicationView(appView *models.ApplicationView, app *specV1.Application) (*models.ApplicationView, error) {
+	if app.Type == common.FunctionApp {
+		return appView, nil
+	}
+	res, err := api.ToApplicationView(app)
+	if err != nil {
+		return nil, err
+	}
+	res.CronJob, res.Warnings = appView.CronJob, appView.Warnings
+	return res, nil
+}
+
 func (api *API) ToApplication(appView *models.ApplicationView, oldApp *specV1.Application) (*specV1.Application, []specV1.Configuration, error) {
 	app := new(specV1.Application)
 	copier.Copy(app, appView)
@@ -452,8 +613,19 @@ func (api *API) ToApplication(appView *models.ApplicationView, oldApp *specV1.Ap
 	translateSecretLikedModelsToSecrets(appView, app)
 	translateNativeApp(appView, app, oldApp)
 
+	var configs []specV1.Configuration
+	cronConfig, err := translateCronJob(appView, app, oldApp)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cronConfig != nil {
+		configs = append(configs, *cronConfig)
+	}
 	if app.Type != common.FunctionApp {
-		return app, nil, nil
+		return app, configs, nil
+	}
+	if err = translateFunctionScale(appView, app); err != nil {
+		return nil, nil, err
 	}
 	oldServices := map[string]bool{}
 	if oldApp != nil {
@@ -465,11 +637,12 @@ func (api *API) ToApplication(appView *models.ApplicationView, oldApp *specV1.Ap
 	for _, vol := range app.Volumes {
 		volMap[vol.Name] = true
 	}
+	clearFunctionEnvSecrets(app.Labels)
 
-	var configs []specV1.Configuration
+	gpu := false
 	for index := range app.Services {
 		service := &app.Services[index]
-		config, err := generateConfigOfFunctionService(service, app)
+		config, err := generateConfigOfFunctionService(service, app, appView.FunctionScale)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -520,6 +693,26 @@ func (api *API) ToApplication(appView *models.ApplicationView, oldApp *specV1.Ap
 				Protocol:      "TCP",
 			},
 		}
+		versions, err := api.functionVersionsOfService(app, service)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = renderFunctionEnvs(app, service, versions); err != nil {
+			return nil, nil, err
+		}
+		if err = renderFunctionWasm(service, versions); err != nil {
+			return nil, nil, err
+		}
+		flavored, err := api.renderFunctionFlavor(app, service, versions)
+		if err != nil {
+			return nil, nil, err
+		}
+		gpu = gpu || flavored
+	}
+	if gpu {
+		if err = api.checkNodeAccelerators(app.Namespace, app.Selector, appAcceleratorRequests(app)); err != nil {
+			return nil, nil, err
+		}
 	}
 	return app, configs, nil
 }
@@ -639,6 +832,7 @@ func (api *API) translateSecretsToSecretLikedResources(appView *models.Applicati
 				registry := models.FromSecretToRegistry(secret, false)
 				appView.Registries = append(appView.Registries, models.RegistryView{
 					Name:     registry.Name,
+					Type:     registry.Type,
 					Address:  registry.Address,
 					Username: registry.Username,
 				})
@@ -689,11 +883,15 @@ func (api *API) validApplication(namespace string, app *models.ApplicationView)
 		}
 	}
 
+	// the artifact registries are not used to pull images, their credentials are not delivered with apps
 	for _, r := range app.Registries {
-		_, err := api.Secret.Get(namespace, r.Name, "")
+		secret, err := api.Secret.Get(namespace, r.Name, "")
 		if err != nil {
 			return err
 		}
+		if registry := models.FromSecretToRegistry(secret, true); registry != nil && registry.Type != models.RegistryTypeContainer {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the registry "+r.Name+" of type "+registry.Type+" can not be used to pull images"))
+		}
 	}
 
 	ports := make(map[int32]bool)
@@ -745,6 +943,13 @@ func (api *API) validApplication(namespace string, app *models.ApplicationView)
 		}
 	}
 
+	if err := api.validAcceleratorRequests(namespace, app); err != nil {
+		return err
+	}
+	if err := api.validCapacityRequests(namespace, app); err != nil {
+		return err
+	}
+
 	app.Labels = common.AddSystemLabel(app.Labels, map[string]string{
 		common.LabelAppMode: app.Mode,
 	})
@@ -922,9 +1127,10 @@ func getGenProgramNameOfFunctionService(app *specV1.Application, serviceName str
 	return strings.ToLower(fmt.Sprintf("%s-%s-%s-%s", FunctionProgramConfigPrefix, app.Name, serviceName, common.RandString(9))), nil
 }
 
-func generateConfigOfFunctionService(service *specV1.Service, app *specV1.Application) (*specV1.Configuration, error) {
+func generateConfigOfFunctionService(service *specV1.Service, app *specV1.Application, scale *models.FunctionScale) (*specV1.Configuration, error) {
 	serviceFunctions := models.ServiceFunction{
 		Functions: service.Functions,
+		Scale:     scale,
 	}
 
 	data, err := json.Marshal(serviceFunctions)
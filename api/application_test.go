@@ -403,6 +403,72 @@ func TestListApplication(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestListApplicationContinue(t *testing.T) {
+	api, router, mockCtl := initApplicationAPI(t)
+	defer mockCtl.Finish()
+
+	sApp := ms.NewMockApplicationService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	// the first page is limited by storage, which issues the continue token of next page
+	sApp.EXPECT().List("baetyl-cloud", gomock.Any()).DoAndReturn(func(_ string, params *models.ListOptions) (*models.ApplicationList, error) {
+		assert.Equal(t, int64(2), params.Limit)
+		assert.Empty(t, params.Continue)
+		params.Continue = "kube-continue"
+		return &models.ApplicationList{Total: 2, ListOptions: params, Items: []models.AppItem{{Name: "app01"}, {Name: "app02"}}}, nil
+	})
+	req, _ := http.NewRequest(http.MethodGet, "/v1/apps?limit=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res common.ListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "kube-continue", res.NextToken)
+
+	sApp.EXPECT().List("baetyl-cloud", gomock.Any()).DoAndReturn(func(_ string, params *models.ListOptions) (*models.ApplicationList, error) {
+		assert.Equal(t, int64(2), params.Limit)
+		assert.Equal(t, "kube-continue", params.Continue)
+		params.Continue = ""
+		return &models.ApplicationList{Total: 1, ListOptions: params, Items: []models.AppItem{{Name: "app03"}}}, nil
+	})
+	req, _ = http.NewRequest(http.MethodGet, "/v1/apps?limit=2&continue=kube-continue", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "nextToken")
+
+	// the pages after the first one are paged in memory by offset
+	sApp.EXPECT().List("baetyl-cloud", gomock.Any()).DoAndReturn(func(_ string, params *models.ListOptions) (*models.ApplicationList, error) {
+		assert.Equal(t, int64(0), params.Limit)
+		assert.Equal(t, 2, params.GetLimitOffset())
+		assert.Equal(t, 2, params.GetLimitNumber())
+		return &models.ApplicationList{Total: 3, ListOptions: params, Items: []models.AppItem{{Name: "app03"}}}, nil
+	})
+	req, _ = http.NewRequest(http.MethodGet, "/v1/apps?pageNo=2&pageSize=2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"pageNo":2,"pageSize":2`)
+}
+
+func TestToListResponse(t *testing.T) {
+	api := &API{}
+	params := &models.ListOptions{LabelSelector: "a=b", Filter: models.Filter{PageNo: 1, PageSize: 1}}
+	data, err := json.Marshal(api.ToListResponse(3, []string{"app01"}, params))
+	assert.NoError(t, err)
+	// pageNo and pageSize are kept for the clients reading them before the envelope, the list options are not
+	assert.Equal(t, `{"total":3,"items":["app01"],"nextToken":"`+common.NextOffsetToken(0, 1, 3)+`","pageNo":1,"pageSize":1}`, string(data))
+
+	params = &models.ListOptions{NodeQuery: models.NodeQuery{Ready: models.NodeReadyOnline}}
+	data, err = json.Marshal(api.ToListResponse(0, []string{}, params))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"total":0,"items":[]}`, string(data))
+
+	data, err = json.Marshal(api.ToListResponse(0, []string{}, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"total":0,"items":[]}`, string(data))
+}
+
 func TestCreateContainerApplication(t *testing.T) {
 	api, router, mockCtl := initApplicationAPI(t)
 	defer mockCtl.Finish()
@@ -2337,6 +2403,7 @@ func TestDeleteApplication(t *testing.T) {
 
 	// 500
 	sApp.EXPECT().Get(gomock.Any(), "abc", gomock.Any()).Return(app, nil).Times(1)
+	sApp.EXPECT().List("baetyl-cloud", &models.ListOptions{LabelSelector: common.LabelPrefixDependsOn + "abc"}).Return(&models.ApplicationList{}, nil).Times(2)
 	fApp.EXPECT().DeleteApp(app.Namespace, app.Name, gomock.Any()).Return(fmt.Errorf("error")).Times(1)
 	req, _ := http.NewRequest(http.MethodDelete, "/v1/apps/abc", nil)
 	w := httptest.NewRecorder()
@@ -2351,6 +2418,15 @@ func TestDeleteApplication(t *testing.T) {
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
+	// 400 the app depended on by others
+	sApp.EXPECT().Get(gomock.Any(), "abc", gomock.Any()).Return(app, nil).Times(1)
+	sApp.EXPECT().List("baetyl-cloud", &models.ListOptions{LabelSelector: common.LabelPrefixDependsOn + "abc"}).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "web"}}}, nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/apps/abc", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), common.ErrResourceDeleteForbidden)
+
 	// 200 delete non-existent app
 	sApp.EXPECT().Get(gomock.Any(), "abc", gomock.Any()).Return(nil, common.Error(common.ErrResourceNotFound)).Times(1)
 	req, _ = http.NewRequest(http.MethodDelete, "/v1/apps/abc", nil)
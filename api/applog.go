@@ -0,0 +1,194 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+const (
+	defaultLogTail = 1000
+	// logReadTimeout the max time to wait for the node to send the logs which are not followed
+	logReadTimeout = 30 * time.Second
+)
+
+// GetAppInstanceLog reads the logs of container in the app instance from its node through the sync link.
+// The logs are returned as plain text, or streamed in stdout frames by websocket until the client disconnects in follow mode
+func (api *API) GetAppInstanceLog(c *common.Context) (interface{}, error) {
+	ns, name, instance := c.GetNamespace(), c.GetNameFromParam(), c.Param("instance")
+	err := api.Auth.Verify(c, &plugin.PermissionRequest{
+		Resource:   plugin.PermissionResourceApp,
+		Permission: []string{plugin.PermissionRead},
+		RequestContext: plugin.RequestContext{
+			IpAddress: c.ClientIP(),
+			Referer:   c.Request.Referer(),
+		},
+	})
+	if err != nil {
+		return nil, common.Error(common.ErrRequestAccessDenied, common.Field("error", err.Error()))
+	}
+	req, err := parseLogRequest(c)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.getVisibleApp(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	items, err := api.listAppInstances(app)
+	if err != nil {
+		return nil, err
+	}
+	var target *models.AppInstance
+	for i := range items {
+		if items[i].Name == instance {
+			target = &items[i]
+			break
+		}
+	}
+	if target == nil {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "instance"),
+			common.Field("name", instance), common.Field("namespace", ns))
+	}
+	container, err := logContainer(target, c.Query("container"))
+	if err != nil {
+		return nil, err
+	}
+
+	user := c.GetUser()
+	if user.ID == "" {
+		user.ID = user.Name
+	}
+	_, requestID := c.GetTrace()
+	req.Instance = instance
+	session := &models.ExecSession{
+		ID:        common.UUIDPrune(),
+		Namespace: ns,
+		Node:      target.Node,
+		User:      user.ID,
+		RequestId: requestID,
+		ExecRequest: models.ExecRequest{
+			App:       name,
+			Container: container,
+			Log:       req,
+		},
+		CreateTime: time.Now().UTC(),
+	}
+	output, err := api.Exec.Open(session)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Follow {
+		defer api.closeExecSession(session)
+		data, err := readExecLog(session, output)
+		if err != nil {
+			return nil, err
+		}
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", data)
+		return nil, nil
+	}
+
+	conn, err := execUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the response has been written by upgrader
+		log.L().Warn("failed to upgrade log request", log.Any(c.GetTrace()), log.Error(err))
+		api.closeExecSession(session)
+		return nil, nil
+	}
+	defer conn.Close()
+	log.L().Info("log session opened", log.Any("session", session.ID), log.Any("app", name), log.Any("instance", instance))
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		api.pumpExecOutput(conn, session, output, stop)
+	}()
+	// nothing is sent to node in follow mode, the messages of client are read until it disconnects
+	for {
+		if _, _, err = conn.NextReader(); err != nil {
+			break
+		}
+	}
+	close(stop)
+	<-done
+
+	api.closeExecSession(session)
+	return nil, nil
+}
+
+// readExecLog collects the logs sent by node until the exit frame
+func readExecLog(session *models.ExecSession, output <-chan models.ExecFrame) ([]byte, error) {
+	var buf bytes.Buffer
+	timer := time.NewTimer(logReadTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			return nil, common.Error(common.ErrRequestTimeout)
+		case frame, ok := <-output:
+			if !ok {
+				return buf.Bytes(), nil
+			}
+			switch frame.Type {
+			case models.ExecFrameStdout, models.ExecFrameStderr:
+				// the node reports the failure of reading logs in stderr, such as the container not started yet
+				buf.Write(frame.Data)
+			case models.ExecFrameExit:
+				code := frame.Code
+				session.ExitCode = &code
+				if code != 0 {
+					return nil, common.Error(common.ErrUnknown, common.Field("error", buf.String()))
+				}
+				return buf.Bytes(), nil
+			}
+		}
+	}
+}
+
+// logContainer returns the container to read logs from, which is required if the instance has more than one container
+func logContainer(instance *models.AppInstance, container string) (string, error) {
+	if container == "" {
+		if len(instance.Containers) > 1 {
+			return "", common.Error(common.ErrRequestParamInvalid,
+				common.Field("error", "container is required since the instance has more than one container"))
+		}
+		if len(instance.Containers) == 1 {
+			container = instance.Containers[0].Name
+		}
+		return container, nil
+	}
+	if len(instance.Containers) == 0 {
+		// the containers are not reported by node, it is checked by node
+		return container, nil
+	}
+	for _, c := range instance.Containers {
+		if c.Name == container {
+			return container, nil
+		}
+	}
+	return "", common.Error(common.ErrResourceNotFound, common.Field("type", "container"), common.Field("name", container))
+}
+
+func parseLogRequest(c *common.Context) (*models.LogRequest, error) {
+	req := &models.LogRequest{Tail: defaultLogTail}
+	if tail := c.Query("tail"); tail != "" {
+		var err error
+		if req.Tail, err = strconv.ParseInt(tail, 10, 64); err != nil || req.Tail < 0 {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "tail should be a non-negative integer"))
+		}
+	}
+	if follow := c.Query("follow"); follow != "" {
+		var err error
+		if req.Follow, err = strconv.ParseBool(follow); err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "follow should be a boolean"))
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initAppLogAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) {
+		common.NewContext(c).SetNamespace("default")
+		common.NewContext(c).SetUser(common.User{ID: "u1"})
+	}
+	router.GET("/v1/apps/:name/instances/:instance/log", mockIM, common.WrapperNative(api.GetAppInstanceLog, true))
+	return api, router, mockCtl
+}
+
+func TestGetAppInstanceLog(t *testing.T) {
+	api, router, mockCtl := initAppLogAPI(t)
+	defer mockCtl.Finish()
+
+	sAuth, sApp := ms.NewMockAuthService(mockCtl), ms.NewMockApplicationService(mockCtl)
+	sNode, sExec := ms.NewMockNodeService(mockCtl), ms.NewMockExecService(mockCtl)
+	api.Auth, api.Node, api.Exec = sAuth, sNode, sExec
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	app := &specV1.Application{Namespace: "default", Name: "web", Selector: "app=web"}
+	nodes := &models.NodeList{Items: []specV1.Node{{Name: "n1", Report: specV1.Report{"appstats": []interface{}{map[string]interface{}{
+		"name": "web",
+		"instances": map[string]interface{}{
+			"web-0": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "nginx"}}},
+			"web-1": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "nginx"}, map[string]interface{}{"name": "agent"}}},
+		},
+	}}}}}}
+	sAuth.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *common.Context, pr *plugin.PermissionRequest) error {
+		assert.Equal(t, plugin.PermissionResourceApp, pr.Resource)
+		assert.Equal(t, []string{plugin.PermissionRead}, pr.Permission)
+		return nil
+	}).AnyTimes()
+	sApp.EXPECT().Get("default", "web", "").Return(app, nil).AnyTimes()
+	sNode.EXPECT().List("default", &models.ListOptions{LabelSelector: "app=web"}).Return(nodes, nil).AnyTimes()
+
+	// the only container of instance is read by default
+	output := make(chan models.ExecFrame, 3)
+	output <- models.ExecFrame{Type: models.ExecFrameStdout, Data: []byte("line1\n")}
+	output <- models.ExecFrame{Type: models.ExecFrameStdout, Data: []byte("line2\n")}
+	output <- models.ExecFrame{Type: models.ExecFrameExit}
+	sExec.EXPECT().Open(gomock.Any()).DoAndReturn(func(s *models.ExecSession) (<-chan models.ExecFrame, error) {
+		assert.Equal(t, "n1", s.Node)
+		assert.Equal(t, "web", s.App)
+		assert.Equal(t, "nginx", s.Container)
+		assert.Equal(t, &models.LogRequest{Instance: "web-0", Tail: 10}, s.Log)
+		return output, nil
+	})
+	sExec.EXPECT().Close(gomock.Any()).Return(nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/apps/web/instances/web-0/log?tail=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "line1\nline2\n", w.Body.String())
+
+	// the failure reported by node
+	output = make(chan models.ExecFrame, 2)
+	output <- models.ExecFrame{Type: models.ExecFrameStderr, Data: []byte("container agent is not started")}
+	output <- models.ExecFrame{Type: models.ExecFrameExit, Code: 1}
+	sExec.EXPECT().Open(gomock.Any()).Return(output, nil)
+	sExec.EXPECT().Close(gomock.Any()).Return(nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/apps/web/instances/web-1/log?container=agent", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "container agent is not started")
+
+	for url, code := range map[string]int{
+		"/v1/apps/web/instances/web-1/log":                   http.StatusBadRequest,
+		"/v1/apps/web/instances/web-1/log?container=sidecar": http.StatusNotFound,
+		"/v1/apps/web/instances/web-9/log":                   http.StatusNotFound,
+		"/v1/apps/web/instances/web-0/log?tail=-1":           http.StatusBadRequest,
+		"/v1/apps/web/instances/web-0/log?follow=x":          http.StatusBadRequest,
+	} {
+		req, _ = http.NewRequest(http.MethodGet, url, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, code, w.Code, url)
+	}
+}
+
+func TestFollowAppInstanceLog(t *testing.T) {
+	api, router, mockCtl := initAppLogAPI(t)
+	defer mockCtl.Finish()
+
+	sAuth, sApp := ms.NewMockAuthService(mockCtl), ms.NewMockApplicationService(mockCtl)
+	sNode, sExec := ms.NewMockNodeService(mockCtl), ms.NewMockExecService(mockCtl)
+	api.Auth, api.Node, api.Exec = sAuth, sNode, sExec
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	url := "ws" + strings.TrimPrefix(svr.URL, "http") + "/v1/apps/web/instances/web-0/log?follow=true"
+
+	app := &specV1.Application{Namespace: "default", Name: "web", Selector: "app=web"}
+	nodes := &models.NodeList{Items: []specV1.Node{{Name: "n1", Report: specV1.Report{"appstats": []interface{}{map[string]interface{}{
+		"name":      "web",
+		"instances": map[string]interface{}{"web-0": map[string]interface{}{}},
+	}}}}}}
+	output, closed := make(chan models.ExecFrame, 1), make(chan *models.ExecSession, 1)
+	sAuth.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(nil)
+	sApp.EXPECT().Get("default", "web", "").Return(app, nil)
+	sNode.EXPECT().List("default", &models.ListOptions{LabelSelector: "app=web"}).Return(nodes, nil)
+	sExec.EXPECT().Open(gomock.Any()).DoAndReturn(func(s *models.ExecSession) (<-chan models.ExecFrame, error) {
+		assert.True(t, s.Log.Follow)
+		return output, nil
+	})
+	sExec.EXPECT().Close(gomock.Any()).DoAndReturn(func(s *models.ExecSession) error {
+		closed <- s
+		return nil
+	})
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err)
+	output <- models.ExecFrame{Type: models.ExecFrameStdout, Data: []byte("line1\n")}
+	var frame models.ExecFrame
+	assert.NoError(t, conn.ReadJSON(&frame))
+	assert.Equal(t, []byte("line1\n"), frame.Data)
+	conn.Close()
+
+	select {
+	case s := <-closed:
+		assert.Equal(t, "web-0", s.Log.Instance)
+	case <-time.After(5 * time.Second):
+		t.Fatal("session is not closed")
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// PauseApplication pauses the reconciliation of application, its nodes keep the versions they run and
+// the changes made on the edge are not reverted, the changes of application are withheld until it is resumed
+func (api *API) PauseApplication(c *common.Context) (interface{}, error) {
+	return api.setAppPaused(c, true)
+}
+
+// ResumeApplication resumes the reconciliation of application, its nodes are synced with the latest version again
+func (api *API) ResumeApplication(c *common.Context) (interface{}, error) {
+	return api.setAppPaused(c, false)
+}
+
+func (api *API) setAppPaused(c *common.Context, paused bool) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	app, err := api.getVisibleApp(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	if CheckIsSysResources(app.Labels) {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the reconciliation of system application can not be paused"))
+	}
+	// the apps quarantined stay paused until the quarantine is lifted
+	if !paused {
+		if err = api.checkAppQuarantine(ns, app); err != nil {
+			return nil, err
+		}
+	}
+	if app, err = api.updateAppPaused(ns, app, paused); err != nil {
+		return nil, err
+	}
+	return api.ToApplicationView(app)
+}
+
+func (api *API) updateAppPaused(ns string, app *specV1.Application, paused bool) (*specV1.Application, error) {
+	if service.IsAppPaused(app.Labels) == paused {
+		return app, nil
+	}
+	app.Labels = service.SetAppPaused(app.Labels, paused)
+	app, err := api.App.Update(nil, ns, app)
+	if err != nil {
+		return nil, err
+	}
+	// the nodes are synced with the new version once the application is resumed
+	if _, err = api.Node.UpdateNodeAppVersion(nil, ns, app); err != nil {
+		return nil, err
+	}
+	log.L().Info("app reconciliation is changed", log.Any(common.KeyContextNamespace, ns), log.Any("name", app.Name), log.Any("paused", paused))
+	return app, nil
+}
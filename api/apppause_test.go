@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initAppPauseAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { c.Set(common.KeyContextNamespace, "baetyl-cloud") }
+	v1 := router.Group("v1")
+	{
+		apps := v1.Group("/apps")
+		apps.POST("/:name/pause", mockIM, common.Wrapper(api.PauseApplication))
+		apps.POST("/:name/resume", mockIM, common.Wrapper(api.ResumeApplication))
+	}
+	return api, router, mockCtl
+}
+
+func TestPauseApplication(t *testing.T) {
+	api, router, mockCtl := initAppPauseAPI(t)
+	defer mockCtl.Finish()
+	sApp, sNode := ms.NewMockApplicationService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+	api.Node = sNode
+
+	app := &specV1.Application{
+		Namespace: "baetyl-cloud",
+		Name:      "web",
+		Type:      common.ContainerApp,
+		Version:   "1",
+		Labels:    map[string]string{"app": "web"},
+		Services:  []specV1.Service{{Name: "nginx", Image: "nginx"}},
+	}
+	sApp.EXPECT().Get("baetyl-cloud", "web", "").Return(app, nil)
+	sApp.EXPECT().Update(nil, "baetyl-cloud", gomock.Any()).DoAndReturn(func(_ interface{}, _ string, app *specV1.Application) (*specV1.Application, error) {
+		assert.Equal(t, "true", app.Labels[common.LabelAppPaused])
+		app.Version = "2"
+		return app, nil
+	})
+	sNode.EXPECT().UpdateNodeAppVersion(nil, "baetyl-cloud", gomock.Any()).Return(nil, nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/apps/web/pause", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.ApplicationView)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.True(t, res.Paused)
+
+	// pausing again saves nothing
+	sApp.EXPECT().Get("baetyl-cloud", "web", "").Return(app, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/web/pause", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sApp.EXPECT().Get("baetyl-cloud", "web", "").Return(app, nil)
+	sApp.EXPECT().Update(nil, "baetyl-cloud", gomock.Any()).DoAndReturn(func(_ interface{}, _ string, app *specV1.Application) (*specV1.Application, error) {
+		assert.NotContains(t, app.Labels, common.LabelAppPaused)
+		app.Version = "3"
+		return app, nil
+	})
+	sNode.EXPECT().UpdateNodeAppVersion(nil, "baetyl-cloud", gomock.Any()).Return(nil, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/web/resume", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res = new(models.ApplicationView)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.False(t, res.Paused)
+	assert.Equal(t, "3", res.Version)
+
+	// the system app is not paused
+	sys := &specV1.Application{Namespace: "baetyl-cloud", Name: "baetyl-core", Labels: map[string]string{common.LabelSystem: "true"}}
+	sApp.EXPECT().Get("baetyl-cloud", "baetyl-core", "").Return(sys, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/baetyl-core/pause", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
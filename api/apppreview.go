@@ -0,0 +1,125 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// PreviewApplication returns the changes of resources and nodes if the application is updated with the spec of request,
+// the application is checked as updating but nothing is saved
+func (api *API) PreviewApplication(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	appView, err := api.ParseApplication(c)
+	if err != nil {
+		return nil, err
+	}
+	oldApp, app, configs, err := api.prepareAppUpdate(ns, name, appView)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &models.AppPreview{Namespace: ns, Name: name, Resources: []models.ResourceDiff{}}
+	appDiff, err := diffAppResource(string(common.APP), name, oldApp, app)
+	if err != nil {
+		return nil, err
+	}
+	preview.Resources = append(preview.Resources, appDiff)
+	for i := range configs {
+		cfgDiff, err := api.diffAppConfig(ns, &configs[i])
+		if err != nil {
+			return nil, err
+		}
+		preview.Resources = append(preview.Resources, cfgDiff)
+	}
+	for _, r := range preview.Resources {
+		if r.Op != "" {
+			preview.Changed = true
+		}
+	}
+
+	if preview.Nodes, err = api.previewAppNodes(ns, oldApp, app, preview.Changed); err != nil {
+		return nil, err
+	}
+	if preview.Application, err = api.dryRunApplicationView(appView, app); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// diffAppResource compares the resource before and after, the op is empty if nothing is changed
+func diffAppResource(kind, name string, before, after interface{}) (models.ResourceDiff, error) {
+	res := models.ResourceDiff{Kind: kind, Name: name}
+	changes, err := common.DiffJSON(before, after)
+	if err != nil {
+		return res, errors.Trace(err)
+	}
+	if len(changes) > 0 {
+		res.Op, res.Changes = common.ChangeModified, changes
+	}
+	return res, nil
+}
+
+// diffAppConfig compares the config generated for function app with the stored one,
+// only the data is compared since the others are kept while updating
+func (api *API) diffAppConfig(ns string, cfg *specV1.Configuration) (models.ResourceDiff, error) {
+	old, err := api.Config.Get(ns, cfg.Name, "")
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return models.ResourceDiff{Kind: string(common.Configuration), Name: cfg.Name, Op: common.ChangeAdded}, nil
+		}
+		return models.ResourceDiff{}, err
+	}
+	return diffAppResource(string(common.Configuration), cfg.Name, old.Data, cfg.Data)
+}
+
+// previewAppNodes lists the nodes which the application is delivered to or removed from,
+// the nodes matched both before and after are updated only if the application is changed
+func (api *API) previewAppNodes(ns string, oldApp, app *specV1.Application, changed bool) (models.AppPreviewNodes, error) {
+	res := models.AppPreviewNodes{Added: []string{}, Removed: []string{}, Updated: []string{}}
+	before, err := api.listAppNodes(ns, oldApp)
+	if err != nil {
+		return res, err
+	}
+	after, err := api.listAppNodes(ns, app)
+	if err != nil {
+		return res, err
+	}
+	for node := range after {
+		if !before[node] {
+			res.Added = append(res.Added, node)
+		} else if changed {
+			res.Updated = append(res.Updated, node)
+		}
+	}
+	for node := range before {
+		if !after[node] {
+			res.Removed = append(res.Removed, node)
+		}
+	}
+	sort.Strings(res.Added)
+	sort.Strings(res.Removed)
+	sort.Strings(res.Updated)
+	return res, nil
+}
+
+// listAppNodes returns the nodes matched by the selector of application, the cordoned nodes are skipped for user apps
+func (api *API) listAppNodes(ns string, app *specV1.Application) (map[string]bool, error) {
+	list, err := api.Node.List(ns, &models.ListOptions{LabelSelector: app.Selector})
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]bool{}
+	for _, node := range list.Items {
+		if !app.System && service.IsNodeCordoned(node.Labels) {
+			continue
+		}
+		res[node.Name] = true
+	}
+	return res, nil
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initAppPreviewAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { c.Set(common.KeyContextNamespace, "baetyl-cloud") }
+	v1 := router.Group("v1")
+	{
+		apps := v1.Group("/apps")
+		apps.POST("/:name/preview", mockIM, common.Wrapper(api.PreviewApplication))
+	}
+	return api, router, mockCtl
+}
+
+func TestPreviewApplication(t *testing.T) {
+	api, router, mockCtl := initAppPreviewAPI(t)
+	defer mockCtl.Finish()
+	sApp, sConfig, sSecret, sNode := ms.NewMockApplicationService(mockCtl), ms.NewMockConfigService(mockCtl),
+		ms.NewMockSecretService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{App: sApp, Config: sConfig, Secret: sSecret}
+	api.Node = sNode
+
+	config := &specV1.Configuration{Name: "agent-conf", Version: "123"}
+	secret := &specV1.Secret{Name: "secret01", Version: "123"}
+	sConfig.EXPECT().Get(gomock.Any(), gomock.Any(), "").Return(config, nil).AnyTimes()
+	sSecret.EXPECT().Get(gomock.Any(), secret.Name, gomock.Any()).Return(secret, nil).AnyTimes()
+
+	current := getMockContainerApp()
+	current.Version = "3"
+	current.Mode = context.RunModeKube
+	current.Workload = specV1.WorkloadDeployment
+	current.Selector = "city=bj"
+	sApp.EXPECT().Get("baetyl-cloud", "abc", "").Return(current, nil).AnyTimes()
+
+	appView, err := api.ToApplicationView(getMockContainerApp())
+	assert.NoError(t, err)
+	appView.Mode, appView.Workload = current.Mode, current.Workload
+	appView.Selector = "city in (bj,sh)"
+	appView.Services[0].Image = "hub.baidubce.com/baetyl/baetyl-agent:1.0.1"
+
+	sNode.EXPECT().List("baetyl-cloud", &models.ListOptions{LabelSelector: "city=bj"}).Return(&models.NodeList{Items: []specV1.Node{
+		{Name: "node02"}, {Name: "node01"},
+	}}, nil).AnyTimes()
+	sNode.EXPECT().List("baetyl-cloud", &models.ListOptions{LabelSelector: "city in (bj,sh)"}).Return(&models.NodeList{Items: []specV1.Node{
+		{Name: "node01"}, {Name: "node02"}, {Name: "node03"},
+		{Name: "node04", Labels: map[string]string{common.LabelNodeCordon: "true"}},
+	}}, nil)
+	body, _ := json.Marshal(appView)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/apps/abc/preview", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.AppPreview)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.True(t, res.Changed)
+	assert.Len(t, res.Resources, 1)
+	assert.Equal(t, string(common.APP), res.Resources[0].Kind)
+	assert.Equal(t, common.ChangeModified, res.Resources[0].Op)
+	assert.Contains(t, res.Resources[0].Changes, common.JSONChange{
+		Path: "services[agent].image", Op: common.ChangeModified,
+		Old: "hub.baidubce.com/baetyl/baetyl-agent:1.0.0", New: "hub.baidubce.com/baetyl/baetyl-agent:1.0.1",
+	})
+	// the cordoned node is not affected
+	assert.Equal(t, models.AppPreviewNodes{Added: []string{"node03"}, Removed: []string{}, Updated: []string{"node01", "node02"}}, res.Nodes)
+	assert.Equal(t, "3", res.Application.Version)
+
+	// nothing is changed
+	appView.Selector = current.Selector
+	appView.Services[0].Image = current.Services[0].Image
+	body, _ = json.Marshal(appView)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/abc/preview", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res = new(models.AppPreview)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.False(t, res.Changed)
+	assert.Equal(t, models.AppPreviewNodes{Added: []string{}, Removed: []string{}, Updated: []string{}}, res.Nodes)
+
+	// the invalid spec is rejected
+	appView.Type = "other"
+	body, _ = json.Marshal(appView)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/abc/preview", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
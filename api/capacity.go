@@ -0,0 +1,109 @@
+package api
+
+import (
+	"fmt"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// capacityResources the resources of nodes which the requests of app are checked against
+var capacityResources = []string{"cpu", "memory"}
+
+// validCapacityRequests checks the resources requested by the application against the capacity reported by
+// every node selected. The app is rejected if more than the capacity is requested, and warned if more than
+// the resources not used yet is requested. The nodes which have not reported their stats are skipped
+func (api *API) validCapacityRequests(namespace string, app *models.ApplicationView) error {
+	app.Warnings = nil
+	requests := capacityRequests(app)
+	if len(requests) == 0 || app.Selector == "" || app.System {
+		return nil
+	}
+	nodes, err := api.Node.List(namespace, &models.ListOptions{LabelSelector: app.Selector})
+	if err != nil {
+		return err
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		capacity, usage := service.NodeCapacity(node)
+		for _, name := range capacityResources {
+			request, ok := requests[name]
+			if !ok {
+				continue
+			}
+			total, ok := capacity[name]
+			if !ok || total.IsZero() {
+				continue
+			}
+			if request.Cmp(total) > 0 {
+				return common.Error(common.ErrNodeCapacityShort,
+					common.Field("name", node.Name),
+					common.Field("resource", name),
+					common.Field("capacity", total.String()),
+					common.Field("request", request.String()))
+			}
+			available := total.DeepCopy()
+			available.Sub(usage[name])
+			if request.Cmp(available) > 0 {
+				app.Warnings = append(app.Warnings, fmt.Sprintf("the node %s has %s %s available, but %s is requested by the app",
+					node.Name, available.String(), name, request.String()))
+			}
+		}
+	}
+	return nil
+}
+
+// capacityRequests sums the resources requested by the services of all the replicas placed on one node,
+// the init services run before the others one by one so only the largest of them counts.
+// The limit is taken as the request of service if the request is not set, as kubernetes does
+func capacityRequests(app *models.ApplicationView) map[string]resource.Quantity {
+	res := map[string]resource.Quantity{}
+	for _, svc := range app.Services {
+		for name, q := range serviceRequests(svc) {
+			sum := res[name]
+			sum.Add(q)
+			res[name] = sum
+		}
+	}
+	for _, svc := range app.InitServices {
+		for name, q := range serviceRequests(svc) {
+			if largest, ok := res[name]; !ok || q.Cmp(largest) > 0 {
+				res[name] = q
+			}
+		}
+	}
+	replicas := int64(1)
+	if (app.Workload == specV1.WorkloadDeployment || app.Workload == specV1.WorkloadStatefulSet) && app.Replica > 1 {
+		replicas = int64(app.Replica)
+	}
+	for name, q := range res {
+		res[name] = *resource.NewMilliQuantity(q.MilliValue()*replicas, q.Format)
+	}
+	return res
+}
+
+func serviceRequests(svc models.ServiceView) map[string]resource.Quantity {
+	res := map[string]resource.Quantity{}
+	if svc.Resources == nil {
+		return res
+	}
+	for _, name := range capacityResources {
+		val, ok := svc.Resources.Requests[name]
+		if !ok {
+			val, ok = svc.Resources.Limits[name]
+		}
+		if !ok {
+			continue
+		}
+		q, err := resource.ParseQuantity(val)
+		if err != nil || q.IsZero() {
+			continue
+		}
+		res[name] = q
+	}
+	return res
+}
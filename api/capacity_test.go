@@ -0,0 +1,105 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func genCapacityNode(name, cpu, memory, memoryUsage string) specV1.Node {
+	return specV1.Node{
+		Namespace: "default",
+		Name:      name,
+		Report: specV1.Report{common.NodeStats: specV1.NodeStats{
+			Usage:    map[string]string{"cpu": "0", "memory": memoryUsage},
+			Capacity: map[string]string{"cpu": cpu, "memory": memory},
+		}},
+	}
+}
+
+func genCapacityService(name string, requests, limits map[string]string) models.ServiceView {
+	return models.ServiceView{Service: specV1.Service{Name: name, Resources: &specV1.Resources{Requests: requests, Limits: limits}}}
+}
+
+func TestCapacityRequests(t *testing.T) {
+	app := &models.ApplicationView{
+		Workload: specV1.WorkloadDeployment,
+		Replica:  2,
+		Services: []models.ServiceView{
+			genCapacityService("web", map[string]string{"cpu": "100m", "memory": "128Mi"}, nil),
+			genCapacityService("agent", nil, map[string]string{"cpu": "200m", "memory": "64Mi"}),
+			{Service: specV1.Service{Name: "none"}},
+		},
+		InitServices: []models.ServiceView{
+			genCapacityService("init", map[string]string{"cpu": "1", "memory": "32Mi"}, nil),
+		},
+	}
+	res := capacityRequests(app)
+	cpu, memory := res["cpu"], res["memory"]
+	assert.Equal(t, "2", cpu.String())
+	assert.Equal(t, "384Mi", memory.String())
+
+	app.Workload = specV1.WorkloadDaemonSet
+	res = capacityRequests(app)
+	cpu, memory = res["cpu"], res["memory"]
+	assert.Equal(t, "1", cpu.String())
+	assert.Equal(t, "192Mi", memory.String())
+
+	app.Services, app.InitServices = []models.ServiceView{{Service: specV1.Service{Name: "none"}}}, nil
+	assert.Empty(t, capacityRequests(app))
+}
+
+func TestValidCapacityRequests(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api := &API{Node: sNode, log: log.L()}
+
+	app := &models.ApplicationView{
+		Selector: "box=small",
+		Workload: specV1.WorkloadDeployment,
+		Services: []models.ServiceView{genCapacityService("web", map[string]string{"memory": "512Mi"}, nil)},
+	}
+	opt := &models.ListOptions{LabelSelector: "box=small"}
+	sNode.EXPECT().List("default", opt).Return(&models.NodeList{Items: []specV1.Node{
+		genCapacityNode("node01", "2", "1Gi", "256Mi"),
+		{Name: "node02"},
+	}}, nil)
+	assert.NoError(t, api.validCapacityRequests("default", app))
+	assert.Empty(t, app.Warnings)
+
+	// more than the available one is warned
+	sNode.EXPECT().List("default", opt).Return(&models.NodeList{Items: []specV1.Node{
+		genCapacityNode("node01", "2", "1Gi", "768Mi"),
+	}}, nil)
+	assert.NoError(t, api.validCapacityRequests("default", app))
+	assert.Equal(t, []string{"the node node01 has 256Mi memory available, but 512Mi is requested by the app"}, app.Warnings)
+
+	// more than the capacity is rejected
+	app.Replica = 3
+	sNode.EXPECT().List("default", opt).Return(&models.NodeList{Items: []specV1.Node{
+		genCapacityNode("node01", "2", "1Gi", "0"),
+	}}, nil)
+	err := api.validCapacityRequests("default", app)
+	e, ok := err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, common.ErrNodeCapacityShort, e.Code())
+	assert.Contains(t, err.Error(), "node01")
+	assert.Contains(t, err.Error(), "1536Mi")
+
+	// the nodes are not listed for system apps or apps requesting nothing
+	app.System = true
+	assert.NoError(t, api.validCapacityRequests("default", app))
+	app.System = false
+	app.Services = []models.ServiceView{{Service: specV1.Service{Name: "web"}}}
+	assert.NoError(t, api.validCapacityRequests("default", app))
+	assert.Nil(t, app.Warnings)
+}
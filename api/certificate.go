@@ -35,7 +35,8 @@ func (api *API) ListCertificate(c *common.Context) (interface{}, error) {
 		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
 	}
 
-	return api.ToFilteredCertificateViewList(secrets), nil
+	res := api.ToFilteredCertificateViewList(secrets)
+	return api.ToListResponse(res.Total, res.Items, res.ListOptions), nil
 }
 
 // CreateCertificate create one Certificate
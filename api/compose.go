@@ -0,0 +1,567 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+const (
+	// ComposeVolumeDir the host directory where the named volumes of compose file are placed
+	ComposeVolumeDir = "/var/lib/baetyl/volumes"
+	// ComposeProgramConfig the extension field of compose service which refers to the program config in native mode
+	ComposeProgramConfig = "x-baetyl-program-config"
+)
+
+type composeFile struct {
+	Name     string                            `yaml:"name"`
+	Version  string                            `yaml:"version"`
+	Services map[string]composeService         `yaml:"services"`
+	Volumes  map[string]map[string]interface{} `yaml:"volumes"`
+	Networks interface{}                       `yaml:"networks"`
+	Secrets  interface{}                       `yaml:"secrets"`
+	Configs  interface{}                       `yaml:"configs"`
+	Extra    map[string]interface{}            `yaml:",inline"`
+}
+
+type composeService struct {
+	Image         string                 `yaml:"image"`
+	Hostname      string                 `yaml:"hostname"`
+	Entrypoint    interface{}            `yaml:"entrypoint"`
+	Command       interface{}            `yaml:"command"`
+	Environment   interface{}            `yaml:"environment"`
+	Ports         []interface{}          `yaml:"ports"`
+	Volumes       []interface{}          `yaml:"volumes"`
+	Tmpfs         interface{}            `yaml:"tmpfs"`
+	Devices       []string               `yaml:"devices"`
+	Privileged    bool                   `yaml:"privileged"`
+	NetworkMode   string                 `yaml:"network_mode"`
+	Restart       string                 `yaml:"restart"`
+	Deploy        *composeDeploy         `yaml:"deploy"`
+	ProgramConfig string                 `yaml:"x-baetyl-program-config"`
+	Extra         map[string]interface{} `yaml:",inline"`
+}
+
+type composeDeploy struct {
+	Mode      string `yaml:"mode"`
+	Replicas  *int   `yaml:"replicas"`
+	Resources struct {
+		Limits       composeResources `yaml:"limits"`
+		Reservations composeResources `yaml:"reservations"`
+	} `yaml:"resources"`
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+type composeResources struct {
+	Cpus   interface{}            `yaml:"cpus"`
+	Memory string                 `yaml:"memory"`
+	Extra  map[string]interface{} `yaml:",inline"`
+}
+
+// composeConverter converts the compose file into application view and collects the warnings on the way
+type composeConverter struct {
+	app      *models.ApplicationView
+	volumes  map[string]string // the names of volumes keyed by the host paths and named volumes they come from
+	warnings []string
+}
+
+// ImportComposeApplication converts the docker compose file uploaded into an application and creates it,
+// the fields not supported are ignored with the warnings returned along with the application
+func (api *API) ImportComposeApplication(c *common.Context) (interface{}, error) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	defer file.Close()
+	if ext := path.Ext(header.Filename); ext != ".yaml" && ext != ".yml" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the compose file should be yaml"))
+	}
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+
+	mode := c.PostForm("mode")
+	if mode == "" {
+		mode = context.RunModeKube
+	}
+	if mode != context.RunModeKube && mode != context.RunModeNative {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "mode should be kube or native"))
+	}
+	res, err := ConvertCompose(data, c.PostForm("name"), mode)
+	if err != nil {
+		return nil, err
+	}
+	if !common.ValidNonBaetyl(res.Application.Name) {
+		return nil, common.Error(common.ErrInvalidName, common.Field("nonBaetyl", "Name"))
+	}
+	res.Application.Namespace = c.GetNamespace()
+	res.Application.Selector = c.PostForm("selector")
+	if err = api.checkApplicationView(res.Application); err != nil {
+		return nil, err
+	}
+	if res.Application, err = api.createApplicationView(c, c.GetNamespace(), res.Application); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ConvertCompose converts the docker compose file into the application view of mode,
+// the name of compose file is used if name is not given
+func ConvertCompose(data []byte, name, mode string) (*models.ComposeImport, error) {
+	var compose composeFile
+	if err := yaml.Unmarshal(data, &compose); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("failed to parse compose file: %s", err.Error())))
+	}
+	if len(compose.Services) == 0 {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "no service is defined in compose file"))
+	}
+	if name == "" {
+		name = compose.Name
+	}
+	if name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	if err := validateResourceName(name); err != nil {
+		return nil, err
+	}
+
+	cc := &composeConverter{
+		app: &models.ApplicationView{
+			Name:     name,
+			Mode:     mode,
+			Type:     common.ContainerApp,
+			Workload: specV1.WorkloadDeployment,
+			Replica:  1,
+		},
+		volumes: map[string]string{},
+	}
+	cc.warnExtra("", compose.Extra)
+	for _, field := range []struct {
+		name  string
+		value interface{}
+	}{{"networks", compose.Networks}, {"secrets", compose.Secrets}, {"configs", compose.Configs}} {
+		if field.value != nil {
+			cc.warn("%s is not supported and ignored", field.name)
+		}
+	}
+	vols := make([]string, 0, len(compose.Volumes))
+	for vol, opts := range compose.Volumes {
+		if len(opts) > 0 {
+			vols = append(vols, vol)
+		}
+	}
+	sort.Strings(vols)
+	for _, vol := range vols {
+		cc.warn("the options of volumes.%s are ignored", vol)
+	}
+
+	names := make([]string, 0, len(compose.Services))
+	for svc := range compose.Services {
+		names = append(names, svc)
+	}
+	sort.Strings(names)
+	replicas, global := map[int]bool{}, 0
+	hostNetwork := 0
+	for _, svcName := range names {
+		svc := compose.Services[svcName]
+		if err := cc.convertService(svcName, &svc); err != nil {
+			return nil, err
+		}
+		replica := 1
+		if svc.Deploy != nil && svc.Deploy.Mode == "global" {
+			global++
+		} else if svc.Deploy != nil && svc.Deploy.Replicas != nil {
+			replica = *svc.Deploy.Replicas
+		}
+		replicas[replica] = true
+		if replica > cc.app.Replica {
+			cc.app.Replica = replica
+		}
+		if svc.NetworkMode == "host" {
+			hostNetwork++
+		}
+	}
+
+	// all services of the application are deployed as one workload
+	if global > 0 {
+		cc.app.Workload = specV1.WorkloadDaemonSet
+		if global != len(names) {
+			cc.warn("all services are deployed globally since some of them are in global mode")
+		}
+	} else if len(replicas) > 1 {
+		cc.warn("all services are deployed with %d replicas since their replicas are different", cc.app.Replica)
+	}
+	if hostNetwork > 0 {
+		cc.app.HostNetwork = true
+		if hostNetwork != len(names) {
+			cc.warn("all services use the host network since some of them are in host network mode")
+		}
+	}
+	return &models.ComposeImport{Application: cc.app, Warnings: cc.warnings}, nil
+}
+
+func (cc *composeConverter) convertService(name string, svc *composeService) error {
+	prefix := "services." + name
+	svcName := strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	if err := validateResourceName(svcName); err != nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("the name of service %s is invalid", name)))
+	}
+	res := models.ServiceView{
+		Service: specV1.Service{
+			Name:     svcName,
+			Hostname: svc.Hostname,
+			Image:    svc.Image,
+			Replica:  1,
+		},
+		ProgramConfig: svc.ProgramConfig,
+	}
+	cc.warnExtra(prefix, svc.Extra)
+
+	var err error
+	if res.Command, err = composeCommand(svc.Entrypoint); err != nil {
+		return composeError(prefix+".entrypoint", err)
+	}
+	if res.Args, err = composeCommand(svc.Command); err != nil {
+		return composeError(prefix+".command", err)
+	}
+	if res.Env, err = cc.convertEnv(prefix, svc.Environment); err != nil {
+		return err
+	}
+	for _, p := range svc.Ports {
+		port, ok, err := cc.convertPort(prefix, p)
+		if err != nil {
+			return err
+		}
+		if ok {
+			res.Ports = append(res.Ports, port)
+		}
+	}
+	for i, v := range svc.Volumes {
+		mount, ok, err := cc.convertVolume(prefix, svcName, i, v)
+		if err != nil {
+			return err
+		}
+		if ok {
+			res.VolumeMounts = append(res.VolumeMounts, mount)
+		}
+	}
+	tmpfs, err := composeCommand(svc.Tmpfs)
+	if err != nil {
+		return composeError(prefix+".tmpfs", err)
+	}
+	for i, target := range tmpfs {
+		volName := fmt.Sprintf("%s-tmpfs-%d", svcName, i)
+		cc.app.Volumes = append(cc.app.Volumes, models.VolumeView{
+			Name:     volName,
+			EmptyDir: &specV1.EmptyDirVolumeSource{Medium: string(corev1.StorageMediumMemory)},
+		})
+		res.VolumeMounts = append(res.VolumeMounts, specV1.VolumeMount{Name: volName, MountPath: strings.SplitN(target, ":", 2)[0]})
+	}
+	for _, d := range svc.Devices {
+		res.Devices = append(res.Devices, specV1.Device{DevicePath: strings.SplitN(d, ":", 2)[0]})
+		if strings.Contains(d, ":") {
+			cc.warn("%s.devices %s is mapped to the same path in container", prefix, d)
+		}
+	}
+	if svc.Privileged {
+		res.SecurityContext = &specV1.SecurityContext{Privileged: true}
+	}
+	if svc.NetworkMode != "" && svc.NetworkMode != "host" && svc.NetworkMode != "bridge" {
+		cc.warn("%s.network_mode %s is not supported and ignored", prefix, svc.NetworkMode)
+	}
+	if svc.Restart == "no" || strings.HasPrefix(svc.Restart, "on-failure") {
+		cc.warn("%s.restart %s is not supported, the service is always restarted", prefix, svc.Restart)
+	}
+	if svc.Deploy != nil {
+		if err = cc.convertDeploy(prefix+".deploy", &res, svc.Deploy); err != nil {
+			return err
+		}
+	}
+	if cc.app.Mode == context.RunModeNative && svc.ProgramConfig == "" {
+		return composeError(prefix+"."+ComposeProgramConfig, fmt.Errorf("is required in native mode"))
+	}
+	cc.app.Services = append(cc.app.Services, res)
+	return nil
+}
+
+// convertEnv converts the environment in the form of list or map, the variables without value are ignored
+func (cc *composeConverter) convertEnv(prefix string, env interface{}) ([]specV1.Environment, error) {
+	var res []specV1.Environment
+	switch val := env.(type) {
+	case nil:
+	case []interface{}:
+		for _, item := range val {
+			kv := strings.SplitN(fmt.Sprint(item), "=", 2)
+			if len(kv) != 2 {
+				cc.warn("%s.environment %s without value is ignored", prefix, kv[0])
+				continue
+			}
+			res = append(res, specV1.Environment{Name: kv[0], Value: kv[1]})
+		}
+	case map[interface{}]interface{}:
+		keys := make([]string, 0, len(val))
+		values := map[string]interface{}{}
+		for k, v := range val {
+			keys = append(keys, fmt.Sprint(k))
+			values[fmt.Sprint(k)] = v
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if values[k] == nil {
+				cc.warn("%s.environment %s without value is ignored", prefix, k)
+				continue
+			}
+			res = append(res, specV1.Environment{Name: k, Value: fmt.Sprint(values[k])})
+		}
+	default:
+		return nil, composeError(prefix+".environment", fmt.Errorf("should be a list or map"))
+	}
+	return res, nil
+}
+
+// convertPort converts the port in short syntax ([HOST_IP:]HOST_PORT:CONTAINER_PORT[/PROTOCOL]) or long syntax,
+// the port ranges are not supported
+func (cc *composeConverter) convertPort(prefix string, port interface{}) (specV1.ContainerPort, bool, error) {
+	var hostIP, published, target, protocol string
+	switch val := port.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range val {
+			switch fmt.Sprint(k) {
+			case "target":
+				target = fmt.Sprint(v)
+			case "published":
+				published = fmt.Sprint(v)
+			case "protocol":
+				protocol = fmt.Sprint(v)
+			case "host_ip":
+				hostIP = fmt.Sprint(v)
+			default:
+				cc.warn("%s.ports.%v is not supported and ignored", prefix, k)
+			}
+		}
+	default:
+		spec := fmt.Sprint(val)
+		if i := strings.LastIndex(spec, "/"); i >= 0 {
+			spec, protocol = spec[:i], spec[i+1:]
+		}
+		parts := strings.Split(spec, ":")
+		target = parts[len(parts)-1]
+		if len(parts) > 1 {
+			published = parts[len(parts)-2]
+		}
+		if len(parts) > 2 {
+			hostIP = strings.Join(parts[:len(parts)-2], ":")
+		}
+	}
+	if strings.Contains(target, "-") || strings.Contains(published, "-") {
+		cc.warn("%s.ports %v in range is not supported and ignored", prefix, port)
+		return specV1.ContainerPort{}, false, nil
+	}
+	if hostIP != "" {
+		cc.warn("%s.ports %v is bound to all host addresses instead of %s", prefix, port, hostIP)
+	}
+	res := specV1.ContainerPort{
+		Protocol:    strings.ToUpper(protocol),
+		ServiceType: string(corev1.ServiceTypeClusterIP),
+	}
+	if res.Protocol == "" {
+		res.Protocol = string(corev1.ProtocolTCP)
+	}
+	p, err := strconv.ParseInt(target, 10, 32)
+	if err != nil || p <= 0 {
+		return res, false, composeError(prefix+".ports", fmt.Errorf("the container port of %v is invalid", port))
+	}
+	res.ContainerPort = int32(p)
+	if published != "" {
+		if p, err = strconv.ParseInt(published, 10, 32); err != nil || p <= 0 {
+			return res, false, composeError(prefix+".ports", fmt.Errorf("the host port of %v is invalid", port))
+		}
+		res.HostPort = int32(p)
+	}
+	return res, true, nil
+}
+
+// convertVolume converts the volume of service in short syntax (SOURCE:TARGET[:MODE]) or long syntax into the mount,
+// the absolute host paths are mounted as host path, the named volumes are placed in ComposeVolumeDir of host
+// and the anonymous ones are mounted as empty dir
+func (cc *composeConverter) convertVolume(prefix, svcName string, index int, volume interface{}) (specV1.VolumeMount, bool, error) {
+	var typ, source, target string
+	var readOnly bool
+	switch val := volume.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range val {
+			switch fmt.Sprint(k) {
+			case "type":
+				typ = fmt.Sprint(v)
+			case "source":
+				source = fmt.Sprint(v)
+			case "target":
+				target = fmt.Sprint(v)
+			case "read_only":
+				readOnly, _ = v.(bool)
+			default:
+				cc.warn("%s.volumes.%v is not supported and ignored", prefix, k)
+			}
+		}
+	default:
+		parts := strings.Split(fmt.Sprint(val), ":")
+		switch len(parts) {
+		case 1:
+			target = parts[0]
+		case 2:
+			source, target = parts[0], parts[1]
+		default:
+			source, target = parts[0], parts[1]
+			for _, opt := range strings.Split(parts[2], ",") {
+				if opt == "ro" {
+					readOnly = true
+				}
+			}
+		}
+	}
+	if target == "" {
+		return specV1.VolumeMount{}, false, composeError(prefix+".volumes", fmt.Errorf("the target of %v is required", volume))
+	}
+
+	mount := specV1.VolumeMount{MountPath: target, ReadOnly: readOnly}
+	vol := models.VolumeView{Name: fmt.Sprintf("%s-volume-%d", svcName, index)}
+	switch {
+	case typ == "tmpfs":
+		vol.EmptyDir = &specV1.EmptyDirVolumeSource{Medium: string(corev1.StorageMediumMemory)}
+	case source == "":
+		vol.EmptyDir = &specV1.EmptyDirVolumeSource{}
+	case strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~"):
+		cc.warn("%s.volumes %v on the relative host path is not supported and ignored", prefix, volume)
+		return mount, false, nil
+	default:
+		// the services mounting the same host path or named volume share one volume
+		if name, ok := cc.volumes[source]; ok {
+			mount.Name = name
+			return mount, true, nil
+		}
+		if path.IsAbs(source) {
+			vol.HostPath = &specV1.HostPathVolumeSource{Path: source}
+		} else {
+			hostPath := path.Join(ComposeVolumeDir, cc.app.Name+"-"+source)
+			vol.HostPath = &specV1.HostPathVolumeSource{Path: hostPath, Type: string(corev1.HostPathDirectoryOrCreate)}
+			cc.warn("the named volume %s is placed in %s of host", source, hostPath)
+		}
+		cc.volumes[source] = vol.Name
+	}
+	cc.app.Volumes = append(cc.app.Volumes, vol)
+	mount.Name = vol.Name
+	return mount, true, nil
+}
+
+func (cc *composeConverter) convertDeploy(prefix string, svc *models.ServiceView, deploy *composeDeploy) error {
+	cc.warnExtra(prefix, deploy.Extra)
+	if deploy.Mode != "" && deploy.Mode != "global" && deploy.Mode != "replicated" {
+		return composeError(prefix+".mode", fmt.Errorf("%s is invalid", deploy.Mode))
+	}
+	limits, err := cc.convertResources(prefix+".resources.limits", deploy.Resources.Limits)
+	if err != nil {
+		return err
+	}
+	requests, err := cc.convertResources(prefix+".resources.reservations", deploy.Resources.Reservations)
+	if err != nil {
+		return err
+	}
+	if limits != nil || requests != nil {
+		svc.Resources = &specV1.Resources{Limits: limits, Requests: requests}
+	}
+	return nil
+}
+
+func (cc *composeConverter) convertResources(prefix string, res composeResources) (map[string]string, error) {
+	cc.warnExtra(prefix, res.Extra)
+	var quantities map[string]string
+	if res.Cpus != nil {
+		cpu := fmt.Sprint(res.Cpus)
+		if _, err := strconv.ParseFloat(cpu, 64); err != nil {
+			return nil, composeError(prefix+".cpus", fmt.Errorf("%s is invalid", cpu))
+		}
+		quantities = map[string]string{string(corev1.ResourceCPU): cpu}
+	}
+	if res.Memory != "" {
+		memory, err := composeMemory(res.Memory)
+		if err != nil {
+			return nil, composeError(prefix+".memory", err)
+		}
+		if quantities == nil {
+			quantities = map[string]string{}
+		}
+		quantities[string(corev1.ResourceMemory)] = memory
+	}
+	return quantities, nil
+}
+
+func (cc *composeConverter) warnExtra(prefix string, extra map[string]interface{}) {
+	if prefix != "" {
+		prefix += "."
+	}
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		// the extension fields are left to other tools
+		if !strings.HasPrefix(k, "x-") {
+			cc.warn("%s%s is not supported and ignored", prefix, k)
+		}
+	}
+}
+
+func (cc *composeConverter) warn(format string, args ...interface{}) {
+	cc.warnings = append(cc.warnings, fmt.Sprintf(format, args...))
+}
+
+// composeCommand converts the command in the form of string or list into the list of arguments
+func composeCommand(cmd interface{}) ([]string, error) {
+	switch val := cmd.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return strings.Fields(val), nil
+	case []interface{}:
+		res := make([]string, 0, len(val))
+		for _, item := range val {
+			res = append(res, fmt.Sprint(item))
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("should be a string or list")
+	}
+}
+
+// composeMemory converts the byte value of compose such as 512m or 1gb into the quantity of kube such as 512Mi
+func composeMemory(s string) (string, error) {
+	v := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "b")
+	units := map[string]string{"k": "Ki", "m": "Mi", "g": "Gi", "t": "Ti"}
+	suffix := ""
+	if n := len(v); n > 0 {
+		if unit, ok := units[v[n-1:]]; ok {
+			v, suffix = v[:n-1], unit
+		}
+	}
+	if _, err := strconv.ParseUint(v, 10, 64); err != nil {
+		return "", fmt.Errorf("%s is invalid", s)
+	}
+	return v + suffix, nil
+}
+
+func composeError(field string, err error) error {
+	return common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("%s %s", field, err.Error())))
+}
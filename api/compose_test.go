@@ -0,0 +1,253 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mf "github.com/baetyl/baetyl-cloud/v2/mock/facade"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+const testCompose = `
+version: "3.8"
+name: web
+services:
+  nginx:
+    image: nginx:1.21
+    command: ["nginx", "-g", "daemon off;"]
+    environment:
+      - MODE=prod
+      - TOKEN
+    ports:
+      - "8080:80"
+      - "127.0.0.1:8443:443/tcp"
+      - "9000-9001:9000-9001"
+    volumes:
+      - /etc/nginx/conf.d:/etc/nginx/conf.d:ro
+      - data:/usr/share/nginx/html
+    healthcheck:
+      test: ["CMD", "curl", "-f", "http://localhost"]
+    deploy:
+      replicas: 2
+      resources:
+        limits:
+          cpus: "0.5"
+          memory: 512M
+        reservations:
+          memory: 128mb
+  cache_db:
+    image: redis:6
+    entrypoint: redis-server --appendonly yes
+    environment:
+      LEVEL: debug
+      EMPTY:
+    privileged: true
+    restart: "no"
+    devices:
+      - /dev/ttyUSB0:/dev/ttyUSB1
+    volumes:
+      - type: volume
+        source: data
+        target: /data
+      - ./logs:/logs
+      - /tmp
+    tmpfs: /run
+    x-owner: ops
+volumes:
+  data:
+    driver: local
+networks:
+  default:
+x-common: true
+`
+
+func TestConvertCompose(t *testing.T) {
+	res, err := ConvertCompose([]byte(testCompose), "", context.RunModeKube)
+	assert.NoError(t, err)
+	app := res.Application
+	assert.Equal(t, "web", app.Name)
+	assert.Equal(t, context.RunModeKube, app.Mode)
+	assert.Equal(t, common.ContainerApp, app.Type)
+	assert.Equal(t, specV1.WorkloadDeployment, app.Workload)
+	assert.Equal(t, 2, app.Replica)
+	assert.False(t, app.HostNetwork)
+
+	assert.Len(t, app.Services, 2)
+	cache, nginx := app.Services[0], app.Services[1]
+	assert.Equal(t, "cache-db", cache.Name)
+	assert.Equal(t, []string{"redis-server", "--appendonly", "yes"}, cache.Command)
+	assert.Equal(t, []specV1.Environment{{Name: "LEVEL", Value: "debug"}}, cache.Env)
+	assert.Equal(t, []specV1.Device{{DevicePath: "/dev/ttyUSB0"}}, cache.Devices)
+	assert.True(t, cache.SecurityContext.Privileged)
+	assert.Equal(t, []specV1.VolumeMount{
+		{Name: "cache-db-volume-0", MountPath: "/data"},
+		{Name: "cache-db-volume-2", MountPath: "/tmp"},
+		{Name: "cache-db-tmpfs-0", MountPath: "/run"},
+	}, cache.VolumeMounts)
+
+	assert.Equal(t, "nginx", nginx.Name)
+	assert.Equal(t, "nginx:1.21", nginx.Image)
+	assert.Equal(t, []string{"nginx", "-g", "daemon off;"}, nginx.Args)
+	assert.Equal(t, []specV1.Environment{{Name: "MODE", Value: "prod"}}, nginx.Env)
+	assert.Equal(t, []specV1.ContainerPort{
+		{HostPort: 8080, ContainerPort: 80, Protocol: "TCP", ServiceType: "ClusterIP"},
+		{HostPort: 8443, ContainerPort: 443, Protocol: "TCP", ServiceType: "ClusterIP"},
+	}, nginx.Ports)
+	assert.Equal(t, []specV1.VolumeMount{
+		{Name: "nginx-volume-0", MountPath: "/etc/nginx/conf.d", ReadOnly: true},
+		{Name: "cache-db-volume-0", MountPath: "/usr/share/nginx/html"},
+	}, nginx.VolumeMounts)
+	assert.Equal(t, &specV1.Resources{
+		Limits:   map[string]string{"cpu": "0.5", "memory": "512Mi"},
+		Requests: map[string]string{"memory": "128Mi"},
+	}, nginx.Resources)
+
+	assert.Equal(t, []models.VolumeView{
+		{Name: "cache-db-volume-0", HostPath: &specV1.HostPathVolumeSource{Path: "/var/lib/baetyl/volumes/web-data", Type: "DirectoryOrCreate"}},
+		{Name: "cache-db-volume-2", EmptyDir: &specV1.EmptyDirVolumeSource{}},
+		{Name: "cache-db-tmpfs-0", EmptyDir: &specV1.EmptyDirVolumeSource{Medium: "Memory"}},
+		{Name: "nginx-volume-0", HostPath: &specV1.HostPathVolumeSource{Path: "/etc/nginx/conf.d"}},
+	}, app.Volumes)
+
+	assert.Equal(t, []string{
+		"networks is not supported and ignored",
+		"the options of volumes.data are ignored",
+		"services.cache_db.environment EMPTY without value is ignored",
+		"the named volume data is placed in /var/lib/baetyl/volumes/web-data of host",
+		"services.cache_db.volumes ./logs:/logs on the relative host path is not supported and ignored",
+		"services.cache_db.devices /dev/ttyUSB0:/dev/ttyUSB1 is mapped to the same path in container",
+		"services.cache_db.restart no is not supported, the service is always restarted",
+		"services.nginx.healthcheck is not supported and ignored",
+		"services.nginx.environment TOKEN without value is ignored",
+		"services.nginx.ports 127.0.0.1:8443:443/tcp is bound to all host addresses instead of 127.0.0.1",
+		"services.nginx.ports 9000-9001:9000-9001 in range is not supported and ignored",
+		"all services are deployed with 2 replicas since their replicas are different",
+	}, res.Warnings)
+
+	// the name given takes precedence
+	res, err = ConvertCompose([]byte("services:\n  web:\n    image: nginx\n    network_mode: host\n    deploy:\n      mode: global\n"), "nginx", context.RunModeKube)
+	assert.NoError(t, err)
+	assert.Equal(t, "nginx", res.Application.Name)
+	assert.Equal(t, specV1.WorkloadDaemonSet, res.Application.Workload)
+	assert.True(t, res.Application.HostNetwork)
+	assert.Empty(t, res.Warnings)
+
+	// the program config is required in native mode
+	_, err = ConvertCompose([]byte("services:\n  web:\n    image: nginx\n"), "web", context.RunModeNative)
+	assert.Error(t, err)
+	res, err = ConvertCompose([]byte("services:\n  web:\n    x-baetyl-program-config: web-program\n"), "web", context.RunModeNative)
+	assert.NoError(t, err)
+	assert.Equal(t, "web-program", res.Application.Services[0].ProgramConfig)
+
+	for _, data := range []string{
+		"services: [",
+		"version: '3'",
+		"services:\n  Web!:\n    image: nginx\n",
+		"services:\n  web:\n    image: nginx\n    ports: ['http']\n",
+		"services:\n  web:\n    image: nginx\n    environment: 1\n",
+		"services:\n  web:\n    image: nginx\n    deploy:\n      resources:\n        limits:\n          memory: 1x\n",
+	} {
+		_, err = ConvertCompose([]byte(data), "web", context.RunModeKube)
+		assert.Error(t, err, data)
+	}
+	_, err = ConvertCompose([]byte("services:\n  web:\n    image: nginx\n"), "", context.RunModeKube)
+	assert.Error(t, err)
+}
+
+func TestComposeMemory(t *testing.T) {
+	for s, expect := range map[string]string{"512M": "512Mi", "1gb": "1Gi", "64k": "64Ki", "1024": "1024", "100b": "100"} {
+		res, err := composeMemory(s)
+		assert.NoError(t, err)
+		assert.Equal(t, expect, res)
+	}
+	_, err := composeMemory("m")
+	assert.Error(t, err)
+}
+
+func newComposeRequest(t *testing.T, url, filename, compose string, fields map[string]string) *http.Request {
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+	for k, v := range fields {
+		assert.NoError(t, w.WriteField(k, v))
+	}
+	fw, err := w.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	fw.Write([]byte(compose))
+	assert.NoError(t, w.Close())
+	req, _ := http.NewRequest(http.MethodPost, url, buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestImportComposeApplication(t *testing.T) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mockIM := func(c *gin.Context) { c.Set(common.KeyContextNamespace, "baetyl-cloud") }
+	router.POST("/v1/apps/compose", mockIM, common.Wrapper(api.ImportComposeApplication))
+
+	sApp := ms.NewMockApplicationService(mockCtl)
+	fApp := mf.NewMockFacade(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+	api.Facade = fApp
+
+	compose := "services:\n  web:\n    image: nginx\n    ports: ['8080:80']\n    restart: on-failure\n"
+	sApp.EXPECT().Get("baetyl-cloud", "web", "").Return(nil, common.Error(common.ErrResourceNotFound)).Times(2)
+	fApp.EXPECT().CreateApp("baetyl-cloud", nil, gomock.Any(), gomock.Any()).DoAndReturn(func(_ string, _ *specV1.Application, app *specV1.Application, _ []specV1.Configuration) (*specV1.Application, error) {
+		assert.Equal(t, "web", app.Name)
+		assert.Equal(t, "app=web", app.Selector)
+		assert.Len(t, app.Services, 1)
+		assert.Equal(t, "nginx", app.Services[0].Image)
+		assert.Equal(t, int32(8080), app.Services[0].Ports[0].HostPort)
+		return app, nil
+	})
+	req := newComposeRequest(t, "/v1/apps/compose", "docker-compose.yml", compose, map[string]string{"name": "web", "selector": "app=web"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"warnings":["services.web.restart on-failure is not supported, the service is always restarted"]`)
+	assert.Contains(t, w.Body.String(), `"image":"nginx"`)
+
+	// dry run
+	req = newComposeRequest(t, "/v1/apps/compose?dryRun=true", "docker-compose.yml", compose, map[string]string{"name": "web"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the app exists
+	sApp.EXPECT().Get("baetyl-cloud", "web", "").Return(&specV1.Application{Name: "web"}, nil)
+	req = newComposeRequest(t, "/v1/apps/compose", "docker-compose.yml", compose, map[string]string{"name": "web"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	req = newComposeRequest(t, "/v1/apps/compose", "docker-compose.json", compose, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = newComposeRequest(t, "/v1/apps/compose", "docker-compose.yml", compose, map[string]string{"name": "web", "mode": "docker"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the program config is required in native mode
+	req = newComposeRequest(t, "/v1/apps/compose", "docker-compose.yml", compose, map[string]string{"name": "web", "mode": context.RunModeNative})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
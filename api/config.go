@@ -10,16 +10,19 @@ import (
 	"github.com/baetyl/baetyl-go/v2/log"
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 	"github.com/baetyl/baetyl-go/v2/utils"
+	"github.com/gin-gonic/gin"
 	"github.com/jinzhu/copier"
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
 	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
 )
 
 const (
 	ConfigTypeKV            = "kv"
 	ConfigTypeObject        = "object"
 	ConfigTypeFunction      = "function"
+	ConfigTypeTemplate      = "template"
 	ConfigObjectTypeHttp    = "http"
 	ConfigImageTypeSelector = "baetyl-config-type=baetyl-image"
 )
@@ -54,12 +57,12 @@ func (api *API) ListConfig(c *common.Context) (interface{}, error) {
 			cfg.Data = nil
 		}
 	}
-	return list, err
+	return api.ToListResponse(list.Total, list.Items, list.ListOptions), nil
 }
 
 // CreateConfig create one config
 func (api *API) CreateConfig(c *common.Context) (interface{}, error) {
-	config, err := api.parseAndCheckConfigView(c)
+	config, uploads, err := api.parseAndCheckConfigView(c)
 	if err != nil {
 		log.L().Error("parse and check config model failed", log.Error(err))
 		return nil, err
@@ -81,17 +84,25 @@ func (api *API) CreateConfig(c *common.Context) (interface{}, error) {
 			common.Field("error", "this name is already in use"))
 	}
 
+	if err = api.offloadConfig(c.GetUser().ID, config, c.IsDryRun()); err != nil {
+		return nil, err
+	}
+
+	if c.IsDryRun() {
+		return api.toUploadedConfigView(config, uploads)
+	}
+
 	config, err = api.Facade.CreateConfig(ns, config)
 	if err != nil {
 		return nil, err
 	}
 
-	return api.ToConfigurationView(config)
+	return api.toUploadedConfigView(config, uploads)
 }
 
 // UpdateConfig update the config
 func (api *API) UpdateConfig(c *common.Context) (interface{}, error) {
-	config, err := api.parseAndCheckConfigView(c)
+	config, uploads, err := api.parseAndCheckConfigView(c)
 	if err != nil {
 		return nil, err
 	}
@@ -107,25 +118,38 @@ func (api *API) UpdateConfig(c *common.Context) (interface{}, error) {
 		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "labels can't be modified of sys apps"))
 	}
 
+	// the large items are named by content, the config is unchanged if they are the same as the ones offloaded before
+	if err = api.offloadConfig(c.GetUser().ID, config, c.IsDryRun()); err != nil {
+		return nil, err
+	}
+
 	if models.EqualConfig(res, config) {
-		return api.ToConfigurationView(res)
+		return api.toUploadedConfigView(res, uploads)
 	}
 
 	config.Version = res.Version
 	config.UpdateTimestamp = time.Now()
 	config.CreationTimestamp = res.CreationTimestamp
 
+	if c.IsDryRun() {
+		return api.toUploadedConfigView(config, uploads)
+	}
+
 	res, err = api.Facade.UpdateConfig(ns, config)
 	if err != nil {
 		return nil, err
 	}
 
-	return api.ToConfigurationView(res)
+	return api.toUploadedConfigView(res, uploads)
 }
 
-// DeleteConfig delete the config
+// DeleteConfig delete the config, which is refused if the apps still reference it unless force=true
 func (api *API) DeleteConfig(c *common.Context) (interface{}, error) {
 	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	force, err := parseForceDelete(c)
+	if err != nil {
+		return nil, err
+	}
 	res, err := api.Config.Get(ns, n, "")
 	if err != nil {
 		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
@@ -142,9 +166,10 @@ func (api *API) DeleteConfig(c *common.Context) (interface{}, error) {
 	}
 
 	if len(appNames) > 0 {
-		return nil, common.Error(common.ErrResourceHasBeenUsed,
-			common.Field("type", "config"),
-			common.Field("name", n))
+		if !force {
+			return nil, checkResourceReferences("config", n, appNames)
+		}
+		log.L().Warn("delete config referenced by apps", log.Any("name", n), log.Any("namespace", ns), log.Any("apps", appNames))
 	}
 
 	//TODO: should remove file(bos/aws) of a function Config
@@ -161,23 +186,38 @@ func (api *API) GetAppByConfig(c *common.Context) (interface{}, error) {
 	return api.listAppByConfig(ns, res.Name)
 }
 
-// parseAndCheckConfigModel parse and check the config model
-func (api *API) parseAndCheckConfigView(c *common.Context) (*specV1.Configuration, error) {
+// parseAndCheckConfigModel parse and check the config model, which is loaded from the multipart form
+// with the files uploaded as items if the request is multipart
+func (api *API) parseAndCheckConfigView(c *common.Context) (*specV1.Configuration, []models.ConfigUpload, error) {
 	configView := new(models.ConfigurationView)
 	configView.Name = c.GetNameFromParam()
 	configView.Namespace = c.GetNamespace()
-	err := c.LoadBody(configView)
-	if err != nil {
+	var uploads []models.ConfigUpload
+	var err error
+	if c.ContentType() == gin.MIMEMultipartPOSTForm {
+		if uploads, err = api.loadConfigForm(c, configView); err != nil {
+			return nil, nil, err
+		}
+	} else if err = c.LoadBody(configView); err != nil {
 		log.L().Error("parse config failed", log.Error(err))
-		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+		return nil, nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
 	}
 	if name := c.GetNameFromParam(); name != "" {
 		configView.Name = name
 	}
 	if configView.Name == "" {
-		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+		return nil, nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	config, err := api.checkConfigView(c.GetUser().ID, configView)
+	if err != nil {
+		return nil, nil, err
 	}
+	return config, uploads, nil
+}
 
+// checkConfigView checks the data items of config view and converts it to the config
+func (api *API) checkConfigView(userID string, configView *models.ConfigurationView) (*specV1.Configuration, error) {
+	var err error
 	for _, item := range configView.Data {
 		if _type, ok := item.Value["type"]; ok {
 			switch _type {
@@ -206,18 +246,52 @@ func (api *API) parseAndCheckConfigView(c *common.Context) (*specV1.Configuratio
 					return nil, common.Error(common.ErrRequestParamInvalid,
 						common.Field("error", "key of kv data can't start with "+common.ConfigObjectPrefix))
 				}
+				if strings.HasPrefix(item.Key, common.ConfigTemplatePrefix) {
+					return nil, common.Error(common.ErrRequestParamInvalid,
+						common.Field("error", "key of kv data can't start with "+common.ConfigTemplatePrefix))
+				}
+				if item.Key == common.ConfigSchemaKey {
+					return nil, common.Error(common.ErrRequestParamInvalid,
+						common.Field("error", "key of kv data can't be "+common.ConfigSchemaKey))
+				}
+			case ConfigTypeTemplate:
+				if _, err = service.ParseConfigTemplate(item.Key, item.Value["value"]); err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
+	// the template items are rendered to nodes with their keys, which are not allowed to duplicate the others
+	keys := map[string]int{}
+	for _, item := range configView.Data {
+		keys[item.Key]++
+	}
+	for _, item := range configView.Data {
+		if item.Value["type"] == ConfigTypeTemplate && keys[item.Key] > 1 {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the key "+item.Key+" of config is duplicated"))
+		}
+	}
 
-	config, err := api.ToConfiguration(c.GetUser().ID, configView)
+	config, err := api.ToConfiguration(userID, configView)
 	if err != nil {
 		return nil, err
 	}
+	if err = api.checkConfigSchema(configView.Namespace, config); err != nil {
+		return nil, err
+	}
 
 	return config, nil
 }
 
+func (api *API) toUploadedConfigView(config *specV1.Configuration, uploads []models.ConfigUpload) (*models.ConfigurationView, error) {
+	view, err := api.ToConfigurationView(config)
+	if err != nil {
+		return nil, err
+	}
+	view.Uploads = uploads
+	return view, nil
+}
+
 func (api *API) ToConfigurationView(config *specV1.Configuration) (*models.ConfigurationView, error) {
 	configView := new(models.ConfigurationView)
 	err := copier.Copy(configView, config)
@@ -226,11 +300,28 @@ func (api *API) ToConfigurationView(config *specV1.Configuration) (*models.Confi
 	}
 
 	for k, v := range config.Data {
+		if k == common.ConfigSchemaKey {
+			configView.Schema = new(models.ConfigSchema)
+			if err = json.Unmarshal([]byte(v), configView.Schema); err != nil {
+				return nil, err
+			}
+			continue
+		}
 		obj := models.ConfigDataItem{
 			Key:   k,
 			Value: map[string]string{},
 		}
 
+		if strings.HasPrefix(k, common.ConfigTemplatePrefix) {
+			obj.Key = strings.TrimPrefix(k, common.ConfigTemplatePrefix)
+			obj.Value = map[string]string{
+				"type":  ConfigTypeTemplate,
+				"value": v,
+			}
+			configView.Data = append(configView.Data, obj)
+			continue
+		}
+
 		var object specV1.ConfigurationObject
 		if strings.HasPrefix(k, common.ConfigObjectPrefix) {
 			obj.Key = strings.TrimPrefix(k, common.ConfigObjectPrefix)
@@ -267,6 +358,8 @@ func (api *API) ToConfiguration(userID string, configView *models.ConfigurationV
 		switch v.Value["type"] {
 		case ConfigTypeKV:
 			config.Data[v.Key] = v.Value["value"]
+		case ConfigTypeTemplate:
+			config.Data[common.ConfigTemplatePrefix+v.Key] = v.Value["value"]
 		case ConfigTypeFunction, ConfigTypeObject:
 			object := &specV1.ConfigurationObject{
 				URL:      v.Value["url"],
@@ -283,9 +376,24 @@ func (api *API) ToConfiguration(userID string, configView *models.ConfigurationV
 			config.Data[common.ConfigObjectPrefix+v.Key] = string(bytes)
 		}
 	}
+	if configView.Schema != nil {
+		data, err := json.Marshal(configView.Schema)
+		if err != nil {
+			return nil, err
+		}
+		config.Data[common.ConfigSchemaKey] = string(data)
+	}
 	return config, nil
 }
 
+// offloadConfig stores the large kv items of config in object storage
+func (api *API) offloadConfig(userID string, config *specV1.Configuration, dryRun bool) error {
+	if api.ConfigObject == nil {
+		return nil
+	}
+	return api.ConfigObject.Offload(userID, config, dryRun)
+}
+
 func checkElementsExist(m map[string]string, elems ...string) bool {
 	for _, v := range elems {
 		if _, ok := m[v]; !ok {
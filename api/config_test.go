@@ -2,11 +2,15 @@ package api
 
 import (
 	"bytes"
+	"crypto/md5"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -478,6 +482,43 @@ func TestCreateConfig(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+func TestCreateConfigDryRun(t *testing.T) {
+	api, router, mockCtl := initConfigAPI(t)
+	defer mockCtl.Finish()
+
+	sConfig := ms.NewMockConfigService(mockCtl)
+	// the facade is not expected to be called
+	api.Facade = mf.NewMockFacade(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{
+		Config: sConfig,
+	}
+
+	mConf := &models.ConfigurationView{
+		Name: "abc",
+		Data: []models.ConfigDataItem{
+			{
+				Key: "key",
+				Value: map[string]string{
+					"type":  ConfigTypeKV,
+					"value": "value",
+				},
+			},
+		},
+	}
+	sConfig.EXPECT().Get("default", "abc", "").Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(mConf)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/configs?dryRun=true", bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := &models.ConfigurationView{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, "abc", res.Name)
+	assert.Equal(t, "default", res.Namespace)
+	assert.Equal(t, mConf.Data, res.Data)
+}
+
 func TestUpdateConfig(t *testing.T) {
 	api, router, mockCtl := initConfigAPI(t)
 	defer mockCtl.Finish()
@@ -722,13 +763,29 @@ func TestDeleteConfig(t *testing.T) {
 
 	appNames := []string{"app01"}
 
-	// 403
+	// 409
 	sConfig.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(mConf, nil)
 	sIndex.EXPECT().ListAppIndexByConfig(gomock.Any(), gomock.Any()).Return(appNames, nil)
 	req, _ = http.NewRequest(http.MethodDelete, "/v1/configs/abc", nil)
 	w = httptest.NewRecorder()
 	router.ServeHTTP(w, req)
-	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "app01")
+
+	// 200 referenced config deleted by force
+	sConfig.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(mConf, nil)
+	sIndex.EXPECT().ListAppIndexByConfig(gomock.Any(), gomock.Any()).Return(appNames, nil)
+	fConfig.EXPECT().DeleteConfig(mConf.Namespace, mConf.Name).Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/configs/abc?force=true", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 400 invalid force
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/configs/abc?force=yes", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	// 200
 	sConfig.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(mConf, nil)
@@ -810,3 +867,182 @@ func TestGetAppByConfig(t *testing.T) {
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+func TestCreateConfigOffload(t *testing.T) {
+	api, router, mockCtl := initConfigAPI(t)
+	defer mockCtl.Finish()
+
+	sConfig, sConfigObject := ms.NewMockConfigService(mockCtl), ms.NewMockConfigObjectService(mockCtl)
+	fConfig := mf.NewMockFacade(mockCtl)
+	api.Facade, api.ConfigObject = fConfig, sConfigObject
+	api.AppCombinedService = &service.AppCombinedService{Config: sConfig}
+
+	mConf := &models.ConfigurationView{
+		Name: "model",
+		Data: []models.ConfigDataItem{{Key: "model.bin", Value: map[string]string{"type": ConfigTypeKV, "value": "large"}}},
+	}
+	offloaded := `{"metadata":{"bucket":"baetyl-cloud-default","object":"configs/default/model/md5/model.bin","source":"minio","type":"object","userID":"default"}}`
+	sConfig.EXPECT().Get("default", "model", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	sConfigObject.EXPECT().Offload("default", gomock.Any(), false).DoAndReturn(func(_ string, cfg *specV1.Configuration, _ bool) error {
+		assert.Equal(t, "large", cfg.Data["model.bin"])
+		cfg.Data = map[string]string{common.ConfigObjectPrefix + "model.bin": offloaded}
+		return nil
+	})
+	fConfig.EXPECT().CreateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		assert.Equal(t, map[string]string{common.ConfigObjectPrefix + "model.bin": offloaded}, cfg.Data)
+		return cfg, nil
+	})
+	body, _ := json.Marshal(mConf)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/configs", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	view := new(models.ConfigurationView)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), view))
+	assert.Equal(t, "model.bin", view.Data[0].Key)
+	assert.Equal(t, ConfigTypeObject, view.Data[0].Value["type"])
+	assert.Equal(t, "minio", view.Data[0].Value["source"])
+
+	// the items are not uploaded in dry run
+	sConfig.EXPECT().Get("default", "model", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	sConfigObject.EXPECT().Offload("default", gomock.Any(), true).Return(nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/configs?dryRun=true", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sConfig.EXPECT().Get("default", "model", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	sConfigObject.EXPECT().Offload("default", gomock.Any(), false).Return(fmt.Errorf("error"))
+	req, _ = http.NewRequest(http.MethodPost, "/v1/configs", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestCreateConfigTemplate(t *testing.T) {
+	api, router, mockCtl := initConfigAPI(t)
+	defer mockCtl.Finish()
+
+	sConfig := ms.NewMockConfigService(mockCtl)
+	fConfig := mf.NewMockFacade(mockCtl)
+	api.Facade = fConfig
+	api.AppCombinedService = &service.AppCombinedService{Config: sConfig}
+
+	mConf := &models.ConfigurationView{
+		Name: "device",
+		Data: []models.ConfigDataItem{
+			{Key: "device.yml", Value: map[string]string{"type": ConfigTypeTemplate, "value": "id: {{.Properties.deviceId}}"}},
+			{Key: "static", Value: map[string]string{"type": ConfigTypeKV, "value": "{{.Name}}"}},
+		},
+	}
+	sConfig.EXPECT().Get("default", "device", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	fConfig.EXPECT().CreateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		assert.Equal(t, map[string]string{
+			common.ConfigTemplatePrefix + "device.yml": "id: {{.Properties.deviceId}}",
+			"static": "{{.Name}}",
+		}, cfg.Data)
+		return cfg, nil
+	})
+	body, _ := json.Marshal(mConf)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/configs", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	view := new(models.ConfigurationView)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), view))
+	assert.Len(t, view.Data, 2)
+	for _, item := range view.Data {
+		if item.Key == "device.yml" {
+			assert.Equal(t, map[string]string{"type": ConfigTypeTemplate, "value": "id: {{.Properties.deviceId}}"}, item.Value)
+		}
+	}
+
+	// the template is parsed before the config is saved
+	mConf.Data[0].Value["value"] = "id: {{.Properties.deviceId"
+	body, _ = json.Marshal(mConf)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/configs", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the rendered key should not duplicate the others
+	mConf.Data[0].Value["value"] = "{{.Name}}"
+	mConf.Data[1].Key = "device.yml"
+	body, _ = json.Marshal(mConf)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/configs", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateConfigUpload(t *testing.T) {
+	api, router, mockCtl := initConfigAPI(t)
+	defer mockCtl.Finish()
+
+	sConfig, fConfig, sObject := ms.NewMockConfigService(mockCtl), mf.NewMockFacade(mockCtl), ms.NewMockConfigObjectService(mockCtl)
+	api.Facade, api.ConfigObject = fConfig, sObject
+	api.AppCombinedService = &service.AppCombinedService{Config: sConfig}
+
+	binary := "\x89PNG\x00\x01"
+	newRequest := func(checksums string) *http.Request {
+		buf := new(bytes.Buffer)
+		w := multipart.NewWriter(buf)
+		assert.NoError(t, w.WriteField("config", `{"name":"web","data":[{"key":"conf.yml","value":{"type":"kv","value":"level: info"}}]}`))
+		if checksums != "" {
+			assert.NoError(t, w.WriteField("checksums", checksums))
+		}
+		fw, err := w.CreateFormFile("file", "index.html")
+		assert.NoError(t, err)
+		fw.Write([]byte("<html></html>"))
+		fw, err = w.CreateFormFile("file", "logo.png")
+		assert.NoError(t, err)
+		fw.Write([]byte(binary))
+		assert.NoError(t, w.Close())
+		req, _ := http.NewRequest(http.MethodPost, "/v1/configs", buf)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		return req
+	}
+	sum := md5.Sum([]byte(binary))
+	md5Hex := hex.EncodeToString(sum[:])
+
+	sObject.EXPECT().CheckUpload("index.html", int64(13)).Return(nil)
+	sObject.EXPECT().CheckUpload("logo.png", int64(len(binary))).Return(nil)
+	sConfig.EXPECT().Get("default", "web", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	sObject.EXPECT().Offload("default", gomock.Any(), false).DoAndReturn(func(_ string, cfg *specV1.Configuration, _ bool) error {
+		assert.Equal(t, map[string]string{"conf.yml": "level: info", "index.html": "<html></html>", "logo.png": binary}, cfg.Data)
+		return nil
+	})
+	fConfig.EXPECT().CreateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		return cfg, nil
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest(`{"logo.png":"`+strings.ToUpper(md5Hex)+`"}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+	view := new(models.ConfigurationView)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), view))
+	assert.Len(t, view.Data, 3)
+	assert.Len(t, view.Uploads, 2)
+	assert.Equal(t, "index.html", view.Uploads[0].Key)
+	assert.Equal(t, "index.html", view.Uploads[0].Filename)
+	assert.Equal(t, int64(13), view.Uploads[0].Size)
+	assert.Equal(t, md5Hex, view.Uploads[1].MD5)
+	assert.Len(t, view.Uploads[1].SHA256, 64)
+
+	// the checksum does not match
+	sObject.EXPECT().CheckUpload(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest(`{"logo.png":"`+strings.Repeat("0", 32)+`"}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the checksum of file not uploaded
+	sObject.EXPECT().CheckUpload(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest(`{"model.bin":"`+md5Hex+`"}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the file exceeds the limits
+	sObject.EXPECT().CheckUpload("index.html", int64(13)).Return(common.Error(common.ErrRequestParamInvalid))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest(""))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// the suffixes tried to rename the configs imported, such as web-1, web-2
+const maxConfigRenameTries = 100
+
+// ExportConfigs exports the configs of namespace matching the selector as a tar.gz archive, the system configs excluded
+func (api *API) ExportConfigs(c *common.Context) (interface{}, error) {
+	ns := c.GetNamespace()
+	params, err := api.ParseListOptionsAppendSystemLabel(c)
+	if err != nil {
+		return nil, err
+	}
+	list, err := api.Config.List(ns, params)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]models.ConfigurationView, 0, len(list.Items))
+	for i := range list.Items {
+		view, err := api.ToConfigurationView(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, *view)
+	}
+	archive, err := service.PackConfigArchive(views)
+	if err != nil {
+		return nil, err
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-configs.tar.gz"`, ns))
+	c.Data(http.StatusOK, "application/gzip", archive)
+	return nil, nil
+}
+
+// ImportConfigs imports the configs from the archive exported by ExportConfigs. The configs which the namespace has
+// already are skipped, overwritten or created with new names according to the strategy, skip by default.
+// All configs are checked before any of them is imported
+func (api *API) ImportConfigs(c *common.Context) (interface{}, error) {
+	ns, userID := c.GetNamespace(), c.GetUser().ID
+	strategy := c.Query("strategy")
+	if strategy == "" {
+		strategy = models.ConfigImportSkip
+	}
+	if strategy != models.ConfigImportSkip && strategy != models.ConfigImportOverwrite && strategy != models.ConfigImportRename {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "strategy should be one of skip, overwrite and rename"))
+	}
+	views, err := api.loadConfigArchive(c)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, v := range views {
+		names[v.Name] = true
+	}
+	configs := make([]*specV1.Configuration, len(views))
+	olds := make([]*specV1.Configuration, len(views))
+	res := &models.ConfigImport{
+		Strategy: strategy,
+		Created:  []string{},
+		Updated:  []string{},
+		Skipped:  []string{},
+		Renamed:  map[string]string{},
+	}
+	for i := range views {
+		view := &views[i]
+		view.Namespace, view.Version, view.System = ns, "", false
+		if err = common.ValidateStruct(view); err != nil {
+			return nil, err
+		}
+		if !common.ValidNonBaetyl(view.Name) || CheckIsSysResources(view.Labels) {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the system config "+view.Name+" can not be imported"))
+		}
+		if configs[i], err = api.checkConfigView(userID, view); err != nil {
+			return nil, err
+		}
+		configs[i].Namespace = ns
+		if olds[i], err = api.getImportedConfig(ns, view.Name); err != nil {
+			return nil, err
+		}
+		if olds[i] == nil {
+			continue
+		}
+		switch strategy {
+		case models.ConfigImportOverwrite:
+			if CheckIsSysResources(olds[i].Labels) {
+				return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the system config "+view.Name+" can not be overwritten"))
+			}
+		case models.ConfigImportRename:
+			name, err := api.renameImportedConfig(ns, view.Name, names)
+			if err != nil {
+				return nil, err
+			}
+			names[name] = true
+			res.Renamed[view.Name] = name
+			configs[i].Name, olds[i] = name, nil
+		}
+	}
+
+	for i, cfg := range configs {
+		old := olds[i]
+		if old != nil && strategy == models.ConfigImportSkip {
+			res.Skipped = append(res.Skipped, cfg.Name)
+			continue
+		}
+		if err = api.offloadConfig(userID, cfg, c.IsDryRun()); err != nil {
+			return nil, err
+		}
+		if old == nil {
+			if !c.IsDryRun() {
+				if _, err = api.Facade.CreateConfig(ns, cfg); err != nil {
+					return nil, err
+				}
+			}
+			res.Created = append(res.Created, cfg.Name)
+			continue
+		}
+		if models.EqualConfig(old, cfg) {
+			res.Skipped = append(res.Skipped, cfg.Name)
+			continue
+		}
+		cfg.Version = old.Version
+		cfg.UpdateTimestamp = time.Now()
+		cfg.CreationTimestamp = old.CreationTimestamp
+		if !c.IsDryRun() {
+			if _, err = api.Facade.UpdateConfig(ns, cfg); err != nil {
+				return nil, err
+			}
+		}
+		res.Updated = append(res.Updated, cfg.Name)
+	}
+	log.L().Info("configs imported", log.Any("namespace", ns), log.Any("strategy", strategy),
+		log.Any("created", len(res.Created)), log.Any("updated", len(res.Updated)), log.Any("skipped", len(res.Skipped)))
+	return res, nil
+}
+
+func (api *API) loadConfigArchive(c *common.Context) ([]models.ConfigurationView, error) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	defer file.Close()
+	if !strings.HasSuffix(header.Filename, ".tgz") && !strings.HasSuffix(header.Filename, ".tar.gz") {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the configs should be packaged as .tar.gz"))
+	}
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return service.LoadConfigArchive(data)
+}
+
+// getImportedConfig returns the config of namespace which has the same name as the one imported, nil if not found
+func (api *API) getImportedConfig(ns, name string) (*specV1.Configuration, error) {
+	cfg, err := api.Config.Get(ns, name, "")
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// renameImportedConfig returns the name suffixed with a number, which is used by neither the namespace nor the archive
+func (api *API) renameImportedConfig(ns, name string, names map[string]bool) (string, error) {
+	for i := 1; i <= maxConfigRenameTries; i++ {
+		candidate := fmt.Sprintf("%s-%d", name, i)
+		if names[candidate] {
+			continue
+		}
+		old, err := api.getImportedConfig(ns, candidate)
+		if err != nil {
+			return "", err
+		}
+		if old == nil {
+			if err = common.ValidateStruct(&models.ConfigurationView{Name: candidate}); err != nil {
+				return "", err
+			}
+			return candidate, nil
+		}
+	}
+	return "", common.Error(common.ErrRequestParamInvalid, common.Field("error", "failed to rename the config "+name))
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mf "github.com/baetyl/baetyl-cloud/v2/mock/facade"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initConfigArchiveAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) {
+		common.NewContext(c).SetNamespace("default")
+		common.NewContext(c).SetUser(common.User{ID: "default"})
+	}
+	v1 := router.Group("v1")
+	{
+		configs := v1.Group("/configs")
+		configs.GET("/export", mockIM, common.WrapperNative(api.ExportConfigs, true))
+		configs.POST("/import", mockIM, common.Wrapper(api.ImportConfigs))
+	}
+	return api, router, mockCtl
+}
+
+func newConfigImportRequest(t *testing.T, url, filename string, archive []byte) *http.Request {
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+	fw, err := w.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	fw.Write(archive)
+	assert.NoError(t, w.Close())
+	req, _ := http.NewRequest(http.MethodPost, url, buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestExportConfigs(t *testing.T) {
+	api, router, mockCtl := initConfigArchiveAPI(t)
+	defer mockCtl.Finish()
+	sConfig := ms.NewMockConfigService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{Config: sConfig}
+
+	sConfig.EXPECT().List("default", gomock.Any()).DoAndReturn(func(_ string, params *models.ListOptions) (*models.ConfigurationList, error) {
+		assert.Equal(t, "env=dev,!"+common.LabelSystem, params.LabelSelector)
+		return &models.ConfigurationList{Total: 1, Items: []specV1.Configuration{{
+			Name:      "web",
+			Namespace: "default",
+			Labels:    map[string]string{"env": "dev"},
+			Version:   "5",
+			Data: map[string]string{
+				"conf.yml":                               "level: debug",
+				common.ConfigTemplatePrefix + "node.yml": "name: {{.Name}}",
+			},
+		}}}, nil
+	})
+	req, _ := http.NewRequest(http.MethodGet, "/v1/configs/export?selector=env=dev", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/gzip", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "default-configs.tar.gz")
+
+	views, err := service.LoadConfigArchive(w.Body.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, views, 1)
+	assert.Equal(t, "web", views[0].Name)
+	assert.Empty(t, views[0].Namespace)
+	assert.Empty(t, views[0].Version)
+	assert.Len(t, views[0].Data, 2)
+}
+
+func TestImportConfigs(t *testing.T) {
+	api, router, mockCtl := initConfigArchiveAPI(t)
+	defer mockCtl.Finish()
+	sConfig, fConfig := ms.NewMockConfigService(mockCtl), mf.NewMockFacade(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{Config: sConfig}
+	api.Facade = fConfig
+
+	archive, err := service.PackConfigArchive([]models.ConfigurationView{
+		{Name: "db", Data: []models.ConfigDataItem{{Key: "a", Value: map[string]string{"type": ConfigTypeKV, "value": "new"}}}},
+		{Name: "web", Data: []models.ConfigDataItem{{Key: "b", Value: map[string]string{"type": ConfigTypeKV, "value": "b"}}}},
+	})
+	assert.NoError(t, err)
+	existing := &specV1.Configuration{Name: "db", Namespace: "default", Version: "7", Data: map[string]string{"a": "old"}}
+	notFound := common.Error(common.ErrResourceNotFound)
+
+	// skip by default
+	sConfig.EXPECT().Get("default", "db", "").Return(existing, nil)
+	sConfig.EXPECT().Get("default", "web", "").Return(nil, notFound)
+	fConfig.EXPECT().CreateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		assert.Equal(t, "web", cfg.Name)
+		assert.Equal(t, "default", cfg.Namespace)
+		assert.Equal(t, map[string]string{"b": "b"}, cfg.Data)
+		return cfg, nil
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newConfigImportRequest(t, "/v1/configs/import", "dev.tar.gz", archive))
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.ConfigImport)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, models.ConfigImportSkip, res.Strategy)
+	assert.Equal(t, []string{"web"}, res.Created)
+	assert.Equal(t, []string{"db"}, res.Skipped)
+
+	// overwrite
+	sConfig.EXPECT().Get("default", "db", "").Return(existing, nil)
+	sConfig.EXPECT().Get("default", "web", "").Return(&specV1.Configuration{Name: "web", Namespace: "default", Data: map[string]string{"b": "b"}}, nil)
+	fConfig.EXPECT().UpdateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		assert.Equal(t, "db", cfg.Name)
+		assert.Equal(t, "7", cfg.Version)
+		assert.Equal(t, map[string]string{"a": "new"}, cfg.Data)
+		return cfg, nil
+	})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newConfigImportRequest(t, "/v1/configs/import?strategy=overwrite", "dev.tgz", archive))
+	assert.Equal(t, http.StatusOK, w.Code)
+	res = new(models.ConfigImport)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, []string{"db"}, res.Updated)
+	assert.Equal(t, []string{"web"}, res.Skipped)
+
+	// rename
+	sConfig.EXPECT().Get("default", "db", "").Return(existing, nil)
+	sConfig.EXPECT().Get("default", "db-1", "").Return(existing, nil)
+	sConfig.EXPECT().Get("default", "db-2", "").Return(nil, notFound)
+	sConfig.EXPECT().Get("default", "web", "").Return(nil, notFound)
+	fConfig.EXPECT().CreateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		assert.Equal(t, "db-2", cfg.Name)
+		return cfg, nil
+	})
+	fConfig.EXPECT().CreateConfig("default", gomock.Any()).Return(nil, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newConfigImportRequest(t, "/v1/configs/import?strategy=rename", "dev.tgz", archive))
+	assert.Equal(t, http.StatusOK, w.Code)
+	res = new(models.ConfigImport)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, []string{"db-2", "web"}, res.Created)
+	assert.Equal(t, map[string]string{"db": "db-2"}, res.Renamed)
+
+	// the system configs are not overwritten
+	sConfig.EXPECT().Get("default", "db", "").Return(&specV1.Configuration{Name: "db", Labels: map[string]string{common.LabelSystem: "true"}}, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newConfigImportRequest(t, "/v1/configs/import?strategy=overwrite", "dev.tgz", archive))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newConfigImportRequest(t, "/v1/configs/import?strategy=merge", "dev.tgz", archive))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newConfigImportRequest(t, "/v1/configs/import", "dev.zip", archive))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
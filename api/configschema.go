@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// checkConfigSchema validates the kv items of config against its json schema, all errors of items are returned
+// with the json pointers of the invalid values, such as rules.json#/rules/0/threshold. The configs referencing
+// a schema config are validated when they are saved, not when the schema config changes
+func (api *API) checkConfigSchema(namespace string, cfg *specV1.Configuration) error {
+	raw, ok := cfg.Data[common.ConfigSchemaKey]
+	if !ok {
+		return nil
+	}
+	ref := new(models.ConfigSchema)
+	if err := json.Unmarshal([]byte(raw), ref); err != nil {
+		return errors.Trace(err)
+	}
+	data, err := api.loadConfigSchema(namespace, cfg, ref)
+	if err != nil {
+		return err
+	}
+	schema, err := service.ParseJSONSchema(data)
+	if err != nil {
+		return err
+	}
+
+	keys := ref.Keys
+	if len(keys) == 0 {
+		for k := range cfg.Data {
+			if isSchemaDocument(k) && !(ref.Key == k && (ref.Config == "" || ref.Config == cfg.Name)) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+	}
+	var errs common.ValidationErrors
+	for _, k := range keys {
+		v, ok := cfg.Data[k]
+		if !ok || k == common.ConfigSchemaKey {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the kv item "+k+" validated by schema is not found"))
+		}
+		doc, err := service.ParseSchemaDocument([]byte(v), isYAMLDocument(k))
+		if err != nil {
+			errs = append(errs, common.Error(common.ErrRequestParamInvalid, common.Field("error", k+": failed to parse: "+err.Error())))
+			continue
+		}
+		for _, msg := range schema.Validate(doc) {
+			errs = append(errs, common.Error(common.ErrRequestParamInvalid, common.Field("error", k+"#"+msg)))
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errs
+	}
+}
+
+// loadConfigSchema returns the schema inline, or the one stored as the kv item of config
+func (api *API) loadConfigSchema(namespace string, cfg *specV1.Configuration, ref *models.ConfigSchema) ([]byte, error) {
+	if len(ref.Inline) > 0 {
+		if ref.Config != "" || ref.Key != "" {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the schema should be either inline or in config"))
+		}
+		return ref.Inline, nil
+	}
+	if ref.Key == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the key of schema is required"))
+	}
+	data := cfg.Data
+	if ref.Config != "" && ref.Config != cfg.Name {
+		res, err := api.Config.Get(namespace, ref.Config, "")
+		if err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the schema config "+ref.Config+" is not found"))
+			}
+			return nil, err
+		}
+		data = res.Data
+	}
+	v, ok := data[ref.Key]
+	if !ok {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the schema "+ref.Key+" should be a kv item of config"))
+	}
+	return []byte(v), nil
+}
+
+func isSchemaDocument(key string) bool {
+	if strings.HasPrefix(key, common.ConfigObjectPrefix) || strings.HasPrefix(key, common.ConfigTemplatePrefix) {
+		return false
+	}
+	return strings.HasSuffix(key, ".json") || isYAMLDocument(key)
+}
+
+func isYAMLDocument(key string) bool {
+	ext := path.Ext(key)
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mf "github.com/baetyl/baetyl-cloud/v2/mock/facade"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+const testConfigSchema = `{"type":"object","required":["threshold"],"properties":{"threshold":{"type":"number","minimum":0}}}`
+
+func TestCreateConfigSchema(t *testing.T) {
+	api, router, mockCtl := initConfigAPI(t)
+	defer mockCtl.Finish()
+	sConfig, fConfig := ms.NewMockConfigService(mockCtl), mf.NewMockFacade(mockCtl)
+	api.Facade = fConfig
+	api.AppCombinedService = &service.AppCombinedService{Config: sConfig}
+
+	post := func(view *models.ConfigurationView) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(view)
+		req, _ := http.NewRequest(http.MethodPost, "/v1/configs", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	kv := func(key, value string) models.ConfigDataItem {
+		return models.ConfigDataItem{Key: key, Value: map[string]string{"type": ConfigTypeKV, "value": value}}
+	}
+
+	// the items in json and yaml are validated against the inline schema, the others are not
+	view := &models.ConfigurationView{
+		Name:   "rules",
+		Schema: &models.ConfigSchema{Inline: json.RawMessage(testConfigSchema)},
+		Data:   []models.ConfigDataItem{kv("rules.json", `{"threshold":1}`), kv("rules.yml", "threshold: 2"), kv("readme", "rules")},
+	}
+	sConfig.EXPECT().Get("default", "rules", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	fConfig.EXPECT().CreateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		assert.Contains(t, cfg.Data, common.ConfigSchemaKey)
+		assert.Len(t, cfg.Data, 4)
+		return cfg, nil
+	})
+	w := post(view)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.ConfigurationView)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Len(t, res.Data, 3)
+	assert.JSONEq(t, testConfigSchema, string(res.Schema.Inline))
+
+	// all errors of items are returned
+	view.Data = []models.ConfigDataItem{kv("rules.json", `{"threshold":-1}`), kv("rules.yml", "level: info"), kv("bad.json", "{")}
+	w = post(view)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	body := struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(t, body.Errors, 3)
+	assert.Contains(t, body.Errors[0].Message, "bad.json: failed to parse")
+	assert.Contains(t, body.Errors[1].Message, "rules.json#/threshold: the value should be >= 0")
+	assert.Contains(t, body.Errors[2].Message, `rules.yml#/: the property "threshold" is required`)
+
+	// the schema stored in another config validates the keys given
+	view.Schema = &models.ConfigSchema{Config: "rule-schema", Key: "schema.json", Keys: []string{"rules"}}
+	view.Data = []models.ConfigDataItem{kv("rules", `{"threshold":3}`), kv("bad.json", "{")}
+	sConfig.EXPECT().Get("default", "rule-schema", "").Return(&specV1.Configuration{Data: map[string]string{"schema.json": testConfigSchema}}, nil)
+	sConfig.EXPECT().Get("default", "rules", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	fConfig.EXPECT().CreateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		return cfg, nil
+	})
+	w = post(view)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the schema stored in the config itself is not validated
+	view.Schema = &models.ConfigSchema{Key: "schema.json"}
+	view.Data = []models.ConfigDataItem{kv("schema.json", testConfigSchema), kv("rules.json", `{"threshold":"3"}`)}
+	w = post(view)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	msg := struct {
+		Message string `json:"message"`
+	}{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &msg))
+	assert.Contains(t, msg.Message, "rules.json#/threshold: expected number, but got string")
+
+	sConfig.EXPECT().Get("default", "none", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	view.Schema = &models.ConfigSchema{Config: "none", Key: "schema.json"}
+	w = post(view)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	view.Schema = &models.ConfigSchema{Inline: json.RawMessage(`{"pattern":"["}`)}
+	w = post(view)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	view.Schema = nil
+	view.Data = []models.ConfigDataItem{kv(common.ConfigSchemaKey, testConfigSchema)}
+	w = post(view)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
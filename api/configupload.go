@@ -0,0 +1,101 @@
+package api
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/utils"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// the memory used to parse the multipart form, the larger files are buffered in temporary files
+const maxConfigFormMemory = 32 << 20
+
+// loadConfigForm loads the config view from the multipart form, which has the fields:
+//   - config: the config view in json, optional
+//   - file: the files uploaded as the kv items of config, keyed by their filenames
+//   - checksums: the md5 or sha256 in hex of files keyed by the item keys in json, which are verified if present
+//
+// The binary files are stored in object storage like the large items
+func (api *API) loadConfigForm(c *common.Context, view *models.ConfigurationView) ([]models.ConfigUpload, error) {
+	if err := c.Request.ParseMultipartForm(maxConfigFormMemory); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if data := c.Request.FormValue("config"); data != "" {
+		if err := json.Unmarshal([]byte(data), view); err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "failed to parse config: "+err.Error()))
+		}
+	}
+	checksums := map[string]string{}
+	if data := c.Request.FormValue("checksums"); data != "" {
+		if err := json.Unmarshal([]byte(data), &checksums); err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "failed to parse checksums: "+err.Error()))
+		}
+	}
+
+	keys := map[string]bool{}
+	for _, item := range view.Data {
+		keys[item.Key] = true
+	}
+	var uploads []models.ConfigUpload
+	for _, header := range c.Request.MultipartForm.File["file"] {
+		// the path of file is dropped, which is sent by the browsers on windows
+		key := path.Base(strings.ReplaceAll(header.Filename, "\\", "/"))
+		if keys[key] {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the key "+key+" of config is duplicated"))
+		}
+		keys[key] = true
+		if api.ConfigObject != nil {
+			if err := api.ConfigObject.CheckUpload(header.Filename, header.Size); err != nil {
+				return nil, err
+			}
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		data, err := ioutil.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		md5Sum, sha256Sum := md5.Sum(data), sha256.Sum256(data)
+		upload := models.ConfigUpload{
+			Key:         key,
+			Filename:    header.Filename,
+			ContentType: header.Header.Get("Content-Type"),
+			Size:        int64(len(data)),
+			MD5:         hex.EncodeToString(md5Sum[:]),
+			SHA256:      hex.EncodeToString(sha256Sum[:]),
+		}
+		if sum, ok := checksums[key]; ok {
+			if sum = strings.ToLower(sum); sum != upload.MD5 && sum != upload.SHA256 {
+				return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the checksum of file "+header.Filename+" does not match"))
+			}
+			delete(checksums, key)
+		}
+		uploads = append(uploads, upload)
+		view.Data = append(view.Data, models.ConfigDataItem{
+			Key:   key,
+			Value: map[string]string{"type": ConfigTypeKV, "value": string(data)},
+		})
+	}
+	for key := range checksums {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the file of checksum "+key+" is not uploaded"))
+	}
+	if err := common.ValidateStruct(view); err != nil {
+		return nil, err
+	}
+	if err := utils.SetDefaults(view); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return uploads, nil
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+const (
+	CronJobConcurrencyAllow   = "Allow"
+	CronJobConcurrencyForbid  = "Forbid"
+	CronJobConcurrencyReplace = "Replace"
+
+	defaultSuccessfulJobsHistoryLimit int32 = 3
+	defaultFailedJobsHistoryLimit     int32 = 1
+)
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	// both 0 and 7 are sunday
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+var cronDescriptors = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// checkAppCronJob checks the schedule of app in cronjob workload and fills its defaults,
+// the jobs scheduled should not be restarted always
+func checkAppCronJob(app *models.ApplicationView) error {
+	if app.Workload != common.WorkloadCronJob {
+		if app.CronJob != nil {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "cronJob is only supported by the app in cronjob workload"))
+		}
+		return nil
+	}
+	cron := app.CronJob
+	if cron == nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "cronJob is required by the app in cronjob workload"))
+	}
+	if err := validateCronSchedule(cron.Schedule); err != nil {
+		return err
+	}
+	switch cron.ConcurrencyPolicy {
+	case "":
+		cron.ConcurrencyPolicy = CronJobConcurrencyAllow
+	case CronJobConcurrencyAllow, CronJobConcurrencyForbid, CronJobConcurrencyReplace:
+	default:
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error",
+			"concurrencyPolicy should be Allow / Forbid / Replace"))
+	}
+	if cron.StartingDeadlineSeconds != nil && *cron.StartingDeadlineSeconds <= 0 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "startingDeadlineSeconds should be positive"))
+	}
+	if cron.SuccessfulJobsHistoryLimit == nil {
+		limit := defaultSuccessfulJobsHistoryLimit
+		cron.SuccessfulJobsHistoryLimit = &limit
+	} else if *cron.SuccessfulJobsHistoryLimit < 0 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "successfulJobsHistoryLimit should not be negative"))
+	}
+	if cron.FailedJobsHistoryLimit == nil {
+		limit := defaultFailedJobsHistoryLimit
+		cron.FailedJobsHistoryLimit = &limit
+	} else if *cron.FailedJobsHistoryLimit < 0 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "failedJobsHistoryLimit should not be negative"))
+	}
+	if app.JobConfig != nil && app.JobConfig.RestartPolicy == "Always" {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "restartPolicy of cronjob should be Never / OnFailure"))
+	}
+	return nil
+}
+
+// validateCronSchedule validates the schedule in standard cron format of 5 fields,
+// the predefined schedules such as @daily and @every <duration> are also supported
+func validateCronSchedule(schedule string) error {
+	schedule = strings.TrimSpace(schedule)
+	if schedule == "" {
+		return cronError("schedule is required")
+	}
+	if strings.HasPrefix(schedule, "@") {
+		if cronDescriptors[schedule] {
+			return nil
+		}
+		if strings.HasPrefix(schedule, "@every ") {
+			d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(schedule, "@every ")))
+			if err != nil || d <= 0 {
+				return cronError(fmt.Sprintf("the duration of schedule %s is invalid", schedule))
+			}
+			return nil
+		}
+		return cronError(fmt.Sprintf("the schedule %s is not supported", schedule))
+	}
+	parts := strings.Fields(schedule)
+	if len(parts) != len(cronFields) {
+		return cronError(fmt.Sprintf("the schedule %s should contain %d fields", schedule, len(cronFields)))
+	}
+	for i, part := range parts {
+		for _, item := range strings.Split(part, ",") {
+			if err := cronFields[i].validate(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (f cronField) validate(item string) error {
+	rng, step := item, ""
+	if i := strings.Index(item, "/"); i >= 0 {
+		rng, step = item[:i], item[i+1:]
+		if n, err := strconv.Atoi(step); err != nil || n <= 0 {
+			return cronError(fmt.Sprintf("the step %s of %s is invalid", item, f.name))
+		}
+	}
+	if rng == "*" || (rng == "?" && (f.name == "day of month" || f.name == "day of week")) {
+		return nil
+	}
+	bounds := strings.SplitN(rng, "-", 2)
+	start, err := f.value(bounds[0])
+	if err != nil {
+		return err
+	}
+	if len(bounds) == 1 {
+		return nil
+	}
+	end, err := f.value(bounds[1])
+	if err != nil {
+		return err
+	}
+	if start > end {
+		return cronError(fmt.Sprintf("the range %s of %s is invalid", rng, f.name))
+	}
+	return nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, cronError(fmt.Sprintf("the value %s of %s should be in range [%d, %d]", s, f.name, f.min, f.max))
+	}
+	return v, nil
+}
+
+func cronError(msg string) error {
+	return common.Error(common.ErrRequestParamInvalid, common.Field("error", msg))
+}
+
+// translateCronJob generates the config holding the schedule of app in cronjob workload,
+// which is referenced by the volume of app so that node runs the jobs on schedule
+func translateCronJob(appView *models.ApplicationView, app, oldApp *specV1.Application) (*specV1.Configuration, error) {
+	for i, v := range app.Volumes {
+		if v.Name == CronJobConfigPrefix {
+			app.Volumes = append(app.Volumes[:i:i], app.Volumes[i+1:]...)
+			break
+		}
+	}
+	if app.Workload != common.WorkloadCronJob || appView.CronJob == nil {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(appView.CronJob)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	name := strings.ToLower(fmt.Sprintf("%s-%s-%s", CronJobConfigPrefix, app.Name, common.RandString(9)))
+	if oldApp != nil {
+		for _, v := range oldApp.Volumes {
+			if v.Name == CronJobConfigPrefix && v.Config != nil {
+				name = v.Config.Name
+				break
+			}
+		}
+	}
+	_, volume := generateVmAndMount(name, CronJobConfigPrefix, "")
+	app.Volumes = append(app.Volumes, volume)
+	return &specV1.Configuration{
+		Name:      name,
+		Namespace: app.Namespace,
+		Labels: map[string]string{
+			common.LabelSystem: "true",
+		},
+		Data: map[string]string{
+			CronJobConfigFile: string(data),
+		},
+	}, nil
+}
+
+// translateToCronJobView reads the schedule of app from its generated config,
+// the volume referencing the config is hidden from the view
+func (api *API) translateToCronJobView(appView *models.ApplicationView) error {
+	for i, v := range appView.Volumes {
+		if v.Name != CronJobConfigPrefix || v.Config == nil {
+			continue
+		}
+		appView.Volumes = append(appView.Volumes[:i:i], appView.Volumes[i+1:]...)
+		cfg, err := api.Config.Get(appView.Namespace, v.Config.Name, "")
+		if err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				return nil
+			}
+			return err
+		}
+		cron := new(models.CronJobConfig)
+		if err = yaml.Unmarshal([]byte(cfg.Data[CronJobConfigFile]), cron); err != nil {
+			return errors.Trace(err)
+		}
+		appView.CronJob = cron
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func TestValidateCronSchedule(t *testing.T) {
+	for _, s := range []string{
+		"*/10 * * * *", "0 3 * * 1-5", "0,30 8-18/2 1,15 jan-jun MON", "0 0 ? * sun,7",
+		"@hourly", "@daily", "@every 1h30m",
+	} {
+		assert.NoError(t, validateCronSchedule(s), s)
+	}
+	for _, s := range []string{
+		"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *",
+		"* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *", "? * * * *", "@reboot", "@every 1x", "@every -1s",
+	} {
+		assert.Error(t, validateCronSchedule(s), s)
+	}
+}
+
+func TestCheckAppCronJob(t *testing.T) {
+	app := &models.ApplicationView{Workload: common.WorkloadCronJob}
+	assert.Error(t, checkAppCronJob(app))
+
+	app.CronJob = &models.CronJobConfig{Schedule: "*/5 * * * *"}
+	assert.NoError(t, checkAppCronJob(app))
+	assert.Equal(t, CronJobConcurrencyAllow, app.CronJob.ConcurrencyPolicy)
+	assert.Equal(t, int32(3), *app.CronJob.SuccessfulJobsHistoryLimit)
+	assert.Equal(t, int32(1), *app.CronJob.FailedJobsHistoryLimit)
+
+	negative, deadline := int32(-1), int64(0)
+	for _, cron := range []*models.CronJobConfig{
+		{Schedule: "* * *"},
+		{Schedule: "@daily", ConcurrencyPolicy: "Queue"},
+		{Schedule: "@daily", StartingDeadlineSeconds: &deadline},
+		{Schedule: "@daily", SuccessfulJobsHistoryLimit: &negative},
+		{Schedule: "@daily", FailedJobsHistoryLimit: &negative},
+	} {
+		app.CronJob = cron
+		assert.Error(t, checkAppCronJob(app))
+	}
+
+	app.CronJob = &models.CronJobConfig{Schedule: "@daily", ConcurrencyPolicy: CronJobConcurrencyForbid}
+	app.JobConfig = &specV1.AppJobConfig{RestartPolicy: "Always"}
+	assert.Error(t, checkAppCronJob(app))
+	app.JobConfig.RestartPolicy = "OnFailure"
+	assert.NoError(t, checkAppCronJob(app))
+
+	// the schedule is only for cronjob
+	app = &models.ApplicationView{Workload: specV1.WorkloadJob, CronJob: &models.CronJobConfig{Schedule: "@daily"}}
+	assert.Error(t, checkAppCronJob(app))
+	app.CronJob = nil
+	assert.NoError(t, checkAppCronJob(app))
+}
+
+func TestTranslateCronJob(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sConfig := ms.NewMockConfigService(mockCtl)
+	api := &API{AppCombinedService: &service.AppCombinedService{Config: sConfig}}
+
+	limit := int32(2)
+	appView := &models.ApplicationView{
+		Name:      "report",
+		Namespace: "baetyl-cloud",
+		Mode:      context.RunModeKube,
+		Type:      common.ContainerApp,
+		Workload:  common.WorkloadCronJob,
+		Volumes:   []models.VolumeView{{Name: "data", EmptyDir: &specV1.EmptyDirVolumeSource{}}},
+		CronJob: &models.CronJobConfig{
+			Schedule:                   "0 * * * *",
+			ConcurrencyPolicy:          CronJobConcurrencyReplace,
+			SuccessfulJobsHistoryLimit: &limit,
+		},
+	}
+	app, configs, err := api.ToApplication(appView, nil)
+	assert.NoError(t, err)
+	assert.Len(t, configs, 1)
+	cfg := configs[0]
+	assert.True(t, strings.HasPrefix(cfg.Name, "baetyl-cronjob-config-report-"))
+	assert.Equal(t, "true", cfg.Labels[common.LabelSystem])
+	assert.Contains(t, cfg.Data[CronJobConfigFile], "schedule: 0 * * * *")
+	assert.Contains(t, cfg.Data[CronJobConfigFile], "concurrencyPolicy: Replace")
+	assert.Len(t, app.Volumes, 2)
+	assert.Equal(t, CronJobConfigPrefix, app.Volumes[1].Name)
+	assert.Equal(t, cfg.Name, app.Volumes[1].Config.Name)
+
+	// the name of config is kept on update
+	appView.CronJob.Schedule = "@daily"
+	updated, configs, err := api.ToApplication(appView, app)
+	assert.NoError(t, err)
+	assert.Len(t, configs, 1)
+	assert.Equal(t, cfg.Name, configs[0].Name)
+	assert.Len(t, updated.Volumes, 2)
+
+	// the schedule is read back from the config, and the volume is hidden
+	sConfig.EXPECT().Get("baetyl-cloud", cfg.Name, "").Return(&configs[0], nil)
+	view, err := api.ToApplicationView(updated)
+	assert.NoError(t, err)
+	assert.Equal(t, "@daily", view.CronJob.Schedule)
+	assert.Equal(t, CronJobConcurrencyReplace, view.CronJob.ConcurrencyPolicy)
+	assert.Equal(t, int32(2), *view.CronJob.SuccessfulJobsHistoryLimit)
+	assert.Equal(t, []models.VolumeView{{Name: "data", EmptyDir: &specV1.EmptyDirVolumeSource{}}}, view.Volumes)
+
+	sConfig.EXPECT().Get("baetyl-cloud", cfg.Name, "").Return(nil, common.Error(common.ErrResourceNotFound))
+	view, err = api.ToApplicationView(updated)
+	assert.NoError(t, err)
+	assert.Nil(t, view.CronJob)
+
+	// the config is no longer generated when the workload changes
+	appView.Workload, appView.CronJob = specV1.WorkloadJob, nil
+	updated, configs, err = api.ToApplication(appView, updated)
+	assert.NoError(t, err)
+	assert.Empty(t, configs)
+	assert.Len(t, updated.Volumes, 1)
+}
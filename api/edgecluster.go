@@ -0,0 +1,221 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	v1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/baetyl/baetyl-go/v2/utils"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetEdgeCluster get the edge cluster with the status aggregated from its members
+func (api *API) GetEdgeCluster(c *common.Context) (interface{}, error) {
+	cluster, err := api.EdgeCluster.Get(c.GetNamespace(), c.GetNameFromParam())
+	if err != nil {
+		return nil, err
+	}
+	return api.toEdgeClusterView(cluster)
+}
+
+// ListEdgeCluster list edge clusters
+func (api *API) ListEdgeCluster(c *common.Context) (interface{}, error) {
+	params, err := api.ParseListOptions(c)
+	if err != nil {
+		return nil, err
+	}
+	list, err := api.EdgeCluster.List(c.GetNamespace(), params)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]models.EdgeClusterView, 0, len(list.Items))
+	for i := range list.Items {
+		view, err := api.toEdgeClusterView(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *view)
+	}
+	return api.ToListResponse(list.Total, items, params), nil
+}
+
+// CreateEdgeCluster create an edge cluster and the node of its core, the cluster is activated by the init command of node
+func (api *API) CreateEdgeCluster(c *common.Context) (interface{}, error) {
+	cluster, err := parseAndCheckEdgeCluster(c)
+	if err != nil {
+		return nil, err
+	}
+	old, err := api.EdgeCluster.Get(cluster.Namespace, cluster.Name)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+	}
+	if old != nil {
+		return nil, common.Error(common.ErrResourceConflict, common.Field("type", "edgecluster"), common.Field("name", cluster.Name))
+	}
+
+	n := &v1.Node{}
+	if err = utils.SetDefaults(n); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	n.Name, n.Description = cluster.Node, cluster.Description
+	n.Cluster, n.NodeMode = true, context.RunModeKube
+	n.Labels = map[string]string{common.LabelEdgeCluster: cluster.Name}
+	if err = api.checkBatchNode(n); err != nil {
+		return nil, err
+	}
+	if _, err = api.createNode(c, n); err != nil {
+		return nil, err
+	}
+	if c.IsDryRun() {
+		return &models.EdgeClusterView{EdgeCluster: *cluster}, nil
+	}
+	res, err := api.EdgeCluster.Create(cluster)
+	if err != nil {
+		if _, e := api.deleteNode(c, n); e != nil {
+			log.L().Error("failed to delete the node of edge cluster", log.Any("name", n.Name), log.Error(e))
+		}
+		return nil, err
+	}
+	return api.toEdgeClusterView(res)
+}
+
+// UpdateEdgeCluster update the description and labels of edge cluster
+func (api *API) UpdateEdgeCluster(c *common.Context) (interface{}, error) {
+	cluster, err := parseAndCheckEdgeCluster(c)
+	if err != nil {
+		return nil, err
+	}
+	old, err := api.EdgeCluster.Get(cluster.Namespace, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+	cluster.Node, cluster.CreateTime = old.Node, old.CreateTime
+	if c.IsDryRun() {
+		return api.toEdgeClusterView(cluster)
+	}
+	res, err := api.EdgeCluster.Update(cluster)
+	if err != nil {
+		return nil, err
+	}
+	c.SetAuditDiff(old, res)
+	return api.toEdgeClusterView(res)
+}
+
+// DeleteEdgeCluster delete the edge cluster which no application is deployed to, the node of cluster is deleted too
+func (api *API) DeleteEdgeCluster(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	cluster, err := api.EdgeCluster.Get(ns, name)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	apps, err := api.App.List(ns, &models.ListOptions{LabelSelector: common.LabelEdgeCluster + "=" + name})
+	if err != nil {
+		return nil, err
+	}
+	if len(apps.Items) > 0 {
+		return nil, common.Error(common.ErrResourceDeleteForbidden, common.Field("type", "edgecluster"), common.Field("name", name))
+	}
+	node, err := api.Node.Get(nil, ns, cluster.Node)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+	}
+	if node != nil {
+		if _, err = api.deleteNode(c, node); err != nil {
+			return nil, err
+		}
+	}
+	return nil, api.EdgeCluster.Delete(ns, name)
+}
+
+// GetEdgeClusterInitCmd get the command to install the core on the master of edge cluster
+func (api *API) GetEdgeClusterInitCmd(c *common.Context) (interface{}, error) {
+	ns := c.GetNamespace()
+	cluster, err := api.EdgeCluster.Get(ns, c.GetNameFromParam())
+	if err != nil {
+		return nil, err
+	}
+	template := service.TemplateBaetylInitCommand
+	if c.Query("method") == MethodWget {
+		template = service.TemplateInitCommandWget
+	}
+	cmd, err := api.genInitCmd(ns, cluster.Node, context.RunModeKube, template, "")
+	if err != nil {
+		return nil, err
+	}
+	return models.InitCMD{CMD: cmd}, nil
+}
+
+func (api *API) toEdgeClusterView(cluster *models.EdgeCluster) (*models.EdgeClusterView, error) {
+	res := &models.EdgeClusterView{EdgeCluster: *cluster}
+	node, err := api.Node.Get(nil, cluster.Namespace, cluster.Node)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return res, nil
+		}
+		return nil, err
+	}
+	view, err := api.ToNodeView(node)
+	if err != nil {
+		return nil, err
+	}
+	res.Status = service.EdgeClusterStatus(node, view.Ready == v1.NodeOnline)
+	return res, nil
+}
+
+// applyEdgeCluster deploys the application targeting the edge cluster to the node of cluster,
+// the services are scheduled to the members by the cluster itself
+func (api *API) applyEdgeCluster(appView *models.ApplicationView) error {
+	if appView.EdgeCluster == "" {
+		delete(appView.Labels, common.LabelEdgeCluster)
+		return nil
+	}
+	if appView.NodeGroup != "" {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "either nodeGroup or edgeCluster can be set"))
+	}
+	if appView.Mode != context.RunModeKube {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the application of edge cluster should run in kube mode"))
+	}
+	cluster, err := api.EdgeCluster.Get(appView.Namespace, appView.EdgeCluster)
+	if err != nil {
+		return err
+	}
+	if appView.Labels == nil {
+		appView.Labels = map[string]string{}
+	}
+	appView.Labels[common.LabelEdgeCluster] = cluster.Name
+	appView.Selector = fmt.Sprintf("%s=%s", common.LabelNodeName, cluster.Node)
+	return nil
+}
+
+func parseAndCheckEdgeCluster(c *common.Context) (*models.EdgeCluster, error) {
+	cluster := new(models.EdgeCluster)
+	if err := c.LoadBody(cluster); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	cluster.Namespace = c.GetNamespace()
+	if name := c.GetNameFromParam(); name != "" {
+		cluster.Name = name
+	}
+	if cluster.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	cluster.Node = cluster.Name
+	for k := range cluster.Labels {
+		if isReservedNodeLabel(k) {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("label %s is reserved", k)))
+		}
+	}
+	return cluster, nil
+}
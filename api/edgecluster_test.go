@@ -0,0 +1,218 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initEdgeClusterAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	api.AppCombinedService = &service.AppCombinedService{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		clusters := v1.Group("/edgeclusters")
+		clusters.GET("/:name", mockIM, common.Wrapper(api.GetEdgeCluster))
+		clusters.GET("/:name/init", mockIM, common.Wrapper(api.GetEdgeClusterInitCmd))
+		clusters.PUT("/:name", mockIM, common.Wrapper(api.UpdateEdgeCluster))
+		clusters.DELETE("/:name", mockIM, common.Wrapper(api.DeleteEdgeCluster))
+		clusters.POST("", mockIM, common.Wrapper(api.CreateEdgeCluster))
+		clusters.GET("", mockIM, common.Wrapper(api.ListEdgeCluster))
+	}
+	return api, router, mockCtl
+}
+
+func genEdgeClusterNode() *specV1.Node {
+	return &specV1.Node{
+		Namespace: "default",
+		Name:      "c1",
+		Cluster:   true,
+		Labels:    map[string]string{common.LabelEdgeCluster: "c1"},
+		Attributes: map[string]interface{}{
+			specV1.BaetylCoreFrequency: common.DefaultCoreFrequency,
+		},
+		Report: specV1.Report{
+			common.NodeInfo: map[string]interface{}{
+				"master":  map[string]interface{}{"labels": map[string]string{"node-role.kubernetes.io/master": "true"}},
+				"worker1": map[string]interface{}{},
+			},
+			common.NodeStats: map[string]interface{}{
+				"master": map[string]interface{}{"capacity": map[string]string{"cpu": "4"}},
+			},
+		},
+	}
+}
+
+func TestCreateEdgeCluster(t *testing.T) {
+	api, router, mockCtl := initEdgeClusterAPI(t)
+	defer mockCtl.Finish()
+	mLicense, sNode, sModule, sCluster := ms.NewMockLicenseService(mockCtl), ms.NewMockNodeService(mockCtl),
+		ms.NewMockModuleService(mockCtl), ms.NewMockEdgeClusterService(mockCtl)
+	api.License, api.Node, api.Module, api.EdgeCluster = mLicense, sNode, sModule, sCluster
+	cfg := &config.CloudConfig{}
+	cfg.Plugin.Tx = "defaulttx"
+	api.Wrapper, _ = service.NewWrapperService(cfg)
+
+	cluster := &models.EdgeCluster{Namespace: "default", Name: "c1", Node: "c1", Description: "k3s", Labels: map[string]string{"city": "bj"}}
+	node := genEdgeClusterNode()
+	sCluster.EXPECT().Get("default", "c1").Return(nil, common.Error(common.ErrResourceNotFound))
+	sModule.EXPECT().GetLatestModule(gomock.Any()).Return(&models.Module{Name: "baetyl", Version: "2.1.2"}, nil).AnyTimes()
+	sNode.EXPECT().Get(nil, "default", "c1").Return(nil, nil)
+	mLicense.EXPECT().AcquireQuota("default", plugin.QuotaNode, 1).Return(nil)
+	sNode.EXPECT().Create(gomock.Any(), "default", gomock.Any()).DoAndReturn(func(_ interface{}, _ string, n *specV1.Node) (*specV1.Node, error) {
+		assert.True(t, n.Cluster)
+		assert.Equal(t, "kube", n.NodeMode)
+		assert.Equal(t, "c1", n.Labels[common.LabelEdgeCluster])
+		assert.Equal(t, "k3s", n.Description)
+		return node, nil
+	})
+	sCluster.EXPECT().Create(cluster).Return(cluster, nil)
+	sNode.EXPECT().Get(nil, "default", "c1").Return(node, nil)
+	body, _ := json.Marshal(&models.EdgeCluster{Name: "c1", Description: "k3s", Labels: map[string]string{"city": "bj"}})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/edgeclusters", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.EdgeClusterView)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, "c1", res.Node)
+	assert.Equal(t, 2, res.Status.Total)
+	assert.False(t, res.Status.Online)
+
+	// conflict
+	sCluster.EXPECT().Get("default", "c1").Return(cluster, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/edgeclusters", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), common.ErrResourceConflict)
+
+	// reserved label
+	body, _ = json.Marshal(&models.EdgeCluster{Name: "c2", Labels: map[string]string{common.LabelEdgeCluster: "c3"}})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/edgeclusters", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetAndListEdgeCluster(t *testing.T) {
+	api, router, mockCtl := initEdgeClusterAPI(t)
+	defer mockCtl.Finish()
+	sNode, sCluster := ms.NewMockNodeService(mockCtl), ms.NewMockEdgeClusterService(mockCtl)
+	api.Node, api.EdgeCluster = sNode, sCluster
+
+	cluster := &models.EdgeCluster{Namespace: "default", Name: "c1", Node: "c1"}
+	sCluster.EXPECT().Get("default", "c1").Return(cluster, nil)
+	sNode.EXPECT().Get(nil, "default", "c1").Return(genEdgeClusterNode(), nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/edgeclusters/c1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.EdgeClusterView)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, 2, res.Status.Total)
+	assert.Equal(t, service.EdgeClusterRoleMaster, res.Status.Members[0].Role)
+	assert.Equal(t, "4", res.Status.Capacity["cpu"])
+
+	// the cluster is listed without status if its node is missing
+	params := &models.ListOptions{}
+	sCluster.EXPECT().List("default", gomock.Any()).Return(&models.EdgeClusterList{Total: 1, ListOptions: params, Items: []models.EdgeCluster{*cluster}}, nil)
+	sNode.EXPECT().Get(nil, "default", "c1").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/edgeclusters", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\"total\":1")
+	assert.Contains(t, w.Body.String(), "\"name\":\"c1\"")
+}
+
+func TestDeleteEdgeCluster(t *testing.T) {
+	api, router, mockCtl := initEdgeClusterAPI(t)
+	defer mockCtl.Finish()
+	sNode, sCluster, sApp, sIndex, mLicense := ms.NewMockNodeService(mockCtl), ms.NewMockEdgeClusterService(mockCtl),
+		ms.NewMockApplicationService(mockCtl), ms.NewMockIndexService(mockCtl), ms.NewMockLicenseService(mockCtl)
+	api.Node, api.EdgeCluster, api.App, api.Index, api.License = sNode, sCluster, sApp, sIndex, mLicense
+
+	cluster := &models.EdgeCluster{Namespace: "default", Name: "c1", Node: "c1"}
+	selector := &models.ListOptions{LabelSelector: common.LabelEdgeCluster + "=c1"}
+	sCluster.EXPECT().Get("default", "c1").Return(cluster, nil)
+	sApp.EXPECT().List("default", selector).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "app1"}}}, nil)
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/edgeclusters/c1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), common.ErrResourceDeleteForbidden)
+
+	node := genEdgeClusterNode()
+	sCluster.EXPECT().Get("default", "c1").Return(cluster, nil)
+	sApp.EXPECT().List("default", selector).Return(&models.ApplicationList{}, nil)
+	sNode.EXPECT().Get(nil, "default", "c1").Return(node, nil)
+	sNode.EXPECT().Delete("default", node).Return(nil)
+	mLicense.EXPECT().ReleaseQuota("default", plugin.QuotaNode, 1).Return(nil)
+	sCluster.EXPECT().Delete("default", "c1").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/edgeclusters/c1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sCluster.EXPECT().Get("default", "c2").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/edgeclusters/c2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestApplyEdgeCluster(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sCluster := ms.NewMockEdgeClusterService(mockCtl)
+	api := &API{EdgeCluster: sCluster}
+
+	app := &models.ApplicationView{Namespace: "default", Name: "app1", Mode: "kube", Labels: map[string]string{common.LabelEdgeCluster: "c1"}}
+	assert.NoError(t, api.applyEdgeCluster(app))
+	assert.NotContains(t, app.Labels, common.LabelEdgeCluster)
+
+	app.EdgeCluster = "c1"
+	sCluster.EXPECT().Get("default", "c1").Return(&models.EdgeCluster{Namespace: "default", Name: "c1", Node: "c1"}, nil)
+	assert.NoError(t, api.applyEdgeCluster(app))
+	assert.Equal(t, "c1", app.Labels[common.LabelEdgeCluster])
+	assert.Equal(t, common.LabelNodeName+"=c1", app.Selector)
+
+	app.NodeGroup = "g1"
+	assert.Error(t, api.applyEdgeCluster(app))
+	app.NodeGroup, app.Mode = "", "native"
+	assert.Error(t, api.applyEdgeCluster(app))
+}
+
+func TestDeleteNodeOfEdgeCluster(t *testing.T) {
+	api, router, mockCtl := initNodeAPI(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+
+	// the node is deleted with its edge cluster
+	sNode.EXPECT().Get(nil, "default", "c1").Return(genEdgeClusterNode(), nil)
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/nodes/c1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), common.ErrResourceDeleteForbidden)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"reflect"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetEnvGroup get the env group
+func (api *API) GetEnvGroup(c *common.Context) (interface{}, error) {
+	return api.EnvGroup.Get(c.GetNamespace(), c.GetNameFromParam())
+}
+
+// ListEnvGroup list the env groups of namespace
+func (api *API) ListEnvGroup(c *common.Context) (interface{}, error) {
+	groups, err := api.EnvGroup.List(c.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(groups), groups, ""), nil
+}
+
+// CreateEnvGroup create the env group
+func (api *API) CreateEnvGroup(c *common.Context) (interface{}, error) {
+	group, err := api.parseEnvGroup(c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = api.EnvGroup.Get(group.Namespace, group.Name); err == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "this name is already in use"))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, err
+	}
+	if c.IsDryRun() {
+		return group, nil
+	}
+	return api.EnvGroup.Create(group)
+}
+
+// UpdateEnvGroup updates the env group, the apps referencing it are saved again
+// so that the nodes render them with the new variables
+func (api *API) UpdateEnvGroup(c *common.Context) (interface{}, error) {
+	group, err := api.parseEnvGroup(c)
+	if err != nil {
+		return nil, err
+	}
+	old, err := api.EnvGroup.Get(group.Namespace, group.Name)
+	if err != nil {
+		return nil, err
+	}
+	if old.Description == group.Description && reflect.DeepEqual(old.Envs, group.Envs) {
+		return old, nil
+	}
+	if c.IsDryRun() {
+		group.CreateTime = old.CreateTime
+		return group, nil
+	}
+	res, err := api.EnvGroup.Update(group)
+	if err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(old.Envs, group.Envs) {
+		if err = api.refreshEnvGroupApps(group.Namespace, group.Name); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// DeleteEnvGroup deletes the env group which is referenced by no app
+func (api *API) DeleteEnvGroup(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.EnvGroup.Get(ns, name); err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	apps, err := api.App.List(ns, &models.ListOptions{LabelSelector: common.LabelPrefixEnvGroup + name})
+	if err != nil {
+		return nil, err
+	}
+	if len(apps.Items) > 0 {
+		return nil, common.Error(common.ErrResourceHasBeenUsed, common.Field("type", "env group"), common.Field("name", name))
+	}
+	return nil, api.EnvGroup.Delete(ns, name)
+}
+
+// GetAppByEnvGroup list the apps referencing the env group
+func (api *API) GetAppByEnvGroup(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.EnvGroup.Get(ns, name); err != nil {
+		return nil, err
+	}
+	return api.App.List(ns, &models.ListOptions{LabelSelector: common.LabelPrefixEnvGroup + name})
+}
+
+func (api *API) parseEnvGroup(c *common.Context) (*models.EnvGroup, error) {
+	group := new(models.EnvGroup)
+	group.Name = c.GetNameFromParam()
+	if err := c.LoadBody(group); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if name := c.GetNameFromParam(); name != "" {
+		group.Name = name
+	}
+	if group.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	group.Namespace = c.GetNamespace()
+	names := map[string]bool{}
+	for _, env := range group.Envs {
+		if err := validateKeyValue(env.Name); err != nil {
+			return nil, err
+		}
+		if names[env.Name] {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "env "+env.Name+" is duplicated"))
+		}
+		names[env.Name] = true
+	}
+	return group, nil
+}
+
+// applyAppEnvGroups marks the env groups referenced in the labels of application, they should exist in the same namespace
+func (api *API) applyAppEnvGroups(appView *models.ApplicationView) error {
+	appView.Labels = service.SetAppEnvGroups(appView.Labels, nil)
+	if len(appView.EnvGroups) == 0 {
+		return nil
+	}
+	for _, name := range appView.EnvGroups {
+		if _, err := api.EnvGroup.Get(appView.Namespace, name); err != nil {
+			return err
+		}
+	}
+	appView.Labels = service.SetAppEnvGroups(appView.Labels, appView.EnvGroups)
+	return nil
+}
+
+// refreshEnvGroupApps saves the apps referencing the env group again for their new versions,
+// and updates the versions desired by their nodes
+func (api *API) refreshEnvGroupApps(ns, name string) error {
+	apps, err := api.App.List(ns, &models.ListOptions{LabelSelector: common.LabelPrefixEnvGroup + name})
+	if err != nil {
+		return err
+	}
+	for _, item := range apps.Items {
+		app, err := api.App.Get(ns, item.Name, "")
+		if err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				continue
+			}
+			return err
+		}
+		if app, err = api.App.Update(nil, ns, app); err != nil {
+			return err
+		}
+		if _, err = api.Node.UpdateNodeAppVersion(nil, ns, app); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initEnvGroupAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		envGroups := v1.Group("/envgroups")
+		envGroups.GET("", mockIM, common.Wrapper(api.ListEnvGroup))
+		envGroups.GET("/:name", mockIM, common.Wrapper(api.GetEnvGroup))
+		envGroups.GET("/:name/apps", mockIM, common.Wrapper(api.GetAppByEnvGroup))
+		envGroups.POST("", mockIM, common.Wrapper(api.CreateEnvGroup))
+		envGroups.PUT("/:name", mockIM, common.Wrapper(api.UpdateEnvGroup))
+		envGroups.DELETE("/:name", mockIM, common.Wrapper(api.DeleteEnvGroup))
+	}
+	return api, router, mockCtl
+}
+
+func TestCreateEnvGroup(t *testing.T) {
+	api, router, mockCtl := initEnvGroupAPI(t)
+	defer mockCtl.Finish()
+	sGroup := ms.NewMockEnvGroupService(mockCtl)
+	api.EnvGroup = sGroup
+
+	group := &models.EnvGroup{Name: "mqtt", Envs: []specV1.Environment{{Name: "MQTT_ADDRESS", Value: "tcp://broker:1883"}}}
+	sGroup.EXPECT().Get("default", "mqtt").Return(nil, common.Error(common.ErrResourceNotFound))
+	sGroup.EXPECT().Create(gomock.Any()).DoAndReturn(func(g *models.EnvGroup) (*models.EnvGroup, error) {
+		assert.Equal(t, "default", g.Namespace)
+		assert.Equal(t, group.Envs, g.Envs)
+		return g, nil
+	})
+	body, _ := json.Marshal(group)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/envgroups", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "MQTT_ADDRESS")
+
+	sGroup.EXPECT().Get("default", "mqtt").Return(group, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/envgroups", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	for _, g := range []*models.EnvGroup{
+		{Name: "MQTT"},
+		{Name: "mqtt", Envs: []specV1.Environment{{Name: "MQTT ADDRESS", Value: "a"}}},
+		{Name: "mqtt", Envs: []specV1.Environment{{Name: "QOS", Value: "0"}, {Name: "QOS", Value: "1"}}},
+	} {
+		body, _ = json.Marshal(g)
+		req, _ = http.NewRequest(http.MethodPost, "/v1/envgroups", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestUpdateEnvGroup(t *testing.T) {
+	api, router, mockCtl := initEnvGroupAPI(t)
+	defer mockCtl.Finish()
+	sGroup, sApp, sNode := ms.NewMockEnvGroupService(mockCtl), ms.NewMockApplicationService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.EnvGroup, api.Node = sGroup, sNode
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	old := &models.EnvGroup{Namespace: "default", Name: "mqtt", Envs: []specV1.Environment{{Name: "MQTT_ADDRESS", Value: "tcp://broker:1883"}}}
+	group := &models.EnvGroup{Envs: []specV1.Environment{{Name: "MQTT_ADDRESS", Value: "tcp://broker:8883"}}}
+	app := &specV1.Application{Namespace: "default", Name: "web", Version: "v1"}
+	selector := &models.ListOptions{LabelSelector: common.LabelPrefixEnvGroup + "mqtt"}
+	sGroup.EXPECT().Get("default", "mqtt").Return(old, nil)
+	sGroup.EXPECT().Update(gomock.Any()).DoAndReturn(func(g *models.EnvGroup) (*models.EnvGroup, error) {
+		return g, nil
+	})
+	sApp.EXPECT().List("default", selector).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "web"}, {Name: "db"}}}, nil)
+	sApp.EXPECT().Get("default", "web", "").Return(app, nil)
+	sApp.EXPECT().Get("default", "db", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	sApp.EXPECT().Update(nil, "default", app).Return(&specV1.Application{Namespace: "default", Name: "web", Version: "v2"}, nil)
+	sNode.EXPECT().UpdateNodeAppVersion(nil, "default", gomock.Any()).DoAndReturn(func(_ interface{}, _ string, a *specV1.Application) ([]string, error) {
+		assert.Equal(t, "v2", a.Version)
+		return []string{"node01"}, nil
+	})
+	body, _ := json.Marshal(group)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/envgroups/mqtt", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "8883")
+
+	// nothing changed
+	sGroup.EXPECT().Get("default", "mqtt").Return(old, nil)
+	body, _ = json.Marshal(old)
+	req, _ = http.NewRequest(http.MethodPut, "/v1/envgroups/mqtt", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the apps are not saved if only the description is changed
+	sGroup.EXPECT().Get("default", "mqtt").Return(old, nil)
+	sGroup.EXPECT().Update(gomock.Any()).Return(old, nil)
+	body, _ = json.Marshal(&models.EnvGroup{Description: "broker", Envs: old.Envs})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/envgroups/mqtt", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sGroup.EXPECT().Get("default", "mqtt").Return(old, nil)
+	sGroup.EXPECT().Update(gomock.Any()).Return(old, nil)
+	sApp.EXPECT().List("default", selector).Return(nil, fmt.Errorf("error"))
+	body, _ = json.Marshal(group)
+	req, _ = http.NewRequest(http.MethodPut, "/v1/envgroups/mqtt", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	sGroup.EXPECT().Get("default", "redis").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodPut, "/v1/envgroups/redis", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetListDeleteEnvGroup(t *testing.T) {
+	api, router, mockCtl := initEnvGroupAPI(t)
+	defer mockCtl.Finish()
+	sGroup, sApp := ms.NewMockEnvGroupService(mockCtl), ms.NewMockApplicationService(mockCtl)
+	api.EnvGroup = sGroup
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	group := &models.EnvGroup{Namespace: "default", Name: "mqtt"}
+	selector := &models.ListOptions{LabelSelector: common.LabelPrefixEnvGroup + "mqtt"}
+	sGroup.EXPECT().List("default").Return([]models.EnvGroup{*group}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/envgroups", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	sGroup.EXPECT().Get("default", "mqtt").Return(group, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/envgroups/mqtt", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sGroup.EXPECT().Get("default", "mqtt").Return(group, nil)
+	sApp.EXPECT().List("default", selector).Return(&models.ApplicationList{Total: 1, Items: []models.AppItem{{Name: "web"}}}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/envgroups/mqtt/apps", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"web"`)
+
+	// the group referenced by apps can not be deleted
+	sGroup.EXPECT().Get("default", "mqtt").Return(group, nil)
+	sApp.EXPECT().List("default", selector).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "web"}}}, nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/envgroups/mqtt", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	sGroup.EXPECT().Get("default", "mqtt").Return(group, nil)
+	sApp.EXPECT().List("default", selector).Return(&models.ApplicationList{}, nil)
+	sGroup.EXPECT().Delete("default", "mqtt").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/envgroups/mqtt", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sGroup.EXPECT().Get("default", "mqtt").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/envgroups/mqtt", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestApplyAppEnvGroups(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sGroup := ms.NewMockEnvGroupService(mockCtl)
+	api := &API{EnvGroup: sGroup}
+
+	appView := &models.ApplicationView{Namespace: "default", Name: "web", EnvGroups: []string{"mqtt"},
+		Labels: map[string]string{common.LabelPrefixEnvGroup + "region": "true"}}
+	sGroup.EXPECT().Get("default", "mqtt").Return(&models.EnvGroup{Name: "mqtt"}, nil)
+	assert.NoError(t, api.applyAppEnvGroups(appView))
+	assert.Equal(t, map[string]string{common.LabelPrefixEnvGroup + "mqtt": "true"}, appView.Labels)
+
+	sGroup.EXPECT().Get("default", "mqtt").Return(nil, common.Error(common.ErrResourceNotFound))
+	assert.Error(t, api.applyAppEnvGroups(appView))
+
+	appView.EnvGroups = nil
+	assert.NoError(t, api.applyAppEnvGroups(appView))
+	assert.Empty(t, appView.Labels)
+}
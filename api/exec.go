@@ -0,0 +1,173 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gorilla/websocket"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+const (
+	// MaxExecInputRecorded the max bytes of user input recorded in the audit of exec session
+	MaxExecInputRecorded = 64 * 1024
+	execWriteTimeout     = 10 * time.Second
+	defaultExecShell     = "sh"
+)
+
+var execUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// ExecNode upgrades the request to websocket and tunnels the shell or command to the node through the sync link,
+// both directions carry json encoded ExecFrame, the client sends stdin and resize frames and receives stdout, stderr and exit frames
+func (api *API) ExecNode(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	err := api.Auth.Verify(c, &plugin.PermissionRequest{
+		Resource:   plugin.PermissionResourceNode,
+		Permission: []string{plugin.PermissionFull},
+		RequestContext: plugin.RequestContext{
+			IpAddress: c.ClientIP(),
+			Referer:   c.Request.Referer(),
+		},
+	})
+	if err != nil {
+		return nil, common.Error(common.ErrRequestAccessDenied, common.Field("error", err.Error()))
+	}
+	req, err := parseExecRequest(c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = api.Node.Get(nil, ns, name); err != nil {
+		return nil, err
+	}
+
+	user := c.GetUser()
+	if user.ID == "" {
+		user.ID = user.Name
+	}
+	_, requestID := c.GetTrace()
+	session := &models.ExecSession{
+		ID:          common.UUIDPrune(),
+		Namespace:   ns,
+		Node:        name,
+		User:        user.ID,
+		RequestId:   requestID,
+		ExecRequest: *req,
+		CreateTime:  time.Now().UTC(),
+	}
+	output, err := api.Exec.Open(session)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := execUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the response has been written by upgrader
+		log.L().Warn("failed to upgrade exec request", log.Any(c.GetTrace()), log.Error(err))
+		api.closeExecSession(session)
+		return nil, nil
+	}
+	defer conn.Close()
+	log.L().Info("exec session opened", log.Any("session", session.ID), log.Any("node", name), log.Any("user", user.ID))
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		api.pumpExecOutput(conn, session, output, stop)
+	}()
+	api.pumpExecInput(conn, session)
+	close(stop)
+	<-done
+
+	api.closeExecSession(session)
+	return nil, nil
+}
+
+// pumpExecOutput writes the output frames of node to client, the connection is closed after the command exits
+func (api *API) pumpExecOutput(conn *websocket.Conn, session *models.ExecSession, output <-chan models.ExecFrame, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case frame, ok := <-output:
+			if !ok {
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(execWriteTimeout))
+			if err := conn.WriteJSON(frame); err != nil {
+				log.L().Warn("failed to write exec frame", log.Any("session", session.ID), log.Error(err))
+				conn.Close()
+				return
+			}
+			if frame.Type != models.ExecFrameExit {
+				continue
+			}
+			code := frame.Code
+			session.ExitCode = &code
+			msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "exit code "+strconv.Itoa(code))
+			conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(execWriteTimeout))
+			conn.Close()
+			return
+		}
+	}
+}
+
+// pumpExecInput forwards the stdin and resize frames of client to node until the connection is closed
+func (api *API) pumpExecInput(conn *websocket.Conn, session *models.ExecSession) {
+	for {
+		var frame models.ExecFrame
+		if err := conn.ReadJSON(&frame); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.L().Debug("exec connection is closed", log.Any("session", session.ID), log.Error(err))
+			}
+			return
+		}
+		switch frame.Type {
+		case models.ExecFrameStdin:
+			if remain := MaxExecInputRecorded - len(session.Input); remain > 0 {
+				if len(frame.Data) > remain {
+					session.Input += string(frame.Data[:remain])
+				} else {
+					session.Input += string(frame.Data)
+				}
+			}
+		case models.ExecFrameResize:
+		default:
+			continue
+		}
+		if err := api.Exec.Send(session.ID, frame); err != nil {
+			log.L().Warn("failed to send exec frame", log.Any("session", session.ID), log.Error(err))
+			return
+		}
+	}
+}
+
+func (api *API) closeExecSession(session *models.ExecSession) {
+	if err := api.Exec.Close(session); err != nil {
+		log.L().Error("failed to close exec session", log.Any("session", session.ID), log.Error(err))
+	}
+}
+
+func parseExecRequest(c *common.Context) (*models.ExecRequest, error) {
+	req := &models.ExecRequest{
+		App:       c.Query("app"),
+		Container: c.Query("container"),
+		Command:   c.QueryArray("command"),
+	}
+	if tty := c.Query("tty"); tty != "" {
+		var err error
+		if req.TTY, err = strconv.ParseBool(tty); err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "tty should be a boolean"))
+		}
+	}
+	if len(req.Command) == 0 {
+		req.Command = []string{defaultExecShell}
+		req.TTY = true
+	}
+	return req, nil
+}
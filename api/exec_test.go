@@ -0,0 +1,137 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func initExecAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) {
+		common.NewContext(c).SetNamespace("default")
+		common.NewContext(c).SetUser(common.User{ID: "u1"})
+	}
+	v1 := router.Group("v1")
+	{
+		nodes := v1.Group("/nodes")
+		nodes.GET("/:name/exec", mockIM, common.WrapperNative(api.ExecNode, true))
+	}
+	return api, router, mockCtl
+}
+
+func TestExecNode(t *testing.T) {
+	api, router, mockCtl := initExecAPI(t)
+	defer mockCtl.Finish()
+
+	sAuth := ms.NewMockAuthService(mockCtl)
+	sNode := ms.NewMockNodeService(mockCtl)
+	sExec := ms.NewMockExecService(mockCtl)
+	api.Auth = sAuth
+	api.Node = sNode
+	api.Exec = sExec
+
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	url := "ws" + strings.TrimPrefix(svr.URL, "http") + "/v1/nodes/n1/exec?app=app1&command=ls&command=-l"
+
+	output := make(chan models.ExecFrame, 2)
+	sent, closed := make(chan models.ExecFrame, 1), make(chan *models.ExecSession, 1)
+	sAuth.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *common.Context, pr *plugin.PermissionRequest) error {
+		assert.Equal(t, plugin.PermissionResourceNode, pr.Resource)
+		assert.Equal(t, []string{plugin.PermissionFull}, pr.Permission)
+		return nil
+	})
+	sNode.EXPECT().Get(nil, "default", "n1").Return(&specV1.Node{Namespace: "default", Name: "n1"}, nil)
+	sExec.EXPECT().Open(gomock.Any()).DoAndReturn(func(s *models.ExecSession) (<-chan models.ExecFrame, error) {
+		assert.Equal(t, "n1", s.Node)
+		assert.Equal(t, "u1", s.User)
+		assert.Equal(t, "app1", s.App)
+		assert.Equal(t, []string{"ls", "-l"}, s.Command)
+		assert.NotEmpty(t, s.ID)
+		return output, nil
+	})
+	sExec.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ string, f models.ExecFrame) error {
+		sent <- f
+		return nil
+	})
+	sExec.EXPECT().Close(gomock.Any()).DoAndReturn(func(s *models.ExecSession) error {
+		closed <- s
+		return nil
+	})
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	output <- models.ExecFrame{Type: models.ExecFrameStdout, Data: []byte("a")}
+	var frame models.ExecFrame
+	assert.NoError(t, conn.ReadJSON(&frame))
+	assert.Equal(t, models.ExecFrameStdout, frame.Type)
+	assert.Equal(t, []byte("a"), frame.Data)
+
+	// frames other than stdin and resize are ignored
+	assert.NoError(t, conn.WriteJSON(models.ExecFrame{Type: models.ExecFrameExit}))
+	assert.NoError(t, conn.WriteJSON(models.ExecFrame{Type: models.ExecFrameStdin, Data: []byte("q\n")}))
+	assert.Equal(t, models.ExecFrameStdin, (<-sent).Type)
+
+	output <- models.ExecFrame{Type: models.ExecFrameExit, Code: 2}
+	assert.NoError(t, conn.ReadJSON(&frame))
+	assert.Equal(t, models.ExecFrameExit, frame.Type)
+	assert.Equal(t, 2, frame.Code)
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure))
+
+	select {
+	case s := <-closed:
+		assert.Equal(t, "q\n", s.Input)
+		assert.Equal(t, 2, *s.ExitCode)
+	case <-time.After(5 * time.Second):
+		t.Fatal("session is not closed")
+	}
+}
+
+func TestExecNodeDenied(t *testing.T) {
+	api, router, mockCtl := initExecAPI(t)
+	defer mockCtl.Finish()
+
+	sAuth := ms.NewMockAuthService(mockCtl)
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Auth = sAuth
+	api.Node = sNode
+
+	sAuth.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(fmt.Errorf("denied"))
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/n1/exec", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	sAuth.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/n1/exec?tty=x", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	sAuth.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(nil)
+	sNode.EXPECT().Get(nil, "default", "n1").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/n1/exec", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetFeatureFlag get the feature flag
+func (api *API) GetFeatureFlag(c *common.Context) (interface{}, error) {
+	return api.FeatureFlag.Get(c.GetNameFromParam())
+}
+
+// ListFeatureFlag list the feature flags along with their overrides of namespaces
+func (api *API) ListFeatureFlag(c *common.Context) (interface{}, error) {
+	flags, err := api.FeatureFlag.List()
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(flags), flags, ""), nil
+}
+
+// ListNamespaceFeature list the feature flags enabled for the namespace of request, so that consoles can show the
+// capabilities enabled
+func (api *API) ListNamespaceFeature(c *common.Context) (interface{}, error) {
+	return api.FeatureFlag.Resolve(c.GetNamespace())
+}
+
+// CreateFeatureFlag defines the feature flag, which is disabled for all namespaces unless enabled or rolled out
+func (api *API) CreateFeatureFlag(c *common.Context) (interface{}, error) {
+	flag, err := parseFeatureFlag(c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = api.FeatureFlag.Get(flag.Name); err == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "this name is already in use"))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, err
+	}
+	return api.FeatureFlag.Create(flag)
+}
+
+// UpdateFeatureFlag updates the feature flag, the overrides of namespaces are kept if not given
+func (api *API) UpdateFeatureFlag(c *common.Context) (interface{}, error) {
+	flag, err := parseFeatureFlag(c)
+	if err != nil {
+		return nil, err
+	}
+	old, err := api.FeatureFlag.Get(flag.Name)
+	if err != nil {
+		return nil, err
+	}
+	if flag.Overrides == nil {
+		flag.Overrides = old.Overrides
+	}
+	return api.FeatureFlag.Update(flag)
+}
+
+// DeleteFeatureFlag deletes the feature flag, which is disabled for all namespaces then
+func (api *API) DeleteFeatureFlag(c *common.Context) (interface{}, error) {
+	name := c.GetNameFromParam()
+	if _, err := api.FeatureFlag.Get(name); err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return nil, api.FeatureFlag.Delete(name)
+}
+
+// SetFeatureOverride enables or disables the feature flag for the namespace regardless of its rollout
+func (api *API) SetFeatureOverride(c *common.Context) (interface{}, error) {
+	override := new(models.FeatureOverride)
+	if err := c.LoadBody(override); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	ns := c.Param("namespace")
+	if err := api.checkTenantNamespace(ns); err != nil {
+		return nil, err
+	}
+	return api.FeatureFlag.SetOverride(c.GetNameFromParam(), ns, &override.Enabled)
+}
+
+// DeleteFeatureOverride removes the override of namespace, the namespace follows the rollout of flag again
+func (api *API) DeleteFeatureOverride(c *common.Context) (interface{}, error) {
+	return api.FeatureFlag.SetOverride(c.GetNameFromParam(), c.Param("namespace"), nil)
+}
+
+func parseFeatureFlag(c *common.Context) (*models.FeatureFlag, error) {
+	flag := new(models.FeatureFlag)
+	flag.Name = c.GetNameFromParam()
+	if err := c.LoadBody(flag); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if name := c.GetNameFromParam(); name != "" {
+		flag.Name = name
+	}
+	if flag.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	return flag, nil
+}
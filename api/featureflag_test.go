@@ -0,0 +1,122 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initFeatureFlagAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		features := v1.Group("/features")
+		features.GET("", common.WrapperMis(api.ListFeatureFlag))
+		features.GET("/:name", common.WrapperMis(api.GetFeatureFlag))
+		features.POST("", common.WrapperMis(api.CreateFeatureFlag))
+		features.PUT("/:name", common.WrapperMis(api.UpdateFeatureFlag))
+		features.DELETE("/:name", common.WrapperMis(api.DeleteFeatureFlag))
+		features.PUT("/:name/namespaces/:namespace", common.WrapperMis(api.SetFeatureOverride))
+		features.DELETE("/:name/namespaces/:namespace", common.WrapperMis(api.DeleteFeatureOverride))
+	}
+	{
+		features := v1.Group("/namespace/features")
+		features.GET("", mockIM, common.Wrapper(api.ListNamespaceFeature))
+	}
+	return api, router, mockCtl
+}
+
+func TestFeatureFlag(t *testing.T) {
+	api, router, mockCtl := initFeatureFlagAPI(t)
+	defer mockCtl.Finish()
+	sFlag, sNS := ms.NewMockFeatureFlagService(mockCtl), ms.NewMockNamespaceService(mockCtl)
+	api.FeatureFlag, api.NS = sFlag, sNS
+
+	flag := &models.FeatureFlag{Name: "delta-sync", Description: "sync the deltas of desires", Percentage: 10}
+	sFlag.EXPECT().Get("delta-sync").Return(nil, common.Error(common.ErrResourceNotFound))
+	sFlag.EXPECT().Create(flag).Return(flag, nil)
+	body, _ := json.Marshal(flag)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/features", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":0`)
+
+	for _, f := range []*models.FeatureFlag{
+		{Name: "delta-sync", Percentage: 101},
+		{Percentage: 10},
+	} {
+		body, _ = json.Marshal(f)
+		req, _ = http.NewRequest(http.MethodPost, "/v1/features", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Contains(t, w.Body.String(), `"status":1`)
+	}
+
+	// the overrides are kept if not given
+	sFlag.EXPECT().Get("delta-sync").Return(&models.FeatureFlag{Name: "delta-sync", Overrides: map[string]bool{"tenant-a": true}}, nil)
+	sFlag.EXPECT().Update(gomock.Any()).DoAndReturn(func(f *models.FeatureFlag) (*models.FeatureFlag, error) {
+		assert.Equal(t, 50, f.Percentage)
+		assert.Equal(t, map[string]bool{"tenant-a": true}, f.Overrides)
+		return f, nil
+	})
+	body, _ = json.Marshal(&models.FeatureFlag{Percentage: 50})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/features/delta-sync", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":0`)
+
+	enabled := true
+	sNS.EXPECT().Get("tenant-b").Return(&models.Namespace{Name: "tenant-b"}, nil)
+	sFlag.EXPECT().SetOverride("delta-sync", "tenant-b", &enabled).Return(flag, nil)
+	req, _ = http.NewRequest(http.MethodPut, "/v1/features/delta-sync/namespaces/tenant-b", bytes.NewReader([]byte(`{"enabled":true}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":0`)
+
+	sNS.EXPECT().Get("unknown").Return(nil, nil)
+	req, _ = http.NewRequest(http.MethodPut, "/v1/features/delta-sync/namespaces/unknown", bytes.NewReader([]byte(`{"enabled":true}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":1`)
+
+	sFlag.EXPECT().SetOverride("delta-sync", "tenant-b", nil).Return(flag, nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/features/delta-sync/namespaces/tenant-b", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":0`)
+
+	sFlag.EXPECT().Resolve("default").Return(map[string]bool{"delta-sync": true}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/namespace/features", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"delta-sync":true`)
+
+	// the flags deleted already are ignored
+	sFlag.EXPECT().Get("delta-sync").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/features/delta-sync", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":0`)
+
+	sFlag.EXPECT().Get("delta-sync").Return(flag, nil)
+	sFlag.EXPECT().Delete("delta-sync").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/features/delta-sync", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":0`)
+}
@@ -3,7 +3,9 @@ package api
 import (
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/baetyl/baetyl-go/v2/errors"
@@ -85,7 +87,7 @@ func (api *API) ImportFunction(c *common.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	return &models.ConfigFunctionItem{
+	item := &models.ConfigFunctionItem{
 		Function: functionObj.Name,
 		Version:  functionObj.Version,
 		Runtime:  functionObj.Runtime,
@@ -96,7 +98,18 @@ func (api *API) ImportFunction(c *common.Context) (interface{}, error) {
 			Object: objectName,
 			Unpack: common.UnpackTypeZip,
 		},
-	}, nil
+	}
+	if len(functionObj.Env) > 0 {
+		env, err := json.Marshal(functionObj.Env)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		item.Env = string(env)
+	}
+	if functionObj.MemorySize > 0 {
+		item.MemorySize = strconv.Itoa(functionObj.MemorySize)
+	}
+	return item, nil
 }
 
 func base64ToHex(s string) (string, error) {
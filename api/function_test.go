@@ -177,6 +177,8 @@ func TestImportFunction(t *testing.T) {
 			Sha256:   "nwJRg4SsziinnzTflN8XBilgUzeGIUZS/mxjwnQkzM8=",
 			Location: "bj",
 		},
+		Env:        map[string]string{"LOG_LEVEL": "debug"},
+		MemorySize: 128,
 	}
 	namespace := "default"
 	sFunc.EXPECT().GetFunction(namespace, function.Name,
@@ -205,6 +207,8 @@ func TestImportFunction(t *testing.T) {
 	assert.Equal(t, "handler1", res.Handler)
 	assert.Equal(t, "baetyl-cloud-default", res.Bucket)
 	assert.Equal(t, "9f02518384acce28a79f34df94df17062960533786214652fe6c63c27424cccf/name1.zip", res.Object)
+	assert.Equal(t, `{"LOG_LEVEL":"debug"}`, res.Env)
+	assert.Equal(t, "128", res.MemorySize)
 
 	sFunc.EXPECT().GetFunction(namespace, function.Name,
 		function.Version, "baiducfc").Return(nil, errors.New("err")).Times(1)
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// BuildFunction uploads the code zip of function version as the form file and builds it into an image,
+// the runtime and the handler of function are given in form
+func (api *API) BuildFunction(c *common.Context) (interface{}, error) {
+	if api.FunctionBuild == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the build of function is not supported"))
+	}
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the code zip of function is required: "+err.Error()))
+	}
+	defer file.Close()
+	if !strings.HasSuffix(strings.ToLower(header.Filename), ".zip") {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the code of function should be packaged as .zip"))
+	}
+	code, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	build := &models.FunctionBuild{
+		Namespace: c.GetNamespace(),
+		Name:      c.Param("name"),
+		Version:   c.Param("version"),
+		Runtime:   c.PostForm("runtime"),
+		Handler:   c.PostForm("handler"),
+	}
+	return api.FunctionBuild.Build(c.GetUser().ID, build, code)
+}
+
+// GetFunctionBuild returns the build of function version with the image built
+func (api *API) GetFunctionBuild(c *common.Context) (interface{}, error) {
+	if api.FunctionBuild == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the build of function is not supported"))
+	}
+	return api.FunctionBuild.Get(c.GetNamespace(), c.Param("name"), c.Param("version"))
+}
+
+// ListFunctionBuilds lists the builds of the versions of function
+func (api *API) ListFunctionBuilds(c *common.Context) (interface{}, error) {
+	if api.FunctionBuild == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the build of function is not supported"))
+	}
+	builds, err := api.FunctionBuild.List(c.GetNamespace(), c.Param("name"))
+	if err != nil {
+		return nil, err
+	}
+	return &models.FunctionBuildView{Builds: builds}, nil
+}
+
+// DeleteFunctionBuild deletes the build of function version
+func (api *API) DeleteFunctionBuild(c *common.Context) (interface{}, error) {
+	if api.FunctionBuild == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the build of function is not supported"))
+	}
+	return nil, api.FunctionBuild.Delete(c.GetNamespace(), c.Param("name"), c.Param("version"))
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initFunctionBuildAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) {
+		cc := common.NewContext(c)
+		cc.SetNamespace("default")
+		cc.SetUser(common.User{ID: "u1"})
+	}
+	v1 := router.Group("v1")
+	{
+		builds := v1.Group("/functionbuilds")
+		builds.GET("/:name/versions", mockIM, common.Wrapper(api.ListFunctionBuilds))
+		builds.GET("/:name/versions/:version", mockIM, common.Wrapper(api.GetFunctionBuild))
+		builds.POST("/:name/versions/:version", mockIM, common.Wrapper(api.BuildFunction))
+		builds.DELETE("/:name/versions/:version", mockIM, common.Wrapper(api.DeleteFunctionBuild))
+	}
+	return api, router, mockCtl
+}
+
+func newFunctionBuildRequest(t *testing.T, url, filename string, code []byte) *http.Request {
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+	assert.NoError(t, w.WriteField("runtime", "python3"))
+	assert.NoError(t, w.WriteField("handler", "index.handler"))
+	if code != nil {
+		fw, err := w.CreateFormFile("file", filename)
+		assert.NoError(t, err)
+		fw.Write(code)
+	}
+	assert.NoError(t, w.Close())
+	req, _ := http.NewRequest(http.MethodPost, url, buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestBuildFunction(t *testing.T) {
+	api, router, mockCtl := initFunctionBuildAPI(t)
+	defer mockCtl.Finish()
+
+	// the build is not supported without the service
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newFunctionBuildRequest(t, "/v1/functionbuilds/infer/versions/v1", "code.zip", []byte("zip")))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	sBuild := ms.NewMockFunctionBuildService(mockCtl)
+	api.FunctionBuild = sBuild
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newFunctionBuildRequest(t, "/v1/functionbuilds/infer/versions/v1", "", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newFunctionBuildRequest(t, "/v1/functionbuilds/infer/versions/v1", "code.tgz", []byte("zip")))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	sBuild.EXPECT().Build("u1", gomock.Any(), []byte("zip")).DoAndReturn(func(_ string, build *models.FunctionBuild, _ []byte) (*models.FunctionBuild, error) {
+		assert.Equal(t, &models.FunctionBuild{Namespace: "default", Name: "infer", Version: "v1", Runtime: "python3", Handler: "index.handler"}, build)
+		build.Image, build.Status = "harbor.local/functions/default/infer:v1", models.FunctionBuildRunning
+		return build, nil
+	})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newFunctionBuildRequest(t, "/v1/functionbuilds/infer/versions/v1", "code.ZIP", []byte("zip")))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"building"`)
+}
+
+func TestGetFunctionBuild(t *testing.T) {
+	api, router, mockCtl := initFunctionBuildAPI(t)
+	defer mockCtl.Finish()
+	sBuild := ms.NewMockFunctionBuildService(mockCtl)
+	api.FunctionBuild = sBuild
+
+	build := models.FunctionBuild{Namespace: "default", Name: "infer", Version: "v1", Image: "harbor.local/functions/default/infer:v1", Status: models.FunctionBuildSucceeded}
+	sBuild.EXPECT().Get("default", "infer", "v1").Return(&build, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/functionbuilds/infer/versions/v1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), build.Image)
+
+	sBuild.EXPECT().Get("default", "infer", "v2").Return(nil, common.Error(common.ErrResourceNotFound, common.Field("type", "function build"), common.Field("name", "infer:v2")))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/functionbuilds/infer/versions/v2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	sBuild.EXPECT().List("default", "infer").Return([]models.FunctionBuild{build}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/functionbuilds/infer/versions", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"builds":[`)
+
+	sBuild.EXPECT().Delete("default", "infer", "v1").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/functionbuilds/infer/versions/v1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetFunctionDraft get the draft of function with the contents of files
+func (api *API) GetFunctionDraft(c *common.Context) (interface{}, error) {
+	return api.FunctionDraft.Get(c.GetNamespace(), c.GetNameFromParam())
+}
+
+// ListFunctionDrafts list the drafts of namespace
+func (api *API) ListFunctionDrafts(c *common.Context) (interface{}, error) {
+	drafts, err := api.FunctionDraft.List(c.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(drafts), drafts, ""), nil
+}
+
+// CreateFunctionDraft create the draft of function, the files can be given or saved one by one later
+func (api *API) CreateFunctionDraft(c *common.Context) (interface{}, error) {
+	draft, err := api.parseFunctionDraft(c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = api.FunctionDraft.Get(draft.Namespace, draft.Name); err == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "this name is already in use"))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, err
+	}
+	return api.FunctionDraft.Create(draft)
+}
+
+// UpdateFunctionDraft update the runtime, the handler and the description of draft
+func (api *API) UpdateFunctionDraft(c *common.Context) (interface{}, error) {
+	draft, err := api.parseFunctionDraft(c)
+	if err != nil {
+		return nil, err
+	}
+	return api.FunctionDraft.Update(draft)
+}
+
+// DeleteFunctionDraft delete the draft of function
+func (api *API) DeleteFunctionDraft(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.FunctionDraft.Get(ns, name); err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return nil, api.FunctionDraft.Delete(ns, name)
+}
+
+// GetFunctionDraftFile get the file of draft
+func (api *API) GetFunctionDraftFile(c *common.Context) (interface{}, error) {
+	return api.FunctionDraft.GetFile(c.GetNamespace(), c.GetNameFromParam(), c.Param("path"))
+}
+
+// SaveFunctionDraftFile save the content of the file of draft, the apps are not affected until the draft is published
+func (api *API) SaveFunctionDraftFile(c *common.Context) (interface{}, error) {
+	file := new(models.FunctionDraftFile)
+	if err := c.LoadBody(file); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	file.Path = c.Param("path")
+	return api.FunctionDraft.SaveFile(c.GetNamespace(), c.GetNameFromParam(), file)
+}
+
+// DeleteFunctionDraftFile delete the file of draft
+func (api *API) DeleteFunctionDraftFile(c *common.Context) (interface{}, error) {
+	return nil, api.FunctionDraft.DeleteFile(c.GetNamespace(), c.GetNameFromParam(), c.Param("path"))
+}
+
+// InvokeFunctionDraft invoke the draft in the cloud sandbox with the payload
+func (api *API) InvokeFunctionDraft(c *common.Context) (interface{}, error) {
+	req := new(models.FunctionInvokeRequest)
+	if err := c.LoadBody(req); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	req.Namespace, req.Name = c.GetNamespace(), c.GetNameFromParam()
+	// the draft is always invoked in sandbox, the versions and the nodes are invoked by InvokeFunction
+	req.Version, req.Node, req.App = "", "", ""
+	return api.FunctionDraft.Invoke(c.GetUser().ID, req)
+}
+
+// PublishFunctionDraft publish the draft as an immutable version
+func (api *API) PublishFunctionDraft(c *common.Context) (interface{}, error) {
+	req := new(models.FunctionPublishRequest)
+	if err := c.LoadBody(req); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	return api.FunctionDraft.Publish(c.GetUser().ID, c.GetNamespace(), c.GetNameFromParam(), req)
+}
+
+// the memory used to parse the multipart form of wasm bundles
+const maxBundleFormMemory = 32 << 20
+
+// PublishFunctionDraftBundle publish the wasm bundle built from the draft as an immutable version, the form has the
+// fields version, description and flavor of the request, and the bundle in file
+func (api *API) PublishFunctionDraftBundle(c *common.Context) (interface{}, error) {
+	if err := c.Request.ParseMultipartForm(maxBundleFormMemory); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	req := &models.FunctionPublishRequest{
+		Version:     c.Request.FormValue("version"),
+		Description: c.Request.FormValue("description"),
+		Flavor:      c.Request.FormValue("flavor"),
+	}
+	if err := common.ValidateStruct(req); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the wasm bundle is required"))
+	}
+	defer file.Close()
+	bundle, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.FunctionDraft.PublishBundle(c.GetUser().ID, c.GetNamespace(), c.GetNameFromParam(), req, bundle)
+}
+
+// GetFunctionDraftVersion get the version published
+func (api *API) GetFunctionDraftVersion(c *common.Context) (interface{}, error) {
+	return api.FunctionDraft.GetVersion(c.GetNamespace(), c.GetNameFromParam(), c.Param("version"))
+}
+
+// ListFunctionDraftVersions list the versions published from the draft
+func (api *API) ListFunctionDraftVersions(c *common.Context) (interface{}, error) {
+	versions, err := api.FunctionDraft.ListVersions(c.GetNamespace(), c.GetNameFromParam())
+	if err != nil {
+		return nil, err
+	}
+	return &models.FunctionVersionView{Versions: versions}, nil
+}
+
+// ImportFunctionDraftVersion returns the function item of config referencing the code of version,
+// like the functions imported from the function sources
+func (api *API) ImportFunctionDraftVersion(c *common.Context) (interface{}, error) {
+	version, err := api.FunctionDraft.GetVersion(c.GetNamespace(), c.GetNameFromParam(), c.Param("version"))
+	if err != nil {
+		return nil, err
+	}
+	item := &models.ConfigFunctionItem{
+		Function: version.Name,
+		Version:  version.Version,
+		Runtime:  version.Runtime,
+		Handler:  version.Handler,
+		ConfigObjectItem: models.ConfigObjectItem{
+			Source: version.Source,
+			Bucket: version.Bucket,
+			Object: version.Object,
+			Unpack: common.UnpackTypeZip,
+		},
+	}
+	if version.IsWasm() {
+		item.Unpack = ""
+	}
+	return item, nil
+}
+
+func (api *API) parseFunctionDraft(c *common.Context) (*models.FunctionDraft, error) {
+	draft := new(models.FunctionDraft)
+	if err := c.LoadBody(draft); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if name := c.GetNameFromParam(); name != "" {
+		draft.Name = name
+	}
+	if draft.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	draft.Namespace = c.GetNamespace()
+	return draft, nil
+}
+
+// functionVersionsOfService returns the function versions in the code config of service,
+// the functions imported from the vendors have no versions and are skipped
+func (api *API) functionVersionsOfService(app *specV1.Application, svc *specV1.Service) ([]models.FunctionVersion, error) {
+	if api.FunctionDraft == nil {
+		return nil, nil
+	}
+	codeVm := getNameOfFunctionCodeVolumeMount(svc.Name)
+	var cfgName string
+	for _, v := range app.Volumes {
+		if v.Name == codeVm && v.Config != nil {
+			cfgName = v.Config.Name
+			break
+		}
+	}
+	if cfgName == "" {
+		return nil, nil
+	}
+	cfg, err := api.Config.Get(app.Namespace, cfgName, "")
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var keys []string
+	for k := range cfg.Data {
+		if strings.HasPrefix(k, common.ConfigObjectPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var res []models.FunctionVersion
+	for _, k := range keys {
+		object := new(specV1.ConfigurationObject)
+		if err = json.Unmarshal([]byte(cfg.Data[k]), object); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if object.Metadata["type"] != ConfigTypeFunction {
+			continue
+		}
+		version, err := api.FunctionDraft.GetVersion(app.Namespace, object.Metadata["function"], object.Metadata["version"])
+		if err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				continue
+			}
+			return nil, err
+		}
+		res = append(res, *version)
+	}
+	return res, nil
+}
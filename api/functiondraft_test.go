@@ -0,0 +1,227 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initFunctionDraftAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) {
+		cc := common.NewContext(c)
+		cc.SetNamespace("default")
+		cc.SetUser(common.User{ID: "u1"})
+	}
+	v1 := router.Group("v1")
+	{
+		drafts := v1.Group("/functiondrafts")
+		drafts.GET("", mockIM, common.Wrapper(api.ListFunctionDrafts))
+		drafts.GET("/:name", mockIM, common.Wrapper(api.GetFunctionDraft))
+		drafts.POST("", mockIM, common.Wrapper(api.CreateFunctionDraft))
+		drafts.PUT("/:name", mockIM, common.Wrapper(api.UpdateFunctionDraft))
+		drafts.DELETE("/:name", mockIM, common.Wrapper(api.DeleteFunctionDraft))
+		drafts.GET("/:name/files/*path", mockIM, common.Wrapper(api.GetFunctionDraftFile))
+		drafts.PUT("/:name/files/*path", mockIM, common.Wrapper(api.SaveFunctionDraftFile))
+		drafts.DELETE("/:name/files/*path", mockIM, common.Wrapper(api.DeleteFunctionDraftFile))
+		drafts.POST("/:name/invoke", mockIM, common.Wrapper(api.InvokeFunctionDraft))
+		drafts.GET("/:name/versions", mockIM, common.Wrapper(api.ListFunctionDraftVersions))
+		drafts.GET("/:name/versions/:version", mockIM, common.Wrapper(api.GetFunctionDraftVersion))
+		drafts.POST("/:name/versions", mockIM, common.Wrapper(api.PublishFunctionDraft))
+		drafts.POST("/:name/versions/:version/import", mockIM, common.Wrapper(api.ImportFunctionDraftVersion))
+		drafts.POST("/:name/bundles", mockIM, common.Wrapper(api.PublishFunctionDraftBundle))
+	}
+	return api, router, mockCtl
+}
+
+func TestCreateFunctionDraft(t *testing.T) {
+	api, router, mockCtl := initFunctionDraftAPI(t)
+	defer mockCtl.Finish()
+	sDraft := ms.NewMockFunctionDraftService(mockCtl)
+	api.FunctionDraft = sDraft
+
+	draft := &models.FunctionDraft{Name: "infer", Runtime: "python3", Handler: "index.handler", Files: map[string]string{"index.py": "pass\n"}}
+	sDraft.EXPECT().Get("default", "infer").Return(nil, common.Error(common.ErrResourceNotFound))
+	sDraft.EXPECT().Create(gomock.Any()).DoAndReturn(func(d *models.FunctionDraft) (*models.FunctionDraft, error) {
+		assert.Equal(t, "default", d.Namespace)
+		assert.Equal(t, draft.Files, d.Files)
+		return d, nil
+	})
+	body, _ := json.Marshal(draft)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/functiondrafts", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sDraft.EXPECT().Get("default", "infer").Return(draft, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/functiondrafts", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	body, _ = json.Marshal(&models.FunctionDraft{Name: "infer"})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/functiondrafts", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestFunctionDraftFile(t *testing.T) {
+	api, router, mockCtl := initFunctionDraftAPI(t)
+	defer mockCtl.Finish()
+	sDraft := ms.NewMockFunctionDraftService(mockCtl)
+	api.FunctionDraft = sDraft
+
+	sDraft.EXPECT().SaveFile("default", "infer", gomock.Any()).DoAndReturn(func(_, _ string, f *models.FunctionDraftFile) (*models.FunctionDraftFile, error) {
+		assert.Equal(t, "/lib/util.py", f.Path)
+		assert.Equal(t, "PI = 3.14\n", f.Content)
+		return &models.FunctionDraftFile{Path: "lib/util.py", Content: f.Content}, nil
+	})
+	body, _ := json.Marshal(&models.FunctionDraftFile{Content: "PI = 3.14\n"})
+	req, _ := http.NewRequest(http.MethodPut, "/v1/functiondrafts/infer/files/lib/util.py", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"path":"lib/util.py"`)
+
+	sDraft.EXPECT().GetFile("default", "infer", "/lib/util.py").Return(&models.FunctionDraftFile{Path: "lib/util.py", Content: "PI = 3.14\n"}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/functiondrafts/infer/files/lib/util.py", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sDraft.EXPECT().DeleteFile("default", "infer", "/lib/util.py").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/functiondrafts/infer/files/lib/util.py", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestInvokeFunctionDraft(t *testing.T) {
+	api, router, mockCtl := initFunctionDraftAPI(t)
+	defer mockCtl.Finish()
+	sDraft := ms.NewMockFunctionDraftService(mockCtl)
+	api.FunctionDraft = sDraft
+
+	sDraft.EXPECT().Invoke("u1", gomock.Any()).DoAndReturn(func(_ string, r *models.FunctionInvokeRequest) (*models.FunctionInvokeResult, error) {
+		assert.Equal(t, "default", r.Namespace)
+		assert.Empty(t, r.Version)
+		assert.Equal(t, "infer", r.Name)
+		assert.JSONEq(t, `{"a":1}`, string(r.Payload))
+		return &models.FunctionInvokeResult{Result: r.Payload, Logs: "ok", Duration: 5}, nil
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/functiondrafts/infer/invoke", bytes.NewReader([]byte(`{"payload":{"a":1},"version":"v1"}`)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"logs":"ok"`)
+}
+
+func TestPublishFunctionDraft(t *testing.T) {
+	api, router, mockCtl := initFunctionDraftAPI(t)
+	defer mockCtl.Finish()
+	sDraft := ms.NewMockFunctionDraftService(mockCtl)
+	api.FunctionDraft = sDraft
+
+	version := &models.FunctionVersion{
+		Namespace: "default", Name: "infer", Version: "v1", Runtime: "python3", Handler: "index.handler",
+		Source: "minio", Bucket: "baetyl-cloud-u1", Object: "functions/default/infer/v1/sha.zip",
+	}
+	sDraft.EXPECT().Publish("u1", "default", "infer", &models.FunctionPublishRequest{Version: "v1"}).Return(version, nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/functiondrafts/infer/versions", bytes.NewReader([]byte(`{"version":"v1"}`)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/v1/functiondrafts/infer/versions", bytes.NewReader([]byte(`{}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	sDraft.EXPECT().ListVersions("default", "infer").Return([]models.FunctionVersion{*version}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/functiondrafts/infer/versions", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sDraft.EXPECT().GetVersion("default", "infer", "v1").Return(version, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/functiondrafts/infer/versions/v1/import", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	item := new(models.ConfigFunctionItem)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), item))
+	assert.Equal(t, "infer", item.Function)
+	assert.Equal(t, "v1", item.Version)
+	assert.Equal(t, "functions/default/infer/v1/sha.zip", item.Object)
+	assert.Equal(t, common.UnpackTypeZip, item.Unpack)
+}
+
+func TestPublishFunctionDraftBundle(t *testing.T) {
+	api, router, mockCtl := initFunctionDraftAPI(t)
+	defer mockCtl.Finish()
+	sDraft := ms.NewMockFunctionDraftService(mockCtl)
+	api.FunctionDraft = sDraft
+
+	form := func(version string, bundle []byte) (*bytes.Buffer, string) {
+		buf := new(bytes.Buffer)
+		w := multipart.NewWriter(buf)
+		w.WriteField("version", version)
+		if bundle != nil {
+			f, _ := w.CreateFormFile("file", "filter.wasm")
+			f.Write(bundle)
+		}
+		w.Close()
+		return buf, w.FormDataContentType()
+	}
+	wasm := []byte("\x00asm\x01\x00\x00\x00")
+	version := &models.FunctionVersion{
+		Namespace: "default", Name: "filter", Version: "v1", Runtime: "wasmedge", Handler: "filter",
+		Source: "minio", Bucket: "baetyl-cloud-u1", Object: "functions/default/filter/v1/sha.wasm",
+	}
+	sDraft.EXPECT().PublishBundle("u1", "default", "filter", &models.FunctionPublishRequest{Version: "v1"}, wasm).Return(version, nil)
+	body, contentType := form("v1", wasm)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/functiondrafts/filter/bundles", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	body, contentType = form("", wasm)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/functiondrafts/filter/bundles", body)
+	req.Header.Set("Content-Type", contentType)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	body, contentType = form("v1", nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/functiondrafts/filter/bundles", body)
+	req.Header.Set("Content-Type", contentType)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the wasm bundle is used as is on nodes
+	sDraft.EXPECT().GetVersion("default", "filter", "v1").Return(version, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/functiondrafts/filter/versions/v1/import", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	item := new(models.ConfigFunctionItem)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), item))
+	assert.Empty(t, item.Unpack)
+}
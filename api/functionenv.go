@@ -0,0 +1,29 @@
+package api
+
+import (
+	"strings"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// renderFunctionEnvs renders the envs declared by the function versions of service
+func renderFunctionEnvs(app *specV1.Application, svc *specV1.Service, versions []models.FunctionVersion) error {
+	var envs []models.FunctionEnv
+	for _, v := range versions {
+		envs = append(envs, v.Env...)
+	}
+	return service.ApplyFunctionEnvs(app, svc, envs)
+}
+
+// clearFunctionEnvSecrets removes the secrets bound to the envs of app, which are bound again by the rendering
+func clearFunctionEnvSecrets(labels map[string]string) {
+	for k := range labels {
+		if strings.HasPrefix(k, common.LabelPrefixEnvSecret) {
+			delete(labels, k)
+		}
+	}
+}
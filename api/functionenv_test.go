@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func TestRenderFunctionEnvs(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sConfig, sDraft := ms.NewMockConfigService(mockCtl), ms.NewMockFunctionDraftService(mockCtl)
+	api := &API{FunctionDraft: sDraft}
+	api.AppCombinedService = &service.AppCombinedService{Config: sConfig}
+
+	object := func(md map[string]string) string {
+		data, _ := json.Marshal(&specV1.ConfigurationObject{Metadata: md})
+		return string(data)
+	}
+	cfg := &specV1.Configuration{Name: "code", Namespace: "default", Data: map[string]string{
+		common.ConfigObjectPrefix + "infer":  object(map[string]string{"type": ConfigTypeFunction, "function": "infer", "version": "v1"}),
+		common.ConfigObjectPrefix + "lambda": object(map[string]string{"type": ConfigTypeFunction, "function": "lambda", "version": "1"}),
+		common.ConfigObjectPrefix + "model":  object(map[string]string{"type": ConfigTypeObject}),
+		"threshold":                          "30",
+	}}
+	app := &specV1.Application{
+		Name:      "app",
+		Namespace: "default",
+		Labels:    map[string]string{common.LabelPrefixEnvSecret + "OLD": "cred_old"},
+		Volumes:   []specV1.Volume{{Name: getNameOfFunctionCodeVolumeMount("infer"), VolumeSource: specV1.VolumeSource{Config: &specV1.ObjectReference{Name: "code"}}}},
+	}
+	svc := &specV1.Service{Name: "infer"}
+	sConfig.EXPECT().Get("default", "code", "").Return(cfg, nil).Times(2)
+	sDraft.EXPECT().GetVersion("default", "infer", "v1").Return(&models.FunctionVersion{Env: []models.FunctionEnv{
+		{Name: "LOG_LEVEL", Default: "info"},
+		{Name: "TOKEN", Required: true, Secret: &models.FunctionEnvSecret{Name: "cred", Key: "token"}},
+	}}, nil)
+	// the function imported from vendor has no version
+	sDraft.EXPECT().GetVersion("default", "lambda", "1").Return(nil, common.Error(common.ErrResourceNotFound))
+
+	clearFunctionEnvSecrets(app.Labels)
+	versions, err := api.functionVersionsOfService(app, svc)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 1)
+	assert.NoError(t, renderFunctionEnvs(app, svc, versions))
+	assert.Equal(t, []specV1.Environment{{Name: "LOG_LEVEL", Value: "info"}, {Name: "TOKEN"}}, svc.Env)
+	assert.Equal(t, map[string]string{common.LabelPrefixEnvSecret + "TOKEN": "cred_token"}, app.Labels)
+
+	sDraft.EXPECT().GetVersion("default", "infer", "v1").Return(&models.FunctionVersion{Env: []models.FunctionEnv{{Name: "PORT", Required: true}}}, nil)
+	sDraft.EXPECT().GetVersion("default", "lambda", "1").Return(nil, common.Error(common.ErrResourceNotFound))
+	versions, err = api.functionVersionsOfService(app, svc)
+	assert.NoError(t, err)
+	assert.Error(t, renderFunctionEnvs(app, svc, versions))
+
+	// the service without code config has no versions
+	versions, err = api.functionVersionsOfService(app, &specV1.Service{Name: "other"})
+	assert.NoError(t, err)
+	assert.Empty(t, versions)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// renderFunctionFlavor runs the CUDA-enabled image of runtime for the service with any function version of gpu flavor,
+// and requests a GPU of device plugin unless the service requests the resource itself. It returns whether the service
+// is of gpu flavor, so that the GPU inventory of nodes is checked once the services are rendered
+func (api *API) renderFunctionFlavor(app *specV1.Application, svc *specV1.Service, versions []models.FunctionVersion) (bool, error) {
+	gpu := false
+	for _, v := range versions {
+		if v.Flavor == models.FunctionFlavorGPU {
+			gpu = true
+			break
+		}
+	}
+	if !gpu {
+		return false, nil
+	}
+	if app.Mode == context.RunModeNative {
+		return false, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the functions of gpu flavor can't run in native mode"))
+	}
+	runtime, err := api.Func.GetFunctionRuntime(svc.FunctionConfig.Runtime)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return false, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the runtime "+svc.FunctionConfig.Runtime+" of gpu flavor is not registered"))
+		}
+		return false, err
+	}
+	if runtime.GPUImage == "" {
+		return false, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the runtime "+runtime.Name+" has no image of gpu flavor"))
+	}
+	svc.Image = runtime.GPUImage
+
+	if svc.Resources == nil {
+		svc.Resources = &specV1.Resources{}
+	}
+	_, requested := svc.Resources.Requests[models.FunctionGPUResource]
+	_, limited := svc.Resources.Limits[models.FunctionGPUResource]
+	if !requested && !limited {
+		if svc.Resources.Limits == nil {
+			svc.Resources.Limits = map[string]string{}
+		}
+		svc.Resources.Limits[models.FunctionGPUResource] = "1"
+	}
+	return true, nil
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestRenderFunctionFlavor(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sFunc, sNode := ms.NewMockFunctionService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api := &API{Func: sFunc, Node: sNode, log: log.L()}
+
+	gpu := []models.FunctionVersion{{Name: "pre"}, {Name: "infer", Flavor: models.FunctionFlavorGPU}}
+	app := &specV1.Application{Name: "app", Namespace: "default", Mode: context.RunModeKube, Selector: "gpu=true"}
+	svc := &specV1.Service{Name: "infer", Image: "baetyltech/python:3.9", FunctionConfig: &specV1.ServiceFunctionConfig{Runtime: "python3.9"}}
+
+	// the versions of cpu flavor keep the image of runtime
+	ok, err := api.renderFunctionFlavor(app, svc, gpu[:1])
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, svc.Resources)
+
+	sFunc.EXPECT().GetFunctionRuntime("python3.9").Return(&models.FunctionRuntime{Name: "python3.9", Image: "baetyltech/python:3.9", GPUImage: "baetyltech/python-cuda:3.9"}, nil)
+	ok, err = api.renderFunctionFlavor(app, svc, gpu)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "baetyltech/python-cuda:3.9", svc.Image)
+	assert.Equal(t, map[string]string{models.FunctionGPUResource: "1"}, svc.Resources.Limits)
+
+	// the GPUs requested by service are kept
+	svc.Resources = &specV1.Resources{Requests: map[string]string{models.FunctionGPUResource: "2"}}
+	sFunc.EXPECT().GetFunctionRuntime("python3.9").Return(&models.FunctionRuntime{Name: "python3.9", GPUImage: "baetyltech/python-cuda:3.9"}, nil)
+	_, err = api.renderFunctionFlavor(app, svc, gpu)
+	assert.NoError(t, err)
+	assert.Empty(t, svc.Resources.Limits)
+	app.Services = []specV1.Service{*svc}
+
+	opt := &models.ListOptions{LabelSelector: "gpu=true"}
+	sNode.EXPECT().List("default", opt).Return(&models.NodeList{Items: []specV1.Node{genAcceleratorNode("node01", 1)}}, nil)
+	err = api.checkNodeAccelerators(app.Namespace, app.Selector, appAcceleratorRequests(app))
+	assert.Equal(t, common.ErrNodeAcceleratorShort, err.(errors.Coder).Code())
+
+	sFunc.EXPECT().GetFunctionRuntime("python3.9").Return(&models.FunctionRuntime{Name: "python3.9"}, nil)
+	_, err = api.renderFunctionFlavor(app, svc, gpu)
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+
+	sFunc.EXPECT().GetFunctionRuntime("python3.9").Return(nil, common.Error(common.ErrResourceNotFound))
+	_, err = api.renderFunctionFlavor(app, svc, gpu)
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+
+	app.Mode = context.RunModeNative
+	_, err = api.renderFunctionFlavor(app, svc, gpu)
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+}
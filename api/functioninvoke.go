@@ -0,0 +1,136 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// FunctionInvokeTimeout the max time to wait for the node to return the result of function
+const FunctionInvokeTimeout = 30 * time.Second
+
+// InvokeFunction invokes the function with the test payload and returns the result, the logs and the duration.
+// The draft or the version of function runs in the cloud sandbox, unless the node is given, in which case
+// the function of app running on the node is invoked through the sync link
+func (api *API) InvokeFunction(c *common.Context) (interface{}, error) {
+	req := new(models.FunctionInvokeRequest)
+	if err := c.LoadBody(req); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	// the name of function shares the wildcard of source in the routes of functions
+	req.Namespace, req.Name = c.GetNamespace(), c.Param("source")
+	if req.Node == "" {
+		return api.FunctionDraft.Invoke(c.GetUser().ID, req)
+	}
+	return api.invokeNodeFunction(c, req)
+}
+
+func (api *API) invokeNodeFunction(c *common.Context, req *models.FunctionInvokeRequest) (*models.FunctionInvokeResult, error) {
+	err := api.Auth.Verify(c, &plugin.PermissionRequest{
+		Resource:   plugin.PermissionResourceApp,
+		Permission: []string{plugin.PermissionFull},
+		RequestContext: plugin.RequestContext{
+			IpAddress: c.ClientIP(),
+			Referer:   c.Request.Referer(),
+		},
+	})
+	if err != nil {
+		return nil, common.Error(common.ErrRequestAccessDenied, common.Field("error", err.Error()))
+	}
+	if req.App == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the app of function is required to invoke it on node"))
+	}
+	if _, err = api.Node.Get(nil, req.Namespace, req.Node); err != nil {
+		return nil, err
+	}
+	app, err := api.getVisibleApp(req.Namespace, req.App)
+	if err != nil {
+		return nil, err
+	}
+	if app.Type != common.FunctionApp {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the app "+req.App+" is not a function app"))
+	}
+
+	user := c.GetUser()
+	if user.ID == "" {
+		user.ID = user.Name
+	}
+	_, requestID := c.GetTrace()
+	session := &models.ExecSession{
+		ID:        common.UUIDPrune(),
+		Namespace: req.Namespace,
+		Node:      req.Node,
+		User:      user.ID,
+		RequestId: requestID,
+		ExecRequest: models.ExecRequest{
+			App:      req.App,
+			Function: &models.FunctionCall{Name: req.Name, Payload: req.Payload},
+		},
+		CreateTime: time.Now().UTC(),
+	}
+	start := time.Now()
+	output, err := api.Exec.Open(session)
+	if err != nil {
+		return nil, err
+	}
+	defer api.closeExecSession(session)
+	return readFunctionResult(session, output, start)
+}
+
+// readFunctionResult collects the result and the logs of function sent by node until the exit frame
+func readFunctionResult(session *models.ExecSession, output <-chan models.ExecFrame, start time.Time) (*models.FunctionInvokeResult, error) {
+	var stdout, stderr bytes.Buffer
+	timer := time.NewTimer(FunctionInvokeTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			return nil, common.Error(common.ErrRequestTimeout)
+		case frame, ok := <-output:
+			if !ok {
+				return nil, errors.Errorf("the node closed the invocation of function without result")
+			}
+			switch frame.Type {
+			case models.ExecFrameStdout:
+				stdout.Write(frame.Data)
+			case models.ExecFrameStderr:
+				stderr.Write(frame.Data)
+			case models.ExecFrameExit:
+				code := frame.Code
+				session.ExitCode = &code
+				res := &models.FunctionInvokeResult{
+					Logs:     stderr.String(),
+					Duration: time.Since(start).Milliseconds(),
+				}
+				if code != 0 {
+					res.Error = string(frame.Data)
+					if res.Error == "" {
+						res.Error = "the function exited with code " + strconv.Itoa(code)
+					}
+					return res, nil
+				}
+				res.Result = functionOutput(stdout.Bytes())
+				return res, nil
+			}
+		}
+	}
+}
+
+// functionOutput returns the output of function as json, the output which is not json is returned as a string
+func functionOutput(data []byte) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+	if json.Valid(data) {
+		return data
+	}
+	res, _ := json.Marshal(string(data))
+	return res
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initFunctionInvokeAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) {
+		common.NewContext(c).SetNamespace("default")
+		common.NewContext(c).SetUser(common.User{ID: "u1"})
+	}
+	router.POST("/v1/functions/:source/invoke", mockIM, common.Wrapper(api.InvokeFunction))
+	return api, router, mockCtl
+}
+
+func TestInvokeFunctionInSandbox(t *testing.T) {
+	api, router, mockCtl := initFunctionInvokeAPI(t)
+	defer mockCtl.Finish()
+	sDraft := ms.NewMockFunctionDraftService(mockCtl)
+	api.FunctionDraft = sDraft
+
+	sDraft.EXPECT().Invoke("u1", gomock.Any()).DoAndReturn(func(_ string, r *models.FunctionInvokeRequest) (*models.FunctionInvokeResult, error) {
+		assert.Equal(t, "infer", r.Name)
+		assert.Equal(t, "v1", r.Version)
+		return &models.FunctionInvokeResult{Result: r.Payload, Duration: 8}, nil
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/functions/infer/invoke", bytes.NewReader([]byte(`{"version":"v1","payload":{"a":1}}`)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.FunctionInvokeResult)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.JSONEq(t, `{"a":1}`, string(res.Result))
+	assert.Equal(t, int64(8), res.Duration)
+}
+
+func TestInvokeFunctionOnNode(t *testing.T) {
+	api, router, mockCtl := initFunctionInvokeAPI(t)
+	defer mockCtl.Finish()
+	sAuth, sApp := ms.NewMockAuthService(mockCtl), ms.NewMockApplicationService(mockCtl)
+	sNode, sExec := ms.NewMockNodeService(mockCtl), ms.NewMockExecService(mockCtl)
+	api.Auth, api.Node, api.Exec = sAuth, sNode, sExec
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	sAuth.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *common.Context, pr *plugin.PermissionRequest) error {
+		assert.Equal(t, plugin.PermissionResourceApp, pr.Resource)
+		assert.Equal(t, []string{plugin.PermissionFull}, pr.Permission)
+		return nil
+	}).AnyTimes()
+	sNode.EXPECT().Get(nil, "default", "n1").Return(&specV1.Node{Name: "n1"}, nil).AnyTimes()
+	sApp.EXPECT().Get("default", "rules", "").Return(&specV1.Application{Name: "rules", Type: common.FunctionApp}, nil).AnyTimes()
+	sApp.EXPECT().Get("default", "web", "").Return(&specV1.Application{Name: "web", Type: common.ContainerApp}, nil).AnyTimes()
+
+	output := make(chan models.ExecFrame, 4)
+	output <- models.ExecFrame{Type: models.ExecFrameStderr, Data: []byte("received\n")}
+	output <- models.ExecFrame{Type: models.ExecFrameStdout, Data: []byte(`{"alarm":`)}
+	output <- models.ExecFrame{Type: models.ExecFrameStdout, Data: []byte(`true}`)}
+	output <- models.ExecFrame{Type: models.ExecFrameExit}
+	sExec.EXPECT().Open(gomock.Any()).DoAndReturn(func(s *models.ExecSession) (<-chan models.ExecFrame, error) {
+		assert.Equal(t, "n1", s.Node)
+		assert.Equal(t, "rules", s.App)
+		assert.Equal(t, &models.FunctionCall{Name: "filter", Payload: []byte(`{"temp":80}`)}, s.Function)
+		return output, nil
+	})
+	sExec.EXPECT().Close(gomock.Any()).Return(nil)
+	body := []byte(`{"node":"n1","app":"rules","payload":{"temp":80}}`)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/functions/filter/invoke", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.FunctionInvokeResult)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.JSONEq(t, `{"alarm":true}`, string(res.Result))
+	assert.Equal(t, "received\n", res.Logs)
+
+	// the error raised by the function
+	output = make(chan models.ExecFrame, 2)
+	output <- models.ExecFrame{Type: models.ExecFrameStdout, Data: []byte("partial")}
+	output <- models.ExecFrame{Type: models.ExecFrameExit, Code: 1, Data: []byte("TypeError: temp is undefined")}
+	sExec.EXPECT().Open(gomock.Any()).Return(output, nil)
+	sExec.EXPECT().Close(gomock.Any()).Return(nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/functions/filter/invoke", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res = new(models.FunctionInvokeResult)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, "TypeError: temp is undefined", res.Error)
+	assert.Nil(t, res.Result)
+
+	for _, b := range []string{
+		`{"node":"n1"}`,
+		`{"node":"n1","app":"web"}`,
+	} {
+		req, _ = http.NewRequest(http.MethodPost, "/v1/functions/filter/invoke", bytes.NewReader([]byte(b)))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, b)
+	}
+}
+
+func TestFunctionOutput(t *testing.T) {
+	assert.Nil(t, functionOutput(nil))
+	assert.Equal(t, `{"a":1}`, string(functionOutput([]byte(`{"a":1}`))))
+	assert.Equal(t, `"hello\n"`, string(functionOutput([]byte("hello\n"))))
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetFunctionRuntime get the function runtime
+func (api *API) GetFunctionRuntime(c *common.Context) (interface{}, error) {
+	return api.Func.GetFunctionRuntime(c.GetNameFromParam())
+}
+
+// ListFunctionRuntime list the function runtimes registered
+func (api *API) ListFunctionRuntime(c *common.Context) (interface{}, error) {
+	runtimes, err := api.Func.ListFunctionRuntimes()
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(runtimes), runtimes, ""), nil
+}
+
+// CreateFunctionRuntime registers the function runtime, the function apps can use it without redeploying the cloud
+func (api *API) CreateFunctionRuntime(c *common.Context) (interface{}, error) {
+	runtime, err := api.parseFunctionRuntime(c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = api.Func.GetFunctionRuntime(runtime.Name); err == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "this name is already in use"))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, err
+	}
+	return api.Func.CreateFunctionRuntime(runtime)
+}
+
+// UpdateFunctionRuntime updates the function runtime, the function apps saved after use the new image
+func (api *API) UpdateFunctionRuntime(c *common.Context) (interface{}, error) {
+	runtime, err := api.parseFunctionRuntime(c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = api.Func.GetFunctionRuntime(runtime.Name); err != nil {
+		return nil, err
+	}
+	return api.Func.UpdateFunctionRuntime(runtime)
+}
+
+// DeleteFunctionRuntime delete the function runtime
+func (api *API) DeleteFunctionRuntime(c *common.Context) (interface{}, error) {
+	name := c.GetNameFromParam()
+	if _, err := api.Func.GetFunctionRuntime(name); err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return nil, api.Func.DeleteFunctionRuntime(name)
+}
+
+func (api *API) parseFunctionRuntime(c *common.Context) (*models.FunctionRuntime, error) {
+	runtime := new(models.FunctionRuntime)
+	runtime.Name = c.GetNameFromParam()
+	if err := c.LoadBody(runtime); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if name := c.GetNameFromParam(); name != "" {
+		runtime.Name = name
+	}
+	if runtime.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	return runtime, nil
+}
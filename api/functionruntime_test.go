@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initFunctionRuntimeAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	v1 := router.Group("v1")
+	{
+		runtime := v1.Group("/function-runtimes")
+		runtime.GET("", common.Wrapper(api.ListFunctionRuntime))
+		runtime.GET("/:name", common.Wrapper(api.GetFunctionRuntime))
+		runtime.POST("", common.Wrapper(api.CreateFunctionRuntime))
+		runtime.PUT("/:name", common.Wrapper(api.UpdateFunctionRuntime))
+		runtime.DELETE("/:name", common.Wrapper(api.DeleteFunctionRuntime))
+	}
+	return api, router, mockCtl
+}
+
+func TestCreateFunctionRuntime(t *testing.T) {
+	api, router, mockCtl := initFunctionRuntimeAPI(t)
+	defer mockCtl.Finish()
+	sFunc := ms.NewMockFunctionService(mockCtl)
+	api.Func = sFunc
+
+	runtime := &models.FunctionRuntime{Name: "node20", Language: "nodejs", Version: "20", Image: "baetyltech/node:20", Handler: "index.handler"}
+	sFunc.EXPECT().GetFunctionRuntime("node20").Return(nil, common.Error(common.ErrResourceNotFound))
+	sFunc.EXPECT().CreateFunctionRuntime(gomock.Any()).DoAndReturn(func(r *models.FunctionRuntime) (*models.FunctionRuntime, error) {
+		assert.Equal(t, runtime, r)
+		return r, nil
+	})
+	body, _ := json.Marshal(runtime)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/function-runtimes", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "baetyltech/node:20")
+
+	sFunc.EXPECT().GetFunctionRuntime("node20").Return(runtime, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/function-runtimes", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	for _, r := range []*models.FunctionRuntime{
+		{Name: "Node20", Language: "nodejs", Version: "20", Image: "baetyltech/node:20"},
+		{Name: "node20", Language: "nodejs", Version: "20"},
+		{Language: "nodejs", Version: "20", Image: "baetyltech/node:20"},
+	} {
+		body, _ = json.Marshal(r)
+		req, _ = http.NewRequest(http.MethodPost, "/v1/function-runtimes", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestUpdateFunctionRuntime(t *testing.T) {
+	api, router, mockCtl := initFunctionRuntimeAPI(t)
+	defer mockCtl.Finish()
+	sFunc := ms.NewMockFunctionService(mockCtl)
+	api.Func = sFunc
+
+	runtime := &models.FunctionRuntime{Language: "nodejs", Version: "20", Image: "baetyltech/node:20.1"}
+	sFunc.EXPECT().GetFunctionRuntime("node20").Return(&models.FunctionRuntime{Name: "node20"}, nil)
+	sFunc.EXPECT().UpdateFunctionRuntime(gomock.Any()).DoAndReturn(func(r *models.FunctionRuntime) (*models.FunctionRuntime, error) {
+		assert.Equal(t, "node20", r.Name)
+		assert.Equal(t, "baetyltech/node:20.1", r.Image)
+		return r, nil
+	})
+	body, _ := json.Marshal(runtime)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/function-runtimes/node20", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sFunc.EXPECT().GetFunctionRuntime("node18").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodPut, "/v1/function-runtimes/node18", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListAndDeleteFunctionRuntime(t *testing.T) {
+	api, router, mockCtl := initFunctionRuntimeAPI(t)
+	defer mockCtl.Finish()
+	sFunc := ms.NewMockFunctionService(mockCtl)
+	api.Func = sFunc
+
+	sFunc.EXPECT().ListFunctionRuntimes().Return([]models.FunctionRuntime{{Name: "go1.21"}, {Name: "node20"}}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/function-runtimes", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(common.ListResponse)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, 2, res.Total)
+
+	sFunc.EXPECT().GetFunctionRuntime("node20").Return(&models.FunctionRuntime{Name: "node20"}, nil)
+	sFunc.EXPECT().DeleteFunctionRuntime("node20").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/function-runtimes/node20", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// deleting the runtime not found is idempotent
+	sFunc.EXPECT().GetFunctionRuntime("node20").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/function-runtimes/node20", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/jinzhu/copier"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// checkFunctionScale checks the scale of function app, the app and its services start with the min replicas
+func checkFunctionScale(app *models.ApplicationView) error {
+	scale := app.FunctionScale
+	if scale == nil {
+		return nil
+	}
+	if app.Type != common.FunctionApp {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "functionScale is only supported by function app"))
+	}
+	if app.Workload != specV1.WorkloadDeployment {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "functionScale is only supported by the app in deployment workload"))
+	}
+	if scale.MinReplicas <= 0 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "minReplicas must be greater than 0"))
+	}
+	if scale.MaxReplicas < scale.MinReplicas {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "minReplicas must be less than maxReplicas"))
+	}
+	if scale.TargetConcurrency <= 0 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "targetConcurrency must be greater than 0"))
+	}
+	app.Replica = scale.MinReplicas
+	for i := range app.Services {
+		app.Services[i].Replica = scale.MinReplicas
+	}
+	return nil
+}
+
+// translateFunctionScale renders the scale of function app into the autoscaling of app in kube mode, which the HPA
+// is created by. The metrics of autoScaleCfg are kept if set, or the default ones of HPA are used
+func translateFunctionScale(appView *models.ApplicationView, app *specV1.Application) error {
+	if appView.FunctionScale == nil || app.Mode != context.RunModeKube {
+		return nil
+	}
+	if app.AutoScaleCfg == nil {
+		app.AutoScaleCfg = new(specV1.AutoScaleCfg)
+	}
+	return errors.Trace(copier.Copy(app.AutoScaleCfg, appView.FunctionScale))
+}
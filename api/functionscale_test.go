@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestCheckFunctionScale(t *testing.T) {
+	app := &models.ApplicationView{
+		Type:     common.FunctionApp,
+		Workload: specV1.WorkloadDeployment,
+		Replica:  1,
+		Services: []specV1.Service{{Name: "infer", Replica: 1}},
+	}
+	assert.NoError(t, checkFunctionScale(app))
+
+	app.FunctionScale = &models.FunctionScale{MinReplicas: 2, MaxReplicas: 5, TargetConcurrency: 4}
+	assert.NoError(t, checkFunctionScale(app))
+	assert.Equal(t, 2, app.Replica)
+	assert.Equal(t, 2, app.Services[0].Replica)
+
+	for _, scale := range []*models.FunctionScale{
+		{MinReplicas: 0, MaxReplicas: 5, TargetConcurrency: 4},
+		{MinReplicas: 3, MaxReplicas: 2, TargetConcurrency: 4},
+		{MinReplicas: 1, MaxReplicas: 2, TargetConcurrency: 0},
+	} {
+		app.FunctionScale = scale
+		assert.Error(t, checkFunctionScale(app))
+	}
+
+	app.FunctionScale = &models.FunctionScale{MinReplicas: 1, MaxReplicas: 2, TargetConcurrency: 1}
+	app.Workload = specV1.WorkloadDaemonSet
+	assert.Error(t, checkFunctionScale(app))
+	app.Workload, app.Type = specV1.WorkloadDeployment, common.ContainerApp
+	assert.Error(t, checkFunctionScale(app))
+}
+
+func TestTranslateFunctionScale(t *testing.T) {
+	view := &models.ApplicationView{
+		FunctionScale: &models.FunctionScale{MinReplicas: 1, MaxReplicas: 4, TargetConcurrency: 8},
+	}
+	app := &specV1.Application{Mode: context.RunModeKube}
+	assert.NoError(t, translateFunctionScale(view, app))
+	assert.NotNil(t, app.AutoScaleCfg)
+	assert.EqualValues(t, 1, app.AutoScaleCfg.MinReplicas)
+	assert.EqualValues(t, 4, app.AutoScaleCfg.MaxReplicas)
+
+	// the edge in native mode scales the app with the hint in function config
+	app = &specV1.Application{Mode: context.RunModeNative}
+	assert.NoError(t, translateFunctionScale(view, app))
+	assert.Nil(t, app.AutoScaleCfg)
+
+	app = &specV1.Application{Name: "infer", Mode: context.RunModeNative}
+	service := &specV1.Service{Name: "resnet", Functions: []specV1.ServiceFunction{{Name: "classify", Handler: "index.handler"}}}
+	cfg, err := generateConfigOfFunctionService(service, app, view.FunctionScale)
+	assert.NoError(t, err)
+	res := new(models.ServiceFunction)
+	assert.NoError(t, json.Unmarshal([]byte(cfg.Data[FunctionDefaultConfigFile]), res))
+	assert.Equal(t, view.FunctionScale, res.Scale)
+	assert.Equal(t, service.Functions, res.Functions)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"strconv"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// the size of the pages of wasm memory
+const wasmPageSize = 64 << 10
+
+// renderFunctionWasm maps the memory limit of the service running wasm bundles to the page limit of modules,
+// so that the modules fail to grow memory instead of the container being killed
+func renderFunctionWasm(svc *specV1.Service, versions []models.FunctionVersion) error {
+	wasm := false
+	for i := range versions {
+		if versions[i].IsWasm() {
+			wasm = true
+			break
+		}
+	}
+	if !wasm || svc.Resources == nil {
+		return nil
+	}
+	limit, ok := svc.Resources.Limits["memory"]
+	if !ok {
+		return nil
+	}
+	q, err := resource.ParseQuantity(limit)
+	if err != nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the memory limit "+limit+" of service "+svc.Name+" is invalid"))
+	}
+	pages := q.Value() / wasmPageSize
+	if pages < 1 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the memory limit of service "+svc.Name+" is less than a page of wasm"))
+	}
+	value := strconv.FormatInt(pages, 10)
+	for i := range svc.Env {
+		if svc.Env[i].Name == common.EnvWasmMemoryPageLimit {
+			svc.Env[i].Value = value
+			return nil
+		}
+	}
+	svc.Env = append(svc.Env, specV1.Environment{Name: common.EnvWasmMemoryPageLimit, Value: value})
+	return nil
+}
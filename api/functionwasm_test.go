@@ -0,0 +1,39 @@
+package api
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestRenderFunctionWasm(t *testing.T) {
+	wasm := []models.FunctionVersion{{Name: "filter", Object: "functions/default/filter/v1/sha.wasm"}}
+	zip := []models.FunctionVersion{{Name: "infer", Object: "functions/default/infer/v1/sha.zip"}}
+	limits := func(memory string) *specV1.Resources {
+		return &specV1.Resources{Limits: map[string]string{"memory": memory}}
+	}
+
+	svc := &specV1.Service{Name: "filter", Resources: limits("64Mi")}
+	assert.NoError(t, renderFunctionWasm(svc, wasm))
+	assert.Equal(t, []specV1.Environment{{Name: common.EnvWasmMemoryPageLimit, Value: "1024"}}, svc.Env)
+
+	// the limit is updated with the memory limit
+	svc.Resources = limits("1Gi")
+	assert.NoError(t, renderFunctionWasm(svc, wasm))
+	assert.Equal(t, []specV1.Environment{{Name: common.EnvWasmMemoryPageLimit, Value: "16384"}}, svc.Env)
+
+	svc = &specV1.Service{Name: "infer", Resources: limits("64Mi")}
+	assert.NoError(t, renderFunctionWasm(svc, zip))
+	assert.Empty(t, svc.Env)
+
+	svc = &specV1.Service{Name: "filter"}
+	assert.NoError(t, renderFunctionWasm(svc, wasm))
+	assert.Empty(t, svc.Env)
+
+	assert.Error(t, renderFunctionWasm(&specV1.Service{Name: "filter", Resources: limits("32Ki")}, wasm))
+	assert.Error(t, renderFunctionWasm(&specV1.Service{Name: "filter", Resources: limits("lots")}, wasm))
+}
@@ -0,0 +1,323 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// garbageRefs the owners and references found by the scan of namespaces, the shadows, certificates and objects
+// are judged by them once all namespaces are scanned
+type garbageRefs struct {
+	// nodes the nodes of the namespaces scanned
+	nodes map[string]map[string]bool
+	// certs the ids of the certificates referenced by secrets
+	certs map[string]bool
+	// objects the keys of the objects referenced by configs, and the buckets storing them
+	objects map[string]bool
+	buckets map[string]models.GarbageItem
+}
+
+// GetGarbageReport gets the latest report of the resources without owners
+func (api *API) GetGarbageReport(c *common.Context) (interface{}, error) {
+	report := api.Garbage.Get()
+	if report == nil {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "garbage report"))
+	}
+	return report, nil
+}
+
+// RescanGarbage scans the resources without owners now instead of at the next interval
+func (api *API) RescanGarbage(c *common.Context) (interface{}, error) {
+	report := api.ScanGarbage()
+	api.Garbage.Set(report)
+	return report, nil
+}
+
+// CleanGarbage deletes the resources of the latest report, which is confirmed by its id. The resources are scanned
+// again before the cleanup and only the ones still without owners are deleted, the report is used once
+func (api *API) CleanGarbage(c *common.Context) (interface{}, error) {
+	cleanup := new(models.GarbageCleanup)
+	if err := c.LoadBody(cleanup); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	latest := api.Garbage.Get()
+	if latest == nil || latest.ID != cleanup.Report {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the report is not the latest one, review the latest report and confirm it by its id"))
+	}
+	confirmed := map[string]bool{}
+	for i := range latest.Items {
+		confirmed[latest.Items[i].Key()] = true
+	}
+
+	fresh := api.ScanGarbage()
+	res := &models.GarbageCleanupResult{Deleted: []string{}}
+	remained := fresh.Items
+	fresh.Items, fresh.Total, fresh.Counts = []models.GarbageItem{}, 0, map[string]int{}
+	for i := range remained {
+		item := &remained[i]
+		key := item.Key()
+		if !confirmed[key] || !cleanup.Cleans(item.Kind) {
+			fresh.Add(*item)
+			continue
+		}
+		if err := api.deleteGarbage(item); err != nil {
+			if res.Failures == nil {
+				res.Failures = map[string]string{}
+			}
+			res.Failures[key] = err.Error()
+			fresh.Add(*item)
+			continue
+		}
+		res.Deleted = append(res.Deleted, key)
+	}
+	api.Garbage.Set(fresh)
+	log.L().Info("garbage cleaned", log.Any("report", cleanup.Report), log.Any("deleted", len(res.Deleted)), log.Any("failures", len(res.Failures)))
+	return res, nil
+}
+
+// RunGarbageScan scans the resources without owners at once and then every interval until done is closed
+func (api *API) RunGarbageScan(interval time.Duration, done <-chan struct{}) {
+	if interval <= 0 || api.Garbage == nil {
+		return
+	}
+	api.Garbage.Set(api.ScanGarbage())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			api.Garbage.Set(api.ScanGarbage())
+		}
+	}
+}
+
+// ScanGarbage finds the resources without owners older than the min age of garbage service. The failure of one
+// namespace is recorded in the errors of report, the certificates and objects are not judged then since their
+// references may be missed
+func (api *API) ScanGarbage() *models.GarbageReport {
+	now := time.Now().UTC()
+	before := now.Add(-api.Garbage.MinAge())
+	res := &models.GarbageReport{
+		ID:     common.UUIDPrune(),
+		Counts: map[string]int{},
+		Items:  []models.GarbageItem{},
+		Time:   now,
+	}
+	failed := func(part string, err error) {
+		res.Errors = append(res.Errors, fmt.Sprintf("failed to scan %s: %s", part, err.Error()))
+	}
+	refs := &garbageRefs{
+		nodes:   map[string]map[string]bool{},
+		certs:   map[string]bool{},
+		objects: map[string]bool{},
+		buckets: map[string]models.GarbageItem{},
+	}
+	list, err := api.NS.List(&models.ListOptions{})
+	if err != nil {
+		failed("namespaces", err)
+		return api.finishGarbageScan(res, now)
+	}
+	namespaces, complete := map[string]bool{}, true
+	for _, item := range list.Items {
+		namespaces[item.Name] = true
+		if err = api.scanNamespaceGarbage(item.Name, before, refs, res); err != nil {
+			failed("namespace "+item.Name, err)
+			complete = false
+		}
+	}
+
+	if shadows, err := api.Garbage.ListGarbageShadow(); err != nil {
+		failed("shadows", err)
+	} else {
+		for _, shadow := range shadows {
+			if !shadow.Time.Before(before) {
+				continue
+			}
+			if !namespaces[shadow.Namespace] {
+				shadow.Reason = "the namespace of shadow no longer exists"
+				res.Add(shadow)
+			} else if nodes, ok := refs.nodes[shadow.Namespace]; ok && !nodes[shadow.Name] {
+				res.Add(shadow)
+			}
+		}
+	}
+	if !complete {
+		return api.finishGarbageScan(res, now)
+	}
+	if certs, err := api.Garbage.ListGarbageCert(before); err != nil {
+		failed("certificates", err)
+	} else {
+		for _, cert := range certs {
+			if !refs.certs[cert.Name] {
+				res.Add(cert)
+			}
+		}
+	}
+	for _, bucket := range refs.buckets {
+		if err = api.scanObjectGarbage(bucket, before, refs, res); err != nil {
+			failed("bucket "+bucket.Bucket, err)
+		}
+	}
+	return api.finishGarbageScan(res, now)
+}
+
+func (api *API) finishGarbageScan(res *models.GarbageReport, now time.Time) *models.GarbageReport {
+	res.Elapsed = time.Since(now).Milliseconds()
+	if len(res.Errors) > 0 {
+		api.log.Warn("failed to scan part of garbage", log.Any("errors", res.Errors))
+	}
+	return res
+}
+
+// scanNamespaceGarbage reports the system resources of the nodes deleted, and collects the references of namespace
+func (api *API) scanNamespaceGarbage(ns string, before time.Time, refs *garbageRefs, res *models.GarbageReport) error {
+	nodes, err := api.Node.List(ns, &models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	names := map[string]bool{}
+	for i := range nodes.Items {
+		names[nodes.Items[i].Name] = true
+	}
+	// the system resources belong to the node labeled, they are garbage once the node is deleted
+	orphaned := func(labels map[string]string, created time.Time) (string, bool) {
+		node := labels[common.LabelNodeName]
+		return node, CheckIsSysResources(labels) && node != "" && !names[node] && created.Before(before)
+	}
+
+	apps, err := api.App.List(ns, &models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, app := range apps.Items {
+		// the system apps select their nodes instead of being labeled
+		node := strings.TrimPrefix(app.Selector, common.LabelNodeName+"=")
+		if !app.System || node == app.Selector || strings.ContainsAny(node, ",!=") {
+			continue
+		}
+		if !names[node] && app.CreationTimestamp.Before(before) {
+			res.Add(models.GarbageItem{Kind: models.GarbageApp, Namespace: ns, Name: app.Name,
+				Reason: fmt.Sprintf("the node (%s) of system app no longer exists", node), Time: app.CreationTimestamp.UTC()})
+		}
+	}
+
+	configs, err := api.Config.List(ns, &models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range configs.Items {
+		cfg := &configs.Items[i]
+		if node, ok := orphaned(cfg.Labels, cfg.CreationTimestamp); ok {
+			res.Add(models.GarbageItem{Kind: models.GarbageConfig, Namespace: ns, Name: cfg.Name,
+				Reason: fmt.Sprintf("the node (%s) of system config no longer exists", node), Time: cfg.CreationTimestamp.UTC()})
+		}
+		collectObjectRefs(cfg, refs)
+	}
+
+	secrets, err := api.Secret.List(ns, &models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if node, ok := orphaned(secret.Labels, secret.CreationTimestamp); ok {
+			res.Add(models.GarbageItem{Kind: models.GarbageSecret, Namespace: ns, Name: secret.Name,
+				Reason: fmt.Sprintf("the node (%s) of system secret no longer exists", node), Time: secret.CreationTimestamp.UTC()})
+		}
+		// the certificates of the secrets reported are deleted along with them
+		if certID, ok := secret.Annotations[common.AnnotationPkiCertID]; ok {
+			refs.certs[certID] = true
+		}
+	}
+	refs.nodes[ns] = names
+	return nil
+}
+
+// collectObjectRefs collects the objects offloaded from the items of config, see ConfigObjectService
+func collectObjectRefs(cfg *specV1.Configuration, refs *garbageRefs) {
+	for k, v := range cfg.Data {
+		if !strings.HasPrefix(k, common.ConfigObjectPrefix) {
+			continue
+		}
+		var obj specV1.ConfigurationObject
+		if err := json.Unmarshal([]byte(v), &obj); err != nil {
+			continue
+		}
+		bucket := models.GarbageItem{Kind: models.GarbageObject, Source: obj.Metadata["source"], Bucket: obj.Metadata["bucket"], UserID: obj.Metadata["userID"]}
+		if bucket.Source == "" || bucket.Bucket == "" {
+			continue
+		}
+		bucket.Name = obj.Metadata["object"]
+		refs.objects[bucket.Key()] = true
+		bucket.Name = ""
+		refs.buckets[bucket.Key()] = bucket
+	}
+}
+
+// scanObjectGarbage reports the objects offloaded by cloud which no config references, the ones uploaded by users
+// are never reported. Only the buckets referenced by configs are scanned
+func (api *API) scanObjectGarbage(bucket models.GarbageItem, before time.Time, refs *garbageRefs, res *models.GarbageReport) error {
+	objects, err := api.Obj.ListInternalBucketObjects(bucket.UserID, bucket.Bucket, bucket.Source)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objects.Contents {
+		// configs/<namespace>/<config>/<md5>/<key>
+		parts := strings.SplitN(obj.Key, "/", 5)
+		if len(parts) != 5 || parts[0] != "configs" || !obj.LastModified.Before(before) {
+			continue
+		}
+		item := bucket
+		item.Namespace, item.Name, item.Size, item.Time = parts[1], obj.Key, obj.Size, obj.LastModified.UTC()
+		if refs.objects[item.Key()] {
+			continue
+		}
+		item.Reason = fmt.Sprintf("the object of config (%s) is referenced by no config", parts[2])
+		res.Add(item)
+	}
+	return nil
+}
+
+func (api *API) deleteGarbage(item *models.GarbageItem) error {
+	var err error
+	switch item.Kind {
+	case models.GarbageApp:
+		if err = api.App.Delete(nil, item.Namespace, item.Name, ""); err == nil {
+			err = api.Index.RefreshNodesIndexByApp(nil, item.Namespace, item.Name, make([]string, 0))
+		}
+	case models.GarbageConfig:
+		err = api.Config.Delete(nil, item.Namespace, item.Name)
+	case models.GarbageSecret:
+		var secret *specV1.Secret
+		if secret, err = api.Secret.Get(item.Namespace, item.Name, ""); err == nil {
+			if certID, ok := secret.Annotations[common.AnnotationPkiCertID]; ok {
+				if err = api.PKI.DeleteClientCertificate(certID); err != nil {
+					return err
+				}
+			}
+			err = api.Secret.Delete(item.Namespace, item.Name)
+		}
+	case models.GarbageObject:
+		err = api.Obj.DeleteInternalObject(item.UserID, item.Bucket, item.Name, item.Source)
+	case models.GarbageCert:
+		err = api.PKI.DeleteClientCertificate(item.Name)
+	case models.GarbageShadow:
+		err = api.Garbage.DeleteShadow(item.Namespace, item.Name)
+	}
+	// the resources deleted along with the others, such as the configs of apps, are done already
+	if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+		return nil
+	}
+	return err
+}
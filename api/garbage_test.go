@@ -0,0 +1,203 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+type garbageMocks struct {
+	garbage *ms.MockGarbageService
+	ns      *ms.MockNamespaceService
+	node    *ms.MockNodeService
+	app     *ms.MockApplicationService
+	config  *ms.MockConfigService
+	secret  *ms.MockSecretService
+	obj     *ms.MockObjectService
+	pki     *ms.MockPKIService
+	index   *ms.MockIndexService
+}
+
+func initGarbageAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller, *garbageMocks) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	v1 := router.Group("v1")
+	{
+		garbage := v1.Group("/garbage")
+		garbage.GET("", common.Wrapper(api.GetGarbageReport))
+		garbage.POST("/scan", common.Wrapper(api.RescanGarbage))
+		garbage.POST("/cleanup", common.Wrapper(api.CleanGarbage))
+	}
+	m := &garbageMocks{
+		garbage: ms.NewMockGarbageService(mockCtl),
+		ns:      ms.NewMockNamespaceService(mockCtl),
+		node:    ms.NewMockNodeService(mockCtl),
+		app:     ms.NewMockApplicationService(mockCtl),
+		config:  ms.NewMockConfigService(mockCtl),
+		secret:  ms.NewMockSecretService(mockCtl),
+		obj:     ms.NewMockObjectService(mockCtl),
+		pki:     ms.NewMockPKIService(mockCtl),
+		index:   ms.NewMockIndexService(mockCtl),
+	}
+	api.Garbage, api.NS, api.Node, api.Obj, api.PKI, api.Index = m.garbage, m.ns, m.node, m.obj, m.pki, m.index
+	api.AppCombinedService = &service.AppCombinedService{App: m.app, Config: m.config, Secret: m.secret}
+	m.garbage.EXPECT().MinAge().Return(24 * time.Hour).AnyTimes()
+	return api, router, mockCtl, m
+}
+
+// expectGarbageScan mocks the namespace default whose node02 is deleted, and the namespace gone deleted
+func (m *garbageMocks) expectGarbageScan(times int) {
+	old, young := time.Now().Add(-48*time.Hour), time.Now()
+	sys := func(node string) map[string]string {
+		return map[string]string{common.LabelSystem: "true", common.LabelNodeName: node}
+	}
+	object := func(md5 string) string {
+		data, _ := json.Marshal(&specV1.ConfigurationObject{MD5: md5, Metadata: map[string]string{
+			"type": "object", "source": "awss3", "bucket": "baetyl-cloud-u1", "object": "configs/default/big/" + md5 + "/data", "userID": "u1"}})
+		return string(data)
+	}
+
+	m.ns.EXPECT().List(gomock.Any()).Return(&models.NamespaceList{Items: []models.Namespace{{Name: "default"}}}, nil).Times(times)
+	m.node.EXPECT().List("default", gomock.Any()).Return(&models.NodeList{Items: []specV1.Node{{Name: "node01"}}}, nil).Times(times)
+	m.app.EXPECT().List("default", gomock.Any()).Return(&models.ApplicationList{Items: []models.AppItem{
+		{Name: "baetyl-core-abc", System: true, Selector: "baetyl-node-name=node02", CreationTimestamp: old},
+		{Name: "baetyl-core-def", System: true, Selector: "baetyl-node-name=node01", CreationTimestamp: old},
+		{Name: "baetyl-core-new", System: true, Selector: "baetyl-node-name=node03", CreationTimestamp: young},
+		{Name: "web", Selector: "baetyl-node-name=node02", CreationTimestamp: old},
+	}}, nil).Times(times)
+	m.config.EXPECT().List("default", gomock.Any()).Return(&models.ConfigurationList{Items: []specV1.Configuration{
+		{Name: "baetyl-core-conf-abc", Labels: sys("node02"), CreationTimestamp: old},
+		{Name: "baetyl-core-conf-def", Labels: sys("node01"), CreationTimestamp: old},
+		{Name: "big", CreationTimestamp: old, Data: map[string]string{common.ConfigObjectPrefix + "data": object("md5a")}},
+	}}, nil).Times(times)
+	m.secret.EXPECT().List("default", gomock.Any()).Return(&models.SecretList{Items: []specV1.Secret{
+		{Name: "crt-node02", Labels: sys("node02"), CreationTimestamp: old, Annotations: map[string]string{common.AnnotationPkiCertID: "c2"}},
+		{Name: "crt-node01", Labels: sys("node01"), CreationTimestamp: old, Annotations: map[string]string{common.AnnotationPkiCertID: "c1"}},
+	}}, nil).Times(times)
+	m.garbage.EXPECT().ListGarbageShadow().Return([]models.GarbageItem{
+		{Kind: models.GarbageShadow, Namespace: "default", Name: "node01", Time: old},
+		{Kind: models.GarbageShadow, Namespace: "default", Name: "node02", Time: old},
+		{Kind: models.GarbageShadow, Namespace: "gone", Name: "node01", Time: old},
+	}, nil).Times(times)
+	m.garbage.EXPECT().ListGarbageCert(gomock.Any()).Return([]models.GarbageItem{
+		{Kind: models.GarbageCert, Name: "c1"}, {Kind: models.GarbageCert, Name: "c2"}, {Kind: models.GarbageCert, Name: "c3"},
+	}, nil).Times(times)
+	m.obj.EXPECT().ListInternalBucketObjects("u1", "baetyl-cloud-u1", "awss3").Return(&models.ListObjectsResult{Contents: []models.ObjectSummaryType{
+		{Key: "configs/default/big/md5a/data", LastModified: old},
+		{Key: "configs/default/big/md5b/data", LastModified: old, Size: 1024},
+		{Key: "configs/default/big/md5c/data", LastModified: young},
+		{Key: "uploads/data", LastModified: old},
+	}}, nil).Times(times)
+}
+
+func TestScanGarbage(t *testing.T) {
+	api, router, mockCtl, m := initGarbageAPI(t)
+	defer mockCtl.Finish()
+
+	m.garbage.EXPECT().Get().Return(nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/garbage", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	m.expectGarbageScan(1)
+	m.garbage.EXPECT().Set(gomock.Any())
+	req, _ = http.NewRequest(http.MethodPost, "/v1/garbage/scan", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	report := new(models.GarbageReport)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), report))
+	assert.Empty(t, report.Errors)
+	var keys []string
+	for i := range report.Items {
+		keys = append(keys, report.Items[i].Key())
+	}
+	assert.Equal(t, []string{
+		"app/default/baetyl-core-abc",
+		"config/default/baetyl-core-conf-abc",
+		"secret/default/crt-node02",
+		"shadow/default/node02",
+		"shadow/gone/node01",
+		"cert/c3",
+		"object/awss3/baetyl-cloud-u1/configs/default/big/md5b/data",
+	}, keys)
+	assert.Equal(t, 7, report.Total)
+	assert.Equal(t, 2, report.Counts[models.GarbageShadow])
+	assert.Equal(t, int64(1024), report.Items[6].Size)
+	assert.NotEmpty(t, report.ID)
+
+	// the certificates and objects are not judged if any namespace fails
+	m.ns.EXPECT().List(gomock.Any()).Return(&models.NamespaceList{Items: []models.Namespace{{Name: "default"}}}, nil)
+	m.node.EXPECT().List("default", gomock.Any()).Return(nil, fmt.Errorf("timeout"))
+	m.garbage.EXPECT().ListGarbageShadow().Return([]models.GarbageItem{
+		{Kind: models.GarbageShadow, Namespace: "default", Name: "node02", Time: time.Now().Add(-48 * time.Hour)},
+	}, nil)
+	report = api.ScanGarbage()
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, 0, report.Total)
+}
+
+func TestCleanGarbage(t *testing.T) {
+	api, router, mockCtl, m := initGarbageAPI(t)
+	defer mockCtl.Finish()
+
+	m.expectGarbageScan(2)
+	latest := api.ScanGarbage()
+	// the report not reviewed is rejected
+	m.garbage.EXPECT().Get().Return(latest)
+	body, _ := json.Marshal(&models.GarbageCleanup{Report: "unknown"})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/garbage/cleanup", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	m.garbage.EXPECT().Get().Return(latest)
+	m.app.EXPECT().Delete(nil, "default", "baetyl-core-abc", "").Return(nil)
+	m.index.EXPECT().RefreshNodesIndexByApp(nil, "default", "baetyl-core-abc", []string{}).Return(nil)
+	m.secret.EXPECT().Get("default", "crt-node02", "").Return(&specV1.Secret{Name: "crt-node02",
+		Annotations: map[string]string{common.AnnotationPkiCertID: "c2"}}, nil)
+	m.pki.EXPECT().DeleteClientCertificate("c2").Return(nil)
+	m.secret.EXPECT().Delete("default", "crt-node02").Return(nil)
+	m.garbage.EXPECT().DeleteShadow("default", "node02").Return(nil)
+	m.garbage.EXPECT().DeleteShadow("gone", "node01").Return(fmt.Errorf("timeout"))
+	m.obj.EXPECT().DeleteInternalObject("u1", "baetyl-cloud-u1", "configs/default/big/md5b/data", "awss3").Return(nil)
+	m.garbage.EXPECT().Set(gomock.Any()).Do(func(report *models.GarbageReport) {
+		// the ones kept and failed are reported again
+		assert.Equal(t, 3, report.Total)
+		assert.Equal(t, 1, report.Counts[models.GarbageConfig])
+		assert.Equal(t, 1, report.Counts[models.GarbageCert])
+		assert.Equal(t, 1, report.Counts[models.GarbageShadow])
+	})
+	body, _ = json.Marshal(&models.GarbageCleanup{Report: latest.ID, Kinds: []string{models.GarbageApp, models.GarbageSecret, models.GarbageShadow, models.GarbageObject}})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/garbage/cleanup", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.GarbageCleanupResult)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Len(t, res.Deleted, 4)
+	assert.Contains(t, res.Failures, "shadow/gone/node01")
+
+	body, _ = json.Marshal(&models.GarbageCleanup{Report: latest.ID, Kinds: []string{"node"}})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/garbage/cleanup", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetNodeHealth get the health of node, which is scored by the thresholds of namespace
+func (api *API) GetNodeHealth(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	threshold, err := api.Health.GetThreshold(ns)
+	if err != nil {
+		return nil, err
+	}
+	return api.Health.Evaluate(node, threshold), nil
+}
+
+// GetHealthThreshold get the health threshold of namespace
+func (api *API) GetHealthThreshold(c *common.Context) (interface{}, error) {
+	return api.Health.GetThreshold(c.GetNamespace())
+}
+
+// UpdateHealthThreshold update the health threshold of namespace, the webhook is notified when the level of node changes
+func (api *API) UpdateHealthThreshold(c *common.Context) (interface{}, error) {
+	threshold := &models.HealthThreshold{
+		WarningScore:  models.DefaultHealthWarningScore,
+		CriticalScore: models.DefaultHealthCriticalScore,
+	}
+	if err := c.LoadBody(threshold); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	threshold.Namespace = c.GetNamespace()
+	return api.Health.SetThreshold(threshold)
+}
+
+// DeleteHealthThreshold delete the health threshold of namespace, the default one is used after deletion
+func (api *API) DeleteHealthThreshold(c *common.Context) (interface{}, error) {
+	return nil, api.Health.DeleteThreshold(c.GetNamespace())
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initHealthAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		v1.GET("/nodes/:name/health", mockIM, common.Wrapper(api.GetNodeHealth))
+		health := v1.Group("/health/threshold")
+		health.GET("", mockIM, common.Wrapper(api.GetHealthThreshold))
+		health.PUT("", mockIM, common.Wrapper(api.UpdateHealthThreshold))
+		health.DELETE("", mockIM, common.Wrapper(api.DeleteHealthThreshold))
+	}
+	return api, router, mockCtl
+}
+
+func TestGetNodeHealth(t *testing.T) {
+	api, router, mockCtl := initHealthAPI(t)
+	defer mockCtl.Finish()
+	sNode, sHealth := ms.NewMockNodeService(mockCtl), ms.NewMockHealthService(mockCtl)
+	api.Node, api.Health = sNode, sHealth
+
+	node := &specV1.Node{Namespace: "default", Name: "n1"}
+	threshold := &models.HealthThreshold{Namespace: "default", WarningScore: 80, CriticalScore: 50}
+	health := &models.NodeHealth{Score: 70, Level: models.HealthLevelWarning, Reasons: []string{"cpu usage is 85%"}}
+	sNode.EXPECT().Get(nil, "default", "n1").Return(node, nil)
+	sHealth.EXPECT().GetThreshold("default").Return(threshold, nil)
+	sHealth.EXPECT().Evaluate(node, threshold).Return(health)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/n1/health", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.NodeHealth
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, *health, res)
+
+	sNode.EXPECT().Get(nil, "default", "n2").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/n2/health", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHealthThreshold(t *testing.T) {
+	api, router, mockCtl := initHealthAPI(t)
+	defer mockCtl.Finish()
+	sHealth := ms.NewMockHealthService(mockCtl)
+	api.Health = sHealth
+
+	threshold := &models.HealthThreshold{Namespace: "default", WarningScore: 80, CriticalScore: 50}
+	sHealth.EXPECT().GetThreshold("default").Return(threshold, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/health/threshold", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	update := &models.HealthThreshold{Namespace: "default", WarningScore: 70, CriticalScore: 40, Webhook: "http://alert.example.com/hook"}
+	sHealth.EXPECT().SetThreshold(update).Return(update, nil)
+	body, _ := json.Marshal(map[string]interface{}{"warningScore": 70, "criticalScore": 40, "webhook": "http://alert.example.com/hook"})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/health/threshold", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the critical score should be lower than the warning one
+	body, _ = json.Marshal(map[string]interface{}{"warningScore": 40, "criticalScore": 70})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/health/threshold", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	body, _ = json.Marshal(map[string]interface{}{"webhook": "not a url"})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/health/threshold", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	sHealth.EXPECT().DeleteThreshold("default").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/health/threshold", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetHeartbeatPolicy get the heartbeat policy of namespace
+func (api *API) GetHeartbeatPolicy(c *common.Context) (interface{}, error) {
+	return api.Heartbeat.GetPolicy(c.GetNamespace())
+}
+
+// UpdateHeartbeatPolicy update the heartbeat policy of namespace, the nodes on unstable links need a longer offline timeout
+func (api *API) UpdateHeartbeatPolicy(c *common.Context) (interface{}, error) {
+	policy := &models.HeartbeatPolicy{
+		OfflineTimeout: models.DefaultOfflineTimeout,
+	}
+	if err := c.LoadBody(policy); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	policy.Namespace = c.GetNamespace()
+	return api.Heartbeat.SetPolicy(policy)
+}
+
+// DeleteHeartbeatPolicy delete the heartbeat policy of namespace, the default offline timeout is used after deletion
+func (api *API) DeleteHeartbeatPolicy(c *common.Context) (interface{}, error) {
+	return nil, api.Heartbeat.DeletePolicy(c.GetNamespace())
+}
+
+// ListNodeEvent list the online and offline events of nodes, which can be filtered by node, type, start and end
+func (api *API) ListNodeEvent(c *common.Context) (interface{}, error) {
+	params, err := api.ParseListOptions(c)
+	if err != nil {
+		return nil, err
+	}
+	filter := &models.NodeEventFilter{
+		Node:   c.Query("node"),
+		Type:   c.Query("type"),
+		Filter: params.Filter,
+	}
+	if filter.Type != "" && filter.Type != models.NodeEventOnline && filter.Type != models.NodeEventOffline {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "invalid type: "+filter.Type))
+	}
+	if filter.Start, err = parseMetricsTime(c.Query("start"), time.Time{}); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "invalid start: "+err.Error()))
+	}
+	if filter.End, err = parseMetricsTime(c.Query("end"), time.Time{}); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "invalid end: "+err.Error()))
+	}
+	list, err := api.Heartbeat.ListEvent(c.GetNamespace(), filter)
+	if err != nil {
+		return nil, err
+	}
+	return api.ToListResponse(list.Total, list.Items, params), nil
+}
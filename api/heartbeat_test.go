@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initHeartbeatAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		heartbeat := v1.Group("/heartbeat")
+		heartbeat.GET("/policy", mockIM, common.Wrapper(api.GetHeartbeatPolicy))
+		heartbeat.PUT("/policy", mockIM, common.Wrapper(api.UpdateHeartbeatPolicy))
+		heartbeat.DELETE("/policy", mockIM, common.Wrapper(api.DeleteHeartbeatPolicy))
+		heartbeat.GET("/events", mockIM, common.Wrapper(api.ListNodeEvent))
+	}
+	return api, router, mockCtl
+}
+
+func TestHeartbeatPolicy(t *testing.T) {
+	api, router, mockCtl := initHeartbeatAPI(t)
+	defer mockCtl.Finish()
+	sHeartbeat := ms.NewMockHeartbeatService(mockCtl)
+	api.Heartbeat = sHeartbeat
+
+	policy := &models.HeartbeatPolicy{Namespace: "default", OfflineTimeout: models.DefaultOfflineTimeout}
+	sHeartbeat.EXPECT().GetPolicy("default").Return(policy, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/heartbeat/policy", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	update := &models.HeartbeatPolicy{Namespace: "default", OfflineTimeout: 600, Webhook: "http://event.example.com/hook"}
+	sHeartbeat.EXPECT().SetPolicy(update).Return(update, nil)
+	body, _ := json.Marshal(map[string]interface{}{"offlineTimeout": 600, "webhook": "http://event.example.com/hook"})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/heartbeat/policy", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	body, _ = json.Marshal(map[string]interface{}{"offlineTimeout": -1})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/heartbeat/policy", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	body, _ = json.Marshal(map[string]interface{}{"webhook": "not a url"})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/heartbeat/policy", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	sHeartbeat.EXPECT().DeletePolicy("default").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/heartbeat/policy", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestListNodeEvent(t *testing.T) {
+	api, router, mockCtl := initHeartbeatAPI(t)
+	defer mockCtl.Finish()
+	sHeartbeat := ms.NewMockHeartbeatService(mockCtl)
+	api.Heartbeat = sHeartbeat
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	list := &models.NodeEventList{
+		Total: 1,
+		Items: []models.NodeEvent{{Id: 1, Namespace: "default", Node: "node01", Type: models.NodeEventOffline, Time: now}},
+	}
+	sHeartbeat.EXPECT().ListEvent("default", gomock.Any()).DoAndReturn(func(_ string, filter *models.NodeEventFilter) (*models.NodeEventList, error) {
+		assert.Equal(t, "node01", filter.Node)
+		assert.Equal(t, models.NodeEventOffline, filter.Type)
+		assert.Equal(t, now.Add(-time.Hour), filter.Start)
+		assert.Equal(t, now, filter.End)
+		assert.Equal(t, 10, filter.GetLimitNumber())
+		return list, nil
+	})
+	req, _ := http.NewRequest(http.MethodGet, "/v1/heartbeat/events?node=node01&type=node-offline&start=2021-12-31T23:00:00Z&end=1640995200&pageSize=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res struct {
+		Total int                `json:"total"`
+		Items []models.NodeEvent `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 1, res.Total)
+	assert.Equal(t, list.Items, res.Items)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/heartbeat/events?type=unknown", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/heartbeat/events?start=yesterday", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestOfflineTimeout(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	api := &API{}
+	assert.Equal(t, OfflineDuration*time.Second, api.offlineTimeout("default"))
+
+	sHeartbeat := ms.NewMockHeartbeatService(mockCtl)
+	api.Heartbeat = sHeartbeat
+	sHeartbeat.EXPECT().OfflineTimeout("default").Return(5 * time.Minute)
+	assert.Equal(t, 5*time.Minute, api.offlineTimeout("default"))
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetHelmRelease get the helm release
+func (api *API) GetHelmRelease(c *common.Context) (interface{}, error) {
+	return api.Helm.Get(c.GetNamespace(), c.GetNameFromParam())
+}
+
+// ListHelmRelease list the helm releases of namespace
+func (api *API) ListHelmRelease(c *common.Context) (interface{}, error) {
+	releases, err := api.Helm.List(c.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(releases), releases, ""), nil
+}
+
+// ImportHelmChart renders the chart uploaded as file or referenced by url with the values into applications,
+// configs and secrets as the yaml resources, the release is recorded with its chart to upgrade them later
+func (api *API) ImportHelmChart(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.PostForm("name")
+	if err := validateResourceName(name); err != nil {
+		return nil, err
+	}
+	if _, err := api.Helm.Get(ns, name); err == nil {
+		return nil, common.Error(common.ErrResourceConflict, common.Field("type", "helm release"), common.Field("name", name))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, err
+	}
+
+	release := &models.HelmRelease{Namespace: ns, Name: name, Revision: 1}
+	if err := api.loadHelmChart(c, release, true); err != nil {
+		return nil, err
+	}
+	resources, err := api.renderHelmRelease(release)
+	if err != nil {
+		return nil, err
+	}
+	var created []runtime.Object
+	for _, r := range resources {
+		if _, err = api.createYamlResource(ns, c.GetUser().ID, r); err != nil {
+			api.cleanHelmResources(ns, created)
+			return nil, err
+		}
+		created = append(created, r)
+	}
+	release.Resources = toHelmResources(resources)
+	res, err := api.Helm.Create(release)
+	if err != nil {
+		api.cleanHelmResources(ns, created)
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpgradeHelmRelease renders the release again with the chart and values of request, the stored ones are used
+// if they are not given, the resources rendered are created or updated and the ones no longer rendered are deleted
+func (api *API) UpgradeHelmRelease(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	release, err := api.Helm.Get(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	prev := *release
+	previous, err := api.renderHelmRelease(&prev)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = api.loadHelmChart(c, release, false); err != nil {
+		return nil, err
+	}
+	release.Revision++
+	resources, err := api.renderHelmRelease(release)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[models.HelmResource]bool{}
+	for _, r := range prev.Resources {
+		existing[r] = true
+	}
+	rendered := map[models.HelmResource]bool{}
+	for _, r := range resources {
+		key := toHelmResource(r)
+		rendered[key] = true
+		if existing[key] {
+			_, err = api.updateYamlResource(ns, c.GetUser().ID, r)
+		} else {
+			_, err = api.createYamlResource(ns, c.GetUser().ID, r)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := len(previous) - 1; i >= 0; i-- {
+		if rendered[toHelmResource(previous[i])] {
+			continue
+		}
+		if err = api.deleteYamlResource(ns, previous[i]); err != nil {
+			return nil, err
+		}
+	}
+	release.Resources = toHelmResources(resources)
+	return api.Helm.Update(release)
+}
+
+// DeleteHelmRelease deletes the resources of release in reverse order and then the release
+func (api *API) DeleteHelmRelease(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	release, err := api.Helm.Get(ns, name)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	resources, err := api.renderHelmRelease(release)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(resources) - 1; i >= 0; i-- {
+		if err = api.deleteYamlResource(ns, resources[i]); err != nil {
+			return nil, err
+		}
+	}
+	return nil, api.Helm.Delete(ns, name)
+}
+
+// loadHelmChart loads the chart from the form file or the url, and the values in yaml or json from the form,
+// the chart and values of release are kept if they are not given and the chart is not required
+func (api *API) loadHelmChart(c *common.Context, release *models.HelmRelease, required bool) error {
+	file, header, err := c.Request.FormFile("file")
+	switch {
+	case err == nil:
+		defer file.Close()
+		if !strings.HasSuffix(header.Filename, ".tgz") && !strings.HasSuffix(header.Filename, ".tar.gz") {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the chart should be packaged as .tgz"))
+		}
+		if release.Archive, err = ioutil.ReadAll(file); err != nil {
+			return errors.Trace(err)
+		}
+		release.URL = ""
+	case err != http.ErrMissingFile:
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	case c.PostForm("url") != "":
+		release.URL = c.PostForm("url")
+		if release.Archive, err = api.Helm.Fetch(release.URL); err != nil {
+			return err
+		}
+	case required:
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "either file or url of chart is required"))
+	}
+
+	if values, ok := c.GetPostForm("values"); ok {
+		if release.Values, err = service.ParseHelmValues([]byte(values)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderHelmRelease renders the chart of release into the kube objects supported by yaml resources
+func (api *API) renderHelmRelease(release *models.HelmRelease) ([]runtime.Object, error) {
+	manifests, err := api.Helm.Render(release)
+	if err != nil {
+		return nil, err
+	}
+	var res []runtime.Object
+	for _, r := range api.parseK8SYaml(manifests) {
+		switch r.GetObjectKind().GroupVersionKind().Kind {
+		case TypeSecret, TypeConfig, TypeDeploy, TypeDaemonset, TypeJob, TypeService:
+			res = append(res, r)
+		}
+	}
+	if len(res) == 0 {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "no resource is rendered from the chart"))
+	}
+	return res, nil
+}
+
+// cleanHelmResources deletes the resources created before the import failed
+func (api *API) cleanHelmResources(ns string, resources []runtime.Object) {
+	for i := len(resources) - 1; i >= 0; i-- {
+		if err := api.deleteYamlResource(ns, resources[i]); err != nil {
+			r := toHelmResource(resources[i])
+			common.LogDirtyData(err, log.Any("type", r.Kind), log.Any(common.KeyContextNamespace, ns), log.Any("name", r.Name))
+		}
+	}
+}
+
+func toHelmResource(r runtime.Object) models.HelmResource {
+	res := models.HelmResource{Kind: r.GetObjectKind().GroupVersionKind().Kind}
+	if obj, err := meta.Accessor(r); err == nil {
+		res.Name = obj.GetName()
+	}
+	return res
+}
+
+func toHelmResources(resources []runtime.Object) []models.HelmResource {
+	res := make([]models.HelmResource, 0, len(resources))
+	for _, r := range resources {
+		res = append(res, toHelmResource(r))
+	}
+	return res
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mf "github.com/baetyl/baetyl-cloud/v2/mock/facade"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initHelmAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		helm := v1.Group("/helmreleases")
+		helm.GET("", mockIM, common.Wrapper(api.ListHelmRelease))
+		helm.GET("/:name", mockIM, common.Wrapper(api.GetHelmRelease))
+		helm.POST("", mockIM, common.Wrapper(api.ImportHelmChart))
+		helm.PUT("/:name", mockIM, common.Wrapper(api.UpgradeHelmRelease))
+		helm.DELETE("/:name", mockIM, common.Wrapper(api.DeleteHelmRelease))
+	}
+	return api, router, mockCtl
+}
+
+func helmConfigManifest(name, data string) string {
+	return fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\ndata:\n  key: %s\n", name, data)
+}
+
+func newHelmRequest(t *testing.T, method, url string, fields map[string]string, chart []byte) *http.Request {
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+	for k, v := range fields {
+		assert.NoError(t, w.WriteField(k, v))
+	}
+	if chart != nil {
+		fw, err := w.CreateFormFile("file", "nginx-0.1.0.tgz")
+		assert.NoError(t, err)
+		fw.Write(chart)
+	}
+	assert.NoError(t, w.Close())
+	req, _ := http.NewRequest(method, url, buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestImportHelmChart(t *testing.T) {
+	api, router, mockCtl := initHelmAPI(t)
+	defer mockCtl.Finish()
+	sHelm, sConfig, sIndex, fApp := ms.NewMockHelmService(mockCtl), ms.NewMockConfigService(mockCtl),
+		ms.NewMockIndexService(mockCtl), mf.NewMockFacade(mockCtl)
+	api.Helm, api.Index, api.Facade = sHelm, sIndex, fApp
+	api.AppCombinedService = &service.AppCombinedService{Config: sConfig}
+
+	manifests := helmConfigManifest("web-a", "a") + "---\n" + helmConfigManifest("web-b", "b")
+	sHelm.EXPECT().Get("default", "web").Return(nil, common.Error(common.ErrResourceNotFound)).Times(2)
+	sHelm.EXPECT().Render(gomock.Any()).Return([]byte(manifests), nil).Times(2)
+	sConfig.EXPECT().Get("default", gomock.Any(), "").Return(nil, nil).Times(2)
+	fApp.EXPECT().CreateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		return cfg, nil
+	}).Times(2)
+	sHelm.EXPECT().Create(gomock.Any()).DoAndReturn(func(release *models.HelmRelease) (*models.HelmRelease, error) {
+		assert.Equal(t, []byte("chart"), release.Archive)
+		assert.Equal(t, 1, release.Revision)
+		assert.Equal(t, map[string]interface{}{"replicaCount": 2}, release.Values)
+		assert.Equal(t, []models.HelmResource{{Kind: TypeConfig, Name: "web-a"}, {Kind: TypeConfig, Name: "web-b"}}, release.Resources)
+		return release, nil
+	})
+	req := newHelmRequest(t, http.MethodPost, "/v1/helmreleases", map[string]string{"name": "web", "values": "replicaCount: 2"}, []byte("chart"))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "web-b")
+
+	// the configs created are removed if the second one failed
+	sConfig.EXPECT().Get("default", "web-a", "").Return(nil, nil)
+	sConfig.EXPECT().Get("default", "web-b", "").Return(nil, nil)
+	fApp.EXPECT().CreateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		return cfg, nil
+	})
+	fApp.EXPECT().CreateConfig("default", gomock.Any()).Return(nil, fmt.Errorf("error"))
+	sConfig.EXPECT().Get("default", "web-a", "").Return(&specV1.Configuration{Name: "web-a"}, nil)
+	sIndex.EXPECT().ListAppIndexByConfig("default", "web-a").Return(nil, nil)
+	fApp.EXPECT().DeleteConfig("default", "web-a").Return(nil)
+	req = newHelmRequest(t, http.MethodPost, "/v1/helmreleases", map[string]string{"name": "web"}, []byte("chart"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// the chart is downloaded from url
+	sHelm.EXPECT().Get("default", "api").Return(nil, common.Error(common.ErrResourceNotFound))
+	sHelm.EXPECT().Fetch("https://charts.example.com/api.tgz").Return(nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "failed")))
+	req = newHelmRequest(t, http.MethodPost, "/v1/helmreleases", map[string]string{"name": "api", "url": "https://charts.example.com/api.tgz"}, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// no chart
+	sHelm.EXPECT().Get("default", "api").Return(nil, common.Error(common.ErrResourceNotFound))
+	req = newHelmRequest(t, http.MethodPost, "/v1/helmreleases", map[string]string{"name": "api"}, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the release exists
+	sHelm.EXPECT().Get("default", "api").Return(&models.HelmRelease{Name: "api"}, nil)
+	req = newHelmRequest(t, http.MethodPost, "/v1/helmreleases", map[string]string{"name": "api"}, []byte("chart"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = newHelmRequest(t, http.MethodPost, "/v1/helmreleases", map[string]string{"name": "API"}, []byte("chart"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpgradeHelmRelease(t *testing.T) {
+	api, router, mockCtl := initHelmAPI(t)
+	defer mockCtl.Finish()
+	sHelm, sConfig, sIndex, fApp := ms.NewMockHelmService(mockCtl), ms.NewMockConfigService(mockCtl),
+		ms.NewMockIndexService(mockCtl), mf.NewMockFacade(mockCtl)
+	api.Helm, api.Index, api.Facade = sHelm, sIndex, fApp
+	api.AppCombinedService = &service.AppCombinedService{Config: sConfig}
+
+	release := &models.HelmRelease{
+		Namespace: "default",
+		Name:      "web",
+		Revision:  1,
+		Values:    map[string]interface{}{"replicaCount": 1},
+		Resources: []models.HelmResource{{Kind: TypeConfig, Name: "web-a"}, {Kind: TypeConfig, Name: "web-b"}},
+		Archive:   []byte("chart"),
+	}
+	sHelm.EXPECT().Get("default", "web").Return(release, nil)
+	gomock.InOrder(
+		sHelm.EXPECT().Render(gomock.Any()).DoAndReturn(func(r *models.HelmRelease) ([]byte, error) {
+			assert.Equal(t, 1, r.Revision)
+			return []byte(helmConfigManifest("web-a", "a") + "---\n" + helmConfigManifest("web-b", "b")), nil
+		}),
+		sHelm.EXPECT().Render(gomock.Any()).DoAndReturn(func(r *models.HelmRelease) ([]byte, error) {
+			assert.Equal(t, 2, r.Revision)
+			// the chart stored is rendered with the new values
+			assert.Equal(t, []byte("chart"), r.Archive)
+			assert.Equal(t, map[string]interface{}{"replicaCount": 3}, r.Values)
+			return []byte(helmConfigManifest("web-a", "a2") + "---\n" + helmConfigManifest("web-c", "c")), nil
+		}),
+	)
+	// web-a is updated
+	sConfig.EXPECT().Get("default", "web-a", "").Return(&specV1.Configuration{Name: "web-a", Namespace: "default", Data: map[string]string{"key": "a"}}, nil)
+	fApp.EXPECT().UpdateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		assert.Equal(t, "a2", cfg.Data["key"])
+		return cfg, nil
+	})
+	// web-c is created
+	sConfig.EXPECT().Get("default", "web-c", "").Return(nil, nil)
+	fApp.EXPECT().CreateConfig("default", gomock.Any()).DoAndReturn(func(_ string, cfg *specV1.Configuration) (*specV1.Configuration, error) {
+		return cfg, nil
+	})
+	// web-b is deleted
+	sConfig.EXPECT().Get("default", "web-b", "").Return(&specV1.Configuration{Name: "web-b"}, nil)
+	sIndex.EXPECT().ListAppIndexByConfig("default", "web-b").Return(nil, nil)
+	fApp.EXPECT().DeleteConfig("default", "web-b").Return(nil)
+	sHelm.EXPECT().Update(gomock.Any()).DoAndReturn(func(r *models.HelmRelease) (*models.HelmRelease, error) {
+		assert.Equal(t, 2, r.Revision)
+		assert.Equal(t, []models.HelmResource{{Kind: TypeConfig, Name: "web-a"}, {Kind: TypeConfig, Name: "web-c"}}, r.Resources)
+		return r, nil
+	})
+	req := newHelmRequest(t, http.MethodPut, "/v1/helmreleases/web", map[string]string{"values": `{"replicaCount": 3}`}, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sHelm.EXPECT().Get("default", "api").Return(nil, common.Error(common.ErrResourceNotFound))
+	req = newHelmRequest(t, http.MethodPut, "/v1/helmreleases/api", nil, []byte("chart"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetListDeleteHelmRelease(t *testing.T) {
+	api, router, mockCtl := initHelmAPI(t)
+	defer mockCtl.Finish()
+	sHelm, sConfig, sIndex, fApp := ms.NewMockHelmService(mockCtl), ms.NewMockConfigService(mockCtl),
+		ms.NewMockIndexService(mockCtl), mf.NewMockFacade(mockCtl)
+	api.Helm, api.Index, api.Facade = sHelm, sIndex, fApp
+	api.AppCombinedService = &service.AppCombinedService{Config: sConfig}
+
+	release := &models.HelmRelease{Namespace: "default", Name: "web", Chart: "nginx", Revision: 2, Archive: []byte("chart")}
+	sHelm.EXPECT().Get("default", "web").Return(release, nil).Times(2)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/helmreleases/web", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "nginx")
+	// the chart archive is not returned
+	assert.False(t, strings.Contains(w.Body.String(), "archive"))
+
+	sHelm.EXPECT().List("default").Return([]models.HelmRelease{*release}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/helmreleases", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	sHelm.EXPECT().Render(release).Return([]byte(helmConfigManifest("web-a", "a")), nil)
+	sConfig.EXPECT().Get("default", "web-a", "").Return(&specV1.Configuration{Name: "web-a"}, nil)
+	sIndex.EXPECT().ListAppIndexByConfig("default", "web-a").Return(nil, nil)
+	fApp.EXPECT().DeleteConfig("default", "web-a").Return(nil)
+	sHelm.EXPECT().Delete("default", "web").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/helmreleases/web", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sHelm.EXPECT().Get("default", "api").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/helmreleases/api", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetImagePolicy get the image policy of namespace
+func (api *API) GetImagePolicy(c *common.Context) (interface{}, error) {
+	return api.ImagePolicy.Get(c.GetNamespace())
+}
+
+// UpdateImagePolicy update the image policy of namespace, such as redirecting all images to the local registry
+// of air-gapped sites. The apps whose images are changed by the policy are saved again so that nodes pull the new images
+func (api *API) UpdateImagePolicy(c *common.Context) (interface{}, error) {
+	policy := new(models.ImagePolicy)
+	if err := c.LoadBody(policy); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	policy.Namespace = c.GetNamespace()
+	if err := checkImagePolicy(policy); err != nil {
+		return nil, err
+	}
+	old, err := api.ImagePolicy.Get(policy.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	res, err := api.ImagePolicy.Set(policy)
+	if err != nil {
+		return nil, err
+	}
+	if err = api.refreshImagePolicyApps(policy.Namespace, old, policy); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteImagePolicy delete the image policy of namespace, the apps get their original images back
+func (api *API) DeleteImagePolicy(c *common.Context) (interface{}, error) {
+	ns := c.GetNamespace()
+	old, err := api.ImagePolicy.Get(ns)
+	if err != nil {
+		return nil, err
+	}
+	if err = api.ImagePolicy.Delete(ns); err != nil {
+		return nil, err
+	}
+	return nil, api.refreshImagePolicyApps(ns, old, &models.ImagePolicy{Namespace: ns})
+}
+
+func checkImagePolicy(policy *models.ImagePolicy) error {
+	policy.Mirror = strings.TrimSuffix(strings.TrimSpace(policy.Mirror), "/")
+	if strings.Contains(policy.Mirror, "://") || strings.ContainsAny(policy.Mirror, " \t@") {
+		return common.Error(common.ErrRequestParamInvalid,
+			common.Field("error", "the mirror should be a registry host with an optional path, such as harbor.local/proxy"))
+	}
+	for _, r := range policy.Registries {
+		if strings.TrimSpace(r) == "" {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the registry should not be empty"))
+		}
+	}
+	if policy.Mirror == "" && len(policy.Registries) > 0 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the mirror is required to rewrite registries"))
+	}
+	return nil
+}
+
+// refreshImagePolicyApps saves the apps of namespace rendered differently by the old and new policies again
+// for their new versions, and updates the versions desired by their nodes
+func (api *API) refreshImagePolicyApps(ns string, old, policy *models.ImagePolicy) error {
+	var sidecars []models.SidecarPolicy
+	if api.SidecarPolicy != nil {
+		var err error
+		if sidecars, err = api.SidecarPolicy.List(ns); err != nil {
+			return err
+		}
+	}
+	apps, err := api.App.List(ns, &models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, item := range apps.Items {
+		app, err := api.App.Get(ns, item.Name, "")
+		if err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				continue
+			}
+			return err
+		}
+		if reflect.DeepEqual(renderAppImages(app, sidecars, old), renderAppImages(app, sidecars, policy)) {
+			continue
+		}
+		if app, err = api.App.Update(nil, ns, app); err != nil {
+			return err
+		}
+		if _, err = api.Node.UpdateNodeAppVersion(nil, ns, app); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderAppImages returns the images of app delivered to nodes with the policy followed by the pull policy,
+// the images of the sidecars injected are included
+func renderAppImages(app *specV1.Application, sidecars []models.SidecarPolicy, policy *models.ImagePolicy) []string {
+	rendered := *app
+	rendered.InitServices = append([]specV1.Service{}, app.InitServices...)
+	rendered.Services = append([]specV1.Service{}, app.Services...)
+	rendered.Volumes = append([]specV1.Volume{}, app.Volumes...)
+	rendered.Labels = map[string]string{}
+	for k, v := range app.Labels {
+		rendered.Labels[k] = v
+	}
+	service.ApplySidecarPolicies(&rendered, sidecars)
+	service.ApplyImagePolicy(&rendered, policy)
+	var images []string
+	for _, svc := range append(rendered.InitServices, rendered.Services...) {
+		images = append(images, svc.Image)
+	}
+	return append(images, rendered.Labels[common.LabelImagePullPolicy])
+}
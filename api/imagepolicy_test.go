@@ -0,0 +1,114 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initImagePolicyAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		images := v1.Group("/imagepolicy")
+		images.GET("", mockIM, common.Wrapper(api.GetImagePolicy))
+		images.PUT("", mockIM, common.Wrapper(api.UpdateImagePolicy))
+		images.DELETE("", mockIM, common.Wrapper(api.DeleteImagePolicy))
+	}
+	return api, router, mockCtl
+}
+
+func TestUpdateImagePolicy(t *testing.T) {
+	api, router, mockCtl := initImagePolicyAPI(t)
+	defer mockCtl.Finish()
+	sPolicy, sApp, sNode := ms.NewMockImagePolicyService(mockCtl), ms.NewMockApplicationService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.ImagePolicy, api.Node = sPolicy, sNode
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	web := &specV1.Application{Namespace: "default", Name: "web", Mode: context.RunModeKube, Services: []specV1.Service{{Name: "web", Image: "nginx"}}}
+	mirrored := &specV1.Application{Namespace: "default", Name: "agent", Mode: context.RunModeKube, Services: []specV1.Service{{Name: "agent", Image: "harbor.local/agent"}}}
+	native := &specV1.Application{Namespace: "default", Name: "native", Mode: context.RunModeNative, Services: []specV1.Service{{Name: "native", Image: "native"}}}
+
+	policy := &models.ImagePolicy{Mirror: "harbor.local/", PullPolicy: models.ImagePullIfNotPresent}
+	sPolicy.EXPECT().Get("default").Return(&models.ImagePolicy{Namespace: "default"}, nil)
+	sPolicy.EXPECT().Set(gomock.Any()).DoAndReturn(func(p *models.ImagePolicy) (*models.ImagePolicy, error) {
+		assert.Equal(t, "default", p.Namespace)
+		assert.Equal(t, "harbor.local", p.Mirror)
+		return p, nil
+	})
+	sApp.EXPECT().List("default", &models.ListOptions{}).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "web"}, {Name: "agent"}, {Name: "native"}}}, nil)
+	sApp.EXPECT().Get("default", "web", "").Return(web, nil)
+	sApp.EXPECT().Get("default", "agent", "").Return(mirrored, nil)
+	sApp.EXPECT().Get("default", "native", "").Return(native, nil)
+	// the app on the mirror already is saved again for the pull policy, the native one is kept
+	sApp.EXPECT().Update(nil, "default", web).Return(web, nil)
+	sNode.EXPECT().UpdateNodeAppVersion(nil, "default", web).Return([]string{"node01"}, nil)
+	sApp.EXPECT().Update(nil, "default", mirrored).Return(mirrored, nil)
+	sNode.EXPECT().UpdateNodeAppVersion(nil, "default", mirrored).Return([]string{"node01"}, nil)
+	body, _ := json.Marshal(policy)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/imagepolicy", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	// the stored app is not rendered
+	assert.Equal(t, "nginx", web.Services[0].Image)
+	assert.Nil(t, web.Labels)
+
+	for _, p := range []*models.ImagePolicy{
+		{Mirror: "https://harbor.local"},
+		{Mirror: "harbor.local", PullPolicy: "Sometimes"},
+		{Mirror: "harbor.local", Registries: []string{""}},
+		{Registries: []string{"docker.io"}},
+	} {
+		body, _ = json.Marshal(p)
+		req, _ = http.NewRequest(http.MethodPut, "/v1/imagepolicy", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestDeleteImagePolicy(t *testing.T) {
+	api, router, mockCtl := initImagePolicyAPI(t)
+	defer mockCtl.Finish()
+	sPolicy, sApp, sNode := ms.NewMockImagePolicyService(mockCtl), ms.NewMockApplicationService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.ImagePolicy, api.Node = sPolicy, sNode
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	web := &specV1.Application{Namespace: "default", Name: "web", Mode: context.RunModeKube, Services: []specV1.Service{{Name: "web", Image: "nginx"}}}
+	other := &specV1.Application{Namespace: "default", Name: "other", Mode: context.RunModeKube, Services: []specV1.Service{{Name: "other", Image: "quay.io/other"}}}
+	sPolicy.EXPECT().Get("default").Return(&models.ImagePolicy{Namespace: "default", Mirror: "harbor.local", Registries: []string{"docker.io"}}, nil)
+	sPolicy.EXPECT().Delete("default").Return(nil)
+	sApp.EXPECT().List("default", &models.ListOptions{}).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "web"}, {Name: "other"}}}, nil)
+	sApp.EXPECT().Get("default", "web", "").Return(web, nil)
+	sApp.EXPECT().Get("default", "other", "").Return(other, nil)
+	sApp.EXPECT().Update(nil, "default", web).Return(web, nil)
+	sNode.EXPECT().UpdateNodeAppVersion(nil, "default", web).Return([]string{"node01"}, nil)
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/imagepolicy", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sPolicy.EXPECT().Get("default").Return(&models.ImagePolicy{Namespace: "default"}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/imagepolicy", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
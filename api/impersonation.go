@@ -0,0 +1,27 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// CreateImpersonation issues the operator of mis a short-lived token acting as the user of namespace, the requests
+// of admin server carrying it in header Baetyl-Impersonation are audited along with the operator
+func (api *API) CreateImpersonation(c *common.Context) (interface{}, error) {
+	imp := new(models.Impersonation)
+	if err := c.LoadBody(imp); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	imp.Operator = c.GetUser().ID
+	if imp.Operator == "" {
+		return nil, common.Error(common.ErrRequestAccessDenied)
+	}
+	ns, err := api.NS.Get(imp.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if ns == nil {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "namespace"), common.Field("name", imp.Namespace))
+	}
+	return api.Impersonation.Issue(imp)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestCreateImpersonation(t *testing.T) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sNS := ms.NewMockNamespaceService(mockCtl)
+	sImpersonation := ms.NewMockImpersonationService(mockCtl)
+	api.NS, api.Impersonation = sNS, sImpersonation
+	router.Use(func(c *gin.Context) {
+		if user := c.GetHeader("baetyl-cloud-user"); user != "" {
+			common.NewContext(c).SetUser(common.User{ID: user, Name: user})
+		}
+	})
+	router.POST("/v1/impersonations", common.WrapperMis(api.CreateImpersonation))
+
+	newRequest := func(body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, "/v1/impersonations", bytes.NewReader([]byte(body)))
+		req.Header.Set("baetyl-cloud-user", "support01")
+		return req
+	}
+
+	sNS.EXPECT().Get("tenant-a").Return(&models.Namespace{Name: "tenant-a"}, nil)
+	sImpersonation.EXPECT().Issue(gomock.Any()).DoAndReturn(func(imp *models.Impersonation) (*models.Impersonation, error) {
+		assert.Equal(t, "support01", imp.Operator)
+		assert.Equal(t, "user01", imp.User)
+		assert.Equal(t, "30m", imp.Expiry)
+		res := *imp
+		res.Token = "token01"
+		return &res, nil
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest(`{"namespace":"tenant-a","user":"user01","reason":"ticket 42","expiry":"30m"}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"token":"token01"`)
+
+	// the reason is required
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest(`{"namespace":"tenant-a","user":"user01"}`))
+	assert.Contains(t, w.Body.String(), `"status":1`)
+
+	sNS.EXPECT().Get("tenant-b").Return(nil, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest(`{"namespace":"tenant-b","user":"user01","reason":"ticket 42"}`))
+	assert.Contains(t, w.Body.String(), `"status":1`)
+
+	// the operator is unknown
+	req, _ := http.NewRequest(http.MethodPost, "/v1/impersonations", bytes.NewReader([]byte(`{"namespace":"tenant-a","user":"user01","reason":"ticket 42"}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":1`)
+}
@@ -3,20 +3,30 @@ package api
 import (
 	"encoding/hex"
 	"encoding/json"
+	"strings"
 	"time"
 
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/errors"
 	"github.com/baetyl/baetyl-go/v2/log"
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
 	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 	"github.com/baetyl/baetyl-cloud/v2/service"
 )
 
 //go:generate mockgen -destination=../mock/api/init.go -package=api github.com/baetyl/baetyl-cloud/v2/api InitAPI
 
 type InitAPI struct {
-	Init service.InitService
-	Sign service.SignService
+	Init       service.InitService
+	Sign       service.SignService
+	Activation service.ActivationTokenService
+	// Registration is nil if the registrations of devices are not accepted
+	Registration service.NodeRegistrationService
+	Fingerprint  service.NodeFingerprintService
+	Onboarding   service.NodeOnboardingService
+	Preflight    service.NodePreflightService
 }
 
 func NewInitAPI(cfg *config.CloudConfig) (*InitAPI, error) {
@@ -28,10 +38,36 @@ func NewInitAPI(cfg *config.CloudConfig) (*InitAPI, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &InitAPI{
-		Init: initService,
-		Sign: signService,
-	}, nil
+	activationService, err := service.NewActivationTokenService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	fingerprintService, err := service.NewNodeFingerprintService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	onboardingService, err := service.NewNodeOnboardingService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	preflightService, err := service.NewNodePreflightService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	initAPI := &InitAPI{
+		Init:        initService,
+		Sign:        signService,
+		Activation:  activationService,
+		Fingerprint: fingerprintService,
+		Onboarding:  onboardingService,
+		Preflight:   preflightService,
+	}
+	if cfg.NodeRegistration.Enable {
+		if initAPI.Registration, err = service.NewNodeRegistrationService(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return initAPI, nil
 }
 
 func (api *InitAPI) GetResource(c *common.Context) (interface{}, error) {
@@ -41,6 +77,9 @@ func (api *InitAPI) GetResource(c *common.Context) (interface{}, error) {
 		Node          string `form:"node,omitempty"`
 		InitApplyYaml string `form:"initApplyYaml,omitempty"`
 		Mode          string `form:"mode,omitempty"`
+		MachineID     string `form:"machineId,omitempty"`
+		MACs          string `form:"macs,omitempty"`
+		TPMEKHash     string `form:"tpmEkHash,omitempty"`
 	}{}
 	err := c.Bind(query)
 	if err != nil {
@@ -48,13 +87,36 @@ func (api *InitAPI) GetResource(c *common.Context) (interface{}, error) {
 			common.ErrRequestParamInvalid,
 			common.Field("error", err))
 	}
+	// the install commands are generated by admin server only, which issue new tokens
+	if resourceName == service.TemplateBaetylInitCommand {
+		return nil, common.Error(
+			common.ErrResourceNotFound,
+			common.Field("type", "resource"),
+			common.Field("name", resourceName))
+	}
 	data, err := CheckAndParseToken(query.Token, api.Sign.GenToken)
 	if err != nil {
 		return nil, common.Error(
 			common.ErrRequestParamInvalid,
 			common.Field("error", err))
 	}
-	return api.Init.GetResource(data[service.InfoNamespace].(string), data[service.InfoName].(string), resourceName, map[string]interface{}{
+	ns, name := data[service.InfoNamespace].(string), data[service.InfoName].(string)
+	// the installs activating node count as uses of token, the other resources are fetched while it's valid
+	activation := data[service.InfoActivationToken].(string)
+	if service.ActivationResources[resourceName] {
+		err = api.activate(ns, name, activation, &models.HardwareFingerprint{
+			MachineID: query.MachineID,
+			MACs:      strings.Split(query.MACs, ","),
+			TPMEKHash: query.TPMEKHash,
+		})
+		api.recordActivation(ns, name, err)
+	} else {
+		err = api.Activation.Check(ns, activation, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return api.Init.GetResource(ns, name, resourceName, map[string]interface{}{
 		"Token":         query.Token,
 		"KubeNodeName":  query.Node,
 		"InitApplyYaml": query.InitApplyYaml,
@@ -62,7 +124,193 @@ func (api *InitAPI) GetResource(c *common.Context) (interface{}, error) {
 	})
 }
 
+// activate counts a use of token by the install of node, which carries the certificate of node. The node is bound
+// to the hardware fingerprint presented at its first activation, and the later ones present the same hardware.
+// The hosts refused by their preflight checks are not activated
+func (api *InitAPI) activate(ns, name, activation string, fingerprint *models.HardwareFingerprint) error {
+	if api.Preflight != nil {
+		if err := api.Preflight.Verify(ns, name); err != nil {
+			return err
+		}
+	}
+	// the fingerprint is checked first, so the uses of token are not taken by the hardware mismatched
+	if err := api.Fingerprint.Check(ns, name, fingerprint); err != nil {
+		return err
+	}
+	if err := api.Activation.Use(ns, activation, name); err != nil {
+		return err
+	}
+	return api.Fingerprint.Bind(ns, name, fingerprint)
+}
+
+// recordActivation records the activation of node as the first step of its onboarding, which is failed with the
+// reason if the activation is rejected. The onboarding is diagnostic, so the failure to record it fails nothing
+func (api *InitAPI) recordActivation(ns, name string, activateErr error) {
+	if api.Onboarding == nil {
+		return
+	}
+	step := &models.OnboardingStep{Name: models.OnboardingActivated, Status: models.OnboardingSucceeded}
+	if activateErr != nil {
+		step.Status, step.Reason = models.OnboardingFailed, activateErr.Error()
+	}
+	if err := api.Onboarding.Record(ns, name, step); err != nil {
+		log.L().Warn("failed to record activation of node", log.Any("namespace", ns), log.Any("name", name), log.Error(err))
+	}
+}
+
+// ReportOnboarding records the step of onboarding reported by the node presenting the token it's installed with,
+// which is accepted since the uses of token are taken by the activation. The steps are timed by cloud
+func (api *InitAPI) ReportOnboarding(c *common.Context) (interface{}, error) {
+	req := new(models.OnboardingReport)
+	if err := c.LoadBody(req); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if req.Name == models.OnboardingActivated {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the activation is recorded by cloud"))
+	}
+	data, err := CheckAndParseToken(req.Token, api.Sign.GenToken)
+	if err != nil {
+		return nil, common.Error(
+			common.ErrRequestParamInvalid,
+			common.Field("error", err))
+	}
+	ns, name := data[service.InfoNamespace].(string), data[service.InfoName].(string)
+	step := req.OnboardingStep
+	step.Time = time.Time{}
+	return nil, api.Onboarding.Record(ns, name, &step)
+}
+
+// ReportPreflight checks the facts of host collected by the install script before it activates the node, the
+// script stops installing if the host is refused and prints the warnings otherwise
+func (api *InitAPI) ReportPreflight(c *common.Context) (interface{}, error) {
+	req := new(models.PreflightReport)
+	if err := c.LoadBody(req); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	data, err := CheckAndParseToken(req.Token, api.Sign.GenToken)
+	if err != nil {
+		return nil, common.Error(
+			common.ErrRequestParamInvalid,
+			common.Field("error", err))
+	}
+	ns, name := data[service.InfoNamespace].(string), data[service.InfoName].(string)
+	if err = api.Activation.Check(ns, data[service.InfoActivationToken].(string), name); err != nil {
+		return nil, err
+	}
+	return api.Preflight.Check(ns, name, &req.HostFacts)
+}
+
+// Register registers the device presenting the bootstrap credential of a node group as a pending node, the device
+// sends the same registration until it's approved with the install command of node or rejected
+func (api *InitAPI) Register(c *common.Context) (interface{}, error) {
+	if api.Registration == nil {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "resource"), common.Field("name", "registrations"))
+	}
+	req := new(models.NodeRegistrationRequest)
+	if err := c.LoadBody(req); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	data, err := CheckAndParseBootstrapToken(req.Token, api.Sign.GenToken)
+	if err != nil {
+		return nil, err
+	}
+	ns, activation := data[service.InfoNamespace].(string), data[service.InfoActivationToken].(string)
+	registration, err := api.Registration.GetByFingerprint(ns, req.Fingerprint)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+		// the new registrations count as uses of token
+		token, err := api.Activation.UseBootstrap(ns, activation)
+		if err != nil {
+			return nil, err
+		}
+		registration = &models.NodeRegistration{
+			Namespace:   ns,
+			Name:        req.Name,
+			Fingerprint: req.Fingerprint,
+			Hardware:    req.Hardware,
+			Mode:        req.Mode,
+			Token:       activation,
+			NodeGroup:   token.NodeGroup,
+		}
+		api.Registration.Place(token, registration, c.ClientIP())
+		if registration, err = api.Registration.Register(registration); err != nil {
+			return nil, err
+		}
+		log.L().Info("node registration is pending", log.Any("namespace", ns), log.Any("name", registration.Name), log.Any("fingerprint", req.Fingerprint))
+	} else if registration.Token != activation {
+		log.L().Info("node registration of another token", log.Any("namespace", ns), log.Any("name", registration.Name), log.Any("token", activation))
+		return nil, common.Error(common.ErrInvalidToken)
+	} else if _, err = api.Activation.CheckBootstrap(ns, activation); err != nil {
+		return nil, err
+	}
+
+	res := &models.NodeRegistrationStatus{Name: registration.Name, Status: registration.Status, Reason: registration.Reason}
+	if registration.Status == models.NodeRegistrationApproved {
+		if res.Command, err = api.genRegistrationCmd(registration); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// genRegistrationCmd returns the install command of the node approved, which carries the token issued for the node
+func (api *InitAPI) genRegistrationCmd(registration *models.NodeRegistration) (string, error) {
+	params := map[string]interface{}{
+		"mode":            registration.Mode,
+		"template":        service.TemplateBaetylInitCommand,
+		"ActivationToken": registration.ActivationToken,
+	}
+	if registration.Mode == context.RunModeNative {
+		params["InitApplyYaml"] = "baetyl-init-apply.json"
+	} else {
+		params["InitApplyYaml"] = "baetyl-init-deployment.yml"
+	}
+	cmd, err := api.Init.GetResource(registration.Namespace, registration.Name, service.TemplateBaetylInitCommand, params)
+	if err != nil {
+		return "", err
+	}
+	return string(cmd.([]byte)), nil
+}
+
 func CheckAndParseToken(token string, genToken func(map[string]interface{}) (string, error)) (map[string]interface{}, error) {
+	info, err := parseSignedToken(token, genToken)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ok := info[service.InfoName].(string)
+	if !ok {
+		log.L().Info("invalid token no node name")
+		return nil, common.Error(common.ErrInvalidToken)
+	}
+
+	_, ok = info[service.InfoActivationToken].(string)
+	if !ok {
+		log.L().Info("invalid token no activation token")
+		return nil, common.Error(common.ErrInvalidToken)
+	}
+	return info, nil
+}
+
+// CheckAndParseBootstrapToken checks the bootstrap credential of the devices registering as nodes, which carries the
+// activation token of a node group instead of a node name
+func CheckAndParseBootstrapToken(token string, genToken func(map[string]interface{}) (string, error)) (map[string]interface{}, error) {
+	info, err := parseSignedToken(token, genToken)
+	if err != nil {
+		return nil, err
+	}
+	_, ok := info[service.InfoActivationToken].(string)
+	if !ok {
+		log.L().Info("invalid token no activation token")
+		return nil, common.Error(common.ErrInvalidToken)
+	}
+	return info, nil
+}
+
+// parseSignedToken checks the sign, namespace and expiration of token
+func parseSignedToken(token string, genToken func(map[string]interface{}) (string, error)) (map[string]interface{}, error) {
 	// check len
 	if len(token) < 10 {
 		log.L().Info("invalid token length")
@@ -96,12 +344,6 @@ func CheckAndParseToken(token string, genToken func(map[string]interface{}) (str
 		return nil, common.Error(common.ErrInvalidToken)
 	}
 
-	_, ok = info[service.InfoName].(string)
-	if !ok {
-		log.L().Info("invalid token no node name", log.Error(err))
-		return nil, common.Error(common.ErrInvalidToken)
-	}
-
 	expiry, ok := info[service.InfoExpiry].(float64)
 	if !ok {
 		log.L().Info("invalid token no expiry", log.Error(err))
@@ -1,8 +1,10 @@
 package api
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -16,6 +18,7 @@ import (
 	"github.com/baetyl/baetyl-cloud/v2/common"
 	"github.com/baetyl/baetyl-cloud/v2/config"
 	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 	"github.com/baetyl/baetyl-cloud/v2/service"
 )
 
@@ -29,6 +32,13 @@ func initInitAPI(t *testing.T) (*InitAPI, *gin.Engine, *gomock.Controller) {
 		init := v1.Group("/init")
 		init.GET("/:resource", mockIM, common.WrapperRaw(api.GetResource, true))
 	}
+	{
+		v1.POST("/registrations", common.Wrapper(api.Register))
+	}
+	{
+		v1.POST("/onboarding", common.Wrapper(api.ReportOnboarding))
+		v1.POST("/preflight", common.Wrapper(api.ReportPreflight))
+	}
 	return api, router, mockCtl
 }
 
@@ -45,11 +55,16 @@ func TestInitAPIImpl_GetResource(t *testing.T) {
 	api.Init = mInit
 	mSign := ms.NewMockSignService(mockCtl)
 	api.Sign = mSign
+	mActivation := ms.NewMockActivationTokenService(mockCtl)
+	api.Activation = mActivation
+	mFingerprint := ms.NewMockNodeFingerprintService(mockCtl)
+	api.Fingerprint = mFingerprint
 	// 构造token
 	info := map[string]interface{}{
-		service.InfoName:      "n0",
-		service.InfoNamespace: "default",
-		service.InfoExpiry:    time.Now().Unix() + 60*60*24*3650,
+		service.InfoName:            "n0",
+		service.InfoNamespace:       "default",
+		service.InfoExpiry:          time.Now().Unix() + 60*60*24*3650,
+		service.InfoActivationToken: "token01",
 	}
 	data, err := json.Marshal(info)
 	assert.NoError(t, err)
@@ -64,7 +79,8 @@ func TestInitAPIImpl_GetResource(t *testing.T) {
 
 	// ResourceSetup
 	mInit.EXPECT().GetResource("default", "n0", "kube-init-setup.sh", gomock.Any()).Return([]byte("setup"), nil)
-	mSign.EXPECT().GenToken(gomock.Any()).Return(token, nil).Times(3)
+	mSign.EXPECT().GenToken(gomock.Any()).Return(token, nil).Times(7)
+	mActivation.EXPECT().Check("default", "token01", "n0").Return(nil).Times(3)
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, sendUrl.String(), nil)
 
@@ -96,6 +112,57 @@ func TestInitAPIImpl_GetResource(t *testing.T) {
 
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the install of node counts as a use of token
+	sendUrl, _ = url.Parse("/v1/init/" + "baetyl-init-deployment.yml?")
+	val = sendUrl.Query()
+	val.Set("token", token)
+	sendUrl.RawQuery = val.Encode()
+
+	fingerprint := &models.HardwareFingerprint{MachineID: "m01", MACs: []string{"00:11:22:33:44:55", "00:11:22:33:44:66"}, TPMEKHash: "ek01"}
+	mFingerprint.EXPECT().Check("default", "n0", fingerprint).Return(nil)
+	mActivation.EXPECT().Use("default", "token01", "n0").Return(nil)
+	mFingerprint.EXPECT().Bind("default", "n0", fingerprint).Return(nil)
+	mInit.EXPECT().GetResource("default", "n0", "baetyl-init-deployment.yml", gomock.Any()).Return([]byte("deployment"), nil)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, sendUrl.String()+"&machineId=m01&macs=00:11:22:33:44:55,00:11:22:33:44:66&tpmEkHash=ek01", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the token used up or revoked is rejected
+	mFingerprint.EXPECT().Check("default", "n0", gomock.Any()).Return(nil)
+	mActivation.EXPECT().Use("default", "token01", "n0").Return(common.Error(common.ErrInvalidToken))
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, sendUrl.String(), nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the hardware mismatched takes no use of token
+	mFingerprint.EXPECT().Check("default", "n0", gomock.Any()).Return(common.Error(common.ErrNodeFingerprint, common.Field("name", "n0")))
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, sendUrl.String()+"&machineId=m02", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	mActivation.EXPECT().Check("default", "token01", "n0").Return(common.Error(common.ErrInvalidToken))
+	sendUrl, _ = url.Parse("/v1/init/" + "baetyl-install.sh?")
+	val = sendUrl.Query()
+	val.Set("token", token)
+	sendUrl.RawQuery = val.Encode()
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, sendUrl.String(), nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the install command is not served, which issues new tokens
+	sendUrl, _ = url.Parse("/v1/init/" + service.TemplateBaetylInitCommand + "?")
+	val = sendUrl.Query()
+	val.Set("token", token)
+	sendUrl.RawQuery = val.Encode()
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, sendUrl.String(), nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
 func TestInitAPIImpl_CheckAndParseToken(t *testing.T) {
@@ -105,9 +172,10 @@ func TestInitAPIImpl_CheckAndParseToken(t *testing.T) {
 	mSign := ms.NewMockSignService(mockCtl)
 	as.Sign = mSign
 	info := map[string]interface{}{
-		service.InfoName:      "n0",
-		service.InfoNamespace: "default",
-		service.InfoExpiry:    time.Now().Unix() + 60*60*24*3650,
+		service.InfoName:            "n0",
+		service.InfoNamespace:       "default",
+		service.InfoExpiry:          time.Now().Unix() + 60*60*24*3650,
+		service.InfoActivationToken: "token01",
 	}
 	data, err := json.Marshal(info)
 	assert.NoError(t, err)
@@ -121,4 +189,185 @@ func TestInitAPIImpl_CheckAndParseToken(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, info[service.InfoName], res[service.InfoName].(string))
 	assert.Equal(t, info[service.InfoNamespace], res[service.InfoNamespace].(string))
+	assert.Equal(t, info[service.InfoActivationToken], res[service.InfoActivationToken].(string))
+
+	// the tokens generated before activation tokens are rejected
+	delete(info, service.InfoActivationToken)
+	data, err = json.Marshal(info)
+	assert.NoError(t, err)
+	token = sign + hex.EncodeToString(data)
+	mSign.EXPECT().GenToken(gomock.Any()).Return(token, nil).Times(1)
+	_, err = CheckAndParseToken(token, as.Sign.GenToken)
+	assert.Error(t, err)
+}
+
+func TestInitAPIImpl_Register(t *testing.T) {
+	api, router, mockCtl := initInitAPI(t)
+	defer mockCtl.Finish()
+
+	send := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/v1/registrations", bytes.NewReader([]byte(body)))
+		router.ServeHTTP(w, req)
+		return w
+	}
+	// the registrations are not accepted by default
+	assert.Equal(t, http.StatusNotFound, send(`{"token":"t","fingerprint":"sn-0001"}`).Code)
+
+	mInit, mSign := ms.NewMockInitService(mockCtl), ms.NewMockSignService(mockCtl)
+	mActivation, mRegistration := ms.NewMockActivationTokenService(mockCtl), ms.NewMockNodeRegistrationService(mockCtl)
+	api.Init, api.Sign, api.Activation, api.Registration = mInit, mSign, mActivation, mRegistration
+
+	// the bootstrap credential carries no node name
+	data, err := json.Marshal(map[string]interface{}{
+		service.InfoNamespace:       "default",
+		service.InfoExpiry:          time.Now().Unix() + 3600,
+		service.InfoActivationToken: "bootstrap01",
+	})
+	assert.NoError(t, err)
+	token := "0123456789" + hex.EncodeToString(data)
+	mSign.EXPECT().GenToken(gomock.Any()).Return(token, nil).AnyTimes()
+	body, _ := json.Marshal(&models.NodeRegistrationRequest{Token: token, Fingerprint: "sn-0001", Hardware: map[string]string{"model": "box-x1"}})
+
+	pending := &models.NodeRegistration{Namespace: "default", Name: "node01", Fingerprint: "sn-0001", Token: "bootstrap01", NodeGroup: "group01", Status: models.NodeRegistrationPending}
+	mRegistration.EXPECT().GetByFingerprint("default", "sn-0001").Return(nil, common.Error(common.ErrResourceNotFound))
+	bootstrap := &models.ActivationToken{Namespace: "default", Name: "bootstrap01", NodeGroup: "group01",
+		Placement: &models.NodePlacement{Labels: map[string]string{"model": "{{.model}}"}}}
+	mActivation.EXPECT().UseBootstrap("default", "bootstrap01").Return(bootstrap, nil)
+	// the registration is placed by the token before registered
+	mRegistration.EXPECT().Place(bootstrap, gomock.Any(), gomock.Any()).Do(func(_ *models.ActivationToken, r *models.NodeRegistration, _ string) {
+		r.Labels, r.NodeGroup = map[string]string{"model": "box-x1"}, "group02"
+	})
+	mRegistration.EXPECT().Register(&models.NodeRegistration{Namespace: "default", Fingerprint: "sn-0001", Hardware: map[string]string{"model": "box-x1"}, Token: "bootstrap01",
+		NodeGroup: "group02", Labels: map[string]string{"model": "box-x1"}}).Return(pending, nil)
+	w := send(string(body))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.NodeRegistrationStatus
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, models.NodeRegistrationStatus{Name: "node01", Status: models.NodeRegistrationPending}, res)
+
+	// the approved device gets the install command carrying the token of node
+	approved := *pending
+	approved.Status = models.NodeRegistrationApproved
+	approved.ActivationToken = "token01"
+	mRegistration.EXPECT().GetByFingerprint("default", "sn-0001").Return(&approved, nil)
+	mActivation.EXPECT().CheckBootstrap("default", "bootstrap01").Return(&models.ActivationToken{Namespace: "default", Name: "bootstrap01", NodeGroup: "group01"}, nil)
+	mInit.EXPECT().GetResource("default", "node01", service.TemplateBaetylInitCommand, map[string]interface{}{
+		"mode":            "",
+		"template":        service.TemplateBaetylInitCommand,
+		"ActivationToken": "token01",
+		"InitApplyYaml":   "baetyl-init-deployment.yml",
+	}).Return([]byte("curl"), nil)
+	w = send(string(body))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "curl", res.Command)
+
+	// the fingerprint registered by another credential
+	other := *pending
+	other.Token = "bootstrap02"
+	mRegistration.EXPECT().GetByFingerprint("default", "sn-0001").Return(&other, nil)
+	assert.Equal(t, http.StatusBadRequest, send(string(body)).Code)
+
+	assert.Equal(t, http.StatusBadRequest, send(`{"token":"0123456789","fingerprint":"sn-0001"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, send(`{"token":"`+token+`"}`).Code)
+}
+
+func TestInitAPIImpl_ReportOnboarding(t *testing.T) {
+	api, router, mockCtl := initInitAPI(t)
+	defer mockCtl.Finish()
+	mSign, mOnboarding := ms.NewMockSignService(mockCtl), ms.NewMockNodeOnboardingService(mockCtl)
+	mActivation, mFingerprint, mInit := ms.NewMockActivationTokenService(mockCtl), ms.NewMockNodeFingerprintService(mockCtl), ms.NewMockInitService(mockCtl)
+	api.Sign, api.Onboarding, api.Activation, api.Fingerprint, api.Init = mSign, mOnboarding, mActivation, mFingerprint, mInit
+
+	data, err := json.Marshal(map[string]interface{}{
+		service.InfoName:            "n0",
+		service.InfoNamespace:       "default",
+		service.InfoExpiry:          time.Now().Unix() + 3600,
+		service.InfoActivationToken: "token01",
+	})
+	assert.NoError(t, err)
+	token := "0123456789" + hex.EncodeToString(data)
+	mSign.EXPECT().GenToken(gomock.Any()).Return(token, nil).AnyTimes()
+	send := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/v1/onboarding", bytes.NewReader([]byte(body)))
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// the activation is recorded once the resources carrying the certificate are fetched
+	mFingerprint.EXPECT().Check("default", "n0", gomock.Any()).Return(nil)
+	mActivation.EXPECT().Use("default", "token01", "n0").Return(common.Error(common.ErrInvalidToken))
+	mOnboarding.EXPECT().Record("default", "n0", gomock.Any()).DoAndReturn(func(_, _ string, step *models.OnboardingStep) error {
+		assert.Equal(t, models.OnboardingActivated, step.Name)
+		assert.Equal(t, models.OnboardingFailed, step.Status)
+		assert.NotEmpty(t, step.Reason)
+		return nil
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/init/baetyl-init-deployment.yml?token="+token, nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mFingerprint.EXPECT().Check("default", "n0", gomock.Any()).Return(nil)
+	mActivation.EXPECT().Use("default", "token01", "n0").Return(nil)
+	mFingerprint.EXPECT().Bind("default", "n0", gomock.Any()).Return(nil)
+	mOnboarding.EXPECT().Record("default", "n0", &models.OnboardingStep{Name: models.OnboardingActivated, Status: models.OnboardingSucceeded}).Return(fmt.Errorf("error"))
+	mInit.EXPECT().GetResource("default", "n0", "baetyl-init-deployment.yml", gomock.Any()).Return([]byte("deployment"), nil)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/v1/init/baetyl-init-deployment.yml?token="+token, nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the steps reported by node are timed by cloud
+	mOnboarding.EXPECT().Record("default", "n0", &models.OnboardingStep{Name: models.OnboardingCoreStarted, Status: models.OnboardingFailed, Reason: "image pull failed"}).Return(nil)
+	assert.Equal(t, http.StatusOK, send(`{"token":"`+token+`","name":"coreStarted","status":"failed","reason":"image pull failed","time":"2020-01-01T00:00:00Z"}`).Code)
+
+	assert.Equal(t, http.StatusBadRequest, send(`{"token":"`+token+`","name":"activated","status":"succeeded"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, send(`{"token":"`+token+`","name":"unknown","status":"succeeded"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, send(`{"token":"0123456789","name":"downloaded","status":"succeeded"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, send(`{"name":"downloaded","status":"succeeded"}`).Code)
+}
+
+func TestInitAPIImpl_ReportPreflight(t *testing.T) {
+	api, router, mockCtl := initInitAPI(t)
+	defer mockCtl.Finish()
+	mSign, mPreflight, mActivation := ms.NewMockSignService(mockCtl), ms.NewMockNodePreflightService(mockCtl), ms.NewMockActivationTokenService(mockCtl)
+	api.Sign, api.Preflight, api.Activation = mSign, mPreflight, mActivation
+
+	data, err := json.Marshal(map[string]interface{}{
+		service.InfoName:            "n0",
+		service.InfoNamespace:       "default",
+		service.InfoExpiry:          time.Now().Unix() + 3600,
+		service.InfoActivationToken: "token01",
+	})
+	assert.NoError(t, err)
+	token := "0123456789" + hex.EncodeToString(data)
+	mSign.EXPECT().GenToken(gomock.Any()).Return(token, nil).AnyTimes()
+	send := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/v1/preflight", bytes.NewReader([]byte(body)))
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	facts := &models.HostFacts{OS: "Linux", Kernel: "3.10.0", Arch: "x86_64", CgroupDriver: "cgroupfs", DiskFree: 512}
+	mActivation.EXPECT().Check("default", "token01", "n0").Return(nil)
+	mPreflight.EXPECT().Check("default", "n0", facts).Return(&models.NodePreflight{Facts: *facts, Errors: []string{"the kernel version 3.10.0 is lower than 4.4"}, Refused: true}, nil)
+	w := send(`{"token":"` + token + `","os":"Linux","kernel":"3.10.0","arch":"x86_64","cgroupDriver":"cgroupfs","diskFree":512}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"refused":true`)
+
+	// the token used up is not checked
+	mActivation.EXPECT().Check("default", "token01", "n0").Return(common.Error(common.ErrInvalidToken))
+	assert.Equal(t, http.StatusBadRequest, send(`{"token":"`+token+`","kernel":"5.4"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, send(`{"kernel":"5.4"}`).Code)
+
+	// the host refused is not activated
+	mPreflight.EXPECT().Verify("default", "n0").Return(common.Error(common.ErrNodePreflight, common.Field("name", "n0")))
+	w = httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/init/baetyl-init-deployment.yml?token="+token, nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
 }
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetInstallTemplate get the install template overridden in namespace
+func (api *API) GetInstallTemplate(c *common.Context) (interface{}, error) {
+	return api.InstallTemplate.Get(c.GetNamespace(), c.GetNameFromParam())
+}
+
+// GetDefaultInstallTemplate get the default template, which is rendered if not overridden
+func (api *API) GetDefaultInstallTemplate(c *common.Context) (interface{}, error) {
+	return api.InstallTemplate.GetDefault(c.GetNameFromParam())
+}
+
+// ListInstallTemplate list the install templates overridden in namespace
+func (api *API) ListInstallTemplate(c *common.Context) (interface{}, error) {
+	tpls, err := api.InstallTemplate.List(c.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(tpls), tpls, ""), nil
+}
+
+// CreateInstallTemplate overrides the install template in namespace, the nodes installed later get it
+func (api *API) CreateInstallTemplate(c *common.Context) (interface{}, error) {
+	tpl, err := api.parseInstallTemplate(c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = api.InstallTemplate.Get(tpl.Namespace, tpl.Name); err == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the install template is already overridden"))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, err
+	}
+	if c.IsDryRun() {
+		return tpl, nil
+	}
+	return api.InstallTemplate.Create(tpl)
+}
+
+// UpdateInstallTemplate update the install template overridden, the nodes installed are not changed
+func (api *API) UpdateInstallTemplate(c *common.Context) (interface{}, error) {
+	tpl, err := api.parseInstallTemplate(c)
+	if err != nil {
+		return nil, err
+	}
+	old, err := api.InstallTemplate.Get(tpl.Namespace, tpl.Name)
+	if err != nil {
+		return nil, err
+	}
+	if c.IsDryRun() {
+		tpl.CreateTime = old.CreateTime
+		return tpl, nil
+	}
+	return api.InstallTemplate.Update(tpl)
+}
+
+// DeleteInstallTemplate delete the install template overridden, the default one is rendered again
+func (api *API) DeleteInstallTemplate(c *common.Context) (interface{}, error) {
+	return nil, api.InstallTemplate.Delete(c.GetNamespace(), c.GetNameFromParam())
+}
+
+func (api *API) parseInstallTemplate(c *common.Context) (*models.InstallTemplate, error) {
+	tpl := new(models.InstallTemplate)
+	if err := c.LoadBody(tpl); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if name := c.GetNameFromParam(); name != "" {
+		tpl.Name = name
+	}
+	if tpl.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	tpl.Namespace = c.GetNamespace()
+	// the overrides are checked before the dry run, which is not passed to the service
+	if err := service.ValidateInstallTemplate(tpl); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initInstallTemplateAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		installTemplates := v1.Group("/installtemplates")
+		installTemplates.GET("", mockIM, common.Wrapper(api.ListInstallTemplate))
+		installTemplates.GET("/:name", mockIM, common.Wrapper(api.GetInstallTemplate))
+		installTemplates.GET("/:name/default", mockIM, common.Wrapper(api.GetDefaultInstallTemplate))
+		installTemplates.POST("", mockIM, common.Wrapper(api.CreateInstallTemplate))
+		installTemplates.PUT("/:name", mockIM, common.Wrapper(api.UpdateInstallTemplate))
+		installTemplates.DELETE("/:name", mockIM, common.Wrapper(api.DeleteInstallTemplate))
+	}
+	return api, router, mockCtl
+}
+
+func TestInstallTemplateAPI(t *testing.T) {
+	api, router, mockCtl := initInstallTemplateAPI(t)
+	defer mockCtl.Finish()
+	sTemplate := ms.NewMockInstallTemplateService(mockCtl)
+	api.InstallTemplate = sTemplate
+
+	tpl := &models.InstallTemplate{Name: "baetyl-install.sh", Content: "export HTTPS_PROXY={{.Values.proxy}}", Values: map[string]string{"proxy": "http://proxy:3128"}}
+	sTemplate.EXPECT().Get("default", "baetyl-install.sh").Return(nil, common.Error(common.ErrResourceNotFound))
+	sTemplate.EXPECT().Create(gomock.Any()).DoAndReturn(func(t2 *models.InstallTemplate) (*models.InstallTemplate, error) {
+		assert.Equal(t, "default", t2.Namespace)
+		assert.Equal(t, tpl.Values, t2.Values)
+		return t2, nil
+	})
+	body, _ := json.Marshal(tpl)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/installtemplates", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// overridden once
+	sTemplate.EXPECT().Get("default", "baetyl-install.sh").Return(tpl, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/installtemplates", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the properties of platform are not read by the overrides
+	req, _ = http.NewRequest(http.MethodPost, "/v1/installtemplates?dryRun=true", bytes.NewReader([]byte(`{"name":"baetyl-install.sh","content":"{{GetProperty \"system-registry-auth\"}}"}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the content is required
+	req, _ = http.NewRequest(http.MethodPost, "/v1/installtemplates", bytes.NewReader([]byte(`{"name":"baetyl-install.sh"}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	sTemplate.EXPECT().Get("default", "baetyl-install.sh").Return(tpl, nil)
+	sTemplate.EXPECT().Update(gomock.Any()).DoAndReturn(func(t2 *models.InstallTemplate) (*models.InstallTemplate, error) {
+		assert.Equal(t, "curl {{.Values.mirror}}", t2.Content)
+		return t2, nil
+	})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/installtemplates/baetyl-install.sh", bytes.NewReader([]byte(`{"content":"curl {{.Values.mirror}}"}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sTemplate.EXPECT().List("default").Return([]models.InstallTemplate{*tpl}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/installtemplates", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	sTemplate.EXPECT().GetDefault("baetyl-install.sh").Return(&models.InstallTemplateDefault{Name: "baetyl-install.sh", Content: "#!/bin/sh"}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/installtemplates/baetyl-install.sh/default", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "#!/bin/sh")
+
+	sTemplate.EXPECT().Delete("default", "baetyl-install.sh").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/installtemplates/baetyl-install.sh", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
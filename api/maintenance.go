@@ -0,0 +1,61 @@
+package api
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetNodeMaintenance get the maintenance window in effect for node and whether it is open now
+func (api *API) GetNodeMaintenance(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	return api.Maintenance.Get(node, time.Now())
+}
+
+// UpdateNodeMaintenance set the maintenance window of node, which overrides the windows of its groups
+func (api *API) UpdateNodeMaintenance(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	window := new(models.MaintenanceWindow)
+	if err := c.LoadBody(window); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if err := service.ValidateMaintenanceWindow(window); err != nil {
+		return nil, err
+	}
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if node.Attributes == nil {
+		node.Attributes = map[string]interface{}{}
+	}
+	node.Attributes[common.AttributeMaintenanceWindow] = window
+	if node, err = api.Node.Update(ns, node); err != nil {
+		return nil, err
+	}
+	log.L().Info("node maintenance window is set", log.Any("namespace", ns), log.Any("name", n), log.Any("window", window))
+	return api.Maintenance.Get(node, time.Now())
+}
+
+// DeleteNodeMaintenance delete the maintenance window of node, the windows of its groups are in effect again
+func (api *API) DeleteNodeMaintenance(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := node.Attributes[common.AttributeMaintenanceWindow]; !ok {
+		return nil, nil
+	}
+	delete(node.Attributes, common.AttributeMaintenanceWindow)
+	_, err = api.Node.Update(ns, node)
+	return nil, err
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initMaintenanceAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		nodes := v1.Group("/nodes")
+		nodes.GET("/:name/maintenance", mockIM, common.Wrapper(api.GetNodeMaintenance))
+		nodes.PUT("/:name/maintenance", mockIM, common.Wrapper(api.UpdateNodeMaintenance))
+		nodes.DELETE("/:name/maintenance", mockIM, common.Wrapper(api.DeleteNodeMaintenance))
+	}
+	return api, router, mockCtl
+}
+
+func TestGetNodeMaintenance(t *testing.T) {
+	api, router, mockCtl := initMaintenanceAPI(t)
+	defer mockCtl.Finish()
+	sNode, sMaintenance := ms.NewMockNodeService(mockCtl), ms.NewMockMaintenanceService(mockCtl)
+	api.Node, api.Maintenance = sNode, sMaintenance
+
+	node := &specV1.Node{Namespace: "default", Name: "node01"}
+	window := &models.MaintenanceWindow{Cron: "0 2 * * *", Duration: "2h"}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	sMaintenance.EXPECT().Get(node, gomock.Any()).Return(&models.NodeMaintenance{Window: window, Group: "g1"}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/maintenance", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.NodeMaintenance
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, window, res.Window)
+	assert.Equal(t, "g1", res.Group)
+	assert.False(t, res.Open)
+}
+
+func TestUpdateNodeMaintenance(t *testing.T) {
+	api, router, mockCtl := initMaintenanceAPI(t)
+	defer mockCtl.Finish()
+	sNode, sMaintenance := ms.NewMockNodeService(mockCtl), ms.NewMockMaintenanceService(mockCtl)
+	api.Node, api.Maintenance = sNode, sMaintenance
+
+	window := &models.MaintenanceWindow{Cron: "0 2 * * 1-5", Duration: "2h", Timezone: "Asia/Shanghai"}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01"}, nil)
+	sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(_ string, n *specV1.Node) (*specV1.Node, error) {
+		assert.Equal(t, window, n.Attributes[common.AttributeMaintenanceWindow])
+		return n, nil
+	})
+	sMaintenance.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.NodeMaintenance{Window: window}, nil)
+	body, _ := json.Marshal(window)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/nodes/node01/maintenance", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	for _, b := range []*models.MaintenanceWindow{
+		{Cron: "0 2 * * *"},
+		{Cron: "0 2 * * *", Duration: "2d"},
+		{Cron: "every day", Duration: "2h"},
+	} {
+		body, _ = json.Marshal(b)
+		req, _ = http.NewRequest(http.MethodPut, "/v1/nodes/node01/maintenance", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestDeleteNodeMaintenance(t *testing.T) {
+	api, router, mockCtl := initMaintenanceAPI(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+
+	node := &specV1.Node{
+		Namespace:  "default",
+		Name:       "node01",
+		Attributes: map[string]interface{}{common.AttributeMaintenanceWindow: map[string]interface{}{"cron": "0 2 * * *", "duration": "2h"}},
+	}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(_ string, n *specV1.Node) (*specV1.Node, error) {
+		assert.NotContains(t, n.Attributes, common.AttributeMaintenanceWindow)
+		return n, nil
+	})
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/nodes/node01/maintenance", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// nothing to delete
+	sNode.EXPECT().Get(nil, "default", "node02").Return(&specV1.Node{Namespace: "default", Name: "node02"}, nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/nodes/node02/maintenance", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
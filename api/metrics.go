@@ -0,0 +1,74 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+const (
+	defaultMetricsRange = time.Hour
+	defaultMetricsStep  = time.Minute
+	// MaxMetricsPoints the max points returned in one query, the step should be larger for the longer range
+	MaxMetricsPoints = 11000
+)
+
+// GetNodeMetrics get the history of node resource usage for charting,
+// start and end are unix timestamps or RFC3339 times, step is the seconds or duration between points
+func (api *API) GetNodeMetrics(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	end, err := parseMetricsTime(c.Query("end"), time.Now().UTC())
+	if err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "invalid end: "+err.Error()))
+	}
+	start, err := parseMetricsTime(c.Query("start"), end.Add(-defaultMetricsRange))
+	if err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "invalid start: "+err.Error()))
+	}
+	step, err := parseMetricsStep(c.Query("step"))
+	if err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "invalid step: "+err.Error()))
+	}
+	if !start.Before(end) {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "start should be before end"))
+	}
+	if end.Sub(start)/step > MaxMetricsPoints {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "too many points, the step should be larger"))
+	}
+	if _, err = api.Node.Get(nil, ns, n); err != nil {
+		return nil, err
+	}
+	return api.Metrics.Query(ns, n, start, end, step)
+}
+
+func parseMetricsTime(val string, def time.Time) (time.Time, error) {
+	if val == "" {
+		return def, nil
+	}
+	if sec, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, val)
+	return t.UTC(), err
+}
+
+func parseMetricsStep(val string) (time.Duration, error) {
+	if val == "" {
+		return defaultMetricsStep, nil
+	}
+	step, err := time.ParseDuration(val)
+	if err != nil {
+		sec, serr := strconv.Atoi(val)
+		if serr != nil {
+			return 0, err
+		}
+		step = time.Duration(sec) * time.Second
+	}
+	if step < time.Second {
+		return 0, errors.New("step should be at least 1s")
+	}
+	return step, nil
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initMetricsAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		v1.GET("/nodes/:name/metrics", mockIM, common.Wrapper(api.GetNodeMetrics))
+	}
+	return api, router, mockCtl
+}
+
+func TestGetNodeMetrics(t *testing.T) {
+	api, router, mockCtl := initMetricsAPI(t)
+	defer mockCtl.Finish()
+	sNode, sMetrics := ms.NewMockNodeService(mockCtl), ms.NewMockNodeMetricsService(mockCtl)
+	api.Node, api.Metrics = sNode, sMetrics
+
+	start, end := time.Unix(1640995200, 0).UTC(), time.Unix(1640998800, 0).UTC()
+	sNode.EXPECT().Get(nil, "default", "n1").Return(&specV1.Node{Namespace: "default", Name: "n1"}, nil).Times(2)
+	sMetrics.EXPECT().Query("default", "n1", start, end, 5*time.Minute).Return(&models.NodeMetrics{Name: "n1", Step: 300}, nil).Times(2)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/n1/metrics?start=1640995200&end=1640998800&step=300", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/n1/metrics?start=2022-01-01T00:00:00Z&end=2022-01-01T01:00:00Z&step=5m", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	for _, query := range []string{
+		"start=abc",
+		"step=0",
+		"step=abc",
+		"start=1640998800&end=1640995200",
+		"start=0&end=1640998800&step=1",
+	} {
+		req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/n1/metrics?"+query, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	sNode.EXPECT().Get(nil, "default", "n2").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/n2/metrics", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
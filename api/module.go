@@ -70,6 +70,9 @@ func (api *API) UpdateModule(c *common.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = api.refreshModuleArtifactsImage(res); err != nil {
+		return nil, err
+	}
 	return res, nil
 }
 
@@ -93,6 +96,7 @@ func (api *API) ListModules(c *common.Context) (interface{}, error) {
 	if err := c.Bind(params); err != nil {
 		return nil, err
 	}
+	params.SetContext(c.RequestContext())
 	res, err := api.Module.ListModules(params, common.ModuleType(tp))
 	if err != nil {
 		return nil, err
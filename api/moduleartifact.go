@@ -0,0 +1,68 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetModuleArtifacts get the artifacts of module version built for the platforms
+func (api *API) GetModuleArtifacts(c *common.Context) (interface{}, error) {
+	name, version := c.GetNameFromParam(), c.Param("version")
+	if _, err := api.Module.GetModuleByVersion(name, version); err != nil {
+		return nil, err
+	}
+	return api.ModuleArtifact.Get(name, version)
+}
+
+// UpdateModuleArtifacts update the artifacts of module version, the nodes of the platforms listed run the images
+// built for them instead of the image of module version since their next sync
+func (api *API) UpdateModuleArtifacts(c *common.Context) (interface{}, error) {
+	name, version := c.GetNameFromParam(), c.Param("version")
+	module, err := api.Module.GetModuleByVersion(name, version)
+	if err != nil {
+		return nil, err
+	}
+	artifacts := new(models.ModuleArtifacts)
+	if err = c.LoadBody(artifacts); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	platforms := map[string]bool{}
+	for i := range artifacts.Artifacts {
+		artifact := &artifacts.Artifacts[i]
+		artifact.Platform = strings.ToLower(strings.TrimSpace(artifact.Platform))
+		if platforms[artifact.Platform] {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "platform "+artifact.Platform+" is duplicated"))
+		}
+		if artifact.Image == "" && artifact.Program == "" {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "neither image nor program of platform "+artifact.Platform+" is set"))
+		}
+		platforms[artifact.Platform] = true
+	}
+	artifacts.Name, artifacts.Version, artifacts.Image = name, version, module.Image
+	return api.ModuleArtifact.Set(artifacts)
+}
+
+// DeleteModuleArtifacts delete the artifacts of module version, all nodes run the image of module version
+func (api *API) DeleteModuleArtifacts(c *common.Context) (interface{}, error) {
+	return nil, api.ModuleArtifact.Delete(c.GetNameFromParam(), c.Param("version"))
+}
+
+// refreshModuleArtifactsImage keeps the image of the artifacts of module version the same as the module version,
+// by which the services running it are matched
+func (api *API) refreshModuleArtifactsImage(module *models.Module) error {
+	if api.ModuleArtifact == nil || module == nil {
+		return nil
+	}
+	artifacts, err := api.ModuleArtifact.Get(module.Name, module.Version)
+	if err != nil {
+		return err
+	}
+	if len(artifacts.Artifacts) == 0 || artifacts.Image == module.Image {
+		return nil
+	}
+	artifacts.Image = module.Image
+	_, err = api.ModuleArtifact.Set(artifacts)
+	return err
+}
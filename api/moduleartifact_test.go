@@ -0,0 +1,120 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initModuleArtifactAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		module := v1.Group("modules")
+		module.PUT("/:name/version/:version", mockIM, common.Wrapper(api.UpdateModule))
+		module.GET("/:name/version/:version/artifacts", mockIM, common.Wrapper(api.GetModuleArtifacts))
+		module.PUT("/:name/version/:version/artifacts", mockIM, common.Wrapper(api.UpdateModuleArtifacts))
+		module.DELETE("/:name/version/:version/artifacts", mockIM, common.Wrapper(api.DeleteModuleArtifacts))
+	}
+	return api, router, mockCtl
+}
+
+func TestModuleArtifacts(t *testing.T) {
+	api, router, mockCtl := initModuleArtifactAPI(t)
+	defer mockCtl.Finish()
+	sModule := ms.NewMockModuleService(mockCtl)
+	sArtifact := ms.NewMockModuleArtifactService(mockCtl)
+	api.Module = sModule
+	api.ModuleArtifact = sArtifact
+
+	module := &models.Module{Name: "baetyl", Version: "v2.2.0", Image: "baetyltech/baetyl:v2.2.0"}
+	sModule.EXPECT().GetModuleByVersion("baetyl", "v2.2.0").Return(module, nil).AnyTimes()
+	sModule.EXPECT().GetModuleByVersion("baetyl", "v0").Return(nil, common.Error(common.ErrResourceNotFound)).AnyTimes()
+
+	sArtifact.EXPECT().Get("baetyl", "v2.2.0").Return(&models.ModuleArtifacts{Name: "baetyl", Version: "v2.2.0"}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/modules/baetyl/version/v2.2.0/artifacts", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/modules/baetyl/version/v0/artifacts", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// the image of module version is kept on the artifacts
+	body := &models.ModuleArtifacts{Image: "nginx", Artifacts: []models.ModuleArtifact{
+		{Platform: " Linux-ARM64 ", Image: "baetyltech/baetyl:v2.2.0-arm64"},
+		{Platform: "windows-amd64", Program: "https://example.com/baetyl_windows_amd64.zip"},
+	}}
+	expect := &models.ModuleArtifacts{Name: "baetyl", Version: "v2.2.0", Image: module.Image, Artifacts: []models.ModuleArtifact{
+		{Platform: "linux-arm64", Image: "baetyltech/baetyl:v2.2.0-arm64"},
+		{Platform: "windows-amd64", Program: "https://example.com/baetyl_windows_amd64.zip"},
+	}}
+	sArtifact.EXPECT().Set(expect).Return(expect, nil)
+	data, _ := json.Marshal(body)
+	req, _ = http.NewRequest(http.MethodPut, "/v1/modules/baetyl/version/v2.2.0/artifacts", bytes.NewReader(data))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	for _, artifacts := range [][]models.ModuleArtifact{
+		{{Platform: "linux-arm64", Image: "a"}, {Platform: "LINUX-ARM64", Image: "b"}},
+		{{Platform: "linux-arm64"}},
+		{{Image: "a"}},
+		{{Platform: "linux-arm64", Program: "baetyl.zip"}},
+	} {
+		data, _ = json.Marshal(&models.ModuleArtifacts{Artifacts: artifacts})
+		req, _ = http.NewRequest(http.MethodPut, "/v1/modules/baetyl/version/v2.2.0/artifacts", bytes.NewReader(data))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+
+	sArtifact.EXPECT().Delete("baetyl", "v2.2.0").Return(errors.New("error"))
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/modules/baetyl/version/v2.2.0/artifacts", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestUpdateModuleArtifactsImage(t *testing.T) {
+	api, router, mockCtl := initModuleArtifactAPI(t)
+	defer mockCtl.Finish()
+	sModule := ms.NewMockModuleService(mockCtl)
+	sArtifact := ms.NewMockModuleArtifactService(mockCtl)
+	api.Module = sModule
+	api.ModuleArtifact = sArtifact
+
+	module := &models.Module{Name: "baetyl", Version: "v2.2.0", Image: "baetyltech/baetyl:v2.2.0"}
+	updated := &models.Module{Name: "baetyl", Version: "v2.2.0", Image: "baetyltech/baetyl:v2.2.1"}
+	artifacts := &models.ModuleArtifacts{Name: "baetyl", Version: "v2.2.0", Image: module.Image, Artifacts: []models.ModuleArtifact{
+		{Platform: "linux-arm64", Image: "baetyltech/baetyl:v2.2.0-arm64"},
+	}}
+	sModule.EXPECT().GetModuleByVersion("baetyl", "v2.2.0").Return(module, nil)
+	sModule.EXPECT().UpdateModuleByVersion(gomock.Any()).Return(updated, nil)
+	sArtifact.EXPECT().Get("baetyl", "v2.2.0").Return(artifacts, nil)
+	sArtifact.EXPECT().Set(artifacts).DoAndReturn(func(a *models.ModuleArtifacts) (*models.ModuleArtifacts, error) {
+		assert.Equal(t, updated.Image, a.Image)
+		return a, nil
+	})
+	data, _ := json.Marshal(updated)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/modules/baetyl/version/v2.2.0", bytes.NewReader(data))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
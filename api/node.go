@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -23,6 +24,7 @@ import (
 const (
 	OfflineDuration         = 20
 	NodeNumber              = 1
+	MaxBatchNodeNumber      = 500
 	BaetylCorePrevVersion   = "BaetylCorePrevVersion"
 	BaetylNodeNameKey       = "baetyl-node-name"
 	BaetylAppNameKey        = "baetyl-app-name"
@@ -106,6 +108,109 @@ func (api *API) GetNodes(c *common.Context) (interface{}, error) {
 	return nodeViewList, nil
 }
 
+// GetNodeDiff compares the desired apps of node with the reported ones and returns the drift
+func (api *API) GetNodeDiff(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &models.NodeDiff{
+		Namespace:  ns,
+		Name:       n,
+		Converged:  true,
+		ReportTime: node.Report["time"],
+		Items:      []models.NodeDiffItem{},
+	}
+	for _, isSys := range []bool{true, false} {
+		items, err := diffNodeApps(node, isSys)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if item.Drift != "" {
+				diff.Converged = false
+			}
+		}
+		diff.Items = append(diff.Items, items...)
+	}
+	return diff, nil
+}
+
+func diffNodeApps(node *v1.Node, isSys bool) ([]models.NodeDiffItem, error) {
+	appsKey, statsKey := "apps", "appstats"
+	if isSys {
+		appsKey, statsKey = "sysapps", "sysappstats"
+	}
+	var reported []v1.AppInfo
+	if err := common.DecodeReport(node.Report, appsKey, &reported); err != nil {
+		return nil, err
+	}
+	var stats []v1.AppStats
+	if err := common.DecodeReport(node.Report, statsKey, &stats); err != nil {
+		return nil, err
+	}
+	reportedVersions := map[string]string{}
+	for _, app := range reported {
+		reportedVersions[app.Name] = app.Version
+	}
+	appStats := map[string]v1.AppStats{}
+	for _, stat := range stats {
+		appStats[stat.Name] = stat
+	}
+
+	var items []models.NodeDiffItem
+	desired := map[string]bool{}
+	for _, app := range node.Desire.AppInfos(isSys) {
+		desired[app.Name] = true
+		item := models.NodeDiffItem{
+			Name:           app.Name,
+			System:         isSys,
+			DesiredVersion: app.Version,
+		}
+		version, ok := reportedVersions[app.Name]
+		if !ok {
+			item.Drift = models.DriftMissing
+			items = append(items, item)
+			continue
+		}
+		item.ReportedVersion = version
+		if version != app.Version {
+			item.Drift = models.DriftVersion
+		}
+		if stat, ok := appStats[app.Name]; ok {
+			running := true
+			item.Instances = map[string]string{}
+			for name, ins := range stat.InstanceStats {
+				item.Instances[name] = ins.Status
+				if ins.Status != "Running" {
+					running = false
+					if item.Cause == "" {
+						item.Cause = ins.Cause
+					}
+				}
+			}
+			if !running && item.Drift == "" {
+				item.Drift = models.DriftNotRunning
+			}
+		}
+		items = append(items, item)
+	}
+	for _, app := range reported {
+		if desired[app.Name] {
+			continue
+		}
+		items = append(items, models.NodeDiffItem{
+			Name:            app.Name,
+			System:          isSys,
+			ReportedVersion: app.Version,
+			Drift:           models.DriftUnexpected,
+		})
+	}
+	return items, nil
+}
+
 // GetNodeStats get a node stats
 func (api *API) GetNodeStats(c *common.Context) (interface{}, error) {
 	ns, n := c.GetNamespace(), c.GetNameFromParam()
@@ -131,6 +236,12 @@ func (api *API) ListNode(c *common.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = api.applyNodeFilter(c, params); err != nil {
+		return nil, err
+	}
+	if err = checkNodeQuery(&params.NodeQuery); err != nil {
+		return nil, err
+	}
 	nodeList, err := api.Node.List(ns, params)
 	if err != nil {
 		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
@@ -140,6 +251,11 @@ func (api *API) ListNode(c *common.Context) (interface{}, error) {
 		ListOptions: nodeList.ListOptions,
 		Items:       make([]v1.NodeView, 0, len(nodeList.Items)),
 	}
+	threshold, err := api.Health.GetThreshold(ns)
+	if err != nil {
+		return nil, err
+	}
+	health := map[string]*models.NodeHealth{}
 	var onlineNode, offlineNode, resNode []v1.NodeView
 	for idx := range nodeList.Items {
 		n := &nodeList.Items[idx]
@@ -150,21 +266,33 @@ func (api *API) ListNode(c *common.Context) (interface{}, error) {
 			return nil, err
 		}
 		view.Desire = nil
+		health[n.Name] = api.Health.Evaluate(n, threshold)
 		if view.Ready == v1.NodeOnline {
 			onlineNode = append(onlineNode, *view)
 		} else {
 			offlineNode = append(offlineNode, *view)
 		}
 	}
-	resNode = append(resNode, onlineNode...)
-	resNode = append(resNode, offlineNode...)
+	if params.Ready != models.NodeReadyOffline {
+		resNode = append(resNode, onlineNode...)
+	}
+	if params.Ready != models.NodeReadyOnline {
+		resNode = append(resNode, offlineNode...)
+	}
+	if params.Ready != "" {
+		nodeViewList.Total = len(resNode)
+	}
 
 	start, end := models.GetPagingParam(params, nodeViewList.Total)
 	nodeViewList.Items = resNode[start:end]
 
 	filterByNodeSelector(&nodeViewList)
 
-	return nodeViewList, nil
+	items := make([]models.NodeHealthView, 0, len(nodeViewList.Items))
+	for _, view := range nodeViewList.Items {
+		items = append(items, models.NodeHealthView{NodeView: view, Health: health[view.Name]})
+	}
+	return api.ToListResponse(nodeViewList.Total, items, params), nil
 }
 
 func filterByNodeSelector(list *models.NodeViewList) {
@@ -204,6 +332,60 @@ func (api *API) CreateNode(c *common.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	return api.createNode(c, n)
+}
+
+// BatchCreateNode creates nodes in batch, the result of each node is returned instead of failing the whole batch
+func (api *API) BatchCreateNode(c *common.Context) (interface{}, error) {
+	nodes, err := api.ParseAndCheckNodeBatch(c)
+	if err != nil {
+		return nil, err
+	}
+	res := &models.NodeBatchResult{
+		Total: len(nodes),
+		Items: make([]models.NodeBatchItem, 0, len(nodes)),
+	}
+	for _, n := range nodes {
+		item := models.NodeBatchItem{Name: n.Name}
+		var view *v1.NodeView
+		err = api.checkBatchNode(n)
+		if err == nil {
+			view, err = api.createNode(c, n)
+		}
+		if err != nil {
+			log.L().Warn("failed to create node in batch", log.Any(c.GetTrace()), log.Any("name", n.Name), log.Error(err))
+			item.Error = err.Error()
+			res.Failed++
+		} else {
+			item.Success = true
+			item.Node = view
+			res.Succeeded++
+		}
+		res.Items = append(res.Items, item)
+	}
+	return res, nil
+}
+
+func (api *API) checkBatchNode(n *v1.Node) error {
+	if n.Name == "" {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	if err := common.ValidateStruct(n); err != nil {
+		return err
+	}
+	if err := api.NodeModeParamCheck(n); err != nil {
+		return err
+	}
+	if err := checkNodeSyncLink(n); err != nil {
+		return err
+	}
+	if _, err := common.NodeDownloadRate(n.Labels); err != nil {
+		return err
+	}
+	return api.CheckNodeOptionalSysApps(n.SysApps, n.NodeMode)
+}
+
+func (api *API) createNode(c *common.Context, n *v1.Node) (*v1.NodeView, error) {
 	ns := c.GetNamespace()
 	n.Namespace = ns
 
@@ -225,7 +407,13 @@ func (api *API) CreateNode(c *common.Context) (interface{}, error) {
 		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "this name is already in use"))
 	}
 
-	err = api.License.AcquireQuota(ns, plugin.QuotaNode, NodeNumber)
+	dryRun := c.IsDryRun()
+	if dryRun {
+		// the quota is checked but not acquired
+		err = api.License.CheckQuota(ns, api.NodeNumberCollector)
+	} else {
+		err = api.License.AcquireQuota(ns, plugin.QuotaNode, NodeNumber)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -240,6 +428,9 @@ func (api *API) CreateNode(c *common.Context) (interface{}, error) {
 	n.Attributes["BaetylCoreVersion"] = version
 
 	n.SysApps = common.UpdateSysAppByAccelerator(n.Accelerator, n.SysApps)
+	if dryRun {
+		return api.dryRunNodeView(n)
+	}
 
 	node, err := api.Wrapper.CreateNodeTx(api.Node.Create)(nil, n.Namespace, n)
 	if err != nil {
@@ -288,6 +479,10 @@ func (api *API) UpdateNode(c *common.Context) (interface{}, error) {
 		common.LabelCluster:     strconv.FormatBool(node.Cluster),
 		common.LabelNodeMode:    node.NodeMode,
 	})
+	// the node of edge cluster is kept in the cluster
+	if cluster, ok := oldNode.Labels[common.LabelEdgeCluster]; ok {
+		node.Labels[common.LabelEdgeCluster] = cluster
+	}
 	node.Version = oldNode.Version
 	node.Attributes = oldNode.Attributes
 	node.CreationTimestamp = oldNode.CreationTimestamp
@@ -296,6 +491,13 @@ func (api *API) UpdateNode(c *common.Context) (interface{}, error) {
 	node.Mode = oldNode.Mode
 	node.NodeMode = oldNode.NodeMode
 
+	if c.IsDryRun() {
+		if node.Accelerator != oldNode.Accelerator {
+			node.SysApps = common.UpdateSysAppByAccelerator(node.Accelerator, node.SysApps)
+		}
+		return api.dryRunNodeView(node)
+	}
+
 	if node.Accelerator != oldNode.Accelerator {
 		// TODO remove redundant logic
 		err = api.deleteGPUMetricsAppsIfNeed(oldNode)
@@ -324,6 +526,7 @@ func (api *API) UpdateNode(c *common.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	c.SetAuditDiff(oldNode, node)
 
 	if !reflect.DeepEqual(node.SysApps, oldNode.SysApps) {
 		oldNode.Accelerator = node.Accelerator
@@ -377,7 +580,17 @@ func (api *API) DeleteNode(c *common.Context) (interface{}, error) {
 		}
 		return nil, err
 	}
+	// the node of edge cluster is deleted with the cluster
+	if _, ok := node.Labels[common.LabelEdgeCluster]; ok {
+		return nil, common.Error(common.ErrResourceDeleteForbidden, common.Field("type", "node"), common.Field("name", n))
+	}
+	return api.deleteNode(c, node)
+}
 
+// deleteNode deletes the node which is drained unless forced, the quota and system apps of node are released too
+func (api *API) deleteNode(c *common.Context, node *v1.Node) (interface{}, error) {
+	var err error
+	ns, n := c.GetNamespace(), node.Name
 	for _, item := range HookDeleteList {
 		if f, exist := api.Hooks[item]; exist {
 			if hk, ok := f.(DeleteNodeHook); ok {
@@ -389,8 +602,20 @@ func (api *API) DeleteNode(c *common.Context) (interface{}, error) {
 		}
 	}
 
+	// the apps are left running on the node if it is deleted before drained
+	if force, _ := strconv.ParseBool(c.Query("force")); !force {
+		status, err := nodeDrainStatus(node)
+		if err != nil {
+			return nil, err
+		}
+		if len(status.RemainingApps) > 0 {
+			return nil, common.Error(common.ErrNodeNotDrained, common.Field("name", n),
+				common.Field("apps", strings.Join(status.RemainingApps, ",")))
+		}
+	}
+
 	// Delete Node
-	if err := api.Node.Delete(c.GetNamespace(), node); err != nil {
+	if err := api.Node.Delete(ns, node); err != nil {
 		return nil, err
 	}
 	if e := api.ReleaseQuota(ns, plugin.QuotaNode, NodeNumber); e != nil {
@@ -400,13 +625,273 @@ func (api *API) DeleteNode(c *common.Context) (interface{}, error) {
 	return api.deleteAllSysAppsOfNode(node)
 }
 
+// DrainNode cordons the node so that the user apps are removed from its desire, the node stops the apps and reports back,
+// it is drained once no user app is reported, then it can be deleted
+func (api *API) DrainNode(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if !service.IsNodeCordoned(node.Labels) {
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+		node.Labels[common.LabelNodeCordon] = "true"
+		if _, err = api.Node.Update(ns, node); err != nil {
+			return nil, err
+		}
+		log.L().Info("node is cordoned", log.Any("namespace", ns), log.Any("name", n))
+		if node, err = api.Node.Get(nil, ns, n); err != nil {
+			return nil, err
+		}
+	}
+	return nodeDrainStatus(node)
+}
+
+// UncordonNode redeploys the user apps to the node
+func (api *API) UncordonNode(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if service.IsNodeCordoned(node.Labels) {
+		delete(node.Labels, common.LabelNodeCordon)
+		if _, err = api.Node.Update(ns, node); err != nil {
+			return nil, err
+		}
+		log.L().Info("node is uncordoned", log.Any("namespace", ns), log.Any("name", n))
+	}
+	return nodeDrainStatus(node)
+}
+
+// GetNodeDrainStatus get the progress of draining node
+func (api *API) GetNodeDrainStatus(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	return nodeDrainStatus(node)
+}
+
+func nodeDrainStatus(node *v1.Node) (*models.NodeDrainStatus, error) {
+	var reported []v1.AppInfo
+	if err := common.DecodeReport(node.Report, "apps", &reported); err != nil {
+		return nil, err
+	}
+	status := &models.NodeDrainStatus{
+		Name:          node.Name,
+		Cordoned:      service.IsNodeCordoned(node.Labels),
+		RemainingApps: []string{},
+	}
+	for _, app := range reported {
+		status.RemainingApps = append(status.RemainingApps, app.Name)
+	}
+	status.Drained = status.Cordoned && len(status.RemainingApps) == 0
+	return status, nil
+}
+
+// PatchNodeLabels adds and removes the labels of nodes in batch, the nodes which have been updated are rolled back
+// if any node fails, so that the nodes are all relabeled or none of them
+func (api *API) PatchNodeLabels(c *common.Context) (interface{}, error) {
+	ns := c.GetNamespace()
+	patch, err := parseAndCheckNodeLabelsPatch(c)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := api.listPatchNodes(ns, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.NodeLabelsResult{
+		Total: len(nodes),
+		Items: make([]models.NodeLabelsItem, len(nodes)),
+	}
+	olds := make([]map[string]string, len(nodes))
+	for i, node := range nodes {
+		olds[i] = node.Labels
+		node.Labels = patchLabels(node.Labels, patch)
+		res.Items[i] = models.NodeLabelsItem{
+			Name:    node.Name,
+			Changed: isLabelsChanged(olds[i], node.Labels),
+			Labels:  node.Labels,
+		}
+	}
+	if c.IsDryRun() {
+		for i := range res.Items {
+			res.Items[i].Success = true
+		}
+		res.Succeeded = res.Total
+		return res, nil
+	}
+
+	for i, node := range nodes {
+		if !res.Items[i].Changed {
+			res.Items[i].Success = true
+			res.Succeeded++
+			continue
+		}
+		if nodes[i], err = api.Node.Update(ns, node); err != nil {
+			log.L().Warn("failed to patch node labels", log.Any(c.GetTrace()), log.Any("name", node.Name), log.Error(err))
+			res.Items[i].Error = err.Error()
+			res.Items[i].Labels = olds[i]
+			res.Failed++
+			for j := i + 1; j < len(nodes); j++ {
+				if res.Items[j].Changed {
+					res.Items[j].Labels = olds[j]
+					res.Items[j].Error = "not applied because of the failure of node " + node.Name
+					res.Failed++
+				} else {
+					res.Items[j].Success = true
+					res.Succeeded++
+				}
+			}
+			api.rollbackNodeLabels(c, nodes[:i], olds, res)
+			return res, nil
+		}
+		res.Items[i].Success = true
+		res.Succeeded++
+	}
+	return res, nil
+}
+
+// rollbackNodeLabels restores the labels of nodes which have been updated
+func (api *API) rollbackNodeLabels(c *common.Context, nodes []*v1.Node, olds []map[string]string, res *models.NodeLabelsResult) {
+	res.RolledBack = true
+	for i, node := range nodes {
+		item := &res.Items[i]
+		if !item.Changed {
+			continue
+		}
+		node.Labels = olds[i]
+		if _, err := api.Node.Update(c.GetNamespace(), node); err != nil {
+			log.L().Error("failed to roll back node labels", log.Any(c.GetTrace()), log.Any("name", node.Name), log.Error(err))
+			item.Error = "failed to roll back: " + err.Error()
+			continue
+		}
+		item.Success = false
+		item.Labels = olds[i]
+		item.Error = "rolled back"
+		res.Succeeded--
+		res.Failed++
+	}
+}
+
+func (api *API) listPatchNodes(ns string, patch *models.NodeLabelsPatch) ([]*v1.Node, error) {
+	var nodes []*v1.Node
+	if patch.Selector != "" {
+		list, err := api.Node.List(ns, &models.ListOptions{LabelSelector: patch.Selector})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			nodes = append(nodes, &list.Items[i])
+		}
+	} else {
+		for _, name := range patch.Nodes {
+			node, err := api.Node.Get(nil, ns, name)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) > MaxBatchNodeNumber {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("at most %d nodes can be patched in batch", MaxBatchNodeNumber)))
+	}
+	return nodes, nil
+}
+
+func parseAndCheckNodeLabelsPatch(c *common.Context) (*models.NodeLabelsPatch, error) {
+	patch := new(models.NodeLabelsPatch)
+	if err := c.LoadBody(patch); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if (patch.Selector == "") == (len(patch.Nodes) == 0) {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "either selector or nodes should be set"))
+	}
+	if len(patch.Nodes) > MaxBatchNodeNumber {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("at most %d nodes can be patched in batch", MaxBatchNodeNumber)))
+	}
+	if _, err := utils.IsLabelMatch(patch.Selector, map[string]string{}); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if len(patch.Add) == 0 && len(patch.Remove) == 0 {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "add or remove is required"))
+	}
+	keys := make([]string, 0, len(patch.Add)+len(patch.Remove))
+	for k := range patch.Add {
+		keys = append(keys, k)
+	}
+	for _, k := range patch.Remove {
+		if _, ok := patch.Add[k]; ok {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("label %s can not be added and removed together", k)))
+		}
+		keys = append(keys, k)
+	}
+	for _, k := range keys {
+		if isReservedNodeLabel(k) {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("label %s is reserved", k)))
+		}
+	}
+	return patch, nil
+}
+
+// isReservedNodeLabel returns whether the label is maintained by cloud, such as the cordon label which is set by drain
+func isReservedNodeLabel(key string) bool {
+	switch key {
+	case common.LabelNodeName, common.LabelAccelerator, common.LabelCluster, common.LabelNodeMode, common.LabelNodeCordon,
+		common.LabelEdgeCluster:
+		return true
+	}
+	return false
+}
+
+// checkNodeSyncLink checks the link selected by node, which takes effect once the system apps of node are generated
+func checkNodeSyncLink(node *v1.Node) error {
+	switch common.NodeSyncLink(node.Labels) {
+	case common.SyncLinkHTTP, common.SyncLinkMQTT:
+		return nil
+	}
+	return common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("the sync link of node must be %s or %s", common.SyncLinkHTTP, common.SyncLinkMQTT)))
+}
+
+func isLabelsChanged(old, new map[string]string) bool {
+	if len(old) != len(new) {
+		return true
+	}
+	for k, v := range new {
+		if ov, ok := old[k]; !ok || ov != v {
+			return true
+		}
+	}
+	return false
+}
+
+func patchLabels(labels map[string]string, patch *models.NodeLabelsPatch) map[string]string {
+	res := make(map[string]string, len(labels)+len(patch.Add))
+	for k, v := range labels {
+		res[k] = v
+	}
+	for k, v := range patch.Add {
+		res[k] = v
+	}
+	for _, k := range patch.Remove {
+		delete(res, k)
+	}
+	return res
+}
+
 func (api *API) ToNodeView(node *v1.Node) (*v1.NodeView, error) {
 	// get frequency
 	frequency, err := api.getCoreAppFrequency(node)
 	if err != nil {
 		return nil, err
 	}
-	t := time.Duration(frequency+OfflineDuration) * time.Second
+	t := time.Duration(frequency)*time.Second + api.offlineTimeout(node.Namespace)
 	view, err := node.View(t)
 	if err != nil {
 		return nil, err
@@ -419,6 +904,30 @@ func (api *API) ToNodeView(node *v1.Node) (*v1.NodeView, error) {
 	return view, nil
 }
 
+// offlineTimeout returns the timeout of heartbeat policy in namespace, OfflineDuration is used if no policy
+func (api *API) offlineTimeout(namespace string) time.Duration {
+	if api.Heartbeat == nil {
+		return OfflineDuration * time.Second
+	}
+	return api.Heartbeat.OfflineTimeout(namespace)
+}
+
+// dryRunNodeView renders the node which is not stored, the default frequency is used for the new node
+func (api *API) dryRunNodeView(node *v1.Node) (*v1.NodeView, error) {
+	if node.Attributes == nil {
+		node.Attributes = make(map[string]interface{})
+	}
+	if _, ok := node.Attributes[v1.BaetylCoreFrequency]; !ok {
+		node.Attributes[v1.BaetylCoreFrequency] = common.DefaultCoreFrequency
+	}
+	view, err := api.ToNodeView(node)
+	if err != nil {
+		return nil, err
+	}
+	view.Desire = nil
+	return view, nil
+}
+
 func (api *API) deleteAllSysAppsOfNode(node *v1.Node) (interface{}, error) {
 	sysAppInfos := node.Desire.AppInfos(true)
 
@@ -466,48 +975,72 @@ func (api *API) GetAppByNode(c *common.Context) (interface{}, error) {
 	return api.listAppByNames(ns, appNames)
 }
 
-// GenInitCmdFromNode generate install command
+// GenInitCmdFromNode generate install command, which carries the activation token designated by the query
+// activationToken, or a token issued for the node
 func (api *API) GenInitCmdFromNode(c *common.Context) (interface{}, error) {
 	ns, name := c.GetNamespace(), c.Param("name")
 	_, err := api.Node.Get(nil, ns, name)
 	if err != nil {
 		return nil, err
 	}
+	token := c.Query("activationToken")
+	if token != "" {
+		if err = api.ActivationToken.Check(ns, token, name); err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrInvalidToken {
+				return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the activation token cannot activate the node"))
+			}
+			return nil, err
+		}
+	}
+	if c.Query("platform") == PlatformAndroid {
+		return api.GenAndroidInitCmdFromNode()
+	}
+	mode, template := initCmdOptions(c)
+	cmd, err := api.genInitCmd(ns, name, mode, template, token)
+	if err != nil {
+		return nil, err
+	}
+	return models.InitCMD{CMD: cmd}, nil
+}
+
+// initCmdOptions returns the mode and the template of install command designated by the queries mode, method and
+// platform, which are kube and curl by default
+func initCmdOptions(c *common.Context) (string, string) {
 	mode := c.Query("mode")
 	if mode == "" {
 		mode = context.RunModeKube
 	}
-	method := c.Query("method")
-	if method == "" {
-		method = MethodCurl
-	}
 	template := service.TemplateBaetylInitCommand
-	if method == MethodWget {
+	if c.Query("method") == MethodWget {
 		template = service.TemplateInitCommandWget
 	}
-	switch c.Query("platform") {
-	case PlatformWindows:
+	if c.Query("platform") == PlatformWindows {
 		template = service.TemplateInitCommandWindows
-	case PlatformAndroid:
-		return api.GenAndroidInitCmdFromNode()
 	}
+	return mode, template
+}
+
+func (api *API) genInitCmd(ns, name, mode, template, token string) (string, error) {
 	params := map[string]interface{}{
 		"mode":     mode,
 		"template": template,
 	}
+	if token != "" {
+		params["ActivationToken"] = token
+	}
 	if mode == context.RunModeKube {
 		params["InitApplyYaml"] = "baetyl-init-deployment.yml"
 	} else if mode == context.RunModeNative {
 		params["InitApplyYaml"] = "baetyl-init-apply.json"
 	} else {
-		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("mode", mode))
+		return "", common.Error(common.ErrRequestParamInvalid, common.Field("mode", mode))
 	}
 
 	cmd, err := api.Init.GetResource(ns, name, service.TemplateBaetylInitCommand, params)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	return models.InitCMD{CMD: string(cmd.([]byte))}, nil
+	return string(cmd.([]byte)), nil
 }
 
 func (api *API) GenAndroidInitCmdFromNode() (interface{}, error) {
@@ -547,6 +1080,14 @@ func (api *API) ParseAndCheckNode(c *common.Context) (*v1.Node, error) {
 		return nil, err
 	}
 
+	err = checkNodeSyncLink(node)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = common.NodeDownloadRate(node.Labels); err != nil {
+		return nil, err
+	}
+
 	err = api.CheckNodeOptionalSysApps(node.SysApps, node.NodeMode)
 	if err != nil {
 		return nil, err
@@ -555,6 +1096,59 @@ func (api *API) ParseAndCheckNode(c *common.Context) (*v1.Node, error) {
 	return node, nil
 }
 
+// ParseAndCheckNodeBatch parses the nodes to create in batch, the nodes are checked one by one when created
+func (api *API) ParseAndCheckNodeBatch(c *common.Context) ([]*v1.Node, error) {
+	batch := new(models.NodeBatch)
+	if err := c.LoadBody(batch); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if len(batch.Nodes) > 0 && batch.Count > 0 {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "nodes and count can not be used together"))
+	}
+	if len(batch.Nodes) > MaxBatchNodeNumber || batch.Count > MaxBatchNodeNumber {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("at most %d nodes can be created in batch", MaxBatchNodeNumber)))
+	}
+	if batch.Count <= 0 {
+		if len(batch.Nodes) == 0 {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "nodes or count is required"))
+		}
+		for _, n := range batch.Nodes {
+			if n == nil {
+				return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "node should not be null"))
+			}
+			if err := utils.SetDefaults(n); err != nil {
+				return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+			}
+		}
+		return batch.Nodes, nil
+	}
+
+	if batch.NamePrefix == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "namePrefix is required"))
+	}
+	tpl := []byte("{}")
+	if batch.Template != nil {
+		data, err := json.Marshal(batch.Template)
+		if err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+		}
+		tpl = data
+	}
+	nodes := make([]*v1.Node, 0, batch.Count)
+	for i := batch.StartIndex; i < batch.StartIndex+batch.Count; i++ {
+		n := new(v1.Node)
+		if err := json.Unmarshal(tpl, n); err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+		}
+		if err := utils.SetDefaults(n); err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+		}
+		n.Name = fmt.Sprintf("%s-%d", batch.NamePrefix, i)
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
 func (api *API) ParseAndCheckNodeNames(c *common.Context) (*models.NodeNames, error) {
 	_, ok := c.GetQuery("batch")
 	if !ok {
@@ -692,14 +1286,14 @@ func (api *API) UpdateCoreApp(c *common.Context) (interface{}, error) {
 		return api.ToApplicationView(app)
 	}
 
-	api.updateCoreVersions(node, version, coreConfig.Version)
-
-	image, err := api.getCoreImageByVersion(coreConfig.Version)
+	image, err := api.getCoreImageByVersion(node, version, coreConfig.Version)
 	if err != nil {
 		return nil, err
 	}
 	coreService.Image = image
 
+	api.updateCoreVersions(node, version, coreConfig.Version)
+
 	err = api.updateCoreAppAPIPort(ns, coreService, port, coreConfig.APIPort)
 	if err != nil {
 		return nil, err
@@ -863,6 +1457,9 @@ func (api *API) GetCoreAppVersions(c *common.Context) (interface{}, error) {
 	latestVersion := res.Version
 	if latestVersion != "" && latestVersion != currentVersion {
 		coreVersions.Versions = append(coreVersions.Versions, latestVersion)
+		if res.ReleaseNotes != "" {
+			coreVersions.ReleaseNotes = map[string]string{latestVersion: res.ReleaseNotes}
+		}
 	}
 	return coreVersions, nil
 }
@@ -1153,11 +1750,24 @@ func (api *API) getCoreLatestVersion() (string, error) {
 	return app.Version, nil
 }
 
-func (api *API) getCoreImageByVersion(version string) (string, error) {
+// getCoreImageByVersion returns the image of the core version node upgrades to, the deprecated versions are
+// installed to the nodes running them or rolling back to them only
+func (api *API) getCoreImageByVersion(node *v1.Node, currentVersion, version string) (string, error) {
 	app, err := api.Module.GetModuleByVersion(BaetylModule, version)
 	if err != nil {
 		return "", err
 	}
+	if version == currentVersion {
+		return app.Image, nil
+	}
+	if !app.SupportsCloud(utils.VERSION) {
+		return "", common.Error(common.ErrModuleUnavailable, common.Field("name", BaetylModule), common.Field("version", version),
+			common.Field("error", fmt.Sprintf("it requires the cloud of %s or later", app.MinCloudVersion)))
+	}
+	if prev, _ := node.Attributes[BaetylCorePrevVersion].(string); app.Deprecated && prev != version {
+		return "", common.Error(common.ErrModuleUnavailable, common.Field("name", BaetylModule), common.Field("version", version),
+			common.Field("error", "it's deprecated"))
+	}
 	return app.Image, nil
 }
 
@@ -1209,6 +1819,7 @@ func (api *API) updateCoreAppConfig(app *v1.Application, node *v1.Node, freq, ag
 		"NodeMode":      node.NodeMode,
 		"CoreFrequency": fmt.Sprintf("%ds", freq),
 		"AgentPort":     fmt.Sprintf("%d", agentPort),
+		"SyncLink":      common.NodeSyncLink(node.Labels),
 		"GPUStats":      node.NodeMode == context.RunModeKube,
 		"DiskNetStats":  node.NodeMode == context.RunModeKube,
 		"QPSStats":      node.NodeMode == context.RunModeKube,
@@ -1285,6 +1896,7 @@ func (api *API) updateInitAppConfig(app *v1.Application, node *v1.Node, agentPor
 		"InitConfName": config.Name,
 		"InitAppName":  app.Name,
 		"AgentPort":    fmt.Sprintf("%d", agentPort),
+		"SyncLink":     common.NodeSyncLink(node.Labels),
 		"GPUStats":     node.NodeMode == context.RunModeKube,
 		"DiskNetStats": node.NodeMode == context.RunModeKube,
 		"QPSStats":     node.NodeMode == context.RunModeKube,
@@ -13,6 +13,7 @@ import (
 	"github.com/baetyl/baetyl-go/v2/context"
 	"github.com/baetyl/baetyl-go/v2/log"
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/baetyl/baetyl-go/v2/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -75,12 +76,19 @@ func initNodeAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
 		nodes := v1.Group("/nodes")
 		nodes.GET("/:name", mockIM, common.Wrapper(api.GetNode))
 		nodes.PUT("", mockIM, common.Wrapper(api.GetNodes))
+		nodes.PATCH("/labels", mockIM, common.Wrapper(api.PatchNodeLabels))
 		nodes.GET("/:name/stats", mockIM, common.Wrapper(api.GetNodeStats))
 		nodes.GET("/:name/apps", mockIM, common.Wrapper(api.GetAppByNode))
+		nodes.GET("/:name/diff", mockIM, common.Wrapper(api.GetNodeDiff))
 		nodes.PUT("/:name", mockIM, common.Wrapper(api.UpdateNode))
 		nodes.DELETE("/:name", mockIM, common.Wrapper(api.DeleteNode))
+		nodes.GET("/:name/drain", mockIM, common.Wrapper(api.GetNodeDrainStatus))
+		nodes.POST("/:name/drain", mockIM, common.Wrapper(api.DrainNode))
+		nodes.POST("/:name/uncordon", mockIM, common.Wrapper(api.UncordonNode))
 		nodes.GET("/:name/init", mockIM, common.Wrapper(api.GenInitCmdFromNode))
 		nodes.POST("", mockIM, common.Wrapper(api.CreateNode))
+		nodes.POST("/batch", mockIM, common.Wrapper(api.BatchCreateNode))
+		nodes.POST("/import", mockIM, common.Wrapper(api.ImportNode))
 		nodes.GET("", mockIM, common.Wrapper(api.ListNode))
 		nodes.GET("/:name/deploys", mockIM, common.Wrapper(api.GetNodeDeployHistory))
 		nodes.GET("/:name/properties", mockIM, common.Wrapper(api.GetNodeProperties))
@@ -159,6 +167,94 @@ func TestGetNode(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w2.Code)
 }
 
+func TestGetNodeDiff(t *testing.T) {
+	api, router, mockCtl := initNodeAPI(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+
+	mNode := &specV1.Node{
+		Namespace: "default",
+		Name:      "abc",
+		Desire: specV1.Desire{
+			"sysapps": []interface{}{
+				map[string]interface{}{"name": "core", "version": "2"},
+			},
+			"apps": []interface{}{
+				map[string]interface{}{"name": "app1", "version": "1"},
+				map[string]interface{}{"name": "app2", "version": "1"},
+			},
+		},
+		Report: specV1.Report{
+			"time": "2022-01-01T00:00:00Z",
+			"sysapps": []interface{}{
+				map[string]interface{}{"name": "core", "version": "1"},
+			},
+			"apps": []interface{}{
+				map[string]interface{}{"name": "app1", "version": "1"},
+				map[string]interface{}{"name": "app3", "version": "1"},
+			},
+			"appstats": []specV1.AppStats{
+				{
+					AppInfo: specV1.AppInfo{Name: "app1", Version: "1"},
+					InstanceStats: map[string]specV1.InstanceStats{
+						"app1-0": {Name: "app1-0", Status: "Pending"},
+					},
+				},
+			},
+		},
+	}
+	sNode.EXPECT().Get(nil, mNode.Namespace, mNode.Name).Return(mNode, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/abc/diff", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var diff models.NodeDiff
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &diff))
+	assert.False(t, diff.Converged)
+	assert.Equal(t, "2022-01-01T00:00:00Z", diff.ReportTime)
+	assert.Len(t, diff.Items, 4)
+	drifts := map[string]string{}
+	for _, item := range diff.Items {
+		drifts[item.Name] = item.Drift
+	}
+	assert.Equal(t, map[string]string{
+		"core": models.DriftVersion,
+		"app1": models.DriftNotRunning,
+		"app2": models.DriftMissing,
+		"app3": models.DriftUnexpected,
+	}, drifts)
+
+	// converged
+	mNode.Desire = specV1.Desire{
+		"apps": []interface{}{
+			map[string]interface{}{"name": "app3", "version": "1"},
+		},
+	}
+	mNode.Report = specV1.Report{
+		"apps": []interface{}{
+			map[string]interface{}{"name": "app3", "version": "1"},
+		},
+	}
+	sNode.EXPECT().Get(nil, mNode.Namespace, mNode.Name).Return(mNode, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/abc/diff", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	diff = models.NodeDiff{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &diff))
+	assert.True(t, diff.Converged)
+	assert.Len(t, diff.Items, 1)
+
+	sNode.EXPECT().Get(nil, mNode.Namespace, mNode.Name).Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/abc/diff", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 func TestGetNodes(t *testing.T) {
 	api, router, mockCtl := initNodeAPI(t)
 	defer mockCtl.Finish()
@@ -410,6 +506,11 @@ func TestListNode(t *testing.T) {
 	sNode.EXPECT().List("default", &models.ListOptions{
 		NodeSelector: "test=test",
 	}).Return(mClist, nil)
+	sHealth := ms.NewMockHealthService(mockCtl)
+	api.Health = sHealth
+	threshold := &models.HealthThreshold{Namespace: "default", WarningScore: 80, CriticalScore: 50}
+	sHealth.EXPECT().GetThreshold("default").Return(threshold, nil)
+	sHealth.EXPECT().Evaluate(&mClist.Items[0], threshold).Return(&models.NodeHealth{Level: models.HealthLevelUnknown})
 
 	// 200
 	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes?nodeSelector=test=test", nil)
@@ -418,7 +519,7 @@ func TestListNode(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	bytes := w.Body.Bytes()
 	fmt.Println(string(bytes))
-	assert.Equal(t, string(bytes), "{\"total\":1,\"items\":[{\"name\":\"node01\",\"createTime\":\"0001-01-01T00:00:00Z\",\"labels\":{\"test\":\"test\"},\"cluster\":false,\"ready\":0,\"mode\":\"\"}]}\n")
+	assert.Equal(t, string(bytes), "{\"total\":1,\"items\":[{\"name\":\"node01\",\"createTime\":\"0001-01-01T00:00:00Z\",\"labels\":{\"test\":\"test\"},\"cluster\":false,\"ready\":0,\"mode\":\"\",\"health\":{\"score\":0,\"level\":\"unknown\"}}]}\n")
 	nodelist := new(models.NodeList)
 	err := json.Unmarshal(bytes, nodelist)
 	assert.NoError(t, err)
@@ -537,6 +638,133 @@ func TestCreateNode(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "The request parameter is invalid. (name is required)")
 }
 
+func TestCreateNodeDryRun(t *testing.T) {
+	api, router, mockCtl := initNodeAPI(t)
+	defer mockCtl.Finish()
+
+	mLicense := ms.NewMockLicenseService(mockCtl)
+	api.License = mLicense
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+	sModule := ms.NewMockModuleService(mockCtl)
+	api.Module = sModule
+
+	mNode := getMockNode2()
+	m := &models.Module{
+		Name:    "baetyl",
+		Version: "2.1.2",
+	}
+	sNode.EXPECT().Get(nil, gomock.Any(), gomock.Any()).Return(nil, nil)
+	// neither acquire quota nor create node
+	mLicense.EXPECT().CheckQuota(mNode.Namespace, gomock.Any()).Return(nil)
+	sModule.EXPECT().GetLatestModule(gomock.Any()).Return(m, nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(mNode)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/nodes?dryRun=true", bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	view := &specV1.NodeView{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), view))
+	assert.Equal(t, mNode.Name, view.Name)
+
+	sNode.EXPECT().Get(nil, gomock.Any(), gomock.Any()).Return(nil, nil)
+	mLicense.EXPECT().CheckQuota(mNode.Namespace, gomock.Any()).Return(common.Error(common.ErrLicenseQuota, common.Field("name", plugin.QuotaNode), common.Field("limit", 1)))
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes?dryRun=true", bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBatchCreateNode(t *testing.T) {
+	api, router, mockCtl := initNodeAPI(t)
+	defer mockCtl.Finish()
+
+	mLicense := ms.NewMockLicenseService(mockCtl)
+	api.License = mLicense
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+	sModule := ms.NewMockModuleService(mockCtl)
+	api.Module = sModule
+	cfg := &config.CloudConfig{}
+	cfg.Plugin.Tx = "defaulttx"
+	wrpper, _ := service.NewWrapperService(cfg)
+	api.Wrapper = wrpper
+
+	m := &models.Module{
+		Name:    "baetyl",
+		Version: "2.1.2",
+	}
+	sModule.EXPECT().GetLatestModule(gomock.Any()).Return(m, nil).AnyTimes()
+	sNode.EXPECT().Get(nil, "default", gomock.Any()).Return(nil, nil).Times(3)
+	mLicense.EXPECT().AcquireQuota("default", plugin.QuotaNode, 1).Return(nil).Times(3)
+	create := func(tx interface{}, ns string, n *specV1.Node) (*specV1.Node, error) {
+		if n.Name == "edge-3" {
+			return nil, fmt.Errorf("create node error")
+		}
+		return n, nil
+	}
+	sNode.EXPECT().Create(gomock.Any(), "default", gomock.Any()).DoAndReturn(create).Times(3)
+	mLicense.EXPECT().ReleaseQuota("default", plugin.QuotaNode, 1).Return(nil)
+
+	batch := &models.NodeBatch{
+		Count:      3,
+		NamePrefix: "edge",
+		Template: &specV1.Node{
+			Labels: map[string]string{"line": "a"},
+			Attributes: map[string]interface{}{
+				specV1.BaetylCoreFrequency: common.DefaultCoreFrequency,
+			},
+		},
+	}
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(batch)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/nodes/batch", bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := &models.NodeBatchResult{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, 3, res.Total)
+	assert.Equal(t, 2, res.Succeeded)
+	assert.Equal(t, 1, res.Failed)
+	assert.Equal(t, "edge-1", res.Items[0].Name)
+	assert.True(t, res.Items[0].Success)
+	assert.Equal(t, "a", res.Items[0].Node.Labels["line"])
+	assert.Equal(t, "edge-3", res.Items[2].Name)
+	assert.False(t, res.Items[2].Success)
+	assert.Contains(t, res.Items[2].Error, "create node error")
+
+	// invalid names are reported per node
+	sNode.EXPECT().Get(nil, "default", "abc").Return(nil, nil)
+	mLicense.EXPECT().AcquireQuota("default", plugin.QuotaNode, 1).Return(nil)
+	sNode.EXPECT().Create(gomock.Any(), "default", gomock.Any()).DoAndReturn(create)
+	batch = &models.NodeBatch{Nodes: []*specV1.Node{getMockNode2(), {Name: ""}}}
+	w = httptest.NewRecorder()
+	body, _ = json.Marshal(batch)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes/batch", bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res = &models.NodeBatchResult{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, 1, res.Succeeded)
+	assert.Equal(t, 1, res.Failed)
+	assert.Contains(t, res.Items[1].Error, "name is required")
+
+	for _, b := range []*models.NodeBatch{
+		{},
+		{Count: 2},
+		{Count: MaxBatchNodeNumber + 1, NamePrefix: "edge"},
+		{Count: 1, NamePrefix: "edge", Nodes: []*specV1.Node{getMockNode2()}},
+	} {
+		w = httptest.NewRecorder()
+		body, _ = json.Marshal(b)
+		req, _ = http.NewRequest(http.MethodPost, "/v1/nodes/batch", bytes.NewReader(body))
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
 func TestCreateNodeWithSysApps(t *testing.T) {
 	api, router, mockCtl := initNodeAPI(t)
 	defer mockCtl.Finish()
@@ -1330,6 +1558,182 @@ func TestDeleteNode(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+func TestDrainNode(t *testing.T) {
+	api, router, mockCtl := initNodeAPI(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+
+	mNode := &specV1.Node{
+		Namespace: "default",
+		Name:      "abc",
+		Labels:    map[string]string{common.LabelNodeName: "abc"},
+		Report: specV1.Report{
+			"apps": []interface{}{map[string]interface{}{"name": "app1", "version": "1"}},
+		},
+	}
+	sNode.EXPECT().Get(nil, "default", "abc").Return(mNode, nil).Times(2)
+	sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(_ string, node *specV1.Node) (*specV1.Node, error) {
+		assert.Equal(t, "true", node.Labels[common.LabelNodeCordon])
+		return node, nil
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/nodes/abc/drain", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var status models.NodeDrainStatus
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.True(t, status.Cordoned)
+	assert.False(t, status.Drained)
+	assert.Equal(t, []string{"app1"}, status.RemainingApps)
+
+	// the node is still running apps
+	sNode.EXPECT().Get(nil, "default", "abc").Return(mNode, nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/nodes/abc", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), common.ErrNodeNotDrained)
+
+	// drained
+	mNode.Report = specV1.Report{"apps": []interface{}{}}
+	sNode.EXPECT().Get(nil, "default", "abc").Return(mNode, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/abc/drain", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	status = models.NodeDrainStatus{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.True(t, status.Drained)
+
+	// drain again does not update the node
+	sNode.EXPECT().Get(nil, "default", "abc").Return(mNode, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes/abc/drain", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sNode.EXPECT().Get(nil, "default", "abc").Return(mNode, nil)
+	sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(_ string, node *specV1.Node) (*specV1.Node, error) {
+		_, ok := node.Labels[common.LabelNodeCordon]
+		assert.False(t, ok)
+		return node, nil
+	})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes/abc/uncordon", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	status = models.NodeDrainStatus{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.False(t, status.Cordoned)
+
+	sNode.EXPECT().Get(nil, "default", "abc").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes/abc/drain", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPatchNodeLabels(t *testing.T) {
+	api, router, mockCtl := initNodeAPI(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+
+	newNodes := func() *models.NodeList {
+		return &models.NodeList{Items: []specV1.Node{
+			{Namespace: "default", Name: "n1", Labels: map[string]string{common.LabelNodeName: "n1", "city": "bj", "old": "1"}},
+			{Namespace: "default", Name: "n2", Labels: map[string]string{common.LabelNodeName: "n2", "city": "bj", "zone": "a"}},
+			{Namespace: "default", Name: "n3", Labels: map[string]string{common.LabelNodeName: "n3", "city": "bj", "zone": "a"}},
+		}}
+	}
+	patch := &models.NodeLabelsPatch{
+		Selector: "city=bj",
+		Add:      map[string]string{"zone": "a"},
+		Remove:   []string{"old"},
+	}
+	body, _ := json.Marshal(patch)
+
+	// dry run
+	sNode.EXPECT().List("default", &models.ListOptions{LabelSelector: "city=bj"}).Return(newNodes(), nil)
+	req, _ := http.NewRequest(http.MethodPatch, "/v1/nodes/labels?dryRun=true", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.NodeLabelsResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 3, res.Succeeded)
+	assert.True(t, res.Items[0].Changed)
+	assert.False(t, res.Items[1].Changed)
+	assert.Equal(t, map[string]string{common.LabelNodeName: "n1", "city": "bj", "zone": "a"}, res.Items[0].Labels)
+
+	// only the changed nodes are updated
+	sNode.EXPECT().List("default", &models.ListOptions{LabelSelector: "city=bj"}).Return(newNodes(), nil)
+	sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(_ string, node *specV1.Node) (*specV1.Node, error) {
+		assert.Equal(t, "n1", node.Name)
+		assert.Equal(t, "a", node.Labels["zone"])
+		return node, nil
+	})
+	req, _ = http.NewRequest(http.MethodPatch, "/v1/nodes/labels", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res = models.NodeLabelsResult{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 3, res.Total)
+	assert.Equal(t, 3, res.Succeeded)
+	assert.False(t, res.RolledBack)
+
+	// the updated nodes are rolled back if any node fails
+	patch = &models.NodeLabelsPatch{Nodes: []string{"n1", "n2", "n3"}, Add: map[string]string{"zone": "b"}}
+	body, _ = json.Marshal(patch)
+	list := newNodes()
+	for i := range list.Items {
+		sNode.EXPECT().Get(nil, "default", list.Items[i].Name).Return(&list.Items[i], nil)
+	}
+	gomock.InOrder(
+		sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(_ string, node *specV1.Node) (*specV1.Node, error) {
+			assert.Equal(t, "b", node.Labels["zone"])
+			return node, nil
+		}),
+		sNode.EXPECT().Update("default", gomock.Any()).Return(nil, errors.New("conflict")),
+		sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(_ string, node *specV1.Node) (*specV1.Node, error) {
+			assert.Equal(t, "n1", node.Name)
+			_, ok := node.Labels["zone"]
+			assert.False(t, ok)
+			return node, nil
+		}),
+	)
+	req, _ = http.NewRequest(http.MethodPatch, "/v1/nodes/labels", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res = models.NodeLabelsResult{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.True(t, res.RolledBack)
+	assert.Equal(t, 0, res.Succeeded)
+	assert.Equal(t, 3, res.Failed)
+	assert.Equal(t, "rolled back", res.Items[0].Error)
+	assert.Equal(t, "conflict", res.Items[1].Error)
+	assert.Contains(t, res.Items[2].Error, "not applied")
+
+	// bad requests
+	for _, p := range []*models.NodeLabelsPatch{
+		{Add: map[string]string{"zone": "a"}},
+		{Selector: "city=bj", Nodes: []string{"n1"}, Add: map[string]string{"zone": "a"}},
+		{Selector: "city=bj"},
+		{Selector: "city=bj", Add: map[string]string{"zone": "a"}, Remove: []string{"zone"}},
+		{Selector: "city=bj", Remove: []string{common.LabelNodeCordon}},
+		{Selector: "city=bj", Add: map[string]string{"zone": "a b"}},
+	} {
+		body, _ = json.Marshal(p)
+		req, _ = http.NewRequest(http.MethodPatch, "/v1/nodes/labels", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, string(body))
+	}
+}
+
 func TestDeleteNodeError(t *testing.T) {
 	api, router, mockCtl := initNodeAPI(t)
 	defer mockCtl.Finish()
@@ -1554,6 +1958,24 @@ func TestGenInitCmdFromNode(t *testing.T) {
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the activation token designated is carried
+	sActivation := ms.NewMockActivationTokenService(mockCtl)
+	api.ActivationToken = sActivation
+	params["ActivationToken"] = "token01"
+	sNode.EXPECT().Get(nil, node.Namespace, node.Name).Return(node, nil).Times(2)
+	sActivation.EXPECT().Check("default", "token01", "abc").Return(nil)
+	sInit.EXPECT().GetResource("default", "abc", service.TemplateBaetylInitCommand, params).Return(expect, nil).Times(1)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/abc/init?activationToken=token01", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sActivation.EXPECT().Check("default", "token01", "abc").Return(common.Error(common.ErrInvalidToken))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/abc/init?activationToken=token01", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestGenInitCmdFromNode_ErrNode(t *testing.T) {
@@ -1949,6 +2371,7 @@ func TestAPI_UpdateCoreApp(t *testing.T) {
 		"CoreFrequency": "40s",
 		"NodeMode":      "kube",
 		"AgentPort":     "30080",
+		"SyncLink":      "httplink",
 		"GPUStats":      true,
 		"DiskNetStats":  true,
 		"QPSStats":      true,
@@ -2257,3 +2680,37 @@ func TestAPI_GetCoreAppVersions(t *testing.T) {
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+func TestCheckNodeSyncLink(t *testing.T) {
+	assert.NoError(t, checkNodeSyncLink(&specV1.Node{Name: "node01"}))
+	assert.NoError(t, checkNodeSyncLink(&specV1.Node{Name: "node01", Labels: map[string]string{common.LabelSyncLink: common.SyncLinkMQTT}}))
+	assert.Error(t, checkNodeSyncLink(&specV1.Node{Name: "node01", Labels: map[string]string{common.LabelSyncLink: "coaplink"}}))
+}
+
+func TestGetCoreImageByVersion(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sModule := ms.NewMockModuleService(mockCtl)
+	api := &API{Module: sModule}
+
+	node := &specV1.Node{Name: "node01", Attributes: map[string]interface{}{BaetylCorePrevVersion: "v2.1.0"}}
+	sModule.EXPECT().GetModuleByVersion(BaetylModule, "v2.1.0").Return(&models.Module{Version: "v2.1.0", Image: "baetyl:v2.1.0", Deprecated: true}, nil).Times(2)
+	sModule.EXPECT().GetModuleByVersion(BaetylModule, "v2.2.0").Return(&models.Module{Version: "v2.2.0", Image: "baetyl:v2.2.0", Deprecated: true}, nil)
+	sModule.EXPECT().GetModuleByVersion(BaetylModule, "v9.0.0").Return(&models.Module{Version: "v9.0.0", Image: "baetyl:v9.0.0", MinCloudVersion: "v9.0.0"}, nil)
+
+	// the deprecated versions are installed to the nodes rolling back to them
+	image, err := api.getCoreImageByVersion(node, "v2.3.0", "v2.1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "baetyl:v2.1.0", image)
+	image, err = api.getCoreImageByVersion(node, "v2.1.0", "v2.1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "baetyl:v2.1.0", image)
+	_, err = api.getCoreImageByVersion(node, "v2.3.0", "v2.2.0")
+	assert.Error(t, err)
+
+	version := utils.VERSION
+	defer func() { utils.VERSION = version }()
+	utils.VERSION = "v2.4.0"
+	_, err = api.getCoreImageByVersion(node, "v2.3.0", "v9.0.0")
+	assert.Error(t, err)
+}
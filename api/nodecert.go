@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetNodeCertificate get the certificate which is used by node to connect to cloud
+func (api *API) GetNodeCertificate(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.Node.Get(nil, ns, n); err != nil {
+		return nil, err
+	}
+	secret, err := api.NodeCert.Get(ns, n)
+	if err != nil {
+		return nil, err
+	}
+	return models.FromSecretToNodeCertificate(secret, n)
+}
+
+// RotateNodeCertificate re-issue the certificate of node, the new one is synchronized to node with the core application
+func (api *API) RotateNodeCertificate(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.Node.Get(nil, ns, n); err != nil {
+		return nil, err
+	}
+	secret, err := api.NodeCert.Get(ns, n)
+	if err != nil {
+		return nil, err
+	}
+	return api.rotateNodeCert(ns, n, secret)
+}
+
+// RunNodeCertRenewal renews the node certificates expiring within renewBefore every interval until done is closed
+func (api *API) RunNodeCertRenewal(interval, renewBefore time.Duration, done <-chan struct{}) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := api.RenewNodeCertificates(renewBefore); err != nil {
+				api.log.Warn("failed to renew node certificates", log.Error(err))
+			}
+		}
+	}
+}
+
+// RenewNodeCertificates rotates the certificates of all nodes expiring within renewBefore,
+// the namespace is locked as the applications of node are updated
+func (api *API) RenewNodeCertificates(renewBefore time.Duration) error {
+	list, err := api.NS.List(&models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	before := time.Now().Add(renewBefore)
+	for _, item := range list.Items {
+		if err = api.renewNamespaceNodeCerts(item.Name, before); err != nil {
+			api.log.Warn("failed to renew node certificates of namespace", log.Any("namespace", item.Name), log.Error(err))
+		}
+	}
+	return nil
+}
+
+func (api *API) renewNamespaceNodeCerts(namespace string, before time.Time) error {
+	secrets, err := api.NodeCert.ListExpiring(namespace, before)
+	if err != nil || len(secrets) == 0 {
+		return err
+	}
+	ctx := context.Background()
+	lockName := "namespace_" + namespace
+	version, err := api.Locker.Lock(ctx, lockName, 0)
+	if err != nil {
+		return err
+	}
+	defer api.Locker.Unlock(ctx, lockName, version)
+
+	for i := range secrets {
+		node := secrets[i].Labels[common.LabelNodeName]
+		cert, err := api.rotateNodeCert(namespace, node, &secrets[i])
+		if err != nil {
+			api.log.Warn("failed to renew node certificate", log.Any("namespace", namespace), log.Any("node", node), log.Error(err))
+			continue
+		}
+		api.log.Info("node certificate renewed", log.Any("namespace", namespace), log.Any("node", node), log.Any("notAfter", cert.NotAfter))
+	}
+	return nil
+}
+
+// rotateNodeCert updates the secret with the new certificate, the versions of applications mounting it are increased
+func (api *API) rotateNodeCert(namespace, node string, secret *specV1.Secret) (*models.NodeCertificate, error) {
+	renewed, err := api.NodeCert.Renew(secret)
+	if err != nil {
+		return nil, err
+	}
+	res, err := api.Facade.UpdateSecret(namespace, renewed)
+	if err != nil {
+		return nil, err
+	}
+	return models.FromSecretToNodeCertificate(res, node)
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mf "github.com/baetyl/baetyl-cloud/v2/mock/facade"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func genNodeCertSecret(t *testing.T, node string, notAfter time.Time) *specV1.Secret {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "default." + node},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &priv.PublicKey, priv)
+	assert.NoError(t, err)
+	return &specV1.Secret{
+		Name:      "crt-" + node,
+		Namespace: "default",
+		Labels:    map[string]string{common.LabelNodeName: node, specV1.SecretLabel: specV1.SecretConfig},
+		Data: map[string][]byte{
+			"client.pem": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			"client.key": []byte("key"),
+		},
+	}
+}
+
+func initNodeCertAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		nodes := v1.Group("/nodes")
+		nodes.GET("/:name/certificate", mockIM, common.Wrapper(api.GetNodeCertificate))
+		nodes.POST("/:name/certificate/rotate", mockIM, common.Wrapper(api.RotateNodeCertificate))
+	}
+	return api, router, mockCtl
+}
+
+func TestGetNodeCertificate(t *testing.T) {
+	api, router, mockCtl := initNodeCertAPI(t)
+	defer mockCtl.Finish()
+	sNode, sCert := ms.NewMockNodeService(mockCtl), ms.NewMockNodeCertService(mockCtl)
+	api.Node, api.NodeCert = sNode, sCert
+
+	notAfter := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	secret := genNodeCertSecret(t, "node01", notAfter)
+	sNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Name: "node01"}, nil)
+	sCert.EXPECT().Get("default", "node01").Return(secret, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/certificate", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.NodeCertificate
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "default.node01", res.CommonName)
+	assert.Equal(t, notAfter, res.NotAfter)
+	assert.NotContains(t, w.Body.String(), "client.key")
+
+	sNode.EXPECT().Get(nil, "default", "node02").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/node02/certificate", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRotateNodeCertificate(t *testing.T) {
+	api, router, mockCtl := initNodeCertAPI(t)
+	defer mockCtl.Finish()
+	sNode, sCert, sFacade := ms.NewMockNodeService(mockCtl), ms.NewMockNodeCertService(mockCtl), mf.NewMockFacade(mockCtl)
+	api.Node, api.NodeCert, api.Facade = sNode, sCert, sFacade
+
+	old := genNodeCertSecret(t, "node01", time.Now().Add(time.Hour))
+	notAfter := time.Now().Add(365 * 24 * time.Hour).UTC().Truncate(time.Second)
+	renewed := genNodeCertSecret(t, "node01", notAfter)
+	stored := *renewed
+	stored.Version = "2"
+	sNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Name: "node01"}, nil)
+	sCert.EXPECT().Get("default", "node01").Return(old, nil)
+	sCert.EXPECT().Renew(old).Return(renewed, nil)
+	sFacade.EXPECT().UpdateSecret("default", renewed).Return(&stored, nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/nodes/node01/certificate/rotate", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.NodeCertificate
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, notAfter, res.NotAfter)
+	assert.Equal(t, "2", res.SecretVersion)
+
+	sNode.EXPECT().Get(nil, "default", "node02").Return(&specV1.Node{Name: "node02"}, nil)
+	sCert.EXPECT().Get("default", "node02").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes/node02/certificate/rotate", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRenewNodeCertificates(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sNS, sCert, sFacade, sLocker := ms.NewMockNamespaceService(mockCtl), ms.NewMockNodeCertService(mockCtl),
+		mf.NewMockFacade(mockCtl), ms.NewMockLockerService(mockCtl)
+	api := &API{NS: sNS, NodeCert: sCert, Facade: sFacade, Locker: sLocker, log: log.L()}
+
+	expiring := genNodeCertSecret(t, "node01", time.Now().Add(time.Hour))
+	failing := genNodeCertSecret(t, "node02", time.Now().Add(time.Hour))
+	renewed := genNodeCertSecret(t, "node01", time.Now().Add(365*24*time.Hour))
+	sNS.EXPECT().List(&models.ListOptions{}).Return(&models.NamespaceList{Items: []models.Namespace{{Name: "default"}, {Name: "other"}}}, nil)
+	sCert.EXPECT().ListExpiring("default", gomock.Any()).DoAndReturn(func(_ string, before time.Time) ([]specV1.Secret, error) {
+		assert.True(t, before.After(time.Now().Add(29*24*time.Hour)))
+		return []specV1.Secret{*expiring, *failing}, nil
+	})
+	sCert.EXPECT().ListExpiring("other", gomock.Any()).Return(nil, nil)
+	sLocker.EXPECT().Lock(gomock.Any(), "namespace_default", int64(0)).Return("v1", nil)
+	sLocker.EXPECT().Unlock(gomock.Any(), "namespace_default", "v1")
+	sCert.EXPECT().Renew(expiring).Return(renewed, nil)
+	sFacade.EXPECT().UpdateSecret("default", renewed).Return(renewed, nil)
+	// the failure of one node does not stop the others
+	sCert.EXPECT().Renew(failing).Return(nil, common.Error(common.ErrRequestParamInvalid))
+	assert.NoError(t, api.RenewNodeCertificates(30*24*time.Hour))
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/log"
+	v1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetNamespaceEndpoints get the endpoints of cloud overridden for the nodes of namespace
+func (api *API) GetNamespaceEndpoints(c *common.Context) (interface{}, error) {
+	return api.NodeEndpoints.Get(c.GetNamespace())
+}
+
+// UpdateNamespaceEndpoints update the endpoints overridden for the nodes of namespace, such as the addresses of
+// the reverse proxy of site. The configs of the nodes whose sync endpoints are changed are rendered again
+func (api *API) UpdateNamespaceEndpoints(c *common.Context) (interface{}, error) {
+	endpoints := new(models.NodeEndpoints)
+	if err := c.LoadBody(endpoints); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	endpoints.Namespace = c.GetNamespace()
+	if err := service.ValidateNodeEndpoints(endpoints); err != nil {
+		return nil, err
+	}
+	old, err := api.NodeEndpoints.Get(endpoints.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	res, err := api.NodeEndpoints.Set(endpoints)
+	if err != nil {
+		return nil, err
+	}
+	if err = api.refreshNamespaceEndpoints(endpoints.Namespace, old, endpoints); err != nil {
+		return nil, err
+	}
+	log.L().Info("node endpoints of namespace are set", log.Any("namespace", endpoints.Namespace), log.Any("endpoints", res))
+	return res, nil
+}
+
+// DeleteNamespaceEndpoints delete the endpoints overridden for the nodes of namespace, the nodes connect to the
+// endpoints of cloud again unless the endpoints are set on them
+func (api *API) DeleteNamespaceEndpoints(c *common.Context) (interface{}, error) {
+	ns := c.GetNamespace()
+	old, err := api.NodeEndpoints.Get(ns)
+	if err != nil {
+		return nil, err
+	}
+	if err = api.NodeEndpoints.Delete(ns); err != nil {
+		return nil, err
+	}
+	return nil, api.refreshNamespaceEndpoints(ns, old, &models.NodeEndpoints{Namespace: ns})
+}
+
+// GetNodeEndpoints get the endpoints in effect for node, the ones set on node override the ones of namespace
+func (api *API) GetNodeEndpoints(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	return api.NodeEndpoints.Resolve(ns, node)
+}
+
+// UpdateNodeEndpoints set the endpoints of node, which override the ones of namespace
+func (api *API) UpdateNodeEndpoints(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	endpoints := new(models.NodeEndpoints)
+	if err := c.LoadBody(endpoints); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if err := service.ValidateNodeEndpoints(endpoints); err != nil {
+		return nil, err
+	}
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if node, err = api.setNodeEndpoints(ns, node, endpoints); err != nil {
+		return nil, err
+	}
+	log.L().Info("node endpoints are set", log.Any("namespace", ns), log.Any("name", n), log.Any("endpoints", endpoints))
+	return api.NodeEndpoints.Resolve(ns, node)
+}
+
+// DeleteNodeEndpoints delete the endpoints of node, the ones of namespace are in effect again
+func (api *API) DeleteNodeEndpoints(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := node.Attributes[common.AttributeNodeEndpoints]; !ok {
+		return nil, nil
+	}
+	_, err = api.setNodeEndpoints(ns, node, nil)
+	return nil, err
+}
+
+// bindNodeEndpoints merges the endpoints of the token issued for node into the ones set on node
+func (api *API) bindNodeEndpoints(token *models.ActivationToken) error {
+	node, err := api.Node.Get(nil, token.Namespace, token.Node)
+	if err != nil {
+		return err
+	}
+	current, err := service.GetNodeEndpoints(node)
+	if err != nil {
+		return err
+	}
+	_, err = api.setNodeEndpoints(token.Namespace, node, service.MergeNodeEndpoints(current, token.Endpoints))
+	return err
+}
+
+// setNodeEndpoints stores the endpoints on node, which are deleted if nil. The configs of the system apps of node
+// are rendered again once its sync endpoints in effect are changed
+func (api *API) setNodeEndpoints(ns string, node *v1.Node, endpoints *models.NodeEndpoints) (*v1.Node, error) {
+	old, err := api.NodeEndpoints.Resolve(ns, node)
+	if err != nil {
+		return nil, err
+	}
+	if endpoints == nil {
+		delete(node.Attributes, common.AttributeNodeEndpoints)
+	} else {
+		if node.Attributes == nil {
+			node.Attributes = map[string]interface{}{}
+		}
+		endpoints.Namespace = ""
+		node.Attributes[common.AttributeNodeEndpoints] = endpoints
+	}
+	if node, err = api.Node.Update(ns, node); err != nil {
+		return nil, err
+	}
+	current, err := api.NodeEndpoints.Resolve(ns, node)
+	if err != nil {
+		return nil, err
+	}
+	if !syncEndpointsChanged(old, current) {
+		return node, nil
+	}
+	return node, api.UpdateConfigByAccelerator(ns, node)
+}
+
+// refreshNamespaceEndpoints renders the configs of the system apps of the nodes whose sync endpoints in effect are
+// changed by the endpoints of namespace. The failure of one node does not stop the others
+func (api *API) refreshNamespaceEndpoints(ns string, old, endpoints *models.NodeEndpoints) error {
+	if !syncEndpointsChanged(old, endpoints) {
+		return nil
+	}
+	nodes, err := api.Node.List(ns, &models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		set, err := service.GetNodeEndpoints(node)
+		if err != nil {
+			return err
+		}
+		if !syncEndpointsChanged(service.MergeNodeEndpoints(old, set), service.MergeNodeEndpoints(endpoints, set)) {
+			continue
+		}
+		if err = api.UpdateConfigByAccelerator(ns, node); err != nil {
+			api.log.Warn("failed to render the configs of node with the endpoints of namespace", log.Any("namespace", ns),
+				log.Any("name", node.Name), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// syncEndpointsChanged returns true if the endpoints rendered into the configs of system apps are changed, the init
+// and object storage endpoints are rendered on each request
+func syncEndpointsChanged(old, endpoints *models.NodeEndpoints) bool {
+	return old.Address(models.EndpointSyncServer) != endpoints.Address(models.EndpointSyncServer) ||
+		old.Address(models.EndpointSyncMQTT) != endpoints.Address(models.EndpointSyncMQTT)
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initNodeEndpointsAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		endpoints := v1.Group("/nodeendpoints")
+		endpoints.GET("", mockIM, common.Wrapper(api.GetNamespaceEndpoints))
+		endpoints.PUT("", mockIM, common.Wrapper(api.UpdateNamespaceEndpoints))
+		endpoints.DELETE("", mockIM, common.Wrapper(api.DeleteNamespaceEndpoints))
+	}
+	{
+		nodes := v1.Group("/nodes")
+		nodes.GET("/:name/endpoints", mockIM, common.Wrapper(api.GetNodeEndpoints))
+		nodes.PUT("/:name/endpoints", mockIM, common.Wrapper(api.UpdateNodeEndpoints))
+		nodes.DELETE("/:name/endpoints", mockIM, common.Wrapper(api.DeleteNodeEndpoints))
+	}
+	return api, router, mockCtl
+}
+
+func TestUpdateNamespaceEndpoints(t *testing.T) {
+	api, router, mockCtl := initNodeEndpointsAPI(t)
+	defer mockCtl.Finish()
+	sEndpoints, sNode := ms.NewMockNodeEndpointsService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.NodeEndpoints, api.Node = sEndpoints, sNode
+
+	// the object storage endpoint is rendered on each sync, no config is rendered again
+	old := &models.NodeEndpoints{Namespace: "default", SyncServer: "https://gateway.site:30005"}
+	sEndpoints.EXPECT().Get("default").Return(old, nil)
+	sEndpoints.EXPECT().Set(gomock.Any()).DoAndReturn(func(e *models.NodeEndpoints) (*models.NodeEndpoints, error) {
+		assert.Equal(t, "default", e.Namespace)
+		assert.Equal(t, "https://gateway.site/objects", e.ObjectStorage)
+		return e, nil
+	})
+	body, _ := json.Marshal(&models.NodeEndpoints{SyncServer: "https://gateway.site:30005", ObjectStorage: "https://gateway.site/objects/"})
+	req, _ := http.NewRequest(http.MethodPut, "/v1/nodeendpoints", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the node setting its own sync endpoints is kept
+	node := &specV1.Node{Namespace: "default", Name: "node01", Attributes: map[string]interface{}{
+		common.AttributeNodeEndpoints: map[string]interface{}{"syncServer": "https://proxy.local:30005", "syncMqtt": "ssl://proxy.local:30883"},
+	}}
+	sEndpoints.EXPECT().Get("default").Return(old, nil)
+	sEndpoints.EXPECT().Set(gomock.Any()).DoAndReturn(func(e *models.NodeEndpoints) (*models.NodeEndpoints, error) {
+		return e, nil
+	})
+	sNode.EXPECT().List("default", &models.ListOptions{}).Return(&models.NodeList{Items: []specV1.Node{*node}}, nil)
+	body, _ = json.Marshal(&models.NodeEndpoints{SyncServer: "https://gateway.site:30006"})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/nodeendpoints", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	for _, e := range []*models.NodeEndpoints{
+		{SyncServer: "gateway.site:30005"},
+		{InitServer: "https://"},
+		{SyncMQTT: "not a url"},
+	} {
+		body, _ = json.Marshal(e)
+		req, _ = http.NewRequest(http.MethodPut, "/v1/nodeendpoints", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, e)
+	}
+
+	sEndpoints.EXPECT().Get("default").Return(&models.NodeEndpoints{Namespace: "default", InitServer: "https://gateway.site:30003"}, nil)
+	sEndpoints.EXPECT().Delete("default").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/nodeendpoints", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestUpdateNodeEndpoints(t *testing.T) {
+	api, router, mockCtl := initNodeEndpointsAPI(t)
+	defer mockCtl.Finish()
+	sEndpoints, sNode := ms.NewMockNodeEndpointsService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.NodeEndpoints, api.Node = sEndpoints, sNode
+
+	node := &specV1.Node{Namespace: "default", Name: "node01"}
+	resolved := &models.NodeEndpoints{Namespace: "default", SyncServer: "https://gateway.site:30005"}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	sEndpoints.EXPECT().Resolve("default", node).Return(resolved, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/endpoints", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the init endpoint is rendered into the install commands on request, no config is rendered again
+	endpoints := &models.NodeEndpoints{InitServer: "https://proxy.local:30003"}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	sEndpoints.EXPECT().Resolve("default", node).Return(resolved, nil)
+	sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(ns string, n *specV1.Node) (*specV1.Node, error) {
+		assert.Equal(t, endpoints, n.Attributes[common.AttributeNodeEndpoints])
+		return n, nil
+	})
+	sEndpoints.EXPECT().Resolve("default", node).Return(&models.NodeEndpoints{Namespace: "default", InitServer: "https://proxy.local:30003",
+		SyncServer: "https://gateway.site:30005"}, nil).Times(2)
+	body, _ := json.Marshal(endpoints)
+	req, _ = http.NewRequest(http.MethodPut, "/v1/nodes/node01/endpoints", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodPut, "/v1/nodes/node01/endpoints", bytes.NewReader([]byte(`{"initServer":"proxy.local"}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// nothing is deleted if not set
+	sNode.EXPECT().Get(nil, "default", "node02").Return(&specV1.Node{Namespace: "default", Name: "node02"}, nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/nodes/node02/endpoints", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/utils"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// NodeFilterQuery the query parameter of listing nodes by the saved filter
+const NodeFilterQuery = "filter"
+
+// GetNodeFilter get a node filter saved by user
+func (api *API) GetNodeFilter(c *common.Context) (interface{}, error) {
+	return api.NodeFilter.Get(c.GetNamespace(), c.GetUser().ID, c.GetNameFromParam())
+}
+
+// ListNodeFilter list the node filters saved by user
+func (api *API) ListNodeFilter(c *common.Context) (interface{}, error) {
+	items, err := api.NodeFilter.List(c.GetNamespace(), c.GetUser().ID)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(items), items, ""), nil
+}
+
+// CreateNodeFilter save a node filter for user
+func (api *API) CreateNodeFilter(c *common.Context) (interface{}, error) {
+	filter, err := parseAndCheckNodeFilter(c)
+	if err != nil {
+		return nil, err
+	}
+	old, err := api.NodeFilter.Get(filter.Namespace, filter.User, filter.Name)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+	}
+	if old != nil {
+		return nil, common.Error(common.ErrResourceConflict, common.Field("type", "nodefilter"), common.Field("name", filter.Name))
+	}
+	return api.NodeFilter.Create(filter)
+}
+
+// UpdateNodeFilter update the node filter saved by user
+func (api *API) UpdateNodeFilter(c *common.Context) (interface{}, error) {
+	filter, err := parseAndCheckNodeFilter(c)
+	if err != nil {
+		return nil, err
+	}
+	old, err := api.NodeFilter.Get(filter.Namespace, filter.User, filter.Name)
+	if err != nil {
+		return nil, err
+	}
+	filter.CreateTime = old.CreateTime
+	return api.NodeFilter.Update(filter)
+}
+
+// DeleteNodeFilter delete the node filter saved by user
+func (api *API) DeleteNodeFilter(c *common.Context) (interface{}, error) {
+	return nil, api.NodeFilter.Delete(c.GetNamespace(), c.GetUser().ID, c.GetNameFromParam())
+}
+
+// applyNodeFilter fills the conditions of the saved filter into the list options,
+// the conditions set in the request take precedence over the saved ones
+func (api *API) applyNodeFilter(c *common.Context, params *models.ListOptions) error {
+	name, ok := c.GetQuery(NodeFilterQuery)
+	if !ok || name == "" {
+		return nil
+	}
+	filter, err := api.NodeFilter.Get(c.GetNamespace(), c.GetUser().ID, name)
+	if err != nil {
+		return err
+	}
+	if params.LabelSelector == "" {
+		params.LabelSelector = filter.Selector
+	}
+	q := &params.NodeQuery
+	if q.Ready == "" {
+		q.Ready = filter.Query.Ready
+	}
+	if q.MinVersion == "" {
+		q.MinVersion = filter.Query.MinVersion
+	}
+	if q.MaxVersion == "" {
+		q.MaxVersion = filter.Query.MaxVersion
+	}
+	if q.CreatedAfter.IsZero() {
+		q.CreatedAfter = filter.Query.CreatedAfter
+	}
+	if q.CreatedBefore.IsZero() {
+		q.CreatedBefore = filter.Query.CreatedBefore
+	}
+	return nil
+}
+
+func parseAndCheckNodeFilter(c *common.Context) (*models.NodeFilter, error) {
+	filter := new(models.NodeFilter)
+	if err := c.LoadBody(filter); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	filter.Namespace, filter.User = c.GetNamespace(), c.GetUser().ID
+	if name := c.GetNameFromParam(); name != "" {
+		filter.Name = name
+	}
+	if filter.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	if err := checkNodeQuery(&filter.Query); err != nil {
+		return nil, err
+	}
+	if _, err := utils.IsLabelMatch(filter.Selector, map[string]string{}); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	return filter, nil
+}
+
+func checkNodeQuery(q *models.NodeQuery) error {
+	if q.Ready != "" && q.Ready != models.NodeReadyOnline && q.Ready != models.NodeReadyOffline {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "ready should be online or offline"))
+	}
+	if !q.CreatedAfter.IsZero() && !q.CreatedBefore.IsZero() && !q.CreatedAfter.Before(q.CreatedBefore) {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "createdAfter should be before createdBefore"))
+	}
+	if q.MinVersion != "" && q.MaxVersion != "" && common.CompareVersion(q.MinVersion, q.MaxVersion) > 0 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "minVersion should not be greater than maxVersion"))
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initNodeFilterAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	api.AppCombinedService = &service.AppCombinedService{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) {
+		cc := common.NewContext(c)
+		cc.SetNamespace("default")
+		cc.SetUser(common.User{ID: "user01"})
+	}
+	v1 := router.Group("v1")
+	{
+		filters := v1.Group("/nodefilters")
+		filters.GET("/:name", mockIM, common.Wrapper(api.GetNodeFilter))
+		filters.PUT("/:name", mockIM, common.Wrapper(api.UpdateNodeFilter))
+		filters.DELETE("/:name", mockIM, common.Wrapper(api.DeleteNodeFilter))
+		filters.POST("", mockIM, common.Wrapper(api.CreateNodeFilter))
+		filters.GET("", mockIM, common.Wrapper(api.ListNodeFilter))
+		v1.GET("/nodes", mockIM, common.Wrapper(api.ListNode))
+	}
+	return api, router, mockCtl
+}
+
+func TestCreateNodeFilter(t *testing.T) {
+	api, router, mockCtl := initNodeFilterAPI(t)
+	defer mockCtl.Finish()
+	sFilter := ms.NewMockNodeFilterService(mockCtl)
+	api.NodeFilter = sFilter
+
+	filter := &models.NodeFilter{
+		Namespace: "default",
+		User:      "user01",
+		Name:      "f1",
+		Selector:  "city=bj",
+		Query:     models.NodeQuery{Ready: models.NodeReadyOnline, MinVersion: "v2.2.0"},
+	}
+	sFilter.EXPECT().Get("default", "user01", "f1").Return(nil, common.Error(common.ErrResourceNotFound))
+	sFilter.EXPECT().Create(filter).Return(filter, nil)
+	body, _ := json.Marshal(&models.NodeFilter{Name: "f1", Selector: "city=bj", Query: filter.Query})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/nodefilters", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// conflict
+	sFilter.EXPECT().Get("default", "user01", "f1").Return(filter, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodefilters", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), common.ErrResourceConflict)
+
+	// invalid ready
+	body, _ = json.Marshal(&models.NodeFilter{Name: "f2", Query: models.NodeQuery{Ready: "unknown"}})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodefilters", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid version range
+	body, _ = json.Marshal(&models.NodeFilter{Name: "f2", Query: models.NodeQuery{MinVersion: "v2.3.0", MaxVersion: "v2.2.0"}})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodefilters", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid selector
+	body, _ = json.Marshal(&models.NodeFilter{Name: "f2", Selector: "a=b=c"})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodefilters", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateAndDeleteNodeFilter(t *testing.T) {
+	api, router, mockCtl := initNodeFilterAPI(t)
+	defer mockCtl.Finish()
+	sFilter := ms.NewMockNodeFilterService(mockCtl)
+	api.NodeFilter = sFilter
+
+	old := &models.NodeFilter{Namespace: "default", User: "user01", Name: "f1", Selector: "city=bj"}
+	filter := &models.NodeFilter{Namespace: "default", User: "user01", Name: "f1", Selector: "city=sh"}
+	sFilter.EXPECT().Get("default", "user01", "f1").Return(old, nil)
+	sFilter.EXPECT().Update(filter).Return(filter, nil)
+	body, _ := json.Marshal(&models.NodeFilter{Selector: "city=sh"})
+	req, _ := http.NewRequest(http.MethodPut, "/v1/nodefilters/f1", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sFilter.EXPECT().Get("default", "user01", "f2").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodPut, "/v1/nodefilters/f2", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	sFilter.EXPECT().List("default", "user01").Return([]models.NodeFilter{*filter}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodefilters", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "city=sh")
+
+	sFilter.EXPECT().Delete("default", "user01", "f1").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/nodefilters/f1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestListNodeWithFilter(t *testing.T) {
+	api, router, mockCtl := initNodeFilterAPI(t)
+	defer mockCtl.Finish()
+	sNode, sHealth, sFilter := ms.NewMockNodeService(mockCtl), ms.NewMockHealthService(mockCtl), ms.NewMockNodeFilterService(mockCtl)
+	api.Node, api.Health, api.NodeFilter = sNode, sHealth, sFilter
+
+	list := &models.NodeList{
+		Total: 1,
+		Items: []specV1.Node{{Name: "node01", Labels: map[string]string{"city": "bj"}}},
+	}
+	threshold := &models.HealthThreshold{Namespace: "default", WarningScore: 80, CriticalScore: 50}
+	filter := &models.NodeFilter{
+		Namespace: "default",
+		User:      "user01",
+		Name:      "f1",
+		Selector:  "city=bj",
+		Query:     models.NodeQuery{Ready: models.NodeReadyOnline, MinVersion: "v2.2.0"},
+	}
+
+	// the saved ready condition filters out the offline node
+	sFilter.EXPECT().Get("default", "user01", "f1").Return(filter, nil)
+	sNode.EXPECT().List("default", gomock.Any()).DoAndReturn(func(_ string, params *models.ListOptions) (*models.NodeList, error) {
+		assert.Equal(t, "city=bj", params.LabelSelector)
+		assert.Equal(t, filter.Query, params.NodeQuery)
+		return list, nil
+	})
+	sHealth.EXPECT().GetThreshold("default").Return(threshold, nil)
+	sHealth.EXPECT().Evaluate(gomock.Any(), threshold).Return(&models.NodeHealth{Level: models.HealthLevelUnknown})
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes?filter=f1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "{\"total\":0,\"items\":[]}\n", w.Body.String())
+
+	// the conditions of request take precedence over the saved ones
+	sFilter.EXPECT().Get("default", "user01", "f1").Return(filter, nil)
+	sNode.EXPECT().List("default", gomock.Any()).DoAndReturn(func(_ string, params *models.ListOptions) (*models.NodeList, error) {
+		assert.Equal(t, models.NodeReadyOffline, params.Ready)
+		return list, nil
+	})
+	sHealth.EXPECT().GetThreshold("default").Return(threshold, nil)
+	sHealth.EXPECT().Evaluate(gomock.Any(), threshold).Return(&models.NodeHealth{Level: models.HealthLevelUnknown})
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes?filter=f1&ready=offline", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\"total\":1")
+
+	// the filter of other user is not found
+	sFilter.EXPECT().Get("default", "user01", "f2").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes?filter=f2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes?ready=unknown", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
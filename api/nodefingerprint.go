@@ -0,0 +1,31 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+// GetNodeFingerprint get the hardware fingerprint which node is bound to at its first activation
+func (api *API) GetNodeFingerprint(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	fingerprint, err := api.Fingerprint.Get(ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if fingerprint == nil {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "fingerprint"), common.Field("name", n), common.Field("namespace", ns))
+	}
+	return fingerprint, nil
+}
+
+// ResetNodeFingerprint reset the hardware binding of node, such as once its hardware is replaced. The node is bound
+// to the hardware of its next activation
+func (api *API) ResetNodeFingerprint(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	if err := api.Fingerprint.Reset(ns, n); err != nil {
+		return nil, err
+	}
+	log.L().Info("node fingerprint is reset", log.Any(c.GetTrace()), log.Any("namespace", ns), log.Any("name", n))
+	return nil, nil
+}
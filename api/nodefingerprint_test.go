@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initNodeFingerprintAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		nodes := v1.Group("/nodes")
+		nodes.GET("/:name/fingerprint", mockIM, common.Wrapper(api.GetNodeFingerprint))
+		nodes.DELETE("/:name/fingerprint", mockIM, common.Wrapper(api.ResetNodeFingerprint))
+	}
+	return api, router, mockCtl
+}
+
+func TestNodeFingerprintAPI(t *testing.T) {
+	api, router, mockCtl := initNodeFingerprintAPI(t)
+	defer mockCtl.Finish()
+	sFingerprint := ms.NewMockNodeFingerprintService(mockCtl)
+	api.Fingerprint = sFingerprint
+
+	sFingerprint.EXPECT().Get("default", "node01").Return(&models.HardwareFingerprint{MachineID: "m01", MACs: []string{"00:11:22:33:44:55"}}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/fingerprint", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"machineId":"m01"`)
+
+	// the node not activated yet is not bound
+	sFingerprint.EXPECT().Get("default", "node02").Return(nil, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/node02/fingerprint", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	sFingerprint.EXPECT().Reset("default", "node01").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/nodes/node01/fingerprint", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/baetyl/baetyl-go/v2/utils"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetNodeGroup get a node group
+func (api *API) GetNodeGroup(c *common.Context) (interface{}, error) {
+	return api.Group.Get(c.GetNamespace(), c.GetNameFromParam())
+}
+
+// ListNodeGroup list node groups
+func (api *API) ListNodeGroup(c *common.Context) (interface{}, error) {
+	params, err := api.ParseListOptions(c)
+	if err != nil {
+		return nil, err
+	}
+	list, err := api.Group.List(c.GetNamespace(), params)
+	if err != nil {
+		return nil, err
+	}
+	return api.ToListResponse(list.Total, list.Items, params), nil
+}
+
+// CreateNodeGroup create a node group
+func (api *API) CreateNodeGroup(c *common.Context) (interface{}, error) {
+	group, err := api.parseAndCheckNodeGroup(c)
+	if err != nil {
+		return nil, err
+	}
+	old, err := api.Group.Get(group.Namespace, group.Name)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+	}
+	if old != nil {
+		return nil, common.Error(common.ErrResourceConflict, common.Field("type", "nodegroup"), common.Field("name", group.Name))
+	}
+	if c.IsDryRun() {
+		return group, nil
+	}
+	return api.Group.Create(group)
+}
+
+// UpdateNodeGroup update the node group, the applications targeting the group are deployed to the new members
+func (api *API) UpdateNodeGroup(c *common.Context) (interface{}, error) {
+	group, err := api.parseAndCheckNodeGroup(c)
+	if err != nil {
+		return nil, err
+	}
+	old, err := api.Group.Get(group.Namespace, group.Name)
+	if err != nil {
+		return nil, err
+	}
+	group.CreateTime = old.CreateTime
+	if c.IsDryRun() {
+		return group, nil
+	}
+	res, err := api.Group.Update(group)
+	if err != nil {
+		return nil, err
+	}
+	c.SetAuditDiff(old, res)
+
+	if res.NodeSelector() != old.NodeSelector() {
+		if err = api.reconcileNodeGroupApps(res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// DeleteNodeGroup delete the node group which is not targeted by any application
+func (api *API) DeleteNodeGroup(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	apps, err := api.listNodeGroupApps(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(apps.Items) > 0 {
+		return nil, common.Error(common.ErrResourceDeleteForbidden, common.Field("type", "nodegroup"), common.Field("name", name))
+	}
+	return nil, api.Group.Delete(ns, name)
+}
+
+func (api *API) parseAndCheckNodeGroup(c *common.Context) (*models.NodeGroup, error) {
+	group := new(models.NodeGroup)
+	if err := c.LoadBody(group); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	group.Namespace = c.GetNamespace()
+	if name := c.GetNameFromParam(); name != "" {
+		group.Name = name
+	}
+	if group.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	if (group.Selector == "") == (len(group.Nodes) == 0) {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "either selector or nodes should be set"))
+	}
+	for _, n := range group.Nodes {
+		if n == "" {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the name of node should not be empty"))
+		}
+	}
+	if _, err := utils.IsLabelMatch(group.NodeSelector(), map[string]string{}); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if group.MaintenanceWindow != nil {
+		if err := service.ValidateMaintenanceWindow(group.MaintenanceWindow); err != nil {
+			return nil, err
+		}
+	}
+	if group.SyncInterval != nil {
+		if err := service.ValidateSyncInterval(group.SyncInterval); err != nil {
+			return nil, err
+		}
+	}
+	return group, nil
+}
+
+func (api *API) listNodeGroupApps(ns, name string) (*models.ApplicationList, error) {
+	return api.App.List(ns, &models.ListOptions{LabelSelector: common.LabelNodeGroup + "=" + name})
+}
+
+// reconcileNodeGroupApps updates the selector of applications targeting the group
+func (api *API) reconcileNodeGroupApps(group *models.NodeGroup) error {
+	apps, err := api.listNodeGroupApps(group.Namespace, group.Name)
+	if err != nil {
+		return err
+	}
+	selector := group.NodeSelector()
+	for _, item := range apps.Items {
+		oldApp, err := api.App.Get(group.Namespace, item.Name, "")
+		if err != nil {
+			return err
+		}
+		if oldApp.Selector == selector {
+			continue
+		}
+		app := *oldApp
+		app.Selector = selector
+		if _, err = api.Facade.UpdateApp(group.Namespace, oldApp, &app, nil); err != nil {
+			return err
+		}
+		log.L().Info("app is reconciled with node group", log.Any("app", app.Name), log.Any("nodegroup", group.Name))
+	}
+	return nil
+}
+
+// applyNodeGroup sets the selector of application targeting the group
+func (api *API) applyNodeGroup(appView *models.ApplicationView) error {
+	if appView.NodeGroup == "" {
+		delete(appView.Labels, common.LabelNodeGroup)
+		return nil
+	}
+	group, err := api.Group.Get(appView.Namespace, appView.NodeGroup)
+	if err != nil {
+		return err
+	}
+	if appView.Labels == nil {
+		appView.Labels = map[string]string{}
+	}
+	appView.Labels[common.LabelNodeGroup] = group.Name
+	appView.Selector = group.NodeSelector()
+	return nil
+}
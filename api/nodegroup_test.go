@@ -0,0 +1,188 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mf "github.com/baetyl/baetyl-cloud/v2/mock/facade"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initNodeGroupAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		groups := v1.Group("/nodegroups")
+		groups.GET("/:name", mockIM, common.Wrapper(api.GetNodeGroup))
+		groups.PUT("/:name", mockIM, common.Wrapper(api.UpdateNodeGroup))
+		groups.DELETE("/:name", mockIM, common.Wrapper(api.DeleteNodeGroup))
+		groups.POST("", mockIM, common.Wrapper(api.CreateNodeGroup))
+		groups.GET("", mockIM, common.Wrapper(api.ListNodeGroup))
+	}
+	return api, router, mockCtl
+}
+
+func TestCreateNodeGroup(t *testing.T) {
+	api, router, mockCtl := initNodeGroupAPI(t)
+	defer mockCtl.Finish()
+
+	sGroup := ms.NewMockNodeGroupService(mockCtl)
+	api.Group = sGroup
+
+	group := &models.NodeGroup{
+		Namespace: "default",
+		Name:      "g1",
+		Nodes:     []string{"n1", "n2"},
+	}
+	sGroup.EXPECT().Get("default", "g1").Return(nil, common.Error(common.ErrResourceNotFound)).Times(2)
+	sGroup.EXPECT().Create(group).Return(group, nil)
+
+	body, _ := json.Marshal(group)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/nodegroups", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// dry run
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodegroups?dryRun=true", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// conflict
+	sGroup.EXPECT().Get("default", "g1").Return(group, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodegroups", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), common.ErrResourceConflict)
+
+	// both selector and nodes
+	body, _ = json.Marshal(&models.NodeGroup{Name: "g2", Selector: "a=b", Nodes: []string{"n1"}})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodegroups", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid selector
+	body, _ = json.Marshal(&models.NodeGroup{Name: "g2", Selector: "a=b=c"})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodegroups", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid maintenance window
+	body, _ = json.Marshal(&models.NodeGroup{Name: "g2", Selector: "a=b", MaintenanceWindow: &models.MaintenanceWindow{Cron: "0 25 * * *", Duration: "2h"}})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodegroups", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid sync interval
+	body, _ = json.Marshal(&models.NodeGroup{Name: "g2", Selector: "a=b", SyncInterval: &models.SyncInterval{Report: "10s", Jitter: "1m"}})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodegroups", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateNodeGroup(t *testing.T) {
+	api, router, mockCtl := initNodeGroupAPI(t)
+	defer mockCtl.Finish()
+
+	sGroup := ms.NewMockNodeGroupService(mockCtl)
+	sApp := ms.NewMockApplicationService(mockCtl)
+	mFacade := mf.NewMockFacade(mockCtl)
+	api.Group = sGroup
+	api.Facade = mFacade
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	old := &models.NodeGroup{Namespace: "default", Name: "g1", Nodes: []string{"n1"}}
+	group := &models.NodeGroup{Namespace: "default", Name: "g1", Selector: "region=east"}
+	app := &specV1.Application{
+		Namespace: "default",
+		Name:      "app1",
+		Labels:    map[string]string{common.LabelNodeGroup: "g1"},
+		Selector:  old.NodeSelector(),
+	}
+	updated := *app
+	updated.Selector = "region=east"
+
+	sGroup.EXPECT().Get("default", "g1").Return(old, nil)
+	sGroup.EXPECT().Update(group).Return(group, nil)
+	sApp.EXPECT().List("default", &models.ListOptions{LabelSelector: common.LabelNodeGroup + "=g1"}).
+		Return(&models.ApplicationList{Items: []models.AppItem{{Name: "app1"}}}, nil)
+	sApp.EXPECT().Get("default", "app1", "").Return(app, nil)
+	mFacade.EXPECT().UpdateApp("default", app, &updated, nil).Return(&updated, nil)
+
+	body, _ := json.Marshal(&models.NodeGroup{Selector: "region=east"})
+	req, _ := http.NewRequest(http.MethodPut, "/v1/nodegroups/g1", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// not found
+	sGroup.EXPECT().Get("default", "g2").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodPut, "/v1/nodegroups/g2", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteNodeGroup(t *testing.T) {
+	api, router, mockCtl := initNodeGroupAPI(t)
+	defer mockCtl.Finish()
+
+	sGroup := ms.NewMockNodeGroupService(mockCtl)
+	sApp := ms.NewMockApplicationService(mockCtl)
+	api.Group = sGroup
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	listOptions := &models.ListOptions{LabelSelector: common.LabelNodeGroup + "=g1"}
+	sApp.EXPECT().List("default", listOptions).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "app1"}}}, nil)
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/nodegroups/g1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), common.ErrResourceDeleteForbidden)
+
+	sApp.EXPECT().List("default", listOptions).Return(&models.ApplicationList{}, nil)
+	sGroup.EXPECT().Delete("default", "g1").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/nodegroups/g1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestListNodeGroup(t *testing.T) {
+	api, router, mockCtl := initNodeGroupAPI(t)
+	defer mockCtl.Finish()
+
+	sGroup := ms.NewMockNodeGroupService(mockCtl)
+	api.Group = sGroup
+
+	list := &models.NodeGroupList{
+		Total: 1,
+		Items: []models.NodeGroup{{Namespace: "default", Name: "g1", Selector: "a=b"}},
+	}
+	sGroup.EXPECT().List("default", gomock.Any()).Return(list, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodegroups", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\"g1\"")
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/log"
+	v1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/baetyl/baetyl-go/v2/utils"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// ImportNode registers the nodes of an existing kubernetes cluster as nodes in batch,
+// the install command of each created node is returned to activate it on the kubernetes node
+func (api *API) ImportNode(c *common.Context) (interface{}, error) {
+	req, err := parseAndCheckNodeImport(c)
+	if err != nil {
+		return nil, err
+	}
+	clusterNodes, err := api.ClusterImport.ListNodes(req)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]models.ClusterNode, 0, len(clusterNodes))
+	for _, cn := range clusterNodes {
+		if cn.Master && !req.IncludeMasters {
+			continue
+		}
+		nodes = append(nodes, cn)
+	}
+	if len(nodes) > MaxBatchNodeNumber {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("at most %d nodes can be imported in batch", MaxBatchNodeNumber)))
+	}
+
+	tpl := []byte("{}")
+	if req.Template != nil {
+		if tpl, err = json.Marshal(req.Template); err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+		}
+	}
+	res := &models.NodeBatchResult{
+		Total: len(nodes),
+		Items: make([]models.NodeBatchItem, 0, len(nodes)),
+	}
+	for _, cn := range nodes {
+		item := models.NodeBatchItem{Name: req.NamePrefix + cn.Name, Source: cn.Name}
+		if err = api.importNode(c, req, tpl, &item); err != nil {
+			log.L().Warn("failed to import node", log.Any(c.GetTrace()), log.Any("name", item.Name), log.Error(err))
+			item.Error = err.Error()
+			res.Failed++
+		} else {
+			item.Success = true
+			res.Succeeded++
+		}
+		res.Items = append(res.Items, item)
+	}
+	return res, nil
+}
+
+func (api *API) importNode(c *common.Context, req *models.NodeImport, tpl []byte, item *models.NodeBatchItem) error {
+	n := new(v1.Node)
+	if err := json.Unmarshal(tpl, n); err != nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if err := utils.SetDefaults(n); err != nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	n.Name = item.Name
+	if n.Description == "" {
+		n.Description = "imported from kubernetes node " + item.Source
+	}
+	if err := api.checkBatchNode(n); err != nil {
+		return err
+	}
+	view, err := api.createNode(c, n)
+	if err != nil {
+		return err
+	}
+	item.Node = view
+	if c.IsDryRun() {
+		return nil
+	}
+	item.InitCMD, err = api.genInitCmd(c.GetNamespace(), n.Name, req.Mode, service.TemplateBaetylInitCommand, "")
+	return err
+}
+
+func parseAndCheckNodeImport(c *common.Context) (*models.NodeImport, error) {
+	req := new(models.NodeImport)
+	if err := c.LoadBody(req); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if req.Kubeconfig == "" && (req.Server == "" || req.Token == "") {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "kubeconfig or server and token is required"))
+	}
+	if req.Mode != context.RunModeKube && req.Mode != context.RunModeNative {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("mode", req.Mode))
+	}
+	if _, err := utils.IsLabelMatch(req.Selector, map[string]string{}); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	return req, nil
+}
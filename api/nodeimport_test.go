@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func TestImportNode(t *testing.T) {
+	api, router, mockCtl := initNodeAPI(t)
+	defer mockCtl.Finish()
+
+	mLicense, sNode, sModule := ms.NewMockLicenseService(mockCtl), ms.NewMockNodeService(mockCtl), ms.NewMockModuleService(mockCtl)
+	sImport, sInit := ms.NewMockClusterImportService(mockCtl), ms.NewMockInitService(mockCtl)
+	api.License, api.Node, api.Module, api.ClusterImport, api.Init = mLicense, sNode, sModule, sImport, sInit
+	cfg := &config.CloudConfig{}
+	cfg.Plugin.Tx = "defaulttx"
+	api.Wrapper, _ = service.NewWrapperService(cfg)
+
+	req := &models.NodeImport{
+		Kubeconfig: "kubeconfig",
+		Selector:   "zone=a",
+		NamePrefix: "k3s-",
+		Template: &specV1.Node{
+			Labels: map[string]string{"line": "a"},
+			Attributes: map[string]interface{}{
+				specV1.BaetylCoreFrequency: common.DefaultCoreFrequency,
+			},
+		},
+	}
+	sImport.EXPECT().ListNodes(gomock.Any()).DoAndReturn(func(r *models.NodeImport) ([]models.ClusterNode, error) {
+		assert.Equal(t, "kubeconfig", r.Kubeconfig)
+		assert.Equal(t, "kube", r.Mode)
+		return []models.ClusterNode{
+			{Name: "master", Master: true},
+			{Name: "worker1"},
+			{Name: "worker2"},
+		}, nil
+	})
+	sModule.EXPECT().GetLatestModule(gomock.Any()).Return(&models.Module{Name: "baetyl", Version: "2.1.2"}, nil).AnyTimes()
+	sNode.EXPECT().Get(nil, "default", gomock.Any()).Return(nil, nil).Times(2)
+	mLicense.EXPECT().AcquireQuota("default", plugin.QuotaNode, 1).Return(nil).Times(2)
+	sNode.EXPECT().Create(gomock.Any(), "default", gomock.Any()).DoAndReturn(func(_ interface{}, _ string, n *specV1.Node) (*specV1.Node, error) {
+		if n.Name == "k3s-worker2" {
+			return nil, fmt.Errorf("create node error")
+		}
+		assert.Equal(t, "imported from kubernetes node worker1", n.Description)
+		return n, nil
+	}).Times(2)
+	mLicense.EXPECT().ReleaseQuota("default", plugin.QuotaNode, 1).Return(nil)
+	sInit.EXPECT().GetResource("default", "k3s-worker1", service.TemplateBaetylInitCommand, gomock.Any()).Return([]byte("curl init"), nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(req)
+	r, _ := http.NewRequest(http.MethodPost, "/v1/nodes/import", bytes.NewReader(body))
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := &models.NodeBatchResult{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, 2, res.Total)
+	assert.Equal(t, 1, res.Succeeded)
+	assert.Equal(t, 1, res.Failed)
+	assert.Equal(t, models.NodeBatchItem{Name: "k3s-worker1", Source: "worker1", Success: true, InitCMD: "curl init", Node: res.Items[0].Node}, res.Items[0])
+	assert.Equal(t, "a", res.Items[0].Node.Labels["line"])
+	// the failure is reported per node
+	assert.Equal(t, "k3s-worker2", res.Items[1].Name)
+	assert.Contains(t, res.Items[1].Error, "create node error")
+
+	for _, b := range []*models.NodeImport{
+		{},
+		{Server: "https://127.0.0.1:6443"},
+		{Kubeconfig: "kubeconfig", Mode: "unknown"},
+		{Kubeconfig: "kubeconfig", Selector: "a=b=c"},
+	} {
+		w = httptest.NewRecorder()
+		body, _ = json.Marshal(b)
+		r, _ = http.NewRequest(http.MethodPost, "/v1/nodes/import", bytes.NewReader(body))
+		router.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
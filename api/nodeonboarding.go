@@ -0,0 +1,19 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+// GetNodeOnboarding get the steps of node onboarding since its last activation, the stalled step tells where the
+// onboarding waits with the reason of failure
+func (api *API) GetNodeOnboarding(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	onboarding, err := api.Onboarding.Get(ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if onboarding == nil {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "onboarding"), common.Field("name", n), common.Field("namespace", ns))
+	}
+	return onboarding, nil
+}
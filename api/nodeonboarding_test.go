@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initNodeOnboardingAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		nodes := v1.Group("/nodes")
+		nodes.GET("/:name/onboarding", mockIM, common.Wrapper(api.GetNodeOnboarding))
+	}
+	return api, router, mockCtl
+}
+
+func TestNodeOnboardingAPI(t *testing.T) {
+	api, router, mockCtl := initNodeOnboardingAPI(t)
+	defer mockCtl.Finish()
+	sOnboarding := ms.NewMockNodeOnboardingService(mockCtl)
+	api.Onboarding = sOnboarding
+
+	sOnboarding.EXPECT().Get("default", "node01").Return(&models.NodeOnboarding{
+		Steps: []models.OnboardingStep{
+			{Name: models.OnboardingActivated, Status: models.OnboardingSucceeded},
+			{Name: models.OnboardingDownloaded, Status: models.OnboardingFailed, Reason: "failed to apply manifests"},
+		},
+		Stalled: models.OnboardingDownloaded,
+		Reason:  "failed to apply manifests",
+	}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/onboarding", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"stalled":"downloaded"`)
+
+	// the node never activated has no onboarding
+	sOnboarding.EXPECT().Get("default", "node02").Return(nil, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/node02/onboarding", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+// GetNodePreflight get the latest preflight checks of the host of node, which are reported by the install script
+// before activation
+func (api *API) GetNodePreflight(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	preflight, err := api.Preflight.Get(ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if preflight == nil {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "preflight"), common.Field("name", n), common.Field("namespace", ns))
+	}
+	return preflight, nil
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initNodePreflightAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		nodes := v1.Group("/nodes")
+		nodes.GET("/:name/preflight", mockIM, common.Wrapper(api.GetNodePreflight))
+	}
+	return api, router, mockCtl
+}
+
+func TestNodePreflightAPI(t *testing.T) {
+	api, router, mockCtl := initNodePreflightAPI(t)
+	defer mockCtl.Finish()
+	sPreflight := ms.NewMockNodePreflightService(mockCtl)
+	api.Preflight = sPreflight
+
+	sPreflight.EXPECT().Get("default", "node01").Return(&models.NodePreflight{
+		Facts:    models.HostFacts{Kernel: "5.4.0", Arch: "amd64"},
+		Passed:   true,
+		Warnings: []string{"the cgroup driver is unknown"},
+	}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/preflight", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"passed":true`)
+
+	// the host of node never checked
+	sPreflight.EXPECT().Get("default", "node02").Return(nil, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/node02/preflight", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// ReactivateNode recover the identity of node whose disk is wiped, the apps, the shadow and the labels of node are
+// kept. The certificate and the sync key of node are re-issued, the tokens of node are revoked and the hardware
+// binding is reset, the install command returned carries a one-time token activating the node again
+func (api *API) ReactivateNode(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.Node.Get(nil, ns, name); err != nil {
+		return nil, err
+	}
+	mode, template := initCmdOptions(c)
+	if mode != context.RunModeKube && mode != context.RunModeNative {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("mode", mode))
+	}
+	if err := api.revokeNodeTokens(ns, name); err != nil {
+		return nil, err
+	}
+	if err := api.reissueNodeCert(ns, name); err != nil {
+		return nil, err
+	}
+	if err := api.Fingerprint.Reset(ns, name); err != nil {
+		return nil, err
+	}
+	token, err := api.ActivationToken.Issue(&models.ActivationToken{Namespace: ns, Node: name, MaxUses: 1, Description: "reactivation"})
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := api.genInitCmd(ns, name, mode, template, token.Name)
+	if err != nil {
+		return nil, err
+	}
+	log.L().Info("node is reactivated", log.Any(c.GetTrace()), log.Any("namespace", ns), log.Any("name", name), log.Any("token", token.Name))
+	return &models.NodeReactivation{ActivationToken: token, InitCMD: models.InitCMD{CMD: cmd}}, nil
+}
+
+// revokeNodeTokens revokes the tokens issued for node, so that the install commands of the lost node are rejected
+func (api *API) revokeNodeTokens(ns, name string) error {
+	tokens, err := api.ActivationToken.List(ns)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if token.Node != name {
+			continue
+		}
+		if err = api.ActivationToken.Revoke(ns, token.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reissueNodeCert stores the certificate re-issued for node, and deletes the certificate of the lost node from PKI
+// once the new one is stored. The versions of applications mounting it are increased
+func (api *API) reissueNodeCert(ns, name string) error {
+	secret, err := api.NodeCert.Get(ns, name)
+	if err != nil {
+		return err
+	}
+	reissued, err := api.NodeCert.Reissue(secret)
+	if err != nil {
+		return err
+	}
+	if _, err = api.Facade.UpdateSecret(ns, reissued); err != nil {
+		return err
+	}
+	if certID := secret.Annotations[common.AnnotationPkiCertID]; certID != "" && certID != reissued.Annotations[common.AnnotationPkiCertID] {
+		if err = api.PKI.DeleteClientCertificate(certID); err != nil {
+			common.LogDirtyData(err,
+				log.Any("type", "pki"),
+				log.Any(common.KeyContextNamespace, ns),
+				log.Any(common.AnnotationPkiCertID, certID))
+		}
+	}
+	return nil
+}
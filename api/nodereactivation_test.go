@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mf "github.com/baetyl/baetyl-cloud/v2/mock/facade"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initNodeReactivationAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		nodes := v1.Group("/nodes")
+		nodes.POST("/:name/reactivate", mockIM, common.Wrapper(api.ReactivateNode))
+	}
+	return api, router, mockCtl
+}
+
+func TestReactivateNode(t *testing.T) {
+	api, router, mockCtl := initNodeReactivationAPI(t)
+	defer mockCtl.Finish()
+	sNode, sCert, sFacade, sPKI := ms.NewMockNodeService(mockCtl), ms.NewMockNodeCertService(mockCtl), mf.NewMockFacade(mockCtl), ms.NewMockPKIService(mockCtl)
+	sToken, sFingerprint, sInit := ms.NewMockActivationTokenService(mockCtl), ms.NewMockNodeFingerprintService(mockCtl), ms.NewMockInitService(mockCtl)
+	api.Node, api.NodeCert, api.Facade, api.PKI = sNode, sCert, sFacade, sPKI
+	api.ActivationToken, api.Fingerprint, api.Init = sToken, sFingerprint, sInit
+
+	old := genNodeCertSecret(t, "node01", time.Now().Add(time.Hour))
+	old.Annotations = map[string]string{common.AnnotationPkiCertID: "cert-old"}
+	reissued := genNodeCertSecret(t, "node01", time.Now().Add(365*24*time.Hour))
+	reissued.Annotations = map[string]string{common.AnnotationPkiCertID: "cert-new"}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01"}, nil)
+	// the tokens of the lost node are revoked
+	sToken.EXPECT().List("default").Return([]models.ActivationToken{{Name: "token01", Node: "node01"}, {Name: "token02", Node: "node02"}, {Name: "group01", NodeGroup: "group01"}}, nil)
+	sToken.EXPECT().Revoke("default", "token01").Return(nil)
+	sCert.EXPECT().Get("default", "node01").Return(old, nil)
+	sCert.EXPECT().Reissue(old).Return(reissued, nil)
+	sFacade.EXPECT().UpdateSecret("default", reissued).Return(reissued, nil)
+	sPKI.EXPECT().DeleteClientCertificate("cert-old").Return(nil)
+	sFingerprint.EXPECT().Reset("default", "node01").Return(nil)
+	sToken.EXPECT().Issue(&models.ActivationToken{Namespace: "default", Node: "node01", MaxUses: 1, Description: "reactivation"}).
+		Return(&models.ActivationToken{Namespace: "default", Name: "token03", Node: "node01", MaxUses: 1}, nil)
+	sInit.EXPECT().GetResource("default", "node01", service.TemplateBaetylInitCommand, map[string]interface{}{
+		"mode":            "native",
+		"template":        service.TemplateBaetylInitCommand,
+		"ActivationToken": "token03",
+		"InitApplyYaml":   "baetyl-init-apply.json",
+	}).Return([]byte("curl"), nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/nodes/node01/reactivate?mode=native", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.NodeReactivation
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "curl", res.CMD)
+	assert.Equal(t, "token03", res.ActivationToken.Name)
+
+	// nothing is changed for the mode not supported
+	sNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01"}, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes/node01/reactivate?mode=unknown", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	sNode.EXPECT().Get(nil, "default", "node02").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes/node02/reactivate", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	v1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/baetyl/baetyl-go/v2/utils"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// ListNodeRegistration list the registrations of devices, which are filtered by the query status
+func (api *API) ListNodeRegistration(c *common.Context) (interface{}, error) {
+	status := c.Query("status")
+	switch status {
+	case "", models.NodeRegistrationPending, models.NodeRegistrationApproved, models.NodeRegistrationRejected:
+	default:
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the status should be pending, approved or rejected"))
+	}
+	registrations, err := api.Registration.List(c.GetNamespace(), status)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(registrations), registrations, ""), nil
+}
+
+// GetNodeRegistration get the registration of device
+func (api *API) GetNodeRegistration(c *common.Context) (interface{}, error) {
+	return api.Registration.Get(c.GetNamespace(), c.GetNameFromParam())
+}
+
+// ApproveNodeRegistration approve the registration, the node is created in the group of the bootstrap credential
+// and the device gets its install command on the next registration
+func (api *API) ApproveNodeRegistration(c *common.Context) (interface{}, error) {
+	decision := new(models.NodeRegistrationDecision)
+	if err := c.LoadBody(decision); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	registration, err := api.Registration.Get(c.GetNamespace(), c.GetNameFromParam())
+	if err != nil {
+		return nil, err
+	}
+	if registration.Status == models.NodeRegistrationApproved {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the registration is already approved"))
+	}
+	registration.Reason = decision.Reason
+	return api.approveNodeRegistration(c, registration, "")
+}
+
+// RejectNodeRegistration reject the registration, the device is told the reason on the next registration
+func (api *API) RejectNodeRegistration(c *common.Context) (interface{}, error) {
+	decision := new(models.NodeRegistrationDecision)
+	if err := c.LoadBody(decision); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	registration, err := api.Registration.Get(c.GetNamespace(), c.GetNameFromParam())
+	if err != nil {
+		return nil, err
+	}
+	if registration.Status != models.NodeRegistrationPending {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the registration is not pending"))
+	}
+	registration.Status = models.NodeRegistrationRejected
+	registration.Reason = decision.Reason
+	if err = api.Registration.Update(registration); err != nil {
+		return nil, err
+	}
+	log.L().Info("node registration is rejected", log.Any("namespace", registration.Namespace), log.Any("name", registration.Name))
+	return api.Registration.Get(registration.Namespace, registration.Name)
+}
+
+// DeleteNodeRegistration delete the registration, the device registers as a new one on the next registration.
+// The node of the registration approved is kept
+func (api *API) DeleteNodeRegistration(c *common.Context) (interface{}, error) {
+	return nil, api.Registration.Delete(c.GetNamespace(), c.GetNameFromParam())
+}
+
+// ListNodeRegistrationRule list the rules approving the registrations, which are checked by name
+func (api *API) ListNodeRegistrationRule(c *common.Context) (interface{}, error) {
+	rules, err := api.Registration.ListRule(c.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(rules), rules, ""), nil
+}
+
+// GetNodeRegistrationRule get the rule approving the registrations
+func (api *API) GetNodeRegistrationRule(c *common.Context) (interface{}, error) {
+	return api.Registration.GetRule(c.GetNamespace(), c.GetNameFromParam())
+}
+
+// CreateNodeRegistrationRule create the rule approving the pending registrations matching the fingerprint pattern
+// and the hardware
+func (api *API) CreateNodeRegistrationRule(c *common.Context) (interface{}, error) {
+	rule := new(models.NodeRegistrationRule)
+	if err := c.LoadBody(rule); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if rule.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	rule.Namespace = c.GetNamespace()
+	return api.Registration.CreateRule(rule)
+}
+
+// DeleteNodeRegistrationRule delete the rule, the registrations approved by it are kept
+func (api *API) DeleteNodeRegistrationRule(c *common.Context) (interface{}, error) {
+	return nil, api.Registration.DeleteRule(c.GetNamespace(), c.GetNameFromParam())
+}
+
+// RunNodeRegistration approves the pending registrations matching the rules every interval until done is closed
+func (api *API) RunNodeRegistration(interval time.Duration, done <-chan struct{}) {
+	if interval <= 0 || api.Registration == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := api.ApprovePendingNodeRegistrations(); err != nil {
+				api.log.Warn("failed to approve node registrations", log.Error(err))
+			}
+		}
+	}
+}
+
+// ApprovePendingNodeRegistrations checks the pending registrations of all namespaces against their rules,
+// the failure of one registration does not stop the others
+func (api *API) ApprovePendingNodeRegistrations() error {
+	list, err := api.NS.List(&models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, item := range list.Items {
+		if err = api.approveNamespaceRegistrations(item.Name); err != nil {
+			api.log.Warn("failed to approve node registrations of namespace", log.Any("namespace", item.Name), log.Error(err))
+		}
+	}
+	return nil
+}
+
+func (api *API) approveNamespaceRegistrations(namespace string) error {
+	registrations, err := api.Registration.List(namespace, models.NodeRegistrationPending)
+	if err != nil {
+		return err
+	}
+	for i := range registrations {
+		rule, err := api.Registration.Match(&registrations[i])
+		if err != nil {
+			return err
+		}
+		if rule == nil {
+			continue
+		}
+		c, err := newRegistrationContext(namespace)
+		if err != nil {
+			return err
+		}
+		if _, err = api.approveNodeRegistration(c, &registrations[i], rule.Name); err != nil {
+			api.log.Warn("failed to approve node registration by rule", log.Any("namespace", namespace),
+				log.Any("name", registrations[i].Name), log.Any("rule", rule.Name), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// approveNodeRegistration creates the node of registration in its group and issues the token activating it, the
+// node is labeled by the selector of group or added to the members of group
+func (api *API) approveNodeRegistration(c *common.Context, registration *models.NodeRegistration, rule string) (interface{}, error) {
+	group, err := api.Group.Get(registration.Namespace, registration.NodeGroup)
+	if err != nil {
+		return nil, err
+	}
+	node := &v1.Node{Name: registration.Name, NodeMode: registration.Mode, Labels: map[string]string{}}
+	if err = utils.SetDefaults(node); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	for k, v := range registration.Labels {
+		node.Labels[k] = v
+	}
+	// the labels of group selector win over the ones placed, so that the node is selected by the group
+	if len(group.Nodes) == 0 && group.Selector != "" {
+		set, err := labels.ConvertSelectorToLabelsMap(group.Selector)
+		if err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the selector of node group should only consist of label equalities to label the nodes registered"))
+		}
+		for k, v := range set {
+			node.Labels[k] = v
+		}
+	}
+	if err = api.bindRegistrationEndpoints(registration, node); err != nil {
+		return nil, err
+	}
+	if err = api.checkBatchNode(node); err != nil {
+		return nil, err
+	}
+	view, err := api.createNode(c, node)
+	if err != nil {
+		return nil, err
+	}
+	if c.IsDryRun() {
+		return view, nil
+	}
+
+	if len(group.Nodes) > 0 {
+		old := group.NodeSelector()
+		group.Nodes = append(group.Nodes, registration.Name)
+		res, err := api.Group.Update(group)
+		if err != nil {
+			return nil, err
+		}
+		if res.NodeSelector() != old {
+			if err = api.reconcileNodeGroupApps(res); err != nil {
+				return nil, err
+			}
+		}
+	}
+	token, err := api.ActivationToken.Issue(&models.ActivationToken{Namespace: registration.Namespace, Node: registration.Name})
+	if err != nil {
+		return nil, err
+	}
+	registration.Status = models.NodeRegistrationApproved
+	registration.Rule = rule
+	registration.ActivationToken = token.Name
+	if err = api.Registration.Update(registration); err != nil {
+		return nil, err
+	}
+	log.L().Info("node registration is approved", log.Any("namespace", registration.Namespace),
+		log.Any("name", registration.Name), log.Any("nodeGroup", registration.NodeGroup), log.Any("rule", rule))
+	return api.Registration.Get(registration.Namespace, registration.Name)
+}
+
+// bindRegistrationEndpoints sets the endpoints of the bootstrap token on the node of registration before it's
+// created, so that its configs are rendered with them. The token revoked since the registration binds nothing
+func (api *API) bindRegistrationEndpoints(registration *models.NodeRegistration, node *v1.Node) error {
+	if registration.Token == "" {
+		return nil
+	}
+	token, err := api.ActivationToken.Get(registration.Namespace, registration.Token)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil
+		}
+		return err
+	}
+	if token.Endpoints == nil {
+		return nil
+	}
+	if node.Attributes == nil {
+		node.Attributes = map[string]interface{}{}
+	}
+	node.Attributes[common.AttributeNodeEndpoints] = token.Endpoints
+	return nil
+}
+
+// newRegistrationContext returns the context of namespace creating the nodes approved by rules
+func newRegistrationContext(namespace string) (*common.Context, error) {
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	if err != nil {
+		return nil, err
+	}
+	c := common.NewContextEmpty()
+	c.Request = req
+	c.SetNamespace(namespace)
+	return c, nil
+}
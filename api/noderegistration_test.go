@@ -0,0 +1,172 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initNodeRegistrationAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		registrations := v1.Group("/node-registrations")
+		registrations.GET("", mockIM, common.Wrapper(api.ListNodeRegistration))
+		registrations.GET("/:name", mockIM, common.Wrapper(api.GetNodeRegistration))
+		registrations.POST("/:name/approve", mockIM, common.Wrapper(api.ApproveNodeRegistration))
+		registrations.POST("/:name/reject", mockIM, common.Wrapper(api.RejectNodeRegistration))
+		registrations.DELETE("/:name", mockIM, common.Wrapper(api.DeleteNodeRegistration))
+	}
+	{
+		rules := v1.Group("/node-registration-rules")
+		rules.GET("", mockIM, common.Wrapper(api.ListNodeRegistrationRule))
+		rules.POST("", mockIM, common.Wrapper(api.CreateNodeRegistrationRule))
+		rules.DELETE("/:name", mockIM, common.Wrapper(api.DeleteNodeRegistrationRule))
+	}
+	return api, router, mockCtl
+}
+
+func TestNodeRegistrationAPI(t *testing.T) {
+	api, router, mockCtl := initNodeRegistrationAPI(t)
+	defer mockCtl.Finish()
+	sRegistration := ms.NewMockNodeRegistrationService(mockCtl)
+	api.Registration = sRegistration
+
+	pending := models.NodeRegistration{Namespace: "default", Name: "node01", Fingerprint: "sn-0001", NodeGroup: "group01", Status: models.NodeRegistrationPending}
+	sRegistration.EXPECT().List("default", models.NodeRegistrationPending).Return([]models.NodeRegistration{pending}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/node-registrations?status=pending", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Total int                       `json:"total"`
+		Items []models.NodeRegistration `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 1, list.Total)
+	assert.Equal(t, "sn-0001", list.Items[0].Fingerprint)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/node-registrations?status=unknown", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// reject the pending registration only
+	rejected := pending
+	rejected.Status = models.NodeRegistrationRejected
+	rejected.Reason = "unknown device"
+	sRegistration.EXPECT().Get("default", "node01").Return(&pending, nil)
+	sRegistration.EXPECT().Update(&rejected).Return(nil)
+	sRegistration.EXPECT().Get("default", "node01").Return(&rejected, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/node-registrations/node01/reject", bytes.NewReader([]byte(`{"reason":"unknown device"}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sRegistration.EXPECT().Get("default", "node01").Return(&rejected, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/node-registrations/node01/reject", bytes.NewReader([]byte(`{}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	approved := models.NodeRegistration{Namespace: "default", Name: "node02", Status: models.NodeRegistrationApproved}
+	sRegistration.EXPECT().Get("default", "node02").Return(&approved, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/node-registrations/node02/approve", bytes.NewReader([]byte(`{}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	sRegistration.EXPECT().Delete("default", "node01").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/node-registrations/node01", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the rules are named
+	req, _ = http.NewRequest(http.MethodPost, "/v1/node-registration-rules", bytes.NewReader([]byte(`{"fingerprint":"sn-*"}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	rule := &models.NodeRegistrationRule{Namespace: "default", Name: "rule01", Fingerprint: "sn-*", Hardware: map[string]string{"model": "box-x1"}}
+	sRegistration.EXPECT().CreateRule(rule).Return(rule, nil)
+	body, _ := json.Marshal(&models.NodeRegistrationRule{Name: "rule01", Fingerprint: "sn-*", Hardware: map[string]string{"model": "box-x1"}})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/node-registration-rules", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sRegistration.EXPECT().ListRule("default").Return([]models.NodeRegistrationRule{*rule}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/node-registration-rules", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sRegistration.EXPECT().DeleteRule("default", "rule01").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/node-registration-rules/rule01", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestApprovePendingNodeRegistrations(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sNS, sRegistration, sGroup := ms.NewMockNamespaceService(mockCtl), ms.NewMockNodeRegistrationService(mockCtl), ms.NewMockNodeGroupService(mockCtl)
+	sNode, sLicense, sModule, sToken := ms.NewMockNodeService(mockCtl), ms.NewMockLicenseService(mockCtl), ms.NewMockModuleService(mockCtl), ms.NewMockActivationTokenService(mockCtl)
+	cfg := &config.CloudConfig{}
+	cfg.Plugin.Tx = "defaulttx"
+	wrapper, _ := service.NewWrapperService(cfg)
+	api := &API{NS: sNS, Registration: sRegistration, Group: sGroup, Node: sNode, License: sLicense, Module: sModule,
+		ActivationToken: sToken, Wrapper: wrapper, AppCombinedService: &service.AppCombinedService{}, log: log.L()}
+
+	matched := models.NodeRegistration{Namespace: "default", Name: "node01", Fingerprint: "sn-1001", Mode: "kube", NodeGroup: "group01", Token: "bootstrap01",
+		Labels: map[string]string{"model": "box-x1", "env": "test"}, Status: models.NodeRegistrationPending}
+	unmatched := models.NodeRegistration{Namespace: "default", Name: "node02", Fingerprint: "sn-3001", Mode: "kube", NodeGroup: "group01", Status: models.NodeRegistrationPending}
+	sNS.EXPECT().List(&models.ListOptions{}).Return(&models.NamespaceList{Items: []models.Namespace{{Name: "default"}}}, nil)
+	sRegistration.EXPECT().List("default", models.NodeRegistrationPending).Return([]models.NodeRegistration{matched, unmatched}, nil)
+	sRegistration.EXPECT().Match(&matched).Return(&models.NodeRegistrationRule{Namespace: "default", Name: "rule01", Fingerprint: "sn-1*"}, nil)
+	sRegistration.EXPECT().Match(&unmatched).Return(nil, nil)
+
+	// the node is labeled by the placement and the selector of group, which wins
+	sGroup.EXPECT().Get("default", "group01").Return(&models.NodeGroup{Namespace: "default", Name: "group01", Selector: "env=prod"}, nil)
+	endpoints := &models.NodeEndpoints{SyncServer: "https://gateway.site:30005"}
+	sToken.EXPECT().Get("default", "bootstrap01").Return(&models.ActivationToken{Namespace: "default", Name: "bootstrap01", NodeGroup: "group01", Endpoints: endpoints}, nil)
+	sNode.EXPECT().Get(nil, "default", "node01").Return(nil, common.Error(common.ErrResourceNotFound))
+	sLicense.EXPECT().AcquireQuota("default", plugin.QuotaNode, 1).Return(nil)
+	sModule.EXPECT().GetLatestModule(gomock.Any()).Return(&models.Module{Name: "baetyl", Version: "2.1.2"}, nil)
+	sNode.EXPECT().Create(gomock.Any(), "default", gomock.Any()).DoAndReturn(func(tx interface{}, ns string, n *specV1.Node) (*specV1.Node, error) {
+		assert.Equal(t, "prod", n.Labels["env"])
+		assert.Equal(t, "box-x1", n.Labels["model"])
+		assert.Equal(t, endpoints, n.Attributes[common.AttributeNodeEndpoints])
+		n.Attributes[specV1.BaetylCoreFrequency] = common.DefaultCoreFrequency
+		return n, nil
+	})
+	sToken.EXPECT().Issue(&models.ActivationToken{Namespace: "default", Node: "node01"}).Return(&models.ActivationToken{Namespace: "default", Name: "token01", Node: "node01"}, nil)
+	sRegistration.EXPECT().Update(gomock.Any()).DoAndReturn(func(r *models.NodeRegistration) error {
+		assert.Equal(t, models.NodeRegistrationApproved, r.Status)
+		assert.Equal(t, "rule01", r.Rule)
+		assert.Equal(t, "token01", r.ActivationToken)
+		return nil
+	})
+	sRegistration.EXPECT().Get("default", "node01").Return(&matched, nil)
+	assert.NoError(t, api.ApprovePendingNodeRegistrations())
+}
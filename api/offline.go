@@ -0,0 +1,40 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// ExportNodeBundle exports the full desired state of node as the signed bundle file,
+// which is carried to the air-gapped node and applied by baetyl-init
+func (api *API) ExportNodeBundle(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	bundle, err := api.Offline.Bundle(ns, n)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s-bundle.json"`, ns, n))
+	c.Data(http.StatusOK, "application/json", data)
+	return nil, nil
+}
+
+// ImportNodeReport imports the report carried from the air-gapped node to reconcile its status,
+// the desire not yet applied by node is returned
+func (api *API) ImportNodeReport(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	var report models.OfflineReport
+	if err := c.LoadBody(&report); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	return api.Offline.ImportReport(ns, n, &report)
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNodeOfflineBundle(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sOffline := ms.NewMockOfflineService(mockCtl)
+	api := &API{Offline: sOffline, log: log.L()}
+	router := gin.Default()
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	nodes := router.Group("v1").Group("/nodes")
+	nodes.GET("/:name/bundle", mockIM, common.WrapperNative(api.ExportNodeBundle, true))
+	nodes.POST("/:name/bundle/report", mockIM, common.Wrapper(api.ImportNodeReport))
+
+	bundle := &models.OfflineBundle{Namespace: "default", Node: "node01", Signature: &models.SyncSignature{KeyID: "k1", Signature: "s1"}}
+	sOffline.EXPECT().Bundle("default", "node01").Return(bundle, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/bundle", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "default-node01-bundle.json")
+	var exported models.OfflineBundle
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &exported))
+	assert.Equal(t, "s1", exported.Signature.Signature)
+
+	sOffline.EXPECT().Bundle("default", "node02").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/node02/bundle", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	report := &models.OfflineReport{Namespace: "default", Node: "node01", Report: specV1.Report{"apps": []interface{}{}}}
+	body, err := json.Marshal(report)
+	assert.NoError(t, err)
+	sOffline.EXPECT().ImportReport("default", "node01", gomock.Any()).Return(&models.OfflineReportImport{Delta: specV1.Delta{"apps": []interface{}{}}}, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes/node01/bundle/report", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "delta")
+
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes/node01/bundle/report", bytes.NewReader([]byte("{")))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/log"
+	v1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetPlatformStats gets the stats of all namespaces cached, or the section of them designated by param section,
+// such as nodes, apps, traffic, database and certificates
+func (api *API) GetPlatformStats(c *common.Context) (interface{}, error) {
+	stats := api.PlatformStats.Get()
+	if stats == nil {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "stats"))
+	}
+	switch section := c.Param("section"); section {
+	case "":
+		return stats, nil
+	case "nodes":
+		return stats.Nodes, nil
+	case "apps":
+		return stats.Apps, nil
+	case "traffic":
+		return stats.Traffic, nil
+	case "database":
+		return stats.Database, nil
+	case "certificates":
+		return stats.Certificates, nil
+	default:
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("the section %s of stats is unknown", section)))
+	}
+}
+
+// RunPlatformStats computes the stats of all namespaces at once and then every interval until done is closed,
+// the sync traffic is summed over the window before each computation
+func (api *API) RunPlatformStats(interval, window time.Duration, done <-chan struct{}) {
+	if interval <= 0 || api.PlatformStats == nil {
+		return
+	}
+	api.PlatformStats.Set(api.ComputePlatformStats(window))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			api.PlatformStats.Set(api.ComputePlatformStats(window))
+		}
+	}
+}
+
+// ComputePlatformStats aggregates the resources of all namespaces,
+// the failure of one part is recorded in the errors of stats and does not stop the others
+func (api *API) ComputePlatformStats(window time.Duration) *models.PlatformStats {
+	now := time.Now().UTC()
+	res := &models.PlatformStats{
+		Apps:     models.AppModeStats{Modes: map[string]int{}},
+		Traffic:  models.SyncTrafficStats{Since: now.Add(-window), Items: []models.SyncTraffic{}},
+		Database: []models.TableSize{},
+		Time:     now,
+	}
+	failed := func(part string, err error) {
+		res.Errors = append(res.Errors, fmt.Sprintf("failed to compute %s: %s", part, err.Error()))
+	}
+	list, err := api.NS.List(&models.ListOptions{})
+	if err != nil {
+		failed("namespaces", err)
+	} else {
+		res.Namespaces = len(list.Items)
+		for _, item := range list.Items {
+			if err = api.countNamespaceStats(item.Name, res, now); err != nil {
+				failed("namespace "+item.Name, err)
+			}
+		}
+	}
+	if traffic, err := api.PlatformStats.CountSyncTraffic(res.Traffic.Since); err != nil {
+		failed("traffic", err)
+	} else {
+		res.Traffic.Items = traffic
+	}
+	if sizes, err := api.PlatformStats.ListTableSize(); err != nil {
+		failed("database", err)
+	} else {
+		res.Database = sizes
+	}
+	res.Elapsed = time.Since(now).Milliseconds()
+	if len(res.Errors) > 0 {
+		api.log.Warn("failed to compute part of platform stats", log.Any("errors", res.Errors))
+	}
+	return res
+}
+
+func (api *API) countNamespaceStats(namespace string, res *models.PlatformStats, now time.Time) error {
+	nodes, err := api.Node.List(namespace, &models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		res.Nodes.Total++
+		if service.IsNodeCordoned(node.Labels) {
+			res.Nodes.Cordoned++
+		}
+		if node.Report == nil {
+			res.Nodes.Inactive++
+			continue
+		}
+		// the nodes whose report frequency is unknown are offline as in the list of nodes
+		if view, err := api.ToNodeView(node); err == nil && view.Ready == v1.NodeOnline {
+			res.Nodes.Online++
+		} else {
+			res.Nodes.Offline++
+		}
+	}
+	apps, err := api.App.List(namespace, &models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, app := range apps.Items {
+		mode := app.Mode
+		if mode == "" {
+			mode = context.RunModeKube
+		}
+		res.Apps.Total++
+		res.Apps.Modes[mode]++
+		if app.System {
+			res.Apps.System++
+		}
+	}
+	secrets, err := api.NodeCert.ListExpiring(namespace, now.AddDate(100, 0, 0))
+	if err != nil {
+		return err
+	}
+	for i := range secrets {
+		cert, err := models.FromSecretToNodeCertificate(&secrets[i], secrets[i].Labels[common.LabelNodeName])
+		if err != nil {
+			continue
+		}
+		res.Certificates.Count(cert.NotAfter, now)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func TestComputePlatformStats(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sNS := ms.NewMockNamespaceService(mockCtl)
+	sNode := ms.NewMockNodeService(mockCtl)
+	sApp := ms.NewMockApplicationService(mockCtl)
+	sNodeCert := ms.NewMockNodeCertService(mockCtl)
+	sStats := ms.NewMockPlatformStatsService(mockCtl)
+	api := &API{NS: sNS, Node: sNode, NodeCert: sNodeCert, PlatformStats: sStats, log: log.L()}
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	sNS.EXPECT().List(gomock.Any()).Return(&models.NamespaceList{Items: []models.Namespace{{Name: "default"}, {Name: "tenant-a"}}}, nil)
+	sNode.EXPECT().List("default", gomock.Any()).Return(&models.NodeList{Items: []specV1.Node{
+		{Namespace: "default", Name: "node01", Labels: map[string]string{common.LabelNodeCordon: "true"}},
+		// the report frequency of node is unknown
+		{Namespace: "default", Name: "node02", Report: specV1.Report{"time": time.Now()}},
+	}}, nil)
+	sApp.EXPECT().List("default", gomock.Any()).Return(&models.ApplicationList{Items: []models.AppItem{
+		{Name: "baetyl-core-node01", Mode: "kube", System: true},
+		{Name: "web", Mode: "native"},
+		{Name: "db"},
+	}}, nil)
+	now := time.Now()
+	sNodeCert.EXPECT().ListExpiring("default", gomock.Any()).Return([]specV1.Secret{
+		*genNodeCertSecret(t, "node01", now.Add(24*time.Hour)),
+		*genNodeCertSecret(t, "node02", now.Add(365*24*time.Hour)),
+	}, nil)
+	sNode.EXPECT().List("tenant-a", gomock.Any()).Return(nil, errors.New("error"))
+	sStats.EXPECT().CountSyncTraffic(gomock.Any()).DoAndReturn(func(since time.Time) ([]models.SyncTraffic, error) {
+		assert.WithinDuration(t, now.Add(-time.Hour), since, time.Minute)
+		return []models.SyncTraffic{{Kind: "report", Total: 10}}, nil
+	})
+	sStats.EXPECT().ListTableSize().Return(nil, errors.New("not supported"))
+
+	res := api.ComputePlatformStats(time.Hour)
+	assert.Equal(t, 2, res.Namespaces)
+	assert.Equal(t, models.NodeStateStats{Total: 2, Offline: 1, Inactive: 1, Cordoned: 1}, res.Nodes)
+	assert.Equal(t, models.AppModeStats{Total: 3, System: 1, Modes: map[string]int{"kube": 2, "native": 1}}, res.Apps)
+	assert.Equal(t, models.CertExpiryStats{Total: 2, Within7d: 1, Later: 1}, res.Certificates)
+	assert.Equal(t, []models.SyncTraffic{{Kind: "report", Total: 10}}, res.Traffic.Items)
+	assert.Empty(t, res.Database)
+	assert.Len(t, res.Errors, 2)
+}
+
+func TestGetPlatformStats(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sStats := ms.NewMockPlatformStatsService(mockCtl)
+	api := &API{PlatformStats: sStats}
+	router := gin.Default()
+	router.GET("/v1/stats", common.WrapperMis(api.GetPlatformStats))
+	router.GET("/v1/stats/:section", common.WrapperMis(api.GetPlatformStats))
+
+	// not computed yet
+	sStats.EXPECT().Get().Return(nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/stats", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":1`)
+
+	stats := &models.PlatformStats{Namespaces: 2, Nodes: models.NodeStateStats{Total: 3, Online: 2}}
+	sStats.EXPECT().Get().Return(stats).Times(3)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/stats", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"namespaces":2`)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/stats/nodes", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"data":{"total":3,"online":2`)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/stats/unknown", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":1`)
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetQuarantine get the quarantine
+func (api *API) GetQuarantine(c *common.Context) (interface{}, error) {
+	return api.Quarantine.Get(c.GetNameFromParam())
+}
+
+// ListQuarantine list the resources quarantined
+func (api *API) ListQuarantine(c *common.Context) (interface{}, error) {
+	quarantines, err := api.Quarantine.List()
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(quarantines), quarantines, ""), nil
+}
+
+// CreateQuarantine quarantines the app or image platform-wide, the apps affected are paused and their owners are
+// notified, the deployments referencing it are rejected with ErrAppQuarantined until it's lifted
+func (api *API) CreateQuarantine(c *common.Context) (interface{}, error) {
+	quarantine := new(models.Quarantine)
+	if err := c.LoadBody(quarantine); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if quarantine.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	switch quarantine.Type {
+	case models.QuarantineApp:
+		if quarantine.Namespace == "" || quarantine.App == "" {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "namespace and app are required to quarantine app"))
+		}
+		quarantine.Image = ""
+	case models.QuarantineImage:
+		if quarantine.Image == "" {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "image is required to quarantine image"))
+		}
+		quarantine.Namespace, quarantine.App = "", ""
+	}
+	if _, err := api.Quarantine.Get(quarantine.Name); err == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "this name is already in use"))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, err
+	}
+	quarantine.Operator = c.GetUser().ID
+	res, err := api.Quarantine.Create(quarantine)
+	if err != nil {
+		return nil, err
+	}
+	return api.enforceQuarantine(res), nil
+}
+
+// DeleteQuarantine lifts the quarantine, the apps paused by it stay paused until their owners resume them
+func (api *API) DeleteQuarantine(c *common.Context) (interface{}, error) {
+	name := c.GetNameFromParam()
+	if _, err := api.Quarantine.Get(name); err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return nil, api.Quarantine.Delete(name)
+}
+
+// enforceQuarantine pauses the apps affected by the quarantine, the failures of apps don't abort the others
+func (api *API) enforceQuarantine(quarantine *models.Quarantine) *models.QuarantineResult {
+	res := &models.QuarantineResult{Quarantine: quarantine, Paused: []string{}}
+	if quarantine.Type == models.QuarantineApp {
+		app, err := api.App.Get(quarantine.Namespace, quarantine.App, "")
+		if err != nil {
+			res.Fail(quarantine.Namespace+"/"+quarantine.App, err.Error())
+		} else {
+			api.pauseQuarantinedApp(quarantine, quarantine.Namespace, app, nil, res)
+		}
+		return res
+	}
+	list, err := api.NS.List(&models.ListOptions{})
+	if err != nil {
+		res.Fail("namespaces", err.Error())
+		return res
+	}
+	for _, ns := range list.Items {
+		apps, err := api.App.List(ns.Name, &models.ListOptions{})
+		if err != nil {
+			res.Fail(ns.Name, err.Error())
+			continue
+		}
+		for _, item := range apps.Items {
+			// the list of apps carries no services, the images are read from the apps themselves
+			app, err := api.App.Get(ns.Name, item.Name, "")
+			if err != nil {
+				res.Fail(ns.Name+"/"+item.Name, err.Error())
+				continue
+			}
+			if images, ok := service.QuarantineMatches(quarantine, ns.Name, app); ok {
+				api.pauseQuarantinedApp(quarantine, ns.Name, app, images, res)
+			}
+		}
+	}
+	if len(res.Failures) > 0 {
+		api.log.Warn("failed to pause part of apps quarantined", log.Any("quarantine", quarantine.Name), log.Any("failures", res.Failures))
+	}
+	return res
+}
+
+func (api *API) pauseQuarantinedApp(quarantine *models.Quarantine, ns string, app *specV1.Application, images []string, res *models.QuarantineResult) {
+	key := ns + "/" + app.Name
+	if CheckIsSysResources(app.Labels) {
+		res.Fail(key, "the reconciliation of system application can not be paused")
+		return
+	}
+	if _, err := api.updateAppPaused(ns, app, true); err != nil {
+		res.Fail(key, err.Error())
+		return
+	}
+	res.Paused = append(res.Paused, key)
+	err := api.Quarantine.Notify(&models.QuarantineEvent{
+		Namespace:  ns,
+		App:        app.Name,
+		Quarantine: quarantine.Name,
+		Type:       models.QuarantineEventPaused,
+		Reason:     quarantine.Reason,
+		Images:     images,
+		Time:       time.Now().UTC(),
+	})
+	if err != nil {
+		// the app is paused already, the owner learns it from the console if the webhook fails
+		api.log.Warn("failed to notify the quarantine of app", log.Any(common.KeyContextNamespace, ns), log.Any("app", app.Name), log.Error(err))
+	}
+}
+
+// checkAppQuarantine rejects the deployment of app referencing any resource quarantined
+func (api *API) checkAppQuarantine(ns string, app *specV1.Application) error {
+	if api.Quarantine == nil {
+		return nil
+	}
+	return api.Quarantine.Check(ns, app)
+}
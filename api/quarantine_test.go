@@ -0,0 +1,165 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initQuarantineAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockOperator := func(c *gin.Context) { common.NewContext(c).SetUser(common.User{ID: "ops"}) }
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		quarantines := v1.Group("/quarantines")
+		quarantines.GET("", common.Wrapper(api.ListQuarantine))
+		quarantines.GET("/:name", common.Wrapper(api.GetQuarantine))
+		quarantines.POST("", mockOperator, common.Wrapper(api.CreateQuarantine))
+		quarantines.DELETE("/:name", common.Wrapper(api.DeleteQuarantine))
+	}
+	{
+		apps := v1.Group("/apps")
+		apps.POST("/:name/resume", mockIM, common.Wrapper(api.ResumeApplication))
+	}
+	return api, router, mockCtl
+}
+
+func TestCreateQuarantineImage(t *testing.T) {
+	api, router, mockCtl := initQuarantineAPI(t)
+	defer mockCtl.Finish()
+	sQuarantine, sNS := ms.NewMockQuarantineService(mockCtl), ms.NewMockNamespaceService(mockCtl)
+	sApp, sNode := ms.NewMockApplicationService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.Quarantine, api.NS, api.Node = sQuarantine, sNS, sNode
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	quarantine := &models.Quarantine{Name: "cve", Type: models.QuarantineImage, Image: "nginx:1.20", Reason: "CVE-2021-23017", Operator: "ops"}
+	sQuarantine.EXPECT().Get("cve").Return(nil, common.Error(common.ErrResourceNotFound))
+	sQuarantine.EXPECT().Create(gomock.Any()).DoAndReturn(func(q *models.Quarantine) (*models.Quarantine, error) {
+		assert.Equal(t, quarantine, q)
+		return q, nil
+	})
+	sNS.EXPECT().List(gomock.Any()).Return(&models.NamespaceList{Items: []models.Namespace{{Name: "default"}, {Name: "tenant-a"}}}, nil)
+	sApp.EXPECT().List("default", gomock.Any()).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "web"}, {Name: "api"}}}, nil)
+	sApp.EXPECT().List("tenant-a", gomock.Any()).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "proxy"}}}, nil)
+	sApp.EXPECT().Get("default", "web", "").Return(&specV1.Application{Name: "web", Services: []specV1.Service{{Name: "nginx", Image: "nginx:1.20"}}}, nil)
+	sApp.EXPECT().Get("default", "api", "").Return(&specV1.Application{Name: "api", Services: []specV1.Service{{Name: "api", Image: "api:v1"}}}, nil)
+	sApp.EXPECT().Get("tenant-a", "proxy", "").Return(&specV1.Application{Name: "proxy", Services: []specV1.Service{{Name: "proxy", Image: "docker.io/library/nginx:1.20"}}}, nil)
+	sApp.EXPECT().Update(nil, gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, _ string, app *specV1.Application) (*specV1.Application, error) {
+		assert.Equal(t, "true", app.Labels[common.LabelAppPaused])
+		return app, nil
+	}).Times(2)
+	sNode.EXPECT().UpdateNodeAppVersion(nil, "default", gomock.Any()).Return(nil, nil)
+	sNode.EXPECT().UpdateNodeAppVersion(nil, "tenant-a", gomock.Any()).Return(nil, common.Error(common.ErrRequestTimeout))
+	sQuarantine.EXPECT().Notify(gomock.Any()).DoAndReturn(func(event *models.QuarantineEvent) error {
+		assert.Equal(t, "default", event.Namespace)
+		assert.Equal(t, "web", event.App)
+		assert.Equal(t, []string{"nginx:1.20"}, event.Images)
+		assert.Equal(t, models.QuarantineEventPaused, event.Type)
+		return nil
+	})
+
+	body, _ := json.Marshal(quarantine)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/quarantines", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.QuarantineResult)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, []string{"default/web"}, res.Paused)
+	assert.Contains(t, res.Failures, "tenant-a/proxy")
+
+	for _, q := range []*models.Quarantine{
+		{Name: "cve", Type: models.QuarantineImage, Reason: "cve"},
+		{Name: "cve", Type: models.QuarantineApp, App: "web", Reason: "cve"},
+		{Name: "cve", Type: "node", Image: "nginx", Reason: "cve"},
+		{Name: "cve", Type: models.QuarantineImage, Image: "nginx"},
+		{Type: models.QuarantineImage, Image: "nginx", Reason: "cve"},
+	} {
+		body, _ = json.Marshal(q)
+		req, _ = http.NewRequest(http.MethodPost, "/v1/quarantines", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCreateQuarantineApp(t *testing.T) {
+	api, router, mockCtl := initQuarantineAPI(t)
+	defer mockCtl.Finish()
+	sQuarantine, sApp, sNode := ms.NewMockQuarantineService(mockCtl), ms.NewMockApplicationService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.Quarantine, api.Node = sQuarantine, sNode
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	quarantine := &models.Quarantine{Name: "leaky", Type: models.QuarantineApp, Namespace: "default", App: "web", Reason: "leaks the tokens"}
+	sQuarantine.EXPECT().Get("leaky").Return(nil, common.Error(common.ErrResourceNotFound))
+	sQuarantine.EXPECT().Create(gomock.Any()).DoAndReturn(func(q *models.Quarantine) (*models.Quarantine, error) {
+		return q, nil
+	})
+	// the app paused already is not updated again
+	paused := &specV1.Application{Name: "web", Labels: service.SetAppPaused(nil, true)}
+	sApp.EXPECT().Get("default", "web", "").Return(paused, nil)
+	sQuarantine.EXPECT().Notify(gomock.Any()).Return(common.Error(common.ErrRequestTimeout))
+
+	body, _ := json.Marshal(quarantine)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/quarantines", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.QuarantineResult)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, []string{"default/web"}, res.Paused)
+	assert.Empty(t, res.Failures)
+
+	// the app quarantined can not be resumed
+	sApp.EXPECT().Get("default", "web", "").Return(paused, nil)
+	sQuarantine.EXPECT().Check("default", paused).Return(common.Error(common.ErrAppQuarantined, common.Field("name", "web")))
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/web/resume", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), common.ErrAppQuarantined)
+}
+
+func TestDeleteQuarantine(t *testing.T) {
+	api, router, mockCtl := initQuarantineAPI(t)
+	defer mockCtl.Finish()
+	sQuarantine := ms.NewMockQuarantineService(mockCtl)
+	api.Quarantine = sQuarantine
+
+	sQuarantine.EXPECT().List().Return([]models.Quarantine{{Name: "cve", Type: models.QuarantineImage, Image: "nginx:1.20"}}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/quarantines", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "nginx:1.20")
+
+	// the quarantines lifted already are ignored
+	sQuarantine.EXPECT().Get("cve").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/quarantines/cve", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sQuarantine.EXPECT().Get("cve").Return(&models.Quarantine{Name: "cve"}, nil)
+	sQuarantine.EXPECT().Delete("cve").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/quarantines/cve", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
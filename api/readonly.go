@@ -0,0 +1,28 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetReadOnly gets the read-only mode of platform
+func (api *API) GetReadOnly(c *common.Context) (interface{}, error) {
+	return api.ReadOnly.Get()
+}
+
+// EnableReadOnly puts the platform into read-only, the tenant-facing writes are refused with 503 until it's disabled.
+// The nodes keep syncing and reporting
+func (api *API) EnableReadOnly(c *common.Context) (interface{}, error) {
+	mode := new(models.ReadOnly)
+	if c.Request.ContentLength != 0 {
+		if err := c.LoadBody(mode); err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+		}
+	}
+	return api.ReadOnly.Set(&models.ReadOnly{Enabled: true, Reason: mode.Reason, Operator: c.GetUser().ID})
+}
+
+// DisableReadOnly makes the platform writable again
+func (api *API) DisableReadOnly(c *common.Context) (interface{}, error) {
+	return api.ReadOnly.Set(&models.ReadOnly{Operator: c.GetUser().ID})
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initReadOnlyAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockOperator := func(c *gin.Context) { common.NewContext(c).SetUser(common.User{ID: "ops"}) }
+	v1 := router.Group("v1")
+	{
+		readOnly := v1.Group("/readonly", mockOperator)
+		readOnly.GET("", common.WrapperMis(api.GetReadOnly))
+		readOnly.POST("/enable", common.WrapperMis(api.EnableReadOnly))
+		readOnly.POST("/disable", common.WrapperMis(api.DisableReadOnly))
+	}
+	return api, router, mockCtl
+}
+
+func TestReadOnly(t *testing.T) {
+	api, router, mockCtl := initReadOnlyAPI(t)
+	defer mockCtl.Finish()
+	sReadOnly := ms.NewMockReadOnlyService(mockCtl)
+	api.ReadOnly = sReadOnly
+
+	sReadOnly.EXPECT().Get().Return(&models.ReadOnly{}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/readonly", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"enabled":false`)
+
+	enabled := &models.ReadOnly{Enabled: true, Reason: "database migration", Operator: "ops"}
+	sReadOnly.EXPECT().Set(enabled).Return(enabled, nil)
+	body, _ := json.Marshal(&models.ReadOnly{Reason: "database migration"})
+	req, _ = http.NewRequest(http.MethodPost, "/v1/readonly/enable", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the reason is optional
+	sReadOnly.EXPECT().Set(&models.ReadOnly{Enabled: true, Operator: "ops"}).Return(enabled, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/readonly/enable", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/v1/readonly/enable", bytes.NewReader([]byte("{")))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":1`)
+
+	sReadOnly.EXPECT().Set(&models.ReadOnly{Operator: "ops"}).Return(&models.ReadOnly{Operator: "ops"}, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/readonly/disable", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetConfigReferences list the apps mounting the config
+func (api *API) GetConfigReferences(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	res, err := api.Config.Get(ns, n, "")
+	if err != nil {
+		return nil, err
+	}
+	appNames, err := api.Index.ListAppIndexByConfig(ns, res.Name)
+	if err != nil {
+		return nil, err
+	}
+	return api.listResourceReferences(ns, "config", res.Name, appNames)
+}
+
+// GetSecretReferences list the apps mounting the secret
+func (api *API) GetSecretReferences(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	secret, err := api.Secret.Get(ns, n, "")
+	if err != nil {
+		return nil, err
+	}
+	appNames, err := api.Index.ListAppIndexBySecret(ns, secret.Name)
+	if err != nil {
+		return nil, err
+	}
+	return api.listResourceReferences(ns, "secret", secret.Name, appNames)
+}
+
+// GetRegistryReferences list the apps pulling images with the registry
+func (api *API) GetRegistryReferences(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	secret, err := api.Secret.Get(ns, n, "")
+	if err != nil {
+		return nil, wrapSecretLikedResourceNotFoundError(n, common.Registry, err)
+	}
+	appNames, err := api.Index.ListAppIndexBySecret(ns, secret.Name)
+	if err != nil {
+		return nil, err
+	}
+	return api.listResourceReferences(ns, "registry", secret.Name, appNames)
+}
+
+// listResourceReferences returns the apps indexed by the resource with the volumes referencing it,
+// the apps deleted already are ignored
+func (api *API) listResourceReferences(namespace, resourceType, name string, appNames []string) (*models.ResourceReferences, error) {
+	res := &models.ResourceReferences{Type: resourceType, Name: name, Items: []models.ResourceReference{}}
+	for _, appName := range appNames {
+		app, err := api.App.Get(namespace, appName, "")
+		if err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				continue
+			}
+			return nil, err
+		}
+		ref := models.ResourceReference{App: app.Name, Version: app.Version, System: app.System}
+		for _, v := range app.Volumes {
+			if resourceType == "config" && v.Config != nil && v.Config.Name == name ||
+				resourceType != "config" && v.Secret != nil && v.Secret.Name == name {
+				ref.Volumes = append(ref.Volumes, v.Name)
+			}
+		}
+		res.Items = append(res.Items, ref)
+	}
+	res.Total = len(res.Items)
+	return res, nil
+}
+
+// checkResourceReferences returns the conflict listing the apps if the resource is still referenced
+func checkResourceReferences(resourceType, name string, appNames []string) error {
+	if len(appNames) == 0 {
+		return nil
+	}
+	return common.Error(common.ErrResourceReferenced, common.Field("type", resourceType),
+		common.Field("name", name), common.Field("apps", strings.Join(appNames, ",")))
+}
+
+func parseForceDelete(c *common.Context) (bool, error) {
+	force := c.Query("force")
+	if force == "" {
+		return false, nil
+	}
+	res, err := strconv.ParseBool(force)
+	if err != nil {
+		return false, common.Error(common.ErrRequestParamInvalid, common.Field("error", "force should be a boolean"))
+	}
+	return res, nil
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initReferenceAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		v1.GET("/configs/:name/references", mockIM, common.Wrapper(api.GetConfigReferences))
+		v1.GET("/secrets/:name/references", mockIM, common.Wrapper(api.GetSecretReferences))
+		v1.GET("/registries/:name/references", mockIM, common.Wrapper(api.GetRegistryReferences))
+	}
+	return api, router, mockCtl
+}
+
+func TestGetConfigReferences(t *testing.T) {
+	api, router, mockCtl := initReferenceAPI(t)
+	defer mockCtl.Finish()
+	sApp, sConfig, sIndex := ms.NewMockApplicationService(mockCtl), ms.NewMockConfigService(mockCtl), ms.NewMockIndexService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{App: sApp, Config: sConfig}
+	api.Index = sIndex
+
+	sConfig.EXPECT().Get("default", "cfg", "").Return(&specV1.Configuration{Namespace: "default", Name: "cfg"}, nil)
+	sIndex.EXPECT().ListAppIndexByConfig("default", "cfg").Return([]string{"app1", "app2", "deleted"}, nil)
+	sApp.EXPECT().Get("default", "app1", "").Return(&specV1.Application{
+		Name:    "app1",
+		Version: "3",
+		Volumes: []specV1.Volume{
+			{Name: "conf", VolumeSource: specV1.VolumeSource{Config: &specV1.ObjectReference{Name: "cfg"}}},
+			{Name: "other", VolumeSource: specV1.VolumeSource{Config: &specV1.ObjectReference{Name: "other"}}},
+			{Name: "secret", VolumeSource: specV1.VolumeSource{Secret: &specV1.ObjectReference{Name: "cfg"}}},
+		},
+	}, nil)
+	sApp.EXPECT().Get("default", "app2", "").Return(&specV1.Application{
+		Name:    "app2",
+		System:  true,
+		Volumes: []specV1.Volume{{Name: "conf", VolumeSource: specV1.VolumeSource{Config: &specV1.ObjectReference{Name: "cfg"}}}},
+	}, nil)
+	sApp.EXPECT().Get("default", "deleted", "").Return(nil, common.Error(common.ErrResourceNotFound))
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/configs/cfg/references", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.ResourceReferences)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, &models.ResourceReferences{
+		Type:  "config",
+		Name:  "cfg",
+		Total: 2,
+		Items: []models.ResourceReference{
+			{App: "app1", Version: "3", Volumes: []string{"conf"}},
+			{App: "app2", System: true, Volumes: []string{"conf"}},
+		},
+	}, res)
+
+	sConfig.EXPECT().Get("default", "none", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/configs/none/references", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetSecretReferences(t *testing.T) {
+	api, router, mockCtl := initReferenceAPI(t)
+	defer mockCtl.Finish()
+	sApp, sSecret, sIndex := ms.NewMockApplicationService(mockCtl), ms.NewMockSecretService(mockCtl), ms.NewMockIndexService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{App: sApp, Secret: sSecret}
+	api.Index = sIndex
+
+	registry := &specV1.Secret{Namespace: "default", Name: "harbor", Labels: map[string]string{specV1.SecretLabel: specV1.SecretRegistry}}
+	sSecret.EXPECT().Get("default", "harbor", "").Return(registry, nil).Times(2)
+	sIndex.EXPECT().ListAppIndexBySecret("default", "harbor").Return([]string{"app1"}, nil).Times(2)
+	sApp.EXPECT().Get("default", "app1", "").Return(&specV1.Application{
+		Name:    "app1",
+		Volumes: []specV1.Volume{{Name: "harbor", VolumeSource: specV1.VolumeSource{Secret: &specV1.ObjectReference{Name: "harbor"}}}},
+	}, nil).Times(2)
+
+	for _, path := range []string{"/v1/secrets/harbor/references", "/v1/registries/harbor/references"} {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		res := new(models.ResourceReferences)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+		assert.Equal(t, 1, res.Total)
+		assert.Equal(t, []string{"harbor"}, res.Items[0].Volumes)
+	}
+
+	sSecret.EXPECT().Get("default", "none", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ := http.NewRequest(http.MethodGet, "/v1/registries/none/references", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
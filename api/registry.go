@@ -2,6 +2,8 @@ package api
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -33,12 +35,23 @@ func (api *API) ListRegistry(c *common.Context) (interface{}, error) {
 		return nil, err
 	}
 	params.LabelSelector += "," + fmt.Sprintf("%s=%s", specV1.SecretLabel, specV1.SecretRegistry)
+	// the container registries have no type label, including the ones created before types are introduced
+	switch t := c.Query("type"); t {
+	case "":
+	case models.RegistryTypeContainer:
+		params.LabelSelector += "," + fmt.Sprintf("%s notin (%s,%s)", common.LabelRegistryType, models.RegistryTypeOCI, models.RegistryTypeHelm)
+	case models.RegistryTypeOCI, models.RegistryTypeHelm:
+		params.LabelSelector += "," + fmt.Sprintf("%s=%s", common.LabelRegistryType, t)
+	default:
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the type of registry should be one of container, oci and helm"))
+	}
 	secrets, err := api.Secret.List(ns, params)
 	if err != nil {
 		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
 	}
 
-	return api.ToFilteredRegistryViewList(secrets), nil
+	res := api.ToFilteredRegistryViewList(secrets)
+	return api.ToListResponse(res.Total, res.Items, res.ListOptions), nil
 }
 
 // CreateRegistry create one Registry
@@ -63,11 +76,19 @@ func (api *API) CreateRegistry(c *common.Context) (interface{}, error) {
 	if err = api.ValidateRegistryModel(cfg); err != nil {
 		return nil, err
 	}
+	verify, err := parseRegistryVerify(c)
+	if err != nil {
+		return nil, err
+	}
 	secret, err := api.Facade.CreateSecret(ns, cfg.ToSecret())
 	if err != nil {
 		return nil, err
 	}
-	return hidePwd(api.ToFilteredRegistryView(secret)), nil
+	res := hidePwd(api.ToFilteredRegistryView(secret))
+	if verify && res != nil {
+		res.Verification = api.Registry.Verify(cfg, "")
+	}
+	return res, nil
 }
 
 // UpdateRegistry update the Registry
@@ -111,24 +132,52 @@ func (api *API) RefreshRegistryPassword(c *common.Context) (interface{}, error)
 		return nil, wrapSecretLikedResourceNotFoundError(n, common.Registry, err)
 	}
 
+	// the token of oci registry is refreshed in the same way as the password
 	sd := api.ToRegistryView(secret)
 	sd.UpdateTimestamp = time.Now()
-	sd.Password = cfg.Password
+	sd.Password, sd.Token = cfg.Password, cfg.Token
 	if err = api.ValidateRegistryModel(sd); err != nil {
 		return nil, err
 	}
+	verify, err := parseRegistryVerify(c)
+	if err != nil {
+		return nil, err
+	}
 
 	secret, err = api.Facade.UpdateSecret(ns, sd.ToSecret())
 	if err != nil {
 		return nil, err
 	}
-	return hidePwd(api.ToRegistryView(secret)), nil
+	res := hidePwd(api.ToRegistryView(secret))
+	if verify {
+		res.Verification = api.Registry.Verify(sd, "")
+	}
+	return res, nil
+}
+
+// VerifyRegistry log in to the registry with the stored credential, the manifest of the image in query is checked too
+// if set. The result is returned even if the registry is not reachable, such as from a private network
+func (api *API) VerifyRegistry(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	secret, err := api.Secret.Get(ns, n, "")
+	if err != nil {
+		return nil, wrapSecretLikedResourceNotFoundError(n, common.Registry, err)
+	}
+	registry := api.ToFilteredRegistryView(secret)
+	if registry == nil {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", common.Registry), common.Field("name", n))
+	}
+	return api.Registry.Verify(registry, strings.TrimSpace(c.Query("image"))), nil
 }
 
-// DeleteRegistry delete the Registry
+// DeleteRegistry delete the Registry, which is refused if the apps still reference it unless force=true
 func (api *API) DeleteRegistry(c *common.Context) (interface{}, error) {
 	ns, n := c.GetNamespace(), c.GetNameFromParam()
-	return api.DeleteSecretResource(ns, n, "registry")
+	force, err := parseForceDelete(c)
+	if err != nil {
+		return nil, err
+	}
+	return api.deleteSecretResource(ns, n, "registry", force)
 }
 
 // GetAppByRegistry list app
@@ -159,9 +208,23 @@ func (api *API) parseAndCheckRegistryModel(c *common.Context) (*models.Registry,
 	return registry, err
 }
 
+// parseRegistryVerify returns whether the credential is verified after the registry is saved
+func parseRegistryVerify(c *common.Context) (bool, error) {
+	verify := c.Query("verify")
+	if verify == "" {
+		return false, nil
+	}
+	res, err := strconv.ParseBool(verify)
+	if err != nil {
+		return false, common.Error(common.ErrRequestParamInvalid, common.Field("error", "verify should be a boolean"))
+	}
+	return res, nil
+}
+
 func hidePwd(r *models.Registry) *models.Registry {
 	if r != nil {
 		r.Password = ""
+		r.Token = ""
 	}
 	return r
 }
@@ -190,10 +253,41 @@ func (api *API) ToRegistryViewList(s *models.SecretList) *models.RegistryList {
 	return res
 }
 
-// validateRegistryModel validate the registry model
+// ValidateRegistryModel validates the address and credential of registry by its type, the registry without type
+// is a container registry
 func (api *API) ValidateRegistryModel(r *models.Registry) error {
-	if r.Address == "" || r.Username == "" || r.Password == "" {
-		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "address/username/password is required"))
+	if r.Type == "" {
+		r.Type = models.RegistryTypeContainer
+	}
+	switch r.Type {
+	case models.RegistryTypeContainer:
+		if r.Address == "" || r.Username == "" || r.Password == "" {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "address/username/password is required"))
+		}
+		if r.Token != "" {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the token is only supported by oci registry"))
+		}
+	case models.RegistryTypeOCI:
+		if r.Address == "" {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "address is required"))
+		}
+		basic := r.Username != "" && r.Password != ""
+		if basic == (r.Token != "") || (!basic && (r.Username != "" || r.Password != "")) {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the credential of oci registry should be either username/password or token"))
+		}
+	case models.RegistryTypeHelm:
+		u, err := url.Parse(r.Address)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the address of helm repository should be a http or https url"))
+		}
+		if (r.Username == "") != (r.Password == "") {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the username and password of helm repository should be set together"))
+		}
+		if r.Token != "" {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the token is only supported by oci registry"))
+		}
+	default:
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the type of registry should be one of container, oci and helm"))
 	}
 	return nil
 }
@@ -35,6 +35,7 @@ func initRegistryAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
 		configs.GET("/:name/apps", mockIM, common.Wrapper(api.GetAppByRegistry))
 		configs.PUT("/:name", mockIM, common.Wrapper(api.UpdateRegistry))
 		configs.POST(":name/refresh", mockIM, common.Wrapper(api.RefreshRegistryPassword))
+		configs.POST("/:name/verify", mockIM, common.Wrapper(api.VerifyRegistry))
 		configs.DELETE("/:name", mockIM, common.Wrapper(api.DeleteRegistry))
 		configs.POST("", mockIM, common.Wrapper(api.CreateRegistry))
 		configs.GET("", mockIM, common.Wrapper(api.ListRegistry))
@@ -157,6 +158,99 @@ func TestCreateRegistry(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w2.Code)
 }
 
+func TestCreateRegistryTypes(t *testing.T) {
+	api, router, mockCtl := initRegistryAPI(t)
+	defer mockCtl.Finish()
+
+	sSecret := ms.NewMockSecretService(mockCtl)
+	fSecret := mf.NewMockFacade(mockCtl)
+	api.Facade = fSecret
+	api.AppCombinedService = &service.AppCombinedService{
+		Secret: sSecret,
+	}
+
+	sSecret.EXPECT().Get("default", gomock.Any(), "").Return(nil, common.Error(common.ErrResourceNotFound)).AnyTimes()
+	var created *specV1.Secret
+	fSecret.EXPECT().CreateSecret("default", gomock.Any()).DoAndReturn(func(_ string, secret *specV1.Secret) (*specV1.Secret, error) {
+		created = secret
+		return secret, nil
+	}).Times(3)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/v1/registries", bytes.NewReader([]byte(body)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"name":"models","type":"oci","address":"ghcr.io/baetyl","token":"ghp_token"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, models.RegistryTypeOCI, created.Labels[common.LabelRegistryType])
+	assert.Equal(t, "ghp_token", string(created.Data["token"]))
+	res := new(models.Registry)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, models.RegistryTypeOCI, res.Type)
+	assert.Empty(t, res.Token)
+
+	w = post(`{"name":"charts","type":"helm","address":"https://charts.bitnami.com/bitnami"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, models.RegistryTypeHelm, created.Labels[common.LabelRegistryType])
+	assert.Equal(t, models.RegistryTypeHelm, models.FromSecretToRegistry(created, true).Type)
+
+	// the container registries are stored as before
+	w = post(`{"name":"images","address":"harbor.local","username":"baetyl","password":"secret"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, created.Labels, common.LabelRegistryType)
+	assert.NotContains(t, created.Data, "type")
+	assert.Equal(t, models.RegistryTypeContainer, models.FromSecretToRegistry(created, true).Type)
+
+	bodies := []string{
+		`{"name":"a","type":"maven","address":"repo.local","username":"u","password":"p"}`,
+		`{"name":"a","address":"harbor.local","token":"t"}`,
+		`{"name":"a","address":"harbor.local","username":"u","password":"p","token":"t"}`,
+		`{"name":"a","type":"oci","address":"ghcr.io"}`,
+		`{"name":"a","type":"oci","address":"ghcr.io","username":"u","token":"t"}`,
+		`{"name":"a","type":"oci","address":"ghcr.io","username":"u","password":"p","token":"t"}`,
+		`{"name":"a","type":"helm","address":"charts.local"}`,
+		`{"name":"a","type":"helm","address":"https://charts.local","username":"u"}`,
+		`{"name":"a","type":"helm","address":"https://charts.local","token":"t"}`,
+	}
+	for _, b := range bodies {
+		assert.Equal(t, http.StatusBadRequest, post(b).Code, b)
+	}
+}
+
+func TestListRegistryByType(t *testing.T) {
+	api, router, mockCtl := initRegistryAPI(t)
+	defer mockCtl.Finish()
+
+	sSecret := ms.NewMockSecretService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{
+		Secret: sSecret,
+	}
+
+	cases := map[string]string{
+		"helm":      common.LabelRegistryType + "=helm",
+		"container": common.LabelRegistryType + " notin (oci,helm)",
+	}
+	for typ, selector := range cases {
+		sSecret.EXPECT().List("default", gomock.Any()).DoAndReturn(func(_ string, params *models.ListOptions) (*models.SecretList, error) {
+			assert.Contains(t, params.LabelSelector, specV1.SecretLabel+"="+specV1.SecretRegistry)
+			assert.Contains(t, params.LabelSelector, selector)
+			return &models.SecretList{}, nil
+		})
+		req, _ := http.NewRequest(http.MethodGet, "/v1/registries?type="+typ, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/registries?type=maven", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestUpdateRegistry(t *testing.T) {
 	api, router, mockCtl := initRegistryAPI(t)
 	defer mockCtl.Finish()
@@ -362,3 +456,89 @@ func TestGetAppByRegistry(t *testing.T) {
 	router.ServeHTTP(w4, req4)
 	assert.Equal(t, http.StatusOK, w4.Code)
 }
+
+func TestVerifyRegistry(t *testing.T) {
+	api, router, mockCtl := initRegistryAPI(t)
+	defer mockCtl.Finish()
+
+	sSecret := ms.NewMockSecretService(mockCtl)
+	sRegistry := ms.NewMockRegistryService(mockCtl)
+	fSecret := mf.NewMockFacade(mockCtl)
+	api.Facade, api.Registry = fSecret, sRegistry
+	api.AppCombinedService = &service.AppCombinedService{
+		Secret: sSecret,
+	}
+
+	mConf := &models.Registry{
+		Namespace: "default",
+		Name:      "abc",
+		Username:  "username",
+		Password:  "password",
+		Address:   "harbor.local",
+	}
+	mSecret := &specV1.Secret{
+		Namespace: "default",
+		Name:      "abc",
+		Labels: map[string]string{
+			specV1.SecretLabel: specV1.SecretRegistry,
+		},
+		Data: map[string][]byte{
+			"address":  []byte("harbor.local"),
+			"password": []byte("password"),
+			"username": []byte("username"),
+		},
+	}
+
+	// the credential is verified after the registry is created if requested
+	sSecret.EXPECT().Get("default", "abc", "").Return(nil, nil)
+	fSecret.EXPECT().CreateSecret("default", gomock.Any()).Return(mSecret, nil)
+	sRegistry.EXPECT().Verify(gomock.Any(), "").DoAndReturn(func(r *models.Registry, _ string) *models.RegistryVerification {
+		assert.Equal(t, "password", r.Password)
+		return &models.RegistryVerification{Reachable: true, Status: http.StatusUnauthorized, Message: "failed to log in to registry"}
+	})
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(mConf)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/registries?verify=true", bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.Registry)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Empty(t, res.Password)
+	assert.True(t, res.Verification.Reachable)
+	assert.False(t, res.Verification.Authenticated)
+
+	sSecret.EXPECT().Get("default", "abc", "").Return(nil, nil)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/v1/registries?verify=yes", bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	found := true
+	sSecret.EXPECT().Get("default", "abc", "").Return(mSecret, nil)
+	sRegistry.EXPECT().Verify(gomock.Any(), "library/nginx:1.21").DoAndReturn(func(r *models.Registry, image string) *models.RegistryVerification {
+		assert.Equal(t, "harbor.local", r.Address)
+		assert.Equal(t, "password", r.Password)
+		return &models.RegistryVerification{Reachable: true, Authenticated: true, Image: image, ImageFound: &found, Status: http.StatusOK}
+	})
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/v1/registries/abc/verify?image=library/nginx:1.21", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	verification := new(models.RegistryVerification)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), verification))
+	assert.True(t, verification.Authenticated)
+	assert.True(t, *verification.ImageFound)
+
+	// the secret of other type is not a registry
+	sSecret.EXPECT().Get("default", "abc", "").Return(&specV1.Secret{Namespace: "default", Name: "abc"}, nil)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/v1/registries/abc/verify", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	sSecret.EXPECT().Get("default", "abc", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/v1/registries/abc/verify", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
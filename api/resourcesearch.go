@@ -0,0 +1,35 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// SearchResources search the nodes, apps and configs of all namespaces by name, label selector or image, such as
+// the apps still running an image. The results are paged by offset since they are merged from the namespaces
+func (api *API) SearchResources(c *common.Context) (interface{}, error) {
+	query := new(models.ResourceSearch)
+	if err := c.Bind(query); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if err := common.ValidateStruct(query); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if query.Image != "" && query.Kind != "" && query.Kind != models.SearchKindApp {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "only apps are searched by image"))
+	}
+	pagination, err := c.LoadPagination()
+	if err != nil {
+		return nil, err
+	}
+	if pagination.Continue != "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "continue should be issued by the search"))
+	}
+	query.PageNo, query.PageSize, query.Offset = 0, pagination.Limit, pagination.Offset
+	query.SetContext(c.RequestContext())
+	res, err := api.ResourceSearch.Search(query)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(res.Total, res.Items, pagination.NextOffsetToken(res.Total)), nil
+}
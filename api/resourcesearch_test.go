@@ -0,0 +1,56 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestSearchResources(t *testing.T) {
+	api := &API{}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sSearch := ms.NewMockResourceSearchService(mockCtl)
+	api.ResourceSearch = sSearch
+	router.GET("/v1/resources", common.WrapperMis(api.SearchResources))
+
+	res := &models.ResourceSearchList{Total: 3, Items: []models.ResourceSearchItem{{Kind: models.SearchKindApp, Namespace: "tenant-a", Name: "web"}}}
+	sSearch.EXPECT().Search(gomock.Any()).DoAndReturn(func(query *models.ResourceSearch) (*models.ResourceSearchList, error) {
+		assert.Equal(t, models.SearchKindApp, query.Kind)
+		assert.Equal(t, "nginx:1.21", query.Image)
+		assert.Equal(t, "web", query.Name)
+		assert.Equal(t, 1, query.GetLimitNumber())
+		assert.Equal(t, 1, query.GetLimitOffset())
+		return res, nil
+	})
+	req, _ := http.NewRequest(http.MethodGet, "/v1/resources?kind=app&image=nginx:1.21&name=web&limit=1&offset=1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"nextToken":"`+common.NextOffsetToken(1, 1, 3)+`"`)
+
+	for _, query := range []string{"kind=secret", "kind=node&image=nginx", "continue=abc", "limit=-1"} {
+		req, _ = http.NewRequest(http.MethodGet, "/v1/resources?"+query, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		// the failures of mis are responded with status 1
+		assert.Equal(t, http.StatusOK, w.Code, query)
+		assert.Contains(t, w.Body.String(), `"status":1`, query)
+	}
+
+	sSearch.EXPECT().Search(gomock.Any()).Return(nil, errors.New("error"))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/resources", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":1`)
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetAppRollout get the staged rollout of application
+func (api *API) GetAppRollout(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.getVisibleApp(ns, name); err != nil {
+		return nil, err
+	}
+	return api.Rollout.Get(ns, name)
+}
+
+// PauseAppRollout stops releasing the application to more nodes, the released nodes keep the new version
+func (api *API) PauseAppRollout(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.getVisibleApp(ns, name); err != nil {
+		return nil, err
+	}
+	return api.Rollout.Pause(ns, name)
+}
+
+// ResumeAppRollout continues the paused rollout of application
+func (api *API) ResumeAppRollout(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.getVisibleApp(ns, name); err != nil {
+		return nil, err
+	}
+	return api.Rollout.Resume(ns, name)
+}
+
+// RunRollout advances the progressing rollouts every interval until done is closed
+func (api *API) RunRollout(interval time.Duration, done <-chan struct{}) {
+	if interval <= 0 || api.Rollout == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := api.AdvanceRollouts(); err != nil {
+				api.log.Warn("failed to advance app rollouts", log.Error(err))
+			}
+		}
+	}
+}
+
+// AdvanceRollouts checks the progressing rollouts of all namespaces,
+// the failure of one namespace does not stop the others
+func (api *API) AdvanceRollouts() error {
+	list, err := api.NS.List(&models.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, item := range list.Items {
+		if err = api.advanceNamespaceRollouts(item.Name); err != nil {
+			api.log.Warn("failed to advance app rollouts of namespace", log.Any("namespace", item.Name), log.Error(err))
+		}
+	}
+	return nil
+}
+
+func (api *API) advanceNamespaceRollouts(namespace string) error {
+	rollouts, err := api.Rollout.List(namespace)
+	if err != nil {
+		return err
+	}
+	var progressing []models.Rollout
+	for _, r := range rollouts {
+		if r.Status == models.RolloutProgressing {
+			progressing = append(progressing, r)
+		}
+	}
+	if len(progressing) == 0 {
+		return nil
+	}
+	ctx := context.Background()
+	lockName := "namespace_" + namespace
+	version, err := api.Locker.Lock(ctx, lockName, 0)
+	if err != nil {
+		return err
+	}
+	defer api.Locker.Unlock(ctx, lockName, version)
+
+	now := time.Now()
+	for i := range progressing {
+		rollout := &progressing[i]
+		app, err := api.App.Get(namespace, rollout.App, "")
+		if err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				api.deleteAppRollout(namespace, rollout.App)
+				continue
+			}
+			api.log.Warn("failed to get app of rollout", log.Any("namespace", namespace), log.Any("app", rollout.App), log.Error(err))
+			continue
+		}
+		stage, status := rollout.Stage, rollout.Status
+		if rollout, err = api.Rollout.Advance(app, rollout, now); err != nil {
+			api.log.Warn("failed to advance app rollout", log.Any("namespace", namespace), log.Any("app", app.Name), log.Error(err))
+			continue
+		}
+		if rollout.Stage != stage || rollout.Status != status {
+			api.log.Info("app rollout advanced", log.Any("namespace", namespace), log.Any("app", app.Name),
+				log.Any("stage", rollout.Stage), log.Any("status", rollout.Status), log.Any("nodes", len(rollout.Nodes)))
+		}
+	}
+	return nil
+}
+
+// checkAppRollout checks the rollout strategy of application, each stage is released to either a percentage of
+// the matched nodes or the members of a node group
+func (api *API) checkAppRollout(appView *models.ApplicationView) error {
+	strategy := appView.Rollout
+	if strategy == nil {
+		return nil
+	}
+	if api.Rollout == nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the staged rollout is not supported"))
+	}
+	if appView.System {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the system app can not be released in stages"))
+	}
+	if appView.CronStatus == specV1.CronWait {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the cron app can not be released in stages"))
+	}
+	if len(strategy.Stages) == 0 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "at least one stage is required for rollout"))
+	}
+	for _, stage := range strategy.Stages {
+		if (stage.Percent == 0) == (stage.NodeGroup == "") {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "either percent or nodeGroup should be set for each stage of rollout"))
+		}
+		if stage.NodeGroup == "" {
+			continue
+		}
+		if _, err := api.Group.Get(appView.Namespace, stage.NodeGroup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startAppRollout starts the rollout before the application is saved, so that the new version is
+// never delivered to the nodes out of the first stage
+func (api *API) startAppRollout(app *specV1.Application, strategy *models.RolloutStrategy) (*models.Rollout, error) {
+	if strategy == nil {
+		return nil, nil
+	}
+	return api.Rollout.Start(app, strategy)
+}
+
+// finishAppRollout records the saved version of application in its rollout,
+// the previous rollout is removed if the application is released to all nodes at once
+func (api *API) finishAppRollout(app *specV1.Application, rollout *models.Rollout) {
+	if rollout == nil {
+		api.deleteAppRollout(app.Namespace, app.Name)
+		return
+	}
+	rollout.Version = app.Version
+	if err := api.Rollout.Update(rollout); err != nil {
+		log.L().Warn("failed to update the version of app rollout", log.Any("ns", app.Namespace), log.Any("name", app.Name), log.Error(err))
+	}
+}
+
+func (api *API) deleteAppRollout(ns, name string) {
+	if api.Rollout == nil {
+		return
+	}
+	if err := api.Rollout.Delete(ns, name); err != nil {
+		common.LogDirtyData(err, log.Any("type", "rollout"), log.Any(common.KeyContextNamespace, ns), log.Any("app", name))
+	}
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initRolloutAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { c.Set(common.KeyContextNamespace, "baetyl-cloud") }
+	v1 := router.Group("v1")
+	{
+		apps := v1.Group("/apps")
+		apps.GET("/:name/rollout", mockIM, common.Wrapper(api.GetAppRollout))
+		apps.POST("/:name/rollout/pause", mockIM, common.Wrapper(api.PauseAppRollout))
+		apps.POST("/:name/rollout/resume", mockIM, common.Wrapper(api.ResumeAppRollout))
+	}
+	return api, router, mockCtl
+}
+
+func TestAppRollout(t *testing.T) {
+	api, router, mockCtl := initRolloutAPI(t)
+	defer mockCtl.Finish()
+	sApp, sRollout := ms.NewMockApplicationService(mockCtl), ms.NewMockRolloutService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+	api.Rollout = sRollout
+
+	rollout := &models.Rollout{Namespace: "baetyl-cloud", App: "abc", Status: models.RolloutProgressing, Nodes: []string{"node01"}}
+	sApp.EXPECT().Get("baetyl-cloud", "abc", "").Return(getMockContainerApp(), nil).AnyTimes()
+	sRollout.EXPECT().Get("baetyl-cloud", "abc").Return(rollout, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/apps/abc/rollout", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.Rollout)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, []string{"node01"}, res.Nodes)
+
+	sRollout.EXPECT().Pause("baetyl-cloud", "abc").Return(&models.Rollout{Status: models.RolloutPaused}, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/abc/rollout/pause", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), models.RolloutPaused)
+
+	sRollout.EXPECT().Resume("baetyl-cloud", "abc").Return(nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the rollout is completed")))
+	req, _ = http.NewRequest(http.MethodPost, "/v1/apps/abc/rollout/resume", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCheckAppRollout(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sGroup := ms.NewMockNodeGroupService(mockCtl)
+	api := &API{Group: sGroup, log: log.L()}
+
+	appView := &models.ApplicationView{Namespace: "default", Name: "abc"}
+	assert.NoError(t, api.checkAppRollout(appView))
+
+	appView.Rollout = &models.RolloutStrategy{Stages: []models.RolloutStage{{Percent: 10}}}
+	assert.Error(t, api.checkAppRollout(appView))
+
+	api.Rollout = ms.NewMockRolloutService(mockCtl)
+	assert.NoError(t, api.checkAppRollout(appView))
+
+	appView.Rollout.Stages = append(appView.Rollout.Stages, models.RolloutStage{Percent: 50, NodeGroup: "group01"})
+	assert.Error(t, api.checkAppRollout(appView))
+
+	appView.Rollout.Stages[1].Percent = 0
+	sGroup.EXPECT().Get("default", "group01").Return(nil, common.Error(common.ErrResourceNotFound))
+	assert.Error(t, api.checkAppRollout(appView))
+
+	appView.Rollout.Stages = appView.Rollout.Stages[:1]
+	appView.System = true
+	assert.Error(t, api.checkAppRollout(appView))
+
+	appView.System = false
+	appView.CronStatus = specV1.CronWait
+	assert.Error(t, api.checkAppRollout(appView))
+}
+
+func TestStartAndFinishAppRollout(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sRollout := ms.NewMockRolloutService(mockCtl)
+	api := &API{Rollout: sRollout, log: log.L()}
+
+	app := &specV1.Application{Namespace: "default", Name: "abc", Version: "1"}
+	strategy := &models.RolloutStrategy{Stages: []models.RolloutStage{{Percent: 10}}}
+	rollout, err := api.startAppRollout(app, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, rollout)
+
+	sRollout.EXPECT().Start(app, strategy).Return(&models.Rollout{App: "abc", Version: "1"}, nil)
+	rollout, err = api.startAppRollout(app, strategy)
+	assert.NoError(t, err)
+
+	// the version saved is recorded
+	saved := &specV1.Application{Namespace: "default", Name: "abc", Version: "2"}
+	sRollout.EXPECT().Update(rollout).Return(nil)
+	api.finishAppRollout(saved, rollout)
+	assert.Equal(t, "2", rollout.Version)
+
+	// the app released at once has no rollout
+	sRollout.EXPECT().Delete("default", "abc").Return(fmt.Errorf("error"))
+	api.finishAppRollout(saved, nil)
+}
+
+func TestAdvanceRollouts(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sNS, sApp, sRollout, sLocker := ms.NewMockNamespaceService(mockCtl), ms.NewMockApplicationService(mockCtl),
+		ms.NewMockRolloutService(mockCtl), ms.NewMockLockerService(mockCtl)
+	api := &API{NS: sNS, Rollout: sRollout, Locker: sLocker, AppCombinedService: &service.AppCombinedService{App: sApp}, log: log.L()}
+
+	app := &specV1.Application{Namespace: "default", Name: "app01", Version: "2"}
+	sNS.EXPECT().List(&models.ListOptions{}).Return(&models.NamespaceList{Items: []models.Namespace{{Name: "default"}, {Name: "other"}}}, nil)
+	sRollout.EXPECT().List("default").Return([]models.Rollout{
+		{Namespace: "default", App: "app01", Status: models.RolloutProgressing},
+		{Namespace: "default", App: "app02", Status: models.RolloutProgressing},
+		{Namespace: "default", App: "app03", Status: models.RolloutPaused},
+	}, nil)
+	// no lock is taken without progressing rollouts
+	sRollout.EXPECT().List("other").Return([]models.Rollout{{Namespace: "other", App: "app01", Status: models.RolloutCompleted}}, nil)
+	sLocker.EXPECT().Lock(gomock.Any(), "namespace_default", int64(0)).Return("v1", nil)
+	sLocker.EXPECT().Unlock(gomock.Any(), "namespace_default", "v1")
+	sApp.EXPECT().Get("default", "app01", "").Return(app, nil)
+	sRollout.EXPECT().Advance(app, gomock.Any(), gomock.Any()).Return(&models.Rollout{App: "app01", Stage: 1, Status: models.RolloutProgressing}, nil)
+	// the rollout of app deleted is removed
+	sApp.EXPECT().Get("default", "app02", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	sRollout.EXPECT().Delete("default", "app02").Return(nil)
+	assert.NoError(t, api.AdvanceRollouts())
+}
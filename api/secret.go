@@ -21,7 +21,11 @@ func (api *API) GetSecret(c *common.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return api.ToSecretView(res), nil
+	view := api.ToSecretView(res)
+	if view.Rotation, err = api.getSecretRotation(ns, n); err != nil {
+		return nil, err
+	}
+	return view, nil
 }
 
 // ListSecret list secret
@@ -36,7 +40,8 @@ func (api *API) ListSecret(c *common.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return api.ToFilteredSecretViewList(res), nil
+	list := api.ToFilteredSecretViewList(res)
+	return api.ToListResponse(list.Total, list.Items, list.ListOptions), nil
 }
 
 // CreateSecret create one secret
@@ -61,7 +66,13 @@ func (api *API) CreateSecret(c *common.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return api.ToFilteredSecretView(res), nil
+	view := api.ToFilteredSecretView(res)
+	if cfg.Rotation != nil && view != nil {
+		if view.Rotation, err = api.setSecretRotation(ns, name, cfg.Rotation); err != nil {
+			return nil, err
+		}
+	}
+	return view, nil
 }
 
 // UpdateSecret update the secret
@@ -76,7 +87,18 @@ func (api *API) UpdateSecret(c *common.Context) (interface{}, error) {
 		return nil, err
 	}
 
+	// the rotation is not part of the data synchronized to nodes, it is saved even if the data is unchanged
+	var rotation *models.SecretRotation
+	if cfg.Rotation != nil {
+		if rotation, err = api.setSecretRotation(ns, n, cfg.Rotation); err != nil {
+			return nil, err
+		}
+	} else if rotation, err = api.getSecretRotation(ns, n); err != nil {
+		return nil, err
+	}
+
 	sd := api.ToSecretView(oldSecret)
+	sd.Rotation = rotation
 	if sd.Equal(cfg) {
 		return sd, nil
 	}
@@ -87,13 +109,19 @@ func (api *API) UpdateSecret(c *common.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return api.ToSecretView(secret), nil
+	view := api.ToSecretView(secret)
+	view.Rotation = rotation
+	return view, nil
 }
 
-// DeleteSecret delete the secret
+// DeleteSecret delete the secret, which is refused if the apps still reference it unless force=true
 func (api *API) DeleteSecret(c *common.Context) (interface{}, error) {
 	ns, n := c.GetNamespace(), c.GetNameFromParam()
-	return api.DeleteSecretResource(ns, n, "secret")
+	force, err := parseForceDelete(c)
+	if err != nil {
+		return nil, err
+	}
+	return api.deleteSecretResource(ns, n, "secret", force)
 }
 
 // GetAppBySecret list app
@@ -119,10 +147,39 @@ func (api *API) parseAndCheckSecretModel(c *common.Context) (*models.SecretView,
 		secret.Name = name
 	}
 	if secret.Name == "" {
-		err = common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	if secret.External == nil {
+		if secret.Data == nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "data is required"))
+		}
+		if _, ok := secret.Data[common.SecretExternalKey]; ok {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the key "+common.SecretExternalKey+" is reserved"))
+		}
+		return secret, nil
+	}
+	if err = api.checkExternalSecret(secret); err != nil {
+		return nil, err
 	}
+	return secret, nil
+}
 
-	return secret, err
+// checkExternalSecret checks the secret which only stores the reference to the external store, its data is
+// read by the provider on sync and can not be rotated by cloud
+func (api *API) checkExternalSecret(secret *models.SecretView) error {
+	if len(secret.Data) > 0 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the data of external secret should be empty"))
+	}
+	if secret.Rotation != nil && secret.Rotation.Rotator != "" {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the external secret should be rotated in its store"))
+	}
+	if secret.External.Provider == "" || secret.External.Path == "" {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the provider and path of external secret are required"))
+	}
+	if api.ExternalSecret == nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the external secret is not supported"))
+	}
+	return api.ExternalSecret.Check(secret.External)
 }
 
 func (api *API) ToFilteredSecretView(s *specV1.Secret) *models.SecretView {
@@ -142,6 +199,11 @@ func (api *API) ToSecretViewList(s *models.SecretList) *models.SecretViewList {
 }
 
 func (api *API) DeleteSecretResource(namespace, secret, secretType string) (interface{}, error) {
+	return api.deleteSecretResource(namespace, secret, secretType, false)
+}
+
+// deleteSecretResource deletes the secret liked resource, the references of apps are ignored if force is true
+func (api *API) deleteSecretResource(namespace, secret, secretType string, force bool) (interface{}, error) {
 	_, err := api.Secret.Get(namespace, secret, "")
 	if err != nil {
 		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
@@ -157,9 +219,20 @@ func (api *API) DeleteSecretResource(namespace, secret, secretType string) (inte
 		return nil, err
 	}
 	if len(appNames) > 0 {
-		return nil, common.Error(common.ErrResourceHasBeenUsed, common.Field("type", secretType), common.Field("name", secret))
+		if !force {
+			return nil, checkResourceReferences(secretType, secret, appNames)
+		}
+		log.L().Warn("delete secret referenced by apps", log.Any("type", secretType), log.Any("name", secret), log.Any("namespace", namespace), log.Any("apps", appNames))
+	}
+	if err = api.Facade.DeleteSecret(namespace, secret); err != nil {
+		return nil, err
+	}
+	if api.SecretRotation != nil {
+		if err = api.SecretRotation.Delete(namespace, secret); err != nil {
+			log.L().Warn("delete secret rotation failed", log.Error(err), log.Any("name", secret), log.Any("namespace", namespace))
+		}
 	}
-	return nil, api.Facade.DeleteSecret(namespace, secret)
+	return nil, nil
 }
 
 func (api *API) listAppBySecret(namespace, secret string) (*models.ApplicationList, error) {
@@ -150,6 +150,55 @@ func TestCreateSecret(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w2.Code)
 }
 
+func TestCreateExternalSecret(t *testing.T) {
+	api, router, mockCtl := initSecretAPI(t)
+	defer mockCtl.Finish()
+
+	sSecret := ms.NewMockSecretService(mockCtl)
+	fSecret := mf.NewMockFacade(mockCtl)
+	sExternal := ms.NewMockExternalSecretService(mockCtl)
+	api.Facade, api.ExternalSecret = fSecret, sExternal
+	api.AppCombinedService = &service.AppCombinedService{
+		Secret: sSecret,
+	}
+
+	ref := &models.SecretExternal{Provider: "vaultkv", Path: "app/db", Version: "3"}
+	sExternal.EXPECT().Check(ref).Return(nil)
+	sSecret.EXPECT().Get("default", "db", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	fSecret.EXPECT().CreateSecret("default", gomock.Any()).DoAndReturn(func(_ string, secret *specV1.Secret) (*specV1.Secret, error) {
+		// only the reference is stored in cloud
+		assert.Len(t, secret.Data, 1)
+		assert.JSONEq(t, `{"provider":"vaultkv","path":"app/db","version":"3"}`, string(secret.Data[common.SecretExternalKey]))
+		assert.True(t, models.IsExternalSecret(secret))
+		return secret, nil
+	})
+	body := `{"name":"db","external":{"provider":"vaultkv","path":"app/db","version":"3"}}`
+	req, _ := http.NewRequest(http.MethodPost, "/v1/secrets", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	view := new(models.SecretView)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), view))
+	assert.Equal(t, ref, view.External)
+	assert.Empty(t, view.Data)
+
+	sExternal.EXPECT().Check(gomock.Any()).Return(common.Error(common.ErrRequestParamInvalid, common.Field("error", "the secret provider aws is not supported")))
+	bodies := []string{
+		`{"name":"db","external":{"provider":"aws","path":"app/db"}}`,
+		`{"name":"db","external":{"provider":"vaultkv"}}`,
+		`{"name":"db","data":{"a":"b"},"external":{"provider":"vaultkv","path":"app/db"}}`,
+		`{"name":"db","external":{"provider":"vaultkv","path":"app/db"},"rotation":{"rotator":"defaultrotator","interval":"24h"}}`,
+		`{"name":"db","data":{"` + common.SecretExternalKey + `":"{}"}}`,
+		`{"name":"db"}`,
+	}
+	for _, b := range bodies {
+		req, _ = http.NewRequest(http.MethodPost, "/v1/secrets", bytes.NewReader([]byte(b)))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, b)
+	}
+}
+
 func TestUpdateSecret(t *testing.T) {
 	api, router, mockCtl := initSecretAPI(t)
 	defer mockCtl.Finish()
@@ -261,11 +310,19 @@ func TestDeleteSecret(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 
 	sIndex.EXPECT().ListAppIndexBySecret(gomock.Any(), gomock.Any()).Return([]string{"app1", "app2"}, nil)
-	// 400
+	// 409
 	req2, _ := http.NewRequest(http.MethodDelete, "/v1/secrets/abc", nil)
 	w2 := httptest.NewRecorder()
 	router.ServeHTTP(w2, req2)
-	assert.Equal(t, http.StatusForbidden, w2.Code)
+	assert.Equal(t, http.StatusConflict, w2.Code)
+	assert.Contains(t, w2.Body.String(), "app1,app2")
+
+	sIndex.EXPECT().ListAppIndexBySecret(gomock.Any(), gomock.Any()).Return([]string{"app1", "app2"}, nil)
+	// 200 deleted by force
+	req3, _ := http.NewRequest(http.MethodDelete, "/v1/secrets/abc?force=true", nil)
+	w3 := httptest.NewRecorder()
+	router.ServeHTTP(w3, req3)
+	assert.Equal(t, http.StatusOK, w3.Code)
 }
 
 func TestGetAppBySecret(t *testing.T) {
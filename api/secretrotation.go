@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// RotateSecret rotates the secret by its rotator right now, the applications using it are updated with the new data
+func (api *API) RotateSecret(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	rotation, err := api.SecretRotation.Get(ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if rotation.Rotator == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the rotator of secret is not set"))
+	}
+	secret, err := api.rotateSecret(rotation, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	view := api.ToSecretView(secret)
+	view.Rotation = rotation
+	return view, nil
+}
+
+// DeleteSecretRotation delete the expiry and rotation policy of secret, the secret never expires then
+func (api *API) DeleteSecretRotation(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.Secret.Get(ns, n, ""); err != nil {
+		return nil, err
+	}
+	return nil, api.SecretRotation.Delete(ns, n)
+}
+
+// RunSecretRotation rotates or flags the secrets expiring within notifyBefore every interval until done is closed
+func (api *API) RunSecretRotation(interval, notifyBefore time.Duration, done <-chan struct{}) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := api.CheckSecretRotations(notifyBefore); err != nil {
+				api.log.Warn("failed to check secret rotations", log.Error(err))
+			}
+		}
+	}
+}
+
+// CheckSecretRotations rotates the secrets expiring within notifyBefore which have rotators, the others are flagged
+// expiring or expired. The namespace is locked as the applications using the secrets are updated
+func (api *API) CheckSecretRotations(notifyBefore time.Duration) error {
+	now := time.Now().UTC()
+	rotations, err := api.SecretRotation.ListExpiring(now.Add(notifyBefore))
+	if err != nil {
+		return err
+	}
+	var namespaces []string
+	byNamespace := map[string][]models.SecretRotation{}
+	for _, r := range rotations {
+		if _, ok := byNamespace[r.Namespace]; !ok {
+			namespaces = append(namespaces, r.Namespace)
+		}
+		byNamespace[r.Namespace] = append(byNamespace[r.Namespace], r)
+	}
+	for _, ns := range namespaces {
+		if err = api.checkNamespaceSecretRotations(ns, byNamespace[ns], now); err != nil {
+			api.log.Warn("failed to check secret rotations of namespace", log.Any("namespace", ns), log.Error(err))
+		}
+	}
+	return nil
+}
+
+func (api *API) checkNamespaceSecretRotations(namespace string, rotations []models.SecretRotation, now time.Time) error {
+	ctx := context.Background()
+	lockName := "namespace_" + namespace
+	version, err := api.Locker.Lock(ctx, lockName, 0)
+	if err != nil {
+		return err
+	}
+	defer api.Locker.Unlock(ctx, lockName, version)
+
+	for i := range rotations {
+		rotation := &rotations[i]
+		if rotation.Rotator != "" {
+			if _, err = api.rotateSecret(rotation, now); err == nil {
+				api.log.Info("secret rotated", log.Any("namespace", namespace), log.Any("secret", rotation.Name), log.Any("expiresAt", rotation.ExpiresAt))
+				continue
+			}
+			// the secret is flagged once the rotation fails, it is rotated again until it expires
+			api.log.Warn("failed to rotate secret", log.Any("namespace", namespace), log.Any("secret", rotation.Name), log.Error(err))
+		}
+		if err = api.flagSecretRotation(rotation, now); err != nil {
+			api.log.Warn("failed to flag secret", log.Any("namespace", namespace), log.Any("secret", rotation.Name), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// rotateSecret updates the secret with the data generated by the rotator, the versions of applications mounting it
+// are increased so that the nodes get the new data. The secret is valid for the interval of rotation from now on
+func (api *API) rotateSecret(rotation *models.SecretRotation, now time.Time) (*specV1.Secret, error) {
+	interval, err := time.ParseDuration(rotation.Interval)
+	if err != nil || interval <= 0 {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the interval of rotation is invalid"))
+	}
+	secret, err := api.Secret.Get(rotation.Namespace, rotation.Name, "")
+	if err != nil {
+		return nil, err
+	}
+	if models.IsExternalSecret(secret) {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the external secret should be rotated in its store"))
+	}
+	rotated, err := api.SecretRotation.Rotate(secret, rotation)
+	if err != nil {
+		return nil, err
+	}
+	rotated.UpdateTimestamp = now
+	res, err := api.Facade.UpdateSecret(rotation.Namespace, rotated)
+	if err != nil {
+		return nil, err
+	}
+	rotation.ExpiresAt = now.Add(interval)
+	rotation.Status = models.SecretRotationActive
+	rotation.RotateTime = now
+	updated, err := api.SecretRotation.Set(rotation)
+	if err != nil {
+		return nil, err
+	}
+	*rotation = *updated
+	api.notifySecretRotation(rotation, models.SecretEventRotated)
+	return res, nil
+}
+
+// flagSecretRotation marks the secret expiring or expired, the event is posted once the status changes
+func (api *API) flagSecretRotation(rotation *models.SecretRotation, now time.Time) error {
+	status, event := models.SecretRotationExpiring, models.SecretEventExpiring
+	if !now.Before(rotation.ExpiresAt) {
+		status, event = models.SecretRotationExpired, models.SecretEventExpired
+	}
+	if rotation.Status == status {
+		return nil
+	}
+	rotation.Status = status
+	if _, err := api.SecretRotation.Set(rotation); err != nil {
+		return err
+	}
+	api.notifySecretRotation(rotation, event)
+	return nil
+}
+
+// notifySecretRotation posts the event to the webhook of rotation, the failure does not affect the rotation done
+func (api *API) notifySecretRotation(rotation *models.SecretRotation, eventType string) {
+	if err := api.SecretRotation.Notify(rotation, eventType); err != nil {
+		api.log.Warn("failed to post secret event", log.Any("namespace", rotation.Namespace),
+			log.Any("secret", rotation.Name), log.Any("type", eventType), log.Error(err))
+	}
+}
+
+// setSecretRotation saves the rotation of secret. The status and the last rotation time are kept unless the secret
+// expires at another time
+func (api *API) setSecretRotation(namespace, name string, rotation *models.SecretRotation) (*models.SecretRotation, error) {
+	rotation.Namespace, rotation.Name = namespace, name
+	if err := checkSecretRotation(rotation); err != nil {
+		return nil, err
+	}
+	rotation.Status = ""
+	old, err := api.getSecretRotation(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if old != nil {
+		rotation.RotateTime = old.RotateTime
+		if old.ExpiresAt.Equal(rotation.ExpiresAt) {
+			rotation.Status = old.Status
+		}
+	}
+	return api.SecretRotation.Set(rotation)
+}
+
+// getSecretRotation returns the rotation of secret, nil is returned if it is not set
+func (api *API) getSecretRotation(namespace, name string) (*models.SecretRotation, error) {
+	if api.SecretRotation == nil {
+		return nil, nil
+	}
+	res, err := api.SecretRotation.Get(namespace, name)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func checkSecretRotation(rotation *models.SecretRotation) error {
+	if rotation.ExpiresAt.IsZero() {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "expiresAt is required"))
+	}
+	rotation.ExpiresAt = rotation.ExpiresAt.UTC()
+	if rotation.Rotator != "" {
+		if interval, err := time.ParseDuration(rotation.Interval); err != nil || interval <= 0 {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the interval is required to rotate secret"))
+		}
+	}
+	for _, k := range rotation.Keys {
+		if strings.TrimSpace(k) == "" {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the key to rotate should not be empty"))
+		}
+	}
+	return nil
+}
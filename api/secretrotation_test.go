@@ -0,0 +1,197 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mf "github.com/baetyl/baetyl-cloud/v2/mock/facade"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initSecretRotationAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		secrets := v1.Group("/secrets")
+		secrets.PUT("/:name", mockIM, common.Wrapper(api.UpdateSecret))
+		secrets.POST("/:name/rotate", mockIM, common.Wrapper(api.RotateSecret))
+		secrets.DELETE("/:name/rotation", mockIM, common.Wrapper(api.DeleteSecretRotation))
+	}
+	return api, router, mockCtl
+}
+
+func TestUpdateSecretRotation(t *testing.T) {
+	api, router, mockCtl := initSecretRotationAPI(t)
+	defer mockCtl.Finish()
+	sSecret, sRotation := ms.NewMockSecretService(mockCtl), ms.NewMockSecretRotationService(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{Secret: sSecret}
+	api.SecretRotation = sRotation
+
+	secret := &specV1.Secret{
+		Name:      "db",
+		Namespace: "default",
+		Labels:    map[string]string{specV1.SecretLabel: specV1.SecretConfig},
+		Data:      map[string][]byte{"password": []byte("old")},
+		Version:   "3",
+	}
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	body := func(rotation *models.SecretRotation) *bytes.Reader {
+		data, _ := json.Marshal(&models.SecretView{Data: map[string]string{"password": "old"}, Rotation: rotation})
+		return bytes.NewReader(data)
+	}
+
+	// the interval is required by rotator
+	sSecret.EXPECT().Get("default", "db", "").Return(secret, nil)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/v1/secrets/db", body(&models.SecretRotation{ExpiresAt: expiresAt, Rotator: "defaultrotator"}))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the rotation is saved though the data is unchanged, the status is kept if it expires at the same time
+	old := &models.SecretRotation{Namespace: "default", Name: "db", ExpiresAt: expiresAt, Status: models.SecretRotationExpiring, RotateTime: expiresAt.Add(-time.Hour)}
+	sSecret.EXPECT().Get("default", "db", "").Return(secret, nil)
+	sRotation.EXPECT().Get("default", "db").Return(old, nil)
+	sRotation.EXPECT().Set(gomock.Any()).DoAndReturn(func(r *models.SecretRotation) (*models.SecretRotation, error) {
+		assert.Equal(t, "db", r.Name)
+		assert.Equal(t, models.SecretRotationExpiring, r.Status)
+		assert.Equal(t, old.RotateTime, r.RotateTime)
+		assert.Equal(t, "720h", r.Interval)
+		return r, nil
+	})
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPut, "/v1/secrets/db", body(&models.SecretRotation{ExpiresAt: expiresAt, Rotator: "defaultrotator", Interval: "720h"}))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.SecretView)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, "defaultrotator", res.Rotation.Rotator)
+
+	// the status is reset if it expires at another time
+	sSecret.EXPECT().Get("default", "db", "").Return(secret, nil)
+	sRotation.EXPECT().Get("default", "db").Return(old, nil)
+	sRotation.EXPECT().Set(gomock.Any()).DoAndReturn(func(r *models.SecretRotation) (*models.SecretRotation, error) {
+		assert.Empty(t, r.Status)
+		return r, nil
+	})
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPut, "/v1/secrets/db", body(&models.SecretRotation{ExpiresAt: expiresAt.Add(time.Hour)}))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sSecret.EXPECT().Get("default", "db", "").Return(secret, nil)
+	sRotation.EXPECT().Delete("default", "db").Return(nil)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/secrets/db/rotation", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRotateSecret(t *testing.T) {
+	api, router, mockCtl := initSecretRotationAPI(t)
+	defer mockCtl.Finish()
+	sSecret, sRotation, sFacade := ms.NewMockSecretService(mockCtl), ms.NewMockSecretRotationService(mockCtl), mf.NewMockFacade(mockCtl)
+	api.AppCombinedService = &service.AppCombinedService{Secret: sSecret}
+	api.SecretRotation, api.Facade = sRotation, sFacade
+
+	secret := &specV1.Secret{Name: "db", Namespace: "default", Data: map[string][]byte{"password": []byte("old")}, Version: "3"}
+	rotated := &specV1.Secret{Name: "db", Namespace: "default", Data: map[string][]byte{"password": []byte("new")}, Version: "3"}
+	stored := &specV1.Secret{Name: "db", Namespace: "default", Data: map[string][]byte{"password": []byte("new")}, Version: "4"}
+
+	// the secret without rotator is not rotated
+	sRotation.EXPECT().Get("default", "db").Return(&models.SecretRotation{Namespace: "default", Name: "db"}, nil)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/secrets/db/rotate", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	rotation := &models.SecretRotation{Namespace: "default", Name: "db", Rotator: "defaultrotator", Interval: "24h", Webhook: "http://hook.local"}
+	sRotation.EXPECT().Get("default", "db").Return(rotation, nil)
+	sSecret.EXPECT().Get("default", "db", "").Return(secret, nil)
+	sRotation.EXPECT().Rotate(secret, rotation).Return(rotated, nil)
+	sFacade.EXPECT().UpdateSecret("default", rotated).Return(stored, nil)
+	sRotation.EXPECT().Set(rotation).DoAndReturn(func(r *models.SecretRotation) (*models.SecretRotation, error) {
+		assert.Equal(t, models.SecretRotationActive, r.Status)
+		assert.True(t, r.ExpiresAt.After(time.Now().Add(23*time.Hour)))
+		return r, nil
+	})
+	sRotation.EXPECT().Notify(rotation, models.SecretEventRotated).Return(fmt.Errorf("error"))
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/v1/secrets/db/rotate", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	res := new(models.SecretView)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+	assert.Equal(t, "4", res.Version)
+	assert.Equal(t, "new", res.Data["password"])
+	assert.Equal(t, models.SecretRotationActive, res.Rotation.Status)
+}
+
+func TestCheckSecretRotations(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sSecret, sRotation, sFacade, sLocker := ms.NewMockSecretService(mockCtl), ms.NewMockSecretRotationService(mockCtl),
+		mf.NewMockFacade(mockCtl), ms.NewMockLockerService(mockCtl)
+	api := &API{SecretRotation: sRotation, Facade: sFacade, Locker: sLocker, log: log.L()}
+	api.AppCombinedService = &service.AppCombinedService{Secret: sSecret}
+
+	now := time.Now().UTC()
+	rotating := models.SecretRotation{Namespace: "default", Name: "db", ExpiresAt: now.Add(time.Hour), Rotator: "defaultrotator", Interval: "720h"}
+	failing := models.SecretRotation{Namespace: "default", Name: "token", ExpiresAt: now.Add(-time.Minute), Rotator: "defaultrotator", Interval: "720h", Status: models.SecretRotationExpiring}
+	expiring := models.SecretRotation{Namespace: "prod", Name: "cert", ExpiresAt: now.Add(time.Hour), Status: models.SecretRotationActive}
+	flagged := models.SecretRotation{Namespace: "prod", Name: "key", ExpiresAt: now.Add(time.Hour), Status: models.SecretRotationExpiring}
+	sRotation.EXPECT().ListExpiring(gomock.Any()).DoAndReturn(func(before time.Time) ([]models.SecretRotation, error) {
+		assert.True(t, before.After(now.Add(167*time.Hour)))
+		return []models.SecretRotation{rotating, failing, expiring, flagged}, nil
+	})
+
+	sLocker.EXPECT().Lock(gomock.Any(), "namespace_default", int64(0)).Return("v1", nil)
+	sLocker.EXPECT().Unlock(gomock.Any(), "namespace_default", "v1")
+	secret := &specV1.Secret{Name: "db", Namespace: "default", Data: map[string][]byte{"password": []byte("old")}}
+	rotated := &specV1.Secret{Name: "db", Namespace: "default", Data: map[string][]byte{"password": []byte("new")}}
+	sSecret.EXPECT().Get("default", "db", "").Return(secret, nil)
+	sRotation.EXPECT().Rotate(secret, gomock.Any()).Return(rotated, nil)
+	sFacade.EXPECT().UpdateSecret("default", rotated).Return(rotated, nil)
+	sRotation.EXPECT().Set(gomock.Any()).DoAndReturn(func(r *models.SecretRotation) (*models.SecretRotation, error) {
+		assert.Equal(t, "db", r.Name)
+		assert.Equal(t, models.SecretRotationActive, r.Status)
+		return r, nil
+	})
+	sRotation.EXPECT().Notify(gomock.Any(), models.SecretEventRotated).Return(nil)
+	// the secret failing to rotate is flagged expired
+	sSecret.EXPECT().Get("default", "token", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	sRotation.EXPECT().Set(gomock.Any()).DoAndReturn(func(r *models.SecretRotation) (*models.SecretRotation, error) {
+		assert.Equal(t, "token", r.Name)
+		assert.Equal(t, models.SecretRotationExpired, r.Status)
+		return r, nil
+	})
+	sRotation.EXPECT().Notify(gomock.Any(), models.SecretEventExpired).Return(nil)
+
+	// the secret flagged already is not notified again
+	sLocker.EXPECT().Lock(gomock.Any(), "namespace_prod", int64(0)).Return("v2", nil)
+	sLocker.EXPECT().Unlock(gomock.Any(), "namespace_prod", "v2")
+	sRotation.EXPECT().Set(gomock.Any()).DoAndReturn(func(r *models.SecretRotation) (*models.SecretRotation, error) {
+		assert.Equal(t, "cert", r.Name)
+		assert.Equal(t, models.SecretRotationExpiring, r.Status)
+		return r, nil
+	})
+	sRotation.EXPECT().Notify(gomock.Any(), models.SecretEventExpiring).Return(nil)
+
+	assert.NoError(t, api.CheckSecretRotations(168*time.Hour))
+}
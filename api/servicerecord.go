@@ -0,0 +1,21 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+// ListServiceRecord lists the service records of the apps in namespace for the topology view,
+// the records of the apps on the node are listed if the query node is set
+func (api *API) ListServiceRecord(c *common.Context) (interface{}, error) {
+	ns, node := c.GetNamespace(), c.Query("node")
+	if node != "" {
+		if _, err := api.Node.Get(nil, ns, node); err != nil {
+			return nil, err
+		}
+	}
+	items, err := api.ServiceRecord.List(ns, node)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(items), items, ""), nil
+}
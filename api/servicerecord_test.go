@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initServiceRecordAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		services := v1.Group("/services")
+		services.GET("", mockIM, common.Wrapper(api.ListServiceRecord))
+	}
+	return api, router, mockCtl
+}
+
+func TestListServiceRecord(t *testing.T) {
+	api, router, mockCtl := initServiceRecordAPI(t)
+	defer mockCtl.Finish()
+	sNode, sRecord := ms.NewMockNodeService(mockCtl), ms.NewMockServiceRecordService(mockCtl)
+	api.Node, api.ServiceRecord = sNode, sRecord
+
+	records := []models.ServiceRecord{{
+		Namespace: "default",
+		App:       "hub",
+		Service:   "broker",
+		Host:      "broker.hub.baetyl.local",
+		Ports:     []models.ServiceRecordPort{{Port: 1883, Protocol: "TCP"}},
+		Nodes:     []string{"node01"},
+	}}
+	sRecord.EXPECT().List("default", "").Return(records, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/services", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res struct {
+		Total int                    `json:"total"`
+		Items []models.ServiceRecord `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 1, res.Total)
+	assert.Equal(t, records, res.Items)
+
+	sNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Name: "node01"}, nil)
+	sRecord.EXPECT().List("default", "node01").Return([]models.ServiceRecord{}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/services?node=node01", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sNode.EXPECT().Get(nil, "default", "none").Return(nil, common.Error(common.ErrResourceNotFound, common.Field("type", "node"), common.Field("name", "none")))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/services?node=none", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	sRecord.EXPECT().List("default", "").Return(nil, fmt.Errorf("error"))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/services", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
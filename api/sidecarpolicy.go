@@ -0,0 +1,168 @@
+package api
+
+import (
+	"reflect"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/utils"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetSidecarPolicy get the sidecar policy
+func (api *API) GetSidecarPolicy(c *common.Context) (interface{}, error) {
+	return api.SidecarPolicy.Get(c.GetNamespace(), c.GetNameFromParam())
+}
+
+// ListSidecarPolicy list the sidecar policies of namespace
+func (api *API) ListSidecarPolicy(c *common.Context) (interface{}, error) {
+	policies, err := api.SidecarPolicy.List(c.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return common.NewListResponse(len(policies), policies, ""), nil
+}
+
+// CreateSidecarPolicy creates the sidecar policy, the apps selected are saved again
+// so that the nodes receive them with the sidecar
+func (api *API) CreateSidecarPolicy(c *common.Context) (interface{}, error) {
+	policy, err := api.parseSidecarPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = api.SidecarPolicy.Get(policy.Namespace, policy.Name); err == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "this name is already in use"))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, err
+	}
+	if c.IsDryRun() {
+		return policy, nil
+	}
+	res, err := api.SidecarPolicy.Create(policy)
+	if err != nil {
+		return nil, err
+	}
+	if err = api.refreshSidecarPolicyApps(policy.Namespace, policy); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateSidecarPolicy updates the sidecar policy, the apps selected before or after are saved again
+func (api *API) UpdateSidecarPolicy(c *common.Context) (interface{}, error) {
+	policy, err := api.parseSidecarPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+	old, err := api.SidecarPolicy.Get(policy.Namespace, policy.Name)
+	if err != nil {
+		return nil, err
+	}
+	injected := old.Selector == policy.Selector &&
+		reflect.DeepEqual(old.Sidecar, policy.Sidecar) && reflect.DeepEqual(old.Volumes, policy.Volumes)
+	if injected && old.Description == policy.Description {
+		return old, nil
+	}
+	if c.IsDryRun() {
+		policy.CreateTime = old.CreateTime
+		return policy, nil
+	}
+	res, err := api.SidecarPolicy.Update(policy)
+	if err != nil {
+		return nil, err
+	}
+	if !injected {
+		if err = api.refreshSidecarPolicyApps(policy.Namespace, old, policy); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// DeleteSidecarPolicy deletes the sidecar policy, the sidecar is removed from the apps selected
+func (api *API) DeleteSidecarPolicy(c *common.Context) (interface{}, error) {
+	ns, name := c.GetNamespace(), c.GetNameFromParam()
+	policy, err := api.SidecarPolicy.Get(ns, name)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err = api.SidecarPolicy.Delete(ns, name); err != nil {
+		return nil, err
+	}
+	return nil, api.refreshSidecarPolicyApps(ns, policy)
+}
+
+func (api *API) parseSidecarPolicy(c *common.Context) (*models.SidecarPolicy, error) {
+	policy := new(models.SidecarPolicy)
+	policy.Name = c.GetNameFromParam()
+	if err := c.LoadBody(policy); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if name := c.GetNameFromParam(); name != "" {
+		policy.Name = name
+	}
+	if policy.Name == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "name is required"))
+	}
+	policy.Namespace = c.GetNamespace()
+	if _, err := utils.IsLabelMatch(policy.Selector, map[string]string{}); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if policy.Sidecar.Name == "" || policy.Sidecar.Image == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the name and image of sidecar are required"))
+	}
+	volumes := map[string]bool{}
+	for _, vol := range policy.Volumes {
+		if vol.Name == "" || volumes[vol.Name] {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the name of volume is empty or duplicated"))
+		}
+		volumes[vol.Name] = true
+	}
+	for _, mount := range policy.Sidecar.VolumeMounts {
+		if !volumes[mount.Name] {
+			return nil, common.Error(common.ErrRequestParamInvalid,
+				common.Field("error", "the volume "+mount.Name+" mounted by sidecar should be defined in the policy"))
+		}
+	}
+	return policy, nil
+}
+
+// refreshSidecarPolicyApps saves the apps selected by the policies again for their new versions,
+// and updates the versions desired by their nodes
+func (api *API) refreshSidecarPolicyApps(ns string, policies ...*models.SidecarPolicy) error {
+	refreshed := map[string]bool{}
+	for _, policy := range policies {
+		apps, err := api.App.List(ns, &models.ListOptions{LabelSelector: policy.Selector})
+		if err != nil {
+			return err
+		}
+		for _, item := range apps.Items {
+			if refreshed[item.Name] {
+				continue
+			}
+			app, err := api.App.Get(ns, item.Name, "")
+			if err != nil {
+				if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+					continue
+				}
+				return err
+			}
+			if !service.SidecarPolicyMatches(policy, app) {
+				continue
+			}
+			refreshed[item.Name] = true
+			if app, err = api.App.Update(nil, ns, app); err != nil {
+				return err
+			}
+			if _, err = api.Node.UpdateNodeAppVersion(nil, ns, app); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
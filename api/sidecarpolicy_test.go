@@ -0,0 +1,202 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initSidecarPolicyAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L().With(log.Any("test", "api"))}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		sidecars := v1.Group("/sidecarpolicies")
+		sidecars.GET("", mockIM, common.Wrapper(api.ListSidecarPolicy))
+		sidecars.GET("/:name", mockIM, common.Wrapper(api.GetSidecarPolicy))
+		sidecars.POST("", mockIM, common.Wrapper(api.CreateSidecarPolicy))
+		sidecars.PUT("/:name", mockIM, common.Wrapper(api.UpdateSidecarPolicy))
+		sidecars.DELETE("/:name", mockIM, common.Wrapper(api.DeleteSidecarPolicy))
+	}
+	return api, router, mockCtl
+}
+
+func getMockSidecarPolicy() *models.SidecarPolicy {
+	return &models.SidecarPolicy{
+		Namespace: "default",
+		Name:      "log-shipper",
+		Selector:  "tier=web",
+		Sidecar: specV1.Service{
+			Name:         "fluent-bit",
+			Image:        "fluent/fluent-bit:1.9",
+			VolumeMounts: []specV1.VolumeMount{{Name: "log", MountPath: "/var/log/app"}},
+		},
+		Volumes: []specV1.Volume{{Name: "log", VolumeSource: specV1.VolumeSource{EmptyDir: &specV1.EmptyDirVolumeSource{}}}},
+	}
+}
+
+func TestCreateSidecarPolicy(t *testing.T) {
+	api, router, mockCtl := initSidecarPolicyAPI(t)
+	defer mockCtl.Finish()
+	sPolicy, sApp, sNode := ms.NewMockSidecarPolicyService(mockCtl), ms.NewMockApplicationService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.SidecarPolicy, api.Node = sPolicy, sNode
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	policy := getMockSidecarPolicy()
+	web := &specV1.Application{Namespace: "default", Name: "web", Labels: map[string]string{"tier": "web"}}
+	job := &specV1.Application{Namespace: "default", Name: "job", Labels: map[string]string{"tier": "web"}, Workload: specV1.WorkloadJob}
+	sPolicy.EXPECT().Get("default", "log-shipper").Return(nil, common.Error(common.ErrResourceNotFound))
+	sPolicy.EXPECT().Create(gomock.Any()).DoAndReturn(func(p *models.SidecarPolicy) (*models.SidecarPolicy, error) {
+		assert.Equal(t, policy, p)
+		return p, nil
+	})
+	sApp.EXPECT().List("default", &models.ListOptions{LabelSelector: "tier=web"}).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "web"}, {Name: "job"}}}, nil)
+	sApp.EXPECT().Get("default", "web", "").Return(web, nil)
+	sApp.EXPECT().Get("default", "job", "").Return(job, nil)
+	sApp.EXPECT().Update(nil, "default", web).Return(web, nil)
+	sNode.EXPECT().UpdateNodeAppVersion(nil, "default", web).Return([]string{"node01"}, nil)
+	body, _ := json.Marshal(policy)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/sidecarpolicies", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "fluent-bit")
+
+	// dry run
+	sPolicy.EXPECT().Get("default", "log-shipper").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodPost, "/v1/sidecarpolicies?dryRun=true", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sPolicy.EXPECT().Get("default", "log-shipper").Return(policy, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/sidecarpolicies", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	invalid := []*models.SidecarPolicy{getMockSidecarPolicy(), getMockSidecarPolicy(), getMockSidecarPolicy(), getMockSidecarPolicy()}
+	invalid[0].Selector = "tier in (web"
+	invalid[1].Sidecar.Image = ""
+	invalid[2].Volumes = append(invalid[2].Volumes, invalid[2].Volumes[0])
+	invalid[3].Sidecar.VolumeMounts[0].Name = "data"
+	for _, p := range invalid {
+		body, _ = json.Marshal(p)
+		req, _ = http.NewRequest(http.MethodPost, "/v1/sidecarpolicies", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestUpdateSidecarPolicy(t *testing.T) {
+	api, router, mockCtl := initSidecarPolicyAPI(t)
+	defer mockCtl.Finish()
+	sPolicy, sApp, sNode := ms.NewMockSidecarPolicyService(mockCtl), ms.NewMockApplicationService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.SidecarPolicy, api.Node = sPolicy, sNode
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	old := getMockSidecarPolicy()
+	policy := getMockSidecarPolicy()
+	policy.Selector = "tier in (web,api)"
+	web := &specV1.Application{Namespace: "default", Name: "web", Labels: map[string]string{"tier": "web"}}
+	api1 := &specV1.Application{Namespace: "default", Name: "api", Labels: map[string]string{"tier": "api"}}
+	sPolicy.EXPECT().Get("default", "log-shipper").Return(old, nil)
+	sPolicy.EXPECT().Update(gomock.Any()).DoAndReturn(func(p *models.SidecarPolicy) (*models.SidecarPolicy, error) {
+		return p, nil
+	})
+	// the apps selected by both are saved once
+	sApp.EXPECT().List("default", &models.ListOptions{LabelSelector: "tier=web"}).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "web"}}}, nil)
+	sApp.EXPECT().List("default", &models.ListOptions{LabelSelector: "tier in (web,api)"}).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "web"}, {Name: "api"}}}, nil)
+	sApp.EXPECT().Get("default", "web", "").Return(web, nil)
+	sApp.EXPECT().Get("default", "api", "").Return(api1, nil)
+	sApp.EXPECT().Update(nil, "default", web).Return(web, nil)
+	sApp.EXPECT().Update(nil, "default", api1).Return(api1, nil)
+	sNode.EXPECT().UpdateNodeAppVersion(nil, "default", gomock.Any()).Return(nil, nil).Times(2)
+	body, _ := json.Marshal(policy)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/sidecarpolicies/log-shipper", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// nothing changed
+	sPolicy.EXPECT().Get("default", "log-shipper").Return(old, nil)
+	body, _ = json.Marshal(old)
+	req, _ = http.NewRequest(http.MethodPut, "/v1/sidecarpolicies/log-shipper", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the apps are not saved if only the description is changed
+	policy = getMockSidecarPolicy()
+	policy.Description = "ship logs"
+	sPolicy.EXPECT().Get("default", "log-shipper").Return(old, nil)
+	sPolicy.EXPECT().Update(gomock.Any()).Return(policy, nil)
+	body, _ = json.Marshal(policy)
+	req, _ = http.NewRequest(http.MethodPut, "/v1/sidecarpolicies/log-shipper", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sPolicy.EXPECT().Get("default", "log-shipper").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodPut, "/v1/sidecarpolicies/log-shipper", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetAndDeleteSidecarPolicy(t *testing.T) {
+	api, router, mockCtl := initSidecarPolicyAPI(t)
+	defer mockCtl.Finish()
+	sPolicy, sApp, sNode := ms.NewMockSidecarPolicyService(mockCtl), ms.NewMockApplicationService(mockCtl), ms.NewMockNodeService(mockCtl)
+	api.SidecarPolicy, api.Node = sPolicy, sNode
+	api.AppCombinedService = &service.AppCombinedService{App: sApp}
+
+	policy := getMockSidecarPolicy()
+	sPolicy.EXPECT().Get("default", "log-shipper").Return(policy, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/sidecarpolicies/log-shipper", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sPolicy.EXPECT().List("default").Return([]models.SidecarPolicy{*policy}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/sidecarpolicies", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	// the sidecar is removed from the apps selected
+	web := &specV1.Application{Namespace: "default", Name: "web", Labels: map[string]string{"tier": "web"}}
+	sPolicy.EXPECT().Get("default", "log-shipper").Return(policy, nil)
+	sPolicy.EXPECT().Delete("default", "log-shipper").Return(nil)
+	sApp.EXPECT().List("default", &models.ListOptions{LabelSelector: "tier=web"}).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "web"}}}, nil)
+	sApp.EXPECT().Get("default", "web", "").Return(web, nil)
+	sApp.EXPECT().Update(nil, "default", web).Return(web, nil)
+	sNode.EXPECT().UpdateNodeAppVersion(nil, "default", web).Return(nil, nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/sidecarpolicies/log-shipper", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	sPolicy.EXPECT().Get("default", "log-shipper").Return(nil, common.Error(common.ErrResourceNotFound))
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/sidecarpolicies/log-shipper", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetNodeSiteSeed get the address node serves the large objects to its site at
+func (api *API) GetNodeSiteSeed(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := service.GetNodeSiteSeed(node)
+	if err != nil {
+		return nil, err
+	}
+	if seed == nil {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "seed"), common.Field("name", n), common.Field("namespace", ns))
+	}
+	return seed, nil
+}
+
+// UpdateNodeSiteSeed designate node as a seed of its site, the other nodes of site download the large objects from it
+func (api *API) UpdateNodeSiteSeed(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	seed := new(models.SiteSeed)
+	if err := c.LoadBody(seed); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if err := service.ValidateSiteSeed(seed); err != nil {
+		return nil, err
+	}
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if node.Labels[common.LabelSite] == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the node is not in any site, label it with "+common.LabelSite+" first"))
+	}
+	if node.Attributes == nil {
+		node.Attributes = map[string]interface{}{}
+	}
+	node.Attributes[common.AttributeSiteSeed] = &models.SiteSeed{Address: seed.Address}
+	if node, err = api.Node.Update(ns, node); err != nil {
+		return nil, err
+	}
+	log.L().Info("node site seed is set", log.Any("namespace", ns), log.Any("name", n), log.Any("address", seed.Address))
+	return service.GetNodeSiteSeed(node)
+}
+
+// DeleteNodeSiteSeed delete the seed of node, the nodes of its site download from the other seeds or the object storage
+func (api *API) DeleteNodeSiteSeed(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := node.Attributes[common.AttributeSiteSeed]; !ok {
+		return nil, nil
+	}
+	delete(node.Attributes, common.AttributeSiteSeed)
+	_, err = api.Node.Update(ns, node)
+	return nil, err
+}
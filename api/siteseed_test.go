@@ -0,0 +1,129 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initSiteSeedAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		nodes := v1.Group("/nodes")
+		nodes.GET("/:name/seed", mockIM, common.Wrapper(api.GetNodeSiteSeed))
+		nodes.PUT("/:name/seed", mockIM, common.Wrapper(api.UpdateNodeSiteSeed))
+		nodes.DELETE("/:name/seed", mockIM, common.Wrapper(api.DeleteNodeSiteSeed))
+	}
+	return api, router, mockCtl
+}
+
+func TestGetNodeSiteSeed(t *testing.T) {
+	api, router, mockCtl := initSiteSeedAPI(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+
+	node := &specV1.Node{
+		Namespace:  "default",
+		Name:       "node01",
+		Labels:     map[string]string{common.LabelSite: "f1"},
+		Attributes: map[string]interface{}{common.AttributeSiteSeed: map[string]interface{}{"address": "http://10.0.0.5:8088"}},
+	}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/seed", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.SiteSeed
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, models.SiteSeed{Site: "f1", Address: "http://10.0.0.5:8088"}, res)
+
+	// not a seed
+	sNode.EXPECT().Get(nil, "default", "node02").Return(&specV1.Node{Namespace: "default", Name: "node02"}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/node02/seed", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUpdateNodeSiteSeed(t *testing.T) {
+	api, router, mockCtl := initSiteSeedAPI(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+
+	seed := &models.SiteSeed{Address: "http://10.0.0.5:8088"}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01", Labels: map[string]string{common.LabelSite: "f1"}}, nil)
+	sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(_ string, n *specV1.Node) (*specV1.Node, error) {
+		assert.Equal(t, seed, n.Attributes[common.AttributeSiteSeed])
+		return n, nil
+	})
+	body, _ := json.Marshal(seed)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/nodes/node01/seed", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.SiteSeed
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "f1", res.Site)
+
+	// the node is not in any site
+	sNode.EXPECT().Get(nil, "default", "node02").Return(&specV1.Node{Namespace: "default", Name: "node02"}, nil)
+	req, _ = http.NewRequest(http.MethodPut, "/v1/nodes/node02/seed", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	for _, addr := range []string{"", "10.0.0.5:8088", "ftp://10.0.0.5"} {
+		body, _ = json.Marshal(&models.SiteSeed{Address: addr})
+		req, _ = http.NewRequest(http.MethodPut, "/v1/nodes/node01/seed", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestDeleteNodeSiteSeed(t *testing.T) {
+	api, router, mockCtl := initSiteSeedAPI(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+
+	node := &specV1.Node{
+		Namespace:  "default",
+		Name:       "node01",
+		Attributes: map[string]interface{}{common.AttributeSiteSeed: map[string]interface{}{"address": "http://10.0.0.5:8088"}},
+	}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(_ string, n *specV1.Node) (*specV1.Node, error) {
+		assert.NotContains(t, n.Attributes, common.AttributeSiteSeed)
+		return n, nil
+	})
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/nodes/node01/seed", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// nothing to delete
+	sNode.EXPECT().Get(nil, "default", "node02").Return(&specV1.Node{Namespace: "default", Name: "node02"}, nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/nodes/node02/seed", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
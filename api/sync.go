@@ -2,7 +2,11 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/baetyl/baetyl-go/v2/context"
 	"github.com/baetyl/baetyl-go/v2/errors"
@@ -11,6 +15,7 @@ import (
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
 	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 	"github.com/baetyl/baetyl-cloud/v2/service"
 )
 
@@ -19,15 +24,36 @@ import (
 type SyncAPI interface {
 	Report(msg specV1.Message) (*specV1.Message, error)
 	Desire(msg specV1.Message) (*specV1.Message, error)
+	DesirePatch(msg specV1.Message) (*specV1.Message, error)
+	DesireWatch(msg specV1.Message) (<-chan *specV1.Message, func(), error)
+	Exec(msg specV1.Message) (*specV1.Message, error)
 }
 
 type SyncAPIImpl struct {
-	Sync service.SyncService
-	Node service.NodeService
-	log  *log.Logger
+	Sync      service.SyncService
+	Node      service.NodeService
+	Tunnel    service.ExecService
+	Health    service.HealthService
+	Metrics   service.NodeMetricsService
+	Heartbeat service.HeartbeatService
+	Sign      service.SyncSignService
+	Telemetry service.TelemetryService
+	History   service.SyncHistoryService
+	// reports the pool handling the reports, which are handled at once if it's nil
+	reports *common.WorkerPool
+	// checks the pool checking the health of nodes reported
+	checks *common.WorkerPool
+	// beats the pool beating the heartbeats of nodes reported
+	beats *common.WorkerPool
+	// forwards the pool forwarding the telemetry of nodes reported
+	forwards *common.WorkerPool
+	// records the pool storing the sync history of nodes
+	records *common.WorkerPool
+	log     *log.Logger
 }
 
-func NewSyncAPI(cfg *config.CloudConfig) (SyncAPI, error) {
+// NewSyncAPI the health service is shared with the admin api, so the checks see the thresholds updated at once
+func NewSyncAPI(cfg *config.CloudConfig, healthService service.HealthService) (SyncAPI, error) {
 	syncService, err := service.NewSyncService(cfg)
 	if err != nil {
 		return nil, err
@@ -36,15 +62,67 @@ func NewSyncAPI(cfg *config.CloudConfig) (SyncAPI, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the tunnel of sync only exchanges the frames, the sessions are closed and audited by the admin api
+	execService, err := service.NewExecService(cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	metricsService, err := service.NewNodeMetricsService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	heartbeatService, err := service.NewHeartbeatService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	signService, err := service.NewSyncSignService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	telemetryService, err := service.NewTelemetryService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	historyService, err := service.NewSyncHistoryService(cfg)
+	if err != nil {
+		return nil, err
+	}
 	return &SyncAPIImpl{
-		Sync: syncService,
-		Node: nodeService,
-		log:  log.L().With(log.Any("api", "sync")),
+		Sync:      syncService,
+		Node:      nodeService,
+		Tunnel:    execService,
+		Health:    healthService,
+		Metrics:   metricsService,
+		Heartbeat: heartbeatService,
+		Sign:      signService,
+		Telemetry: telemetryService,
+		History:   historyService,
+		reports:   common.NewWorkerPool(cfg.ReportQueue.Workers, cfg.ReportQueue.Size, cfg.ReportQueue.Timeout, cfg.ReportQueue.RetryAfter),
+		checks:    common.NewWorkerPool(cfg.ReportTasks.Workers, cfg.ReportTasks.Size, 0, 0),
+		beats:     common.NewWorkerPool(cfg.ReportTasks.Workers, cfg.ReportTasks.Size, 0, 0),
+		forwards:  common.NewWorkerPool(cfg.ReportTasks.Workers, cfg.ReportTasks.Size, 0, 0),
+		records:   common.NewWorkerPool(cfg.ReportTasks.Workers, cfg.ReportTasks.Size, 0, 0),
+		log:       log.L().With(log.Any("api", "sync")),
 	}, nil
 }
 
-// Report for node report
+// Report for node report, the reports are queued and rejected with retry-after once overloaded,
+// so that the bursts of reports don't overwhelm the storage
 func (s *SyncAPIImpl) Report(msg specV1.Message) (*specV1.Message, error) {
+	return s.recorded(msg, func(msg specV1.Message) (*specV1.Message, error) {
+		var res *specV1.Message
+		err := s.reports.Do(func() (err error) {
+			res, err = s.report(msg)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+func (s *SyncAPIImpl) report(msg specV1.Message) (*specV1.Message, error) {
 	var report specV1.Report
 	err := msg.Content.Unmarshal(&report)
 	if err != nil {
@@ -52,6 +130,12 @@ func (s *SyncAPIImpl) Report(msg specV1.Message) (*specV1.Message, error) {
 	}
 
 	setNodeClientIPIfExist(msg, &report)
+	if version, err := models.SyncProtocolFromMetadata(msg.Metadata); err == nil {
+		report[common.NodeSyncProtocol] = version
+	}
+	// the telemetry is forwarded to the sinks instead of being stored in the shadow
+	telemetry, hasTelemetry := report[common.NodeTelemetry]
+	delete(report, common.NodeTelemetry)
 
 	// TODO remove the trick. set node prop if source=baetyl-init
 	ns, n := msg.Metadata["namespace"], msg.Metadata["name"]
@@ -94,6 +178,37 @@ func (s *SyncAPIImpl) Report(msg specV1.Message) (*specV1.Message, error) {
 
 	s.log.Debug("api sync", log.Any("delta", delta), log.Any("report", report))
 
+	if s.Metrics != nil {
+		if err := s.Metrics.Record(ns, n, report); err != nil {
+			s.log.Warn("failed to record node metrics", log.Any("namespace", ns), log.Any("name", n), log.Error(err))
+		}
+	}
+
+	if s.Heartbeat != nil {
+		s.background(s.beats, "heartbeat", ns, n, func() {
+			if err := s.Heartbeat.Beat(ns, n); err != nil {
+				s.log.Warn("failed to beat node heartbeat", log.Any("namespace", ns), log.Any("name", n), log.Error(err))
+			}
+		})
+	}
+
+	if s.Telemetry != nil && hasTelemetry {
+		s.background(s.forwards, "telemetry", ns, n, func() {
+			if err := s.Telemetry.Forward(ns, n, telemetry); err != nil {
+				s.log.Warn("failed to forward node telemetry", log.Any("namespace", ns), log.Any("name", n), log.Error(err))
+			}
+		})
+	}
+
+	if s.Health != nil {
+		// the alert is posted in background to keep the report of node fast
+		s.background(s.checks, "health check", ns, n, func() {
+			if err := s.Health.Check(ns, n); err != nil {
+				s.log.Warn("failed to check node health", log.Any("namespace", ns), log.Any("name", n), log.Error(err))
+			}
+		})
+	}
+
 	return &specV1.Message{
 		Kind:     specV1.MessageReport,
 		Metadata: msg.Metadata,
@@ -103,6 +218,10 @@ func (s *SyncAPIImpl) Report(msg specV1.Message) (*specV1.Message, error) {
 
 // Desire for node synchronize desire info
 func (s *SyncAPIImpl) Desire(msg specV1.Message) (*specV1.Message, error) {
+	return s.recorded(msg, s.desire)
+}
+
+func (s *SyncAPIImpl) desire(msg specV1.Message) (*specV1.Message, error) {
 	var desireRes specV1.DesireRequest
 	err := msg.Content.Unmarshal(&desireRes)
 	if err != nil {
@@ -120,6 +239,199 @@ func (s *SyncAPIImpl) Desire(msg specV1.Message) (*specV1.Message, error) {
 	}, nil
 }
 
+// DesirePatch for node to synchronize the desire info as the patches against the resources it has
+func (s *SyncAPIImpl) DesirePatch(msg specV1.Message) (*specV1.Message, error) {
+	return s.recorded(msg, s.desirePatch)
+}
+
+func (s *SyncAPIImpl) desirePatch(msg specV1.Message) (*specV1.Message, error) {
+	var req models.DesirePatchRequest
+	err := msg.Content.Unmarshal(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.Sync.DesirePatch(msg.Metadata["namespace"], req.Infos, msg.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &specV1.Message{
+		Kind:     models.MessageDesirePatch,
+		Metadata: msg.Metadata,
+		Content:  specV1.LazyValue{Value: models.DesirePatchResponse{Values: res}},
+	}, nil
+}
+
+// DesireWatch for node to watch the changes of its desire, a desireChanged message is pushed once the desire is changed.
+// The returned function stops the watch, after which the channel is closed
+func (s *SyncAPIImpl) DesireWatch(msg specV1.Message) (<-chan *specV1.Message, func(), error) {
+	ns, n := msg.Metadata["namespace"], msg.Metadata["name"]
+	notify, stop, err := s.Node.WatchDesire(ns, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	out, done := make(chan *specV1.Message), make(chan struct{})
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-done:
+				return
+			case <-notify:
+			}
+			push := &specV1.Message{
+				Kind:     models.MessageDesireChanged,
+				Metadata: map[string]string{"namespace": ns, "name": n},
+			}
+			select {
+			case <-done:
+				return
+			case out <- push:
+			}
+		}
+	}()
+	var once sync.Once
+	return out, func() {
+		once.Do(func() {
+			close(done)
+			stop()
+		})
+	}, nil
+}
+
+// Exec for node to send the output of exec sessions and receive the input
+func (s *SyncAPIImpl) Exec(msg specV1.Message) (*specV1.Message, error) {
+	return s.recorded(msg, s.exec)
+}
+
+func (s *SyncAPIImpl) exec(msg specV1.Message) (*specV1.Message, error) {
+	var req models.ExecSync
+	err := msg.Content.Unmarshal(&req)
+	if err != nil {
+		return nil, err
+	}
+	ns, n := msg.Metadata["namespace"], msg.Metadata["name"]
+	frames, err := s.Tunnel.Sync(ns, n, req.Frames)
+	if err != nil {
+		return nil, err
+	}
+	if frames == nil {
+		frames = []models.ExecFrame{}
+	}
+	return &specV1.Message{
+		Kind:     models.MessageExec,
+		Metadata: msg.Metadata,
+		Content:  specV1.LazyValue{Value: models.ExecSync{Frames: frames}},
+	}, nil
+}
+
+// recorded handles the message negotiated and records the exchange in the sync history, including the ones rejected.
+// The record is stored asynchronously, so that the history being unavailable doesn't fail the sync of node
+func (s *SyncAPIImpl) recorded(msg specV1.Message, handle func(specV1.Message) (*specV1.Message, error)) (*specV1.Message, error) {
+	if s.History == nil {
+		return s.negotiated(msg, handle)
+	}
+	start := time.Now()
+	res, err := s.negotiated(msg, handle)
+	record := &models.SyncRecord{
+		Namespace:     msg.Metadata["namespace"],
+		Name:          msg.Metadata["name"],
+		Kind:          string(msg.Kind),
+		Time:          start.UTC(),
+		Latency:       time.Since(start).Milliseconds(),
+		RequestSize:   contentSize(&msg.Content),
+		ClientVersion: msg.Metadata["user-agent"],
+		ClientIP:      msg.Metadata["clientIP"],
+	}
+	record.Protocol, _ = models.SyncProtocolFromMetadata(msg.Metadata)
+	if res != nil {
+		record.ResponseSize = contentSize(&res.Content)
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	s.background(s.records, "sync record", record.Namespace, record.Name, func() {
+		if err := s.History.Record(record); err != nil {
+			s.log.Warn("failed to record node sync", log.Any("namespace", record.Namespace), log.Any("name", record.Name), log.Error(err))
+		}
+	})
+	return res, err
+}
+
+// background runs the task following the sync of node by the pool, the task is dropped once the queue is full,
+// so that the nodes keep syncing while the services behind are slow
+func (s *SyncAPIImpl) background(pool *common.WorkerPool, task, ns, n string, run func()) {
+	if !pool.Go(run) {
+		s.log.Warn(task+" dropped since the queue is full", log.Any("namespace", ns), log.Any("name", n))
+	}
+}
+
+// contentSize returns the size of the content in json, which is 0 if it can't be marshaled
+func contentSize(content *specV1.LazyValue) int {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// negotiated negotiates the protocol version with node before handling the message signed, the version negotiated is
+// set in the reply. The replies to the older versions are adapted by the sync service according to the version in
+// metadata, so that the features they don't know are left out
+func (s *SyncAPIImpl) negotiated(msg specV1.Message, handle func(specV1.Message) (*specV1.Message, error)) (*specV1.Message, error) {
+	version, err := models.SyncProtocolFromMetadata(msg.Metadata)
+	if err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the sync protocol version is invalid"))
+	}
+	if version < models.SyncProtocolMinimum {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error",
+			fmt.Sprintf("the sync protocol version %d of node is not supported any more, which should be at least %d", version, models.SyncProtocolMinimum)))
+	}
+	res, err := s.signed(msg, handle)
+	if err != nil {
+		return nil, err
+	}
+	md := make(map[string]string, len(res.Metadata)+1)
+	for k, v := range res.Metadata {
+		md[k] = v
+	}
+	md[models.SyncProtocolVersion] = strconv.Itoa(models.NegotiateSyncProtocol(version))
+	res.Metadata = md
+	return res, nil
+}
+
+// signed verifies the signature of the message from node before handling, and signs the reply if the message is signed,
+// so that the node verifies the reply is to its message
+func (s *SyncAPIImpl) signed(msg specV1.Message, handle func(specV1.Message) (*specV1.Message, error)) (*specV1.Message, error) {
+	if s.Sign == nil {
+		return handle(msg)
+	}
+	ns, n := msg.Metadata["namespace"], msg.Metadata["name"]
+	sig, err := models.SyncSignatureFromMetadata(msg.Metadata)
+	if err != nil {
+		return nil, common.Error(common.ErrRequestAccessDenied, common.Field("error", err.Error()))
+	}
+	if err = s.Sign.Verify(ns, n, string(msg.Kind), sig, &msg.Content); err != nil {
+		return nil, err
+	}
+	res, err := handle(msg)
+	if err != nil || sig == nil {
+		return res, err
+	}
+	replySig, err := s.Sign.Sign(ns, n, string(res.Kind), sig, &res.Content)
+	if err != nil {
+		return nil, err
+	}
+	// the metadata of reply may be the one of message
+	md := make(map[string]string, len(res.Metadata)+4)
+	for k, v := range res.Metadata {
+		md[k] = v
+	}
+	replySig.ToMetadata(md)
+	res.Metadata = md
+	return res, nil
+}
+
 func (s *SyncAPIImpl) updateAndroidInfo(node *specV1.Node, report *specV1.Report) error {
 	nodeVal, ok := (*report)[common.NodeInfo]
 	if !ok {
@@ -3,21 +3,25 @@ package api
 import (
 	"encoding/json"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/errors"
 	"github.com/baetyl/baetyl-go/v2/log"
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/baetyl/baetyl-cloud/v2/common"
 	"github.com/baetyl/baetyl-cloud/v2/config"
 	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 )
 
 func TestNewSyncAPI(t *testing.T) {
 	// bad case
-	_, err := NewSyncAPI(&config.CloudConfig{})
+	_, err := NewSyncAPI(&config.CloudConfig{}, nil)
 	assert.Error(t, err)
 }
 
@@ -50,7 +54,7 @@ func TestSyncAPIImpl_Report(t *testing.T) {
 	resp := specV1.Delta{}
 	expMsg := &specV1.Message{
 		Kind:     msg.Kind,
-		Metadata: msg.Metadata,
+		Metadata: map[string]string{"name": "test", "namespace": "default", models.SyncProtocolVersion: "1"},
 		Content:  specV1.LazyValue{},
 	}
 	mSync.EXPECT().Report("default", "test", gomock.Any()).Return(resp, nil).Times(1)
@@ -63,6 +67,61 @@ func TestSyncAPIImpl_Report(t *testing.T) {
 	mSync.EXPECT().Report("default", "test", gomock.Any()).Return(nil, os.ErrInvalid).Times(1)
 	_, err = sync.Report(msg)
 	assert.Error(t, err)
+
+	// the health of node is checked after report in background
+	mHealth := ms.NewMockHealthService(mockCtl)
+	sync.Health = mHealth
+	sync.checks = common.NewWorkerPool(1, 1, 0, 0)
+	checked := make(chan struct{})
+	mSync.EXPECT().Report("default", "test", gomock.Any()).Return(resp, nil).Times(1)
+	mHealth.EXPECT().Check("default", "test").DoAndReturn(func(_, _ string) error {
+		close(checked)
+		return os.ErrInvalid
+	})
+	_, err = sync.Report(msg)
+	assert.NoError(t, err)
+	<-checked
+
+	// the metrics of node is recorded, the failure does not break the report
+	mMetrics := ms.NewMockNodeMetricsService(mockCtl)
+	sync.Metrics, sync.Health = mMetrics, nil
+	mSync.EXPECT().Report("default", "test", gomock.Any()).Return(resp, nil).Times(1)
+	mMetrics.EXPECT().Record("default", "test", gomock.Any()).Return(os.ErrInvalid)
+	_, err = sync.Report(msg)
+	assert.NoError(t, err)
+
+	// the heartbeat of node is beaten after report
+	mHeartbeat := ms.NewMockHeartbeatService(mockCtl)
+	sync.Metrics, sync.Heartbeat = nil, mHeartbeat
+	beaten := make(chan struct{})
+	mSync.EXPECT().Report("default", "test", gomock.Any()).Return(resp, nil).Times(1)
+	mHeartbeat.EXPECT().Beat("default", "test").DoAndReturn(func(_, _ string) error {
+		close(beaten)
+		return nil
+	})
+	_, err = sync.Report(msg)
+	assert.NoError(t, err)
+	<-beaten
+
+	// the heartbeat is dropped once the queue is full, the report goes on
+	sync.beats = common.NewWorkerPool(1, 1, 0, 0)
+	beating, release := make(chan struct{}, 2), make(chan struct{})
+	mSync.EXPECT().Report("default", "test", gomock.Any()).Return(resp, nil).Times(3)
+	mHeartbeat.EXPECT().Beat("default", "test").DoAndReturn(func(_, _ string) error {
+		beating <- struct{}{}
+		<-release
+		return nil
+	}).Times(2)
+	_, err = sync.Report(msg)
+	assert.NoError(t, err)
+	<-beating
+	// the second is queued and the third is dropped
+	for i := 0; i < 2; i++ {
+		_, err = sync.Report(msg)
+		assert.NoError(t, err)
+	}
+	close(release)
+	<-beating
 }
 
 func TestSyncAPIImpl_Desire(t *testing.T) {
@@ -86,7 +145,7 @@ func TestSyncAPIImpl_Desire(t *testing.T) {
 	resp := []specV1.ResourceValue{}
 	expMsg := &specV1.Message{
 		Kind:     msg.Kind,
-		Metadata: msg.Metadata,
+		Metadata: map[string]string{"namespace": "default", models.SyncProtocolVersion: "1"},
 		Content:  specV1.LazyValue{},
 	}
 	mSync.EXPECT().Desire("default", nil, msg.Metadata).Return(resp, nil).Times(1)
@@ -101,6 +160,264 @@ func TestSyncAPIImpl_Desire(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSyncAPIImpl_DesirePatch(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mSync := ms.NewMockSyncService(mockCtl)
+	sync := &SyncAPIImpl{Sync: mSync}
+
+	infos := []models.DesirePatchInfo{{ResourceInfo: specV1.ResourceInfo{Kind: specV1.KindApplication, Name: "app", Version: "v1"}, Hash: "h0"}}
+	msg := specV1.Message{
+		Kind:     models.MessageDesirePatch,
+		Metadata: map[string]string{"namespace": "default"},
+		Content:  specV1.LazyValue{},
+	}
+	bt, err := json.Marshal(models.DesirePatchRequest{Infos: infos})
+	assert.NoError(t, err)
+	err = msg.Content.UnmarshalJSON(bt)
+	assert.NoError(t, err)
+	values := []models.DesirePatchValue{{ResourceInfo: infos[0].ResourceInfo, Hash: "h1", Base: "h0", Patch: json.RawMessage(`{"version":"v1"}`)}}
+	mSync.EXPECT().DesirePatch("default", infos, msg.Metadata).Return(values, nil).Times(1)
+	res, err := sync.DesirePatch(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, models.MessageDesirePatch, res.Kind)
+	assert.Equal(t, models.DesirePatchResponse{Values: values}, res.Content.Value)
+
+	mSync.EXPECT().DesirePatch("default", infos, msg.Metadata).Return(nil, os.ErrInvalid).Times(1)
+	_, err = sync.DesirePatch(msg)
+	assert.Error(t, err)
+}
+
+func TestSyncAPIImpl_DesireWatch(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNode := ms.NewMockNodeService(mockCtl)
+	sync := &SyncAPIImpl{Node: mNode}
+	msg := specV1.Message{
+		Kind:     models.MessageDesireWatch,
+		Metadata: map[string]string{"name": "test", "namespace": "default"},
+	}
+
+	notify, stopped := make(chan struct{}, 1), 0
+	mNode.EXPECT().WatchDesire("default", "test").Return((<-chan struct{})(notify), func() { stopped++ }, nil)
+	pushes, stop, err := sync.DesireWatch(msg)
+	assert.NoError(t, err)
+	notify <- struct{}{}
+	push := <-pushes
+	assert.Equal(t, models.MessageDesireChanged, push.Kind)
+	assert.Equal(t, map[string]string{"name": "test", "namespace": "default"}, push.Metadata)
+
+	// the channel is closed once stopped
+	stop()
+	stop()
+	_, ok := <-pushes
+	assert.False(t, ok)
+	assert.Equal(t, 1, stopped)
+
+	mNode.EXPECT().WatchDesire("default", "test").Return(nil, nil, os.ErrInvalid)
+	_, _, err = sync.DesireWatch(msg)
+	assert.Error(t, err)
+}
+
+func TestSyncAPIImpl_Exec(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sync := &SyncAPIImpl{}
+	mExec := ms.NewMockExecService(mockCtl)
+	sync.Tunnel = mExec
+
+	req := models.ExecSync{Frames: []models.ExecFrame{{Session: "s1", Type: models.ExecFrameStdout, Data: []byte("a")}}}
+	msg := specV1.Message{
+		Kind:     models.MessageExec,
+		Metadata: map[string]string{"name": "test", "namespace": "default"},
+		Content:  specV1.LazyValue{},
+	}
+	bt, err := json.Marshal(req)
+	assert.NoError(t, err)
+	err = msg.Content.UnmarshalJSON(bt)
+	assert.NoError(t, err)
+
+	frames := []models.ExecFrame{{Session: "s1", Type: models.ExecFrameStdin, Data: []byte("b")}}
+	mExec.EXPECT().Sync("default", "test", req.Frames).Return(frames, nil)
+	res, err := sync.Exec(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, models.MessageExec, res.Kind)
+	assert.Equal(t, models.ExecSync{Frames: frames}, res.Content.Value)
+
+	mExec.EXPECT().Sync("default", "test", req.Frames).Return(nil, nil)
+	res, err = sync.Exec(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ExecSync{Frames: []models.ExecFrame{}}, res.Content.Value)
+
+	mExec.EXPECT().Sync("default", "test", req.Frames).Return(nil, os.ErrInvalid)
+	_, err = sync.Exec(msg)
+	assert.Error(t, err)
+}
+
+func TestSyncAPIImpl_Signed(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mExec, mSign := ms.NewMockExecService(mockCtl), ms.NewMockSyncSignService(mockCtl)
+	sync := &SyncAPIImpl{Tunnel: mExec, Sign: mSign}
+
+	req := models.ExecSync{Frames: []models.ExecFrame{}}
+	bt, err := json.Marshal(req)
+	assert.NoError(t, err)
+	msg := specV1.Message{Kind: models.MessageExec, Metadata: map[string]string{"name": "test", "namespace": "default"}}
+	assert.NoError(t, msg.Content.UnmarshalJSON(bt))
+
+	// the reply to the message without signature is not signed
+	mSign.EXPECT().Verify("default", "test", string(models.MessageExec), nil, gomock.Any()).Return(nil)
+	mExec.EXPECT().Sync("default", "test", req.Frames).Return(nil, nil)
+	res, err := sync.Exec(msg)
+	assert.NoError(t, err)
+	assert.Empty(t, res.Metadata[models.SyncSignSignature])
+
+	sig := &models.SyncSignature{KeyID: "k1", Timestamp: 1600000000, Nonce: "n1", Signature: "s1"}
+	sig.ToMetadata(msg.Metadata)
+	replySig := &models.SyncSignature{KeyID: "k1", Timestamp: 1600000001, Nonce: "n1", Signature: "s2"}
+	mSign.EXPECT().Verify("default", "test", string(models.MessageExec), sig, gomock.Any()).Return(nil)
+	mExec.EXPECT().Sync("default", "test", req.Frames).Return(nil, nil)
+	mSign.EXPECT().Sign("default", "test", string(models.MessageExec), sig, gomock.Any()).Return(replySig, nil)
+	res, err = sync.Exec(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, "s2", res.Metadata[models.SyncSignSignature])
+	assert.Equal(t, "1600000001", res.Metadata[models.SyncSignTimestamp])
+	// the metadata of message is untouched
+	assert.Equal(t, "s1", msg.Metadata[models.SyncSignSignature])
+
+	// the message is rejected before handling
+	mSign.EXPECT().Verify("default", "test", string(models.MessageExec), sig, gomock.Any()).Return(common.Error(common.ErrRequestAccessDenied))
+	_, err = sync.Exec(msg)
+	assert.Error(t, err)
+
+	msg.Metadata[models.SyncSignTimestamp] = "bad"
+	_, err = sync.Exec(msg)
+	assert.Error(t, err)
+}
+
+func TestSyncAPIImpl_Telemetry(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mSync, mTelemetry := ms.NewMockSyncService(mockCtl), ms.NewMockTelemetryService(mockCtl)
+	sync := &SyncAPIImpl{Sync: mSync, Telemetry: mTelemetry, log: log.L().With(log.Any("test", "sync"))}
+
+	msg := specV1.Message{
+		Kind:     specV1.MessageReport,
+		Metadata: map[string]string{"name": "test", "namespace": "default"},
+	}
+	assert.NoError(t, msg.Content.UnmarshalJSON([]byte(`{"apps":[],"telemetry":{"output":12}}`)))
+	// the telemetry is forwarded without being stored in the shadow
+	mSync.EXPECT().Report("default", "test", gomock.Any()).DoAndReturn(func(_, _ string, report specV1.Report) (specV1.Delta, error) {
+		assert.NotContains(t, report, common.NodeTelemetry)
+		assert.Contains(t, report, "apps")
+		return specV1.Delta{}, nil
+	})
+	forwarded := make(chan struct{})
+	mTelemetry.EXPECT().Forward("default", "test", gomock.Any()).DoAndReturn(func(_, _ string, data interface{}) error {
+		raw, err := json.Marshal(data)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"output":12}`, string(raw))
+		close(forwarded)
+		return os.ErrInvalid
+	})
+	_, err := sync.Report(msg)
+	assert.NoError(t, err)
+	<-forwarded
+
+	// nothing is forwarded without telemetry
+	assert.NoError(t, msg.Content.UnmarshalJSON([]byte(`{"apps":[]}`)))
+	mSync.EXPECT().Report("default", "test", gomock.Any()).Return(specV1.Delta{}, nil)
+	_, err = sync.Report(msg)
+	assert.NoError(t, err)
+}
+
+func TestSyncAPIImpl_History(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mSync, mHistory := ms.NewMockSyncService(mockCtl), ms.NewMockSyncHistoryService(mockCtl)
+	sync := &SyncAPIImpl{Sync: mSync, History: mHistory, log: log.L().With(log.Any("test", "sync"))}
+
+	msg := specV1.Message{
+		Kind: specV1.MessageReport,
+		Metadata: map[string]string{
+			"name":                     "test",
+			"namespace":                "default",
+			"user-agent":               "baetyl-core/v2.4.3",
+			"clientIP":                 "10.0.0.1",
+			models.SyncProtocolVersion: "3",
+		},
+	}
+	assert.NoError(t, msg.Content.UnmarshalJSON([]byte(`{"apps":[]}`)))
+	records := make(chan *models.SyncRecord, 2)
+	mHistory.EXPECT().Record(gomock.Any()).DoAndReturn(func(r *models.SyncRecord) error {
+		records <- r
+		return os.ErrInvalid
+	}).Times(2)
+
+	mSync.EXPECT().Report("default", "test", gomock.Any()).Return(specV1.Delta{}, nil)
+	_, err := sync.Report(msg)
+	assert.NoError(t, err)
+	r := <-records
+	assert.Equal(t, "default", r.Namespace)
+	assert.Equal(t, "test", r.Name)
+	assert.Equal(t, string(specV1.MessageReport), r.Kind)
+	assert.Equal(t, 3, r.Protocol)
+	assert.Equal(t, "baetyl-core/v2.4.3", r.ClientVersion)
+	assert.Equal(t, "10.0.0.1", r.ClientIP)
+	assert.Greater(t, r.RequestSize, 0)
+	assert.Greater(t, r.ResponseSize, 0)
+	assert.Empty(t, r.Error)
+
+	// the exchanges rejected are recorded too
+	msg.Metadata[models.SyncProtocolVersion] = "x"
+	_, err = sync.Report(msg)
+	assert.Error(t, err)
+	r = <-records
+	assert.Equal(t, 0, r.Protocol)
+	assert.Zero(t, r.ResponseSize)
+	assert.NotEmpty(t, r.Error)
+}
+
+func TestSyncAPIImpl_Negotiated(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mSync := ms.NewMockSyncService(mockCtl)
+	sync := &SyncAPIImpl{Sync: mSync, log: log.L().With(log.Any("test", "sync"))}
+
+	msg := specV1.Message{
+		Kind:     specV1.MessageReport,
+		Metadata: map[string]string{"name": "test", "namespace": "default", models.SyncProtocolVersion: "5"},
+	}
+	assert.NoError(t, msg.Content.UnmarshalJSON([]byte(`{}`)))
+	// the version node speaks is recorded in its report, and the reply is in the current version
+	mSync.EXPECT().Report("default", "test", gomock.Any()).DoAndReturn(func(_, _ string, report specV1.Report) (specV1.Delta, error) {
+		assert.Equal(t, 5, report[common.NodeSyncProtocol])
+		return specV1.Delta{}, nil
+	})
+	res, err := sync.Report(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(models.SyncProtocolCurrent), res.Metadata[models.SyncProtocolVersion])
+	assert.Equal(t, "5", msg.Metadata[models.SyncProtocolVersion])
+
+	msg = specV1.Message{
+		Kind:     specV1.MessageDesire,
+		Metadata: map[string]string{"namespace": "default", models.SyncProtocolVersion: "2"},
+	}
+	assert.NoError(t, msg.Content.UnmarshalJSON([]byte(`{}`)))
+	mSync.EXPECT().Desire("default", nil, msg.Metadata).Return(nil, nil)
+	res, err = sync.Desire(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", res.Metadata[models.SyncProtocolVersion])
+
+	// the versions unknown are rejected before handling
+	for _, v := range []string{"0", "v3"} {
+		msg.Metadata[models.SyncProtocolVersion] = v
+		_, err = sync.Desire(msg)
+		assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+	}
+}
+
 func TestSyncAPIImpl_updateAndroidInfo(t *testing.T) {
 	mockCtl := gomock.NewController(t)
 	defer mockCtl.Finish()
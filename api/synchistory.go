@@ -0,0 +1,14 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+// GetNodeSyncHistory get the latest sync exchanges of node with the stats of them
+func (api *API) GetNodeSyncHistory(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	if _, err := api.Node.Get(nil, ns, n); err != nil {
+		return nil, err
+	}
+	return api.SyncHistory.Get(ns, n)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestGetNodeSyncHistory(t *testing.T) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	router.GET("/v1/nodes/:name/sync-history", mockIM, common.Wrapper(api.GetNodeSyncHistory))
+	sNode, sHistory := ms.NewMockNodeService(mockCtl), ms.NewMockSyncHistoryService(mockCtl)
+	api.Node, api.SyncHistory = sNode, sHistory
+
+	history := &models.SyncHistory{
+		Items: []models.SyncRecord{{Kind: "report", Latency: 12, Protocol: 3}},
+		Stats: models.SyncStats{Total: 1, Kinds: map[string]int{"report": 1}, AvgLatency: 12, MaxLatency: 12},
+	}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01"}, nil)
+	sHistory.EXPECT().Get("default", "node01").Return(history, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/sync-history", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.SyncHistory
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, history.Items[0].Kind, res.Items[0].Kind)
+	assert.Equal(t, history.Stats.Kinds, res.Stats.Kinds)
+
+	sNode.EXPECT().Get(nil, "default", "node02").Return(nil, common.Error(common.ErrResourceNotFound, common.Field("type", "node"), common.Field("name", "node02")))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes/node02/sync-history", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
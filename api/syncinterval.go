@@ -0,0 +1,59 @@
+package api
+
+import (
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+// GetNodeSyncInterval get the sync intervals in effect for node
+func (api *API) GetNodeSyncInterval(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	return api.SyncInterval.Get(node)
+}
+
+// UpdateNodeSyncInterval set the sync intervals of node, which override the intervals of its groups
+func (api *API) UpdateNodeSyncInterval(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	interval := new(models.SyncInterval)
+	if err := c.LoadBody(interval); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if err := service.ValidateSyncInterval(interval); err != nil {
+		return nil, err
+	}
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if node.Attributes == nil {
+		node.Attributes = map[string]interface{}{}
+	}
+	node.Attributes[common.AttributeSyncInterval] = interval
+	if node, err = api.Node.Update(ns, node); err != nil {
+		return nil, err
+	}
+	log.L().Info("node sync interval is set", log.Any("namespace", ns), log.Any("name", n), log.Any("interval", interval))
+	return api.SyncInterval.Get(node)
+}
+
+// DeleteNodeSyncInterval delete the sync intervals of node, the intervals of its groups are in effect again
+func (api *API) DeleteNodeSyncInterval(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := node.Attributes[common.AttributeSyncInterval]; !ok {
+		return nil, nil
+	}
+	delete(node.Attributes, common.AttributeSyncInterval)
+	_, err = api.Node.Update(ns, node)
+	return nil, err
+}
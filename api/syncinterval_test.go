@@ -0,0 +1,115 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func initSyncIntervalAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	v1 := router.Group("v1")
+	{
+		nodes := v1.Group("/nodes")
+		nodes.GET("/:name/interval", mockIM, common.Wrapper(api.GetNodeSyncInterval))
+		nodes.PUT("/:name/interval", mockIM, common.Wrapper(api.UpdateNodeSyncInterval))
+		nodes.DELETE("/:name/interval", mockIM, common.Wrapper(api.DeleteNodeSyncInterval))
+	}
+	return api, router, mockCtl
+}
+
+func TestGetNodeSyncInterval(t *testing.T) {
+	api, router, mockCtl := initSyncIntervalAPI(t)
+	defer mockCtl.Finish()
+	sNode, sInterval := ms.NewMockNodeService(mockCtl), ms.NewMockSyncIntervalService(mockCtl)
+	api.Node, api.SyncInterval = sNode, sInterval
+
+	node := &specV1.Node{Namespace: "default", Name: "node01"}
+	interval := &models.SyncInterval{Report: "1h", Desire: "1h", Jitter: "5m"}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	sInterval.EXPECT().Get(node).Return(&models.NodeSyncInterval{Interval: interval, Group: "g1"}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/interval", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res models.NodeSyncInterval
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, interval, res.Interval)
+	assert.Equal(t, "g1", res.Group)
+}
+
+func TestUpdateNodeSyncInterval(t *testing.T) {
+	api, router, mockCtl := initSyncIntervalAPI(t)
+	defer mockCtl.Finish()
+	sNode, sInterval := ms.NewMockNodeService(mockCtl), ms.NewMockSyncIntervalService(mockCtl)
+	api.Node, api.SyncInterval = sNode, sInterval
+
+	interval := &models.SyncInterval{Report: "10s", Desire: "10s", Jitter: "2s"}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01"}, nil)
+	sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(_ string, n *specV1.Node) (*specV1.Node, error) {
+		assert.Equal(t, interval, n.Attributes[common.AttributeSyncInterval])
+		return n, nil
+	})
+	sInterval.EXPECT().Get(gomock.Any()).Return(&models.NodeSyncInterval{Interval: interval}, nil)
+	body, _ := json.Marshal(interval)
+	req, _ := http.NewRequest(http.MethodPut, "/v1/nodes/node01/interval", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	for _, b := range []*models.SyncInterval{
+		{},
+		{Report: "1d"},
+		{Report: "10s", Jitter: "1m"},
+	} {
+		body, _ = json.Marshal(b)
+		req, _ = http.NewRequest(http.MethodPut, "/v1/nodes/node01/interval", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestDeleteNodeSyncInterval(t *testing.T) {
+	api, router, mockCtl := initSyncIntervalAPI(t)
+	defer mockCtl.Finish()
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+
+	node := &specV1.Node{
+		Namespace:  "default",
+		Name:       "node01",
+		Attributes: map[string]interface{}{common.AttributeSyncInterval: map[string]interface{}{"report": "1h"}},
+	}
+	sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	sNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(_ string, n *specV1.Node) (*specV1.Node, error) {
+		assert.NotContains(t, n.Attributes, common.AttributeSyncInterval)
+		return n, nil
+	})
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/nodes/node01/interval", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// nothing to delete
+	sNode.EXPECT().Get(nil, "default", "node02").Return(&specV1.Node{Namespace: "default", Name: "node02"}, nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/nodes/node02/interval", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"strconv"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetNodeSyncProtocol get the sync protocol version node speaks in its last report, and the one cloud speaks to it
+func (api *API) GetNodeSyncProtocol(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetNameFromParam()
+	node, err := api.Node.Get(nil, ns, n)
+	if err != nil {
+		return nil, err
+	}
+	return nodeSyncProtocol(node), nil
+}
+
+func nodeSyncProtocol(node *specV1.Node) *models.NodeSyncProtocol {
+	res := &models.NodeSyncProtocol{Current: models.SyncProtocolCurrent, Minimum: models.SyncProtocolMinimum}
+	// the version is a number once the report is stored
+	switch v := node.Report[common.NodeSyncProtocol].(type) {
+	case int:
+		res.Version = v
+	case float64:
+		res.Version = int(v)
+	case string:
+		res.Version, _ = strconv.Atoi(v)
+	}
+	if res.Version == 0 {
+		return res
+	}
+	res.Negotiated = models.NegotiateSyncProtocol(res.Version)
+	res.Supported = res.Version >= models.SyncProtocolMinimum
+	return res
+}
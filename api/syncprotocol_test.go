@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestGetNodeSyncProtocol(t *testing.T) {
+	api := &API{log: log.L()}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mockIM := func(c *gin.Context) { common.NewContext(c).SetNamespace("default") }
+	router.GET("/v1/nodes/:name/protocol", mockIM, common.Wrapper(api.GetNodeSyncProtocol))
+	sNode := ms.NewMockNodeService(mockCtl)
+	api.Node = sNode
+
+	for report, expect := range map[float64]models.NodeSyncProtocol{
+		2: {Version: 2, Negotiated: 2, Supported: true},
+		9: {Version: 9, Negotiated: models.SyncProtocolCurrent, Supported: true},
+		0: {},
+	} {
+		node := &specV1.Node{Namespace: "default", Name: "node01", Report: specV1.Report{}}
+		if report != 0 {
+			node.Report[common.NodeSyncProtocol] = report
+		}
+		sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01/protocol", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res models.NodeSyncProtocol
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		expect.Current, expect.Minimum = models.SyncProtocolCurrent, models.SyncProtocolMinimum
+		assert.Equal(t, expect, res)
+	}
+}
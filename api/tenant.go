@@ -0,0 +1,231 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// CreateTenant creates the namespace of tenant with the default quotas, the tenant is active once created
+func (api *API) CreateTenant(c *common.Context) (interface{}, error) {
+	tenant := new(models.Tenant)
+	if err := c.LoadBody(tenant); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if tenant.Namespace == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "namespace is required"))
+	}
+	ns, err := api.NS.Get(tenant.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if ns != nil {
+		return nil, common.Error(common.ErrResourceConflict, common.Field("type", "namespace"), common.Field("name", tenant.Namespace))
+	}
+	if _, err = api.NS.Create(&models.Namespace{Name: tenant.Namespace}); err != nil {
+		return nil, err
+	}
+	if e := api.InitQuotas(tenant.Namespace); e != nil {
+		log.L().Error("InitQuotas error", log.Error(e))
+	}
+	return api.Tenant.Set(&models.Tenant{Namespace: tenant.Namespace, Status: models.TenantActive})
+}
+
+// GetTenant gets the lifecycle of tenant
+func (api *API) GetTenant(c *common.Context) (interface{}, error) {
+	ns := c.Param("namespace")
+	if err := api.checkTenantNamespace(ns); err != nil {
+		return nil, err
+	}
+	return api.Tenant.Get(ns)
+}
+
+// FreezeTenant freezes the tenant, the resources of which can only be read by admins until it's unfrozen.
+// The nodes keep running the apps deployed
+func (api *API) FreezeTenant(c *common.Context) (interface{}, error) {
+	ns := c.Param("namespace")
+	freeze := new(models.TenantFreeze)
+	if c.Request.ContentLength != 0 {
+		if err := c.LoadBody(freeze); err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+		}
+	}
+	if err := api.checkTenantNamespace(ns); err != nil {
+		return nil, err
+	}
+	return api.Tenant.Set(&models.Tenant{Namespace: ns, Status: models.TenantFrozen, Reason: freeze.Reason})
+}
+
+// UnfreezeTenant activates the frozen tenant
+func (api *API) UnfreezeTenant(c *common.Context) (interface{}, error) {
+	ns := c.Param("namespace")
+	if err := api.checkTenantNamespace(ns); err != nil {
+		return nil, err
+	}
+	return api.Tenant.Set(&models.Tenant{Namespace: ns, Status: models.TenantActive})
+}
+
+// PurgeTenant removes the nodes, apps, configs, secrets, certificates and quotas of the frozen tenant, then the
+// namespace itself. The query dryRun reports the resources to be removed without removing them
+func (api *API) PurgeTenant(c *common.Context) (interface{}, error) {
+	ns := c.Param("namespace")
+	dryRun, _ := strconv.ParseBool(c.Query("dryRun"))
+	if err := api.checkTenantNamespace(ns); err != nil {
+		return nil, err
+	}
+	res, err := api.planTenantPurge(ns)
+	if err != nil {
+		return nil, err
+	}
+	res.DryRun = dryRun
+	if dryRun {
+		return res, nil
+	}
+	tenant, err := api.Tenant.Get(ns)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Status != models.TenantFrozen {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the tenant should be frozen before purged"))
+	}
+	api.purgeTenant(res)
+	log.L().Info("tenant is purged", log.Any("namespace", ns), log.Any("nodes", len(res.Nodes)),
+		log.Any("apps", len(res.Apps)), log.Any("errors", res.Errors))
+	return res, nil
+}
+
+func (api *API) checkTenantNamespace(ns string) error {
+	res, err := api.NS.Get(ns)
+	if err != nil {
+		return err
+	}
+	if res == nil {
+		return common.Error(common.ErrResourceNotFound, common.Field("type", "namespace"), common.Field("name", ns))
+	}
+	return nil
+}
+
+func (api *API) planTenantPurge(ns string) (*models.TenantPurge, error) {
+	res := &models.TenantPurge{
+		Namespace:    ns,
+		Nodes:        []string{},
+		Apps:         []string{},
+		Configs:      []string{},
+		Secrets:      []string{},
+		Certificates: []string{},
+		Quotas:       []string{},
+	}
+	nodes, err := api.Node.List(ns, &models.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes.Items {
+		res.Nodes = append(res.Nodes, node.Name)
+	}
+	apps, err := api.App.List(ns, &models.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, app := range apps.Items {
+		res.Apps = append(res.Apps, app.Name)
+	}
+	configs, err := api.Config.List(ns, &models.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cfg := range configs.Items {
+		res.Configs = append(res.Configs, cfg.Name)
+	}
+	secrets, err := api.Secret.List(ns, &models.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets.Items {
+		res.Secrets = append(res.Secrets, secret.Name)
+		if certID, ok := secret.Annotations[common.AnnotationPkiCertID]; ok {
+			res.Certificates = append(res.Certificates, certID)
+		}
+	}
+	quotas, err := api.License.GetQuota(ns)
+	if err != nil {
+		return nil, err
+	}
+	for name := range quotas {
+		res.Quotas = append(res.Quotas, name)
+	}
+	sort.Strings(res.Quotas)
+	return res, nil
+}
+
+// purgeTenant removes the resources planned, the ones already removed along with the nodes, such as the system apps
+// and their configs, are skipped. The namespace is kept if any resource fails to remove so that the purge can be retried
+func (api *API) purgeTenant(res *models.TenantPurge) {
+	ns := res.Namespace
+	failed := func(kind, name string, err error) {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return
+		}
+		res.Errors = append(res.Errors, fmt.Sprintf("failed to remove %s (%s): %s", kind, name, err.Error()))
+	}
+	for _, name := range res.Nodes {
+		node, err := api.Node.Get(nil, ns, name)
+		if err == nil {
+			err = api.Node.Delete(ns, node)
+		}
+		if err != nil {
+			failed("node", name, err)
+			continue
+		}
+		api.deleteAllSysAppsOfNode(node)
+	}
+	for _, name := range res.Apps {
+		app, err := api.App.Get(ns, name, "")
+		if err == nil {
+			err = api.Facade.DeleteApp(ns, name, app)
+		}
+		if err != nil {
+			failed("app", name, err)
+			continue
+		}
+		if api.AppHistory != nil {
+			if err = api.AppHistory.Delete(ns, name); err != nil {
+				common.LogDirtyData(err, log.Any("type", "revision"), log.Any(common.KeyContextNamespace, ns), log.Any("app", name))
+			}
+		}
+		api.deleteAppRollout(ns, name)
+	}
+	for _, name := range res.Configs {
+		if err := api.Config.Delete(nil, ns, name); err != nil {
+			failed("config", name, err)
+		}
+	}
+	for _, certID := range res.Certificates {
+		if err := api.PKI.DeleteClientCertificate(certID); err != nil {
+			failed("certificate", certID, err)
+		}
+	}
+	for _, name := range res.Secrets {
+		if err := api.Secret.Delete(ns, name); err != nil {
+			failed("secret", name, err)
+		}
+	}
+	if err := api.License.DeleteQuotaByNamespace(ns); err != nil {
+		failed("quotas", ns, err)
+	}
+	if len(res.Errors) > 0 {
+		return
+	}
+	if err := api.NS.Delete(&models.Namespace{Name: ns}); err != nil {
+		failed("namespace", ns, err)
+		return
+	}
+	if err := api.Tenant.Delete(ns); err != nil {
+		failed("tenant", ns, err)
+	}
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mf "github.com/baetyl/baetyl-cloud/v2/mock/facade"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/service"
+)
+
+func initTenantAPI(t *testing.T) (*API, *gin.Engine, *gomock.Controller) {
+	api := &API{AppCombinedService: &service.AppCombinedService{}}
+	router := gin.Default()
+	mockCtl := gomock.NewController(t)
+	v1 := router.Group("v1")
+	{
+		tenants := v1.Group("/tenants")
+		tenants.POST("", common.WrapperMis(api.CreateTenant))
+		tenants.GET("/:namespace", common.WrapperMis(api.GetTenant))
+		tenants.POST("/:namespace/freeze", common.WrapperMis(api.FreezeTenant))
+		tenants.POST("/:namespace/unfreeze", common.WrapperMis(api.UnfreezeTenant))
+		tenants.DELETE("/:namespace", common.WrapperMis(api.PurgeTenant))
+	}
+	return api, router, mockCtl
+}
+
+func TestTenantLifecycle(t *testing.T) {
+	api, router, mockCtl := initTenantAPI(t)
+	defer mockCtl.Finish()
+	sNS := ms.NewMockNamespaceService(mockCtl)
+	sLicense := ms.NewMockLicenseService(mockCtl)
+	sTenant := ms.NewMockTenantService(mockCtl)
+	api.NS, api.License, api.Tenant = sNS, sLicense, sTenant
+
+	// create
+	sNS.EXPECT().Get("tenant-a").Return(nil, nil)
+	sNS.EXPECT().Create(&models.Namespace{Name: "tenant-a"}).Return(&models.Namespace{Name: "tenant-a"}, nil)
+	sLicense.EXPECT().GetDefaultQuotas("tenant-a").Return(map[string]int{}, nil)
+	active := &models.Tenant{Namespace: "tenant-a", Status: models.TenantActive}
+	sTenant.EXPECT().Set(active).Return(active, nil)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/tenants", bytes.NewReader([]byte(`{"namespace":"tenant-a"}`)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"active"`)
+
+	// the namespace exists
+	sNS.EXPECT().Get("tenant-a").Return(&models.Namespace{Name: "tenant-a"}, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/tenants", bytes.NewReader([]byte(`{"namespace":"tenant-a"}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":1`)
+
+	// freeze
+	frozen := &models.Tenant{Namespace: "tenant-a", Status: models.TenantFrozen, Reason: "contract expired"}
+	sNS.EXPECT().Get("tenant-a").Return(&models.Namespace{Name: "tenant-a"}, nil)
+	sTenant.EXPECT().Set(frozen).Return(frozen, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/tenants/tenant-a/freeze", bytes.NewReader([]byte(`{"reason":"contract expired"}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":"frozen"`)
+
+	sNS.EXPECT().Get("tenant-a").Return(&models.Namespace{Name: "tenant-a"}, nil)
+	sTenant.EXPECT().Get("tenant-a").Return(frozen, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/v1/tenants/tenant-a", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"reason":"contract expired"`)
+
+	// unfreeze
+	sNS.EXPECT().Get("tenant-a").Return(&models.Namespace{Name: "tenant-a"}, nil)
+	sTenant.EXPECT().Set(active).Return(active, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/tenants/tenant-a/unfreeze", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":"active"`)
+
+	// the namespace not found
+	sNS.EXPECT().Get("tenant-b").Return(nil, nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/tenants/tenant-b/freeze", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":1`)
+}
+
+func TestPurgeTenant(t *testing.T) {
+	api, router, mockCtl := initTenantAPI(t)
+	defer mockCtl.Finish()
+	sNS := ms.NewMockNamespaceService(mockCtl)
+	sNode := ms.NewMockNodeService(mockCtl)
+	sApp := ms.NewMockApplicationService(mockCtl)
+	sConfig := ms.NewMockConfigService(mockCtl)
+	sSecret := ms.NewMockSecretService(mockCtl)
+	sPKI := ms.NewMockPKIService(mockCtl)
+	sLicense := ms.NewMockLicenseService(mockCtl)
+	sTenant := ms.NewMockTenantService(mockCtl)
+	sFacade := mf.NewMockFacade(mockCtl)
+	api.NS, api.Node, api.App, api.Config, api.Secret = sNS, sNode, sApp, sConfig, sSecret
+	api.PKI, api.License, api.Tenant, api.Facade = sPKI, sLicense, sTenant, sFacade
+
+	ns := &models.Namespace{Name: "tenant-a"}
+	node := specV1.Node{Namespace: "tenant-a", Name: "node01"}
+	app := &specV1.Application{Namespace: "tenant-a", Name: "web"}
+	secret := specV1.Secret{Namespace: "tenant-a", Name: "cert01", Annotations: map[string]string{common.AnnotationPkiCertID: "certid01"}}
+	plan := func() {
+		sNS.EXPECT().Get("tenant-a").Return(ns, nil)
+		sNode.EXPECT().List("tenant-a", gomock.Any()).Return(&models.NodeList{Items: []specV1.Node{node}}, nil)
+		sApp.EXPECT().List("tenant-a", gomock.Any()).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "web"}}}, nil)
+		sConfig.EXPECT().List("tenant-a", gomock.Any()).Return(&models.ConfigurationList{Items: []specV1.Configuration{{Name: "conf01"}}}, nil)
+		sSecret.EXPECT().List("tenant-a", gomock.Any()).Return(&models.SecretList{Items: []specV1.Secret{secret}}, nil)
+		sLicense.EXPECT().GetQuota("tenant-a").Return(map[string]int{"maxNodeCount": 10, "maxAppCount": 20}, nil)
+	}
+
+	// dry run
+	plan()
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/tenants/tenant-a?dryRun=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data models.TenantPurge `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, models.TenantPurge{
+		Namespace:    "tenant-a",
+		DryRun:       true,
+		Nodes:        []string{"node01"},
+		Apps:         []string{"web"},
+		Configs:      []string{"conf01"},
+		Secrets:      []string{"cert01"},
+		Certificates: []string{"certid01"},
+		Quotas:       []string{"maxAppCount", "maxNodeCount"},
+	}, resp.Data)
+
+	// the active tenant is never purged
+	plan()
+	sTenant.EXPECT().Get("tenant-a").Return(&models.Tenant{Namespace: "tenant-a", Status: models.TenantActive}, nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/tenants/tenant-a", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":1`)
+
+	// purge
+	plan()
+	sTenant.EXPECT().Get("tenant-a").Return(&models.Tenant{Namespace: "tenant-a", Status: models.TenantFrozen}, nil)
+	sNode.EXPECT().Get(nil, "tenant-a", "node01").Return(&node, nil)
+	sNode.EXPECT().Delete("tenant-a", &node).Return(nil)
+	sApp.EXPECT().Get("tenant-a", "web", "").Return(app, nil)
+	sFacade.EXPECT().DeleteApp("tenant-a", "web", app).Return(nil)
+	// the config is removed along with the app
+	sConfig.EXPECT().Delete(nil, "tenant-a", "conf01").Return(common.Error(common.ErrResourceNotFound))
+	sPKI.EXPECT().DeleteClientCertificate("certid01").Return(nil)
+	sSecret.EXPECT().Delete("tenant-a", "cert01").Return(nil)
+	sLicense.EXPECT().DeleteQuotaByNamespace("tenant-a").Return(nil)
+	sNS.EXPECT().Delete(ns).Return(nil)
+	sTenant.EXPECT().Delete("tenant-a").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/tenants/tenant-a", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	resp.Data = models.TenantPurge{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.False(t, resp.Data.DryRun)
+	assert.Empty(t, resp.Data.Errors)
+
+	// the namespace is kept if any resource fails to remove
+	plan()
+	sTenant.EXPECT().Get("tenant-a").Return(&models.Tenant{Namespace: "tenant-a", Status: models.TenantFrozen}, nil)
+	sNode.EXPECT().Get(nil, "tenant-a", "node01").Return(&node, nil)
+	sNode.EXPECT().Delete("tenant-a", &node).Return(common.Error(common.ErrUnknown))
+	sApp.EXPECT().Get("tenant-a", "web", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	sConfig.EXPECT().Delete(nil, "tenant-a", "conf01").Return(nil)
+	sPKI.EXPECT().DeleteClientCertificate("certid01").Return(nil)
+	sSecret.EXPECT().Delete("tenant-a", "cert01").Return(nil)
+	sLicense.EXPECT().DeleteQuotaByNamespace("tenant-a").Return(nil)
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/tenants/tenant-a", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	resp.Data = models.TenantPurge{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data.Errors, 1)
+	assert.Contains(t, resp.Data.Errors[0], "node01")
+}
@@ -3,11 +3,73 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"sort"
+
+	"gopkg.in/go-playground/validator.v9"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 )
 
+const (
+	// ErrInvalidResourceLimit the limit of resource is less than the request
+	ErrInvalidResourceLimit common.Code = "resourceLimit"
+	// ErrInvalidPortRange the port is out of range
+	ErrInvalidPortRange common.Code = "portRange"
+
+	maxPort = 65535
+)
+
+func init() {
+	common.RegisterTemplate(ErrInvalidResourceLimit, "资源限制不能小于资源请求。\nThe limit of ({{if .resourceLimit}}{{.resourceLimit}}{{end}}) should not be less than the request{{if .param}} ({{.param}}){{end}}.")
+	common.RegisterTemplate(ErrInvalidPortRange, "端口超出范围。\nThe port ({{if .portRange}}{{.portRange}}{{end}}) should be between 0 and 65535.")
+	common.RegisterStructValidation(validateServiceView, models.ServiceView{})
+}
+
+// validateServiceView checks the fields of service which depend on each other
+func validateServiceView(sl validator.StructLevel) {
+	svc := sl.Current().Interface().(models.ServiceView)
+	if svc.Resources != nil {
+		names := make([]string, 0, len(svc.Resources.Limits))
+		for name := range svc.Resources.Limits {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			limit := svc.Resources.Limits[name]
+			request, ok := svc.Resources.Requests[name]
+			if !ok {
+				continue
+			}
+			// the format of quantity is not checked here
+			l, err := resource.ParseQuantity(limit)
+			if err != nil {
+				continue
+			}
+			r, err := resource.ParseQuantity(request)
+			if err != nil {
+				continue
+			}
+			if l.Cmp(r) < 0 {
+				field := "Resources.Limits." + name
+				sl.ReportError(limit, field, field, string(ErrInvalidResourceLimit), request)
+			}
+		}
+	}
+	for i, port := range svc.Ports {
+		names := []string{"HostPort", "ContainerPort", "NodePort"}
+		for j, v := range []int32{port.HostPort, port.ContainerPort, port.NodePort} {
+			if v < 0 || v > maxPort {
+				field := fmt.Sprintf("Ports[%d].%s", i, names[j])
+				sl.ReportError(v, field, field, string(ErrInvalidPortRange), "")
+			}
+		}
+	}
+}
+
 // ValidateResourceForCreating validate when resource create
 func (api *API) ValidateResourceForCreating(c *common.Context) (interface{}, error) {
 	resource := struct {
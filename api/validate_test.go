@@ -0,0 +1,41 @@
+package api
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestValidateServiceView(t *testing.T) {
+	app := &models.ApplicationView{
+		Name: "app",
+		Services: []models.ServiceView{{
+			Service: specV1.Service{
+				Name: "svc",
+				Resources: &specV1.Resources{
+					Limits:   map[string]string{"cpu": "1", "memory": "200Mi"},
+					Requests: map[string]string{"cpu": "100m", "memory": "200Mi"},
+				},
+				Ports: []specV1.ContainerPort{{HostPort: 8080, ContainerPort: 80}},
+			},
+		}},
+	}
+	assert.NoError(t, common.ValidateStruct(app))
+
+	app.Services[0].Resources.Limits["memory"] = "100Mi"
+	err := common.ValidateStruct(app)
+	assert.Error(t, err)
+	assert.Equal(t, string(ErrInvalidResourceLimit), err.(interface{ Code() string }).Code())
+	assert.Contains(t, err.Error(), "The limit of (Resources.Limits.memory) should not be less than the request (200Mi).")
+
+	app.Services[0].Ports[0].HostPort = 70000
+	err = common.ValidateStruct(app)
+	es, ok := err.(common.ValidationErrors)
+	assert.True(t, ok)
+	assert.Len(t, es, 2)
+	assert.Contains(t, err.Error(), "The port (Ports[0].HostPort) should be between 0 and 65535.")
+}
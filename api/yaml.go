@@ -45,33 +45,13 @@ func (api *API) CreateYamlResource(c *common.Context) (interface{}, error) {
 	}
 
 	for _, r := range resources {
-		switch r.GetObjectKind().GroupVersionKind().Kind {
-		case TypeSecret:
-			se, err := api.generateSecret(ns, r)
-			if err != nil {
-				return nil, err
-			}
-			res.Items = append(res.Items, se)
-			res.Total++
-		case TypeConfig:
-			cfg, err := api.generateConfig(ns, c.GetUser().ID, r)
-			if err != nil {
-				return nil, err
-			}
-			res.Items = append(res.Items, cfg)
-			res.Total++
-		case TypeDeploy, TypeDaemonset, TypeJob:
-			app, err := api.generateApplication(ns, r)
-			if err != nil {
-				return nil, err
-			}
-			res.Items = append(res.Items, app)
+		item, err := api.createYamlResource(ns, c.GetUser().ID, r)
+		if err != nil {
+			return nil, err
+		}
+		if item != nil {
+			res.Items = append(res.Items, item)
 			res.Total++
-		case TypeService:
-			err = api.generateService(ns, r)
-			if err != nil {
-				return nil, err
-			}
 		}
 	}
 	return res, nil
@@ -86,33 +66,13 @@ func (api *API) UpdateYamlResource(c *common.Context) (interface{}, error) {
 	}
 
 	for _, r := range resources {
-		switch r.GetObjectKind().GroupVersionKind().Kind {
-		case TypeSecret:
-			se, err := api.updateSecret(ns, r)
-			if err != nil {
-				return nil, err
-			}
-			res.Items = append(res.Items, se)
-			res.Total++
-		case TypeConfig:
-			cfg, err := api.updateConfig(ns, c.GetUser().ID, r)
-			if err != nil {
-				return nil, err
-			}
-			res.Items = append(res.Items, cfg)
-			res.Total++
-		case TypeDeploy, TypeDaemonset, TypeJob:
-			app, err := api.updateApplication(ns, r)
-			if err != nil {
-				return nil, err
-			}
-			res.Items = append(res.Items, app)
+		item, err := api.updateYamlResource(ns, c.GetUser().ID, r)
+		if err != nil {
+			return nil, err
+		}
+		if item != nil {
+			res.Items = append(res.Items, item)
 			res.Total++
-		case TypeService:
-			err = api.updateService(ns, r)
-			if err != nil {
-				return nil, err
-			}
 		}
 	}
 	return res, nil
@@ -126,32 +86,57 @@ func (api *API) DeleteYamlResource(c *common.Context) (interface{}, error) {
 	}
 	// 逆序删除，避免依赖
 	for i := len(resources) - 1; i >= 0; i-- {
-		switch resources[i].GetObjectKind().GroupVersionKind().Kind {
-		case TypeSecret:
-			_, err := api.deleteSecret(ns, resources[i])
-			if err != nil {
-				return nil, err
-			}
-		case TypeConfig:
-			_, err := api.deleteConfig(ns, resources[i])
-			if err != nil {
-				return nil, err
-			}
-		case TypeDeploy, TypeDaemonset, TypeJob:
-			_, err := api.deleteApplication(ns, resources[i])
-			if err != nil {
-				return nil, err
-			}
-		case TypeService:
-			_, err := api.deleteService(ns, resources[i])
-			if err != nil {
-				return nil, err
-			}
+		if err = api.deleteYamlResource(ns, resources[i]); err != nil {
+			return nil, err
 		}
 	}
 	return nil, err
 }
 
+// createYamlResource creates the resource of kube object, the service only updates the ports of app and returns nothing
+func (api *API) createYamlResource(ns, userId string, r runtime.Object) (interface{}, error) {
+	switch r.GetObjectKind().GroupVersionKind().Kind {
+	case TypeSecret:
+		return api.generateSecret(ns, r)
+	case TypeConfig:
+		return api.generateConfig(ns, userId, r)
+	case TypeDeploy, TypeDaemonset, TypeJob:
+		return api.generateApplication(ns, r)
+	case TypeService:
+		return nil, api.generateService(ns, r)
+	}
+	return nil, nil
+}
+
+func (api *API) updateYamlResource(ns, userId string, r runtime.Object) (interface{}, error) {
+	switch r.GetObjectKind().GroupVersionKind().Kind {
+	case TypeSecret:
+		return api.updateSecret(ns, r)
+	case TypeConfig:
+		return api.updateConfig(ns, userId, r)
+	case TypeDeploy, TypeDaemonset, TypeJob:
+		return api.updateApplication(ns, r)
+	case TypeService:
+		return nil, api.updateService(ns, r)
+	}
+	return nil, nil
+}
+
+func (api *API) deleteYamlResource(ns string, r runtime.Object) error {
+	var err error
+	switch r.GetObjectKind().GroupVersionKind().Kind {
+	case TypeSecret:
+		_, err = api.deleteSecret(ns, r)
+	case TypeConfig:
+		_, err = api.deleteConfig(ns, r)
+	case TypeDeploy, TypeDaemonset, TypeJob:
+		_, err = api.deleteApplication(ns, r)
+	case TypeService:
+		_, err = api.deleteService(ns, r)
+	}
+	return err
+}
+
 func (api *API) parseYamlFileAndCheck(c *common.Context) ([]runtime.Object, error) {
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
@@ -458,6 +443,10 @@ func (api *API) generateConfig(ns, userId string, r runtime.Object) (interface{}
 			common.Field("error", "this name is already in use"))
 	}
 
+	if err = api.offloadConfig(userId, config, false); err != nil {
+		return nil, err
+	}
+
 	config, err = api.Facade.CreateConfig(ns, config)
 	if err != nil {
 		return nil, err
@@ -499,6 +488,10 @@ func (api *API) updateConfig(ns, userId string, r runtime.Object) (interface{},
 		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "labels can't be modified of sys apps"))
 	}
 
+	if err = api.offloadConfig(userId, config, false); err != nil {
+		return nil, err
+	}
+
 	if models.EqualConfig(res, config) {
 		return res, nil
 	}
@@ -654,6 +647,9 @@ func (api *API) generateApplication(ns string, r runtime.Object) (interface{}, e
 	if err != nil {
 		return nil, err
 	}
+	if err = api.checkAppQuarantine(ns, app); err != nil {
+		return nil, err
+	}
 
 	oldApp, err := api.App.Get(ns, app.Name, "")
 	if err != nil {
@@ -684,6 +680,9 @@ func (api *API) updateApplication(ns string, r runtime.Object) (interface{}, err
 	if err != nil {
 		return nil, err
 	}
+	if err = api.checkAppQuarantine(ns, app); err != nil {
+		return nil, err
+	}
 
 	oldApp, err := api.App.Get(ns, app.Name, "")
 	if err != nil {
@@ -1009,9 +1008,11 @@ func TransContainerToSvc(svc *specV1.Service, c *corev1.Container) error {
 	return nil
 }
 
+// isRegistrySecret returns whether the secret is a container registry, the artifact registries can not pull images
 func isRegistrySecret(secret specV1.Secret) bool {
 	registry, ok := secret.Labels[specV1.SecretLabel]
-	return ok && registry == specV1.SecretRegistry
+	_, artifact := secret.Labels[common.LabelRegistryType]
+	return ok && registry == specV1.SecretRegistry && !artifact
 }
 
 func validateApp(app *specV1.Application) error {
@@ -0,0 +1,94 @@
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+)
+
+const (
+	keyAuditDiff = "auditDiff"
+	keyErrorCode = "errorCode"
+
+	keyImpersonator = "impersonator"
+)
+
+// SetAuditDiff records the change of the mutated resource, which is saved in the audit record of the request
+// the change is rendered as json merge patch (RFC 7386) from before to after
+func (c *Context) SetAuditDiff(before, after interface{}) {
+	diff, err := MergePatch(before, after)
+	if err != nil {
+		log.L().Warn("failed to generate audit diff", log.Any(c.GetTrace()), log.Error(err))
+		return
+	}
+	c.Set(keyAuditDiff, string(diff))
+}
+
+// GetAuditDiff gets the change of the mutated resource if exists
+func (c *Context) GetAuditDiff() string {
+	return c.GetString(keyAuditDiff)
+}
+
+// SetImpersonator sets the operator impersonating the user of request, which is saved in the audit record
+func (c *Context) SetImpersonator(operator string) {
+	c.Set(keyImpersonator, operator)
+}
+
+// GetImpersonator gets the operator impersonating the user of request, empty if not impersonated
+func (c *Context) GetImpersonator() string {
+	return c.GetString(keyImpersonator)
+}
+
+// GetErrorCode gets the code of the failed response if exists
+func (c *Context) GetErrorCode() string {
+	return c.GetString(keyErrorCode)
+}
+
+// MergePatch generates the json merge patch which turns before into after
+func MergePatch(before, after interface{}) ([]byte, error) {
+	b, err := toJSONValue(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := toJSONValue(after)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergePatch(b, a))
+}
+
+func toJSONValue(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	err = json.Unmarshal(data, &v)
+	return v, err
+}
+
+func mergePatch(before, after interface{}) interface{} {
+	bm, ok1 := before.(map[string]interface{})
+	am, ok2 := after.(map[string]interface{})
+	if !ok1 || !ok2 {
+		return after
+	}
+	patch := map[string]interface{}{}
+	for k, bv := range bm {
+		av, ok := am[k]
+		if !ok {
+			patch[k] = nil
+			continue
+		}
+		if !reflect.DeepEqual(bv, av) {
+			patch[k] = mergePatch(bv, av)
+		}
+	}
+	for k, av := range am {
+		if _, ok := bm[k]; !ok {
+			patch[k] = av
+		}
+	}
+	return patch
+}
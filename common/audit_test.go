@@ -0,0 +1,38 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	type obj struct {
+		Name   string            `json:"name,omitempty"`
+		Labels map[string]string `json:"labels,omitempty"`
+		Apps   []string          `json:"apps,omitempty"`
+	}
+	before := &obj{
+		Name:   "node01",
+		Labels: map[string]string{"a": "1", "b": "2"},
+		Apps:   []string{"app1"},
+	}
+	after := &obj{
+		Name:   "node01",
+		Labels: map[string]string{"a": "1", "c": "3"},
+	}
+	patch, err := MergePatch(before, after)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"labels":{"b":null,"c":"3"},"apps":null}`, string(patch))
+
+	patch, err = MergePatch(before, before)
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(patch))
+
+	patch, err = MergePatch(nil, after)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"node01","labels":{"a":"1","c":"3"}}`, string(patch))
+
+	_, err = MergePatch(make(chan int), after)
+	assert.Error(t, err)
+}
@@ -99,6 +99,12 @@ const (
 
 	DesiredApplications    = "apps"
 	DesiredSysApplications = "sysapps"
+	// DesiredRestarts the instances of apps which the node is asked to restart
+	DesiredRestarts = "restarts"
+	// DesiredServices the discovery records of the services of apps on node, delivered with the changes of apps
+	DesiredServices = "services"
+	// ServiceDomain the domain of the stable names of services on node, such as broker.iot-hub.baetyl.local
+	ServiceDomain = "baetyl.local"
 
 	// Receive receive
 	Receive State = "RECEIVE"
@@ -128,8 +134,49 @@ const (
 	LabelCluster     = "baetyl-cluster"
 	LabelNodeMode    = "baetyl-node-mode"
 	LabelAppMode     = "baetyl-app-mode"
+	LabelNodeGroup   = "baetyl-node-group"
+	LabelNodeCordon  = "baetyl-node-cordon"
+	LabelEdgeCluster = "baetyl-edge-cluster"
+	LabelAppPaused   = "baetyl-app-paused"
+	// LabelRegistryType the type of the registries which are not container registries, such as helm
+	LabelRegistryType = "baetyl-registry-type"
 )
 
+// LabelPrefixDependsOn the prefix of the app labels marking the apps depended on, such as depends-on.cloud.baetyl.io/app01
+const LabelPrefixDependsOn = "depends-on.cloud.baetyl.io/"
+
+// LabelPrefixEnvGroup the prefix of the app labels marking the env groups referenced, such as env-group.cloud.baetyl.io/mqtt
+const LabelPrefixEnvGroup = "env-group.cloud.baetyl.io/"
+
+// LabelPrefixEnvSecret the prefix of the app labels binding the envs of function services to the keys of secrets,
+// such as env-secret.cloud.baetyl.io/TOKEN: infer-token_token, the secret name has no underscore
+const LabelPrefixEnvSecret = "env-secret.cloud.baetyl.io/"
+
+// EnvWasmMemoryPageLimit the env of the services running wasm bundles, which the wasm runtime passes to WasmEdge
+// as the limit of pages (64KiB) of the linear memory of modules
+const EnvWasmMemoryPageLimit = "BAETYL_WASM_MEMORY_PAGE_LIMIT"
+
+// LabelSyncLink the label of node selecting the link which the system apps of node synchronize with cloud by,
+// such as sync-link.cloud.baetyl.io: mqttlink, httplink is used if not set
+const LabelSyncLink = "sync-link.cloud.baetyl.io"
+
+const (
+	SyncLinkHTTP = "httplink"
+	SyncLinkMQTT = "mqttlink"
+)
+
+// LabelDownloadRate the label of node capping the bytes per second the node downloads the large objects of configs at,
+// such as download-rate.cloud.baetyl.io: 10Mi, which overrides the default rate of cloud
+const LabelDownloadRate = "download-rate.cloud.baetyl.io"
+
+// LabelSite the label of node grouping the nodes in the same site, such as site.cloud.baetyl.io: factory-01,
+// which download the large objects from the seed nodes of site instead of the object storage of cloud
+const LabelSite = "site.cloud.baetyl.io"
+
+// LabelImagePullPolicy the label carrying the image pull policy of namespace in the apps delivered to nodes,
+// which is read by the engine of node since the services of app have no field for it
+const LabelImagePullPolicy = "image-pull-policy.cloud.baetyl.io"
+
 const (
 	BaetylCloud      = "baetyl-cloud"
 	BaetylCloudGroup = "cloud.baetyl.io"
@@ -144,6 +191,23 @@ const (
 	AnnotationMetadata        = BaetylCloudGroup + "/" + Metadata
 	AnnotationPkiCertID       = BaetylCloudGroup + "/" + PkiCertID
 	AnnotationNodeSelector    = BaetylCloudGroup + "/" + NodeSelector
+
+	// AttributeMaintenanceWindow the attribute of node storing the maintenance window set on it
+	AttributeMaintenanceWindow = "BaetylMaintenanceWindow"
+	// AttributeSiteSeed the attribute of node storing the address which it serves the large objects to its site at
+	AttributeSiteSeed = "BaetylSiteSeed"
+	// AttributeSyncInterval the attribute of node storing the sync intervals set on it
+	AttributeSyncInterval = "BaetylSyncInterval"
+	// AttributeAppConflicts the attribute of node storing the conflicts of the apps changed on node out of band
+	AttributeAppConflicts = "BaetylAppConflicts"
+	// AttributeHardwareFingerprint the attribute of node storing the hardware fingerprint bound at its activation
+	AttributeHardwareFingerprint = "BaetylHardwareFingerprint"
+	// AttributeNodeEndpoints the attribute of node storing the endpoints of cloud overridden for it
+	AttributeNodeEndpoints = "BaetylNodeEndpoints"
+	// AttributeOnboarding the attribute of node storing the steps of its onboarding since the last activation
+	AttributeOnboarding = "BaetylOnboarding"
+	// AttributePreflight the attribute of node storing the preflight checks of its host reported before activation
+	AttributePreflight = "BaetylPreflight"
 )
 
 const (
@@ -195,10 +259,22 @@ const (
 	FunctionApp  = "function"
 )
 
+// WorkloadCronJob the workload of the apps whose services run as jobs on schedule,
+// the schedule is delivered to node as the config generated for app
+const WorkloadCronJob = "cronjob"
+
 const (
 	ConfigObjectPrefix = "_object_"
+	// ConfigTemplatePrefix the prefix of the config items rendered for each node
+	ConfigTemplatePrefix = "_template_"
+	// ConfigSchemaKey the key of the config item storing the json schema of config, which is not delivered to nodes
+	ConfigSchemaKey = "_schema_"
 )
 
+// SecretExternalKey the key of the secret data storing the reference to the external secret store,
+// the secret holding it has no other data and is resolved by the secret provider on sync
+const SecretExternalKey = "_external_"
+
 const (
 	UnpackTypeZip = "zip"
 )
@@ -222,6 +298,16 @@ const (
 	NodeProps  = "nodeprops"
 	NodeInfo   = "node"
 	NodeStats  = "nodestats"
+	// NodeAccelerators the key of GPU and NPU devices in the report of node
+	NodeAccelerators = "accelerators"
+	// NodeSyncProtocol the key of the sync protocol version node speaks in the report of node
+	NodeSyncProtocol = "syncProtocol"
+	// NodeTelemetry the key of the opaque telemetry in the report of node, which is forwarded without being stored
+	NodeTelemetry = "telemetry"
+	// NodeSyncInterval the key of the sync intervals in the delta to node, and of the ones node applies in its report
+	NodeSyncInterval = "syncInterval"
+	// NodeAnnouncements the key of the announcements of the platform in the delta to node, and of the ones node shows in its report
+	NodeAnnouncements = "announcements"
 )
 
 const (
@@ -236,3 +322,14 @@ const (
 const (
 	TaskNamespaceDelete = "namespace-delete"
 )
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+	// HeaderImpersonation carries the token of impersonation issued by mis server
+	HeaderImpersonation = "Baetyl-Impersonation"
+)
+
+const (
+	QueryDryRun = "dryRun"
+)
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"runtime/debug"
+	"strconv"
 
 	"github.com/baetyl/baetyl-go/v2/errors"
 	"github.com/baetyl/baetyl-go/v2/log"
@@ -12,7 +13,6 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	uuid "github.com/satori/go.uuid"
-	"gopkg.in/go-playground/validator.v9"
 )
 
 // Context context
@@ -104,6 +104,36 @@ func (c *Context) GetNameFromParam() string {
 	return c.Param("name")
 }
 
+// IsDryRun reports whether the request is marked by ?dryRun=true, the mutating handlers validate and render
+// the would-be result without persisting it
+func (c *Context) IsDryRun() bool {
+	v, _ := strconv.ParseBool(c.Query(QueryDryRun))
+	return v
+}
+
+// SetFeatures sets the feature flags enabled for the namespace of request
+func (c *Context) SetFeatures(features map[string]bool) {
+	c.Set("features", features)
+}
+
+// IsFeatureEnabled reports whether the feature flag is enabled for the namespace of request,
+// which is false if the flags are not resolved, such as the requests of mis server
+func (c *Context) IsFeatureEnabled(name string) bool {
+	features, ok := c.Get("features")
+	if !ok {
+		return false
+	}
+	return features.(map[string]bool)[name]
+}
+
+// RequestContext returns the context of request, which is done when the client disconnects or the deadline exceeds
+func (c *Context) RequestContext() context.Context {
+	if c.Request == nil {
+		return context.Background()
+	}
+	return c.Request.Context()
+}
+
 // SetTrace set the trace key and value
 func (c *Context) SetTrace() {
 	k := GetTraceHeader()
@@ -119,19 +149,27 @@ func (c *Context) GetTrace() (k string, v string) {
 	return GetTraceKey(), c.Request.Header.Get(GetTraceHeader())
 }
 
+// localize renders the message of error in the language of Accept-Language,
+// the bilingual message is kept if the header is absent
+func (c *Context) localize(err error) string {
+	if c.Request == nil {
+		return err.Error()
+	}
+	accept := c.Request.Header.Get(HeaderAcceptLanguage)
+	if accept == "" {
+		return err.Error()
+	}
+	return Localize(err, MatchLanguage(accept))
+}
+
 // LoadBody loads json data from body into object and set defaults
 func (c *Context) LoadBody(obj interface{}) error {
 	err := c.BindJSON(obj)
 	if err != nil {
 		return err
 	}
-	err = validate.Struct(obj)
+	err = ValidateStruct(obj)
 	if err != nil {
-		if es, ok := err.(validator.ValidationErrors); ok {
-			for _, v := range es {
-				return Error(Code(v.Tag()), Field(v.Tag(), v.Field()), Field("error", err.Error()))
-			}
-		}
 		return err
 	}
 	return utils.SetDefaults(obj)
@@ -142,13 +180,8 @@ func (c *Context) LoadBodyMulti(obj interface{}) error {
 	if err != nil {
 		return err
 	}
-	err = validate.Struct(obj)
+	err = ValidateStruct(obj)
 	if err != nil {
-		if es, ok := err.(validator.ValidationErrors); ok {
-			for _, v := range es {
-				return Error(Code(v.Tag()), Field(v.Tag(), v.Field()), Field("error", err.Error()))
-			}
-		}
 		return err
 	}
 	return utils.SetDefaults(obj)
@@ -172,6 +205,9 @@ func PackageResponse(res interface{}) (int, interface{}) {
 func PopulateFailedResponse(cc *Context, err error, abort bool) {
 	var code string
 	var status int
+	if _, ok := err.(errors.Coder); !ok && cc.Request != nil && cc.Request.Context().Err() == context.DeadlineExceeded {
+		err = Error(ErrRequestTimeout)
+	}
 	switch e := err.(type) {
 	case errors.Coder:
 		code = e.Code()
@@ -183,12 +219,27 @@ func PopulateFailedResponse(cc *Context, err error, abort bool) {
 
 	log.L().Error("process failed.", log.Any(cc.GetTrace()), log.Code(err))
 
+	cc.Set(keyErrorCode, code)
+	if sec, ok := RetryAfter(err); ok {
+		cc.Header("Retry-After", strconv.Itoa(sec))
+	}
 	k, v := cc.GetTrace()
 	body := gin.H{
 		"code":    code,
-		"message": err.Error(),
+		"message": cc.localize(err),
 		k:         v,
 	}
+	if es, ok := err.(ValidationErrors); ok {
+		details := make([]gin.H, 0, len(es))
+		for _, e := range es {
+			detail := gin.H{"code": ErrUnknown, "message": cc.localize(e)}
+			if c, ok := e.(errors.Coder); ok {
+				detail["code"] = c.Code()
+			}
+			details = append(details, detail)
+		}
+		body["errors"] = details
+	}
 	if abort {
 		cc.AbortWithStatusJSON(status, body)
 	} else {
@@ -224,7 +275,7 @@ func Wrapper(handler HandlerFunc) func(c *gin.Context) {
 		}
 		log.L().Debug("process success", log.Any(cc.GetTrace()), log.Any("response", _toJsonString(res)))
 		// unlike JSON, does not replace special html characters with their unicode entities. eg: JSON(&)->'\u0026' PureJSON(&)->'&'
-		cc.PureJSON(PackageResponse(res))
+		cc.RenderResponse(PackageResponse(res))
 	}
 }
 
@@ -278,7 +329,7 @@ func WrapperRaw(handler HandlerFunc, abort bool) func(c *gin.Context) {
 			return
 		}
 		if data, ok := res.([]byte); ok {
-			cc.Data(http.StatusOK, "application/octet-stream", data)
+			cc.Compress(http.StatusOK, "application/octet-stream", data)
 		} else {
 			log.L().Error("failed to convert data to []byte", log.Any(cc.GetTrace()))
 			PopulateFailedResponse(cc, Error(ErrUnknown), abort)
@@ -349,7 +400,7 @@ func PopulateFailedMisResponse(cc *Context, err error, abort bool) {
 
 	body := gin.H{
 		"status": 1,
-		"msg":    err.Error(),
+		"msg":    cc.localize(err),
 	}
 	if abort {
 		cc.AbortWithStatusJSON(status, body)
@@ -1,14 +1,17 @@
 package common
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/go-playground/validator.v9"
 )
 
 func TestWrapper(t *testing.T) {
@@ -157,6 +160,14 @@ func TestValid(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestContext_Features(t *testing.T) {
+	ctx := NewContext(&gin.Context{})
+	assert.False(t, ctx.IsFeatureEnabled("delta-sync"))
+	ctx.SetFeatures(map[string]bool{"delta-sync": true})
+	assert.True(t, ctx.IsFeatureEnabled("delta-sync"))
+	assert.False(t, ctx.IsFeatureEnabled("canary-rollout"))
+}
+
 func TestContext_LoadBody(t *testing.T) {
 	var model struct {
 		Name string `json:"name" validate:"nonBaetyl,resourceName"`
@@ -251,6 +262,64 @@ func TestContext_LoadBodyMulti(t *testing.T) {
 	assert.NoError(t, err3)
 }
 
+type testRange struct {
+	Name string `json:"name" validate:"resourceName"`
+	Min  int    `json:"min"`
+	Max  int    `json:"max" validate:"testEven"`
+}
+
+func TestContext_LoadBodyValidationErrors(t *testing.T) {
+	err := RegisterValidation("testEven", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int()%2 == 0
+	}, "The field ({{.testEven}}) should be even.")
+	assert.NoError(t, err)
+	RegisterTemplate("testRange", "最大值不能小于最小值。\nThe field ({{.testRange}}) should not be less than {{.param}}.")
+	RegisterStructValidation(func(sl validator.StructLevel) {
+		r := sl.Current().Interface().(testRange)
+		if r.Max < r.Min {
+			sl.ReportError(r.Max, "Max", "Max", "testRange", strconv.Itoa(r.Min))
+		}
+	}, testRange{})
+
+	var model testRange
+	ctx := NewContext(&gin.Context{Request: &http.Request{Body: newStringReaderColser(`{"name":"a","min":1,"max":2}`)}})
+	assert.NoError(t, ctx.LoadBody(&model))
+
+	// a single error is returned as before
+	ctx = NewContext(&gin.Context{Request: &http.Request{Body: newStringReaderColser(`{"name":"a","min":1,"max":3}`)}})
+	err = ctx.LoadBody(&model)
+	assert.Error(t, err)
+	assert.Equal(t, "The field (Max) should be even.", err.Error())
+
+	ctx = NewContext(&gin.Context{Request: &http.Request{Body: newStringReaderColser(`{"name":"A","min":4,"max":1}`)}})
+	err = ctx.LoadBody(&model)
+	es, ok := err.(ValidationErrors)
+	assert.True(t, ok)
+	assert.Len(t, es, 3)
+	assert.Equal(t, string(ErrInvalidResourceName), es.Code())
+	assert.Contains(t, err.Error(), "The field (Max) should be even.; The field (Max) should not be less than 4.")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/", nil)
+	c.Request.Header.Set(HeaderAcceptLanguage, LangZhCN)
+	PopulateFailedResponse(NewContext(c), err, false)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var body struct {
+		Code   string `json:"code"`
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, string(ErrInvalidResourceName), body.Code)
+	assert.Len(t, body.Errors, 3)
+	assert.Equal(t, "testEven", body.Errors[1].Code)
+	assert.Equal(t, "testRange", body.Errors[2].Code)
+	assert.Equal(t, "最大值不能小于最小值。", body.Errors[2].Message)
+}
+
 type stringReaderCloser struct {
 	reader *strings.Reader
 	io.Closer
@@ -0,0 +1,137 @@
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// JSONChange the change of one field between the json forms of two objects, Path locates the field such as
+// services[web].image, the elements of array are located by their names if all of them have one
+type JSONChange struct {
+	Path string      `json:"path"`
+	Op   string      `json:"op"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// DiffJSON lists the changes of fields from before to after, the changes of the same object are sorted by key
+func DiffJSON(before, after interface{}) ([]JSONChange, error) {
+	b, err := toJSONValue(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := toJSONValue(after)
+	if err != nil {
+		return nil, err
+	}
+	var res []JSONChange
+	diffJSONValue("", b, a, &res)
+	return res, nil
+}
+
+func diffJSONValue(path string, before, after interface{}, res *[]JSONChange) {
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+	switch {
+	case before == nil:
+		*res = append(*res, JSONChange{Path: path, Op: ChangeAdded, New: after})
+		return
+	case after == nil:
+		*res = append(*res, JSONChange{Path: path, Op: ChangeRemoved, Old: before})
+		return
+	}
+	switch b := before.(type) {
+	case map[string]interface{}:
+		if a, ok := after.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(b)+len(a))
+			for k := range b {
+				keys = append(keys, k)
+			}
+			for k := range a {
+				if _, ok := b[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				p := k
+				if path != "" {
+					p = path + "." + k
+				}
+				diffJSONValue(p, b[k], a[k], res)
+			}
+			return
+		}
+	case []interface{}:
+		if a, ok := after.([]interface{}); ok {
+			bn, bok := namedJSONElements(b)
+			an, aok := namedJSONElements(a)
+			if bok && aok {
+				for _, name := range mergeJSONNames(b, a) {
+					diffJSONValue(fmt.Sprintf("%s[%s]", path, name), bn[name], an[name], res)
+				}
+				return
+			}
+			for i := 0; i < len(b) || i < len(a); i++ {
+				var bv, av interface{}
+				if i < len(b) {
+					bv = b[i]
+				}
+				if i < len(a) {
+					av = a[i]
+				}
+				diffJSONValue(fmt.Sprintf("%s[%d]", path, i), bv, av, res)
+			}
+			return
+		}
+	}
+	*res = append(*res, JSONChange{Path: path, Op: ChangeModified, Old: before, New: after})
+}
+
+func jsonElementName(v interface{}) (string, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	name, ok := m["name"].(string)
+	return name, ok && name != ""
+}
+
+func namedJSONElements(items []interface{}) (map[string]interface{}, bool) {
+	res := map[string]interface{}{}
+	for _, item := range items {
+		name, ok := jsonElementName(item)
+		if !ok {
+			return nil, false
+		}
+		if _, ok = res[name]; ok {
+			return nil, false
+		}
+		res[name] = item
+	}
+	return res, true
+}
+
+// mergeJSONNames returns the names of elements before, followed by the names only after
+func mergeJSONNames(before, after []interface{}) []string {
+	var res []string
+	seen := map[string]bool{}
+	for _, items := range [][]interface{}{before, after} {
+		for _, item := range items {
+			name, _ := jsonElementName(item)
+			if !seen[name] {
+				seen[name] = true
+				res = append(res, name)
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffJSON(t *testing.T) {
+	type svc struct {
+		Name  string `json:"name"`
+		Image string `json:"image,omitempty"`
+	}
+	type obj struct {
+		Labels   map[string]string `json:"labels,omitempty"`
+		Services []svc             `json:"services,omitempty"`
+		Args     []string          `json:"args,omitempty"`
+	}
+	before := &obj{
+		Labels:   map[string]string{"a": "1", "b": "2"},
+		Services: []svc{{Name: "web", Image: "web:1"}, {Name: "db", Image: "db:1"}},
+		Args:     []string{"-v"},
+	}
+	after := &obj{
+		Labels:   map[string]string{"a": "1", "c": "3"},
+		Services: []svc{{Name: "db", Image: "db:1"}, {Name: "web", Image: "web:2"}, {Name: "cache"}},
+		Args:     []string{"-d", "-v"},
+	}
+	changes, err := DiffJSON(before, after)
+	assert.NoError(t, err)
+	assert.Equal(t, []JSONChange{
+		{Path: "args[0]", Op: ChangeModified, Old: "-v", New: "-d"},
+		{Path: "args[1]", Op: ChangeAdded, New: "-v"},
+		{Path: "labels.b", Op: ChangeRemoved, Old: "2"},
+		{Path: "labels.c", Op: ChangeAdded, New: "3"},
+		{Path: "services[web].image", Op: ChangeModified, Old: "web:1", New: "web:2"},
+		{Path: "services[cache]", Op: ChangeAdded, New: map[string]interface{}{"name": "cache"}},
+	}, changes)
+
+	changes, err = DiffJSON(before, before)
+	assert.NoError(t, err)
+	assert.Nil(t, changes)
+
+	changes, err = DiffJSON(nil, &obj{Args: []string{"-v"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []JSONChange{{Op: ChangeAdded, New: map[string]interface{}{"args": []interface{}{"-v"}}}}, changes)
+
+	_, err = DiffJSON(make(chan int), after)
+	assert.Error(t, err)
+}
@@ -2,10 +2,12 @@ package common
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 	"text/template"
 
 	"github.com/baetyl/baetyl-go/v2/errors"
+	pkgerrors "github.com/pkg/errors"
 )
 
 // Field returns a field
@@ -15,24 +17,69 @@ func Field(k string, v interface{}) *F {
 
 // Error returns an error with code and fields
 func Error(c Code, fs ...*F) error {
-	m := c.String()
-	if strings.Contains(m, "{{") {
-		vs := map[string]interface{}{}
-		for _, f := range fs {
-			vs[f.k] = f.v
-		}
-		t, err := template.New(string(c)).Option("missingkey=zero").Parse(m)
-		if err != nil {
-			panic(err)
-		}
-		b := bytes.NewBuffer(nil)
-		err = t.Execute(b, vs)
-		if err != nil {
-			panic(err)
-		}
-		m = b.String()
+	m, err := render(c, c.String(), fs)
+	if err != nil {
+		panic(err)
+	}
+	return &codeError{
+		error:  errors.CodeError(string(c), m),
+		code:   c,
+		fields: fs,
+	}
+}
+
+func render(c Code, m string, fs []*F) (string, error) {
+	if !strings.Contains(m, "{{") {
+		return m, nil
+	}
+	vs := map[string]interface{}{}
+	for _, f := range fs {
+		vs[f.k] = f.v
+	}
+	t, err := template.New(string(c)).Option("missingkey=zero").Parse(m)
+	if err != nil {
+		return "", err
+	}
+	b := bytes.NewBuffer(nil)
+	err = t.Execute(b, vs)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// codeError keeps the fields of error, so that the message can be rendered again in other languages
+type codeError struct {
+	error
+	code   Code
+	fields []*F
+}
+
+func (e *codeError) Code() string {
+	return string(e.code)
+}
+
+func (e *codeError) Cause() error {
+	return e.error
+}
+
+func (e *codeError) Unwrap() error {
+	return e.error
+}
+
+func (e *codeError) StackTrace() pkgerrors.StackTrace {
+	if st, ok := e.error.(interface{ StackTrace() pkgerrors.StackTrace }); ok {
+		return st.StackTrace()
 	}
-	return errors.CodeError(string(c), m)
+	return nil
+}
+
+func (e *codeError) Format(s fmt.State, verb rune) {
+	if f, ok := e.error.(fmt.Formatter); ok {
+		f.Format(s, verb)
+		return
+	}
+	fmt.Fprint(s, e.Error())
 }
 
 // Field field
@@ -40,3 +87,22 @@ type F struct {
 	k string
 	v interface{}
 }
+
+// FieldRetryAfter the field of error carrying the seconds after which the client is asked to retry,
+// which is responded in the Retry-After header
+const FieldRetryAfter = "retryAfter"
+
+// RetryAfter returns the seconds after which the client is asked to retry by the error
+func RetryAfter(err error) (int, bool) {
+	e, ok := err.(*codeError)
+	if !ok {
+		return 0, false
+	}
+	for _, f := range e.fields {
+		if f.k == FieldRetryAfter {
+			sec, ok := f.v.(int)
+			return sec, ok
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,181 @@
+package common
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	LangEnUS = "en-US"
+	LangZhCN = "zh-CN"
+
+	HeaderAcceptLanguage = "Accept-Language"
+)
+
+var (
+	catalogs     = map[string]map[Code]string{}
+	catalogsLock sync.RWMutex
+	languages    = []language.Tag{language.MustParse(LangEnUS), language.MustParse(LangZhCN)}
+	langMatcher  = language.NewMatcher(languages)
+)
+
+// LoadMessageCatalogs loads message catalogs from the directory, each file is named by the language, such as en-US.yml,
+// and contains the templates of codes which override the builtin ones
+func LoadMessageCatalogs(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yml"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		msgs := map[Code]string{}
+		if err = yaml.Unmarshal(data, &msgs); err != nil {
+			return errors.Trace(err)
+		}
+		lang := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		SetMessageCatalog(lang, msgs)
+		log.L().Info("load message catalog", log.Any("language", lang), log.Any("count", len(msgs)))
+	}
+	return nil
+}
+
+// SetMessageCatalog sets the templates of codes in language
+func SetMessageCatalog(lang string, msgs map[Code]string) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		log.L().Warn("unknown language of message catalog", log.Any("language", lang))
+		return
+	}
+
+	catalogsLock.Lock()
+	defer catalogsLock.Unlock()
+	lang = tag.String()
+	catalog, ok := catalogs[lang]
+	if !ok {
+		catalog = map[Code]string{}
+		catalogs[lang] = catalog
+	}
+	if !isSupported(tag) {
+		languages = append(languages, tag)
+		langMatcher = language.NewMatcher(languages)
+	}
+	for k, v := range msgs {
+		catalog[k] = v
+	}
+}
+
+func isSupported(tag language.Tag) bool {
+	for _, v := range languages {
+		if v == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchLanguage returns the supported language which best matches Accept-Language, en-US by default
+func MatchLanguage(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return LangEnUS
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return LangEnUS
+	}
+	catalogsLock.RLock()
+	defer catalogsLock.RUnlock()
+	_, idx, conf := langMatcher.Match(tags...)
+	if conf == language.No {
+		return LangEnUS
+	}
+	return languages[idx].String()
+}
+
+// Localize renders the message of error in language, the message is unchanged if the error is not created by Error
+func Localize(err error, lang string) string {
+	if es, ok := err.(ValidationErrors); ok {
+		msgs := make([]string, 0, len(es))
+		for _, e := range es {
+			msgs = append(msgs, Localize(e, lang))
+		}
+		return strings.Join(msgs, "; ")
+	}
+	var ce *codeError
+	for e := err; e != nil; {
+		if c, ok := e.(*codeError); ok {
+			ce = c
+			break
+		}
+		u, ok := e.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		e = u.Unwrap()
+	}
+	if ce == nil {
+		return err.Error()
+	}
+	m, ok := getMessage(ce.code, lang)
+	if !ok {
+		return err.Error()
+	}
+	res, rerr := render(ce.code, m, ce.fields)
+	if rerr != nil {
+		log.L().Warn("failed to render localized message", log.Any("language", lang), log.Error(rerr))
+		return err.Error()
+	}
+	return res
+}
+
+func getMessage(c Code, lang string) (string, bool) {
+	catalogsLock.RLock()
+	m, ok := catalogs[lang][c]
+	catalogsLock.RUnlock()
+	if ok {
+		return m, true
+	}
+	tmpl, ok := templates[c]
+	if !ok {
+		return "", false
+	}
+	// the builtin templates are written as "chinese\nenglish" or english only
+	zh, en := splitBilingual(tmpl)
+	switch lang {
+	case LangZhCN:
+		if zh != "" {
+			return zh, true
+		}
+		return en, true
+	case LangEnUS:
+		return en, true
+	}
+	return "", false
+}
+
+func splitBilingual(tmpl string) (zh, en string) {
+	parts := strings.SplitN(tmpl, "\n", 2)
+	if len(parts) != 2 || !containsHan(parts[0]) {
+		return "", tmpl
+	}
+	return parts[0], parts[1]
+}
+
+func containsHan(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package common
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchLanguage(t *testing.T) {
+	assert.Equal(t, LangEnUS, MatchLanguage(""))
+	assert.Equal(t, LangEnUS, MatchLanguage("en"))
+	assert.Equal(t, LangZhCN, MatchLanguage("zh-CN,zh;q=0.9,en;q=0.8"))
+	assert.Equal(t, LangZhCN, MatchLanguage("zh"))
+	assert.Equal(t, LangEnUS, MatchLanguage("fr-FR"))
+	assert.Equal(t, LangEnUS, MatchLanguage("!!!"))
+}
+
+func TestLocalize(t *testing.T) {
+	err := Error(ErrResourceNotFound, Field("name", "xxx"))
+	assert.Equal(t, "访问不存在的资源。\nThe resource (xxx) is not found.", err.Error())
+	assert.Equal(t, "The resource (xxx) is not found.", Localize(err, LangEnUS))
+	assert.Equal(t, "访问不存在的资源。", Localize(err, LangZhCN))
+	assert.Equal(t, "The resource (xxx) is not found.", Localize(errors.Trace(err), LangEnUS))
+
+	// english only
+	err = Error(ErrVolumeType, Field("type", "yyy"), Field("name", "baetyl"))
+	assert.Equal(t, "The volume (baetyl) type should be (yyy).", Localize(err, LangZhCN))
+
+	// not created by Error
+	err = fmt.Errorf("custom")
+	assert.Equal(t, "custom", Localize(err, LangZhCN))
+}
+
+func TestLoadMessageCatalogs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "i18n")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "zh-CN.yml"), []byte("ErrVolumeType: \"存储卷{{if .name}}（{{.name}}）{{end}}类型错误。\""), 0644)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "ja-JP.yml"), []byte("ErrRequestAccessDenied: \"アクセスが拒否されました。\""), 0644)
+	assert.NoError(t, err)
+	err = LoadMessageCatalogs(dir)
+	assert.NoError(t, err)
+
+	err = Error(ErrVolumeType, Field("name", "baetyl"))
+	assert.Equal(t, "存储卷（baetyl）类型错误。", Localize(err, LangZhCN))
+	assert.Equal(t, "ja-JP", MatchLanguage("ja"))
+	assert.Equal(t, "アクセスが拒否されました。", Localize(Error(ErrRequestAccessDenied), "ja-JP"))
+
+	err = ioutil.WriteFile(filepath.Join(dir, "en-US.yml"), []byte("{{"), 0644)
+	assert.NoError(t, err)
+	err = LoadMessageCatalogs(dir)
+	assert.Error(t, err)
+}
+
+func TestPopulateFailedResponseLocalized(t *testing.T) {
+	test404 := func(c *Context) (interface{}, error) {
+		return nil, Error(ErrResourceNotFound, Field("name", "test"))
+	}
+	router := gin.Default()
+	router.GET("/404", Wrapper(test404))
+
+	req, _ := http.NewRequest(http.MethodGet, "/404", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.True(t, strings.Contains(w.Body.String(), "\\n"))
+
+	req, _ = http.NewRequest(http.MethodGet, "/404", nil)
+	req.Header.Set(HeaderAcceptLanguage, "en-US,en;q=0.9")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "\"message\":\"The resource (test) is not found.\"")
+}
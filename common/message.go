@@ -24,15 +24,24 @@ const (
 	ErrRequestAccessDenied   = "ErrRequestAccessDenied"
 	ErrRequestMethodNotFound = "ErrRequestMethodNotFound"
 	ErrRequestParamInvalid   = "ErrRequestParamInvalid"
+	ErrTooManyRequests       = "ErrTooManyRequests"
+	ErrRequestTimeout        = "ErrRequestTimeout"
+	ErrIdempotencyKeyReused  = "ErrIdempotencyKeyReused"
+	ErrIdempotencyInProgress = "ErrIdempotencyInProgress"
+	ErrImpersonationReadOnly = "ErrImpersonationReadOnly"
+	ErrPlatformReadOnly      = "ErrPlatformReadOnly"
 	// * resource
 	ErrResourceNotFound        = "ErrResourceNotFound"
 	ErrResourceAccessForbidden = "ErrResourceAccessForbidden"
 	ErrResourceConflict        = "ErrResourceConflict"
 	ErrResourceDeleteForbidden = "ErrResourceDeleteForbidden"
 	ErrResourceHasBeenUsed     = "ErrResourceHasBeenUsed"
+	ErrResourceReferenced      = "ErrResourceReferenced"
 	ErrSubResourceExist        = "ErrSubResourceExist"
 	ErrNodeNotReady            = "ErrNodeNotReady"
 	ErrInvalidToken            = "ErrInvalidToken"
+	ErrNamespaceFrozen         = "ErrNamespaceFrozen"
+	ErrModuleUnavailable       = "ErrModuleUnavailable"
 
 	// * volumes
 	ErrVolumeType = "ErrVolumeType"
@@ -42,9 +51,15 @@ const (
 	ErrAppNameConflict         = "ErrAppNameConflict"
 	ErrVolumeNotFoundWhenMount = "ErrVolumeNotFoundWhenMount"
 	ErrAppReferencedByNode     = "ErrAppReferencedByNode"
+	ErrAppQuarantined          = "ErrAppQuarantined"
 	// * node
 	ErrNodeNumMaxLimit       = "ErrNodeNumMaxLimit"
 	ErrNodeNumQueryException = "ErrNodeNumQueryException"
+	ErrNodeNotDrained        = "ErrNodeNotDrained"
+	ErrNodeAcceleratorShort  = "ErrNodeAcceleratorShort"
+	ErrNodeCapacityShort     = "ErrNodeCapacityShort"
+	ErrNodeFingerprint       = "ErrNodeFingerprint"
+	ErrNodePreflight         = "ErrNodePreflight"
 
 	// * config
 	ErrConfigInUsed = "ErrConfigInUsed"
@@ -109,13 +124,22 @@ var templates = map[Code]string{
 	ErrRequestAccessDenied:   "访问已被拒绝。\nThe request access is denied.",
 	ErrRequestMethodNotFound: "访问了不存在的api接口。\nThe request method is not found.",
 	ErrRequestParamInvalid:   "非法的请求参数。\nThe request parameter is invalid.{{if .error}} ({{.error}}){{end}}",
+	ErrTooManyRequests:       "请求过于频繁，请稍后重试。\nToo many requests, please try again later.",
+	ErrRequestTimeout:        "请求处理超时。\nThe request is timed out.",
+	ErrIdempotencyKeyReused:  "幂等键已被其他请求使用。\nThe Idempotency-Key{{if .key}} ({{.key}}){{end}} is already used by another request.",
+	ErrIdempotencyInProgress: "相同幂等键的请求正在处理中。\nThe request with the same Idempotency-Key{{if .key}} ({{.key}}){{end}} is in progress.",
+	ErrImpersonationReadOnly: "模拟用户访问仅可读取资源。\nThe impersonation{{if .user}} of user ({{.user}}){{end}} can only read the resources.",
+	ErrPlatformReadOnly:      "平台处于只读模式，暂不可修改资源。\nThe platform is read-only{{if .reason}} ({{.reason}}){{end}}, the resources can only be read, please retry later.",
 	// * resource
 	ErrResourceNotFound:        "访问不存在的资源。\nThe {{if .type}}({{.type}}) {{end}}resource{{if .name}} ({{.name}}){{end}} is not found{{if .namespace}} in namespace({{.namespace}}){{end}}.",
 	ErrResourceAccessForbidden: "The {{if .type}}({{.type}}) {{end}}resource{{if .name}} ({{.name}}){{end}} can not be accessed{{if .namespace}} in namespace({{.namespace}}){{end}}.",
 	ErrResourceConflict:        "The {{if .type}}({{.type}}) {{end}}resource{{if .name}} ({{.name}}){{end}} already exist.",
 	ErrResourceHasBeenUsed:     "该资源名称已被占用，请更换命名。The {{if .type}}({{.type}}) {{end}}resource{{if .name}} ({{.name}}){{end}} has been used.",
+	ErrResourceReferenced:      "该资源正在被应用使用，请先解除引用。\nThe {{if .type}}({{.type}}) {{end}}resource{{if .name}} ({{.name}}){{end}} is referenced by apps{{if .apps}} ({{.apps}}){{end}}, delete it with force=true to ignore the references.",
 	ErrSubResourceExist:        "该资源下存在子资源未删除，请删除后重试。The {{if .type}}({{.type}}) {{end}}resource{{if .name}} ({{.name}}){{end}} exist",
 	ErrResourceDeleteForbidden: "The {{if .type}}({{.type}}) {{end}}resource{{if .name}} ({{.name}}){{end}} can not be deleted{{if .namespace}} in namespace({{.namespace}}){{end}}",
+	ErrNamespaceFrozen:         "命名空间已冻结，仅可读取资源。\nThe namespace{{if .namespace}} ({{.namespace}}){{end}} is frozen{{if .reason}} ({{.reason}}){{end}}, the resources can only be read.",
+	ErrModuleUnavailable:       "该模块版本不可用。\nThe version{{if .version}} ({{.version}}){{end}} of module{{if .name}} ({{.name}}){{end}} is unavailable{{if .error}}, {{.error}}{{end}}.",
 	// * volumes
 	ErrVolumeType: "The volume{{if .name}} ({{.name}}){{end}} type should be{{if .type}} ({{.type}}){{end}}.",
 	// * unknown
@@ -125,9 +149,15 @@ var templates = map[Code]string{
 	ErrVolumeNotFoundWhenMount: "The mount volume name{{if .name}}({{.name}}){{end}} can't find in the Volumes[].",
 	ErrNodeNotReady:            "The node {{if .name}}({{.name}} ){{end}}is not ready, please retry later.",
 	ErrAppReferencedByNode:     "The {{if .name}}({{.name}}){{end}} app is still referenced by a node.",
+	ErrAppQuarantined:          "该资源已被平台隔离，禁止部署。\nThe {{if .type}}({{.type}}) {{end}}resource{{if .name}} ({{.name}}){{end}} is quarantined by the platform{{if .reason}} ({{.reason}}){{end}}, the app{{if .app}} ({{.app}}){{end}} can not be deployed.",
 	// * node
 	ErrNodeNumMaxLimit:       "节点个数已达上线，请联系相关人员申请更高节点限额。\nThe number of nodes reaches the maximum limit",
 	ErrNodeNumQueryException: "The number of nodes is null",
+	ErrNodeNotDrained:        "节点上仍有应用在运行，请先驱逐节点。\nThe node {{if .name}}({{.name}}) {{end}}is still running apps{{if .apps}} ({{.apps}}){{end}}, please drain it first.",
+	ErrNodeAcceleratorShort:  "节点加速卡资源不足。\nThe node {{if .name}}({{.name}}) {{end}}has {{if .capacity}}{{.capacity}}{{else}}no{{end}} {{if .resource}}{{.resource}}{{else}}accelerator{{end}}, but {{if .request}}{{.request}}{{end}} is requested by the app.",
+	ErrNodeCapacityShort:     "节点资源不足。\nThe node {{if .name}}({{.name}}) {{end}}has {{if .capacity}}{{.capacity}} {{end}}{{.resource}} in capacity, but {{if .request}}{{.request}}{{end}} is requested by the app.",
+	ErrNodeFingerprint:       "节点硬件指纹不匹配。\nThe hardware fingerprint does not match the one bound to node {{if .name}}({{.name}}){{end}}, please reset the binding if the hardware is replaced.",
+	ErrNodePreflight:         "节点主机预检未通过。\nThe host of node {{if .name}}({{.name}}) {{end}}failed the preflight checks{{if .errors}}: {{.errors}}{{end}}, please fix the host and install again.",
 	// * config
 	ErrConfigInUsed: "该配置名称已被占用，请更换配置名称。\nThe config name {{if .name}}({{.name}}){{end}} in used.",
 	// * register
@@ -184,14 +214,33 @@ var templates = map[Code]string{
 	ErrDataTooLarge:    "数据量过大。\nData too large. Resource {{if .name}}({{.name}}){{end}}, size={{if .size}}({{.size}}){{end}}, max={{if .max}}({{.max}}){{end}}",
 }
 
+// RegisterTemplate registers the message template of code which is defined outside, such as the codes of custom validations,
+// the templates are written as "chinese\nenglish" or english only. It should be called in init
+func RegisterTemplate(c Code, tmpl string) {
+	if tmpl == "" {
+		return
+	}
+	templates[c] = tmpl
+}
+
 func getHTTPStatus(c Code) int {
 	switch c {
 	case ErrResourceNotFound, ErrRequestMethodNotFound:
 		return http.StatusNotFound
 	case ErrRequestAccessDenied:
 		return http.StatusUnauthorized
-	case ErrResourceHasBeenUsed:
+	case ErrResourceHasBeenUsed, ErrNodeFingerprint, ErrNodePreflight, ErrNamespaceFrozen, ErrImpersonationReadOnly, ErrAppQuarantined:
 		return http.StatusForbidden
+	case ErrTooManyRequests:
+		return http.StatusTooManyRequests
+	case ErrRequestTimeout:
+		return http.StatusGatewayTimeout
+	case ErrPlatformReadOnly:
+		return http.StatusServiceUnavailable
+	case ErrIdempotencyKeyReused:
+		return http.StatusUnprocessableEntity
+	case ErrIdempotencyInProgress, ErrResourceReferenced:
+		return http.StatusConflict
 	case ErrUnknown:
 		return http.StatusInternalServerError
 	default:
@@ -0,0 +1,132 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MetricsContentType the content type of prometheus text exposition format
+const MetricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultLatencyBuckets the upper bounds of latency buckets in seconds
+var DefaultLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// RequestDuration the latency histogram of http requests, labeled by server, method and route template
+var RequestDuration = NewHistogram("baetyl_cloud_http_request_duration_seconds",
+	"The latency of http requests in seconds.", DefaultLatencyBuckets, "server", "method", "route")
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64
+	sum         float64
+	count       uint64
+}
+
+// Histogram a histogram which is exposed in prometheus text format
+type Histogram struct {
+	name       string
+	help       string
+	buckets    []float64
+	labelNames []string
+	series     map[string]*histogramSeries
+	mutex      sync.RWMutex
+}
+
+// NewHistogram create a histogram, the buckets are the upper bounds in increasing order
+func NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	bs := make([]float64, len(buckets))
+	copy(bs, buckets)
+	sort.Float64s(bs)
+	return &Histogram{
+		name:       name,
+		help:       help,
+		buckets:    bs,
+		labelNames: labelNames,
+		series:     map[string]*histogramSeries{},
+	}
+}
+
+// Observe adds a value into the series of label values, which are in the same order as label names
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	if len(labelValues) != len(h.labelNames) {
+		return
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues: append([]string{}, labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+	for i, b := range h.buckets {
+		if value <= b {
+			s.counts[i]++
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+// WriteTo writes the histogram in prometheus text format
+func (h *Histogram) WriteTo(w io.Writer) (int64, error) {
+	h.mutex.RLock()
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# HELP %s %s\n", h.name, h.help)
+	fmt.Fprintf(&sb, "# TYPE %s histogram\n", h.name)
+	for _, k := range keys {
+		s := h.series[k]
+		labels := h.formatLabels(s.labelValues)
+		for i, b := range h.buckets {
+			fmt.Fprintf(&sb, "%s_bucket{%s} %d\n", h.name, joinLabels(labels, `le="`+formatFloat(b)+`"`), s.counts[i])
+		}
+		fmt.Fprintf(&sb, "%s_bucket{%s} %d\n", h.name, joinLabels(labels, `le="+Inf"`), s.count)
+		fmt.Fprintf(&sb, "%s_sum{%s} %s\n", h.name, labels, formatFloat(s.sum))
+		fmt.Fprintf(&sb, "%s_count{%s} %d\n", h.name, labels, s.count)
+	}
+	h.mutex.RUnlock()
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+func (h *Histogram) formatLabels(values []string) string {
+	pairs := make([]string, len(values))
+	for i, v := range values {
+		pairs[i] = h.labelNames[i] + `="` + escapeLabelValue(v) + `"`
+	}
+	return strings.Join(pairs, ",")
+}
+
+func joinLabels(labels, extra string) string {
+	if labels == "" {
+		return extra
+	}
+	return labels + "," + extra
+}
+
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram("test_duration_seconds", "The test latency.", []float64{1, 0.1}, "route")
+	h.Observe(0.05, "/v1/nodes")
+	h.Observe(0.5, "/v1/nodes")
+	h.Observe(2, "/v1/nodes")
+	h.Observe(0.2, `/v1/"a"`)
+	// mismatched labels are dropped
+	h.Observe(0.2)
+
+	buf := bytes.NewBuffer(nil)
+	_, err := h.WriteTo(buf)
+	assert.NoError(t, err)
+	expect := `# HELP test_duration_seconds The test latency.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{route="/v1/\"a\"",le="0.1"} 0
+test_duration_seconds_bucket{route="/v1/\"a\"",le="1"} 1
+test_duration_seconds_bucket{route="/v1/\"a\"",le="+Inf"} 1
+test_duration_seconds_sum{route="/v1/\"a\""} 0.2
+test_duration_seconds_count{route="/v1/\"a\""} 1
+test_duration_seconds_bucket{route="/v1/nodes",le="0.1"} 1
+test_duration_seconds_bucket{route="/v1/nodes",le="1"} 2
+test_duration_seconds_bucket{route="/v1/nodes",le="+Inf"} 3
+test_duration_seconds_sum{route="/v1/nodes"} 2.55
+test_duration_seconds_count{route="/v1/nodes"} 3
+`
+	assert.Equal(t, expect, buf.String())
+}
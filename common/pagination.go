@@ -0,0 +1,121 @@
+package common
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxPageLimit the max number of items returned by one list request
+	MaxPageLimit = 1000
+
+	offsetTokenPrefix = "offset:"
+)
+
+// Pagination pagination params of list request
+// Continue is the opaque token issued by the storage (such as kubernetes),
+// the token issued by ListResponse is decoded into Offset
+type Pagination struct {
+	Limit    int
+	Offset   int
+	Continue string
+}
+
+// ListResponse the envelope of list response
+type ListResponse struct {
+	Total     int         `json:"total"`
+	Items     interface{} `json:"items"`
+	NextToken string      `json:"nextToken,omitempty"`
+}
+
+// LoadPagination parses limit/offset/continue from query,
+// pageNo/pageSize is still supported and converted to limit/offset
+func (c *Context) LoadPagination() (*Pagination, error) {
+	p := &Pagination{}
+	var err error
+	if p.Limit, err = c.queryInt("limit", "pageSize"); err != nil {
+		return nil, err
+	}
+	if p.Limit < 0 || p.Limit > MaxPageLimit {
+		return nil, Error(ErrRequestParamInvalid, Field("error", "limit should be between 0 and "+strconv.Itoa(MaxPageLimit)))
+	}
+	if p.Offset, err = c.queryInt("offset"); err != nil {
+		return nil, err
+	}
+	if _, ok := c.GetQuery("offset"); !ok {
+		pageNo, err := c.queryInt("pageNo")
+		if err != nil {
+			return nil, err
+		}
+		if pageNo > 1 {
+			p.Offset = (pageNo - 1) * p.Limit
+		}
+	}
+	if p.Offset < 0 {
+		return nil, Error(ErrRequestParamInvalid, Field("error", "offset should not be negative"))
+	}
+
+	token := c.Query("continue")
+	if token == "" {
+		return p, nil
+	}
+	if _, ok := c.GetQuery("offset"); ok {
+		return nil, Error(ErrRequestParamInvalid, Field("error", "offset and continue can not be used together"))
+	}
+	if offset, ok := decodeOffsetToken(token); ok {
+		p.Offset = offset
+	} else {
+		p.Continue = token
+	}
+	return p, nil
+}
+
+func (c *Context) queryInt(keys ...string) (int, error) {
+	for _, k := range keys {
+		v, ok := c.GetQuery(k)
+		if !ok || v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, Error(ErrRequestParamInvalid, Field("error", k+" should be an integer"))
+		}
+		return n, nil
+	}
+	return 0, nil
+}
+
+// NextOffsetToken returns the token of next page, or empty if there is no more item
+func (p *Pagination) NextOffsetToken(total int) string {
+	return NextOffsetToken(p.Offset, p.Limit, total)
+}
+
+// NextOffsetToken returns the token of the page after the one starts at offset
+func NextOffsetToken(offset, limit, total int) string {
+	if limit <= 0 || offset+limit >= total {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(offsetTokenPrefix + strconv.Itoa(offset+limit)))
+}
+
+func decodeOffsetToken(token string) (int, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(data), offsetTokenPrefix) {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(data), offsetTokenPrefix))
+	if err != nil || offset < 0 {
+		return 0, false
+	}
+	return offset, true
+}
+
+// NewListResponse create a list response
+func NewListResponse(total int, items interface{}, nextToken string) *ListResponse {
+	return &ListResponse{
+		Total:     total,
+		Items:     items,
+		NextToken: nextToken,
+	}
+}
@@ -0,0 +1,61 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadPagination(t *testing.T, query string) (*Pagination, error) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/list?"+query, nil)
+	assert.NotNil(t, c.Request)
+	return NewContext(c).LoadPagination()
+}
+
+func TestLoadPagination(t *testing.T) {
+	p, err := loadPagination(t, "")
+	assert.NoError(t, err)
+	assert.Equal(t, &Pagination{}, p)
+
+	p, err = loadPagination(t, "limit=10&offset=20")
+	assert.NoError(t, err)
+	assert.Equal(t, &Pagination{Limit: 10, Offset: 20}, p)
+
+	// compatible with pageNo and pageSize
+	p, err = loadPagination(t, "pageNo=3&pageSize=10")
+	assert.NoError(t, err)
+	assert.Equal(t, &Pagination{Limit: 10, Offset: 20}, p)
+
+	token := p.NextOffsetToken(100)
+	assert.NotEmpty(t, token)
+	p, err = loadPagination(t, "limit=10&continue="+token)
+	assert.NoError(t, err)
+	assert.Equal(t, &Pagination{Limit: 10, Offset: 30}, p)
+	assert.Equal(t, "", p.NextOffsetToken(40))
+
+	// token issued by storage is passed through
+	p, err = loadPagination(t, "limit=10&continue=abc")
+	assert.NoError(t, err)
+	assert.Equal(t, &Pagination{Limit: 10, Continue: "abc"}, p)
+
+	_, err = loadPagination(t, "limit=abc")
+	assert.Error(t, err)
+	_, err = loadPagination(t, "limit=-1")
+	assert.Error(t, err)
+	_, err = loadPagination(t, "limit=1001")
+	assert.Error(t, err)
+	_, err = loadPagination(t, "offset=-1")
+	assert.Error(t, err)
+	_, err = loadPagination(t, "offset=10&continue=abc")
+	assert.Error(t, err)
+}
+
+func TestNewListResponse(t *testing.T) {
+	res := NewListResponse(2, []string{"a", "b"}, "")
+	assert.Equal(t, &ListResponse{Total: 2, Items: []string{"a", "b"}}, res)
+}
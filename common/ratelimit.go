@@ -0,0 +1,105 @@
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// RateLimitKeyNamespace limits requests by the namespace of context
+	RateLimitKeyNamespace = "namespace"
+	// RateLimitKeyIP limits requests by the client ip
+	RateLimitKeyIP = "ip"
+
+	rateLimiterIdleTime = 10 * time.Minute
+)
+
+// RateLimitKeyFunc returns the key of the bucket which the request is counted in
+type RateLimitKeyFunc func(c *Context) string
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter keeps a token bucket for each key, idle buckets are dropped after a while
+type RateLimiter struct {
+	qps       rate.Limit
+	burst     int
+	limiters  map[string]*limiterEntry
+	lastSweep time.Time
+	mutex     sync.Mutex
+}
+
+// NewRateLimiter create a rate limiter, which allows qps requests per second and bursts of at most burst requests for each key
+func NewRateLimiter(qps float64, burst int) *RateLimiter {
+	if burst <= 0 {
+		burst = int(qps)
+		if burst <= 0 {
+			burst = 1
+		}
+	}
+	return &RateLimiter{
+		qps:       rate.Limit(qps),
+		burst:     burst,
+		limiters:  map[string]*limiterEntry{},
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow reports whether a request of key may happen now
+func (r *RateLimiter) Allow(key string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.lastSweep) > rateLimiterIdleTime {
+		for k, v := range r.limiters {
+			if now.Sub(v.lastSeen) > rateLimiterIdleTime {
+				delete(r.limiters, k)
+			}
+		}
+		r.lastSweep = now
+	}
+
+	entry, ok := r.limiters[key]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(r.qps, r.burst)}
+		r.limiters[key] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter.AllowN(now, 1)
+}
+
+// GetRateLimitKeyFunc returns the key func by the kind, namespace or ip
+func GetRateLimitKeyFunc(kind string) RateLimitKeyFunc {
+	switch kind {
+	case RateLimitKeyIP:
+		return func(c *Context) string {
+			return c.ClientIP()
+		}
+	default:
+		return func(c *Context) string {
+			return c.GetNamespace()
+		}
+	}
+}
+
+// WrapperRateLimit rejects the request with ErrTooManyRequests if the bucket of the key is exhausted,
+// it should be chained before Wrapper
+func WrapperRateLimit(limiter *RateLimiter, keyFunc RateLimitKeyFunc) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		cc := NewContext(c)
+		key := keyFunc(cc)
+		if key == "" || limiter.Allow(key) {
+			cc.Next()
+			return
+		}
+		log.L().Warn("request is rate limited", log.Any(cc.GetTrace()), log.Any("key", key))
+		PopulateFailedResponse(cc, Error(ErrTooManyRequests), true)
+	}
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(1, 2)
+	assert.True(t, limiter.Allow("a"))
+	assert.True(t, limiter.Allow("a"))
+	assert.False(t, limiter.Allow("a"))
+	// buckets are isolated by key
+	assert.True(t, limiter.Allow("b"))
+
+	// burst falls back to qps
+	limiter = NewRateLimiter(0.5, 0)
+	assert.Equal(t, 1, limiter.burst)
+}
+
+func TestWrapperRateLimit(t *testing.T) {
+	test200 := func(c *Context) (interface{}, error) {
+		return nil, nil
+	}
+	mockNamespace := func(c *gin.Context) {
+		c.Set("namespace", c.GetHeader("namespace"))
+	}
+	router := gin.Default()
+	router.Use(mockNamespace)
+	router.Use(WrapperRateLimit(NewRateLimiter(1, 1), GetRateLimitKeyFunc(RateLimitKeyNamespace)))
+	router.GET("/200", Wrapper(test200))
+
+	req, _ := http.NewRequest(http.MethodGet, "/200", nil)
+	req.Header.Set("namespace", "ns1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Body.String(), ErrTooManyRequests)
+
+	req, _ = http.NewRequest(http.MethodGet, "/200", nil)
+	req.Header.Set("namespace", "ns2")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
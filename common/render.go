@@ -0,0 +1,114 @@
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ContentTypeJSON = "application/json; charset=utf-8"
+	ContentTypeYAML = "application/yaml; charset=utf-8"
+
+	// responses smaller than gzipMinLength are not worth compressing
+	gzipMinLength = 1024
+)
+
+// acceptYAML reports whether the client prefers yaml to json
+func (c *Context) acceptYAML() bool {
+	for _, v := range strings.Split(c.GetHeader("Accept"), ",") {
+		mime := strings.TrimSpace(strings.SplitN(v, ";", 2)[0])
+		switch mime {
+		case "application/yaml", "application/x-yaml", "text/yaml":
+			return true
+		case "application/json":
+			return false
+		}
+	}
+	return false
+}
+
+func (c *Context) acceptGzip() bool {
+	for _, v := range strings.Split(c.GetHeader("Accept-Encoding"), ",") {
+		parts := strings.SplitN(strings.TrimSpace(v), ";", 2)
+		if parts[0] != "gzip" {
+			continue
+		}
+		// gzip;q=0 means not acceptable
+		return len(parts) == 1 || strings.ReplaceAll(parts[1], " ", "") != "q=0"
+	}
+	return false
+}
+
+// RenderResponse writes the response in the format of Accept (json by default), and compresses it if Accept-Encoding allows
+func (c *Context) RenderResponse(status int, res interface{}) {
+	if !c.acceptYAML() && !c.acceptGzip() {
+		c.PureJSON(status, res)
+		return
+	}
+	data, contentType, err := marshalResponse(res, c.acceptYAML())
+	if err != nil {
+		PopulateFailedResponse(c, Error(ErrUnknown, Field("error", err.Error())), false)
+		return
+	}
+	c.Compress(status, contentType, data)
+}
+
+// Compress writes the data, which is compressed by gzip if Accept-Encoding allows
+func (c *Context) Compress(status int, contentType string, data []byte) {
+	if len(data) < gzipMinLength || !c.acceptGzip() {
+		c.Data(status, contentType, data)
+		return
+	}
+	buf := bytes.NewBuffer(nil)
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(data); err != nil {
+		c.Data(status, contentType, data)
+		return
+	}
+	if err := w.Close(); err != nil {
+		c.Data(status, contentType, data)
+		return
+	}
+	c.Header("Content-Encoding", "gzip")
+	c.Header("Vary", "Accept-Encoding")
+	c.Data(status, contentType, buf.Bytes())
+}
+
+func marshalResponse(res interface{}, toYAML bool) ([]byte, string, error) {
+	buf := bytes.NewBuffer(nil)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(res); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if !toYAML {
+		return buf.Bytes(), ContentTypeJSON, nil
+	}
+	data, err := JSONToYAML(buf.Bytes())
+	if err != nil {
+		return nil, "", err
+	}
+	return data, ContentTypeYAML, nil
+}
+
+// JSONToYAML converts json to yaml, the field names of json tags are kept, as well as the order of fields
+func JSONToYAML(data []byte) ([]byte, error) {
+	// json is a subset of yaml, decoding into MapSlice keeps the order of fields,
+	// the data is wrapped into an object so that nested objects of arrays are decoded into MapSlice as well
+	var ms yaml.MapSlice
+	wrapped := append(append([]byte(`{"v":`), data...), '}')
+	if err := yaml.Unmarshal(wrapped, &ms); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var obj interface{}
+	if len(ms) == 1 {
+		obj = ms[0].Value
+	}
+	res, err := yaml.Marshal(obj)
+	return res, errors.Trace(err)
+}
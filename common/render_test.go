@@ -0,0 +1,87 @@
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type renderItem struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Count  int               `json:"count"`
+}
+
+func serveWrapper(t *testing.T, headers map[string]string, res interface{}) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.GET("/test", Wrapper(func(c *Context) (interface{}, error) {
+		return res, nil
+	}))
+	req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+	assert.NotNil(t, req)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRenderResponse(t *testing.T) {
+	item := &renderItem{Name: "a&b", Labels: map[string]string{"k": "v"}, Count: 1}
+
+	w := serveWrapper(t, nil, item)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ContentTypeJSON, w.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"name\":\"a&b\",\"labels\":{\"k\":\"v\"},\"count\":1}\n", w.Body.String())
+
+	w = serveWrapper(t, map[string]string{"Accept": "application/yaml"}, item)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ContentTypeYAML, w.Header().Get("Content-Type"))
+	assert.Equal(t, "name: a&b\nlabels:\n  k: v\ncount: 1\n", w.Body.String())
+
+	w = serveWrapper(t, map[string]string{"Accept": "application/json, application/yaml"}, item)
+	assert.Equal(t, ContentTypeJSON, w.Header().Get("Content-Type"))
+
+	w = serveWrapper(t, map[string]string{"Accept": "application/x-yaml"}, []*renderItem{item})
+	assert.Equal(t, "- name: a&b\n  labels:\n    k: v\n  count: 1\n", w.Body.String())
+
+	// small responses are not compressed
+	w = serveWrapper(t, map[string]string{"Accept-Encoding": "gzip"}, item)
+	assert.Equal(t, "", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "{\"name\":\"a&b\",\"labels\":{\"k\":\"v\"},\"count\":1}\n", w.Body.String())
+
+	large := &renderItem{Name: strings.Repeat("a", 2*gzipMinLength), Count: 2}
+	w = serveWrapper(t, map[string]string{"Accept-Encoding": "deflate, gzip"}, large)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	r, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+	assert.NoError(t, err)
+	data, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"name\":\""+large.Name+"\",\"count\":2}\n", string(data))
+
+	w = serveWrapper(t, map[string]string{"Accept-Encoding": "gzip;q=0"}, large)
+	assert.Equal(t, "", w.Header().Get("Content-Encoding"))
+}
+
+func TestJSONToYAML(t *testing.T) {
+	data, err := JSONToYAML([]byte(`{"b":1,"a":{"d":[1,2],"c":"x"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "b: 1\na:\n  d:\n  - 1\n  - 2\n  c: x\n", string(data))
+
+	data, err = JSONToYAML([]byte(`"abc"`))
+	assert.NoError(t, err)
+	assert.Equal(t, "abc\n", string(data))
+
+	_, err = JSONToYAML([]byte(`{"a":`))
+	assert.Error(t, err)
+}
@@ -1,10 +1,13 @@
 package common
 
 import (
+	"encoding/json"
 	"strings"
 
+	"github.com/baetyl/baetyl-go/v2/errors"
 	v1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 	uuid2 "github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // TODO: use uuid v4
@@ -40,6 +43,40 @@ func CompareNumericalString(a, b string) int {
 
 }
 
+// CompareVersion compares the versions such as v2.2.1, the numbers separated by dot are compared in order,
+// the prefix v and the suffix after - or + are ignored
+func CompareVersion(a, b string) int {
+	as, bs := versionSegments(a), versionSegments(b)
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := "0", "0"
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		if res := CompareNumericalString(x, y); res != 0 {
+			return res
+		}
+	}
+	return 0
+}
+
+func versionSegments(v string) []string {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	segments := strings.Split(v, ".")
+	for i, s := range segments {
+		if s = strings.TrimLeft(s, "0"); s == "" {
+			s = "0"
+		}
+		segments[i] = s
+	}
+	return segments
+}
+
 func AddSystemLabel(labels map[string]string, infos map[string]string) map[string]string {
 	if labels == nil {
 		labels = make(map[string]string)
@@ -52,6 +89,27 @@ func AddSystemLabel(labels map[string]string, infos map[string]string) map[strin
 	return labels
 }
 
+// NodeSyncLink returns the link selected by the labels of node
+func NodeSyncLink(labels map[string]string) string {
+	if link := labels[LabelSyncLink]; link != "" {
+		return link
+	}
+	return SyncLinkHTTP
+}
+
+// NodeDownloadRate returns the bytes per second set by the labels of node which the node downloads objects at, 0 if not set
+func NodeDownloadRate(labels map[string]string) (int64, error) {
+	v := labels[LabelDownloadRate]
+	if v == "" {
+		return 0, nil
+	}
+	q, err := resource.ParseQuantity(v)
+	if err != nil || q.Sign() <= 0 {
+		return 0, Error(ErrRequestParamInvalid, Field("error", "the download rate of node must be a positive quantity, such as 10Mi"))
+	}
+	return q.Value(), nil
+}
+
 func UpdateSysAppByAccelerator(accelerator string, sysApps []string) []string {
 	found := false
 	index := 0
@@ -73,3 +131,16 @@ func UpdateSysAppByAccelerator(accelerator string, sysApps []string) []string {
 	}
 	return sysApps
 }
+
+// DecodeReport decodes the value of key in report, which may be stored as raw json object
+func DecodeReport(report v1.Report, key string, out interface{}) error {
+	val, ok := report[key]
+	if !ok || val == nil {
+		return nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(json.Unmarshal(data, out))
+}
@@ -97,3 +97,25 @@ func TestUpdateSysAppByAccelerator(t *testing.T) {
 	assert.Equal(t, expectedApps, resApps)
 
 }
+
+func TestCompareVersion(t *testing.T) {
+	assert.Equal(t, 0, CompareVersion("v2.2.0", "2.2"))
+	assert.Equal(t, 0, CompareVersion("v2.2.0-rc1", "v2.2.0"))
+	assert.Equal(t, -1, CompareVersion("v2.2.0", "v2.10.0"))
+	assert.Equal(t, 1, CompareVersion("v2.2.1", "v2.2.0"))
+	assert.Equal(t, 1, CompareVersion("3", "v2.9.9"))
+	assert.Equal(t, 0, CompareVersion("v2.02", "v2.2"))
+}
+
+func TestNodeDownloadRate(t *testing.T) {
+	rate, err := NodeDownloadRate(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rate)
+	rate, err = NodeDownloadRate(map[string]string{LabelDownloadRate: "10Mi"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10*1024*1024), rate)
+	_, err = NodeDownloadRate(map[string]string{LabelDownloadRate: "fast"})
+	assert.Error(t, err)
+	_, err = NodeDownloadRate(map[string]string{LabelDownloadRate: "0"})
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/baetyl/baetyl-go/v2/errors"
 	"gopkg.in/go-playground/validator.v9"
 )
 
@@ -57,6 +58,78 @@ func init() {
 	}
 }
 
+// RegisterValidation registers a field validation which is used by the tag in `validate`,
+// the failure is reported with the tag as code, whose message is rendered by tmpl.
+// It is not safe to register concurrently with validating, so it should be called in init
+func RegisterValidation(tag string, fn validator.Func, tmpl string) error {
+	if err := validate.RegisterValidation(tag, fn); err != nil {
+		return errors.Trace(err)
+	}
+	RegisterTemplate(Code(tag), tmpl)
+	return nil
+}
+
+// RegisterStructValidation registers a cross-field validation of types, which is applied wherever the types are validated,
+// including the nested ones. The failures are reported by validator.StructLevel.ReportError with the code as tag,
+// whose template is registered by RegisterTemplate. It should be called in init as well
+func RegisterStructValidation(fn validator.StructLevelFunc, types ...interface{}) {
+	validate.RegisterStructValidation(fn, types...)
+}
+
+// ValidationErrors the errors of all invalid fields of a request
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, v := range e {
+		msgs = append(msgs, v.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Code returns the code of the first error
+func (e ValidationErrors) Code() string {
+	if len(e) == 0 {
+		return ErrUnknown
+	}
+	if c, ok := e[0].(errors.Coder); ok {
+		return c.Code()
+	}
+	return ErrUnknown
+}
+
+func (e ValidationErrors) Unwrap() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e[0]
+}
+
+// ValidateStruct validates the object by tags and registered validations, all invalid fields are returned
+func ValidateStruct(obj interface{}) error {
+	err := validate.Struct(obj)
+	if err == nil {
+		return nil
+	}
+	es, ok := err.(validator.ValidationErrors)
+	if !ok || len(es) == 0 {
+		return err
+	}
+	res := make(ValidationErrors, 0, len(es))
+	for _, v := range es {
+		fs := []*F{Field(v.Tag(), v.Field()), Field("error", v.Error())}
+		if v.Param() != "" {
+			fs = append(fs, Field("param", v.Param()))
+		}
+		res = append(res, Error(Code(v.Tag()), fs...))
+	}
+	// a single error is returned as it is, the same as before
+	if len(res) == 1 {
+		return res[0]
+	}
+	return res
+}
+
 func genValidFunc(str string) validator.Func {
 	return func(fl validator.FieldLevel) bool {
 		match, _ := regexp.MatchString(str, fl.Field().String())
@@ -0,0 +1,88 @@
+package common
+
+import (
+	"time"
+)
+
+// WorkerPool runs the jobs by a fixed number of workers, the jobs are queued while all the workers are busy.
+// The jobs are rejected with ErrTooManyRequests once the queue is full or they wait longer than the timeout,
+// asking clients to retry after a while
+type WorkerPool struct {
+	jobs       chan *poolJob
+	timeout    time.Duration
+	retryAfter time.Duration
+}
+
+type poolJob struct {
+	run    func() error
+	expire time.Time
+	err    error
+	done   chan struct{}
+}
+
+// NewWorkerPool creates a pool of workers with a queue of size, the jobs are run at once by the nil pool returned if workers is not positive
+func NewWorkerPool(workers, size int, timeout, retryAfter time.Duration) *WorkerPool {
+	if workers <= 0 {
+		return nil
+	}
+	if size < 0 {
+		size = 0
+	}
+	p := &WorkerPool{
+		jobs:       make(chan *poolJob, size),
+		timeout:    timeout,
+		retryAfter: retryAfter,
+	}
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Do queues the job and waits until it's run or rejected
+func (p *WorkerPool) Do(run func() error) error {
+	if p == nil {
+		return run()
+	}
+	job := &poolJob{run: run, done: make(chan struct{})}
+	if p.timeout > 0 {
+		job.expire = time.Now().Add(p.timeout)
+	}
+	select {
+	case p.jobs <- job:
+	default:
+		return p.reject()
+	}
+	<-job.done
+	return job.err
+}
+
+// Go queues the job without waiting for it, false is returned if the queue is full
+func (p *WorkerPool) Go(run func()) bool {
+	if p == nil {
+		run()
+		return true
+	}
+	job := &poolJob{run: func() error { run(); return nil }, done: make(chan struct{})}
+	select {
+	case p.jobs <- job:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *WorkerPool) work() {
+	for job := range p.jobs {
+		if !job.expire.IsZero() && time.Now().After(job.expire) {
+			job.err = p.reject()
+		} else {
+			job.err = job.run()
+		}
+		close(job.done)
+	}
+}
+
+func (p *WorkerPool) reject() error {
+	return Error(ErrTooManyRequests, Field(FieldRetryAfter, int(p.retryAfter/time.Second)))
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	// the jobs are run at once without workers
+	var pool *WorkerPool
+	assert.Nil(t, NewWorkerPool(0, 10, time.Second, time.Second))
+	assert.Error(t, pool.Do(func() error { return errors.New("failed") }))
+
+	pool = NewWorkerPool(1, 1, time.Minute, 30*time.Second)
+	assert.NoError(t, pool.Do(func() error { return nil }))
+
+	// the worker is busy and the queue is full
+	started, release := make(chan struct{}), make(chan struct{})
+	go pool.Do(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+	queued := make(chan error)
+	go func() {
+		queued <- pool.Do(func() error { return nil })
+	}()
+	for len(pool.jobs) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	err := pool.Do(func() error { return nil })
+	assert.Equal(t, ErrTooManyRequests, err.(*codeError).Code())
+	sec, ok := RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, 30, sec)
+	close(release)
+	assert.NoError(t, <-queued)
+
+	// the job waiting longer than the timeout is rejected without running
+	pool = NewWorkerPool(1, 1, time.Millisecond, time.Second)
+	started, release = make(chan struct{}), make(chan struct{})
+	go pool.Do(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+	go func() {
+		queued <- pool.Do(func() error {
+			t.Error("the expired job is run")
+			return nil
+		})
+	}()
+	for len(pool.jobs) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	err = <-queued
+	assert.Error(t, err)
+	_, ok = RetryAfter(err)
+	assert.True(t, ok)
+
+	_, ok = RetryAfter(Error(ErrTooManyRequests))
+	assert.False(t, ok)
+}
+
+func TestWorkerPoolGo(t *testing.T) {
+	var pool *WorkerPool
+	done := false
+	assert.True(t, pool.Go(func() { done = true }))
+	assert.True(t, done)
+
+	// the job is dropped once the worker is busy and the queue is full
+	pool = NewWorkerPool(1, 1, 0, 0)
+	started, release := make(chan struct{}), make(chan struct{})
+	assert.True(t, pool.Go(func() {
+		close(started)
+		<-release
+	}))
+	<-started
+	run := make(chan struct{})
+	assert.True(t, pool.Go(func() { close(run) }))
+	assert.False(t, pool.Go(func() { t.Error("the job dropped is run") }))
+	close(release)
+	<-run
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	pool := NewWorkerPool(1, 0, 0, 30*time.Second)
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	go pool.Do(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+
+	router := gin.Default()
+	router.GET("/report", Wrapper(func(c *Context) (interface{}, error) {
+		return nil, pool.Do(func() error { return nil })
+	}))
+	req, _ := http.NewRequest(http.MethodGet, "/report", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+}
@@ -16,12 +16,241 @@ type CloudConfig struct {
 	Task        Task       `yaml:"task" json:"task"`
 	Lock        Lock       `yaml:"lock" json:"lock"`
 	CronJobs    []CronJob  `yaml:"cronJobs" json:"cronJobs" default:"[]"`
+	RateLimit   RateLimit  `yaml:"rateLimit" json:"rateLimit"`
 	Cache       struct {
 		ExpirationDuration time.Duration `yaml:"expirationDuration" json:"expirationDuration" default:"10m"`
 	} `yaml:"cache" json:"cache"`
 	Template struct {
 		Path string `yaml:"path" json:"path" default:"/etc/baetyl/templates"`
 	} `yaml:"template" json:"template"`
+	I18n struct {
+		Path string `yaml:"path" json:"path"`
+	} `yaml:"i18n" json:"i18n"`
+	Idempotency struct {
+		TTL time.Duration `yaml:"ttl" json:"ttl" default:"24h"`
+	} `yaml:"idempotency" json:"idempotency"`
+	NodeMetrics struct {
+		Interval  time.Duration `yaml:"interval" json:"interval" default:"1m"`
+		Retention time.Duration `yaml:"retention" json:"retention" default:"168h"`
+	} `yaml:"nodeMetrics" json:"nodeMetrics"`
+	NodeCert struct {
+		RenewBefore   time.Duration `yaml:"renewBefore" json:"renewBefore" default:"720h"`
+		CheckInterval time.Duration `yaml:"checkInterval" json:"checkInterval" default:"12h"`
+	} `yaml:"nodeCert" json:"nodeCert"`
+	AppHistory struct {
+		// Revisions the number of revisions kept for each application
+		Revisions int `yaml:"revisions" json:"revisions" default:"10"`
+	} `yaml:"appHistory" json:"appHistory"`
+	Rollout struct {
+		// CheckInterval the interval to check the progressing rollouts and start their next stages
+		CheckInterval time.Duration `yaml:"checkInterval" json:"checkInterval" default:"1m"`
+	} `yaml:"rollout" json:"rollout"`
+	Helm struct {
+		// MaxChartSize the max size in bytes of the packaged chart which is uploaded or downloaded
+		MaxChartSize int64 `yaml:"maxChartSize" json:"maxChartSize" default:"1048576"`
+		// FetchTimeout the timeout to download the chart from url
+		FetchTimeout time.Duration `yaml:"fetchTimeout" json:"fetchTimeout" default:"30s"`
+	} `yaml:"helm" json:"helm"`
+	ConfigObject struct {
+		// Threshold the kv items of configs larger than it in bytes are stored in object storage, which is disabled if 0
+		Threshold int64 `yaml:"threshold" json:"threshold" default:"262144"`
+		// Source the object source storing the items, the default object source of property is used if empty
+		Source string `yaml:"source" json:"source"`
+		// MaxUploadSize the max size in bytes of each file uploaded as the item of config
+		MaxUploadSize int64 `yaml:"maxUploadSize" json:"maxUploadSize" default:"10485760"`
+		// UploadTypes the extensions of files allowed to upload as the items of config, such as .zip, all allowed if empty
+		UploadTypes []string `yaml:"uploadTypes" json:"uploadTypes"`
+	} `yaml:"configObject" json:"configObject"`
+	Registry struct {
+		// VerifyTimeout the timeout of each request to the registry when its credential is verified
+		VerifyTimeout time.Duration `yaml:"verifyTimeout" json:"verifyTimeout" default:"10s"`
+	} `yaml:"registry" json:"registry"`
+	SecretRotation struct {
+		// CheckInterval the interval to rotate or flag the secrets expiring within NotifyBefore
+		CheckInterval time.Duration `yaml:"checkInterval" json:"checkInterval" default:"1h"`
+		NotifyBefore  time.Duration `yaml:"notifyBefore" json:"notifyBefore" default:"168h"`
+		// Rotators the rotator plugins which the secrets are allowed to use
+		Rotators []string `yaml:"rotators" json:"rotators" default:"[\"defaultrotator\"]"`
+	} `yaml:"secretRotation" json:"secretRotation"`
+	ExternalSecret struct {
+		// Providers the secret provider plugins resolving the external secrets, such as vaultkv and awssecretsmanager
+		Providers []string `yaml:"providers" json:"providers" default:"[]"`
+	} `yaml:"externalSecret" json:"externalSecret"`
+	FunctionBuild struct {
+		// Builder the builder plugin building the images of function code, such as kaniko and buildservice,
+		// the build of function is disabled if empty
+		Builder string `yaml:"builder" json:"builder"`
+		// Registry the repository which the images are pushed to, such as harbor.local/functions
+		Registry string `yaml:"registry" json:"registry"`
+		// Source the object source storing the code, the default object source of property is used if empty
+		Source string `yaml:"source" json:"source"`
+		// MaxCodeSize the max size in bytes of the code zip uploaded
+		MaxCodeSize int64 `yaml:"maxCodeSize" json:"maxCodeSize" default:"52428800"`
+	} `yaml:"functionBuild" json:"functionBuild"`
+	FunctionDraft struct {
+		// Sandbox the sandbox plugin running the drafts of function for test, the test invocation is disabled if empty
+		Sandbox string `yaml:"sandbox" json:"sandbox"`
+		// Source the object source storing the versions published, the default object source of property is used if empty
+		Source string `yaml:"source" json:"source"`
+		// MaxCodeSize the max size in bytes of all the files of draft
+		MaxCodeSize int64 `yaml:"maxCodeSize" json:"maxCodeSize" default:"1048576"`
+	} `yaml:"functionDraft" json:"functionDraft"`
+	DesirePatch struct {
+		// CacheSize the max size in bytes of the resources delivered which are cached to create patches against,
+		// the resources not cached are delivered in full
+		CacheSize int64 `yaml:"cacheSize" json:"cacheSize" default:"67108864"`
+	} `yaml:"desirePatch" json:"desirePatch"`
+	// Telemetry the telemetry sections of node reports forwarded to the sinks of plugin.telemetrySinks
+	Telemetry struct {
+		// MaxSize the max size in bytes of the telemetry of a report, the larger ones are dropped
+		MaxSize int `yaml:"maxSize" json:"maxSize" default:"65536"`
+	} `yaml:"telemetry" json:"telemetry"`
+	// DesireRender caches the apps rendered for nodes by their versions, so that an app is rendered once for the fleet
+	DesireRender struct {
+		// CacheSize the max number of the apps rendered cached, the apps are rendered on each sync if zero
+		CacheSize int `yaml:"cacheSize" json:"cacheSize" default:"1024"`
+		// TTL the time an app rendered is cached
+		TTL time.Duration `yaml:"ttl" json:"ttl" default:"5m"`
+	} `yaml:"desireRender" json:"desireRender"`
+	// DesireFanOut notifies the nodes watching of their desires updated in batches by a fixed number of workers, each
+	// holding the interval after a batch, so that a large fleet fetches the desires gradually. The nodes are notified
+	// at once if workers is zero
+	DesireFanOut struct {
+		Workers   int           `yaml:"workers" json:"workers" default:"4"`
+		BatchSize int           `yaml:"batchSize" json:"batchSize" default:"100"`
+		Interval  time.Duration `yaml:"interval" json:"interval" default:"1s"`
+		// Size the max number of batches waiting, the nodes of the batches beyond get the desires on the next report
+		Size int `yaml:"size" json:"size" default:"1024"`
+	} `yaml:"desireFanOut" json:"desireFanOut"`
+	// DesireThrottle caps the nodes delivered the changed apps per minute by each instance, the nodes beyond get the
+	// changes on their later reports, so that a change of a config used widely doesn't restart the apps of the fleet at once
+	DesireThrottle struct {
+		// Global the max number of nodes of all namespaces per minute, which is unlimited if zero
+		Global int `yaml:"global" json:"global"`
+		// Namespace the max number of nodes of each namespace per minute, which is unlimited if zero
+		Namespace int `yaml:"namespace" json:"namespace"`
+	} `yaml:"desireThrottle" json:"desireThrottle"`
+	// ReportQueue queues the reports of nodes handled by a fixed number of workers, the reports are handled at once if workers is zero
+	ReportQueue struct {
+		Workers int `yaml:"workers" json:"workers" default:"64"`
+		// Size the max number of reports waiting, the reports beyond are rejected
+		Size int `yaml:"size" json:"size" default:"1024"`
+		// Timeout the max time a report waits in queue, after which it's rejected without handling
+		Timeout time.Duration `yaml:"timeout" json:"timeout" default:"10s"`
+		// RetryAfter the time after which the node is asked to report again once rejected
+		RetryAfter time.Duration `yaml:"retryAfter" json:"retryAfter" default:"30s"`
+	} `yaml:"reportQueue" json:"reportQueue"`
+	// ReportTasks the tasks following the reports of nodes, such as the health checks, which are run in background
+	// by a fixed number of workers of each kind, the tasks are run at once if workers is zero
+	ReportTasks struct {
+		Workers int `yaml:"workers" json:"workers" default:"8"`
+		// Size the max number of tasks waiting of each kind, the tasks beyond are dropped
+		Size int `yaml:"size" json:"size" default:"4096"`
+	} `yaml:"reportTasks" json:"reportTasks"`
+	// SyncHistory records the sync exchanges of nodes stored by plugin.syncHistory, to diagnose the nodes not syncing
+	SyncHistory struct {
+		// Retention the time the records are kept
+		Retention time.Duration `yaml:"retention" json:"retention" default:"72h"`
+		// Limit the max number of the latest records of node returned with the stats of them
+		Limit int `yaml:"limit" json:"limit" default:"100"`
+	} `yaml:"syncHistory" json:"syncHistory"`
+	// SyncSign verifies the signatures of sync messages by the sync keys of nodes, which are generated with the
+	// certificates of nodes and rotated on renewal
+	SyncSign struct {
+		// Enforce rejects the messages without signature, otherwise only the signed ones are verified
+		// so that the nodes not upgraded keep synchronizing
+		Enforce bool `yaml:"enforce" json:"enforce"`
+		// Window the max skew between the timestamp of message and the time of cloud, within which the nonces are kept
+		Window time.Duration `yaml:"window" json:"window" default:"5m"`
+		// KeyTTL the time the sync keys of nodes are cached
+		KeyTTL time.Duration `yaml:"keyTTL" json:"keyTTL" default:"1m"`
+	} `yaml:"syncSign" json:"syncSign"`
+	// ObjectDistribution delivers the large objects of configs with the manifests of chunks, so that nodes download
+	// them by ranges at capped rates and resume after failure
+	ObjectDistribution struct {
+		// Threshold the objects larger than it in bytes are delivered with manifests, which is disabled if 0
+		Threshold int64 `yaml:"threshold" json:"threshold" default:"67108864"`
+		// ChunkSize the size in bytes of each chunk
+		ChunkSize int64 `yaml:"chunkSize" json:"chunkSize" default:"8388608"`
+		// RateLimit the max bytes per second each node downloads at, unlimited if 0. It's overridden by the label of node
+		RateLimit int64 `yaml:"rateLimit" json:"rateLimit"`
+		// CacheSize the max number of manifests cached, the manifests are computed again once evicted
+		CacheSize int `yaml:"cacheSize" json:"cacheSize" default:"1024"`
+	} `yaml:"objectDistribution" json:"objectDistribution"`
+	// ActivationToken the defaults of the tokens stored by plugin.activationToken, which authorize the init server
+	// to activate nodes. The install commands of nodes carry the tokens issued for them unless a token is designated
+	ActivationToken struct {
+		// Expiry the duration the tokens are valid for once issued
+		Expiry time.Duration `yaml:"expiry" json:"expiry" default:"1h"`
+		// MaxUses the times the tokens activate nodes
+		MaxUses int `yaml:"maxUses" json:"maxUses" default:"1"`
+	} `yaml:"activationToken" json:"activationToken"`
+	// NodeRegistration registers the devices presenting the bootstrap credentials of node groups as pending nodes,
+	// which are created once approved by admin or by the rules of namespace
+	NodeRegistration struct {
+		// Enable accepts the registrations of devices by init server
+		Enable bool `yaml:"enable" json:"enable"`
+		// CheckInterval the interval the pending registrations are checked against the rules
+		CheckInterval time.Duration `yaml:"checkInterval" json:"checkInterval" default:"30s"`
+	} `yaml:"nodeRegistration" json:"nodeRegistration"`
+	// Preflight checks the hosts reported by the install scripts before activation, the hosts failing the checks are
+	// warned of, or refused to activate if enforced. The requirements not set are not checked
+	Preflight struct {
+		// Enforce refuses to activate the nodes whose hosts failed the checks, the hosts not checked are activated,
+		// such as by the install scripts before preflight
+		Enforce bool `yaml:"enforce" json:"enforce"`
+		// MinKernelVersion the minimal version of linux kernel, such as 4.4
+		MinKernelVersion string `yaml:"minKernelVersion" json:"minKernelVersion"`
+		// Arches the architectures supported, such as amd64, arm64 and arm
+		Arches []string `yaml:"arches" json:"arches"`
+		// CgroupDrivers the cgroup drivers of container runtime supported, such as cgroupfs and systemd
+		CgroupDrivers []string `yaml:"cgroupDrivers" json:"cgroupDrivers"`
+		// MinDiskFree the minimal free disk space in megabytes of the data path of node
+		MinDiskFree int64 `yaml:"minDiskFree" json:"minDiskFree" default:"1024"`
+	} `yaml:"preflight" json:"preflight"`
+	// Impersonation the contexts issued to the operators of mis server, who reproduce the views of users by the tokens
+	// signed by plugin.sign
+	Impersonation struct {
+		// Expiry the default duration the impersonations are valid for
+		Expiry time.Duration `yaml:"expiry" json:"expiry" default:"15m"`
+		// MaxExpiry the max duration the operators request the impersonations for
+		MaxExpiry time.Duration `yaml:"maxExpiry" json:"maxExpiry" default:"1h"`
+	} `yaml:"impersonation" json:"impersonation"`
+	// PlatformStats the aggregate of all namespaces computed in background and cached for the ops dashboard of mis
+	// server, so that the dashboard never lists the resources of namespaces by itself
+	PlatformStats struct {
+		// Interval the interval the stats are computed, which is disabled if 0
+		Interval time.Duration `yaml:"interval" json:"interval" default:"10m"`
+		// TrafficWindow the time before computation the sync traffic is summed over
+		TrafficWindow time.Duration `yaml:"trafficWindow" json:"trafficWindow" default:"24h"`
+	} `yaml:"platformStats" json:"platformStats"`
+	// Garbage the scan of the resources left without owners on long-running installations, which are reported to
+	// mis server and only deleted once operators confirm the report
+	Garbage struct {
+		// Interval the interval the resources are scanned, which is disabled if 0
+		Interval time.Duration `yaml:"interval" json:"interval" default:"24h"`
+		// MinAge the age the resources reach before they are reported, so that the ones being created are kept, and
+		// the objects of the old versions of configs are kept for the nodes not synced yet
+		MinAge time.Duration `yaml:"minAge" json:"minAge" default:"168h"`
+	} `yaml:"garbage" json:"garbage"`
+	// ReadOnly the read-only mode of platform switched by mis server, such as during the maintenance of database
+	ReadOnly struct {
+		// CacheTTL the time the mode is cached for by each instance, since it's checked on each write of admin server
+		CacheTTL time.Duration `yaml:"cacheTTL" json:"cacheTTL" default:"10s"`
+		// Allowlist the routes of admin server still served while read-only, such as the reports of the nodes
+		// synced by bundle. The sync and init servers serve the nodes and are never read-only
+		Allowlist []string `yaml:"allowlist" json:"allowlist" default:"[\"/v1/nodes/:name/bundle/report\"]"`
+	} `yaml:"readOnly" json:"readOnly"`
+	// FeatureFlag the flags enabling the capabilities tenant by tenant
+	FeatureFlag struct {
+		// CacheTTL the time the flags are cached for, since they are resolved on each request of admin server
+		CacheTTL time.Duration `yaml:"cacheTTL" json:"cacheTTL" default:"30s"`
+	} `yaml:"featureFlag" json:"featureFlag"`
+	// Announcement the announcements of the platform shown to the consoles and delivered to nodes
+	Announcement struct {
+		// CacheTTL the time the announcements are cached for, since they are matched on each sync of nodes
+		CacheTTL time.Duration `yaml:"cacheTTL" json:"cacheTTL" default:"30s"`
+	} `yaml:"announcement" json:"announcement"`
 	Plugin struct {
 		Pubsub     string   `yaml:"pubsub" json:"pubsub" default:"defaultpubsub"`
 		PKI        string   `yaml:"pki" json:"pki" default:"defaultpki"`
@@ -47,6 +276,63 @@ type CloudConfig struct {
 		Cron       string   `yaml:"cron" json:"cron" default:"database"`
 		Csrf       string   `yaml:"csrf" json:"csrf" default:"defaultcsrf"`
 		JWT        string   `yaml:"jwt" json:"jwt" default:"defaultjwt"`
+		Auditors   []string `yaml:"auditors" json:"auditors" default:"[]"`
+		Idempotent string   `yaml:"idempotent" json:"idempotent" default:"database"`
+		NodeGroup  string   `yaml:"nodeGroup" json:"nodeGroup" default:"database"`
+		Exec       string   `yaml:"exec" json:"exec" default:"defaultexec"`
+		Health     string   `yaml:"health" json:"health" default:"database"`
+		Metrics    string   `yaml:"metrics" json:"metrics" default:"database"`
+		Heartbeat  string   `yaml:"heartbeat" json:"heartbeat" default:"database"`
+		NodeFilter string   `yaml:"nodeFilter" json:"nodeFilter" default:"database"`
+		// EdgeCluster stores the multi-node clusters at the edge
+		EdgeCluster string `yaml:"edgeCluster" json:"edgeCluster" default:"database"`
+		Rollout     string `yaml:"rollout" json:"rollout" default:"database"`
+		HelmRelease string `yaml:"helmRelease" json:"helmRelease" default:"database"`
+		EnvGroup    string `yaml:"envGroup" json:"envGroup" default:"database"`
+		// SidecarPolicy stores the policies injecting sidecars into applications
+		SidecarPolicy string `yaml:"sidecarPolicy" json:"sidecarPolicy" default:"database"`
+		// ImagePolicy stores the registry mirror and pull policy of images of namespaces
+		ImagePolicy string `yaml:"imagePolicy" json:"imagePolicy" default:"database"`
+		// SecretRotation stores the expiry and rotation policies of secrets
+		SecretRotation string `yaml:"secretRotation" json:"secretRotation" default:"database"`
+		// FunctionBuild stores the builds of the function versions uploaded as code
+		FunctionBuild string `yaml:"functionBuild" json:"functionBuild" default:"database"`
+		// FunctionRuntime stores the runtimes of functions registered by admins
+		FunctionRuntime string `yaml:"functionRuntime" json:"functionRuntime" default:"database"`
+		// FunctionDraft stores the drafts of function code edited online and the versions published
+		FunctionDraft string `yaml:"functionDraft" json:"functionDraft" default:"database"`
+		// SyncHistory stores the records of the sync exchanges of nodes
+		SyncHistory string `yaml:"syncHistory" json:"syncHistory" default:"database"`
+		// ActivationToken stores the tokens authorizing the activation of nodes
+		ActivationToken string `yaml:"activationToken" json:"activationToken" default:"database"`
+		// NodeRegistration stores the registrations of devices and the rules approving them
+		NodeRegistration string `yaml:"nodeRegistration" json:"nodeRegistration" default:"database"`
+		// InstallTemplate stores the templates of install scripts and manifests overridden in namespaces
+		InstallTemplate string `yaml:"installTemplate" json:"installTemplate" default:"database"`
+		// NodeEndpoints stores the endpoints of cloud overridden for the nodes of namespaces
+		NodeEndpoints string `yaml:"nodeEndpoints" json:"nodeEndpoints" default:"database"`
+		// ModuleArtifact stores the manifests of the images and programs of module versions built for the platforms
+		ModuleArtifact string `yaml:"moduleArtifact" json:"moduleArtifact" default:"database"`
+		// Tenant stores the lifecycle of the tenants of namespaces, such as the ones frozen
+		Tenant string `yaml:"tenant" json:"tenant" default:"database"`
+		// PlatformStats aggregates the sync records and the table sizes of storage for the ops dashboard
+		PlatformStats string `yaml:"platformStats" json:"platformStats" default:"database"`
+		// Announcement stores the announcements of the platform published by operators
+		Announcement string `yaml:"announcement" json:"announcement" default:"database"`
+		// Quarantine stores the resources quarantined platform-wide, such as the vulnerable images
+		Quarantine string `yaml:"quarantine" json:"quarantine" default:"database"`
+		// Garbage lists the records of storage which may be left without owners, such as the shadows
+		Garbage string `yaml:"garbage" json:"garbage" default:"database"`
+		// FeatureFlag stores the feature flags along with their overrides of namespaces
+		FeatureFlag string `yaml:"featureFlag" json:"featureFlag" default:"database"`
+		// DesireWatch notifies the nodes watching once their desires are changed, nodes are not notified if empty
+		DesireWatch string `yaml:"desireWatch" json:"desireWatch" default:"defaultdesirewatch"`
+		// Crypto encrypts the data of secrets before they are stored, the secrets are stored in plain if empty
+		Crypto string `yaml:"crypto" json:"crypto"`
+		// Geolocation locates the ips of the devices registering for the placement of nodes, which are not located if empty
+		Geolocation string `yaml:"geolocation" json:"geolocation"`
+		// TelemetrySinks ship the telemetry sections of node reports, which are dropped if empty
+		TelemetrySinks []string `yaml:"telemetrySinks" json:"telemetrySinks" default:"[]"`
 	} `yaml:"plugin" json:"plugin"`
 }
 
@@ -64,11 +350,15 @@ type MisServer struct {
 
 // Server server config
 type Server struct {
-	Port         string            `yaml:"port" json:"port"`
-	ReadTimeout  time.Duration     `yaml:"readTimeout" json:"readTimeout" default:"30s"`
-	WriteTimeout time.Duration     `yaml:"writeTimeout" json:"writeTimeout" default:"30s"`
-	ShutdownTime time.Duration     `yaml:"shutdownTime" json:"shutdownTime" default:"3s"`
-	Certificate  utils.Certificate `yaml:",inline" json:",inline"`
+	Port         string        `yaml:"port" json:"port"`
+	ReadTimeout  time.Duration `yaml:"readTimeout" json:"readTimeout" default:"30s"`
+	WriteTimeout time.Duration `yaml:"writeTimeout" json:"writeTimeout" default:"30s"`
+	ShutdownTime time.Duration `yaml:"shutdownTime" json:"shutdownTime" default:"3s"`
+	// HandlerTimeout the deadline of handling a request, 0 means no deadline
+	HandlerTimeout time.Duration `yaml:"handlerTimeout" json:"handlerTimeout"`
+	// RouteTimeouts overrides HandlerTimeout by route, the key is method and path, such as "GET /v1/nodes"
+	RouteTimeouts map[string]time.Duration `yaml:"routeTimeouts" json:"routeTimeouts"`
+	Certificate   utils.Certificate        `yaml:",inline" json:",inline"`
 }
 
 type Task struct {
@@ -79,6 +369,17 @@ type Task struct {
 	QueueLength     int32 `yaml:"queueLength" json:"queueLength" default:"100"`
 }
 
+// RateLimit limits the requests of admin server, a zero qps disables the rule
+type RateLimit struct {
+	Namespace RateLimitRule `yaml:"namespace" json:"namespace"`
+	IP        RateLimitRule `yaml:"ip" json:"ip"`
+}
+
+type RateLimitRule struct {
+	QPS   float64 `yaml:"qps" json:"qps"`
+	Burst int     `yaml:"burst" json:"burst"`
+}
+
 type Lock struct {
 	ExpireTime int64 `yaml:"expireTime" json:"expireTime" default:"5" unit:"second"`
 }
@@ -59,11 +59,97 @@ func TestDefaultValue(t *testing.T) {
 	expect.Plugin.Cron = "database"
 	expect.Plugin.Csrf = "defaultcsrf"
 	expect.Plugin.JWT = "defaultjwt"
+	expect.Plugin.Auditors = []string{}
+	expect.Plugin.Idempotent = "database"
+	expect.Plugin.NodeGroup = "database"
+	expect.Plugin.Exec = "defaultexec"
+	expect.Plugin.Health = "database"
+	expect.Plugin.Metrics = "database"
+	expect.Plugin.Heartbeat = "database"
+	expect.Plugin.NodeFilter = "database"
+	expect.Plugin.EdgeCluster = "database"
+	expect.Plugin.Rollout = "database"
+	expect.Plugin.HelmRelease = "database"
+	expect.Plugin.EnvGroup = "database"
+	expect.Plugin.SidecarPolicy = "database"
+	expect.Plugin.ImagePolicy = "database"
+	expect.Plugin.ModuleArtifact = "database"
+	expect.Plugin.Tenant = "database"
+	expect.Plugin.PlatformStats = "database"
+	expect.Plugin.Announcement = "database"
+	expect.Plugin.Quarantine = "database"
+	expect.Plugin.Garbage = "database"
+	expect.Plugin.FeatureFlag = "database"
+	expect.Plugin.SecretRotation = "database"
+	expect.Plugin.FunctionBuild = "database"
+	expect.Plugin.FunctionRuntime = "database"
+	expect.Plugin.FunctionDraft = "database"
+	expect.Plugin.SyncHistory = "database"
+	expect.Plugin.ActivationToken = "database"
+	expect.Plugin.NodeRegistration = "database"
+	expect.Plugin.InstallTemplate = "database"
+	expect.Plugin.NodeEndpoints = "database"
+	expect.Plugin.DesireWatch = "defaultdesirewatch"
+	expect.Plugin.TelemetrySinks = []string{}
 
 	expect.Template.Path = "/etc/baetyl/templates"
 
 	expect.Cache.ExpirationDuration = time.Minute * 10
 
+	expect.Idempotency.TTL = time.Hour * 24
+	expect.NodeMetrics.Interval = time.Minute
+	expect.NodeMetrics.Retention = time.Hour * 168
+	expect.NodeCert.RenewBefore = time.Hour * 720
+	expect.NodeCert.CheckInterval = time.Hour * 12
+	expect.AppHistory.Revisions = 10
+	expect.Rollout.CheckInterval = time.Minute
+	expect.Helm.MaxChartSize = 1048576
+	expect.Helm.FetchTimeout = time.Second * 30
+	expect.ConfigObject.Threshold = 262144
+	expect.ConfigObject.MaxUploadSize = 10485760
+	expect.Registry.VerifyTimeout = time.Second * 10
+	expect.SecretRotation.CheckInterval = time.Hour
+	expect.SecretRotation.NotifyBefore = time.Hour * 168
+	expect.SecretRotation.Rotators = []string{"defaultrotator"}
+	expect.ExternalSecret.Providers = []string{}
+	expect.FunctionBuild.MaxCodeSize = 52428800
+	expect.FunctionDraft.MaxCodeSize = 1048576
+	expect.DesirePatch.CacheSize = 67108864
+	expect.Telemetry.MaxSize = 65536
+	expect.DesireRender.CacheSize = 1024
+	expect.DesireRender.TTL = time.Minute * 5
+	expect.DesireFanOut.Workers = 4
+	expect.DesireFanOut.BatchSize = 100
+	expect.DesireFanOut.Interval = time.Second
+	expect.DesireFanOut.Size = 1024
+	expect.ReportQueue.Workers = 64
+	expect.ReportQueue.Size = 1024
+	expect.ReportQueue.Timeout = time.Second * 10
+	expect.ReportQueue.RetryAfter = time.Second * 30
+	expect.ReportTasks.Workers = 8
+	expect.ReportTasks.Size = 4096
+	expect.SyncHistory.Retention = time.Hour * 72
+	expect.SyncHistory.Limit = 100
+	expect.SyncSign.Window = time.Minute * 5
+	expect.SyncSign.KeyTTL = time.Minute
+	expect.ObjectDistribution.Threshold = 67108864
+	expect.ObjectDistribution.ChunkSize = 8388608
+	expect.ObjectDistribution.CacheSize = 1024
+	expect.ActivationToken.Expiry = time.Hour
+	expect.ActivationToken.MaxUses = 1
+	expect.NodeRegistration.CheckInterval = time.Second * 30
+	expect.Preflight.MinDiskFree = 1024
+	expect.Impersonation.Expiry = time.Minute * 15
+	expect.Impersonation.MaxExpiry = time.Hour
+	expect.PlatformStats.Interval = time.Minute * 10
+	expect.PlatformStats.TrafficWindow = time.Hour * 24
+	expect.Announcement.CacheTTL = time.Second * 30
+	expect.Garbage.Interval = time.Hour * 24
+	expect.Garbage.MinAge = time.Hour * 168
+	expect.ReadOnly.CacheTTL = time.Second * 10
+	expect.ReadOnly.Allowlist = []string{"/v1/nodes/:name/bundle/report"}
+	expect.FeatureFlag.CacheTTL = time.Second * 30
+
 	expect.CronJobs = []CronJob{}
 	expect.Task.ScheduleTime = 30
 	expect.Task.ConcurrentNum = 10
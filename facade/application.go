@@ -14,6 +14,7 @@ import (
 const (
 	FunctionConfigPrefix        = "baetyl-function-config"
 	FunctionProgramConfigPrefix = "baetyl-function-program-config"
+	CronJobConfigPrefix         = "baetyl-cronjob-config"
 )
 
 func (a *facade) GetApp(ns, name, version string) (*specV1.Application, error) {
@@ -214,7 +215,8 @@ func (a *facade) cleanGenConfigsOfFunctionApp(tx interface{}, configs []specV1.C
 			continue
 		}
 		if _, ok := m[v.VolumeSource.Config.Name]; !ok && (strings.HasPrefix(v.VolumeSource.Config.Name, FunctionConfigPrefix) ||
-			strings.HasPrefix(v.VolumeSource.Config.Name, FunctionProgramConfigPrefix)) {
+			strings.HasPrefix(v.VolumeSource.Config.Name, FunctionProgramConfigPrefix) ||
+			strings.HasPrefix(v.VolumeSource.Config.Name, CronJobConfigPrefix)) {
 			err := a.config.Delete(tx, oldApp.Namespace, v.VolumeSource.Config.Name)
 			if err != nil {
 				common.LogDirtyData(err,
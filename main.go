@@ -6,25 +6,46 @@ import (
 	"github.com/baetyl/baetyl-go/v2/context"
 	"github.com/baetyl/baetyl-go/v2/log"
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
 
 	"github.com/baetyl/baetyl-cloud/v2/api"
 	"github.com/baetyl/baetyl-cloud/v2/common"
 	"github.com/baetyl/baetyl-cloud/v2/config"
 	"github.com/baetyl/baetyl-cloud/v2/plugin"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/aliyunfc"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/audit/file"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/audit/kafka"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/awslambda"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/awss3"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/awssecretsmanager"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/baiducfc"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/buildservice"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/crypto"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/database"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/decryption"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/auth"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/csrf"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/desirewatch"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/exec"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/geolocation"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/license"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/lock"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/pki"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/rotator"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/sign"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/task"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/default/transaction"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/kaniko"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/kube"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/link/httplink"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/link/mqttlink"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/mongodb"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/sandbox"
 	_ "github.com/baetyl/baetyl-cloud/v2/plugin/sign"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/telemetry/kafka"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/telemetry/remotewrite"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/telemetry/webhook"
+	_ "github.com/baetyl/baetyl-cloud/v2/plugin/vault"
 	"github.com/baetyl/baetyl-cloud/v2/server"
 )
 
@@ -42,12 +63,35 @@ func main() {
 		ctx.Log().Debug("cloud config", log.Any("cfg", cfg))
 
 		common.SetConfFile(ctx.ConfFile())
+		if cfg.I18n.Path != "" {
+			if err = common.LoadMessageCatalogs(cfg.I18n.Path); err != nil {
+				return err
+			}
+		}
 
 		a, err := api.NewAPI(&cfg)
 		if err != nil {
 			return err
 		}
-		sa, err := api.NewSyncAPI(&cfg)
+		renewDone := make(chan struct{})
+		go a.RunNodeCertRenewal(cfg.NodeCert.CheckInterval, cfg.NodeCert.RenewBefore, renewDone)
+		defer close(renewDone)
+		rotationDone := make(chan struct{})
+		go a.RunSecretRotation(cfg.SecretRotation.CheckInterval, cfg.SecretRotation.NotifyBefore, rotationDone)
+		defer close(rotationDone)
+		rolloutDone := make(chan struct{})
+		go a.RunRollout(cfg.Rollout.CheckInterval, rolloutDone)
+		defer close(rolloutDone)
+		registrationDone := make(chan struct{})
+		go a.RunNodeRegistration(cfg.NodeRegistration.CheckInterval, registrationDone)
+		defer close(registrationDone)
+		statsDone := make(chan struct{})
+		go a.RunPlatformStats(cfg.PlatformStats.Interval, cfg.PlatformStats.TrafficWindow, statsDone)
+		defer close(statsDone)
+		garbageDone := make(chan struct{})
+		go a.RunGarbageScan(cfg.Garbage.Interval, garbageDone)
+		defer close(garbageDone)
+		sa, err := api.NewSyncAPI(&cfg, a.Health)
 		if err != nil {
 			return err
 		}
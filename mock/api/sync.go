@@ -48,6 +48,52 @@ func (mr *MockSyncAPIMockRecorder) Desire(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Desire", reflect.TypeOf((*MockSyncAPI)(nil).Desire), arg0)
 }
 
+// DesirePatch mocks base method
+func (m *MockSyncAPI) DesirePatch(arg0 v1.Message) (*v1.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DesirePatch", arg0)
+	ret0, _ := ret[0].(*v1.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DesirePatch indicates an expected call of DesirePatch
+func (mr *MockSyncAPIMockRecorder) DesirePatch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DesirePatch", reflect.TypeOf((*MockSyncAPI)(nil).DesirePatch), arg0)
+}
+
+// DesireWatch mocks base method
+func (m *MockSyncAPI) DesireWatch(arg0 v1.Message) (<-chan *v1.Message, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DesireWatch", arg0)
+	ret0, _ := ret[0].(<-chan *v1.Message)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DesireWatch indicates an expected call of DesireWatch
+func (mr *MockSyncAPIMockRecorder) DesireWatch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DesireWatch", reflect.TypeOf((*MockSyncAPI)(nil).DesireWatch), arg0)
+}
+
+// Exec mocks base method
+func (m *MockSyncAPI) Exec(arg0 v1.Message) (*v1.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", arg0)
+	ret0, _ := ret[0].(*v1.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec
+func (mr *MockSyncAPIMockRecorder) Exec(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockSyncAPI)(nil).Exec), arg0)
+}
+
 // Report mocks base method
 func (m *MockSyncAPI) Report(arg0 v1.Message) (*v1.Message, error) {
 	m.ctrl.T.Helper()
@@ -33,6 +33,35 @@ func (m *MockFunctionService) EXPECT() *MockFunctionServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateFunctionRuntime mocks base method
+func (m *MockFunctionService) CreateFunctionRuntime(arg0 *models.FunctionRuntime) (*models.FunctionRuntime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFunctionRuntime", arg0)
+	ret0, _ := ret[0].(*models.FunctionRuntime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFunctionRuntime indicates an expected call of CreateFunctionRuntime
+func (mr *MockFunctionServiceMockRecorder) CreateFunctionRuntime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFunctionRuntime", reflect.TypeOf((*MockFunctionService)(nil).CreateFunctionRuntime), arg0)
+}
+
+// DeleteFunctionRuntime mocks base method
+func (m *MockFunctionService) DeleteFunctionRuntime(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFunctionRuntime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFunctionRuntime indicates an expected call of DeleteFunctionRuntime
+func (mr *MockFunctionServiceMockRecorder) DeleteFunctionRuntime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionRuntime", reflect.TypeOf((*MockFunctionService)(nil).DeleteFunctionRuntime), arg0)
+}
+
 // GetFunction mocks base method
 func (m *MockFunctionService) GetFunction(arg0, arg1, arg2, arg3 string) (*models.Function, error) {
 	m.ctrl.T.Helper()
@@ -48,6 +77,21 @@ func (mr *MockFunctionServiceMockRecorder) GetFunction(arg0, arg1, arg2, arg3 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunction", reflect.TypeOf((*MockFunctionService)(nil).GetFunction), arg0, arg1, arg2, arg3)
 }
 
+// GetFunctionRuntime mocks base method
+func (m *MockFunctionService) GetFunctionRuntime(arg0 string) (*models.FunctionRuntime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionRuntime", arg0)
+	ret0, _ := ret[0].(*models.FunctionRuntime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionRuntime indicates an expected call of GetFunctionRuntime
+func (mr *MockFunctionServiceMockRecorder) GetFunctionRuntime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionRuntime", reflect.TypeOf((*MockFunctionService)(nil).GetFunctionRuntime), arg0)
+}
+
 // List mocks base method
 func (m *MockFunctionService) List(arg0, arg1 string) ([]models.Function, error) {
 	m.ctrl.T.Helper()
@@ -63,6 +107,21 @@ func (mr *MockFunctionServiceMockRecorder) List(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFunctionService)(nil).List), arg0, arg1)
 }
 
+// ListFunctionRuntimes mocks base method
+func (m *MockFunctionService) ListFunctionRuntimes() ([]models.FunctionRuntime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionRuntimes")
+	ret0, _ := ret[0].([]models.FunctionRuntime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctionRuntimes indicates an expected call of ListFunctionRuntimes
+func (mr *MockFunctionServiceMockRecorder) ListFunctionRuntimes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionRuntimes", reflect.TypeOf((*MockFunctionService)(nil).ListFunctionRuntimes))
+}
+
 // ListFunctionVersions mocks base method
 func (m *MockFunctionService) ListFunctionVersions(arg0, arg1, arg2 string) ([]models.Function, error) {
 	m.ctrl.T.Helper()
@@ -106,3 +165,18 @@ func (mr *MockFunctionServiceMockRecorder) ListSources() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSources", reflect.TypeOf((*MockFunctionService)(nil).ListSources))
 }
+
+// UpdateFunctionRuntime mocks base method
+func (m *MockFunctionService) UpdateFunctionRuntime(arg0 *models.FunctionRuntime) (*models.FunctionRuntime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunctionRuntime", arg0)
+	ret0, _ := ret[0].(*models.FunctionRuntime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunctionRuntime indicates an expected call of UpdateFunctionRuntime
+func (mr *MockFunctionServiceMockRecorder) UpdateFunctionRuntime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionRuntime", reflect.TypeOf((*MockFunctionService)(nil).UpdateFunctionRuntime), arg0)
+}
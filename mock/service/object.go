@@ -76,6 +76,20 @@ func (mr *MockObjectServiceMockRecorder) DeleteExternalObject(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExternalObject", reflect.TypeOf((*MockObjectService)(nil).DeleteExternalObject), arg0, arg1, arg2, arg3)
 }
 
+// DeleteInternalObject mocks base method
+func (m *MockObjectService) DeleteInternalObject(arg0, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInternalObject", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInternalObject indicates an expected call of DeleteInternalObject
+func (mr *MockObjectServiceMockRecorder) DeleteInternalObject(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInternalObject", reflect.TypeOf((*MockObjectService)(nil).DeleteInternalObject), arg0, arg1, arg2, arg3)
+}
+
 // GenExternalObjectURL mocks base method
 func (m *MockObjectService) GenExternalObjectURL(arg0 models.ExternalObjectInfo, arg1, arg2, arg3 string) (*models.ObjectURL, error) {
 	m.ctrl.T.Helper()
@@ -136,6 +150,21 @@ func (mr *MockObjectServiceMockRecorder) GetExternalObject(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalObject", reflect.TypeOf((*MockObjectService)(nil).GetExternalObject), arg0, arg1, arg2, arg3)
 }
 
+// GetInternalObject mocks base method
+func (m *MockObjectService) GetInternalObject(arg0, arg1, arg2, arg3 string) (*models.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInternalObject", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInternalObject indicates an expected call of GetInternalObject
+func (mr *MockObjectServiceMockRecorder) GetInternalObject(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInternalObject", reflect.TypeOf((*MockObjectService)(nil).GetInternalObject), arg0, arg1, arg2, arg3)
+}
+
 // HeadExternalObject mocks base method
 func (m *MockObjectService) HeadExternalObject(arg0 models.ExternalObjectInfo, arg1, arg2, arg3 string) (*models.ObjectMeta, error) {
 	m.ctrl.T.Helper()
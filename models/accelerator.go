@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// AcceleratorDevice the GPU or NPU device reported by node in accelerators
+type AcceleratorDevice struct {
+	// Resource the extended resource name of device requested by services, such as nvidia.com/gpu
+	Resource string `json:"resource"`
+	Index    int    `json:"index"`
+	UUID     string `json:"uuid,omitempty"`
+	Model    string `json:"model,omitempty"`
+	// Memory the memory of device in bytes
+	Memory int64 `json:"memory,omitempty"`
+	// Node the node of cluster where the device is installed
+	Node string `json:"node,omitempty"`
+}
+
+// NodeAccelerators the accelerator inventory of node, Resources counts the devices of each extended resource
+type NodeAccelerators struct {
+	Namespace   string              `json:"namespace"`
+	Name        string              `json:"name"`
+	Accelerator string              `json:"accelerator,omitempty"`
+	Resources   map[string]int64    `json:"resources"`
+	Devices     []AcceleratorDevice `json:"devices,omitempty"`
+	ReportTime  time.Time           `json:"reportTime,omitempty"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// ActivationToken authorizes the init server to activate nodes, the install commands carrying it are rejected once
+// it is expired, revoked or used up. The token is bound to the node it is issued for, or to the nodes of a group
+type ActivationToken struct {
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty" validate:"omitempty,max=1024"`
+	Node        string `json:"node,omitempty" validate:"omitempty,resourceName"`
+	NodeGroup   string `json:"nodeGroup,omitempty" validate:"omitempty,resourceName"`
+	// MaxUses the times the token activates nodes, the node certificates fetched count as uses
+	MaxUses int `json:"maxUses" validate:"min=0"`
+	Uses    int `json:"uses"`
+	// Expiry the duration the token is valid for once issued, the default one is taken if empty
+	Expiry     string    `json:"expiry,omitempty" validate:"omitempty,duration"`
+	ExpireTime time.Time `json:"expireTime,omitempty"`
+	// Placement labels and groups the nodes registered by the token, which is bound to a node group
+	Placement *NodePlacement `json:"placement,omitempty"`
+	// Endpoints overrides the endpoints of the node the token is issued for, or of the nodes registered by the token
+	Endpoints  *NodeEndpoints `json:"endpoints,omitempty"`
+	CreateTime time.Time      `json:"createTime,omitempty"`
+}
+
+// NodeReactivation the install command recovering the identity of node, which carries the one-time token issued
+// for the node
+type NodeReactivation struct {
+	ActivationToken *ActivationToken `json:"activationToken"`
+	InitCMD
+}
+
+func (t *ActivationToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpireTime)
+}
+
+func (t *ActivationToken) IsUsedUp() bool {
+	return t.Uses >= t.MaxUses
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+)
+
+// The levels of announcements, info if empty
+const (
+	AnnouncementInfo     = "info"
+	AnnouncementWarning  = "warning"
+	AnnouncementCritical = "critical"
+)
+
+// Announcement the notice of the platform published by operators, such as the planned maintenance of cloud. It's
+// shown to the users of the namespaces targeted between StartTime and EndTime, and delivered to their nodes if Edge
+type Announcement struct {
+	Name    string `json:"name,omitempty" validate:"resourceName"`
+	Title   string `json:"title" validate:"required,max=128"`
+	Content string `json:"content" validate:"required,max=4096"`
+	Level   string `json:"level,omitempty" validate:"omitempty,oneof=info warning critical"`
+	// StartTime the time the announcement is shown from, which is the time it's created if zero
+	StartTime time.Time `json:"startTime,omitempty"`
+	EndTime   time.Time `json:"endTime" validate:"required"`
+	// Namespaces the namespaces the announcement targets, all namespaces are targeted if empty
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Edge delivers the announcement to the nodes of the namespaces targeted as well
+	Edge bool `json:"edge,omitempty"`
+	// NodeSelector the label selector of the nodes the announcement is delivered to, all nodes if empty
+	NodeSelector string    `json:"nodeSelector,omitempty"`
+	CreateTime   time.Time `json:"createTime,omitempty"`
+	UpdateTime   time.Time `json:"updateTime,omitempty"`
+}
+
+// EdgeAnnouncement the announcement delivered to node in the delta of sync
+type EdgeAnnouncement struct {
+	Name      string    `json:"name"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Level     string    `json:"level"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// IsActive returns true if the announcement is shown at the time
+func (a *Announcement) IsActive(now time.Time) bool {
+	return !now.Before(a.StartTime) && now.Before(a.EndTime)
+}
+
+// Targets returns true if the announcement targets the namespace
+func (a *Announcement) Targets(namespace string) bool {
+	if len(a.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range a.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// ToEdge returns the announcement delivered to nodes
+func (a *Announcement) ToEdge() EdgeAnnouncement {
+	level := a.Level
+	if level == "" {
+		level = AnnouncementInfo
+	}
+	return EdgeAnnouncement{
+		Name:      a.Name,
+		Title:     a.Title,
+		Content:   a.Content,
+		Level:     level,
+		StartTime: a.StartTime.UTC(),
+		EndTime:   a.EndTime.UTC(),
+	}
+}
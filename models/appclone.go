@@ -0,0 +1,24 @@
+package models
+
+// AppCloneRequest the target to clone the application to
+type AppCloneRequest struct {
+	Namespace string `json:"namespace" binding:"required"`
+	// Name the name of the cloned application, the one of origin is kept if empty
+	Name string `json:"name,omitempty" validate:"omitempty,resourceName,nonBaetyl"`
+	// Selector the node selector of the cloned application, which replaces the node group or edge cluster of origin
+	Selector string `json:"selector,omitempty"`
+}
+
+// AppClone the application cloned and the resources it references in the target namespace
+type AppClone struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Configs the configs copied to the target namespace
+	Configs []string `json:"configs"`
+	// Secrets the secrets copied to the target namespace, including the registries and certificates
+	Secrets []string `json:"secrets"`
+	// Existing the configs and secrets which are not copied since the target namespace has them already,
+	// in the form of config/<name> or secret/<name>
+	Existing    []string         `json:"existing"`
+	Application *ApplicationView `json:"application,omitempty"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// The resolutions of app conflicts
+const (
+	// AppConflictAdopt the version node reports is taken as the desired one, the changes on node are kept
+	AppConflictAdopt = "adopt"
+	// AppConflictEnforce the desired version is delivered to node, the changes on node are overwritten
+	AppConflictEnforce = "enforce"
+)
+
+// AppConflict the app changed on node out of band, which node reports in a version cloud never delivers. The desired
+// changes of the app are withheld from node until the conflict is resolved
+type AppConflict struct {
+	App        string    `json:"app"`
+	Desired    string    `json:"desired"`
+	Reported   string    `json:"reported"`
+	Resolution string    `json:"resolution,omitempty"`
+	DetectTime time.Time `json:"detectTime"`
+}
+
+// AppConflictResolution the resolution chosen for the conflict of app
+type AppConflictResolution struct {
+	Resolution string `json:"resolution" validate:"required,oneof=adopt enforce"`
+}
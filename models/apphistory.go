@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+)
+
+// AppRevision the historical spec of application, which is saved each time the application is created or updated
+type AppRevision struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Revision  int64  `json:"revision"`
+	// Version the version of application when the revision is saved
+	Version     string              `json:"version,omitempty"`
+	Application *specV1.Application `json:"application,omitempty"`
+	CreateTime  time.Time           `json:"createTime,omitempty"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// AppInstance the instance of application running on node, with the restarts of its containers reported by node
+type AppInstance struct {
+	Node        string `json:"node"`
+	Name        string `json:"name"`
+	ServiceName string `json:"serviceName,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Cause       string `json:"cause,omitempty"`
+	// RestartCount the total restarts of the containers of instance
+	RestartCount int32             `json:"restartCount"`
+	Containers   []ContainerStatus `json:"containers,omitempty"`
+}
+
+// ContainerStatus the status of container in the app instance
+type ContainerStatus struct {
+	Name         string `json:"name"`
+	State        string `json:"state,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	RestartCount int32  `json:"restartCount"`
+	// LastTermination the last time the container terminated, nil if it never does
+	LastTermination *ContainerTermination `json:"lastTermination,omitempty"`
+}
+
+// ContainerTermination the termination of container, such as OOMKilled with exit code 137
+type ContainerTermination struct {
+	Reason     string    `json:"reason,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	ExitCode   int32     `json:"exitCode"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+}
+
+// AppInstanceRestart the restart of app instance desired by cloud, which is kept in the desire of node
+// until the node reports it is done
+type AppInstanceRestart struct {
+	Node        string    `json:"node,omitempty"`
+	App         string    `json:"app"`
+	Instance    string    `json:"instance"`
+	RequestTime time.Time `json:"requestTime"`
+}
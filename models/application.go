@@ -16,6 +16,7 @@ type ApplicationView struct {
 	Version           string                `json:"version,omitempty"`
 	Selector          string                `json:"selector,omitempty"`
 	NodeSelector      string                `json:"nodeSelector,omitempty"`
+	NodeGroup         string                `json:"nodeGroup,omitempty"` // the node group which the app targets, the selector follows the group
 	InitServices      []ServiceView         `json:"initServices,omitempty" validate:"dive"`
 	Services          []ServiceView         `json:"services,omitempty" validate:"dive"`
 	Volumes           []VolumeView          `json:"volumes,omitempty" validate:"dive"`
@@ -30,6 +31,46 @@ type ApplicationView struct {
 	JobConfig         *specV1.AppJobConfig  `json:"jobConfig,omitempty"`
 	Ota               specV1.OtaInfo        `json:"ota,omitempty"`
 	AutoScaleCfg      *specV1.AutoScaleCfg  `json:"autoScaleCfg,omitempty"`
+	// EdgeCluster the edge cluster which the app is deployed to as a whole, the selector follows the node of cluster
+	EdgeCluster string `json:"edgeCluster,omitempty"`
+	// Rollout the strategy to release this version in stages, the version is released to all nodes at once if not set
+	Rollout *RolloutStrategy `json:"rollout,omitempty"`
+	// DependsOn the apps in the same namespace which are delivered to node and running before this app
+	DependsOn []string `json:"dependsOn,omitempty" validate:"omitempty,dive,resourceName"`
+	// EnvGroups the env groups in the same namespace whose variables are rendered into the services of this app
+	EnvGroups []string `json:"envGroups,omitempty" validate:"omitempty,dive,resourceName"`
+	// CronJob the schedule of the app in cronjob workload, the jobs are run as JobConfig describes
+	CronJob *CronJobConfig `json:"cronJob,omitempty"`
+	// Paused whether the reconciliation of the app is paused, which is set by the pause api only
+	Paused bool `json:"paused,omitempty"`
+	// Warnings the issues found when the app is created or updated which do not reject it, such as the overcommitted nodes
+	Warnings []string `json:"warnings,omitempty"`
+	// FunctionScale the replicas and concurrency of function app, the function app runs one replica if not set
+	FunctionScale *FunctionScale `json:"functionScale,omitempty"`
+}
+
+// FunctionScale scales the function app between MinReplicas and MaxReplicas, each replica handles TargetConcurrency
+// invocations at the same time. The app is scaled by the HPA in kube mode, and by the edge with the hint in native mode
+type FunctionScale struct {
+	MinReplicas       int `json:"minReplicas" yaml:"minReplicas"`
+	MaxReplicas       int `json:"maxReplicas" yaml:"maxReplicas"`
+	TargetConcurrency int `json:"targetConcurrency" yaml:"targetConcurrency"`
+}
+
+// CronJobConfig the schedule of the jobs run by app, following the ones of kubernetes CronJob
+type CronJobConfig struct {
+	// Schedule the schedule in cron format such as "*/10 * * * *", or the predefined ones such as @hourly
+	Schedule string `json:"schedule" yaml:"schedule"`
+	// ConcurrencyPolicy how to treat the concurrent runs of job: Allow, Forbid or Replace
+	ConcurrencyPolicy string `json:"concurrencyPolicy,omitempty" yaml:"concurrencyPolicy,omitempty"`
+	// StartingDeadlineSeconds the deadline in seconds for starting the job which misses its scheduled time
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty" yaml:"startingDeadlineSeconds,omitempty"`
+	// SuccessfulJobsHistoryLimit the number of successful finished jobs to retain, defaults to 3
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty" yaml:"successfulJobsHistoryLimit,omitempty"`
+	// FailedJobsHistoryLimit the number of failed finished jobs to retain, defaults to 1
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty" yaml:"failedJobsHistoryLimit,omitempty"`
+	// Suspend suspends the subsequent runs, the running jobs are not affected
+	Suspend bool `json:"suspend,omitempty" yaml:"suspend,omitempty"`
 }
 
 // VolumeView volume view
@@ -74,6 +115,8 @@ type ApplicationList struct {
 
 type ServiceFunction struct {
 	Functions []specV1.ServiceFunction `json:"functions,omitempty"`
+	// Scale the scale of app read by the function runtime, which limits the concurrent invocations
+	Scale *FunctionScale `json:"scale,omitempty"`
 }
 
 type ServiceView struct {
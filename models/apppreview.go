@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+// ResourceDiff the changes of one resource rendered from the application
+type ResourceDiff struct {
+	Kind    string              `json:"kind"`
+	Name    string              `json:"name"`
+	Op      string              `json:"op"`
+	Changes []common.JSONChange `json:"changes,omitempty"`
+}
+
+// AppPreviewNodes the nodes affected by the change of application
+type AppPreviewNodes struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Updated []string `json:"updated"`
+}
+
+// AppPreview the result to update the application with a new spec, which is not saved
+type AppPreview struct {
+	Namespace   string           `json:"namespace"`
+	Name        string           `json:"name"`
+	Changed     bool             `json:"changed"`
+	Resources   []ResourceDiff   `json:"resources"`
+	Nodes       AppPreviewNodes  `json:"nodes"`
+	Application *ApplicationView `json:"application,omitempty"`
+}
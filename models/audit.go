@@ -0,0 +1,74 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditRecord records who changed which resource and when,
+// each record carries the hash of the previous one, so that any modification breaks the chain
+type AuditRecord struct {
+	Id        uint64 `json:"id,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	User      string `json:"user,omitempty"`
+	// Impersonator the operator of mis server who impersonates the user
+	Impersonator string    `json:"impersonator,omitempty"`
+	Method       string    `json:"method,omitempty"`
+	Path         string    `json:"path,omitempty"`
+	Resource     string    `json:"resource,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	RequestId    string    `json:"requestId,omitempty"`
+	Status       int       `json:"status,omitempty"`
+	Code         string    `json:"code,omitempty"`
+	Diff         string    `json:"diff,omitempty"`
+	PrevHash     string    `json:"prevHash,omitempty"`
+	Hash         string    `json:"hash,omitempty"`
+	CreateTime   time.Time `json:"createTime,omitempty"`
+}
+
+// Seal links the record to the previous one and computes its hash
+func (r *AuditRecord) Seal(prevHash string) {
+	r.PrevHash = prevHash
+	r.Hash = r.ComputeHash()
+}
+
+// ComputeHash computes the hash of the record, including the hash of the previous one
+func (r *AuditRecord) ComputeHash() string {
+	fields := []string{
+		r.PrevHash,
+		r.Namespace,
+		r.User,
+		r.Method,
+		r.Path,
+		r.Resource,
+		r.Name,
+		r.RequestId,
+		strconv.Itoa(r.Status),
+		r.Code,
+		r.Diff,
+		r.CreateTime.UTC().Format(time.RFC3339Nano),
+	}
+	// the records saved before impersonation keep their hashes
+	if r.Impersonator != "" {
+		fields = append(fields, r.Impersonator)
+	}
+	s := strings.Join(fields, "\n")
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyAuditChain returns the index of the first broken record, or -1 if the chain is intact
+func VerifyAuditChain(records []AuditRecord) int {
+	for i := range records {
+		if records[i].Hash != records[i].ComputeHash() {
+			return i
+		}
+		if i > 0 && records[i].PrevHash != records[i-1].Hash {
+			return i
+		}
+	}
+	return -1
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/baetyl/baetyl-go/v2/errors"
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 	"github.com/jinzhu/copier"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
 )
 
 // AltNames contains the domain names and IP addresses that will be added
@@ -139,3 +141,42 @@ func fingerprint(data []byte) string {
 	}
 	return buf.String()
 }
+
+// NodeCertificate the certificate issued to node at activation which is used to connect to cloud,
+// the private key is never returned
+type NodeCertificate struct {
+	Namespace     string    `json:"namespace"`
+	Node          string    `json:"node"`
+	SecretName    string    `json:"secretName"`
+	SecretVersion string    `json:"secretVersion,omitempty"`
+	CertId        string    `json:"certId,omitempty"`
+	CommonName    string    `json:"commonName"`
+	SerialNumber  string    `json:"serialNumber"`
+	FingerPrint   string    `json:"fingerPrint"`
+	NotBefore     time.Time `json:"notBefore"`
+	NotAfter      time.Time `json:"notAfter"`
+}
+
+// FromSecretToNodeCertificate parses the client certificate stored in the secret of node
+func FromSecretToNodeCertificate(s *specV1.Secret, node string) (*NodeCertificate, error) {
+	block, _ := pem.Decode(s.Data["client.pem"])
+	if block == nil {
+		return nil, errors.Errorf("failed to find client certificate in secret %s", s.Name)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse client certificate, err: %s", err)
+	}
+	return &NodeCertificate{
+		Namespace:     s.Namespace,
+		Node:          node,
+		SecretName:    s.Name,
+		SecretVersion: s.Version,
+		CertId:        s.Annotations[common.AnnotationPkiCertID],
+		CommonName:    cert.Subject.CommonName,
+		SerialNumber:  cert.SerialNumber.String(),
+		FingerPrint:   fingerprint(block.Bytes),
+		NotBefore:     cert.NotBefore.UTC(),
+		NotAfter:      cert.NotAfter.UTC(),
+	}, nil
+}
@@ -0,0 +1,8 @@
+package models
+
+// ComposeImport the application converted from docker compose file
+type ComposeImport struct {
+	Application *ApplicationView `json:"application"`
+	// Warnings the fields of compose file which are not supported and ignored, or converted with changes
+	Warnings []string `json:"warnings,omitempty"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"reflect"
 	"time"
 
@@ -24,6 +25,29 @@ type ConfigurationView struct {
 	Description       string            `json:"description,omitempty"`
 	Version           string            `json:"version,omitempty"`
 	System            bool              `json:"system,omitempty"`
+	// Uploads the files uploaded as the items of config by multipart form, returned with their checksums
+	Uploads []ConfigUpload `json:"uploads,omitempty"`
+	Schema  *ConfigSchema  `json:"schema,omitempty"`
+}
+
+// ConfigSchema the json schema which the kv items of config are validated against when the config is saved,
+// the schema is either inline or the kv item Key of Config, which is the config itself if Config is empty
+type ConfigSchema struct {
+	Config string          `json:"config,omitempty"`
+	Key    string          `json:"key,omitempty"`
+	Inline json.RawMessage `json:"inline,omitempty"`
+	// Keys the keys of items validated, the ones in json or yaml (.json, .yaml and .yml) are validated if empty
+	Keys []string `json:"keys,omitempty"`
+}
+
+// ConfigUpload the file uploaded as the item of config, keyed by its filename
+type ConfigUpload struct {
+	Key         string `json:"key"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size"`
+	MD5         string `json:"md5"`
+	SHA256      string `json:"sha256"`
 }
 
 type ConfigDataItem struct {
@@ -37,6 +61,10 @@ type ConfigFunctionItem struct {
 	Version          string `json:"version,omitempty"`
 	Runtime          string `json:"runtime,omitempty"`
 	Handler          string `json:"handler,omitempty"`
+	// Env the environment variables of function encoded as a json object, since the items of config are strings
+	Env string `json:"env,omitempty"`
+	// MemorySize the memory of function in MB
+	MemorySize string `json:"memorySize,omitempty"`
 }
 
 type ConfigObjectItem struct {
@@ -52,6 +80,35 @@ type ConfigObjectItem struct {
 	AddressFormat string `json:"addressFormat,omitempty" default:"pathStyle"`
 }
 
+const (
+	ConfigImportSkip      = "skip"
+	ConfigImportOverwrite = "overwrite"
+	ConfigImportRename    = "rename"
+)
+
+// ConfigImport the result of configs imported from the archive exported by another namespace
+type ConfigImport struct {
+	// Strategy how the configs which the namespace has already are handled, one of skip, overwrite and rename
+	Strategy string   `json:"strategy"`
+	Created  []string `json:"created"`
+	Updated  []string `json:"updated"`
+	// Skipped the configs not imported since the namespace has them already, or they are unchanged if overwritten
+	Skipped []string `json:"skipped"`
+	// Renamed the configs created with new names, keyed by the names in archive
+	Renamed map[string]string `json:"renamed"`
+}
+
+// ConfigTemplateVars the variables of the node which the template items of config are rendered with,
+// such as {{.Name}}, {{.Labels.region}} and {{.Properties.deviceId}}
+type ConfigTemplateVars struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	// Properties the properties of node, the desired values take precedence over the reported ones
+	Properties map[string]interface{} `json:"properties"`
+}
+
 func EqualConfig(config1, config2 *specV1.Configuration) bool {
 	return reflect.DeepEqual(config1.Labels, config2.Labels) &&
 		reflect.DeepEqual(config1.Data, config2.Data) &&
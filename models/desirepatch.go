@@ -0,0 +1,38 @@
+package models
+
+import (
+	"encoding/json"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+)
+
+// MessageDesirePatch the kind of sync message which requests the desired resources as the patches
+// against the ones node has, instead of the full values
+const MessageDesirePatch specV1.MessageKind = "desirePatch"
+
+// DesirePatchRequest the resources requested by node
+type DesirePatchRequest struct {
+	Infos []DesirePatchInfo `json:"infos"`
+}
+
+// DesirePatchInfo the resource requested with the hash of the one node has, the sha256 in hex of its canonical json
+// with the keys sorted and no spaces. The full value is returned if Hash is empty or unknown to cloud
+type DesirePatchInfo struct {
+	specV1.ResourceInfo
+	Hash string `json:"hash,omitempty"`
+}
+
+// DesirePatchResponse the resources desired
+type DesirePatchResponse struct {
+	Values []DesirePatchValue `json:"values"`
+}
+
+// DesirePatchValue the resource desired whose canonical json has Hash. It is either Value in full or Patch,
+// the json merge patch (RFC 7386) against the one of Base node has, and neither if the one node has is unchanged
+type DesirePatchValue struct {
+	specV1.ResourceInfo
+	Hash  string          `json:"hash"`
+	Base  string          `json:"base,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+	Patch json.RawMessage `json:"patch,omitempty"`
+}
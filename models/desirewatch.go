@@ -0,0 +1,12 @@
+package models
+
+import (
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+)
+
+// MessageDesireWatch the kind of sync message with which node watches the changes of its desire
+const MessageDesireWatch specV1.MessageKind = "desireWatch"
+
+// MessageDesireChanged the kind of sync message pushed to the node watching once its desire is changed,
+// the node is expected to report at once to receive the delta
+const MessageDesireChanged specV1.MessageKind = "desireChanged"
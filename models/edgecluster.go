@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+// EdgeCluster a multi-node kubernetes cluster at the edge, such as k3s. The cluster is activated
+// by installing the core on the node created for it in cluster mode, which manages all the members
+type EdgeCluster struct {
+	Namespace   string            `json:"namespace,omitempty"`
+	Name        string            `json:"name,omitempty" validate:"omitempty,resourceName,nonBaetyl"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	// Node the node of core, which is named after the cluster
+	Node       string    `json:"node,omitempty"`
+	CreateTime time.Time `json:"createTime,omitempty"`
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
+
+// EdgeClusterList edge cluster list
+type EdgeClusterList struct {
+	Total        int `json:"total"`
+	*ListOptions `json:",inline"`
+	Items        []EdgeCluster `json:"items"`
+}
+
+// EdgeClusterView the edge cluster with the status aggregated from the report of its node
+type EdgeClusterView struct {
+	EdgeCluster `json:",inline"`
+	Status      EdgeClusterStatus `json:"status"`
+}
+
+// EdgeClusterStatus the aggregated status of edge cluster, Online is whether the core reports in time,
+// Ready counts the members reporting their stats
+type EdgeClusterStatus struct {
+	Online     bool                `json:"online"`
+	Total      int                 `json:"total"`
+	Ready      int                 `json:"ready"`
+	Capacity   map[string]string   `json:"capacity,omitempty"`
+	Usage      map[string]string   `json:"usage,omitempty"`
+	Members    []EdgeClusterMember `json:"members,omitempty"`
+	ReportTime time.Time           `json:"reportTime,omitempty"`
+}
+
+// EdgeClusterMember the member node of edge cluster reported by the core
+type EdgeClusterMember struct {
+	Name     string            `json:"name"`
+	Role     string            `json:"role,omitempty"`
+	Address  string            `json:"address,omitempty"`
+	OS       string            `json:"os,omitempty"`
+	Arch     string            `json:"arch,omitempty"`
+	Ready    bool              `json:"ready"`
+	Capacity map[string]string `json:"capacity,omitempty"`
+	Usage    map[string]string `json:"usage,omitempty"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+)
+
+// EnvGroup the environment variables shared by the applications which reference it,
+// they are rendered into the services of applications when delivered to nodes
+type EnvGroup struct {
+	Namespace   string               `json:"namespace,omitempty"`
+	Name        string               `json:"name,omitempty" validate:"resourceName"`
+	Description string               `json:"description,omitempty"`
+	Envs        []specV1.Environment `json:"envs,omitempty"`
+	CreateTime  time.Time            `json:"createTime,omitempty"`
+	UpdateTime  time.Time            `json:"updateTime,omitempty"`
+}
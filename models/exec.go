@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+)
+
+// MessageExec the kind of sync message which carries the frames of exec sessions
+const MessageExec specV1.MessageKind = "exec"
+
+// the types of exec frame, open/stdin/resize/close are sent to the node, stdout/stderr/exit are sent back
+const (
+	ExecFrameOpen   = "open"
+	ExecFrameStdin  = "stdin"
+	ExecFrameResize = "resize"
+	ExecFrameClose  = "close"
+	ExecFrameStdout = "stdout"
+	ExecFrameStderr = "stderr"
+	ExecFrameExit   = "exit"
+)
+
+// ExecRequest the command to run in the container of app on node, an interactive shell is started if no command is given
+type ExecRequest struct {
+	App       string   `json:"app,omitempty"`
+	Container string   `json:"container,omitempty"`
+	Command   []string `json:"command,omitempty"`
+	TTY       bool     `json:"tty,omitempty"`
+	// Log the logs of container are sent back in stdout frames instead of running the command if it is set
+	Log *LogRequest `json:"log,omitempty"`
+	// Function the function of app is invoked instead of running the command if it is set
+	Function *FunctionCall `json:"function,omitempty"`
+}
+
+// FunctionCall the function to invoke with Payload on node, the node sends the result in stdout frames,
+// the logs of invocation in stderr frames and the error of function in the data of exit frame
+type FunctionCall struct {
+	Name    string `json:"name"`
+	Payload []byte `json:"payload,omitempty"`
+}
+
+// LogRequest the logs to read from the container of app instance, the node sends the exit frame after
+// the logs are sent unless they are followed
+type LogRequest struct {
+	Instance string `json:"instance"`
+	// Tail the number of lines from the end of logs, all lines are read if it is 0
+	Tail   int64 `json:"tail,omitempty"`
+	Follow bool  `json:"follow,omitempty"`
+}
+
+// ExecFrame a piece of exec session, Request is only set in the open frame
+type ExecFrame struct {
+	Session string       `json:"session"`
+	Type    string       `json:"type"`
+	Data    []byte       `json:"data,omitempty"`
+	Rows    uint16       `json:"rows,omitempty"`
+	Cols    uint16       `json:"cols,omitempty"`
+	Code    int          `json:"code,omitempty"`
+	Request *ExecRequest `json:"request,omitempty"`
+}
+
+// ExecSync the content of exec message, the node sends the output frames and receives the pending input frames
+type ExecSync struct {
+	Frames []ExecFrame `json:"frames"`
+}
+
+// ExecSession the exec session opened by user, Input and ExitCode are filled when the session is closed
+type ExecSession struct {
+	ID          string `json:"id"`
+	Namespace   string `json:"namespace"`
+	Node        string `json:"node"`
+	User        string `json:"user,omitempty"`
+	RequestId   string `json:"requestId,omitempty"`
+	ExecRequest `json:",inline"`
+	Input       string    `json:"input,omitempty"`
+	ExitCode    *int      `json:"exitCode,omitempty"`
+	CreateTime  time.Time `json:"createTime"`
+	CloseTime   time.Time `json:"closeTime,omitempty"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// FeatureFlag the capability enabled tenant by tenant, such as a new protocol of sync. Handlers read the flags of
+// the namespace of request from the context
+type FeatureFlag struct {
+	Name        string `json:"name,omitempty" validate:"resourceName"`
+	Description string `json:"description,omitempty" validate:"max=1024"`
+	// Enabled enables the flag for all namespaces not overridden
+	Enabled bool `json:"enabled,omitempty"`
+	// Percentage the percentage of the namespaces not overridden which the flag is rolled out to. The namespaces are
+	// chosen by the hash of flag and namespace, so the ones enabled stay enabled as the percentage grows
+	Percentage int `json:"percentage,omitempty" validate:"min=0,max=100"`
+	// Overrides the flag set for namespaces explicitly, which take precedence over Enabled and Percentage
+	Overrides  map[string]bool `json:"overrides,omitempty"`
+	CreateTime time.Time       `json:"createTime,omitempty"`
+	UpdateTime time.Time       `json:"updateTime,omitempty"`
+}
+
+// FeatureOverride the flag set for a namespace explicitly
+type FeatureOverride struct {
+	Enabled bool `json:"enabled"`
+}
+
+// IsEnabled returns true if the flag is enabled for the namespace
+func (f *FeatureFlag) IsEnabled(namespace string) bool {
+	if v, ok := f.Overrides[namespace]; ok {
+		return v
+	}
+	if f.Enabled {
+		return true
+	}
+	return f.Percentage > 0 && FeatureBucket(f.Name, namespace) < f.Percentage
+}
+
+// FeatureBucket returns the bucket of namespace for the flag in [0, 100), which is the same on all instances
+func FeatureBucket(flag, namespace string) int {
+	h := fnv.New32a()
+	h.Write([]byte(flag + "/" + namespace))
+	return int(h.Sum32() % 100)
+}
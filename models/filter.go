@@ -1,6 +1,11 @@
 package models
 
-import "strings"
+import (
+	"context"
+	"strings"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
 
 type ListView struct {
 	Total    int         `json:"total"`
@@ -9,10 +14,21 @@ type ListView struct {
 	Items    interface{} `json:"items"`
 }
 
+// ListOptionsResponse the list envelope keeping pageNo and pageSize of the paged requests, which the list
+// responses returned before the envelope have, so the clients reading them still work
+type ListOptionsResponse struct {
+	*common.ListResponse `json:",inline"`
+	PageNo               int `json:"pageNo,omitempty"`
+	PageSize             int `json:"pageSize,omitempty"`
+}
+
 type Filter struct {
 	PageNo   int    `form:"pageNo" json:"pageNo,omitempty"`
 	PageSize int    `form:"pageSize" json:"pageSize,omitempty"`
+	Offset   int    `form:"offset" json:"offset,omitempty"`
 	Name     string `form:"name,omitempty" json:"name,omitempty"`
+
+	ctx context.Context
 }
 
 type ListOptions struct {
@@ -24,9 +40,13 @@ type ListOptions struct {
 	Limit         int64  `form:"limit,omitempty" json:"limit,omitempty"`
 	Continue      string `form:"continue,omitempty" json:"continue,omitempty"`
 	Filter        `json:",inline"`
+	NodeQuery     `json:",inline"`
 }
 
 func (f *Filter) GetLimitOffset() int {
+	if f.Offset > 0 {
+		return f.Offset
+	}
 	if f.PageNo <= 0 {
 		f.PageNo = 1
 	}
@@ -37,6 +57,23 @@ func (f *Filter) GetLimitNumber() int {
 	return f.PageSize
 }
 
+// SetContext keeps the context of request to abort the storage operations,
+// the context is ignored if it can never be canceled
+func (f *Filter) SetContext(ctx context.Context) {
+	if ctx == nil || ctx.Done() == nil {
+		return
+	}
+	f.ctx = ctx
+}
+
+// Context returns the context of request, or background if not set
+func (f *Filter) Context() context.Context {
+	if f.ctx == nil {
+		return context.Background()
+	}
+	return f.ctx
+}
+
 func (f *Filter) GetFuzzyName() string {
 	if f.Name == "" {
 		return "%"
@@ -55,6 +92,36 @@ func (l *ListOptions) GetFuzzyKeyword() string {
 	return l.Keyword
 }
 
+// SetPagination applies the pagination loaded from request
+func (l *ListOptions) SetPagination(p *common.Pagination) {
+	if p.Continue != "" {
+		// paging by storage
+		l.Continue = p.Continue
+		l.Limit = int64(p.Limit)
+		l.PageNo, l.PageSize, l.Offset = 0, 0, 0
+		return
+	}
+	// the first page is limited by storage too, so that the storage paging issues the continue token,
+	// the others are paged in memory by offset
+	l.Limit = 0
+	if p.Offset == 0 {
+		l.Limit = int64(p.Limit)
+	}
+	l.PageSize = p.Limit
+	l.Offset = p.Offset
+}
+
+// GetNextToken returns the token of next page
+func (l *ListOptions) GetNextToken(total int) string {
+	if l == nil {
+		return ""
+	}
+	if l.Continue != "" {
+		return l.Continue
+	}
+	return common.NextOffsetToken(l.GetLimitOffset(), l.GetLimitNumber(), total)
+}
+
 func GetPagingParam(listOptions *ListOptions, resLen int) (start, end int) {
 	start = 0
 	end = resLen
@@ -6,6 +6,10 @@ type Function struct {
 	Version string       `yaml:"version,omitempty" json:"version,omitempty"`
 	Runtime string       `yaml:"runtime,omitempty" json:"runtime,omitempty"`
 	Code    FunctionCode `yaml:"code,omitempty" json:"code,omitempty"`
+	// Env the environment variables of function in the cloud, carried over when the function is imported
+	Env map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+	// MemorySize the memory allocated to the function in MB
+	MemorySize int `yaml:"memorySize,omitempty" json:"memorySize,omitempty"`
 }
 
 type FunctionView struct {
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	FunctionBuildRunning   = "building"
+	FunctionBuildSucceeded = "succeeded"
+	FunctionBuildFailed    = "failed"
+)
+
+// FunctionBuild the build of function version, the code zip uploaded to object storage is built into Image
+// on top of the image of Runtime, which is ready to run without pushing the code to nodes
+type FunctionBuild struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Runtime   string `json:"runtime"`
+	Handler   string `json:"handler,omitempty"`
+	// Source, Bucket and Object locate the code zip in object storage
+	Source string `json:"source"`
+	Bucket string `json:"bucket"`
+	Object string `json:"object"`
+	Sha256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+	// Builder the builder plugin and BuildID the id of build returned by it
+	Builder string `json:"builder"`
+	BuildID string `json:"buildID,omitempty"`
+	// Image the image pushed by the build, which is available once the build succeeded
+	Image      string    `json:"image"`
+	Status     string    `json:"status"`
+	Message    string    `json:"message,omitempty"`
+	CreateTime time.Time `json:"createTime,omitempty"`
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
+
+// FunctionBuildView the builds of the versions of function
+type FunctionBuildView struct {
+	Builds []FunctionBuild `json:"builds"`
+}
+
+// FunctionBuildRequest builds Image with Dockerfile from the code zip downloaded from CodeURL
+type FunctionBuildRequest struct {
+	Namespace  string
+	Name       string
+	Version    string
+	CodeURL    string
+	Dockerfile string
+	Image      string
+}
+
+// FunctionBuildStatus the status of build reported by builder, the message explains the failure
+type FunctionBuildStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"encoding/json"
+	"path"
+	"time"
+)
+
+// FunctionDraft the code of function edited online, which is neither deployed nor referenced by applications
+// until it is published as a version. Files are the contents of the code files keyed by their relative paths
+type FunctionDraft struct {
+	Namespace   string            `json:"namespace"`
+	Name        string            `json:"name"`
+	Runtime     string            `json:"runtime" validate:"required"`
+	Handler     string            `json:"handler,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Files       map[string]string `json:"files,omitempty"`
+	// Env the environment variables which the function requires, validated when the draft is published
+	Env        []FunctionEnv `json:"env,omitempty"`
+	CreateTime time.Time     `json:"createTime,omitempty"`
+	UpdateTime time.Time     `json:"updateTime,omitempty"`
+}
+
+// FunctionDraftFile the code file of draft
+type FunctionDraftFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// FunctionVersion the immutable version published from the draft of function, the code zip is stored
+// in object storage and is referenced by the function configs of applications
+type FunctionVersion struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Runtime     string `json:"runtime"`
+	Handler     string `json:"handler,omitempty"`
+	Description string `json:"description,omitempty"`
+	Source      string `json:"source"`
+	Bucket      string `json:"bucket"`
+	Object      string `json:"object"`
+	Sha256      string `json:"sha256"`
+	Size        int64  `json:"size"`
+	// Flavor the flavor of runtime, the services of the version of gpu flavor run the CUDA-enabled image
+	// of runtime and request a GPU of device plugin
+	Flavor string `json:"flavor,omitempty"`
+	// Env the environment variables rendered into the services of the function apps using the version
+	Env        []FunctionEnv `json:"env,omitempty"`
+	CreateTime time.Time     `json:"createTime,omitempty"`
+}
+
+const (
+	FunctionFlavorCPU = "cpu"
+	FunctionFlavorGPU = "gpu"
+	// FunctionGPUResource the extended resource requested by the services of gpu flavor
+	FunctionGPUResource = "nvidia.com/gpu"
+)
+
+const (
+	FunctionEnvString = "string"
+	FunctionEnvInt    = "int"
+	FunctionEnvNumber = "number"
+	FunctionEnvBool   = "bool"
+)
+
+// FunctionEnv declares the environment variable of function, the value is either set by the service of app,
+// the default or read from the key of secret in namespace when the app is delivered to nodes
+type FunctionEnv struct {
+	Name string `json:"name"`
+	// Type one of string, int, number and bool, string if empty
+	Type        string             `json:"type,omitempty"`
+	Required    bool               `json:"required,omitempty"`
+	Default     string             `json:"default,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Secret      *FunctionEnvSecret `json:"secret,omitempty"`
+}
+
+// FunctionEnvSecret the key of secret bound to the environment variable
+type FunctionEnvSecret struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// IsWasm returns whether the code of version is the wasm bundle, which is used on nodes as is rather than unpacked
+func (v *FunctionVersion) IsWasm() bool {
+	return path.Ext(v.Object) == FunctionWasmExt
+}
+
+// FunctionWasmExt the extension of the objects of wasm bundles
+const FunctionWasmExt = ".wasm"
+
+// FunctionVersionView the published versions of function
+type FunctionVersionView struct {
+	Versions []FunctionVersion `json:"versions"`
+}
+
+// FunctionPublishRequest publishes the draft as Version
+type FunctionPublishRequest struct {
+	Version     string `json:"version" validate:"required"`
+	Description string `json:"description,omitempty"`
+	// Flavor cpu or gpu, cpu if empty
+	Flavor string `json:"flavor,omitempty" validate:"omitempty,oneof=cpu gpu"`
+}
+
+// FunctionInvokeRequest invokes the function with Payload as the event. The function runs in sandbox
+// unless Node is given, Code is the zip of the code of draft or CodeURL the one of Version run by Image of the runtime
+type FunctionInvokeRequest struct {
+	Namespace string `json:"-"`
+	Name      string `json:"-"`
+	// Version the version published to invoke in sandbox, the draft is invoked if empty
+	Version string `json:"version,omitempty"`
+	// Node and App the node and the function app running on it to invoke the function of
+	Node    string          `json:"node,omitempty"`
+	App     string          `json:"app,omitempty"`
+	Runtime string          `json:"-"`
+	Image   string          `json:"-"`
+	Handler string          `json:"-"`
+	Code    []byte          `json:"-"`
+	CodeURL string          `json:"-"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// FunctionInvokeResult the result of invocation in sandbox, Error is the error raised by the function
+type FunctionInvokeResult struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	Logs   string          `json:"logs,omitempty"`
+	// Duration the milliseconds the invocation took
+	Duration int64 `json:"duration"`
+}
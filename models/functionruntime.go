@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	FunctionRuntimeContainer = "container"
+	FunctionRuntimeWasm      = "wasm"
+)
+
+// FunctionRuntime the runtime of functions registered by admins, the function apps of the runtime run Image
+// with the code and the configuration of functions mounted. It overrides the runtime module of the same name
+type FunctionRuntime struct {
+	Name     string `json:"name,omitempty" validate:"resourceName"`
+	Language string `json:"language" validate:"required"`
+	Version  string `json:"version" validate:"required"`
+	// Type container or wasm, the wasm runtime runs the wasm bundles of functions in Image, container if empty
+	Type  string `json:"type,omitempty" validate:"omitempty,oneof=container wasm"`
+	Image string `json:"image" validate:"required"`
+	// GPUImage the CUDA-enabled image run by the functions of gpu flavor, which the runtime doesn't support if empty
+	GPUImage string `json:"gpuImage,omitempty"`
+	// Handler the convention of handlers of the functions, such as file.function for index.handler
+	Handler     string    `json:"handler,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreateTime  time.Time `json:"createTime,omitempty"`
+	UpdateTime  time.Time `json:"updateTime,omitempty"`
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+)
+
+// The kinds of garbage left without owners
+const (
+	// GarbageApp the system apps of the nodes deleted
+	GarbageApp = "app"
+	// GarbageConfig the system configs of the nodes deleted
+	GarbageConfig = "config"
+	// GarbageSecret the system secrets of the nodes deleted, such as their certificates
+	GarbageSecret = "secret"
+	// GarbageObject the objects offloaded from configs which no config references
+	GarbageObject = "object"
+	// GarbageCert the certificates expired which no secret references, such as the ones renewed
+	GarbageCert = "cert"
+	// GarbageShadow the shadows of the nodes deleted
+	GarbageShadow = "shadow"
+)
+
+// GarbageReport the resources found without owners by the scan, which are cleaned once the report is confirmed
+type GarbageReport struct {
+	// ID identifies the report, the cleanup confirms it so that only the resources reviewed are deleted
+	ID     string         `json:"id"`
+	Total  int            `json:"total"`
+	Counts map[string]int `json:"counts"`
+	Items  []GarbageItem  `json:"items"`
+	// Errors the failures of the parts of scan, the resources of the parts failed are not reported
+	Errors []string  `json:"errors,omitempty"`
+	Time   time.Time `json:"time"`
+	// Elapsed the milliseconds the scan took
+	Elapsed int64 `json:"elapsed"`
+}
+
+// GarbageItem the resource without owner, Source, Bucket and UserID locate it if it's an object
+type GarbageItem struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+	Source    string `json:"source,omitempty"`
+	Bucket    string `json:"bucket,omitempty"`
+	UserID    string `json:"userID,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	// Time the time the resource is created, or expired if it's a certificate
+	Time time.Time `json:"time,omitempty"`
+}
+
+// GarbageCleanup the cleanup of the garbage reported
+type GarbageCleanup struct {
+	// Report the id of the report reviewed
+	Report string `json:"report" validate:"required"`
+	// Kinds the kinds of garbage cleaned, all kinds are cleaned if empty
+	Kinds []string `json:"kinds,omitempty" validate:"dive,oneof=app config secret object cert shadow"`
+}
+
+// GarbageCleanupResult the resources deleted by the cleanup, keyed as the items of report
+type GarbageCleanupResult struct {
+	Deleted  []string          `json:"deleted"`
+	Failures map[string]string `json:"failures,omitempty"`
+}
+
+// Key identifies the item across the reports
+func (i *GarbageItem) Key() string {
+	if i.Kind == GarbageObject {
+		return i.Kind + "/" + i.Source + "/" + i.Bucket + "/" + i.Name
+	}
+	if i.Namespace == "" {
+		return i.Kind + "/" + i.Name
+	}
+	return i.Kind + "/" + i.Namespace + "/" + i.Name
+}
+
+// Add appends the item to the report
+func (r *GarbageReport) Add(item GarbageItem) {
+	r.Items = append(r.Items, item)
+	r.Counts[item.Kind]++
+	r.Total++
+}
+
+// Cleans returns true if the kind of garbage is cleaned
+func (c *GarbageCleanup) Cleans(kind string) bool {
+	if len(c.Kinds) == 0 {
+		return true
+	}
+	for _, k := range c.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
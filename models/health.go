@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+)
+
+const (
+	HealthLevelHealthy  = "healthy"
+	HealthLevelWarning  = "warning"
+	HealthLevelCritical = "critical"
+	// HealthLevelUnknown the node has never reported
+	HealthLevelUnknown = "unknown"
+
+	DefaultHealthWarningScore  = 80
+	DefaultHealthCriticalScore = 50
+)
+
+// NodeHealth the health of node, the score ranges from 0 to 100 and the reasons explain the deductions
+type NodeHealth struct {
+	Score   int      `json:"score"`
+	Level   string   `json:"level"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// HealthThreshold the thresholds of health level in namespace, the node whose score is lower than
+// WarningScore is warning and lower than CriticalScore is critical, the level changes are posted to Webhook
+type HealthThreshold struct {
+	Namespace     string    `json:"namespace,omitempty"`
+	WarningScore  int       `json:"warningScore" validate:"min=0,max=100,gtfield=CriticalScore"`
+	CriticalScore int       `json:"criticalScore" validate:"min=0,max=100"`
+	Webhook       string    `json:"webhook,omitempty" validate:"omitempty,url"`
+	CreateTime    time.Time `json:"createTime,omitempty"`
+	UpdateTime    time.Time `json:"updateTime,omitempty"`
+}
+
+// NodeHealthAlert the body posted to the webhook of namespace when the health level of node changes
+type NodeHealthAlert struct {
+	Namespace     string    `json:"namespace"`
+	Node          string    `json:"node"`
+	Score         int       `json:"score"`
+	Level         string    `json:"level"`
+	PreviousLevel string    `json:"previousLevel,omitempty"`
+	Reasons       []string  `json:"reasons,omitempty"`
+	Time          time.Time `json:"time"`
+}
+
+// NodeHealthView the node view with health, which is returned in the node list
+type NodeHealthView struct {
+	specV1.NodeView `json:",inline"`
+	Health          *NodeHealth `json:"health,omitempty"`
+}
+
+// Level returns the health level of score
+func (t *HealthThreshold) Level(score int) string {
+	switch {
+	case score < t.CriticalScore:
+		return HealthLevelCritical
+	case score < t.WarningScore:
+		return HealthLevelWarning
+	default:
+		return HealthLevelHealthy
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	NodeEventOnline  = "node-online"
+	NodeEventOffline = "node-offline"
+
+	// DefaultOfflineTimeout the seconds added to the report frequency before the node is offline
+	DefaultOfflineTimeout = 20
+)
+
+// HeartbeatPolicy the heartbeat policy of namespace, the node is offline if it does not report
+// within its report frequency plus OfflineTimeout seconds, the online and offline events are posted to Webhook
+type HeartbeatPolicy struct {
+	Namespace      string    `json:"namespace,omitempty"`
+	OfflineTimeout int       `json:"offlineTimeout" validate:"min=1,max=86400"`
+	Webhook        string    `json:"webhook,omitempty" validate:"omitempty,url"`
+	CreateTime     time.Time `json:"createTime,omitempty"`
+	UpdateTime     time.Time `json:"updateTime,omitempty"`
+}
+
+// NodeEvent the event emitted when the node goes online or offline
+type NodeEvent struct {
+	Id        uint64    `json:"id,omitempty"`
+	Namespace string    `json:"namespace"`
+	Node      string    `json:"node"`
+	Type      string    `json:"type"`
+	Time      time.Time `json:"time"`
+	// ReportTime the last report time of node when the event is emitted
+	ReportTime time.Time `json:"reportTime,omitempty"`
+}
+
+// NodeEventFilter the filter of node events, the events in [Start, End) are listed if set
+type NodeEventFilter struct {
+	Node   string    `form:"node,omitempty" json:"node,omitempty"`
+	Type   string    `form:"type,omitempty" json:"type,omitempty" validate:"omitempty,oneof=node-online node-offline"`
+	Start  time.Time `form:"-" json:"start,omitempty"`
+	End    time.Time `form:"-" json:"end,omitempty"`
+	Filter `json:",inline"`
+}
+
+// NodeEventList node event list
+type NodeEventList struct {
+	Total int         `json:"total"`
+	Items []NodeEvent `json:"items"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// HelmRelease the resources rendered from a helm chart with values,
+// the chart is kept to render the resources again when the release is upgraded
+type HelmRelease struct {
+	Namespace    string `json:"namespace,omitempty"`
+	Name         string `json:"name,omitempty" validate:"resourceName"`
+	Chart        string `json:"chart,omitempty"`
+	ChartVersion string `json:"chartVersion,omitempty"`
+	AppVersion   string `json:"appVersion,omitempty"`
+	// URL the address which the chart is downloaded from, it is empty if the chart is uploaded
+	URL       string                 `json:"url,omitempty"`
+	Values    map[string]interface{} `json:"values,omitempty"`
+	Revision  int                    `json:"revision"`
+	Resources []HelmResource         `json:"resources"`
+	// Archive the packaged chart (.tgz)
+	Archive    []byte    `json:"-"`
+	CreateTime time.Time `json:"createTime,omitempty"`
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
+
+// HelmResource the kube resource rendered from chart, which is stored as an application, config or secret
+type HelmResource struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
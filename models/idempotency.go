@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// IdempotencyRecord the response of request with Idempotency-Key, which is replayed on retries
+// the record is pending until the request is handled, in which case Status is 0
+type IdempotencyRecord struct {
+	Namespace   string    `json:"namespace,omitempty"`
+	Key         string    `json:"key,omitempty"`
+	RequestHash string    `json:"requestHash,omitempty"`
+	Status      int       `json:"status,omitempty"`
+	ContentType string    `json:"contentType,omitempty"`
+	Response    []byte    `json:"response,omitempty"`
+	ExpireTime  time.Time `json:"expireTime,omitempty"`
+}
+
+func (r *IdempotencyRecord) IsPending() bool {
+	return r.Status == 0
+}
+
+func (r *IdempotencyRecord) IsExpired() bool {
+	return time.Now().After(r.ExpireTime)
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+const (
+	ImagePullAlways       = "Always"
+	ImagePullIfNotPresent = "IfNotPresent"
+	ImagePullNever        = "Never"
+)
+
+// ImagePolicy the image policy of namespace rendered into the applications when delivered to nodes,
+// the images of the registries listed are rewritten to the mirror, such as nginx:1.21 to harbor.local/library/nginx:1.21
+type ImagePolicy struct {
+	Namespace string `json:"namespace,omitempty"`
+	// Mirror the registry mirror with an optional project, such as harbor.local or harbor.local/proxy, no rewrite if empty
+	Mirror string `json:"mirror,omitempty"`
+	// Registries the registries rewritten to the mirror, all registries are rewritten if empty
+	Registries []string `json:"registries,omitempty"`
+	// PullPolicy the pull policy of images, the one of node engine is used if empty
+	PullPolicy string    `json:"pullPolicy,omitempty" validate:"omitempty,oneof=Always IfNotPresent Never"`
+	CreateTime time.Time `json:"createTime,omitempty"`
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Impersonation the short-lived context issued to the operator of mis server, the requests of admin server carrying
+// its token act as the user of namespace. They are read-only unless mutable, and audited along with the operator
+type Impersonation struct {
+	ID        string `json:"id,omitempty"`
+	Namespace string `json:"namespace" validate:"namespace"`
+	User      string `json:"user" validate:"required,max=128"`
+	Operator  string `json:"operator,omitempty"`
+	// Reason tells why the operator impersonates the user, such as the ticket to reproduce
+	Reason  string `json:"reason" validate:"required,max=512"`
+	Mutable bool   `json:"mutable,omitempty"`
+	// Expiry the duration the impersonation is valid for once issued, the default one is taken if empty
+	Expiry     string    `json:"expiry,omitempty" validate:"omitempty,duration"`
+	ExpireTime time.Time `json:"expireTime,omitempty"`
+	Token      string    `json:"token,omitempty"`
+}
+
+func (i *Impersonation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpireTime)
+}
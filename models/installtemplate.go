@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// InstallTemplate overrides the template of the install command, the setup script or the init manifest for the
+// nodes of namespace, such as to set the proxy, the private registry or the container runtime of the hosts. The
+// template is rendered with the params of the default one, and the values of the override are referenced as
+// {{.Values.key}}
+type InstallTemplate struct {
+	Namespace   string            `json:"namespace,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty" validate:"omitempty,max=1024"`
+	Content     string            `json:"content" validate:"required,max=65536"`
+	Values      map[string]string `json:"values,omitempty" validate:"max=64"`
+	CreateTime  time.Time         `json:"createTime,omitempty"`
+	UpdateTime  time.Time         `json:"updateTime,omitempty"`
+}
+
+// InstallTemplateDefault the default template of the install resource, which is used if not overridden
+type InstallTemplateDefault struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// MaintenanceWindow the window in which the desired state is delivered to node. The window opens at the times
+// matching Cron (minute hour day-of-month month day-of-week) in Timezone and lasts for Duration
+type MaintenanceWindow struct {
+	Cron     string `json:"cron" validate:"required"`
+	Duration string `json:"duration" validate:"required,duration"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// NodeMaintenance the maintenance window in effect for node, which is set on node or inherited from Group
+type NodeMaintenance struct {
+	Window *MaintenanceWindow `json:"window,omitempty"`
+	Group  string             `json:"group,omitempty"`
+	Open   bool               `json:"open"`
+	// Start the start of the open window, or of the next one if it is closed
+	Start time.Time `json:"start,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// NodeMetricsPoint the resource usage of node at Time, cpu is measured in cores, memory and disk are in bytes
+type NodeMetricsPoint struct {
+	Time           time.Time `json:"time"`
+	CPUUsage       float64   `json:"cpuUsage"`
+	CPUCapacity    float64   `json:"cpuCapacity"`
+	MemoryUsage    int64     `json:"memoryUsage"`
+	MemoryCapacity int64     `json:"memoryCapacity"`
+	DiskUsage      int64     `json:"diskUsage"`
+	DiskCapacity   int64     `json:"diskCapacity"`
+}
+
+// NodeMetrics the history of node resource usage between Start and End, the points are averaged by Step in seconds
+type NodeMetrics struct {
+	Namespace string             `json:"namespace"`
+	Name      string             `json:"name"`
+	Start     time.Time          `json:"start"`
+	End       time.Time          `json:"end"`
+	Step      int                `json:"step"`
+	Points    []NodeMetricsPoint `json:"points"`
+}
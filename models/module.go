@@ -1,9 +1,14 @@
 package models
 
 import (
+	"strings"
 	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
 )
 
+// Module the version of module in the catalog, the latest one is installed to the nodes created and offered to
+// the nodes upgrading unless it's deprecated or requires a newer cloud, the newest available version is instead
 type Module struct {
 	Name              string            `json:"name,omitempty"`
 	Version           string            `json:"version,omitempty"`
@@ -15,6 +20,25 @@ type Module struct {
 	Description       string            `json:"description,omitempty"`
 	CreationTimestamp time.Time         `json:"createTime,omitempty"`
 	UpdateTimestamp   time.Time         `json:"updateTime,omitempty"`
+	// ReleaseNotes the changes of the version shown to the users upgrading to it
+	ReleaseNotes string `json:"releaseNotes,omitempty" validate:"max=4096"`
+	// Deprecated the version is no longer installed, the nodes running it keep it until upgraded
+	Deprecated bool `json:"deprecated,omitempty"`
+	// MinCloudVersion the minimal version of cloud the version works with, any cloud if empty
+	MinCloudVersion string `json:"minCloudVersion,omitempty" validate:"max=36"`
+}
+
+// SupportsCloud returns true if the version works with the cloud of version, the clouds built without release
+// versions, such as the ones built from sources, support all versions
+func (m *Module) SupportsCloud(version string) bool {
+	if m.MinCloudVersion == "" {
+		return true
+	}
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if v == "" || v[0] < '0' || v[0] > '9' {
+		return true
+	}
+	return common.CompareVersion(version, m.MinCloudVersion) >= 0
 }
 
 type InitCMD struct {
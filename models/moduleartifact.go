@@ -0,0 +1,62 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ModuleArtifacts the manifest of the artifacts of a module version built for the platforms, the image of module
+// version is delivered to the nodes of the platforms not built for. Image is the one of module version, which tells
+// the services running it on sync
+type ModuleArtifacts struct {
+	Name       string           `json:"name,omitempty"`
+	Version    string           `json:"version,omitempty"`
+	Image      string           `json:"image,omitempty"`
+	Artifacts  []ModuleArtifact `json:"artifacts" validate:"dive"`
+	CreateTime time.Time        `json:"createTime,omitempty"`
+	UpdateTime time.Time        `json:"updateTime,omitempty"`
+}
+
+// ModuleArtifact the image and the program of module version built for the platform, which is os-arch with the
+// variant of arm optional, such as linux-amd64, linux-arm64, linux-arm-v7 and windows-amd64
+type ModuleArtifact struct {
+	Platform string `json:"platform" validate:"required,max=64"`
+	Image    string `json:"image,omitempty" validate:"omitempty,max=512"`
+	Program  string `json:"program,omitempty" validate:"omitempty,url,max=1024"`
+}
+
+// Select returns the artifact built for the platform, the artifacts of the variants of platform are selected for
+// the platform without variant and the other way around, such as linux-arm-v7 for linux-arm
+func (m *ModuleArtifacts) Select(platform string) *ModuleArtifact {
+	if m == nil || platform == "" {
+		return nil
+	}
+	platform = strings.ToLower(platform)
+	for i := range m.Artifacts {
+		if strings.ToLower(m.Artifacts[i].Platform) == platform {
+			return &m.Artifacts[i]
+		}
+	}
+	for i := range m.Artifacts {
+		p := strings.ToLower(m.Artifacts[i].Platform)
+		if strings.HasPrefix(p, platform+"-") || strings.HasPrefix(platform, p+"-") {
+			return &m.Artifacts[i]
+		}
+	}
+	return nil
+}
+
+// Platform returns the platform of os, arch and variant, which is empty if the arch is unknown
+func Platform(os, arch, variant string) string {
+	if arch == "" {
+		return ""
+	}
+	if os == "" {
+		os = "linux"
+	}
+	res := strings.ToLower(os + "-" + arch)
+	if variant != "" {
+		res += "-" + strings.ToLower(variant)
+	}
+	return res
+}
@@ -22,6 +22,128 @@ type NodeNames struct {
 	Names []string `json:"names,"validate:"maxLength=20"`
 }
 
+// NodeBatch the nodes to create in batch, which are given by Nodes,
+// or generated from Template and named by NamePrefix-index for index in [StartIndex, StartIndex+Count)
+type NodeBatch struct {
+	Nodes      []*specV1.Node `json:"nodes,omitempty"`
+	Count      int            `json:"count,omitempty"`
+	NamePrefix string         `json:"namePrefix,omitempty"`
+	StartIndex int            `json:"startIndex,omitempty" default:"1"`
+	Template   *specV1.Node   `json:"template,omitempty"`
+}
+
+// NodeBatchItem the result of creating a node in batch
+type NodeBatchItem struct {
+	Name    string           `json:"name"`
+	Success bool             `json:"success"`
+	Error   string           `json:"error,omitempty"`
+	Node    *specV1.NodeView `json:"node,omitempty"`
+	// Source the name of kubernetes node which is imported as the node
+	Source string `json:"source,omitempty"`
+	// InitCMD the command to install baetyl on the imported kubernetes node
+	InitCMD string `json:"initCmd,omitempty"`
+}
+
+// NodeBatchResult the result of creating nodes in batch
+type NodeBatchResult struct {
+	Total     int             `json:"total"`
+	Succeeded int             `json:"succeeded"`
+	Failed    int             `json:"failed"`
+	Items     []NodeBatchItem `json:"items"`
+}
+
+// NodeImport the existing kubernetes cluster whose nodes are imported, which is connected by Kubeconfig,
+// or by Server and Token. The nodes are created from Template and named by NamePrefix and the name of kubernetes node
+type NodeImport struct {
+	Kubeconfig     string       `json:"kubeconfig,omitempty"`
+	Server         string       `json:"server,omitempty" validate:"omitempty,url"`
+	Token          string       `json:"token,omitempty"`
+	CAData         string       `json:"caData,omitempty"`
+	Insecure       bool         `json:"insecure,omitempty"`
+	Selector       string       `json:"selector,omitempty"`
+	IncludeMasters bool         `json:"includeMasters,omitempty"`
+	NamePrefix     string       `json:"namePrefix,omitempty"`
+	Mode           string       `json:"mode,omitempty" default:"kube"`
+	Template       *specV1.Node `json:"template,omitempty"`
+}
+
+// ClusterNode the node of the kubernetes cluster to import
+type ClusterNode struct {
+	Name           string            `json:"name"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	Address        string            `json:"address,omitempty"`
+	OS             string            `json:"os,omitempty"`
+	Arch           string            `json:"arch,omitempty"`
+	KubeletVersion string            `json:"kubeletVersion,omitempty"`
+	Master         bool              `json:"master"`
+	Ready          bool              `json:"ready"`
+}
+
+// NodeLabelsPatch the labels to add to and remove from the nodes, which are matched by Selector or listed in Nodes
+type NodeLabelsPatch struct {
+	Selector string            `json:"selector,omitempty"`
+	Nodes    []string          `json:"nodes,omitempty"`
+	Add      map[string]string `json:"add,omitempty" validate:"omitempty,validLabels"`
+	Remove   []string          `json:"remove,omitempty"`
+}
+
+// NodeLabelsItem the result of patching the labels of a node
+type NodeLabelsItem struct {
+	Name    string            `json:"name"`
+	Success bool              `json:"success"`
+	Changed bool              `json:"changed"`
+	Error   string            `json:"error,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// NodeLabelsResult the result of patching the labels of nodes, the updated nodes are rolled back if any node fails
+type NodeLabelsResult struct {
+	Total      int              `json:"total"`
+	Succeeded  int              `json:"succeeded"`
+	Failed     int              `json:"failed"`
+	RolledBack bool             `json:"rolledBack"`
+	Items      []NodeLabelsItem `json:"items"`
+}
+
+const (
+	// DriftMissing the app is desired but not reported by the node
+	DriftMissing = "missing"
+	// DriftVersion the reported version of app differs from the desired one
+	DriftVersion = "version"
+	// DriftNotRunning the app is reported in desired version but some instances are not running
+	DriftNotRunning = "notRunning"
+	// DriftUnexpected the app is reported but not desired any more
+	DriftUnexpected = "unexpected"
+)
+
+// NodeDiff the drift report between the desired and the reported state of node
+type NodeDiff struct {
+	Namespace  string         `json:"namespace"`
+	Name       string         `json:"name"`
+	Converged  bool           `json:"converged"`
+	ReportTime interface{}    `json:"reportTime,omitempty"`
+	Items      []NodeDiffItem `json:"items"`
+}
+
+// NodeDiffItem the drift of an app, Drift is empty if the app has converged
+type NodeDiffItem struct {
+	Name            string            `json:"name"`
+	System          bool              `json:"system"`
+	DesiredVersion  string            `json:"desiredVersion,omitempty"`
+	ReportedVersion string            `json:"reportedVersion,omitempty"`
+	Drift           string            `json:"drift,omitempty"`
+	Instances       map[string]string `json:"instances,omitempty"`
+	Cause           string            `json:"cause,omitempty"`
+}
+
+// NodeDrainStatus the progress of draining node, the node is drained if it is cordoned and reports no user app
+type NodeDrainStatus struct {
+	Name          string   `json:"name"`
+	Cordoned      bool     `json:"cordoned"`
+	Drained       bool     `json:"drained"`
+	RemainingApps []string `json:"remainingApps"`
+}
+
 type NodeProperties struct {
 	State NodePropertiesState    `yaml:"state,omitempty" json:"state,omitempty"`
 	Meta  NodePropertiesMetadata `yaml:"metadata,omitempty" json:"metadata,omitempty"`
@@ -63,6 +185,8 @@ type NodeCoreConfigs struct {
 
 type NodeCoreVersions struct {
 	Versions []string `yaml:"versions,omitempty" json:"versions,omitempty"`
+	// ReleaseNotes the release notes of the versions upgraded to
+	ReleaseNotes map[string]string `yaml:"releaseNotes,omitempty" json:"releaseNotes,omitempty"`
 }
 
 type NodeSysAppView struct {
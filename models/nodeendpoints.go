@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// The names of the endpoints nodes connect to, the init and sync endpoints not overridden are the properties of the
+// same names. The object storage endpoint has no property, the object urls are generated by the object storage then
+const (
+	EndpointInitServer    = "init-server-address"
+	EndpointSyncServer    = "sync-server-address"
+	EndpointSyncMQTT      = "sync-mqtt-address"
+	EndpointObjectStorage = "object-storage-address"
+)
+
+// NodeEndpoints the addresses of cloud injected into the install commands and configs of nodes, which override the
+// ones of cloud for the sites reaching cloud through a local reverse proxy or NAT gateway. The endpoints are set on
+// namespace, or on node which override the ones of namespace. The fields not set are not overridden
+type NodeEndpoints struct {
+	Namespace  string `json:"namespace,omitempty"`
+	InitServer string `json:"initServer,omitempty" validate:"omitempty,url,max=512"`
+	SyncServer string `json:"syncServer,omitempty" validate:"omitempty,url,max=512"`
+	SyncMQTT   string `json:"syncMqtt,omitempty" validate:"omitempty,url,max=512"`
+	// ObjectStorage the scheme and host replacing the ones of the object urls, the path is prefixed to their paths
+	ObjectStorage string    `json:"objectStorage,omitempty" validate:"omitempty,url,max=512"`
+	CreateTime    time.Time `json:"createTime,omitempty"`
+	UpdateTime    time.Time `json:"updateTime,omitempty"`
+}
+
+// Address returns the endpoint of name, which is empty if not overridden
+func (e *NodeEndpoints) Address(name string) string {
+	if e == nil {
+		return ""
+	}
+	switch name {
+	case EndpointInitServer:
+		return e.InitServer
+	case EndpointSyncServer:
+		return e.SyncServer
+	case EndpointSyncMQTT:
+		return e.SyncMQTT
+	case EndpointObjectStorage:
+		return e.ObjectStorage
+	}
+	return ""
+}
+
+// IsEmpty returns true if no endpoint is overridden
+func (e *NodeEndpoints) IsEmpty() bool {
+	return e == nil || e.InitServer == "" && e.SyncServer == "" && e.SyncMQTT == "" && e.ObjectStorage == ""
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+const (
+	NodeReadyOnline  = "online"
+	NodeReadyOffline = "offline"
+)
+
+// NodeQuery the conditions of listing nodes besides the selectors. Ready is matched by the state of node,
+// the others are matched by the storage of nodes with MinVersion and MaxVersion compared to the version of core
+type NodeQuery struct {
+	Ready         string    `form:"ready,omitempty" json:"ready,omitempty" validate:"omitempty,oneof=online offline"`
+	MinVersion    string    `form:"minVersion,omitempty" json:"minVersion,omitempty"`
+	MaxVersion    string    `form:"maxVersion,omitempty" json:"maxVersion,omitempty"`
+	CreatedAfter  time.Time `form:"createdAfter,omitempty" json:"createdAfter,omitempty"`
+	CreatedBefore time.Time `form:"createdBefore,omitempty" json:"createdBefore,omitempty"`
+}
+
+// NodeFilter the named filter of nodes saved by user
+type NodeFilter struct {
+	Namespace   string    `json:"namespace,omitempty"`
+	User        string    `json:"user,omitempty"`
+	Name        string    `json:"name,omitempty" validate:"omitempty,resourceName"`
+	Description string    `json:"description,omitempty"`
+	Selector    string    `json:"selector,omitempty"`
+	Query       NodeQuery `json:"query"`
+	CreateTime  time.Time `json:"createTime,omitempty"`
+	UpdateTime  time.Time `json:"updateTime,omitempty"`
+}
+
+// Match returns whether the node is created in the time range and runs the core in the version range
+func (q *NodeQuery) Match(node *specV1.Node) bool {
+	if !q.CreatedAfter.IsZero() && node.CreationTimestamp.Before(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !node.CreationTimestamp.Before(q.CreatedBefore) {
+		return false
+	}
+	if q.MinVersion == "" && q.MaxVersion == "" {
+		return true
+	}
+	version, _ := node.Attributes[specV1.BaetylCoreVersion].(string)
+	if version == "" {
+		return false
+	}
+	if q.MinVersion != "" && common.CompareVersion(version, q.MinVersion) < 0 {
+		return false
+	}
+	return q.MaxVersion == "" || common.CompareVersion(version, q.MaxVersion) <= 0
+}
+
+// IsEmpty returns whether no condition is set
+func (q *NodeQuery) IsEmpty() bool {
+	return *q == NodeQuery{}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// HardwareFingerprint the hardware identity presented by node at activation, the one presented first is bound to
+// the node and the later activations of node present the same hardware
+type HardwareFingerprint struct {
+	MachineID string    `json:"machineId,omitempty"`
+	MACs      []string  `json:"macs,omitempty"`
+	TPMEKHash string    `json:"tpmEkHash,omitempty"`
+	BindTime  time.Time `json:"bindTime,omitempty"`
+}
+
+// IsEmpty reports whether no identity is presented, such as by the installers before fingerprint binding
+func (f *HardwareFingerprint) IsEmpty() bool {
+	return f == nil || (f.MachineID == "" && len(f.MACs) == 0 && f.TPMEKHash == "")
+}
+
+// Normalize lowers the case of identities and sorts the MACs without duplicates
+func (f *HardwareFingerprint) Normalize() {
+	f.MachineID = strings.ToLower(strings.TrimSpace(f.MachineID))
+	f.TPMEKHash = strings.ToLower(strings.TrimSpace(f.TPMEKHash))
+	macs := map[string]bool{}
+	for _, mac := range f.MACs {
+		if mac = strings.ToLower(strings.TrimSpace(mac)); mac != "" {
+			macs[mac] = true
+		}
+	}
+	f.MACs = f.MACs[:0]
+	for mac := range macs {
+		f.MACs = append(f.MACs, mac)
+	}
+	sort.Strings(f.MACs)
+}
+
+// Match reports whether the fingerprint presented matches the bound one. The machine id and the TPM EK hash bound
+// are presented the same, and one of the MACs bound at least, so that the NICs of node can be replaced one by one
+// while the VMs cloned with new MACs are told apart
+func (f *HardwareFingerprint) Match(presented *HardwareFingerprint) bool {
+	if presented.IsEmpty() {
+		return false
+	}
+	if f.MachineID != presented.MachineID || f.TPMEKHash != presented.TPMEKHash {
+		return false
+	}
+	if len(f.MACs) == 0 {
+		return true
+	}
+	for _, mac := range presented.MACs {
+		i := sort.SearchStrings(f.MACs, mac)
+		if i < len(f.MACs) && f.MACs[i] == mac {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+// NodeGroup a group of nodes, the members are matched by Selector or listed in Nodes
+type NodeGroup struct {
+	Namespace   string    `json:"namespace,omitempty"`
+	Name        string    `json:"name,omitempty" validate:"omitempty,resourceName,nonBaetyl"`
+	Description string    `json:"description,omitempty"`
+	Selector    string    `json:"selector,omitempty"`
+	Nodes       []string  `json:"nodes,omitempty"`
+	CreateTime  time.Time `json:"createTime,omitempty"`
+	UpdateTime  time.Time `json:"updateTime,omitempty"`
+	// MaintenanceWindow the window applied to the member nodes which have no window set
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// SyncInterval the sync intervals applied to the member nodes which have no intervals set
+	SyncInterval *SyncInterval `json:"syncInterval,omitempty"`
+}
+
+// NodeGroupList node group list
+type NodeGroupList struct {
+	Total        int `json:"total"`
+	*ListOptions `json:",inline"`
+	Items        []NodeGroup `json:"items"`
+}
+
+// NodeSelector returns the selector of member nodes, which is used as the selector of applications targeting the group
+func (g *NodeGroup) NodeSelector() string {
+	if len(g.Nodes) == 0 {
+		return g.Selector
+	}
+	return fmt.Sprintf("%s in (%s)", common.LabelNodeName, strings.Join(g.Nodes, ","))
+}
@@ -0,0 +1,34 @@
+package models
+
+// The attributes of registration rendered into the labels of node besides the hardware reported, which are not
+// overridden by the hardware
+const (
+	PlacementAttributeFingerprint = "fingerprint"
+	PlacementAttributeMode        = "mode"
+	PlacementAttributeIP          = "ip"
+	PlacementAttributeCountry     = "country"
+	PlacementAttributeRegion      = "region"
+	PlacementAttributeCity        = "city"
+)
+
+// NodePlacement places the nodes registered by the bootstrap credential of token. The labels are the templates
+// rendered with the attributes of registration, such as {"region": "{{.region}}", "model": "{{.model}}"}, the ones
+// rendered empty are dropped. The node is assigned to the group of the first rule matching the labels rendered, or
+// to the group of token if none matches
+type NodePlacement struct {
+	Labels map[string]string   `json:"labels,omitempty" validate:"max=32"`
+	Rules  []NodePlacementRule `json:"rules,omitempty" validate:"max=32,dive"`
+}
+
+// NodePlacementRule assigns the nodes whose labels match the selector to the node group
+type NodePlacementRule struct {
+	Selector  string `json:"selector" validate:"required"`
+	NodeGroup string `json:"nodeGroup" validate:"required,resourceName"`
+}
+
+// Geolocation the location of ip, the fields unknown are empty
+type Geolocation struct {
+	Country string `json:"country,omitempty"`
+	Region  string `json:"region,omitempty"`
+	City    string `json:"city,omitempty"`
+}
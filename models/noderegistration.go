@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// The status of node registrations
+const (
+	NodeRegistrationPending  = "pending"
+	NodeRegistrationApproved = "approved"
+	NodeRegistrationRejected = "rejected"
+)
+
+// NodeRegistration a device asking to be registered as node by the bootstrap credential of a node group, the node
+// is created once the registration is approved by admin or by a rule
+type NodeRegistration struct {
+	Namespace   string            `json:"namespace,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Fingerprint string            `json:"fingerprint,omitempty"`
+	Hardware    map[string]string `json:"hardware,omitempty"`
+	Mode        string            `json:"mode,omitempty"`
+	// Token the bootstrap activation token presented by device
+	Token     string `json:"token,omitempty"`
+	NodeGroup string `json:"nodeGroup,omitempty"`
+	// Labels the labels of node placed by the token, which are set once the node is created
+	Labels map[string]string `json:"labels,omitempty"`
+	Status string            `json:"status,omitempty"`
+	Reason string            `json:"reason,omitempty"`
+	// Rule the rule approving the registration, which is empty if approved by admin
+	Rule string `json:"rule,omitempty"`
+	// ActivationToken the token issued for the node once approved, which is carried by its install command
+	ActivationToken string    `json:"-"`
+	CreateTime      time.Time `json:"createTime,omitempty"`
+	UpdateTime      time.Time `json:"updateTime,omitempty"`
+}
+
+// NodeRegistrationRequest the registration sent by device to init server, the node is named by the fingerprint if
+// no name is requested
+type NodeRegistrationRequest struct {
+	Token       string            `json:"token" validate:"required"`
+	Name        string            `json:"name,omitempty" validate:"omitempty,resourceName,nonBaetyl"`
+	Fingerprint string            `json:"fingerprint" validate:"required,max=256"`
+	Hardware    map[string]string `json:"hardware,omitempty" validate:"max=32"`
+	Mode        string            `json:"mode,omitempty" validate:"omitempty,oneof=kube native"`
+}
+
+// NodeRegistrationStatus the status of registration replied to device, the device keeps sending the registration
+// until it's approved with the install command or rejected
+type NodeRegistrationStatus struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Command string `json:"command,omitempty"`
+}
+
+// NodeRegistrationDecision the reason of admin approving or rejecting the registration
+type NodeRegistrationDecision struct {
+	Reason string `json:"reason,omitempty" validate:"omitempty,max=1024"`
+}
+
+// NodeRegistrationRule approves the pending registrations automatically, whose fingerprint matches the glob pattern
+// and whose hardware has all the values of rule
+type NodeRegistrationRule struct {
+	Namespace   string            `json:"namespace,omitempty"`
+	Name        string            `json:"name,omitempty" validate:"omitempty,resourceName"`
+	Description string            `json:"description,omitempty" validate:"omitempty,max=1024"`
+	Fingerprint string            `json:"fingerprint" validate:"required,max=256"`
+	Hardware    map[string]string `json:"hardware,omitempty"`
+	CreateTime  time.Time         `json:"createTime,omitempty"`
+}
+
+// BootstrapCredential the credential shared by the devices registering as nodes of the group of the activation token
+type BootstrapCredential struct {
+	Token      string    `json:"token"`
+	NodeGroup  string    `json:"nodeGroup"`
+	ExpireTime time.Time `json:"expireTime"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// ObjectManifestKey the metadata key of the config object which carries the manifest in json
+const ObjectManifestKey = "manifest"
+
+// ObjectManifest the chunks of the large object of config, with which node downloads the object by byte ranges and
+// resumes from the chunks verified after failure instead of from zero. RateLimit is the max bytes per second the node
+// downloads at, unlimited if 0. Peers are the urls of the object on the seed nodes of site, which node tries in order
+// before the url of object storage, the chunks from peers are verified the same way
+type ObjectManifest struct {
+	Size      int64         `json:"size"`
+	ETag      string        `json:"etag,omitempty"`
+	ChunkSize int64         `json:"chunkSize"`
+	Chunks    []ObjectChunk `json:"chunks"`
+	RateLimit int64         `json:"rateLimit,omitempty"`
+	Peers     []string      `json:"peers,omitempty"`
+}
+
+// ObjectChunk the byte range [Offset, Offset+Size) of object with the sha256 in hex of its content
+type ObjectChunk struct {
+	Offset int64  `json:"offset"`
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256"`
+}
+
+// SiteSeed the seed node serving the large objects to the nodes in its site at Address, such as http://192.168.1.10:8090.
+// The object is served at Address/objects/{etag}, see ObjectManifest
+type SiteSeed struct {
+	Site    string `json:"site,omitempty"`
+	Address string `json:"address"`
+}
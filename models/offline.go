@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+)
+
+// The kinds signed of the offline bundle and report, see SyncSignature
+const (
+	OfflineBundleKind = "offlineBundle"
+	OfflineReportKind = "offlineReport"
+)
+
+// OfflineBundle the full desired state of node, which is carried to the air-gapped node and applied by baetyl-init.
+// It's signed by the sync key of node with Signature unset. The secrets are in plain, so the bundle should be carried
+// as carefully as the node certificate
+type OfflineBundle struct {
+	Namespace  string                 `json:"namespace"`
+	Node       string                 `json:"node"`
+	CreateTime time.Time              `json:"createTime"`
+	Desire     specV1.Desire          `json:"desire"`
+	Values     []specV1.ResourceValue `json:"values"`
+	// Objects the objects referenced by the configs, which are downloaded with the bundle
+	Objects   []OfflineBundleObject `json:"objects,omitempty"`
+	Signature *SyncSignature        `json:"signature,omitempty"`
+}
+
+// OfflineBundleObject the object referenced by the item of config
+type OfflineBundleObject struct {
+	Config string `json:"config"`
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	MD5    string `json:"md5,omitempty"`
+	Unpack string `json:"unpack,omitempty"`
+}
+
+// OfflineReport the report of the air-gapped node applying the bundle, which is signed with Signature unset too
+type OfflineReport struct {
+	Namespace  string         `json:"namespace"`
+	Node       string         `json:"node"`
+	CreateTime time.Time      `json:"createTime"`
+	Report     specV1.Report  `json:"report"`
+	Signature  *SyncSignature `json:"signature,omitempty"`
+}
+
+// OfflineReportImport the result of importing the offline report, Delta is the desire not yet applied by node
+type OfflineReportImport struct {
+	Delta specV1.Delta `json:"delta"`
+}
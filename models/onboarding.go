@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// The steps of onboarding in order, the node is activated once it fetches the resources carrying its certificate,
+// the others are reported by node except the first report, which is recorded by sync server as well
+const (
+	OnboardingActivated   = "activated"
+	OnboardingDownloaded  = "downloaded"
+	OnboardingCoreStarted = "coreStarted"
+	OnboardingFirstReport = "firstReport"
+)
+
+// OnboardingSteps the steps of onboarding in order
+var OnboardingSteps = []string{OnboardingActivated, OnboardingDownloaded, OnboardingCoreStarted, OnboardingFirstReport}
+
+const (
+	OnboardingSucceeded = "succeeded"
+	OnboardingFailed    = "failed"
+)
+
+// OnboardingStep the status of a step of onboarding, the reason tells why the step failed
+type OnboardingStep struct {
+	Name   string    `json:"name" validate:"oneof=activated downloaded coreStarted firstReport"`
+	Status string    `json:"status" validate:"oneof=succeeded failed"`
+	Reason string    `json:"reason,omitempty" validate:"max=1024"`
+	Time   time.Time `json:"time,omitempty"`
+}
+
+// NodeOnboarding the steps of node onboarding recorded since its last activation. Stalled is the first step not
+// succeeded yet, which is empty once the onboarding is completed
+type NodeOnboarding struct {
+	Steps     []OnboardingStep `json:"steps"`
+	Completed bool             `json:"completed"`
+	Stalled   string           `json:"stalled,omitempty"`
+	Reason    string           `json:"reason,omitempty"`
+}
+
+// OnboardingReport the step of onboarding reported by node, which presents the token it's installed with
+type OnboardingReport struct {
+	Token string `json:"token" validate:"required"`
+	OnboardingStep
+}
+
+// Step returns the step recorded by name, which is nil if not recorded
+func (o *NodeOnboarding) Step(name string) *OnboardingStep {
+	if o == nil {
+		return nil
+	}
+	for i := range o.Steps {
+		if o.Steps[i].Name == name {
+			return &o.Steps[i]
+		}
+	}
+	return nil
+}
+
+// Evaluate sets the stalled step and the completion by the steps recorded
+func (o *NodeOnboarding) Evaluate() {
+	o.Completed, o.Stalled, o.Reason = false, "", ""
+	for _, name := range OnboardingSteps {
+		step := o.Step(name)
+		if step == nil || step.Status != OnboardingSucceeded {
+			o.Stalled = name
+			if step != nil {
+				o.Reason = step.Reason
+			}
+			return
+		}
+	}
+	o.Completed = true
+}
@@ -0,0 +1,86 @@
+package models
+
+import "time"
+
+// PlatformStats the aggregate of the resources of all namespaces computed in background for the ops dashboard.
+// Elapsed is the milliseconds it took to compute, and the errors tell the parts failed, such as the namespaces
+type PlatformStats struct {
+	Namespaces   int              `json:"namespaces"`
+	Nodes        NodeStateStats   `json:"nodes"`
+	Apps         AppModeStats     `json:"apps"`
+	Traffic      SyncTrafficStats `json:"traffic"`
+	Database     []TableSize      `json:"database"`
+	Certificates CertExpiryStats  `json:"certificates"`
+	Errors       []string         `json:"errors,omitempty"`
+	Time         time.Time        `json:"time"`
+	Elapsed      int64            `json:"elapsed"`
+}
+
+// NodeStateStats the nodes by state, the inactive ones never report. The cordoned ones are counted in their states too
+type NodeStateStats struct {
+	Total    int `json:"total"`
+	Online   int `json:"online"`
+	Offline  int `json:"offline"`
+	Inactive int `json:"inactive"`
+	Cordoned int `json:"cordoned"`
+}
+
+// AppModeStats the apps by mode, such as kube and native, the system apps are counted in their modes too
+type AppModeStats struct {
+	Total  int            `json:"total"`
+	System int            `json:"system"`
+	Modes  map[string]int `json:"modes"`
+}
+
+// SyncTrafficStats the sync exchanges of all nodes since the time by kind, such as report and desire
+type SyncTrafficStats struct {
+	Since time.Time     `json:"since"`
+	Items []SyncTraffic `json:"items"`
+}
+
+// SyncTraffic the sum of the sync exchanges of a kind, the sizes are in bytes
+type SyncTraffic struct {
+	Kind          string `json:"kind"`
+	Total         int64  `json:"total"`
+	Failures      int64  `json:"failures"`
+	RequestBytes  int64  `json:"requestBytes"`
+	ResponseBytes int64  `json:"responseBytes"`
+}
+
+// TableSize the size of a table of database, the rows are estimated by database
+type TableSize struct {
+	Name       string `json:"name"`
+	Rows       int64  `json:"rows"`
+	DataBytes  int64  `json:"dataBytes"`
+	IndexBytes int64  `json:"indexBytes"`
+}
+
+// CertExpiryStats the node certificates by the time left before they expire, each certificate is counted in the
+// first bucket it falls into
+type CertExpiryStats struct {
+	Total     int `json:"total"`
+	Expired   int `json:"expired"`
+	Within7d  int `json:"within7d"`
+	Within30d int `json:"within30d"`
+	Within90d int `json:"within90d"`
+	Later     int `json:"later"`
+}
+
+// Count counts the certificate expiring at the time
+func (s *CertExpiryStats) Count(notAfter, now time.Time) {
+	s.Total++
+	left := notAfter.Sub(now)
+	day := 24 * time.Hour
+	switch {
+	case left <= 0:
+		s.Expired++
+	case left <= 7*day:
+		s.Within7d++
+	case left <= 30*day:
+		s.Within30d++
+	case left <= 90*day:
+		s.Within90d++
+	default:
+		s.Later++
+	}
+}
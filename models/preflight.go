@@ -0,0 +1,94 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HostFacts the facts of the host collected by the install script before activation, DiskFree is the free disk
+// space in megabytes of the data path of node
+type HostFacts struct {
+	OS           string `json:"os,omitempty" validate:"max=128"`
+	Kernel       string `json:"kernel,omitempty" validate:"max=128"`
+	Arch         string `json:"arch,omitempty" validate:"max=32"`
+	CgroupDriver string `json:"cgroupDriver,omitempty" validate:"max=32"`
+	DiskFree     int64  `json:"diskFree,omitempty" validate:"min=0"`
+}
+
+// PreflightReport the facts of host reported by the install script, which presents the token it's installed with
+type PreflightReport struct {
+	Token string `json:"token" validate:"required"`
+	HostFacts
+}
+
+// NodePreflight the result of the latest preflight checks of the host of node. The host is passed if no errors,
+// the warnings tell the facts not collected. Refused is set once the host failed and the checks are enforced
+type NodePreflight struct {
+	Facts    HostFacts `json:"facts"`
+	Passed   bool      `json:"passed"`
+	Refused  bool      `json:"refused"`
+	Errors   []string  `json:"errors,omitempty"`
+	Warnings []string  `json:"warnings,omitempty"`
+	Time     time.Time `json:"time,omitempty"`
+}
+
+var hostArches = map[string]string{
+	"x86_64":  "amd64",
+	"aarch64": "arm64",
+	"armv6l":  "arm",
+	"armv7l":  "arm",
+	"i386":    "386",
+	"i686":    "386",
+}
+
+// Normalize trims the facts and converts the machine hardware name of uname to the architecture of go
+func (f *HostFacts) Normalize() {
+	f.OS = strings.TrimSpace(f.OS)
+	f.Kernel = strings.TrimSpace(f.Kernel)
+	f.Arch = strings.ToLower(strings.TrimSpace(f.Arch))
+	if arch, ok := hostArches[f.Arch]; ok {
+		f.Arch = arch
+	}
+	f.CgroupDriver = strings.ToLower(strings.TrimSpace(f.CgroupDriver))
+}
+
+// CompareKernelVersion compares the leading numbers of kernel versions, such as 5.4 of 5.4.0-42-generic, and
+// returns -1, 0 or 1. It returns false if either is not a version
+func CompareKernelVersion(a, b string) (int, bool) {
+	va, vb := parseKernelVersion(a), parseKernelVersion(b)
+	if va == nil || vb == nil {
+		return 0, false
+	}
+	for i := 0; i < len(va) || i < len(vb); i++ {
+		var x, y int
+		if i < len(va) {
+			x = va[i]
+		}
+		if i < len(vb) {
+			y = vb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+func parseKernelVersion(v string) []int {
+	if i := strings.IndexFunc(v, func(r rune) bool { return r != '.' && (r < '0' || r > '9') }); i >= 0 {
+		v = v[:i]
+	}
+	var res []int
+	for _, part := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		res = append(res, n)
+	}
+	return res
+}
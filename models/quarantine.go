@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+)
+
+// The types of resources quarantined
+const (
+	QuarantineApp   = "app"
+	QuarantineImage = "image"
+
+	QuarantineEventPaused = "app-quarantined"
+)
+
+// Quarantine the resource quarantined platform-wide by operators, such as a vulnerable image. The apps affected are
+// paused once it's created, and the deployments referencing it are rejected until it's lifted
+type Quarantine struct {
+	Name string `json:"name,omitempty" validate:"resourceName"`
+	Type string `json:"type" validate:"oneof=app image"`
+	// Namespace and App the application quarantined if the type is app
+	Namespace string `json:"namespace,omitempty"`
+	App       string `json:"app,omitempty"`
+	// Image the image quarantined if the type is image, such as nginx, nginx:1.21 or nginx@sha256:..., all tags of
+	// image are quarantined if it has none
+	Image      string    `json:"image,omitempty"`
+	Reason     string    `json:"reason" validate:"required,max=512"`
+	Operator   string    `json:"operator,omitempty"`
+	CreateTime time.Time `json:"createTime,omitempty"`
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
+
+// QuarantineResult the quarantine created along with the apps paused by it
+type QuarantineResult struct {
+	Quarantine *Quarantine `json:"quarantine"`
+	// Paused the apps paused, such as default/nginx
+	Paused []string `json:"paused"`
+	// Failures the errors of the apps failed to pause, keyed by the apps or the namespaces failed to list
+	Failures map[string]string `json:"failures,omitempty"`
+}
+
+// QuarantineEvent the event posted to the webhook of namespace once its app is paused by quarantine
+type QuarantineEvent struct {
+	Namespace  string    `json:"namespace"`
+	App        string    `json:"app"`
+	Quarantine string    `json:"quarantine"`
+	Type       string    `json:"type"`
+	Reason     string    `json:"reason"`
+	Images     []string  `json:"images,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// Targets returns true if the quarantine is set on the app, the image ones are matched by the caller
+func (q *Quarantine) Targets(namespace, app string) bool {
+	return q.Type == QuarantineApp && q.Namespace == namespace && q.App == app
+}
+
+// Fail records the error of the app or namespace failed to pause
+func (r *QuarantineResult) Fail(key, msg string) {
+	if r.Failures == nil {
+		r.Failures = map[string]string{}
+	}
+	r.Failures[key] = msg
+}
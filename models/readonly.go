@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// ReadOnly the read-only mode of platform, the tenant-facing APIs refuse to mutate resources while it's enabled
+type ReadOnly struct {
+	Enabled    bool      `json:"enabled"`
+	Reason     string    `json:"reason,omitempty"`
+	Operator   string    `json:"operator,omitempty"`
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// ResourceReferences the applications consuming the config or secret, which is not deleted until they release it
+type ResourceReferences struct {
+	Type  string              `json:"type,omitempty"`
+	Name  string              `json:"name,omitempty"`
+	Total int                 `json:"total"`
+	Items []ResourceReference `json:"items"`
+}
+
+// ResourceReference the application mounting the resource by its volumes
+type ResourceReference struct {
+	App     string   `json:"app"`
+	Version string   `json:"version,omitempty"`
+	System  bool     `json:"system,omitempty"`
+	Volumes []string `json:"volumes,omitempty"`
+}
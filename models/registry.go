@@ -7,23 +7,55 @@ import (
 
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 	"github.com/jinzhu/copier"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+const (
+	// RegistryTypeContainer the registry of container images, whose credential is used by nodes to pull images
+	RegistryTypeContainer = "container"
+	// RegistryTypeOCI the registry of oci artifacts, such as the charts and models pushed by oras
+	RegistryTypeOCI = "oci"
+	// RegistryTypeHelm the chart repository of helm serving index.yaml, whose address is the url of repository
+	RegistryTypeHelm = "helm"
 )
 
 // Registry Registry
 type Registry struct {
-	Name              string    `json:"name,omitempty" validate:"omitempty,resourceName"`
-	Namespace         string    `json:"namespace,omitempty"`
-	Address           string    `json:"address"`
-	Username          string    `json:"username"`
-	Password          string    `json:"password,omitempty"`
+	Name      string `json:"name,omitempty" validate:"omitempty,resourceName"`
+	Namespace string `json:"namespace,omitempty"`
+	// Type the type of registry, such as container, oci and helm, the registry is a container registry if empty
+	Type     string `json:"type,omitempty"`
+	Address  string `json:"address"`
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+	// Token the bearer token of oci registry used instead of the username and password
+	Token             string    `json:"token,omitempty"`
 	CreationTimestamp time.Time `json:"createTime,omitempty"`
 	UpdateTimestamp   time.Time `json:"updateTime,omitempty"`
 	Description       string    `json:"description"`
 	Version           string    `json:"version,omitempty"`
+	// Verification the result of logging in to the registry, which is returned if verification is requested
+	Verification *RegistryVerification `json:"verification,omitempty"`
+}
+
+// RegistryVerification the result of logging in to the registry with the credential, the manifest of Image is
+// checked too if set. Reachable is false if the registry can not be connected or is not a docker registry
+type RegistryVerification struct {
+	Reachable     bool   `json:"reachable"`
+	Authenticated bool   `json:"authenticated"`
+	Image         string `json:"image,omitempty"`
+	// ImageFound whether the manifest of image is found, nil if the image is not checked
+	ImageFound *bool `json:"imageFound,omitempty"`
+	// Status the http status of the last request to the registry
+	Status  int       `json:"status,omitempty"`
+	Message string    `json:"message,omitempty"`
+	Time    time.Time `json:"time"`
 }
 
 type RegistryView struct {
 	Name     string `json:"name,omitempty"`
+	Type     string `json:"type,omitempty"`
 	Address  string `json:"address,omitempty"`
 	Username string `json:"username,omitempty"`
 }
@@ -36,9 +68,11 @@ type RegistryList struct {
 }
 
 func (r *Registry) Equal(target *Registry) bool {
-	return reflect.DeepEqual(r.Address, target.Address) &&
+	return reflect.DeepEqual(r.Type, target.Type) &&
+		reflect.DeepEqual(r.Address, target.Address) &&
 		reflect.DeepEqual(r.Username, target.Username) &&
 		reflect.DeepEqual(r.Password, target.Password) &&
+		reflect.DeepEqual(r.Token, target.Token) &&
 		reflect.DeepEqual(r.Description, target.Description)
 }
 
@@ -57,5 +91,13 @@ func (r *Registry) ToSecret() *specV1.Secret {
 		"username": []byte(r.Username),
 		"address":  []byte(r.Address),
 	}
+	// the container registries keep the data and labels as before, so that the old ones are the same
+	if r.Type != "" && r.Type != RegistryTypeContainer {
+		res.Labels[common.LabelRegistryType] = r.Type
+		res.Data["type"] = []byte(r.Type)
+	}
+	if r.Token != "" {
+		res.Data["token"] = []byte(r.Token)
+	}
 	return res
 }
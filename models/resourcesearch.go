@@ -0,0 +1,61 @@
+package models
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// The kinds of resources searched across namespaces
+const (
+	SearchKindNode   = "node"
+	SearchKindApp    = "app"
+	SearchKindConfig = "config"
+)
+
+// ResourceSearch the query of resources across namespaces. The name matches the names containing it, or the ones
+// matching it as a glob if it has *. The image matches the apps running it, all tags of image are matched if it has
+// none, only the apps are searched by image
+type ResourceSearch struct {
+	Kind          string `form:"kind,omitempty" json:"kind,omitempty" validate:"omitempty,oneof=node app config"`
+	LabelSelector string `form:"selector,omitempty" json:"selector,omitempty"`
+	Image         string `form:"image,omitempty" json:"image,omitempty"`
+	Filter        `json:",inline"`
+}
+
+// ResourceSearchItem the resource found, Images are the ones of app matching the image searched
+type ResourceSearchItem struct {
+	Kind              string            `json:"kind"`
+	Namespace         string            `json:"namespace"`
+	Name              string            `json:"name"`
+	Version           string            `json:"version,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	Images            []string          `json:"images,omitempty"`
+	CreationTimestamp time.Time         `json:"createTime,omitempty"`
+}
+
+// ResourceSearchList the page of resources found, which are ordered by namespace, kind and name
+type ResourceSearchList struct {
+	Total int                  `json:"total"`
+	Items []ResourceSearchItem `json:"items"`
+}
+
+// Searches returns whether the resources of kind are searched
+func (s *ResourceSearch) Searches(kind string) bool {
+	if s.Image != "" && kind != SearchKindApp {
+		return false
+	}
+	return s.Kind == "" || s.Kind == kind
+}
+
+// MatchName returns whether the name matches the one searched
+func (s *ResourceSearch) MatchName(name string) bool {
+	if s.Name == "" {
+		return true
+	}
+	if strings.Contains(s.Name, "*") {
+		ok, err := path.Match(s.Name, name)
+		return err == nil && ok
+	}
+	return strings.Contains(name, s.Name)
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	RolloutProgressing = "progressing"
+	RolloutPaused      = "paused"
+	RolloutCompleted   = "completed"
+)
+
+// RolloutStrategy releases an application version to the matched nodes stage by stage instead of all at once,
+// the next stage starts after BakeTime if the released nodes are healthy
+type RolloutStrategy struct {
+	Stages []RolloutStage `json:"stages" validate:"required,min=1,dive"`
+	// BakeTime the time to wait after a stage is released before starting the next one
+	BakeTime string `json:"bakeTime,omitempty" validate:"omitempty,duration"`
+	// PauseOnFailure pauses the rollout once more than MaxFailures released nodes report the app failed
+	PauseOnFailure bool `json:"pauseOnFailure,omitempty"`
+	MaxFailures    int  `json:"maxFailures,omitempty" validate:"min=0"`
+}
+
+// RolloutStage the nodes released in a stage, which are Percent of the matched nodes or the members of NodeGroup
+type RolloutStage struct {
+	Percent   int    `json:"percent,omitempty" validate:"omitempty,min=1,max=100"`
+	NodeGroup string `json:"nodeGroup,omitempty"`
+}
+
+// Rollout the progress of the staged rollout of application, the nodes not in Nodes keep the app they run
+// until they are released or the rollout is completed
+type Rollout struct {
+	Namespace string          `json:"namespace"`
+	App       string          `json:"app"`
+	Version   string          `json:"version"`
+	Strategy  RolloutStrategy `json:"strategy"`
+	// Stage the index of the current stage
+	Stage  int    `json:"stage"`
+	Status string `json:"status"`
+	// Nodes the nodes released so far
+	Nodes []string `json:"nodes"`
+	// Failed the released nodes reporting the app failed at the last check
+	Failed     []string  `json:"failed,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	StageTime  time.Time `json:"stageTime,omitempty"`
+	CreateTime time.Time `json:"createTime,omitempty"`
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
+
+// Holds returns whether the app is withheld from the node by the rollout
+func (r *Rollout) Holds(node string) bool {
+	if r.Status == RolloutCompleted {
+		return false
+	}
+	for _, n := range r.Nodes {
+		if n == node {
+			return false
+		}
+	}
+	return true
+}
@@ -1,12 +1,15 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"time"
 
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 	"github.com/jinzhu/copier"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
 )
 
 type SecretList struct {
@@ -33,6 +36,13 @@ func FromSecretToRegistry(s *specV1.Secret, needToFilter bool) *Registry {
 	if v, ok := s.Data["username"]; ok {
 		res.Username = string(v)
 	}
+	if v, ok := s.Data["token"]; ok {
+		res.Token = string(v)
+	}
+	res.Type = RegistryTypeContainer
+	if v, ok := s.Data["type"]; ok && len(v) > 0 {
+		res.Type = string(v)
+	}
 	return res
 }
 
@@ -111,15 +121,31 @@ func FromSecretListToCertificateList(s *SecretList, needToFilter bool) *Certific
 type SecretView struct {
 	Name              string            `json:"name,omitempty" validate:"omitempty,resourceName"`
 	Namespace         string            `json:"namespace,omitempty"`
-	Data              map[string]string `json:"data,omitempty" binding:"required"`
+	Data              map[string]string `json:"data,omitempty"`
 	CreationTimestamp time.Time         `json:"createTime,omitempty"`
 	UpdateTimestamp   time.Time         `json:"updateTime,omitempty"`
 	Description       string            `json:"description"`
 	Version           string            `json:"version,omitempty"`
+	// Rotation the expiry and rotation policy of secret, it is kept as it is if not set on update
+	Rotation *SecretRotation `json:"rotation,omitempty"`
+	// External the reference to the external secret store, the secret has no data in cloud if set
+	External *SecretExternal `json:"external,omitempty"`
+}
+
+// SecretExternal the reference to the secret in the external store, such as vault and aws secrets manager,
+// which is resolved by Provider when the secret is synchronized to nodes
+type SecretExternal struct {
+	Provider string `json:"provider" validate:"required"`
+	Path     string `json:"path" validate:"required"`
+	// Version the version of secret in store, the latest one is used if empty
+	Version string `json:"version,omitempty"`
+	// Keys the keys of secret delivered to nodes, all keys are delivered if empty
+	Keys []string `json:"keys,omitempty"`
 }
 
 func (s *SecretView) Equal(target *SecretView) bool {
 	return reflect.DeepEqual(s.Data, target.Data) &&
+		reflect.DeepEqual(s.External, target.External) &&
 		reflect.DeepEqual(s.Description, target.Description)
 }
 
@@ -143,6 +169,13 @@ func (s *SecretView) ToSecret() *specV1.Secret {
 	for k, v := range s.Data {
 		res.Data[k] = []byte(v)
 	}
+	if s.External != nil {
+		data, err := json.Marshal(s.External)
+		if err != nil {
+			panic(fmt.Sprintf("json exception: %s", err.Error()))
+		}
+		res.Data = map[string][]byte{common.SecretExternalKey: data}
+	}
 	return res
 }
 
@@ -159,9 +192,23 @@ func FromSecretToView(s *specV1.Secret, needToFilter bool) *SecretView {
 	for k, v := range s.Data {
 		res.Data[k] = string(v)
 	}
+	// the reference which fails to decode is kept as the data, so that it can be fixed by update
+	if v, ok := res.Data[common.SecretExternalKey]; ok {
+		external := new(SecretExternal)
+		if err := json.Unmarshal([]byte(v), external); err == nil {
+			res.External = external
+			res.Data = map[string]string{}
+		}
+	}
 	return res
 }
 
+// IsExternalSecret returns whether the secret only stores the reference to the external secret store
+func IsExternalSecret(s *specV1.Secret) bool {
+	_, ok := s.Data[common.SecretExternalKey]
+	return ok
+}
+
 func FromSecretListToView(s *SecretList, needToFilter bool) *SecretViewList {
 	res := &SecretViewList{
 		Total:       s.Total,
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	SecretRotationActive   = "active"
+	SecretRotationExpiring = "expiring"
+	SecretRotationExpired  = "expired"
+
+	SecretEventExpiring = "secret-expiring"
+	SecretEventExpired  = "secret-expired"
+	SecretEventRotated  = "secret-rotated"
+)
+
+// SecretRotation the expiry and rotation policy of secret. The secret is rotated by Rotator before it expires,
+// and valid for Interval after each rotation. It is only flagged expiring or expired if no rotator is set
+type SecretRotation struct {
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Rotator   string    `json:"rotator,omitempty"`
+	// Interval the validity of the data generated by rotation, such as 720h
+	Interval string `json:"interval,omitempty" validate:"omitempty,duration"`
+	// Keys the keys of secret rotated, all keys are rotated if empty
+	Keys []string `json:"keys,omitempty"`
+	// Webhook receives the events of secret, such as the rotation, the dependent apps are updated by cloud
+	Webhook    string    `json:"webhook,omitempty" validate:"omitempty,url"`
+	Status     string    `json:"status,omitempty"`
+	RotateTime time.Time `json:"rotateTime,omitempty"`
+	CreateTime time.Time `json:"createTime,omitempty"`
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
+
+// SecretEvent the event posted to the webhook of secret rotation
+type SecretEvent struct {
+	Namespace string    `json:"namespace"`
+	Secret    string    `json:"secret"`
+	Type      string    `json:"type"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Time      time.Time `json:"time"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// ServiceRecord the discovery record of a service of application derived from its ports, the modules on node
+// address the service by the stable host of record instead of the container name
+type ServiceRecord struct {
+	Namespace string              `json:"namespace,omitempty"`
+	App       string              `json:"app"`
+	Service   string              `json:"service"`
+	Host      string              `json:"host"`
+	Ports     []ServiceRecordPort `json:"ports"`
+	// Nodes the nodes which the app is deployed to, only listed for the console
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// ServiceRecordPort the port of service, the host port and node port are set if the service exposes them on node
+type ServiceRecordPort struct {
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol,omitempty"`
+	HostPort int32  `json:"hostPort,omitempty"`
+	NodePort int32  `json:"nodePort,omitempty"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+)
+
+// SidecarPolicy the container and volumes injected into the applications of namespace selected by labels,
+// they are rendered into the applications when delivered to nodes
+type SidecarPolicy struct {
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty" validate:"resourceName"`
+	Description string `json:"description,omitempty"`
+	// Selector the label selector of applications, all applications are selected if empty
+	Selector   string          `json:"selector,omitempty"`
+	Sidecar    specV1.Service  `json:"sidecar"`
+	Volumes    []specV1.Volume `json:"volumes,omitempty"`
+	CreateTime time.Time       `json:"createTime,omitempty"`
+	UpdateTime time.Time       `json:"updateTime,omitempty"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+// SyncRecord the metadata of a sync exchange between node and cloud. Latency is in milliseconds, and Error is empty
+// if the exchange succeeds
+type SyncRecord struct {
+	Namespace     string    `json:"namespace,omitempty"`
+	Name          string    `json:"name,omitempty"`
+	Kind          string    `json:"kind"`
+	Time          time.Time `json:"time"`
+	RequestSize   int       `json:"requestSize"`
+	ResponseSize  int       `json:"responseSize"`
+	Latency       int64     `json:"latency"`
+	Protocol      int       `json:"protocol"`
+	ClientVersion string    `json:"clientVersion,omitempty"`
+	ClientIP      string    `json:"clientIP,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// SyncHistory the latest sync records of node, the latest first, with the stats of them
+type SyncHistory struct {
+	Items []SyncRecord `json:"items"`
+	Stats SyncStats    `json:"stats"`
+}
+
+// SyncStats the aggregate of the sync records of node, the latencies are in milliseconds and the sizes in bytes
+type SyncStats struct {
+	Total           int            `json:"total"`
+	Failures        int            `json:"failures"`
+	Kinds           map[string]int `json:"kinds,omitempty"`
+	LastContact     time.Time      `json:"lastContact,omitempty"`
+	LastSuccess     time.Time      `json:"lastSuccess,omitempty"`
+	LastError       string         `json:"lastError,omitempty"`
+	AvgLatency      int64          `json:"avgLatency"`
+	MaxLatency      int64          `json:"maxLatency"`
+	AvgRequestSize  int            `json:"avgRequestSize"`
+	AvgResponseSize int            `json:"avgResponseSize"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// SyncInterval the intervals node reports its status and polls its desire at, which are delivered to node in the
+// response of report. Each sync is delayed by a random time up to Jitter, so that the nodes started together spread
+// their syncs. The fields not set fall back to the settings of node
+type SyncInterval struct {
+	Report string `json:"report,omitempty" validate:"omitempty,duration"`
+	Desire string `json:"desire,omitempty" validate:"omitempty,duration"`
+	Jitter string `json:"jitter,omitempty" validate:"omitempty,duration"`
+}
+
+// NodeSyncInterval the sync intervals in effect for node, which are set on node or inherited from Group
+type NodeSyncInterval struct {
+	Interval *SyncInterval `json:"interval,omitempty"`
+	Group    string        `json:"group,omitempty"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"strconv"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+)
+
+// SyncProtocolVersion the metadata of sync messages carrying the protocol version, which is the header of http requests
+// and responses. Node sends the latest version it speaks, and cloud replies with the version negotiated, which is the
+// lower one of node and cloud. The messages without it are of version 1, which are sent by the nodes before versioning
+const SyncProtocolVersion = "baetyl-sync-protocol"
+
+// The versions of sync protocol, cloud speaks the nodes in at least the two versions before the current one
+const (
+	// SyncProtocolV1 the report and desire
+	SyncProtocolV1 = 1
+	// SyncProtocolV2 the desire patches, desire watch and exec sessions
+	SyncProtocolV2 = 2
+	// SyncProtocolV3 the signed messages and the manifests of large config objects
+	SyncProtocolV3 = 3
+
+	SyncProtocolCurrent = SyncProtocolV3
+	SyncProtocolMinimum = SyncProtocolCurrent - 2
+)
+
+// NodeSyncProtocol the protocol version node speaks, which is recorded on each report. Version is 0 if node never
+// reports, Negotiated is the version cloud speaks to it
+type NodeSyncProtocol struct {
+	Version    int  `json:"version"`
+	Negotiated int  `json:"negotiated"`
+	Current    int  `json:"current"`
+	Minimum    int  `json:"minimum"`
+	Supported  bool `json:"supported"`
+}
+
+// SyncProtocolFromMetadata returns the protocol version in the metadata of message, which is 1 if not set
+func SyncProtocolFromMetadata(md map[string]string) (int, error) {
+	v, ok := md[SyncProtocolVersion]
+	if !ok || v == "" {
+		return SyncProtocolV1, nil
+	}
+	version, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return version, nil
+}
+
+// NegotiateSyncProtocol returns the version cloud speaks to the node speaking version
+func NegotiateSyncProtocol(version int) int {
+	if version > SyncProtocolCurrent {
+		return SyncProtocolCurrent
+	}
+	return version
+}
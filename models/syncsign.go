@@ -0,0 +1,61 @@
+package models
+
+import (
+	"strconv"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+)
+
+// The metadata of sync messages carrying the signature, which are the headers of http requests and responses.
+// The signature is the HMAC-SHA256 in hex by the sync key of node, over the lines joined by "\n" of the message kind,
+// the namespace, the node name, the timestamp, the nonce and the sha256 in hex of the canonical json of content,
+// see DesirePatchInfo for the canonical json
+const (
+	SyncSignKeyID     = "baetyl-sync-key-id"
+	SyncSignTimestamp = "baetyl-sync-timestamp"
+	SyncSignNonce     = "baetyl-sync-nonce"
+	SyncSignSignature = "baetyl-sync-signature"
+)
+
+// The data keys of the sync keys in the certificate secret of node. The previous key is kept after rotation
+// until the node synchronizes the new one
+const (
+	SyncKey         = "sync.key"
+	SyncKeyPrevious = "sync-prev.key"
+)
+
+// SyncSignature the signature of sync message. KeyID is the first 8 bytes in hex of the sha256 of the key signing,
+// Timestamp is the unix time in seconds. The reply is signed by the same key with the nonce of request, so that
+// node rejects the replies replayed
+type SyncSignature struct {
+	KeyID     string `json:"keyId"`
+	Timestamp int64  `json:"timestamp"`
+	Nonce     string `json:"nonce"`
+	Signature string `json:"signature"`
+}
+
+// SyncSignatureFromMetadata returns the signature in the metadata of message, which is nil if the message is not signed
+func SyncSignatureFromMetadata(md map[string]string) (*SyncSignature, error) {
+	sig, ok := md[SyncSignSignature]
+	if !ok || sig == "" {
+		return nil, nil
+	}
+	ts, err := strconv.ParseInt(md[SyncSignTimestamp], 10, 64)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SyncSignature{
+		KeyID:     md[SyncSignKeyID],
+		Timestamp: ts,
+		Nonce:     md[SyncSignNonce],
+		Signature: sig,
+	}, nil
+}
+
+// ToMetadata sets the signature in the metadata of message
+func (s *SyncSignature) ToMetadata(md map[string]string) {
+	md[SyncSignKeyID] = s.KeyID
+	md[SyncSignTimestamp] = strconv.FormatInt(s.Timestamp, 10)
+	md[SyncSignNonce] = s.Nonce
+	md[SyncSignSignature] = s.Signature
+}
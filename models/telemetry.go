@@ -0,0 +1,15 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Telemetry the opaque telemetry section of a node report, which is forwarded to the sinks as it is without
+// being stored in the shadow of node
+type Telemetry struct {
+	Namespace string          `json:"namespace"`
+	Node      string          `json:"node"`
+	Time      time.Time       `json:"time"`
+	Data      json.RawMessage `json:"data"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// The status of tenants, the frozen tenants are read-only for admins
+const (
+	TenantActive = "active"
+	TenantFrozen = "frozen"
+)
+
+// Tenant the lifecycle of the tenant of namespace, the namespaces created before are active
+type Tenant struct {
+	Namespace  string    `json:"namespace" validate:"namespace"`
+	Status     string    `json:"status"`
+	Reason     string    `json:"reason,omitempty" validate:"max=512"`
+	CreateTime time.Time `json:"createTime,omitempty"`
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
+
+// TenantFreeze the reason tenant is frozen for, such as the contract expired
+type TenantFreeze struct {
+	Reason string `json:"reason,omitempty" validate:"max=512"`
+}
+
+// TenantPurge the resources of tenant removed by purge, or the ones to be removed if it's a dry run.
+// Certificates are the ids of the certificates issued for the secrets, the errors tell the ones failed to remove
+type TenantPurge struct {
+	Namespace    string   `json:"namespace"`
+	DryRun       bool     `json:"dryRun"`
+	Nodes        []string `json:"nodes"`
+	Apps         []string `json:"apps"`
+	Configs      []string `json:"configs"`
+	Secrets      []string `json:"secrets"`
+	Certificates []string `json:"certificates"`
+	Quotas       []string `json:"quotas"`
+	Errors       []string `json:"errors,omitempty"`
+}
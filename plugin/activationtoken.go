@@ -0,0 +1,23 @@
+package plugin
+
+import (
+	"io"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/activationtoken.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin ActivationToken
+
+// ActivationToken stores the tokens authorizing the activation of nodes
+type ActivationToken interface {
+	GetActivationToken(namespace, name string) (*models.ActivationToken, error)
+	ListActivationToken(namespace string) ([]models.ActivationToken, error)
+	CreateActivationToken(token *models.ActivationToken) error
+	// UseActivationToken counts a use of the token, which returns false if the token is expired or used up at the time
+	UseActivationToken(namespace, name string, t time.Time) (bool, error)
+	DeleteActivationToken(namespace, name string) error
+	// DeleteExpiredActivationToken deletes the tokens of all namespaces expired before the time
+	DeleteExpiredActivationToken(before time.Time) error
+	io.Closer
+}
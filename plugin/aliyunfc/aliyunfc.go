@@ -0,0 +1,249 @@
+package aliyunfc
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+const (
+	apiVersion = "/2016-08-15"
+	// LatestVersion the qualifier of the functions not published
+	LatestVersion = "LATEST"
+)
+
+// fcFunction imports the functions of a service of aliyun fc, the versions of functions are the ones of service.
+// The credential of config is used for all users
+type fcFunction struct {
+	cfg    CloudConfig
+	client *http.Client
+}
+
+type functionConfig struct {
+	FunctionName         string            `json:"functionName"`
+	Handler              string            `json:"handler"`
+	Runtime              string            `json:"runtime"`
+	MemorySize           int               `json:"memorySize"`
+	CodeSize             int32             `json:"codeSize"`
+	CodeChecksum         string            `json:"codeChecksum"`
+	EnvironmentVariables map[string]string `json:"environmentVariables"`
+}
+
+type listFunctionsResponse struct {
+	Functions []functionConfig `json:"functions"`
+	NextToken string           `json:"nextToken"`
+}
+
+type listVersionsResponse struct {
+	Versions []struct {
+		VersionID string `json:"versionId"`
+	} `json:"versions"`
+	NextToken string `json:"nextToken"`
+}
+
+type functionCode struct {
+	URL      string `json:"url"`
+	Checksum string `json:"checksum"`
+}
+
+type errorResponse struct {
+	ErrorCode    string `json:"ErrorCode"`
+	ErrorMessage string `json:"ErrorMessage"`
+}
+
+func init() {
+	plugin.RegisterFactory("aliyunfc", New)
+}
+
+// New New
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &fcFunction{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.AliyunFC.Timeout},
+	}, nil
+}
+
+// List lists the functions of service not published, whose versions are LATEST
+func (f *fcFunction) List(_ string) ([]models.Function, error) {
+	res := []models.Function{}
+	query := url.Values{"limit": []string{"100"}}
+	for {
+		var page listFunctionsResponse
+		if err := f.do(f.servicePath(LatestVersion)+"/functions", query, "", &page); err != nil {
+			return nil, err
+		}
+		for i := range page.Functions {
+			res = append(res, toFunction(&page.Functions[i], LatestVersion))
+		}
+		if page.NextToken == "" {
+			return res, nil
+		}
+		query.Set("nextToken", page.NextToken)
+	}
+}
+
+// ListFunctionVersions lists LATEST and the versions of service which the function is published with
+func (f *fcFunction) ListFunctionVersions(_, name string) ([]models.Function, error) {
+	latest, err := f.getConfig(name, LatestVersion)
+	if err != nil {
+		return nil, err
+	}
+	res := []models.Function{*latest}
+	query := url.Values{"limit": []string{"100"}}
+	for {
+		var page listVersionsResponse
+		if err = f.do(f.servicePath("")+"/versions", query, "", &page); err != nil {
+			return nil, err
+		}
+		for _, v := range page.Versions {
+			fn, err := f.getConfig(name, v.VersionID)
+			if err != nil {
+				// the versions of service published before the function is created
+				if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+					continue
+				}
+				return nil, err
+			}
+			res = append(res, *fn)
+		}
+		if page.NextToken == "" {
+			return res, nil
+		}
+		query.Set("nextToken", page.NextToken)
+	}
+}
+
+// Get gets the version of function with the url of its code. Fc reports the crc64 checksum of code rather than
+// sha256, the big-endian bytes of checksum encoded in base64 are taken as the digest of code instead
+func (f *fcFunction) Get(_, name, version string) (*models.Function, error) {
+	if version == "" {
+		version = LatestVersion
+	}
+	fn, err := f.getConfig(name, version)
+	if err != nil {
+		return nil, err
+	}
+	var code functionCode
+	if err = f.do(f.functionPath(name, version)+"/code", nil, name, &code); err != nil {
+		return nil, err
+	}
+	if code.URL == "" {
+		return nil, errors.Errorf("the function %s of aliyun fc has no code", name)
+	}
+	if code.Checksum != "" {
+		fn.Code.Sha256, err = checksumDigest(code.Checksum)
+		if err != nil {
+			return nil, err
+		}
+	}
+	fn.Code.Location = code.URL
+	return fn, nil
+}
+
+// Close Close
+func (f *fcFunction) Close() error {
+	return nil
+}
+
+func (f *fcFunction) getConfig(name, version string) (*models.Function, error) {
+	var conf functionConfig
+	if err := f.do(f.functionPath(name, version), nil, name, &conf); err != nil {
+		return nil, err
+	}
+	fn := toFunction(&conf, version)
+	if conf.CodeChecksum != "" {
+		var err error
+		if fn.Code.Sha256, err = checksumDigest(conf.CodeChecksum); err != nil {
+			return nil, err
+		}
+	}
+	return &fn, nil
+}
+
+func (f *fcFunction) servicePath(version string) string {
+	p := apiVersion + "/services/" + url.PathEscape(f.cfg.AliyunFC.Service)
+	if version != "" {
+		p += "." + url.PathEscape(version)
+	}
+	return p
+}
+
+func (f *fcFunction) functionPath(name, version string) string {
+	return f.servicePath(version) + "/functions/" + url.PathEscape(name)
+}
+
+func (f *fcFunction) do(path string, query url.Values, name string, out interface{}) error {
+	u := strings.TrimSuffix(f.cfg.AliyunFC.Endpoint, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	sign(req, f.cfg.AliyunFC.Ak, f.cfg.AliyunFC.Sk, time.Now())
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if resp.StatusCode/100 != 2 {
+		var e errorResponse
+		json.Unmarshal(data, &e)
+		if resp.StatusCode == http.StatusNotFound && name != "" {
+			return common.Error(common.ErrResourceNotFound, common.Field("type", "function"), common.Field("name", name))
+		}
+		return errors.Errorf("failed to request aliyun fc (%d): %s", resp.StatusCode, e.ErrorMessage)
+	}
+	return errors.Trace(json.Unmarshal(data, out))
+}
+
+func toFunction(conf *functionConfig, version string) models.Function {
+	res := models.Function{
+		Name:       conf.FunctionName,
+		Handler:    conf.Handler,
+		Version:    version,
+		Runtime:    conf.Runtime,
+		MemorySize: conf.MemorySize,
+		Code: models.FunctionCode{
+			Size: conf.CodeSize,
+		},
+	}
+	if len(conf.EnvironmentVariables) > 0 {
+		res.Env = conf.EnvironmentVariables
+	}
+	return res
+}
+
+// checksumDigest encodes the crc64 checksum in decimal the way of sha256 of the other sources
+func checksumDigest(checksum string) (string, error) {
+	v, err := strconv.ParseUint(checksum, 10, 64)
+	if err != nil {
+		return "", errors.Errorf("the checksum %s of function code is invalid", checksum)
+	}
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return base64.StdEncoding.EncodeToString(b), nil
+}
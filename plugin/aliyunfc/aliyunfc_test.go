@@ -0,0 +1,131 @@
+package aliyunfc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+var testConfiguration = map[string]interface{}{
+	"functionName":         "hello",
+	"handler":              "index.handler",
+	"runtime":              "python3",
+	"memorySize":           512,
+	"codeSize":             256,
+	"codeChecksum":         "1234567890",
+	"environmentVariables": map[string]string{"LOG_LEVEL": "debug"},
+}
+
+func TestFCFunction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date, err := time.Parse(http.TimeFormat, r.Header.Get("Date"))
+		assert.NoError(t, err)
+		expected := httptest.NewRequest(r.Method, r.URL.RequestURI(), nil)
+		expected.Header.Set("Content-Type", r.Header.Get("Content-Type"))
+		sign(expected, "ak", "sk", date)
+		assert.Equal(t, expected.Header.Get("Authorization"), r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/2016-08-15/services/demo.LATEST/functions":
+			if r.URL.Query().Get("nextToken") == "" {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"functions": []interface{}{map[string]interface{}{"functionName": "world", "runtime": "nodejs12"}},
+					"nextToken": "t1",
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"functions": []interface{}{testConfiguration}})
+		case "/2016-08-15/services/demo/versions":
+			json.NewEncoder(w).Encode(map[string]interface{}{"versions": []interface{}{
+				map[string]string{"versionId": "2"},
+				map[string]string{"versionId": "1"},
+			}})
+		case "/2016-08-15/services/demo.LATEST/functions/hello", "/2016-08-15/services/demo.2/functions/hello":
+			json.NewEncoder(w).Encode(testConfiguration)
+		case "/2016-08-15/services/demo.2/functions/hello/code":
+			json.NewEncoder(w).Encode(map[string]string{"url": "https://fc.oss.aliyuncs.com/hello.zip", "checksum": "1234567890"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"ErrorCode":"FunctionNotFound","ErrorMessage":"function not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	conf := `
+aliyunfc:
+  endpoint: ` + server.URL + `
+  service: demo
+  ak: ak
+  sk: sk
+`
+	filename := "cloud.yml"
+	err := ioutil.WriteFile(filename, []byte(conf), 0644)
+	assert.NoError(t, err)
+	defer os.Remove(filename)
+	common.SetConfFile(filename)
+
+	p, err := New()
+	assert.NoError(t, err)
+	f := p.(plugin.Function)
+	defer f.Close()
+
+	expected := models.Function{
+		Name:       "hello",
+		Handler:    "index.handler",
+		Version:    LatestVersion,
+		Runtime:    "python3",
+		Env:        map[string]string{"LOG_LEVEL": "debug"},
+		MemorySize: 512,
+		Code: models.FunctionCode{
+			Size:   256,
+			Sha256: "AAAAAEmWAtI=",
+		},
+	}
+
+	list, err := f.List("default")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Function{{Name: "world", Version: LatestVersion, Runtime: "nodejs12"}, expected}, list)
+
+	// the function is not published with the version 1 of service
+	list, err = f.ListFunctionVersions("default", "hello")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, expected, list[0])
+	assert.Equal(t, "2", list[1].Version)
+
+	res, err := f.Get("default", "hello", "2")
+	assert.NoError(t, err)
+	expected.Version = "2"
+	expected.Code.Location = "https://fc.oss.aliyuncs.com/hello.zip"
+	assert.Equal(t, &expected, res)
+
+	_, err = f.Get("default", "none", "")
+	assert.Error(t, err)
+	e, ok := err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, common.ErrResourceNotFound, e.Code())
+
+	_, err = f.ListFunctionVersions("default", "none")
+	assert.Error(t, err)
+}
+
+func TestChecksumDigest(t *testing.T) {
+	digest, err := checksumDigest("18446744073709551615")
+	assert.NoError(t, err)
+	assert.Equal(t, "//////////8=", digest)
+
+	_, err = checksumDigest("abc")
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package aliyunfc
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const fcHeaderPrefix = "x-fc-"
+
+// sign sets the date and the authorization of fc to the request, the string signed consists of the method,
+// the content headers, the date, the x-fc-* headers and the resource with its query
+func sign(req *http.Request, ak, sk string, now time.Time) {
+	date := now.UTC().Format(http.TimeFormat)
+	req.Header.Set("Date", date)
+
+	var fcHeaders []string
+	for k := range req.Header {
+		if k = strings.ToLower(k); strings.HasPrefix(k, fcHeaderPrefix) {
+			fcHeaders = append(fcHeaders, k+":"+req.Header.Get(k)+"\n")
+		}
+	}
+	sort.Strings(fcHeaders)
+
+	var params []string
+	for k, vs := range req.URL.Query() {
+		if len(vs) == 0 {
+			params = append(params, k)
+		}
+		for _, v := range vs {
+			params = append(params, k+"="+v)
+		}
+	}
+	sort.Strings(params)
+
+	stringToSign := strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		date,
+		strings.Join(fcHeaders, "") + req.URL.Path + "\n" + strings.Join(params, "\n"),
+	}, "\n")
+	h := hmac.New(sha1.New, []byte(sk))
+	h.Write([]byte(stringToSign))
+	req.Header.Set("Authorization", "FC "+ak+":"+base64.StdEncoding.EncodeToString(h.Sum(nil)))
+}
@@ -0,0 +1,16 @@
+package aliyunfc
+
+import "time"
+
+// CloudConfig baetyl-cloud config
+type CloudConfig struct {
+	AliyunFC struct {
+		// Endpoint the endpoint of the account in region, such as https://123456.cn-shanghai.fc.aliyuncs.com
+		Endpoint string `yaml:"endpoint" json:"endpoint" validate:"nonzero"`
+		// Service the service of fc whose functions are imported
+		Service string        `yaml:"service" json:"service" validate:"nonzero"`
+		Ak      string        `yaml:"ak" json:"ak" validate:"nonzero"`
+		Sk      string        `yaml:"sk" json:"sk" validate:"nonzero"`
+		Timeout time.Duration `yaml:"timeout" json:"timeout" default:"10s"`
+	} `yaml:"aliyunfc" json:"aliyunfc"`
+}
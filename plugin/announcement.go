@@ -0,0 +1,19 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/announcement.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin Announcement
+
+// Announcement stores the announcements of the platform published by operators
+type Announcement interface {
+	GetAnnouncement(name string) (*models.Announcement, error)
+	ListAnnouncement() ([]models.Announcement, error)
+	CreateAnnouncement(announcement *models.Announcement) error
+	UpdateAnnouncement(announcement *models.Announcement) error
+	DeleteAnnouncement(name string) error
+	io.Closer
+}
@@ -0,0 +1,21 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/apphistory.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin AppHistory
+
+// AppHistory stores the revisions of applications
+type AppHistory interface {
+	GetAppRevision(namespace, name string, revision int64) (*models.AppRevision, error)
+	// ListAppRevision lists the revisions of application without the spec, the latest first
+	ListAppRevision(namespace, name string) ([]models.AppRevision, error)
+	CreateAppRevision(revision *models.AppRevision) error
+	// DeleteAppRevisionBefore deletes the revisions of application older than the revision
+	DeleteAppRevisionBefore(namespace, name string, revision int64) error
+	DeleteAppRevisions(namespace, name string) error
+	io.Closer
+}
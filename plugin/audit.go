@@ -0,0 +1,18 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/audit.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin Auditor
+
+// Auditor ships the audit records to the sink
+type Auditor interface {
+	// Audit saves the sealed record
+	Audit(record *models.AuditRecord) error
+	// GetLastAuditHash returns the hash of the last record saved by the sink, empty if there is none
+	GetLastAuditHash() (string, error)
+	io.Closer
+}
@@ -0,0 +1,7 @@
+package file
+
+type CloudConfig struct {
+	FileAuditor struct {
+		Path string `yaml:"path" json:"path" default:"/var/log/baetyl-cloud/audit.log"`
+	} `yaml:"fileauditor" json:"fileauditor"`
+}
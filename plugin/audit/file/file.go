@@ -0,0 +1,110 @@
+package file
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func init() {
+	plugin.RegisterFactory("fileauditor", New)
+}
+
+// fileAuditor appends the records to a file as json lines
+type fileAuditor struct {
+	file     *os.File
+	lastHash string
+	mutex    sync.Mutex
+	log      *log.Logger
+}
+
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newFileAuditor(cfg.FileAuditor.Path)
+}
+
+func newFileAuditor(path string) (*fileAuditor, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	lastHash, err := readLastHash(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &fileAuditor{
+		file:     file,
+		lastHash: lastHash,
+		log:      log.With(log.Any("plugin", "fileauditor")),
+	}, nil
+}
+
+func readLastHash(path string) (string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var last []byte
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) > 0 {
+			last = append(last[:0], scanner.Bytes()...)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", err
+	}
+	if len(last) == 0 {
+		return "", nil
+	}
+	var record models.AuditRecord
+	if err = json.Unmarshal(last, &record); err != nil {
+		return "", err
+	}
+	return record.Hash, nil
+}
+
+func (f *fileAuditor) Audit(record *models.AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if _, err = f.file.Write(append(data, '\n')); err != nil {
+		return errors.Trace(err)
+	}
+	f.lastHash = record.Hash
+	return nil
+}
+
+func (f *fileAuditor) GetLastAuditHash() (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.lastHash, nil
+}
+
+func (f *fileAuditor) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,54 @@
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestFileAuditor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "log", "audit.log")
+
+	auditor, err := newFileAuditor(path)
+	assert.NoError(t, err)
+	hash, err := auditor.GetLastAuditHash()
+	assert.NoError(t, err)
+	assert.Equal(t, "", hash)
+
+	record := &models.AuditRecord{
+		Namespace:  "default",
+		Method:     "DELETE",
+		Path:       "/v1/apps/:name",
+		Name:       "app01",
+		CreateTime: time.Now(),
+	}
+	record.Seal(hash)
+	assert.NoError(t, auditor.Audit(record))
+	hash, err = auditor.GetLastAuditHash()
+	assert.NoError(t, err)
+	assert.Equal(t, record.Hash, hash)
+	assert.NoError(t, auditor.Close())
+
+	// the chain continues after restart
+	auditor, err = newFileAuditor(path)
+	assert.NoError(t, err)
+	defer auditor.Close()
+	hash, err = auditor.GetLastAuditHash()
+	assert.NoError(t, err)
+	assert.Equal(t, record.Hash, hash)
+
+	next := &models.AuditRecord{Namespace: "default", Method: "POST", CreateTime: time.Now()}
+	next.Seal(hash)
+	assert.Equal(t, -1, models.VerifyAuditChain([]models.AuditRecord{*record, *next}))
+	next.Method = "PUT"
+	assert.Equal(t, 1, models.VerifyAuditChain([]models.AuditRecord{*record, *next}))
+}
@@ -0,0 +1,12 @@
+package kafka
+
+import "time"
+
+type CloudConfig struct {
+	KafkaAuditor struct {
+		// Address the address of kafka rest proxy
+		Address string        `yaml:"address" json:"address" validate:"nonzero"`
+		Topic   string        `yaml:"topic" json:"topic" default:"baetyl-audit"`
+		Timeout time.Duration `yaml:"timeout" json:"timeout" default:"10s"`
+	} `yaml:"kafkaauditor" json:"kafkaauditor"`
+}
@@ -0,0 +1,89 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+const contentTypeKafkaJSON = "application/vnd.kafka.json.v2+json"
+
+func init() {
+	plugin.RegisterFactory("kafkaauditor", New)
+}
+
+// kafkaAuditor produces the records to the topic through kafka rest proxy
+type kafkaAuditor struct {
+	url      string
+	client   *http.Client
+	lastHash string
+	mutex    sync.Mutex
+	log      *log.Logger
+}
+
+type produceRequest struct {
+	Records []produceRecord `json:"records"`
+}
+
+type produceRecord struct {
+	Key   string              `json:"key,omitempty"`
+	Value *models.AuditRecord `json:"value"`
+}
+
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &kafkaAuditor{
+		url:    fmt.Sprintf("%s/topics/%s", strings.TrimSuffix(cfg.KafkaAuditor.Address, "/"), cfg.KafkaAuditor.Topic),
+		client: &http.Client{Timeout: cfg.KafkaAuditor.Timeout},
+		log:    log.With(log.Any("plugin", "kafkaauditor")),
+	}, nil
+}
+
+func (k *kafkaAuditor) Audit(record *models.AuditRecord) error {
+	// records of the same namespace go to the same partition to keep the order
+	data, err := json.Marshal(&produceRequest{
+		Records: []produceRecord{{Key: record.Namespace, Value: record}},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := k.client.Post(k.url, contentTypeKafkaJSON, bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("failed to produce audit record to kafka, status %d: %s", resp.StatusCode, string(body))
+	}
+
+	k.mutex.Lock()
+	k.lastHash = record.Hash
+	k.mutex.Unlock()
+	return nil
+}
+
+// GetLastAuditHash the topic can not be read back, so the chain starts again after restart
+func (k *kafkaAuditor) GetLastAuditHash() (string, error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	return k.lastHash, nil
+}
+
+func (k *kafkaAuditor) Close() error {
+	return nil
+}
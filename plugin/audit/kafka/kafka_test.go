@@ -0,0 +1,50 @@
+package kafka
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestKafkaAuditor(t *testing.T) {
+	var received produceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/topics/baetyl-audit", r.URL.Path)
+		assert.Equal(t, contentTypeKafkaJSON, r.Header.Get("Content-Type"))
+		data, _ := ioutil.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(data, &received))
+		if received.Records[0].Value.Name == "bad" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	auditor := &kafkaAuditor{
+		url:    server.URL + "/topics/baetyl-audit",
+		client: server.Client(),
+	}
+	record := &models.AuditRecord{Namespace: "default", Name: "node01"}
+	record.Seal("")
+	assert.NoError(t, auditor.Audit(record))
+	assert.Equal(t, "default", received.Records[0].Key)
+	assert.Equal(t, record.Hash, received.Records[0].Value.Hash)
+	hash, err := auditor.GetLastAuditHash()
+	assert.NoError(t, err)
+	assert.Equal(t, record.Hash, hash)
+
+	bad := &models.AuditRecord{Namespace: "default", Name: "bad"}
+	bad.Seal(hash)
+	assert.Error(t, auditor.Audit(bad))
+	hash, err = auditor.GetLastAuditHash()
+	assert.NoError(t, err)
+	assert.Equal(t, record.Hash, hash)
+	assert.NoError(t, auditor.Close())
+}
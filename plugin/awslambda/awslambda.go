@@ -0,0 +1,122 @@
+package awslambda
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// lambdaFunction imports the functions of aws lambda, the credential of config is used for all users
+type lambdaFunction struct {
+	client *lambda.Lambda
+}
+
+func init() {
+	plugin.RegisterFactory("awslambda", New)
+}
+
+// New New
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	awsConfig := &aws.Config{Region: aws.String(cfg.AWSLambda.Region)}
+	if cfg.AWSLambda.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.AWSLambda.Endpoint)
+	}
+	if cfg.AWSLambda.Ak != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.AWSLambda.Ak, cfg.AWSLambda.Sk, "")
+	}
+	s, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &lambdaFunction{client: lambda.New(s)}, nil
+}
+
+// List lists the latest functions, whose versions are $LATEST
+func (l *lambdaFunction) List(_ string) ([]models.Function, error) {
+	res := []models.Function{}
+	err := l.client.ListFunctionsPages(&lambda.ListFunctionsInput{}, func(out *lambda.ListFunctionsOutput, _ bool) bool {
+		for _, v := range out.Functions {
+			res = append(res, toFunction(v))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return res, nil
+}
+
+// ListFunctionVersions lists the published versions of function as well as $LATEST
+func (l *lambdaFunction) ListFunctionVersions(_, name string) ([]models.Function, error) {
+	res := []models.Function{}
+	input := &lambda.ListVersionsByFunctionInput{FunctionName: aws.String(name)}
+	err := l.client.ListVersionsByFunctionPages(input, func(out *lambda.ListVersionsByFunctionOutput, _ bool) bool {
+		for _, v := range out.Versions {
+			res = append(res, toFunction(v))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, wrapError(err, name)
+	}
+	return res, nil
+}
+
+// Get gets the version of function with the presigned url of its code, which expires in 10 minutes
+func (l *lambdaFunction) Get(_, name, version string) (*models.Function, error) {
+	input := &lambda.GetFunctionInput{FunctionName: aws.String(name)}
+	if version != "" {
+		input.Qualifier = aws.String(version)
+	}
+	out, err := l.client.GetFunction(input)
+	if err != nil {
+		return nil, wrapError(err, name)
+	}
+	if out.Configuration == nil || out.Code == nil {
+		return nil, errors.Errorf("the function %s of aws lambda has no code", name)
+	}
+	res := toFunction(out.Configuration)
+	res.Code.Location = aws.StringValue(out.Code.Location)
+	return &res, nil
+}
+
+// Close Close
+func (l *lambdaFunction) Close() error {
+	return nil
+}
+
+func toFunction(conf *lambda.FunctionConfiguration) models.Function {
+	res := models.Function{
+		Name:       aws.StringValue(conf.FunctionName),
+		Handler:    aws.StringValue(conf.Handler),
+		Version:    aws.StringValue(conf.Version),
+		Runtime:    aws.StringValue(conf.Runtime),
+		MemorySize: int(aws.Int64Value(conf.MemorySize)),
+		Code: models.FunctionCode{
+			Size:   int32(aws.Int64Value(conf.CodeSize)),
+			Sha256: aws.StringValue(conf.CodeSha256),
+		},
+	}
+	if conf.Environment != nil && len(conf.Environment.Variables) > 0 {
+		res.Env = aws.StringValueMap(conf.Environment.Variables)
+	}
+	return res
+}
+
+func wrapError(err error, name string) error {
+	if e, ok := err.(awserr.Error); ok && e.Code() == lambda.ErrCodeResourceNotFoundException {
+		return common.Error(common.ErrResourceNotFound, common.Field("type", "function"), common.Field("name", name))
+	}
+	return errors.Trace(err)
+}
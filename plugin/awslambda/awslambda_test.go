@@ -0,0 +1,111 @@
+package awslambda
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+var testConfiguration = map[string]interface{}{
+	"FunctionName": "hello",
+	"Handler":      "index.handler",
+	"Runtime":      "python3.8",
+	"Version":      "2",
+	"MemorySize":   256,
+	"CodeSize":     1024,
+	"CodeSha256":   "nwJRg4SsziinnzTflN8XBilgUzeGIUZS/mxjwnQkzM8=",
+	"Environment":  map[string]interface{}{"Variables": map[string]string{"LOG_LEVEL": "debug"}},
+}
+
+func TestLambdaFunction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/2015-03-31/functions/":
+			if r.URL.Query().Get("Marker") == "" {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"Functions":  []interface{}{map[string]interface{}{"FunctionName": "world", "Version": "$LATEST", "Runtime": "nodejs12.x"}},
+					"NextMarker": "m1",
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"Functions": []interface{}{testConfiguration}})
+		case "/2015-03-31/functions/hello/versions":
+			json.NewEncoder(w).Encode(map[string]interface{}{"Versions": []interface{}{testConfiguration}})
+		case "/2015-03-31/functions/hello":
+			assert.Equal(t, "2", r.URL.Query().Get("Qualifier"))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"Configuration": testConfiguration,
+				"Code":          map[string]string{"Location": "https://awslambda.s3.amazonaws.com/hello.zip", "RepositoryType": "S3"},
+			})
+		default:
+			w.Header().Set("X-Amzn-Errortype", "ResourceNotFoundException")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Type":"User","Message":"Function not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	conf := `
+awslambda:
+  endpoint: ` + server.URL + `
+  ak: ak
+  sk: sk
+`
+	filename := "cloud.yml"
+	err := ioutil.WriteFile(filename, []byte(conf), 0644)
+	assert.NoError(t, err)
+	defer os.Remove(filename)
+	common.SetConfFile(filename)
+
+	p, err := New()
+	assert.NoError(t, err)
+	f := p.(plugin.Function)
+	defer f.Close()
+
+	expected := models.Function{
+		Name:       "hello",
+		Handler:    "index.handler",
+		Version:    "2",
+		Runtime:    "python3.8",
+		Env:        map[string]string{"LOG_LEVEL": "debug"},
+		MemorySize: 256,
+		Code: models.FunctionCode{
+			Size:   1024,
+			Sha256: "nwJRg4SsziinnzTflN8XBilgUzeGIUZS/mxjwnQkzM8=",
+		},
+	}
+
+	list, err := f.List("default")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Function{{Name: "world", Version: "$LATEST", Runtime: "nodejs12.x"}, expected}, list)
+
+	list, err = f.ListFunctionVersions("default", "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Function{expected}, list)
+
+	res, err := f.Get("default", "hello", "2")
+	assert.NoError(t, err)
+	expected.Code.Location = "https://awslambda.s3.amazonaws.com/hello.zip"
+	assert.Equal(t, &expected, res)
+
+	_, err = f.Get("default", "none", "")
+	assert.Error(t, err)
+	e, ok := err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, common.ErrResourceNotFound, e.Code())
+
+	_, err = f.ListFunctionVersions("default", "none")
+	assert.Error(t, err)
+}
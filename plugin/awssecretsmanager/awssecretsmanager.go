@@ -0,0 +1,96 @@
+package awssecretsmanager
+
+import (
+	"encoding/json"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// ValueKey the key of the secret data if the secret in store is not a json object, such as binary
+const ValueKey = "value"
+
+// the version ids of secrets are uuids, the other versions are taken as staging labels
+var versionIDRegexp = regexp.MustCompile("^[0-9a-fA-F-]{32,64}$")
+
+// smProvider reads the secrets from aws secrets manager
+type smProvider struct {
+	client *secretsmanager.SecretsManager
+}
+
+func init() {
+	plugin.RegisterFactory("awssecretsmanager", New)
+}
+
+// New New
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	awsConfig := &aws.Config{Region: aws.String(cfg.AWSSecretsManager.Region)}
+	if cfg.AWSSecretsManager.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.AWSSecretsManager.Endpoint)
+	}
+	if cfg.AWSSecretsManager.Ak != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.AWSSecretsManager.Ak, cfg.AWSSecretsManager.Sk, "")
+	}
+	s, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &smProvider{client: secretsmanager.New(s)}, nil
+}
+
+// GetSecretData reads the secret whose name or arn is the path of reference, the version is either the version id
+// or the staging label of secret, such as AWSPREVIOUS.
+// The secret string of json object is delivered as the keys of object, the others are delivered as ValueKey
+func (p *smProvider) GetSecretData(ref *models.SecretExternal) (map[string][]byte, error) {
+	input := &secretsmanager.GetSecretValueInput{SecretId: aws.String(ref.Path)}
+	if versionIDRegexp.MatchString(ref.Version) {
+		input.VersionId = aws.String(ref.Version)
+	} else if ref.Version != "" {
+		input.VersionStage = aws.String(ref.Version)
+	}
+	out, err := p.client.GetSecretValue(input)
+	if err != nil {
+		if e, ok := err.(awserr.Error); ok && e.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+			return nil, errors.Errorf("the secret %s is not found in aws secrets manager", ref.Path)
+		}
+		return nil, errors.Trace(err)
+	}
+	if out.SecretString == nil {
+		return map[string][]byte{ValueKey: out.SecretBinary}, nil
+	}
+	var obj map[string]interface{}
+	if err = json.Unmarshal([]byte(*out.SecretString), &obj); err != nil || obj == nil {
+		return map[string][]byte{ValueKey: []byte(*out.SecretString)}, nil
+	}
+	data := map[string][]byte{}
+	for k, v := range obj {
+		if s, ok := v.(string); ok {
+			data[k] = []byte(s)
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		data[k] = b
+	}
+	return data, nil
+}
+
+// Close Close
+func (p *smProvider) Close() error {
+	return nil
+}
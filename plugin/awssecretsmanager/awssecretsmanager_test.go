@@ -0,0 +1,74 @@
+package awssecretsmanager
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestGetSecretData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		var input map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		switch input["SecretId"] + "@" + input["VersionId"] + input["VersionStage"] {
+		case "app/db@":
+			json.NewEncoder(w).Encode(map[string]interface{}{"Name": "app/db", "SecretString": `{"username":"baetyl","port":3306}`})
+		case "app/db@AWSPREVIOUS", "app/db@3f1c9b2e-6d4a-4e8b-9c0d-1a2b3c4d5e6f":
+			json.NewEncoder(w).Encode(map[string]interface{}{"Name": "app/db", "SecretString": "plain"})
+		case "app/cert@":
+			json.NewEncoder(w).Encode(map[string]interface{}{"Name": "app/cert", "SecretBinary": []byte{0xde, 0xad}})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"ResourceNotFoundException","Message":"Secrets Manager can't find the specified secret."}`))
+		}
+	}))
+	defer server.Close()
+
+	conf := `
+awssecretsmanager:
+  endpoint: ` + server.URL + `
+  ak: ak
+  sk: sk
+`
+	filename := "cloud.yml"
+	err := ioutil.WriteFile(filename, []byte(conf), 0644)
+	assert.NoError(t, err)
+	defer os.Remove(filename)
+	common.SetConfFile(filename)
+
+	p, err := New()
+	assert.NoError(t, err)
+	sm := p.(plugin.SecretProvider)
+
+	data, err := sm.GetSecretData(&models.SecretExternal{Provider: "awssecretsmanager", Path: "app/db"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"username": []byte("baetyl"), "port": []byte("3306")}, data)
+
+	data, err = sm.GetSecretData(&models.SecretExternal{Provider: "awssecretsmanager", Path: "app/db", Version: "AWSPREVIOUS"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{ValueKey: []byte("plain")}, data)
+
+	data, err = sm.GetSecretData(&models.SecretExternal{Provider: "awssecretsmanager", Path: "app/db", Version: "3f1c9b2e-6d4a-4e8b-9c0d-1a2b3c4d5e6f"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{ValueKey: []byte("plain")}, data)
+
+	data, err = sm.GetSecretData(&models.SecretExternal{Provider: "awssecretsmanager", Path: "app/cert"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{ValueKey: {0xde, 0xad}}, data)
+
+	_, err = sm.GetSecretData(&models.SecretExternal{Provider: "awssecretsmanager", Path: "app/none"})
+	assert.EqualError(t, err, "the secret app/none is not found in aws secrets manager")
+
+	assert.NoError(t, sm.Close())
+}
@@ -0,0 +1,13 @@
+package awssecretsmanager
+
+// CloudConfig baetyl-cloud config
+type CloudConfig struct {
+	AWSSecretsManager struct {
+		// Endpoint the endpoint of secrets manager, the one of region is used if empty
+		Endpoint string `yaml:"endpoint" json:"endpoint"`
+		Region   string `yaml:"region" json:"region" default:"us-east-1"`
+		// Ak and Sk the static credential, the default credential chain of aws is used if empty, such as the iam role
+		Ak string `yaml:"ak" json:"ak"`
+		Sk string `yaml:"sk" json:"sk"`
+	} `yaml:"awssecretsmanager" json:"awssecretsmanager"`
+}
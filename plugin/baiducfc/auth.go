@@ -0,0 +1,88 @@
+package baiducfc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	bceDateHeader   = "x-bce-date"
+	bceAuthVersion  = "bce-auth-v1"
+	bceSignExpiring = 1800
+)
+
+// sign sets the authorization of bce auth v1 to the request, the host and x-bce-date headers are signed
+func sign(req *http.Request, ak, sk string, now time.Time) {
+	timestamp := now.UTC().Format("2006-01-02T15:04:05Z")
+	req.Header.Set(bceDateHeader, timestamp)
+	prefix := fmt.Sprintf("%s/%s/%s/%d", bceAuthVersion, ak, timestamp, bceSignExpiring)
+	signingKey := hmacSHA256(sk, prefix)
+
+	headers := map[string]string{
+		"host":        req.URL.Host,
+		bceDateHeader: timestamp,
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	canonicalHeaders := make([]string, 0, len(names))
+	for _, k := range names {
+		canonicalHeaders = append(canonicalHeaders, uriEncode(k, true)+":"+uriEncode(strings.TrimSpace(headers[k]), true))
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		strings.Join(canonicalHeaders, "\n"),
+	}, "\n")
+	signature := hmacSHA256(signingKey, canonicalRequest)
+	req.Header.Set("Authorization", prefix+"/"+strings.Join(names, ";")+"/"+signature)
+}
+
+// canonicalQuery encodes the query sorted by the keys, the empty values are kept as key=
+func canonicalQuery(query url.Values) string {
+	params := make([]string, 0, len(query))
+	for k, vs := range query {
+		if strings.ToLower(k) == "authorization" {
+			continue
+		}
+		for _, v := range vs {
+			params = append(params, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// uriEncode encodes all characters except the unreserved ones of RFC 3986, the slashes are kept if not encodeSlash
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key, data string) string {
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write([]byte(data))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,171 @@
+package baiducfc
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// cfcFunction imports the functions of baidu cfc by its open api, the credential of config is used for all users
+type cfcFunction struct {
+	cfg    CloudConfig
+	client *http.Client
+}
+
+type functionConfig struct {
+	FunctionName string `json:"FunctionName"`
+	Handler      string `json:"Handler"`
+	Version      string `json:"Version"`
+	Runtime      string `json:"Runtime"`
+	MemorySize   int    `json:"MemorySize"`
+	CodeSize     int32  `json:"CodeSize"`
+	CodeSha256   string `json:"CodeSha256"`
+	Environment  *struct {
+		Variables map[string]string `json:"Variables"`
+	} `json:"Environment"`
+}
+
+type listFunctionsResponse struct {
+	Functions  []functionConfig `json:"Functions"`
+	Versions   []functionConfig `json:"Versions"`
+	NextMarker string           `json:"NextMarker"`
+}
+
+type getFunctionResponse struct {
+	Code struct {
+		Location string `json:"Location"`
+	} `json:"Code"`
+	Configuration *functionConfig `json:"Configuration"`
+}
+
+type errorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func init() {
+	plugin.RegisterFactory("baiducfc", New)
+}
+
+// New New
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &cfcFunction{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.BaiduCFC.Timeout},
+	}, nil
+}
+
+// List lists the latest functions, whose versions are $LATEST
+func (f *cfcFunction) List(_ string) ([]models.Function, error) {
+	return f.list("/v1/functions", url.Values{"FunctionVersion": []string{"$LATEST"}}, "")
+}
+
+// ListFunctionVersions lists the published versions of function as well as $LATEST
+func (f *cfcFunction) ListFunctionVersions(_, name string) ([]models.Function, error) {
+	return f.list("/v1/functions/"+url.PathEscape(name)+"/versions", url.Values{}, name)
+}
+
+// Get gets the version of function with the url of its code, which expires in a short time
+func (f *cfcFunction) Get(_, name, version string) (*models.Function, error) {
+	query := url.Values{}
+	if version != "" {
+		query.Set("Qualifier", version)
+	}
+	var res getFunctionResponse
+	if err := f.do("/v1/functions/"+url.PathEscape(name), query, name, &res); err != nil {
+		return nil, err
+	}
+	if res.Configuration == nil || res.Code.Location == "" {
+		return nil, errors.Errorf("the function %s of baidu cfc has no code", name)
+	}
+	fn := toFunction(res.Configuration)
+	fn.Code.Location = res.Code.Location
+	return &fn, nil
+}
+
+// Close Close
+func (f *cfcFunction) Close() error {
+	return nil
+}
+
+func (f *cfcFunction) list(path string, query url.Values, name string) ([]models.Function, error) {
+	res := []models.Function{}
+	for {
+		var page listFunctionsResponse
+		if err := f.do(path, query, name, &page); err != nil {
+			return nil, err
+		}
+		for i := range page.Functions {
+			res = append(res, toFunction(&page.Functions[i]))
+		}
+		for i := range page.Versions {
+			res = append(res, toFunction(&page.Versions[i]))
+		}
+		if page.NextMarker == "" || page.NextMarker == query.Get("Marker") {
+			return res, nil
+		}
+		query.Set("Marker", page.NextMarker)
+	}
+}
+
+func (f *cfcFunction) do(path string, query url.Values, name string, out interface{}) error {
+	u := strings.TrimSuffix(f.cfg.BaiduCFC.Endpoint, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sign(req, f.cfg.BaiduCFC.Ak, f.cfg.BaiduCFC.Sk, time.Now())
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if resp.StatusCode/100 != 2 {
+		var e errorResponse
+		json.Unmarshal(data, &e)
+		if resp.StatusCode == http.StatusNotFound && name != "" {
+			return common.Error(common.ErrResourceNotFound, common.Field("type", "function"), common.Field("name", name))
+		}
+		return errors.Errorf("failed to request baidu cfc (%d): %s", resp.StatusCode, e.Message)
+	}
+	return errors.Trace(json.Unmarshal(data, out))
+}
+
+func toFunction(conf *functionConfig) models.Function {
+	res := models.Function{
+		Name:       conf.FunctionName,
+		Handler:    conf.Handler,
+		Version:    conf.Version,
+		Runtime:    conf.Runtime,
+		MemorySize: conf.MemorySize,
+		Code: models.FunctionCode{
+			Size:   conf.CodeSize,
+			Sha256: conf.CodeSha256,
+		},
+	}
+	if conf.Environment != nil && len(conf.Environment.Variables) > 0 {
+		res.Env = conf.Environment.Variables
+	}
+	return res
+}
@@ -0,0 +1,144 @@
+package baiducfc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+var testConfiguration = map[string]interface{}{
+	"FunctionName": "hello",
+	"Handler":      "index.handler",
+	"Runtime":      "python3",
+	"Version":      "1",
+	"MemorySize":   128,
+	"CodeSize":     512,
+	"CodeSha256":   "nwJRg4SsziinnzTflN8XBilgUzeGIUZS/mxjwnQkzM8=",
+	"Environment":  map[string]interface{}{"Variables": map[string]string{"LOG_LEVEL": "debug"}},
+}
+
+func TestCFCFunction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the request is signed with the host and path received
+		timestamp, err := time.Parse("2006-01-02T15:04:05Z", r.Header.Get(bceDateHeader))
+		assert.NoError(t, err)
+		expected := httptest.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+		sign(expected, "ak", "sk", timestamp)
+		assert.Equal(t, expected.Header.Get("Authorization"), r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/functions":
+			assert.Equal(t, "$LATEST", r.URL.Query().Get("FunctionVersion"))
+			if r.URL.Query().Get("Marker") == "" {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"Functions":  []interface{}{map[string]interface{}{"FunctionName": "world", "Version": "$LATEST", "Runtime": "nodejs12"}},
+					"NextMarker": "1",
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"Functions": []interface{}{testConfiguration}})
+		case "/v1/functions/hello/versions":
+			json.NewEncoder(w).Encode(map[string]interface{}{"Versions": []interface{}{testConfiguration}})
+		case "/v1/functions/hello":
+			assert.Equal(t, "1", r.URL.Query().Get("Qualifier"))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"Configuration": testConfiguration,
+				"Code":          map[string]string{"Location": "https://cfc.bj.bcebos.com/hello.zip"},
+			})
+		case "/v1/functions/denied":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"code":"AccessDenied","message":"access denied"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"ResourceNotFoundException","message":"function not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	conf := `
+baiducfc:
+  endpoint: ` + server.URL + `
+  ak: ak
+  sk: sk
+`
+	filename := "cloud.yml"
+	err := ioutil.WriteFile(filename, []byte(conf), 0644)
+	assert.NoError(t, err)
+	defer os.Remove(filename)
+	common.SetConfFile(filename)
+
+	p, err := New()
+	assert.NoError(t, err)
+	f := p.(plugin.Function)
+	defer f.Close()
+
+	expected := models.Function{
+		Name:       "hello",
+		Handler:    "index.handler",
+		Version:    "1",
+		Runtime:    "python3",
+		Env:        map[string]string{"LOG_LEVEL": "debug"},
+		MemorySize: 128,
+		Code: models.FunctionCode{
+			Size:   512,
+			Sha256: "nwJRg4SsziinnzTflN8XBilgUzeGIUZS/mxjwnQkzM8=",
+		},
+	}
+
+	list, err := f.List("default")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Function{{Name: "world", Version: "$LATEST", Runtime: "nodejs12"}, expected}, list)
+
+	list, err = f.ListFunctionVersions("default", "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Function{expected}, list)
+
+	res, err := f.Get("default", "hello", "1")
+	assert.NoError(t, err)
+	expected.Code.Location = "https://cfc.bj.bcebos.com/hello.zip"
+	assert.Equal(t, &expected, res)
+
+	_, err = f.Get("default", "none", "")
+	assert.Error(t, err)
+	e, ok := err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, common.ErrResourceNotFound, e.Code())
+
+	_, err = f.Get("default", "denied", "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, "a%20b%2Fc~", uriEncode("a b/c~", true))
+	assert.Equal(t, "/v1/functions/a%3Ab", uriEncode("/v1/functions/a:b", false))
+	assert.Equal(t, "FunctionVersion=%24LATEST&Marker=2", canonicalQuery(url.Values{
+		"Marker":          []string{"2"},
+		"FunctionVersion": []string{"$LATEST"},
+		"authorization":   []string{"ignored"},
+	}))
+
+	now := time.Date(2021, 6, 1, 8, 0, 0, 0, time.UTC)
+	req := httptest.NewRequest(http.MethodGet, "https://cfc.bj.baidubce.com/v1/functions", nil)
+	sign(req, "ak", "sk", now)
+	assert.Equal(t, "2021-06-01T08:00:00Z", req.Header.Get(bceDateHeader))
+	auth := req.Header.Get("Authorization")
+	assert.Regexp(t, "^bce-auth-v1/ak/2021-06-01T08:00:00Z/1800/host;x-bce-date/[0-9a-f]{64}$", auth)
+
+	// the signature changes with the credential
+	sign(req, "ak", "other", now)
+	assert.NotEqual(t, auth, req.Header.Get("Authorization"))
+}
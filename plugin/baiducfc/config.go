@@ -0,0 +1,14 @@
+package baiducfc
+
+import "time"
+
+// CloudConfig baetyl-cloud config
+type CloudConfig struct {
+	BaiduCFC struct {
+		// Endpoint the endpoint of the region of cfc, such as https://cfc.gz.baidubce.com
+		Endpoint string        `yaml:"endpoint" json:"endpoint" default:"https://cfc.bj.baidubce.com"`
+		Ak       string        `yaml:"ak" json:"ak" validate:"nonzero"`
+		Sk       string        `yaml:"sk" json:"sk" validate:"nonzero"`
+		Timeout  time.Duration `yaml:"timeout" json:"timeout" default:"10s"`
+	} `yaml:"baiducfc" json:"baiducfc"`
+}
@@ -0,0 +1,127 @@
+package buildservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// buildService builds the images of function code by a hosted build service, the builds are created by
+// POST /v1/builds and polled by GET /v1/builds/{id}
+type buildService struct {
+	cfg    CloudConfig
+	client *http.Client
+}
+
+type buildRequest struct {
+	CodeURL    string            `json:"codeURL"`
+	Dockerfile string            `json:"dockerfile"`
+	Image      string            `json:"image"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+type buildResponse struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+func init() {
+	plugin.RegisterFactory("buildservice", New)
+}
+
+// New New
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &buildService{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.BuildService.Timeout},
+	}, nil
+}
+
+func (b *buildService) StartBuild(req *models.FunctionBuildRequest) (string, error) {
+	body, err := json.Marshal(&buildRequest{
+		CodeURL:    req.CodeURL,
+		Dockerfile: req.Dockerfile,
+		Image:      req.Image,
+		Labels: map[string]string{
+			"namespace": req.Namespace,
+			"function":  req.Name,
+			"version":   req.Version,
+		},
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	res, err := b.do(http.MethodPost, "/v1/builds", body)
+	if err != nil {
+		return "", err
+	}
+	if res.ID == "" {
+		return "", errors.Errorf("no id of build is returned by the build service")
+	}
+	return res.ID, nil
+}
+
+// GetBuildStatus returns the status of build, the statuses other than succeeded and failed are running
+func (b *buildService) GetBuildStatus(id string) (*models.FunctionBuildStatus, error) {
+	res, err := b.do(http.MethodGet, "/v1/builds/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(res.Status) {
+	case models.FunctionBuildSucceeded:
+		return &models.FunctionBuildStatus{Status: models.FunctionBuildSucceeded}, nil
+	case models.FunctionBuildFailed:
+		return &models.FunctionBuildStatus{Status: models.FunctionBuildFailed, Message: res.Message}, nil
+	default:
+		return &models.FunctionBuildStatus{Status: models.FunctionBuildRunning}, nil
+	}
+}
+
+// Close Close
+func (b *buildService) Close() error {
+	return nil
+}
+
+func (b *buildService) do(method, path string, body []byte) (*buildResponse, error) {
+	req, err := http.NewRequest(method, strings.TrimSuffix(b.cfg.BuildService.Address, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.cfg.BuildService.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.cfg.BuildService.Token)
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res := new(buildResponse)
+	if resp.StatusCode/100 != 2 {
+		json.Unmarshal(data, res)
+		return nil, errors.Errorf("failed to request the build service (%d): %s", resp.StatusCode, res.Message)
+	}
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return res, nil
+}
@@ -0,0 +1,73 @@
+package buildservice
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestBuildService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer b.token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"invalid token"}`))
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/builds":
+			var req buildRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "http://minio/code.zip", req.CodeURL)
+			assert.Equal(t, "infer", req.Labels["function"])
+			w.Write([]byte(`{"id":"b-1","status":"queued"}`))
+		case "GET /v1/builds/b-1":
+			w.Write([]byte(`{"id":"b-1","status":"queued"}`))
+		case "GET /v1/builds/b-2":
+			w.Write([]byte(`{"id":"b-2","status":"Succeeded"}`))
+		case "GET /v1/builds/b-3":
+			w.Write([]byte(`{"id":"b-3","status":"failed","message":"no such file"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"the build is not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	b := &buildService{client: &http.Client{Timeout: time.Second}}
+	b.cfg.BuildService.Address = server.URL + "/"
+	b.cfg.BuildService.Token = "b.token"
+
+	id, err := b.StartBuild(&models.FunctionBuildRequest{
+		Namespace:  "default",
+		Name:       "infer",
+		Version:    "v1",
+		CodeURL:    "http://minio/code.zip",
+		Dockerfile: "FROM python:3\n",
+		Image:      "harbor.local/functions/default/infer:v1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "b-1", id)
+
+	status, err := b.GetBuildStatus("b-1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.FunctionBuildRunning, status.Status)
+	status, err = b.GetBuildStatus("b-2")
+	assert.NoError(t, err)
+	assert.Equal(t, models.FunctionBuildSucceeded, status.Status)
+	status, err = b.GetBuildStatus("b-3")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.FunctionBuildStatus{Status: models.FunctionBuildFailed, Message: "no such file"}, status)
+
+	_, err = b.GetBuildStatus("b-4")
+	assert.EqualError(t, err, "failed to request the build service (404): the build is not found")
+
+	b.cfg.BuildService.Token = "expired"
+	_, err = b.GetBuildStatus("b-1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,13 @@
+package buildservice
+
+import "time"
+
+// CloudConfig baetyl-cloud config
+type CloudConfig struct {
+	BuildService struct {
+		Address string `yaml:"address" json:"address" validate:"nonzero"`
+		// Token the bearer token of the requests to the build service, no authorization is sent if empty
+		Token   string        `yaml:"token" json:"token"`
+		Timeout time.Duration `yaml:"timeout" json:"timeout" default:"10s"`
+	} `yaml:"buildService" json:"buildService"`
+}
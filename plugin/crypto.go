@@ -0,0 +1,13 @@
+package plugin
+
+import "io"
+
+//go:generate mockgen -destination=../mock/plugin/crypto.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin Crypto
+
+// Crypto encrypts the sensitive data before it is written to the backends, such as the data of secrets
+type Crypto interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+
+	io.Closer
+}
@@ -0,0 +1,74 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// aesGCM encrypts the data by AES-GCM with the local master key, the random nonce is prepended to the ciphertext
+type aesGCM struct {
+	aead cipher.AEAD
+}
+
+func init() {
+	plugin.RegisterFactory("aesgcm", NewAESGCM)
+}
+
+func NewAESGCM() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.Crypto.AESGCM == nil {
+		return nil, errors.Trace(errors.New("the config of aesgcm crypto is missing"))
+	}
+	key, err := base64.StdEncoding.DecodeString(cfg.Crypto.AESGCM.MasterKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newAESGCM(key)
+}
+
+func newAESGCM(key []byte) (*aesGCM, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &aesGCM{aead: aead}, nil
+}
+
+func (a *aesGCM) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, a.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (a *aesGCM) Decrypt(ciphertext []byte) ([]byte, error) {
+	size := a.aead.NonceSize()
+	if len(ciphertext) < size {
+		return nil, errors.Trace(errors.New("the ciphertext is too short"))
+	}
+	res, err := a.aead.Open(nil, ciphertext[:size], ciphertext[size:], nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return res, nil
+}
+
+func (a *aesGCM) Close() error {
+	return nil
+}
@@ -0,0 +1,98 @@
+package crypto
+
+import (
+	"encoding/binary"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// awsKMS encrypts the data by envelope encryption, the data is encrypted by AES-GCM with a data key generated by KMS,
+// and the data key encrypted by the master key is stored before the ciphertext as: key length (2 bytes) | key | ciphertext
+type awsKMS struct {
+	client kmsiface.KMSAPI
+	keyID  string
+}
+
+func init() {
+	plugin.RegisterFactory("awskms", NewAWSKMS)
+}
+
+func NewAWSKMS() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	c := cfg.Crypto.AWSKMS
+	if c == nil {
+		return nil, errors.Trace(errors.New("the config of awskms crypto is missing"))
+	}
+	awsConfig := &aws.Config{Region: aws.String(c.Region)}
+	if c.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(c.Endpoint)
+	}
+	// the default credential chain, such as the environment variables and the instance role, is used if not configured
+	if c.Ak != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(c.Ak, c.Sk, "")
+	}
+	s, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &awsKMS{client: kms.New(s), keyID: c.KeyID}, nil
+}
+
+func (a *awsKMS) Encrypt(plaintext []byte) ([]byte, error) {
+	out, err := a.client.GenerateDataKey(&kms.GenerateDataKeyInput{
+		KeyId:   aws.String(a.keyID),
+		KeySpec: aws.String(kms.DataKeySpecAes256),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	gcm, err := newAESGCM(out.Plaintext)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := gcm.Encrypt(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]byte, 2, 2+len(out.CiphertextBlob)+len(sealed))
+	binary.BigEndian.PutUint16(res, uint16(len(out.CiphertextBlob)))
+	res = append(res, out.CiphertextBlob...)
+	return append(res, sealed...), nil
+}
+
+func (a *awsKMS) Decrypt(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 2 {
+		return nil, errors.Trace(errors.New("the ciphertext is too short"))
+	}
+	size := int(binary.BigEndian.Uint16(ciphertext))
+	if len(ciphertext) < 2+size {
+		return nil, errors.Trace(errors.New("the ciphertext is too short"))
+	}
+	out, err := a.client.Decrypt(&kms.DecryptInput{
+		KeyId:          aws.String(a.keyID),
+		CiphertextBlob: ciphertext[2 : 2+size],
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	gcm, err := newAESGCM(out.Plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Decrypt(ciphertext[2+size:])
+}
+
+func (a *awsKMS) Close() error {
+	return nil
+}
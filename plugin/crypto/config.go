@@ -0,0 +1,35 @@
+package crypto
+
+import "time"
+
+// CloudConfig the configs of the crypto plugins, only the one of the plugin in use is required
+type CloudConfig struct {
+	Crypto struct {
+		AESGCM *AESGCMConfig `yaml:"aesgcm" json:"aesgcm"`
+		AWSKMS *AWSKMSConfig `yaml:"awskms" json:"awskms"`
+		Vault  *VaultConfig  `yaml:"vault" json:"vault"`
+	} `yaml:"crypto" json:"crypto" default:"{}"`
+}
+
+type AESGCMConfig struct {
+	// MasterKey the base64 encoded key of 16, 24 or 32 bytes
+	MasterKey string `yaml:"masterKey" json:"masterKey" validate:"nonzero"`
+}
+
+type AWSKMSConfig struct {
+	Endpoint string `yaml:"endpoint" json:"endpoint"`
+	Region   string `yaml:"region" json:"region" default:"us-east-1"`
+	Ak       string `yaml:"ak" json:"ak"`
+	Sk       string `yaml:"sk" json:"sk"`
+	// KeyID the id or arn of the customer master key generating the data keys
+	KeyID string `yaml:"keyId" json:"keyId" validate:"nonzero"`
+}
+
+type VaultConfig struct {
+	Address string `yaml:"address" json:"address" validate:"nonzero"`
+	Token   string `yaml:"token" json:"token" validate:"nonzero"`
+	// Mount the path where the transit secrets engine is mounted
+	Mount   string        `yaml:"mount" json:"mount" default:"transit"`
+	Key     string        `yaml:"key" json:"key" validate:"nonzero"`
+	Timeout time.Duration `yaml:"timeout" json:"timeout" default:"10s"`
+}
@@ -0,0 +1,127 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAESGCM(t *testing.T) {
+	_, err := newAESGCM([]byte("short"))
+	assert.Error(t, err)
+
+	c, err := newAESGCM(bytes.Repeat([]byte("k"), 32))
+	assert.NoError(t, err)
+	cipher1, err := c.Encrypt([]byte("password"))
+	assert.NoError(t, err)
+	cipher2, err := c.Encrypt([]byte("password"))
+	assert.NoError(t, err)
+	// the nonce is random
+	assert.NotEqual(t, cipher1, cipher2)
+	assert.NotContains(t, string(cipher1), "password")
+
+	res, err := c.Decrypt(cipher1)
+	assert.NoError(t, err)
+	assert.Equal(t, "password", string(res))
+
+	cipher1[len(cipher1)-1] ^= 0xff
+	_, err = c.Decrypt(cipher1)
+	assert.Error(t, err)
+	_, err = c.Decrypt([]byte("x"))
+	assert.Error(t, err)
+
+	other, err := newAESGCM(bytes.Repeat([]byte("o"), 32))
+	assert.NoError(t, err)
+	_, err = other.Decrypt(cipher2)
+	assert.Error(t, err)
+}
+
+// fakeKMS wraps the data key with the master key by xor
+type fakeKMS struct {
+	kmsiface.KMSAPI
+	master byte
+}
+
+func (f *fakeKMS) xor(b []byte) []byte {
+	res := make([]byte, len(b))
+	for i := range b {
+		res[i] = b[i] ^ f.master
+	}
+	return res
+}
+
+func (f *fakeKMS) GenerateDataKey(in *kms.GenerateDataKeyInput) (*kms.GenerateDataKeyOutput, error) {
+	key := bytes.Repeat([]byte("d"), 32)
+	return &kms.GenerateDataKeyOutput{KeyId: in.KeyId, Plaintext: key, CiphertextBlob: f.xor(key)}, nil
+}
+
+func (f *fakeKMS) Decrypt(in *kms.DecryptInput) (*kms.DecryptOutput, error) {
+	return &kms.DecryptOutput{KeyId: in.KeyId, Plaintext: f.xor(in.CiphertextBlob)}, nil
+}
+
+func TestAWSKMS(t *testing.T) {
+	c := &awsKMS{client: &fakeKMS{master: 0x5a}, keyID: "alias/baetyl"}
+	ciphertext, err := c.Encrypt([]byte("password"))
+	assert.NoError(t, err)
+	// the encrypted data key is stored before the ciphertext
+	assert.Equal(t, []byte{0, 32}, ciphertext[:2])
+	assert.NotContains(t, string(ciphertext), "password")
+
+	res, err := c.Decrypt(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "password", string(res))
+
+	_, err = c.Decrypt(ciphertext[:10])
+	assert.Error(t, err)
+	_, err = c.Decrypt([]byte{1})
+	assert.Error(t, err)
+}
+
+func TestVaultTransit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		req := new(vaultRequest)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/baetyl":
+			json.NewEncoder(w).Encode(&vaultResponse{Data: vaultRequest{Ciphertext: "vault:v1:" + req.Plaintext}})
+		case "/v1/transit/decrypt/baetyl":
+			json.NewEncoder(w).Encode(&vaultResponse{Data: vaultRequest{Plaintext: strings.TrimPrefix(req.Ciphertext, "vault:v1:")}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := &VaultConfig{Address: server.URL + "/", Token: "token", Mount: "transit", Key: "baetyl", Timeout: time.Second}
+	c := newVaultTransit(cfg)
+	ciphertext, err := c.Encrypt([]byte("password"))
+	assert.NoError(t, err)
+	assert.Equal(t, "vault:v1:"+base64.StdEncoding.EncodeToString([]byte("password")), string(ciphertext))
+	res, err := c.Decrypt(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "password", string(res))
+
+	cfg.Token = "invalid"
+	_, err = c.Encrypt([]byte("password"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied")
+
+	cfg.Token, cfg.Key = "token", "other"
+	_, err = c.Decrypt(ciphertext)
+	assert.Error(t, err)
+}
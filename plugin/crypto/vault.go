@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// vaultTransit encrypts the data by the transit secrets engine of vault, the key never leaves vault
+// and the ciphertext is the one returned by vault, such as vault:v1:...
+type vaultTransit struct {
+	cfg    *VaultConfig
+	client *http.Client
+}
+
+type vaultRequest struct {
+	Plaintext  string `json:"plaintext,omitempty"`
+	Ciphertext string `json:"ciphertext,omitempty"`
+}
+
+type vaultResponse struct {
+	Data   vaultRequest `json:"data"`
+	Errors []string     `json:"errors"`
+}
+
+func init() {
+	plugin.RegisterFactory("vaulttransit", NewVault)
+}
+
+func NewVault() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.Crypto.Vault == nil {
+		return nil, errors.Trace(errors.New("the config of vault crypto is missing"))
+	}
+	return newVaultTransit(cfg.Crypto.Vault), nil
+}
+
+func newVaultTransit(cfg *VaultConfig) *vaultTransit {
+	return &vaultTransit{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+func (v *vaultTransit) Encrypt(plaintext []byte) ([]byte, error) {
+	res, err := v.call("encrypt", &vaultRequest{Plaintext: base64.StdEncoding.EncodeToString(plaintext)})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(res.Ciphertext), nil
+}
+
+func (v *vaultTransit) Decrypt(ciphertext []byte) ([]byte, error) {
+	res, err := v.call("decrypt", &vaultRequest{Ciphertext: string(ciphertext)})
+	if err != nil {
+		return nil, err
+	}
+	data, err := base64.StdEncoding.DecodeString(res.Plaintext)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+func (v *vaultTransit) call(op string, req *vaultRequest) (*vaultRequest, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	url := fmt.Sprintf("%s/v1/%s/%s/%s", strings.TrimSuffix(v.cfg.Address, "/"), v.cfg.Mount, op, v.cfg.Key)
+	r, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r.Header.Set("X-Vault-Token", v.cfg.Token)
+	r.Header.Set("Content-Type", "application/json")
+	resp, err := v.client.Do(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	res := new(vaultResponse)
+	if err = json.NewDecoder(resp.Body).Decode(res); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to %s by vault (%d): %s", op, resp.StatusCode, strings.Join(res.Errors, "; "))
+	}
+	return &res.Data, nil
+}
+
+func (v *vaultTransit) Close() error {
+	return nil
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetActivationToken(namespace, name string) (*models.ActivationToken, error) {
+	selectSQL := `
+SELECT namespace, name, description, node, node_group, max_uses, uses, placement, endpoints, expire_time, create_time 
+FROM baetyl_activation_token WHERE namespace=? AND name=?
+`
+	var tokens []entities.ActivationToken
+	if err := d.Query(nil, selectSQL, &tokens, namespace, name); err != nil {
+		return nil, err
+	}
+	if len(tokens) > 0 {
+		return entities.ToActivationTokenModel(&tokens[0])
+	}
+	return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "activationToken"),
+		common.Field("name", name), common.Field("namespace", namespace))
+}
+
+func (d *DB) ListActivationToken(namespace string) ([]models.ActivationToken, error) {
+	selectSQL := `
+SELECT namespace, name, description, node, node_group, max_uses, uses, placement, endpoints, expire_time, create_time 
+FROM baetyl_activation_token WHERE namespace=? ORDER BY create_time DESC, id DESC
+`
+	var tokens []entities.ActivationToken
+	if err := d.Query(nil, selectSQL, &tokens, namespace); err != nil {
+		return nil, err
+	}
+	res := make([]models.ActivationToken, 0, len(tokens))
+	for i := range tokens {
+		token, err := entities.ToActivationTokenModel(&tokens[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *token)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateActivationToken(token *models.ActivationToken) error {
+	insertSQL := `
+INSERT INTO baetyl_activation_token 
+(namespace, name, description, node, node_group, max_uses, uses, placement, endpoints, expire_time) 
+VALUES (?,?,?,?,?,?,?,?,?,?)
+`
+	t, err := entities.FromActivationTokenModel(token)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, t.Namespace, t.Name, t.Description, t.Node, t.NodeGroup,
+		t.MaxUses, t.Uses, t.Placement, t.Endpoints, t.ExpireTime)
+	return err
+}
+
+func (d *DB) UseActivationToken(namespace, name string, t time.Time) (bool, error) {
+	// the uses are counted in the condition, so that the concurrent activations never exceed the max
+	updateSQL := `
+UPDATE baetyl_activation_token SET uses=uses+1 
+WHERE namespace=? AND name=? AND uses<max_uses AND expire_time>?
+`
+	res, err := d.Exec(nil, updateSQL, namespace, name, t.UTC())
+	if err != nil {
+		return false, err
+	}
+	num, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return num == 1, nil
+}
+
+func (d *DB) DeleteActivationToken(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_activation_token WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
+
+func (d *DB) DeleteExpiredActivationToken(before time.Time) error {
+	deleteSQL := `DELETE FROM baetyl_activation_token WHERE expire_time<?`
+	_, err := d.Exec(nil, deleteSQL, before.UTC())
+	return err
+}
@@ -0,0 +1,124 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	activationTokenTables = []string{
+		`
+CREATE TABLE baetyl_activation_token(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    name        VARCHAR(64) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    node        VARCHAR(128) NOT NULL DEFAULT '',
+    node_group  VARCHAR(128) NOT NULL DEFAULT '',
+    max_uses    INT NOT NULL DEFAULT 1,
+    uses        INT NOT NULL DEFAULT 0,
+    placement   TEXT NULL,
+    endpoints   TEXT NULL,
+    expire_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateActivationTokenTable() {
+	for _, sql := range activationTokenTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestActivationToken(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateActivationTokenTable()
+
+	now := time.Now()
+	token := &models.ActivationToken{
+		Namespace:  "default",
+		Name:       "token01",
+		NodeGroup:  "group01",
+		MaxUses:    2,
+		ExpireTime: now.Add(time.Hour),
+		Placement: &models.NodePlacement{
+			Labels: map[string]string{"region": "{{.region}}"},
+			Rules:  []models.NodePlacementRule{{Selector: "region=beijing", NodeGroup: "group02"}},
+		},
+		Endpoints: &models.NodeEndpoints{InitServer: "https://gateway.site:30003"},
+	}
+	_, err = db.GetActivationToken("default", "token01")
+	assert.Error(t, err)
+
+	err = db.CreateActivationToken(token)
+	assert.NoError(t, err)
+	err = db.CreateActivationToken(token)
+	assert.Error(t, err)
+	err = db.CreateActivationToken(&models.ActivationToken{Namespace: "default", Name: "token02", Node: "node01", MaxUses: 1, ExpireTime: now.Add(-time.Hour)})
+	assert.NoError(t, err)
+
+	res, err := db.GetActivationToken("default", "token01")
+	assert.NoError(t, err)
+	assert.Equal(t, "group01", res.NodeGroup)
+	assert.Equal(t, 2, res.MaxUses)
+	assert.Equal(t, 0, res.Uses)
+	assert.Equal(t, token.ExpireTime.Unix(), res.ExpireTime.Unix())
+	assert.Equal(t, token.Placement, res.Placement)
+	assert.Equal(t, token.Endpoints, res.Endpoints)
+
+	list, err := db.ListActivationToken("default")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	list, err = db.ListActivationToken("other")
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	// the uses stop at the max
+	ok, err := db.UseActivationToken("default", "token01", now)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = db.UseActivationToken("default", "token01", now)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = db.UseActivationToken("default", "token01", now)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	res, err = db.GetActivationToken("default", "token01")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Uses)
+
+	// the expired token is not used
+	ok, err = db.UseActivationToken("default", "token02", now)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = db.UseActivationToken("default", "token03", now)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	err = db.DeleteExpiredActivationToken(now)
+	assert.NoError(t, err)
+	_, err = db.GetActivationToken("default", "token02")
+	assert.Error(t, err)
+
+	err = db.DeleteActivationToken("default", "token01")
+	assert.NoError(t, err)
+	_, err = db.GetActivationToken("default", "token01")
+	assert.Error(t, err)
+}
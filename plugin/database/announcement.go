@@ -0,0 +1,74 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetAnnouncement(name string) (*models.Announcement, error) {
+	selectSQL := `
+SELECT name, title, content, level, start_time, end_time, namespaces, edge, node_selector, create_time, update_time
+FROM baetyl_announcement WHERE name=?
+`
+	var announcements []entities.Announcement
+	if err := d.Query(nil, selectSQL, &announcements, name); err != nil {
+		return nil, err
+	}
+	if len(announcements) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "announcement"), common.Field("name", name))
+	}
+	return entities.ToAnnouncementModel(&announcements[0])
+}
+
+func (d *DB) ListAnnouncement() ([]models.Announcement, error) {
+	selectSQL := `
+SELECT name, title, content, level, start_time, end_time, namespaces, edge, node_selector, create_time, update_time
+FROM baetyl_announcement ORDER BY start_time, name
+`
+	var announcements []entities.Announcement
+	if err := d.Query(nil, selectSQL, &announcements); err != nil {
+		return nil, err
+	}
+	res := make([]models.Announcement, 0, len(announcements))
+	for i := range announcements {
+		announcement, err := entities.ToAnnouncementModel(&announcements[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *announcement)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateAnnouncement(announcement *models.Announcement) error {
+	insertSQL := `
+INSERT INTO baetyl_announcement (name, title, content, level, start_time, end_time, namespaces, edge, node_selector)
+VALUES (?,?,?,?,?,?,?,?,?)
+`
+	a, err := entities.FromAnnouncementModel(announcement)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, a.Name, a.Title, a.Content, a.Level, a.StartTime, a.EndTime, a.Namespaces, a.Edge, a.NodeSelector)
+	return err
+}
+
+func (d *DB) UpdateAnnouncement(announcement *models.Announcement) error {
+	updateSQL := `
+UPDATE baetyl_announcement SET title=?, content=?, level=?, start_time=?, end_time=?, namespaces=?, edge=?, node_selector=?
+WHERE name=?
+`
+	a, err := entities.FromAnnouncementModel(announcement)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, a.Title, a.Content, a.Level, a.StartTime, a.EndTime, a.Namespaces, a.Edge, a.NodeSelector, a.Name)
+	return err
+}
+
+func (d *DB) DeleteAnnouncement(name string) error {
+	deleteSQL := `DELETE FROM baetyl_announcement WHERE name=?`
+	_, err := d.Exec(nil, deleteSQL, name)
+	return err
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	announcementTables = []string{
+		`
+CREATE TABLE baetyl_announcement(
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    name          VARCHAR(128) NOT NULL DEFAULT '',
+    title         VARCHAR(128) NOT NULL DEFAULT '',
+    content       TEXT NULL,
+    level         VARCHAR(32) NOT NULL DEFAULT '',
+    start_time    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    end_time      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    namespaces    TEXT NULL,
+    edge          BOOLEAN NOT NULL DEFAULT FALSE,
+    node_selector VARCHAR(2048) NOT NULL DEFAULT '',
+    create_time   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateAnnouncementTable() {
+	for _, sql := range announcementTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestAnnouncement(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateAnnouncementTable()
+
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	announcement := &models.Announcement{
+		Name:      "maintenance",
+		Title:     "planned maintenance",
+		Content:   "the cloud is under maintenance",
+		Level:     models.AnnouncementWarning,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	}
+	_, err = db.GetAnnouncement(announcement.Name)
+	assert.Error(t, err)
+
+	err = db.CreateAnnouncement(announcement)
+	assert.NoError(t, err)
+	err = db.CreateAnnouncement(announcement)
+	assert.Error(t, err)
+
+	res, err := db.GetAnnouncement(announcement.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "planned maintenance", res.Title)
+	assert.Equal(t, start, res.StartTime)
+	assert.Nil(t, res.Namespaces)
+	assert.False(t, res.Edge)
+
+	announcement.Namespaces = []string{"default", "tenant-a"}
+	announcement.Edge, announcement.NodeSelector = true, "region=bj"
+	err = db.UpdateAnnouncement(announcement)
+	assert.NoError(t, err)
+	res, err = db.GetAnnouncement(announcement.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"default", "tenant-a"}, res.Namespaces)
+	assert.True(t, res.Edge)
+	assert.Equal(t, "region=bj", res.NodeSelector)
+
+	err = db.CreateAnnouncement(&models.Announcement{Name: "earlier", Title: "t", Content: "c", StartTime: start.Add(-time.Hour), EndTime: start})
+	assert.NoError(t, err)
+	list, err := db.ListAnnouncement()
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "earlier", list[0].Name)
+
+	err = db.DeleteAnnouncement(announcement.Name)
+	assert.NoError(t, err)
+	_, err = db.GetAnnouncement(announcement.Name)
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetAppRevision(namespace, name string, revision int64) (*models.AppRevision, error) {
+	selectSQL := `
+SELECT namespace, name, revision, version, content, create_time 
+FROM baetyl_app_history WHERE namespace=? AND name=? AND revision=?
+`
+	var revisions []entities.AppRevision
+	if err := d.Query(nil, selectSQL, &revisions, namespace, name, revision); err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "revision"), common.Field("name", name))
+	}
+	return entities.ToAppRevisionModel(&revisions[0])
+}
+
+func (d *DB) ListAppRevision(namespace, name string) ([]models.AppRevision, error) {
+	selectSQL := `
+SELECT namespace, name, revision, version, create_time 
+FROM baetyl_app_history WHERE namespace=? AND name=? ORDER BY revision DESC
+`
+	var revisions []entities.AppRevision
+	if err := d.Query(nil, selectSQL, &revisions, namespace, name); err != nil {
+		return nil, err
+	}
+	res := make([]models.AppRevision, 0, len(revisions))
+	for i := range revisions {
+		revision, err := entities.ToAppRevisionModel(&revisions[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *revision)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateAppRevision(revision *models.AppRevision) error {
+	insertSQL := `
+INSERT INTO baetyl_app_history (namespace, name, revision, version, content) 
+VALUES (?,?,?,?,?)
+`
+	r, err := entities.FromAppRevisionModel(revision)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, r.Namespace, r.Name, r.Revision, r.Version, r.Content)
+	return err
+}
+
+func (d *DB) DeleteAppRevisionBefore(namespace, name string, revision int64) error {
+	deleteSQL := `DELETE FROM baetyl_app_history WHERE namespace=? AND name=? AND revision<?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name, revision)
+	return err
+}
+
+func (d *DB) DeleteAppRevisions(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_app_history WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	appHistoryTables = []string{
+		`
+CREATE TABLE baetyl_app_history(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    revision    BIGINT NOT NULL DEFAULT 0,
+    version     VARCHAR(36) NOT NULL DEFAULT '',
+    content     TEXT,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name, revision)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateAppHistoryTable() {
+	for _, sql := range appHistoryTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestAppHistory(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateAppHistoryTable()
+
+	_, err = db.GetAppRevision("default", "app01", 1)
+	assert.Error(t, err)
+
+	for i := int64(1); i <= 3; i++ {
+		app := &specV1.Application{Namespace: "default", Name: "app01", Version: fmt.Sprintf("v%d", i)}
+		err = db.CreateAppRevision(&models.AppRevision{Namespace: "default", Name: "app01", Revision: i, Version: app.Version, Application: app})
+		assert.NoError(t, err)
+	}
+	err = db.CreateAppRevision(&models.AppRevision{Namespace: "default", Name: "app01", Revision: 3})
+	assert.Error(t, err)
+	err = db.CreateAppRevision(&models.AppRevision{Namespace: "default", Name: "app02", Revision: 1})
+	assert.NoError(t, err)
+
+	res, err := db.GetAppRevision("default", "app01", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", res.Version)
+	assert.Equal(t, "v2", res.Application.Version)
+	assert.Equal(t, "app01", res.Application.Name)
+
+	list, err := db.ListAppRevision("default", "app01")
+	assert.NoError(t, err)
+	assert.Len(t, list, 3)
+	assert.Equal(t, int64(3), list[0].Revision)
+	assert.Nil(t, list[0].Application)
+
+	err = db.DeleteAppRevisionBefore("default", "app01", 3)
+	assert.NoError(t, err)
+	list, err = db.ListAppRevision("default", "app01")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, int64(3), list[0].Revision)
+
+	err = db.DeleteAppRevisions("default", "app01")
+	assert.NoError(t, err)
+	list, err = db.ListAppRevision("default", "app01")
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+	list, err = db.ListAppRevision("default", "app02")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func (d *DB) Audit(record *models.AuditRecord) error {
+	insertSQL := `
+INSERT INTO baetyl_audit_record 
+(namespace, username, impersonator, method, path, resource, name, request_id, status, code, diff, prev_hash, hash, create_time) 
+VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)
+`
+	_, err := d.Exec(nil, insertSQL, record.Namespace, record.User, record.Impersonator, record.Method, record.Path, record.Resource,
+		record.Name, record.RequestId, record.Status, record.Code, record.Diff, record.PrevHash, record.Hash, record.CreateTime)
+	return err
+}
+
+func (d *DB) GetLastAuditHash() (string, error) {
+	selectSQL := `SELECT hash FROM baetyl_audit_record ORDER BY id DESC LIMIT 1`
+	var hashes []string
+	if err := d.Query(nil, selectSQL, &hashes); err != nil {
+		return "", err
+	}
+	if len(hashes) == 0 {
+		return "", nil
+	}
+	return hashes[0], nil
+}
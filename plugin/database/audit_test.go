@@ -0,0 +1,96 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	auditTables = []string{
+		`
+CREATE TABLE baetyl_audit_record(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    username    VARCHAR(128) NOT NULL DEFAULT '',
+    impersonator VARCHAR(128) NOT NULL DEFAULT '',
+    method      VARCHAR(16) NOT NULL DEFAULT '',
+    path        VARCHAR(512) NOT NULL DEFAULT '',
+    resource    VARCHAR(64) NOT NULL DEFAULT '',
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    request_id  VARCHAR(64) NOT NULL DEFAULT '',
+    status      INTEGER NOT NULL DEFAULT 0,
+    code        VARCHAR(64) NOT NULL DEFAULT '',
+    diff        TEXT,
+    prev_hash   VARCHAR(64) NOT NULL DEFAULT '',
+    hash        VARCHAR(64) NOT NULL DEFAULT '',
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateAuditTable() {
+	for _, sql := range auditTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestAudit(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateAuditTable()
+
+	hash, err := db.GetLastAuditHash()
+	assert.NoError(t, err)
+	assert.Equal(t, "", hash)
+
+	record := &models.AuditRecord{
+		Namespace:  "default",
+		User:       "baetyl",
+		Method:     "PUT",
+		Path:       "/v1/nodes/:name",
+		Resource:   "nodes",
+		Name:       "node01",
+		Status:     200,
+		CreateTime: time.Now(),
+	}
+	record.Seal(hash)
+	err = db.Audit(record)
+	assert.NoError(t, err)
+
+	hash, err = db.GetLastAuditHash()
+	assert.NoError(t, err)
+	assert.Equal(t, record.Hash, hash)
+
+	impersonated := &models.AuditRecord{
+		Namespace:    "default",
+		User:         "baetyl",
+		Impersonator: "support01",
+		Method:       "GET",
+		Path:         "/v1/nodes/:name",
+		Resource:     "nodes",
+		Name:         "node01",
+		Status:       200,
+		CreateTime:   record.CreateTime,
+	}
+	impersonated.Seal(hash)
+	assert.NotEqual(t, record.Hash, impersonated.Hash)
+	err = db.Audit(impersonated)
+	assert.NoError(t, err)
+
+	hash, err = db.GetLastAuditHash()
+	assert.NoError(t, err)
+	assert.Equal(t, impersonated.Hash, hash)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"io"
+	"regexp"
 	"strings"
 	"time"
 
@@ -33,6 +34,13 @@ type DB struct {
 	Log *log.Logger
 }
 
+// the types of database supported, which are the names of the drivers
+const (
+	TypeMySQL    = "mysql"
+	TypeSQLite   = "sqlite3"
+	TypePostgres = "postgres"
+)
+
 func init() {
 	plugin.RegisterFactory("database", New)
 }
@@ -41,6 +49,8 @@ var (
 	HookSQL = func(s string) string {
 		return s
 	}
+
+	limitOffset = regexp.MustCompile(`(?i)LIMIT\s+(\?|\d+)\s*,\s*(\?|\d+)`)
 )
 
 // New New
@@ -75,11 +85,16 @@ func NewDB(cfg CloudConfig) (*DB, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &DB{
+	d := &DB{
 		db:  db,
 		cfg: cfg,
 		Log: log.With(log.Any("plugin", "database")),
-	}, nil
+	}
+	if err = d.initSchema(migrationFS); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return d, nil
 }
 
 func genDecryptedURL(originURL string) (string, error) {
@@ -131,7 +146,7 @@ func (d *DB) Transact(handler func(*sqlx.Tx) error) (err error) {
 }
 
 func (d *DB) Exec(tx *sqlx.Tx, sql string, args ...interface{}) (res sql.Result, err error) {
-	sql = HookSQL(sql)
+	sql = d.rebind(HookSQL(sql))
 	if tx == nil {
 		res, err = d.db.Exec(sql, args...)
 	} else {
@@ -142,7 +157,7 @@ func (d *DB) Exec(tx *sqlx.Tx, sql string, args ...interface{}) (res sql.Result,
 }
 
 func (d *DB) Query(tx *sqlx.Tx, sql string, data interface{}, args ...interface{}) (err error) {
-	sql = HookSQL(sql)
+	sql = d.rebind(HookSQL(sql))
 	if tx == nil {
 		err = d.db.Select(data, sql, args...)
 	} else {
@@ -152,6 +167,27 @@ func (d *DB) Query(tx *sqlx.Tx, sql string, data interface{}, args ...interface{
 	return
 }
 
+// QueryContext is the same as Query, and aborts the query when the context is done
+func (d *DB) QueryContext(ctx context.Context, tx *sqlx.Tx, sql string, data interface{}, args ...interface{}) (err error) {
+	sql = d.rebind(HookSQL(sql))
+	if tx == nil {
+		err = d.db.SelectContext(ctx, data, sql, args...)
+	} else {
+		err = tx.SelectContext(ctx, data, sql, args...)
+	}
+	err = errors.Trace(err)
+	return
+}
+
+// rebind adapts the sql written for mysql to the dialect of database. The sql is the same for mysql and sqlite,
+// and the placeholders and the paging are rewritten for postgres
+func (d *DB) rebind(s string) string {
+	if d.cfg.Database.Type != TypePostgres {
+		return s
+	}
+	return sqlx.Rebind(sqlx.DOLLAR, limitOffset.ReplaceAllString(s, "OFFSET ${1} LIMIT ${2}"))
+}
+
 func (d *DB) BeginTx() (*sqlx.Tx, error) {
 	return d.db.Beginx()
 }
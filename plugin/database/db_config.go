@@ -9,5 +9,12 @@ type CloudConfig struct {
 		MaxConns        int    `yaml:"maxConns" json:"maxConns" default:20`
 		MaxIdleConns    int    `yaml:"maxIdleConns" json:"maxIdleConns" default:5`
 		ConnMaxLifetime int    `yaml:"connMaxLifetime" json:"connMaxLifetime" default:150`
+
+		// AutoMigrate migrates the schema to MigrateVersion on startup, otherwise the startup fails
+		// if the schema is not at the version
+		AutoMigrate bool `yaml:"autoMigrate" json:"autoMigrate"`
+		// MigrateVersion the version of schema required, which is the latest by default. The schema
+		// is rolled back if the version is lower than the one applied
+		MigrateVersion int64 `yaml:"migrateVersion" json:"migrateVersion"`
 	} `yaml:"database" json:"database" default:"{}"`
 }
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetEdgeCluster(namespace, name string) (*models.EdgeCluster, error) {
+	selectSQL := `
+SELECT namespace, name, description, labels, node, create_time, update_time 
+FROM baetyl_edge_cluster WHERE namespace=? AND name=?
+`
+	var clusters []entities.EdgeCluster
+	if err := d.Query(nil, selectSQL, &clusters, namespace, name); err != nil {
+		return nil, err
+	}
+	if len(clusters) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "edgecluster"), common.Field("name", name))
+	}
+	return entities.ToEdgeClusterModel(&clusters[0])
+}
+
+func (d *DB) ListEdgeCluster(namespace string, filter *models.Filter) ([]models.EdgeCluster, error) {
+	selectSQL := `
+SELECT namespace, name, description, labels, node, create_time, update_time 
+FROM baetyl_edge_cluster WHERE namespace=? AND name LIKE ? ORDER BY create_time DESC 
+`
+	args := []interface{}{namespace, filter.GetFuzzyName()}
+	if filter.GetLimitNumber() > 0 {
+		selectSQL = selectSQL + "LIMIT ?,?"
+		args = append(args, filter.GetLimitOffset(), filter.GetLimitNumber())
+	}
+	var clusters []entities.EdgeCluster
+	if err := d.QueryContext(filter.Context(), nil, selectSQL, &clusters, args...); err != nil {
+		return nil, err
+	}
+	res := make([]models.EdgeCluster, 0, len(clusters))
+	for i := range clusters {
+		cluster, err := entities.ToEdgeClusterModel(&clusters[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *cluster)
+	}
+	return res, nil
+}
+
+func (d *DB) CountEdgeCluster(namespace, name string) (int, error) {
+	selectSQL := `
+SELECT count(name) AS count FROM baetyl_edge_cluster WHERE namespace=? AND name LIKE ?
+`
+	var res []struct {
+		Count int `db:"count"`
+	}
+	if err := d.Query(nil, selectSQL, &res, namespace, "%"+name+"%"); err != nil {
+		return 0, err
+	}
+	return res[0].Count, nil
+}
+
+func (d *DB) CreateEdgeCluster(cluster *models.EdgeCluster) error {
+	insertSQL := `
+INSERT INTO baetyl_edge_cluster (namespace, name, description, labels, node) 
+VALUES (?,?,?,?,?)
+`
+	e, err := entities.FromEdgeClusterModel(cluster)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, e.Namespace, e.Name, e.Description, e.Labels, e.Node)
+	return err
+}
+
+func (d *DB) UpdateEdgeCluster(cluster *models.EdgeCluster) error {
+	updateSQL := `
+UPDATE baetyl_edge_cluster SET description=?, labels=? 
+WHERE namespace=? AND name=?
+`
+	e, err := entities.FromEdgeClusterModel(cluster)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, e.Description, e.Labels, e.Namespace, e.Name)
+	return err
+}
+
+func (d *DB) DeleteEdgeCluster(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_edge_cluster WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
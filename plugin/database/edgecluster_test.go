@@ -0,0 +1,99 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	edgeClusterTables = []string{
+		`
+CREATE TABLE baetyl_edge_cluster(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    labels      TEXT,
+    node        VARCHAR(128) NOT NULL DEFAULT '',
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateEdgeClusterTable() {
+	for _, sql := range edgeClusterTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestEdgeCluster(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateEdgeClusterTable()
+
+	cluster := &models.EdgeCluster{
+		Namespace: "default",
+		Name:      "cluster01",
+		Node:      "cluster01",
+	}
+	_, err = db.GetEdgeCluster(cluster.Namespace, cluster.Name)
+	assert.Error(t, err)
+
+	err = db.CreateEdgeCluster(cluster)
+	assert.NoError(t, err)
+	err = db.CreateEdgeCluster(cluster)
+	assert.Error(t, err)
+	err = db.CreateEdgeCluster(&models.EdgeCluster{Namespace: "default", Name: "cluster02", Node: "cluster02", Labels: map[string]string{"city": "bj"}})
+	assert.NoError(t, err)
+
+	res, err := db.GetEdgeCluster(cluster.Namespace, cluster.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "cluster01", res.Node)
+	assert.Nil(t, res.Labels)
+
+	cluster.Description = "desc"
+	cluster.Labels = map[string]string{"city": "sh"}
+	cluster.Node = "other"
+	err = db.UpdateEdgeCluster(cluster)
+	assert.NoError(t, err)
+	res, err = db.GetEdgeCluster(cluster.Namespace, cluster.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "desc", res.Description)
+	assert.Equal(t, map[string]string{"city": "sh"}, res.Labels)
+	// the node of cluster can not be changed
+	assert.Equal(t, "cluster01", res.Node)
+
+	list, err := db.ListEdgeCluster("default", &models.Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	list, err = db.ListEdgeCluster("default", &models.Filter{Name: "02", PageNo: 1, PageSize: 10})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, map[string]string{"city": "bj"}, list[0].Labels)
+	list, err = db.ListEdgeCluster("other", &models.Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	count, err := db.CountEdgeCluster("default", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	err = db.DeleteEdgeCluster(cluster.Namespace, cluster.Name)
+	assert.NoError(t, err)
+	_, err = db.GetEdgeCluster(cluster.Namespace, cluster.Name)
+	assert.Error(t, err)
+}
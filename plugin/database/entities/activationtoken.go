@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type ActivationToken struct {
+	Id          uint64    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Node        string    `db:"node"`
+	NodeGroup   string    `db:"node_group"`
+	MaxUses     int       `db:"max_uses"`
+	Uses        int       `db:"uses"`
+	Placement   string    `db:"placement"`
+	Endpoints   string    `db:"endpoints"`
+	ExpireTime  time.Time `db:"expire_time"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func FromActivationTokenModel(token *models.ActivationToken) (*ActivationToken, error) {
+	res := &ActivationToken{
+		Namespace:   token.Namespace,
+		Name:        token.Name,
+		Description: token.Description,
+		Node:        token.Node,
+		NodeGroup:   token.NodeGroup,
+		MaxUses:     token.MaxUses,
+		Uses:        token.Uses,
+		ExpireTime:  token.ExpireTime.UTC(),
+	}
+	if token.Placement != nil {
+		placement, err := json.Marshal(token.Placement)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		res.Placement = string(placement)
+	}
+	if token.Endpoints != nil {
+		endpoints, err := json.Marshal(token.Endpoints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		res.Endpoints = string(endpoints)
+	}
+	return res, nil
+}
+
+func ToActivationTokenModel(token *ActivationToken) (*models.ActivationToken, error) {
+	res := &models.ActivationToken{
+		Namespace:   token.Namespace,
+		Name:        token.Name,
+		Description: token.Description,
+		Node:        token.Node,
+		NodeGroup:   token.NodeGroup,
+		MaxUses:     token.MaxUses,
+		Uses:        token.Uses,
+		ExpireTime:  token.ExpireTime.UTC(),
+		CreateTime:  token.CreateTime.UTC(),
+	}
+	if token.Placement != "" {
+		res.Placement = new(models.NodePlacement)
+		if err := json.Unmarshal([]byte(token.Placement), res.Placement); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if token.Endpoints != "" {
+		res.Endpoints = new(models.NodeEndpoints)
+		if err := json.Unmarshal([]byte(token.Endpoints), res.Endpoints); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type Announcement struct {
+	Id           uint64    `db:"id"`
+	Name         string    `db:"name"`
+	Title        string    `db:"title"`
+	Content      string    `db:"content"`
+	Level        string    `db:"level"`
+	StartTime    time.Time `db:"start_time"`
+	EndTime      time.Time `db:"end_time"`
+	Namespaces   string    `db:"namespaces"`
+	Edge         bool      `db:"edge"`
+	NodeSelector string    `db:"node_selector"`
+	CreateTime   time.Time `db:"create_time"`
+	UpdateTime   time.Time `db:"update_time"`
+}
+
+func FromAnnouncementModel(announcement *models.Announcement) (*Announcement, error) {
+	res := &Announcement{
+		Name:         announcement.Name,
+		Title:        announcement.Title,
+		Content:      announcement.Content,
+		Level:        announcement.Level,
+		StartTime:    announcement.StartTime.UTC(),
+		EndTime:      announcement.EndTime.UTC(),
+		Edge:         announcement.Edge,
+		NodeSelector: announcement.NodeSelector,
+	}
+	if len(announcement.Namespaces) > 0 {
+		namespaces, err := json.Marshal(announcement.Namespaces)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		res.Namespaces = string(namespaces)
+	}
+	return res, nil
+}
+
+func ToAnnouncementModel(announcement *Announcement) (*models.Announcement, error) {
+	res := &models.Announcement{
+		Name:         announcement.Name,
+		Title:        announcement.Title,
+		Content:      announcement.Content,
+		Level:        announcement.Level,
+		StartTime:    announcement.StartTime.UTC(),
+		EndTime:      announcement.EndTime.UTC(),
+		Edge:         announcement.Edge,
+		NodeSelector: announcement.NodeSelector,
+		CreateTime:   announcement.CreateTime.UTC(),
+		UpdateTime:   announcement.UpdateTime.UTC(),
+	}
+	if announcement.Namespaces != "" {
+		if err := json.Unmarshal([]byte(announcement.Namespaces), &res.Namespaces); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
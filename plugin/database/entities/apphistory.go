@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type AppRevision struct {
+	Id         uint64    `db:"id"`
+	Namespace  string    `db:"namespace"`
+	Name       string    `db:"name"`
+	Revision   int64     `db:"revision"`
+	Version    string    `db:"version"`
+	Content    string    `db:"content"`
+	CreateTime time.Time `db:"create_time"`
+}
+
+func FromAppRevisionModel(revision *models.AppRevision) (*AppRevision, error) {
+	content := ""
+	if revision.Application != nil {
+		data, err := json.Marshal(revision.Application)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		content = string(data)
+	}
+	return &AppRevision{
+		Namespace: revision.Namespace,
+		Name:      revision.Name,
+		Revision:  revision.Revision,
+		Version:   revision.Version,
+		Content:   content,
+	}, nil
+}
+
+func ToAppRevisionModel(revision *AppRevision) (*models.AppRevision, error) {
+	res := &models.AppRevision{
+		Namespace:  revision.Namespace,
+		Name:       revision.Name,
+		Revision:   revision.Revision,
+		Version:    revision.Version,
+		CreateTime: revision.CreateTime.UTC(),
+	}
+	if revision.Content != "" {
+		res.Application = new(specV1.Application)
+		if err := json.Unmarshal([]byte(revision.Content), res.Application); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type AuditRecord struct {
+	Id           uint64    `db:"id"`
+	Namespace    string    `db:"namespace"`
+	User         string    `db:"username"`
+	Impersonator string    `db:"impersonator"`
+	Method       string    `db:"method"`
+	Path         string    `db:"path"`
+	Resource     string    `db:"resource"`
+	Name         string    `db:"name"`
+	RequestId    string    `db:"request_id"`
+	Status       int       `db:"status"`
+	Code         string    `db:"code"`
+	Diff         string    `db:"diff"`
+	PrevHash     string    `db:"prev_hash"`
+	Hash         string    `db:"hash"`
+	CreateTime   time.Time `db:"create_time"`
+}
+
+func ToAuditRecordModel(r *AuditRecord) *models.AuditRecord {
+	return &models.AuditRecord{
+		Id:           r.Id,
+		Namespace:    r.Namespace,
+		User:         r.User,
+		Impersonator: r.Impersonator,
+		Method:       r.Method,
+		Path:         r.Path,
+		Resource:     r.Resource,
+		Name:         r.Name,
+		RequestId:    r.RequestId,
+		Status:       r.Status,
+		Code:         r.Code,
+		Diff:         r.Diff,
+		PrevHash:     r.PrevHash,
+		Hash:         r.Hash,
+		CreateTime:   r.CreateTime.UTC(),
+	}
+}
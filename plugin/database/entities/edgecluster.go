@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type EdgeCluster struct {
+	Id          uint64    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Labels      string    `db:"labels"`
+	Node        string    `db:"node"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func FromEdgeClusterModel(cluster *models.EdgeCluster) (*EdgeCluster, error) {
+	labels := ""
+	if len(cluster.Labels) > 0 {
+		data, err := json.Marshal(cluster.Labels)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		labels = string(data)
+	}
+	return &EdgeCluster{
+		Namespace:   cluster.Namespace,
+		Name:        cluster.Name,
+		Description: cluster.Description,
+		Labels:      labels,
+		Node:        cluster.Node,
+	}, nil
+}
+
+func ToEdgeClusterModel(cluster *EdgeCluster) (*models.EdgeCluster, error) {
+	var labels map[string]string
+	if cluster.Labels != "" {
+		if err := json.Unmarshal([]byte(cluster.Labels), &labels); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &models.EdgeCluster{
+		Namespace:   cluster.Namespace,
+		Name:        cluster.Name,
+		Description: cluster.Description,
+		Labels:      labels,
+		Node:        cluster.Node,
+		CreateTime:  cluster.CreateTime.UTC(),
+		UpdateTime:  cluster.UpdateTime.UTC(),
+	}, nil
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type EnvGroup struct {
+	Id          uint64    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Envs        string    `db:"envs"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func FromEnvGroupModel(group *models.EnvGroup) (*EnvGroup, error) {
+	envs, err := json.Marshal(group.Envs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &EnvGroup{
+		Namespace:   group.Namespace,
+		Name:        group.Name,
+		Description: group.Description,
+		Envs:        string(envs),
+	}, nil
+}
+
+func ToEnvGroupModel(group *EnvGroup) (*models.EnvGroup, error) {
+	res := &models.EnvGroup{
+		Namespace:   group.Namespace,
+		Name:        group.Name,
+		Description: group.Description,
+		CreateTime:  group.CreateTime.UTC(),
+		UpdateTime:  group.UpdateTime.UTC(),
+	}
+	if group.Envs != "" {
+		if err := json.Unmarshal([]byte(group.Envs), &res.Envs); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type FeatureFlag struct {
+	Id          uint64    `db:"id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Enabled     bool      `db:"enabled"`
+	Percentage  int       `db:"percentage"`
+	Overrides   string    `db:"overrides"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func FromFeatureFlagModel(flag *models.FeatureFlag) (*FeatureFlag, error) {
+	res := &FeatureFlag{
+		Name:        flag.Name,
+		Description: flag.Description,
+		Enabled:     flag.Enabled,
+		Percentage:  flag.Percentage,
+	}
+	if len(flag.Overrides) > 0 {
+		overrides, err := json.Marshal(flag.Overrides)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		res.Overrides = string(overrides)
+	}
+	return res, nil
+}
+
+func ToFeatureFlagModel(flag *FeatureFlag) (*models.FeatureFlag, error) {
+	res := &models.FeatureFlag{
+		Name:        flag.Name,
+		Description: flag.Description,
+		Enabled:     flag.Enabled,
+		Percentage:  flag.Percentage,
+		CreateTime:  flag.CreateTime.UTC(),
+		UpdateTime:  flag.UpdateTime.UTC(),
+	}
+	if flag.Overrides != "" {
+		if err := json.Unmarshal([]byte(flag.Overrides), &res.Overrides); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type FunctionBuild struct {
+	Id         uint64    `db:"id"`
+	Namespace  string    `db:"namespace"`
+	Name       string    `db:"name"`
+	Version    string    `db:"version"`
+	Runtime    string    `db:"runtime"`
+	Handler    string    `db:"handler"`
+	Source     string    `db:"source"`
+	Bucket     string    `db:"bucket"`
+	Object     string    `db:"object"`
+	Sha256     string    `db:"sha256"`
+	Size       int64     `db:"size"`
+	Builder    string    `db:"builder"`
+	BuildID    string    `db:"build_id"`
+	Image      string    `db:"image"`
+	Status     string    `db:"status"`
+	Message    string    `db:"message"`
+	CreateTime time.Time `db:"create_time"`
+	UpdateTime time.Time `db:"update_time"`
+}
+
+func FromFunctionBuildModel(build *models.FunctionBuild) *FunctionBuild {
+	return &FunctionBuild{
+		Namespace: build.Namespace,
+		Name:      build.Name,
+		Version:   build.Version,
+		Runtime:   build.Runtime,
+		Handler:   build.Handler,
+		Source:    build.Source,
+		Bucket:    build.Bucket,
+		Object:    build.Object,
+		Sha256:    build.Sha256,
+		Size:      build.Size,
+		Builder:   build.Builder,
+		BuildID:   build.BuildID,
+		Image:     build.Image,
+		Status:    build.Status,
+		Message:   build.Message,
+	}
+}
+
+func ToFunctionBuildModel(build *FunctionBuild) *models.FunctionBuild {
+	return &models.FunctionBuild{
+		Namespace:  build.Namespace,
+		Name:       build.Name,
+		Version:    build.Version,
+		Runtime:    build.Runtime,
+		Handler:    build.Handler,
+		Source:     build.Source,
+		Bucket:     build.Bucket,
+		Object:     build.Object,
+		Sha256:     build.Sha256,
+		Size:       build.Size,
+		Builder:    build.Builder,
+		BuildID:    build.BuildID,
+		Image:      build.Image,
+		Status:     build.Status,
+		Message:    build.Message,
+		CreateTime: build.CreateTime.UTC(),
+		UpdateTime: build.UpdateTime.UTC(),
+	}
+}
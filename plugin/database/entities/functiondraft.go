@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type FunctionDraft struct {
+	Id          uint64    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Runtime     string    `db:"runtime"`
+	Handler     string    `db:"handler"`
+	Description string    `db:"description"`
+	Files       string    `db:"files"`
+	Env         string    `db:"env"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func FromFunctionDraftModel(draft *models.FunctionDraft) (*FunctionDraft, error) {
+	files, err := json.Marshal(draft.Files)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env, err := json.Marshal(draft.Env)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FunctionDraft{
+		Namespace:   draft.Namespace,
+		Name:        draft.Name,
+		Runtime:     draft.Runtime,
+		Handler:     draft.Handler,
+		Description: draft.Description,
+		Files:       string(files),
+		Env:         string(env),
+	}, nil
+}
+
+func ToFunctionDraftModel(draft *FunctionDraft) (*models.FunctionDraft, error) {
+	res := &models.FunctionDraft{
+		Namespace:   draft.Namespace,
+		Name:        draft.Name,
+		Runtime:     draft.Runtime,
+		Handler:     draft.Handler,
+		Description: draft.Description,
+		CreateTime:  draft.CreateTime.UTC(),
+		UpdateTime:  draft.UpdateTime.UTC(),
+	}
+	if draft.Files != "" {
+		if err := json.Unmarshal([]byte(draft.Files), &res.Files); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if draft.Env != "" {
+		if err := json.Unmarshal([]byte(draft.Env), &res.Env); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
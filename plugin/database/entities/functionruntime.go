@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type FunctionRuntime struct {
+	Id          uint64    `db:"id"`
+	Name        string    `db:"name"`
+	Language    string    `db:"language"`
+	Version     string    `db:"version"`
+	Type        string    `db:"type"`
+	Image       string    `db:"image"`
+	GPUImage    string    `db:"gpu_image"`
+	Handler     string    `db:"handler"`
+	Description string    `db:"description"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func FromFunctionRuntimeModel(runtime *models.FunctionRuntime) *FunctionRuntime {
+	return &FunctionRuntime{
+		Name:        runtime.Name,
+		Language:    runtime.Language,
+		Version:     runtime.Version,
+		Type:        runtime.Type,
+		Image:       runtime.Image,
+		GPUImage:    runtime.GPUImage,
+		Handler:     runtime.Handler,
+		Description: runtime.Description,
+	}
+}
+
+func ToFunctionRuntimeModel(runtime *FunctionRuntime) *models.FunctionRuntime {
+	return &models.FunctionRuntime{
+		Name:        runtime.Name,
+		Language:    runtime.Language,
+		Version:     runtime.Version,
+		Type:        runtime.Type,
+		Image:       runtime.Image,
+		GPUImage:    runtime.GPUImage,
+		Handler:     runtime.Handler,
+		Description: runtime.Description,
+		CreateTime:  runtime.CreateTime.UTC(),
+		UpdateTime:  runtime.UpdateTime.UTC(),
+	}
+}
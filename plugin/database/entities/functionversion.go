@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type FunctionVersion struct {
+	Id          uint64    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Version     string    `db:"version"`
+	Runtime     string    `db:"runtime"`
+	Handler     string    `db:"handler"`
+	Description string    `db:"description"`
+	Source      string    `db:"source"`
+	Bucket      string    `db:"bucket"`
+	Object      string    `db:"object"`
+	Sha256      string    `db:"sha256"`
+	Size        int64     `db:"size"`
+	Flavor      string    `db:"flavor"`
+	Env         string    `db:"env"`
+	CreateTime  time.Time `db:"create_time"`
+}
+
+func FromFunctionVersionModel(version *models.FunctionVersion) (*FunctionVersion, error) {
+	env, err := json.Marshal(version.Env)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FunctionVersion{
+		Namespace:   version.Namespace,
+		Name:        version.Name,
+		Version:     version.Version,
+		Runtime:     version.Runtime,
+		Handler:     version.Handler,
+		Description: version.Description,
+		Source:      version.Source,
+		Bucket:      version.Bucket,
+		Object:      version.Object,
+		Sha256:      version.Sha256,
+		Size:        version.Size,
+		Flavor:      version.Flavor,
+		Env:         string(env),
+	}, nil
+}
+
+func ToFunctionVersionModel(version *FunctionVersion) (*models.FunctionVersion, error) {
+	res := &models.FunctionVersion{
+		Namespace:   version.Namespace,
+		Name:        version.Name,
+		Version:     version.Version,
+		Runtime:     version.Runtime,
+		Handler:     version.Handler,
+		Description: version.Description,
+		Source:      version.Source,
+		Bucket:      version.Bucket,
+		Object:      version.Object,
+		Sha256:      version.Sha256,
+		Size:        version.Size,
+		Flavor:      version.Flavor,
+		CreateTime:  version.CreateTime.UTC(),
+	}
+	if version.Env != "" {
+		if err := json.Unmarshal([]byte(version.Env), &res.Env); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
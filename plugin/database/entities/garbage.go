@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type GarbageShadow struct {
+	Namespace  string    `db:"namespace"`
+	Name       string    `db:"name"`
+	CreateTime time.Time `db:"create_time"`
+}
+
+type GarbageCert struct {
+	CertId     string    `db:"cert_id"`
+	CommonName string    `db:"common_name"`
+	NotAfter   time.Time `db:"not_after"`
+}
+
+func ToGarbageShadowModel(shadow *GarbageShadow) models.GarbageItem {
+	return models.GarbageItem{
+		Kind:      models.GarbageShadow,
+		Namespace: shadow.Namespace,
+		Name:      shadow.Name,
+		Reason:    "the node of shadow no longer exists",
+		Time:      shadow.CreateTime.UTC(),
+	}
+}
+
+func ToGarbageCertModel(cert *GarbageCert) models.GarbageItem {
+	return models.GarbageItem{
+		Kind:   models.GarbageCert,
+		Name:   cert.CertId,
+		Reason: fmt.Sprintf("the certificate (%s) is expired and referenced by no secret", cert.CommonName),
+		Time:   cert.NotAfter.UTC(),
+	}
+}
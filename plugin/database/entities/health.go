@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type HealthThreshold struct {
+	Id            uint64    `db:"id"`
+	Namespace     string    `db:"namespace"`
+	WarningScore  int       `db:"warning_score"`
+	CriticalScore int       `db:"critical_score"`
+	Webhook       string    `db:"webhook"`
+	CreateTime    time.Time `db:"create_time"`
+	UpdateTime    time.Time `db:"update_time"`
+}
+
+func FromHealthThresholdModel(threshold *models.HealthThreshold) *HealthThreshold {
+	return &HealthThreshold{
+		Namespace:     threshold.Namespace,
+		WarningScore:  threshold.WarningScore,
+		CriticalScore: threshold.CriticalScore,
+		Webhook:       threshold.Webhook,
+	}
+}
+
+func ToHealthThresholdModel(threshold *HealthThreshold) *models.HealthThreshold {
+	return &models.HealthThreshold{
+		Namespace:     threshold.Namespace,
+		WarningScore:  threshold.WarningScore,
+		CriticalScore: threshold.CriticalScore,
+		Webhook:       threshold.Webhook,
+		CreateTime:    threshold.CreateTime.UTC(),
+		UpdateTime:    threshold.UpdateTime.UTC(),
+	}
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type HeartbeatPolicy struct {
+	Id             uint64    `db:"id"`
+	Namespace      string    `db:"namespace"`
+	OfflineTimeout int       `db:"offline_timeout"`
+	Webhook        string    `db:"webhook"`
+	CreateTime     time.Time `db:"create_time"`
+	UpdateTime     time.Time `db:"update_time"`
+}
+
+type NodeEvent struct {
+	Id         uint64    `db:"id"`
+	Namespace  string    `db:"namespace"`
+	Node       string    `db:"node"`
+	Type       string    `db:"type"`
+	EventTime  time.Time `db:"event_time"`
+	ReportTime time.Time `db:"report_time"`
+}
+
+func FromHeartbeatPolicyModel(policy *models.HeartbeatPolicy) *HeartbeatPolicy {
+	return &HeartbeatPolicy{
+		Namespace:      policy.Namespace,
+		OfflineTimeout: policy.OfflineTimeout,
+		Webhook:        policy.Webhook,
+	}
+}
+
+func ToHeartbeatPolicyModel(policy *HeartbeatPolicy) *models.HeartbeatPolicy {
+	return &models.HeartbeatPolicy{
+		Namespace:      policy.Namespace,
+		OfflineTimeout: policy.OfflineTimeout,
+		Webhook:        policy.Webhook,
+		CreateTime:     policy.CreateTime.UTC(),
+		UpdateTime:     policy.UpdateTime.UTC(),
+	}
+}
+
+func FromNodeEventModel(event *models.NodeEvent) *NodeEvent {
+	return &NodeEvent{
+		Namespace:  event.Namespace,
+		Node:       event.Node,
+		Type:       event.Type,
+		EventTime:  event.Time.UTC(),
+		ReportTime: event.ReportTime.UTC(),
+	}
+}
+
+func ToNodeEventModel(event *NodeEvent) models.NodeEvent {
+	return models.NodeEvent{
+		Id:         event.Id,
+		Namespace:  event.Namespace,
+		Node:       event.Node,
+		Type:       event.Type,
+		Time:       event.EventTime.UTC(),
+		ReportTime: event.ReportTime.UTC(),
+	}
+}
@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type HelmRelease struct {
+	Id           uint64    `db:"id"`
+	Namespace    string    `db:"namespace"`
+	Name         string    `db:"name"`
+	Chart        string    `db:"chart"`
+	ChartVersion string    `db:"chart_version"`
+	AppVersion   string    `db:"app_version"`
+	URL          string    `db:"url"`
+	Values       string    `db:"chart_values"`
+	Revision     int       `db:"revision"`
+	Resources    string    `db:"resources"`
+	Archive      string    `db:"archive"`
+	CreateTime   time.Time `db:"create_time"`
+	UpdateTime   time.Time `db:"update_time"`
+}
+
+func FromHelmReleaseModel(release *models.HelmRelease) (*HelmRelease, error) {
+	values, err := json.Marshal(release.Values)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resources, err := json.Marshal(release.Resources)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &HelmRelease{
+		Namespace:    release.Namespace,
+		Name:         release.Name,
+		Chart:        release.Chart,
+		ChartVersion: release.ChartVersion,
+		AppVersion:   release.AppVersion,
+		URL:          release.URL,
+		Values:       string(values),
+		Revision:     release.Revision,
+		Resources:    string(resources),
+		Archive:      base64.StdEncoding.EncodeToString(release.Archive),
+	}, nil
+}
+
+func ToHelmReleaseModel(release *HelmRelease) (*models.HelmRelease, error) {
+	res := &models.HelmRelease{
+		Namespace:    release.Namespace,
+		Name:         release.Name,
+		Chart:        release.Chart,
+		ChartVersion: release.ChartVersion,
+		AppVersion:   release.AppVersion,
+		URL:          release.URL,
+		Revision:     release.Revision,
+		CreateTime:   release.CreateTime.UTC(),
+		UpdateTime:   release.UpdateTime.UTC(),
+	}
+	if release.Values != "" {
+		if err := json.Unmarshal([]byte(release.Values), &res.Values); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if release.Resources != "" {
+		if err := json.Unmarshal([]byte(release.Resources), &res.Resources); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	archive, err := base64.StdEncoding.DecodeString(release.Archive)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res.Archive = archive
+	return res, nil
+}
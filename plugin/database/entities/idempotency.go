@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type IdempotencyRecord struct {
+	Id          uint64    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	Key         string    `db:"idempotency_key"`
+	RequestHash string    `db:"request_hash"`
+	Status      int       `db:"status"`
+	ContentType string    `db:"content_type"`
+	Response    []byte    `db:"response"`
+	ExpireTime  time.Time `db:"expire_time"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func ToIdempotencyRecordModel(r *IdempotencyRecord) *models.IdempotencyRecord {
+	return &models.IdempotencyRecord{
+		Namespace:   r.Namespace,
+		Key:         r.Key,
+		RequestHash: r.RequestHash,
+		Status:      r.Status,
+		ContentType: r.ContentType,
+		Response:    r.Response,
+		ExpireTime:  r.ExpireTime.UTC(),
+	}
+}
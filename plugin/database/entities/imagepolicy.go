@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type ImagePolicy struct {
+	Id         uint64    `db:"id"`
+	Namespace  string    `db:"namespace"`
+	Mirror     string    `db:"mirror"`
+	Registries string    `db:"registries"`
+	PullPolicy string    `db:"pull_policy"`
+	CreateTime time.Time `db:"create_time"`
+	UpdateTime time.Time `db:"update_time"`
+}
+
+func FromImagePolicyModel(policy *models.ImagePolicy) (*ImagePolicy, error) {
+	registries, err := json.Marshal(policy.Registries)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ImagePolicy{
+		Namespace:  policy.Namespace,
+		Mirror:     policy.Mirror,
+		Registries: string(registries),
+		PullPolicy: policy.PullPolicy,
+	}, nil
+}
+
+func ToImagePolicyModel(policy *ImagePolicy) (*models.ImagePolicy, error) {
+	res := &models.ImagePolicy{
+		Namespace:  policy.Namespace,
+		Mirror:     policy.Mirror,
+		PullPolicy: policy.PullPolicy,
+		CreateTime: policy.CreateTime.UTC(),
+		UpdateTime: policy.UpdateTime.UTC(),
+	}
+	if policy.Registries != "" {
+		if err := json.Unmarshal([]byte(policy.Registries), &res.Registries); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
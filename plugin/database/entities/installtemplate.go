@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type InstallTemplate struct {
+	Id          uint64    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Content     string    `db:"content"`
+	Values      string    `db:"template_values"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func FromInstallTemplateModel(tpl *models.InstallTemplate) (*InstallTemplate, error) {
+	res := &InstallTemplate{
+		Namespace:   tpl.Namespace,
+		Name:        tpl.Name,
+		Description: tpl.Description,
+		Content:     tpl.Content,
+	}
+	if len(tpl.Values) > 0 {
+		values, err := json.Marshal(tpl.Values)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		res.Values = string(values)
+	}
+	return res, nil
+}
+
+func ToInstallTemplateModel(tpl *InstallTemplate) (*models.InstallTemplate, error) {
+	res := &models.InstallTemplate{
+		Namespace:   tpl.Namespace,
+		Name:        tpl.Name,
+		Description: tpl.Description,
+		Content:     tpl.Content,
+		CreateTime:  tpl.CreateTime.UTC(),
+		UpdateTime:  tpl.UpdateTime.UTC(),
+	}
+	if tpl.Values != "" {
+		if err := json.Unmarshal([]byte(tpl.Values), &res.Values); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
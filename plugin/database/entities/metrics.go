@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type NodeMetrics struct {
+	Id             uint64    `db:"id"`
+	Namespace      string    `db:"namespace"`
+	Name           string    `db:"name"`
+	CPUUsage       float64   `db:"cpu_usage"`
+	CPUCapacity    float64   `db:"cpu_capacity"`
+	MemoryUsage    int64     `db:"memory_usage"`
+	MemoryCapacity int64     `db:"memory_capacity"`
+	DiskUsage      int64     `db:"disk_usage"`
+	DiskCapacity   int64     `db:"disk_capacity"`
+	ReportTime     time.Time `db:"report_time"`
+}
+
+func FromNodeMetricsModel(namespace, name string, point *models.NodeMetricsPoint) *NodeMetrics {
+	return &NodeMetrics{
+		Namespace:      namespace,
+		Name:           name,
+		CPUUsage:       point.CPUUsage,
+		CPUCapacity:    point.CPUCapacity,
+		MemoryUsage:    point.MemoryUsage,
+		MemoryCapacity: point.MemoryCapacity,
+		DiskUsage:      point.DiskUsage,
+		DiskCapacity:   point.DiskCapacity,
+		ReportTime:     point.Time.UTC(),
+	}
+}
+
+func ToNodeMetricsModel(metrics *NodeMetrics) models.NodeMetricsPoint {
+	return models.NodeMetricsPoint{
+		Time:           metrics.ReportTime.UTC(),
+		CPUUsage:       metrics.CPUUsage,
+		CPUCapacity:    metrics.CPUCapacity,
+		MemoryUsage:    metrics.MemoryUsage,
+		MemoryCapacity: metrics.MemoryCapacity,
+		DiskUsage:      metrics.DiskUsage,
+		DiskCapacity:   metrics.DiskCapacity,
+	}
+}
@@ -21,6 +21,10 @@ type Module struct {
 	Description string    `db:"description"`
 	CreateTime  time.Time `db:"create_time"`
 	UpdateTime  time.Time `db:"update_time"`
+	// the release notes, deprecation and constraint of the version in the catalog
+	ReleaseNotes    string `db:"release_notes"`
+	Deprecated      bool   `db:"deprecated"`
+	MinCloudVersion string `db:"min_cloud_version"`
 }
 
 func ToModuleModel(module *Module) (*models.Module, error) {
@@ -35,6 +39,9 @@ func ToModuleModel(module *Module) (*models.Module, error) {
 		Description:       module.Description,
 		CreationTimestamp: module.CreateTime,
 		UpdateTimestamp:   module.UpdateTime,
+		ReleaseNotes:      module.ReleaseNotes,
+		Deprecated:        module.Deprecated,
+		MinCloudVersion:   module.MinCloudVersion,
 	}
 
 	if module.Programs != "" {
@@ -69,6 +76,10 @@ func FromModuleModel(module *models.Module) (*Module, error) {
 		Description: module.Description,
 		CreateTime:  time.Time{},
 		UpdateTime:  time.Time{},
+		// the release notes, deprecation and constraint of the version in the catalog
+		ReleaseNotes:    module.ReleaseNotes,
+		Deprecated:      module.Deprecated,
+		MinCloudVersion: module.MinCloudVersion,
 	}
 	return app, nil
 }
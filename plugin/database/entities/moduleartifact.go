@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type ModuleArtifacts struct {
+	Id         uint64    `db:"id"`
+	Name       string    `db:"name"`
+	Version    string    `db:"version"`
+	Image      string    `db:"image"`
+	Artifacts  string    `db:"artifacts"`
+	CreateTime time.Time `db:"create_time"`
+	UpdateTime time.Time `db:"update_time"`
+}
+
+func FromModuleArtifactsModel(artifacts *models.ModuleArtifacts) (*ModuleArtifacts, error) {
+	data, err := json.Marshal(artifacts.Artifacts)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModuleArtifacts{
+		Name:      artifacts.Name,
+		Version:   artifacts.Version,
+		Image:     artifacts.Image,
+		Artifacts: string(data),
+	}, nil
+}
+
+func ToModuleArtifactsModel(artifacts *ModuleArtifacts) (*models.ModuleArtifacts, error) {
+	res := &models.ModuleArtifacts{
+		Name:       artifacts.Name,
+		Version:    artifacts.Version,
+		Image:      artifacts.Image,
+		CreateTime: artifacts.CreateTime.UTC(),
+		UpdateTime: artifacts.UpdateTime.UTC(),
+	}
+	if artifacts.Artifacts != "" {
+		if err := json.Unmarshal([]byte(artifacts.Artifacts), &res.Artifacts); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
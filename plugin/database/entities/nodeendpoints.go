@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type NodeEndpoints struct {
+	Id            uint64    `db:"id"`
+	Namespace     string    `db:"namespace"`
+	InitServer    string    `db:"init_server"`
+	SyncServer    string    `db:"sync_server"`
+	SyncMQTT      string    `db:"sync_mqtt"`
+	ObjectStorage string    `db:"object_storage"`
+	CreateTime    time.Time `db:"create_time"`
+	UpdateTime    time.Time `db:"update_time"`
+}
+
+func FromNodeEndpointsModel(endpoints *models.NodeEndpoints) *NodeEndpoints {
+	return &NodeEndpoints{
+		Namespace:     endpoints.Namespace,
+		InitServer:    endpoints.InitServer,
+		SyncServer:    endpoints.SyncServer,
+		SyncMQTT:      endpoints.SyncMQTT,
+		ObjectStorage: endpoints.ObjectStorage,
+	}
+}
+
+func ToNodeEndpointsModel(endpoints *NodeEndpoints) *models.NodeEndpoints {
+	return &models.NodeEndpoints{
+		Namespace:     endpoints.Namespace,
+		InitServer:    endpoints.InitServer,
+		SyncServer:    endpoints.SyncServer,
+		SyncMQTT:      endpoints.SyncMQTT,
+		ObjectStorage: endpoints.ObjectStorage,
+		CreateTime:    endpoints.CreateTime.UTC(),
+		UpdateTime:    endpoints.UpdateTime.UTC(),
+	}
+}
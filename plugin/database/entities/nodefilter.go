@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type NodeFilter struct {
+	Id          uint64    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	User        string    `db:"user_id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Selector    string    `db:"selector"`
+	Query       string    `db:"query"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func FromNodeFilterModel(filter *models.NodeFilter) (*NodeFilter, error) {
+	query, err := json.Marshal(filter.Query)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &NodeFilter{
+		Namespace:   filter.Namespace,
+		User:        filter.User,
+		Name:        filter.Name,
+		Description: filter.Description,
+		Selector:    filter.Selector,
+		Query:       string(query),
+	}, nil
+}
+
+func ToNodeFilterModel(filter *NodeFilter) (*models.NodeFilter, error) {
+	res := &models.NodeFilter{
+		Namespace:   filter.Namespace,
+		User:        filter.User,
+		Name:        filter.Name,
+		Description: filter.Description,
+		Selector:    filter.Selector,
+		CreateTime:  filter.CreateTime.UTC(),
+		UpdateTime:  filter.UpdateTime.UTC(),
+	}
+	if filter.Query != "" {
+		if err := json.Unmarshal([]byte(filter.Query), &res.Query); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type NodeGroup struct {
+	Id          uint64    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Selector    string    `db:"selector"`
+	Nodes       string    `db:"nodes"`
+	Maintenance string    `db:"maintenance_window"`
+	Interval    string    `db:"sync_interval"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func FromNodeGroupModel(group *models.NodeGroup) (*NodeGroup, error) {
+	nodes := ""
+	if len(group.Nodes) > 0 {
+		data, err := json.Marshal(group.Nodes)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		nodes = string(data)
+	}
+	maintenance := ""
+	if group.MaintenanceWindow != nil {
+		data, err := json.Marshal(group.MaintenanceWindow)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		maintenance = string(data)
+	}
+	interval := ""
+	if group.SyncInterval != nil {
+		data, err := json.Marshal(group.SyncInterval)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		interval = string(data)
+	}
+	return &NodeGroup{
+		Namespace:   group.Namespace,
+		Name:        group.Name,
+		Description: group.Description,
+		Selector:    group.Selector,
+		Nodes:       nodes,
+		Maintenance: maintenance,
+		Interval:    interval,
+	}, nil
+}
+
+func ToNodeGroupModel(group *NodeGroup) (*models.NodeGroup, error) {
+	var nodes []string
+	if group.Nodes != "" {
+		if err := json.Unmarshal([]byte(group.Nodes), &nodes); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	res := &models.NodeGroup{
+		Namespace:   group.Namespace,
+		Name:        group.Name,
+		Description: group.Description,
+		Selector:    group.Selector,
+		Nodes:       nodes,
+		CreateTime:  group.CreateTime.UTC(),
+		UpdateTime:  group.UpdateTime.UTC(),
+	}
+	if group.Maintenance != "" {
+		res.MaintenanceWindow = new(models.MaintenanceWindow)
+		if err := json.Unmarshal([]byte(group.Maintenance), res.MaintenanceWindow); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if group.Interval != "" {
+		res.SyncInterval = new(models.SyncInterval)
+		if err := json.Unmarshal([]byte(group.Interval), res.SyncInterval); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
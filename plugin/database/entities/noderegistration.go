@@ -0,0 +1,142 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type NodeRegistration struct {
+	Id              uint64    `db:"id"`
+	Namespace       string    `db:"namespace"`
+	Name            string    `db:"name"`
+	Fingerprint     string    `db:"fingerprint"`
+	Hardware        string    `db:"hardware"`
+	Mode            string    `db:"mode"`
+	Token           string    `db:"token"`
+	NodeGroup       string    `db:"node_group"`
+	Labels          string    `db:"labels"`
+	Status          string    `db:"status"`
+	Reason          string    `db:"reason"`
+	Rule            string    `db:"rule"`
+	ActivationToken string    `db:"activation_token"`
+	CreateTime      time.Time `db:"create_time"`
+	UpdateTime      time.Time `db:"update_time"`
+}
+
+type NodeRegistrationRule struct {
+	Id          uint64    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Fingerprint string    `db:"fingerprint"`
+	Hardware    string    `db:"hardware"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func FromNodeRegistrationModel(registration *models.NodeRegistration) (*NodeRegistration, error) {
+	hardware, err := fromStringMap(registration.Hardware)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := fromStringMap(registration.Labels)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistration{
+		Namespace:       registration.Namespace,
+		Name:            registration.Name,
+		Fingerprint:     registration.Fingerprint,
+		Hardware:        hardware,
+		Mode:            registration.Mode,
+		Token:           registration.Token,
+		NodeGroup:       registration.NodeGroup,
+		Labels:          labels,
+		Status:          registration.Status,
+		Reason:          registration.Reason,
+		Rule:            registration.Rule,
+		ActivationToken: registration.ActivationToken,
+	}, nil
+}
+
+func ToNodeRegistrationModel(registration *NodeRegistration) (*models.NodeRegistration, error) {
+	hardware, err := toStringMap(registration.Hardware)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := toStringMap(registration.Labels)
+	if err != nil {
+		return nil, err
+	}
+	return &models.NodeRegistration{
+		Namespace:       registration.Namespace,
+		Name:            registration.Name,
+		Fingerprint:     registration.Fingerprint,
+		Hardware:        hardware,
+		Mode:            registration.Mode,
+		Token:           registration.Token,
+		NodeGroup:       registration.NodeGroup,
+		Labels:          labels,
+		Status:          registration.Status,
+		Reason:          registration.Reason,
+		Rule:            registration.Rule,
+		ActivationToken: registration.ActivationToken,
+		CreateTime:      registration.CreateTime.UTC(),
+		UpdateTime:      registration.UpdateTime.UTC(),
+	}, nil
+}
+
+func FromNodeRegistrationRuleModel(rule *models.NodeRegistrationRule) (*NodeRegistrationRule, error) {
+	hardware, err := fromStringMap(rule.Hardware)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistrationRule{
+		Namespace:   rule.Namespace,
+		Name:        rule.Name,
+		Description: rule.Description,
+		Fingerprint: rule.Fingerprint,
+		Hardware:    hardware,
+	}, nil
+}
+
+func ToNodeRegistrationRuleModel(rule *NodeRegistrationRule) (*models.NodeRegistrationRule, error) {
+	hardware, err := toStringMap(rule.Hardware)
+	if err != nil {
+		return nil, err
+	}
+	return &models.NodeRegistrationRule{
+		Namespace:   rule.Namespace,
+		Name:        rule.Name,
+		Description: rule.Description,
+		Fingerprint: rule.Fingerprint,
+		Hardware:    hardware,
+		CreateTime:  rule.CreateTime.UTC(),
+	}, nil
+}
+
+func fromStringMap(m map[string]string) (string, error) {
+	if len(m) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+func toStringMap(data string) (map[string]string, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var m map[string]string
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
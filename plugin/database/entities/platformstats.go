@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type SyncTraffic struct {
+	Kind          string `db:"kind"`
+	Total         int64  `db:"total"`
+	Failures      int64  `db:"failures"`
+	RequestBytes  int64  `db:"request_bytes"`
+	ResponseBytes int64  `db:"response_bytes"`
+}
+
+type TableSize struct {
+	Name       string `db:"name"`
+	Rows       int64  `db:"table_rows"`
+	DataBytes  int64  `db:"data_bytes"`
+	IndexBytes int64  `db:"index_bytes"`
+}
+
+func ToSyncTrafficModel(t *SyncTraffic) models.SyncTraffic {
+	return models.SyncTraffic{
+		Kind:          t.Kind,
+		Total:         t.Total,
+		Failures:      t.Failures,
+		RequestBytes:  t.RequestBytes,
+		ResponseBytes: t.ResponseBytes,
+	}
+}
+
+func ToTableSizeModel(t *TableSize) models.TableSize {
+	return models.TableSize{
+		Name:       t.Name,
+		Rows:       t.Rows,
+		DataBytes:  t.DataBytes,
+		IndexBytes: t.IndexBytes,
+	}
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type Quarantine struct {
+	Id         uint64    `db:"id"`
+	Name       string    `db:"name"`
+	Type       string    `db:"type"`
+	Namespace  string    `db:"namespace"`
+	App        string    `db:"app"`
+	Image      string    `db:"image"`
+	Reason     string    `db:"reason"`
+	Operator   string    `db:"operator"`
+	CreateTime time.Time `db:"create_time"`
+	UpdateTime time.Time `db:"update_time"`
+}
+
+func FromQuarantineModel(quarantine *models.Quarantine) *Quarantine {
+	return &Quarantine{
+		Name:      quarantine.Name,
+		Type:      quarantine.Type,
+		Namespace: quarantine.Namespace,
+		App:       quarantine.App,
+		Image:     quarantine.Image,
+		Reason:    quarantine.Reason,
+		Operator:  quarantine.Operator,
+	}
+}
+
+func ToQuarantineModel(quarantine *Quarantine) *models.Quarantine {
+	return &models.Quarantine{
+		Name:       quarantine.Name,
+		Type:       quarantine.Type,
+		Namespace:  quarantine.Namespace,
+		App:        quarantine.App,
+		Image:      quarantine.Image,
+		Reason:     quarantine.Reason,
+		Operator:   quarantine.Operator,
+		CreateTime: quarantine.CreateTime.UTC(),
+		UpdateTime: quarantine.UpdateTime.UTC(),
+	}
+}
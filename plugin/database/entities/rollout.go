@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type Rollout struct {
+	Id         uint64    `db:"id"`
+	Namespace  string    `db:"namespace"`
+	App        string    `db:"app"`
+	Version    string    `db:"version"`
+	Strategy   string    `db:"strategy"`
+	Stage      int       `db:"stage"`
+	Status     string    `db:"status"`
+	Nodes      string    `db:"nodes"`
+	Failed     string    `db:"failed"`
+	Message    string    `db:"message"`
+	StageTime  time.Time `db:"stage_time"`
+	CreateTime time.Time `db:"create_time"`
+	UpdateTime time.Time `db:"update_time"`
+}
+
+func FromRolloutModel(rollout *models.Rollout) (*Rollout, error) {
+	strategy, err := json.Marshal(rollout.Strategy)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nodes, err := json.Marshal(rollout.Nodes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	failed := ""
+	if len(rollout.Failed) > 0 {
+		data, err := json.Marshal(rollout.Failed)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		failed = string(data)
+	}
+	return &Rollout{
+		Namespace: rollout.Namespace,
+		App:       rollout.App,
+		Version:   rollout.Version,
+		Strategy:  string(strategy),
+		Stage:     rollout.Stage,
+		Status:    rollout.Status,
+		Nodes:     string(nodes),
+		Failed:    failed,
+		Message:   rollout.Message,
+		StageTime: rollout.StageTime,
+	}, nil
+}
+
+func ToRolloutModel(rollout *Rollout) (*models.Rollout, error) {
+	res := &models.Rollout{
+		Namespace:  rollout.Namespace,
+		App:        rollout.App,
+		Version:    rollout.Version,
+		Stage:      rollout.Stage,
+		Status:     rollout.Status,
+		Message:    rollout.Message,
+		StageTime:  rollout.StageTime.UTC(),
+		CreateTime: rollout.CreateTime.UTC(),
+		UpdateTime: rollout.UpdateTime.UTC(),
+	}
+	if err := json.Unmarshal([]byte(rollout.Strategy), &res.Strategy); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if rollout.Nodes != "" {
+		if err := json.Unmarshal([]byte(rollout.Nodes), &res.Nodes); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if rollout.Failed != "" {
+		if err := json.Unmarshal([]byte(rollout.Failed), &res.Failed); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type SecretRotation struct {
+	Id         uint64    `db:"id"`
+	Namespace  string    `db:"namespace"`
+	Name       string    `db:"name"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	Rotator    string    `db:"rotator"`
+	Interval   string    `db:"rotate_interval"`
+	Keys       string    `db:"rotate_keys"`
+	Webhook    string    `db:"webhook"`
+	Status     string    `db:"status"`
+	RotateTime time.Time `db:"rotate_time"`
+	CreateTime time.Time `db:"create_time"`
+	UpdateTime time.Time `db:"update_time"`
+}
+
+func FromSecretRotationModel(rotation *models.SecretRotation) (*SecretRotation, error) {
+	keys, err := json.Marshal(rotation.Keys)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SecretRotation{
+		Namespace:  rotation.Namespace,
+		Name:       rotation.Name,
+		ExpiresAt:  rotation.ExpiresAt,
+		Rotator:    rotation.Rotator,
+		Interval:   rotation.Interval,
+		Keys:       string(keys),
+		Webhook:    rotation.Webhook,
+		Status:     rotation.Status,
+		RotateTime: rotation.RotateTime,
+	}, nil
+}
+
+func ToSecretRotationModel(rotation *SecretRotation) (*models.SecretRotation, error) {
+	res := &models.SecretRotation{
+		Namespace:  rotation.Namespace,
+		Name:       rotation.Name,
+		ExpiresAt:  rotation.ExpiresAt.UTC(),
+		Rotator:    rotation.Rotator,
+		Interval:   rotation.Interval,
+		Webhook:    rotation.Webhook,
+		Status:     rotation.Status,
+		RotateTime: rotation.RotateTime.UTC(),
+		CreateTime: rotation.CreateTime.UTC(),
+		UpdateTime: rotation.UpdateTime.UTC(),
+	}
+	if rotation.Keys != "" {
+		if err := json.Unmarshal([]byte(rotation.Keys), &res.Keys); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
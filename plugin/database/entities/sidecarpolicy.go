@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type SidecarPolicy struct {
+	Id          uint64    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Selector    string    `db:"selector"`
+	Sidecar     string    `db:"sidecar"`
+	Volumes     string    `db:"volumes"`
+	CreateTime  time.Time `db:"create_time"`
+	UpdateTime  time.Time `db:"update_time"`
+}
+
+func FromSidecarPolicyModel(policy *models.SidecarPolicy) (*SidecarPolicy, error) {
+	sidecar, err := json.Marshal(policy.Sidecar)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes, err := json.Marshal(policy.Volumes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SidecarPolicy{
+		Namespace:   policy.Namespace,
+		Name:        policy.Name,
+		Description: policy.Description,
+		Selector:    policy.Selector,
+		Sidecar:     string(sidecar),
+		Volumes:     string(volumes),
+	}, nil
+}
+
+func ToSidecarPolicyModel(policy *SidecarPolicy) (*models.SidecarPolicy, error) {
+	res := &models.SidecarPolicy{
+		Namespace:   policy.Namespace,
+		Name:        policy.Name,
+		Description: policy.Description,
+		Selector:    policy.Selector,
+		CreateTime:  policy.CreateTime.UTC(),
+		UpdateTime:  policy.UpdateTime.UTC(),
+	}
+	if policy.Sidecar != "" {
+		if err := json.Unmarshal([]byte(policy.Sidecar), &res.Sidecar); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if policy.Volumes != "" {
+		if err := json.Unmarshal([]byte(policy.Volumes), &res.Volumes); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type SyncRecord struct {
+	Id            uint64    `db:"id"`
+	Namespace     string    `db:"namespace"`
+	Name          string    `db:"name"`
+	Kind          string    `db:"kind"`
+	RequestSize   int       `db:"request_size"`
+	ResponseSize  int       `db:"response_size"`
+	Latency       int64     `db:"latency"`
+	Protocol      int       `db:"protocol"`
+	ClientVersion string    `db:"client_version"`
+	ClientIP      string    `db:"client_ip"`
+	Error         string    `db:"error"`
+	SyncTime      time.Time `db:"sync_time"`
+}
+
+func FromSyncRecordModel(record *models.SyncRecord) *SyncRecord {
+	return &SyncRecord{
+		Namespace:     record.Namespace,
+		Name:          record.Name,
+		Kind:          record.Kind,
+		RequestSize:   record.RequestSize,
+		ResponseSize:  record.ResponseSize,
+		Latency:       record.Latency,
+		Protocol:      record.Protocol,
+		ClientVersion: record.ClientVersion,
+		ClientIP:      record.ClientIP,
+		Error:         record.Error,
+		SyncTime:      record.Time.UTC(),
+	}
+}
+
+func ToSyncRecordModel(record *SyncRecord) models.SyncRecord {
+	return models.SyncRecord{
+		Namespace:     record.Namespace,
+		Name:          record.Name,
+		Kind:          record.Kind,
+		Time:          record.SyncTime.UTC(),
+		RequestSize:   record.RequestSize,
+		ResponseSize:  record.ResponseSize,
+		Latency:       record.Latency,
+		Protocol:      record.Protocol,
+		ClientVersion: record.ClientVersion,
+		ClientIP:      record.ClientIP,
+		Error:         record.Error,
+	}
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type Tenant struct {
+	Id         uint64    `db:"id"`
+	Namespace  string    `db:"namespace"`
+	Status     string    `db:"status"`
+	Reason     string    `db:"reason"`
+	CreateTime time.Time `db:"create_time"`
+	UpdateTime time.Time `db:"update_time"`
+}
+
+func FromTenantModel(tenant *models.Tenant) *Tenant {
+	return &Tenant{
+		Namespace: tenant.Namespace,
+		Status:    tenant.Status,
+		Reason:    tenant.Reason,
+	}
+}
+
+func ToTenantModel(tenant *Tenant) *models.Tenant {
+	return &models.Tenant{
+		Namespace:  tenant.Namespace,
+		Status:     tenant.Status,
+		Reason:     tenant.Reason,
+		CreateTime: tenant.CreateTime.UTC(),
+		UpdateTime: tenant.UpdateTime.UTC(),
+	}
+}
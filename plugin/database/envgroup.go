@@ -0,0 +1,74 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetEnvGroup(namespace, name string) (*models.EnvGroup, error) {
+	selectSQL := `
+SELECT namespace, name, description, envs, create_time, update_time 
+FROM baetyl_env_group WHERE namespace=? AND name=?
+`
+	var groups []entities.EnvGroup
+	if err := d.Query(nil, selectSQL, &groups, namespace, name); err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "env group"), common.Field("name", name))
+	}
+	return entities.ToEnvGroupModel(&groups[0])
+}
+
+func (d *DB) ListEnvGroup(namespace string) ([]models.EnvGroup, error) {
+	selectSQL := `
+SELECT namespace, name, description, envs, create_time, update_time 
+FROM baetyl_env_group WHERE namespace=? ORDER BY name
+`
+	var groups []entities.EnvGroup
+	if err := d.Query(nil, selectSQL, &groups, namespace); err != nil {
+		return nil, err
+	}
+	res := make([]models.EnvGroup, 0, len(groups))
+	for i := range groups {
+		group, err := entities.ToEnvGroupModel(&groups[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *group)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateEnvGroup(group *models.EnvGroup) error {
+	insertSQL := `
+INSERT INTO baetyl_env_group (namespace, name, description, envs) 
+VALUES (?,?,?,?)
+`
+	g, err := entities.FromEnvGroupModel(group)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, g.Namespace, g.Name, g.Description, g.Envs)
+	return err
+}
+
+func (d *DB) UpdateEnvGroup(group *models.EnvGroup) error {
+	updateSQL := `
+UPDATE baetyl_env_group SET description=?, envs=? 
+WHERE namespace=? AND name=?
+`
+	g, err := entities.FromEnvGroupModel(group)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, g.Description, g.Envs, g.Namespace, g.Name)
+	return err
+}
+
+func (d *DB) DeleteEnvGroup(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_env_group WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
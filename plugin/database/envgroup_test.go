@@ -0,0 +1,91 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	envGroupTables = []string{
+		`
+CREATE TABLE baetyl_env_group(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    envs        TEXT,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateEnvGroupTable() {
+	for _, sql := range envGroupTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestEnvGroup(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateEnvGroupTable()
+
+	group := &models.EnvGroup{
+		Namespace:   "default",
+		Name:        "mqtt",
+		Description: "broker",
+		Envs:        []specV1.Environment{{Name: "MQTT_ADDRESS", Value: "tcp://broker:1883"}},
+	}
+	_, err = db.GetEnvGroup(group.Namespace, group.Name)
+	assert.Error(t, err)
+
+	err = db.CreateEnvGroup(group)
+	assert.NoError(t, err)
+	err = db.CreateEnvGroup(group)
+	assert.Error(t, err)
+
+	res, err := db.GetEnvGroup(group.Namespace, group.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "broker", res.Description)
+	assert.Equal(t, group.Envs, res.Envs)
+
+	group.Description = "the mqtt broker"
+	group.Envs = append(group.Envs, specV1.Environment{Name: "MQTT_QOS", Value: "1"})
+	err = db.UpdateEnvGroup(group)
+	assert.NoError(t, err)
+	res, err = db.GetEnvGroup(group.Namespace, group.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "the mqtt broker", res.Description)
+	assert.Equal(t, group.Envs, res.Envs)
+
+	err = db.CreateEnvGroup(&models.EnvGroup{Namespace: "default", Name: "region"})
+	assert.NoError(t, err)
+	list, err := db.ListEnvGroup("default")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "mqtt", list[0].Name)
+	assert.Empty(t, list[1].Envs)
+	list, err = db.ListEnvGroup("other")
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	err = db.DeleteEnvGroup(group.Namespace, group.Name)
+	assert.NoError(t, err)
+	_, err = db.GetEnvGroup(group.Namespace, group.Name)
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetFeatureFlag(name string) (*models.FeatureFlag, error) {
+	selectSQL := `
+SELECT name, description, enabled, percentage, overrides, create_time, update_time
+FROM baetyl_feature_flag WHERE name=?
+`
+	var flags []entities.FeatureFlag
+	if err := d.Query(nil, selectSQL, &flags, name); err != nil {
+		return nil, err
+	}
+	if len(flags) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "feature flag"), common.Field("name", name))
+	}
+	return entities.ToFeatureFlagModel(&flags[0])
+}
+
+func (d *DB) ListFeatureFlag() ([]models.FeatureFlag, error) {
+	selectSQL := `
+SELECT name, description, enabled, percentage, overrides, create_time, update_time
+FROM baetyl_feature_flag ORDER BY name
+`
+	var flags []entities.FeatureFlag
+	if err := d.Query(nil, selectSQL, &flags); err != nil {
+		return nil, err
+	}
+	res := make([]models.FeatureFlag, 0, len(flags))
+	for i := range flags {
+		flag, err := entities.ToFeatureFlagModel(&flags[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *flag)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateFeatureFlag(flag *models.FeatureFlag) error {
+	insertSQL := `
+INSERT INTO baetyl_feature_flag (name, description, enabled, percentage, overrides)
+VALUES (?,?,?,?,?)
+`
+	f, err := entities.FromFeatureFlagModel(flag)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, f.Name, f.Description, f.Enabled, f.Percentage, f.Overrides)
+	return err
+}
+
+func (d *DB) UpdateFeatureFlag(flag *models.FeatureFlag) error {
+	updateSQL := `
+UPDATE baetyl_feature_flag SET description=?, enabled=?, percentage=?, overrides=?
+WHERE name=?
+`
+	f, err := entities.FromFeatureFlagModel(flag)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, f.Description, f.Enabled, f.Percentage, f.Overrides, f.Name)
+	return err
+}
+
+func (d *DB) DeleteFeatureFlag(name string) error {
+	deleteSQL := `DELETE FROM baetyl_feature_flag WHERE name=?`
+	_, err := d.Exec(nil, deleteSQL, name)
+	return err
+}
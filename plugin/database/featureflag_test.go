@@ -0,0 +1,83 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	featureFlagTables = []string{
+		`
+CREATE TABLE baetyl_feature_flag(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    enabled     BOOLEAN NOT NULL DEFAULT FALSE,
+    percentage  INTEGER NOT NULL DEFAULT 0,
+    overrides   TEXT NULL,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateFeatureFlagTable() {
+	for _, sql := range featureFlagTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestFeatureFlag(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateFeatureFlagTable()
+
+	flag := &models.FeatureFlag{Name: "delta-sync", Description: "sync the deltas of desires", Percentage: 10}
+	_, err = db.GetFeatureFlag(flag.Name)
+	assert.Error(t, err)
+
+	err = db.CreateFeatureFlag(flag)
+	assert.NoError(t, err)
+	err = db.CreateFeatureFlag(flag)
+	assert.Error(t, err)
+
+	res, err := db.GetFeatureFlag(flag.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "sync the deltas of desires", res.Description)
+	assert.Equal(t, 10, res.Percentage)
+	assert.False(t, res.Enabled)
+	assert.Nil(t, res.Overrides)
+
+	flag.Enabled, flag.Overrides = true, map[string]bool{"default": false, "tenant-a": true}
+	err = db.UpdateFeatureFlag(flag)
+	assert.NoError(t, err)
+	res, err = db.GetFeatureFlag(flag.Name)
+	assert.NoError(t, err)
+	assert.True(t, res.Enabled)
+	assert.Equal(t, map[string]bool{"default": false, "tenant-a": true}, res.Overrides)
+
+	err = db.CreateFeatureFlag(&models.FeatureFlag{Name: "canary-rollout"})
+	assert.NoError(t, err)
+	list, err := db.ListFeatureFlag()
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "canary-rollout", list[0].Name)
+
+	err = db.DeleteFeatureFlag(flag.Name)
+	assert.NoError(t, err)
+	_, err = db.GetFeatureFlag(flag.Name)
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetFunctionBuild(namespace, name, version string) (*models.FunctionBuild, error) {
+	selectSQL := `
+SELECT namespace, name, version, runtime, handler, source, bucket, object, sha256, size, 
+builder, build_id, image, status, message, create_time, update_time 
+FROM baetyl_function_build WHERE namespace=? AND name=? AND version=?
+`
+	var builds []entities.FunctionBuild
+	if err := d.Query(nil, selectSQL, &builds, namespace, name, version); err != nil {
+		return nil, err
+	}
+	if len(builds) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "function build"), common.Field("name", name+":"+version))
+	}
+	return entities.ToFunctionBuildModel(&builds[0]), nil
+}
+
+func (d *DB) ListFunctionBuild(namespace, name string) ([]models.FunctionBuild, error) {
+	selectSQL := `
+SELECT namespace, name, version, runtime, handler, source, bucket, object, sha256, size, 
+builder, build_id, image, status, message, create_time, update_time 
+FROM baetyl_function_build WHERE namespace=? AND name=? ORDER BY create_time DESC, id DESC
+`
+	var builds []entities.FunctionBuild
+	if err := d.Query(nil, selectSQL, &builds, namespace, name); err != nil {
+		return nil, err
+	}
+	res := make([]models.FunctionBuild, 0, len(builds))
+	for i := range builds {
+		res = append(res, *entities.ToFunctionBuildModel(&builds[i]))
+	}
+	return res, nil
+}
+
+func (d *DB) CreateFunctionBuild(build *models.FunctionBuild) error {
+	insertSQL := `
+INSERT INTO baetyl_function_build (namespace, name, version, runtime, handler, source, bucket, object, sha256, size, 
+builder, build_id, image, status, message) 
+VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)
+`
+	b := entities.FromFunctionBuildModel(build)
+	_, err := d.Exec(nil, insertSQL, b.Namespace, b.Name, b.Version, b.Runtime, b.Handler, b.Source, b.Bucket, b.Object,
+		b.Sha256, b.Size, b.Builder, b.BuildID, b.Image, b.Status, b.Message)
+	return err
+}
+
+func (d *DB) UpdateFunctionBuild(build *models.FunctionBuild) error {
+	updateSQL := `
+UPDATE baetyl_function_build SET runtime=?, handler=?, source=?, bucket=?, object=?, sha256=?, size=?, 
+builder=?, build_id=?, image=?, status=?, message=? 
+WHERE namespace=? AND name=? AND version=?
+`
+	b := entities.FromFunctionBuildModel(build)
+	_, err := d.Exec(nil, updateSQL, b.Runtime, b.Handler, b.Source, b.Bucket, b.Object, b.Sha256, b.Size,
+		b.Builder, b.BuildID, b.Image, b.Status, b.Message, b.Namespace, b.Name, b.Version)
+	return err
+}
+
+func (d *DB) DeleteFunctionBuild(namespace, name, version string) error {
+	deleteSQL := `DELETE FROM baetyl_function_build WHERE namespace=? AND name=? AND version=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name, version)
+	return err
+}
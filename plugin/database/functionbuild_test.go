@@ -0,0 +1,112 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	functionBuildTables = []string{
+		`
+CREATE TABLE baetyl_function_build(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    version     VARCHAR(64) NOT NULL DEFAULT '',
+    runtime     VARCHAR(64) NOT NULL DEFAULT '',
+    handler     VARCHAR(128) NOT NULL DEFAULT '',
+    source      VARCHAR(64) NOT NULL DEFAULT '',
+    bucket      VARCHAR(128) NOT NULL DEFAULT '',
+    object      VARCHAR(512) NOT NULL DEFAULT '',
+    sha256      VARCHAR(64) NOT NULL DEFAULT '',
+    size        BIGINT NOT NULL DEFAULT 0,
+    builder     VARCHAR(64) NOT NULL DEFAULT '',
+    build_id    VARCHAR(128) NOT NULL DEFAULT '',
+    image       VARCHAR(512) NOT NULL DEFAULT '',
+    status      VARCHAR(32) NOT NULL DEFAULT '',
+    message     VARCHAR(1024) NOT NULL DEFAULT '',
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name, version)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateFunctionBuildTable() {
+	for _, sql := range functionBuildTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestFunctionBuild(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateFunctionBuildTable()
+
+	build := &models.FunctionBuild{
+		Namespace: "default",
+		Name:      "infer",
+		Version:   "v1",
+		Runtime:   "python3",
+		Handler:   "index.handler",
+		Source:    "awss3",
+		Bucket:    "baetyl-cloud-default",
+		Object:    "functions/default/infer/v1/code.zip",
+		Sha256:    "abc",
+		Size:      1024,
+		Builder:   "kaniko",
+		BuildID:   "infer-v1-abc",
+		Image:     "harbor.local/functions/default/infer:v1",
+		Status:    models.FunctionBuildRunning,
+	}
+	_, err = db.GetFunctionBuild("default", "infer", "v1")
+	assert.Error(t, err)
+
+	err = db.CreateFunctionBuild(build)
+	assert.NoError(t, err)
+	err = db.CreateFunctionBuild(build)
+	assert.Error(t, err)
+
+	res, err := db.GetFunctionBuild("default", "infer", "v1")
+	assert.NoError(t, err)
+	assert.Equal(t, build.Object, res.Object)
+	assert.Equal(t, int64(1024), res.Size)
+	assert.Equal(t, models.FunctionBuildRunning, res.Status)
+	assert.False(t, res.CreateTime.IsZero())
+
+	build.Status = models.FunctionBuildFailed
+	build.Message = "the handler is not found"
+	err = db.UpdateFunctionBuild(build)
+	assert.NoError(t, err)
+	res, err = db.GetFunctionBuild("default", "infer", "v1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.FunctionBuildFailed, res.Status)
+	assert.Equal(t, "the handler is not found", res.Message)
+
+	err = db.CreateFunctionBuild(&models.FunctionBuild{Namespace: "default", Name: "infer", Version: "v2", Status: models.FunctionBuildRunning})
+	assert.NoError(t, err)
+	list, err := db.ListFunctionBuild("default", "infer")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "v2", list[0].Version)
+	list, err = db.ListFunctionBuild("default", "other")
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	err = db.DeleteFunctionBuild("default", "infer", "v1")
+	assert.NoError(t, err)
+	_, err = db.GetFunctionBuild("default", "infer", "v1")
+	assert.Error(t, err)
+}
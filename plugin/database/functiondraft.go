@@ -0,0 +1,123 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetFunctionDraft(namespace, name string) (*models.FunctionDraft, error) {
+	selectSQL := `
+SELECT namespace, name, runtime, handler, description, files, env, create_time, update_time 
+FROM baetyl_function_draft WHERE namespace=? AND name=?
+`
+	var drafts []entities.FunctionDraft
+	if err := d.Query(nil, selectSQL, &drafts, namespace, name); err != nil {
+		return nil, err
+	}
+	if len(drafts) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "function draft"), common.Field("name", name))
+	}
+	return entities.ToFunctionDraftModel(&drafts[0])
+}
+
+func (d *DB) ListFunctionDraft(namespace string) ([]models.FunctionDraft, error) {
+	selectSQL := `
+SELECT namespace, name, runtime, handler, description, env, create_time, update_time 
+FROM baetyl_function_draft WHERE namespace=? ORDER BY name
+`
+	var drafts []entities.FunctionDraft
+	if err := d.Query(nil, selectSQL, &drafts, namespace); err != nil {
+		return nil, err
+	}
+	res := make([]models.FunctionDraft, 0, len(drafts))
+	for i := range drafts {
+		draft, err := entities.ToFunctionDraftModel(&drafts[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *draft)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateFunctionDraft(draft *models.FunctionDraft) error {
+	insertSQL := `
+INSERT INTO baetyl_function_draft (namespace, name, runtime, handler, description, files, env) 
+VALUES (?,?,?,?,?,?,?)
+`
+	f, err := entities.FromFunctionDraftModel(draft)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, f.Namespace, f.Name, f.Runtime, f.Handler, f.Description, f.Files, f.Env)
+	return err
+}
+
+func (d *DB) UpdateFunctionDraft(draft *models.FunctionDraft) error {
+	updateSQL := `
+UPDATE baetyl_function_draft SET runtime=?, handler=?, description=?, files=?, env=? 
+WHERE namespace=? AND name=?
+`
+	f, err := entities.FromFunctionDraftModel(draft)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, f.Runtime, f.Handler, f.Description, f.Files, f.Env, f.Namespace, f.Name)
+	return err
+}
+
+func (d *DB) DeleteFunctionDraft(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_function_draft WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
+
+func (d *DB) GetFunctionVersion(namespace, name, version string) (*models.FunctionVersion, error) {
+	selectSQL := `
+SELECT namespace, name, version, runtime, handler, description, source, bucket, object, sha256, size, flavor, env, create_time 
+FROM baetyl_function_version WHERE namespace=? AND name=? AND version=?
+`
+	var versions []entities.FunctionVersion
+	if err := d.Query(nil, selectSQL, &versions, namespace, name, version); err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "function version"), common.Field("name", name+":"+version))
+	}
+	return entities.ToFunctionVersionModel(&versions[0])
+}
+
+func (d *DB) ListFunctionVersion(namespace, name string) ([]models.FunctionVersion, error) {
+	selectSQL := `
+SELECT namespace, name, version, runtime, handler, description, source, bucket, object, sha256, size, flavor, env, create_time 
+FROM baetyl_function_version WHERE namespace=? AND name=? ORDER BY create_time DESC, id DESC
+`
+	var versions []entities.FunctionVersion
+	if err := d.Query(nil, selectSQL, &versions, namespace, name); err != nil {
+		return nil, err
+	}
+	res := make([]models.FunctionVersion, 0, len(versions))
+	for i := range versions {
+		version, err := entities.ToFunctionVersionModel(&versions[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *version)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateFunctionVersion(version *models.FunctionVersion) error {
+	insertSQL := `
+INSERT INTO baetyl_function_version (namespace, name, version, runtime, handler, description, source, bucket, object, sha256, size, flavor, env) 
+VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)
+`
+	v, err := entities.FromFunctionVersionModel(version)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, v.Namespace, v.Name, v.Version, v.Runtime, v.Handler, v.Description,
+		v.Source, v.Bucket, v.Object, v.Sha256, v.Size, v.Flavor, v.Env)
+	return err
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	functionDraftTables = []string{
+		`
+CREATE TABLE baetyl_function_draft(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    runtime     VARCHAR(64) NOT NULL DEFAULT '',
+    handler     VARCHAR(128) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    files       TEXT,
+    env         TEXT,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name)
+);
+`,
+		`
+CREATE TABLE baetyl_function_version(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    version     VARCHAR(64) NOT NULL DEFAULT '',
+    runtime     VARCHAR(64) NOT NULL DEFAULT '',
+    handler     VARCHAR(128) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    source      VARCHAR(64) NOT NULL DEFAULT '',
+    bucket      VARCHAR(128) NOT NULL DEFAULT '',
+    object      VARCHAR(512) NOT NULL DEFAULT '',
+    sha256      VARCHAR(64) NOT NULL DEFAULT '',
+    size        BIGINT NOT NULL DEFAULT 0,
+    flavor      VARCHAR(16) NOT NULL DEFAULT '',
+    env         TEXT,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name, version)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateFunctionDraftTable() {
+	for _, sql := range functionDraftTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestFunctionDraft(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateFunctionDraftTable()
+
+	draft := &models.FunctionDraft{
+		Namespace: "default",
+		Name:      "infer",
+		Runtime:   "python3",
+		Handler:   "index.handler",
+		Files:     map[string]string{"index.py": "def handler(event, context):\n    return event\n"},
+	}
+	_, err = db.GetFunctionDraft(draft.Namespace, draft.Name)
+	assert.Error(t, err)
+
+	err = db.CreateFunctionDraft(draft)
+	assert.NoError(t, err)
+	err = db.CreateFunctionDraft(draft)
+	assert.Error(t, err)
+
+	res, err := db.GetFunctionDraft(draft.Namespace, draft.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "python3", res.Runtime)
+	assert.Equal(t, draft.Files, res.Files)
+
+	assert.Nil(t, res.Env)
+
+	draft.Files["lib/util.py"] = "PI = 3.14\n"
+	draft.Description = "inference"
+	draft.Env = []models.FunctionEnv{{Name: "THRESHOLD", Type: models.FunctionEnvNumber, Default: "0.5"}}
+	err = db.UpdateFunctionDraft(draft)
+	assert.NoError(t, err)
+	res, err = db.GetFunctionDraft(draft.Namespace, draft.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "inference", res.Description)
+	assert.Len(t, res.Files, 2)
+	assert.Equal(t, draft.Env, res.Env)
+
+	err = db.CreateFunctionDraft(&models.FunctionDraft{Namespace: "default", Name: "alarm", Runtime: "nodejs10"})
+	assert.NoError(t, err)
+	list, err := db.ListFunctionDraft("default")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "alarm", list[0].Name)
+	assert.Nil(t, list[1].Files)
+
+	err = db.DeleteFunctionDraft(draft.Namespace, draft.Name)
+	assert.NoError(t, err)
+	_, err = db.GetFunctionDraft(draft.Namespace, draft.Name)
+	assert.Error(t, err)
+}
+
+func TestFunctionVersion(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateFunctionDraftTable()
+
+	version := &models.FunctionVersion{
+		Namespace: "default",
+		Name:      "infer",
+		Version:   "v1",
+		Runtime:   "python3",
+		Handler:   "index.handler",
+		Source:    "minio",
+		Bucket:    "baetyl-cloud-u1",
+		Object:    "functions/default/infer/v1/sha.zip",
+		Sha256:    "sha",
+		Size:      120,
+		Flavor:    models.FunctionFlavorGPU,
+		Env: []models.FunctionEnv{
+			{Name: "TOKEN", Required: true, Secret: &models.FunctionEnvSecret{Name: "infer-token", Key: "token"}},
+		},
+	}
+	_, err = db.GetFunctionVersion("default", "infer", "v1")
+	assert.Error(t, err)
+
+	err = db.CreateFunctionVersion(version)
+	assert.NoError(t, err)
+	err = db.CreateFunctionVersion(version)
+	assert.Error(t, err)
+
+	res, err := db.GetFunctionVersion("default", "infer", "v1")
+	assert.NoError(t, err)
+	assert.Equal(t, version.Object, res.Object)
+	assert.Equal(t, int64(120), res.Size)
+	assert.Equal(t, models.FunctionFlavorGPU, res.Flavor)
+	assert.Equal(t, version.Env, res.Env)
+
+	version.Version = "v2"
+	err = db.CreateFunctionVersion(version)
+	assert.NoError(t, err)
+	list, err := db.ListFunctionVersion("default", "infer")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "v2", list[0].Version)
+	list, err = db.ListFunctionVersion("default", "alarm")
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+}
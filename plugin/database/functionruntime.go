@@ -0,0 +1,64 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetFunctionRuntime(name string) (*models.FunctionRuntime, error) {
+	selectSQL := `
+SELECT name, language, version, type, image, gpu_image, handler, description, create_time, update_time 
+FROM baetyl_function_runtime WHERE name=?
+`
+	var runtimes []entities.FunctionRuntime
+	if err := d.Query(nil, selectSQL, &runtimes, name); err != nil {
+		return nil, err
+	}
+	if len(runtimes) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "function runtime"), common.Field("name", name))
+	}
+	return entities.ToFunctionRuntimeModel(&runtimes[0]), nil
+}
+
+func (d *DB) ListFunctionRuntime() ([]models.FunctionRuntime, error) {
+	selectSQL := `
+SELECT name, language, version, type, image, gpu_image, handler, description, create_time, update_time 
+FROM baetyl_function_runtime ORDER BY name
+`
+	var runtimes []entities.FunctionRuntime
+	if err := d.Query(nil, selectSQL, &runtimes); err != nil {
+		return nil, err
+	}
+	res := make([]models.FunctionRuntime, 0, len(runtimes))
+	for i := range runtimes {
+		res = append(res, *entities.ToFunctionRuntimeModel(&runtimes[i]))
+	}
+	return res, nil
+}
+
+func (d *DB) CreateFunctionRuntime(runtime *models.FunctionRuntime) error {
+	insertSQL := `
+INSERT INTO baetyl_function_runtime (name, language, version, type, image, gpu_image, handler, description) 
+VALUES (?,?,?,?,?,?,?,?)
+`
+	r := entities.FromFunctionRuntimeModel(runtime)
+	_, err := d.Exec(nil, insertSQL, r.Name, r.Language, r.Version, r.Type, r.Image, r.GPUImage, r.Handler, r.Description)
+	return err
+}
+
+func (d *DB) UpdateFunctionRuntime(runtime *models.FunctionRuntime) error {
+	updateSQL := `
+UPDATE baetyl_function_runtime SET language=?, version=?, type=?, image=?, gpu_image=?, handler=?, description=? 
+WHERE name=?
+`
+	r := entities.FromFunctionRuntimeModel(runtime)
+	_, err := d.Exec(nil, updateSQL, r.Language, r.Version, r.Type, r.Image, r.GPUImage, r.Handler, r.Description, r.Name)
+	return err
+}
+
+func (d *DB) DeleteFunctionRuntime(name string) error {
+	deleteSQL := `DELETE FROM baetyl_function_runtime WHERE name=?`
+	_, err := d.Exec(nil, deleteSQL, name)
+	return err
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	functionRuntimeTables = []string{
+		`
+CREATE TABLE baetyl_function_runtime(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    language    VARCHAR(64) NOT NULL DEFAULT '',
+    version     VARCHAR(64) NOT NULL DEFAULT '',
+    type        VARCHAR(16) NOT NULL DEFAULT '',
+    image       VARCHAR(512) NOT NULL DEFAULT '',
+    gpu_image   VARCHAR(512) NOT NULL DEFAULT '',
+    handler     VARCHAR(128) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateFunctionRuntimeTable() {
+	for _, sql := range functionRuntimeTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestFunctionRuntime(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateFunctionRuntimeTable()
+
+	runtime := &models.FunctionRuntime{
+		Name:     "node20",
+		Language: "nodejs",
+		Version:  "20",
+		Image:    "baetyltech/node:20",
+		Handler:  "index.handler",
+	}
+	_, err = db.GetFunctionRuntime(runtime.Name)
+	assert.Error(t, err)
+
+	err = db.CreateFunctionRuntime(runtime)
+	assert.NoError(t, err)
+	err = db.CreateFunctionRuntime(runtime)
+	assert.Error(t, err)
+
+	res, err := db.GetFunctionRuntime(runtime.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "nodejs", res.Language)
+	assert.Equal(t, "baetyltech/node:20", res.Image)
+	assert.Equal(t, "index.handler", res.Handler)
+
+	assert.Empty(t, res.GPUImage)
+
+	runtime.Image = "baetyltech/node:20.1"
+	runtime.GPUImage = "baetyltech/node-cuda:20.1"
+	runtime.Description = "node.js 20"
+	err = db.UpdateFunctionRuntime(runtime)
+	assert.NoError(t, err)
+	res, err = db.GetFunctionRuntime(runtime.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "baetyltech/node:20.1", res.Image)
+	assert.Equal(t, "baetyltech/node-cuda:20.1", res.GPUImage)
+	assert.Equal(t, "node.js 20", res.Description)
+
+	err = db.CreateFunctionRuntime(&models.FunctionRuntime{Name: "go1.21", Language: "go", Version: "1.21", Image: "baetyltech/go:1.21"})
+	assert.NoError(t, err)
+	list, err := db.ListFunctionRuntime()
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "go1.21", list[0].Name)
+	assert.Equal(t, "node20", list[1].Name)
+
+	err = db.CreateFunctionRuntime(&models.FunctionRuntime{Name: "wasmedge", Language: "rust", Version: "1.70", Type: models.FunctionRuntimeWasm, Image: "baetyltech/wasmedge:0.13"})
+	assert.NoError(t, err)
+	res, err = db.GetFunctionRuntime("wasmedge")
+	assert.NoError(t, err)
+	assert.Equal(t, models.FunctionRuntimeWasm, res.Type)
+
+	err = db.DeleteFunctionRuntime(runtime.Name)
+	assert.NoError(t, err)
+	_, err = db.GetFunctionRuntime(runtime.Name)
+	assert.Error(t, err)
+}
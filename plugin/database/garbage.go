@@ -0,0 +1,40 @@
+package database
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) ListGarbageShadow() ([]models.GarbageItem, error) {
+	selectSQL := `
+SELECT namespace, name, create_time FROM baetyl_node_shadow ORDER BY namespace, name
+`
+	var shadows []entities.GarbageShadow
+	if err := d.Query(nil, selectSQL, &shadows); err != nil {
+		return nil, err
+	}
+	res := make([]models.GarbageItem, 0, len(shadows))
+	for i := range shadows {
+		res = append(res, entities.ToGarbageShadowModel(&shadows[i]))
+	}
+	return res, nil
+}
+
+// ListGarbageCert lists the sub certificates only, the root ones are never garbage
+func (d *DB) ListGarbageCert(before time.Time) ([]models.GarbageItem, error) {
+	selectSQL := `
+SELECT cert_id, common_name, not_after FROM baetyl_certificate
+WHERE type='IssuingSubCertificate' AND not_after<? ORDER BY not_after
+`
+	var certs []entities.GarbageCert
+	if err := d.Query(nil, selectSQL, &certs, before.UTC()); err != nil {
+		return nil, err
+	}
+	res := make([]models.GarbageItem, 0, len(certs))
+	for i := range certs {
+		res = append(res, entities.ToGarbageCertModel(&certs[i]))
+	}
+	return res, nil
+}
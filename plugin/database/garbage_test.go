@@ -0,0 +1,53 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestListGarbage(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateShadowTable()
+	db.MockCreateCertificateTable()
+
+	for _, name := range []string{"node02", "node01"} {
+		_, err = db.Create(nil, &models.Shadow{Namespace: "default", Name: name})
+		assert.NoError(t, err)
+	}
+	shadows, err := db.ListGarbageShadow()
+	assert.NoError(t, err)
+	assert.Len(t, shadows, 2)
+	assert.Equal(t, "node01", shadows[0].Name)
+	assert.Equal(t, models.GarbageShadow, shadows[0].Kind)
+	assert.Equal(t, "shadow/default/node01", shadows[0].Key())
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, cert := range []plugin.Cert{
+		{CertId: "expired", Type: "IssuingSubCertificate", CommonName: "default.node01", NotAfter: now.Add(-48 * time.Hour)},
+		{CertId: "valid", Type: "IssuingSubCertificate", CommonName: "default.node02", NotAfter: now.Add(time.Hour)},
+		{CertId: "root", Type: "IssuingCA", CommonName: "root", NotAfter: now.Add(-48 * time.Hour)},
+	} {
+		assert.NoError(t, db.CreateCert(cert))
+	}
+	certs, err := db.ListGarbageCert(now.Add(-24 * time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, certs, 1)
+	assert.Equal(t, "expired", certs[0].Name)
+	assert.Equal(t, "cert/expired", certs[0].Key())
+	assert.Contains(t, certs[0].Reason, "default.node01")
+
+	certs, err = db.ListGarbageCert(now.Add(-72 * time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, certs, 0)
+}
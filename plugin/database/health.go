@@ -0,0 +1,48 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetHealthThreshold(namespace string) (*models.HealthThreshold, error) {
+	selectSQL := `
+SELECT namespace, warning_score, critical_score, webhook, create_time, update_time 
+FROM baetyl_health_threshold WHERE namespace=?
+`
+	var thresholds []entities.HealthThreshold
+	if err := d.Query(nil, selectSQL, &thresholds, namespace); err != nil {
+		return nil, err
+	}
+	if len(thresholds) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "healththreshold"), common.Field("name", namespace))
+	}
+	return entities.ToHealthThresholdModel(&thresholds[0]), nil
+}
+
+func (d *DB) CreateHealthThreshold(threshold *models.HealthThreshold) error {
+	insertSQL := `
+INSERT INTO baetyl_health_threshold (namespace, warning_score, critical_score, webhook) 
+VALUES (?,?,?,?)
+`
+	t := entities.FromHealthThresholdModel(threshold)
+	_, err := d.Exec(nil, insertSQL, t.Namespace, t.WarningScore, t.CriticalScore, t.Webhook)
+	return err
+}
+
+func (d *DB) UpdateHealthThreshold(threshold *models.HealthThreshold) error {
+	updateSQL := `
+UPDATE baetyl_health_threshold SET warning_score=?, critical_score=?, webhook=? 
+WHERE namespace=?
+`
+	t := entities.FromHealthThresholdModel(threshold)
+	_, err := d.Exec(nil, updateSQL, t.WarningScore, t.CriticalScore, t.Webhook, t.Namespace)
+	return err
+}
+
+func (d *DB) DeleteHealthThreshold(namespace string) error {
+	deleteSQL := `DELETE FROM baetyl_health_threshold WHERE namespace=?`
+	_, err := d.Exec(nil, deleteSQL, namespace)
+	return err
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	healthThresholdTables = []string{
+		`
+CREATE TABLE baetyl_health_threshold(
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace      VARCHAR(64) NOT NULL DEFAULT '',
+    warning_score  INTEGER NOT NULL DEFAULT 80,
+    critical_score INTEGER NOT NULL DEFAULT 50,
+    webhook        VARCHAR(1024) NOT NULL DEFAULT '',
+    create_time    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateHealthThresholdTable() {
+	for _, sql := range healthThresholdTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestHealthThreshold(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateHealthThresholdTable()
+
+	threshold := &models.HealthThreshold{
+		Namespace:     "default",
+		WarningScore:  70,
+		CriticalScore: 40,
+	}
+	_, err = db.GetHealthThreshold(threshold.Namespace)
+	assert.Error(t, err)
+
+	err = db.CreateHealthThreshold(threshold)
+	assert.NoError(t, err)
+	err = db.CreateHealthThreshold(threshold)
+	assert.Error(t, err)
+
+	res, err := db.GetHealthThreshold(threshold.Namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, 70, res.WarningScore)
+	assert.Equal(t, 40, res.CriticalScore)
+	assert.Equal(t, "", res.Webhook)
+
+	threshold.WarningScore = 90
+	threshold.Webhook = "http://alert.example.com/hook"
+	err = db.UpdateHealthThreshold(threshold)
+	assert.NoError(t, err)
+	res, err = db.GetHealthThreshold(threshold.Namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, 90, res.WarningScore)
+	assert.Equal(t, "http://alert.example.com/hook", res.Webhook)
+
+	_, err = db.GetHealthThreshold("other")
+	assert.Error(t, err)
+
+	err = db.DeleteHealthThreshold(threshold.Namespace)
+	assert.NoError(t, err)
+	_, err = db.GetHealthThreshold(threshold.Namespace)
+	assert.Error(t, err)
+}
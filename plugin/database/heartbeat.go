@@ -0,0 +1,129 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetHeartbeatPolicy(namespace string) (*models.HeartbeatPolicy, error) {
+	selectSQL := `
+SELECT namespace, offline_timeout, webhook, create_time, update_time
+FROM baetyl_heartbeat_policy WHERE namespace=?
+`
+	var policies []entities.HeartbeatPolicy
+	if err := d.Query(nil, selectSQL, &policies, namespace); err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "heartbeatpolicy"), common.Field("name", namespace))
+	}
+	return entities.ToHeartbeatPolicyModel(&policies[0]), nil
+}
+
+func (d *DB) CreateHeartbeatPolicy(policy *models.HeartbeatPolicy) error {
+	insertSQL := `
+INSERT INTO baetyl_heartbeat_policy (namespace, offline_timeout, webhook)
+VALUES (?,?,?)
+`
+	p := entities.FromHeartbeatPolicyModel(policy)
+	_, err := d.Exec(nil, insertSQL, p.Namespace, p.OfflineTimeout, p.Webhook)
+	return err
+}
+
+func (d *DB) UpdateHeartbeatPolicy(policy *models.HeartbeatPolicy) error {
+	updateSQL := `
+UPDATE baetyl_heartbeat_policy SET offline_timeout=?, webhook=?
+WHERE namespace=?
+`
+	p := entities.FromHeartbeatPolicyModel(policy)
+	_, err := d.Exec(nil, updateSQL, p.OfflineTimeout, p.Webhook, p.Namespace)
+	return err
+}
+
+func (d *DB) DeleteHeartbeatPolicy(namespace string) error {
+	deleteSQL := `DELETE FROM baetyl_heartbeat_policy WHERE namespace=?`
+	_, err := d.Exec(nil, deleteSQL, namespace)
+	return err
+}
+
+func (d *DB) CreateNodeEvent(event *models.NodeEvent) error {
+	insertSQL := `
+INSERT INTO baetyl_node_event (namespace, node, type, event_time, report_time)
+VALUES (?,?,?,?,?)
+`
+	e := entities.FromNodeEventModel(event)
+	_, err := d.Exec(nil, insertSQL, e.Namespace, e.Node, e.Type, e.EventTime, e.ReportTime)
+	return err
+}
+
+func (d *DB) GetLatestNodeEvent(namespace, node string) (*models.NodeEvent, error) {
+	selectSQL := `
+SELECT id, namespace, node, type, event_time, report_time
+FROM baetyl_node_event WHERE namespace=? AND node=? ORDER BY event_time DESC, id DESC LIMIT 1
+`
+	var events []entities.NodeEvent
+	if err := d.Query(nil, selectSQL, &events, namespace, node); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	res := entities.ToNodeEventModel(&events[0])
+	return &res, nil
+}
+
+func (d *DB) ListNodeEvent(namespace string, filter *models.NodeEventFilter) ([]models.NodeEvent, error) {
+	selectSQL := `
+SELECT id, namespace, node, type, event_time, report_time
+FROM baetyl_node_event WHERE namespace=?`
+	where, args := nodeEventConditions(namespace, filter)
+	selectSQL = selectSQL + where + " ORDER BY event_time DESC, id DESC "
+	if filter.GetLimitNumber() > 0 {
+		selectSQL = selectSQL + "LIMIT ?,?"
+		args = append(args, filter.GetLimitOffset(), filter.GetLimitNumber())
+	}
+	var events []entities.NodeEvent
+	if err := d.QueryContext(filter.Context(), nil, selectSQL, &events, args...); err != nil {
+		return nil, err
+	}
+	res := make([]models.NodeEvent, 0, len(events))
+	for i := range events {
+		res = append(res, entities.ToNodeEventModel(&events[i]))
+	}
+	return res, nil
+}
+
+func (d *DB) CountNodeEvent(namespace string, filter *models.NodeEventFilter) (int, error) {
+	selectSQL := `SELECT count(id) AS count FROM baetyl_node_event WHERE namespace=?`
+	where, args := nodeEventConditions(namespace, filter)
+	var res []struct {
+		Count int `db:"count"`
+	}
+	if err := d.QueryContext(filter.Context(), nil, selectSQL+where, &res, args...); err != nil {
+		return 0, err
+	}
+	return res[0].Count, nil
+}
+
+// nodeEventConditions returns the conditions appended to namespace and the args of them
+func nodeEventConditions(namespace string, filter *models.NodeEventFilter) (string, []interface{}) {
+	where, args := "", []interface{}{namespace}
+	if filter.Node != "" {
+		where += " AND node=?"
+		args = append(args, filter.Node)
+	}
+	if filter.Type != "" {
+		where += " AND type=?"
+		args = append(args, filter.Type)
+	}
+	if !filter.Start.IsZero() {
+		where += " AND event_time>=?"
+		args = append(args, filter.Start.UTC())
+	}
+	if !filter.End.IsZero() {
+		where += " AND event_time<?"
+		args = append(args, filter.End.UTC())
+	}
+	return where, args
+}
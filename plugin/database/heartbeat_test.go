@@ -0,0 +1,143 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	heartbeatTables = []string{
+		`
+CREATE TABLE baetyl_heartbeat_policy(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace       VARCHAR(64) NOT NULL DEFAULT '',
+    offline_timeout INTEGER NOT NULL DEFAULT 20,
+    webhook         VARCHAR(1024) NOT NULL DEFAULT '',
+    create_time     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace)
+);
+`,
+		`
+CREATE TABLE baetyl_node_event(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    node        VARCHAR(128) NOT NULL DEFAULT '',
+    type        VARCHAR(32) NOT NULL DEFAULT '',
+    event_time  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    report_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateHeartbeatTable() {
+	for _, sql := range heartbeatTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestHeartbeatPolicy(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateHeartbeatTable()
+
+	policy := &models.HeartbeatPolicy{Namespace: "default", OfflineTimeout: 300}
+	_, err = db.GetHeartbeatPolicy(policy.Namespace)
+	assert.Error(t, err)
+
+	err = db.CreateHeartbeatPolicy(policy)
+	assert.NoError(t, err)
+	err = db.CreateHeartbeatPolicy(policy)
+	assert.Error(t, err)
+
+	res, err := db.GetHeartbeatPolicy(policy.Namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, 300, res.OfflineTimeout)
+	assert.Equal(t, "", res.Webhook)
+
+	policy.OfflineTimeout = 600
+	policy.Webhook = "http://event.example.com/hook"
+	err = db.UpdateHeartbeatPolicy(policy)
+	assert.NoError(t, err)
+	res, err = db.GetHeartbeatPolicy(policy.Namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, 600, res.OfflineTimeout)
+	assert.Equal(t, "http://event.example.com/hook", res.Webhook)
+
+	err = db.DeleteHeartbeatPolicy(policy.Namespace)
+	assert.NoError(t, err)
+	_, err = db.GetHeartbeatPolicy(policy.Namespace)
+	assert.Error(t, err)
+}
+
+func TestNodeEvent(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateHeartbeatTable()
+
+	res, err := db.GetLatestNodeEvent("default", "node01")
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	events := []models.NodeEvent{
+		{Namespace: "default", Node: "node01", Type: models.NodeEventOffline, Time: now.Add(-3 * time.Minute), ReportTime: now.Add(-4 * time.Minute)},
+		{Namespace: "default", Node: "node01", Type: models.NodeEventOnline, Time: now.Add(-2 * time.Minute), ReportTime: now.Add(-2 * time.Minute)},
+		{Namespace: "default", Node: "node02", Type: models.NodeEventOffline, Time: now.Add(-time.Minute), ReportTime: now.Add(-2 * time.Minute)},
+		{Namespace: "other", Node: "node01", Type: models.NodeEventOffline, Time: now, ReportTime: now},
+	}
+	for i := range events {
+		assert.NoError(t, db.CreateNodeEvent(&events[i]))
+	}
+
+	res, err = db.GetLatestNodeEvent("default", "node01")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NodeEventOnline, res.Type)
+	assert.Equal(t, now.Add(-2*time.Minute), res.Time)
+
+	filter := &models.NodeEventFilter{}
+	list, err := db.ListNodeEvent("default", filter)
+	assert.NoError(t, err)
+	assert.Len(t, list, 3)
+	assert.Equal(t, "node02", list[0].Node)
+	count, err := db.CountNodeEvent("default", filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	filter = &models.NodeEventFilter{Node: "node01", Type: models.NodeEventOffline}
+	list, err = db.ListNodeEvent("default", filter)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, now.Add(-4*time.Minute), list[0].ReportTime)
+
+	filter = &models.NodeEventFilter{Start: now.Add(-150 * time.Second), End: now}
+	list, err = db.ListNodeEvent("default", filter)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	count, err = db.CountNodeEvent("default", filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	filter = &models.NodeEventFilter{Filter: models.Filter{PageNo: 2, PageSize: 2}}
+	list, err = db.ListNodeEvent("default", filter)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, models.NodeEventOffline, list[0].Type)
+}
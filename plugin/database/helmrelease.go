@@ -0,0 +1,75 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetHelmRelease(namespace, name string) (*models.HelmRelease, error) {
+	selectSQL := `
+SELECT namespace, name, chart, chart_version, app_version, url, chart_values, revision, resources, archive, create_time, update_time 
+FROM baetyl_helm_release WHERE namespace=? AND name=?
+`
+	var releases []entities.HelmRelease
+	if err := d.Query(nil, selectSQL, &releases, namespace, name); err != nil {
+		return nil, err
+	}
+	if len(releases) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "helm release"), common.Field("name", name))
+	}
+	return entities.ToHelmReleaseModel(&releases[0])
+}
+
+// ListHelmRelease lists the releases of namespace without their chart archives
+func (d *DB) ListHelmRelease(namespace string) ([]models.HelmRelease, error) {
+	selectSQL := `
+SELECT namespace, name, chart, chart_version, app_version, url, chart_values, revision, resources, create_time, update_time 
+FROM baetyl_helm_release WHERE namespace=? ORDER BY name
+`
+	var releases []entities.HelmRelease
+	if err := d.Query(nil, selectSQL, &releases, namespace); err != nil {
+		return nil, err
+	}
+	res := make([]models.HelmRelease, 0, len(releases))
+	for i := range releases {
+		release, err := entities.ToHelmReleaseModel(&releases[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *release)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateHelmRelease(release *models.HelmRelease) error {
+	insertSQL := `
+INSERT INTO baetyl_helm_release (namespace, name, chart, chart_version, app_version, url, chart_values, revision, resources, archive) 
+VALUES (?,?,?,?,?,?,?,?,?,?)
+`
+	r, err := entities.FromHelmReleaseModel(release)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, r.Namespace, r.Name, r.Chart, r.ChartVersion, r.AppVersion, r.URL, r.Values, r.Revision, r.Resources, r.Archive)
+	return err
+}
+
+func (d *DB) UpdateHelmRelease(release *models.HelmRelease) error {
+	updateSQL := `
+UPDATE baetyl_helm_release SET chart=?, chart_version=?, app_version=?, url=?, chart_values=?, revision=?, resources=?, archive=? 
+WHERE namespace=? AND name=?
+`
+	r, err := entities.FromHelmReleaseModel(release)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, r.Chart, r.ChartVersion, r.AppVersion, r.URL, r.Values, r.Revision, r.Resources, r.Archive, r.Namespace, r.Name)
+	return err
+}
+
+func (d *DB) DeleteHelmRelease(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_helm_release WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
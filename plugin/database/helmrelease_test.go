@@ -0,0 +1,108 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	helmReleaseTables = []string{
+		`
+CREATE TABLE baetyl_helm_release(
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace     VARCHAR(64) NOT NULL DEFAULT '',
+    name          VARCHAR(128) NOT NULL DEFAULT '',
+    chart         VARCHAR(128) NOT NULL DEFAULT '',
+    chart_version VARCHAR(64) NOT NULL DEFAULT '',
+    app_version   VARCHAR(64) NOT NULL DEFAULT '',
+    url           VARCHAR(1024) NOT NULL DEFAULT '',
+    chart_values  TEXT,
+    revision      INTEGER NOT NULL DEFAULT 0,
+    resources     TEXT,
+    archive       TEXT,
+    create_time   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateHelmReleaseTable() {
+	for _, sql := range helmReleaseTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestHelmRelease(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateHelmReleaseTable()
+
+	release := &models.HelmRelease{
+		Namespace:    "default",
+		Name:         "release01",
+		Chart:        "nginx",
+		ChartVersion: "0.1.0",
+		AppVersion:   "1.21",
+		URL:          "https://charts.example.com/nginx-0.1.0.tgz",
+		Values:       map[string]interface{}{"replicaCount": float64(2)},
+		Revision:     1,
+		Resources:    []models.HelmResource{{Kind: "Deployment", Name: "release01-nginx"}},
+		Archive:      []byte("chart"),
+	}
+	_, err = db.GetHelmRelease(release.Namespace, release.Name)
+	assert.Error(t, err)
+
+	err = db.CreateHelmRelease(release)
+	assert.NoError(t, err)
+	err = db.CreateHelmRelease(release)
+	assert.Error(t, err)
+
+	res, err := db.GetHelmRelease(release.Namespace, release.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "nginx", res.Chart)
+	assert.Equal(t, release.Values, res.Values)
+	assert.Equal(t, release.Resources, res.Resources)
+	assert.Equal(t, []byte("chart"), res.Archive)
+
+	release.ChartVersion = "0.2.0"
+	release.Revision = 2
+	release.Values = map[string]interface{}{"replicaCount": float64(3)}
+	release.Resources = append(release.Resources, models.HelmResource{Kind: "ConfigMap", Name: "release01-conf"})
+	err = db.UpdateHelmRelease(release)
+	assert.NoError(t, err)
+	res, err = db.GetHelmRelease(release.Namespace, release.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.2.0", res.ChartVersion)
+	assert.Equal(t, 2, res.Revision)
+	assert.Equal(t, release.Values, res.Values)
+	assert.Len(t, res.Resources, 2)
+
+	err = db.CreateHelmRelease(&models.HelmRelease{Namespace: "default", Name: "release00", Chart: "redis", Revision: 1})
+	assert.NoError(t, err)
+	list, err := db.ListHelmRelease("default")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "release00", list[0].Name)
+	assert.Empty(t, list[1].Archive)
+	list, err = db.ListHelmRelease("other")
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	err = db.DeleteHelmRelease(release.Namespace, release.Name)
+	assert.NoError(t, err)
+	_, err = db.GetHelmRelease(release.Namespace, release.Name)
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetIdempotencyRecord(namespace, key string) (*models.IdempotencyRecord, error) {
+	selectSQL := `
+SELECT namespace, idempotency_key, request_hash, status, content_type, response, expire_time 
+FROM baetyl_idempotency_record WHERE namespace=? AND idempotency_key=?
+`
+	var records []entities.IdempotencyRecord
+	if err := d.Query(nil, selectSQL, &records, namespace, key); err != nil {
+		return nil, err
+	}
+	if len(records) > 0 {
+		return entities.ToIdempotencyRecordModel(&records[0]), nil
+	}
+	return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "idempotencyRecord"), common.Field("name", key))
+}
+
+func (d *DB) CreateIdempotencyRecord(record *models.IdempotencyRecord) error {
+	insertSQL := `
+INSERT INTO baetyl_idempotency_record 
+(namespace, idempotency_key, request_hash, status, content_type, response, expire_time) 
+VALUES (?,?,?,?,?,?,?)
+`
+	_, err := d.Exec(nil, insertSQL, record.Namespace, record.Key, record.RequestHash,
+		record.Status, record.ContentType, record.Response, record.ExpireTime)
+	if err != nil {
+		// the unique key is violated by the concurrent request
+		if _, gerr := d.GetIdempotencyRecord(record.Namespace, record.Key); gerr == nil {
+			return common.Error(common.ErrResourceConflict, common.Field("type", "idempotencyRecord"), common.Field("name", record.Key))
+		}
+	}
+	return err
+}
+
+func (d *DB) UpdateIdempotencyRecord(record *models.IdempotencyRecord) error {
+	updateSQL := `
+UPDATE baetyl_idempotency_record SET status=?, content_type=?, response=?, expire_time=? 
+WHERE namespace=? AND idempotency_key=?
+`
+	_, err := d.Exec(nil, updateSQL, record.Status, record.ContentType, record.Response, record.ExpireTime,
+		record.Namespace, record.Key)
+	return err
+}
+
+func (d *DB) DeleteIdempotencyRecord(namespace, key string) error {
+	deleteSQL := `DELETE FROM baetyl_idempotency_record WHERE namespace=? AND idempotency_key=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, key)
+	return err
+}
+
+func (d *DB) DeleteExpiredIdempotencyRecords() error {
+	deleteSQL := `DELETE FROM baetyl_idempotency_record WHERE expire_time < ?`
+	_, err := d.Exec(nil, deleteSQL, time.Now().UTC())
+	return err
+}
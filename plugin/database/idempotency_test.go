@@ -0,0 +1,92 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	idempotencyTables = []string{
+		`
+CREATE TABLE baetyl_idempotency_record(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace       VARCHAR(64) NOT NULL DEFAULT '',
+    idempotency_key VARCHAR(128) NOT NULL DEFAULT '',
+    request_hash    VARCHAR(64) NOT NULL DEFAULT '',
+    status          INTEGER NOT NULL DEFAULT 0,
+    content_type    VARCHAR(128) NOT NULL DEFAULT '',
+    response        BLOB,
+    expire_time     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    create_time     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, idempotency_key)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateIdempotencyTable() {
+	for _, sql := range idempotencyTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestIdempotencyRecord(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateIdempotencyTable()
+
+	record := &models.IdempotencyRecord{
+		Namespace:   "default",
+		Key:         "key01",
+		RequestHash: "hash",
+		ExpireTime:  time.Now().Add(time.Hour).UTC(),
+	}
+	_, err = db.GetIdempotencyRecord(record.Namespace, record.Key)
+	assert.Error(t, err)
+
+	err = db.CreateIdempotencyRecord(record)
+	assert.NoError(t, err)
+	err = db.CreateIdempotencyRecord(record)
+	e, ok := err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, common.ErrResourceConflict, e.Code())
+
+	res, err := db.GetIdempotencyRecord(record.Namespace, record.Key)
+	assert.NoError(t, err)
+	assert.True(t, res.IsPending())
+
+	record.Status = 200
+	record.ContentType = "application/json"
+	record.Response = []byte(`{"name":"node01"}`)
+	err = db.UpdateIdempotencyRecord(record)
+	assert.NoError(t, err)
+	res, err = db.GetIdempotencyRecord(record.Namespace, record.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, res.Status)
+	assert.Equal(t, record.Response, res.Response)
+
+	err = db.DeleteExpiredIdempotencyRecords()
+	assert.NoError(t, err)
+	_, err = db.GetIdempotencyRecord(record.Namespace, record.Key)
+	assert.NoError(t, err)
+
+	err = db.DeleteIdempotencyRecord(record.Namespace, record.Key)
+	assert.NoError(t, err)
+	_, err = db.GetIdempotencyRecord(record.Namespace, record.Key)
+	assert.Error(t, err)
+}
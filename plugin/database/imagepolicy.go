@@ -0,0 +1,54 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetImagePolicy(namespace string) (*models.ImagePolicy, error) {
+	selectSQL := `
+SELECT namespace, mirror, registries, pull_policy, create_time, update_time 
+FROM baetyl_image_policy WHERE namespace=?
+`
+	var policies []entities.ImagePolicy
+	if err := d.Query(nil, selectSQL, &policies, namespace); err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "imagepolicy"), common.Field("name", namespace))
+	}
+	return entities.ToImagePolicyModel(&policies[0])
+}
+
+func (d *DB) CreateImagePolicy(policy *models.ImagePolicy) error {
+	insertSQL := `
+INSERT INTO baetyl_image_policy (namespace, mirror, registries, pull_policy) 
+VALUES (?,?,?,?)
+`
+	p, err := entities.FromImagePolicyModel(policy)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, p.Namespace, p.Mirror, p.Registries, p.PullPolicy)
+	return err
+}
+
+func (d *DB) UpdateImagePolicy(policy *models.ImagePolicy) error {
+	updateSQL := `
+UPDATE baetyl_image_policy SET mirror=?, registries=?, pull_policy=? 
+WHERE namespace=?
+`
+	p, err := entities.FromImagePolicyModel(policy)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, p.Mirror, p.Registries, p.PullPolicy, p.Namespace)
+	return err
+}
+
+func (d *DB) DeleteImagePolicy(namespace string) error {
+	deleteSQL := `DELETE FROM baetyl_image_policy WHERE namespace=?`
+	_, err := d.Exec(nil, deleteSQL, namespace)
+	return err
+}
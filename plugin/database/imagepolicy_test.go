@@ -0,0 +1,83 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	imagePolicyTables = []string{
+		`
+CREATE TABLE baetyl_image_policy(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    mirror      VARCHAR(512) NOT NULL DEFAULT '',
+    registries  TEXT,
+    pull_policy VARCHAR(32) NOT NULL DEFAULT '',
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateImagePolicyTable() {
+	for _, sql := range imagePolicyTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestImagePolicy(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateImagePolicyTable()
+
+	policy := &models.ImagePolicy{
+		Namespace:  "default",
+		Mirror:     "harbor.local/proxy",
+		Registries: []string{"docker.io", "quay.io"},
+		PullPolicy: models.ImagePullIfNotPresent,
+	}
+	_, err = db.GetImagePolicy(policy.Namespace)
+	assert.Error(t, err)
+
+	err = db.CreateImagePolicy(policy)
+	assert.NoError(t, err)
+	err = db.CreateImagePolicy(policy)
+	assert.Error(t, err)
+
+	res, err := db.GetImagePolicy(policy.Namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, "harbor.local/proxy", res.Mirror)
+	assert.Equal(t, []string{"docker.io", "quay.io"}, res.Registries)
+	assert.Equal(t, models.ImagePullIfNotPresent, res.PullPolicy)
+
+	policy.Registries = nil
+	policy.PullPolicy = models.ImagePullAlways
+	err = db.UpdateImagePolicy(policy)
+	assert.NoError(t, err)
+	res, err = db.GetImagePolicy(policy.Namespace)
+	assert.NoError(t, err)
+	assert.Empty(t, res.Registries)
+	assert.Equal(t, models.ImagePullAlways, res.PullPolicy)
+
+	_, err = db.GetImagePolicy("other")
+	assert.Error(t, err)
+
+	err = db.DeleteImagePolicy(policy.Namespace)
+	assert.NoError(t, err)
+	_, err = db.GetImagePolicy(policy.Namespace)
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetInstallTemplate(namespace, name string) (*models.InstallTemplate, error) {
+	selectSQL := `
+SELECT namespace, name, description, content, template_values, create_time, update_time 
+FROM baetyl_install_template WHERE namespace=? AND name=?
+`
+	var tpls []entities.InstallTemplate
+	if err := d.Query(nil, selectSQL, &tpls, namespace, name); err != nil {
+		return nil, err
+	}
+	if len(tpls) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "install template"),
+			common.Field("name", name), common.Field("namespace", namespace))
+	}
+	return entities.ToInstallTemplateModel(&tpls[0])
+}
+
+func (d *DB) ListInstallTemplate(namespace string) ([]models.InstallTemplate, error) {
+	selectSQL := `
+SELECT namespace, name, description, content, template_values, create_time, update_time 
+FROM baetyl_install_template WHERE namespace=? ORDER BY name
+`
+	var tpls []entities.InstallTemplate
+	if err := d.Query(nil, selectSQL, &tpls, namespace); err != nil {
+		return nil, err
+	}
+	res := make([]models.InstallTemplate, 0, len(tpls))
+	for i := range tpls {
+		tpl, err := entities.ToInstallTemplateModel(&tpls[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *tpl)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateInstallTemplate(tpl *models.InstallTemplate) error {
+	insertSQL := `
+INSERT INTO baetyl_install_template (namespace, name, description, content, template_values) 
+VALUES (?,?,?,?,?)
+`
+	t, err := entities.FromInstallTemplateModel(tpl)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, t.Namespace, t.Name, t.Description, t.Content, t.Values)
+	return err
+}
+
+func (d *DB) UpdateInstallTemplate(tpl *models.InstallTemplate) error {
+	updateSQL := `
+UPDATE baetyl_install_template SET description=?, content=?, template_values=? 
+WHERE namespace=? AND name=?
+`
+	t, err := entities.FromInstallTemplateModel(tpl)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, t.Description, t.Content, t.Values, t.Namespace, t.Name)
+	return err
+}
+
+func (d *DB) DeleteInstallTemplate(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_install_template WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	installTemplateTables = []string{
+		`
+CREATE TABLE baetyl_install_template(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace       VARCHAR(64) NOT NULL DEFAULT '',
+    name            VARCHAR(128) NOT NULL DEFAULT '',
+    description     VARCHAR(1024) NOT NULL DEFAULT '',
+    content         TEXT NOT NULL,
+    template_values TEXT,
+    create_time     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateInstallTemplateTable() {
+	for _, sql := range installTemplateTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestInstallTemplate(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateInstallTemplateTable()
+
+	tpl := &models.InstallTemplate{
+		Namespace:   "default",
+		Name:        "baetyl-install.sh",
+		Description: "behind proxy",
+		Content:     "export HTTPS_PROXY={{.Values.proxy}}",
+		Values:      map[string]string{"proxy": "http://proxy:3128"},
+	}
+	_, err = db.GetInstallTemplate(tpl.Namespace, tpl.Name)
+	assert.Error(t, err)
+
+	err = db.CreateInstallTemplate(tpl)
+	assert.NoError(t, err)
+	err = db.CreateInstallTemplate(tpl)
+	assert.Error(t, err)
+
+	res, err := db.GetInstallTemplate(tpl.Namespace, tpl.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "behind proxy", res.Description)
+	assert.Equal(t, tpl.Content, res.Content)
+	assert.Equal(t, tpl.Values, res.Values)
+
+	tpl.Content = "export HTTPS_PROXY={{.Values.proxy}} NO_PROXY={{.Values.noProxy}}"
+	tpl.Values["noProxy"] = "localhost"
+	err = db.UpdateInstallTemplate(tpl)
+	assert.NoError(t, err)
+	res, err = db.GetInstallTemplate(tpl.Namespace, tpl.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, tpl.Content, res.Content)
+	assert.Equal(t, tpl.Values, res.Values)
+
+	err = db.CreateInstallTemplate(&models.InstallTemplate{Namespace: "default", Name: "baetyl-init-command", Content: "curl"})
+	assert.NoError(t, err)
+	list, err := db.ListInstallTemplate("default")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "baetyl-init-command", list[0].Name)
+	assert.Empty(t, list[0].Values)
+	list, err = db.ListInstallTemplate("other")
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	err = db.DeleteInstallTemplate(tpl.Namespace, tpl.Name)
+	assert.NoError(t, err)
+	_, err = db.GetInstallTemplate(tpl.Namespace, tpl.Name)
+	assert.Error(t, err)
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) CreateNodeMetrics(namespace, name string, point *models.NodeMetricsPoint) error {
+	insertSQL := `
+INSERT INTO baetyl_node_metrics (namespace, name, cpu_usage, cpu_capacity, 
+memory_usage, memory_capacity, disk_usage, disk_capacity, report_time) 
+VALUES (?,?,?,?,?,?,?,?,?)
+`
+	m := entities.FromNodeMetricsModel(namespace, name, point)
+	_, err := d.Exec(nil, insertSQL, m.Namespace, m.Name, m.CPUUsage, m.CPUCapacity,
+		m.MemoryUsage, m.MemoryCapacity, m.DiskUsage, m.DiskCapacity, m.ReportTime)
+	return err
+}
+
+func (d *DB) ListNodeMetrics(namespace, name string, start, end time.Time) ([]models.NodeMetricsPoint, error) {
+	selectSQL := `
+SELECT namespace, name, cpu_usage, cpu_capacity, memory_usage, memory_capacity, 
+disk_usage, disk_capacity, report_time 
+FROM baetyl_node_metrics WHERE namespace=? AND name=? AND report_time>=? AND report_time<? 
+ORDER BY report_time ASC
+`
+	var metrics []entities.NodeMetrics
+	if err := d.Query(nil, selectSQL, &metrics, namespace, name, start.UTC(), end.UTC()); err != nil {
+		return nil, err
+	}
+	res := make([]models.NodeMetricsPoint, 0, len(metrics))
+	for i := range metrics {
+		res = append(res, entities.ToNodeMetricsModel(&metrics[i]))
+	}
+	return res, nil
+}
+
+func (d *DB) DeleteNodeMetrics(before time.Time) error {
+	deleteSQL := `DELETE FROM baetyl_node_metrics WHERE report_time<?`
+	_, err := d.Exec(nil, deleteSQL, before.UTC())
+	return err
+}
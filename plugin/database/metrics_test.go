@@ -0,0 +1,84 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	nodeMetricsTables = []string{
+		`
+CREATE TABLE baetyl_node_metrics(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace       VARCHAR(64) NOT NULL DEFAULT '',
+    name            VARCHAR(128) NOT NULL DEFAULT '',
+    cpu_usage       DOUBLE NOT NULL DEFAULT 0,
+    cpu_capacity    DOUBLE NOT NULL DEFAULT 0,
+    memory_usage    BIGINT NOT NULL DEFAULT 0,
+    memory_capacity BIGINT NOT NULL DEFAULT 0,
+    disk_usage      BIGINT NOT NULL DEFAULT 0,
+    disk_capacity   BIGINT NOT NULL DEFAULT 0,
+    report_time     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateNodeMetricsTable() {
+	for _, sql := range nodeMetricsTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestNodeMetrics(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateNodeMetricsTable()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		err = db.CreateNodeMetrics("default", "node01", &models.NodeMetricsPoint{
+			Time:           now.Add(time.Duration(i-2) * time.Minute),
+			CPUUsage:       0.5 + float64(i),
+			CPUCapacity:    4,
+			MemoryUsage:    int64(i) * 1024,
+			MemoryCapacity: 8192,
+		})
+		assert.NoError(t, err)
+	}
+	err = db.CreateNodeMetrics("default", "node02", &models.NodeMetricsPoint{Time: now, CPUCapacity: 2})
+	assert.NoError(t, err)
+
+	points, err := db.ListNodeMetrics("default", "node01", now.Add(-time.Hour), now.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Len(t, points, 3)
+	assert.Equal(t, now.Add(-2*time.Minute), points[0].Time)
+	assert.Equal(t, 2.5, points[2].CPUUsage)
+	assert.Equal(t, int64(2048), points[2].MemoryUsage)
+	assert.Equal(t, int64(8192), points[2].MemoryCapacity)
+
+	points, err = db.ListNodeMetrics("default", "node01", now.Add(-time.Minute), now)
+	assert.NoError(t, err)
+	assert.Len(t, points, 1)
+
+	err = db.DeleteNodeMetrics(now.Add(-30 * time.Second))
+	assert.NoError(t, err)
+	points, err = db.ListNodeMetrics("default", "node01", now.Add(-time.Hour), now.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Len(t, points, 1)
+	points, err = db.ListNodeMetrics("default", "node02", now.Add(-time.Hour), now.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Len(t, points, 1)
+}
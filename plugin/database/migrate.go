@@ -0,0 +1,332 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+)
+
+// migrationFS the migrations of schema in the directories of the types of database, which are named as
+// <version>_<name>.up.sql and <version>_<name>.down.sql
+//
+//go:embed migrations
+var migrationFS embed.FS
+
+const (
+	migrationDir = "migrations"
+	// migrationLockKey the key of advisory lock of postgres
+	migrationLockKey = 20201001
+	// migrationLockTimeout the seconds to wait for the lock of mysql held by another instance migrating
+	migrationLockTimeout = 600
+	// baselineVersion the version of schema created by the scripts before the migrations
+	baselineVersion = 1
+	// baselineTable the table of the schema created by the scripts
+	baselineTable = "baetyl_property"
+)
+
+const (
+	createMigrationTableSQL = `
+CREATE TABLE IF NOT EXISTS baetyl_schema_migration (
+  version BIGINT NOT NULL,
+  name VARCHAR(255) NOT NULL DEFAULT '',
+  dirty BOOLEAN NOT NULL DEFAULT FALSE,
+  applied_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (version)
+)`
+	selectMigrationSQL = `SELECT version, dirty FROM baetyl_schema_migration ORDER BY version DESC LIMIT 1`
+	insertMigrationSQL = `INSERT INTO baetyl_schema_migration (version, name, dirty) VALUES (?, ?, ?)`
+	dirtyMigrationSQL  = `UPDATE baetyl_schema_migration SET dirty=? WHERE version=?`
+	deleteMigrationSQL = `DELETE FROM baetyl_schema_migration WHERE version=?`
+)
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration the change of schema, which is rolled back by Down
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// loadMigrations returns the migrations in the directory sorted by version, none if the directory is absent
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	files, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Trace(err)
+	}
+	migrations := map[int64]*Migration{}
+	for _, f := range files {
+		match := migrationFile.FindStringSubmatch(f.Name())
+		if f.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, errors.Errorf("the version of migration %s is invalid", f.Name())
+		}
+		m, ok := migrations[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			migrations[version] = m
+		} else if m.Name != match[2] {
+			return nil, errors.Errorf("the migrations %s and %s have the same version", m.Name, match[2])
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, f.Name()))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if match[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+	res := make([]Migration, 0, len(migrations))
+	for _, m := range migrations {
+		if m.Up == "" {
+			return nil, errors.Errorf("the migration %d_%s has no up sql", m.Version, m.Name)
+		}
+		res = append(res, *m)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Version < res[j].Version })
+	return res, nil
+}
+
+// initSchema migrates the schema to the version configured if autoMigrate is set, otherwise checks that the
+// schema is at the version. The schema of the database without migrations, such as sqlite, is not managed
+func (d *DB) initSchema(fsys fs.FS) error {
+	migrations, err := loadMigrations(fsys, path.Join(migrationDir, d.cfg.Database.Type))
+	if err != nil || len(migrations) == 0 {
+		return err
+	}
+	target := migrations[len(migrations)-1].Version
+	if v := d.cfg.Database.MigrateVersion; v != 0 {
+		i := sort.Search(len(migrations), func(i int) bool { return migrations[i].Version >= v })
+		if i == len(migrations) || migrations[i].Version != v {
+			return errors.Errorf("the migration of version %d is not found", v)
+		}
+		target = v
+	}
+
+	ctx := context.Background()
+	conn, err := d.db.Conn(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer conn.Close()
+	unlock, err := d.lockSchema(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if _, err = conn.ExecContext(ctx, createMigrationTableSQL); err != nil {
+		return errors.Trace(err)
+	}
+	version, dirty, err := d.schemaVersion(ctx, conn, migrations[0])
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return errors.Errorf("the migration of schema to version %d failed, fix the schema and the record in baetyl_schema_migration manually", version)
+	}
+	if version == target {
+		return nil
+	}
+	if !d.cfg.Database.AutoMigrate {
+		return errors.Errorf("the schema of database is at version %d rather than %d, set database.autoMigrate to migrate it", version, target)
+	}
+	return d.migrateSchema(ctx, conn, migrations, version, target)
+}
+
+func (d *DB) migrateSchema(ctx context.Context, conn *sql.Conn, migrations []Migration, version, target int64) error {
+	if version < target {
+		for _, m := range migrations {
+			if m.Version > version && m.Version <= target {
+				if err := d.applyMigration(ctx, conn, m, true); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.Version <= version && m.Version > target {
+			if m.Down == "" {
+				return errors.Errorf("the migration %d_%s can not be rolled back", m.Version, m.Name)
+			}
+			if err := d.applyMigration(ctx, conn, m, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyMigration runs the migration in a transaction except mysql, whose ddl commits implicitly. The migration
+// is recorded as dirty while running, so the schema left by a failure is found on the next startup
+func (d *DB) applyMigration(ctx context.Context, conn *sql.Conn, m Migration, up bool) (err error) {
+	var ex execer = conn
+	if d.cfg.Database.Type != TypeMySQL {
+		var tx *sql.Tx
+		if tx, err = conn.BeginTx(ctx, nil); err != nil {
+			return errors.Trace(err)
+		}
+		defer func() {
+			if err != nil {
+				tx.Rollback()
+			} else {
+				err = errors.Trace(tx.Commit())
+			}
+		}()
+		ex = tx
+	}
+
+	script := m.Up
+	if up {
+		_, err = ex.ExecContext(ctx, d.rebind(insertMigrationSQL), m.Version, m.Name, true)
+	} else {
+		script = m.Down
+		_, err = ex.ExecContext(ctx, d.rebind(dirtyMigrationSQL), true, m.Version)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, s := range splitStatements(script) {
+		if _, err = ex.ExecContext(ctx, s); err != nil {
+			return errors.Errorf("failed to run the migration %d_%s: %s", m.Version, m.Name, err.Error())
+		}
+	}
+	if up {
+		_, err = ex.ExecContext(ctx, d.rebind(dirtyMigrationSQL), false, m.Version)
+	} else {
+		_, err = ex.ExecContext(ctx, d.rebind(deleteMigrationSQL), m.Version)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	log.L().Info("schema migrated", log.Any("version", m.Version), log.Any("name", m.Name), log.Any("up", up))
+	return nil
+}
+
+// schemaVersion returns the latest version applied. The schema created by the scripts before the migrations is
+// recorded as the baseline
+func (d *DB) schemaVersion(ctx context.Context, conn *sql.Conn, baseline Migration) (version int64, dirty bool, err error) {
+	err = conn.QueryRowContext(ctx, selectMigrationSQL).Scan(&version, &dirty)
+	if err == nil {
+		return
+	}
+	if err != sql.ErrNoRows {
+		return 0, false, errors.Trace(err)
+	}
+	if baseline.Version != baselineVersion {
+		return 0, false, nil
+	}
+	if _, err = conn.ExecContext(ctx, "SELECT 1 FROM "+baselineTable+" WHERE 1=0"); err != nil {
+		return 0, false, nil
+	}
+	if _, err = conn.ExecContext(ctx, d.rebind(insertMigrationSQL), baseline.Version, baseline.Name, false); err != nil {
+		return 0, false, errors.Trace(err)
+	}
+	log.L().Warn("the schema created by the scripts is taken as the baseline of migrations", log.Any("version", baseline.Version))
+	return baseline.Version, false, nil
+}
+
+// lockSchema keeps the instances starting at the same time from migrating the schema concurrently
+func (d *DB) lockSchema(ctx context.Context, conn *sql.Conn) (func(), error) {
+	switch d.cfg.Database.Type {
+	case TypeMySQL:
+		var res sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", "baetyl_schema_migration", migrationLockTimeout).Scan(&res); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if res.Int64 != 1 {
+			return nil, errors.Errorf("timeout to wait for the schema migrated by another instance")
+		}
+		return func() {
+			conn.QueryRowContext(ctx, "SELECT RELEASE_LOCK(?)", "baetyl_schema_migration").Scan(&res)
+		}, nil
+	case TypePostgres:
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return func() {
+			conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey)
+		}, nil
+	default:
+		return func() {}, nil
+	}
+}
+
+// splitStatements splits the sql by the semicolons out of the quotes and the bodies of postgres functions
+// quoted by $$, the comments starting with -- are removed
+func splitStatements(s string) []string {
+	var res []string
+	var b strings.Builder
+	flush := func() {
+		if st := strings.TrimSpace(b.String()); st != "" {
+			res = append(res, st)
+		}
+		b.Reset()
+	}
+	quote := ""
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != "" {
+			b.WriteByte(c)
+			if c == '\\' && quote != "$$" && i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			} else if strings.HasPrefix(s[i:], quote) {
+				if quote == "$$" {
+					i++
+					b.WriteByte(s[i])
+				}
+				quote = ""
+			}
+			continue
+		}
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			quote = string(c)
+		case strings.HasPrefix(s[i:], "$$"):
+			quote = "$$"
+			b.WriteByte(c)
+			i++
+			c = s[i]
+		case strings.HasPrefix(s[i:], "--"):
+			j := strings.IndexByte(s[i:], '\n')
+			if j < 0 {
+				i = len(s)
+				continue
+			}
+			i += j
+			c = '\n'
+		case c == ';':
+			flush()
+			continue
+		}
+		b.WriteByte(c)
+	}
+	flush()
+	return res
+}
@@ -0,0 +1,221 @@
+package database
+
+import (
+	"context"
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+// the tests of mysql run against the database given by the env, such as
+// root:secretpassword@(localhost:3306)/baetyl_cloud?charset=utf8&parseTime=true
+const envTestMySQL = "BAETYL_TEST_MYSQL"
+
+var (
+	testMigrations = fstest.MapFS{
+		"migrations/sqlite3/0001_init.up.sql": {Data: []byte(`
+CREATE TABLE baetyl_property (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  value TEXT NOT NULL DEFAULT '' -- the value; may be empty
+);
+INSERT INTO baetyl_property (name, value) VALUES ('init', 'a;b');
+`)},
+		"migrations/sqlite3/0001_init.down.sql": {Data: []byte(`DROP TABLE baetyl_property;`)},
+		"migrations/sqlite3/0002_node.up.sql":   {Data: []byte(`CREATE TABLE baetyl_node (name VARCHAR(128) NOT NULL DEFAULT '');`)},
+		"migrations/sqlite3/0002_node.down.sql": {Data: []byte(`DROP TABLE baetyl_node;`)},
+	}
+)
+
+func mockMigrateDB(t *testing.T) *DB {
+	db, err := MockNewDB()
+	assert.NoError(t, err)
+	// the connections of sqlite in memory are different databases
+	db.db.SetMaxOpenConns(1)
+	return db
+}
+
+func schemaVersions(t *testing.T, db *DB) []int64 {
+	var res []int64
+	assert.NoError(t, db.db.Select(&res, "SELECT version FROM baetyl_schema_migration WHERE dirty=0 ORDER BY version"))
+	return res
+}
+
+func tableExists(db *DB, table string) bool {
+	_, err := db.db.Exec("SELECT 1 FROM " + table + " WHERE 1=0")
+	return err == nil
+}
+
+func TestSplitStatements(t *testing.T) {
+	res := splitStatements(`
+-- the comment; is removed
+CREATE TABLE IF NOT EXISTS ` + "`baetyl_a;b`" + ` (
+  name varchar(128) NOT NULL DEFAULT 'a;b' COMMENT 'it''s \'a;b\''
+);
+
+CREATE OR REPLACE FUNCTION baetyl_set_update_time() RETURNS TRIGGER AS $$
+BEGIN
+  NEW.update_time = CURRENT_TIMESTAMP;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+DROP TABLE x`)
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS `baetyl_a;b` (\n  name varchar(128) NOT NULL DEFAULT 'a;b' COMMENT 'it''s \\'a;b\\''\n)",
+		"CREATE OR REPLACE FUNCTION baetyl_set_update_time() RETURNS TRIGGER AS $$\nBEGIN\n  NEW.update_time = CURRENT_TIMESTAMP;\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql",
+		"DROP TABLE x",
+	}, res)
+	assert.Empty(t, splitStatements("-- nothing\n;\n"))
+}
+
+func TestLoadMigrations(t *testing.T) {
+	mysql, err := loadMigrations(migrationFS, path.Join(migrationDir, TypeMySQL))
+	assert.NoError(t, err)
+	postgres, err := loadMigrations(migrationFS, path.Join(migrationDir, TypePostgres))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, mysql)
+	assert.Len(t, postgres, len(mysql))
+	assert.Equal(t, int64(baselineVersion), mysql[0].Version)
+	for i := range mysql {
+		assert.Equal(t, mysql[i].Version, postgres[i].Version)
+		assert.Equal(t, mysql[i].Name, postgres[i].Name)
+		assert.NotEmpty(t, mysql[i].Down)
+		assert.NotEmpty(t, postgres[i].Down)
+	}
+	// the tables created are the same
+	tables := func(sql string) (res []string) {
+		for _, s := range splitStatements(sql) {
+			if strings.HasPrefix(s, "CREATE TABLE IF NOT EXISTS ") {
+				res = append(res, strings.Trim(strings.Fields(s)[5], "`"))
+			}
+		}
+		return
+	}
+	for i := range mysql {
+		assert.Equal(t, tables(mysql[i].Up), tables(postgres[i].Up), mysql[i].Name)
+	}
+	assert.Contains(t, tables(mysql[0].Up), baselineTable)
+	// the baseline is the schema of the scripts, the tables added later are migrated
+	assert.Len(t, tables(mysql[0].Up), 11)
+
+	res, err := loadMigrations(testMigrations, "migrations/sqlite3")
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "init", res[0].Name)
+	assert.Equal(t, int64(2), res[1].Version)
+	res, err = loadMigrations(testMigrations, "migrations/"+TypeMySQL)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	_, err = loadMigrations(fstest.MapFS{
+		"migrations/sqlite3/0001_init.down.sql": {Data: []byte(`DROP TABLE baetyl_property;`)},
+	}, "migrations/sqlite3")
+	assert.Error(t, err)
+	_, err = loadMigrations(fstest.MapFS{
+		"migrations/sqlite3/0001_init.up.sql": {Data: []byte(`SELECT 1;`)},
+		"migrations/sqlite3/0001_node.up.sql": {Data: []byte(`SELECT 1;`)},
+	}, "migrations/sqlite3")
+	assert.Error(t, err)
+}
+
+func TestInitSchema(t *testing.T) {
+	db := mockMigrateDB(t)
+	defer db.Close()
+
+	// the schema is checked only
+	err := db.initSchema(testMigrations)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "autoMigrate")
+	assert.False(t, tableExists(db, "baetyl_property"))
+
+	db.cfg.Database.AutoMigrate = true
+	assert.NoError(t, db.initSchema(testMigrations))
+	assert.Equal(t, []int64{1, 2}, schemaVersions(t, db))
+	assert.True(t, tableExists(db, "baetyl_node"))
+	var values []string
+	assert.NoError(t, db.db.Select(&values, "SELECT value FROM baetyl_property"))
+	assert.Equal(t, []string{"a;b"}, values)
+
+	db.cfg.Database.AutoMigrate = false
+	assert.NoError(t, db.initSchema(testMigrations))
+
+	// rollback
+	db.cfg.Database.AutoMigrate = true
+	db.cfg.Database.MigrateVersion = 1
+	assert.NoError(t, db.initSchema(testMigrations))
+	assert.Equal(t, []int64{1}, schemaVersions(t, db))
+	assert.False(t, tableExists(db, "baetyl_node"))
+	assert.True(t, tableExists(db, "baetyl_property"))
+
+	db.cfg.Database.MigrateVersion = 3
+	assert.Error(t, db.initSchema(testMigrations))
+
+	// the failed migration is rolled back
+	db.cfg.Database.MigrateVersion = 0
+	failed := fstest.MapFS{
+		"migrations/sqlite3/0001_init.up.sql":   testMigrations["migrations/sqlite3/0001_init.up.sql"],
+		"migrations/sqlite3/0002_node.up.sql":   {Data: []byte(`CREATE TABLE baetyl_node (name VARCHAR(128)); SELECT * FROM unknown;`)},
+		"migrations/sqlite3/0003_other.up.sql":  {Data: []byte(`CREATE TABLE baetyl_other (name VARCHAR(128));`)},
+		"migrations/sqlite3/0002_node.down.sql": testMigrations["migrations/sqlite3/0002_node.down.sql"],
+	}
+	err = db.initSchema(failed)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "2_node")
+	assert.Equal(t, []int64{1}, schemaVersions(t, db))
+	assert.False(t, tableExists(db, "baetyl_node"))
+	assert.False(t, tableExists(db, "baetyl_other"))
+
+	// the dirty schema is fixed manually
+	_, err = db.db.Exec("INSERT INTO baetyl_schema_migration (version, name, dirty) VALUES (2, 'node', 1)")
+	assert.NoError(t, err)
+	err = db.initSchema(testMigrations)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "manually")
+}
+
+func TestInitSchemaBaseline(t *testing.T) {
+	db := mockMigrateDB(t)
+	defer db.Close()
+	db.cfg.Database.AutoMigrate = true
+
+	// the schema created by the scripts
+	_, err := db.db.Exec("CREATE TABLE baetyl_property (name VARCHAR(128) NOT NULL DEFAULT '')")
+	assert.NoError(t, err)
+	assert.NoError(t, db.initSchema(testMigrations))
+	assert.Equal(t, []int64{1, 2}, schemaVersions(t, db))
+	var values []string
+	assert.NoError(t, db.db.Select(&values, "SELECT name FROM baetyl_property"))
+	assert.Empty(t, values)
+
+	// the schema of the database without migrations is not managed
+	db.cfg.Database.Type = "unknown"
+	assert.NoError(t, db.initSchema(testMigrations))
+}
+
+func TestMigrateExternalDB(t *testing.T) {
+	for tp, env := range map[string]string{TypeMySQL: envTestMySQL, TypePostgres: envTestPostgres} {
+		t.Run(tp, func(t *testing.T) {
+			db := mockNewExternalDB(t, tp, env)
+			defer db.Close()
+			migrations, err := loadMigrations(migrationFS, path.Join(migrationDir, tp))
+			assert.NoError(t, err)
+			latest := migrations[len(migrations)-1].Version
+			assert.True(t, tableExists(db, baselineTable))
+
+			// all the migrations are rolled back and applied again
+			ctx := context.Background()
+			conn, err := db.db.Conn(ctx)
+			assert.NoError(t, err)
+			defer conn.Close()
+			assert.NoError(t, db.migrateSchema(ctx, conn, migrations, latest, 0))
+			assert.False(t, tableExists(db, baselineTable))
+			assert.NoError(t, db.migrateSchema(ctx, conn, migrations, 0, latest))
+			assert.True(t, tableExists(db, baselineTable))
+			assert.NoError(t, db.initSchema(migrationFS))
+		})
+	}
+}
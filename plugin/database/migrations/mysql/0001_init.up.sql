@@ -1,5 +1,3 @@
-CREATE DATABASE IF NOT EXISTS `baetyl_cloud` DEFAULT CHARACTER SET utf8 COLLATE utf8_general_ci;
-USE `baetyl_cloud`;
 CREATE TABLE IF NOT EXISTS `baetyl_batch` (
   `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
   `name` varchar(128) NOT NULL DEFAULT '' COMMENT '批号',
@@ -21,7 +19,6 @@ CREATE TABLE IF NOT EXISTS `baetyl_batch` (
   UNIQUE KEY `unique_name` (`namespace`,`name`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='批号管理';
 
-
 CREATE TABLE IF NOT EXISTS `baetyl_batch_record` (
   `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
   `name` varchar(128) NOT NULL DEFAULT '' COMMENT '注册记录uuid',
@@ -162,4 +159,3 @@ CREATE TABLE IF NOT EXISTS `baetyl_cron_app` (
   PRIMARY KEY (`id`),
   UNIQUE KEY `unique_name` (`namespace`,`name`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='cron app table';
-COMMIT;
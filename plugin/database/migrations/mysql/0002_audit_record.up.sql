@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS `baetyl_audit_record` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `username` varchar(128) NOT NULL DEFAULT '' COMMENT '操作用户',
+  `method` varchar(16) NOT NULL DEFAULT '' COMMENT '请求方法',
+  `path` varchar(512) NOT NULL DEFAULT '' COMMENT '请求路径',
+  `resource` varchar(64) NOT NULL DEFAULT '' COMMENT '资源类型',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '资源名称',
+  `request_id` varchar(64) NOT NULL DEFAULT '' COMMENT '请求ID',
+  `status` int(11) NOT NULL DEFAULT '0' COMMENT 'http状态码',
+  `code` varchar(64) NOT NULL DEFAULT '' COMMENT '错误码',
+  `diff` mediumtext NULL COMMENT '资源变更',
+  `prev_hash` varchar(64) NOT NULL DEFAULT '' COMMENT '上一条记录的hash',
+  `hash` varchar(64) NOT NULL DEFAULT '' COMMENT '记录hash',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  PRIMARY KEY (`id`),
+  KEY `idx_namespace` (`namespace`,`create_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='audit record table';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS `baetyl_idempotency_record` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `idempotency_key` varchar(128) NOT NULL DEFAULT '' COMMENT '幂等键',
+  `request_hash` varchar(64) NOT NULL DEFAULT '' COMMENT '请求hash',
+  `status` int(11) NOT NULL DEFAULT '0' COMMENT 'http状态码,0表示处理中',
+  `content_type` varchar(128) NOT NULL DEFAULT '' COMMENT '响应类型',
+  `response` mediumblob NULL COMMENT '响应内容',
+  `expire_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '过期时间',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_key` (`namespace`,`idempotency_key`),
+  KEY `idx_expire_time` (`expire_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='idempotency record table';
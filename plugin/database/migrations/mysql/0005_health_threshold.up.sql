@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `baetyl_health_threshold` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `warning_score` int(11) NOT NULL DEFAULT '80' COMMENT '告警分数线',
+  `critical_score` int(11) NOT NULL DEFAULT '50' COMMENT '严重分数线',
+  `webhook` varchar(1024) NOT NULL DEFAULT '' COMMENT '告警回调地址',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_namespace` (`namespace`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='node health threshold table';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS `baetyl_node_metrics` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '节点名称',
+  `cpu_usage` double NOT NULL DEFAULT '0' COMMENT 'CPU使用量(核)',
+  `cpu_capacity` double NOT NULL DEFAULT '0' COMMENT 'CPU总量(核)',
+  `memory_usage` bigint(20) NOT NULL DEFAULT '0' COMMENT '内存使用量(字节)',
+  `memory_capacity` bigint(20) NOT NULL DEFAULT '0' COMMENT '内存总量(字节)',
+  `disk_usage` bigint(20) NOT NULL DEFAULT '0' COMMENT '磁盘使用量(字节)',
+  `disk_capacity` bigint(20) NOT NULL DEFAULT '0' COMMENT '磁盘总量(字节)',
+  `report_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '上报时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_node_time` (`namespace`,`name`,`report_time`),
+  KEY `idx_report_time` (`report_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='node metrics table';
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS `baetyl_heartbeat_policy` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `offline_timeout` int(11) NOT NULL DEFAULT '20' COMMENT '离线超时(秒)',
+  `webhook` varchar(1024) NOT NULL DEFAULT '' COMMENT '事件回调地址',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_namespace` (`namespace`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='heartbeat policy table';
+
+CREATE TABLE IF NOT EXISTS `baetyl_node_event` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `node` varchar(128) NOT NULL DEFAULT '' COMMENT '节点名称',
+  `type` varchar(32) NOT NULL DEFAULT '' COMMENT '事件类型',
+  `event_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '事件时间',
+  `report_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '最后上报时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_node_time` (`namespace`,`node`,`event_time`),
+  KEY `idx_event_time` (`namespace`,`event_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='node online and offline event table';
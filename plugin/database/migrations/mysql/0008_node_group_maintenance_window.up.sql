@@ -0,0 +1,2 @@
+ALTER TABLE `baetyl_node_group`
+  ADD COLUMN `maintenance_window` text NULL COMMENT '维护窗口' AFTER `nodes`;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `baetyl_node_filter` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `user_id` varchar(128) NOT NULL DEFAULT '' COMMENT '用户ID',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '筛选条件名称',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '描述',
+  `selector` varchar(2048) NOT NULL DEFAULT '' COMMENT '节点标签选择器',
+  `query` text NULL COMMENT '节点筛选条件',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`namespace`,`user_id`,`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='saved node filter table';
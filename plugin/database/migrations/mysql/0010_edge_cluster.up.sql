@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `baetyl_edge_cluster` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '边缘集群名称',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '描述',
+  `labels` text NULL COMMENT '标签',
+  `node` varchar(128) NOT NULL DEFAULT '' COMMENT '集群管理节点名称',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`namespace`,`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='edge cluster table';
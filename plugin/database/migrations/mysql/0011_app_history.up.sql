@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `baetyl_app_history` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '应用名称',
+  `revision` bigint(20) NOT NULL DEFAULT '0' COMMENT '修订号',
+  `version` varchar(36) NOT NULL DEFAULT '' COMMENT '应用版本',
+  `content` mediumtext NULL COMMENT '应用内容',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_revision` (`namespace`,`name`,`revision`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='application history table';
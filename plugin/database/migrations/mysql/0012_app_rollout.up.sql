@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS `baetyl_app_rollout` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `app` varchar(128) NOT NULL DEFAULT '' COMMENT '应用名称',
+  `version` varchar(36) NOT NULL DEFAULT '' COMMENT '发布的应用版本',
+  `strategy` text NULL COMMENT '分批发布策略',
+  `stage` int(11) NOT NULL DEFAULT '0' COMMENT '当前批次',
+  `status` varchar(32) NOT NULL DEFAULT '' COMMENT '发布状态',
+  `nodes` mediumtext NULL COMMENT '已发布节点',
+  `failed` text NULL COMMENT '异常节点',
+  `message` varchar(1024) NOT NULL DEFAULT '' COMMENT '状态信息',
+  `stage_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '当前批次开始时间',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_app` (`namespace`,`app`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='application rollout table';
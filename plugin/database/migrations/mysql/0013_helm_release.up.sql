@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS `baetyl_helm_release` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '发布名称',
+  `chart` varchar(128) NOT NULL DEFAULT '' COMMENT 'chart名称',
+  `chart_version` varchar(64) NOT NULL DEFAULT '' COMMENT 'chart版本',
+  `app_version` varchar(64) NOT NULL DEFAULT '' COMMENT '应用版本',
+  `url` varchar(1024) NOT NULL DEFAULT '' COMMENT 'chart下载地址',
+  `chart_values` mediumtext NULL COMMENT '渲染参数',
+  `revision` int(11) NOT NULL DEFAULT '0' COMMENT '修订号',
+  `resources` text NULL COMMENT '生成的资源',
+  `archive` mediumtext NULL COMMENT 'chart压缩包',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`namespace`,`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='helm release table';
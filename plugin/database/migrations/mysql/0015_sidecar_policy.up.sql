@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `baetyl_sidecar_policy` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '边车策略名称',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '描述',
+  `selector` varchar(2048) NOT NULL DEFAULT '' COMMENT '应用标签选择器',
+  `sidecar` text NULL COMMENT '注入的边车容器',
+  `volumes` text NULL COMMENT '注入的卷',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`namespace`,`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='sidecar policy table';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `baetyl_image_policy` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `mirror` varchar(512) NOT NULL DEFAULT '' COMMENT '镜像仓库地址',
+  `registries` text NULL COMMENT '改写的镜像仓库列表',
+  `pull_policy` varchar(32) NOT NULL DEFAULT '' COMMENT '镜像拉取策略',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_namespace` (`namespace`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='image policy table';
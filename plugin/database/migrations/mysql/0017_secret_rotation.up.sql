@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS `baetyl_secret_rotation` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '密钥名称',
+  `expires_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '过期时间',
+  `rotator` varchar(64) NOT NULL DEFAULT '' COMMENT '轮换插件',
+  `rotate_interval` varchar(32) NOT NULL DEFAULT '' COMMENT '轮换后有效期',
+  `rotate_keys` text NULL COMMENT '轮换的键列表',
+  `webhook` varchar(1024) NOT NULL DEFAULT '' COMMENT '事件回调地址',
+  `status` varchar(32) NOT NULL DEFAULT '' COMMENT '状态',
+  `rotate_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '最近轮换时间',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_namespace_name` (`namespace`, `name`),
+  KEY `idx_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='secret rotation table';
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `baetyl_function_runtime` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '运行时名称',
+  `language` varchar(64) NOT NULL DEFAULT '' COMMENT '编程语言',
+  `version` varchar(64) NOT NULL DEFAULT '' COMMENT '语言版本',
+  `image` varchar(512) NOT NULL DEFAULT '' COMMENT '基础镜像',
+  `handler` varchar(128) NOT NULL DEFAULT '' COMMENT '函数入口约定',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '描述',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='function runtime table';
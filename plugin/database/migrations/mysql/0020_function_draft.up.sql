@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS `baetyl_function_draft` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '函数名称',
+  `runtime` varchar(64) NOT NULL DEFAULT '' COMMENT '函数运行时',
+  `handler` varchar(128) NOT NULL DEFAULT '' COMMENT '函数入口',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '描述',
+  `files` mediumtext NULL COMMENT '代码文件',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`namespace`,`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='function draft table';
+
+CREATE TABLE IF NOT EXISTS `baetyl_function_version` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '函数名称',
+  `version` varchar(64) NOT NULL DEFAULT '' COMMENT '函数版本',
+  `runtime` varchar(64) NOT NULL DEFAULT '' COMMENT '函数运行时',
+  `handler` varchar(128) NOT NULL DEFAULT '' COMMENT '函数入口',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '描述',
+  `source` varchar(64) NOT NULL DEFAULT '' COMMENT '代码包对象存储源',
+  `bucket` varchar(128) NOT NULL DEFAULT '' COMMENT '代码包所在桶',
+  `object` varchar(512) NOT NULL DEFAULT '' COMMENT '代码包对象名称',
+  `sha256` varchar(64) NOT NULL DEFAULT '' COMMENT '代码包摘要',
+  `size` bigint(20) NOT NULL DEFAULT '0' COMMENT '代码包大小',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_version` (`namespace`,`name`,`version`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='function version table';
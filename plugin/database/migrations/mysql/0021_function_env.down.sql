@@ -0,0 +1,4 @@
+ALTER TABLE `baetyl_function_version`
+  DROP COLUMN `env`;
+ALTER TABLE `baetyl_function_draft`
+  DROP COLUMN `env`;
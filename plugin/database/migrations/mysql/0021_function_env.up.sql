@@ -0,0 +1,5 @@
+ALTER TABLE `baetyl_function_draft`
+  ADD COLUMN `env` text NULL COMMENT '环境变量声明' AFTER `files`;
+
+ALTER TABLE `baetyl_function_version`
+  ADD COLUMN `env` text NULL COMMENT '环境变量声明' AFTER `size`;
@@ -0,0 +1,4 @@
+ALTER TABLE `baetyl_function_runtime`
+  DROP COLUMN `gpu_image`;
+ALTER TABLE `baetyl_function_build`
+  DROP COLUMN `flavor`;
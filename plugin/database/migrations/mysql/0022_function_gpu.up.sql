@@ -0,0 +1,5 @@
+ALTER TABLE `baetyl_function_build`
+  ADD COLUMN `flavor` varchar(16) NOT NULL DEFAULT '' COMMENT '运行时规格' AFTER `size`;
+
+ALTER TABLE `baetyl_function_runtime`
+  ADD COLUMN `gpu_image` varchar(512) NOT NULL DEFAULT '' COMMENT 'GPU基础镜像' AFTER `image`;
@@ -0,0 +1,2 @@
+ALTER TABLE `baetyl_function_runtime`
+  ADD COLUMN `type` varchar(16) NOT NULL DEFAULT '' COMMENT '运行时类型' AFTER `version`;
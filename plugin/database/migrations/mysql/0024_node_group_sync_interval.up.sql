@@ -0,0 +1,2 @@
+ALTER TABLE `baetyl_node_group`
+  ADD COLUMN `sync_interval` text NULL COMMENT '同步间隔' AFTER `maintenance_window`;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS `baetyl_sync_history` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '节点名称',
+  `kind` varchar(64) NOT NULL DEFAULT '' COMMENT '同步消息类型',
+  `request_size` int(11) NOT NULL DEFAULT '0' COMMENT '请求大小(字节)',
+  `response_size` int(11) NOT NULL DEFAULT '0' COMMENT '响应大小(字节)',
+  `latency` bigint(20) NOT NULL DEFAULT '0' COMMENT '处理耗时(毫秒)',
+  `protocol` int(11) NOT NULL DEFAULT '0' COMMENT '同步协议版本',
+  `client_version` varchar(256) NOT NULL DEFAULT '' COMMENT '客户端版本',
+  `client_ip` varchar(64) NOT NULL DEFAULT '' COMMENT '客户端IP',
+  `error` varchar(1024) NOT NULL DEFAULT '' COMMENT '错误信息',
+  `sync_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '同步时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_node_time` (`namespace`,`name`,`sync_time`),
+  KEY `idx_sync_time` (`sync_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='sync history table';
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `baetyl_activation_token` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(64) NOT NULL DEFAULT '' COMMENT '激活令牌名称',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '描述',
+  `node` varchar(128) NOT NULL DEFAULT '' COMMENT '绑定的节点',
+  `node_group` varchar(128) NOT NULL DEFAULT '' COMMENT '绑定的节点组',
+  `max_uses` int(11) NOT NULL DEFAULT '1' COMMENT '最大使用次数',
+  `uses` int(11) NOT NULL DEFAULT '0' COMMENT '已使用次数',
+  `expire_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '过期时间',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`namespace`,`name`),
+  KEY `idx_expire_time` (`expire_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='activation token table';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `baetyl_node_registration_rule`;
+DROP TABLE IF EXISTS `baetyl_node_registration`;
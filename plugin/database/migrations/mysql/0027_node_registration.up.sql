@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS `baetyl_node_registration` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '节点名称',
+  `fingerprint` varchar(256) NOT NULL DEFAULT '' COMMENT '设备指纹',
+  `hardware` text NULL COMMENT '设备硬件信息',
+  `mode` varchar(32) NOT NULL DEFAULT '' COMMENT '节点运行模式',
+  `token` varchar(64) NOT NULL DEFAULT '' COMMENT '引导激活令牌',
+  `node_group` varchar(128) NOT NULL DEFAULT '' COMMENT '节点组',
+  `status` varchar(32) NOT NULL DEFAULT '' COMMENT '注册状态',
+  `reason` varchar(1024) NOT NULL DEFAULT '' COMMENT '审批原因',
+  `rule` varchar(128) NOT NULL DEFAULT '' COMMENT '自动审批规则',
+  `activation_token` varchar(64) NOT NULL DEFAULT '' COMMENT '节点激活令牌',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`namespace`,`name`),
+  UNIQUE KEY `unique_fingerprint` (`namespace`,`fingerprint`),
+  KEY `idx_status` (`namespace`,`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='node registration table';
+
+CREATE TABLE IF NOT EXISTS `baetyl_node_registration_rule` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '规则名称',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '描述',
+  `fingerprint` varchar(256) NOT NULL DEFAULT '' COMMENT '设备指纹匹配模式',
+  `hardware` text NULL COMMENT '设备硬件信息',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`namespace`,`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='node registration rule table';
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `baetyl_install_template` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '安装模板名称',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '描述',
+  `content` mediumtext NOT NULL COMMENT '模板内容',
+  `template_values` text NULL COMMENT '模板变量',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`namespace`,`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='install template table';
@@ -0,0 +1,5 @@
+ALTER TABLE `baetyl_activation_token`
+  ADD COLUMN `placement` text NULL COMMENT '节点放置规则' AFTER `uses`;
+
+ALTER TABLE `baetyl_node_registration`
+  ADD COLUMN `labels` text NULL COMMENT '节点标签' AFTER `node_group`;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS `baetyl_node_endpoints` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '命名空间',
+  `init_server` varchar(512) NOT NULL DEFAULT '' COMMENT '节点访问的激活服务地址',
+  `sync_server` varchar(512) NOT NULL DEFAULT '' COMMENT '节点访问的同步服务地址',
+  `sync_mqtt` varchar(512) NOT NULL DEFAULT '' COMMENT '节点访问的MQTT同步服务地址',
+  `object_storage` varchar(512) NOT NULL DEFAULT '' COMMENT '节点访问的对象存储地址',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_namespace` (`namespace`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='node endpoints table';
+
+ALTER TABLE `baetyl_activation_token`
+  ADD COLUMN `endpoints` text NULL COMMENT '节点访问的云端地址' AFTER `placement`;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `baetyl_module_artifact` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '模块名称',
+  `version` varchar(64) NOT NULL DEFAULT '' COMMENT '模块版本',
+  `image` varchar(512) NOT NULL DEFAULT '' COMMENT '模块版本镜像',
+  `artifacts` text NULL COMMENT '各平台的镜像和程序包',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name_version` (`name`, `version`),
+  KEY `idx_image` (`image`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='module artifact table';
@@ -0,0 +1,2 @@
+ALTER TABLE `baetyl_audit_record`
+  DROP COLUMN `impersonator`;
@@ -0,0 +1,2 @@
+ALTER TABLE `baetyl_audit_record`
+  ADD COLUMN `impersonator` varchar(128) NOT NULL DEFAULT '' COMMENT '模拟用户的运维人员' AFTER `username`;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `baetyl_announcement` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '公告名称',
+  `title` varchar(128) NOT NULL DEFAULT '' COMMENT '公告标题',
+  `content` text NULL COMMENT '公告内容',
+  `level` varchar(32) NOT NULL DEFAULT '' COMMENT '公告级别',
+  `start_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '开始时间',
+  `end_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '结束时间',
+  `namespaces` text NULL COMMENT '公告的命名空间列表',
+  `edge` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否下发到节点',
+  `node_selector` varchar(2048) NOT NULL DEFAULT '' COMMENT '节点标签选择器',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='announcement table';
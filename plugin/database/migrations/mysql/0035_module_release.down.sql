@@ -0,0 +1,4 @@
+ALTER TABLE `baetyl_module`
+  DROP COLUMN `min_cloud_version`,
+  DROP COLUMN `deprecated`,
+  DROP COLUMN `release_notes`;
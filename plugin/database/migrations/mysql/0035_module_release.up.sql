@@ -0,0 +1,4 @@
+ALTER TABLE `baetyl_module`
+  ADD COLUMN `release_notes` varchar(4096) NOT NULL DEFAULT '' COMMENT '版本发布说明' AFTER `description`,
+  ADD COLUMN `deprecated` int(1) NOT NULL DEFAULT '0' COMMENT '是否已废弃' AFTER `release_notes`,
+  ADD COLUMN `min_cloud_version` varchar(36) NOT NULL DEFAULT '' COMMENT '要求的最低云端版本' AFTER `deprecated`;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `baetyl_quarantine` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '隔离名称',
+  `type` varchar(32) NOT NULL DEFAULT '' COMMENT '隔离资源类型',
+  `namespace` varchar(64) NOT NULL DEFAULT '' COMMENT '应用的命名空间',
+  `app` varchar(128) NOT NULL DEFAULT '' COMMENT '隔离的应用名称',
+  `image` varchar(1024) NOT NULL DEFAULT '' COMMENT '隔离的镜像',
+  `reason` varchar(512) NOT NULL DEFAULT '' COMMENT '隔离原因',
+  `operator` varchar(128) NOT NULL DEFAULT '' COMMENT '操作人',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='quarantine table';
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `baetyl_feature_flag` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID,主键',
+  `name` varchar(128) NOT NULL DEFAULT '' COMMENT '特性开关名称',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '特性描述',
+  `enabled` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否全量开启',
+  `percentage` int(11) NOT NULL DEFAULT '0' COMMENT '灰度开启的命名空间百分比',
+  `overrides` text NULL COMMENT '命名空间的开关设置',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COMMENT='feature flag table';
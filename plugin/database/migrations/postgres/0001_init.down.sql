@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS baetyl_cron_app;
+DROP TABLE IF EXISTS baetyl_module;
+DROP TABLE IF EXISTS baetyl_property;
+DROP TABLE IF EXISTS baetyl_certificate;
+DROP TABLE IF EXISTS baetyl_node_shadow;
+DROP TABLE IF EXISTS baetyl_index_application_secret;
+DROP TABLE IF EXISTS baetyl_index_application_node;
+DROP TABLE IF EXISTS baetyl_index_application_config;
+DROP TABLE IF EXISTS baetyl_callback;
+DROP TABLE IF EXISTS baetyl_batch_record;
+DROP TABLE IF EXISTS baetyl_batch;
+DROP FUNCTION IF EXISTS baetyl_set_update_time();
@@ -0,0 +1,201 @@
+CREATE OR REPLACE FUNCTION baetyl_set_update_time() RETURNS TRIGGER AS $$
+BEGIN
+  NEW.update_time = CURRENT_TIMESTAMP;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE IF NOT EXISTS baetyl_batch (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  quota_num INTEGER NOT NULL DEFAULT 200,
+  enable_whitelist INTEGER NOT NULL DEFAULT 1,
+  cluster INTEGER NOT NULL DEFAULT 0,
+  security_type VARCHAR(32) NOT NULL DEFAULT 'Token',
+  security_key VARCHAR(64) NOT NULL DEFAULT '',
+  callback_name VARCHAR(64) NOT NULL DEFAULT '',
+  labels VARCHAR(2048) NOT NULL DEFAULT '{}',
+  accelerator VARCHAR(32) NOT NULL DEFAULT '',
+  sys_apps VARCHAR(1024) NOT NULL DEFAULT '',
+  fingerprint VARCHAR(1024) NOT NULL DEFAULT '{}',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_batch_unique_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_batch_update_time ON baetyl_batch;
+CREATE TRIGGER baetyl_batch_update_time BEFORE UPDATE ON baetyl_batch
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_batch_record (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  batch_name VARCHAR(128) NOT NULL DEFAULT '',
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  fingerprint_value VARCHAR(255) NOT NULL DEFAULT '',
+  active SMALLINT NOT NULL DEFAULT 0,
+  node_name VARCHAR(64) NOT NULL DEFAULT '',
+  active_ip VARCHAR(64) NOT NULL DEFAULT '0.0.0.0',
+  active_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_batch_record_unique_fingerprint UNIQUE (namespace, batch_name, fingerprint_value),
+  CONSTRAINT baetyl_batch_record_unique_name UNIQUE (namespace, batch_name, name)
+);
+DROP TRIGGER IF EXISTS baetyl_batch_record_update_time ON baetyl_batch_record;
+CREATE TRIGGER baetyl_batch_record_update_time BEFORE UPDATE ON baetyl_batch_record
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_callback (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  method VARCHAR(36) NOT NULL DEFAULT 'GET',
+  params VARCHAR(2048) NOT NULL DEFAULT '{}',
+  body VARCHAR(2048) NOT NULL DEFAULT '{}',
+  header VARCHAR(1024) NOT NULL DEFAULT '{}',
+  url VARCHAR(1024) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_callback_unique_ns_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_callback_update_time ON baetyl_callback;
+CREATE TRIGGER baetyl_callback_update_time BEFORE UPDATE ON baetyl_callback
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_index_application_config (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  application VARCHAR(128) NOT NULL DEFAULT '',
+  config VARCHAR(128) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS baetyl_index_application_config_idx_application ON baetyl_index_application_config (namespace, application);
+CREATE INDEX IF NOT EXISTS baetyl_index_application_config_idx_config ON baetyl_index_application_config (namespace, config);
+DROP TRIGGER IF EXISTS baetyl_index_application_config_update_time ON baetyl_index_application_config;
+CREATE TRIGGER baetyl_index_application_config_update_time BEFORE UPDATE ON baetyl_index_application_config
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_index_application_node (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  application VARCHAR(128) NOT NULL DEFAULT '',
+  node VARCHAR(128) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS baetyl_index_application_node_idx_application ON baetyl_index_application_node (namespace, application);
+CREATE INDEX IF NOT EXISTS baetyl_index_application_node_idx_node ON baetyl_index_application_node (namespace, node);
+DROP TRIGGER IF EXISTS baetyl_index_application_node_update_time ON baetyl_index_application_node;
+CREATE TRIGGER baetyl_index_application_node_update_time BEFORE UPDATE ON baetyl_index_application_node
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_index_application_secret (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  application VARCHAR(128) NOT NULL DEFAULT '',
+  secret VARCHAR(128) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS baetyl_index_application_secret_idx_application ON baetyl_index_application_secret (namespace, application);
+CREATE INDEX IF NOT EXISTS baetyl_index_application_secret_idx_secret ON baetyl_index_application_secret (namespace, secret);
+DROP TRIGGER IF EXISTS baetyl_index_application_secret_update_time ON baetyl_index_application_secret;
+CREATE TRIGGER baetyl_index_application_secret_update_time BEFORE UPDATE ON baetyl_index_application_secret
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_node_shadow (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  report TEXT,
+  desire TEXT,
+  report_meta TEXT,
+  desire_meta TEXT,
+  desire_version VARCHAR(36) NOT NULL DEFAULT '',
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_node_shadow_unique_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_node_shadow_update_time ON baetyl_node_shadow;
+CREATE TRIGGER baetyl_node_shadow_update_time BEFORE UPDATE ON baetyl_node_shadow
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_certificate (
+  id BIGSERIAL NOT NULL,
+  cert_id VARCHAR(128) NOT NULL DEFAULT '',
+  parent_id VARCHAR(64) NOT NULL DEFAULT '',
+  type VARCHAR(64) NOT NULL DEFAULT '',
+  common_name VARCHAR(128) NOT NULL DEFAULT '',
+  description VARCHAR(256) NOT NULL DEFAULT '',
+  not_before TIMESTAMP NOT NULL DEFAULT '2017-01-01 00:00:00',
+  not_after TIMESTAMP NOT NULL DEFAULT '2017-01-01 00:00:00',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  csr TEXT,
+  content TEXT,
+  private_key TEXT,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_certificate_unique_cert_id UNIQUE (cert_id)
+);
+CREATE INDEX IF NOT EXISTS baetyl_certificate_idx_parent_id ON baetyl_certificate (parent_id);
+DROP TRIGGER IF EXISTS baetyl_certificate_update_time ON baetyl_certificate;
+CREATE TRIGGER baetyl_certificate_update_time BEFORE UPDATE ON baetyl_certificate
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_property (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  value TEXT NOT NULL,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_property_unique_name UNIQUE (name)
+);
+DROP TRIGGER IF EXISTS baetyl_property_update_time ON baetyl_property;
+CREATE TRIGGER baetyl_property_update_time BEFORE UPDATE ON baetyl_property
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_module (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(255) NOT NULL DEFAULT '',
+  version VARCHAR(36) NOT NULL DEFAULT '',
+  image VARCHAR(1024) NOT NULL DEFAULT '',
+  programs VARCHAR(2048) NOT NULL DEFAULT '',
+  type VARCHAR(36) NOT NULL DEFAULT '0',
+  flag INTEGER NOT NULL DEFAULT 0,
+  is_latest BOOLEAN NOT NULL DEFAULT FALSE,
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_module_unique_name UNIQUE (name, version)
+);
+DROP TRIGGER IF EXISTS baetyl_module_update_time ON baetyl_module;
+CREATE TRIGGER baetyl_module_update_time BEFORE UPDATE ON baetyl_module
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_cron_app (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  selector VARCHAR(2048) NOT NULL DEFAULT '',
+  cron_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_cron_app_unique_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_cron_app_update_time ON baetyl_cron_app;
+CREATE TRIGGER baetyl_cron_app_update_time BEFORE UPDATE ON baetyl_cron_app
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
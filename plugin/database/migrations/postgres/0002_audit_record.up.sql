@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS baetyl_audit_record (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  username VARCHAR(128) NOT NULL DEFAULT '',
+  method VARCHAR(16) NOT NULL DEFAULT '',
+  path VARCHAR(512) NOT NULL DEFAULT '',
+  resource VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  request_id VARCHAR(64) NOT NULL DEFAULT '',
+  status INTEGER NOT NULL DEFAULT 0,
+  code VARCHAR(64) NOT NULL DEFAULT '',
+  diff TEXT NULL,
+  prev_hash VARCHAR(64) NOT NULL DEFAULT '',
+  hash VARCHAR(64) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS baetyl_audit_record_idx_namespace ON baetyl_audit_record (namespace, create_time);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS baetyl_idempotency_record (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  idempotency_key VARCHAR(128) NOT NULL DEFAULT '',
+  request_hash VARCHAR(64) NOT NULL DEFAULT '',
+  status INTEGER NOT NULL DEFAULT 0,
+  content_type VARCHAR(128) NOT NULL DEFAULT '',
+  response BYTEA NULL,
+  expire_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_idempotency_record_unique_key UNIQUE (namespace, idempotency_key)
+);
+CREATE INDEX IF NOT EXISTS baetyl_idempotency_record_idx_expire_time ON baetyl_idempotency_record (expire_time);
+DROP TRIGGER IF EXISTS baetyl_idempotency_record_update_time ON baetyl_idempotency_record;
+CREATE TRIGGER baetyl_idempotency_record_update_time BEFORE UPDATE ON baetyl_idempotency_record
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS baetyl_node_group (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  selector VARCHAR(2048) NOT NULL DEFAULT '',
+  nodes TEXT NULL,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_node_group_unique_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_node_group_update_time ON baetyl_node_group;
+CREATE TRIGGER baetyl_node_group_update_time BEFORE UPDATE ON baetyl_node_group
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS baetyl_health_threshold (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  warning_score INTEGER NOT NULL DEFAULT 80,
+  critical_score INTEGER NOT NULL DEFAULT 50,
+  webhook VARCHAR(1024) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_health_threshold_unique_namespace UNIQUE (namespace)
+);
+DROP TRIGGER IF EXISTS baetyl_health_threshold_update_time ON baetyl_health_threshold;
+CREATE TRIGGER baetyl_health_threshold_update_time BEFORE UPDATE ON baetyl_health_threshold
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
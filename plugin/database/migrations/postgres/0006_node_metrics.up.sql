@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS baetyl_node_metrics (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  cpu_usage DOUBLE PRECISION NOT NULL DEFAULT 0,
+  cpu_capacity DOUBLE PRECISION NOT NULL DEFAULT 0,
+  memory_usage BIGINT NOT NULL DEFAULT 0,
+  memory_capacity BIGINT NOT NULL DEFAULT 0,
+  disk_usage BIGINT NOT NULL DEFAULT 0,
+  disk_capacity BIGINT NOT NULL DEFAULT 0,
+  report_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS baetyl_node_metrics_idx_node_time ON baetyl_node_metrics (namespace, name, report_time);
+CREATE INDEX IF NOT EXISTS baetyl_node_metrics_idx_report_time ON baetyl_node_metrics (report_time);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS baetyl_node_event;
+DROP TABLE IF EXISTS baetyl_heartbeat_policy;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS baetyl_heartbeat_policy (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  offline_timeout INTEGER NOT NULL DEFAULT 20,
+  webhook VARCHAR(1024) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_heartbeat_policy_unique_namespace UNIQUE (namespace)
+);
+DROP TRIGGER IF EXISTS baetyl_heartbeat_policy_update_time ON baetyl_heartbeat_policy;
+CREATE TRIGGER baetyl_heartbeat_policy_update_time BEFORE UPDATE ON baetyl_heartbeat_policy
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_node_event (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  node VARCHAR(128) NOT NULL DEFAULT '',
+  type VARCHAR(32) NOT NULL DEFAULT '',
+  event_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  report_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS baetyl_node_event_idx_node_time ON baetyl_node_event (namespace, node, event_time);
+CREATE INDEX IF NOT EXISTS baetyl_node_event_idx_event_time ON baetyl_node_event (namespace, event_time);
@@ -0,0 +1,2 @@
+ALTER TABLE baetyl_node_group
+  DROP COLUMN maintenance_window;
@@ -0,0 +1,2 @@
+ALTER TABLE baetyl_node_group
+  ADD COLUMN maintenance_window TEXT NULL;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS baetyl_edge_cluster (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  labels TEXT NULL,
+  node VARCHAR(128) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_edge_cluster_unique_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_edge_cluster_update_time ON baetyl_edge_cluster;
+CREATE TRIGGER baetyl_edge_cluster_update_time BEFORE UPDATE ON baetyl_edge_cluster
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS baetyl_app_history (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  revision BIGINT NOT NULL DEFAULT 0,
+  version VARCHAR(36) NOT NULL DEFAULT '',
+  content TEXT NULL,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_app_history_unique_revision UNIQUE (namespace, name, revision)
+);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS baetyl_app_rollout (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  app VARCHAR(128) NOT NULL DEFAULT '',
+  version VARCHAR(36) NOT NULL DEFAULT '',
+  strategy TEXT NULL,
+  stage INTEGER NOT NULL DEFAULT 0,
+  status VARCHAR(32) NOT NULL DEFAULT '',
+  nodes TEXT NULL,
+  failed TEXT NULL,
+  message VARCHAR(1024) NOT NULL DEFAULT '',
+  stage_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_app_rollout_unique_app UNIQUE (namespace, app)
+);
+DROP TRIGGER IF EXISTS baetyl_app_rollout_update_time ON baetyl_app_rollout;
+CREATE TRIGGER baetyl_app_rollout_update_time BEFORE UPDATE ON baetyl_app_rollout
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS baetyl_helm_release (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  chart VARCHAR(128) NOT NULL DEFAULT '',
+  chart_version VARCHAR(64) NOT NULL DEFAULT '',
+  app_version VARCHAR(64) NOT NULL DEFAULT '',
+  url VARCHAR(1024) NOT NULL DEFAULT '',
+  chart_values TEXT NULL,
+  revision INTEGER NOT NULL DEFAULT 0,
+  resources TEXT NULL,
+  archive TEXT NULL,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_helm_release_unique_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_helm_release_update_time ON baetyl_helm_release;
+CREATE TRIGGER baetyl_helm_release_update_time BEFORE UPDATE ON baetyl_helm_release
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
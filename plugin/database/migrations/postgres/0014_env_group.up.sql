@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS baetyl_env_group (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  envs TEXT NULL,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_env_group_unique_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_env_group_update_time ON baetyl_env_group;
+CREATE TRIGGER baetyl_env_group_update_time BEFORE UPDATE ON baetyl_env_group
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS baetyl_sidecar_policy (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  selector VARCHAR(2048) NOT NULL DEFAULT '',
+  sidecar TEXT NULL,
+  volumes TEXT NULL,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_sidecar_policy_unique_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_sidecar_policy_update_time ON baetyl_sidecar_policy;
+CREATE TRIGGER baetyl_sidecar_policy_update_time BEFORE UPDATE ON baetyl_sidecar_policy
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
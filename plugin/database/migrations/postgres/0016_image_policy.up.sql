@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS baetyl_image_policy (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  mirror VARCHAR(512) NOT NULL DEFAULT '',
+  registries TEXT NULL,
+  pull_policy VARCHAR(32) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_image_policy_unique_namespace UNIQUE (namespace)
+);
+DROP TRIGGER IF EXISTS baetyl_image_policy_update_time ON baetyl_image_policy;
+CREATE TRIGGER baetyl_image_policy_update_time BEFORE UPDATE ON baetyl_image_policy
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS baetyl_secret_rotation (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  expires_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  rotator VARCHAR(64) NOT NULL DEFAULT '',
+  rotate_interval VARCHAR(32) NOT NULL DEFAULT '',
+  rotate_keys TEXT NULL,
+  webhook VARCHAR(1024) NOT NULL DEFAULT '',
+  status VARCHAR(32) NOT NULL DEFAULT '',
+  rotate_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_secret_rotation_unique_namespace_name UNIQUE (namespace,  name)
+);
+CREATE INDEX IF NOT EXISTS baetyl_secret_rotation_idx_expires_at ON baetyl_secret_rotation (expires_at);
+DROP TRIGGER IF EXISTS baetyl_secret_rotation_update_time ON baetyl_secret_rotation;
+CREATE TRIGGER baetyl_secret_rotation_update_time BEFORE UPDATE ON baetyl_secret_rotation
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
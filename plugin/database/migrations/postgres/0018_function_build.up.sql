@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS baetyl_function_build (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  version VARCHAR(64) NOT NULL DEFAULT '',
+  runtime VARCHAR(64) NOT NULL DEFAULT '',
+  handler VARCHAR(128) NOT NULL DEFAULT '',
+  source VARCHAR(64) NOT NULL DEFAULT '',
+  bucket VARCHAR(128) NOT NULL DEFAULT '',
+  object VARCHAR(512) NOT NULL DEFAULT '',
+  sha256 VARCHAR(64) NOT NULL DEFAULT '',
+  size BIGINT NOT NULL DEFAULT 0,
+  builder VARCHAR(64) NOT NULL DEFAULT '',
+  build_id VARCHAR(128) NOT NULL DEFAULT '',
+  image VARCHAR(512) NOT NULL DEFAULT '',
+  status VARCHAR(32) NOT NULL DEFAULT '',
+  message VARCHAR(1024) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_function_build_unique_version UNIQUE (namespace, name, version)
+);
+DROP TRIGGER IF EXISTS baetyl_function_build_update_time ON baetyl_function_build;
+CREATE TRIGGER baetyl_function_build_update_time BEFORE UPDATE ON baetyl_function_build
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
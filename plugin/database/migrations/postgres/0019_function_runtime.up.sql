@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS baetyl_function_runtime (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  language VARCHAR(64) NOT NULL DEFAULT '',
+  version VARCHAR(64) NOT NULL DEFAULT '',
+  image VARCHAR(512) NOT NULL DEFAULT '',
+  handler VARCHAR(128) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_function_runtime_unique_name UNIQUE (name)
+);
+DROP TRIGGER IF EXISTS baetyl_function_runtime_update_time ON baetyl_function_runtime;
+CREATE TRIGGER baetyl_function_runtime_update_time BEFORE UPDATE ON baetyl_function_runtime
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
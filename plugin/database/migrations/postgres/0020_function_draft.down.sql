@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS baetyl_function_version;
+DROP TABLE IF EXISTS baetyl_function_draft;
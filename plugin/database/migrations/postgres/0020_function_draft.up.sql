@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS baetyl_function_draft (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  runtime VARCHAR(64) NOT NULL DEFAULT '',
+  handler VARCHAR(128) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  files TEXT NULL,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_function_draft_unique_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_function_draft_update_time ON baetyl_function_draft;
+CREATE TRIGGER baetyl_function_draft_update_time BEFORE UPDATE ON baetyl_function_draft
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_function_version (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  version VARCHAR(64) NOT NULL DEFAULT '',
+  runtime VARCHAR(64) NOT NULL DEFAULT '',
+  handler VARCHAR(128) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  source VARCHAR(64) NOT NULL DEFAULT '',
+  bucket VARCHAR(128) NOT NULL DEFAULT '',
+  object VARCHAR(512) NOT NULL DEFAULT '',
+  sha256 VARCHAR(64) NOT NULL DEFAULT '',
+  size BIGINT NOT NULL DEFAULT 0,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_function_version_unique_version UNIQUE (namespace, name, version)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE baetyl_function_draft
+  ADD COLUMN env TEXT NULL;
+
+ALTER TABLE baetyl_function_version
+  ADD COLUMN env TEXT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE baetyl_function_build
+  ADD COLUMN flavor VARCHAR(16) NOT NULL DEFAULT '';
+
+ALTER TABLE baetyl_function_runtime
+  ADD COLUMN gpu_image VARCHAR(512) NOT NULL DEFAULT '';
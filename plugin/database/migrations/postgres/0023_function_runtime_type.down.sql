@@ -0,0 +1,2 @@
+ALTER TABLE baetyl_function_runtime
+  DROP COLUMN type;
@@ -0,0 +1,2 @@
+ALTER TABLE baetyl_function_runtime
+  ADD COLUMN type VARCHAR(16) NOT NULL DEFAULT '';
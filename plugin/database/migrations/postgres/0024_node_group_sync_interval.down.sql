@@ -0,0 +1,2 @@
+ALTER TABLE baetyl_node_group
+  DROP COLUMN sync_interval;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS baetyl_sync_history (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  kind VARCHAR(64) NOT NULL DEFAULT '',
+  request_size INTEGER NOT NULL DEFAULT 0,
+  response_size INTEGER NOT NULL DEFAULT 0,
+  latency BIGINT NOT NULL DEFAULT 0,
+  protocol INTEGER NOT NULL DEFAULT 0,
+  client_version VARCHAR(256) NOT NULL DEFAULT '',
+  client_ip VARCHAR(64) NOT NULL DEFAULT '',
+  error VARCHAR(1024) NOT NULL DEFAULT '',
+  sync_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS baetyl_sync_history_idx_node_time ON baetyl_sync_history (namespace, name, sync_time);
+CREATE INDEX IF NOT EXISTS baetyl_sync_history_idx_sync_time ON baetyl_sync_history (sync_time);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS baetyl_activation_token (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(64) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  node VARCHAR(128) NOT NULL DEFAULT '',
+  node_group VARCHAR(128) NOT NULL DEFAULT '',
+  max_uses INTEGER NOT NULL DEFAULT 1,
+  uses INTEGER NOT NULL DEFAULT 0,
+  expire_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_activation_token_unique_name UNIQUE (namespace, name)
+);
+CREATE INDEX IF NOT EXISTS baetyl_activation_token_idx_expire_time ON baetyl_activation_token (expire_time);
+DROP TRIGGER IF EXISTS baetyl_activation_token_update_time ON baetyl_activation_token;
+CREATE TRIGGER baetyl_activation_token_update_time BEFORE UPDATE ON baetyl_activation_token
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
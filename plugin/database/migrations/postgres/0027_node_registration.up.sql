@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS baetyl_node_registration (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  fingerprint VARCHAR(256) NOT NULL DEFAULT '',
+  hardware TEXT NULL,
+  mode VARCHAR(32) NOT NULL DEFAULT '',
+  token VARCHAR(64) NOT NULL DEFAULT '',
+  node_group VARCHAR(128) NOT NULL DEFAULT '',
+  status VARCHAR(32) NOT NULL DEFAULT '',
+  reason VARCHAR(1024) NOT NULL DEFAULT '',
+  rule VARCHAR(128) NOT NULL DEFAULT '',
+  activation_token VARCHAR(64) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_node_registration_unique_name UNIQUE (namespace, name),
+  CONSTRAINT baetyl_node_registration_unique_fingerprint UNIQUE (namespace, fingerprint)
+);
+CREATE INDEX IF NOT EXISTS baetyl_node_registration_idx_status ON baetyl_node_registration (namespace, status);
+DROP TRIGGER IF EXISTS baetyl_node_registration_update_time ON baetyl_node_registration;
+CREATE TRIGGER baetyl_node_registration_update_time BEFORE UPDATE ON baetyl_node_registration
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+CREATE TABLE IF NOT EXISTS baetyl_node_registration_rule (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  fingerprint VARCHAR(256) NOT NULL DEFAULT '',
+  hardware TEXT NULL,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_node_registration_rule_unique_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_node_registration_rule_update_time ON baetyl_node_registration_rule;
+CREATE TRIGGER baetyl_node_registration_rule_update_time BEFORE UPDATE ON baetyl_node_registration_rule
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
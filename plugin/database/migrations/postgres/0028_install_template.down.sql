@@ -0,0 +1 @@
+DROP TABLE IF EXISTS baetyl_install_template;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS baetyl_install_template (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  content TEXT NOT NULL,
+  template_values TEXT NULL,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_install_template_unique_name UNIQUE (namespace, name)
+);
+DROP TRIGGER IF EXISTS baetyl_install_template_update_time ON baetyl_install_template;
+CREATE TRIGGER baetyl_install_template_update_time BEFORE UPDATE ON baetyl_install_template
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
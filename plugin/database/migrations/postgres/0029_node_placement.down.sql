@@ -0,0 +1,4 @@
+ALTER TABLE baetyl_node_registration
+  DROP COLUMN labels;
+ALTER TABLE baetyl_activation_token
+  DROP COLUMN placement;
@@ -0,0 +1,5 @@
+ALTER TABLE baetyl_activation_token
+  ADD COLUMN placement TEXT NULL;
+
+ALTER TABLE baetyl_node_registration
+  ADD COLUMN labels TEXT NULL;
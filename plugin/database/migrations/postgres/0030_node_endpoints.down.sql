@@ -0,0 +1,3 @@
+ALTER TABLE baetyl_activation_token
+  DROP COLUMN endpoints;
+DROP TABLE IF EXISTS baetyl_node_endpoints;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS baetyl_node_endpoints (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  init_server VARCHAR(512) NOT NULL DEFAULT '',
+  sync_server VARCHAR(512) NOT NULL DEFAULT '',
+  sync_mqtt VARCHAR(512) NOT NULL DEFAULT '',
+  object_storage VARCHAR(512) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_node_endpoints_unique_namespace UNIQUE (namespace)
+);
+DROP TRIGGER IF EXISTS baetyl_node_endpoints_update_time ON baetyl_node_endpoints;
+CREATE TRIGGER baetyl_node_endpoints_update_time BEFORE UPDATE ON baetyl_node_endpoints
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
+
+ALTER TABLE baetyl_activation_token
+  ADD COLUMN endpoints TEXT NULL;
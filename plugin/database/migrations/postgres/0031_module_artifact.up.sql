@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS baetyl_module_artifact (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  version VARCHAR(64) NOT NULL DEFAULT '',
+  image VARCHAR(512) NOT NULL DEFAULT '',
+  artifacts TEXT NULL,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_module_artifact_unique_name_version UNIQUE (name,  version)
+);
+CREATE INDEX IF NOT EXISTS baetyl_module_artifact_idx_image ON baetyl_module_artifact (image);
+DROP TRIGGER IF EXISTS baetyl_module_artifact_update_time ON baetyl_module_artifact;
+CREATE TRIGGER baetyl_module_artifact_update_time BEFORE UPDATE ON baetyl_module_artifact
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS baetyl_tenant (
+  id BIGSERIAL NOT NULL,
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  status VARCHAR(32) NOT NULL DEFAULT '',
+  reason VARCHAR(512) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_tenant_unique_namespace UNIQUE (namespace)
+);
+DROP TRIGGER IF EXISTS baetyl_tenant_update_time ON baetyl_tenant;
+CREATE TRIGGER baetyl_tenant_update_time BEFORE UPDATE ON baetyl_tenant
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
@@ -0,0 +1,2 @@
+ALTER TABLE baetyl_audit_record
+  ADD COLUMN impersonator VARCHAR(128) NOT NULL DEFAULT '';
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS baetyl_announcement (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  title VARCHAR(128) NOT NULL DEFAULT '',
+  content TEXT NULL,
+  level VARCHAR(32) NOT NULL DEFAULT '',
+  start_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  end_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  namespaces TEXT NULL,
+  edge BOOLEAN NOT NULL DEFAULT FALSE,
+  node_selector VARCHAR(2048) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_announcement_unique_name UNIQUE (name)
+);
+DROP TRIGGER IF EXISTS baetyl_announcement_update_time ON baetyl_announcement;
+CREATE TRIGGER baetyl_announcement_update_time BEFORE UPDATE ON baetyl_announcement
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
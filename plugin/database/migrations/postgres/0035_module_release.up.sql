@@ -0,0 +1,4 @@
+ALTER TABLE baetyl_module
+  ADD COLUMN release_notes VARCHAR(4096) NOT NULL DEFAULT '',
+  ADD COLUMN deprecated BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN min_cloud_version VARCHAR(36) NOT NULL DEFAULT '';
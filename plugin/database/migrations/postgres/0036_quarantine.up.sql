@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS baetyl_quarantine (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  type VARCHAR(32) NOT NULL DEFAULT '',
+  namespace VARCHAR(64) NOT NULL DEFAULT '',
+  app VARCHAR(128) NOT NULL DEFAULT '',
+  image VARCHAR(1024) NOT NULL DEFAULT '',
+  reason VARCHAR(512) NOT NULL DEFAULT '',
+  operator VARCHAR(128) NOT NULL DEFAULT '',
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_quarantine_unique_name UNIQUE (name)
+);
+DROP TRIGGER IF EXISTS baetyl_quarantine_update_time ON baetyl_quarantine;
+CREATE TRIGGER baetyl_quarantine_update_time BEFORE UPDATE ON baetyl_quarantine
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
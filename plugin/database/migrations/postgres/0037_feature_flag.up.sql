@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS baetyl_feature_flag (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(128) NOT NULL DEFAULT '',
+  description VARCHAR(1024) NOT NULL DEFAULT '',
+  enabled BOOLEAN NOT NULL DEFAULT FALSE,
+  percentage INTEGER NOT NULL DEFAULT 0,
+  overrides TEXT NULL,
+  create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  CONSTRAINT baetyl_feature_flag_unique_name UNIQUE (name)
+);
+DROP TRIGGER IF EXISTS baetyl_feature_flag_update_time ON baetyl_feature_flag;
+CREATE TRIGGER baetyl_feature_flag_update_time BEFORE UPDATE ON baetyl_feature_flag
+  FOR EACH ROW EXECUTE PROCEDURE baetyl_set_update_time();
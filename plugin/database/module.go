@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
@@ -130,11 +131,11 @@ func (d *DB) GetLatestModuleProgram(name, platform string) (string, error) {
 func (d *DB) GetModuleTx(tx *sqlx.Tx, name string) ([]models.Module, error) {
 	selectSQL := `
 SELECT  
-id, name, image, programs, version, type, flag, is_latest, description, create_time, update_time
+id, name, image, programs, version, type, flag, is_latest, description, release_notes, deprecated, min_cloud_version, create_time, update_time
 FROM baetyl_module 
 WHERE name=? ORDER BY create_time DESC
 `
-	modules, err := d.listModuleTx(tx, selectSQL, name)
+	modules, err := d.listModuleTx(context.Background(), tx, selectSQL, name)
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +151,7 @@ WHERE name=? ORDER BY create_time DESC
 func (d *DB) GetLatestModuleTx(tx *sqlx.Tx, name string) (*models.Module, error) {
 	selectSQL := `
 SELECT  
-id, name, image, programs, version, type, flag, is_latest, description, create_time, update_time
+id, name, image, programs, version, type, flag, is_latest, description, release_notes, deprecated, min_cloud_version, create_time, update_time
 FROM baetyl_module 
 WHERE name=? AND is_latest=?
 `
@@ -160,7 +161,7 @@ WHERE name=? AND is_latest=?
 func (d *DB) GetModuleByVersionTx(tx *sqlx.Tx, name, version string) (*models.Module, error) {
 	selectSQL := `
 SELECT  
-id, name, image, programs, version, type, flag, is_latest, description, create_time, update_time
+id, name, image, programs, version, type, flag, is_latest, description, release_notes, deprecated, min_cloud_version, create_time, update_time
 FROM baetyl_module 
 WHERE name=? AND version=?
 `
@@ -170,7 +171,7 @@ WHERE name=? AND version=?
 func (d *DB) GetModuleByImageTx(tx *sqlx.Tx, name, image string) (*models.Module, error) {
 	selectSQL := `
 SELECT  
-id, name, image, programs, version, type, flag, is_latest, description, create_time, update_time
+id, name, image, programs, version, type, flag, is_latest, description, release_notes, deprecated, min_cloud_version, create_time, update_time
 FROM baetyl_module 
 WHERE name=? AND image=?
 `
@@ -179,28 +180,30 @@ WHERE name=? AND image=?
 
 func (d *DB) CreateModuleTx(tx *sqlx.Tx, module *models.Module) error {
 	insertSQL := `
-INSERT INTO baetyl_module (name, image, programs, version, type, flag, is_latest, description)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO baetyl_module (name, image, programs, version, type, flag, is_latest, description, release_notes, deprecated, min_cloud_version)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 	res, err := entities.FromModuleModel(module)
 	if err != nil {
 		return err
 	}
-	_, err = d.Exec(tx, insertSQL, res.Name, res.Image, res.Programs, res.Version, res.Type, res.Flag, res.IsLatest, res.Description)
+	_, err = d.Exec(tx, insertSQL, res.Name, res.Image, res.Programs, res.Version, res.Type, res.Flag, res.IsLatest, res.Description,
+		res.ReleaseNotes, res.Deprecated, res.MinCloudVersion)
 	return err
 }
 
 func (d *DB) UpdateModuleByVersionTx(tx *sqlx.Tx, module *models.Module) error {
 	updateSQL := `
 UPDATE baetyl_module
-SET image=?, programs=?, version=?, type=?, flag=?, is_latest=?, description=? 
+SET image=?, programs=?, version=?, type=?, flag=?, is_latest=?, description=?, release_notes=?, deprecated=?, min_cloud_version=? 
 WHERE name=? AND version=?
 `
 	res, err := entities.FromModuleModel(module)
 	if err != nil {
 		return err
 	}
-	_, err = d.Exec(tx, updateSQL, res.Image, res.Programs, res.Version, res.Type, res.Flag, res.IsLatest, res.Description, res.Name, res.Version)
+	_, err = d.Exec(tx, updateSQL, res.Image, res.Programs, res.Version, res.Type, res.Flag, res.IsLatest, res.Description,
+		res.ReleaseNotes, res.Deprecated, res.MinCloudVersion, res.Name, res.Version)
 	return err
 }
 
@@ -225,7 +228,7 @@ WHERE name=? AND version=?
 func (d *DB) ListModulesTx(tx *sqlx.Tx, filter *models.Filter) ([]models.Module, error) {
 	selectSQL := `
 SELECT 
-id, name, image, programs, version, type, flag, is_latest, description, create_time, update_time
+id, name, image, programs, version, type, flag, is_latest, description, release_notes, deprecated, min_cloud_version, create_time, update_time
 FROM baetyl_module WHERE name LIKE ? ORDER BY create_time DESC
 `
 	args := []interface{}{filter.GetFuzzyName()}
@@ -234,7 +237,7 @@ FROM baetyl_module WHERE name LIKE ? ORDER BY create_time DESC
 		args = append(args, filter.GetLimitOffset(), filter.GetLimitNumber())
 	}
 
-	return d.listModuleTx(tx, selectSQL, args...)
+	return d.listModuleTx(filter.Context(), tx, selectSQL, args...)
 }
 
 func (d *DB) GetLatestModuleImageTx(tx *sqlx.Tx, name string) (string, error) {
@@ -263,7 +266,7 @@ func (d *DB) GetLatestModuleProgramTx(tx *sqlx.Tx, name, platform string) (strin
 func (d *DB) listModulesByTypeTx(tx *sqlx.Tx, tp common.ModuleType, filter *models.Filter) ([]models.Module, error) {
 	selectSQL := `
 SELECT 
-id, name, image, programs, version, type, flag, is_latest, description, create_time, update_time
+id, name, image, programs, version, type, flag, is_latest, description, release_notes, deprecated, min_cloud_version, create_time, update_time
 FROM baetyl_module WHERE name LIKE ? AND type=? AND is_latest=? ORDER BY create_time DESC
 `
 
@@ -273,7 +276,7 @@ FROM baetyl_module WHERE name LIKE ? AND type=? AND is_latest=? ORDER BY create_
 		args = append(args, filter.GetLimitOffset(), filter.GetLimitNumber())
 	}
 
-	return d.listModuleTx(tx, selectSQL, args...)
+	return d.listModuleTx(filter.Context(), tx, selectSQL, args...)
 }
 
 func (d *DB) getModuleTx(tx *sqlx.Tx, sql string, args ...interface{}) (*models.Module, error) {
@@ -290,9 +293,9 @@ func (d *DB) getModuleTx(tx *sqlx.Tx, sql string, args ...interface{}) (*models.
 		common.Field("name", args))
 }
 
-func (d *DB) listModuleTx(tx *sqlx.Tx, sql string, args ...interface{}) ([]models.Module, error) {
+func (d *DB) listModuleTx(ctx context.Context, tx *sqlx.Tx, sql string, args ...interface{}) ([]models.Module, error) {
 	ms := make([]entities.Module, 0)
-	if err := d.Query(tx, sql, &ms, args...); err != nil {
+	if err := d.QueryContext(ctx, tx, sql, &ms, args...); err != nil {
 		return nil, err
 	}
 
@@ -24,6 +24,9 @@ CREATE TABLE IF NOT EXISTS baetyl_module
   flag        int(10)          NOT NULL DEFAULT '0',
   is_latest   int(1)           NOT NULL DEFAULT '0',
   description varchar(1024)    NOT NULL DEFAULT '',
+  release_notes varchar(4096)  NOT NULL DEFAULT '',
+  deprecated  int(1)           NOT NULL DEFAULT '0',
+  min_cloud_version varchar(36) NOT NULL DEFAULT '',
   create_time timestamp        NOT NULL DEFAULT CURRENT_TIMESTAMP,
   update_time timestamp        NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
@@ -84,9 +87,12 @@ func TestModule(t *testing.T) {
 			"linux-amd64":    "url-linux-amd64",
 			"linux-arm64-v8": "url-linux-arm64-v8",
 		},
-		Type:        "a",
-		IsLatest:    false,
-		Description: "for desp",
+		Type:            "a",
+		IsLatest:        false,
+		Description:     "for desp",
+		ReleaseNotes:    "fix the sync of large configs",
+		Deprecated:      true,
+		MinCloudVersion: "v2.4.0",
 	}
 
 	res, err = db.CreateModule(module01)
@@ -181,6 +187,7 @@ func TestModule(t *testing.T) {
 	module31.Programs = map[string]string{
 		"b": "b-url",
 	}
+	module31.ReleaseNotes, module31.MinCloudVersion = "support the programs of arm64", "v2.3.0"
 	res, err = db.UpdateModuleByVersion(module31)
 	assert.NoError(t, err)
 	checkModule(t, module31, res)
@@ -536,4 +543,7 @@ func checkModule(t *testing.T, expect, actual *models.Module) {
 	assert.EqualValues(t, expect.Type, actual.Type)
 	assert.Equal(t, expect.IsLatest, actual.IsLatest)
 	assert.Equal(t, expect.Description, actual.Description)
+	assert.Equal(t, expect.ReleaseNotes, actual.ReleaseNotes)
+	assert.Equal(t, expect.Deprecated, actual.Deprecated)
+	assert.Equal(t, expect.MinCloudVersion, actual.MinCloudVersion)
 }
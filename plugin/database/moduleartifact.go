@@ -0,0 +1,66 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetModuleArtifacts(name, version string) (*models.ModuleArtifacts, error) {
+	selectSQL := `
+SELECT name, version, image, artifacts, create_time, update_time 
+FROM baetyl_module_artifact WHERE name=? AND version=?
+`
+	return d.getModuleArtifacts(selectSQL, name+":"+version, name, version)
+}
+
+func (d *DB) GetModuleArtifactsByImage(image string) (*models.ModuleArtifacts, error) {
+	selectSQL := `
+SELECT name, version, image, artifacts, create_time, update_time 
+FROM baetyl_module_artifact WHERE image=? ORDER BY id DESC LIMIT 1
+`
+	return d.getModuleArtifacts(selectSQL, image, image)
+}
+
+func (d *DB) getModuleArtifacts(selectSQL, name string, args ...interface{}) (*models.ModuleArtifacts, error) {
+	var artifacts []entities.ModuleArtifacts
+	if err := d.Query(nil, selectSQL, &artifacts, args...); err != nil {
+		return nil, err
+	}
+	if len(artifacts) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "moduleartifacts"), common.Field("name", name))
+	}
+	return entities.ToModuleArtifactsModel(&artifacts[0])
+}
+
+func (d *DB) CreateModuleArtifacts(artifacts *models.ModuleArtifacts) error {
+	insertSQL := `
+INSERT INTO baetyl_module_artifact (name, version, image, artifacts) 
+VALUES (?,?,?,?)
+`
+	a, err := entities.FromModuleArtifactsModel(artifacts)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, a.Name, a.Version, a.Image, a.Artifacts)
+	return err
+}
+
+func (d *DB) UpdateModuleArtifacts(artifacts *models.ModuleArtifacts) error {
+	updateSQL := `
+UPDATE baetyl_module_artifact SET image=?, artifacts=? 
+WHERE name=? AND version=?
+`
+	a, err := entities.FromModuleArtifactsModel(artifacts)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, a.Image, a.Artifacts, a.Name, a.Version)
+	return err
+}
+
+func (d *DB) DeleteModuleArtifacts(name, version string) error {
+	deleteSQL := `DELETE FROM baetyl_module_artifact WHERE name=? AND version=?`
+	_, err := d.Exec(nil, deleteSQL, name, version)
+	return err
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	moduleArtifactTables = []string{
+		`
+CREATE TABLE baetyl_module_artifact(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    version     VARCHAR(64) NOT NULL DEFAULT '',
+    image       VARCHAR(512) NOT NULL DEFAULT '',
+    artifacts   TEXT,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (name, version)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateModuleArtifactTable() {
+	for _, sql := range moduleArtifactTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestModuleArtifact(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateModuleArtifactTable()
+
+	artifacts := &models.ModuleArtifacts{
+		Name:    "baetyl",
+		Version: "v2.2.0",
+		Image:   "docker.io/baetyltech/baetyl:v2.2.0",
+		Artifacts: []models.ModuleArtifact{
+			{Platform: "linux-arm64", Image: "docker.io/baetyltech/baetyl:v2.2.0-arm64"},
+			{Platform: "windows-amd64", Program: "https://example.com/baetyl_windows_amd64.zip"},
+		},
+	}
+	_, err = db.GetModuleArtifacts(artifacts.Name, artifacts.Version)
+	assert.Error(t, err)
+
+	err = db.CreateModuleArtifacts(artifacts)
+	assert.NoError(t, err)
+	err = db.CreateModuleArtifacts(artifacts)
+	assert.Error(t, err)
+
+	res, err := db.GetModuleArtifacts(artifacts.Name, artifacts.Version)
+	assert.NoError(t, err)
+	assert.Equal(t, artifacts.Image, res.Image)
+	assert.Equal(t, artifacts.Artifacts, res.Artifacts)
+
+	res, err = db.GetModuleArtifactsByImage(artifacts.Image)
+	assert.NoError(t, err)
+	assert.Equal(t, artifacts.Version, res.Version)
+	_, err = db.GetModuleArtifactsByImage("docker.io/baetyltech/baetyl:v2.1.0")
+	assert.Error(t, err)
+
+	artifacts.Artifacts = artifacts.Artifacts[:1]
+	err = db.UpdateModuleArtifacts(artifacts)
+	assert.NoError(t, err)
+	res, err = db.GetModuleArtifacts(artifacts.Name, artifacts.Version)
+	assert.NoError(t, err)
+	assert.Len(t, res.Artifacts, 1)
+
+	err = db.DeleteModuleArtifacts(artifacts.Name, artifacts.Version)
+	assert.NoError(t, err)
+	_, err = db.GetModuleArtifacts(artifacts.Name, artifacts.Version)
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetNodeEndpoints(namespace string) (*models.NodeEndpoints, error) {
+	selectSQL := `
+SELECT namespace, init_server, sync_server, sync_mqtt, object_storage, create_time, update_time 
+FROM baetyl_node_endpoints WHERE namespace=?
+`
+	var endpoints []entities.NodeEndpoints
+	if err := d.Query(nil, selectSQL, &endpoints, namespace); err != nil {
+		return nil, err
+	}
+	if len(endpoints) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "nodeendpoints"), common.Field("name", namespace))
+	}
+	return entities.ToNodeEndpointsModel(&endpoints[0]), nil
+}
+
+func (d *DB) CreateNodeEndpoints(endpoints *models.NodeEndpoints) error {
+	insertSQL := `
+INSERT INTO baetyl_node_endpoints (namespace, init_server, sync_server, sync_mqtt, object_storage) 
+VALUES (?,?,?,?,?)
+`
+	e := entities.FromNodeEndpointsModel(endpoints)
+	_, err := d.Exec(nil, insertSQL, e.Namespace, e.InitServer, e.SyncServer, e.SyncMQTT, e.ObjectStorage)
+	return err
+}
+
+func (d *DB) UpdateNodeEndpoints(endpoints *models.NodeEndpoints) error {
+	updateSQL := `
+UPDATE baetyl_node_endpoints SET init_server=?, sync_server=?, sync_mqtt=?, object_storage=? 
+WHERE namespace=?
+`
+	e := entities.FromNodeEndpointsModel(endpoints)
+	_, err := d.Exec(nil, updateSQL, e.InitServer, e.SyncServer, e.SyncMQTT, e.ObjectStorage, e.Namespace)
+	return err
+}
+
+func (d *DB) DeleteNodeEndpoints(namespace string) error {
+	deleteSQL := `DELETE FROM baetyl_node_endpoints WHERE namespace=?`
+	_, err := d.Exec(nil, deleteSQL, namespace)
+	return err
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	nodeEndpointsTables = []string{
+		`
+CREATE TABLE baetyl_node_endpoints(
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace      VARCHAR(64) NOT NULL DEFAULT '',
+    init_server    VARCHAR(512) NOT NULL DEFAULT '',
+    sync_server    VARCHAR(512) NOT NULL DEFAULT '',
+    sync_mqtt      VARCHAR(512) NOT NULL DEFAULT '',
+    object_storage VARCHAR(512) NOT NULL DEFAULT '',
+    create_time    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateNodeEndpointsTable() {
+	for _, sql := range nodeEndpointsTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestNodeEndpoints(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateNodeEndpointsTable()
+
+	endpoints := &models.NodeEndpoints{
+		Namespace:  "default",
+		InitServer: "https://gateway.site:30003",
+		SyncServer: "https://gateway.site:30005",
+	}
+	_, err = db.GetNodeEndpoints(endpoints.Namespace)
+	assert.Error(t, err)
+
+	err = db.CreateNodeEndpoints(endpoints)
+	assert.NoError(t, err)
+	err = db.CreateNodeEndpoints(endpoints)
+	assert.Error(t, err)
+
+	res, err := db.GetNodeEndpoints(endpoints.Namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gateway.site:30003", res.InitServer)
+	assert.Equal(t, "https://gateway.site:30005", res.SyncServer)
+	assert.Empty(t, res.SyncMQTT)
+
+	endpoints.SyncServer = ""
+	endpoints.SyncMQTT = "ssl://gateway.site:30883"
+	endpoints.ObjectStorage = "https://gateway.site/objects"
+	err = db.UpdateNodeEndpoints(endpoints)
+	assert.NoError(t, err)
+	res, err = db.GetNodeEndpoints(endpoints.Namespace)
+	assert.NoError(t, err)
+	assert.Empty(t, res.SyncServer)
+	assert.Equal(t, "ssl://gateway.site:30883", res.SyncMQTT)
+	assert.Equal(t, "https://gateway.site/objects", res.ObjectStorage)
+
+	_, err = db.GetNodeEndpoints("other")
+	assert.Error(t, err)
+
+	err = db.DeleteNodeEndpoints(endpoints.Namespace)
+	assert.NoError(t, err)
+	_, err = db.GetNodeEndpoints(endpoints.Namespace)
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetNodeFilter(namespace, user, name string) (*models.NodeFilter, error) {
+	selectSQL := `
+SELECT namespace, user_id, name, description, selector, query, create_time, update_time 
+FROM baetyl_node_filter WHERE namespace=? AND user_id=? AND name=?
+`
+	var filters []entities.NodeFilter
+	if err := d.Query(nil, selectSQL, &filters, namespace, user, name); err != nil {
+		return nil, err
+	}
+	if len(filters) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "nodefilter"), common.Field("name", name))
+	}
+	return entities.ToNodeFilterModel(&filters[0])
+}
+
+func (d *DB) ListNodeFilter(namespace, user string) ([]models.NodeFilter, error) {
+	selectSQL := `
+SELECT namespace, user_id, name, description, selector, query, create_time, update_time 
+FROM baetyl_node_filter WHERE namespace=? AND user_id=? ORDER BY name
+`
+	var filters []entities.NodeFilter
+	if err := d.Query(nil, selectSQL, &filters, namespace, user); err != nil {
+		return nil, err
+	}
+	res := make([]models.NodeFilter, 0, len(filters))
+	for i := range filters {
+		filter, err := entities.ToNodeFilterModel(&filters[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *filter)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateNodeFilter(filter *models.NodeFilter) error {
+	insertSQL := `
+INSERT INTO baetyl_node_filter (namespace, user_id, name, description, selector, query) 
+VALUES (?,?,?,?,?,?)
+`
+	f, err := entities.FromNodeFilterModel(filter)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, f.Namespace, f.User, f.Name, f.Description, f.Selector, f.Query)
+	return err
+}
+
+func (d *DB) UpdateNodeFilter(filter *models.NodeFilter) error {
+	updateSQL := `
+UPDATE baetyl_node_filter SET description=?, selector=?, query=? 
+WHERE namespace=? AND user_id=? AND name=?
+`
+	f, err := entities.FromNodeFilterModel(filter)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, f.Description, f.Selector, f.Query, f.Namespace, f.User, f.Name)
+	return err
+}
+
+func (d *DB) DeleteNodeFilter(namespace, user, name string) error {
+	deleteSQL := `DELETE FROM baetyl_node_filter WHERE namespace=? AND user_id=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, user, name)
+	return err
+}
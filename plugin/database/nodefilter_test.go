@@ -0,0 +1,92 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	nodeFilterTables = []string{
+		`
+CREATE TABLE baetyl_node_filter(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    user_id     VARCHAR(128) NOT NULL DEFAULT '',
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    selector    VARCHAR(2048) NOT NULL DEFAULT '',
+    query       TEXT,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, user_id, name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateNodeFilterTable() {
+	for _, sql := range nodeFilterTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestNodeFilter(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateNodeFilterTable()
+
+	filter := &models.NodeFilter{
+		Namespace: "default",
+		User:      "u1",
+		Name:      "offline",
+		Selector:  "city=bj",
+		Query:     models.NodeQuery{Ready: "offline"},
+	}
+	_, err = db.GetNodeFilter(filter.Namespace, filter.User, filter.Name)
+	assert.Error(t, err)
+
+	assert.NoError(t, db.CreateNodeFilter(filter))
+	assert.Error(t, db.CreateNodeFilter(filter))
+	// the same name is allowed for other users
+	assert.NoError(t, db.CreateNodeFilter(&models.NodeFilter{Namespace: "default", User: "u2", Name: "offline"}))
+	assert.NoError(t, db.CreateNodeFilter(&models.NodeFilter{Namespace: "default", User: "u1", Name: "new"}))
+
+	res, err := db.GetNodeFilter(filter.Namespace, filter.User, filter.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "city=bj", res.Selector)
+	assert.Equal(t, models.NodeQuery{Ready: "offline"}, res.Query)
+
+	after := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter.Description = "desc"
+	filter.Query = models.NodeQuery{MinVersion: "v2.2.0", CreatedAfter: after}
+	assert.NoError(t, db.UpdateNodeFilter(filter))
+	res, err = db.GetNodeFilter(filter.Namespace, filter.User, filter.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "desc", res.Description)
+	assert.Equal(t, "v2.2.0", res.Query.MinVersion)
+	assert.True(t, after.Equal(res.Query.CreatedAfter))
+
+	list, err := db.ListNodeFilter("default", "u1")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "new", list[0].Name)
+	list, err = db.ListNodeFilter("default", "u3")
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	assert.NoError(t, db.DeleteNodeFilter(filter.Namespace, filter.User, filter.Name))
+	_, err = db.GetNodeFilter(filter.Namespace, filter.User, filter.Name)
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetNodeGroup(namespace, name string) (*models.NodeGroup, error) {
+	selectSQL := `
+SELECT namespace, name, description, selector, nodes, maintenance_window, sync_interval, create_time, update_time 
+FROM baetyl_node_group WHERE namespace=? AND name=?
+`
+	var groups []entities.NodeGroup
+	if err := d.Query(nil, selectSQL, &groups, namespace, name); err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "nodegroup"), common.Field("name", name))
+	}
+	return entities.ToNodeGroupModel(&groups[0])
+}
+
+func (d *DB) ListNodeGroup(namespace string, filter *models.Filter) ([]models.NodeGroup, error) {
+	selectSQL := `
+SELECT namespace, name, description, selector, nodes, maintenance_window, sync_interval, create_time, update_time 
+FROM baetyl_node_group WHERE namespace=? AND name LIKE ? ORDER BY create_time DESC 
+`
+	args := []interface{}{namespace, filter.GetFuzzyName()}
+	if filter.GetLimitNumber() > 0 {
+		selectSQL = selectSQL + "LIMIT ?,?"
+		args = append(args, filter.GetLimitOffset(), filter.GetLimitNumber())
+	}
+	var groups []entities.NodeGroup
+	if err := d.QueryContext(filter.Context(), nil, selectSQL, &groups, args...); err != nil {
+		return nil, err
+	}
+	res := make([]models.NodeGroup, 0, len(groups))
+	for i := range groups {
+		group, err := entities.ToNodeGroupModel(&groups[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *group)
+	}
+	return res, nil
+}
+
+func (d *DB) CountNodeGroup(namespace, name string) (int, error) {
+	selectSQL := `
+SELECT count(name) AS count FROM baetyl_node_group WHERE namespace=? AND name LIKE ?
+`
+	var res []struct {
+		Count int `db:"count"`
+	}
+	if err := d.Query(nil, selectSQL, &res, namespace, "%"+name+"%"); err != nil {
+		return 0, err
+	}
+	return res[0].Count, nil
+}
+
+func (d *DB) CreateNodeGroup(group *models.NodeGroup) error {
+	insertSQL := `
+INSERT INTO baetyl_node_group (namespace, name, description, selector, nodes, maintenance_window, sync_interval) 
+VALUES (?,?,?,?,?,?,?)
+`
+	g, err := entities.FromNodeGroupModel(group)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, g.Namespace, g.Name, g.Description, g.Selector, g.Nodes, g.Maintenance, g.Interval)
+	return err
+}
+
+func (d *DB) UpdateNodeGroup(group *models.NodeGroup) error {
+	updateSQL := `
+UPDATE baetyl_node_group SET description=?, selector=?, nodes=?, maintenance_window=?, sync_interval=? 
+WHERE namespace=? AND name=?
+`
+	g, err := entities.FromNodeGroupModel(group)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, g.Description, g.Selector, g.Nodes, g.Maintenance, g.Interval, g.Namespace, g.Name)
+	return err
+}
+
+func (d *DB) DeleteNodeGroup(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_node_group WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	nodeGroupTables = []string{
+		`
+CREATE TABLE baetyl_node_group(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    selector    VARCHAR(2048) NOT NULL DEFAULT '',
+    nodes       TEXT,
+    maintenance_window TEXT,
+    sync_interval TEXT,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateNodeGroupTable() {
+	for _, sql := range nodeGroupTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestNodeGroup(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateNodeGroupTable()
+
+	group := &models.NodeGroup{
+		Namespace: "default",
+		Name:      "group01",
+		Selector:  "city=bj",
+	}
+	_, err = db.GetNodeGroup(group.Namespace, group.Name)
+	assert.Error(t, err)
+
+	err = db.CreateNodeGroup(group)
+	assert.NoError(t, err)
+	err = db.CreateNodeGroup(group)
+	assert.Error(t, err)
+	err = db.CreateNodeGroup(&models.NodeGroup{Namespace: "default", Name: "group02", Nodes: []string{"n1", "n2"}})
+	assert.NoError(t, err)
+
+	res, err := db.GetNodeGroup(group.Namespace, group.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "city=bj", res.Selector)
+	assert.Nil(t, res.Nodes)
+	assert.Nil(t, res.MaintenanceWindow)
+	assert.Nil(t, res.SyncInterval)
+
+	group.Selector = ""
+	group.Nodes = []string{"n1"}
+	group.Description = "desc"
+	group.MaintenanceWindow = &models.MaintenanceWindow{Cron: "0 2 * * *", Duration: "2h"}
+	group.SyncInterval = &models.SyncInterval{Report: "1h", Jitter: "5m"}
+	err = db.UpdateNodeGroup(group)
+	assert.NoError(t, err)
+	res, err = db.GetNodeGroup(group.Namespace, group.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"n1"}, res.Nodes)
+	assert.Equal(t, "desc", res.Description)
+	assert.Equal(t, group.MaintenanceWindow, res.MaintenanceWindow)
+	assert.Equal(t, group.SyncInterval, res.SyncInterval)
+	assert.Equal(t, "baetyl-node-name in (n1)", res.NodeSelector())
+
+	list, err := db.ListNodeGroup("default", &models.Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	list, err = db.ListNodeGroup("default", &models.Filter{Name: "02", PageNo: 1, PageSize: 10})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, []string{"n1", "n2"}, list[0].Nodes)
+	list, err = db.ListNodeGroup("other", &models.Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	count, err := db.CountNodeGroup("default", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	err = db.DeleteNodeGroup(group.Namespace, group.Name)
+	assert.NoError(t, err)
+	_, err = db.GetNodeGroup(group.Namespace, group.Name)
+	assert.Error(t, err)
+}
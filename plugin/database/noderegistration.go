@@ -0,0 +1,154 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+const nodeRegistrationColumns = `namespace, name, fingerprint, hardware, mode, token, node_group, labels, 
+status, reason, rule, activation_token, create_time, update_time`
+
+func (d *DB) GetNodeRegistration(namespace, name string) (*models.NodeRegistration, error) {
+	selectSQL := `SELECT ` + nodeRegistrationColumns + ` FROM baetyl_node_registration WHERE namespace=? AND name=?`
+	return d.getNodeRegistration(selectSQL, namespace, name)
+}
+
+func (d *DB) GetNodeRegistrationByFingerprint(namespace, fingerprint string) (*models.NodeRegistration, error) {
+	selectSQL := `SELECT ` + nodeRegistrationColumns + ` FROM baetyl_node_registration WHERE namespace=? AND fingerprint=?`
+	return d.getNodeRegistration(selectSQL, namespace, fingerprint)
+}
+
+func (d *DB) getNodeRegistration(selectSQL, namespace, key string) (*models.NodeRegistration, error) {
+	var registrations []entities.NodeRegistration
+	if err := d.Query(nil, selectSQL, &registrations, namespace, key); err != nil {
+		return nil, err
+	}
+	if len(registrations) > 0 {
+		return entities.ToNodeRegistrationModel(&registrations[0])
+	}
+	return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "nodeRegistration"),
+		common.Field("name", key), common.Field("namespace", namespace))
+}
+
+func (d *DB) ListNodeRegistration(namespace, status string) ([]models.NodeRegistration, error) {
+	selectSQL := `SELECT ` + nodeRegistrationColumns + ` FROM baetyl_node_registration WHERE namespace=?`
+	args := []interface{}{namespace}
+	if status != "" {
+		selectSQL += ` AND status=?`
+		args = append(args, status)
+	}
+	selectSQL += ` ORDER BY create_time DESC, id DESC`
+	var registrations []entities.NodeRegistration
+	if err := d.Query(nil, selectSQL, &registrations, args...); err != nil {
+		return nil, err
+	}
+	res := make([]models.NodeRegistration, 0, len(registrations))
+	for i := range registrations {
+		r, err := entities.ToNodeRegistrationModel(&registrations[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *r)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateNodeRegistration(registration *models.NodeRegistration) error {
+	insertSQL := `
+INSERT INTO baetyl_node_registration 
+(namespace, name, fingerprint, hardware, mode, token, node_group, labels, status, reason, rule, activation_token) 
+VALUES (?,?,?,?,?,?,?,?,?,?,?,?)
+`
+	r, err := entities.FromNodeRegistrationModel(registration)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, r.Namespace, r.Name, r.Fingerprint, r.Hardware, r.Mode, r.Token,
+		r.NodeGroup, r.Labels, r.Status, r.Reason, r.Rule, r.ActivationToken)
+	if err != nil {
+		// the unique keys are violated by the concurrent registration
+		if _, gerr := d.GetNodeRegistrationByFingerprint(r.Namespace, r.Fingerprint); gerr == nil {
+			return common.Error(common.ErrResourceConflict, common.Field("type", "nodeRegistration"), common.Field("name", r.Name))
+		}
+		if _, gerr := d.GetNodeRegistration(r.Namespace, r.Name); gerr == nil {
+			return common.Error(common.ErrResourceConflict, common.Field("type", "nodeRegistration"), common.Field("name", r.Name))
+		}
+	}
+	return err
+}
+
+func (d *DB) UpdateNodeRegistration(registration *models.NodeRegistration) error {
+	updateSQL := `
+UPDATE baetyl_node_registration SET hardware=?, mode=?, status=?, reason=?, rule=?, activation_token=? 
+WHERE namespace=? AND name=?
+`
+	r, err := entities.FromNodeRegistrationModel(registration)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, r.Hardware, r.Mode, r.Status, r.Reason, r.Rule, r.ActivationToken,
+		r.Namespace, r.Name)
+	return err
+}
+
+func (d *DB) DeleteNodeRegistration(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_node_registration WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
+
+func (d *DB) GetNodeRegistrationRule(namespace, name string) (*models.NodeRegistrationRule, error) {
+	selectSQL := `
+SELECT namespace, name, description, fingerprint, hardware, create_time, update_time 
+FROM baetyl_node_registration_rule WHERE namespace=? AND name=?
+`
+	var rules []entities.NodeRegistrationRule
+	if err := d.Query(nil, selectSQL, &rules, namespace, name); err != nil {
+		return nil, err
+	}
+	if len(rules) > 0 {
+		return entities.ToNodeRegistrationRuleModel(&rules[0])
+	}
+	return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "nodeRegistrationRule"),
+		common.Field("name", name), common.Field("namespace", namespace))
+}
+
+func (d *DB) ListNodeRegistrationRule(namespace string) ([]models.NodeRegistrationRule, error) {
+	selectSQL := `
+SELECT namespace, name, description, fingerprint, hardware, create_time, update_time 
+FROM baetyl_node_registration_rule WHERE namespace=? ORDER BY name
+`
+	var rules []entities.NodeRegistrationRule
+	if err := d.Query(nil, selectSQL, &rules, namespace); err != nil {
+		return nil, err
+	}
+	res := make([]models.NodeRegistrationRule, 0, len(rules))
+	for i := range rules {
+		r, err := entities.ToNodeRegistrationRuleModel(&rules[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *r)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateNodeRegistrationRule(rule *models.NodeRegistrationRule) error {
+	insertSQL := `
+INSERT INTO baetyl_node_registration_rule (namespace, name, description, fingerprint, hardware) 
+VALUES (?,?,?,?,?)
+`
+	r, err := entities.FromNodeRegistrationRuleModel(rule)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, r.Namespace, r.Name, r.Description, r.Fingerprint, r.Hardware)
+	return err
+}
+
+func (d *DB) DeleteNodeRegistrationRule(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_node_registration_rule WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
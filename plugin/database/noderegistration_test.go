@@ -0,0 +1,163 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	nodeRegistrationTables = []string{
+		`
+CREATE TABLE baetyl_node_registration(
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace        VARCHAR(64) NOT NULL DEFAULT '',
+    name             VARCHAR(128) NOT NULL DEFAULT '',
+    fingerprint      VARCHAR(256) NOT NULL DEFAULT '',
+    hardware         TEXT NULL,
+    mode             VARCHAR(32) NOT NULL DEFAULT '',
+    token            VARCHAR(64) NOT NULL DEFAULT '',
+    node_group       VARCHAR(128) NOT NULL DEFAULT '',
+    labels           TEXT NULL,
+    status           VARCHAR(32) NOT NULL DEFAULT '',
+    reason           VARCHAR(1024) NOT NULL DEFAULT '',
+    rule             VARCHAR(128) NOT NULL DEFAULT '',
+    activation_token VARCHAR(64) NOT NULL DEFAULT '',
+    create_time      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name),
+    UNIQUE (namespace, fingerprint)
+);
+`,
+		`
+CREATE TABLE baetyl_node_registration_rule(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    fingerprint VARCHAR(256) NOT NULL DEFAULT '',
+    hardware    TEXT NULL,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateNodeRegistrationTable() {
+	for _, sql := range nodeRegistrationTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestNodeRegistration(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateNodeRegistrationTable()
+
+	r := &models.NodeRegistration{
+		Namespace:   "default",
+		Name:        "node01",
+		Fingerprint: "sn-0001",
+		Hardware:    map[string]string{"model": "box-x1", "cpu": "arm64"},
+		Mode:        "kube",
+		Token:       "token01",
+		NodeGroup:   "group01",
+		Labels:      map[string]string{"region": "beijing"},
+		Status:      models.NodeRegistrationPending,
+	}
+	_, err = db.GetNodeRegistration("default", "node01")
+	assert.Error(t, err)
+
+	err = db.CreateNodeRegistration(r)
+	assert.NoError(t, err)
+	// the same fingerprint or name is registered once
+	err = db.CreateNodeRegistration(&models.NodeRegistration{Namespace: "default", Name: "node02", Fingerprint: "sn-0001"})
+	e, ok := err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, common.ErrResourceConflict, e.Code())
+	err = db.CreateNodeRegistration(&models.NodeRegistration{Namespace: "default", Name: "node01", Fingerprint: "sn-0002"})
+	e, ok = err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, common.ErrResourceConflict, e.Code())
+	err = db.CreateNodeRegistration(&models.NodeRegistration{Namespace: "default", Name: "node03", Fingerprint: "sn-0003", Status: models.NodeRegistrationRejected})
+	assert.NoError(t, err)
+
+	res, err := db.GetNodeRegistrationByFingerprint("default", "sn-0001")
+	assert.NoError(t, err)
+	assert.Equal(t, "node01", res.Name)
+	assert.Equal(t, r.Hardware, res.Hardware)
+	assert.Equal(t, "group01", res.NodeGroup)
+	assert.Equal(t, r.Labels, res.Labels)
+
+	list, err := db.ListNodeRegistration("default", "")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	list, err = db.ListNodeRegistration("default", models.NodeRegistrationPending)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "node01", list[0].Name)
+
+	r.Status, r.Rule, r.ActivationToken = models.NodeRegistrationApproved, "rule01", "token02"
+	err = db.UpdateNodeRegistration(r)
+	assert.NoError(t, err)
+	res, err = db.GetNodeRegistration("default", "node01")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NodeRegistrationApproved, res.Status)
+	assert.Equal(t, "rule01", res.Rule)
+	assert.Equal(t, "token02", res.ActivationToken)
+
+	err = db.DeleteNodeRegistration("default", "node01")
+	assert.NoError(t, err)
+	_, err = db.GetNodeRegistration("default", "node01")
+	assert.Error(t, err)
+}
+
+func TestNodeRegistrationRule(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateNodeRegistrationTable()
+
+	rule := &models.NodeRegistrationRule{Namespace: "default", Name: "rule01", Fingerprint: "sn-*", Hardware: map[string]string{"model": "box-x1"}}
+	_, err = db.GetNodeRegistrationRule("default", "rule01")
+	assert.Error(t, err)
+	err = db.CreateNodeRegistrationRule(rule)
+	assert.NoError(t, err)
+	err = db.CreateNodeRegistrationRule(rule)
+	assert.Error(t, err)
+	err = db.CreateNodeRegistrationRule(&models.NodeRegistrationRule{Namespace: "default", Name: "rule00", Fingerprint: "*"})
+	assert.NoError(t, err)
+
+	res, err := db.GetNodeRegistrationRule("default", "rule01")
+	assert.NoError(t, err)
+	assert.Equal(t, "sn-*", res.Fingerprint)
+	assert.Equal(t, rule.Hardware, res.Hardware)
+
+	list, err := db.ListNodeRegistrationRule("default")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "rule00", list[0].Name)
+	assert.Nil(t, list[0].Hardware)
+
+	err = db.DeleteNodeRegistrationRule("default", "rule01")
+	assert.NoError(t, err)
+	_, err = db.GetNodeRegistrationRule("default", "rule01")
+	assert.Error(t, err)
+}
@@ -13,7 +13,7 @@ cert_id, parent_id, type, common_name,
 description, csr, content, private_key, not_before, not_after) 
 VALUES (?,?,?,?,?,?,?,?,?,?)
 `
-	_, err := d.db.Exec(insertSQL,
+	_, err := d.Exec(nil, insertSQL,
 		cert.CertId, cert.ParentId, cert.Type,
 		cert.CommonName, cert.Description, cert.Csr,
 		cert.Content, cert.PrivateKey, cert.NotBefore, cert.NotAfter)
@@ -24,7 +24,7 @@ func (d DB) DeleteCert(certId string) error {
 	deleteSQL := `
 DELETE FROM baetyl_certificate where cert_id=?
 `
-	_, err := d.db.Exec(deleteSQL, certId)
+	_, err := d.Exec(nil, deleteSQL, certId)
 	return err
 }
 
@@ -35,7 +35,7 @@ common_name=?,description=?,csr=?,content=?,private_key=?,
 not_before=?, not_after=? 
 WHERE cert_id=?
 `
-	_, err := d.db.Exec(updateSQL,
+	_, err := d.Exec(nil, updateSQL,
 		cert.ParentId, cert.Type, cert.CommonName, cert.Description, cert.Csr,
 		cert.Content, cert.PrivateKey, cert.NotBefore, cert.NotAfter, cert.CertId)
 	return err
@@ -49,7 +49,7 @@ FROM baetyl_certificate
 WHERE cert_id=? LIMIT 0,1
 `
 	var cert []plugin.Cert
-	if err := d.db.Select(&cert, selectSQL, certId); err != nil {
+	if err := d.Query(nil, selectSQL, &cert, certId); err != nil {
 		return nil, err
 	}
 	if len(cert) > 0 {
@@ -67,7 +67,7 @@ WHERE parent_id=?
 	var res []struct {
 		Count int `db:"count"`
 	}
-	if err := d.db.Select(&res, selectSQL, parentId); err != nil {
+	if err := d.Query(nil, selectSQL, &res, parentId); err != nil {
 		return 0, err
 	}
 	return res[0].Count, nil
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) CountSyncTraffic(since time.Time) ([]models.SyncTraffic, error) {
+	selectSQL := `
+SELECT kind, COUNT(*) AS total, SUM(CASE WHEN error<>'' THEN 1 ELSE 0 END) AS failures, 
+COALESCE(SUM(request_size), 0) AS request_bytes, COALESCE(SUM(response_size), 0) AS response_bytes 
+FROM baetyl_sync_history WHERE sync_time>=? GROUP BY kind ORDER BY kind
+`
+	var traffic []entities.SyncTraffic
+	if err := d.Query(nil, selectSQL, &traffic, since.UTC()); err != nil {
+		return nil, err
+	}
+	res := make([]models.SyncTraffic, 0, len(traffic))
+	for i := range traffic {
+		res = append(res, entities.ToSyncTrafficModel(&traffic[i]))
+	}
+	return res, nil
+}
+
+// ListTableSize only support for mysql
+func (d *DB) ListTableSize() ([]models.TableSize, error) {
+	selectSQL := `
+SELECT table_name AS name, COALESCE(table_rows, 0) AS table_rows, COALESCE(data_length, 0) AS data_bytes, 
+COALESCE(index_length, 0) AS index_bytes 
+FROM information_schema.tables WHERE table_schema=DATABASE() ORDER BY data_length DESC
+`
+	var sizes []entities.TableSize
+	if err := d.Query(nil, selectSQL, &sizes); err != nil {
+		return nil, err
+	}
+	res := make([]models.TableSize, 0, len(sizes))
+	for i := range sizes {
+		res = append(res, entities.ToTableSizeModel(&sizes[i]))
+	}
+	return res, nil
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestCountSyncTraffic(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateSyncHistoryTable()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for i, ns := range []string{"default", "tenant-a", "tenant-a"} {
+		err = db.CreateSyncRecord(&models.SyncRecord{Namespace: ns, Name: "node01", Kind: "report", Time: now.Add(-time.Duration(i) * time.Minute), RequestSize: 1024, ResponseSize: 64})
+		assert.NoError(t, err)
+	}
+	err = db.CreateSyncRecord(&models.SyncRecord{Namespace: "default", Name: "node02", Kind: "desire", Time: now, RequestSize: 128, Error: "timeout"})
+	assert.NoError(t, err)
+	// out of the window
+	err = db.CreateSyncRecord(&models.SyncRecord{Namespace: "default", Name: "node01", Kind: "report", Time: now.Add(-2 * time.Hour), RequestSize: 1024})
+	assert.NoError(t, err)
+
+	traffic, err := db.CountSyncTraffic(now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []models.SyncTraffic{
+		{Kind: "desire", Total: 1, Failures: 1, RequestBytes: 128},
+		{Kind: "report", Total: 3, RequestBytes: 3072, ResponseBytes: 192},
+	}, traffic)
+
+	traffic, err = db.CountSyncTraffic(now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, traffic)
+}
@@ -67,7 +67,7 @@ func (d *DB) ListProperty(filter *models.Filter) ([]models.Property, error) {
 		args = append(args, filter.GetLimitOffset(), filter.GetLimitNumber())
 	}
 
-	if err := d.Query(nil, selectSQL, &cs, args...); err != nil {
+	if err := d.QueryContext(filter.Context(), nil, selectSQL, &cs, args...); err != nil {
 		return nil, err
 	}
 	return cs, nil
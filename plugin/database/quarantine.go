@@ -0,0 +1,54 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetQuarantine(name string) (*models.Quarantine, error) {
+	selectSQL := `
+SELECT name, type, namespace, app, image, reason, operator, create_time, update_time
+FROM baetyl_quarantine WHERE name=?
+`
+	var quarantines []entities.Quarantine
+	if err := d.Query(nil, selectSQL, &quarantines, name); err != nil {
+		return nil, err
+	}
+	if len(quarantines) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "quarantine"), common.Field("name", name))
+	}
+	return entities.ToQuarantineModel(&quarantines[0]), nil
+}
+
+func (d *DB) ListQuarantine() ([]models.Quarantine, error) {
+	selectSQL := `
+SELECT name, type, namespace, app, image, reason, operator, create_time, update_time
+FROM baetyl_quarantine ORDER BY create_time, name
+`
+	var quarantines []entities.Quarantine
+	if err := d.Query(nil, selectSQL, &quarantines); err != nil {
+		return nil, err
+	}
+	res := make([]models.Quarantine, 0, len(quarantines))
+	for i := range quarantines {
+		res = append(res, *entities.ToQuarantineModel(&quarantines[i]))
+	}
+	return res, nil
+}
+
+func (d *DB) CreateQuarantine(quarantine *models.Quarantine) error {
+	insertSQL := `
+INSERT INTO baetyl_quarantine (name, type, namespace, app, image, reason, operator)
+VALUES (?,?,?,?,?,?,?)
+`
+	q := entities.FromQuarantineModel(quarantine)
+	_, err := d.Exec(nil, insertSQL, q.Name, q.Type, q.Namespace, q.App, q.Image, q.Reason, q.Operator)
+	return err
+}
+
+func (d *DB) DeleteQuarantine(name string) error {
+	deleteSQL := `DELETE FROM baetyl_quarantine WHERE name=?`
+	_, err := d.Exec(nil, deleteSQL, name)
+	return err
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	quarantineTables = []string{
+		`
+CREATE TABLE baetyl_quarantine(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    type        VARCHAR(32) NOT NULL DEFAULT '',
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    app         VARCHAR(128) NOT NULL DEFAULT '',
+    image       VARCHAR(1024) NOT NULL DEFAULT '',
+    reason      VARCHAR(512) NOT NULL DEFAULT '',
+    operator    VARCHAR(128) NOT NULL DEFAULT '',
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateQuarantineTable() {
+	for _, sql := range quarantineTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestQuarantine(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateQuarantineTable()
+
+	quarantine := &models.Quarantine{
+		Name:     "cve-2021-23017",
+		Type:     models.QuarantineImage,
+		Image:    "nginx:1.20",
+		Reason:   "CVE-2021-23017 of resolver",
+		Operator: "ops",
+	}
+	_, err = db.GetQuarantine(quarantine.Name)
+	assert.Error(t, err)
+
+	err = db.CreateQuarantine(quarantine)
+	assert.NoError(t, err)
+	err = db.CreateQuarantine(quarantine)
+	assert.Error(t, err)
+
+	res, err := db.GetQuarantine(quarantine.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, models.QuarantineImage, res.Type)
+	assert.Equal(t, "nginx:1.20", res.Image)
+	assert.Equal(t, "ops", res.Operator)
+	assert.Empty(t, res.Namespace)
+
+	err = db.CreateQuarantine(&models.Quarantine{Name: "leaky", Type: models.QuarantineApp, Namespace: "default", App: "nginx", Reason: "leaks"})
+	assert.NoError(t, err)
+	list, err := db.ListQuarantine()
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	err = db.DeleteQuarantine(quarantine.Name)
+	assert.NoError(t, err)
+	_, err = db.GetQuarantine(quarantine.Name)
+	assert.Error(t, err)
+	list, err = db.ListQuarantine()
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "default", list[0].Namespace)
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetRollout(namespace, app string) (*models.Rollout, error) {
+	selectSQL := `
+SELECT namespace, app, version, strategy, stage, status, nodes, failed, message, stage_time, create_time, update_time 
+FROM baetyl_app_rollout WHERE namespace=? AND app=?
+`
+	var rollouts []entities.Rollout
+	if err := d.Query(nil, selectSQL, &rollouts, namespace, app); err != nil {
+		return nil, err
+	}
+	if len(rollouts) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "rollout"), common.Field("name", app))
+	}
+	return entities.ToRolloutModel(&rollouts[0])
+}
+
+func (d *DB) ListRollout(namespace string) ([]models.Rollout, error) {
+	selectSQL := `
+SELECT namespace, app, version, strategy, stage, status, nodes, failed, message, stage_time, create_time, update_time 
+FROM baetyl_app_rollout WHERE namespace=? ORDER BY app
+`
+	var rollouts []entities.Rollout
+	if err := d.Query(nil, selectSQL, &rollouts, namespace); err != nil {
+		return nil, err
+	}
+	res := make([]models.Rollout, 0, len(rollouts))
+	for i := range rollouts {
+		rollout, err := entities.ToRolloutModel(&rollouts[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *rollout)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateRollout(rollout *models.Rollout) error {
+	insertSQL := `
+INSERT INTO baetyl_app_rollout (namespace, app, version, strategy, stage, status, nodes, failed, message, stage_time) 
+VALUES (?,?,?,?,?,?,?,?,?,?)
+`
+	r, err := entities.FromRolloutModel(rollout)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, r.Namespace, r.App, r.Version, r.Strategy, r.Stage, r.Status, r.Nodes, r.Failed, r.Message, r.StageTime)
+	return err
+}
+
+func (d *DB) UpdateRollout(rollout *models.Rollout) error {
+	updateSQL := `
+UPDATE baetyl_app_rollout SET stage=?, status=?, nodes=?, failed=?, message=?, stage_time=? 
+WHERE namespace=? AND app=?
+`
+	r, err := entities.FromRolloutModel(rollout)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, r.Stage, r.Status, r.Nodes, r.Failed, r.Message, r.StageTime, r.Namespace, r.App)
+	return err
+}
+
+func (d *DB) DeleteRollout(namespace, app string) error {
+	deleteSQL := `DELETE FROM baetyl_app_rollout WHERE namespace=? AND app=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, app)
+	return err
+}
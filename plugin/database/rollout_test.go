@@ -0,0 +1,112 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	rolloutTables = []string{
+		`
+CREATE TABLE baetyl_app_rollout(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    app         VARCHAR(128) NOT NULL DEFAULT '',
+    version     VARCHAR(36) NOT NULL DEFAULT '',
+    strategy    TEXT,
+    stage       INTEGER NOT NULL DEFAULT 0,
+    status      VARCHAR(32) NOT NULL DEFAULT '',
+    nodes       TEXT,
+    failed      TEXT,
+    message     VARCHAR(1024) NOT NULL DEFAULT '',
+    stage_time  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, app)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateRolloutTable() {
+	for _, sql := range rolloutTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestRollout(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateRolloutTable()
+
+	stageTime := time.Now().UTC().Truncate(time.Second)
+	rollout := &models.Rollout{
+		Namespace: "default",
+		App:       "app01",
+		Version:   "1",
+		Strategy: models.RolloutStrategy{
+			Stages:         []models.RolloutStage{{Percent: 10}, {NodeGroup: "group01"}},
+			BakeTime:       "1h",
+			PauseOnFailure: true,
+		},
+		Status:    models.RolloutProgressing,
+		Nodes:     []string{"node01"},
+		StageTime: stageTime,
+	}
+	_, err = db.GetRollout(rollout.Namespace, rollout.App)
+	assert.Error(t, err)
+
+	err = db.CreateRollout(rollout)
+	assert.NoError(t, err)
+	err = db.CreateRollout(rollout)
+	assert.Error(t, err)
+
+	res, err := db.GetRollout(rollout.Namespace, rollout.App)
+	assert.NoError(t, err)
+	assert.Equal(t, rollout.Strategy, res.Strategy)
+	assert.Equal(t, []string{"node01"}, res.Nodes)
+	assert.Nil(t, res.Failed)
+	assert.Equal(t, stageTime, res.StageTime)
+
+	rollout.Stage = 1
+	rollout.Status = models.RolloutPaused
+	rollout.Nodes = []string{"node01", "node02"}
+	rollout.Failed = []string{"node02"}
+	rollout.Message = "failed"
+	err = db.UpdateRollout(rollout)
+	assert.NoError(t, err)
+	res, err = db.GetRollout(rollout.Namespace, rollout.App)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Stage)
+	assert.Equal(t, models.RolloutPaused, res.Status)
+	assert.Equal(t, []string{"node01", "node02"}, res.Nodes)
+	assert.Equal(t, []string{"node02"}, res.Failed)
+	assert.Equal(t, "failed", res.Message)
+
+	err = db.CreateRollout(&models.Rollout{Namespace: "default", App: "app02", Version: "2", Status: models.RolloutCompleted})
+	assert.NoError(t, err)
+	list, err := db.ListRollout("default")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "app01", list[0].App)
+	list, err = db.ListRollout("other")
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	err = db.DeleteRollout(rollout.Namespace, rollout.App)
+	assert.NoError(t, err)
+	_, err = db.GetRollout(rollout.Namespace, rollout.App)
+	assert.Error(t, err)
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetSecretRotation(namespace, name string) (*models.SecretRotation, error) {
+	selectSQL := `
+SELECT namespace, name, expires_at, rotator, rotate_interval, rotate_keys, webhook, status, rotate_time, create_time, update_time 
+FROM baetyl_secret_rotation WHERE namespace=? AND name=?
+`
+	var rotations []entities.SecretRotation
+	if err := d.Query(nil, selectSQL, &rotations, namespace, name); err != nil {
+		return nil, err
+	}
+	if len(rotations) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "secretrotation"),
+			common.Field("name", name), common.Field("namespace", namespace))
+	}
+	return entities.ToSecretRotationModel(&rotations[0])
+}
+
+func (d *DB) ListExpiringSecretRotation(before time.Time) ([]models.SecretRotation, error) {
+	selectSQL := `
+SELECT namespace, name, expires_at, rotator, rotate_interval, rotate_keys, webhook, status, rotate_time, create_time, update_time 
+FROM baetyl_secret_rotation WHERE expires_at<=? AND status!=? ORDER BY expires_at
+`
+	var rotations []entities.SecretRotation
+	if err := d.Query(nil, selectSQL, &rotations, before.UTC(), models.SecretRotationExpired); err != nil {
+		return nil, err
+	}
+	res := make([]models.SecretRotation, 0, len(rotations))
+	for i := range rotations {
+		rotation, err := entities.ToSecretRotationModel(&rotations[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *rotation)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateSecretRotation(rotation *models.SecretRotation) error {
+	insertSQL := `
+INSERT INTO baetyl_secret_rotation (namespace, name, expires_at, rotator, rotate_interval, rotate_keys, webhook, status, rotate_time) 
+VALUES (?,?,?,?,?,?,?,?,?)
+`
+	r, err := entities.FromSecretRotationModel(rotation)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, r.Namespace, r.Name, r.ExpiresAt.UTC(), r.Rotator, r.Interval, r.Keys, r.Webhook, r.Status, r.RotateTime.UTC())
+	return err
+}
+
+func (d *DB) UpdateSecretRotation(rotation *models.SecretRotation) error {
+	updateSQL := `
+UPDATE baetyl_secret_rotation SET expires_at=?, rotator=?, rotate_interval=?, rotate_keys=?, webhook=?, status=?, rotate_time=? 
+WHERE namespace=? AND name=?
+`
+	r, err := entities.FromSecretRotationModel(rotation)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, r.ExpiresAt.UTC(), r.Rotator, r.Interval, r.Keys, r.Webhook, r.Status, r.RotateTime.UTC(), r.Namespace, r.Name)
+	return err
+}
+
+func (d *DB) DeleteSecretRotation(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_secret_rotation WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
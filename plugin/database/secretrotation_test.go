@@ -0,0 +1,119 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	secretRotationTables = []string{
+		`
+CREATE TABLE baetyl_secret_rotation(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace       VARCHAR(64) NOT NULL DEFAULT '',
+    name            VARCHAR(128) NOT NULL DEFAULT '',
+    expires_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rotator         VARCHAR(64) NOT NULL DEFAULT '',
+    rotate_interval VARCHAR(32) NOT NULL DEFAULT '',
+    rotate_keys     TEXT,
+    webhook         VARCHAR(1024) NOT NULL DEFAULT '',
+    status          VARCHAR(32) NOT NULL DEFAULT '',
+    rotate_time     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    create_time     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateSecretRotationTable() {
+	for _, sql := range secretRotationTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestSecretRotation(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateSecretRotationTable()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	rotation := &models.SecretRotation{
+		Namespace:  "default",
+		Name:       "db",
+		ExpiresAt:  now.Add(time.Hour),
+		Rotator:    "defaultrotator",
+		Interval:   "720h",
+		Keys:       []string{"password"},
+		Webhook:    "http://hook.local/secrets",
+		Status:     models.SecretRotationActive,
+		RotateTime: now,
+	}
+	_, err = db.GetSecretRotation(rotation.Namespace, rotation.Name)
+	assert.Error(t, err)
+
+	err = db.CreateSecretRotation(rotation)
+	assert.NoError(t, err)
+	err = db.CreateSecretRotation(rotation)
+	assert.Error(t, err)
+
+	other := &models.SecretRotation{
+		Namespace:  "prod",
+		Name:       "token",
+		ExpiresAt:  now.Add(48 * time.Hour),
+		Status:     models.SecretRotationActive,
+		RotateTime: now,
+	}
+	err = db.CreateSecretRotation(other)
+	assert.NoError(t, err)
+
+	res, err := db.GetSecretRotation(rotation.Namespace, rotation.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, rotation.ExpiresAt, res.ExpiresAt)
+	assert.Equal(t, "defaultrotator", res.Rotator)
+	assert.Equal(t, "720h", res.Interval)
+	assert.Equal(t, []string{"password"}, res.Keys)
+	assert.Equal(t, "http://hook.local/secrets", res.Webhook)
+	assert.Equal(t, now, res.RotateTime)
+
+	list, err := db.ListExpiringSecretRotation(now.Add(24 * time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "db", list[0].Name)
+	list, err = db.ListExpiringSecretRotation(now.Add(72 * time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "token", list[1].Name)
+
+	// the expired ones are not listed again
+	rotation.Keys = nil
+	rotation.Status = models.SecretRotationExpired
+	err = db.UpdateSecretRotation(rotation)
+	assert.NoError(t, err)
+	res, err = db.GetSecretRotation(rotation.Namespace, rotation.Name)
+	assert.NoError(t, err)
+	assert.Empty(t, res.Keys)
+	assert.Equal(t, models.SecretRotationExpired, res.Status)
+	list, err = db.ListExpiringSecretRotation(now.Add(72 * time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "token", list[0].Name)
+
+	err = db.DeleteSecretRotation(rotation.Namespace, rotation.Name)
+	assert.NoError(t, err)
+	_, err = db.GetSecretRotation(rotation.Namespace, rotation.Name)
+	assert.Error(t, err)
+}
@@ -144,11 +144,18 @@ func (d *DB) updateDesiresTx(tx *sqlx.Tx, shadows []*models.Shadow) (sql.Result,
 		params = append(params, shadow.Name, shadow.Namespace, desire, genResourceVersion(), desireMeta)
 	}
 	insertSQL = strings.TrimRight(insertSQL, ",")
-	insertSQL += `
+	if d.cfg.Database.Type == TypePostgres {
+		insertSQL += `
+ON CONFLICT (namespace, name) DO UPDATE SET 
+desire=EXCLUDED.desire,desire_version=EXCLUDED.desire_version,desire_meta=EXCLUDED.desire_meta
+`
+	} else {
+		insertSQL += `
 ON DUPLICATE KEY UPDATE 
 name=VALUES(name),namespace=VALUES(namespace),
 desire=VALUES(desire),desire_version=VALUES(desire_version),desire_meta=VALUES(desire_meta)
 `
+	}
 	return d.Exec(tx, insertSQL, params...)
 }
 
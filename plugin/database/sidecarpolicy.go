@@ -0,0 +1,74 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetSidecarPolicy(namespace, name string) (*models.SidecarPolicy, error) {
+	selectSQL := `
+SELECT namespace, name, description, selector, sidecar, volumes, create_time, update_time 
+FROM baetyl_sidecar_policy WHERE namespace=? AND name=?
+`
+	var policies []entities.SidecarPolicy
+	if err := d.Query(nil, selectSQL, &policies, namespace, name); err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "sidecar policy"), common.Field("name", name))
+	}
+	return entities.ToSidecarPolicyModel(&policies[0])
+}
+
+func (d *DB) ListSidecarPolicy(namespace string) ([]models.SidecarPolicy, error) {
+	selectSQL := `
+SELECT namespace, name, description, selector, sidecar, volumes, create_time, update_time 
+FROM baetyl_sidecar_policy WHERE namespace=? ORDER BY name
+`
+	var policies []entities.SidecarPolicy
+	if err := d.Query(nil, selectSQL, &policies, namespace); err != nil {
+		return nil, err
+	}
+	res := make([]models.SidecarPolicy, 0, len(policies))
+	for i := range policies {
+		policy, err := entities.ToSidecarPolicyModel(&policies[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *policy)
+	}
+	return res, nil
+}
+
+func (d *DB) CreateSidecarPolicy(policy *models.SidecarPolicy) error {
+	insertSQL := `
+INSERT INTO baetyl_sidecar_policy (namespace, name, description, selector, sidecar, volumes) 
+VALUES (?,?,?,?,?,?)
+`
+	p, err := entities.FromSidecarPolicyModel(policy)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, insertSQL, p.Namespace, p.Name, p.Description, p.Selector, p.Sidecar, p.Volumes)
+	return err
+}
+
+func (d *DB) UpdateSidecarPolicy(policy *models.SidecarPolicy) error {
+	updateSQL := `
+UPDATE baetyl_sidecar_policy SET description=?, selector=?, sidecar=?, volumes=? 
+WHERE namespace=? AND name=?
+`
+	p, err := entities.FromSidecarPolicyModel(policy)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(nil, updateSQL, p.Description, p.Selector, p.Sidecar, p.Volumes, p.Namespace, p.Name)
+	return err
+}
+
+func (d *DB) DeleteSidecarPolicy(namespace, name string) error {
+	deleteSQL := `DELETE FROM baetyl_sidecar_policy WHERE namespace=? AND name=?`
+	_, err := d.Exec(nil, deleteSQL, namespace, name)
+	return err
+}
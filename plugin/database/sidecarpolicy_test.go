@@ -0,0 +1,103 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	sidecarPolicyTables = []string{
+		`
+CREATE TABLE baetyl_sidecar_policy(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    name        VARCHAR(128) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    selector    VARCHAR(2048) NOT NULL DEFAULT '',
+    sidecar     TEXT,
+    volumes     TEXT,
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace, name)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateSidecarPolicyTable() {
+	for _, sql := range sidecarPolicyTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestSidecarPolicy(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateSidecarPolicyTable()
+
+	policy := &models.SidecarPolicy{
+		Namespace:   "default",
+		Name:        "log-shipper",
+		Description: "ship logs",
+		Selector:    "tier=web",
+		Sidecar: specV1.Service{
+			Name:         "fluent-bit",
+			Image:        "fluent/fluent-bit:1.9",
+			VolumeMounts: []specV1.VolumeMount{{Name: "log", MountPath: "/var/log/app"}},
+		},
+		Volumes: []specV1.Volume{{Name: "log", VolumeSource: specV1.VolumeSource{EmptyDir: &specV1.EmptyDirVolumeSource{}}}},
+	}
+	_, err = db.GetSidecarPolicy(policy.Namespace, policy.Name)
+	assert.Error(t, err)
+
+	err = db.CreateSidecarPolicy(policy)
+	assert.NoError(t, err)
+	err = db.CreateSidecarPolicy(policy)
+	assert.Error(t, err)
+
+	res, err := db.GetSidecarPolicy(policy.Namespace, policy.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "ship logs", res.Description)
+	assert.Equal(t, "tier=web", res.Selector)
+	assert.Equal(t, policy.Sidecar, res.Sidecar)
+	assert.Equal(t, policy.Volumes, res.Volumes)
+
+	policy.Selector = ""
+	policy.Sidecar.Image = "fluent/fluent-bit:2.0"
+	policy.Volumes = nil
+	err = db.UpdateSidecarPolicy(policy)
+	assert.NoError(t, err)
+	res, err = db.GetSidecarPolicy(policy.Namespace, policy.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "", res.Selector)
+	assert.Equal(t, "fluent/fluent-bit:2.0", res.Sidecar.Image)
+	assert.Empty(t, res.Volumes)
+
+	err = db.CreateSidecarPolicy(&models.SidecarPolicy{Namespace: "default", Name: "metrics", Sidecar: specV1.Service{Name: "agent", Image: "agent"}})
+	assert.NoError(t, err)
+	list, err := db.ListSidecarPolicy("default")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "log-shipper", list[0].Name)
+	assert.Equal(t, "agent", list[1].Sidecar.Name)
+	list, err = db.ListSidecarPolicy("other")
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+
+	err = db.DeleteSidecarPolicy(policy.Namespace, policy.Name)
+	assert.NoError(t, err)
+	_, err = db.GetSidecarPolicy(policy.Namespace, policy.Name)
+	assert.Error(t, err)
+}
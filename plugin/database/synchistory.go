@@ -0,0 +1,44 @@
+package database
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) CreateSyncRecord(record *models.SyncRecord) error {
+	insertSQL := `
+INSERT INTO baetyl_sync_history (namespace, name, kind, request_size, response_size, 
+latency, protocol, client_version, client_ip, error, sync_time) 
+VALUES (?,?,?,?,?,?,?,?,?,?,?)
+`
+	r := entities.FromSyncRecordModel(record)
+	_, err := d.Exec(nil, insertSQL, r.Namespace, r.Name, r.Kind, r.RequestSize, r.ResponseSize,
+		r.Latency, r.Protocol, r.ClientVersion, r.ClientIP, r.Error, r.SyncTime)
+	return err
+}
+
+func (d *DB) ListSyncRecord(namespace, name string, since time.Time, limit int) ([]models.SyncRecord, error) {
+	selectSQL := `
+SELECT namespace, name, kind, request_size, response_size, latency, protocol, 
+client_version, client_ip, error, sync_time 
+FROM baetyl_sync_history WHERE namespace=? AND name=? AND sync_time>=? 
+ORDER BY sync_time DESC, id DESC LIMIT ?
+`
+	var records []entities.SyncRecord
+	if err := d.Query(nil, selectSQL, &records, namespace, name, since.UTC(), limit); err != nil {
+		return nil, err
+	}
+	res := make([]models.SyncRecord, 0, len(records))
+	for i := range records {
+		res = append(res, entities.ToSyncRecordModel(&records[i]))
+	}
+	return res, nil
+}
+
+func (d *DB) DeleteSyncRecord(before time.Time) error {
+	deleteSQL := `DELETE FROM baetyl_sync_history WHERE sync_time<?`
+	_, err := d.Exec(nil, deleteSQL, before.UTC())
+	return err
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	syncHistoryTables = []string{
+		`
+CREATE TABLE baetyl_sync_history(
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace      VARCHAR(64) NOT NULL DEFAULT '',
+    name           VARCHAR(128) NOT NULL DEFAULT '',
+    kind           VARCHAR(64) NOT NULL DEFAULT '',
+    request_size   INT NOT NULL DEFAULT 0,
+    response_size  INT NOT NULL DEFAULT 0,
+    latency        BIGINT NOT NULL DEFAULT 0,
+    protocol       INT NOT NULL DEFAULT 0,
+    client_version VARCHAR(256) NOT NULL DEFAULT '',
+    client_ip      VARCHAR(64) NOT NULL DEFAULT '',
+    error          VARCHAR(1024) NOT NULL DEFAULT '',
+    sync_time      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateSyncHistoryTable() {
+	for _, sql := range syncHistoryTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestSyncHistory(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateSyncHistoryTable()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		err = db.CreateSyncRecord(&models.SyncRecord{
+			Namespace:     "default",
+			Name:          "node01",
+			Kind:          "report",
+			Time:          now.Add(time.Duration(i-2) * time.Minute),
+			RequestSize:   1024 * i,
+			ResponseSize:  64,
+			Latency:       int64(10 * i),
+			Protocol:      3,
+			ClientVersion: "baetyl-core/v2.4.3",
+			ClientIP:      "10.0.0.1",
+		})
+		assert.NoError(t, err)
+	}
+	err = db.CreateSyncRecord(&models.SyncRecord{Namespace: "default", Name: "node02", Kind: "desire", Time: now, Error: "timeout"})
+	assert.NoError(t, err)
+
+	records, err := db.ListSyncRecord("default", "node01", now.Add(-time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, now, records[0].Time)
+	assert.Equal(t, 2048, records[0].RequestSize)
+	assert.Equal(t, int64(20), records[0].Latency)
+	assert.Equal(t, "baetyl-core/v2.4.3", records[0].ClientVersion)
+	assert.Equal(t, now.Add(-2*time.Minute), records[2].Time)
+
+	records, err = db.ListSyncRecord("default", "node01", now.Add(-time.Hour), 2)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	records, err = db.ListSyncRecord("default", "node01", now.Add(-time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	records, err = db.ListSyncRecord("default", "node02", now.Add(-time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "timeout", records[0].Error)
+
+	err = db.DeleteSyncRecord(now.Add(-30 * time.Second))
+	assert.NoError(t, err)
+	records, err = db.ListSyncRecord("default", "node01", now.Add(-time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+}
@@ -41,12 +41,16 @@ func (d *DB) AcquireTaskLock(task *models.Task) (bool, error) {
 	return isOperatedSuccess(result)
 }
 
-// GetNeedProcessTask only support for mysql
+// GetNeedProcessTask only support for mysql and postgres
 func (d *DB) GetNeedProcessTask(batchNum, expiredSeconds int32) ([]*models.Task, error) {
+	expireTime := "DATE_ADD(NOW(), INTERVAL ? SECOND)"
+	if d.cfg.Database.Type == TypePostgres {
+		expireTime = "NOW() + make_interval(secs => ?)"
+	}
 	selectSQL := `SELECT id, name, registration_name, namespace, resource_name, resource_type, version, expire_time, 
 status, content, create_time, update_time
 FROM baetyl_task 
-WHERE update_time < DATE_ADD(NOW(), INTERVAL ? SECOND) AND status < ?
+WHERE update_time < ` + expireTime + ` AND status < ?
 limit ?`
 	var tArr []*entities.Task
 	var tasks []*models.Task
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+func (d *DB) GetTenant(namespace string) (*models.Tenant, error) {
+	selectSQL := `
+SELECT namespace, status, reason, create_time, update_time 
+FROM baetyl_tenant WHERE namespace=?
+`
+	var tenants []entities.Tenant
+	if err := d.Query(nil, selectSQL, &tenants, namespace); err != nil {
+		return nil, err
+	}
+	if len(tenants) == 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "tenant"), common.Field("name", namespace))
+	}
+	return entities.ToTenantModel(&tenants[0]), nil
+}
+
+func (d *DB) CreateTenant(tenant *models.Tenant) error {
+	insertSQL := `
+INSERT INTO baetyl_tenant (namespace, status, reason) 
+VALUES (?,?,?)
+`
+	t := entities.FromTenantModel(tenant)
+	_, err := d.Exec(nil, insertSQL, t.Namespace, t.Status, t.Reason)
+	return err
+}
+
+func (d *DB) UpdateTenant(tenant *models.Tenant) error {
+	updateSQL := `
+UPDATE baetyl_tenant SET status=?, reason=? 
+WHERE namespace=?
+`
+	t := entities.FromTenantModel(tenant)
+	_, err := d.Exec(nil, updateSQL, t.Status, t.Reason, t.Namespace)
+	return err
+}
+
+func (d *DB) DeleteTenant(namespace string) error {
+	deleteSQL := `DELETE FROM baetyl_tenant WHERE namespace=?`
+	_, err := d.Exec(nil, deleteSQL, namespace)
+	return err
+}
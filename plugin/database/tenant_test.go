@@ -0,0 +1,67 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var (
+	tenantTables = []string{
+		`
+CREATE TABLE baetyl_tenant(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace   VARCHAR(64) NOT NULL DEFAULT '',
+    status      VARCHAR(32) NOT NULL DEFAULT '',
+    reason      VARCHAR(512) NOT NULL DEFAULT '',
+    create_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (namespace)
+);
+`,
+	}
+)
+
+func (d *DB) MockCreateTenantTable() {
+	for _, sql := range tenantTables {
+		_, err := d.Exec(nil, sql)
+		if err != nil {
+			panic(fmt.Sprintf("create table exception: %s", err.Error()))
+		}
+	}
+}
+
+func TestTenant(t *testing.T) {
+	db, err := MockNewDB()
+	if err != nil {
+		fmt.Printf("get mock sqlite3 error = %s", err.Error())
+		t.Fail()
+		return
+	}
+	db.MockCreateTenantTable()
+
+	tenant := &models.Tenant{Namespace: "tenant-a", Status: models.TenantActive}
+	_, err = db.GetTenant(tenant.Namespace)
+	assert.Error(t, err)
+
+	err = db.CreateTenant(tenant)
+	assert.NoError(t, err)
+	err = db.CreateTenant(tenant)
+	assert.Error(t, err)
+
+	tenant.Status, tenant.Reason = models.TenantFrozen, "contract expired"
+	err = db.UpdateTenant(tenant)
+	assert.NoError(t, err)
+	res, err := db.GetTenant(tenant.Namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, models.TenantFrozen, res.Status)
+	assert.Equal(t, "contract expired", res.Reason)
+
+	err = db.DeleteTenant(tenant.Namespace)
+	assert.NoError(t, err)
+	_, err = db.GetTenant(tenant.Namespace)
+	assert.Error(t, err)
+}
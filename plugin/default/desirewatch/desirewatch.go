@@ -0,0 +1,80 @@
+package desirewatch
+
+import (
+	"sync"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// MaxWatchesPerNode the max number of watches of a node at the same time, such as the ones of core and init
+const MaxWatchesPerNode = 5
+
+func init() {
+	plugin.RegisterFactory("defaultdesirewatch", New)
+}
+
+type watch struct {
+	notify chan struct{}
+}
+
+// memoryDesireWatch keeps the watches in memory, only the changes made by the same instance are notified,
+// the nodes watching another instance get the changes on the next report
+type memoryDesireWatch struct {
+	watches map[string]map[*watch]struct{}
+	mutex   sync.Mutex
+}
+
+func New() (plugin.Plugin, error) {
+	return &memoryDesireWatch{
+		watches: map[string]map[*watch]struct{}{},
+	}, nil
+}
+
+func (m *memoryDesireWatch) Watch(namespace, node string) (<-chan struct{}, func(), error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := namespace + "/" + node
+	ws, ok := m.watches[key]
+	if !ok {
+		ws = map[*watch]struct{}{}
+		m.watches[key] = ws
+	}
+	if len(ws) >= MaxWatchesPerNode {
+		return nil, nil, common.Error(common.ErrTooManyRequests)
+	}
+	w := &watch{notify: make(chan struct{}, 1)}
+	ws[w] = struct{}{}
+
+	var once sync.Once
+	return w.notify, func() {
+		once.Do(func() {
+			m.mutex.Lock()
+			defer m.mutex.Unlock()
+			delete(ws, w)
+			if len(ws) == 0 {
+				delete(m.watches, key)
+			}
+		})
+	}, nil
+}
+
+func (m *memoryDesireWatch) Notify(namespace string, nodes []string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, n := range nodes {
+		for w := range m.watches[namespace+"/"+n] {
+			select {
+			case w.notify <- struct{}{}:
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+func (m *memoryDesireWatch) Close() error {
+	return nil
+}
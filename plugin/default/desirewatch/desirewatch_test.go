@@ -0,0 +1,47 @@
+package desirewatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestMemoryDesireWatch(t *testing.T) {
+	p, err := New()
+	assert.NoError(t, err)
+	w := p.(plugin.DesireWatch)
+	defer w.Close()
+
+	core, stopCore, err := w.Watch("default", "n1")
+	assert.NoError(t, err)
+	other, stopOther, err := w.Watch("default", "n2")
+	assert.NoError(t, err)
+	defer stopOther()
+
+	// the notifications are merged
+	assert.NoError(t, w.Notify("default", []string{"n1"}))
+	assert.NoError(t, w.Notify("default", []string{"n1", "n3"}))
+	assert.Len(t, core, 1)
+	assert.Len(t, other, 0)
+	<-core
+
+	stopCore()
+	stopCore()
+	assert.NoError(t, w.Notify("default", []string{"n1"}))
+	assert.Len(t, core, 0)
+	assert.NotContains(t, p.(*memoryDesireWatch).watches, "default/n1")
+
+	var stops []func()
+	for i := 0; i < MaxWatchesPerNode; i++ {
+		_, stop, err := w.Watch("default", "n1")
+		assert.NoError(t, err)
+		stops = append(stops, stop)
+	}
+	_, _, err = w.Watch("default", "n1")
+	assert.Error(t, err)
+	stops[0]()
+	_, _, err = w.Watch("default", "n1")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,173 @@
+package exec
+
+import (
+	"sync"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+const (
+	outputBufferSize = 64
+	// MaxSessionsPerNode the max number of exec sessions on a node at the same time
+	MaxSessionsPerNode = 5
+)
+
+func init() {
+	plugin.RegisterFactory("defaultexec", New)
+}
+
+type session struct {
+	*models.ExecSession
+	output chan models.ExecFrame
+}
+
+// queue the frames waiting to be synced by node
+type queue struct {
+	frames []models.ExecFrame
+	notify chan struct{}
+}
+
+// memoryExec keeps the sessions in memory, the node must sync with the same instance which the user connects to
+type memoryExec struct {
+	sessions map[string]*session
+	queues   map[string]*queue
+	mutex    sync.Mutex
+}
+
+func New() (plugin.Plugin, error) {
+	return &memoryExec{
+		sessions: map[string]*session{},
+		queues:   map[string]*queue{},
+	}, nil
+}
+
+func (m *memoryExec) OpenSession(s *models.ExecSession) (<-chan models.ExecFrame, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.sessions[s.ID]; ok {
+		return nil, common.Error(common.ErrResourceConflict, common.Field("type", "session"), common.Field("name", s.ID))
+	}
+	count := 0
+	for _, v := range m.sessions {
+		if v.Namespace == s.Namespace && v.Node == s.Node {
+			count++
+		}
+	}
+	if count >= MaxSessionsPerNode {
+		return nil, common.Error(common.ErrTooManyRequests)
+	}
+	ss := &session{ExecSession: s, output: make(chan models.ExecFrame, outputBufferSize)}
+	m.sessions[s.ID] = ss
+	req := s.ExecRequest
+	m.push(s.Namespace, s.Node, models.ExecFrame{Session: s.ID, Type: models.ExecFrameOpen, Request: &req})
+	return ss.output, nil
+}
+
+func (m *memoryExec) SendFrame(id string, frame models.ExecFrame) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s, ok := m.sessions[id]
+	if !ok {
+		return common.Error(common.ErrResourceNotFound, common.Field("type", "session"), common.Field("name", id))
+	}
+	frame.Session = id
+	m.push(s.Namespace, s.Node, frame)
+	return nil
+}
+
+func (m *memoryExec) CloseSession(id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil
+	}
+	delete(m.sessions, id)
+	close(s.output)
+	m.push(s.Namespace, s.Node, models.ExecFrame{Session: id, Type: models.ExecFrameClose})
+	return nil
+}
+
+func (m *memoryExec) SyncFrames(namespace, node string, frames []models.ExecFrame, timeout time.Duration) ([]models.ExecFrame, error) {
+	m.mutex.Lock()
+	for _, f := range frames {
+		s, ok := m.sessions[f.Session]
+		// the node is only allowed to write to its own sessions
+		if !ok || s.Namespace != namespace || s.Node != node {
+			continue
+		}
+		select {
+		case s.output <- f:
+		default:
+			log.L().Warn("exec output is dropped since the session is slow", log.Any("session", f.Session))
+		}
+	}
+	q := m.getQueue(namespace, node)
+	if len(q.frames) > 0 || timeout <= 0 {
+		res := m.pop(namespace, node)
+		m.mutex.Unlock()
+		return res, nil
+	}
+	notify := q.notify
+	m.mutex.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-notify:
+	case <-timer.C:
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.pop(namespace, node), nil
+}
+
+func (m *memoryExec) Close() error {
+	return nil
+}
+
+func (m *memoryExec) getQueue(namespace, node string) *queue {
+	key := namespace + "/" + node
+	q, ok := m.queues[key]
+	if !ok {
+		q = &queue{notify: make(chan struct{}, 1)}
+		m.queues[key] = q
+	}
+	return q
+}
+
+func (m *memoryExec) push(namespace, node string, frame models.ExecFrame) {
+	q := m.getQueue(namespace, node)
+	q.frames = append(q.frames, frame)
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// pop drains the queue, which is dropped if there is no session on the node
+func (m *memoryExec) pop(namespace, node string) []models.ExecFrame {
+	key := namespace + "/" + node
+	q, ok := m.queues[key]
+	if !ok {
+		return nil
+	}
+	res := q.frames
+	q.frames = nil
+	for _, s := range m.sessions {
+		if s.Namespace == namespace && s.Node == node {
+			return res
+		}
+	}
+	delete(m.queues, key)
+	return res
+}
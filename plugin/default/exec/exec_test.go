@@ -0,0 +1,93 @@
+package exec
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestMemoryExec(t *testing.T) {
+	p, err := New()
+	assert.NoError(t, err)
+	e := p.(plugin.Exec)
+	defer e.Close()
+
+	s := &models.ExecSession{
+		ID:          "s1",
+		Namespace:   "default",
+		Node:        "n1",
+		ExecRequest: models.ExecRequest{App: "app", Command: []string{"ls"}},
+	}
+	output, err := e.OpenSession(s)
+	assert.NoError(t, err)
+	_, err = e.OpenSession(s)
+	assert.Error(t, err)
+
+	err = e.SendFrame("s1", models.ExecFrame{Type: models.ExecFrameStdin, Data: []byte("a")})
+	assert.NoError(t, err)
+	err = e.SendFrame("s2", models.ExecFrame{Type: models.ExecFrameStdin})
+	assert.Error(t, err)
+
+	// the frames of other node are ignored
+	frames, err := e.SyncFrames("default", "n2", []models.ExecFrame{{Session: "s1", Type: models.ExecFrameStdout, Data: []byte("x")}}, 0)
+	assert.NoError(t, err)
+	assert.Len(t, frames, 0)
+
+	frames, err = e.SyncFrames("default", "n1", []models.ExecFrame{{Session: "s1", Type: models.ExecFrameStdout, Data: []byte("b")}}, time.Second)
+	assert.NoError(t, err)
+	assert.Len(t, frames, 2)
+	assert.Equal(t, models.ExecFrameOpen, frames[0].Type)
+	assert.Equal(t, []string{"ls"}, frames[0].Request.Command)
+	assert.Equal(t, models.ExecFrameStdin, frames[1].Type)
+	assert.Equal(t, "s1", frames[1].Session)
+
+	f := <-output
+	assert.Equal(t, []byte("b"), f.Data)
+	assert.Len(t, output, 0)
+
+	// wait for new frames
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		assert.NoError(t, e.SendFrame("s1", models.ExecFrame{Type: models.ExecFrameResize, Rows: 24, Cols: 80}))
+	}()
+	frames, err = e.SyncFrames("default", "n1", nil, 5*time.Second)
+	assert.NoError(t, err)
+	assert.Len(t, frames, 1)
+	assert.Equal(t, models.ExecFrameResize, frames[0].Type)
+
+	// timeout
+	start := time.Now()
+	frames, err = e.SyncFrames("default", "n1", nil, 100*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Len(t, frames, 0)
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+
+	assert.NoError(t, e.CloseSession("s1"))
+	assert.NoError(t, e.CloseSession("s1"))
+	_, ok := <-output
+	assert.False(t, ok)
+	frames, err = e.SyncFrames("default", "n1", nil, 0)
+	assert.NoError(t, err)
+	assert.Len(t, frames, 1)
+	assert.Equal(t, models.ExecFrameClose, frames[0].Type)
+}
+
+func TestMemoryExecLimit(t *testing.T) {
+	p, err := New()
+	assert.NoError(t, err)
+	e := p.(plugin.Exec)
+
+	for i := 0; i < MaxSessionsPerNode; i++ {
+		_, err = e.OpenSession(&models.ExecSession{ID: strconv.Itoa(i), Namespace: "default", Node: "n1"})
+		assert.NoError(t, err)
+	}
+	_, err = e.OpenSession(&models.ExecSession{ID: "x", Namespace: "default", Node: "n1"})
+	assert.Error(t, err)
+	_, err = e.OpenSession(&models.ExecSession{ID: "x", Namespace: "default", Node: "n2"})
+	assert.NoError(t, err)
+}
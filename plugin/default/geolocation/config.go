@@ -0,0 +1,15 @@
+package geolocation
+
+type CloudConfig struct {
+	Geolocation struct {
+		// Networks the locations of the networks the devices register from, the first one containing the ip wins
+		Networks []Network `yaml:"networks" json:"networks"`
+	} `yaml:"defaultgeolocation" json:"defaultgeolocation"`
+}
+
+type Network struct {
+	CIDR    string `yaml:"cidr" json:"cidr" validate:"required"`
+	Country string `yaml:"country" json:"country"`
+	Region  string `yaml:"region" json:"region"`
+	City    string `yaml:"city" json:"city"`
+}
@@ -0,0 +1,66 @@
+package geolocation
+
+import (
+	"net"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func init() {
+	plugin.RegisterFactory("defaultgeolocation", New)
+}
+
+type network struct {
+	ipnet    *net.IPNet
+	location models.Geolocation
+}
+
+// networkGeolocation locates the ips by the networks configured, such as the networks of the sites of factory
+type networkGeolocation struct {
+	networks []network
+}
+
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newNetworkGeolocation(cfg)
+}
+
+func newNetworkGeolocation(cfg CloudConfig) (*networkGeolocation, error) {
+	g := &networkGeolocation{}
+	for _, n := range cfg.Geolocation.Networks {
+		_, ipnet, err := net.ParseCIDR(n.CIDR)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		g.networks = append(g.networks, network{
+			ipnet:    ipnet,
+			location: models.Geolocation{Country: n.Country, Region: n.Region, City: n.City},
+		})
+	}
+	return g, nil
+}
+
+func (g *networkGeolocation) Locate(ip string) (*models.Geolocation, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return nil, nil
+	}
+	for _, n := range g.networks {
+		if n.ipnet.Contains(addr) {
+			location := n.location
+			return &location, nil
+		}
+	}
+	return nil, nil
+}
+
+func (g *networkGeolocation) Close() error {
+	return nil
+}
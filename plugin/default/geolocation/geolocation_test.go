@@ -0,0 +1,42 @@
+package geolocation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNetworkGeolocation(t *testing.T) {
+	var cfg CloudConfig
+	cfg.Geolocation.Networks = []Network{
+		{CIDR: "10.1.0.0/16", Country: "CN", Region: "beijing", City: "beijing"},
+		{CIDR: "10.0.0.0/8", Country: "CN", Region: "shanghai"},
+		{CIDR: "2001:db8::/32", Country: "DE", Region: "bavaria"},
+	}
+	g, err := newNetworkGeolocation(cfg)
+	assert.NoError(t, err)
+
+	res, err := g.Locate("10.1.2.3")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Geolocation{Country: "CN", Region: "beijing", City: "beijing"}, res)
+	res, err = g.Locate("10.2.2.3")
+	assert.NoError(t, err)
+	assert.Equal(t, "shanghai", res.Region)
+	res, err = g.Locate("2001:db8::1")
+	assert.NoError(t, err)
+	assert.Equal(t, "bavaria", res.Region)
+
+	// the unknown ips are not located
+	res, err = g.Locate("192.168.1.1")
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+	res, err = g.Locate("unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	cfg.Geolocation.Networks = []Network{{CIDR: "10.1.0.0"}}
+	_, err = newNetworkGeolocation(cfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,8 @@
+package rotator
+
+type CloudConfig struct {
+	DefaultRotator struct {
+		// Length the number of random bytes of the value generated for each key
+		Length int `yaml:"length" json:"length" default:"32"`
+	} `yaml:"defaultrotator" json:"defaultrotator"`
+}
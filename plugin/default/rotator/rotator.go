@@ -0,0 +1,52 @@
+package rotator
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// defaultRotator replaces the values of secret with random hex strings, such as passwords and tokens
+type defaultRotator struct {
+	length int
+}
+
+func init() {
+	plugin.RegisterFactory("defaultrotator", New)
+}
+
+// New New
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, err
+	}
+	return &defaultRotator{length: cfg.DefaultRotator.Length}, nil
+}
+
+func (r *defaultRotator) Rotate(secret *specV1.Secret, keys []string) (map[string][]byte, error) {
+	if len(keys) == 0 {
+		for k := range secret.Data {
+			keys = append(keys, k)
+		}
+	}
+	res := map[string][]byte{}
+	for _, k := range keys {
+		buf := make([]byte, r.length)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, errors.Trace(err)
+		}
+		res[k] = []byte(hex.EncodeToString(buf))
+	}
+	return res, nil
+}
+
+// Close Close
+func (r *defaultRotator) Close() error {
+	return nil
+}
@@ -0,0 +1,34 @@
+package rotator
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultRotator(t *testing.T) {
+	r := &defaultRotator{length: 16}
+	secret := &specV1.Secret{
+		Name: "db",
+		Data: map[string][]byte{"username": []byte("baetyl"), "password": []byte("old")},
+	}
+
+	res, err := r.Rotate(secret, []string{"password"})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Len(t, res["password"], 32)
+	assert.NotEqual(t, "old", string(res["password"]))
+
+	again, err := r.Rotate(secret, []string{"password"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, res["password"], again["password"])
+
+	// all keys are rotated if none is listed
+	res, err = r.Rotate(secret, nil)
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, []byte("old"), secret.Data["password"])
+
+	assert.NoError(t, r.Close())
+}
@@ -0,0 +1,17 @@
+package plugin
+
+import (
+	"io"
+)
+
+//go:generate mockgen -destination=../mock/plugin/desirewatch.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin DesireWatch
+
+// DesireWatch notifies the nodes watching once their desires are changed, so that they synchronize without waiting for the next report
+type DesireWatch interface {
+	// Watch registers the watch of node, the returned channel receives a value once the desire of node is changed,
+	// the notifications are merged if the watcher is slow. The returned function stops the watch
+	Watch(namespace, node string) (<-chan struct{}, func(), error)
+	// Notify notifies the watches of the nodes
+	Notify(namespace string, nodes []string) error
+	io.Closer
+}
@@ -0,0 +1,20 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/edgecluster.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin EdgeCluster
+
+// EdgeCluster stores the multi-node clusters at the edge
+type EdgeCluster interface {
+	GetEdgeCluster(namespace, name string) (*models.EdgeCluster, error)
+	ListEdgeCluster(namespace string, filter *models.Filter) ([]models.EdgeCluster, error)
+	CountEdgeCluster(namespace, name string) (int, error)
+	CreateEdgeCluster(cluster *models.EdgeCluster) error
+	UpdateEdgeCluster(cluster *models.EdgeCluster) error
+	DeleteEdgeCluster(namespace, name string) error
+	io.Closer
+}
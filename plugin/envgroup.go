@@ -0,0 +1,19 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/envgroup.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin EnvGroup
+
+// EnvGroup stores the groups of environment variables shared by applications
+type EnvGroup interface {
+	GetEnvGroup(namespace, name string) (*models.EnvGroup, error)
+	ListEnvGroup(namespace string) ([]models.EnvGroup, error)
+	CreateEnvGroup(group *models.EnvGroup) error
+	UpdateEnvGroup(group *models.EnvGroup) error
+	DeleteEnvGroup(namespace, name string) error
+	io.Closer
+}
@@ -0,0 +1,24 @@
+package plugin
+
+import (
+	"io"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/exec.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin Exec
+
+// Exec relays the frames of exec sessions between users and nodes
+type Exec interface {
+	// OpenSession registers the session and queues the open frame to the node, the output frames are sent to the returned channel
+	OpenSession(session *models.ExecSession) (<-chan models.ExecFrame, error)
+	// SendFrame queues the frame of session to the node
+	SendFrame(id string, frame models.ExecFrame) error
+	// CloseSession queues the close frame to the node and closes the output channel
+	CloseSession(id string) error
+	// SyncFrames dispatches the output frames reported by node, and returns the queued frames of node,
+	// it waits at most timeout for new frames if there is none
+	SyncFrames(namespace, node string, frames []models.ExecFrame, timeout time.Duration) ([]models.ExecFrame, error)
+	io.Closer
+}
@@ -0,0 +1,19 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/featureflag.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin FeatureFlag
+
+// FeatureFlag stores the feature flags along with their overrides of namespaces
+type FeatureFlag interface {
+	GetFeatureFlag(name string) (*models.FeatureFlag, error)
+	ListFeatureFlag() ([]models.FeatureFlag, error)
+	CreateFeatureFlag(flag *models.FeatureFlag) error
+	UpdateFeatureFlag(flag *models.FeatureFlag) error
+	DeleteFeatureFlag(name string) error
+	io.Closer
+}
@@ -0,0 +1,20 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/functionbuild.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin FunctionBuild
+
+// FunctionBuild stores the builds of function versions, one for each version
+type FunctionBuild interface {
+	GetFunctionBuild(namespace, name, version string) (*models.FunctionBuild, error)
+	// ListFunctionBuild lists the builds of the versions of function, the latest created first
+	ListFunctionBuild(namespace, name string) ([]models.FunctionBuild, error)
+	CreateFunctionBuild(build *models.FunctionBuild) error
+	UpdateFunctionBuild(build *models.FunctionBuild) error
+	DeleteFunctionBuild(namespace, name, version string) error
+	io.Closer
+}
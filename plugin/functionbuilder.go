@@ -0,0 +1,19 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/functionbuilder.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin FunctionBuilder
+
+// FunctionBuilder builds and pushes the images of function code, such as the kaniko jobs in the cloud cluster
+// or a hosted build service
+type FunctionBuilder interface {
+	// StartBuild starts the build asynchronously and returns the id of build
+	StartBuild(req *models.FunctionBuildRequest) (string, error)
+	// GetBuildStatus returns the status of build, which is building, succeeded or failed
+	GetBuildStatus(id string) (*models.FunctionBuildStatus, error)
+	io.Closer
+}
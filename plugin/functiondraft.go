@@ -0,0 +1,25 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/functiondraft.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin FunctionDraft
+
+// FunctionDraft stores the drafts of function code and the versions published from them
+type FunctionDraft interface {
+	GetFunctionDraft(namespace, name string) (*models.FunctionDraft, error)
+	// ListFunctionDraft lists the drafts of namespace without the contents of files
+	ListFunctionDraft(namespace string) ([]models.FunctionDraft, error)
+	CreateFunctionDraft(draft *models.FunctionDraft) error
+	UpdateFunctionDraft(draft *models.FunctionDraft) error
+	DeleteFunctionDraft(namespace, name string) error
+
+	GetFunctionVersion(namespace, name, version string) (*models.FunctionVersion, error)
+	// ListFunctionVersion lists the versions of function, the latest published first
+	ListFunctionVersion(namespace, name string) ([]models.FunctionVersion, error)
+	CreateFunctionVersion(version *models.FunctionVersion) error
+	io.Closer
+}
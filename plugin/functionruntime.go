@@ -0,0 +1,19 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/functionruntime.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin FunctionRuntime
+
+// FunctionRuntime stores the runtimes of functions registered by admins, which are shared by all namespaces
+type FunctionRuntime interface {
+	GetFunctionRuntime(name string) (*models.FunctionRuntime, error)
+	ListFunctionRuntime() ([]models.FunctionRuntime, error)
+	CreateFunctionRuntime(runtime *models.FunctionRuntime) error
+	UpdateFunctionRuntime(runtime *models.FunctionRuntime) error
+	DeleteFunctionRuntime(name string) error
+	io.Closer
+}
@@ -0,0 +1,15 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/functionsandbox.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin FunctionSandbox
+
+// FunctionSandbox runs the code of functions in the cloud to test them before they are published
+type FunctionSandbox interface {
+	Invoke(req *models.FunctionInvokeRequest) (*models.FunctionInvokeResult, error)
+	io.Closer
+}
@@ -0,0 +1,20 @@
+package plugin
+
+import (
+	"io"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/garbage.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin Garbage
+
+// Garbage lists the records of storage which may be left without owners, the scanner decides whether they are
+type Garbage interface {
+	// ListGarbageShadow lists the shadows of all namespaces, the ones of the nodes deleted are garbage
+	ListGarbageShadow() ([]models.GarbageItem, error)
+	// ListGarbageCert lists the certificates issued by the root expired before the time, the ones no secret
+	// references are garbage
+	ListGarbageCert(before time.Time) ([]models.GarbageItem, error)
+	io.Closer
+}
@@ -0,0 +1,16 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/geolocation.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin Geolocation
+
+// Geolocation locates the ips of the devices registering, the location is nil if the ip is unknown
+type Geolocation interface {
+	Locate(ip string) (*models.Geolocation, error)
+
+	io.Closer
+}
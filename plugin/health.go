@@ -0,0 +1,18 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/health.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin HealthThreshold
+
+// HealthThreshold stores the node health thresholds of namespaces
+type HealthThreshold interface {
+	GetHealthThreshold(namespace string) (*models.HealthThreshold, error)
+	CreateHealthThreshold(threshold *models.HealthThreshold) error
+	UpdateHealthThreshold(threshold *models.HealthThreshold) error
+	DeleteHealthThreshold(namespace string) error
+	io.Closer
+}
@@ -0,0 +1,25 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/heartbeat.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin Heartbeat
+
+// Heartbeat stores the heartbeat policies of namespaces and the online and offline events of nodes
+type Heartbeat interface {
+	GetHeartbeatPolicy(namespace string) (*models.HeartbeatPolicy, error)
+	CreateHeartbeatPolicy(policy *models.HeartbeatPolicy) error
+	UpdateHeartbeatPolicy(policy *models.HeartbeatPolicy) error
+	DeleteHeartbeatPolicy(namespace string) error
+
+	CreateNodeEvent(event *models.NodeEvent) error
+	// GetLatestNodeEvent returns the latest event of node, nil is returned if there is no event
+	GetLatestNodeEvent(namespace, node string) (*models.NodeEvent, error)
+	// ListNodeEvent lists the events of namespace ordered by time descending
+	ListNodeEvent(namespace string, filter *models.NodeEventFilter) ([]models.NodeEvent, error)
+	CountNodeEvent(namespace string, filter *models.NodeEventFilter) (int, error)
+	io.Closer
+}
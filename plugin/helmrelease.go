@@ -0,0 +1,19 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/helmrelease.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin HelmRelease
+
+// HelmRelease stores the releases of helm charts with their charts and values
+type HelmRelease interface {
+	GetHelmRelease(namespace, name string) (*models.HelmRelease, error)
+	ListHelmRelease(namespace string) ([]models.HelmRelease, error)
+	CreateHelmRelease(release *models.HelmRelease) error
+	UpdateHelmRelease(release *models.HelmRelease) error
+	DeleteHelmRelease(namespace, name string) error
+	io.Closer
+}
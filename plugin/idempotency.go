@@ -0,0 +1,20 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/idempotency.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin Idempotency
+
+// Idempotency stores the responses of requests with Idempotency-Key
+type Idempotency interface {
+	GetIdempotencyRecord(namespace, key string) (*models.IdempotencyRecord, error)
+	// CreateIdempotencyRecord returns ErrResourceConflict if the key of namespace exists
+	CreateIdempotencyRecord(record *models.IdempotencyRecord) error
+	UpdateIdempotencyRecord(record *models.IdempotencyRecord) error
+	DeleteIdempotencyRecord(namespace, key string) error
+	DeleteExpiredIdempotencyRecords() error
+	io.Closer
+}
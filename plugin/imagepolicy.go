@@ -0,0 +1,18 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/imagepolicy.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin ImagePolicy
+
+// ImagePolicy stores the image policies of namespaces
+type ImagePolicy interface {
+	GetImagePolicy(namespace string) (*models.ImagePolicy, error)
+	CreateImagePolicy(policy *models.ImagePolicy) error
+	UpdateImagePolicy(policy *models.ImagePolicy) error
+	DeleteImagePolicy(namespace string) error
+	io.Closer
+}
@@ -0,0 +1,19 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/installtemplate.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin InstallTemplate
+
+// InstallTemplate stores the install templates overridden in namespaces
+type InstallTemplate interface {
+	GetInstallTemplate(namespace, name string) (*models.InstallTemplate, error)
+	ListInstallTemplate(namespace string) ([]models.InstallTemplate, error)
+	CreateInstallTemplate(tpl *models.InstallTemplate) error
+	UpdateInstallTemplate(tpl *models.InstallTemplate) error
+	DeleteInstallTemplate(namespace, name string) error
+	io.Closer
+}
@@ -0,0 +1,24 @@
+package kaniko
+
+import "time"
+
+// CloudConfig baetyl-cloud config
+type CloudConfig struct {
+	Kaniko struct {
+		// Namespace the namespace of the cloud cluster where the build jobs run
+		Namespace string `yaml:"namespace" json:"namespace" default:"baetyl-cloud"`
+		Image     string `yaml:"image" json:"image" default:"gcr.io/kaniko-project/executor:v1.9.1"`
+		// InitImage downloads and unpacks the code zip into the build context, which has wget and unzip
+		InitImage string `yaml:"initImage" json:"initImage" default:"busybox:1.35"`
+		// PushSecret the secret in Namespace whose config.json is the docker config to push images with
+		PushSecret string `yaml:"pushSecret" json:"pushSecret"`
+		// Insecure pushes the images to the registry over http
+		Insecure bool `yaml:"insecure" json:"insecure"`
+		// Timeout the build job is failed if it runs longer
+		Timeout time.Duration `yaml:"timeout" json:"timeout" default:"30m"`
+		// TTL the time the finished build jobs are kept
+		TTL        time.Duration `yaml:"ttl" json:"ttl" default:"24h"`
+		OutCluster bool          `yaml:"outCluster" json:"outCluster"`
+		ConfigPath string        `yaml:"configPath" json:"configPath" default:"etc/baetyl/kubeconfig.yml"`
+	} `yaml:"kaniko" json:"kaniko"`
+}
@@ -0,0 +1,197 @@
+package kaniko
+
+import (
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedbatchv1 "k8s.io/client-go/kubernetes/typed/batch/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+const (
+	labelNamespace = "baetyl-function-namespace"
+	labelFunction  = "baetyl-function"
+	labelVersion   = "baetyl-function-version"
+	workspaceDir   = "/workspace"
+	// the script of init container unpacking the code zip and writing the dockerfile out of the build context
+	initScript = `wget -q -O /tmp/code.zip "$CODE_URL" && mkdir -p /workspace/code && unzip -q /tmp/code.zip -d /workspace/code && printf '%s' "$DOCKERFILE" > /workspace/Dockerfile`
+)
+
+// kanikoBuilder builds the images of function code by the kaniko jobs in the cloud cluster
+type kanikoBuilder struct {
+	cfg  CloudConfig
+	jobs typedbatchv1.JobInterface
+}
+
+func init() {
+	plugin.RegisterFactory("kaniko", New)
+}
+
+// New New
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	kubeConfig, err := func() (*rest.Config, error) {
+		if !cfg.Kaniko.OutCluster {
+			return rest.InClusterConfig()
+		}
+		return clientcmd.BuildConfigFromFlags("", cfg.Kaniko.ConfigPath)
+	}()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &kanikoBuilder{
+		cfg:  cfg,
+		jobs: kubeClient.BatchV1().Jobs(cfg.Kaniko.Namespace),
+	}, nil
+}
+
+// StartBuild creates the job building the image, the name of job is the id of build
+func (k *kanikoBuilder) StartBuild(req *models.FunctionBuildRequest) (string, error) {
+	job, err := k.newJob(req)
+	if err != nil {
+		return "", err
+	}
+	if _, err = k.jobs.Create(job); err != nil {
+		return "", errors.Trace(err)
+	}
+	return job.Name, nil
+}
+
+// GetBuildStatus returns the status of the build job, the job deleted before it finished is failed
+func (k *kanikoBuilder) GetBuildStatus(id string) (*models.FunctionBuildStatus, error) {
+	job, err := k.jobs.Get(id, metav1.GetOptions{})
+	if err != nil {
+		if kubeerrors.IsNotFound(err) {
+			return &models.FunctionBuildStatus{Status: models.FunctionBuildFailed, Message: "the build job " + id + " is not found"}, nil
+		}
+		return nil, errors.Trace(err)
+	}
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return &models.FunctionBuildStatus{Status: models.FunctionBuildSucceeded}, nil
+		case batchv1.JobFailed:
+			msg := c.Message
+			if msg == "" {
+				msg = c.Reason
+			}
+			return &models.FunctionBuildStatus{Status: models.FunctionBuildFailed, Message: msg}, nil
+		}
+	}
+	if job.Status.Succeeded > 0 {
+		return &models.FunctionBuildStatus{Status: models.FunctionBuildSucceeded}, nil
+	}
+	return &models.FunctionBuildStatus{Status: models.FunctionBuildRunning}, nil
+}
+
+// Close Close
+func (k *kanikoBuilder) Close() error {
+	return nil
+}
+
+func (k *kanikoBuilder) newJob(req *models.FunctionBuildRequest) (*batchv1.Job, error) {
+	if req.CodeURL == "" || req.Image == "" || req.Dockerfile == "" {
+		return nil, errors.Errorf("the code url, image and dockerfile of build are required")
+	}
+	backoff := int32(0)
+	deadline := int64(k.cfg.Kaniko.Timeout.Seconds())
+	ttl := int32(k.cfg.Kaniko.TTL.Seconds())
+	args := []string{
+		"--context=dir://" + workspaceDir + "/code",
+		"--dockerfile=" + workspaceDir + "/Dockerfile",
+		"--destination=" + req.Image,
+	}
+	if k.cfg.Kaniko.Insecure {
+		args = append(args, "--insecure", "--skip-tls-verify")
+	}
+	volumes := []corev1.Volume{{Name: "workspace", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	mounts := []corev1.VolumeMount{{Name: "workspace", MountPath: workspaceDir}}
+	kanikoMounts := mounts
+	if k.cfg.Kaniko.PushSecret != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "docker-config",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName: k.cfg.Kaniko.PushSecret,
+				Items:      []corev1.KeyToPath{{Key: "config.json", Path: "config.json"}},
+			}},
+		})
+		kanikoMounts = append([]corev1.VolumeMount{}, mounts...)
+		kanikoMounts = append(kanikoMounts, corev1.VolumeMount{Name: "docker-config", MountPath: "/kaniko/.docker"})
+	}
+
+	labels := map[string]string{
+		common.LabelSystem: "true",
+		labelNamespace:     labelValue(req.Namespace),
+		labelFunction:      labelValue(req.Name),
+		labelVersion:       labelValue(req.Version),
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "baetyl-function-build-" + common.RandString(10),
+			Namespace: k.cfg.Kaniko.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoff,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					InitContainers: []corev1.Container{{
+						Name:    "code",
+						Image:   k.cfg.Kaniko.InitImage,
+						Command: []string{"sh", "-c", initScript},
+						Env: []corev1.EnvVar{
+							{Name: "CODE_URL", Value: req.CodeURL},
+							{Name: "DOCKERFILE", Value: req.Dockerfile},
+						},
+						VolumeMounts: mounts,
+					}},
+					Containers: []corev1.Container{{
+						Name:         "kaniko",
+						Image:        k.cfg.Kaniko.Image,
+						Args:         args,
+						VolumeMounts: kanikoMounts,
+					}},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+	if deadline > 0 {
+		job.Spec.ActiveDeadlineSeconds = &deadline
+	}
+	if ttl <= 0 {
+		job.Spec.TTLSecondsAfterFinished = nil
+	}
+	return job, nil
+}
+
+// labelValue truncates the value to the max length of label value of kubernetes
+func labelValue(v string) string {
+	if len(v) > 63 {
+		v = v[:63]
+	}
+	return strings.Trim(v, "._-")
+}
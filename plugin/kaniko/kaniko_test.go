@@ -0,0 +1,74 @@
+package kaniko
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestKanikoBuild(t *testing.T) {
+	var cfg CloudConfig
+	cfg.Kaniko.Namespace = "baetyl-cloud"
+	cfg.Kaniko.Image = "gcr.io/kaniko-project/executor:v1.9.1"
+	cfg.Kaniko.InitImage = "busybox:1.35"
+	cfg.Kaniko.PushSecret = "push-secret"
+	cfg.Kaniko.Insecure = true
+	cfg.Kaniko.Timeout = time.Minute * 30
+	k := &kanikoBuilder{cfg: cfg, jobs: fake.NewSimpleClientset().BatchV1().Jobs(cfg.Kaniko.Namespace)}
+
+	_, err := k.StartBuild(&models.FunctionBuildRequest{Name: "infer", Version: "v1"})
+	assert.Error(t, err)
+
+	req := &models.FunctionBuildRequest{
+		Namespace:  "default",
+		Name:       "infer",
+		Version:    "v1",
+		CodeURL:    "http://minio/code.zip",
+		Dockerfile: "FROM python:3\nCOPY . /var/lib/baetyl/code/\n",
+		Image:      "harbor.local/functions/default/infer:v1",
+	}
+	id, err := k.StartBuild(req)
+	assert.NoError(t, err)
+	job, err := k.jobs.Get(id, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "infer", job.Labels[labelFunction])
+	assert.Equal(t, int64(1800), *job.Spec.ActiveDeadlineSeconds)
+	assert.Nil(t, job.Spec.TTLSecondsAfterFinished)
+	pod := job.Spec.Template.Spec
+	assert.Equal(t, []corev1.EnvVar{{Name: "CODE_URL", Value: req.CodeURL}, {Name: "DOCKERFILE", Value: req.Dockerfile}}, pod.InitContainers[0].Env)
+	assert.Len(t, pod.InitContainers[0].VolumeMounts, 1)
+	assert.Contains(t, pod.Containers[0].Args, "--destination="+req.Image)
+	assert.Contains(t, pod.Containers[0].Args, "--insecure")
+	assert.Len(t, pod.Containers[0].VolumeMounts, 2)
+	assert.Equal(t, "push-secret", pod.Volumes[1].Secret.SecretName)
+
+	status, err := k.GetBuildStatus(id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.FunctionBuildRunning, status.Status)
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded"}}
+	_, err = k.jobs.UpdateStatus(job)
+	assert.NoError(t, err)
+	status, err = k.GetBuildStatus(id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.FunctionBuildFailed, status.Status)
+	assert.Equal(t, "DeadlineExceeded", status.Message)
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	_, err = k.jobs.UpdateStatus(job)
+	assert.NoError(t, err)
+	status, err = k.GetBuildStatus(id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.FunctionBuildSucceeded, status.Status)
+
+	status, err = k.GetBuildStatus("none")
+	assert.NoError(t, err)
+	assert.Equal(t, models.FunctionBuildFailed, status.Status)
+}
@@ -2,6 +2,8 @@ package kube
 
 import (
 	"fmt"
+	"math"
+	"time"
 
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 	"github.com/baetyl/baetyl-go/v2/utils"
@@ -94,6 +96,16 @@ func fromListOptionsModel(listOptions *models.ListOptions) *metav1.ListOptions {
 	if err != nil {
 		panic(fmt.Sprintf("copier exception: %s", err.Error()))
 	}
+	// the list request is aborted by client-go when the deadline of request exceeds
+	if listOptions != nil {
+		if deadline, ok := listOptions.Context().Deadline(); ok {
+			seconds := int64(math.Ceil(time.Until(deadline).Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+			res.TimeoutSeconds = &seconds
+		}
+	}
 	return res
 }
 
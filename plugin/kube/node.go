@@ -214,6 +214,16 @@ func (c *client) ListNode(tx interface{}, namespace string, listOptions *models.
 	}
 	listOptions.Continue = list.Continue
 	res := toNodeListModel(list)
+	if !listOptions.NodeQuery.IsEmpty() {
+		items := res.Items[:0]
+		for i := range res.Items {
+			if listOptions.NodeQuery.Match(&res.Items[i]) {
+				items = append(items, res.Items[i])
+			}
+		}
+		res.Items = items
+		res.Total = len(items)
+	}
 	res.ListOptions = listOptions
 	return res, nil
 }
@@ -3,6 +3,7 @@ package kube
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/baetyl/baetyl-go/v2/log"
 
@@ -279,6 +280,23 @@ func TestListNode(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestListNodeWithQuery(t *testing.T) {
+	c := initNodeClient()
+	list, err := c.ListNode(nil, "default", &models.ListOptions{})
+	assert.NoError(t, err)
+	total := list.Total
+	assert.True(t, total > 0)
+
+	list, err = c.ListNode(nil, "default", &models.ListOptions{NodeQuery: models.NodeQuery{CreatedBefore: time.Now().Add(time.Hour)}})
+	assert.NoError(t, err)
+	assert.Equal(t, total, list.Total)
+
+	list, err = c.ListNode(nil, "default", &models.ListOptions{NodeQuery: models.NodeQuery{MinVersion: "v2.2.0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, list.Total)
+	assert.Len(t, list.Items, 0)
+}
+
 func TestUpdateNodeDesire(t *testing.T) {
 	c := initNodeClient()
 	namespace := "default"
@@ -1,6 +1,8 @@
 package httplink
 
 import (
+	"time"
+
 	"github.com/baetyl/baetyl-cloud/v2/config"
 )
 
@@ -11,4 +13,7 @@ type CloudConfig struct {
 type HTTPLinkConfig struct {
 	config.Server `yaml:",inline" json:",inline"`
 	CommonName    string `yaml:"commonName" json:"commonName" default:"common-name"`
+	// WatchPingInterval the interval of pinging the websocket connections of watches, the connection is closed
+	// if node doesn't respond in two intervals
+	WatchPingInterval time.Duration `yaml:"watchPingInterval" json:"watchPingInterval" default:"30s"`
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 	"github.com/baetyl/baetyl-cloud/v2/plugin"
 	"github.com/baetyl/baetyl-cloud/v2/server"
 )
@@ -112,6 +113,9 @@ func (l *httpLink) initRouter() {
 		sync := v1.Group("/sync")
 		sync.POST("/report", common.Wrapper(l.wrapper(specV1.MessageReport)))
 		sync.POST("/desire", common.Wrapper(l.wrapper(specV1.MessageDesire)))
+		sync.POST("/desirepatch", common.Wrapper(l.wrapper(models.MessageDesirePatch)))
+		sync.POST("/exec", common.Wrapper(l.wrapper(models.MessageExec)))
+		sync.GET("/watch", common.WrapperNative(l.watch, true))
 	}
 }
 
@@ -1,8 +1,10 @@
 package httplink
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
+	nethttp "net/http"
 	"os"
 	"path"
 	"testing"
@@ -10,9 +12,11 @@ import (
 
 	"github.com/baetyl/baetyl-go/v2/http"
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 	"github.com/baetyl/baetyl-cloud/v2/plugin"
 	"github.com/baetyl/baetyl-cloud/v2/server"
 )
@@ -48,6 +52,8 @@ type handler struct {
 	t            *testing.T
 	expReportMsg specV1.Message
 	expDesireMsg specV1.Message
+	push         chan *specV1.Message
+	stopped      chan struct{}
 }
 
 func (h *handler) report(m specV1.Message) (*specV1.Message, error) {
@@ -66,6 +72,28 @@ func (h *handler) desire(m specV1.Message) (*specV1.Message, error) {
 	return &desiretMsg, nil
 }
 
+func (h *handler) watch(m specV1.Message) (<-chan *specV1.Message, func(), error) {
+	assert.Equal(h.t, "default", m.Metadata["namespace"])
+	assert.Equal(h.t, "test", m.Metadata["name"])
+	return h.push, func() { close(h.stopped) }, nil
+}
+
+func (h *handler) exec(m specV1.Message) (*specV1.Message, error) {
+	var req models.ExecSync
+	err := m.Content.Unmarshal(&req)
+	assert.NoError(h.t, err)
+	assert.Equal(h.t, "default", m.Metadata["namespace"])
+	assert.Equal(h.t, "test", m.Metadata["name"])
+	res := &specV1.Message{Kind: models.MessageExec, Metadata: map[string]string{}, Content: specV1.LazyValue{Value: req}}
+	if sig, _ := models.SyncSignatureFromMetadata(m.Metadata); sig != nil {
+		sig.ToMetadata(res.Metadata)
+	}
+	if v := m.Metadata[models.SyncProtocolVersion]; v != "" {
+		res.Metadata[models.SyncProtocolVersion] = v
+	}
+	return res, nil
+}
+
 func TestNewHTTPLink(t *testing.T) {
 	cfg := &CloudConfig{}
 	common.SetConfFile(path.Join(genHTTPLinkConf(t), "config.yml"))
@@ -86,10 +114,14 @@ func TestNewHTTPLink(t *testing.T) {
 		t:            t,
 		expReportMsg: reportMsg,
 		expDesireMsg: desiretMsg,
+		push:         make(chan *specV1.Message, 1),
+		stopped:      make(chan struct{}),
 	}
 
 	link.AddMsgRouter(string(specV1.MessageReport), server.HandlerMessage(handler.report))
 	link.AddMsgRouter(string(specV1.MessageDesire), server.HandlerMessage(handler.desire))
+	link.AddMsgRouter(string(models.MessageExec), server.HandlerMessage(handler.exec))
+	link.AddMsgRouter(string(models.MessageDesireWatch), server.HandlerWatch(handler.watch))
 
 	go link.Start()
 
@@ -128,6 +160,46 @@ func TestNewHTTPLink(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, desiretMsg.Content.Value, desireResp)
 
+	// exec
+	execReq := models.ExecSync{Frames: []models.ExecFrame{{Session: "s1", Type: models.ExecFrameStdout, Data: []byte("a")}}}
+	dt, err = json.Marshal(execReq)
+	assert.NoError(t, err)
+	resp, err = cli.PostJSON("v1/sync/exec", dt, map[string]string{"cn": "default.test"})
+	assert.NoError(t, err)
+
+	var execResp models.ExecSync
+	err = json.Unmarshal(resp, &execResp)
+	assert.NoError(t, err)
+	assert.EqualValues(t, execReq, execResp)
+
+	// the signature and protocol version of reply are set in headers
+	req, err := nethttp.NewRequest(nethttp.MethodPost, "http://0.0.0.0:9939/v1/sync/exec", bytes.NewReader(dt))
+	assert.NoError(t, err)
+	req.Header.Set("cn", "default.test")
+	req.Header.Set(models.SyncSignTimestamp, "1600000000")
+	req.Header.Set(models.SyncSignNonce, "n1")
+	req.Header.Set(models.SyncSignSignature, "sig")
+	req.Header.Set(models.SyncProtocolVersion, "3")
+	signed, err := nethttp.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	signed.Body.Close()
+	assert.Equal(t, nethttp.StatusOK, signed.StatusCode)
+	assert.Equal(t, "n1", signed.Header.Get(models.SyncSignNonce))
+	assert.Equal(t, "sig", signed.Header.Get(models.SyncSignSignature))
+	assert.Equal(t, "3", signed.Header.Get(models.SyncProtocolVersion))
+
+	// watch
+	conn, _, err := websocket.DefaultDialer.Dial("ws://0.0.0.0:9939/v1/sync/watch", nethttp.Header{"cn": []string{"default.test"}})
+	assert.NoError(t, err)
+	handler.push <- &specV1.Message{Kind: models.MessageDesireChanged, Metadata: map[string]string{"name": "test"}}
+	var push specV1.Message
+	err = conn.ReadJSON(&push)
+	assert.NoError(t, err)
+	assert.Equal(t, models.MessageDesireChanged, push.Kind)
+	assert.Equal(t, "test", push.Metadata["name"])
+	conn.Close()
+	<-handler.stopped
+
 	err = link.Close()
 	assert.NoError(t, err)
 }
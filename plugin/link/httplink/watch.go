@@ -0,0 +1,95 @@
+package httplink
+
+import (
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gorilla/websocket"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/server"
+)
+
+const watchWriteTimeout = 10 * time.Second
+
+var watchUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// watch upgrades the request of node to websocket and pushes the json encoded messages of the changes of its desire,
+// until node closes the connection or doesn't respond to the pings
+func (l *httpLink) watch(c *common.Context) (interface{}, error) {
+	ns, n := c.GetNamespace(), c.GetName()
+	if ns == "" || n == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid)
+	}
+	handler, ok := l.msgRouter[string(models.MessageDesireWatch)].(server.HandlerWatch)
+	if !ok {
+		return nil, common.Error(common.ErrRequestMethodNotFound)
+	}
+
+	msg := specV1.Message{
+		Kind:     models.MessageDesireWatch,
+		Metadata: map[string]string{},
+	}
+	for k := range c.Request.Header {
+		msg.Metadata[strings.ToLower(k)] = c.GetHeader(k)
+	}
+	msg.Metadata["name"] = n
+	msg.Metadata["namespace"] = ns
+	pushes, stop, err := handler(msg)
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+
+	conn, err := watchUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the response has been written by upgrader
+		log.L().Warn("failed to upgrade watch request", log.Any(c.GetTrace()), log.Error(err))
+		return nil, nil
+	}
+	defer conn.Close()
+
+	// the deadlines of server are replaced, the connection lives as long as node responds to the pings
+	interval := l.cfg.HTTPLink.WatchPingInterval
+	conn.SetReadDeadline(time.Now().Add(2 * interval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * interval))
+	})
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closed:
+			return nil, nil
+		case push, ok := <-pushes:
+			if !ok {
+				return nil, nil
+			}
+			conn.SetWriteDeadline(time.Now().Add(watchWriteTimeout))
+			if err = conn.WriteJSON(push); err != nil {
+				log.L().Warn("failed to push message to node", log.Any("namespace", ns), log.Any("name", n), log.Error(err))
+				return nil, nil
+			}
+		case <-ticker.C:
+			if err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(watchWriteTimeout)); err != nil {
+				return nil, nil
+			}
+		}
+	}
+}
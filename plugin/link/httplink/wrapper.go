@@ -6,6 +6,7 @@ import (
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 	"github.com/baetyl/baetyl-cloud/v2/server"
 )
 
@@ -41,9 +42,10 @@ func (l *httpLink) wrapper(tp specV1.MessageKind) common.HandlerFunc {
 			if err != nil {
 				return nil, err
 			}
+			setReplyHeaders(c, resp)
 			return resp.Content.Value, nil
 		}
-	case specV1.MessageDesire:
+	case specV1.MessageDesire, models.MessageDesirePatch:
 		return func(c *common.Context) (interface{}, error) {
 			ns := c.GetNamespace()
 			if ns == "" {
@@ -56,7 +58,7 @@ func (l *httpLink) wrapper(tp specV1.MessageKind) common.HandlerFunc {
 			}
 
 			msg := specV1.Message{
-				Kind:     specV1.MessageDesire,
+				Kind:     tp,
 				Content:  specV1.LazyValue{},
 				Metadata: map[string]string{},
 			}
@@ -68,11 +70,45 @@ func (l *httpLink) wrapper(tp specV1.MessageKind) common.HandlerFunc {
 			for k := range c.Request.Header {
 				msg.Metadata[strings.ToLower(k)] = c.GetHeader(k)
 			}
+			msg.Metadata["name"] = c.GetName()
 			msg.Metadata["namespace"] = ns
-			resp, err := l.msgRouter[string(specV1.MessageDesire)].(server.HandlerMessage)(msg)
+			resp, err := l.msgRouter[string(tp)].(server.HandlerMessage)(msg)
 			if err != nil {
 				return nil, err
 			}
+			setReplyHeaders(c, resp)
+			return resp.Content.Value, nil
+		}
+	case models.MessageExec:
+		return func(c *common.Context) (interface{}, error) {
+			ns, n := c.GetNamespace(), c.GetName()
+			if ns == "" || n == "" {
+				return nil, common.Error(common.ErrRequestParamInvalid)
+			}
+			body, err := c.GetRawData()
+			if err != nil {
+				return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+			}
+
+			msg := specV1.Message{
+				Kind:     models.MessageExec,
+				Content:  specV1.LazyValue{},
+				Metadata: map[string]string{},
+			}
+			err = msg.Content.UnmarshalJSON(body)
+			if err != nil {
+				return nil, err
+			}
+			for k := range c.Request.Header {
+				msg.Metadata[strings.ToLower(k)] = c.GetHeader(k)
+			}
+			msg.Metadata["name"] = n
+			msg.Metadata["namespace"] = ns
+			resp, err := l.msgRouter[string(models.MessageExec)].(server.HandlerMessage)(msg)
+			if err != nil {
+				return nil, err
+			}
+			setReplyHeaders(c, resp)
 			return resp.Content.Value, nil
 		}
 	}
@@ -80,3 +116,17 @@ func (l *httpLink) wrapper(tp specV1.MessageKind) common.HandlerFunc {
 		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "messageType"))
 	}
 }
+
+// setReplyHeaders sets the protocol version negotiated and the signature of reply in the headers of response,
+// see models.SyncProtocolVersion and models.SyncSignature
+func setReplyHeaders(c *common.Context, resp *specV1.Message) {
+	if v := resp.Metadata[models.SyncProtocolVersion]; v != "" {
+		c.Header(models.SyncProtocolVersion, v)
+	}
+	if resp.Metadata[models.SyncSignSignature] == "" {
+		return
+	}
+	for _, k := range []string{models.SyncSignKeyID, models.SyncSignTimestamp, models.SyncSignNonce, models.SyncSignSignature} {
+		c.Header(k, resp.Metadata[k])
+	}
+}
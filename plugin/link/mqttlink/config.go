@@ -0,0 +1,19 @@
+package mqttlink
+
+import (
+	"github.com/baetyl/baetyl-go/v2/mqtt"
+)
+
+type CloudConfig struct {
+	MQTTLink MQTTLinkConfig `yaml:"mqttlink" json:"mqttlink"`
+}
+
+type MQTTLinkConfig struct {
+	mqtt.ClientConfig `yaml:",inline" json:",inline"`
+	// TopicPrefix the prefix of topics, nodes publish the messages to <prefix>/<namespace>/<name>/<kind> and
+	// subscribe the replies from <prefix>/<namespace>/<name>/<kind>/reply
+	TopicPrefix string `yaml:"topicPrefix" json:"topicPrefix" default:"baetyl/sync"`
+	// ShareGroup the group of shared subscription, the messages of nodes are handled by one of the instances of cloud
+	ShareGroup string `yaml:"shareGroup" json:"shareGroup" default:"baetyl-cloud"`
+	QOS        uint32 `yaml:"qos" json:"qos" default:"1" validate:"max=1"`
+}
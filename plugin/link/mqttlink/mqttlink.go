@@ -0,0 +1,155 @@
+package mqttlink
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/baetyl/baetyl-go/v2/mqtt"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/server"
+)
+
+const (
+	replySuffix = "reply"
+)
+
+// mqttLink synchronizes with nodes through the broker, the namespace and name of node are taken from the topic,
+// so the broker must only allow node to publish to and subscribe from the topics of its own
+type mqttLink struct {
+	cfg       *CloudConfig
+	cli       *mqtt.Client
+	msgRouter map[string]interface{}
+	pid       uint32
+	log       *log.Logger
+}
+
+func init() {
+	plugin.RegisterFactory("mqttlink", NewMQTTLink)
+}
+
+func NewMQTTLink() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, err
+	}
+
+	topic := fmt.Sprintf("%s/+/+/+", cfg.MQTTLink.TopicPrefix)
+	if cfg.MQTTLink.ShareGroup != "" {
+		topic = fmt.Sprintf("$share/%s/%s", cfg.MQTTLink.ShareGroup, topic)
+	}
+	cfg.MQTTLink.Subscriptions = []mqtt.QOSTopic{{QOS: cfg.MQTTLink.QOS, Topic: topic}}
+	ops, err := cfg.MQTTLink.ToClientOptions()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &mqttLink{
+		cfg:       &cfg,
+		cli:       mqtt.NewClient(*ops),
+		msgRouter: map[string]interface{}{},
+		log:       log.L().With(log.Any("link", "mqttlink")),
+	}, nil
+}
+
+func (l *mqttLink) Start() {
+	if err := l.cli.Start(l); err != nil {
+		l.log.Error("failed to start mqtt link", log.Error(err))
+	}
+}
+
+func (l *mqttLink) AddMsgRouter(k string, v interface{}) {
+	l.msgRouter[k] = v
+}
+
+func (l *mqttLink) Close() error {
+	return l.cli.Close()
+}
+
+// OnPublish handles the message of node without blocking the client, the reply is published once handled
+func (l *mqttLink) OnPublish(pkt *mqtt.Publish) error {
+	go l.handle(pkt.Message.Topic, pkt.Message.Payload)
+	return nil
+}
+
+func (l *mqttLink) OnPuback(*mqtt.Puback) error {
+	return nil
+}
+
+func (l *mqttLink) OnError(err error) {
+	l.log.Error("mqtt link error", log.Error(err))
+}
+
+func (l *mqttLink) handle(topic string, payload []byte) {
+	ns, name, kind, ok := l.parseTopic(topic)
+	if !ok {
+		l.log.Warn("ignore the message of unknown topic", log.Any("topic", topic))
+		return
+	}
+	handler, ok := l.msgRouter[kind].(server.HandlerMessage)
+	if !ok {
+		l.log.Warn("ignore the message of unknown kind", log.Any("topic", topic))
+		return
+	}
+
+	var msg specV1.Message
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		l.log.Warn("ignore the message which is invalid", log.Any("topic", topic), log.Error(err))
+		return
+	}
+	msg.Kind = specV1.MessageKind(kind)
+	if msg.Metadata == nil {
+		msg.Metadata = map[string]string{}
+	}
+	msg.Metadata["namespace"] = ns
+	msg.Metadata["name"] = name
+
+	reply := &specV1.Message{Kind: msg.Kind, Metadata: msg.Metadata}
+	resp, err := handler(msg)
+	if err != nil {
+		l.log.Error("failed to handle the message of node", log.Any("namespace", ns), log.Any("name", name), log.Any("kind", kind), log.Error(err))
+		reply.Metadata["error"] = err.Error()
+		if sec, ok := common.RetryAfter(err); ok {
+			reply.Metadata["retryAfter"] = strconv.Itoa(sec)
+		}
+	} else if resp != nil {
+		reply.Content = resp.Content
+		if resp.Metadata != nil {
+			// the reply carries the signature if signed
+			reply.Metadata = resp.Metadata
+		}
+	}
+	data, err := json.Marshal(reply)
+	if err != nil {
+		l.log.Error("failed to marshal the reply", log.Any("topic", topic), log.Error(err))
+		return
+	}
+	if err = l.cli.Publish(mqtt.QOS(l.cfg.MQTTLink.QOS), topic+"/"+replySuffix, data, l.nextID(), false, false); err != nil {
+		l.log.Error("failed to publish the reply", log.Any("topic", topic), log.Error(err))
+	}
+}
+
+// parseTopic returns the namespace, name and message kind of the topic <prefix>/<namespace>/<name>/<kind>
+func (l *mqttLink) parseTopic(topic string) (string, string, string, bool) {
+	prefix := l.cfg.MQTTLink.TopicPrefix + "/"
+	if !strings.HasPrefix(topic, prefix) {
+		return "", "", "", false
+	}
+	parts := strings.Split(strings.TrimPrefix(topic, prefix), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", false
+	}
+	return parts[0], parts[1], parts[2], true
+}
+
+// nextID returns the packet id of the reply, which is in range [1, 65535]
+func (l *mqttLink) nextID() mqtt.ID {
+	return mqtt.ID(atomic.AddUint32(&l.pid, 1)%65535 + 1)
+}
@@ -0,0 +1,60 @@
+package mqttlink
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/mqtt"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+const (
+	configYaml = `
+mqttlink:
+  address: "tcp://127.0.0.1:1883"
+  clientid: "baetyl-cloud"
+`
+)
+
+func genMQTTLinkConf(t *testing.T) string {
+	tempDir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(tempDir, "config.yml"), []byte(configYaml), 0644)
+	assert.NoError(t, err)
+	return tempDir
+}
+
+func TestNewMQTTLink(t *testing.T) {
+	common.SetConfFile(path.Join(genMQTTLinkConf(t), "config.yml"))
+
+	pl, err := NewMQTTLink()
+	assert.NoError(t, err)
+	_, ok := pl.(plugin.SyncLink)
+	assert.True(t, ok)
+
+	link := pl.(*mqttLink)
+	assert.Equal(t, []mqtt.QOSTopic{{QOS: 1, Topic: "$share/baetyl-cloud/baetyl/sync/+/+/+"}}, link.cfg.MQTTLink.Subscriptions)
+
+	ns, name, kind, ok := link.parseTopic("baetyl/sync/default/node01/desire")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"default", "node01", "desire"}, []string{ns, name, kind})
+
+	for _, topic := range []string{
+		"baetyl/sync/default/node01/desire/reply",
+		"baetyl/sync/default/node01",
+		"baetyl/sync/default//desire",
+		"other/default/node01/desire",
+	} {
+		_, _, _, ok = link.parseTopic(topic)
+		assert.False(t, ok, topic)
+	}
+
+	assert.Equal(t, mqtt.ID(1), link.nextID())
+	link.pid = 65534
+	assert.Equal(t, mqtt.ID(65535), link.nextID())
+	assert.Equal(t, mqtt.ID(1), link.nextID())
+}
@@ -0,0 +1,20 @@
+package plugin
+
+import (
+	"io"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/metrics.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin NodeMetrics
+
+// NodeMetrics stores the history of node resource usage, it can be backed by database or time series database
+type NodeMetrics interface {
+	CreateNodeMetrics(namespace, name string, point *models.NodeMetricsPoint) error
+	// ListNodeMetrics lists the points of node in [start, end) ordered by time
+	ListNodeMetrics(namespace, name string, start, end time.Time) ([]models.NodeMetricsPoint, error)
+	// DeleteNodeMetrics deletes the points of all nodes before the time
+	DeleteNodeMetrics(before time.Time) error
+	io.Closer
+}
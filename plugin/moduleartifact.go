@@ -0,0 +1,20 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/moduleartifact.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin ModuleArtifact
+
+// ModuleArtifact stores the manifests of the artifacts of module versions built for the platforms
+type ModuleArtifact interface {
+	GetModuleArtifacts(name, version string) (*models.ModuleArtifacts, error)
+	// GetModuleArtifactsByImage returns the manifest of the module version of image
+	GetModuleArtifactsByImage(image string) (*models.ModuleArtifacts, error)
+	CreateModuleArtifacts(artifacts *models.ModuleArtifacts) error
+	UpdateModuleArtifacts(artifacts *models.ModuleArtifacts) error
+	DeleteModuleArtifacts(name, version string) error
+	io.Closer
+}
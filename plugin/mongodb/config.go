@@ -0,0 +1,14 @@
+package mongodb
+
+import "time"
+
+// CloudConfig baetyl-cloud config
+type CloudConfig struct {
+	MongoDB struct {
+		// URI the connection string of mongodb, the write concern of reports is set by it too, such as w=1
+		URI        string        `yaml:"uri" json:"uri" validate:"nonzero"`
+		Database   string        `yaml:"database" json:"database" default:"baetyl_cloud"`
+		Collection string        `yaml:"collection" json:"collection" default:"baetyl_node_shadow"`
+		Timeout    time.Duration `yaml:"timeout" json:"timeout" default:"10s"`
+	} `yaml:"mongodb" json:"mongodb"`
+}
@@ -0,0 +1,290 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/plugin/database/entities"
+)
+
+// shadowStorage stores the shadows of nodes in mongodb for the deployments whose reports overload the row updates
+// of database. A report is written by one update without transaction, and the documents are up to 16MB.
+// The transactions of database passed in are ignored, so the shadows left by the transactions rolled back are
+// cleaned up as garbage
+type shadowStorage struct {
+	cfg    CloudConfig
+	client *mongo.Client
+	coll   *mongo.Collection
+}
+
+// shadowDocument the document of shadow, the report and desire are kept as json rather than converted to bson
+// since they are read and written as a whole
+type shadowDocument struct {
+	Namespace     string    `bson:"namespace"`
+	Name          string    `bson:"name"`
+	Report        string    `bson:"report"`
+	Desire        string    `bson:"desire"`
+	ReportMeta    string    `bson:"report_meta"`
+	DesireMeta    string    `bson:"desire_meta"`
+	DesireVersion string    `bson:"desire_version"`
+	CreateTime    time.Time `bson:"create_time"`
+	UpdateTime    time.Time `bson:"update_time"`
+}
+
+func init() {
+	plugin.RegisterFactory("mongodb", New)
+}
+
+// New New
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.MongoDB.Timeout)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoDB.URI))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = client.Ping(ctx, nil); err != nil {
+		client.Disconnect(ctx)
+		return nil, errors.Trace(err)
+	}
+	s := &shadowStorage{
+		cfg:    cfg,
+		client: client,
+		coll:   client.Database(cfg.MongoDB.Database).Collection(cfg.MongoDB.Collection),
+	}
+	_, err = s.coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "namespace", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().SetName("unique_name").SetUnique(true),
+	})
+	if err != nil {
+		client.Disconnect(ctx)
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+func (s *shadowStorage) Get(_ interface{}, namespace, name string) (*models.Shadow, error) {
+	ctx, cancel := s.context()
+	defer cancel()
+	var doc shadowDocument
+	err := s.coll.FindOne(ctx, shadowFilter(namespace, name)).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.toShadowModel()
+}
+
+func (s *shadowStorage) ListShadowByNames(_ interface{}, namespace string, names []string) ([]*models.Shadow, error) {
+	if len(names) < 1 {
+		return nil, nil
+	}
+	ctx, cancel := s.context()
+	defer cancel()
+	cur, err := s.coll.Find(ctx, bson.M{"namespace": namespace, "name": bson.M{"$in": names}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var docs []shadowDocument
+	if err = cur.All(ctx, &docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var res []*models.Shadow
+	for i := range docs {
+		shadow, err := docs[i].toShadowModel()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, shadow)
+	}
+	return res, nil
+}
+
+func (s *shadowStorage) Create(_ interface{}, shadow *models.Shadow) (*models.Shadow, error) {
+	doc, err := newShadowDocument(shadow)
+	if err != nil {
+		return nil, err
+	}
+	doc.CreateTime = time.Now().UTC()
+	doc.UpdateTime = doc.CreateTime
+	ctx, cancel := s.context()
+	defer cancel()
+	if _, err = s.coll.InsertOne(ctx, doc); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.toShadowModel()
+}
+
+func (s *shadowStorage) Delete(namespace, name string) error {
+	ctx, cancel := s.context()
+	defer cancel()
+	_, err := s.coll.DeleteOne(ctx, shadowFilter(namespace, name))
+	return errors.Trace(err)
+}
+
+// UpdateDesire updates the desire if the version is not changed by others
+func (s *shadowStorage) UpdateDesire(_ interface{}, shadow *models.Shadow) error {
+	update, err := desireUpdate(shadow)
+	if err != nil {
+		return err
+	}
+	filter := shadowFilter(shadow.Namespace, shadow.Name)
+	filter["desire_version"] = shadow.DesireVersion
+	ctx, cancel := s.context()
+	defer cancel()
+	_, err = s.coll.UpdateOne(ctx, filter, bson.M{"$set": update})
+	return errors.Trace(err)
+}
+
+// UpdateDesires updates the desires and creates the shadows absent in one request
+func (s *shadowStorage) UpdateDesires(_ interface{}, shadows []*models.Shadow) error {
+	if len(shadows) < 1 {
+		return nil
+	}
+	writes := make([]mongo.WriteModel, 0, len(shadows))
+	for _, shadow := range shadows {
+		update, err := desireUpdate(shadow)
+		if err != nil {
+			return err
+		}
+		report, err := shadow.GetReportString()
+		if err != nil {
+			return err
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(shadowFilter(shadow.Namespace, shadow.Name)).
+			SetUpdate(bson.M{
+				"$set":         update,
+				"$setOnInsert": bson.M{"report": report, "report_meta": "", "create_time": update["update_time"]},
+			}).
+			SetUpsert(true))
+	}
+	ctx, cancel := s.context()
+	defer cancel()
+	_, err := s.coll.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return errors.Trace(err)
+}
+
+// UpdateReport updates the report and returns the shadow updated in one request
+func (s *shadowStorage) UpdateReport(shadow *models.Shadow) (*models.Shadow, error) {
+	report, err := shadow.GetReportString()
+	if err != nil {
+		return nil, err
+	}
+	reportMeta, err := shadow.GetReportMetaString()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := s.context()
+	defer cancel()
+	var doc shadowDocument
+	err = s.coll.FindOneAndUpdate(ctx, shadowFilter(shadow.Namespace, shadow.Name),
+		bson.M{"$set": bson.M{"report": report, "report_meta": reportMeta, "update_time": time.Now().UTC()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.toShadowModel()
+}
+
+func (s *shadowStorage) List(namespace string, nodeList *models.NodeList) (*models.ShadowList, error) {
+	names := make([]string, 0, len(nodeList.Items))
+	for _, node := range nodeList.Items {
+		names = append(names, node.Name)
+	}
+	shadows, err := s.ListShadowByNames(nil, namespace, names)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]models.Shadow, 0, len(shadows))
+	for _, shadow := range shadows {
+		items = append(items, *shadow)
+	}
+	return &models.ShadowList{Total: len(items), Items: items}, nil
+}
+
+func (s *shadowStorage) Close() error {
+	ctx, cancel := s.context()
+	defer cancel()
+	return errors.Trace(s.client.Disconnect(ctx))
+}
+
+func (s *shadowStorage) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), s.cfg.MongoDB.Timeout)
+}
+
+func shadowFilter(namespace, name string) bson.M {
+	return bson.M{"namespace": namespace, "name": name}
+}
+
+func desireUpdate(shadow *models.Shadow) (bson.M, error) {
+	desire, err := shadow.GetDesireString()
+	if err != nil {
+		return nil, err
+	}
+	desireMeta, err := shadow.GetDesireMetaString()
+	if err != nil {
+		return nil, err
+	}
+	return bson.M{
+		"desire":         desire,
+		"desire_meta":    desireMeta,
+		"desire_version": genResourceVersion(),
+		"update_time":    time.Now().UTC(),
+	}, nil
+}
+
+func genResourceVersion() string {
+	return fmt.Sprintf("%d%s", time.Now().UTC().Unix(), common.RandString(6))
+}
+
+// newShadowDocument converts the shadow as the entity of database does, so the shadows read are the same
+func newShadowDocument(shadow *models.Shadow) (*shadowDocument, error) {
+	shd, err := entities.NewShadowFromShadowModel(shadow)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &shadowDocument{
+		Namespace:     shd.Namespace,
+		Name:          shd.Name,
+		Report:        shd.Report,
+		Desire:        shd.Desire,
+		ReportMeta:    shd.ReportMeta,
+		DesireMeta:    shd.DesireMeta,
+		DesireVersion: shadow.DesireVersion,
+	}, nil
+}
+
+func (d *shadowDocument) toShadowModel() (*models.Shadow, error) {
+	shd := &entities.Shadow{
+		Namespace:     d.Namespace,
+		Name:          d.Name,
+		CreateTime:    d.CreateTime,
+		UpdateTime:    d.UpdateTime,
+		Report:        d.Report,
+		Desire:        d.Desire,
+		ReportMeta:    d.ReportMeta,
+		DesireMeta:    d.DesireMeta,
+		DesireVersion: d.DesireVersion,
+	}
+	shadow, err := shd.ToShadowModel()
+	return shadow, errors.Trace(err)
+}
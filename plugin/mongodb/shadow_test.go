@@ -0,0 +1,140 @@
+package mongodb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// the tests of mongodb run against the server given by the env, such as mongodb://localhost:27017
+const envTestMongo = "BAETYL_TEST_MONGO"
+
+func mockNewShadowStorage(t *testing.T) *shadowStorage {
+	uri := os.Getenv(envTestMongo)
+	if uri == "" {
+		t.Skipf("%s is not set", envTestMongo)
+	}
+	conf := "mongodb:\n  uri: " + uri + "\n  collection: baetyl_node_shadow_test\n"
+	filename := "cloud.yml"
+	assert.NoError(t, ioutil.WriteFile(filename, []byte(conf), 0644))
+	defer os.Remove(filename)
+	common.SetConfFile(filename)
+
+	p, err := New()
+	if err != nil {
+		t.Fatalf("connect mongodb error = %s", err.Error())
+	}
+	s := p.(*shadowStorage)
+	ctx, cancel := s.context()
+	defer cancel()
+	_, err = s.coll.DeleteMany(ctx, map[string]interface{}{})
+	assert.NoError(t, err)
+	return s
+}
+
+func TestShadowDocument(t *testing.T) {
+	shadow := &models.Shadow{
+		Namespace:     "default",
+		Name:          "node01",
+		DesireVersion: "1",
+		Report:        v1.Report{"apps": []v1.AppInfo{{Name: "app01", Version: "1"}}},
+		Desire:        v1.Desire{"apps": []v1.AppInfo{{Name: "app01", Version: "2"}}},
+		ReportMeta:    v1.Report{"apps": map[string]interface{}{"app01": "2020-10-01T00:00:00Z"}},
+	}
+	doc, err := newShadowDocument(shadow)
+	assert.NoError(t, err)
+	assert.Equal(t, "default", doc.Namespace)
+	assert.Equal(t, `{"apps":[{"name":"app01","version":"1"}]}`, doc.Report)
+	assert.Equal(t, "1", doc.DesireVersion)
+	assert.Equal(t, "null", doc.DesireMeta)
+
+	doc.CreateTime = time.Date(2020, 10, 1, 8, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	res, err := doc.toShadowModel()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC), res.CreationTimestamp)
+	assert.Equal(t, shadow.Report.AppInfos(false), res.Report.AppInfos(false))
+	assert.Equal(t, shadow.Desire.AppInfos(false), res.Desire.AppInfos(false))
+	assert.Equal(t, shadow.ReportMeta, res.ReportMeta)
+	assert.Nil(t, res.DesireMeta)
+
+	doc.Report = ""
+	_, err = doc.toShadowModel()
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	conf := `
+mongodb:
+  database: baetyl_cloud
+`
+	filename := "cloud.yml"
+	err := ioutil.WriteFile(filename, []byte(conf), 0644)
+	assert.NoError(t, err)
+	defer os.Remove(filename)
+	common.SetConfFile(filename)
+
+	_, err = New()
+	assert.Error(t, err)
+}
+
+func TestShadowStorage(t *testing.T) {
+	s := mockNewShadowStorage(t)
+	defer s.Close()
+	var _ plugin.Shadow = s
+
+	res, err := s.Get(nil, "default", "node01")
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+	res, err = s.UpdateReport(&models.Shadow{Namespace: "default", Name: "node01", Report: v1.Report{}})
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	shadow, err := s.Create(nil, &models.Shadow{Namespace: "default", Name: "node01", Report: v1.Report{}, Desire: v1.Desire{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "node01", shadow.Name)
+	_, err = s.Create(nil, &models.Shadow{Namespace: "default", Name: "node01", Report: v1.Report{}, Desire: v1.Desire{}})
+	assert.Error(t, err)
+
+	// the desire of the version changed is not updated
+	shadow.Desire = v1.Desire{"apps": []v1.AppInfo{{Name: "app01", Version: "1"}}}
+	shadow.DesireVersion = "unknown"
+	assert.NoError(t, s.UpdateDesire(nil, shadow))
+	res, err = s.Get(nil, "default", "node01")
+	assert.NoError(t, err)
+	assert.Empty(t, res.Desire.AppInfos(false))
+	shadow.DesireVersion = res.DesireVersion
+	assert.NoError(t, s.UpdateDesire(nil, shadow))
+	res, err = s.Get(nil, "default", "node01")
+	assert.NoError(t, err)
+	assert.Len(t, res.Desire.AppInfos(false), 1)
+	assert.NotEqual(t, shadow.DesireVersion, res.DesireVersion)
+
+	// the absent shadows are created by the desires
+	node02 := &models.Shadow{Namespace: "default", Name: "node02", Report: v1.Report{}, Desire: v1.Desire{"apps": []v1.AppInfo{{Name: "app02", Version: "2"}}}}
+	assert.NoError(t, s.UpdateDesires(nil, []*models.Shadow{shadow, node02}))
+	shadows, err := s.ListShadowByNames(nil, "default", []string{"node01", "node02", "node03"})
+	assert.NoError(t, err)
+	assert.Len(t, shadows, 2)
+
+	res, err = s.UpdateReport(&models.Shadow{Namespace: "default", Name: "node02", Report: v1.Report{"apps": []v1.AppInfo{{Name: "app02", Version: "2"}}}})
+	assert.NoError(t, err)
+	assert.Len(t, res.Report.AppInfos(false), 1)
+	assert.Len(t, res.Desire.AppInfos(false), 1)
+
+	list, err := s.List("default", &models.NodeList{Items: []v1.Node{{Name: "node01"}, {Name: "node02"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, list.Total)
+
+	assert.NoError(t, s.Delete("default", "node01"))
+	res, err = s.Get(nil, "default", "node01")
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
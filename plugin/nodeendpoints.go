@@ -0,0 +1,18 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/nodeendpoints.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin NodeEndpoints
+
+// NodeEndpoints stores the node endpoints overridden in namespaces
+type NodeEndpoints interface {
+	GetNodeEndpoints(namespace string) (*models.NodeEndpoints, error)
+	CreateNodeEndpoints(endpoints *models.NodeEndpoints) error
+	UpdateNodeEndpoints(endpoints *models.NodeEndpoints) error
+	DeleteNodeEndpoints(namespace string) error
+	io.Closer
+}
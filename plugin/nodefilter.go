@@ -0,0 +1,20 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/nodefilter.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin NodeFilter
+
+// NodeFilter stores the filters of nodes saved by users
+type NodeFilter interface {
+	GetNodeFilter(namespace, user, name string) (*models.NodeFilter, error)
+	// ListNodeFilter lists the filters of user ordered by name
+	ListNodeFilter(namespace, user string) ([]models.NodeFilter, error)
+	CreateNodeFilter(filter *models.NodeFilter) error
+	UpdateNodeFilter(filter *models.NodeFilter) error
+	DeleteNodeFilter(namespace, user, name string) error
+	io.Closer
+}
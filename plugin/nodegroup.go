@@ -0,0 +1,20 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/nodegroup.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin NodeGroup
+
+// NodeGroup stores the groups of nodes
+type NodeGroup interface {
+	GetNodeGroup(namespace, name string) (*models.NodeGroup, error)
+	ListNodeGroup(namespace string, filter *models.Filter) ([]models.NodeGroup, error)
+	CountNodeGroup(namespace, name string) (int, error)
+	CreateNodeGroup(group *models.NodeGroup) error
+	UpdateNodeGroup(group *models.NodeGroup) error
+	DeleteNodeGroup(namespace, name string) error
+	io.Closer
+}
@@ -0,0 +1,26 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/noderegistration.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin NodeRegistration
+
+// NodeRegistration stores the registrations of devices and the rules approving them
+type NodeRegistration interface {
+	GetNodeRegistration(namespace, name string) (*models.NodeRegistration, error)
+	GetNodeRegistrationByFingerprint(namespace, fingerprint string) (*models.NodeRegistration, error)
+	// ListNodeRegistration lists the registrations of the status, all are listed if status is empty
+	ListNodeRegistration(namespace, status string) ([]models.NodeRegistration, error)
+	CreateNodeRegistration(registration *models.NodeRegistration) error
+	UpdateNodeRegistration(registration *models.NodeRegistration) error
+	DeleteNodeRegistration(namespace, name string) error
+
+	GetNodeRegistrationRule(namespace, name string) (*models.NodeRegistrationRule, error)
+	ListNodeRegistrationRule(namespace string) ([]models.NodeRegistrationRule, error)
+	CreateNodeRegistrationRule(rule *models.NodeRegistrationRule) error
+	DeleteNodeRegistrationRule(namespace, name string) error
+	io.Closer
+}
@@ -0,0 +1,19 @@
+package plugin
+
+import (
+	"io"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/platformstats.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin PlatformStats
+
+// PlatformStats aggregates the records of storage across namespaces for the ops dashboard
+type PlatformStats interface {
+	// CountSyncTraffic sums the sync records of all nodes since the time by kind
+	CountSyncTraffic(since time.Time) ([]models.SyncTraffic, error)
+	// ListTableSize lists the sizes of the tables of storage
+	ListTableSize() ([]models.TableSize, error)
+	io.Closer
+}
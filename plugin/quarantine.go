@@ -0,0 +1,18 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/quarantine.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin Quarantine
+
+// Quarantine stores the resources quarantined platform-wide by operators
+type Quarantine interface {
+	GetQuarantine(name string) (*models.Quarantine, error)
+	ListQuarantine() ([]models.Quarantine, error)
+	CreateQuarantine(quarantine *models.Quarantine) error
+	DeleteQuarantine(name string) error
+	io.Closer
+}
@@ -0,0 +1,19 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/rollout.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin Rollout
+
+// Rollout stores the staged rollouts of applications, one for each application
+type Rollout interface {
+	GetRollout(namespace, app string) (*models.Rollout, error)
+	ListRollout(namespace string) ([]models.Rollout, error)
+	CreateRollout(rollout *models.Rollout) error
+	UpdateRollout(rollout *models.Rollout) error
+	DeleteRollout(namespace, app string) error
+	io.Closer
+}
@@ -0,0 +1,14 @@
+package sandbox
+
+import "time"
+
+// CloudConfig baetyl-cloud config
+type CloudConfig struct {
+	Sandbox struct {
+		Address string `yaml:"address" json:"address" validate:"nonzero"`
+		// Token the bearer token of the requests to the sandbox, no authorization is sent if empty
+		Token string `yaml:"token" json:"token"`
+		// Timeout the timeout of invocations, including the time the sandbox takes to start the runtime
+		Timeout time.Duration `yaml:"timeout" json:"timeout" default:"30s"`
+	} `yaml:"sandbox" json:"sandbox"`
+}
@@ -0,0 +1,105 @@
+package sandbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// sandbox invokes the code of functions by a hosted sandbox service, which runs the code in a container
+// of the runtime image for each invocation of POST /v1/invocations. The code is either sent inline or
+// downloaded by the sandbox from the url of code
+type sandbox struct {
+	cfg    CloudConfig
+	client *http.Client
+}
+
+type invokeRequest struct {
+	Runtime string            `json:"runtime"`
+	Image   string            `json:"image"`
+	Handler string            `json:"handler"`
+	Code    []byte            `json:"code,omitempty"`
+	CodeURL string            `json:"codeURL,omitempty"`
+	Payload json.RawMessage   `json:"payload,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+type invokeResponse struct {
+	models.FunctionInvokeResult
+	Message string `json:"message"`
+}
+
+func init() {
+	plugin.RegisterFactory("sandbox", New)
+}
+
+// New New
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &sandbox{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Sandbox.Timeout},
+	}, nil
+}
+
+// Invoke invokes the function, the error raised by the function is returned in result instead of as error
+func (s *sandbox) Invoke(req *models.FunctionInvokeRequest) (*models.FunctionInvokeResult, error) {
+	body, err := json.Marshal(&invokeRequest{
+		Runtime: req.Runtime,
+		Image:   req.Image,
+		Handler: req.Handler,
+		Code:    req.Code,
+		CodeURL: req.CodeURL,
+		Payload: req.Payload,
+		Labels: map[string]string{
+			"namespace": req.Namespace,
+			"function":  req.Name,
+		},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(s.cfg.Sandbox.Address, "/")+"/v1/invocations", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if s.cfg.Sandbox.Token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+s.cfg.Sandbox.Token)
+	}
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res := new(invokeResponse)
+	if resp.StatusCode/100 != 2 {
+		json.Unmarshal(data, res)
+		return nil, errors.Errorf("failed to request the sandbox (%d): %s", resp.StatusCode, res.Message)
+	}
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &res.FunctionInvokeResult, nil
+}
+
+// Close Close
+func (s *sandbox) Close() error {
+	return nil
+}
@@ -0,0 +1,64 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestSandbox(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s.token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"invalid token"}`))
+			return
+		}
+		assert.Equal(t, "POST /v1/invocations", r.Method+" "+r.URL.Path)
+		var req invokeRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "baetyltech/function-python:v2.2.0", req.Image)
+		assert.Equal(t, []byte("zip"), req.Code)
+		assert.Equal(t, "infer", req.Labels["function"])
+		switch string(req.Payload) {
+		case `{"a":1}`:
+			w.Write([]byte(`{"result":{"a":1},"logs":"start\n","duration":12}`))
+		default:
+			w.Write([]byte(`{"error":"KeyError: 'a'","duration":3}`))
+		}
+	}))
+	defer server.Close()
+
+	s := &sandbox{client: &http.Client{Timeout: time.Second}}
+	s.cfg.Sandbox.Address = server.URL + "/"
+	s.cfg.Sandbox.Token = "s.token"
+
+	req := &models.FunctionInvokeRequest{
+		Namespace: "default",
+		Name:      "infer",
+		Runtime:   "python3",
+		Image:     "baetyltech/function-python:v2.2.0",
+		Handler:   "index.handler",
+		Code:      []byte("zip"),
+		Payload:   json.RawMessage(`{"a":1}`),
+	}
+	res, err := s.Invoke(req)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":1}`, string(res.Result))
+	assert.Equal(t, "start\n", res.Logs)
+	assert.Equal(t, int64(12), res.Duration)
+
+	req.Payload = json.RawMessage(`{}`)
+	res, err = s.Invoke(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "KeyError: 'a'", res.Error)
+
+	s.cfg.Sandbox.Token = "expired"
+	_, err = s.Invoke(req)
+	assert.EqualError(t, err, "failed to request the sandbox (401): invalid token")
+}
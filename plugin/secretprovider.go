@@ -0,0 +1,17 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/secretprovider.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin SecretProvider
+
+// SecretProvider reads the secrets from the external secret store, such as vault
+type SecretProvider interface {
+	// GetSecretData returns the data of the secret at the path of reference, the values which are not
+	// strings in store are encoded as json
+	GetSecretData(ref *models.SecretExternal) (map[string][]byte, error)
+	io.Closer
+}
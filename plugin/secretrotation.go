@@ -0,0 +1,21 @@
+package plugin
+
+import (
+	"io"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/secretrotation.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin SecretRotation
+
+// SecretRotation stores the expiry and rotation policies of secrets
+type SecretRotation interface {
+	GetSecretRotation(namespace, name string) (*models.SecretRotation, error)
+	// ListExpiringSecretRotation lists the rotations of all namespaces expiring before the time, except the expired ones
+	ListExpiringSecretRotation(before time.Time) ([]models.SecretRotation, error)
+	CreateSecretRotation(rotation *models.SecretRotation) error
+	UpdateSecretRotation(rotation *models.SecretRotation) error
+	DeleteSecretRotation(namespace, name string) error
+	io.Closer
+}
@@ -0,0 +1,16 @@
+package plugin
+
+import (
+	"io"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+)
+
+//go:generate mockgen -destination=../mock/plugin/secretrotator.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin SecretRotator
+
+// SecretRotator generates the new data of secret on rotation
+type SecretRotator interface {
+	// Rotate returns the new data of the keys of secret, the other keys are kept
+	Rotate(secret *specV1.Secret, keys []string) (map[string][]byte, error)
+	io.Closer
+}
@@ -0,0 +1,19 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/sidecarpolicy.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin SidecarPolicy
+
+// SidecarPolicy stores the policies injecting sidecars into applications
+type SidecarPolicy interface {
+	GetSidecarPolicy(namespace, name string) (*models.SidecarPolicy, error)
+	ListSidecarPolicy(namespace string) ([]models.SidecarPolicy, error)
+	CreateSidecarPolicy(policy *models.SidecarPolicy) error
+	UpdateSidecarPolicy(policy *models.SidecarPolicy) error
+	DeleteSidecarPolicy(namespace, name string) error
+	io.Closer
+}
@@ -0,0 +1,20 @@
+package plugin
+
+import (
+	"io"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/synchistory.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin SyncHistory
+
+// SyncHistory stores the records of the sync exchanges between nodes and cloud
+type SyncHistory interface {
+	CreateSyncRecord(record *models.SyncRecord) error
+	// ListSyncRecord lists at most limit records of node since the time, the latest first
+	ListSyncRecord(namespace, name string, since time.Time, limit int) ([]models.SyncRecord, error)
+	// DeleteSyncRecord deletes the records of all nodes before the time
+	DeleteSyncRecord(before time.Time) error
+	io.Closer
+}
@@ -0,0 +1,15 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/telemetry.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin TelemetrySink
+
+// TelemetrySink ships the telemetry of nodes to the pipeline of users, such as kafka, prometheus or a webhook
+type TelemetrySink interface {
+	Ship(telemetry *models.Telemetry) error
+	io.Closer
+}
@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+const contentTypeKafkaJSON = "application/vnd.kafka.json.v2+json"
+
+func init() {
+	plugin.RegisterFactory("kafkatelemetry", New)
+}
+
+// kafkaTelemetry produces the telemetry to the topic through kafka rest proxy
+type kafkaTelemetry struct {
+	url    string
+	client *http.Client
+}
+
+type produceRequest struct {
+	Records []produceRecord `json:"records"`
+}
+
+type produceRecord struct {
+	Key   string            `json:"key,omitempty"`
+	Value *models.Telemetry `json:"value"`
+}
+
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &kafkaTelemetry{
+		url:    fmt.Sprintf("%s/topics/%s", strings.TrimSuffix(cfg.KafkaTelemetry.Address, "/"), cfg.KafkaTelemetry.Topic),
+		client: &http.Client{Timeout: cfg.KafkaTelemetry.Timeout},
+	}, nil
+}
+
+func (k *kafkaTelemetry) Ship(telemetry *models.Telemetry) error {
+	// the telemetry of the same node goes to the same partition to keep the order
+	data, err := json.Marshal(&produceRequest{
+		Records: []produceRecord{{Key: telemetry.Namespace + "/" + telemetry.Node, Value: telemetry}},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := k.client.Post(k.url, contentTypeKafkaJSON, bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("failed to produce telemetry to kafka, status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (k *kafkaTelemetry) Close() error {
+	return nil
+}
@@ -0,0 +1,41 @@
+package kafka
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestKafkaTelemetry(t *testing.T) {
+	var received produceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/topics/baetyl-telemetry", r.URL.Path)
+		assert.Equal(t, contentTypeKafkaJSON, r.Header.Get("Content-Type"))
+		data, _ := ioutil.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(data, &received))
+		if received.Records[0].Value.Node == "bad" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := &kafkaTelemetry{
+		url:    server.URL + "/topics/baetyl-telemetry",
+		client: server.Client(),
+	}
+	telemetry := &models.Telemetry{Namespace: "default", Node: "node01", Data: json.RawMessage(`{"output":12}`)}
+	assert.NoError(t, sink.Ship(telemetry))
+	assert.Equal(t, "default/node01", received.Records[0].Key)
+	assert.JSONEq(t, `{"output":12}`, string(received.Records[0].Value.Data))
+
+	assert.Error(t, sink.Ship(&models.Telemetry{Namespace: "default", Node: "bad", Data: json.RawMessage(`{}`)}))
+	assert.NoError(t, sink.Close())
+}
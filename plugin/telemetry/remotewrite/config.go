@@ -0,0 +1,13 @@
+package remotewrite
+
+import "time"
+
+type CloudConfig struct {
+	RemoteWriteTelemetry struct {
+		// URL the remote write endpoint of prometheus, such as http://prometheus:9090/api/v1/write
+		URL string `yaml:"url" json:"url" validate:"nonzero"`
+		// Prefix the prefix of the names of the metrics, which are the paths of the numbers in telemetry
+		Prefix  string        `yaml:"prefix" json:"prefix" default:"baetyl_telemetry"`
+		Timeout time.Duration `yaml:"timeout" json:"timeout" default:"10s"`
+	} `yaml:"remotewritetelemetry" json:"remotewritetelemetry"`
+}
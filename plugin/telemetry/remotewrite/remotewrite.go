@@ -0,0 +1,156 @@
+package remotewrite
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func init() {
+	plugin.RegisterFactory("remotewritetelemetry", New)
+}
+
+// remoteWriteTelemetry writes the numbers of telemetry as the samples of gauges to prometheus by remote write, the
+// metric of a number is named by its path in telemetry, such as baetyl_telemetry_line1_output for {"line1":{"output":12}},
+// and labeled with the namespace and node. The booleans are written as 0 or 1, the strings and arrays are skipped
+type remoteWriteTelemetry struct {
+	url    string
+	prefix string
+	client *http.Client
+}
+
+type series struct {
+	labels [][2]string
+	value  float64
+}
+
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &remoteWriteTelemetry{
+		url:    cfg.RemoteWriteTelemetry.URL,
+		prefix: cfg.RemoteWriteTelemetry.Prefix,
+		client: &http.Client{Timeout: cfg.RemoteWriteTelemetry.Timeout},
+	}, nil
+}
+
+func (r *remoteWriteTelemetry) Ship(telemetry *models.Telemetry) error {
+	dec := json.NewDecoder(bytes.NewReader(telemetry.Data))
+	dec.UseNumber()
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return errors.Trace(err)
+	}
+	var res []series
+	flatten(r.prefix, data, func(name string, value float64) {
+		res = append(res, series{
+			labels: [][2]string{{"__name__", name}, {"namespace", telemetry.Namespace}, {"node", telemetry.Node}},
+			value:  value,
+		})
+	})
+	if len(res) == 0 {
+		return nil
+	}
+	body := snappy.Encode(nil, encodeWriteRequest(res, telemetry.Time.UnixNano()/1e6))
+
+	req, err := http.NewRequest(http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("failed to write telemetry to prometheus, status %d: %s", resp.StatusCode, string(msg))
+	}
+	return nil
+}
+
+func (r *remoteWriteTelemetry) Close() error {
+	return nil
+}
+
+// flatten calls fn with the metric name of each number in data, in the order of keys
+func flatten(name string, data interface{}, fn func(string, float64)) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			flatten(name+"_"+sanitize(k), v[k], fn)
+		}
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			fn(name, f)
+		}
+	case bool:
+		if v {
+			fn(name, 1)
+		} else {
+			fn(name, 0)
+		}
+	}
+}
+
+// sanitize replaces the characters not allowed in metric names with underscores
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == ':' {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// encodeWriteRequest encodes the series into the protobuf of prometheus.WriteRequest, each with a sample at timestamp
+// in milliseconds, the labels are sorted by name as prometheus requires
+func encodeWriteRequest(res []series, timestamp int64) []byte {
+	var buf []byte
+	for _, s := range res {
+		labels := append([][2]string{}, s.labels...)
+		sort.Slice(labels, func(i, j int) bool { return labels[i][0] < labels[j][0] })
+		var ts []byte
+		for _, l := range labels {
+			var label []byte
+			label = protowire.AppendTag(label, 1, protowire.BytesType)
+			label = protowire.AppendString(label, l[0])
+			label = protowire.AppendTag(label, 2, protowire.BytesType)
+			label = protowire.AppendString(label, l[1])
+			ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, label)
+		}
+		var sample []byte
+		sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(s.value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(timestamp))
+		ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, sample)
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, ts)
+	}
+	return buf
+}
@@ -0,0 +1,106 @@
+package remotewrite
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+type decodedSeries struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// decodeWriteRequest decodes the fields of prometheus.WriteRequest written by encodeWriteRequest
+func decodeWriteRequest(t *testing.T, data []byte) []decodedSeries {
+	var res []decodedSeries
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		assert.Equal(t, protowire.Number(1), num)
+		assert.Equal(t, protowire.BytesType, typ)
+		ts, m := protowire.ConsumeBytes(data[n:])
+		data = data[n+m:]
+
+		s := decodedSeries{labels: map[string]string{}}
+		for len(ts) > 0 {
+			num, _, n := protowire.ConsumeTag(ts)
+			field, m := protowire.ConsumeBytes(ts[n:])
+			ts = ts[n+m:]
+			var name string
+			for len(field) > 0 {
+				fnum, ftyp, n := protowire.ConsumeTag(field)
+				field = field[n:]
+				switch {
+				case num == 1 && fnum == 1:
+					v, m := protowire.ConsumeString(field)
+					name, field = v, field[m:]
+				case num == 1 && fnum == 2:
+					v, m := protowire.ConsumeString(field)
+					s.labels[name], field = v, field[m:]
+				case num == 2 && fnum == 1:
+					assert.Equal(t, protowire.Fixed64Type, ftyp)
+					v, m := protowire.ConsumeFixed64(field)
+					s.value, field = math.Float64frombits(v), field[m:]
+				case num == 2 && fnum == 2:
+					v, m := protowire.ConsumeVarint(field)
+					s.timestamp, field = int64(v), field[m:]
+				}
+			}
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+func TestRemoteWriteTelemetry(t *testing.T) {
+	var received []decodedSeries
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		data, err := snappy.Decode(nil, body)
+		assert.NoError(t, err)
+		received = decodeWriteRequest(t, data)
+		if received[0].labels["node"] == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := &remoteWriteTelemetry{url: server.URL, prefix: "baetyl_telemetry", client: server.Client()}
+	now := time.Unix(1600000000, 0)
+	telemetry := &models.Telemetry{
+		Namespace: "default",
+		Node:      "node01",
+		Time:      now,
+		Data:      json.RawMessage(`{"line-1":{"output":12,"running":true,"shift":"a"},"rate":0.5,"batches":[1,2]}`),
+	}
+	assert.NoError(t, sink.Ship(telemetry))
+	assert.Equal(t, []decodedSeries{
+		{labels: map[string]string{"__name__": "baetyl_telemetry_line_1_output", "namespace": "default", "node": "node01"}, value: 12, timestamp: now.UnixNano() / 1e6},
+		{labels: map[string]string{"__name__": "baetyl_telemetry_line_1_running", "namespace": "default", "node": "node01"}, value: 1, timestamp: now.UnixNano() / 1e6},
+		{labels: map[string]string{"__name__": "baetyl_telemetry_rate", "namespace": "default", "node": "node01"}, value: 0.5, timestamp: now.UnixNano() / 1e6},
+	}, received)
+
+	// nothing is written without numbers
+	received = nil
+	assert.NoError(t, sink.Ship(&models.Telemetry{Namespace: "default", Node: "node01", Data: json.RawMessage(`{"shift":"a"}`)}))
+	assert.Nil(t, received)
+
+	assert.Error(t, sink.Ship(&models.Telemetry{Namespace: "default", Node: "bad", Data: json.RawMessage(`{"rate":1}`)}))
+	assert.Error(t, sink.Ship(&models.Telemetry{Namespace: "default", Node: "node01", Data: json.RawMessage(`{`)}))
+	assert.NoError(t, sink.Close())
+}
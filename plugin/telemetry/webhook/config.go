@@ -0,0 +1,12 @@
+package webhook
+
+import "time"
+
+type CloudConfig struct {
+	WebhookTelemetry struct {
+		URL string `yaml:"url" json:"url" validate:"nonzero"`
+		// Headers the headers of requests, such as the token authorizing cloud
+		Headers map[string]string `yaml:"headers" json:"headers"`
+		Timeout time.Duration     `yaml:"timeout" json:"timeout" default:"10s"`
+	} `yaml:"webhooktelemetry" json:"webhooktelemetry"`
+}
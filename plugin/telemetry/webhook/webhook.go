@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func init() {
+	plugin.RegisterFactory("webhooktelemetry", New)
+}
+
+// webhookTelemetry posts the telemetry as json to the webhook of users
+type webhookTelemetry struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &webhookTelemetry{
+		url:     cfg.WebhookTelemetry.URL,
+		headers: cfg.WebhookTelemetry.Headers,
+		client:  &http.Client{Timeout: cfg.WebhookTelemetry.Timeout},
+	}, nil
+}
+
+func (w *webhookTelemetry) Ship(telemetry *models.Telemetry) error {
+	data, err := json.Marshal(telemetry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("failed to post telemetry to webhook, status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (w *webhookTelemetry) Close() error {
+	return nil
+}
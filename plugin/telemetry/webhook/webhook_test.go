@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestWebhookTelemetry(t *testing.T) {
+	var received models.Telemetry
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "t0", r.Header.Get("X-Token"))
+		data, _ := ioutil.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(data, &received))
+		if received.Node == "bad" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sink := &webhookTelemetry{url: server.URL, headers: map[string]string{"X-Token": "t0"}, client: server.Client()}
+	assert.NoError(t, sink.Ship(&models.Telemetry{Namespace: "default", Node: "node01", Data: json.RawMessage(`{"output":12}`)}))
+	assert.Equal(t, "node01", received.Node)
+	assert.JSONEq(t, `{"output":12}`, string(received.Data))
+
+	assert.Error(t, sink.Ship(&models.Telemetry{Namespace: "default", Node: "bad", Data: json.RawMessage(`{}`)}))
+	assert.NoError(t, sink.Close())
+}
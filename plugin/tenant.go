@@ -0,0 +1,18 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/plugin/tenant.go -package=plugin github.com/baetyl/baetyl-cloud/v2/plugin Tenant
+
+// Tenant stores the lifecycle of the tenants of namespaces
+type Tenant interface {
+	GetTenant(namespace string) (*models.Tenant, error)
+	CreateTenant(tenant *models.Tenant) error
+	UpdateTenant(tenant *models.Tenant) error
+	DeleteTenant(namespace string) error
+	io.Closer
+}
@@ -0,0 +1,16 @@
+package vault
+
+import "time"
+
+// CloudConfig baetyl-cloud config
+type CloudConfig struct {
+	Vault struct {
+		Address string `yaml:"address" json:"address" validate:"nonzero"`
+		Token   string `yaml:"token" json:"token" validate:"nonzero"`
+		// Mount the path where the kv secrets engine of version 2 is mounted
+		Mount string `yaml:"mount" json:"mount" default:"secret"`
+		// Namespace the namespace of vault enterprise, the root namespace is used if empty
+		Namespace string        `yaml:"namespace" json:"namespace"`
+		Timeout   time.Duration `yaml:"timeout" json:"timeout" default:"10s"`
+	} `yaml:"vault" json:"vault"`
+}
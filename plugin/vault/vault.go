@@ -0,0 +1,105 @@
+package vault
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+// vaultProvider reads the secrets from the kv secrets engine of vault by its http api
+type vaultProvider struct {
+	cfg    CloudConfig
+	client *http.Client
+}
+
+type kvResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func init() {
+	plugin.RegisterFactory("vaultkv", New)
+}
+
+// New New
+func New() (plugin.Plugin, error) {
+	var cfg CloudConfig
+	if err := common.LoadConfig(&cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &vaultProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Vault.Timeout},
+	}, nil
+}
+
+// GetSecretData reads the secret at the path of reference, the version is the version number of kv secret
+func (v *vaultProvider) GetSecretData(ref *models.SecretExternal) (map[string][]byte, error) {
+	u := strings.TrimSuffix(v.cfg.Vault.Address, "/") + "/v1/" + strings.Trim(v.cfg.Vault.Mount, "/") + "/data/" + strings.Trim(ref.Path, "/")
+	if ref.Version != "" {
+		if _, err := strconv.Atoi(ref.Version); err != nil {
+			return nil, errors.Errorf("the version (%s) of vault secret should be a number", ref.Version)
+		}
+		u += "?" + url.Values{"version": []string{ref.Version}}.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("X-Vault-Token", v.cfg.Vault.Token)
+	if v.cfg.Vault.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.cfg.Vault.Namespace)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var res kvResponse
+	if err = json.Unmarshal(body, &res); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, errors.Trace(err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errors.Errorf("the secret %s is not found in vault", ref.Path)
+	case resp.StatusCode != http.StatusOK:
+		return nil, errors.Errorf("failed to read the secret %s from vault (%d): %s", ref.Path, resp.StatusCode, strings.Join(res.Errors, "; "))
+	case res.Data.Data == nil:
+		// the data of the version deleted or destroyed is null
+		return nil, errors.Errorf("the secret %s of version (%s) is deleted in vault", ref.Path, ref.Version)
+	}
+	data := map[string][]byte{}
+	for k, val := range res.Data.Data {
+		if s, ok := val.(string); ok {
+			data[k] = []byte(s)
+			continue
+		}
+		b, err := json.Marshal(val)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		data[k] = b
+	}
+	return data, nil
+}
+
+// Close Close
+func (v *vaultProvider) Close() error {
+	return nil
+}
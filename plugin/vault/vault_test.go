@@ -0,0 +1,85 @@
+package vault
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestVaultGetSecretData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		assert.Equal(t, "team", r.Header.Get("X-Vault-Namespace"))
+		switch r.URL.Path + "?" + r.URL.RawQuery {
+		case "/v1/kv/data/app/db?":
+			w.Write([]byte(`{"data":{"data":{"username":"baetyl","password":"secret","port":3306},"metadata":{"version":3}}}`))
+		case "/v1/kv/data/app/db?version=2":
+			w.Write([]byte(`{"data":{"data":null,"metadata":{"version":2,"deletion_time":"2021-01-01T00:00:00Z"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	v := &vaultProvider{client: &http.Client{Timeout: time.Second}}
+	v.cfg.Vault.Address = server.URL + "/"
+	v.cfg.Vault.Token = "s.token"
+	v.cfg.Vault.Mount = "/kv/"
+	v.cfg.Vault.Namespace = "team"
+
+	data, err := v.GetSecretData(&models.SecretExternal{Provider: "vaultkv", Path: "/app/db"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"username": []byte("baetyl"),
+		"password": []byte("secret"),
+		"port":     []byte("3306"),
+	}, data)
+
+	_, err = v.GetSecretData(&models.SecretExternal{Provider: "vaultkv", Path: "app/db", Version: "2"})
+	assert.Error(t, err)
+	_, err = v.GetSecretData(&models.SecretExternal{Provider: "vaultkv", Path: "app/db", Version: "latest"})
+	assert.Error(t, err)
+	_, err = v.GetSecretData(&models.SecretExternal{Provider: "vaultkv", Path: "app/none"})
+	assert.EqualError(t, err, "the secret app/none is not found in vault")
+
+	v.cfg.Vault.Token = "typo"
+	_, err = v.GetSecretData(&models.SecretExternal{Provider: "vaultkv", Path: "app/db"})
+	assert.EqualError(t, err, "failed to read the secret app/db from vault (403): permission denied")
+
+	assert.NoError(t, v.Close())
+}
+
+func TestNew(t *testing.T) {
+	conf := `
+vault:
+  address: http://127.0.0.1:8200
+  token: s.token
+`
+	filename := "cloud.yml"
+	err := ioutil.WriteFile(filename, []byte(conf), 0644)
+	assert.NoError(t, err)
+	defer os.Remove(filename)
+	common.SetConfFile(filename)
+
+	p, err := New()
+	assert.NoError(t, err)
+	_, ok := p.(plugin.SecretProvider)
+	assert.True(t, ok)
+	v := p.(*vaultProvider)
+	assert.Equal(t, "secret", v.cfg.Vault.Mount)
+	assert.Equal(t, 10*time.Second, v.client.Timeout)
+}
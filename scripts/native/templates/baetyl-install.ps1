@@ -1,4 +1,4 @@
-$Addr="{{GetProperty "init-server-address"}}"
+$Addr="{{GetEndpoint $ "init-server-address"}}"
 $DeployYaml="{{.InitApplyYaml}}"
 $DbPath='{{.DBPath}}'
 $Token="{{.Token}}"
@@ -23,13 +23,39 @@ function Remove-DbFile {
     }
 }
 
+function Get-Fingerprint {
+    $MachineId = (Get-ItemProperty -Path 'HKLM:\SOFTWARE\Microsoft\Cryptography' -Name MachineGuid -ErrorAction SilentlyContinue).MachineGuid
+    $Macs = (Get-NetAdapter -Physical -ErrorAction SilentlyContinue | ForEach-Object { $_.MacAddress.Replace('-', ':') }) -join ','
+    $TpmEkHash = ''
+    $EkInfo = Get-TpmEndorsementKeyInfo -HashAlgorithm sha256 -ErrorAction SilentlyContinue
+    if ($EkInfo) {
+        $TpmEkHash = $EkInfo.PublicKeyHash
+    }
+    return "machineId=$MachineId&macs=$Macs&tpmEkHash=$TpmEkHash"
+}
+
+# the steps of onboarding are diagnostic, the failure to report them fails nothing
+function Send-Step($Name, $Status, $Reason) {
+    $Body = @{ token = $Token; name = $Name; status = $Status; reason = $Reason } | ConvertTo-Json
+    try {
+        Invoke-RestMethod -Method Post -Uri "$Addr/v1/onboarding" -ContentType 'application/json' -Body $Body | Out-Null
+    } catch {
+    }
+}
+
 function Install-Baetyl {
     Remove-DbFile
+    $Fingerprint = Get-Fingerprint
     if ($Mode -eq "native") {
         Write-Host "baetyl install in native mode"
         if (Get-Command baetyl -ErrorAction SilentlyContinue) {
             baetyl delete
-            baetyl apply -f "$Addr/v1/init/$($DeployYaml)?token=$Token" --skip-verify=true
+            baetyl apply -f "$Addr/v1/init/$($DeployYaml)?token=$Token&$Fingerprint" --skip-verify=true
+            if ($LASTEXITCODE -eq 0) {
+                Send-Step 'downloaded' 'succeeded' ''
+            } else {
+                Send-Step 'downloaded' 'failed' 'failed to apply the manifests of baetyl-init'
+            }
         } else {
             Write-Warning "baetyl not installed yet, please install baetyl firstly"
             Break Script
@@ -1,6 +1,6 @@
 #!/bin/sh
 
-ADDR="{{GetProperty "init-server-address"}}"
+ADDR="{{GetEndpoint $ "init-server-address"}}"
 DEPLOYYML="{{.InitApplyYaml}}"
 DB_PATH='{{.DBPath}}'
 TOKEN="{{.Token}}"
@@ -48,20 +48,85 @@ kube_apply() {
   else
     exec_cmd_nobail "curl -skfL \"$1\" >$TempFile" $SUDO
   fi
-  exec_cmd_nobail "kubectl apply -f $TempFile" $SUDO
+  if exec_cmd_nobail "kubectl apply -f $TempFile" $SUDO; then
+    report_step downloaded succeeded
+  else
+    report_step downloaded failed "failed to apply the manifests of baetyl-init"
+  fi
   exec_cmd_nobail "rm -f $TempFile 2>/dev/null" $SUDO
 }
 
+# post_json posts the json body to cloud and prints the response
+post_json() {
+  if [ "$download_tool" = "wget" ]; then
+    wget --no-check-certificate -q -O - --header="Content-Type: application/json" --post-data="$2" "$1" 2>/dev/null
+  else
+    curl -skf -X POST -H "Content-Type: application/json" -d "$2" "$1" 2>/dev/null
+  fi
+}
+
+# report_step reports the step of onboarding to cloud, which is diagnostic and fails nothing
+report_step() {
+  post_json "$ADDR/v1/onboarding" "{\"token\":\"$TOKEN\",\"name\":\"$1\",\"status\":\"$2\",\"reason\":\"$3\"}" >/dev/null
+}
+
+# preflight_check uploads the facts of host before activation, the install stops if cloud refuses the host
+preflight_check() {
+  CGROUP_DRIVER=""
+  if [ $MODE = "kube" ] && [ -x "$(command -v docker)" ]; then
+    CGROUP_DRIVER=$($SUDO docker info 2>/dev/null | grep -i "cgroup driver" | awk -F': ' '{print $2}')
+  fi
+  DISK_PATH=$DB_PATH
+  while [ ! -d "$DISK_PATH" ]; do
+    DISK_PATH=$(dirname "$DISK_PATH")
+  done
+  DISK_FREE=$(df -Pm "$DISK_PATH" 2>/dev/null | awk 'NR==2 {print $4}')
+  RESULT=$(post_json "$ADDR/v1/preflight" "{\"token\":\"$TOKEN\",\"os\":\"$(uname -s)\",\"kernel\":\"$(uname -r)\",\"arch\":\"$(uname -m)\",\"cgroupDriver\":\"$CGROUP_DRIVER\",\"diskFree\":${DISK_FREE:-0}}")
+  if echo "$RESULT" | grep -q '"refused":true'; then
+    print_status "the host is refused by the preflight checks: $RESULT"
+    exit 1
+  fi
+  if echo "$RESULT" | grep -q '"passed":false\|"warnings"'; then
+    print_status "preflight checks: $RESULT"
+  fi
+}
+
+FINGERPRINT=""
+get_fingerprint() {
+  MACHINE_ID=$(cat /etc/machine-id 2>/dev/null || cat /var/lib/dbus/machine-id 2>/dev/null)
+  MACS=""
+  for dev in /sys/class/net/*; do
+    if [ -e "$dev/device" ]; then
+      MACS="$MACS${MACS:+,}$(cat $dev/address)"
+    fi
+  done
+  TPM_EK_HASH=""
+  if [ -x "$(command -v tpm2_readpublic)" ]; then
+    EkFile=$(mktemp ek.XXXXXX)
+    if $SUDO tpm2_readpublic -c 0x81010001 -o $EkFile >/dev/null 2>&1; then
+      TPM_EK_HASH=$(sha256sum $EkFile | cut -d' ' -f1)
+    fi
+    rm -f $EkFile 2>/dev/null
+  fi
+  FINGERPRINT="machineId=$MACHINE_ID&macs=$MACS&tpmEkHash=$TPM_EK_HASH"
+}
+
 install_baetyl() {
   dbfile_clean
+  get_fingerprint
+  preflight_check
   if [ $MODE = "kube" ]; then
     print_status "baetyl install in k8s mode"
     kube_clean
-    kube_apply "$ADDR/v1/init/$DEPLOYYML?token=$TOKEN"
+    kube_apply "$ADDR/v1/init/$DEPLOYYML?token=$TOKEN&$FINGERPRINT"
   elif [ $MODE = "native" ]; then
     print_status "baetyl install in native mode"
     exec_cmd_nobail "baetyl delete" $SUDO
-    exec_cmd_nobail "baetyl apply -f '$ADDR/v1/init/$DEPLOYYML?token=$TOKEN' --skip-verify=true" $SUDO
+    if exec_cmd_nobail "baetyl apply -f '$ADDR/v1/init/$DEPLOYYML?token=$TOKEN&$FINGERPRINT' --skip-verify=true" $SUDO; then
+      report_step downloaded succeeded
+    else
+      report_step downloaded failed "failed to apply the manifests of baetyl-init"
+    fi
   else
     print_status "Not supported install mode $MODE"
     exit 0
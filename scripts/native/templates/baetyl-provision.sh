@@ -0,0 +1,78 @@
+#!/bin/sh
+
+CONF=/etc/baetyl/baetyl-provision.conf
+DONE=/var/lib/baetyl/provisioned
+
+print_status() {
+  echo "## $1"
+}
+
+if [ -f $DONE ]; then
+  exit 0
+fi
+. $CONF
+
+# the devices imaged from the same golden image share the machine id, the fingerprint is the serial
+# of board or the address of the first network device instead
+FINGERPRINT=""
+get_fingerprint() {
+  FINGERPRINT=$(cat /sys/class/dmi/id/product_serial 2>/dev/null || cat /sys/firmware/devicetree/base/serial-number 2>/dev/null)
+  FINGERPRINT=$(echo "$FINGERPRINT" | tr -d '\000 ')
+  if [ -z "$FINGERPRINT" ] || [ "$FINGERPRINT" = "0" ]; then
+    for dev in /sys/class/net/*; do
+      if [ -e "$dev/device" ]; then
+        FINGERPRINT=$(cat $dev/address)
+        break
+      fi
+    done
+  fi
+}
+
+# json_value prints the string field $2 of the json $1, the escapes of go encoder are decoded
+json_value() {
+  echo "$1" | sed -n "s/.*\"$2\":\"\(\([^\"\\\\]\|\\\\.\)*\)\".*/\1/p" |
+    sed -e 's/\\u0026/\&/g' -e 's/\\u003c/</g' -e 's/\\u003e/>/g' -e 's/\\"/"/g' -e 's/\\\\/\\/g'
+}
+
+register() {
+  BODY="{\"token\":\"$TOKEN\",\"fingerprint\":\"$FINGERPRINT\",\"mode\":\"$MODE\",\"hardware\":{\"model\":\"$(cat /sys/class/dmi/id/product_name 2>/dev/null | tr -d '"')\",\"arch\":\"$(uname -m)\"}}"
+  if [ -x "$(command -v curl)" ]; then
+    curl -skfL -X POST -H "Content-Type: application/json" -d "$BODY" "$ADDR/v1/registrations"
+  else
+    wget --no-check-certificate -q -O - --header="Content-Type: application/json" --post-data="$BODY" "$ADDR/v1/registrations"
+  fi
+}
+
+provision() {
+  get_fingerprint
+  if [ -z "$FINGERPRINT" ]; then
+    print_status "no fingerprint of device"
+    exit 1
+  fi
+  print_status "register device $FINGERPRINT"
+  while true; do
+    RES=$(register)
+    STATUS=$(json_value "$RES" status)
+    if [ "$STATUS" = "approved" ]; then
+      break
+    elif [ "$STATUS" = "rejected" ]; then
+      print_status "registration rejected: $(json_value "$RES" reason)"
+      exit 1
+    fi
+    print_status "registration ${STATUS:-failed}, retry in $INTERVAL seconds"
+    sleep $INTERVAL
+  done
+  COMMAND=$(json_value "$RES" command)
+  print_status "registration approved as node $(json_value "$RES" name)"
+  WORKDIR=$(mktemp -d)
+  cd $WORKDIR && sh -c "$COMMAND"
+  RET=$?
+  cd / && rm -rf $WORKDIR
+  if [ $RET -ne 0 ]; then
+    print_status "install failed"
+    exit $RET
+  fi
+  mkdir -p $(dirname $DONE) && touch $DONE
+}
+
+provision
@@ -1,8 +1,15 @@
 package server
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/baetyl/baetyl-go/v2/errors"
 	"github.com/baetyl/baetyl-go/v2/log"
@@ -11,6 +18,7 @@ import (
 	"github.com/baetyl/baetyl-cloud/v2/api"
 	"github.com/baetyl/baetyl-cloud/v2/common"
 	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 	"github.com/baetyl/baetyl-cloud/v2/plugin"
 	"github.com/baetyl/baetyl-cloud/v2/service"
 )
@@ -19,6 +27,12 @@ import (
 type AdminServer struct {
 	Auth             service.AuthService
 	License          service.LicenseService
+	Audit            service.AuditService
+	Idempotency      service.IdempotencyService
+	Tenant           service.TenantService
+	ReadOnly         service.ReadOnlyService
+	FeatureFlag      service.FeatureFlagService
+	Impersonation    service.ImpersonationService
 	ExternalHandlers []gin.HandlerFunc
 
 	cfg    *config.CloudConfig
@@ -32,6 +46,20 @@ var (
 	NodeCollector plugin.QuotaCollector
 )
 
+// keyImpersonation the key of the impersonation authenticating the request in context
+const keyImpersonation = "impersonation"
+
+// impersonationUnsafeReads the GET routes which do more than read, such as opening shells on nodes and handing out
+// the bundles and credentials of nodes, they are refused as the writes unless the impersonation is mutable
+var impersonationUnsafeReads = map[string]bool{
+	"/v1/nodes/:name/exec":                    true,
+	"/v1/nodes/:name/bundle":                  true,
+	"/v1/nodes/:name/init":                    true,
+	"/v1/edgeclusters/:name/init":             true,
+	"/v1/activationtokens/:name/bootstrap":    true,
+	"/v1/activationtokens/:name/provisioning": true,
+}
+
 // NewAdminServer create admin server
 func NewAdminServer(config *config.CloudConfig) (*AdminServer, error) {
 	auth, err := service.NewAuthService(config)
@@ -44,6 +72,26 @@ func NewAdminServer(config *config.CloudConfig) (*AdminServer, error) {
 		return nil, err
 	}
 
+	idempotency, err := service.NewIdempotencyService(config)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := service.NewTenantService(config)
+	if err != nil {
+		return nil, err
+	}
+
+	readOnly, err := service.NewReadOnlyService(config)
+	if err != nil {
+		return nil, err
+	}
+
+	featureFlag, err := service.NewFeatureFlagService(config)
+	if err != nil {
+		return nil, err
+	}
+
 	router := gin.New()
 	server := &http.Server{
 		Addr:           config.AdminServer.Port,
@@ -53,12 +101,16 @@ func NewAdminServer(config *config.CloudConfig) (*AdminServer, error) {
 		MaxHeaderBytes: 1 << 20,
 	}
 	return &AdminServer{
-		cfg:     config,
-		router:  router,
-		server:  server,
-		Auth:    auth,
-		License: ls,
-		log:     log.L().With(log.Any("server", "AdminServer")),
+		cfg:         config,
+		router:      router,
+		server:      server,
+		Auth:        auth,
+		License:     ls,
+		Idempotency: idempotency,
+		Tenant:      tenant,
+		ReadOnly:    readOnly,
+		FeatureFlag: featureFlag,
+		log:         log.L().With(log.Any("server", "AdminServer")),
 	}, nil
 }
 
@@ -68,8 +120,11 @@ func (s *AdminServer) Run() {
 	}
 }
 
+// SetAPI sets the api, whose audit is shared with the exec sessions and the impersonations
 func (s *AdminServer) SetAPI(api *api.API) {
 	s.api = api
+	s.Audit = api.Audit
+	s.Impersonation = api.Impersonation
 }
 
 // Close close server
@@ -83,10 +138,39 @@ func (s *AdminServer) InitRoute() {
 	s.router.NoRoute(NoRouteHandler)
 	s.router.NoMethod(NoMethodHandler)
 	s.router.GET("/health", Health)
+	s.router.GET("/metrics", Metrics)
 
 	s.router.Use(RequestIDHandler)
 	s.router.Use(LoggerHandler)
+	s.router.Use(AccessLogHandler("admin"))
+	s.router.Use(TimeoutHandler(s.cfg.AdminServer))
+	if rule := s.cfg.RateLimit.IP; rule.QPS > 0 {
+		s.router.Use(common.WrapperRateLimit(common.NewRateLimiter(rule.QPS, rule.Burst), common.GetRateLimitKeyFunc(common.RateLimitKeyIP)))
+	}
 	s.router.Use(s.AuthHandler)
+	// namespace is known only after authentication
+	if rule := s.cfg.RateLimit.Namespace; rule.QPS > 0 {
+		s.router.Use(common.WrapperRateLimit(common.NewRateLimiter(rule.QPS, rule.Burst), common.GetRateLimitKeyFunc(common.RateLimitKeyNamespace)))
+	}
+	if s.Audit != nil {
+		s.router.Use(s.AuditHandler)
+	}
+	if s.Impersonation != nil {
+		s.router.Use(s.ImpersonationHandler)
+	}
+	if s.FeatureFlag != nil {
+		s.router.Use(s.FeatureFlagHandler)
+	}
+	// the writes refused are audited, but never saved for replay
+	if s.ReadOnly != nil {
+		s.router.Use(s.ReadOnlyHandler)
+	}
+	if s.Tenant != nil {
+		s.router.Use(s.TenantHandler)
+	}
+	if s.Idempotency != nil {
+		s.router.Use(s.IdempotencyHandler)
+	}
 	s.router.Use(s.ExternalHandlers...)
 
 	NodeCollector = s.api.NodeNumberCollector
@@ -100,16 +184,21 @@ func (s *AdminServer) InitRoute() {
 		configs.POST("", common.WrapperRaw(s.api.ValidateResourceForCreating, true), common.Wrapper(s.api.CreateConfig))
 		configs.GET("", common.Wrapper(s.api.ListConfig))
 		configs.GET("/:name/apps", common.Wrapper(s.api.GetAppByConfig))
+		configs.GET("/:name/references", common.Wrapper(s.api.GetConfigReferences))
+		configs.GET("/export", common.WrapperNative(s.api.ExportConfigs, true))
+		configs.POST("/import", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.ImportConfigs))
 	}
 	{
 		registry := v1.Group("/registries")
 		registry.GET("/:name", common.Wrapper(s.api.GetRegistry))
 		registry.PUT("/:name", common.Wrapper(s.api.UpdateRegistry))
 		registry.POST("/:name/refresh", common.Wrapper(s.api.RefreshRegistryPassword))
+		registry.POST("/:name/verify", common.Wrapper(s.api.VerifyRegistry))
 		registry.DELETE("/:name", common.WrapperRaw(s.api.ValidateResourceForDeleting, true), common.Wrapper(s.api.DeleteRegistry))
 		registry.POST("", common.WrapperRaw(s.api.ValidateResourceForCreating, true), common.Wrapper(s.api.CreateRegistry))
 		registry.GET("", common.Wrapper(s.api.ListRegistry))
 		registry.GET("/:name/apps", common.Wrapper(s.api.GetAppByRegistry))
+		registry.GET("/:name/references", common.Wrapper(s.api.GetRegistryReferences))
 	}
 	{
 		certificate := v1.Group("/certificates")
@@ -128,19 +217,58 @@ func (s *AdminServer) InitRoute() {
 		configs.POST("", common.WrapperRaw(s.api.ValidateResourceForCreating, true), common.Wrapper(s.api.CreateSecret))
 		configs.GET("", common.Wrapper(s.api.ListSecret))
 		configs.GET("/:name/apps", common.Wrapper(s.api.GetAppBySecret))
+		configs.GET("/:name/references", common.Wrapper(s.api.GetSecretReferences))
+		configs.POST("/:name/rotate", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.RotateSecret))
+		configs.DELETE("/:name/rotation", common.Wrapper(s.api.DeleteSecretRotation))
 	}
 	{
 		nodes := v1.Group("/nodes")
 		nodes.GET("/:name", common.Wrapper(s.api.GetNode))
 		nodes.PUT("", common.Wrapper(s.api.GetNodes))
+		nodes.PATCH("/labels", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.PatchNodeLabels))
 		nodes.GET("/:name/apps", common.Wrapper(s.api.GetAppByNode))
+		nodes.GET("/:name/diff", common.Wrapper(s.api.GetNodeDiff))
+		nodes.GET("/:name/exec", common.WrapperNative(s.api.ExecNode, true))
+		nodes.GET("/:name/drain", common.Wrapper(s.api.GetNodeDrainStatus))
+		nodes.POST("/:name/drain", common.Wrapper(s.api.DrainNode))
+		nodes.POST("/:name/uncordon", common.Wrapper(s.api.UncordonNode))
 		nodes.GET("/:name/stats", common.Wrapper(s.api.GetNodeStats))
+		nodes.GET("/:name/health", common.Wrapper(s.api.GetNodeHealth))
+		nodes.GET("/:name/metrics", common.Wrapper(s.api.GetNodeMetrics))
+		nodes.GET("/:name/certificate", common.Wrapper(s.api.GetNodeCertificate))
+		nodes.GET("/:name/bundle", common.WrapperNative(s.api.ExportNodeBundle, true))
+		nodes.POST("/:name/bundle/report", common.Wrapper(s.api.ImportNodeReport))
+		nodes.POST("/:name/certificate/rotate", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.RotateNodeCertificate))
+		nodes.GET("/:name/accelerators", common.Wrapper(s.api.GetNodeAccelerators))
+		nodes.GET("/:name/maintenance", common.Wrapper(s.api.GetNodeMaintenance))
+		nodes.PUT("/:name/maintenance", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpdateNodeMaintenance))
+		nodes.DELETE("/:name/maintenance", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.DeleteNodeMaintenance))
+		nodes.GET("/:name/interval", common.Wrapper(s.api.GetNodeSyncInterval))
+		nodes.GET("/:name/sync-history", common.Wrapper(s.api.GetNodeSyncHistory))
+		nodes.GET("/:name/conflicts", common.Wrapper(s.api.ListNodeAppConflicts))
+		nodes.PUT("/:name/conflicts/:app", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.ResolveNodeAppConflict))
+		nodes.PUT("/:name/interval", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpdateNodeSyncInterval))
+		nodes.DELETE("/:name/interval", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.DeleteNodeSyncInterval))
+		nodes.GET("/:name/protocol", common.Wrapper(s.api.GetNodeSyncProtocol))
+		nodes.GET("/:name/endpoints", common.Wrapper(s.api.GetNodeEndpoints))
+		nodes.PUT("/:name/endpoints", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpdateNodeEndpoints))
+		nodes.DELETE("/:name/endpoints", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.DeleteNodeEndpoints))
+		nodes.GET("/:name/seed", common.Wrapper(s.api.GetNodeSiteSeed))
+		nodes.PUT("/:name/seed", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpdateNodeSiteSeed))
+		nodes.DELETE("/:name/seed", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.DeleteNodeSiteSeed))
+		nodes.GET("/:name/fingerprint", common.Wrapper(s.api.GetNodeFingerprint))
+		nodes.DELETE("/:name/fingerprint", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.ResetNodeFingerprint))
+		nodes.GET("/:name/onboarding", common.Wrapper(s.api.GetNodeOnboarding))
+		nodes.GET("/:name/preflight", common.Wrapper(s.api.GetNodePreflight))
 		nodes.PUT("/:name", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpdateNode))
 		nodes.DELETE("/:name", common.Wrapper(s.api.DeleteNode))
 		nodes.POST("", s.NodeQuotaHandler, common.Wrapper(s.api.CreateNode))
+		nodes.POST("/batch", s.NodeQuotaHandler, common.Wrapper(s.api.BatchCreateNode))
+		nodes.POST("/import", s.NodeQuotaHandler, common.Wrapper(s.api.ImportNode))
 		nodes.GET("", common.Wrapper(s.api.ListNode))
 		nodes.GET("/:name/deploys", common.Wrapper(s.api.GetNodeDeployHistory))
 		nodes.GET("/:name/init", common.Wrapper(s.api.GenInitCmdFromNode))
+		nodes.POST("/:name/reactivate", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.ReactivateNode))
 		nodes.PUT("/:name/mode", common.Wrapper(s.api.UpdateNodeMode))
 		nodes.PUT("/:name/properties", common.Wrapper(s.api.UpdateNodeProperties))
 		nodes.GET("/:name/properties", common.Wrapper(s.api.GetNodeProperties))
@@ -148,6 +276,68 @@ func (s *AdminServer) InitRoute() {
 		nodes.GET("/:name/core/configs", common.Wrapper(s.api.GetCoreAppConfigs))
 		nodes.GET("/:name/core/versions", common.Wrapper(s.api.GetCoreAppVersions))
 	}
+	{
+		groups := v1.Group("/nodegroups")
+		groups.GET("/:name", common.Wrapper(s.api.GetNodeGroup))
+		groups.PUT("/:name", common.Wrapper(s.api.UpdateNodeGroup))
+		groups.DELETE("/:name", common.Wrapper(s.api.DeleteNodeGroup))
+		groups.POST("", common.Wrapper(s.api.CreateNodeGroup))
+		groups.GET("", common.Wrapper(s.api.ListNodeGroup))
+	}
+	{
+		tokens := v1.Group("/activationtokens")
+		tokens.GET("", common.Wrapper(s.api.ListActivationToken))
+		tokens.GET("/:name", common.Wrapper(s.api.GetActivationToken))
+		tokens.POST("", common.Wrapper(s.api.IssueActivationToken))
+		tokens.DELETE("/:name", common.Wrapper(s.api.RevokeActivationToken))
+		tokens.GET("/:name/bootstrap", common.Wrapper(s.api.GetBootstrapCredential))
+		tokens.GET("/:name/provisioning", common.WrapperNative(s.api.GetProvisioningBundle, true))
+	}
+	{
+		registrations := v1.Group("/node-registrations")
+		registrations.GET("", common.Wrapper(s.api.ListNodeRegistration))
+		registrations.GET("/:name", common.Wrapper(s.api.GetNodeRegistration))
+		registrations.POST("/:name/approve", s.NodeQuotaHandler, common.Wrapper(s.api.ApproveNodeRegistration))
+		registrations.POST("/:name/reject", common.Wrapper(s.api.RejectNodeRegistration))
+		registrations.DELETE("/:name", common.Wrapper(s.api.DeleteNodeRegistration))
+	}
+	{
+		rules := v1.Group("/node-registration-rules")
+		rules.GET("", common.Wrapper(s.api.ListNodeRegistrationRule))
+		rules.GET("/:name", common.Wrapper(s.api.GetNodeRegistrationRule))
+		rules.POST("", common.Wrapper(s.api.CreateNodeRegistrationRule))
+		rules.DELETE("/:name", common.Wrapper(s.api.DeleteNodeRegistrationRule))
+	}
+	{
+		clusters := v1.Group("/edgeclusters")
+		clusters.GET("/:name", common.Wrapper(s.api.GetEdgeCluster))
+		clusters.GET("/:name/init", common.Wrapper(s.api.GetEdgeClusterInitCmd))
+		clusters.PUT("/:name", common.Wrapper(s.api.UpdateEdgeCluster))
+		clusters.DELETE("/:name", common.Wrapper(s.api.DeleteEdgeCluster))
+		clusters.POST("", s.NodeQuotaHandler, common.Wrapper(s.api.CreateEdgeCluster))
+		clusters.GET("", common.Wrapper(s.api.ListEdgeCluster))
+	}
+	{
+		filters := v1.Group("/nodefilters")
+		filters.GET("/:name", common.Wrapper(s.api.GetNodeFilter))
+		filters.PUT("/:name", common.Wrapper(s.api.UpdateNodeFilter))
+		filters.DELETE("/:name", common.Wrapper(s.api.DeleteNodeFilter))
+		filters.POST("", common.Wrapper(s.api.CreateNodeFilter))
+		filters.GET("", common.Wrapper(s.api.ListNodeFilter))
+	}
+	{
+		health := v1.Group("/health/threshold")
+		health.GET("", common.Wrapper(s.api.GetHealthThreshold))
+		health.PUT("", common.Wrapper(s.api.UpdateHealthThreshold))
+		health.DELETE("", common.Wrapper(s.api.DeleteHealthThreshold))
+	}
+	{
+		heartbeat := v1.Group("/heartbeat")
+		heartbeat.GET("/policy", common.Wrapper(s.api.GetHeartbeatPolicy))
+		heartbeat.PUT("/policy", common.Wrapper(s.api.UpdateHeartbeatPolicy))
+		heartbeat.DELETE("/policy", common.Wrapper(s.api.DeleteHeartbeatPolicy))
+		heartbeat.GET("/events", common.Wrapper(s.api.ListNodeEvent))
+	}
 	{
 		apps := v1.Group("/apps")
 		apps.GET("/:name", common.Wrapper(s.api.GetApplication))
@@ -155,8 +345,22 @@ func (s *AdminServer) InitRoute() {
 		apps.GET("/:name/secrets", common.Wrapper(s.api.GetSysAppSecrets))
 		apps.GET("/:name/certificates", common.Wrapper(s.api.GetSysAppCertificates))
 		apps.GET("/:name/registries", common.Wrapper(s.api.GetSysAppRegistries))
+		apps.GET("/:name/revisions", common.Wrapper(s.api.ListAppRevision))
+		apps.GET("/:name/revisions/:revision", common.Wrapper(s.api.GetAppRevision))
+		apps.POST("/:name/rollback", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.RollbackApplication))
+		apps.GET("/:name/rollout", common.Wrapper(s.api.GetAppRollout))
+		apps.POST("/:name/rollout/pause", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.PauseAppRollout))
+		apps.POST("/:name/rollout/resume", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.ResumeAppRollout))
+		apps.POST("/:name/preview", common.Wrapper(s.api.PreviewApplication))
 		apps.PUT("/:name", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpdateApplication))
 		apps.DELETE("/:name", common.WrapperRaw(s.api.ValidateResourceForDeleting, true), common.Wrapper(s.api.DeleteApplication))
+		apps.POST("/compose", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.ImportComposeApplication))
+		apps.POST("/:name/clone", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.CloneApplication))
+		apps.POST("/:name/pause", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.PauseApplication))
+		apps.POST("/:name/resume", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.ResumeApplication))
+		apps.GET("/:name/instances", common.Wrapper(s.api.ListAppInstance))
+		apps.POST("/:name/instances/:instance/restart", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.RestartAppInstance))
+		apps.GET("/:name/instances/:instance/log", common.WrapperNative(s.api.GetAppInstanceLog, true))
 		apps.POST("", common.WrapperRaw(s.api.ValidateResourceForCreating, true), common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.CreateApplication))
 		apps.GET("", common.Wrapper(s.api.ListApplication))
 	}
@@ -174,6 +378,37 @@ func (s *AdminServer) InitRoute() {
 			function.GET("/:source/functions/:name/versions", common.Wrapper(s.api.ListFunctionVersions))
 			function.POST("/:source/functions/:name/versions/:version", common.Wrapper(s.api.ImportFunction))
 		}
+		// POST /functions/{name}/invoke, the name of function takes the wildcard of source
+		function.POST("/:source/invoke", common.Wrapper(s.api.InvokeFunction))
+	}
+	if s.cfg.FunctionBuild.Builder != "" {
+		builds := v1.Group("/functionbuilds")
+		builds.GET("/:name/versions", common.Wrapper(s.api.ListFunctionBuilds))
+		builds.GET("/:name/versions/:version", common.Wrapper(s.api.GetFunctionBuild))
+		builds.POST("/:name/versions/:version", common.Wrapper(s.api.BuildFunction))
+		builds.DELETE("/:name/versions/:version", common.Wrapper(s.api.DeleteFunctionBuild))
+	}
+	{
+		drafts := v1.Group("/functiondrafts")
+		drafts.GET("", common.Wrapper(s.api.ListFunctionDrafts))
+		drafts.GET("/:name", common.Wrapper(s.api.GetFunctionDraft))
+		drafts.POST("", common.Wrapper(s.api.CreateFunctionDraft))
+		drafts.PUT("/:name", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpdateFunctionDraft))
+		drafts.DELETE("/:name", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.DeleteFunctionDraft))
+		drafts.GET("/:name/files/*path", common.Wrapper(s.api.GetFunctionDraftFile))
+		drafts.PUT("/:name/files/*path", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.SaveFunctionDraftFile))
+		drafts.DELETE("/:name/files/*path", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.DeleteFunctionDraftFile))
+		drafts.POST("/:name/invoke", common.Wrapper(s.api.InvokeFunctionDraft))
+		drafts.GET("/:name/versions", common.Wrapper(s.api.ListFunctionDraftVersions))
+		drafts.GET("/:name/versions/:version", common.Wrapper(s.api.GetFunctionDraftVersion))
+		drafts.POST("/:name/versions", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.PublishFunctionDraft))
+		drafts.POST("/:name/versions/:version/import", common.Wrapper(s.api.ImportFunctionDraftVersion))
+		drafts.POST("/:name/bundles", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.PublishFunctionDraftBundle))
+	}
+	{
+		runtime := v1.Group("/function-runtimes")
+		runtime.GET("", common.Wrapper(s.api.ListFunctionRuntime))
+		runtime.GET("/:name", common.Wrapper(s.api.GetFunctionRuntime))
 	}
 	{
 		// Deprecated
@@ -231,6 +466,9 @@ func (s *AdminServer) InitRoute() {
 		module.PUT("/:name/version/:version", common.Wrapper(s.api.UpdateModule))
 		module.DELETE("/:name", common.Wrapper(s.api.DeleteModules))
 		module.DELETE("/:name/version/:version", common.Wrapper(s.api.DeleteModules))
+		module.GET("/:name/version/:version/artifacts", common.Wrapper(s.api.GetModuleArtifacts))
+		module.PUT("/:name/version/:version/artifacts", common.Wrapper(s.api.UpdateModuleArtifacts))
+		module.DELETE("/:name/version/:version/artifacts", common.Wrapper(s.api.DeleteModuleArtifacts))
 	}
 	{
 		quotas := v1.Group("/quotas")
@@ -242,6 +480,64 @@ func (s *AdminServer) InitRoute() {
 		yaml.PUT("", common.Wrapper(s.api.UpdateYamlResource))
 		yaml.POST("/delete", common.Wrapper(s.api.DeleteYamlResource))
 	}
+	{
+		helm := v1.Group("/helmreleases")
+		helm.GET("", common.Wrapper(s.api.ListHelmRelease))
+		helm.GET("/:name", common.Wrapper(s.api.GetHelmRelease))
+		helm.POST("", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.ImportHelmChart))
+		helm.PUT("/:name", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpgradeHelmRelease))
+		helm.DELETE("/:name", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.DeleteHelmRelease))
+	}
+	{
+		envGroups := v1.Group("/envgroups")
+		envGroups.GET("", common.Wrapper(s.api.ListEnvGroup))
+		envGroups.GET("/:name", common.Wrapper(s.api.GetEnvGroup))
+		envGroups.GET("/:name/apps", common.Wrapper(s.api.GetAppByEnvGroup))
+		envGroups.POST("", common.Wrapper(s.api.CreateEnvGroup))
+		envGroups.PUT("/:name", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpdateEnvGroup))
+		envGroups.DELETE("/:name", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.DeleteEnvGroup))
+	}
+	{
+		installTemplates := v1.Group("/installtemplates")
+		installTemplates.GET("", common.Wrapper(s.api.ListInstallTemplate))
+		installTemplates.GET("/:name", common.Wrapper(s.api.GetInstallTemplate))
+		installTemplates.GET("/:name/default", common.Wrapper(s.api.GetDefaultInstallTemplate))
+		installTemplates.POST("", common.Wrapper(s.api.CreateInstallTemplate))
+		installTemplates.PUT("/:name", common.Wrapper(s.api.UpdateInstallTemplate))
+		installTemplates.DELETE("/:name", common.Wrapper(s.api.DeleteInstallTemplate))
+	}
+	{
+		sidecars := v1.Group("/sidecarpolicies")
+		sidecars.GET("", common.Wrapper(s.api.ListSidecarPolicy))
+		sidecars.GET("/:name", common.Wrapper(s.api.GetSidecarPolicy))
+		sidecars.POST("", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.CreateSidecarPolicy))
+		sidecars.PUT("/:name", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpdateSidecarPolicy))
+		sidecars.DELETE("/:name", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.DeleteSidecarPolicy))
+	}
+	{
+		images := v1.Group("/imagepolicy")
+		images.GET("", common.Wrapper(s.api.GetImagePolicy))
+		images.PUT("", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpdateImagePolicy))
+		images.DELETE("", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.DeleteImagePolicy))
+	}
+	{
+		endpoints := v1.Group("/nodeendpoints")
+		endpoints.GET("", common.Wrapper(s.api.GetNamespaceEndpoints))
+		endpoints.PUT("", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.UpdateNamespaceEndpoints))
+		endpoints.DELETE("", common.WrapperWithLock(s.api.Locker.Lock, s.api.Locker.Unlock), common.Wrapper(s.api.DeleteNamespaceEndpoints))
+	}
+	{
+		services := v1.Group("/services")
+		services.GET("", common.Wrapper(s.api.ListServiceRecord))
+	}
+	{
+		announcements := v1.Group("/announcements")
+		announcements.GET("", common.Wrapper(s.api.ListActiveAnnouncement))
+	}
+	{
+		features := v1.Group("/features")
+		features.GET("", common.Wrapper(s.api.ListNamespaceFeature))
+	}
 
 	v2 := s.router.Group("v2")
 	{
@@ -264,6 +560,10 @@ func (s *AdminServer) GetRoute() *gin.Engine {
 // auth handler
 func (s *AdminServer) AuthHandler(c *gin.Context) {
 	cc := common.NewContext(c)
+	if token := c.GetHeader(common.HeaderImpersonation); token != "" && s.Impersonation != nil {
+		s.impersonate(cc, token)
+		return
+	}
 	err := s.Auth.Authenticate(cc)
 	if err != nil {
 		s.log.Error("request authenticate failed",
@@ -275,6 +575,226 @@ func (s *AdminServer) AuthHandler(c *gin.Context) {
 	}
 }
 
+// impersonate authenticates the request by the impersonation issued to the operator of mis server, instead of
+// the credentials of the user
+func (s *AdminServer) impersonate(cc *common.Context, token string) {
+	imp, err := s.Impersonation.Verify(token)
+	if err != nil {
+		s.log.Error("request impersonation failed", log.Any(cc.GetTrace()), log.Error(err))
+		common.PopulateFailedResponse(cc, common.Error(common.ErrRequestAccessDenied), true)
+		return
+	}
+	cc.SetNamespace(imp.Namespace)
+	cc.SetUser(common.User{ID: imp.User, Name: imp.User})
+	cc.SetImpersonator(imp.Operator)
+	cc.Set(keyImpersonation, imp)
+}
+
+// ImpersonationHandler refuses the impersonated requests which mutate resources or do more than read unless the
+// impersonation is mutable, it's used after audit so that the requests refused are audited
+func (s *AdminServer) ImpersonationHandler(c *gin.Context) {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		if !impersonationUnsafeReads[c.FullPath()] {
+			return
+		}
+	}
+	val, ok := c.Get(keyImpersonation)
+	if !ok {
+		return
+	}
+	if imp := val.(*models.Impersonation); !imp.Mutable {
+		common.PopulateFailedResponse(common.NewContext(c), common.Error(common.ErrImpersonationReadOnly, common.Field("user", imp.User)), true)
+	}
+}
+
+// AuditHandler audits the requests which mutate resources, after they are handled. The impersonated requests
+// are all audited, including the reads
+func (s *AdminServer) AuditHandler(c *gin.Context) {
+	cc := common.NewContext(c)
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		if cc.GetImpersonator() == "" {
+			return
+		}
+	}
+	c.Next()
+
+	user := cc.GetUser()
+	if user.ID == "" {
+		user.ID = user.Name
+	}
+	_, requestID := cc.GetTrace()
+	record := &models.AuditRecord{
+		Namespace:    cc.GetNamespace(),
+		User:         user.ID,
+		Impersonator: cc.GetImpersonator(),
+		Method:       c.Request.Method,
+		Path:         c.Request.URL.Path,
+		Resource:     getAuditResource(c.FullPath()),
+		Name:         cc.GetNameFromParam(),
+		RequestId:    requestID,
+		Status:       c.Writer.Status(),
+		Code:         cc.GetErrorCode(),
+		Diff:         cc.GetAuditDiff(),
+		CreateTime:   time.Now().UTC(),
+	}
+	if err := s.Audit.Audit(record); err != nil {
+		s.log.Error("failed to audit request", log.Any(cc.GetTrace()), log.Any("path", record.Path), log.Error(err))
+	}
+}
+
+// TenantHandler refuses the requests which mutate resources of the frozen namespace
+func (s *AdminServer) TenantHandler(c *gin.Context) {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return
+	}
+	cc := common.NewContext(c)
+	if err := s.Tenant.CheckWritable(cc.GetNamespace()); err != nil {
+		common.PopulateFailedResponse(cc, err, true)
+	}
+}
+
+// ReadOnlyHandler refuses the requests which mutate resources while the platform is read-only, except the routes
+// of the allowlist
+func (s *AdminServer) ReadOnlyHandler(c *gin.Context) {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return
+	}
+	for _, route := range s.cfg.ReadOnly.Allowlist {
+		if route == c.FullPath() {
+			return
+		}
+	}
+	if err := s.ReadOnly.CheckWritable(); err != nil {
+		common.PopulateFailedResponse(common.NewContext(c), err, true)
+	}
+}
+
+// FeatureFlagHandler resolves the feature flags of the namespace of request into context, the flags are all
+// disabled if they fail to resolve so that the request is still served
+func (s *AdminServer) FeatureFlagHandler(c *gin.Context) {
+	cc := common.NewContext(c)
+	features, err := s.FeatureFlag.Resolve(cc.GetNamespace())
+	if err != nil {
+		s.log.Warn("failed to resolve feature flags", log.Any(cc.GetTrace()), log.Any(common.KeyContextNamespace, cc.GetNamespace()), log.Error(err))
+		features = map[string]bool{}
+	}
+	cc.SetFeatures(features)
+}
+
+// getAuditResource gets the resource kind from route, such as nodes of /v1/nodes/:name
+func getAuditResource(route string) string {
+	parts := strings.Split(strings.Trim(route, "/"), "/")
+	if len(parts) < 2 {
+		return route
+	}
+	return parts[1]
+}
+
+// IdempotencyHandler replays the response of the request with the same Idempotency-Key,
+// the response is not saved if the request fails with server error, so that it can be retried
+func (s *AdminServer) IdempotencyHandler(c *gin.Context) {
+	key := c.GetHeader(common.HeaderIdempotencyKey)
+	if key == "" || (c.Request.Method != http.MethodPost && c.Request.Method != http.MethodPut) {
+		return
+	}
+	cc := common.NewContext(c)
+	var body []byte
+	if c.Request.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(c.Request.Body); err != nil {
+			common.PopulateFailedResponse(cc, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error())), true)
+			return
+		}
+		c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	sum := sha256.Sum256(append([]byte(c.Request.Method+" "+c.Request.URL.RequestURI()+"\n"), body...))
+	hash := hex.EncodeToString(sum[:])
+
+	ns := cc.GetNamespace()
+	record, err := s.Idempotency.Acquire(ns, key, hash)
+	if err != nil {
+		common.PopulateFailedResponse(cc, err, true)
+		return
+	}
+	if record != nil {
+		if record.RequestHash != hash {
+			common.PopulateFailedResponse(cc, common.Error(common.ErrIdempotencyKeyReused, common.Field("key", key)), true)
+		} else if record.IsPending() {
+			common.PopulateFailedResponse(cc, common.Error(common.ErrIdempotencyInProgress, common.Field("key", key)), true)
+		} else {
+			c.Header(common.HeaderIdempotentReplayed, "true")
+			// compressed again if the client replaying accepts
+			cc.Compress(record.Status, record.ContentType, record.Response)
+			c.Abort()
+		}
+		return
+	}
+
+	writer := &bodyRecorder{ResponseWriter: c.Writer, body: bytes.NewBuffer(nil)}
+	c.Writer = writer
+	c.Next()
+
+	status := writer.Status()
+	if status >= http.StatusInternalServerError {
+		if err = s.Idempotency.Release(ns, key); err != nil {
+			s.log.Error("failed to release idempotency key", log.Any(cc.GetTrace()), log.Any("key", key), log.Error(err))
+		}
+		return
+	}
+	response := writer.body.Bytes()
+	// the response is saved uncompressed, since the client replaying may not accept gzip
+	if writer.Header().Get("Content-Encoding") == "gzip" {
+		if response, err = gunzip(response); err != nil {
+			s.log.Error("failed to decompress idempotent response", log.Any(cc.GetTrace()), log.Any("key", key), log.Error(err))
+			if err = s.Idempotency.Release(ns, key); err != nil {
+				s.log.Error("failed to release idempotency key", log.Any(cc.GetTrace()), log.Any("key", key), log.Error(err))
+			}
+			return
+		}
+	}
+	record = &models.IdempotencyRecord{
+		Namespace:   ns,
+		Key:         key,
+		RequestHash: hash,
+		Status:      status,
+		ContentType: writer.Header().Get("Content-Type"),
+		Response:    response,
+	}
+	if err = s.Idempotency.Complete(record); err != nil {
+		s.log.Error("failed to save idempotency record", log.Any(cc.GetTrace()), log.Any("key", key), log.Error(err))
+	}
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+	res, err := ioutil.ReadAll(r)
+	return res, errors.Trace(err)
+}
+
+// bodyRecorder keeps a copy of the response body
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
 func (s *AdminServer) NodeQuotaHandler(c *gin.Context) {
 	cc := common.NewContext(c)
 	namespace := cc.GetNamespace()
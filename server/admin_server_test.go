@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/baetyl/baetyl-cloud/v2/models"
 
@@ -14,7 +16,9 @@ import (
 
 	"github.com/baetyl/baetyl-cloud/v2/api"
 
+	"github.com/baetyl/baetyl-go/v2/log"
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-gonic/gin"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -43,6 +47,34 @@ func initAdminServerMock(t *testing.T) (*AdminServer, *mockPlugin.MockAuth, *moc
 	c.Plugin.Tx = common.RandString(9)
 	c.Plugin.Sign = common.RandString(9)
 	c.Plugin.Cron = common.RandString(9)
+	c.Plugin.NodeGroup = common.RandString(9)
+	c.Plugin.Exec = common.RandString(9)
+	c.Plugin.Health = common.RandString(9)
+	c.Plugin.Metrics = common.RandString(9)
+	c.Plugin.Heartbeat = common.RandString(9)
+	c.Plugin.NodeFilter = common.RandString(9)
+	c.Plugin.EdgeCluster = common.RandString(9)
+	c.Plugin.Rollout = common.RandString(9)
+	c.Plugin.HelmRelease = common.RandString(9)
+	c.Plugin.EnvGroup = common.RandString(9)
+	c.Plugin.SidecarPolicy = common.RandString(9)
+	c.Plugin.ImagePolicy = common.RandString(9)
+	c.Plugin.SecretRotation = common.RandString(9)
+	c.Plugin.FunctionBuild = common.RandString(9)
+	c.Plugin.FunctionRuntime = common.RandString(9)
+	c.Plugin.FunctionDraft = common.RandString(9)
+	c.Plugin.SyncHistory = common.RandString(9)
+	c.Plugin.ActivationToken = common.RandString(9)
+	c.Plugin.NodeRegistration = common.RandString(9)
+	c.Plugin.InstallTemplate = common.RandString(9)
+	c.Plugin.NodeEndpoints = common.RandString(9)
+	c.Plugin.ModuleArtifact = common.RandString(9)
+	c.Plugin.Tenant = common.RandString(9)
+	c.Plugin.PlatformStats = common.RandString(9)
+	c.Plugin.Announcement = common.RandString(9)
+	c.Plugin.Quarantine = common.RandString(9)
+	c.Plugin.Garbage = common.RandString(9)
+	c.Plugin.FeatureFlag = common.RandString(9)
 	mockCtl := gomock.NewController(t)
 
 	mockObjectStorage := mockPlugin.NewMockObject(mockCtl)
@@ -121,6 +153,124 @@ func initAdminServerMock(t *testing.T) (*AdminServer, *mockPlugin.MockAuth, *moc
 	plugin.RegisterFactory(c.Plugin.Cron, func() (plugin.Plugin, error) {
 		return mockCronApp, nil
 	})
+	mockNodeGroup := mockPlugin.NewMockNodeGroup(mockCtl)
+	plugin.RegisterFactory(c.Plugin.NodeGroup, func() (plugin.Plugin, error) {
+		return mockNodeGroup, nil
+	})
+	mockExec := mockPlugin.NewMockExec(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Exec, func() (plugin.Plugin, error) {
+		return mockExec, nil
+	})
+	mockHealth := mockPlugin.NewMockHealthThreshold(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Health, func() (plugin.Plugin, error) {
+		return mockHealth, nil
+	})
+	mockMetrics := mockPlugin.NewMockNodeMetrics(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Metrics, func() (plugin.Plugin, error) {
+		return mockMetrics, nil
+	})
+	mockHeartbeat := mockPlugin.NewMockHeartbeat(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Heartbeat, func() (plugin.Plugin, error) {
+		return mockHeartbeat, nil
+	})
+	mockNodeFilter := mockPlugin.NewMockNodeFilter(mockCtl)
+	plugin.RegisterFactory(c.Plugin.NodeFilter, func() (plugin.Plugin, error) {
+		return mockNodeFilter, nil
+	})
+	mockEdgeCluster := mockPlugin.NewMockEdgeCluster(mockCtl)
+	plugin.RegisterFactory(c.Plugin.EdgeCluster, func() (plugin.Plugin, error) {
+		return mockEdgeCluster, nil
+	})
+	mockAppHistory := mockPlugin.NewMockAppHistory(mockCtl)
+	plugin.RegisterFactory(c.Plugin.AppHistory, func() (plugin.Plugin, error) {
+		return mockAppHistory, nil
+	})
+	mockRollout := mockPlugin.NewMockRollout(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Rollout, func() (plugin.Plugin, error) {
+		return mockRollout, nil
+	})
+	mockHelmRelease := mockPlugin.NewMockHelmRelease(mockCtl)
+	plugin.RegisterFactory(c.Plugin.HelmRelease, func() (plugin.Plugin, error) {
+		return mockHelmRelease, nil
+	})
+	mockEnvGroup := mockPlugin.NewMockEnvGroup(mockCtl)
+	plugin.RegisterFactory(c.Plugin.EnvGroup, func() (plugin.Plugin, error) {
+		return mockEnvGroup, nil
+	})
+
+	mockSidecarPolicy := mockPlugin.NewMockSidecarPolicy(mockCtl)
+	plugin.RegisterFactory(c.Plugin.SidecarPolicy, func() (plugin.Plugin, error) {
+		return mockSidecarPolicy, nil
+	})
+
+	mockImagePolicy := mockPlugin.NewMockImagePolicy(mockCtl)
+	plugin.RegisterFactory(c.Plugin.ImagePolicy, func() (plugin.Plugin, error) {
+		return mockImagePolicy, nil
+	})
+	mockSecretRotation := mockPlugin.NewMockSecretRotation(mockCtl)
+	plugin.RegisterFactory(c.Plugin.SecretRotation, func() (plugin.Plugin, error) {
+		return mockSecretRotation, nil
+	})
+	mockFunctionBuild := mockPlugin.NewMockFunctionBuild(mockCtl)
+	plugin.RegisterFactory(c.Plugin.FunctionBuild, func() (plugin.Plugin, error) {
+		return mockFunctionBuild, nil
+	})
+	mockFunctionRuntime := mockPlugin.NewMockFunctionRuntime(mockCtl)
+	plugin.RegisterFactory(c.Plugin.FunctionRuntime, func() (plugin.Plugin, error) {
+		return mockFunctionRuntime, nil
+	})
+	mockFunctionDraft := mockPlugin.NewMockFunctionDraft(mockCtl)
+	plugin.RegisterFactory(c.Plugin.FunctionDraft, func() (plugin.Plugin, error) {
+		return mockFunctionDraft, nil
+	})
+	mockSyncHistory := mockPlugin.NewMockSyncHistory(mockCtl)
+	plugin.RegisterFactory(c.Plugin.SyncHistory, func() (plugin.Plugin, error) {
+		return mockSyncHistory, nil
+	})
+	mockActivationToken := mockPlugin.NewMockActivationToken(mockCtl)
+	plugin.RegisterFactory(c.Plugin.ActivationToken, func() (plugin.Plugin, error) {
+		return mockActivationToken, nil
+	})
+	mockNodeRegistration := mockPlugin.NewMockNodeRegistration(mockCtl)
+	plugin.RegisterFactory(c.Plugin.NodeRegistration, func() (plugin.Plugin, error) {
+		return mockNodeRegistration, nil
+	})
+	mockInstallTemplate := mockPlugin.NewMockInstallTemplate(mockCtl)
+	plugin.RegisterFactory(c.Plugin.InstallTemplate, func() (plugin.Plugin, error) {
+		return mockInstallTemplate, nil
+	})
+	mockNodeEndpoints := mockPlugin.NewMockNodeEndpoints(mockCtl)
+	plugin.RegisterFactory(c.Plugin.NodeEndpoints, func() (plugin.Plugin, error) {
+		return mockNodeEndpoints, nil
+	})
+	mockModuleArtifact := mockPlugin.NewMockModuleArtifact(mockCtl)
+	plugin.RegisterFactory(c.Plugin.ModuleArtifact, func() (plugin.Plugin, error) {
+		return mockModuleArtifact, nil
+	})
+	mockTenant := mockPlugin.NewMockTenant(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Tenant, func() (plugin.Plugin, error) {
+		return mockTenant, nil
+	})
+	mockPlatformStats := mockPlugin.NewMockPlatformStats(mockCtl)
+	plugin.RegisterFactory(c.Plugin.PlatformStats, func() (plugin.Plugin, error) {
+		return mockPlatformStats, nil
+	})
+	mockAnnouncement := mockPlugin.NewMockAnnouncement(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Announcement, func() (plugin.Plugin, error) {
+		return mockAnnouncement, nil
+	})
+	mockQuarantine := mockPlugin.NewMockQuarantine(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Quarantine, func() (plugin.Plugin, error) {
+		return mockQuarantine, nil
+	})
+	mockGarbage := mockPlugin.NewMockGarbage(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Garbage, func() (plugin.Plugin, error) {
+		return mockGarbage, nil
+	})
+	mockFeatureFlag := mockPlugin.NewMockFeatureFlag(mockCtl)
+	plugin.RegisterFactory(c.Plugin.FeatureFlag, func() (plugin.Plugin, error) {
+		return mockFeatureFlag, nil
+	})
 
 	mockAPI, err := api.NewAPI(c)
 	assert.NoError(t, err)
@@ -280,3 +430,423 @@ func TestAdminServer_Handler(t *testing.T) {
 	go s.Run()
 	defer s.Close()
 }
+
+func TestAdminServer_AuditHandler(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mAudit := service.NewMockAuditService(mockCtl)
+	s := &AdminServer{
+		Audit:  mAudit,
+		router: gin.New(),
+		log:    log.L(),
+	}
+	s.router.Use(func(c *gin.Context) {
+		cc := common.NewContext(c)
+		cc.SetNamespace("default")
+		cc.SetUser(common.User{ID: "user01"})
+	})
+	s.router.Use(s.AuditHandler)
+	s.router.GET("/v1/nodes/:name", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return nil, nil
+	}))
+	s.router.PUT("/v1/nodes/:name", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		c.SetAuditDiff(map[string]string{"a": "1"}, map[string]string{"a": "2"})
+		return nil, nil
+	}))
+	s.router.DELETE("/v1/nodes/:name", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return nil, common.Error(common.ErrResourceNotFound)
+	}))
+
+	// read requests are not audited
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/node01", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mAudit.EXPECT().Audit(gomock.Any()).DoAndReturn(func(r *models.AuditRecord) error {
+		assert.Equal(t, "default", r.Namespace)
+		assert.Equal(t, "user01", r.User)
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "nodes", r.Resource)
+		assert.Equal(t, "node01", r.Name)
+		assert.Equal(t, http.StatusOK, r.Status)
+		assert.Equal(t, `{"a":"2"}`, r.Diff)
+		return nil
+	})
+	req, _ = http.NewRequest(http.MethodPut, "/v1/nodes/node01", nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mAudit.EXPECT().Audit(gomock.Any()).DoAndReturn(func(r *models.AuditRecord) error {
+		assert.Equal(t, http.StatusNotFound, r.Status)
+		assert.Equal(t, common.ErrResourceNotFound, r.Code)
+		return fmt.Errorf("error")
+	})
+	req, _ = http.NewRequest(http.MethodDelete, "/v1/nodes/node01", nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTimeoutHandler(t *testing.T) {
+	conf := config.Server{
+		RouteTimeouts: map[string]time.Duration{
+			"GET /v1/nodes": 10 * time.Millisecond,
+		},
+	}
+	router := gin.New()
+	router.Use(TimeoutHandler(conf))
+	handler := common.Wrapper(func(c *common.Context) (interface{}, error) {
+		params := &models.ListOptions{}
+		params.SetContext(c.RequestContext())
+		if _, ok := params.Context().Deadline(); !ok {
+			return nil, nil
+		}
+		<-params.Context().Done()
+		return nil, params.Context().Err()
+	})
+	router.GET("/v1/nodes", handler)
+	router.GET("/v1/apps", handler)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.Contains(t, w.Body.String(), common.ErrRequestTimeout)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/apps", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAccessLogHandler(t *testing.T) {
+	router := gin.New()
+	router.GET("/metrics", Metrics)
+	router.Use(AccessLogHandler("test"))
+	router.GET("/v1/nodes/:name", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return nil, nil
+	}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes/n1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/metrics", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, common.MetricsContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `baetyl_cloud_http_request_duration_seconds_count{server="test",method="GET",route="/v1/nodes/:name"} 1`)
+}
+
+func TestAdminServer_IdempotencyHandler(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mIdempotency := service.NewMockIdempotencyService(mockCtl)
+	s := &AdminServer{
+		Idempotency: mIdempotency,
+		router:      gin.New(),
+		log:         log.L(),
+	}
+	s.router.Use(func(c *gin.Context) {
+		common.NewContext(c).SetNamespace("default")
+	})
+	s.router.Use(s.IdempotencyHandler)
+	s.router.POST("/v1/nodes", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return map[string]string{"name": "node01"}, nil
+	}))
+	s.router.PUT("/v1/nodes/:name", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return nil, common.Error(common.ErrUnknown)
+	}))
+	s.router.POST("/v1/configs", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return map[string]string{"data": strings.Repeat("a", 2048)}, nil
+	}))
+
+	newRequest := func(method, url, body string) *http.Request {
+		req, _ := http.NewRequest(method, url, bytes.NewReader([]byte(body)))
+		req.Header.Set(common.HeaderIdempotencyKey, "key01")
+		return req
+	}
+
+	// first request
+	var saved *models.IdempotencyRecord
+	mIdempotency.EXPECT().Acquire("default", "key01", gomock.Any()).Return(nil, nil)
+	mIdempotency.EXPECT().Complete(gomock.Any()).DoAndReturn(func(r *models.IdempotencyRecord) error {
+		saved = r
+		return nil
+	})
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, newRequest(http.MethodPost, "/v1/nodes", `{"name":"node01"}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusOK, saved.Status)
+	assert.Equal(t, w.Body.Bytes(), saved.Response)
+
+	// replay
+	mIdempotency.EXPECT().Acquire("default", "key01", saved.RequestHash).Return(saved, nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, newRequest(http.MethodPost, "/v1/nodes", `{"name":"node01"}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get(common.HeaderIdempotentReplayed))
+	assert.Equal(t, saved.Response, w.Body.Bytes())
+
+	// the key is reused by another request
+	mIdempotency.EXPECT().Acquire("default", "key01", gomock.Any()).Return(saved, nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, newRequest(http.MethodPost, "/v1/nodes", `{"name":"node02"}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	// in progress
+	pending := &models.IdempotencyRecord{RequestHash: saved.RequestHash}
+	mIdempotency.EXPECT().Acquire("default", "key01", gomock.Any()).Return(pending, nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, newRequest(http.MethodPost, "/v1/nodes", `{"name":"node01"}`))
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// server error releases the key
+	mIdempotency.EXPECT().Acquire("default", "key01", gomock.Any()).Return(nil, nil)
+	mIdempotency.EXPECT().Release("default", "key01").Return(nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, newRequest(http.MethodPut, "/v1/nodes/node01", `{}`))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// the gzipped response is saved uncompressed and compressed on replay only if accepted
+	mIdempotency.EXPECT().Acquire("default", "key01", gomock.Any()).Return(nil, nil)
+	mIdempotency.EXPECT().Complete(gomock.Any()).DoAndReturn(func(r *models.IdempotencyRecord) error {
+		saved = r
+		return nil
+	})
+	req := newRequest(http.MethodPost, "/v1/configs", `{}`)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Contains(t, string(saved.Response), strings.Repeat("a", 2048))
+
+	mIdempotency.EXPECT().Acquire("default", "key01", gomock.Any()).Return(saved, nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, newRequest(http.MethodPost, "/v1/configs", `{}`))
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, saved.Response, w.Body.Bytes())
+
+	mIdempotency.EXPECT().Acquire("default", "key01", gomock.Any()).Return(saved, nil)
+	req = newRequest(http.MethodPost, "/v1/configs", `{}`)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	data, err := gunzip(w.Body.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, saved.Response, data)
+}
+
+func TestAdminServer_TenantHandler(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mTenant := service.NewMockTenantService(mockCtl)
+	s := &AdminServer{
+		Tenant: mTenant,
+		router: gin.New(),
+		log:    log.L(),
+	}
+	s.router.Use(func(c *gin.Context) {
+		common.NewContext(c).SetNamespace("default")
+	})
+	s.router.Use(s.TenantHandler)
+	s.router.GET("/v1/nodes", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return nil, nil
+	}))
+	s.router.POST("/v1/nodes", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return nil, nil
+	}))
+
+	// the frozen namespace can still be read
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mTenant.EXPECT().CheckWritable("default").Return(common.Error(common.ErrNamespaceFrozen, common.Field("namespace", "default")))
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes", bytes.NewReader([]byte(`{}`)))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), common.ErrNamespaceFrozen)
+
+	mTenant.EXPECT().CheckWritable("default").Return(nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes", bytes.NewReader([]byte(`{}`)))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAdminServer_ReadOnlyHandler(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mReadOnly := service.NewMockReadOnlyService(mockCtl)
+	s := &AdminServer{
+		ReadOnly: mReadOnly,
+		cfg:      &config.CloudConfig{},
+		router:   gin.New(),
+		log:      log.L(),
+	}
+	s.cfg.ReadOnly.Allowlist = []string{"/v1/nodes/:name/bundle/report"}
+	s.router.Use(s.ReadOnlyHandler)
+	handler := common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return nil, nil
+	})
+	s.router.GET("/v1/nodes", handler)
+	s.router.POST("/v1/nodes", handler)
+	s.router.POST("/v1/nodes/:name/bundle/report", handler)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the reports of nodes are allowed
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes/node01/bundle/report", bytes.NewReader([]byte(`{}`)))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mReadOnly.EXPECT().CheckWritable().Return(common.Error(common.ErrPlatformReadOnly, common.Field("reason", "database migration")))
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes", bytes.NewReader([]byte(`{}`)))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "database migration")
+
+	mReadOnly.EXPECT().CheckWritable().Return(nil)
+	req, _ = http.NewRequest(http.MethodPost, "/v1/nodes", bytes.NewReader([]byte(`{}`)))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAdminServer_FeatureFlagHandler(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mFlag := service.NewMockFeatureFlagService(mockCtl)
+	s := &AdminServer{
+		FeatureFlag: mFlag,
+		router:      gin.New(),
+		log:         log.L(),
+	}
+	s.router.Use(func(c *gin.Context) {
+		common.NewContext(c).SetNamespace("default")
+	})
+	s.router.Use(s.FeatureFlagHandler)
+	s.router.GET("/v1/nodes", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return c.IsFeatureEnabled("delta-sync"), nil
+	}))
+
+	mFlag.EXPECT().Resolve("default").Return(map[string]bool{"delta-sync": true}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodes", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, "true", w.Body.String())
+
+	// the request is still served with the flags disabled
+	mFlag.EXPECT().Resolve("default").Return(nil, common.Error(common.ErrDatabase))
+	req, _ = http.NewRequest(http.MethodGet, "/v1/nodes", nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, "false", w.Body.String())
+}
+
+func TestAdminServer_Impersonation(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mAuth := service.NewMockAuthService(mockCtl)
+	mAudit := service.NewMockAuditService(mockCtl)
+	mImpersonation := service.NewMockImpersonationService(mockCtl)
+	s := &AdminServer{
+		Auth:          mAuth,
+		Audit:         mAudit,
+		Impersonation: mImpersonation,
+		router:        gin.New(),
+		log:           log.L(),
+	}
+	s.router.Use(s.AuthHandler)
+	s.router.Use(s.AuditHandler)
+	s.router.Use(s.ImpersonationHandler)
+	s.router.GET("/v1/nodes/:name", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		assert.Equal(t, "tenant-a", c.GetNamespace())
+		assert.Equal(t, "user01", c.GetUser().ID)
+		return nil, nil
+	}))
+	s.router.PUT("/v1/nodes/:name", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return nil, nil
+	}))
+	s.router.GET("/v1/nodes/:name/exec", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return nil, nil
+	}))
+	s.router.GET("/v1/activationtokens/:name/bootstrap", common.Wrapper(func(c *common.Context) (interface{}, error) {
+		return nil, nil
+	}))
+
+	newPathRequest := func(method, path, token string) *http.Request {
+		req, _ := http.NewRequest(method, path, bytes.NewReader([]byte(`{}`)))
+		req.Header.Set(common.HeaderImpersonation, token)
+		return req
+	}
+	newRequest := func(method, token string) *http.Request {
+		return newPathRequest(method, "/v1/nodes/node01", token)
+	}
+	imp := &models.Impersonation{ID: "imp01", Namespace: "tenant-a", User: "user01", Operator: "support01"}
+
+	// the impersonated reads are audited along with the operator
+	mImpersonation.EXPECT().Verify("token01").Return(imp, nil)
+	mAudit.EXPECT().Audit(gomock.Any()).DoAndReturn(func(r *models.AuditRecord) error {
+		assert.Equal(t, "tenant-a", r.Namespace)
+		assert.Equal(t, "user01", r.User)
+		assert.Equal(t, "support01", r.Impersonator)
+		assert.Equal(t, http.MethodGet, r.Method)
+		return nil
+	})
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, newRequest(http.MethodGet, "token01"))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// read-only
+	mImpersonation.EXPECT().Verify("token01").Return(imp, nil)
+	mAudit.EXPECT().Audit(gomock.Any()).DoAndReturn(func(r *models.AuditRecord) error {
+		assert.Equal(t, http.StatusForbidden, r.Status)
+		assert.Equal(t, common.ErrImpersonationReadOnly, r.Code)
+		return nil
+	})
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, newRequest(http.MethodPut, "token01"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// the reads opening shells or handing out credentials are refused as the writes
+	for _, path := range []string{"/v1/nodes/node01/exec", "/v1/activationtokens/token01/bootstrap"} {
+		mImpersonation.EXPECT().Verify("token01").Return(imp, nil)
+		mAudit.EXPECT().Audit(gomock.Any()).DoAndReturn(func(r *models.AuditRecord) error {
+			assert.Equal(t, http.StatusForbidden, r.Status)
+			assert.Equal(t, common.ErrImpersonationReadOnly, r.Code)
+			return nil
+		})
+		w = httptest.NewRecorder()
+		s.router.ServeHTTP(w, newPathRequest(http.MethodGet, path, "token01"))
+		assert.Equal(t, http.StatusForbidden, w.Code, path)
+	}
+
+	mutable := *imp
+	mutable.Mutable = true
+	mImpersonation.EXPECT().Verify("token02").Return(&mutable, nil)
+	mAudit.EXPECT().Audit(gomock.Any()).Return(nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, newRequest(http.MethodPut, "token02"))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mImpersonation.EXPECT().Verify("token03").Return(nil, common.Error(common.ErrInvalidToken))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, newRequest(http.MethodGet, "token03"))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
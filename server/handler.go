@@ -2,7 +2,9 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"io/ioutil"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
 )
 
 var (
@@ -57,6 +60,56 @@ func LoggerHandler(c *gin.Context) {
 	)
 }
 
+// AccessLogHandler logs every request with the route template and records the latency into common.RequestDuration
+func AccessLogHandler(server string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		latency := time.Since(start)
+
+		cc := common.NewContext(c)
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		common.RequestDuration.Observe(latency.Seconds(), server, c.Request.Method, route)
+		log.L().Info("access",
+			log.Any(cc.GetTrace()),
+			log.Any("method", c.Request.Method),
+			log.Any("route", route),
+			log.Any("status", c.Writer.Status()),
+			log.Any("namespace", cc.GetNamespace()),
+			log.Any("latency", latency),
+			log.Any("bytes", c.Writer.Size()),
+		)
+	}
+}
+
+// Metrics exposes the metrics in prometheus text format
+func Metrics(c *gin.Context) {
+	c.Header("Content-Type", common.MetricsContentType)
+	c.Status(http.StatusOK)
+	common.RequestDuration.WriteTo(c.Writer)
+}
+
+// TimeoutHandler sets the deadline of request by route, the storage operations are aborted when the deadline exceeds
+func TimeoutHandler(conf config.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := conf.RouteTimeouts[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = conf.HandlerTimeout
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
 func Health(c *gin.Context) {
 	c.JSON(common.PackageResponse(nil))
 }
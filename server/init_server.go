@@ -84,6 +84,7 @@ func (s *InitServer) InitRoute() {
 
 	s.router.Use(RequestIDHandler)
 	s.router.Use(LoggerHandler)
+	s.router.Use(AccessLogHandler("init"))
 	v1 := s.router.Group("v1")
 	{
 		// TODO: deprecated
@@ -94,4 +95,11 @@ func (s *InitServer) InitRoute() {
 		initz := v1.Group("/init")
 		initz.GET("/:resource", common.WrapperRaw(s.api.GetResource, true))
 	}
+	{
+		v1.POST("/registrations", common.Wrapper(s.api.Register))
+	}
+	{
+		v1.POST("/onboarding", common.Wrapper(s.api.ReportOnboarding))
+		v1.POST("/preflight", common.Wrapper(s.api.ReportPreflight))
+	}
 }
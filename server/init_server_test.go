@@ -30,6 +30,11 @@ func initInitServerMock(t *testing.T) (*InitServer, *gomock.Controller, *config.
 	c.Plugin.License = common.RandString(9)
 	c.Plugin.Sign = common.RandString(9)
 	c.Plugin.Property = common.RandString(9)
+	c.Plugin.NodeGroup = common.RandString(9)
+	c.Plugin.ActivationToken = common.RandString(9)
+	c.Plugin.InstallTemplate = common.RandString(9)
+	c.Plugin.NodeEndpoints = common.RandString(9)
+	c.Plugin.ModuleArtifact = common.RandString(9)
 	c.InitServer.Certificate.CA = "../scripts/demo/native/certs/client_ca.crt"
 	c.InitServer.Certificate.Cert = "../scripts/demo/native/certs/server.crt"
 	c.InitServer.Certificate.Key = "../scripts/demo/native/certs/server.key"
@@ -83,6 +88,26 @@ func initInitServerMock(t *testing.T) (*InitServer, *gomock.Controller, *config.
 	plugin.RegisterFactory(c.Plugin.Index, func() (plugin.Plugin, error) {
 		return mockIndex, nil
 	})
+	mockNodeGroup := mockPlugin.NewMockNodeGroup(mockCtl)
+	plugin.RegisterFactory(c.Plugin.NodeGroup, func() (plugin.Plugin, error) {
+		return mockNodeGroup, nil
+	})
+	mockActivationToken := mockPlugin.NewMockActivationToken(mockCtl)
+	plugin.RegisterFactory(c.Plugin.ActivationToken, func() (plugin.Plugin, error) {
+		return mockActivationToken, nil
+	})
+	mockInstallTemplate := mockPlugin.NewMockInstallTemplate(mockCtl)
+	plugin.RegisterFactory(c.Plugin.InstallTemplate, func() (plugin.Plugin, error) {
+		return mockInstallTemplate, nil
+	})
+	mockNodeEndpoints := mockPlugin.NewMockNodeEndpoints(mockCtl)
+	plugin.RegisterFactory(c.Plugin.NodeEndpoints, func() (plugin.Plugin, error) {
+		return mockNodeEndpoints, nil
+	})
+	mockModuleArtifact := mockPlugin.NewMockModuleArtifact(mockCtl)
+	plugin.RegisterFactory(c.Plugin.ModuleArtifact, func() (plugin.Plugin, error) {
+		return mockModuleArtifact, nil
+	})
 
 	mockInitAPI, err := api.NewInitAPI(c)
 	assert.NoError(t, err)
@@ -67,6 +67,7 @@ func (s *MisServer) InitRoute() {
 
 	s.router.Use(RequestIDHandler)
 	s.router.Use(LoggerHandler)
+	s.router.Use(AccessLogHandler("mis"))
 	s.router.Use(s.authHandler)
 	v1 := s.router.Group("v1")
 	{
@@ -96,6 +97,75 @@ func (s *MisServer) InitRoute() {
 		module.PUT("/:name/version/:version", common.WrapperMis(s.api.UpdateModule))
 		module.DELETE("/:name", common.WrapperMis(s.api.DeleteModules))
 		module.DELETE("/:name/version/:version", common.WrapperMis(s.api.DeleteModules))
+		module.GET("/:name/version/:version/artifacts", common.WrapperMis(s.api.GetModuleArtifacts))
+		module.PUT("/:name/version/:version/artifacts", common.WrapperMis(s.api.UpdateModuleArtifacts))
+		module.DELETE("/:name/version/:version/artifacts", common.WrapperMis(s.api.DeleteModuleArtifacts))
+	}
+	{
+		resources := v1.Group("/resources")
+		resources.GET("", common.WrapperMis(s.api.SearchResources))
+	}
+	{
+		stats := v1.Group("/stats")
+		stats.GET("", common.WrapperMis(s.api.GetPlatformStats))
+		stats.GET("/:section", common.WrapperMis(s.api.GetPlatformStats))
+	}
+	{
+		impersonation := v1.Group("/impersonations")
+		impersonation.POST("", common.WrapperMis(s.api.CreateImpersonation))
+	}
+	{
+		tenants := v1.Group("/tenants")
+		tenants.POST("", common.WrapperMis(s.api.CreateTenant))
+		tenants.GET("/:namespace", common.WrapperMis(s.api.GetTenant))
+		tenants.POST("/:namespace/freeze", common.WrapperMis(s.api.FreezeTenant))
+		tenants.POST("/:namespace/unfreeze", common.WrapperMis(s.api.UnfreezeTenant))
+		tenants.DELETE("/:namespace", common.WrapperMis(s.api.PurgeTenant))
+	}
+	{
+		announcements := v1.Group("/announcements")
+		announcements.GET("", common.WrapperMis(s.api.ListAnnouncement))
+		announcements.GET("/:name", common.WrapperMis(s.api.GetAnnouncement))
+		announcements.POST("", common.WrapperMis(s.api.CreateAnnouncement))
+		announcements.PUT("/:name", common.WrapperMis(s.api.UpdateAnnouncement))
+		announcements.DELETE("/:name", common.WrapperMis(s.api.DeleteAnnouncement))
+	}
+	{
+		quarantines := v1.Group("/quarantines")
+		quarantines.GET("", common.WrapperMis(s.api.ListQuarantine))
+		quarantines.GET("/:name", common.WrapperMis(s.api.GetQuarantine))
+		quarantines.POST("", common.WrapperMis(s.api.CreateQuarantine))
+		quarantines.DELETE("/:name", common.WrapperMis(s.api.DeleteQuarantine))
+	}
+	{
+		garbage := v1.Group("/garbage")
+		garbage.GET("", common.WrapperMis(s.api.GetGarbageReport))
+		garbage.POST("/scan", common.WrapperMis(s.api.RescanGarbage))
+		garbage.POST("/cleanup", common.WrapperMis(s.api.CleanGarbage))
+	}
+	{
+		readOnly := v1.Group("/readonly")
+		readOnly.GET("", common.WrapperMis(s.api.GetReadOnly))
+		readOnly.POST("/enable", common.WrapperMis(s.api.EnableReadOnly))
+		readOnly.POST("/disable", common.WrapperMis(s.api.DisableReadOnly))
+	}
+	{
+		features := v1.Group("/features")
+		features.GET("", common.WrapperMis(s.api.ListFeatureFlag))
+		features.GET("/:name", common.WrapperMis(s.api.GetFeatureFlag))
+		features.POST("", common.WrapperMis(s.api.CreateFeatureFlag))
+		features.PUT("/:name", common.WrapperMis(s.api.UpdateFeatureFlag))
+		features.DELETE("/:name", common.WrapperMis(s.api.DeleteFeatureFlag))
+		features.PUT("/:name/namespaces/:namespace", common.WrapperMis(s.api.SetFeatureOverride))
+		features.DELETE("/:name/namespaces/:namespace", common.WrapperMis(s.api.DeleteFeatureOverride))
+	}
+	{
+		runtime := v1.Group("/function-runtimes")
+		runtime.GET("", common.WrapperMis(s.api.ListFunctionRuntime))
+		runtime.GET("/:name", common.WrapperMis(s.api.GetFunctionRuntime))
+		runtime.POST("", common.WrapperMis(s.api.CreateFunctionRuntime))
+		runtime.PUT("/:name", common.WrapperMis(s.api.UpdateFunctionRuntime))
+		runtime.DELETE("/:name", common.WrapperMis(s.api.DeleteFunctionRuntime))
 	}
 }
 
@@ -111,6 +181,7 @@ func (s *MisServer) authHandler(c *gin.Context) {
 				log.Any("user", user),
 				log.Any(cc.GetTrace()),
 			)
+			cc.SetUser(common.User{ID: user, Name: user})
 			return
 		}
 	}
@@ -35,6 +35,34 @@ func initMisServerMock(t *testing.T) (*MisServer, *gomock.Controller) {
 	c.Plugin.Locker = common.RandString(9)
 	c.Plugin.Tx = common.RandString(9)
 	c.Plugin.Cron = common.RandString(9)
+	c.Plugin.NodeGroup = common.RandString(9)
+	c.Plugin.Exec = common.RandString(9)
+	c.Plugin.Health = common.RandString(9)
+	c.Plugin.Metrics = common.RandString(9)
+	c.Plugin.Heartbeat = common.RandString(9)
+	c.Plugin.NodeFilter = common.RandString(9)
+	c.Plugin.EdgeCluster = common.RandString(9)
+	c.Plugin.Rollout = common.RandString(9)
+	c.Plugin.HelmRelease = common.RandString(9)
+	c.Plugin.EnvGroup = common.RandString(9)
+	c.Plugin.SidecarPolicy = common.RandString(9)
+	c.Plugin.ImagePolicy = common.RandString(9)
+	c.Plugin.SecretRotation = common.RandString(9)
+	c.Plugin.FunctionBuild = common.RandString(9)
+	c.Plugin.FunctionRuntime = common.RandString(9)
+	c.Plugin.FunctionDraft = common.RandString(9)
+	c.Plugin.SyncHistory = common.RandString(9)
+	c.Plugin.ActivationToken = common.RandString(9)
+	c.Plugin.NodeRegistration = common.RandString(9)
+	c.Plugin.InstallTemplate = common.RandString(9)
+	c.Plugin.NodeEndpoints = common.RandString(9)
+	c.Plugin.ModuleArtifact = common.RandString(9)
+	c.Plugin.Tenant = common.RandString(9)
+	c.Plugin.PlatformStats = common.RandString(9)
+	c.Plugin.Announcement = common.RandString(9)
+	c.Plugin.Quarantine = common.RandString(9)
+	c.Plugin.Garbage = common.RandString(9)
+	c.Plugin.FeatureFlag = common.RandString(9)
 	mockCtl := gomock.NewController(t)
 
 	mockObjectStorage := mockPlugin.NewMockObject(mockCtl)
@@ -124,6 +152,123 @@ func initMisServerMock(t *testing.T) (*MisServer, *gomock.Controller) {
 	plugin.RegisterFactory(c.Plugin.Cron, func() (plugin.Plugin, error) {
 		return mockCronApp, nil
 	})
+	mockNodeGroup := mockPlugin.NewMockNodeGroup(mockCtl)
+	plugin.RegisterFactory(c.Plugin.NodeGroup, func() (plugin.Plugin, error) {
+		return mockNodeGroup, nil
+	})
+	mockExec := mockPlugin.NewMockExec(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Exec, func() (plugin.Plugin, error) {
+		return mockExec, nil
+	})
+	mockHealth := mockPlugin.NewMockHealthThreshold(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Health, func() (plugin.Plugin, error) {
+		return mockHealth, nil
+	})
+	mockMetrics := mockPlugin.NewMockNodeMetrics(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Metrics, func() (plugin.Plugin, error) {
+		return mockMetrics, nil
+	})
+	mockHeartbeat := mockPlugin.NewMockHeartbeat(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Heartbeat, func() (plugin.Plugin, error) {
+		return mockHeartbeat, nil
+	})
+	mockNodeFilter := mockPlugin.NewMockNodeFilter(mockCtl)
+	plugin.RegisterFactory(c.Plugin.NodeFilter, func() (plugin.Plugin, error) {
+		return mockNodeFilter, nil
+	})
+	mockEdgeCluster := mockPlugin.NewMockEdgeCluster(mockCtl)
+	plugin.RegisterFactory(c.Plugin.EdgeCluster, func() (plugin.Plugin, error) {
+		return mockEdgeCluster, nil
+	})
+	mockAppHistory := mockPlugin.NewMockAppHistory(mockCtl)
+	plugin.RegisterFactory(c.Plugin.AppHistory, func() (plugin.Plugin, error) {
+		return mockAppHistory, nil
+	})
+	mockRollout := mockPlugin.NewMockRollout(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Rollout, func() (plugin.Plugin, error) {
+		return mockRollout, nil
+	})
+	mockHelmRelease := mockPlugin.NewMockHelmRelease(mockCtl)
+	plugin.RegisterFactory(c.Plugin.HelmRelease, func() (plugin.Plugin, error) {
+		return mockHelmRelease, nil
+	})
+	mockEnvGroup := mockPlugin.NewMockEnvGroup(mockCtl)
+	plugin.RegisterFactory(c.Plugin.EnvGroup, func() (plugin.Plugin, error) {
+		return mockEnvGroup, nil
+	})
+	mockSidecarPolicy := mockPlugin.NewMockSidecarPolicy(mockCtl)
+	plugin.RegisterFactory(c.Plugin.SidecarPolicy, func() (plugin.Plugin, error) {
+		return mockSidecarPolicy, nil
+	})
+
+	mockImagePolicy := mockPlugin.NewMockImagePolicy(mockCtl)
+	plugin.RegisterFactory(c.Plugin.ImagePolicy, func() (plugin.Plugin, error) {
+		return mockImagePolicy, nil
+	})
+	mockSecretRotation := mockPlugin.NewMockSecretRotation(mockCtl)
+	plugin.RegisterFactory(c.Plugin.SecretRotation, func() (plugin.Plugin, error) {
+		return mockSecretRotation, nil
+	})
+	mockFunctionBuild := mockPlugin.NewMockFunctionBuild(mockCtl)
+	plugin.RegisterFactory(c.Plugin.FunctionBuild, func() (plugin.Plugin, error) {
+		return mockFunctionBuild, nil
+	})
+	mockFunctionRuntime := mockPlugin.NewMockFunctionRuntime(mockCtl)
+	plugin.RegisterFactory(c.Plugin.FunctionRuntime, func() (plugin.Plugin, error) {
+		return mockFunctionRuntime, nil
+	})
+	mockFunctionDraft := mockPlugin.NewMockFunctionDraft(mockCtl)
+	plugin.RegisterFactory(c.Plugin.FunctionDraft, func() (plugin.Plugin, error) {
+		return mockFunctionDraft, nil
+	})
+	mockSyncHistory := mockPlugin.NewMockSyncHistory(mockCtl)
+	plugin.RegisterFactory(c.Plugin.SyncHistory, func() (plugin.Plugin, error) {
+		return mockSyncHistory, nil
+	})
+	mockActivationToken := mockPlugin.NewMockActivationToken(mockCtl)
+	plugin.RegisterFactory(c.Plugin.ActivationToken, func() (plugin.Plugin, error) {
+		return mockActivationToken, nil
+	})
+	mockNodeRegistration := mockPlugin.NewMockNodeRegistration(mockCtl)
+	plugin.RegisterFactory(c.Plugin.NodeRegistration, func() (plugin.Plugin, error) {
+		return mockNodeRegistration, nil
+	})
+	mockInstallTemplate := mockPlugin.NewMockInstallTemplate(mockCtl)
+	plugin.RegisterFactory(c.Plugin.InstallTemplate, func() (plugin.Plugin, error) {
+		return mockInstallTemplate, nil
+	})
+	mockNodeEndpoints := mockPlugin.NewMockNodeEndpoints(mockCtl)
+	plugin.RegisterFactory(c.Plugin.NodeEndpoints, func() (plugin.Plugin, error) {
+		return mockNodeEndpoints, nil
+	})
+	mockModuleArtifact := mockPlugin.NewMockModuleArtifact(mockCtl)
+	plugin.RegisterFactory(c.Plugin.ModuleArtifact, func() (plugin.Plugin, error) {
+		return mockModuleArtifact, nil
+	})
+	mockTenant := mockPlugin.NewMockTenant(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Tenant, func() (plugin.Plugin, error) {
+		return mockTenant, nil
+	})
+	mockPlatformStats := mockPlugin.NewMockPlatformStats(mockCtl)
+	plugin.RegisterFactory(c.Plugin.PlatformStats, func() (plugin.Plugin, error) {
+		return mockPlatformStats, nil
+	})
+	mockAnnouncement := mockPlugin.NewMockAnnouncement(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Announcement, func() (plugin.Plugin, error) {
+		return mockAnnouncement, nil
+	})
+	mockQuarantine := mockPlugin.NewMockQuarantine(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Quarantine, func() (plugin.Plugin, error) {
+		return mockQuarantine, nil
+	})
+	mockGarbage := mockPlugin.NewMockGarbage(mockCtl)
+	plugin.RegisterFactory(c.Plugin.Garbage, func() (plugin.Plugin, error) {
+		return mockGarbage, nil
+	})
+	mockFeatureFlag := mockPlugin.NewMockFeatureFlag(mockCtl)
+	plugin.RegisterFactory(c.Plugin.FeatureFlag, func() (plugin.Plugin, error) {
+		return mockFeatureFlag, nil
+	})
 	mockAPI, err := api.NewAPI(c)
 	assert.NoError(t, err)
 
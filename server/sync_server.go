@@ -6,11 +6,15 @@ import (
 
 	"github.com/baetyl/baetyl-cloud/v2/api"
 	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 	"github.com/baetyl/baetyl-cloud/v2/plugin"
 )
 
 type HandlerMessage func(msg specV1.Message) (*specV1.Message, error)
 
+// HandlerWatch returns the channel of messages pushed to node and the function stopping the push
+type HandlerWatch func(msg specV1.Message) (<-chan *specV1.Message, func(), error)
+
 type SyncServer struct {
 	links   map[string]plugin.SyncLink
 	syncAPI api.SyncAPI
@@ -38,6 +42,9 @@ func (s *SyncServer) InitMsgRouter() {
 	for _, v := range s.links {
 		v.AddMsgRouter(string(specV1.MessageReport), HandlerMessage(s.syncAPI.Report))
 		v.AddMsgRouter(string(specV1.MessageDesire), HandlerMessage(s.syncAPI.Desire))
+		v.AddMsgRouter(string(models.MessageDesirePatch), HandlerMessage(s.syncAPI.DesirePatch))
+		v.AddMsgRouter(string(models.MessageDesireWatch), HandlerWatch(s.syncAPI.DesireWatch))
+		v.AddMsgRouter(string(models.MessageExec), HandlerMessage(s.syncAPI.Exec))
 	}
 }
 
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"strings"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// NodeAcceleratorInventory returns the accelerators reported by node. The devices are reported in accelerators,
+// the extended resources in the capacity of nodestats are counted if none of their devices is reported
+func NodeAcceleratorInventory(node *specV1.Node) *models.NodeAccelerators {
+	res := &models.NodeAccelerators{
+		Namespace:   node.Namespace,
+		Name:        node.Name,
+		Accelerator: node.Accelerator,
+		Resources:   map[string]int64{},
+	}
+	_ = common.DecodeReport(node.Report, "time", &res.ReportTime)
+
+	var devices []models.AcceleratorDevice
+	if err := common.DecodeReport(node.Report, common.NodeAccelerators, &devices); err == nil {
+		for _, dev := range devices {
+			if dev.Resource == "" {
+				continue
+			}
+			res.Devices = append(res.Devices, dev)
+			res.Resources[dev.Resource]++
+		}
+	}
+
+	capacity := map[string]int64{}
+	for _, r := range decodeNodeResources(node.Report) {
+		for name, val := range r.Capacity {
+			if IsAcceleratorResource(name) {
+				capacity[name] += parseQuantity(val).Value()
+			}
+		}
+	}
+	for name, count := range capacity {
+		if _, ok := res.Resources[name]; !ok && count > 0 {
+			res.Resources[name] = count
+		}
+	}
+	return res
+}
+
+// IsAcceleratorResource returns whether the resource is the extended resource of device plugin, such as nvidia.com/gpu
+func IsAcceleratorResource(name string) bool {
+	if !strings.Contains(name, "/") {
+		return false
+	}
+	return !strings.HasPrefix(name, "kubernetes.io/") && !strings.Contains(name, ".kubernetes.io/")
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNodeAcceleratorInventory(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	node := &specV1.Node{Namespace: "default", Name: "node01", Accelerator: "nvidia"}
+	res := NodeAcceleratorInventory(node)
+	assert.Equal(t, map[string]int64{}, res.Resources)
+	assert.Empty(t, res.Devices)
+
+	node.Report = specV1.Report{
+		"time": now.Format(time.RFC3339Nano),
+		common.NodeAccelerators: []map[string]interface{}{
+			{"resource": "nvidia.com/gpu", "index": 0, "model": "T4", "memory": 16 << 30},
+			{"resource": "nvidia.com/gpu", "index": 1, "model": "T4", "memory": 16 << 30},
+			{"index": 2},
+		},
+		common.NodeStats: map[string]interface{}{
+			"master": map[string]interface{}{
+				"capacity": map[string]string{"cpu": "8", "nvidia.com/gpu": "4", "huawei.com/Ascend310": "1"},
+			},
+			"worker": map[string]interface{}{
+				"capacity": map[string]string{"huawei.com/Ascend310": "2", "hugepages-2Mi": "0"},
+			},
+		},
+	}
+	res = NodeAcceleratorInventory(node)
+	assert.Equal(t, "nvidia", res.Accelerator)
+	assert.Equal(t, now, res.ReportTime)
+	assert.Len(t, res.Devices, 2)
+	assert.Equal(t, models.AcceleratorDevice{Resource: "nvidia.com/gpu", Index: 1, Model: "T4", Memory: 16 << 30}, res.Devices[1])
+	// the reported devices take precedence over the capacity
+	assert.Equal(t, map[string]int64{"nvidia.com/gpu": 2, "huawei.com/Ascend310": 3}, res.Resources)
+}
+
+func TestIsAcceleratorResource(t *testing.T) {
+	assert.True(t, IsAcceleratorResource("nvidia.com/gpu"))
+	assert.True(t, IsAcceleratorResource("huawei.com/Ascend310"))
+	assert.False(t, IsAcceleratorResource("cpu"))
+	assert.False(t, IsAcceleratorResource("kubernetes.io/batch"))
+	assert.False(t, IsAcceleratorResource("storage.kubernetes.io/scratch"))
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/baetyl/baetyl-go/v2/utils"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/activationtoken.go -package=service github.com/baetyl/baetyl-cloud/v2/service ActivationTokenService
+
+// activationTokenNameLength the length of the random names of tokens
+const activationTokenNameLength = 24
+
+// ActivationResources the resources of init server carrying the node certificates, each fetch of which counts as a
+// use of the activation token
+var ActivationResources = map[string]bool{
+	templateInitDeploymentYaml: true,
+	"baetyl-init-apply.json":   true,
+}
+
+type ActivationTokenService interface {
+	Get(namespace, name string) (*models.ActivationToken, error)
+	List(namespace string) ([]models.ActivationToken, error)
+	// Issue creates the token with a random name, the expiry and max uses not set are the default ones
+	Issue(token *models.ActivationToken) (*models.ActivationToken, error)
+	// Revoke deletes the token, the install commands carrying it are rejected since
+	Revoke(namespace, name string) error
+	// Check returns ErrInvalidToken if the token cannot activate the node
+	Check(namespace, name, node string) error
+	// Use checks the token and counts a use of it
+	Use(namespace, name, node string) error
+	// CheckBootstrap returns ErrInvalidToken if the token cannot bootstrap the devices registering as nodes of its
+	// group, the uses are not checked since they are counted by the new registrations only
+	CheckBootstrap(namespace, name string) (*models.ActivationToken, error)
+	// UseBootstrap checks the token and counts a use of it by a new registration
+	UseBootstrap(namespace, name string) (*models.ActivationToken, error)
+}
+
+type ActivationTokenServiceImpl struct {
+	Token   plugin.ActivationToken
+	Node    NodeService
+	Group   NodeGroupService
+	expiry  time.Duration
+	maxUses int
+}
+
+func NewActivationTokenService(config *config.CloudConfig) (ActivationTokenService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.ActivationToken)
+	if err != nil {
+		return nil, err
+	}
+	node, err := NewNodeService(config)
+	if err != nil {
+		return nil, err
+	}
+	group, err := NewNodeGroupService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ActivationTokenServiceImpl{
+		Token:   p.(plugin.ActivationToken),
+		Node:    node,
+		Group:   group,
+		expiry:  config.ActivationToken.Expiry,
+		maxUses: config.ActivationToken.MaxUses,
+	}, nil
+}
+
+func (s *ActivationTokenServiceImpl) Get(namespace, name string) (*models.ActivationToken, error) {
+	return s.Token.GetActivationToken(namespace, name)
+}
+
+func (s *ActivationTokenServiceImpl) List(namespace string) ([]models.ActivationToken, error) {
+	return s.Token.ListActivationToken(namespace)
+}
+
+func (s *ActivationTokenServiceImpl) Issue(token *models.ActivationToken) (*models.ActivationToken, error) {
+	if (token.Node == "") == (token.NodeGroup == "") {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the token should be bound to either a node or a node group"))
+	}
+	if token.Node != "" {
+		if _, err := s.Node.Get(nil, token.Namespace, token.Node); err != nil {
+			return nil, err
+		}
+	} else if _, err := s.Group.Get(token.Namespace, token.NodeGroup); err != nil {
+		return nil, err
+	}
+	if token.Placement != nil {
+		if token.NodeGroup == "" {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the placement is only for the token bound to a node group"))
+		}
+		if err := CheckNodePlacement(token.Placement); err != nil {
+			return nil, err
+		}
+		for _, rule := range token.Placement.Rules {
+			if _, err := s.Group.Get(token.Namespace, rule.NodeGroup); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if token.Endpoints != nil {
+		if err := ValidateNodeEndpoints(token.Endpoints); err != nil {
+			return nil, err
+		}
+		token.Endpoints.Namespace = ""
+	}
+	expiry := s.expiry
+	if token.Expiry != "" {
+		d, err := time.ParseDuration(token.Expiry)
+		if err != nil || d <= 0 {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the expiry of token should be a positive duration"))
+		}
+		expiry = d
+	}
+	if token.MaxUses == 0 {
+		token.MaxUses = s.maxUses
+	}
+	now := time.Now()
+	if err := s.Token.DeleteExpiredActivationToken(now); err != nil {
+		log.L().Warn("failed to delete expired activation tokens", log.Error(err))
+	}
+	token.Name = common.RandString(activationTokenNameLength)
+	token.Uses = 0
+	token.ExpireTime = now.Add(expiry).UTC()
+	if err := s.Token.CreateActivationToken(token); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.Token.GetActivationToken(token.Namespace, token.Name)
+}
+
+func (s *ActivationTokenServiceImpl) Revoke(namespace, name string) error {
+	return s.Token.DeleteActivationToken(namespace, name)
+}
+
+func (s *ActivationTokenServiceImpl) Check(namespace, name, node string) error {
+	token, err := s.getValid(namespace, name)
+	if err != nil {
+		return err
+	}
+	if token.IsUsedUp() {
+		log.L().Info("activation token used up", log.Any("namespace", namespace), log.Any("token", name))
+		return common.Error(common.ErrInvalidToken)
+	}
+	ok, err := s.isBound(token, node)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		log.L().Info("activation token not bound to node", log.Any("namespace", namespace), log.Any("token", name), log.Any("node", node))
+		return common.Error(common.ErrInvalidToken)
+	}
+	return nil
+}
+
+func (s *ActivationTokenServiceImpl) Use(namespace, name, node string) error {
+	if err := s.Check(namespace, name, node); err != nil {
+		return err
+	}
+	if err := s.use(namespace, name); err != nil {
+		return err
+	}
+	log.L().Info("activation token is used", log.Any("namespace", namespace), log.Any("token", name), log.Any("node", node))
+	return nil
+}
+
+func (s *ActivationTokenServiceImpl) CheckBootstrap(namespace, name string) (*models.ActivationToken, error) {
+	token, err := s.getValid(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if token.NodeGroup == "" {
+		log.L().Info("activation token not bound to node group", log.Any("namespace", namespace), log.Any("token", name))
+		return nil, common.Error(common.ErrInvalidToken)
+	}
+	return token, nil
+}
+
+func (s *ActivationTokenServiceImpl) UseBootstrap(namespace, name string) (*models.ActivationToken, error) {
+	token, err := s.CheckBootstrap(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if token.IsUsedUp() {
+		log.L().Info("activation token used up", log.Any("namespace", namespace), log.Any("token", name))
+		return nil, common.Error(common.ErrInvalidToken)
+	}
+	if err = s.use(namespace, name); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// getValid returns the token not expired
+func (s *ActivationTokenServiceImpl) getValid(namespace, name string) (*models.ActivationToken, error) {
+	token, err := s.Token.GetActivationToken(namespace, name)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			log.L().Info("activation token not found", log.Any("namespace", namespace), log.Any("token", name))
+			return nil, common.Error(common.ErrInvalidToken)
+		}
+		return nil, err
+	}
+	if token.IsExpired(time.Now()) {
+		log.L().Info("activation token expired", log.Any("namespace", namespace), log.Any("token", name))
+		return nil, common.Error(common.ErrInvalidToken)
+	}
+	return token, nil
+}
+
+func (s *ActivationTokenServiceImpl) use(namespace, name string) error {
+	ok, err := s.Token.UseActivationToken(namespace, name, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// the last use is taken by the concurrent activation
+		log.L().Info("activation token used up", log.Any("namespace", namespace), log.Any("token", name))
+		return common.Error(common.ErrInvalidToken)
+	}
+	return nil
+}
+
+func (s *ActivationTokenServiceImpl) isBound(token *models.ActivationToken, node string) (bool, error) {
+	if token.Node != "" {
+		return token.Node == node, nil
+	}
+	group, err := s.Group.Get(token.Namespace, token.NodeGroup)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	n, err := s.Node.Get(nil, token.Namespace, node)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return utils.IsLabelMatch(group.NodeSelector(), n.Labels)
+}
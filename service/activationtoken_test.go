@@ -0,0 +1,154 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func assertErrorCode(t *testing.T, code string, err error) {
+	e, ok := err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, code, e.Code())
+}
+
+func TestActivationTokenIssue(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mToken, mNode, mGroup := mockPlugin.NewMockActivationToken(mockCtl), ms.NewMockNodeService(mockCtl), ms.NewMockNodeGroupService(mockCtl)
+	s := &ActivationTokenServiceImpl{Token: mToken, Node: mNode, Group: mGroup, expiry: time.Hour, maxUses: 1}
+
+	// the token is bound to either a node or a node group
+	_, err := s.Issue(&models.ActivationToken{Namespace: "default"})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+	_, err = s.Issue(&models.ActivationToken{Namespace: "default", Node: "node01", NodeGroup: "group01"})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+
+	mNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01"}, nil)
+	mToken.EXPECT().DeleteExpiredActivationToken(gomock.Any()).Return(nil)
+	var created *models.ActivationToken
+	mToken.EXPECT().CreateActivationToken(gomock.Any()).DoAndReturn(func(token *models.ActivationToken) error {
+		created = token
+		return nil
+	})
+	mToken.EXPECT().GetActivationToken("default", gomock.Any()).DoAndReturn(func(_, _ string) (*models.ActivationToken, error) {
+		return created, nil
+	})
+	res, err := s.Issue(&models.ActivationToken{Namespace: "default", Node: "node01"})
+	assert.NoError(t, err)
+	assert.Len(t, res.Name, activationTokenNameLength)
+	assert.Equal(t, 1, res.MaxUses)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), res.ExpireTime, time.Minute)
+
+	mGroup.EXPECT().Get("default", "group01").Return(&models.NodeGroup{Namespace: "default", Name: "group01"}, nil).Times(2)
+	mToken.EXPECT().DeleteExpiredActivationToken(gomock.Any()).Return(nil)
+	mToken.EXPECT().CreateActivationToken(gomock.Any()).DoAndReturn(func(token *models.ActivationToken) error {
+		created = token
+		return nil
+	})
+	mToken.EXPECT().GetActivationToken("default", gomock.Any()).DoAndReturn(func(_, _ string) (*models.ActivationToken, error) {
+		return created, nil
+	})
+	res, err = s.Issue(&models.ActivationToken{Namespace: "default", NodeGroup: "group01", MaxUses: 50, Expiry: "72h"})
+	assert.NoError(t, err)
+	assert.Equal(t, 50, res.MaxUses)
+	assert.WithinDuration(t, time.Now().Add(72*time.Hour), res.ExpireTime, time.Minute)
+
+	_, err = s.Issue(&models.ActivationToken{Namespace: "default", NodeGroup: "group01", Expiry: "forever"})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+	mGroup.EXPECT().Get("default", "group01").Return(&models.NodeGroup{Namespace: "default", Name: "group01"}, nil)
+	_, err = s.Issue(&models.ActivationToken{Namespace: "default", NodeGroup: "group01", Endpoints: &models.NodeEndpoints{InitServer: "gateway.site:30003"}})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+
+	mNode.EXPECT().Get(nil, "default", "node02").Return(nil, common.Error(common.ErrResourceNotFound))
+	_, err = s.Issue(&models.ActivationToken{Namespace: "default", Node: "node02"})
+	assertErrorCode(t, common.ErrResourceNotFound, err)
+
+	// the placement is for the groups, its rules assign the nodes to the groups existing
+	placement := &models.NodePlacement{Labels: map[string]string{"region": "{{.region}}"}, Rules: []models.NodePlacementRule{{Selector: "region=beijing", NodeGroup: "group02"}}}
+	mNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01"}, nil)
+	_, err = s.Issue(&models.ActivationToken{Namespace: "default", Node: "node01", Placement: placement})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+	mGroup.EXPECT().Get("default", "group01").Return(&models.NodeGroup{Namespace: "default", Name: "group01"}, nil).Times(2)
+	_, err = s.Issue(&models.ActivationToken{Namespace: "default", NodeGroup: "group01", Placement: &models.NodePlacement{Labels: map[string]string{"region": "{{.region"}}})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+	mGroup.EXPECT().Get("default", "group02").Return(nil, common.Error(common.ErrResourceNotFound))
+	_, err = s.Issue(&models.ActivationToken{Namespace: "default", NodeGroup: "group01", Placement: placement})
+	assertErrorCode(t, common.ErrResourceNotFound, err)
+}
+
+func TestActivationTokenCheck(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mToken, mNode, mGroup := mockPlugin.NewMockActivationToken(mockCtl), ms.NewMockNodeService(mockCtl), ms.NewMockNodeGroupService(mockCtl)
+	s := &ActivationTokenServiceImpl{Token: mToken, Node: mNode, Group: mGroup, expiry: time.Hour, maxUses: 1}
+
+	expire := time.Now().Add(time.Hour)
+	mToken.EXPECT().GetActivationToken("default", "node").Return(&models.ActivationToken{Namespace: "default", Name: "node", Node: "node01", MaxUses: 1, ExpireTime: expire}, nil).AnyTimes()
+	mToken.EXPECT().GetActivationToken("default", "group").Return(&models.ActivationToken{Namespace: "default", Name: "group", NodeGroup: "group01", MaxUses: 3, Uses: 1, ExpireTime: expire}, nil).AnyTimes()
+	mToken.EXPECT().GetActivationToken("default", "expired").Return(&models.ActivationToken{Namespace: "default", Name: "expired", Node: "node01", MaxUses: 1, ExpireTime: time.Now().Add(-time.Minute)}, nil).AnyTimes()
+	mToken.EXPECT().GetActivationToken("default", "usedup").Return(&models.ActivationToken{Namespace: "default", Name: "usedup", Node: "node01", MaxUses: 2, Uses: 2, ExpireTime: expire}, nil).AnyTimes()
+	mToken.EXPECT().GetActivationToken("default", "revoked").Return(nil, common.Error(common.ErrResourceNotFound)).AnyTimes()
+	mGroup.EXPECT().Get("default", "group01").Return(&models.NodeGroup{Namespace: "default", Name: "group01", Selector: "env=prod"}, nil).AnyTimes()
+	mNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01", Labels: map[string]string{"env": "prod"}}, nil).AnyTimes()
+	mNode.EXPECT().Get(nil, "default", "node02").Return(&specV1.Node{Namespace: "default", Name: "node02", Labels: map[string]string{"env": "test"}}, nil).AnyTimes()
+
+	assert.NoError(t, s.Check("default", "node", "node01"))
+	assert.NoError(t, s.Check("default", "group", "node01"))
+	assertErrorCode(t, common.ErrInvalidToken, s.Check("default", "node", "node02"))
+	assertErrorCode(t, common.ErrInvalidToken, s.Check("default", "group", "node02"))
+	assertErrorCode(t, common.ErrInvalidToken, s.Check("default", "expired", "node01"))
+	assertErrorCode(t, common.ErrInvalidToken, s.Check("default", "usedup", "node01"))
+	assertErrorCode(t, common.ErrInvalidToken, s.Check("default", "revoked", "node01"))
+
+	mToken.EXPECT().UseActivationToken("default", "group", gomock.Any()).Return(true, nil)
+	assert.NoError(t, s.Use("default", "group", "node01"))
+	// the last use is taken by the concurrent activation
+	mToken.EXPECT().UseActivationToken("default", "group", gomock.Any()).Return(false, nil)
+	assertErrorCode(t, common.ErrInvalidToken, s.Use("default", "group", "node01"))
+	assertErrorCode(t, common.ErrInvalidToken, s.Use("default", "group", "node02"))
+}
+
+func TestActivationTokenBootstrap(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mToken := mockPlugin.NewMockActivationToken(mockCtl)
+	s := &ActivationTokenServiceImpl{Token: mToken, expiry: time.Hour, maxUses: 1}
+
+	expire := time.Now().Add(time.Hour)
+	group := &models.ActivationToken{Namespace: "default", Name: "group", NodeGroup: "group01", MaxUses: 3, Uses: 1, ExpireTime: expire}
+	mToken.EXPECT().GetActivationToken("default", "group").Return(group, nil).AnyTimes()
+	mToken.EXPECT().GetActivationToken("default", "node").Return(&models.ActivationToken{Namespace: "default", Name: "node", Node: "node01", MaxUses: 1, ExpireTime: expire}, nil).AnyTimes()
+	mToken.EXPECT().GetActivationToken("default", "usedup").Return(&models.ActivationToken{Namespace: "default", Name: "usedup", NodeGroup: "group01", MaxUses: 2, Uses: 2, ExpireTime: expire}, nil).AnyTimes()
+	mToken.EXPECT().GetActivationToken("default", "expired").Return(&models.ActivationToken{Namespace: "default", Name: "expired", NodeGroup: "group01", MaxUses: 2, ExpireTime: time.Now().Add(-time.Minute)}, nil).AnyTimes()
+
+	res, err := s.CheckBootstrap("default", "group")
+	assert.NoError(t, err)
+	assert.Equal(t, group, res)
+	// the registrations of a token used up are still polled
+	_, err = s.CheckBootstrap("default", "usedup")
+	assert.NoError(t, err)
+	_, err = s.CheckBootstrap("default", "node")
+	assertErrorCode(t, common.ErrInvalidToken, err)
+	_, err = s.CheckBootstrap("default", "expired")
+	assertErrorCode(t, common.ErrInvalidToken, err)
+
+	mToken.EXPECT().UseActivationToken("default", "group", gomock.Any()).Return(true, nil)
+	res, err = s.UseBootstrap("default", "group")
+	assert.NoError(t, err)
+	assert.Equal(t, group, res)
+	mToken.EXPECT().UseActivationToken("default", "group", gomock.Any()).Return(false, nil)
+	_, err = s.UseBootstrap("default", "group")
+	assertErrorCode(t, common.ErrInvalidToken, err)
+	_, err = s.UseBootstrap("default", "usedup")
+	assertErrorCode(t, common.ErrInvalidToken, err)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/announcement.go -package=service github.com/baetyl/baetyl-cloud/v2/service AnnouncementService
+
+type AnnouncementService interface {
+	Get(name string) (*models.Announcement, error)
+	List() ([]models.Announcement, error)
+	Create(announcement *models.Announcement) (*models.Announcement, error)
+	Update(announcement *models.Announcement) (*models.Announcement, error)
+	Delete(name string) error
+	// ListActive returns the announcements shown to the users of namespace now
+	ListActive(namespace string) ([]models.Announcement, error)
+	// ListEdge returns the announcements delivered to node now, which are the edge ones selecting node
+	ListEdge(node *specV1.Node) ([]models.EdgeAnnouncement, error)
+}
+
+type AnnouncementServiceImpl struct {
+	Announcement plugin.Announcement
+	ttl          time.Duration
+	mu           sync.Mutex
+	cached       []models.Announcement
+	expire       time.Time
+}
+
+func NewAnnouncementService(config *config.CloudConfig) (AnnouncementService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.Announcement)
+	if err != nil {
+		return nil, err
+	}
+	return &AnnouncementServiceImpl{Announcement: p.(plugin.Announcement), ttl: config.Announcement.CacheTTL}, nil
+}
+
+func (s *AnnouncementServiceImpl) Get(name string) (*models.Announcement, error) {
+	return s.Announcement.GetAnnouncement(name)
+}
+
+func (s *AnnouncementServiceImpl) List() ([]models.Announcement, error) {
+	return s.Announcement.ListAnnouncement()
+}
+
+func (s *AnnouncementServiceImpl) Create(announcement *models.Announcement) (*models.Announcement, error) {
+	if err := s.Announcement.CreateAnnouncement(announcement); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	log.L().Info("announcement published", log.Any("name", announcement.Name), log.Any("start", announcement.StartTime), log.Any("end", announcement.EndTime))
+	return s.Announcement.GetAnnouncement(announcement.Name)
+}
+
+func (s *AnnouncementServiceImpl) Update(announcement *models.Announcement) (*models.Announcement, error) {
+	if err := s.Announcement.UpdateAnnouncement(announcement); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return s.Announcement.GetAnnouncement(announcement.Name)
+}
+
+func (s *AnnouncementServiceImpl) Delete(name string) error {
+	if err := s.Announcement.DeleteAnnouncement(name); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+func (s *AnnouncementServiceImpl) ListActive(namespace string) ([]models.Announcement, error) {
+	announcements, err := s.list()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	res := []models.Announcement{}
+	for i := range announcements {
+		if announcements[i].IsActive(now) && announcements[i].Targets(namespace) {
+			res = append(res, announcements[i])
+		}
+	}
+	return res, nil
+}
+
+func (s *AnnouncementServiceImpl) ListEdge(node *specV1.Node) ([]models.EdgeAnnouncement, error) {
+	announcements, err := s.list()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var res []models.EdgeAnnouncement
+	for i := range announcements {
+		a := &announcements[i]
+		if !a.Edge || !a.IsActive(now) || !a.Targets(node.Namespace) {
+			continue
+		}
+		if a.NodeSelector != "" {
+			selector, err := labels.Parse(a.NodeSelector)
+			if err != nil || !selector.Matches(labels.Set(node.Labels)) {
+				continue
+			}
+		}
+		res = append(res, a.ToEdge())
+	}
+	return res, nil
+}
+
+// list returns the announcements cached, which are listed again once expired or changed by this instance.
+// The changes by other instances take effect after the ttl at most
+func (s *AnnouncementServiceImpl) list() ([]models.Announcement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cached != nil && time.Now().Before(s.expire) {
+		return s.cached, nil
+	}
+	res, err := s.Announcement.ListAnnouncement()
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		res = []models.Announcement{}
+	}
+	s.cached, s.expire = res, time.Now().Add(s.ttl)
+	return res, nil
+}
+
+func (s *AnnouncementServiceImpl) invalidate() {
+	s.mu.Lock()
+	s.cached = nil
+	s.mu.Unlock()
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewAnnouncementService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.Announcement = common.RandString(9)
+	_, err := NewAnnouncementService(conf)
+	assert.Error(t, err)
+}
+
+func TestAnnouncementListActive(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mAnnouncement := mockPlugin.NewMockAnnouncement(mockCtl)
+	s := &AnnouncementServiceImpl{Announcement: mAnnouncement, ttl: time.Minute}
+
+	now := time.Now()
+	announcements := []models.Announcement{
+		{Name: "all", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)},
+		{Name: "tenant", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour), Namespaces: []string{"tenant-a"}},
+		{Name: "planned", StartTime: now.Add(time.Hour), EndTime: now.Add(time.Hour * 2)},
+		{Name: "expired", StartTime: now.Add(-time.Hour * 2), EndTime: now.Add(-time.Hour)},
+	}
+	// the announcements are listed once for the ttl
+	mAnnouncement.EXPECT().ListAnnouncement().Return(announcements, nil).Times(1)
+	res, err := s.ListActive("default")
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "all", res[0].Name)
+	res, err = s.ListActive("tenant-a")
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+
+	// the changes invalidate the cache
+	mAnnouncement.EXPECT().DeleteAnnouncement("all").Return(nil)
+	assert.NoError(t, s.Delete("all"))
+	mAnnouncement.EXPECT().ListAnnouncement().Return(announcements[1:], nil)
+	res, err = s.ListActive("default")
+	assert.NoError(t, err)
+	assert.Len(t, res, 0)
+}
+
+func TestAnnouncementListEdge(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mAnnouncement := mockPlugin.NewMockAnnouncement(mockCtl)
+	s := &AnnouncementServiceImpl{Announcement: mAnnouncement, ttl: time.Minute}
+
+	now := time.Now()
+	mAnnouncement.EXPECT().ListAnnouncement().Return([]models.Announcement{
+		{Name: "console", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)},
+		{Name: "all", Title: "maintenance", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour), Edge: true},
+		{Name: "bj", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour), Edge: true, NodeSelector: "region=bj", Level: models.AnnouncementCritical},
+		{Name: "tenant", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour), Edge: true, Namespaces: []string{"tenant-a"}},
+	}, nil)
+
+	res, err := s.ListEdge(&specV1.Node{Namespace: "default", Name: "node01", Labels: map[string]string{"region": "sh"}})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "maintenance", res[0].Title)
+	assert.Equal(t, models.AnnouncementInfo, res[0].Level)
+
+	res, err = s.ListEdge(&specV1.Node{Namespace: "default", Name: "node02", Labels: map[string]string{"region": "bj"}})
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, models.AnnouncementCritical, res[1].Level)
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-contrib/cache/persistence"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/appconflict.go -package=service github.com/baetyl/baetyl-cloud/v2/service AppConflictService
+
+type AppConflictService interface {
+	// Check detects the apps changed on node out of band by the report in shadow, and returns the conflicts of node
+	// stored in its attributes. The conflicts are removed once node reports the desired versions or the apps are
+	// not desired any more
+	Check(node *specV1.Node, shadow *models.Shadow) ([]models.AppConflict, error)
+	// Resolve resolves the conflict of app on node. The version node reports becomes the desired one if adopted,
+	// while the desired version is delivered to node if enforced
+	Resolve(node *specV1.Node, app, resolution string) (*models.AppConflict, error)
+}
+
+type AppConflictServiceImpl struct {
+	Node       NodeService
+	AppHistory AppHistoryService
+	cache      persistence.CacheStore
+	expire     time.Duration
+}
+
+func NewAppConflictService(config *config.CloudConfig) (AppConflictService, error) {
+	node, err := NewNodeService(config)
+	if err != nil {
+		return nil, err
+	}
+	history, err := NewAppHistoryService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &AppConflictServiceImpl{
+		Node:       node,
+		AppHistory: history,
+		cache:      persistence.NewInMemoryStore(config.Cache.ExpirationDuration),
+		expire:     config.Cache.ExpirationDuration,
+	}, nil
+}
+
+func (s *AppConflictServiceImpl) Check(node *specV1.Node, shadow *models.Shadow) ([]models.AppConflict, error) {
+	conflicts, err := GetNodeAppConflicts(node)
+	if err != nil {
+		return nil, err
+	}
+	desired := map[string]string{}
+	for _, app := range shadow.Desire.AppInfos(false) {
+		desired[app.Name] = app.Version
+	}
+	var reported []specV1.AppInfo
+	if err = common.DecodeReport(shadow.Report, common.DesiredApplications, &reported); err != nil {
+		return nil, err
+	}
+	versions := map[string]string{}
+	for _, app := range reported {
+		versions[app.Name] = app.Version
+	}
+
+	changed := false
+	res := make([]models.AppConflict, 0, len(conflicts))
+	for _, c := range conflicts {
+		version, ok := desired[c.App]
+		if !ok || versions[c.App] == version {
+			changed = true
+			continue
+		}
+		if reportedVersion, ok := versions[c.App]; ok && (c.Reported != reportedVersion || c.Desired != version) {
+			c.Reported, c.Desired = reportedVersion, version
+			changed = true
+		}
+		res = append(res, c)
+	}
+	for _, app := range reported {
+		version, ok := desired[app.Name]
+		if !ok || app.Version == "" || app.Version == version || hasAppConflict(res, app.Name) {
+			continue
+		}
+		delivered, err := s.isDelivered(node.Namespace, app.Name, app.Version)
+		if err != nil {
+			return nil, err
+		}
+		if delivered {
+			continue
+		}
+		res = append(res, models.AppConflict{App: app.Name, Desired: version, Reported: app.Version, DetectTime: time.Now().UTC()})
+		changed = true
+	}
+	if changed {
+		if err = s.store(node, res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *AppConflictServiceImpl) Resolve(node *specV1.Node, app, resolution string) (*models.AppConflict, error) {
+	conflicts, err := GetNodeAppConflicts(node)
+	if err != nil {
+		return nil, err
+	}
+	i := -1
+	for j := range conflicts {
+		if conflicts[j].App == app {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "conflict"), common.Field("name", app), common.Field("namespace", node.Namespace))
+	}
+	res := conflicts[i]
+	res.Resolution = resolution
+	switch resolution {
+	case models.AppConflictAdopt:
+		err = s.Node.UpdateDesire(nil, node.Namespace, []string{node.Name}, nil, func(shadow *models.Shadow, _ *specV1.Application) {
+			if shadow.Desire == nil {
+				return
+			}
+			apps := shadow.Desire.AppInfos(false)
+			for j := range apps {
+				if apps[j].Name == app {
+					apps[j].Version = res.Reported
+				}
+			}
+			shadow.Desire.SetAppInfos(false, apps)
+		})
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts[:i], conflicts[i+1:]...)
+	case models.AppConflictEnforce:
+		conflicts[i] = res
+	default:
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the resolution should be adopt or enforce"))
+	}
+	if err = s.store(node, conflicts); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (s *AppConflictServiceImpl) store(node *specV1.Node, conflicts []models.AppConflict) error {
+	if node.Attributes == nil {
+		node.Attributes = map[string]interface{}{}
+	}
+	if len(conflicts) == 0 {
+		delete(node.Attributes, common.AttributeAppConflicts)
+	} else {
+		node.Attributes[common.AttributeAppConflicts] = conflicts
+	}
+	_, err := s.Node.Update(node.Namespace, node)
+	return err
+}
+
+// isDelivered returns whether the version of app is delivered by cloud, which is one of the revisions of app. The
+// revisions are cached, and listed again before the version is taken as not delivered
+func (s *AppConflictServiceImpl) isDelivered(namespace, app, version string) (bool, error) {
+	key := namespace + "/" + app
+	var revisions []models.AppRevision
+	if err := s.cache.Get(key, &revisions); err == nil && deliveredIn(revisions, version) {
+		return true, nil
+	}
+	revisions, err := s.AppHistory.List(namespace, app)
+	if err != nil {
+		return false, err
+	}
+	s.cache.Set(key, revisions, s.expire)
+	return deliveredIn(revisions, version), nil
+}
+
+// deliveredIn returns whether the version is one of the revisions, the latest first. Once the history is pruned, the
+// versions older than the oldest revision kept are taken as delivered, as well as the ones not numbers to compare.
+// All the versions are taken as delivered if there is no revision
+func deliveredIn(revisions []models.AppRevision, version string) bool {
+	if len(revisions) == 0 {
+		return true
+	}
+	for _, r := range revisions {
+		if r.Version == version {
+			return true
+		}
+	}
+	oldest := revisions[len(revisions)-1]
+	if oldest.Revision <= 1 {
+		return false
+	}
+	v, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return true
+	}
+	o, err := strconv.ParseInt(oldest.Version, 10, 64)
+	return err != nil || v < o
+}
+
+func hasAppConflict(conflicts []models.AppConflict, app string) bool {
+	for _, c := range conflicts {
+		if c.App == app {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNodeAppConflicts returns the conflicts of the apps of node, which is empty if there is none
+func GetNodeAppConflicts(node *specV1.Node) ([]models.AppConflict, error) {
+	res := []models.AppConflict{}
+	val, ok := node.Attributes[common.AttributeAppConflicts]
+	if !ok || val == nil {
+		return res, nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return res, nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-contrib/cache/persistence"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestDeliveredIn(t *testing.T) {
+	assert.True(t, deliveredIn(nil, "100"))
+	complete := []models.AppRevision{{Revision: 2, Version: "120"}, {Revision: 1, Version: "100"}}
+	assert.True(t, deliveredIn(complete, "100"))
+	assert.False(t, deliveredIn(complete, "90"))
+	assert.False(t, deliveredIn(complete, "local-1"))
+	// the versions older than the history kept can't be told apart
+	pruned := []models.AppRevision{{Revision: 12, Version: "120"}, {Revision: 11, Version: "100"}}
+	assert.True(t, deliveredIn(pruned, "90"))
+	assert.True(t, deliveredIn(pruned, "local-1"))
+	assert.False(t, deliveredIn(pruned, "110"))
+}
+
+func TestAppConflictCheck(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNode, mHistory := ms.NewMockNodeService(mockCtl), ms.NewMockAppHistoryService(mockCtl)
+	s := &AppConflictServiceImpl{Node: mNode, AppHistory: mHistory, cache: persistence.NewInMemoryStore(time.Minute), expire: time.Minute}
+
+	node := &specV1.Node{Namespace: "default", Name: "node01"}
+	shadow := &models.Shadow{
+		Desire: specV1.Desire{common.DesiredApplications: []specV1.AppInfo{{Name: "a", Version: "3"}, {Name: "b", Version: "5"}}},
+		Report: specV1.Report{common.DesiredApplications: []interface{}{
+			map[string]interface{}{"name": "a", "version": "2"},
+			map[string]interface{}{"name": "b", "version": "9"},
+		}},
+	}
+	// a is being updated from the version delivered before, while b is changed on node
+	mHistory.EXPECT().List("default", "a").Return([]models.AppRevision{{Revision: 2, Version: "3"}, {Revision: 1, Version: "2"}}, nil)
+	mHistory.EXPECT().List("default", "b").Return([]models.AppRevision{{Revision: 1, Version: "5"}}, nil)
+	mNode.EXPECT().Update("default", node).Return(node, nil)
+	conflicts, err := s.Check(node, shadow)
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+	assert.Equal(t, "b", conflicts[0].App)
+	assert.Equal(t, "5", conflicts[0].Desired)
+	assert.Equal(t, "9", conflicts[0].Reported)
+
+	// the conflicts stored are not detected again
+	conflicts, err = s.Check(node, shadow)
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+
+	// the conflict is cleared once node reports the desired version
+	shadow.Report = specV1.Report{common.DesiredApplications: []specV1.AppInfo{{Name: "a", Version: "3"}, {Name: "b", Version: "5"}}}
+	mNode.EXPECT().Update("default", node).DoAndReturn(func(_ string, n *specV1.Node) (*specV1.Node, error) {
+		assert.NotContains(t, n.Attributes, common.AttributeAppConflicts)
+		return n, nil
+	})
+	conflicts, err = s.Check(node, shadow)
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
+func TestAppConflictResolve(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNode := ms.NewMockNodeService(mockCtl)
+	s := &AppConflictServiceImpl{Node: mNode}
+
+	node := &specV1.Node{
+		Namespace: "default",
+		Name:      "node01",
+		Attributes: map[string]interface{}{common.AttributeAppConflicts: []interface{}{
+			map[string]interface{}{"app": "a", "desired": "3", "reported": "9"},
+			map[string]interface{}{"app": "b", "desired": "5", "reported": "7"},
+		}},
+	}
+	_, err := s.Resolve(node, "c", models.AppConflictAdopt)
+	assert.Error(t, err)
+
+	// the desired changes are delivered once enforced
+	mNode.EXPECT().Update("default", node).Return(node, nil).Times(2)
+	res, err := s.Resolve(node, "a", models.AppConflictEnforce)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AppConflictEnforce, res.Resolution)
+	conflicts, err := GetNodeAppConflicts(node)
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 2)
+	assert.Equal(t, models.AppConflictEnforce, conflicts[0].Resolution)
+
+	// the version of node becomes the desired one once adopted
+	shadow := &models.Shadow{Desire: specV1.Desire{common.DesiredApplications: []specV1.AppInfo{{Name: "a", Version: "3"}, {Name: "b", Version: "5"}}}}
+	mNode.EXPECT().UpdateDesire(nil, "default", []string{"node01"}, nil, gomock.Any()).DoAndReturn(
+		func(_ interface{}, _ string, _ []string, _ *specV1.Application, f func(*models.Shadow, *specV1.Application)) error {
+			f(shadow, nil)
+			return nil
+		})
+	res, err = s.Resolve(node, "b", models.AppConflictAdopt)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", res.Reported)
+	assert.Equal(t, []specV1.AppInfo{{Name: "a", Version: "3"}, {Name: "b", Version: "7"}}, shadow.Desire.AppInfos(false))
+	conflicts, err = GetNodeAppConflicts(node)
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+	assert.Equal(t, "a", conflicts[0].App)
+
+	_, err = s.Resolve(node, "a", "ignore")
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/apphistory.go -package=service github.com/baetyl/baetyl-cloud/v2/service AppHistoryService
+
+// AppHistoryService keeps the latest revisions of applications to roll back
+type AppHistoryService interface {
+	// Record saves the application as the latest revision, the revisions beyond the limit are deleted
+	Record(app *specV1.Application) (*models.AppRevision, error)
+	Get(namespace, name string, revision int64) (*models.AppRevision, error)
+	List(namespace, name string) ([]models.AppRevision, error)
+	Delete(namespace, name string) error
+}
+
+type AppHistoryServiceImpl struct {
+	AppHistory plugin.AppHistory
+	Revisions  int
+}
+
+// NewAppHistoryService NewAppHistoryService
+func NewAppHistoryService(config *config.CloudConfig) (AppHistoryService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.AppHistory)
+	if err != nil {
+		return nil, err
+	}
+	return &AppHistoryServiceImpl{
+		AppHistory: p.(plugin.AppHistory),
+		Revisions:  config.AppHistory.Revisions,
+	}, nil
+}
+
+func (s *AppHistoryServiceImpl) Record(app *specV1.Application) (*models.AppRevision, error) {
+	revisions, err := s.AppHistory.ListAppRevision(app.Namespace, app.Name)
+	if err != nil {
+		return nil, err
+	}
+	res := &models.AppRevision{
+		Namespace:   app.Namespace,
+		Name:        app.Name,
+		Revision:    1,
+		Version:     app.Version,
+		Application: app,
+	}
+	if len(revisions) > 0 {
+		res.Revision = revisions[0].Revision + 1
+	}
+	if err = s.AppHistory.CreateAppRevision(res); err != nil {
+		return nil, err
+	}
+	if s.Revisions > 0 && len(revisions) >= s.Revisions {
+		if err = s.AppHistory.DeleteAppRevisionBefore(app.Namespace, app.Name, res.Revision-int64(s.Revisions)+1); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *AppHistoryServiceImpl) Get(namespace, name string, revision int64) (*models.AppRevision, error) {
+	return s.AppHistory.GetAppRevision(namespace, name, revision)
+}
+
+func (s *AppHistoryServiceImpl) List(namespace, name string) ([]models.AppRevision, error) {
+	return s.AppHistory.ListAppRevision(namespace, name)
+}
+
+func (s *AppHistoryServiceImpl) Delete(namespace, name string) error {
+	return s.AppHistory.DeleteAppRevisions(namespace, name)
+}
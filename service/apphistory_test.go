@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestAppHistoryService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.AppHistory = common.RandString(9)
+	conf.AppHistory.Revisions = 3
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mAppHistory := mockPlugin.NewMockAppHistory(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.AppHistory, func() (plugin.Plugin, error) {
+		return mAppHistory, nil
+	})
+	hs, err := NewAppHistoryService(conf)
+	assert.NoError(t, err)
+
+	app := &specV1.Application{Namespace: "default", Name: "app01", Version: "100"}
+	first := &models.AppRevision{Namespace: "default", Name: "app01", Revision: 1, Version: "100", Application: app}
+	mAppHistory.EXPECT().ListAppRevision("default", "app01").Return([]models.AppRevision{}, nil)
+	mAppHistory.EXPECT().CreateAppRevision(first).Return(nil)
+	res, err := hs.Record(app)
+	assert.NoError(t, err)
+	assert.Equal(t, first, res)
+
+	// the oldest revision is deleted once the limit is reached
+	mAppHistory.EXPECT().ListAppRevision("default", "app01").Return([]models.AppRevision{{Revision: 5}, {Revision: 4}, {Revision: 3}}, nil)
+	mAppHistory.EXPECT().CreateAppRevision(gomock.Any()).Return(nil)
+	mAppHistory.EXPECT().DeleteAppRevisionBefore("default", "app01", int64(4)).Return(nil)
+	res, err = hs.Record(app)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), res.Revision)
+
+	mAppHistory.EXPECT().GetAppRevision("default", "app01", int64(6)).Return(res, nil)
+	rev, err := hs.Get("default", "app01", 6)
+	assert.NoError(t, err)
+	assert.Equal(t, res, rev)
+
+	mAppHistory.EXPECT().ListAppRevision("default", "app01").Return([]models.AppRevision{*res}, nil)
+	list, err := hs.List("default", "app01")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	mAppHistory.EXPECT().DeleteAppRevisions("default", "app01").Return(nil)
+	assert.NoError(t, hs.Delete("default", "app01"))
+}
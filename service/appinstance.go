@@ -0,0 +1,126 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// appInstanceRestartExpiry the restart not reported done by node in time is given up, so that it is not delivered forever
+const appInstanceRestartExpiry = time.Hour
+
+// appStatsReport the stats of app reported by node, the containers of instances carry their restarts
+type appStatsReport struct {
+	Name      string                    `json:"name"`
+	Instances map[string]instanceReport `json:"instances,omitempty"`
+}
+
+type instanceReport struct {
+	ServiceName string                   `json:"serviceName,omitempty"`
+	Status      string                   `json:"status,omitempty"`
+	Cause       string                   `json:"cause,omitempty"`
+	Containers  []models.ContainerStatus `json:"containers,omitempty"`
+}
+
+// AppInstances returns the instances of app reported by node, sorted by name
+func AppInstances(node *specV1.Node, app *specV1.Application) ([]models.AppInstance, error) {
+	key := "appstats"
+	if app.System {
+		key = "sysappstats"
+	}
+	var stats []appStatsReport
+	if err := common.DecodeReport(node.Report, key, &stats); err != nil {
+		return nil, err
+	}
+	var res []models.AppInstance
+	for _, stat := range stats {
+		if stat.Name != app.Name {
+			continue
+		}
+		for name, ins := range stat.Instances {
+			item := models.AppInstance{
+				Node:        node.Name,
+				Name:        name,
+				ServiceName: ins.ServiceName,
+				Status:      ins.Status,
+				Cause:       ins.Cause,
+				Containers:  ins.Containers,
+			}
+			for _, c := range ins.Containers {
+				item.RestartCount += c.RestartCount
+			}
+			res = append(res, item)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
+
+// RestartAppInstance returns the function for NodeService.UpdateDesire which asks node to restart the instance,
+// the restart requested before for the same instance is replaced
+func RestartAppInstance(restart models.AppInstanceRestart) func(*models.Shadow, *specV1.Application) {
+	return func(shadow *models.Shadow, _ *specV1.Application) {
+		res := []models.AppInstanceRestart{}
+		for _, r := range desiredRestarts(shadow) {
+			if r.App != restart.App || r.Instance != restart.Instance {
+				res = append(res, r)
+			}
+		}
+		if shadow.Desire == nil {
+			shadow.Desire = specV1.Desire{}
+		}
+		shadow.Desire[common.DesiredRestarts] = append(res, restart)
+	}
+}
+
+// PruneAppInstanceRestarts removes the restarts which the node reports done or are expired from the desire of node,
+// true is returned if any is removed
+func PruneAppInstanceRestarts(shadow *models.Shadow, now time.Time) bool {
+	restarts := desiredRestarts(shadow)
+	if len(restarts) == 0 {
+		return false
+	}
+	var done []models.AppInstanceRestart
+	if err := common.DecodeReport(shadow.Report, common.DesiredRestarts, &done); err != nil {
+		done = nil
+	}
+	res := []models.AppInstanceRestart{}
+	for _, r := range restarts {
+		if now.Sub(r.RequestTime) > appInstanceRestartExpiry || containsRestart(done, r) {
+			continue
+		}
+		res = append(res, r)
+	}
+	if len(res) == len(restarts) {
+		return false
+	}
+	if len(res) == 0 {
+		delete(shadow.Desire, common.DesiredRestarts)
+	} else {
+		shadow.Desire[common.DesiredRestarts] = res
+	}
+	return true
+}
+
+func desiredRestarts(shadow *models.Shadow) []models.AppInstanceRestart {
+	var res []models.AppInstanceRestart
+	if err := common.DecodeReport(specV1.Report(shadow.Desire), common.DesiredRestarts, &res); err != nil {
+		return nil
+	}
+	return res
+}
+
+func containsRestart(restarts []models.AppInstanceRestart, restart models.AppInstanceRestart) bool {
+	for _, r := range restarts {
+		if r.App == restart.App && r.Instance == restart.Instance && r.RequestTime.Equal(restart.RequestTime) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestAppInstances(t *testing.T) {
+	node := &specV1.Node{
+		Name: "node01",
+		Report: specV1.Report{
+			"appstats": []interface{}{
+				map[string]interface{}{
+					"name": "web",
+					"instances": map[string]interface{}{
+						"web-b": map[string]interface{}{"serviceName": "nginx", "status": "Running"},
+						"web-a": map[string]interface{}{
+							"serviceName": "nginx",
+							"status":      "Failed",
+							"cause":       "CrashLoopBackOff",
+							"containers": []interface{}{
+								map[string]interface{}{"name": "nginx", "state": "waiting", "restartCount": 5,
+									"lastTermination": map[string]interface{}{"reason": "OOMKilled", "exitCode": 137}},
+								map[string]interface{}{"name": "agent", "state": "running", "restartCount": 1},
+							},
+						},
+					},
+				},
+				map[string]interface{}{"name": "db", "instances": map[string]interface{}{"db-0": map[string]interface{}{}}},
+			},
+		},
+	}
+	res, err := AppInstances(node, &specV1.Application{Name: "web"})
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "web-a", res[0].Name)
+	assert.Equal(t, "node01", res[0].Node)
+	assert.Equal(t, int32(6), res[0].RestartCount)
+	assert.Equal(t, "OOMKilled", res[0].Containers[0].LastTermination.Reason)
+	assert.Equal(t, int32(137), res[0].Containers[0].LastTermination.ExitCode)
+	assert.Nil(t, res[0].Containers[1].LastTermination)
+	assert.Equal(t, "web-b", res[1].Name)
+	assert.Zero(t, res[1].RestartCount)
+
+	// the system apps are reported separately
+	res, err = AppInstances(node, &specV1.Application{Name: "web", System: true})
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	node.Report["appstats"] = "broken"
+	_, err = AppInstances(node, &specV1.Application{Name: "web"})
+	assert.Error(t, err)
+}
+
+func TestAppInstanceRestarts(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	shadow := &models.Shadow{}
+	RestartAppInstance(models.AppInstanceRestart{App: "web", Instance: "web-a", RequestTime: now.Add(-time.Minute)})(shadow, nil)
+	RestartAppInstance(models.AppInstanceRestart{App: "web", Instance: "web-b", RequestTime: now.Add(-2 * time.Hour)})(shadow, nil)
+	RestartAppInstance(models.AppInstanceRestart{App: "db", Instance: "db-0", RequestTime: now})(shadow, nil)
+	// the instance restarted again replaces the one before
+	RestartAppInstance(models.AppInstanceRestart{App: "web", Instance: "web-a", RequestTime: now})(shadow, nil)
+	assert.Len(t, desiredRestarts(shadow), 3)
+
+	// web-b is expired and db-0 is reported done
+	shadow.Report = specV1.Report{common.DesiredRestarts: []models.AppInstanceRestart{{App: "db", Instance: "db-0", RequestTime: now}}}
+	assert.True(t, PruneAppInstanceRestarts(shadow, now))
+	assert.Equal(t, []models.AppInstanceRestart{{App: "web", Instance: "web-a", RequestTime: now}}, desiredRestarts(shadow))
+	assert.False(t, PruneAppInstanceRestarts(shadow, now))
+
+	shadow.Report = specV1.Report{common.DesiredRestarts: []models.AppInstanceRestart{{App: "web", Instance: "web-a", RequestTime: now}}}
+	assert.True(t, PruneAppInstanceRestarts(shadow, now))
+	assert.NotContains(t, shadow.Desire, common.DesiredRestarts)
+}
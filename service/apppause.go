@@ -0,0 +1,46 @@
+package service
+
+import (
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// IsAppPaused returns true if the reconciliation of app is paused, its nodes are not synced with the cloud
+func IsAppPaused(labels map[string]string) bool {
+	return labels[common.LabelAppPaused] == "true"
+}
+
+// SetAppPaused marks the labels of app paused or not
+func SetAppPaused(labels map[string]string, paused bool) map[string]string {
+	if !paused {
+		delete(labels, common.LabelAppPaused)
+		return labels
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[common.LabelAppPaused] = "true"
+	return labels
+}
+
+// HoldPausedApps keeps the paused apps in delta at the versions reported by node, so that the changes
+// made on the edge are not reverted. The paused apps which the node does not run are not delivered
+func HoldPausedApps(delta specV1.Delta, report specV1.Report, items []models.AppItem) error {
+	held := map[string]bool{}
+	for _, item := range items {
+		if IsAppPaused(item.Labels) {
+			held[item.Name] = true
+		}
+	}
+	if len(held) == 0 {
+		return nil
+	}
+	var reported []specV1.AppInfo
+	if err := common.DecodeReport(report, common.DesiredApplications, &reported); err != nil {
+		return err
+	}
+	holdDeltaApps(delta, reported, held)
+	return nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestSetAppPaused(t *testing.T) {
+	labels := SetAppPaused(nil, true)
+	assert.True(t, IsAppPaused(labels))
+	labels["a"] = "b"
+	labels = SetAppPaused(labels, false)
+	assert.False(t, IsAppPaused(labels))
+	assert.Equal(t, map[string]string{"a": "b"}, labels)
+	assert.Nil(t, SetAppPaused(nil, false))
+}
+
+func TestHoldPausedApps(t *testing.T) {
+	items := []models.AppItem{{Name: "web", Labels: map[string]string{common.LabelAppPaused: "true"}}, {Name: "db"}}
+	delta := specV1.Delta{common.DesiredApplications: []specV1.AppInfo{{Name: "web", Version: "v2"}, {Name: "db", Version: "v2"}}}
+	report := specV1.Report{common.DesiredApplications: []specV1.AppInfo{{Name: "web", Version: "v1"}}}
+	assert.NoError(t, HoldPausedApps(delta, report, items))
+	assert.Equal(t, []specV1.AppInfo{{Name: "web", Version: "v1"}, {Name: "db", Version: "v2"}}, delta.AppInfos(false))
+
+	// the apps running on node are not removed when the report is broken
+	report = specV1.Report{common.DesiredApplications: "broken"}
+	assert.Error(t, HoldPausedApps(delta, report, items))
+	assert.NoError(t, HoldPausedApps(delta, report, items[1:]))
+}
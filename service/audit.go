@@ -0,0 +1,63 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/audit.go -package=service github.com/baetyl/baetyl-cloud/v2/service AuditService
+
+type AuditService interface {
+	Audit(record *models.AuditRecord) error
+}
+
+type AuditServiceImpl struct {
+	Auditors map[string]plugin.Auditor
+	// the hash chain of each sink must be extended one record at a time
+	mutex sync.Mutex
+}
+
+// NewAuditService returns nil if there is no auditor configured
+func NewAuditService(config *config.CloudConfig) (AuditService, error) {
+	if len(config.Plugin.Auditors) == 0 {
+		return nil, nil
+	}
+	auditors := map[string]plugin.Auditor{}
+	for _, name := range config.Plugin.Auditors {
+		p, err := plugin.GetPlugin(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		auditors[name] = p.(plugin.Auditor)
+	}
+	return &AuditServiceImpl{Auditors: auditors}, nil
+}
+
+// Audit seals the record with the last hash of each sink and ships it, the record is shipped to other sinks even if one fails
+func (a *AuditServiceImpl) Audit(record *models.AuditRecord) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	var res error
+	for name, auditor := range a.Auditors {
+		hash, err := auditor.GetLastAuditHash()
+		if err != nil {
+			log.L().Error("failed to get last audit hash", log.Any("auditor", name), log.Error(err))
+			res = err
+			continue
+		}
+		r := *record
+		r.Seal(hash)
+		if err = auditor.Audit(&r); err != nil {
+			log.L().Error("failed to audit", log.Any("auditor", name), log.Error(err))
+			res = err
+		}
+	}
+	return res
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestAuditService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	as, err := NewAuditService(conf)
+	assert.NoError(t, err)
+	assert.Nil(t, as)
+
+	conf.Plugin.Auditors = []string{common.RandString(9)}
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mAuditor := mockPlugin.NewMockAuditor(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.Auditors[0], func() (plugin.Plugin, error) {
+		return mAuditor, nil
+	})
+
+	as, err = NewAuditService(conf)
+	assert.NoError(t, err)
+
+	record := &models.AuditRecord{Namespace: "default", Method: "PUT", Name: "node01"}
+	mAuditor.EXPECT().GetLastAuditHash().Return("prev", nil)
+	mAuditor.EXPECT().Audit(gomock.Any()).DoAndReturn(func(r *models.AuditRecord) error {
+		assert.Equal(t, "prev", r.PrevHash)
+		assert.Equal(t, r.ComputeHash(), r.Hash)
+		return nil
+	})
+	assert.NoError(t, as.Audit(record))
+	// the record passed in is not sealed
+	assert.Equal(t, "", record.Hash)
+
+	mAuditor.EXPECT().GetLastAuditHash().Return("", fmt.Errorf("error"))
+	assert.Error(t, as.Audit(record))
+
+	mAuditor.EXPECT().GetLastAuditHash().Return("", nil)
+	mAuditor.EXPECT().Audit(gomock.Any()).Return(fmt.Errorf("error"))
+	assert.Error(t, as.Audit(record))
+}
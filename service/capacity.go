@@ -0,0 +1,21 @@
+package service
+
+import (
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NodeCapacity returns the capacity and usage in the nodestats reported by node,
+// which are summed for all the members of cluster. Nil is returned if node reports no nodestats
+func NodeCapacity(node *specV1.Node) (capacity, usage map[string]resource.Quantity) {
+	stats := decodeNodeResources(node.Report)
+	if len(stats) == 0 {
+		return nil, nil
+	}
+	capacity, usage = map[string]resource.Quantity{}, map[string]resource.Quantity{}
+	for _, st := range stats {
+		sumQuantities(capacity, st.Capacity)
+		sumQuantities(usage, st.Usage)
+	}
+	return capacity, usage
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+func TestNodeCapacity(t *testing.T) {
+	capacity, usage := NodeCapacity(&specV1.Node{Name: "node01"})
+	assert.Nil(t, capacity)
+	assert.Nil(t, usage)
+
+	node := &specV1.Node{Name: "node01", Report: specV1.Report{common.NodeStats: specV1.NodeStats{
+		Usage:    map[string]string{"cpu": "500m", "memory": "256Mi"},
+		Capacity: map[string]string{"cpu": "2", "memory": "1Gi"},
+	}}}
+	capacity, usage = NodeCapacity(node)
+	assert.Equal(t, map[string]string{"cpu": "2", "memory": "1Gi"}, formatQuantities(capacity))
+	assert.Equal(t, map[string]string{"cpu": "500m", "memory": "256Mi"}, formatQuantities(usage))
+
+	// the members of cluster are summed
+	node.Report[common.NodeStats] = map[string]interface{}{
+		"k3s-master":  map[string]interface{}{"capacity": map[string]string{"cpu": "4", "memory": "8Gi"}, "usage": map[string]string{"cpu": "1"}},
+		"k3s-worker1": map[string]interface{}{"capacity": map[string]string{"cpu": "2", "memory": "4Gi"}, "usage": map[string]string{"cpu": "500m"}},
+	}
+	capacity, usage = NodeCapacity(node)
+	assert.Equal(t, map[string]string{"cpu": "6", "memory": "12Gi"}, formatQuantities(capacity))
+	assert.Equal(t, map[string]string{"cpu": "1500m"}, formatQuantities(usage))
+}
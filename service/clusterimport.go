@@ -0,0 +1,119 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/clusterimport.go -package=service github.com/baetyl/baetyl-cloud/v2/service ClusterImportService
+
+const (
+	clusterImportTimeout = 10 * time.Second
+
+	labelRoleMaster       = "node-role.kubernetes.io/master"
+	labelRoleControlPlane = "node-role.kubernetes.io/control-plane"
+)
+
+type ClusterImportService interface {
+	// ListNodes connects to the kubernetes cluster to import and lists its nodes matching the selector
+	ListNodes(req *models.NodeImport) ([]models.ClusterNode, error)
+}
+
+type ClusterImportServiceImpl struct {
+	newClient func(cfg *rest.Config) (kubernetes.Interface, error)
+}
+
+func NewClusterImportService(_ *config.CloudConfig) (ClusterImportService, error) {
+	return &ClusterImportServiceImpl{
+		newClient: func(cfg *rest.Config) (kubernetes.Interface, error) {
+			return kubernetes.NewForConfig(cfg)
+		},
+	}, nil
+}
+
+func (s *ClusterImportServiceImpl) ListNodes(req *models.NodeImport) ([]models.ClusterNode, error) {
+	cfg, err := clusterRestConfig(req)
+	if err != nil {
+		return nil, err
+	}
+	cli, err := s.newClient(cfg)
+	if err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	list, err := cli.CoreV1().Nodes().List(metav1.ListOptions{LabelSelector: req.Selector})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res := make([]models.ClusterNode, 0, len(list.Items))
+	for i := range list.Items {
+		res = append(res, toClusterNode(&list.Items[i]))
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res, nil
+}
+
+func clusterRestConfig(req *models.NodeImport) (*rest.Config, error) {
+	var cfg *rest.Config
+	if req.Kubeconfig != "" {
+		c, err := clientcmd.RESTConfigFromKubeConfig([]byte(req.Kubeconfig))
+		if err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "invalid kubeconfig: "+err.Error()))
+		}
+		cfg = c
+	} else {
+		if req.Server == "" || req.Token == "" {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "kubeconfig or server and token is required"))
+		}
+		cfg = &rest.Config{
+			Host:        req.Server,
+			BearerToken: req.Token,
+			TLSClientConfig: rest.TLSClientConfig{
+				CAData:   []byte(req.CAData),
+				Insecure: req.Insecure,
+			},
+		}
+	}
+	cfg.Timeout = clusterImportTimeout
+	return cfg, nil
+}
+
+func toClusterNode(node *corev1.Node) models.ClusterNode {
+	res := models.ClusterNode{
+		Name:           node.Name,
+		Labels:         node.Labels,
+		OS:             node.Status.NodeInfo.OperatingSystem,
+		Arch:           node.Status.NodeInfo.Architecture,
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+	}
+	res.Master = isMasterNode(node.Labels)
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == corev1.NodeInternalIP {
+			res.Address = addr.Address
+			break
+		}
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			res.Ready = cond.Status == corev1.ConditionTrue
+		}
+	}
+	return res
+}
+
+// isMasterNode returns whether the kubernetes node runs the control plane by its role labels
+func isMasterNode(labels map[string]string) bool {
+	_, master := labels[labelRoleMaster]
+	_, controlPlane := labels[labelRoleControlPlane]
+	return master || controlPlane
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestClusterImportListNodes(t *testing.T) {
+	cli := fake.NewSimpleClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker1", Labels: map[string]string{"zone": "a"}},
+			Status: corev1.NodeStatus{
+				Addresses:  []corev1.NodeAddress{{Type: corev1.NodeHostName, Address: "worker1"}, {Type: corev1.NodeInternalIP, Address: "192.168.1.2"}},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				NodeInfo:   corev1.NodeSystemInfo{OperatingSystem: "linux", Architecture: "arm64", KubeletVersion: "v1.21.4+k3s1"},
+			},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "master", Labels: map[string]string{"zone": "a", labelRoleControlPlane: "true"}},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{"zone": "b"}},
+		},
+	)
+	var got *rest.Config
+	s := &ClusterImportServiceImpl{newClient: func(cfg *rest.Config) (kubernetes.Interface, error) {
+		got = cfg
+		return cli, nil
+	}}
+
+	res, err := s.ListNodes(&models.NodeImport{Server: "https://127.0.0.1:6443", Token: "token", Insecure: true, Selector: "zone=a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://127.0.0.1:6443", got.Host)
+	assert.Equal(t, "token", got.BearerToken)
+	assert.True(t, got.Insecure)
+	assert.Equal(t, clusterImportTimeout, got.Timeout)
+	assert.Equal(t, []models.ClusterNode{
+		{Name: "master", Labels: map[string]string{"zone": "a", labelRoleControlPlane: "true"}, Master: true},
+		{Name: "worker1", Labels: map[string]string{"zone": "a"}, Address: "192.168.1.2", OS: "linux", Arch: "arm64", KubeletVersion: "v1.21.4+k3s1", Ready: true},
+	}, res)
+
+	_, err = s.ListNodes(&models.NodeImport{Kubeconfig: "invalid"})
+	assert.Error(t, err)
+	_, err = s.ListNodes(&models.NodeImport{Server: "https://127.0.0.1:6443"})
+	assert.Error(t, err)
+}
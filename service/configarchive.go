@@ -0,0 +1,115 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// the directory of archive placing the configs, one json file of config view for each
+const configArchiveDir = "configs"
+
+// the archive is loaded in memory, which is limited to refuse the ones decompressed into huge files
+const maxConfigArchiveSize = 64 << 20
+
+// PackConfigArchive packs the configs into a tar.gz archive, the fields bound to the namespace such as version and
+// timestamps are dropped so that the archive can be loaded into another namespace
+func PackConfigArchive(configs []models.ConfigurationView) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	now := time.Now()
+	for _, cfg := range configs {
+		cfg.Namespace, cfg.Version = "", ""
+		cfg.CreationTimestamp, cfg.UpdateTimestamp = time.Time{}, time.Time{}
+		data, err := json.MarshalIndent(&cfg, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		header := &tar.Header{
+			Name:     path.Join(configArchiveDir, cfg.Name+".json"),
+			Mode:     0644,
+			Size:     int64(len(data)),
+			ModTime:  now,
+			Typeflag: tar.TypeReg,
+		}
+		if err = tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err = tw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// LoadConfigArchive loads the configs packed by PackConfigArchive, sorted by name
+func LoadConfigArchive(archive []byte) ([]models.ConfigurationView, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, configArchiveError("the archive is not gzipped")
+	}
+	defer gr.Close()
+
+	var res []models.ConfigurationView
+	names := map[string]bool{}
+	total := int64(0)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, configArchiveError(err.Error())
+		}
+		name := path.Clean(header.Name)
+		if header.Typeflag != tar.TypeReg || path.Dir(name) != configArchiveDir || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		if total += header.Size; total > maxConfigArchiveSize {
+			return nil, configArchiveError("the archive is too large")
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(tr, header.Size))
+		if err != nil {
+			return nil, configArchiveError(err.Error())
+		}
+		var cfg models.ConfigurationView
+		if err = json.Unmarshal(data, &cfg); err != nil {
+			return nil, configArchiveError("failed to parse " + name + ": " + err.Error())
+		}
+		if cfg.Name == "" {
+			cfg.Name = strings.TrimSuffix(path.Base(name), ".json")
+		}
+		if names[cfg.Name] {
+			return nil, configArchiveError("the config " + cfg.Name + " is duplicated")
+		}
+		names[cfg.Name] = true
+		res = append(res, cfg)
+	}
+	if len(res) == 0 {
+		return nil, configArchiveError("no config is found in " + configArchiveDir + "/")
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res, nil
+}
+
+func configArchiveError(msg string) error {
+	return common.Error(common.ErrRequestParamInvalid, common.Field("error", "invalid config archive: "+msg))
+}
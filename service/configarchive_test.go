@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestPackAndLoadConfigArchive(t *testing.T) {
+	configs := []models.ConfigurationView{
+		{
+			Name:              "web",
+			Namespace:         "dev",
+			Labels:            map[string]string{"app": "web"},
+			Version:           "12",
+			CreationTimestamp: time.Now(),
+			Data: []models.ConfigDataItem{
+				{Key: "conf.yml", Value: map[string]string{"type": "kv", "value": "level: debug"}},
+			},
+		},
+		{
+			Name:      "db",
+			Namespace: "dev",
+			Data: []models.ConfigDataItem{
+				{Key: "model.zip", Value: map[string]string{"type": "object", "source": "http", "url": "http://a.b/c"}},
+			},
+		},
+	}
+	archive, err := PackConfigArchive(configs)
+	assert.NoError(t, err)
+
+	res, err := LoadConfigArchive(archive)
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "db", res[0].Name)
+	assert.Equal(t, configs[1].Data, res[0].Data)
+	assert.Equal(t, "web", res[1].Name)
+	assert.Empty(t, res[1].Namespace)
+	assert.Empty(t, res[1].Version)
+	assert.True(t, res[1].CreationTimestamp.IsZero())
+	assert.Equal(t, configs[0].Labels, res[1].Labels)
+	assert.Equal(t, configs[0].Data, res[1].Data)
+	// the configs packed are not modified
+	assert.Equal(t, "dev", configs[0].Namespace)
+}
+
+func TestLoadConfigArchiveInvalid(t *testing.T) {
+	_, err := LoadConfigArchive([]byte("not gzipped"))
+	assert.Error(t, err)
+
+	// the files outside of configs are ignored
+	_, err = LoadConfigArchive(packHelmChart(t, map[string]string{"web.json": `{"name":"web"}`}))
+	assert.Error(t, err)
+
+	_, err = LoadConfigArchive(packHelmChart(t, map[string]string{"configs/web.json": `{"name":`}))
+	assert.Error(t, err)
+
+	_, err = LoadConfigArchive(packHelmChart(t, map[string]string{
+		"configs/web.json":  `{"name":"web"}`,
+		"configs/web2.json": `{"name":"web"}`,
+	}))
+	assert.Error(t, err)
+
+	// the name of file is used if the config has no name
+	res, err := LoadConfigArchive(packHelmChart(t, map[string]string{"configs/web.json": `{"labels":{"a":"b"}}`}))
+	assert.NoError(t, err)
+	assert.Equal(t, "web", res[0].Name)
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+)
+
+//go:generate mockgen -destination=../mock/service/configobject.go -package=service github.com/baetyl/baetyl-cloud/v2/service ConfigObjectService
+
+// ConfigObjectService stores the large kv items of configs in object storage, since the size of config is limited
+// by kubernetes. The items are replaced with the object items, whose download urls are generated by sync for nodes
+type ConfigObjectService interface {
+	// Offload replaces the kv items of config larger than the threshold with the object items,
+	// the items are not uploaded in dry run
+	Offload(userID string, cfg *specV1.Configuration, dryRun bool) error
+	// CheckUpload checks the size and the type of file uploaded as the item of config
+	CheckUpload(filename string, size int64) error
+}
+
+type ConfigObjectServiceImpl struct {
+	Object    ObjectService
+	Prop      PropertyService
+	threshold int64
+	source    string
+	maxUpload int64
+	types     []string
+}
+
+// NewConfigObjectService NewConfigObjectService
+func NewConfigObjectService(config *config.CloudConfig) (ConfigObjectService, error) {
+	objectService, err := NewObjectService(config)
+	if err != nil {
+		return nil, err
+	}
+	propertyService, err := NewPropertyService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigObjectServiceImpl{
+		Object:    objectService,
+		Prop:      propertyService,
+		threshold: config.ConfigObject.Threshold,
+		source:    config.ConfigObject.Source,
+		maxUpload: config.ConfigObject.MaxUploadSize,
+		types:     config.ConfigObject.UploadTypes,
+	}, nil
+}
+
+func (s *ConfigObjectServiceImpl) Offload(userID string, cfg *specV1.Configuration, dryRun bool) error {
+	// the configs of system apps are read before the objects can be downloaded, they are kept in place
+	if s.threshold <= 0 || cfg.Labels[common.LabelSystem] == "true" {
+		return nil
+	}
+	var keys, binaries []string
+	for k, v := range cfg.Data {
+		// the templates are rendered by sync for each node, they are kept in place as well as the schema
+		if strings.HasPrefix(k, common.ConfigObjectPrefix) || strings.HasPrefix(k, common.ConfigTemplatePrefix) || k == common.ConfigSchemaKey {
+			continue
+		}
+		// the binary items can not be kept in kubernetes, which stores the data of configmaps as utf-8
+		if !utf8.ValidString(v) {
+			binaries = append(binaries, k)
+			keys = append(keys, k)
+		} else if int64(len(v)) > s.threshold {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	source, err := s.objectSource()
+	if err != nil {
+		return err
+	}
+	if source == "" {
+		if len(binaries) > 0 {
+			sort.Strings(binaries)
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error",
+				"the binary items of config ("+strings.Join(binaries, ",")+") require object storage"))
+		}
+		log.L().Warn("the large items of config are kept since no object storage is available",
+			log.Any(common.KeyContextNamespace, cfg.Namespace), log.Any("name", cfg.Name), log.Any("keys", keys))
+		return nil
+	}
+
+	bucket := fmt.Sprintf("%s-%s", common.BaetylCloud, userID)
+	if !dryRun {
+		if _, err = s.Object.CreateInternalBucketIfNotExist(userID, bucket, common.AWSS3PrivatePermission, source); err != nil {
+			return err
+		}
+	}
+	for _, k := range keys {
+		if _, ok := cfg.Data[common.ConfigObjectPrefix+k]; ok {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the key "+k+" of config is duplicated"))
+		}
+		data := []byte(cfg.Data[k])
+		sum := md5.Sum(data)
+		md5Hex := hex.EncodeToString(sum[:])
+		// the objects are named by content, so that the nodes on the old versions of config still get what they expect
+		object := fmt.Sprintf("configs/%s/%s/%s/%s", cfg.Namespace, cfg.Name, md5Hex, k)
+		if !dryRun {
+			if err = s.Object.PutInternalObject(userID, bucket, object, source, data); err != nil {
+				return err
+			}
+		}
+		item, err := json.Marshal(&specV1.ConfigurationObject{
+			MD5: md5Hex,
+			// the same as the metadata of object items created by users
+			Metadata: map[string]string{
+				"type":   "object",
+				"source": source,
+				"bucket": bucket,
+				"object": object,
+				"md5":    md5Hex,
+				"userID": userID,
+			},
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		delete(cfg.Data, k)
+		cfg.Data[common.ConfigObjectPrefix+k] = string(item)
+	}
+	return nil
+}
+
+func (s *ConfigObjectServiceImpl) CheckUpload(filename string, size int64) error {
+	if s.maxUpload > 0 && size > s.maxUpload {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error",
+			fmt.Sprintf("the file %s is larger than %d bytes", filename, s.maxUpload)))
+	}
+	if len(s.types) == 0 {
+		return nil
+	}
+	ext := strings.ToLower(path.Ext(filename))
+	for _, t := range s.types {
+		if strings.ToLower(t) == ext {
+			return nil
+		}
+	}
+	return common.Error(common.ErrRequestParamInvalid, common.Field("error",
+		"the type of file "+filename+" is not allowed, only "+strings.Join(s.types, ",")+" can be uploaded"))
+}
+
+// objectSource returns the object source storing the items, empty if it is neither configured nor supported
+func (s *ConfigObjectServiceImpl) objectSource() (string, error) {
+	source := s.source
+	if source == "" {
+		var err error
+		if source, err = s.Prop.GetPropertyValue(common.ObjectSource); err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				return "", nil
+			}
+			return "", err
+		}
+	}
+	if _, ok := s.Object.ListSources()[source]; !ok {
+		return "", nil
+	}
+	return source, nil
+}
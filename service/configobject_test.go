@@ -0,0 +1,138 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewConfigObjectService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.Property = common.RandString(9)
+	_, err := NewConfigObjectService(conf)
+	assert.Error(t, err)
+}
+
+func TestConfigObjectOffload(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sObj, sProp := ms.NewMockObjectService(mockCtl), ms.NewMockPropertyService(mockCtl)
+	cs := &ConfigObjectServiceImpl{Object: sObj, Prop: sProp, threshold: 8}
+	sources := map[string]models.ObjectStorageSourceV2{"minio": {}}
+	large := strings.Repeat("a", 9)
+	newConfig := func() *specV1.Configuration {
+		return &specV1.Configuration{
+			Name:      "model",
+			Namespace: "default",
+			Data:      map[string]string{"small": "a", "model.bin": large},
+		}
+	}
+	md5Hex := "552e6a97297c53e592208cf97fbb3b60"
+	object := "configs/default/model/" + md5Hex + "/model.bin"
+
+	// dry run renders the items without uploading
+	cfg := newConfig()
+	sProp.EXPECT().GetPropertyValue(common.ObjectSource).Return("minio", nil)
+	sObj.EXPECT().ListSources().Return(sources)
+	assert.NoError(t, cs.Offload("u1", cfg, true))
+	assert.Equal(t, "a", cfg.Data["small"])
+	assert.NotContains(t, cfg.Data, "model.bin")
+	item := new(specV1.ConfigurationObject)
+	assert.NoError(t, json.Unmarshal([]byte(cfg.Data[common.ConfigObjectPrefix+"model.bin"]), item))
+	assert.Equal(t, md5Hex, item.MD5)
+	assert.Equal(t, map[string]string{
+		"type":   "object",
+		"source": "minio",
+		"bucket": "baetyl-cloud-u1",
+		"object": object,
+		"md5":    md5Hex,
+		"userID": "u1",
+	}, item.Metadata)
+
+	cfg = newConfig()
+	sProp.EXPECT().GetPropertyValue(common.ObjectSource).Return("minio", nil)
+	sObj.EXPECT().ListSources().Return(sources)
+	sObj.EXPECT().CreateInternalBucketIfNotExist("u1", "baetyl-cloud-u1", common.AWSS3PrivatePermission, "minio").Return(&models.Bucket{}, nil)
+	sObj.EXPECT().PutInternalObject("u1", "baetyl-cloud-u1", object, "minio", []byte(large)).Return(nil)
+	assert.NoError(t, cs.Offload("u1", cfg, false))
+	assert.Contains(t, cfg.Data, common.ConfigObjectPrefix+"model.bin")
+
+	cfg = newConfig()
+	sProp.EXPECT().GetPropertyValue(common.ObjectSource).Return("minio", nil)
+	sObj.EXPECT().ListSources().Return(sources)
+	sObj.EXPECT().CreateInternalBucketIfNotExist("u1", "baetyl-cloud-u1", common.AWSS3PrivatePermission, "minio").Return(&models.Bucket{}, nil)
+	sObj.EXPECT().PutInternalObject("u1", "baetyl-cloud-u1", object, "minio", []byte(large)).Return(fmt.Errorf("error"))
+	assert.Error(t, cs.Offload("u1", cfg, false))
+
+	// the items are kept if no object storage is available
+	cfg = newConfig()
+	sProp.EXPECT().GetPropertyValue(common.ObjectSource).Return("", common.Error(common.ErrResourceNotFound))
+	assert.NoError(t, cs.Offload("u1", cfg, false))
+	assert.Equal(t, large, cfg.Data["model.bin"])
+
+	cs.source = "bos"
+	sObj.EXPECT().ListSources().Return(sources)
+	assert.NoError(t, cs.Offload("u1", cfg, false))
+	assert.Equal(t, large, cfg.Data["model.bin"])
+
+	// the configs of system apps and the small ones are not offloaded
+	cfg = newConfig()
+	cfg.Labels = map[string]string{common.LabelSystem: "true"}
+	assert.NoError(t, cs.Offload("u1", cfg, false))
+	assert.Equal(t, large, cfg.Data["model.bin"])
+	cfg = newConfig()
+	delete(cfg.Data, "model.bin")
+	assert.NoError(t, cs.Offload("u1", cfg, false))
+
+	cs.threshold = 0
+	cfg = newConfig()
+	assert.NoError(t, cs.Offload("u1", cfg, false))
+	assert.Equal(t, large, cfg.Data["model.bin"])
+}
+
+func TestConfigObjectOffloadBinary(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sObj, sProp := ms.NewMockObjectService(mockCtl), ms.NewMockPropertyService(mockCtl)
+	cs := &ConfigObjectServiceImpl{Object: sObj, Prop: sProp, threshold: 1024, source: "minio"}
+	sources := map[string]models.ObjectStorageSourceV2{"minio": {}}
+
+	// the binary items are offloaded however small they are
+	cfg := &specV1.Configuration{Name: "model", Namespace: "default", Data: map[string]string{"small": "a", "logo.png": "\x89PNG"}}
+	sObj.EXPECT().ListSources().Return(sources)
+	assert.NoError(t, cs.Offload("u1", cfg, true))
+	assert.Equal(t, "a", cfg.Data["small"])
+	assert.NotContains(t, cfg.Data, "logo.png")
+	assert.Contains(t, cfg.Data, common.ConfigObjectPrefix+"logo.png")
+
+	// the binary items can not be kept without object storage
+	cs.source = "bos"
+	cfg = &specV1.Configuration{Name: "model", Namespace: "default", Data: map[string]string{"logo.png": "\x89PNG"}}
+	sObj.EXPECT().ListSources().Return(sources)
+	assert.Error(t, cs.Offload("u1", cfg, false))
+}
+
+func TestConfigObjectCheckUpload(t *testing.T) {
+	cs := &ConfigObjectServiceImpl{maxUpload: 10}
+	assert.NoError(t, cs.CheckUpload("model.bin", 10))
+	assert.Error(t, cs.CheckUpload("model.bin", 11))
+
+	cs.types = []string{".zip", ".PNG"}
+	assert.NoError(t, cs.CheckUpload("model.zip", 1))
+	assert.NoError(t, cs.CheckUpload("logo.png", 1))
+	assert.Error(t, cs.CheckUpload("model.bin", 1))
+	assert.Error(t, cs.CheckUpload("model", 1))
+
+	cs.maxUpload = 0
+	assert.NoError(t, cs.CheckUpload("model.zip", 1<<30))
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// ParseConfigTemplate parses the template item of config, the variables referenced which the node does not have
+// fail the rendering instead of producing empty content
+func ParseConfigTemplate(key, text string) (*template.Template, error) {
+	tpl, err := template.New(key).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the template of "+key+" is invalid: "+err.Error()))
+	}
+	return tpl, nil
+}
+
+// HasConfigTemplate returns whether the config has the items rendered for each node
+func HasConfigTemplate(cfg *specV1.Configuration) bool {
+	for k := range cfg.Data {
+		if strings.HasPrefix(k, common.ConfigTemplatePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderConfigTemplates replaces the template items of config with the content rendered with the variables of node
+func RenderConfigTemplates(cfg *specV1.Configuration, vars *models.ConfigTemplateVars) error {
+	for k, v := range cfg.Data {
+		if !strings.HasPrefix(k, common.ConfigTemplatePrefix) {
+			continue
+		}
+		key := strings.TrimPrefix(k, common.ConfigTemplatePrefix)
+		tpl, err := ParseConfigTemplate(key, v)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err = tpl.Execute(&buf, vars); err != nil {
+			return errors.Errorf("failed to render the template %s of config %s: %s", key, cfg.Name, err.Error())
+		}
+		delete(cfg.Data, k)
+		cfg.Data[key] = buf.String()
+	}
+	return nil
+}
+
+// NodeConfigTemplateVars returns the variables of node to render the templates with
+func NodeConfigTemplateVars(node *specV1.Node, props *models.NodeProperties) *models.ConfigTemplateVars {
+	vars := &models.ConfigTemplateVars{
+		Name:        node.Name,
+		Namespace:   node.Namespace,
+		Labels:      map[string]string{},
+		Annotations: map[string]string{},
+		Properties:  map[string]interface{}{},
+	}
+	for k, v := range node.Labels {
+		vars.Labels[k] = v
+	}
+	for k, v := range node.Annotations {
+		vars.Annotations[k] = v
+	}
+	if props != nil {
+		for k, v := range props.State.Report {
+			vars.Properties[k] = v
+		}
+		for k, v := range props.State.Desire {
+			vars.Properties[k] = v
+		}
+	}
+	return vars
+}
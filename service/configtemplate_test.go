@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestParseConfigTemplate(t *testing.T) {
+	_, err := ParseConfigTemplate("conf", "{{.Name}}-{{range .Labels}}{{.}}{{end}}")
+	assert.NoError(t, err)
+	_, err = ParseConfigTemplate("conf", "{{.Name")
+	assert.Error(t, err)
+	e, ok := err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, common.ErrRequestParamInvalid, e.Code())
+}
+
+func TestRenderConfigTemplates(t *testing.T) {
+	node := &specV1.Node{
+		Namespace:   "ns01",
+		Name:        "node01",
+		Labels:      map[string]string{"region": "bj"},
+		Annotations: map[string]string{"site": "factory-1"},
+	}
+	vars := NodeConfigTemplateVars(node, nil)
+	assert.Empty(t, vars.Properties)
+
+	cfg := &specV1.Configuration{
+		Name: "device",
+		Data: map[string]string{
+			"plain":                            "{{.Name}}",
+			common.ConfigTemplatePrefix + "id": "{{.Namespace}}.{{.Name}}@{{.Annotations.site}}",
+		},
+	}
+	assert.True(t, HasConfigTemplate(cfg))
+	assert.NoError(t, RenderConfigTemplates(cfg, vars))
+	// the kv items are kept as they are
+	assert.Equal(t, map[string]string{"plain": "{{.Name}}", "id": "ns01.node01@factory-1"}, cfg.Data)
+	assert.False(t, HasConfigTemplate(cfg))
+
+	cfg.Data[common.ConfigTemplatePrefix+"id"] = "{{.Properties.deviceId}}"
+	assert.Error(t, RenderConfigTemplates(cfg, vars))
+
+	vars = NodeConfigTemplateVars(node, &models.NodeProperties{State: models.NodePropertiesState{
+		Report: map[string]interface{}{"deviceId": "reported", "fw": "1.0"},
+		Desire: map[string]interface{}{"deviceId": "dev-01"},
+	}})
+	assert.Equal(t, map[string]interface{}{"deviceId": "dev-01", "fw": "1.0"}, vars.Properties)
+	assert.NoError(t, RenderConfigTemplates(cfg, vars))
+	assert.Equal(t, "dev-01", cfg.Data["id"])
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+// AppDependencies returns the names of the apps which the app depends on, which are marked in its labels
+func AppDependencies(labels map[string]string) []string {
+	var res []string
+	for key := range labels {
+		if strings.HasPrefix(key, common.LabelPrefixDependsOn) {
+			res = append(res, strings.TrimPrefix(key, common.LabelPrefixDependsOn))
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// SetAppDependencies replaces the dependencies marked in labels with deps
+func SetAppDependencies(labels map[string]string, deps []string) map[string]string {
+	for key := range labels {
+		if strings.HasPrefix(key, common.LabelPrefixDependsOn) {
+			delete(labels, key)
+		}
+	}
+	if len(deps) == 0 {
+		return labels
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for _, dep := range deps {
+		labels[common.LabelPrefixDependsOn+dep] = "true"
+	}
+	return labels
+}
+
+// FindAppDependencyCycle returns the path from the app back to itself if it depends on itself through deps,
+// the graph without the app is expected to have no cycle
+func FindAppDependencyCycle(name string, deps map[string][]string) []string {
+	visited := map[string]bool{}
+	var walk func(app string, path []string) []string
+	walk = func(app string, path []string) []string {
+		for _, dep := range deps[app] {
+			if dep == name {
+				return append(path, dep)
+			}
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			if res := walk(dep, append(path, dep)); res != nil {
+				return res
+			}
+		}
+		return nil
+	}
+	return walk(name, []string{name})
+}
+
+// SortAppsByDependency orders the apps so that each one follows the apps it depends on,
+// the apps keep their order otherwise and the dependencies not in apps are ignored
+func SortAppsByDependency(apps []specV1.AppInfo, deps map[string][]string) []specV1.AppInfo {
+	index := map[string]int{}
+	for i, app := range apps {
+		index[app.Name] = i
+	}
+	added := map[string]bool{}
+	res := make([]specV1.AppInfo, 0, len(apps))
+	var add func(i int)
+	add = func(i int) {
+		if added[apps[i].Name] {
+			return
+		}
+		added[apps[i].Name] = true
+		for _, dep := range deps[apps[i].Name] {
+			if j, ok := index[dep]; ok {
+				add(j)
+			}
+		}
+		res = append(res, apps[i])
+	}
+	for i := range apps {
+		add(i)
+	}
+	return res
+}
+
+// HoldDependentApps withholds the apps in delta until the apps they depend on run the desired versions on node,
+// the apps depended on but not desired by node are not waited for
+func HoldDependentApps(delta specV1.Delta, desire specV1.Desire, report specV1.Report, deps map[string][]string) {
+	desired := map[string]string{}
+	for _, app := range desire.AppInfos(false) {
+		desired[app.Name] = app.Version
+	}
+	var reported []specV1.AppInfo
+	var stats []specV1.AppStats
+	if common.DecodeReport(report, common.DesiredApplications, &reported) != nil || common.DecodeReport(report, "appstats", &stats) != nil {
+		reported, stats = nil, nil
+	}
+	ready := map[string]bool{}
+	for _, app := range reported {
+		if version, ok := desired[app.Name]; ok && version == app.Version {
+			ready[app.Name] = true
+		}
+	}
+	running := map[string]bool{}
+	for _, stat := range stats {
+		running[stat.Name] = ready[stat.Name] && isAppStatsRunning(stat)
+	}
+
+	held := map[string]bool{}
+	for app, names := range deps {
+		for _, dep := range names {
+			if _, ok := desired[dep]; ok && !running[dep] {
+				held[app] = true
+				break
+			}
+		}
+	}
+	holdDeltaApps(delta, reported, held)
+}
+
+// isAppStatsRunning returns whether all the instances of app are running, the finished jobs are taken as running
+func isAppStatsRunning(stat specV1.AppStats) bool {
+	if len(stat.InstanceStats) == 0 {
+		return false
+	}
+	for _, ins := range stat.InstanceStats {
+		if ins.Status != "Running" && ins.Status != "Succeeded" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+func TestAppDependencies(t *testing.T) {
+	labels := SetAppDependencies(nil, []string{"db", "cache"})
+	assert.Equal(t, []string{"cache", "db"}, AppDependencies(labels))
+
+	labels["city"] = "bj"
+	labels = SetAppDependencies(labels, []string{"mq"})
+	assert.Equal(t, map[string]string{"city": "bj", common.LabelPrefixDependsOn + "mq": "true"}, labels)
+
+	labels = SetAppDependencies(labels, nil)
+	assert.Nil(t, AppDependencies(labels))
+	assert.Equal(t, map[string]string{"city": "bj"}, labels)
+}
+
+func TestFindAppDependencyCycle(t *testing.T) {
+	deps := map[string][]string{
+		"app01": {"app02", "app03"},
+		"app02": {"app03"},
+		"app03": {"app04"},
+	}
+	assert.Nil(t, FindAppDependencyCycle("app01", deps))
+
+	deps["app04"] = []string{"app01"}
+	assert.Equal(t, []string{"app04", "app01", "app02", "app03", "app04"}, FindAppDependencyCycle("app04", deps))
+
+	assert.Equal(t, []string{"app05", "app05"}, FindAppDependencyCycle("app05", map[string][]string{"app05": {"app05"}}))
+}
+
+func TestSortAppsByDependency(t *testing.T) {
+	apps := []specV1.AppInfo{{Name: "web"}, {Name: "other"}, {Name: "api"}, {Name: "db"}}
+	deps := map[string][]string{
+		"web": {"api"},
+		"api": {"db", "cache"},
+	}
+	res := SortAppsByDependency(apps, deps)
+	assert.Equal(t, []specV1.AppInfo{{Name: "db"}, {Name: "api"}, {Name: "web"}, {Name: "other"}}, res)
+}
+
+func TestHoldDependentApps(t *testing.T) {
+	desire := specV1.Desire{
+		common.DesiredApplications: []specV1.AppInfo{{Name: "db", Version: "2"}, {Name: "api", Version: "2"}, {Name: "web", Version: "1"}, {Name: "job", Version: "1"}},
+	}
+	report := specV1.Report{
+		common.DesiredApplications: []specV1.AppInfo{{Name: "db", Version: "1"}, {Name: "api", Version: "1"}, {Name: "job", Version: "1"}},
+		"appstats": []specV1.AppStats{
+			{AppInfo: specV1.AppInfo{Name: "db", Version: "1"}, InstanceStats: map[string]specV1.InstanceStats{"db-0": {Status: "Running"}}},
+			{AppInfo: specV1.AppInfo{Name: "job", Version: "1"}, InstanceStats: map[string]specV1.InstanceStats{"job-0": {Status: "Succeeded"}}},
+		},
+	}
+	deps := map[string][]string{
+		"api":  {"db", "job"},
+		"web":  {"api"},
+		"job":  {"cache"},
+		"cron": {"db"},
+	}
+	delta := specV1.Delta{common.DesiredApplications: desire[common.DesiredApplications]}
+	HoldDependentApps(delta, desire, report, deps)
+	// db is not running the desired version yet, api keeps the version running and web is not delivered
+	assert.Equal(t, []specV1.AppInfo{{Name: "db", Version: "2"}, {Name: "api", Version: "1"}, {Name: "job", Version: "1"}}, delta.AppInfos(false))
+
+	report[common.DesiredApplications] = []specV1.AppInfo{{Name: "db", Version: "2"}, {Name: "api", Version: "1"}, {Name: "job", Version: "1"}}
+	report["appstats"] = []specV1.AppStats{
+		{AppInfo: specV1.AppInfo{Name: "db", Version: "2"}, InstanceStats: map[string]specV1.InstanceStats{"db-0": {Status: "Running"}}},
+		{AppInfo: specV1.AppInfo{Name: "job", Version: "1"}, InstanceStats: map[string]specV1.InstanceStats{"job-0": {Status: "Succeeded"}}},
+	}
+	delta = specV1.Delta{common.DesiredApplications: desire[common.DesiredApplications]}
+	HoldDependentApps(delta, desire, report, deps)
+	assert.Equal(t, []specV1.AppInfo{{Name: "db", Version: "2"}, {Name: "api", Version: "2"}, {Name: "job", Version: "1"}}, delta.AppInfos(false))
+}
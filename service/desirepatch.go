@@ -0,0 +1,129 @@
+package service
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	jsonpatch "github.com/evanphx/json-patch"
+
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// DesirePatch returns the resources desired like Desire, as the json merge patches against the ones node has if they
+// are cached. The resources delivered are cached by their hashes in memory of each instance, so the nodes synchronizing
+// with another instance receive the full values, which costs the bandwidth only
+func (t *SyncServiceImpl) DesirePatch(namespace string, infos []models.DesirePatchInfo, metadata map[string]string) ([]models.DesirePatchValue, error) {
+	reqs := make([]specV1.ResourceInfo, 0, len(infos))
+	for _, info := range infos {
+		reqs = append(reqs, info.ResourceInfo)
+	}
+	values, err := t.Desire(namespace, reqs, metadata)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]models.DesirePatchValue, 0, len(values))
+	for i, v := range values {
+		data, err := canonicalJSON(v.Value.Value)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		item := models.DesirePatchValue{ResourceInfo: v.ResourceInfo, Hash: hex.EncodeToString(sum[:])}
+		base := infos[i].Hash
+		switch {
+		case base == item.Hash:
+			item.Base = base
+		case base != "":
+			if old, ok := t.patches.get(base); ok {
+				patch, err := jsonpatch.CreateMergePatch(old, data)
+				if err == nil && len(patch) < len(data) {
+					item.Base, item.Patch = base, patch
+				}
+			}
+		}
+		if item.Base == "" {
+			item.Value = data
+		}
+		t.patches.put(item.Hash, data)
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+// canonicalJSON encodes the value in json with the keys of objects sorted, which node hashes the same way
+func canonicalJSON(value interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var obj interface{}
+	if err = dec.Decode(&obj); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if data, err = json.Marshal(obj); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+// desirePatchCache caches the json of resources by their hashes, the least recently used ones are evicted
+// once the total size exceeds the max
+type desirePatchCache struct {
+	mu    sync.Mutex
+	max   int64
+	size  int64
+	items map[string]*list.Element
+	order *list.List
+}
+
+type desirePatchEntry struct {
+	hash string
+	data []byte
+}
+
+func newDesirePatchCache(max int64) *desirePatchCache {
+	return &desirePatchCache{max: max, items: map[string]*list.Element{}, order: list.New()}
+}
+
+func (c *desirePatchCache) get(hash string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[hash]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*desirePatchEntry).data, true
+}
+
+func (c *desirePatchCache) put(hash string, data []byte) {
+	if c == nil || int64(len(data)) > c.max {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[hash]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.items[hash] = c.order.PushFront(&desirePatchEntry{hash: hash, data: data})
+	c.size += int64(len(data))
+	for c.size > c.max {
+		e := c.order.Back()
+		entry := e.Value.(*desirePatchEntry)
+		c.order.Remove(e)
+		delete(c.items, entry.hash)
+		c.size -= int64(len(entry.data))
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestSyncDesirePatch(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	cs := ms.NewMockConfigService(mockCtl)
+	sync := SyncServiceImpl{ConfigService: cs, Hooks: map[string]interface{}{}, patches: newDesirePatchCache(1 << 20)}
+
+	metadata := map[string]string{"namespace": "ns01", "name": "node01"}
+	newConfig := func(version, level string) *specV1.Configuration {
+		data := map[string]string{"level": level}
+		for _, k := range []string{"a", "b", "c", "d", "e", "f"} {
+			data[k] = "the value which is kept across the versions of config " + k
+		}
+		return &specV1.Configuration{Name: "device", Namespace: "ns01", Version: version, Data: data}
+	}
+
+	// the full value is delivered to the node without any resource
+	infos := []models.DesirePatchInfo{{ResourceInfo: specV1.ResourceInfo{Kind: specV1.KindConfiguration, Name: "device", Version: "v1"}}}
+	cs.EXPECT().Get("ns01", "device", "v1").Return(newConfig("v1", "info"), nil)
+	res, err := sync.DesirePatch("ns01", infos, metadata)
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Empty(t, res[0].Base)
+	assert.Empty(t, res[0].Patch)
+	assert.NotEmpty(t, res[0].Hash)
+	v1, hash := []byte(res[0].Value), res[0].Hash
+
+	// the patch against the resource of node is delivered
+	infos[0].Version, infos[0].Hash = "v2", hash
+	cs.EXPECT().Get("ns01", "device", "v2").Return(newConfig("v2", "debug"), nil)
+	res, err = sync.DesirePatch("ns01", infos, metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, res[0].Base)
+	assert.Empty(t, res[0].Value)
+	assert.NotEmpty(t, res[0].Patch)
+	patched, err := jsonpatch.MergePatch(v1, res[0].Patch)
+	assert.NoError(t, err)
+	expected, err := canonicalJSON(newConfig("v2", "debug"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(patched))
+
+	// nothing is delivered for the resource unchanged
+	infos[0].Hash = res[0].Hash
+	cs.EXPECT().Get("ns01", "device", "v2").Return(newConfig("v2", "debug"), nil)
+	res, err = sync.DesirePatch("ns01", infos, metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, infos[0].Hash, res[0].Base)
+	assert.Empty(t, res[0].Value)
+	assert.Empty(t, res[0].Patch)
+
+	// the full value is delivered if the resource of node is not cached
+	infos[0].Hash = "unknown"
+	cs.EXPECT().Get("ns01", "device", "v2").Return(newConfig("v2", "debug"), nil)
+	res, err = sync.DesirePatch("ns01", infos, metadata)
+	assert.NoError(t, err)
+	assert.Empty(t, res[0].Base)
+	assert.JSONEq(t, string(expected), string(res[0].Value))
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	data, err := canonicalJSON(map[string]interface{}{"b": 1, "a": map[string]interface{}{"d": 12345678901234567, "c": "x"}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":{"c":"x","d":12345678901234567},"b":1}`, string(data))
+
+	_, err = canonicalJSON(json.RawMessage("{"))
+	assert.Error(t, err)
+}
+
+func TestDesirePatchCache(t *testing.T) {
+	c := newDesirePatchCache(10)
+	c.put("a", []byte("aaaa"))
+	c.put("b", []byte("bbbb"))
+	_, ok := c.get("a")
+	assert.True(t, ok)
+
+	// the least recently used one is evicted
+	c.put("c", []byte("cccc"))
+	_, ok = c.get("b")
+	assert.False(t, ok)
+	data, ok := c.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "aaaa", string(data))
+	assert.Equal(t, int64(8), c.size)
+
+	// the one larger than the max is not cached
+	c.put("d", []byte("ddddddddddd"))
+	_, ok = c.get("d")
+	assert.False(t, ok)
+
+	var disabled *desirePatchCache
+	disabled.put("a", []byte("aaaa"))
+	_, ok = disabled.get("a")
+	assert.False(t, ok)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+)
+
+// desireRenderCache caches the json of the apps rendered by their versions, so that an app is rendered once for all
+// the nodes synchronizing it. The resources rendered into apps save the apps again for new versions once changed, the
+// ttl bounds the staleness otherwise. The concurrent renders of the same version wait for the first one
+type desireRenderCache struct {
+	mu      sync.Mutex
+	max     int
+	ttl     time.Duration
+	items   map[string]*list.Element
+	order   *list.List
+	pending map[string]*desireRenderCall
+}
+
+type desireRenderEntry struct {
+	key    string
+	data   []byte
+	expire time.Time
+}
+
+type desireRenderCall struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// newDesireRenderCache returns nil if max is not positive, which renders the apps on each sync
+func newDesireRenderCache(max int, ttl time.Duration) *desireRenderCache {
+	if max <= 0 {
+		return nil
+	}
+	return &desireRenderCache{max: max, ttl: ttl, items: map[string]*list.Element{}, order: list.New(), pending: map[string]*desireRenderCall{}}
+}
+
+// render returns a copy of the app rendered by key, the app is rendered by fn if not cached. The app is not cached
+// if key is empty or fn returns it uncacheable
+func (c *desireRenderCache) render(key string, fn func() (*specV1.Application, bool, error)) (*specV1.Application, error) {
+	if c == nil || key == "" {
+		app, _, err := fn()
+		return app, err
+	}
+	c.mu.Lock()
+	if e, ok := c.items[key]; ok {
+		entry := e.Value.(*desireRenderEntry)
+		if time.Now().Before(entry.expire) {
+			c.order.MoveToFront(e)
+			c.mu.Unlock()
+			return decodeRenderedApp(entry.data)
+		}
+		c.order.Remove(e)
+		delete(c.items, key)
+	}
+	if call, ok := c.pending[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		if call.err != nil {
+			return nil, call.err
+		}
+		if call.data == nil {
+			// the first one is not cacheable, which is rendered by each caller
+			app, _, err := fn()
+			return app, err
+		}
+		return decodeRenderedApp(call.data)
+	}
+	call := &desireRenderCall{done: make(chan struct{})}
+	c.pending[key] = call
+	c.mu.Unlock()
+
+	app, cacheable, err := fn()
+	if err == nil && cacheable {
+		if call.data, err = json.Marshal(app); err != nil {
+			err = errors.Trace(err)
+		}
+	}
+	call.err = err
+
+	c.mu.Lock()
+	delete(c.pending, key)
+	if call.err == nil && call.data != nil {
+		c.items[key] = c.order.PushFront(&desireRenderEntry{key: key, data: call.data, expire: time.Now().Add(c.ttl)})
+		for c.order.Len() > c.max {
+			e := c.order.Back()
+			c.order.Remove(e)
+			delete(c.items, e.Value.(*desireRenderEntry).key)
+		}
+	}
+	c.mu.Unlock()
+	close(call.done)
+
+	if err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+func decodeRenderedApp(data []byte) (*specV1.Application, error) {
+	app := new(specV1.Application)
+	if err := json.Unmarshal(data, app); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
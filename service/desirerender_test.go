@@ -0,0 +1,130 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+)
+
+func TestDesireRenderCache(t *testing.T) {
+	c := newDesireRenderCache(2, time.Minute)
+	var rendered int32
+	fn := func(name string, cacheable bool) func() (*specV1.Application, bool, error) {
+		return func() (*specV1.Application, bool, error) {
+			atomic.AddInt32(&rendered, 1)
+			return &specV1.Application{Name: name, Labels: map[string]string{"a": "b"}}, cacheable, nil
+		}
+	}
+
+	app, err := c.render("ns/a/v1", fn("a", true))
+	assert.NoError(t, err)
+	app.Labels["a"] = "changed"
+	// the copy cached is untouched by the callers
+	app, err = c.render("ns/a/v1", fn("a", true))
+	assert.NoError(t, err)
+	assert.Equal(t, "b", app.Labels["a"])
+	assert.Equal(t, int32(1), rendered)
+
+	// the uncacheable ones and the ones without key are rendered each time
+	_, err = c.render("ns/s/v1", fn("s", false))
+	assert.NoError(t, err)
+	_, err = c.render("ns/s/v1", fn("s", false))
+	assert.NoError(t, err)
+	_, err = c.render("", fn("a", true))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), rendered)
+
+	// the errors are not cached
+	_, err = c.render("ns/e/v1", func() (*specV1.Application, bool, error) { return nil, true, fmt.Errorf("error") })
+	assert.Error(t, err)
+	assert.Len(t, c.items, 1)
+
+	// the least recently used ones are evicted
+	_, err = c.render("ns/b/v1", fn("b", true))
+	assert.NoError(t, err)
+	_, err = c.render("ns/a/v1", fn("a", true))
+	assert.NoError(t, err)
+	_, err = c.render("ns/c/v1", fn("c", true))
+	assert.NoError(t, err)
+	assert.Contains(t, c.items, "ns/a/v1")
+	assert.NotContains(t, c.items, "ns/b/v1")
+
+	// the expired ones are rendered again
+	c.items["ns/a/v1"].Value.(*desireRenderEntry).expire = time.Now().Add(-time.Second)
+	rendered = 0
+	_, err = c.render("ns/a/v1", fn("a", true))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), rendered)
+
+	var disabled *desireRenderCache
+	assert.Nil(t, newDesireRenderCache(0, time.Minute))
+	app, err = disabled.render("ns/a/v1", fn("a", true))
+	assert.NoError(t, err)
+	assert.Equal(t, "a", app.Name)
+}
+
+func TestDesireRenderCacheConcurrent(t *testing.T) {
+	c := newDesireRenderCache(8, time.Minute)
+	var rendered int32
+	release := make(chan struct{})
+	fn := func() (*specV1.Application, bool, error) {
+		atomic.AddInt32(&rendered, 1)
+		<-release
+		return &specV1.Application{Name: "a"}, true, nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			app, err := c.render("ns/a/v1", fn)
+			assert.NoError(t, err)
+			assert.Equal(t, "a", app.Name)
+		}()
+	}
+	// the others wait for the first render
+	assert.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.pending) == 1
+	}, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), rendered)
+}
+
+func TestSyncDesireRenderCache(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	as, ss := ms.NewMockApplicationService(mockCtl), ms.NewMockSecretService(mockCtl)
+	svc := SyncServiceImpl{AppService: as, SecretService: ss, renders: newDesireRenderCache(8, time.Minute)}
+
+	// the app is rendered once for all the nodes
+	reqs := []specV1.ResourceInfo{{Kind: specV1.KindApplication, Name: "web", Version: "v1"}}
+	as.EXPECT().Get("ns01", "web", "v1").Return(&specV1.Application{Name: "web", Version: "v1"}, nil).Times(1)
+	for i := 0; i < 3; i++ {
+		res, err := svc.Desire("ns01", reqs, map[string]string{"namespace": "ns01", "name": fmt.Sprintf("node%d", i)})
+		assert.NoError(t, err)
+		assert.Equal(t, "web", res[0].Value.Value.(*specV1.Application).Name)
+	}
+
+	// the apps binding secrets to envs are rendered on each sync
+	labels := map[string]string{common.LabelPrefixEnvSecret + "TOKEN": "cred_token"}
+	reqs = []specV1.ResourceInfo{{Kind: specV1.KindApplication, Name: "infer", Version: "v1"}}
+	as.EXPECT().Get("ns01", "infer", "v1").Return(&specV1.Application{Name: "infer", Labels: labels}, nil).Times(2)
+	ss.EXPECT().Get("ns01", "cred", "").Return(&specV1.Secret{Name: "cred", Data: map[string][]byte{"token": []byte("t0")}}, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		_, err := svc.Desire("ns01", reqs, map[string]string{})
+		assert.NoError(t, err)
+	}
+}
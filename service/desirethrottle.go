@@ -0,0 +1,73 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// desireThrottleWindow the window which the nodes delivered the changed apps are counted in
+const desireThrottleWindow = time.Minute
+
+// desireThrottle caps the nodes delivered the changed apps in each window, of all namespaces and of each namespace. The
+// nodes admitted are counted in memory of each instance, and are admitted again in the window without being counted,
+// since they report again before the changes are applied
+type desireThrottle struct {
+	mu        sync.Mutex
+	global    int
+	namespace int
+	total     int
+	counts    map[string]int
+	admitted  map[string]time.Time
+	order     *list.List
+}
+
+type desireAdmission struct {
+	namespace string
+	key       string
+	time      time.Time
+}
+
+// newDesireThrottle returns nil if neither limit is positive, which delivers the changes to all nodes at once
+func newDesireThrottle(global, namespace int) *desireThrottle {
+	if global <= 0 && namespace <= 0 {
+		return nil
+	}
+	return &desireThrottle{global: global, namespace: namespace, counts: map[string]int{}, admitted: map[string]time.Time{}, order: list.New()}
+}
+
+// admit returns whether the changed apps are delivered to node at the time, which is always true if c is nil
+func (c *desireThrottle) admit(namespace, name string, t time.Time) bool {
+	if c == nil {
+		return true
+	}
+	key := namespace + "/" + name
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for e := c.order.Front(); e != nil; e = c.order.Front() {
+		a := e.Value.(*desireAdmission)
+		if t.Sub(a.time) < desireThrottleWindow {
+			break
+		}
+		c.order.Remove(e)
+		c.total--
+		if c.counts[a.namespace]--; c.counts[a.namespace] <= 0 {
+			delete(c.counts, a.namespace)
+		}
+		delete(c.admitted, a.key)
+	}
+	if _, ok := c.admitted[key]; ok {
+		return true
+	}
+	if c.global > 0 && c.total >= c.global {
+		return false
+	}
+	if c.namespace > 0 && c.counts[namespace] >= c.namespace {
+		return false
+	}
+	c.order.PushBack(&desireAdmission{namespace: namespace, key: key, time: t})
+	c.admitted[key] = t
+	c.counts[namespace]++
+	c.total++
+	return true
+}
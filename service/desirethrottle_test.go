@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestDesireThrottle(t *testing.T) {
+	assert.Nil(t, newDesireThrottle(0, 0))
+	var none *desireThrottle
+	assert.True(t, none.admit("ns01", "n1", time.Now()))
+
+	c := newDesireThrottle(3, 2)
+	now := time.Now()
+	assert.True(t, c.admit("ns01", "n1", now))
+	assert.True(t, c.admit("ns01", "n2", now))
+	// the namespace is full, while the nodes admitted are admitted again
+	assert.False(t, c.admit("ns01", "n3", now))
+	assert.True(t, c.admit("ns01", "n1", now.Add(time.Second)))
+	assert.True(t, c.admit("ns02", "n1", now.Add(time.Second)))
+	// all namespaces are full
+	assert.False(t, c.admit("ns03", "n1", now.Add(time.Second)))
+
+	// the admissions out of the window are not counted
+	assert.True(t, c.admit("ns01", "n3", now.Add(time.Minute)))
+	assert.True(t, c.admit("ns03", "n1", now.Add(time.Minute)))
+	assert.False(t, c.admit("ns01", "n4", now.Add(time.Minute)))
+	assert.Equal(t, 3, c.total)
+	assert.Equal(t, map[string]int{"ns01": 1, "ns02": 1, "ns03": 1}, c.counts)
+}
+
+func TestReportDesireThrottle(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns := ms.NewMockNodeService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns, throttle: newDesireThrottle(0, 1)}
+
+	shadow := &models.Shadow{
+		Desire: specV1.Desire{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "app", Version: "v2"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v2"}},
+		},
+		Report: specV1.Report{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "app", Version: "v1"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}},
+		},
+	}
+	ns.EXPECT().UpdateReport("ns01", gomock.Any(), gomock.Any()).Return(shadow, nil).Times(3)
+	ns.EXPECT().Get(nil, "ns01", gomock.Any()).Return(&specV1.Node{Namespace: "ns01"}, nil).Times(3)
+
+	delta, err := sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Contains(t, delta, common.DesiredApplications)
+
+	// the apps are withheld from the nodes beyond the limit, the system apps are not throttled
+	delta, err = sync.Report("ns01", "node02", specV1.Report{})
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.DesiredApplications)
+	assert.Contains(t, delta, common.DesiredSysApplications)
+
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Contains(t, delta, common.DesiredApplications)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"sort"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/edgecluster.go -package=service github.com/baetyl/baetyl-cloud/v2/service EdgeClusterService
+
+const (
+	EdgeClusterRoleMaster = "master"
+	EdgeClusterRoleWorker = "worker"
+)
+
+type EdgeClusterService interface {
+	Get(namespace, name string) (*models.EdgeCluster, error)
+	List(namespace string, params *models.ListOptions) (*models.EdgeClusterList, error)
+	Create(cluster *models.EdgeCluster) (*models.EdgeCluster, error)
+	Update(cluster *models.EdgeCluster) (*models.EdgeCluster, error)
+	Delete(namespace, name string) error
+}
+
+type EdgeClusterServiceImpl struct {
+	EdgeCluster plugin.EdgeCluster
+}
+
+// edgeClusterMember the info of cluster node reported by core
+type edgeClusterMember struct {
+	Hostname string            `json:"hostname,omitempty"`
+	Address  string            `json:"address,omitempty"`
+	Arch     string            `json:"arch,omitempty"`
+	OS       string            `json:"os,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+// NewEdgeClusterService NewEdgeClusterService
+func NewEdgeClusterService(config *config.CloudConfig) (EdgeClusterService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.EdgeCluster)
+	if err != nil {
+		return nil, err
+	}
+	return &EdgeClusterServiceImpl{
+		EdgeCluster: p.(plugin.EdgeCluster),
+	}, nil
+}
+
+func (s *EdgeClusterServiceImpl) Get(namespace, name string) (*models.EdgeCluster, error) {
+	return s.EdgeCluster.GetEdgeCluster(namespace, name)
+}
+
+func (s *EdgeClusterServiceImpl) List(namespace string, params *models.ListOptions) (*models.EdgeClusterList, error) {
+	items, err := s.EdgeCluster.ListEdgeCluster(namespace, &params.Filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.EdgeCluster.CountEdgeCluster(namespace, params.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &models.EdgeClusterList{
+		Total:       total,
+		ListOptions: params,
+		Items:       items,
+	}, nil
+}
+
+func (s *EdgeClusterServiceImpl) Create(cluster *models.EdgeCluster) (*models.EdgeCluster, error) {
+	if err := s.EdgeCluster.CreateEdgeCluster(cluster); err != nil {
+		return nil, err
+	}
+	return s.EdgeCluster.GetEdgeCluster(cluster.Namespace, cluster.Name)
+}
+
+func (s *EdgeClusterServiceImpl) Update(cluster *models.EdgeCluster) (*models.EdgeCluster, error) {
+	if err := s.EdgeCluster.UpdateEdgeCluster(cluster); err != nil {
+		return nil, err
+	}
+	return s.EdgeCluster.GetEdgeCluster(cluster.Namespace, cluster.Name)
+}
+
+func (s *EdgeClusterServiceImpl) Delete(namespace, name string) error {
+	return s.EdgeCluster.DeleteEdgeCluster(namespace, name)
+}
+
+// EdgeClusterStatus aggregates the status of cluster from the members reported by the core running on its node,
+// a member is ready if the core is online and the stats of member are reported
+func EdgeClusterStatus(node *specV1.Node, online bool) models.EdgeClusterStatus {
+	res := models.EdgeClusterStatus{Online: online}
+	if node == nil {
+		return res
+	}
+	_ = common.DecodeReport(node.Report, "time", &res.ReportTime)
+
+	var members map[string]edgeClusterMember
+	if err := common.DecodeReport(node.Report, common.NodeInfo, &members); err != nil {
+		return res
+	}
+	var stats map[string]nodeResource
+	_ = common.DecodeReport(node.Report, common.NodeStats, &stats)
+
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	capacity, usage := map[string]resource.Quantity{}, map[string]resource.Quantity{}
+	for _, name := range names {
+		info := members[name]
+		m := models.EdgeClusterMember{
+			Name:    name,
+			Role:    EdgeClusterRoleWorker,
+			Address: info.Address,
+			OS:      info.OS,
+			Arch:    info.Arch,
+		}
+		if isMasterNode(info.Labels) {
+			m.Role = EdgeClusterRoleMaster
+		}
+		if st, ok := stats[name]; ok {
+			m.Ready = online
+			m.Capacity, m.Usage = st.Capacity, st.Usage
+			sumQuantities(capacity, st.Capacity)
+			sumQuantities(usage, st.Usage)
+		}
+		if m.Ready {
+			res.Ready++
+		}
+		res.Members = append(res.Members, m)
+	}
+	res.Total = len(res.Members)
+	res.Capacity, res.Usage = formatQuantities(capacity), formatQuantities(usage)
+	return res
+}
+
+func sumQuantities(sum map[string]resource.Quantity, values map[string]string) {
+	for name, val := range values {
+		q := sum[name]
+		q.Add(parseQuantity(val))
+		sum[name] = q
+	}
+}
+
+func formatQuantities(values map[string]resource.Quantity) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(values))
+	for name, q := range values {
+		res[name] = q.String()
+	}
+	return res
+}
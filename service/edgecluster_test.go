@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestEdgeClusterService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.EdgeCluster = common.RandString(9)
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mEdgeCluster := mockPlugin.NewMockEdgeCluster(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.EdgeCluster, func() (plugin.Plugin, error) {
+		return mEdgeCluster, nil
+	})
+	cs, err := NewEdgeClusterService(conf)
+	assert.NoError(t, err)
+
+	cluster := &models.EdgeCluster{Namespace: "default", Name: "cluster01", Node: "cluster01"}
+	mEdgeCluster.EXPECT().CreateEdgeCluster(cluster).Return(nil)
+	mEdgeCluster.EXPECT().GetEdgeCluster("default", "cluster01").Return(cluster, nil)
+	res, err := cs.Create(cluster)
+	assert.NoError(t, err)
+	assert.Equal(t, cluster, res)
+
+	mEdgeCluster.EXPECT().UpdateEdgeCluster(cluster).Return(fmt.Errorf("error"))
+	_, err = cs.Update(cluster)
+	assert.Error(t, err)
+
+	params := &models.ListOptions{}
+	mEdgeCluster.EXPECT().ListEdgeCluster("default", &params.Filter).Return([]models.EdgeCluster{*cluster}, nil)
+	mEdgeCluster.EXPECT().CountEdgeCluster("default", "").Return(1, nil)
+	list, err := cs.List("default", params)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, list.Total)
+	assert.Equal(t, []models.EdgeCluster{*cluster}, list.Items)
+
+	mEdgeCluster.EXPECT().DeleteEdgeCluster("default", "cluster01").Return(nil)
+	assert.NoError(t, cs.Delete("default", "cluster01"))
+}
+
+func TestEdgeClusterStatus(t *testing.T) {
+	node := &specV1.Node{Namespace: "default", Name: "cluster01"}
+	res := EdgeClusterStatus(node, false)
+	assert.Equal(t, 0, res.Total)
+	assert.Nil(t, res.Members)
+
+	node.Report = specV1.Report{
+		common.NodeInfo: map[string]interface{}{
+			"k3s-master": map[string]interface{}{
+				"hostname": "k3s-master",
+				"address":  "192.168.1.10",
+				"arch":     "amd64",
+				"os":       "linux",
+				"labels":   map[string]string{"node-role.kubernetes.io/control-plane": "true"},
+			},
+			"k3s-worker1": map[string]interface{}{"address": "192.168.1.11"},
+			"k3s-worker2": map[string]interface{}{"address": "192.168.1.12"},
+		},
+		common.NodeStats: map[string]interface{}{
+			"k3s-master":  map[string]interface{}{"capacity": map[string]string{"cpu": "4", "memory": "8Gi"}, "usage": map[string]string{"cpu": "1"}},
+			"k3s-worker1": map[string]interface{}{"capacity": map[string]string{"cpu": "2", "memory": "4Gi"}, "usage": map[string]string{"cpu": "500m"}},
+		},
+	}
+	res = EdgeClusterStatus(node, true)
+	assert.True(t, res.Online)
+	assert.Equal(t, 3, res.Total)
+	assert.Equal(t, 2, res.Ready)
+	assert.Equal(t, map[string]string{"cpu": "6", "memory": "12Gi"}, res.Capacity)
+	assert.Equal(t, map[string]string{"cpu": "1500m"}, res.Usage)
+	assert.Equal(t, "k3s-master", res.Members[0].Name)
+	assert.Equal(t, EdgeClusterRoleMaster, res.Members[0].Role)
+	assert.Equal(t, "192.168.1.10", res.Members[0].Address)
+	assert.True(t, res.Members[0].Ready)
+	assert.Equal(t, EdgeClusterRoleWorker, res.Members[1].Role)
+	assert.False(t, res.Members[2].Ready)
+
+	// the members are not ready when the core is offline
+	res = EdgeClusterStatus(node, false)
+	assert.Equal(t, 0, res.Ready)
+	assert.Equal(t, 3, res.Total)
+}
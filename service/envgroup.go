@@ -0,0 +1,128 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/envgroup.go -package=service github.com/baetyl/baetyl-cloud/v2/service EnvGroupService
+
+type EnvGroupService interface {
+	Get(namespace, name string) (*models.EnvGroup, error)
+	List(namespace string) ([]models.EnvGroup, error)
+	Create(group *models.EnvGroup) (*models.EnvGroup, error)
+	Update(group *models.EnvGroup) (*models.EnvGroup, error)
+	Delete(namespace, name string) error
+}
+
+type EnvGroupServiceImpl struct {
+	EnvGroup plugin.EnvGroup
+}
+
+// NewEnvGroupService NewEnvGroupService
+func NewEnvGroupService(config *config.CloudConfig) (EnvGroupService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.EnvGroup)
+	if err != nil {
+		return nil, err
+	}
+	return &EnvGroupServiceImpl{EnvGroup: p.(plugin.EnvGroup)}, nil
+}
+
+func (s *EnvGroupServiceImpl) Get(namespace, name string) (*models.EnvGroup, error) {
+	return s.EnvGroup.GetEnvGroup(namespace, name)
+}
+
+func (s *EnvGroupServiceImpl) List(namespace string) ([]models.EnvGroup, error) {
+	return s.EnvGroup.ListEnvGroup(namespace)
+}
+
+func (s *EnvGroupServiceImpl) Create(group *models.EnvGroup) (*models.EnvGroup, error) {
+	if err := s.EnvGroup.CreateEnvGroup(group); err != nil {
+		return nil, err
+	}
+	return s.EnvGroup.GetEnvGroup(group.Namespace, group.Name)
+}
+
+func (s *EnvGroupServiceImpl) Update(group *models.EnvGroup) (*models.EnvGroup, error) {
+	if err := s.EnvGroup.UpdateEnvGroup(group); err != nil {
+		return nil, err
+	}
+	return s.EnvGroup.GetEnvGroup(group.Namespace, group.Name)
+}
+
+func (s *EnvGroupServiceImpl) Delete(namespace, name string) error {
+	return s.EnvGroup.DeleteEnvGroup(namespace, name)
+}
+
+// AppEnvGroups returns the names of the env groups which the app references, which are marked in its labels
+func AppEnvGroups(labels map[string]string) []string {
+	var res []string
+	for key := range labels {
+		if strings.HasPrefix(key, common.LabelPrefixEnvGroup) {
+			res = append(res, strings.TrimPrefix(key, common.LabelPrefixEnvGroup))
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// SetAppEnvGroups replaces the env groups marked in labels with groups
+func SetAppEnvGroups(labels map[string]string, groups []string) map[string]string {
+	for key := range labels {
+		if strings.HasPrefix(key, common.LabelPrefixEnvGroup) {
+			delete(labels, key)
+		}
+	}
+	if len(groups) == 0 {
+		return labels
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for _, group := range groups {
+		labels[common.LabelPrefixEnvGroup+group] = "true"
+	}
+	return labels
+}
+
+// ApplyEnvGroups renders the environment variables of groups into the services and init services of app,
+// the variables defined by the service itself take precedence, and the later groups override the earlier ones
+func ApplyEnvGroups(app *specV1.Application, groups []models.EnvGroup) {
+	if len(groups) == 0 {
+		return
+	}
+	var names []string
+	values := map[string]string{}
+	for _, group := range groups {
+		for _, env := range group.Envs {
+			if _, ok := values[env.Name]; !ok {
+				names = append(names, env.Name)
+			}
+			values[env.Name] = env.Value
+		}
+	}
+	apply := func(svc *specV1.Service) {
+		defined := map[string]bool{}
+		for _, env := range svc.Env {
+			defined[env.Name] = true
+		}
+		for _, name := range names {
+			if !defined[name] {
+				svc.Env = append(svc.Env, specV1.Environment{Name: name, Value: values[name]})
+			}
+		}
+	}
+	for i := range app.InitServices {
+		apply(&app.InitServices[i])
+	}
+	for i := range app.Services {
+		apply(&app.Services[i])
+	}
+}
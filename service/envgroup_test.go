@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewEnvGroupService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.EnvGroup = common.RandString(9)
+	_, err := NewEnvGroupService(conf)
+	assert.Error(t, err)
+}
+
+func TestEnvGroupService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mGroup := mockPlugin.NewMockEnvGroup(mockCtl)
+	es := &EnvGroupServiceImpl{EnvGroup: mGroup}
+
+	group := &models.EnvGroup{Namespace: "default", Name: "mqtt", Envs: []specV1.Environment{{Name: "MQTT_ADDRESS", Value: "tcp://broker:1883"}}}
+	mGroup.EXPECT().CreateEnvGroup(group).Return(nil)
+	mGroup.EXPECT().GetEnvGroup("default", "mqtt").Return(group, nil).Times(2)
+	res, err := es.Create(group)
+	assert.NoError(t, err)
+	assert.Equal(t, group, res)
+
+	mGroup.EXPECT().UpdateEnvGroup(group).Return(nil)
+	_, err = es.Update(group)
+	assert.NoError(t, err)
+	mGroup.EXPECT().UpdateEnvGroup(group).Return(common.Error(common.ErrResourceNotFound))
+	_, err = es.Update(group)
+	assert.Error(t, err)
+
+	mGroup.EXPECT().ListEnvGroup("default").Return([]models.EnvGroup{*group}, nil)
+	list, err := es.List("default")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	mGroup.EXPECT().DeleteEnvGroup("default", "mqtt").Return(nil)
+	assert.NoError(t, es.Delete("default", "mqtt"))
+}
+
+func TestAppEnvGroups(t *testing.T) {
+	labels := SetAppEnvGroups(nil, []string{"region", "mqtt"})
+	assert.Equal(t, []string{"mqtt", "region"}, AppEnvGroups(labels))
+	labels["app"] = "web"
+	labels = SetAppEnvGroups(labels, []string{"mqtt"})
+	assert.Equal(t, map[string]string{"app": "web", common.LabelPrefixEnvGroup + "mqtt": "true"}, labels)
+	labels = SetAppEnvGroups(labels, nil)
+	assert.Equal(t, map[string]string{"app": "web"}, labels)
+	assert.Nil(t, AppEnvGroups(labels))
+}
+
+func TestApplyEnvGroups(t *testing.T) {
+	app := &specV1.Application{
+		InitServices: []specV1.Service{{Name: "init"}},
+		Services: []specV1.Service{
+			{Name: "web", Env: []specV1.Environment{{Name: "REGION", Value: "bj"}}},
+			{Name: "db"},
+		},
+	}
+	groups := []models.EnvGroup{
+		{Name: "mqtt", Envs: []specV1.Environment{{Name: "MQTT_ADDRESS", Value: "tcp://broker:1883"}, {Name: "REGION", Value: "gz"}}},
+		{Name: "region", Envs: []specV1.Environment{{Name: "REGION", Value: "sh"}}},
+	}
+	ApplyEnvGroups(app, groups)
+	assert.Equal(t, []specV1.Environment{{Name: "MQTT_ADDRESS", Value: "tcp://broker:1883"}, {Name: "REGION", Value: "sh"}}, app.InitServices[0].Env)
+	// the variables of service take precedence
+	assert.Equal(t, []specV1.Environment{{Name: "REGION", Value: "bj"}, {Name: "MQTT_ADDRESS", Value: "tcp://broker:1883"}}, app.Services[0].Env)
+	assert.Equal(t, []specV1.Environment{{Name: "MQTT_ADDRESS", Value: "tcp://broker:1883"}, {Name: "REGION", Value: "sh"}}, app.Services[1].Env)
+
+	ApplyEnvGroups(app, nil)
+	assert.Len(t, app.Services[1].Env, 2)
+}
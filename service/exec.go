@@ -0,0 +1,94 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/exec.go -package=service github.com/baetyl/baetyl-cloud/v2/service ExecService
+
+// ExecSyncTimeout the max time which the sync request of node waits for frames, it should be less than the write timeout of sync link
+const ExecSyncTimeout = 10 * time.Second
+
+type ExecService interface {
+	Open(session *models.ExecSession) (<-chan models.ExecFrame, error)
+	Send(id string, frame models.ExecFrame) error
+	Close(session *models.ExecSession) error
+	Sync(namespace, node string, frames []models.ExecFrame) ([]models.ExecFrame, error)
+}
+
+type ExecServiceImpl struct {
+	Exec  plugin.Exec
+	Audit AuditService
+}
+
+// NewExecService the sessions are closed into the audit given, which is nil if nothing is audited
+func NewExecService(config *config.CloudConfig, audit AuditService) (ExecService, error) {
+	exec, err := plugin.GetPlugin(config.Plugin.Exec)
+	if err != nil {
+		return nil, err
+	}
+	return &ExecServiceImpl{
+		Exec:  exec.(plugin.Exec),
+		Audit: audit,
+	}, nil
+}
+
+func (e *ExecServiceImpl) Open(session *models.ExecSession) (<-chan models.ExecFrame, error) {
+	return e.Exec.OpenSession(session)
+}
+
+func (e *ExecServiceImpl) Send(id string, frame models.ExecFrame) error {
+	return e.Exec.SendFrame(id, frame)
+}
+
+// Close closes the session and records it in audit, including the input of user
+func (e *ExecServiceImpl) Close(session *models.ExecSession) error {
+	if err := e.Exec.CloseSession(session.ID); err != nil {
+		return err
+	}
+	session.CloseTime = time.Now().UTC()
+	if e.Audit == nil {
+		return nil
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	record := &models.AuditRecord{
+		Namespace:  session.Namespace,
+		User:       session.User,
+		Method:     "EXEC",
+		Path:       "/v1/nodes/" + session.Node + "/exec",
+		Resource:   "nodes",
+		Name:       session.Node,
+		RequestId:  session.RequestId,
+		Status:     http.StatusSwitchingProtocols,
+		Diff:       string(data),
+		CreateTime: session.CloseTime,
+	}
+	if session.Log != nil {
+		record.Method, record.Resource, record.Name = "LOG", "apps", session.App
+		record.Path = "/v1/apps/" + session.App + "/instances/" + session.Log.Instance + "/log"
+		if !session.Log.Follow {
+			record.Status = http.StatusOK
+		}
+	}
+	if err = e.Audit.Audit(record); err != nil {
+		log.L().Error("failed to audit exec session", log.Any("session", session.ID), log.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (e *ExecServiceImpl) Sync(namespace, node string, frames []models.ExecFrame) ([]models.ExecFrame, error) {
+	return e.Exec.SyncFrames(namespace, node, frames, ExecSyncTimeout)
+}
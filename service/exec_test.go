@@ -0,0 +1,91 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestExecService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	conf := &config.CloudConfig{}
+	conf.Plugin.Exec = common.RandString(9)
+	mExec := mockPlugin.NewMockExec(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.Exec, func() (plugin.Plugin, error) {
+		return mExec, nil
+	})
+	es, err := NewExecService(conf, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, es.(*ExecServiceImpl).Audit)
+
+	session := &models.ExecSession{ID: "s1", Namespace: "default", Node: "n1", User: "u1"}
+	output := make(chan models.ExecFrame)
+	mExec.EXPECT().OpenSession(session).Return((<-chan models.ExecFrame)(output), nil)
+	res, err := es.Open(session)
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+
+	frame := models.ExecFrame{Type: models.ExecFrameStdin, Data: []byte("ls\n")}
+	mExec.EXPECT().SendFrame("s1", frame).Return(nil)
+	assert.NoError(t, es.Send("s1", frame))
+
+	mExec.EXPECT().SyncFrames("default", "n1", nil, ExecSyncTimeout).Return([]models.ExecFrame{frame}, nil)
+	frames, err := es.Sync("default", "n1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ExecFrame{frame}, frames)
+
+	// without auditor
+	mExec.EXPECT().CloseSession("s1").Return(nil)
+	assert.NoError(t, es.Close(session))
+	assert.False(t, session.CloseTime.IsZero())
+
+	// with auditor
+	mAudit := ms.NewMockAuditService(mockCtl)
+	es.(*ExecServiceImpl).Audit = mAudit
+	code := 0
+	session.Input = "ls\n"
+	session.ExitCode = &code
+	mExec.EXPECT().CloseSession("s1").Return(nil)
+	mAudit.EXPECT().Audit(gomock.Any()).DoAndReturn(func(r *models.AuditRecord) error {
+		assert.Equal(t, "EXEC", r.Method)
+		assert.Equal(t, "n1", r.Name)
+		assert.Equal(t, "u1", r.User)
+		assert.Equal(t, http.StatusSwitchingProtocols, r.Status)
+		var s models.ExecSession
+		assert.NoError(t, json.Unmarshal([]byte(r.Diff), &s))
+		assert.Equal(t, "ls\n", s.Input)
+		assert.Equal(t, 0, *s.ExitCode)
+		assert.WithinDuration(t, time.Now(), r.CreateTime, time.Minute)
+		return nil
+	})
+	assert.NoError(t, es.Close(session))
+
+	// the log session is audited as the read of app instance
+	session.App, session.Log = "app1", &models.LogRequest{Instance: "app1-0"}
+	mExec.EXPECT().CloseSession("s1").Return(nil)
+	mAudit.EXPECT().Audit(gomock.Any()).DoAndReturn(func(r *models.AuditRecord) error {
+		assert.Equal(t, "LOG", r.Method)
+		assert.Equal(t, "app1", r.Name)
+		assert.Equal(t, "/v1/apps/app1/instances/app1-0/log", r.Path)
+		assert.Equal(t, http.StatusOK, r.Status)
+		return nil
+	})
+	assert.NoError(t, es.Close(session))
+
+	mExec.EXPECT().CloseSession("s1").Return(fmt.Errorf("error"))
+	assert.Error(t, es.Close(session))
+}
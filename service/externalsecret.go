@@ -0,0 +1,88 @@
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/externalsecret.go -package=service github.com/baetyl/baetyl-cloud/v2/service ExternalSecretService
+
+// ExternalSecretService resolves the secrets referencing the external secret stores, only the references
+// are stored in cloud and the data is read from the stores each time the secrets are synchronized
+type ExternalSecretService interface {
+	// Check checks the reference is resolvable by one of the configured providers
+	Check(ref *models.SecretExternal) error
+	// Resolve returns a copy of secret with the data read from its store, the secret not external is returned as it is
+	Resolve(secret *specV1.Secret) (*specV1.Secret, error)
+}
+
+type ExternalSecretServiceImpl struct {
+	providers map[string]plugin.SecretProvider
+}
+
+func NewExternalSecretService(config *config.CloudConfig) (ExternalSecretService, error) {
+	providers := map[string]plugin.SecretProvider{}
+	for _, name := range config.ExternalSecret.Providers {
+		p, err := plugin.GetPlugin(name)
+		if err != nil {
+			return nil, err
+		}
+		provider, ok := p.(plugin.SecretProvider)
+		if !ok {
+			return nil, errors.Errorf("the plugin %s is not a secret provider", name)
+		}
+		providers[name] = provider
+	}
+	return &ExternalSecretServiceImpl{providers: providers}, nil
+}
+
+func (s *ExternalSecretServiceImpl) Check(ref *models.SecretExternal) error {
+	if _, ok := s.providers[ref.Provider]; !ok {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the secret provider "+ref.Provider+" is not supported"))
+	}
+	for _, k := range ref.Keys {
+		if k == "" || k == common.SecretExternalKey {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the key "+k+" of external secret is invalid"))
+		}
+	}
+	return nil
+}
+
+func (s *ExternalSecretServiceImpl) Resolve(secret *specV1.Secret) (*specV1.Secret, error) {
+	raw, ok := secret.Data[common.SecretExternalKey]
+	if !ok {
+		return secret, nil
+	}
+	ref := new(models.SecretExternal)
+	if err := json.Unmarshal(raw, ref); err != nil {
+		return nil, errors.Errorf("failed to decode the reference of external secret %s: %s", secret.Name, err.Error())
+	}
+	p, ok := s.providers[ref.Provider]
+	if !ok {
+		return nil, errors.Errorf("the secret provider %s of secret %s is not supported", ref.Provider, secret.Name)
+	}
+	data, err := p.GetSecretData(ref)
+	if err != nil {
+		return nil, errors.Errorf("failed to read the external secret %s from %s: %s", secret.Name, ref.Provider, err.Error())
+	}
+	res := *secret
+	res.Data = data
+	if len(ref.Keys) > 0 {
+		res.Data = map[string][]byte{}
+		for _, k := range ref.Keys {
+			v, ok := data[k]
+			if !ok {
+				return nil, errors.Errorf("the key %s is not found in the external secret %s", k, secret.Name)
+			}
+			res.Data[k] = v
+		}
+	}
+	return &res, nil
+}
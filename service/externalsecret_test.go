@@ -0,0 +1,102 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestExternalSecretResolve(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	vault := mockPlugin.NewMockSecretProvider(mockCtl)
+	s := &ExternalSecretServiceImpl{providers: map[string]plugin.SecretProvider{"vaultkv": vault}}
+
+	assert.NoError(t, s.Check(&models.SecretExternal{Provider: "vaultkv", Path: "app/db"}))
+	assert.Error(t, s.Check(&models.SecretExternal{Provider: "none", Path: "app/db"}))
+	assert.Error(t, s.Check(&models.SecretExternal{Provider: "vaultkv", Path: "app/db", Keys: []string{common.SecretExternalKey}}))
+
+	// the secret not external is returned as it is
+	plain := &specV1.Secret{Name: "plain", Data: map[string][]byte{"a": []byte("b")}}
+	res, err := s.Resolve(plain)
+	assert.NoError(t, err)
+	assert.Equal(t, plain, res)
+
+	ref := &models.SecretExternal{Provider: "vaultkv", Path: "app/db", Version: "2"}
+	newSecret := func() *specV1.Secret {
+		raw, _ := json.Marshal(ref)
+		return &specV1.Secret{Name: "db", Version: "v1", Data: map[string][]byte{common.SecretExternalKey: raw}}
+	}
+	data := map[string][]byte{"username": []byte("baetyl"), "password": []byte("secret")}
+	vault.EXPECT().GetSecretData(ref).Return(data, nil)
+	secret := newSecret()
+	res, err = s.Resolve(secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "db", res.Name)
+	assert.Equal(t, "v1", res.Version)
+	assert.Equal(t, data, res.Data)
+	// the stored secret keeps the reference
+	assert.Contains(t, secret.Data, common.SecretExternalKey)
+
+	ref.Keys = []string{"password"}
+	vault.EXPECT().GetSecretData(ref).Return(data, nil)
+	res, err = s.Resolve(newSecret())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"password": []byte("secret")}, res.Data)
+
+	ref.Keys = []string{"token"}
+	vault.EXPECT().GetSecretData(ref).Return(data, nil)
+	_, err = s.Resolve(newSecret())
+	assert.Error(t, err)
+
+	ref.Keys = nil
+	vault.EXPECT().GetSecretData(ref).Return(nil, errors.New("permission denied"))
+	_, err = s.Resolve(newSecret())
+	assert.Error(t, err)
+
+	ref.Provider = "none"
+	_, err = s.Resolve(newSecret())
+	assert.Error(t, err)
+
+	_, err = s.Resolve(&specV1.Secret{Name: "bad", Data: map[string][]byte{common.SecretExternalKey: []byte("{")}})
+	assert.Error(t, err)
+}
+
+func TestNewExternalSecretService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	provider := mockPlugin.NewMockSecretProvider(mockCtl)
+	name := common.RandString(9)
+	plugin.RegisterFactory(name, func() (plugin.Plugin, error) {
+		return provider, nil
+	})
+
+	conf := &config.CloudConfig{}
+	conf.ExternalSecret.Providers = []string{name}
+	s, err := NewExternalSecretService(conf)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Check(&models.SecretExternal{Provider: name, Path: "app/db"}))
+
+	conf.ExternalSecret.Providers = []string{common.RandString(9)}
+	_, err = NewExternalSecretService(conf)
+	assert.Error(t, err)
+
+	// the plugins of other types are rejected
+	crypto := common.RandString(9)
+	plugin.RegisterFactory(crypto, func() (plugin.Plugin, error) {
+		return mockPlugin.NewMockCrypto(mockCtl), nil
+	})
+	conf.ExternalSecret.Providers = []string{crypto}
+	_, err = NewExternalSecretService(conf)
+	assert.EqualError(t, err, "the plugin "+crypto+" is not a secret provider")
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/featureflag.go -package=service github.com/baetyl/baetyl-cloud/v2/service FeatureFlagService
+
+type FeatureFlagService interface {
+	Get(name string) (*models.FeatureFlag, error)
+	List() ([]models.FeatureFlag, error)
+	Create(flag *models.FeatureFlag) (*models.FeatureFlag, error)
+	Update(flag *models.FeatureFlag) (*models.FeatureFlag, error)
+	Delete(name string) error
+	// SetOverride sets the flag for the namespace explicitly, the override is removed if enabled is nil
+	SetOverride(name, namespace string, enabled *bool) (*models.FeatureFlag, error)
+	// Resolve returns the flags enabled for the namespace
+	Resolve(namespace string) (map[string]bool, error)
+}
+
+type FeatureFlagServiceImpl struct {
+	FeatureFlag plugin.FeatureFlag
+	ttl         time.Duration
+	mu          sync.Mutex
+	cached      []models.FeatureFlag
+	expire      time.Time
+}
+
+func NewFeatureFlagService(config *config.CloudConfig) (FeatureFlagService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.FeatureFlag)
+	if err != nil {
+		return nil, err
+	}
+	return &FeatureFlagServiceImpl{FeatureFlag: p.(plugin.FeatureFlag), ttl: config.FeatureFlag.CacheTTL}, nil
+}
+
+func (s *FeatureFlagServiceImpl) Get(name string) (*models.FeatureFlag, error) {
+	return s.FeatureFlag.GetFeatureFlag(name)
+}
+
+func (s *FeatureFlagServiceImpl) List() ([]models.FeatureFlag, error) {
+	return s.FeatureFlag.ListFeatureFlag()
+}
+
+func (s *FeatureFlagServiceImpl) Create(flag *models.FeatureFlag) (*models.FeatureFlag, error) {
+	if err := s.FeatureFlag.CreateFeatureFlag(flag); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return s.FeatureFlag.GetFeatureFlag(flag.Name)
+}
+
+func (s *FeatureFlagServiceImpl) Update(flag *models.FeatureFlag) (*models.FeatureFlag, error) {
+	if err := s.FeatureFlag.UpdateFeatureFlag(flag); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	log.L().Info("feature flag updated", log.Any("name", flag.Name), log.Any("enabled", flag.Enabled), log.Any("percentage", flag.Percentage))
+	return s.FeatureFlag.GetFeatureFlag(flag.Name)
+}
+
+func (s *FeatureFlagServiceImpl) Delete(name string) error {
+	if err := s.FeatureFlag.DeleteFeatureFlag(name); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+func (s *FeatureFlagServiceImpl) SetOverride(name, namespace string, enabled *bool) (*models.FeatureFlag, error) {
+	flag, err := s.FeatureFlag.GetFeatureFlag(name)
+	if err != nil {
+		return nil, err
+	}
+	if enabled == nil {
+		if _, ok := flag.Overrides[namespace]; !ok {
+			return flag, nil
+		}
+		delete(flag.Overrides, namespace)
+	} else {
+		if flag.Overrides == nil {
+			flag.Overrides = map[string]bool{}
+		}
+		flag.Overrides[namespace] = *enabled
+	}
+	return s.Update(flag)
+}
+
+func (s *FeatureFlagServiceImpl) Resolve(namespace string) (map[string]bool, error) {
+	flags, err := s.list()
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]bool{}
+	for i := range flags {
+		if flags[i].IsEnabled(namespace) {
+			res[flags[i].Name] = true
+		}
+	}
+	return res, nil
+}
+
+// list returns the flags cached, since they are resolved on each request. The changes by other instances take
+// effect after the ttl at most
+func (s *FeatureFlagServiceImpl) list() ([]models.FeatureFlag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cached != nil && time.Now().Before(s.expire) {
+		return s.cached, nil
+	}
+	res, err := s.FeatureFlag.ListFeatureFlag()
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		res = []models.FeatureFlag{}
+	}
+	s.cached, s.expire = res, time.Now().Add(s.ttl)
+	return res, nil
+}
+
+func (s *FeatureFlagServiceImpl) invalidate() {
+	s.mu.Lock()
+	s.cached = nil
+	s.mu.Unlock()
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewFeatureFlagService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.FeatureFlag = common.RandString(9)
+	_, err := NewFeatureFlagService(conf)
+	assert.Error(t, err)
+}
+
+func TestFeatureFlagIsEnabled(t *testing.T) {
+	flag := &models.FeatureFlag{Name: "delta-sync", Overrides: map[string]bool{"tenant-a": true, "tenant-b": false}}
+	assert.True(t, flag.IsEnabled("tenant-a"))
+	assert.False(t, flag.IsEnabled("tenant-b"))
+	assert.False(t, flag.IsEnabled("default"))
+
+	flag.Enabled = true
+	assert.True(t, flag.IsEnabled("default"))
+	assert.False(t, flag.IsEnabled("tenant-b"))
+
+	// the namespaces enabled stay enabled as the percentage grows
+	flag.Enabled = false
+	var enabled []string
+	for _, percentage := range []int{10, 50, 100} {
+		flag.Percentage = percentage
+		var res []string
+		for i := 0; i < 100; i++ {
+			if ns := fmt.Sprintf("ns-%d", i); flag.IsEnabled(ns) {
+				res = append(res, ns)
+			}
+		}
+		assert.Subset(t, res, enabled)
+		enabled = res
+	}
+	assert.Len(t, enabled, 100)
+}
+
+func TestFeatureFlagService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mFlag := mockPlugin.NewMockFeatureFlag(mockCtl)
+	s := &FeatureFlagServiceImpl{FeatureFlag: mFlag, ttl: time.Minute}
+
+	flags := []models.FeatureFlag{
+		{Name: "delta-sync", Overrides: map[string]bool{"tenant-a": true}},
+		{Name: "canary-rollout", Enabled: true},
+	}
+	// the flags are listed once for the ttl
+	mFlag.EXPECT().ListFeatureFlag().Return(flags, nil).Times(1)
+	res, err := s.Resolve("tenant-a")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"delta-sync": true, "canary-rollout": true}, res)
+	res, err = s.Resolve("default")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"canary-rollout": true}, res)
+
+	// the overrides invalidate the cache
+	disabled := false
+	mFlag.EXPECT().GetFeatureFlag("canary-rollout").Return(&models.FeatureFlag{Name: "canary-rollout", Enabled: true}, nil)
+	mFlag.EXPECT().UpdateFeatureFlag(gomock.Any()).DoAndReturn(func(f *models.FeatureFlag) error {
+		assert.Equal(t, map[string]bool{"default": false}, f.Overrides)
+		return nil
+	})
+	mFlag.EXPECT().GetFeatureFlag("canary-rollout").Return(&models.FeatureFlag{Name: "canary-rollout", Enabled: true, Overrides: map[string]bool{"default": false}}, nil)
+	_, err = s.SetOverride("canary-rollout", "default", &disabled)
+	assert.NoError(t, err)
+	mFlag.EXPECT().ListFeatureFlag().Return([]models.FeatureFlag{
+		{Name: "canary-rollout", Enabled: true, Overrides: map[string]bool{"default": false}},
+	}, nil)
+	res, err = s.Resolve("default")
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	// the override absent is not updated
+	mFlag.EXPECT().GetFeatureFlag("delta-sync").Return(&models.FeatureFlag{Name: "delta-sync"}, nil)
+	flag, err := s.SetOverride("delta-sync", "default", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "delta-sync", flag.Name)
+
+	mFlag.EXPECT().GetFeatureFlag("delta-sync").Return(nil, common.Error(common.ErrResourceNotFound))
+	_, err = s.SetOverride("delta-sync", "default", nil)
+	assertErrorCode(t, common.ErrResourceNotFound, err)
+
+	mFlag.EXPECT().DeleteFeatureFlag("delta-sync").Return(fmt.Errorf("error"))
+	assert.Error(t, s.Delete("delta-sync"))
+	mFlag.EXPECT().ListFeatureFlag().Return(nil, fmt.Errorf("error"))
+	s.invalidate()
+	_, err = s.Resolve("default")
+	assert.Error(t, err)
+}
@@ -19,10 +19,17 @@ type FunctionService interface {
 	ListSources() []models.FunctionSource
 	ListRuntimes() (map[string]string, error)
 	GetFunction(userID, name, version, source string) (*models.Function, error)
+
+	GetFunctionRuntime(name string) (*models.FunctionRuntime, error)
+	ListFunctionRuntimes() ([]models.FunctionRuntime, error)
+	CreateFunctionRuntime(runtime *models.FunctionRuntime) (*models.FunctionRuntime, error)
+	UpdateFunctionRuntime(runtime *models.FunctionRuntime) (*models.FunctionRuntime, error)
+	DeleteFunctionRuntime(name string) error
 }
 
 type functionService struct {
 	module    ModuleService
+	runtime   plugin.FunctionRuntime
 	functions map[string]plugin.Function
 }
 
@@ -40,8 +47,13 @@ func NewFunctionService(cfg *config.CloudConfig) (FunctionService, error) {
 		}
 		functions[v] = cs.(plugin.Function)
 	}
+	runtime, err := plugin.GetPlugin(cfg.Plugin.FunctionRuntime)
+	if err != nil {
+		return nil, err
+	}
 	return &functionService{
 		module:    sModule,
+		runtime:   runtime.(plugin.FunctionRuntime),
 		functions: functions,
 	}, nil
 }
@@ -55,7 +67,7 @@ func (c *functionService) List(userID string, source string) ([]models.Function,
 	return functionPlugin.List(userID)
 }
 
-//ListVersions List all versions of a function
+// ListVersions List all versions of a function
 func (c *functionService) ListFunctionVersions(userID, name string, source string) ([]models.Function, error) {
 	functionPlugin, ok := c.functions[source]
 	if !ok {
@@ -85,6 +97,17 @@ func (c *functionService) ListRuntimes() (map[string]string, error) {
 	for _, item := range res {
 		runtimes[item.Name] = item.Image
 	}
+	if c.runtime == nil {
+		return runtimes, nil
+	}
+	// the runtimes registered by admins override the runtime modules of the same name
+	registered, err := c.runtime.ListFunctionRuntime()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range registered {
+		runtimes[item.Name] = item.Image
+	}
 	return runtimes, nil
 }
 
@@ -96,3 +119,30 @@ func (c *functionService) GetFunction(userID, name, version, source string) (*mo
 
 	return functionPlugin.Get(userID, name, version)
 }
+
+func (c *functionService) GetFunctionRuntime(name string) (*models.FunctionRuntime, error) {
+	return c.runtime.GetFunctionRuntime(name)
+}
+
+func (c *functionService) ListFunctionRuntimes() ([]models.FunctionRuntime, error) {
+	return c.runtime.ListFunctionRuntime()
+}
+
+func (c *functionService) CreateFunctionRuntime(runtime *models.FunctionRuntime) (*models.FunctionRuntime, error) {
+	if err := c.runtime.CreateFunctionRuntime(runtime); err != nil {
+		return nil, err
+	}
+	return c.runtime.GetFunctionRuntime(runtime.Name)
+}
+
+func (c *functionService) UpdateFunctionRuntime(runtime *models.FunctionRuntime) (*models.FunctionRuntime, error) {
+	if err := c.runtime.UpdateFunctionRuntime(runtime); err != nil {
+		return nil, err
+	}
+	return c.runtime.GetFunctionRuntime(runtime.Name)
+}
+
+// DeleteFunctionRuntime deletes the runtime, the function apps created with it keep running the image of runtime
+func (c *functionService) DeleteFunctionRuntime(name string) error {
+	return c.runtime.DeleteFunctionRuntime(name)
+}
@@ -135,6 +135,51 @@ func TestDefaultFunctionService_ListRuntimes(t *testing.T) {
 	assert.Equal(t, "url-a", res["a"])
 }
 
+func TestDefaultFunctionService_ListRegisteredRuntimes(t *testing.T) {
+	mockObject := InitMockEnvironment(t)
+	defer mockObject.Close()
+
+	cs, err := NewFunctionService(mockObject.conf)
+	assert.NoError(t, err)
+	sModule := service.NewMockModuleService(mockObject.ctl)
+	cs.(*functionService).module = sModule
+
+	sModule.EXPECT().ListModules(gomock.Any(), common.TypeUserRuntime).Return([]models.Module{
+		{Name: "python3", Image: "url-python3"},
+		{Name: "nodejs10", Image: "url-nodejs10"},
+	}, nil)
+	mockObject.runtime.EXPECT().ListFunctionRuntime().Return([]models.FunctionRuntime{
+		{Name: "node20", Image: "url-node20"},
+		{Name: "python3", Image: "url-python3.11"},
+	}, nil)
+	res, err := cs.ListRuntimes()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"python3":  "url-python3.11",
+		"nodejs10": "url-nodejs10",
+		"node20":   "url-node20",
+	}, res)
+
+	sModule.EXPECT().ListModules(gomock.Any(), common.TypeUserRuntime).Return(nil, nil)
+	mockObject.runtime.EXPECT().ListFunctionRuntime().Return(nil, errors.New("err"))
+	_, err = cs.ListRuntimes()
+	assert.Error(t, err)
+
+	runtime := &models.FunctionRuntime{Name: "node20", Image: "url-node20"}
+	mockObject.runtime.EXPECT().CreateFunctionRuntime(runtime).Return(nil)
+	mockObject.runtime.EXPECT().GetFunctionRuntime("node20").Return(runtime, nil)
+	res2, err := cs.CreateFunctionRuntime(runtime)
+	assert.NoError(t, err)
+	assert.Equal(t, runtime, res2)
+
+	mockObject.runtime.EXPECT().UpdateFunctionRuntime(runtime).Return(errors.New("err"))
+	_, err = cs.UpdateFunctionRuntime(runtime)
+	assert.Error(t, err)
+
+	mockObject.runtime.EXPECT().DeleteFunctionRuntime("node20").Return(nil)
+	assert.NoError(t, cs.DeleteFunctionRuntime("node20"))
+}
+
 func TestDefaultFunctionService_GetFunction(t *testing.T) {
 	mockObject := InitMockEnvironment(t)
 	defer mockObject.Close()
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/functionbuild.go -package=service github.com/baetyl/baetyl-cloud/v2/service FunctionBuildService
+
+// FunctionCodeDir the directory of image where the function runtimes load the code from
+const FunctionCodeDir = "/var/lib/baetyl/code"
+
+var (
+	// the name and the version of function are the repository component and the tag of image
+	functionBuildNameRegexp    = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*$`)
+	functionBuildVersionRegexp = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+)
+
+// FunctionBuildService builds the images of the function versions from the code zips uploaded, which are pushed
+// to the configured registry on top of the runtime images
+type FunctionBuildService interface {
+	// Build uploads the code zip to object storage and starts the build of function version,
+	// the previous build of the version is replaced unless it is still running
+	Build(userID string, build *models.FunctionBuild, code []byte) (*models.FunctionBuild, error)
+	// Get returns the build of function version, whose status is refreshed from the builder if it is running
+	Get(namespace, name, version string) (*models.FunctionBuild, error)
+	List(namespace, name string) ([]models.FunctionBuild, error)
+	// Delete deletes the build of function version, the code and the image are kept for the apps using them
+	Delete(namespace, name, version string) error
+}
+
+type FunctionBuildServiceImpl struct {
+	Storage  plugin.FunctionBuild
+	Builder  plugin.FunctionBuilder
+	Object   ObjectService
+	Func     FunctionService
+	Prop     PropertyService
+	builder  string
+	registry string
+	source   string
+	maxSize  int64
+}
+
+// NewFunctionBuildService NewFunctionBuildService
+func NewFunctionBuildService(config *config.CloudConfig) (FunctionBuildService, error) {
+	storage, err := plugin.GetPlugin(config.Plugin.FunctionBuild)
+	if err != nil {
+		return nil, err
+	}
+	s := &FunctionBuildServiceImpl{
+		Storage:  storage.(plugin.FunctionBuild),
+		builder:  config.FunctionBuild.Builder,
+		registry: strings.TrimSuffix(config.FunctionBuild.Registry, "/"),
+		source:   config.FunctionBuild.Source,
+		maxSize:  config.FunctionBuild.MaxCodeSize,
+	}
+	if s.builder != "" {
+		builder, err := plugin.GetPlugin(s.builder)
+		if err != nil {
+			return nil, err
+		}
+		s.Builder = builder.(plugin.FunctionBuilder)
+	}
+	if s.Object, err = NewObjectService(config); err != nil {
+		return nil, err
+	}
+	if s.Func, err = NewFunctionService(config); err != nil {
+		return nil, err
+	}
+	if s.Prop, err = NewPropertyService(config); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FunctionBuildServiceImpl) Build(userID string, build *models.FunctionBuild, code []byte) (*models.FunctionBuild, error) {
+	if s.Builder == nil || s.registry == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the build of function is not supported"))
+	}
+	if err := s.checkBuild(build, code); err != nil {
+		return nil, err
+	}
+	runtimes, err := s.Func.ListRuntimes()
+	if err != nil {
+		return nil, err
+	}
+	base, ok := runtimes[build.Runtime]
+	if !ok {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "runtime"), common.Field("name", build.Runtime))
+	}
+	old, err := s.Storage.GetFunctionBuild(build.Namespace, build.Name, build.Version)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+		old = nil
+	}
+	if old != nil && old.Status == models.FunctionBuildRunning {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error",
+			fmt.Sprintf("the build of function %s:%s is running", build.Name, build.Version)))
+	}
+
+	source, err := s.objectSource()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(code)
+	build.Sha256 = hex.EncodeToString(sum[:])
+	build.Source = source
+	build.Bucket = fmt.Sprintf("%s-%s", common.BaetylCloud, userID)
+	build.Object = fmt.Sprintf("functions/%s/%s/%s/%s.%s", build.Namespace, build.Name, build.Version, build.Sha256, common.UnpackTypeZip)
+	build.Size = int64(len(code))
+	if _, err = s.Object.CreateInternalBucketIfNotExist(userID, build.Bucket, common.AWSS3PrivatePermission, source); err != nil {
+		return nil, err
+	}
+	if err = s.Object.PutInternalObject(userID, build.Bucket, build.Object, source, code); err != nil {
+		return nil, err
+	}
+	codeURL, err := s.Object.GenInternalObjectURL(userID, build.Bucket, build.Object, source)
+	if err != nil {
+		return nil, err
+	}
+
+	build.Builder = s.builder
+	build.Image = fmt.Sprintf("%s/%s/%s:%s", s.registry, build.Namespace, build.Name, build.Version)
+	build.BuildID, err = s.Builder.StartBuild(&models.FunctionBuildRequest{
+		Namespace:  build.Namespace,
+		Name:       build.Name,
+		Version:    build.Version,
+		CodeURL:    codeURL.URL,
+		Dockerfile: functionDockerfile(base),
+		Image:      build.Image,
+	})
+	if err != nil {
+		return nil, err
+	}
+	build.Status = models.FunctionBuildRunning
+	build.Message = ""
+	if old != nil {
+		err = s.Storage.UpdateFunctionBuild(build)
+	} else {
+		err = s.Storage.CreateFunctionBuild(build)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.Storage.GetFunctionBuild(build.Namespace, build.Name, build.Version)
+}
+
+func (s *FunctionBuildServiceImpl) Get(namespace, name, version string) (*models.FunctionBuild, error) {
+	build, err := s.Storage.GetFunctionBuild(namespace, name, version)
+	if err != nil {
+		return nil, err
+	}
+	return s.refresh(build), nil
+}
+
+func (s *FunctionBuildServiceImpl) List(namespace, name string) ([]models.FunctionBuild, error) {
+	builds, err := s.Storage.ListFunctionBuild(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range builds {
+		builds[i] = *s.refresh(&builds[i])
+	}
+	return builds, nil
+}
+
+func (s *FunctionBuildServiceImpl) Delete(namespace, name, version string) error {
+	return s.Storage.DeleteFunctionBuild(namespace, name, version)
+}
+
+// refresh updates the status of the running build from its builder, the build is returned as it is stored
+// if the builder is not available at the moment
+func (s *FunctionBuildServiceImpl) refresh(build *models.FunctionBuild) *models.FunctionBuild {
+	if build.Status != models.FunctionBuildRunning || s.Builder == nil || build.Builder != s.builder {
+		return build
+	}
+	status, err := s.Builder.GetBuildStatus(build.BuildID)
+	if err != nil {
+		log.L().Warn("failed to get the status of function build", log.Any(common.KeyContextNamespace, build.Namespace),
+			log.Any("name", build.Name), log.Any("version", build.Version), log.Error(err))
+		return build
+	}
+	if status.Status == build.Status {
+		return build
+	}
+	res := *build
+	res.Status, res.Message = status.Status, status.Message
+	if err = s.Storage.UpdateFunctionBuild(&res); err != nil {
+		log.L().Warn("failed to update the status of function build", log.Any(common.KeyContextNamespace, build.Namespace),
+			log.Any("name", build.Name), log.Any("version", build.Version), log.Error(err))
+	}
+	return &res
+}
+
+func (s *FunctionBuildServiceImpl) checkBuild(build *models.FunctionBuild, code []byte) error {
+	if !functionBuildNameRegexp.MatchString(build.Name) || len(build.Name) > 128 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the name of function "+build.Name+" is invalid"))
+	}
+	if !functionBuildVersionRegexp.MatchString(build.Version) {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the version of function "+build.Version+" is invalid"))
+	}
+	if build.Runtime == "" {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the runtime of function is required"))
+	}
+	if len(code) == 0 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the code of function is required"))
+	}
+	if s.maxSize > 0 && int64(len(code)) > s.maxSize {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error",
+			fmt.Sprintf("the code of function is larger than %d bytes", s.maxSize)))
+	}
+	if _, err := zip.NewReader(bytes.NewReader(code), int64(len(code))); err != nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the code of function should be a zip: "+err.Error()))
+	}
+	return nil
+}
+
+// objectSource returns the object source storing the code, which is either configured or the default one
+func (s *FunctionBuildServiceImpl) objectSource() (string, error) {
+	return functionObjectSource(s.Prop, s.Object, s.source)
+}
+
+func functionObjectSource(prop PropertyService, object ObjectService, source string) (string, error) {
+	if source == "" {
+		var err error
+		if source, err = prop.GetPropertyValue(common.ObjectSource); err != nil {
+			return "", err
+		}
+	}
+	if _, ok := object.ListSources()[source]; !ok {
+		return "", common.Error(common.ErrRequestParamInvalid, common.Field("error", "the object source "+source+" is not supported"))
+	}
+	return source, nil
+}
+
+// functionDockerfile returns the dockerfile building the code unpacked in the build context into the runtime image
+func functionDockerfile(base string) string {
+	return fmt.Sprintf("FROM %s\nCOPY . %s/\n", base, FunctionCodeDir)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func testFunctionZip(t *testing.T) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create("index.py")
+	assert.NoError(t, err)
+	_, err = f.Write([]byte("def handler(event, context):\n    return event\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestNewFunctionBuildService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.FunctionBuild = common.RandString(9)
+	_, err := NewFunctionBuildService(conf)
+	assert.Error(t, err)
+}
+
+func TestFunctionBuild(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	storage, builder := mockPlugin.NewMockFunctionBuild(mockCtl), mockPlugin.NewMockFunctionBuilder(mockCtl)
+	sObj, sFunc, sProp := ms.NewMockObjectService(mockCtl), ms.NewMockFunctionService(mockCtl), ms.NewMockPropertyService(mockCtl)
+	s := &FunctionBuildServiceImpl{
+		Storage:  storage,
+		Builder:  builder,
+		Object:   sObj,
+		Func:     sFunc,
+		Prop:     sProp,
+		builder:  "kaniko",
+		registry: "harbor.local/functions",
+		maxSize:  1024,
+	}
+	code := testFunctionZip(t)
+	newBuild := func() *models.FunctionBuild {
+		return &models.FunctionBuild{Namespace: "default", Name: "infer", Version: "v1", Runtime: "python3", Handler: "index.handler"}
+	}
+
+	for _, b := range []*models.FunctionBuild{
+		{Namespace: "default", Name: "Infer", Version: "v1", Runtime: "python3"},
+		{Namespace: "default", Name: "infer", Version: "v1:latest", Runtime: "python3"},
+		{Namespace: "default", Name: "infer", Version: "v1"},
+	} {
+		_, err := s.Build("u1", b, code)
+		assert.Error(t, err)
+	}
+	_, err := s.Build("u1", newBuild(), []byte("not a zip"))
+	assert.Error(t, err)
+	_, err = s.Build("u1", newBuild(), bytes.Repeat([]byte("a"), 1025))
+	assert.Error(t, err)
+
+	runtimes := map[string]string{"python3": "baetyltech/function-python:v2.2.0"}
+	sFunc.EXPECT().ListRuntimes().Return(runtimes, nil).AnyTimes()
+	_, err = s.Build("u1", &models.FunctionBuild{Namespace: "default", Name: "infer", Version: "v1", Runtime: "node10"}, code)
+	assert.Error(t, err)
+
+	// the running build is not replaced
+	running := newBuild()
+	running.Status = models.FunctionBuildRunning
+	storage.EXPECT().GetFunctionBuild("default", "infer", "v1").Return(running, nil)
+	_, err = s.Build("u1", newBuild(), code)
+	assert.Error(t, err)
+
+	notFound := common.Error(common.ErrResourceNotFound, common.Field("type", "function build"), common.Field("name", "infer:v1"))
+	storage.EXPECT().GetFunctionBuild("default", "infer", "v1").Return(nil, notFound)
+	sProp.EXPECT().GetPropertyValue(common.ObjectSource).Return("minio", nil)
+	sObj.EXPECT().ListSources().Return(map[string]models.ObjectStorageSourceV2{"minio": {}})
+	sObj.EXPECT().CreateInternalBucketIfNotExist("u1", "baetyl-cloud-u1", common.AWSS3PrivatePermission, "minio").Return(nil, nil)
+	var object string
+	sObj.EXPECT().PutInternalObject("u1", "baetyl-cloud-u1", gomock.Any(), "minio", code).DoAndReturn(func(_, _, name, _ string, _ []byte) error {
+		object = name
+		return nil
+	})
+	sObj.EXPECT().GenInternalObjectURL("u1", "baetyl-cloud-u1", gomock.Any(), "minio").Return(&models.ObjectURL{URL: "http://minio/code.zip"}, nil)
+	builder.EXPECT().StartBuild(gomock.Any()).DoAndReturn(func(req *models.FunctionBuildRequest) (string, error) {
+		assert.Equal(t, "http://minio/code.zip", req.CodeURL)
+		assert.Equal(t, "harbor.local/functions/default/infer:v1", req.Image)
+		assert.Equal(t, "FROM baetyltech/function-python:v2.2.0\nCOPY . /var/lib/baetyl/code/\n", req.Dockerfile)
+		return "build-1", nil
+	})
+	storage.EXPECT().CreateFunctionBuild(gomock.Any()).DoAndReturn(func(b *models.FunctionBuild) error {
+		assert.Equal(t, "build-1", b.BuildID)
+		assert.Equal(t, "kaniko", b.Builder)
+		assert.Equal(t, models.FunctionBuildRunning, b.Status)
+		assert.Equal(t, int64(len(code)), b.Size)
+		assert.Equal(t, "functions/default/infer/v1/"+b.Sha256+".zip", b.Object)
+		running = b
+		return nil
+	})
+	storage.EXPECT().GetFunctionBuild("default", "infer", "v1").DoAndReturn(func(_, _, _ string) (*models.FunctionBuild, error) {
+		return running, nil
+	})
+	res, err := s.Build("u1", newBuild(), code)
+	assert.NoError(t, err)
+	assert.Equal(t, object, res.Object)
+	assert.Equal(t, "harbor.local/functions/default/infer:v1", res.Image)
+
+	// the status of running build is refreshed from the builder
+	storage.EXPECT().GetFunctionBuild("default", "infer", "v1").Return(running, nil)
+	builder.EXPECT().GetBuildStatus("build-1").Return(&models.FunctionBuildStatus{Status: models.FunctionBuildRunning}, nil)
+	res, err = s.Get("default", "infer", "v1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.FunctionBuildRunning, res.Status)
+
+	storage.EXPECT().GetFunctionBuild("default", "infer", "v1").Return(running, nil)
+	builder.EXPECT().GetBuildStatus("build-1").Return(nil, errors.New("timeout"))
+	res, err = s.Get("default", "infer", "v1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.FunctionBuildRunning, res.Status)
+
+	storage.EXPECT().ListFunctionBuild("default", "infer").Return([]models.FunctionBuild{*running}, nil)
+	builder.EXPECT().GetBuildStatus("build-1").Return(&models.FunctionBuildStatus{Status: models.FunctionBuildFailed, Message: "no such file"}, nil)
+	storage.EXPECT().UpdateFunctionBuild(gomock.Any()).DoAndReturn(func(b *models.FunctionBuild) error {
+		assert.Equal(t, models.FunctionBuildFailed, b.Status)
+		return nil
+	})
+	list, err := s.List("default", "infer")
+	assert.NoError(t, err)
+	assert.Equal(t, models.FunctionBuildFailed, list[0].Status)
+	assert.Equal(t, "no such file", list[0].Message)
+
+	// the build is not supported without builder
+	s.Builder = nil
+	_, err = s.Build("u1", newBuild(), code)
+	assert.Error(t, err)
+
+	storage.EXPECT().DeleteFunctionBuild("default", "infer", "v1").Return(nil)
+	assert.NoError(t, s.Delete("default", "infer", "v1"))
+}
@@ -0,0 +1,468 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/functiondraft.go -package=service github.com/baetyl/baetyl-cloud/v2/service FunctionDraftService
+
+// FunctionDraftService edits the code of functions online, the drafts are tested in sandbox and published
+// as immutable versions, only the versions are referenced by applications and deployed to nodes
+type FunctionDraftService interface {
+	Get(namespace, name string) (*models.FunctionDraft, error)
+	List(namespace string) ([]models.FunctionDraft, error)
+	Create(draft *models.FunctionDraft) (*models.FunctionDraft, error)
+	// Update updates the runtime, the handler, the envs and the description of draft, the files are kept
+	Update(draft *models.FunctionDraft) (*models.FunctionDraft, error)
+	// Delete deletes the draft, the versions published from it are kept for the apps using them
+	Delete(namespace, name string) error
+
+	GetFile(namespace, name, file string) (*models.FunctionDraftFile, error)
+	SaveFile(namespace, name string, file *models.FunctionDraftFile) (*models.FunctionDraftFile, error)
+	DeleteFile(namespace, name, file string) error
+
+	// Invoke runs the draft, or the version given, in sandbox with the payload of request
+	Invoke(userID string, req *models.FunctionInvokeRequest) (*models.FunctionInvokeResult, error)
+	// Publish packages the files of draft into a zip stored in object storage as the version, which is never changed.
+	// The envs of draft are checked, and the secrets bound to them should exist in namespace. The runtime of the version
+	// of gpu flavor should have the CUDA-enabled image
+	Publish(userID, namespace, name string, req *models.FunctionPublishRequest) (*models.FunctionVersion, error)
+	// PublishBundle publishes the wasm bundle built from the draft as the version, the runtime of draft should be of wasm type
+	PublishBundle(userID, namespace, name string, req *models.FunctionPublishRequest, bundle []byte) (*models.FunctionVersion, error)
+	GetVersion(namespace, name, version string) (*models.FunctionVersion, error)
+	ListVersions(namespace, name string) ([]models.FunctionVersion, error)
+}
+
+type FunctionDraftServiceImpl struct {
+	Storage plugin.FunctionDraft
+	Sandbox plugin.FunctionSandbox
+	Object  ObjectService
+	Func    FunctionService
+	Prop    PropertyService
+	Secret  SecretService
+	source  string
+	maxSize int64
+}
+
+// NewFunctionDraftService NewFunctionDraftService
+func NewFunctionDraftService(config *config.CloudConfig) (FunctionDraftService, error) {
+	storage, err := plugin.GetPlugin(config.Plugin.FunctionDraft)
+	if err != nil {
+		return nil, err
+	}
+	s := &FunctionDraftServiceImpl{
+		Storage: storage.(plugin.FunctionDraft),
+		source:  config.FunctionDraft.Source,
+		maxSize: config.FunctionDraft.MaxCodeSize,
+	}
+	if config.FunctionDraft.Sandbox != "" {
+		sandbox, err := plugin.GetPlugin(config.FunctionDraft.Sandbox)
+		if err != nil {
+			return nil, err
+		}
+		s.Sandbox = sandbox.(plugin.FunctionSandbox)
+	}
+	if s.Object, err = NewObjectService(config); err != nil {
+		return nil, err
+	}
+	if s.Func, err = NewFunctionService(config); err != nil {
+		return nil, err
+	}
+	if s.Prop, err = NewPropertyService(config); err != nil {
+		return nil, err
+	}
+	if s.Secret, err = NewSecretService(config); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FunctionDraftServiceImpl) Get(namespace, name string) (*models.FunctionDraft, error) {
+	return s.Storage.GetFunctionDraft(namespace, name)
+}
+
+func (s *FunctionDraftServiceImpl) List(namespace string) ([]models.FunctionDraft, error) {
+	return s.Storage.ListFunctionDraft(namespace)
+}
+
+func (s *FunctionDraftServiceImpl) Create(draft *models.FunctionDraft) (*models.FunctionDraft, error) {
+	if !functionBuildNameRegexp.MatchString(draft.Name) || len(draft.Name) > 128 {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the name of function "+draft.Name+" is invalid"))
+	}
+	if _, err := s.runtimeImage(draft.Runtime); err != nil {
+		return nil, err
+	}
+	files := map[string]string{}
+	for k, v := range draft.Files {
+		p, err := cleanDraftFilePath(k)
+		if err != nil {
+			return nil, err
+		}
+		files[p] = v
+	}
+	draft.Files = files
+	if err := s.checkSize(draft); err != nil {
+		return nil, err
+	}
+	if err := s.Storage.CreateFunctionDraft(draft); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetFunctionDraft(draft.Namespace, draft.Name)
+}
+
+func (s *FunctionDraftServiceImpl) Update(draft *models.FunctionDraft) (*models.FunctionDraft, error) {
+	old, err := s.Storage.GetFunctionDraft(draft.Namespace, draft.Name)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Runtime != old.Runtime {
+		if _, err = s.runtimeImage(draft.Runtime); err != nil {
+			return nil, err
+		}
+	}
+	old.Runtime, old.Handler, old.Description, old.Env = draft.Runtime, draft.Handler, draft.Description, draft.Env
+	if err = s.Storage.UpdateFunctionDraft(old); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetFunctionDraft(draft.Namespace, draft.Name)
+}
+
+func (s *FunctionDraftServiceImpl) Delete(namespace, name string) error {
+	return s.Storage.DeleteFunctionDraft(namespace, name)
+}
+
+func (s *FunctionDraftServiceImpl) GetFile(namespace, name, file string) (*models.FunctionDraftFile, error) {
+	p, err := cleanDraftFilePath(file)
+	if err != nil {
+		return nil, err
+	}
+	draft, err := s.Storage.GetFunctionDraft(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	content, ok := draft.Files[p]
+	if !ok {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "function file"), common.Field("name", p))
+	}
+	return &models.FunctionDraftFile{Path: p, Content: content}, nil
+}
+
+// SaveFile creates or overwrites the file of draft, which is not deployed anywhere until the draft is published
+func (s *FunctionDraftServiceImpl) SaveFile(namespace, name string, file *models.FunctionDraftFile) (*models.FunctionDraftFile, error) {
+	p, err := cleanDraftFilePath(file.Path)
+	if err != nil {
+		return nil, err
+	}
+	draft, err := s.Storage.GetFunctionDraft(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if content, ok := draft.Files[p]; ok && content == file.Content {
+		return &models.FunctionDraftFile{Path: p, Content: content}, nil
+	}
+	if draft.Files == nil {
+		draft.Files = map[string]string{}
+	}
+	draft.Files[p] = file.Content
+	if err = s.checkSize(draft); err != nil {
+		return nil, err
+	}
+	if err = s.Storage.UpdateFunctionDraft(draft); err != nil {
+		return nil, err
+	}
+	return &models.FunctionDraftFile{Path: p, Content: file.Content}, nil
+}
+
+func (s *FunctionDraftServiceImpl) DeleteFile(namespace, name, file string) error {
+	p, err := cleanDraftFilePath(file)
+	if err != nil {
+		return err
+	}
+	draft, err := s.Storage.GetFunctionDraft(namespace, name)
+	if err != nil {
+		return err
+	}
+	if _, ok := draft.Files[p]; !ok {
+		return nil
+	}
+	delete(draft.Files, p)
+	return s.Storage.UpdateFunctionDraft(draft)
+}
+
+func (s *FunctionDraftServiceImpl) Invoke(userID string, req *models.FunctionInvokeRequest) (*models.FunctionInvokeResult, error) {
+	if s.Sandbox == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the test invocation of function is not supported"))
+	}
+	if req.Version != "" {
+		version, err := s.Storage.GetFunctionVersion(req.Namespace, req.Name, req.Version)
+		if err != nil {
+			return nil, err
+		}
+		if req.Image, err = s.runtimeImage(version.Runtime); err != nil {
+			return nil, err
+		}
+		// the sandbox downloads the code of version instead of the code packaged again
+		codeURL, err := s.Object.GenInternalObjectURL(userID, version.Bucket, version.Object, version.Source)
+		if err != nil {
+			return nil, err
+		}
+		req.Runtime, req.Handler, req.CodeURL = version.Runtime, version.Handler, codeURL.URL
+		return s.Sandbox.Invoke(req)
+	}
+	draft, err := s.Storage.GetFunctionDraft(req.Namespace, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	image, err := s.runtimeImage(draft.Runtime)
+	if err != nil {
+		return nil, err
+	}
+	if req.Code, err = packDraftFiles(draft); err != nil {
+		return nil, err
+	}
+	req.Runtime, req.Image, req.Handler = draft.Runtime, image, draft.Handler
+	return s.Sandbox.Invoke(req)
+}
+
+func (s *FunctionDraftServiceImpl) Publish(userID, namespace, name string, req *models.FunctionPublishRequest) (*models.FunctionVersion, error) {
+	draft, runtime, err := s.checkPublish(namespace, name, req)
+	if err != nil {
+		return nil, err
+	}
+	if runtime != nil && runtime.Type == models.FunctionRuntimeWasm {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the function of wasm runtime should be published with the wasm bundle"))
+	}
+	code, err := packDraftFiles(draft)
+	if err != nil {
+		return nil, err
+	}
+	return s.publish(userID, draft, req, code, "."+common.UnpackTypeZip)
+}
+
+func (s *FunctionDraftServiceImpl) PublishBundle(userID, namespace, name string, req *models.FunctionPublishRequest, bundle []byte) (*models.FunctionVersion, error) {
+	draft, runtime, err := s.checkPublish(namespace, name, req)
+	if err != nil {
+		return nil, err
+	}
+	if runtime == nil || runtime.Type != models.FunctionRuntimeWasm {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the runtime "+draft.Runtime+" of function doesn't run wasm bundles"))
+	}
+	if err = checkWasmBundle(bundle); err != nil {
+		return nil, err
+	}
+	if s.maxSize > 0 && int64(len(bundle)) > s.maxSize {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("the size of bundle exceeds the limit %d", s.maxSize)))
+	}
+	return s.publish(userID, draft, req, bundle, models.FunctionWasmExt)
+}
+
+// checkPublish checks the draft published as the version, and returns the runtime registered by admins if any
+func (s *FunctionDraftServiceImpl) checkPublish(namespace, name string, req *models.FunctionPublishRequest) (*models.FunctionDraft, *models.FunctionRuntime, error) {
+	if !functionBuildVersionRegexp.MatchString(req.Version) {
+		return nil, nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the version of function "+req.Version+" is invalid"))
+	}
+	draft, err := s.Storage.GetFunctionDraft(namespace, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err = s.runtimeImage(draft.Runtime); err != nil {
+		return nil, nil, err
+	}
+	// the runtime modules running the container images are not registered
+	runtime, err := s.Func.GetFunctionRuntime(draft.Runtime)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, nil, err
+		}
+	}
+	if err = s.checkEnvs(namespace, draft.Env); err != nil {
+		return nil, nil, err
+	}
+	if err = checkFlavor(draft.Runtime, runtime, req.Flavor); err != nil {
+		return nil, nil, err
+	}
+	if _, err = s.Storage.GetFunctionVersion(namespace, name, req.Version); err == nil {
+		return nil, nil, common.Error(common.ErrResourceConflict, common.Field("type", "function version"), common.Field("name", name+":"+req.Version))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, nil, err
+	}
+	return draft, runtime, nil
+}
+
+// publish stores the code of version in object storage, the object is named by the checksum and the extension given
+func (s *FunctionDraftServiceImpl) publish(userID string, draft *models.FunctionDraft, req *models.FunctionPublishRequest, code []byte, ext string) (*models.FunctionVersion, error) {
+	source, err := functionObjectSource(s.Prop, s.Object, s.source)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(code)
+	version := &models.FunctionVersion{
+		Namespace:   draft.Namespace,
+		Name:        draft.Name,
+		Version:     req.Version,
+		Runtime:     draft.Runtime,
+		Handler:     draft.Handler,
+		Description: req.Description,
+		Source:      source,
+		Bucket:      fmt.Sprintf("%s-%s", common.BaetylCloud, userID),
+		Sha256:      hex.EncodeToString(sum[:]),
+		Size:        int64(len(code)),
+		Flavor:      req.Flavor,
+		Env:         draft.Env,
+	}
+	version.Object = fmt.Sprintf("functions/%s/%s/%s/%s%s", draft.Namespace, draft.Name, req.Version, version.Sha256, ext)
+	if _, err = s.Object.CreateInternalBucketIfNotExist(userID, version.Bucket, common.AWSS3PrivatePermission, source); err != nil {
+		return nil, err
+	}
+	if err = s.Object.PutInternalObject(userID, version.Bucket, version.Object, source, code); err != nil {
+		return nil, err
+	}
+	if err = s.Storage.CreateFunctionVersion(version); err != nil {
+		return nil, err
+	}
+	return s.Storage.GetFunctionVersion(draft.Namespace, draft.Name, req.Version)
+}
+
+func (s *FunctionDraftServiceImpl) GetVersion(namespace, name, version string) (*models.FunctionVersion, error) {
+	return s.Storage.GetFunctionVersion(namespace, name, version)
+}
+
+func (s *FunctionDraftServiceImpl) ListVersions(namespace, name string) ([]models.FunctionVersion, error) {
+	return s.Storage.ListFunctionVersion(namespace, name)
+}
+
+// runtimeImage returns the image of runtime, which is either registered or a runtime module
+func (s *FunctionDraftServiceImpl) runtimeImage(runtime string) (string, error) {
+	if runtime == "" {
+		return "", common.Error(common.ErrRequestParamInvalid, common.Field("error", "the runtime of function is required"))
+	}
+	runtimes, err := s.Func.ListRuntimes()
+	if err != nil {
+		return "", err
+	}
+	image, ok := runtimes[runtime]
+	if !ok {
+		return "", common.Error(common.ErrResourceNotFound, common.Field("type", "runtime"), common.Field("name", runtime))
+	}
+	return image, nil
+}
+
+// checkFlavor checks that the runtime registered has the CUDA-enabled image for the version of gpu flavor
+func checkFlavor(name string, runtime *models.FunctionRuntime, flavor string) error {
+	if flavor != models.FunctionFlavorGPU {
+		return nil
+	}
+	if runtime == nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the runtime "+name+" of gpu flavor should be registered"))
+	}
+	if runtime.GPUImage == "" {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the runtime "+name+" has no image of gpu flavor"))
+	}
+	return nil
+}
+
+// checkWasmBundle checks the magic and the version of the binary format of wasm modules
+func checkWasmBundle(bundle []byte) error {
+	if len(bundle) < 8 || !bytes.Equal(bundle[:4], []byte("\x00asm")) {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the bundle is not a wasm module"))
+	}
+	if binary.LittleEndian.Uint32(bundle[4:8]) != 1 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the version of wasm module is not supported"))
+	}
+	return nil
+}
+
+// checkEnvs checks the envs of draft, the misconfigured ones fail the publishing rather than the function on nodes
+func (s *FunctionDraftServiceImpl) checkEnvs(namespace string, envs []models.FunctionEnv) error {
+	if err := CheckFunctionEnvs(envs); err != nil {
+		return err
+	}
+	for _, env := range envs {
+		if env.Secret == nil {
+			continue
+		}
+		secret, err := s.Secret.Get(namespace, env.Secret.Name, "")
+		if err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the secret "+env.Secret.Name+" of env "+env.Name+" is not found"))
+			}
+			return err
+		}
+		ok, err := SecretHasKey(secret, env.Secret.Key)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !ok {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the key "+env.Secret.Key+" of secret "+env.Secret.Name+" is not found"))
+		}
+	}
+	return nil
+}
+
+func (s *FunctionDraftServiceImpl) checkSize(draft *models.FunctionDraft) error {
+	if s.maxSize <= 0 {
+		return nil
+	}
+	var size int64
+	for k, v := range draft.Files {
+		size += int64(len(k) + len(v))
+	}
+	if size > s.maxSize {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error",
+			fmt.Sprintf("the files of function are larger than %d bytes", s.maxSize)))
+	}
+	return nil
+}
+
+// cleanDraftFilePath returns the clean path of file relative to the code directory, such as lib/util.py
+func cleanDraftFilePath(file string) (string, error) {
+	p := path.Clean(strings.TrimPrefix(file, "/"))
+	if p == "." || p == ".." || strings.HasPrefix(p, "../") || strings.HasPrefix(p, "/") ||
+		strings.Contains(p, "\\") || len(p) > 256 {
+		return "", common.Error(common.ErrRequestParamInvalid, common.Field("error", "the path of file "+file+" is invalid"))
+	}
+	return p, nil
+}
+
+// packDraftFiles packages the files of draft into a zip, the same files always produce the same zip
+func packDraftFiles(draft *models.FunctionDraft) ([]byte, error) {
+	if len(draft.Files) == 0 {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the function "+draft.Name+" has no file"))
+	}
+	paths := make([]string, 0, len(draft.Files))
+	for k := range draft.Files {
+		paths = append(paths, k)
+	}
+	sort.Strings(paths)
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, p := range paths {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: p, Method: zip.Deflate})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err = f.Write([]byte(draft.Files[p])); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewFunctionDraftService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.FunctionDraft = common.RandString(9)
+	_, err := NewFunctionDraftService(conf)
+	assert.Error(t, err)
+}
+
+func TestCleanDraftFilePath(t *testing.T) {
+	for file, expect := range map[string]string{
+		"/index.py":     "index.py",
+		"lib/util.py":   "lib/util.py",
+		"/./lib//a.py/": "lib/a.py",
+	} {
+		p, err := cleanDraftFilePath(file)
+		assert.NoError(t, err, file)
+		assert.Equal(t, expect, p)
+	}
+	for _, file := range []string{"", "/", "/../a.py", "lib/../../a.py", "//etc/passwd", "lib\\a.py"} {
+		_, err := cleanDraftFilePath(file)
+		assert.Error(t, err, file)
+	}
+}
+
+func TestPackDraftFiles(t *testing.T) {
+	draft := &models.FunctionDraft{Name: "infer"}
+	_, err := packDraftFiles(draft)
+	assert.Error(t, err)
+
+	draft.Files = map[string]string{"lib/util.py": "PI = 3.14\n", "index.py": "import lib.util\n"}
+	code, err := packDraftFiles(draft)
+	assert.NoError(t, err)
+	again, err := packDraftFiles(draft)
+	assert.NoError(t, err)
+	assert.Equal(t, code, again)
+
+	r, err := zip.NewReader(bytes.NewReader(code), int64(len(code)))
+	assert.NoError(t, err)
+	assert.Len(t, r.File, 2)
+	assert.Equal(t, "index.py", r.File[0].Name)
+	f, err := r.File[1].Open()
+	assert.NoError(t, err)
+	content, err := ioutil.ReadAll(f)
+	assert.NoError(t, err)
+	assert.Equal(t, "PI = 3.14\n", string(content))
+}
+
+func TestFunctionDraftFiles(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	storage, sFunc := mockPlugin.NewMockFunctionDraft(mockCtl), ms.NewMockFunctionService(mockCtl)
+	s := &FunctionDraftServiceImpl{Storage: storage, Func: sFunc, maxSize: 64}
+	sFunc.EXPECT().ListRuntimes().Return(map[string]string{"python3": "baetyltech/function-python:v2.2.0"}, nil).AnyTimes()
+
+	_, err := s.Create(&models.FunctionDraft{Namespace: "default", Name: "Infer", Runtime: "python3"})
+	assert.Error(t, err)
+	_, err = s.Create(&models.FunctionDraft{Namespace: "default", Name: "infer", Runtime: "node10"})
+	assert.Error(t, err)
+	_, err = s.Create(&models.FunctionDraft{Namespace: "default", Name: "infer", Runtime: "python3", Files: map[string]string{"../a.py": ""}})
+	assert.Error(t, err)
+
+	draft := &models.FunctionDraft{Namespace: "default", Name: "infer", Runtime: "python3", Files: map[string]string{"/index.py": "pass\n"}}
+	storage.EXPECT().CreateFunctionDraft(gomock.Any()).DoAndReturn(func(d *models.FunctionDraft) error {
+		assert.Equal(t, map[string]string{"index.py": "pass\n"}, d.Files)
+		return nil
+	})
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(draft, nil)
+	_, err = s.Create(draft)
+	assert.NoError(t, err)
+
+	stored := func() *models.FunctionDraft {
+		return &models.FunctionDraft{Namespace: "default", Name: "infer", Runtime: "python3", Files: map[string]string{"index.py": "pass\n"}}
+	}
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(stored(), nil)
+	file, err := s.GetFile("default", "infer", "/index.py")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.FunctionDraftFile{Path: "index.py", Content: "pass\n"}, file)
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(stored(), nil)
+	_, err = s.GetFile("default", "infer", "/util.py")
+	assert.Error(t, err)
+
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(stored(), nil)
+	storage.EXPECT().UpdateFunctionDraft(gomock.Any()).DoAndReturn(func(d *models.FunctionDraft) error {
+		assert.Equal(t, "PI = 3.14\n", d.Files["lib/util.py"])
+		assert.Equal(t, "pass\n", d.Files["index.py"])
+		return nil
+	})
+	file, err = s.SaveFile("default", "infer", &models.FunctionDraftFile{Path: "/lib/util.py", Content: "PI = 3.14\n"})
+	assert.NoError(t, err)
+	assert.Equal(t, "lib/util.py", file.Path)
+
+	// the file of the same content is not saved again
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(stored(), nil)
+	_, err = s.SaveFile("default", "infer", &models.FunctionDraftFile{Path: "/index.py", Content: "pass\n"})
+	assert.NoError(t, err)
+
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(stored(), nil)
+	_, err = s.SaveFile("default", "infer", &models.FunctionDraftFile{Path: "/big.py", Content: string(bytes.Repeat([]byte("a"), 64))})
+	assert.Error(t, err)
+
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(stored(), nil)
+	storage.EXPECT().UpdateFunctionDraft(gomock.Any()).DoAndReturn(func(d *models.FunctionDraft) error {
+		assert.Empty(t, d.Files)
+		return nil
+	})
+	assert.NoError(t, s.DeleteFile("default", "infer", "/index.py"))
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(stored(), nil)
+	assert.NoError(t, s.DeleteFile("default", "infer", "/util.py"))
+
+	// the files are kept when the draft is updated
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(stored(), nil)
+	storage.EXPECT().UpdateFunctionDraft(gomock.Any()).DoAndReturn(func(d *models.FunctionDraft) error {
+		assert.Equal(t, "index.main", d.Handler)
+		assert.Len(t, d.Files, 1)
+		return nil
+	})
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(stored(), nil)
+	_, err = s.Update(&models.FunctionDraft{Namespace: "default", Name: "infer", Runtime: "python3", Handler: "index.main"})
+	assert.NoError(t, err)
+}
+
+func TestFunctionDraftInvoke(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	storage, sandbox, sFunc := mockPlugin.NewMockFunctionDraft(mockCtl), mockPlugin.NewMockFunctionSandbox(mockCtl), ms.NewMockFunctionService(mockCtl)
+	s := &FunctionDraftServiceImpl{Storage: storage, Func: sFunc}
+
+	req := &models.FunctionInvokeRequest{Namespace: "default", Name: "infer", Payload: json.RawMessage(`{"a":1}`)}
+	_, err := s.Invoke("u1", req)
+	assert.Error(t, err)
+
+	s.Sandbox = sandbox
+	sFunc.EXPECT().ListRuntimes().Return(map[string]string{"python3": "baetyltech/function-python:v2.2.0"}, nil)
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(&models.FunctionDraft{
+		Namespace: "default", Name: "infer", Runtime: "python3", Handler: "index.handler",
+		Files: map[string]string{"index.py": "def handler(event, context):\n    return event\n"},
+	}, nil)
+	sandbox.EXPECT().Invoke(gomock.Any()).DoAndReturn(func(r *models.FunctionInvokeRequest) (*models.FunctionInvokeResult, error) {
+		assert.Equal(t, "baetyltech/function-python:v2.2.0", r.Image)
+		assert.Equal(t, "index.handler", r.Handler)
+		assert.NotEmpty(t, r.Code)
+		return &models.FunctionInvokeResult{Result: r.Payload, Duration: 10}, nil
+	})
+	res, err := s.Invoke("u1", req)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":1}`, string(res.Result))
+
+	// the sandbox downloads the code of the version published
+	sObj := ms.NewMockObjectService(mockCtl)
+	s.Object = sObj
+	sFunc.EXPECT().ListRuntimes().Return(map[string]string{"python3": "baetyltech/function-python:v2.2.0"}, nil)
+	storage.EXPECT().GetFunctionVersion("default", "infer", "v1").Return(&models.FunctionVersion{
+		Namespace: "default", Name: "infer", Version: "v1", Runtime: "python3", Handler: "index.main",
+		Source: "minio", Bucket: "baetyl-cloud-u1", Object: "functions/default/infer/v1/sha.zip",
+	}, nil)
+	sObj.EXPECT().GenInternalObjectURL("u1", "baetyl-cloud-u1", "functions/default/infer/v1/sha.zip", "minio").Return(&models.ObjectURL{URL: "http://minio/sha.zip"}, nil)
+	sandbox.EXPECT().Invoke(gomock.Any()).DoAndReturn(func(r *models.FunctionInvokeRequest) (*models.FunctionInvokeResult, error) {
+		assert.Equal(t, "index.main", r.Handler)
+		assert.Equal(t, "http://minio/sha.zip", r.CodeURL)
+		assert.Empty(t, r.Code)
+		return &models.FunctionInvokeResult{Error: "KeyError: 'a'"}, nil
+	})
+	res, err = s.Invoke("u1", &models.FunctionInvokeRequest{Namespace: "default", Name: "infer", Version: "v1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "KeyError: 'a'", res.Error)
+}
+
+func TestFunctionDraftPublish(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	storage := mockPlugin.NewMockFunctionDraft(mockCtl)
+	sObj, sFunc, sProp := ms.NewMockObjectService(mockCtl), ms.NewMockFunctionService(mockCtl), ms.NewMockPropertyService(mockCtl)
+	s := &FunctionDraftServiceImpl{Storage: storage, Object: sObj, Func: sFunc, Prop: sProp}
+	sFunc.EXPECT().ListRuntimes().Return(map[string]string{"python3": "baetyltech/function-python:v2.2.0"}, nil).AnyTimes()
+	sFunc.EXPECT().GetFunctionRuntime("python3").Return(nil, common.Error(common.ErrResourceNotFound)).AnyTimes()
+	draft := &models.FunctionDraft{
+		Namespace: "default", Name: "infer", Runtime: "python3", Handler: "index.handler",
+		Files: map[string]string{"index.py": "def handler(event, context):\n    return event\n"},
+	}
+
+	_, err := s.Publish("u1", "default", "infer", &models.FunctionPublishRequest{Version: "v1:latest"})
+	assert.Error(t, err)
+
+	// the version published is never overwritten
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(draft, nil)
+	storage.EXPECT().GetFunctionVersion("default", "infer", "v1").Return(&models.FunctionVersion{}, nil)
+	_, err = s.Publish("u1", "default", "infer", &models.FunctionPublishRequest{Version: "v1"})
+	assert.Error(t, err)
+
+	notFound := common.Error(common.ErrResourceNotFound, common.Field("type", "function version"), common.Field("name", "infer:v1"))
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(draft, nil)
+	storage.EXPECT().GetFunctionVersion("default", "infer", "v1").Return(nil, notFound)
+	sProp.EXPECT().GetPropertyValue(common.ObjectSource).Return("minio", nil)
+	sObj.EXPECT().ListSources().Return(map[string]models.ObjectStorageSourceV2{"minio": {}})
+	sObj.EXPECT().CreateInternalBucketIfNotExist("u1", "baetyl-cloud-u1", common.AWSS3PrivatePermission, "minio").Return(nil, nil)
+	sObj.EXPECT().PutInternalObject("u1", "baetyl-cloud-u1", gomock.Any(), "minio", gomock.Any()).Return(nil)
+	var published *models.FunctionVersion
+	storage.EXPECT().CreateFunctionVersion(gomock.Any()).DoAndReturn(func(v *models.FunctionVersion) error {
+		assert.Equal(t, "python3", v.Runtime)
+		assert.Equal(t, "index.handler", v.Handler)
+		assert.Equal(t, "first", v.Description)
+		assert.Equal(t, "functions/default/infer/v1/"+v.Sha256+".zip", v.Object)
+		published = v
+		return nil
+	})
+	storage.EXPECT().GetFunctionVersion("default", "infer", "v1").DoAndReturn(func(_, _, _ string) (*models.FunctionVersion, error) {
+		return published, nil
+	})
+	res, err := s.Publish("u1", "default", "infer", &models.FunctionPublishRequest{Version: "v1", Description: "first"})
+	assert.NoError(t, err)
+	assert.Equal(t, "minio", res.Source)
+	assert.Equal(t, "baetyl-cloud-u1", res.Bucket)
+}
+
+func TestFunctionDraftPublishEnvs(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	storage := mockPlugin.NewMockFunctionDraft(mockCtl)
+	sFunc, sSecret := ms.NewMockFunctionService(mockCtl), ms.NewMockSecretService(mockCtl)
+	s := &FunctionDraftServiceImpl{Storage: storage, Func: sFunc, Secret: sSecret}
+	sFunc.EXPECT().ListRuntimes().Return(map[string]string{"python3": "baetyltech/function-python:v2.2.0"}, nil).AnyTimes()
+	sFunc.EXPECT().GetFunctionRuntime("python3").Return(nil, common.Error(common.ErrResourceNotFound)).AnyTimes()
+	draft := &models.FunctionDraft{
+		Namespace: "default", Name: "infer", Runtime: "python3", Handler: "index.handler",
+		Files: map[string]string{"index.py": "def handler(event, context):\n    return event\n"},
+		Env:   []models.FunctionEnv{{Name: "THRESHOLD", Type: models.FunctionEnvNumber, Default: "high"}},
+	}
+
+	// the default is not a number
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(draft, nil)
+	_, err := s.Publish("u1", "default", "infer", &models.FunctionPublishRequest{Version: "v1"})
+	assert.Error(t, err)
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+
+	draft.Env = []models.FunctionEnv{{Name: "TOKEN", Required: true, Secret: &models.FunctionEnvSecret{Name: "cred", Key: "token"}}}
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(draft, nil)
+	sSecret.EXPECT().Get("default", "cred", "").Return(nil, common.Error(common.ErrResourceNotFound, common.Field("type", "secret"), common.Field("name", "cred")))
+	_, err = s.Publish("u1", "default", "infer", &models.FunctionPublishRequest{Version: "v1"})
+	assert.Error(t, err)
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(draft, nil)
+	sSecret.EXPECT().Get("default", "cred", "").Return(&specV1.Secret{Data: map[string][]byte{"password": []byte("x")}}, nil)
+	_, err = s.Publish("u1", "default", "infer", &models.FunctionPublishRequest{Version: "v1"})
+	assert.Error(t, err)
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+
+	// the key checked reaches the version
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(draft, nil)
+	sSecret.EXPECT().Get("default", "cred", "").Return(&specV1.Secret{Data: map[string][]byte{"token": []byte("x")}}, nil)
+	storage.EXPECT().GetFunctionVersion("default", "infer", "v1").Return(&models.FunctionVersion{}, nil)
+	_, err = s.Publish("u1", "default", "infer", &models.FunctionPublishRequest{Version: "v1"})
+	assert.Equal(t, common.ErrResourceConflict, err.(errors.Coder).Code())
+}
+
+func TestFunctionDraftPublishFlavor(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	storage := mockPlugin.NewMockFunctionDraft(mockCtl)
+	sFunc := ms.NewMockFunctionService(mockCtl)
+	s := &FunctionDraftServiceImpl{Storage: storage, Func: sFunc}
+	sFunc.EXPECT().ListRuntimes().Return(map[string]string{"python3": "baetyltech/function-python:v2.2.0"}, nil).AnyTimes()
+	draft := &models.FunctionDraft{Namespace: "default", Name: "infer", Runtime: "python3", Handler: "index.handler"}
+	req := &models.FunctionPublishRequest{Version: "v1", Flavor: models.FunctionFlavorGPU}
+
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(draft, nil)
+	sFunc.EXPECT().GetFunctionRuntime("python3").Return(nil, common.Error(common.ErrResourceNotFound))
+	_, err := s.Publish("u1", "default", "infer", req)
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(draft, nil)
+	sFunc.EXPECT().GetFunctionRuntime("python3").Return(&models.FunctionRuntime{Name: "python3", Image: "baetyltech/function-python:v2.2.0"}, nil)
+	_, err = s.Publish("u1", "default", "infer", req)
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+
+	storage.EXPECT().GetFunctionDraft("default", "infer").Return(draft, nil)
+	sFunc.EXPECT().GetFunctionRuntime("python3").Return(&models.FunctionRuntime{Name: "python3", GPUImage: "baetyltech/function-python-cuda:v2.2.0"}, nil)
+	storage.EXPECT().GetFunctionVersion("default", "infer", "v1").Return(&models.FunctionVersion{}, nil)
+	_, err = s.Publish("u1", "default", "infer", req)
+	assert.Equal(t, common.ErrResourceConflict, err.(errors.Coder).Code())
+}
+
+func TestCheckWasmBundle(t *testing.T) {
+	assert.NoError(t, checkWasmBundle([]byte("\x00asm\x01\x00\x00\x00")))
+	assert.Error(t, checkWasmBundle([]byte("\x00asm\x02\x00\x00\x00")))
+	assert.Error(t, checkWasmBundle([]byte("PK\x03\x04\x00\x00\x00\x00")))
+	assert.Error(t, checkWasmBundle([]byte("\x00asm")))
+}
+
+func TestFunctionDraftPublishBundle(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	storage := mockPlugin.NewMockFunctionDraft(mockCtl)
+	sObj, sFunc, sProp := ms.NewMockObjectService(mockCtl), ms.NewMockFunctionService(mockCtl), ms.NewMockPropertyService(mockCtl)
+	s := &FunctionDraftServiceImpl{Storage: storage, Object: sObj, Func: sFunc, Prop: sProp, maxSize: 16}
+	sFunc.EXPECT().ListRuntimes().Return(map[string]string{"python3": "baetyltech/function-python:v2.2.0", "wasmedge": "baetyltech/wasmedge:0.13"}, nil).AnyTimes()
+	notFound := common.Error(common.ErrResourceNotFound, common.Field("type", "function version"), common.Field("name", "filter:v1"))
+	wasm := []byte("\x00asm\x01\x00\x00\x00")
+	req := &models.FunctionPublishRequest{Version: "v1"}
+
+	// the runtime of draft doesn't run wasm bundles
+	storage.EXPECT().GetFunctionDraft("default", "filter").Return(&models.FunctionDraft{Namespace: "default", Name: "filter", Runtime: "python3"}, nil)
+	sFunc.EXPECT().GetFunctionRuntime("python3").Return(nil, common.Error(common.ErrResourceNotFound))
+	storage.EXPECT().GetFunctionVersion("default", "filter", "v1").Return(nil, notFound)
+	_, err := s.PublishBundle("u1", "default", "filter", req, wasm)
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+
+	draft := &models.FunctionDraft{Namespace: "default", Name: "filter", Runtime: "wasmedge", Handler: "filter"}
+	runtime := &models.FunctionRuntime{Name: "wasmedge", Type: models.FunctionRuntimeWasm, Image: "baetyltech/wasmedge:0.13"}
+	sFunc.EXPECT().GetFunctionRuntime("wasmedge").Return(runtime, nil).AnyTimes()
+
+	// the draft of wasm runtime has no files to pack
+	storage.EXPECT().GetFunctionDraft("default", "filter").Return(draft, nil)
+	storage.EXPECT().GetFunctionVersion("default", "filter", "v1").Return(nil, notFound)
+	_, err = s.Publish("u1", "default", "filter", req)
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+
+	storage.EXPECT().GetFunctionDraft("default", "filter").Return(draft, nil)
+	storage.EXPECT().GetFunctionVersion("default", "filter", "v1").Return(nil, notFound)
+	_, err = s.PublishBundle("u1", "default", "filter", req, []byte("PK\x03\x04"))
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+
+	storage.EXPECT().GetFunctionDraft("default", "filter").Return(draft, nil)
+	storage.EXPECT().GetFunctionVersion("default", "filter", "v1").Return(nil, notFound)
+	_, err = s.PublishBundle("u1", "default", "filter", req, append(wasm, make([]byte, 16)...))
+	assert.Equal(t, common.ErrRequestParamInvalid, err.(errors.Coder).Code())
+
+	storage.EXPECT().GetFunctionDraft("default", "filter").Return(draft, nil)
+	storage.EXPECT().GetFunctionVersion("default", "filter", "v1").Return(nil, notFound)
+	sProp.EXPECT().GetPropertyValue(common.ObjectSource).Return("minio", nil)
+	sObj.EXPECT().ListSources().Return(map[string]models.ObjectStorageSourceV2{"minio": {}})
+	sObj.EXPECT().CreateInternalBucketIfNotExist("u1", "baetyl-cloud-u1", common.AWSS3PrivatePermission, "minio").Return(nil, nil)
+	sObj.EXPECT().PutInternalObject("u1", "baetyl-cloud-u1", gomock.Any(), "minio", wasm).Return(nil)
+	var published *models.FunctionVersion
+	storage.EXPECT().CreateFunctionVersion(gomock.Any()).DoAndReturn(func(v *models.FunctionVersion) error {
+		assert.Equal(t, "functions/default/filter/v1/"+v.Sha256+".wasm", v.Object)
+		assert.Equal(t, int64(8), v.Size)
+		assert.True(t, v.IsWasm())
+		published = v
+		return nil
+	})
+	storage.EXPECT().GetFunctionVersion("default", "filter", "v1").DoAndReturn(func(_, _, _ string) (*models.FunctionVersion, error) {
+		return published, nil
+	})
+	res, err := s.PublishBundle("u1", "default", "filter", req, wasm)
+	assert.NoError(t, err)
+	assert.Equal(t, "wasmedge", res.Runtime)
+}
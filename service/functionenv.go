@@ -0,0 +1,174 @@
+package service
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+var functionEnvNameRegexp = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+// CheckFunctionEnvs checks the declarations of the envs of function, the default should be of the type
+// and the env bound to secret has no default
+func CheckFunctionEnvs(envs []models.FunctionEnv) error {
+	names := map[string]bool{}
+	for _, env := range envs {
+		if !functionEnvNameRegexp.MatchString(env.Name) {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the name of env "+env.Name+" is invalid"))
+		}
+		if names[env.Name] {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the env "+env.Name+" is duplicated"))
+		}
+		names[env.Name] = true
+		switch env.Type {
+		case "", models.FunctionEnvString, models.FunctionEnvInt, models.FunctionEnvNumber, models.FunctionEnvBool:
+		default:
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the type "+env.Type+" of env "+env.Name+" is not supported"))
+		}
+		if env.Secret != nil {
+			if env.Default != "" {
+				return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the env "+env.Name+" bound to secret can't have default"))
+			}
+			if env.Secret.Name == "" || env.Secret.Key == "" || strings.Contains(env.Secret.Name, "_") {
+				return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the secret of env "+env.Name+" is invalid"))
+			}
+			continue
+		}
+		if env.Default != "" && !isFunctionEnvValue(&env, env.Default) {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the default "+env.Default+" of env "+env.Name+" should be "+env.Type))
+		}
+	}
+	return nil
+}
+
+// ApplyFunctionEnvs renders the envs declared by the functions of service into it. The values set by service
+// are kept and checked by the types, the defaults are added for the others, and the envs bound to secrets are
+// added empty and marked in the labels of app, which are filled in with the secrets when delivered to nodes
+func ApplyFunctionEnvs(app *specV1.Application, svc *specV1.Service, envs []models.FunctionEnv) error {
+	values := map[string]string{}
+	for _, env := range svc.Env {
+		values[env.Name] = env.Value
+	}
+	for i := range envs {
+		env := &envs[i]
+		if value, ok := values[env.Name]; ok {
+			if env.Secret != nil && value == "" {
+				// the env added by the previous rendering of app
+				if err := bindFunctionEnvSecret(app, env); err != nil {
+					return err
+				}
+				continue
+			}
+			if !isFunctionEnvValue(env, value) {
+				return common.Error(common.ErrRequestParamInvalid, common.Field("error",
+					"the value "+value+" of env "+env.Name+" of service "+svc.Name+" should be "+env.Type))
+			}
+			continue
+		}
+		switch {
+		case env.Secret != nil:
+			if err := bindFunctionEnvSecret(app, env); err != nil {
+				return err
+			}
+			svc.Env = append(svc.Env, specV1.Environment{Name: env.Name})
+			values[env.Name] = ""
+		case env.Default != "":
+			svc.Env = append(svc.Env, specV1.Environment{Name: env.Name, Value: env.Default})
+			values[env.Name] = env.Default
+		case env.Required:
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error",
+				"the env "+env.Name+" required by the functions of service "+svc.Name+" is not set"))
+		}
+	}
+	return nil
+}
+
+// AppEnvSecrets returns the keys of secrets bound to the envs of app, keyed by the names of envs
+func AppEnvSecrets(labels map[string]string) map[string]models.FunctionEnvSecret {
+	res := map[string]models.FunctionEnvSecret{}
+	for k, v := range labels {
+		if !strings.HasPrefix(k, common.LabelPrefixEnvSecret) {
+			continue
+		}
+		parts := strings.SplitN(v, "_", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		res[strings.TrimPrefix(k, common.LabelPrefixEnvSecret)] = models.FunctionEnvSecret{Name: parts[0], Key: parts[1]}
+	}
+	return res
+}
+
+// ApplyEnvSecrets fills in the envs of app bound to secrets, the values set by services are kept
+func ApplyEnvSecrets(app *specV1.Application, values map[string]string) {
+	apply := func(svc *specV1.Service) {
+		for i := range svc.Env {
+			if v, ok := values[svc.Env[i].Name]; ok && svc.Env[i].Value == "" {
+				svc.Env[i].Value = v
+			}
+		}
+	}
+	for i := range app.InitServices {
+		apply(&app.InitServices[i])
+	}
+	for i := range app.Services {
+		apply(&app.Services[i])
+	}
+}
+
+// SecretHasKey returns whether the key is delivered with secret, the keys of the external secret are listed
+// by its reference, and all keys of store are delivered if none is listed
+func SecretHasKey(secret *specV1.Secret, key string) (bool, error) {
+	raw, ok := secret.Data[common.SecretExternalKey]
+	if !ok {
+		_, ok = secret.Data[key]
+		return ok, nil
+	}
+	ref := new(models.SecretExternal)
+	if err := json.Unmarshal(raw, ref); err != nil {
+		return false, err
+	}
+	if len(ref.Keys) == 0 {
+		return true, nil
+	}
+	for _, k := range ref.Keys {
+		if k == key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func bindFunctionEnvSecret(app *specV1.Application, env *models.FunctionEnv) error {
+	key, value := common.LabelPrefixEnvSecret+env.Name, env.Secret.Name+"_"+env.Secret.Key
+	if old, ok := app.Labels[key]; ok && old != value {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the env "+env.Name+" is bound to different secrets by functions"))
+	}
+	if len(value) > 63 {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the secret of env "+env.Name+" is too long to bind"))
+	}
+	if app.Labels == nil {
+		app.Labels = map[string]string{}
+	}
+	app.Labels[key] = value
+	return nil
+}
+
+func isFunctionEnvValue(env *models.FunctionEnv, value string) bool {
+	var err error
+	switch env.Type {
+	case models.FunctionEnvInt:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case models.FunctionEnvNumber:
+		_, err = strconv.ParseFloat(value, 64)
+	case models.FunctionEnvBool:
+		_, err = strconv.ParseBool(value)
+	}
+	return err == nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestCheckFunctionEnvs(t *testing.T) {
+	secret := &models.FunctionEnvSecret{Name: "cred", Key: "token"}
+	cases := []struct {
+		envs  []models.FunctionEnv
+		valid bool
+	}{
+		{nil, true},
+		{[]models.FunctionEnv{{Name: "LOG_LEVEL", Default: "info"}, {Name: "TOKEN", Required: true, Secret: secret}}, true},
+		{[]models.FunctionEnv{{Name: "RATE", Type: models.FunctionEnvNumber, Default: "0.5"}, {Name: "DEBUG", Type: models.FunctionEnvBool}}, true},
+		{[]models.FunctionEnv{{Name: "1ST"}}, false},
+		{[]models.FunctionEnv{{Name: "A"}, {Name: "A"}}, false},
+		{[]models.FunctionEnv{{Name: "A", Type: "list"}}, false},
+		{[]models.FunctionEnv{{Name: "A", Type: models.FunctionEnvInt, Default: "1.5"}}, false},
+		{[]models.FunctionEnv{{Name: "A", Default: "x", Secret: secret}}, false},
+		{[]models.FunctionEnv{{Name: "A", Secret: &models.FunctionEnvSecret{Name: "cred"}}}, false},
+		{[]models.FunctionEnv{{Name: "A", Secret: &models.FunctionEnvSecret{Name: "my_cred", Key: "token"}}}, false},
+	}
+	for i, c := range cases {
+		assert.Equal(t, c.valid, CheckFunctionEnvs(c.envs) == nil, i)
+	}
+}
+
+func TestApplyFunctionEnvs(t *testing.T) {
+	envs := []models.FunctionEnv{
+		{Name: "LOG_LEVEL", Default: "info"},
+		{Name: "PORT", Type: models.FunctionEnvInt, Required: true},
+		{Name: "TOKEN", Required: true, Secret: &models.FunctionEnvSecret{Name: "cred", Key: "token"}},
+		{Name: "EXTRA"},
+	}
+	app := &specV1.Application{Name: "app"}
+	svc := &specV1.Service{Name: "infer", Env: []specV1.Environment{{Name: "PORT", Value: "8080"}}}
+	assert.NoError(t, ApplyFunctionEnvs(app, svc, envs))
+	assert.Equal(t, []specV1.Environment{
+		{Name: "PORT", Value: "8080"},
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "TOKEN"},
+	}, svc.Env)
+	assert.Equal(t, "cred_token", app.Labels[common.LabelPrefixEnvSecret+"TOKEN"])
+
+	// the rendering is idempotent
+	delete(app.Labels, common.LabelPrefixEnvSecret+"TOKEN")
+	assert.NoError(t, ApplyFunctionEnvs(app, svc, envs))
+	assert.Len(t, svc.Env, 3)
+	assert.Equal(t, "cred_token", app.Labels[common.LabelPrefixEnvSecret+"TOKEN"])
+
+	svc = &specV1.Service{Name: "infer", Env: []specV1.Environment{{Name: "PORT", Value: "http"}}}
+	assert.Error(t, ApplyFunctionEnvs(app, svc, envs))
+
+	svc = &specV1.Service{Name: "infer"}
+	assert.Error(t, ApplyFunctionEnvs(app, svc, envs))
+
+	// the env is bound to different secrets by functions of service
+	svc = &specV1.Service{Name: "infer", Env: []specV1.Environment{{Name: "PORT", Value: "80"}}}
+	app.Labels[common.LabelPrefixEnvSecret+"TOKEN"] = "other_token"
+	assert.Error(t, ApplyFunctionEnvs(app, svc, envs))
+}
+
+func TestAppEnvSecrets(t *testing.T) {
+	res := AppEnvSecrets(map[string]string{
+		common.LabelPrefixEnvSecret + "TOKEN": "cred_api_token",
+		common.LabelPrefixEnvSecret + "BAD":   "cred",
+		"baetyl-app-name":                     "app",
+	})
+	assert.Equal(t, map[string]models.FunctionEnvSecret{"TOKEN": {Name: "cred", Key: "api_token"}}, res)
+
+	app := &specV1.Application{
+		InitServices: []specV1.Service{{Env: []specV1.Environment{{Name: "TOKEN"}}}},
+		Services:     []specV1.Service{{Env: []specV1.Environment{{Name: "TOKEN", Value: "set"}, {Name: "OTHER"}}}},
+	}
+	ApplyEnvSecrets(app, map[string]string{"TOKEN": "t0"})
+	assert.Equal(t, "t0", app.InitServices[0].Env[0].Value)
+	assert.Equal(t, "set", app.Services[0].Env[0].Value)
+	assert.Empty(t, app.Services[0].Env[1].Value)
+}
+
+func TestSecretHasKey(t *testing.T) {
+	secret := &specV1.Secret{Data: map[string][]byte{"token": nil}}
+	ok, err := SecretHasKey(secret, "token")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = SecretHasKey(secret, "password")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	secret.Data = map[string][]byte{common.SecretExternalKey: []byte(`{"keys":["token"]}`)}
+	ok, _ = SecretHasKey(secret, "token")
+	assert.True(t, ok)
+	ok, _ = SecretHasKey(secret, "password")
+	assert.False(t, ok)
+
+	// all keys of the external secret are delivered
+	secret.Data = map[string][]byte{common.SecretExternalKey: []byte(`{"provider":"vaultkv","path":"cred"}`)}
+	ok, _ = SecretHasKey(secret, "password")
+	assert.True(t, ok)
+
+	secret.Data = map[string][]byte{common.SecretExternalKey: []byte(`{`)}
+	_, err = SecretHasKey(secret, "token")
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/garbage.go -package=service github.com/baetyl/baetyl-cloud/v2/service GarbageService
+
+type GarbageService interface {
+	plugin.Garbage
+	// Get returns the latest report cached, which is nil before the first scan
+	Get() *models.GarbageReport
+	// Set caches the report scanned
+	Set(report *models.GarbageReport)
+	// DeleteShadow deletes the shadow whose node no longer exists
+	DeleteShadow(namespace, name string) error
+	// MinAge returns the age the resources reach before they are reported
+	MinAge() time.Duration
+}
+
+type GarbageServiceImpl struct {
+	plugin.Garbage
+	Shadow plugin.Shadow
+	minAge time.Duration
+	latest *models.GarbageReport
+	mutex  sync.RWMutex
+}
+
+func NewGarbageService(config *config.CloudConfig) (GarbageService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.Garbage)
+	if err != nil {
+		return nil, err
+	}
+	shadow, err := plugin.GetPlugin(config.Plugin.Shadow)
+	if err != nil {
+		return nil, err
+	}
+	return &GarbageServiceImpl{Garbage: p.(plugin.Garbage), Shadow: shadow.(plugin.Shadow), minAge: config.Garbage.MinAge}, nil
+}
+
+func (s *GarbageServiceImpl) Get() *models.GarbageReport {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.latest
+}
+
+func (s *GarbageServiceImpl) Set(report *models.GarbageReport) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.latest = report
+}
+
+func (s *GarbageServiceImpl) DeleteShadow(namespace, name string) error {
+	return s.Shadow.Delete(namespace, name)
+}
+
+func (s *GarbageServiceImpl) MinAge() time.Duration {
+	return s.minAge
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewGarbageService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.Garbage = common.RandString(9)
+	_, err := NewGarbageService(conf)
+	assert.Error(t, err)
+}
+
+func TestGarbageService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mShadow := mockPlugin.NewMockShadow(mockCtl)
+	s := &GarbageServiceImpl{Garbage: mockPlugin.NewMockGarbage(mockCtl), Shadow: mShadow, minAge: time.Hour}
+
+	assert.Nil(t, s.Get())
+	report := &models.GarbageReport{ID: "r1"}
+	s.Set(report)
+	assert.Equal(t, report, s.Get())
+	assert.Equal(t, time.Hour, s.MinAge())
+
+	mShadow.EXPECT().Delete("default", "node01").Return(nil)
+	assert.NoError(t, s.DeleteShadow("default", "node01"))
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-contrib/cache/persistence"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/health.go -package=service github.com/baetyl/baetyl-cloud/v2/service HealthService
+
+const (
+	healthFullScore = 100
+	// healthOfflineGrace the grace added to the report frequency before the report is stale if no heartbeat policy
+	healthOfflineGrace   = models.DefaultOfflineTimeout * time.Second
+	healthStalePenalty   = 50
+	healthPressureHigh   = 0.9
+	healthPressureMedium = 0.8
+	healthFailingPenalty = 10
+	healthFailingMax     = 30
+	healthWebhookTimeout = 5 * time.Second
+)
+
+type HealthService interface {
+	GetThreshold(namespace string) (*models.HealthThreshold, error)
+	SetThreshold(threshold *models.HealthThreshold) (*models.HealthThreshold, error)
+	DeleteThreshold(namespace string) error
+	Evaluate(node *specV1.Node, threshold *models.HealthThreshold) *models.NodeHealth
+	Check(namespace, name string) error
+}
+
+type HealthServiceImpl struct {
+	Threshold plugin.HealthThreshold
+	Node      NodeService
+	Heartbeat HeartbeatService
+	cache     persistence.CacheStore
+	expire    time.Duration
+	client    *http.Client
+	levels    map[string]string
+	mutex     sync.Mutex
+	log       *log.Logger
+}
+
+// nodeResource the usage and capacity of node in the report of nodestats
+type nodeResource struct {
+	Usage    map[string]string `json:"usage,omitempty"`
+	Capacity map[string]string `json:"capacity,omitempty"`
+}
+
+func NewHealthService(config *config.CloudConfig) (HealthService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.Health)
+	if err != nil {
+		return nil, err
+	}
+	node, err := NewNodeService(config)
+	if err != nil {
+		return nil, err
+	}
+	heartbeat, err := NewHeartbeatService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &HealthServiceImpl{
+		Threshold: p.(plugin.HealthThreshold),
+		Node:      node,
+		Heartbeat: heartbeat,
+		cache:     persistence.NewInMemoryStore(config.Cache.ExpirationDuration),
+		expire:    config.Cache.ExpirationDuration,
+		client:    &http.Client{Timeout: healthWebhookTimeout},
+		levels:    map[string]string{},
+		log:       log.With(log.Any("service", "health")),
+	}, nil
+}
+
+// GetThreshold returns the threshold of namespace, the default one is returned if it is not set
+func (s *HealthServiceImpl) GetThreshold(namespace string) (*models.HealthThreshold, error) {
+	var threshold models.HealthThreshold
+	if err := s.cache.Get(namespace, &threshold); err == nil {
+		return &threshold, nil
+	}
+	res, err := s.Threshold.GetHealthThreshold(namespace)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+		res = &models.HealthThreshold{
+			Namespace:     namespace,
+			WarningScore:  models.DefaultHealthWarningScore,
+			CriticalScore: models.DefaultHealthCriticalScore,
+		}
+	}
+	s.cache.Set(namespace, *res, s.expire)
+	return res, nil
+}
+
+func (s *HealthServiceImpl) SetThreshold(threshold *models.HealthThreshold) (*models.HealthThreshold, error) {
+	_, err := s.Threshold.GetHealthThreshold(threshold.Namespace)
+	if err == nil {
+		err = s.Threshold.UpdateHealthThreshold(threshold)
+	} else if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+		err = s.Threshold.CreateHealthThreshold(threshold)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Delete(threshold.Namespace)
+	return s.Threshold.GetHealthThreshold(threshold.Namespace)
+}
+
+func (s *HealthServiceImpl) DeleteThreshold(namespace string) error {
+	if err := s.Threshold.DeleteHealthThreshold(namespace); err != nil {
+		return err
+	}
+	s.cache.Delete(namespace)
+	return nil
+}
+
+// Evaluate scores the node from 100, the stale report, the pressure of cpu and memory and the failing apps are deducted
+func (s *HealthServiceImpl) Evaluate(node *specV1.Node, threshold *models.HealthThreshold) *models.NodeHealth {
+	health := &models.NodeHealth{Score: healthFullScore}
+	var reportTime time.Time
+	if err := common.DecodeReport(node.Report, "time", &reportTime); err != nil || reportTime.IsZero() {
+		return &models.NodeHealth{Level: models.HealthLevelUnknown, Reasons: []string{"node has never reported"}}
+	}
+
+	if age := time.Since(reportTime); age > nodeReportInterval(node)+s.offlineGrace(node.Namespace) {
+		health.Score -= healthStalePenalty
+		health.Reasons = append(health.Reasons, fmt.Sprintf("report is stale for %s", age.Truncate(time.Second)))
+	}
+
+	for _, res := range decodeNodeResources(node.Report) {
+		for _, name := range []string{"cpu", "memory"} {
+			ratio, ok := resourceRatio(res, name)
+			if !ok {
+				continue
+			}
+			switch {
+			case ratio >= healthPressureHigh:
+				health.Score -= 20
+			case ratio >= healthPressureMedium:
+				health.Score -= 10
+			default:
+				continue
+			}
+			health.Reasons = append(health.Reasons, fmt.Sprintf("%s usage is %d%%", name, int(math.Round(ratio*100))))
+		}
+	}
+
+	penalty := 0
+	for _, key := range []string{"sysappstats", "appstats"} {
+		var stats []specV1.AppStats
+		if err := common.DecodeReport(node.Report, key, &stats); err != nil {
+			continue
+		}
+		for _, stat := range stats {
+			for _, ins := range stat.InstanceStats {
+				if ins.Status == "Running" {
+					continue
+				}
+				penalty += healthFailingPenalty
+				health.Reasons = append(health.Reasons, fmt.Sprintf("app %s is %s", stat.Name, ins.Status))
+				break
+			}
+		}
+	}
+	if penalty > healthFailingMax {
+		penalty = healthFailingMax
+	}
+	health.Score -= penalty
+
+	if health.Score < 0 {
+		health.Score = 0
+	}
+	health.Level = threshold.Level(health.Score)
+	return health
+}
+
+// Check evaluates the node after it reports, the webhook of namespace is notified when the health level changes
+func (s *HealthServiceImpl) Check(namespace, name string) error {
+	threshold, err := s.GetThreshold(namespace)
+	if err != nil {
+		return err
+	}
+	if threshold.Webhook == "" {
+		return nil
+	}
+	node, err := s.Node.Get(nil, namespace, name)
+	if err != nil {
+		return err
+	}
+	health := s.Evaluate(node, threshold)
+	if health.Level == models.HealthLevelUnknown {
+		return nil
+	}
+
+	key := namespace + "/" + name
+	s.mutex.Lock()
+	previous, ok := s.levels[key]
+	s.levels[key] = health.Level
+	s.mutex.Unlock()
+	// the first evaluation of healthy node is not an alert
+	if previous == health.Level || (!ok && health.Level == models.HealthLevelHealthy) {
+		return nil
+	}
+	return s.postAlert(threshold.Webhook, &models.NodeHealthAlert{
+		Namespace:     namespace,
+		Node:          name,
+		Score:         health.Score,
+		Level:         health.Level,
+		PreviousLevel: previous,
+		Reasons:       health.Reasons,
+		Time:          time.Now().UTC(),
+	})
+}
+
+func (s *HealthServiceImpl) postAlert(webhook string, alert *models.NodeHealthAlert) error {
+	if err := postWebhook(s.client, webhook, alert); err != nil {
+		return err
+	}
+	s.log.Debug("node health alert posted", log.Any("namespace", alert.Namespace), log.Any("node", alert.Node), log.Any("level", alert.Level))
+	return nil
+}
+
+// offlineGrace returns the offline timeout of heartbeat policy, the report older than it is stale
+func (s *HealthServiceImpl) offlineGrace(namespace string) time.Duration {
+	if s.Heartbeat == nil {
+		return healthOfflineGrace
+	}
+	return s.Heartbeat.OfflineTimeout(namespace)
+}
+
+// postWebhook posts the body as json to the webhook, the response out of 2xx is an error
+func postWebhook(client *http.Client, webhook string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := client.Post(webhook, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("failed to post webhook %s, status %d: %s", webhook, resp.StatusCode, string(msg))
+	}
+	return nil
+}
+
+// nodeReportInterval returns the report frequency of core, the default one is used if it is not set
+func nodeReportInterval(node *specV1.Node) time.Duration {
+	freq, _ := node.Attributes[specV1.BaetylCoreFrequency].(string)
+	seconds, err := strconv.Atoi(freq)
+	if err != nil {
+		seconds, _ = strconv.Atoi(common.DefaultCoreFrequency)
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// decodeNodeResources decodes nodestats, which is reported per node of cluster or as a single one
+func decodeNodeResources(report specV1.Report) []nodeResource {
+	var single nodeResource
+	if err := common.DecodeReport(report, common.NodeStats, &single); err == nil && (single.Usage != nil || single.Capacity != nil) {
+		return []nodeResource{single}
+	}
+	var cluster map[string]nodeResource
+	if err := common.DecodeReport(report, common.NodeStats, &cluster); err != nil {
+		return nil
+	}
+	res := make([]nodeResource, 0, len(cluster))
+	for _, v := range cluster {
+		res = append(res, v)
+	}
+	return res
+}
+
+func resourceRatio(res nodeResource, name string) (float64, bool) {
+	usage, err := resource.ParseQuantity(res.Usage[name])
+	if err != nil {
+		return 0, false
+	}
+	capacity, err := resource.ParseQuantity(res.Capacity[name])
+	if err != nil || capacity.MilliValue() == 0 {
+		return 0, false
+	}
+	return float64(usage.MilliValue()) / float64(capacity.MilliValue()), true
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-contrib/cache/persistence"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func newMockHealthService(mockCtl *gomock.Controller) (*HealthServiceImpl, *mockPlugin.MockHealthThreshold, *ms.MockNodeService) {
+	mThreshold := mockPlugin.NewMockHealthThreshold(mockCtl)
+	mNode := ms.NewMockNodeService(mockCtl)
+	return &HealthServiceImpl{
+		Threshold: mThreshold,
+		Node:      mNode,
+		cache:     persistence.NewInMemoryStore(time.Minute),
+		expire:    time.Minute,
+		client:    &http.Client{Timeout: time.Second},
+		levels:    map[string]string{},
+		log:       log.L(),
+	}, mThreshold, mNode
+}
+
+func TestHealthEvaluate(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	hs, _, _ := newMockHealthService(mockCtl)
+	threshold := &models.HealthThreshold{WarningScore: 80, CriticalScore: 50}
+
+	node := &specV1.Node{Name: "node01"}
+	health := hs.Evaluate(node, threshold)
+	assert.Equal(t, models.HealthLevelUnknown, health.Level)
+	assert.Equal(t, 0, health.Score)
+
+	node.Report = specV1.Report{
+		"time": time.Now().UTC().Format(time.RFC3339Nano),
+		common.NodeStats: map[string]interface{}{
+			"master": map[string]interface{}{
+				"usage":    map[string]string{"cpu": "1", "memory": "512Mi"},
+				"capacity": map[string]string{"cpu": "2", "memory": "1024Mi"},
+			},
+		},
+	}
+	health = hs.Evaluate(node, threshold)
+	assert.Equal(t, &models.NodeHealth{Score: 100, Level: models.HealthLevelHealthy}, health)
+
+	node.Report[common.NodeStats] = specV1.NodeStats{
+		Usage:    map[string]string{"cpu": "1900m", "memory": "850Mi"},
+		Capacity: map[string]string{"cpu": "2", "memory": "1000Mi"},
+	}
+	health = hs.Evaluate(node, threshold)
+	assert.Equal(t, 70, health.Score)
+	assert.Equal(t, models.HealthLevelWarning, health.Level)
+	assert.Equal(t, []string{"cpu usage is 95%", "memory usage is 85%"}, health.Reasons)
+
+	node.Report["time"] = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339Nano)
+	node.Report["appstats"] = []specV1.AppStats{
+		{AppInfo: specV1.AppInfo{Name: "app01"}, InstanceStats: map[string]specV1.InstanceStats{"i1": {Status: "Running"}}},
+		{AppInfo: specV1.AppInfo{Name: "app02"}, InstanceStats: map[string]specV1.InstanceStats{"i2": {Status: "Pending"}}},
+	}
+	health = hs.Evaluate(node, threshold)
+	assert.Equal(t, 10, health.Score)
+	assert.Equal(t, models.HealthLevelCritical, health.Level)
+	assert.Contains(t, health.Reasons, "app app02 is Pending")
+	assert.Len(t, health.Reasons, 4)
+}
+
+func TestHealthThresholdService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	hs, mThreshold, _ := newMockHealthService(mockCtl)
+
+	mThreshold.EXPECT().GetHealthThreshold("default").Return(nil, common.Error(common.ErrResourceNotFound)).Times(1)
+	res, err := hs.GetThreshold("default")
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultHealthWarningScore, res.WarningScore)
+	assert.Equal(t, models.DefaultHealthCriticalScore, res.CriticalScore)
+	// cached
+	res, err = hs.GetThreshold("default")
+	assert.NoError(t, err)
+	assert.Equal(t, "default", res.Namespace)
+
+	threshold := &models.HealthThreshold{Namespace: "default", WarningScore: 70, CriticalScore: 30, Webhook: "http://example.com"}
+	mThreshold.EXPECT().GetHealthThreshold("default").Return(nil, common.Error(common.ErrResourceNotFound))
+	mThreshold.EXPECT().CreateHealthThreshold(threshold).Return(nil)
+	mThreshold.EXPECT().GetHealthThreshold("default").Return(threshold, nil).Times(2)
+	res, err = hs.SetThreshold(threshold)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold, res)
+	res, err = hs.GetThreshold("default")
+	assert.NoError(t, err)
+	assert.Equal(t, 70, res.WarningScore)
+
+	mThreshold.EXPECT().GetHealthThreshold("default").Return(threshold, nil)
+	mThreshold.EXPECT().UpdateHealthThreshold(threshold).Return(nil)
+	mThreshold.EXPECT().GetHealthThreshold("default").Return(threshold, nil)
+	_, err = hs.SetThreshold(threshold)
+	assert.NoError(t, err)
+
+	mThreshold.EXPECT().DeleteHealthThreshold("default").Return(nil)
+	assert.NoError(t, hs.DeleteThreshold("default"))
+}
+
+func TestHealthCheck(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	hs, mThreshold, mNode := newMockHealthService(mockCtl)
+
+	var alerts []models.NodeHealthAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert models.NodeHealthAlert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		alerts = append(alerts, alert)
+	}))
+	defer server.Close()
+
+	// no webhook
+	mThreshold.EXPECT().GetHealthThreshold("other").Return(nil, common.Error(common.ErrResourceNotFound))
+	assert.NoError(t, hs.Check("other", "node01"))
+
+	threshold := &models.HealthThreshold{Namespace: "default", WarningScore: 80, CriticalScore: 50, Webhook: server.URL}
+	mThreshold.EXPECT().GetHealthThreshold("default").Return(threshold, nil)
+	node := &specV1.Node{
+		Namespace: "default",
+		Name:      "node01",
+		Report:    specV1.Report{"time": time.Now().UTC().Format(time.RFC3339Nano)},
+	}
+	mNode.EXPECT().Get(nil, "default", "node01").Return(node, nil).AnyTimes()
+	assert.NoError(t, hs.Check("default", "node01"))
+	assert.Len(t, alerts, 0)
+
+	node.Report[common.NodeStats] = specV1.NodeStats{
+		Usage:    map[string]string{"cpu": "2", "memory": "1000Mi"},
+		Capacity: map[string]string{"cpu": "2", "memory": "1000Mi"},
+	}
+	assert.NoError(t, hs.Check("default", "node01"))
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, models.HealthLevelWarning, alerts[0].Level)
+	assert.Equal(t, models.HealthLevelHealthy, alerts[0].PreviousLevel)
+	assert.Equal(t, 60, alerts[0].Score)
+
+	// unchanged
+	assert.NoError(t, hs.Check("default", "node01"))
+	assert.Len(t, alerts, 1)
+
+	delete(node.Report, common.NodeStats)
+	assert.NoError(t, hs.Check("default", "node01"))
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, models.HealthLevelHealthy, alerts[1].Level)
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/gin-contrib/cache/persistence"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/heartbeat.go -package=service github.com/baetyl/baetyl-cloud/v2/service HeartbeatService
+
+const heartbeatWebhookTimeout = 5 * time.Second
+
+type HeartbeatService interface {
+	GetPolicy(namespace string) (*models.HeartbeatPolicy, error)
+	SetPolicy(policy *models.HeartbeatPolicy) (*models.HeartbeatPolicy, error)
+	DeletePolicy(namespace string) error
+	// OfflineTimeout returns the timeout added to the report frequency before the node of namespace is offline
+	OfflineTimeout(namespace string) time.Duration
+	Beat(namespace, name string) error
+	ListEvent(namespace string, filter *models.NodeEventFilter) (*models.NodeEventList, error)
+}
+
+type HeartbeatServiceImpl struct {
+	Heartbeat plugin.Heartbeat
+	Node      NodeService
+	cache     persistence.CacheStore
+	expire    time.Duration
+	client    *http.Client
+	states    map[string]*heartbeatState
+	mutex     sync.Mutex
+	log       *log.Logger
+}
+
+// heartbeatState the online state of node known by this instance, the timer fires when the node stops reporting
+type heartbeatState struct {
+	online   bool
+	interval time.Duration
+	timer    *time.Timer
+}
+
+func NewHeartbeatService(config *config.CloudConfig) (HeartbeatService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.Heartbeat)
+	if err != nil {
+		return nil, err
+	}
+	node, err := NewNodeService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &HeartbeatServiceImpl{
+		Heartbeat: p.(plugin.Heartbeat),
+		Node:      node,
+		cache:     persistence.NewInMemoryStore(config.Cache.ExpirationDuration),
+		expire:    config.Cache.ExpirationDuration,
+		client:    &http.Client{Timeout: heartbeatWebhookTimeout},
+		states:    map[string]*heartbeatState{},
+		log:       log.With(log.Any("service", "heartbeat")),
+	}, nil
+}
+
+// GetPolicy returns the policy of namespace, the default one is returned if it is not set
+func (s *HeartbeatServiceImpl) GetPolicy(namespace string) (*models.HeartbeatPolicy, error) {
+	var policy models.HeartbeatPolicy
+	if err := s.cache.Get(namespace, &policy); err == nil {
+		return &policy, nil
+	}
+	res, err := s.Heartbeat.GetHeartbeatPolicy(namespace)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+		res = &models.HeartbeatPolicy{
+			Namespace:      namespace,
+			OfflineTimeout: models.DefaultOfflineTimeout,
+		}
+	}
+	s.cache.Set(namespace, *res, s.expire)
+	return res, nil
+}
+
+func (s *HeartbeatServiceImpl) SetPolicy(policy *models.HeartbeatPolicy) (*models.HeartbeatPolicy, error) {
+	_, err := s.Heartbeat.GetHeartbeatPolicy(policy.Namespace)
+	if err == nil {
+		err = s.Heartbeat.UpdateHeartbeatPolicy(policy)
+	} else if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+		err = s.Heartbeat.CreateHeartbeatPolicy(policy)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Delete(policy.Namespace)
+	return s.Heartbeat.GetHeartbeatPolicy(policy.Namespace)
+}
+
+func (s *HeartbeatServiceImpl) DeletePolicy(namespace string) error {
+	if err := s.Heartbeat.DeleteHeartbeatPolicy(namespace); err != nil {
+		return err
+	}
+	s.cache.Delete(namespace)
+	return nil
+}
+
+// OfflineTimeout falls back to the default timeout if the policy fails to load, to keep the node views available
+func (s *HeartbeatServiceImpl) OfflineTimeout(namespace string) time.Duration {
+	policy, err := s.GetPolicy(namespace)
+	if err != nil {
+		s.log.Warn("failed to get heartbeat policy", log.Any("namespace", namespace), log.Error(err))
+		return models.DefaultOfflineTimeout * time.Second
+	}
+	return time.Duration(policy.OfflineTimeout) * time.Second
+}
+
+// Beat is called after the node reports, the offline timer of node is reset and
+// the online event is emitted if the node is offline or its last event is offline
+func (s *HeartbeatServiceImpl) Beat(namespace, name string) error {
+	key := namespace + "/" + name
+	offlineTimeout := s.OfflineTimeout(namespace)
+	s.mutex.Lock()
+	if state, ok := s.states[key]; ok && state.online {
+		state.timer.Reset(state.interval + offlineTimeout)
+		s.mutex.Unlock()
+		return nil
+	}
+	_, known := s.states[key]
+	s.mutex.Unlock()
+
+	node, err := s.Node.Get(nil, namespace, name)
+	if err != nil {
+		return err
+	}
+	emit := known
+	if !known {
+		// the state is lost after the restart of instance, the last event tells whether the node was offline
+		last, err := s.Heartbeat.GetLatestNodeEvent(namespace, name)
+		if err != nil {
+			return err
+		}
+		emit = last != nil && last.Type == models.NodeEventOffline
+	}
+
+	interval := nodeReportInterval(node)
+	timeout := interval + offlineTimeout
+	s.mutex.Lock()
+	state, ok := s.states[key]
+	if !ok {
+		state = &heartbeatState{timer: time.AfterFunc(timeout, func() { s.checkOffline(namespace, name) })}
+		s.states[key] = state
+	} else if state.online {
+		// set online by the concurrent report
+		emit = false
+	}
+	state.online, state.interval = true, interval
+	state.timer.Reset(timeout)
+	s.mutex.Unlock()
+
+	if !emit {
+		return nil
+	}
+	var reportTime time.Time
+	common.DecodeReport(node.Report, "time", &reportTime)
+	return s.emit(&models.NodeEvent{
+		Namespace:  namespace,
+		Node:       name,
+		Type:       models.NodeEventOnline,
+		Time:       time.Now().UTC(),
+		ReportTime: reportTime.UTC(),
+	})
+}
+
+func (s *HeartbeatServiceImpl) ListEvent(namespace string, filter *models.NodeEventFilter) (*models.NodeEventList, error) {
+	events, err := s.Heartbeat.ListNodeEvent(namespace, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.Heartbeat.CountNodeEvent(namespace, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &models.NodeEventList{
+		Total: total,
+		Items: events,
+	}, nil
+}
+
+// checkOffline is called when the offline timer of node fires, the report time is checked again
+// since the node may report to the other instances, the offline event is emitted only once among them
+func (s *HeartbeatServiceImpl) checkOffline(namespace, name string) {
+	key := namespace + "/" + name
+	node, err := s.Node.Get(nil, namespace, name)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			s.mutex.Lock()
+			delete(s.states, key)
+			s.mutex.Unlock()
+			return
+		}
+		s.log.Warn("failed to get node to check heartbeat", log.Any("namespace", namespace), log.Any("name", name), log.Error(err))
+		// retry after the report interval
+		s.resetTimer(key, 0)
+		return
+	}
+	var reportTime time.Time
+	common.DecodeReport(node.Report, "time", &reportTime)
+	timeout := nodeReportInterval(node) + s.OfflineTimeout(namespace)
+	if remain := timeout - time.Since(reportTime); remain > 0 {
+		s.resetTimer(key, remain)
+		return
+	}
+
+	s.mutex.Lock()
+	state, ok := s.states[key]
+	if !ok || !state.online {
+		s.mutex.Unlock()
+		return
+	}
+	state.online = false
+	s.mutex.Unlock()
+
+	last, err := s.Heartbeat.GetLatestNodeEvent(namespace, name)
+	if err != nil {
+		s.log.Warn("failed to get latest node event", log.Any("namespace", namespace), log.Any("name", name), log.Error(err))
+		return
+	}
+	if last != nil && last.Type == models.NodeEventOffline {
+		return
+	}
+	err = s.emit(&models.NodeEvent{
+		Namespace:  namespace,
+		Node:       name,
+		Type:       models.NodeEventOffline,
+		Time:       time.Now().UTC(),
+		ReportTime: reportTime.UTC(),
+	})
+	if err != nil {
+		s.log.Warn("failed to emit node offline event", log.Any("namespace", namespace), log.Any("name", name), log.Error(err))
+	}
+}
+
+// resetTimer resets the offline timer of node, the report interval of node is used if d is not positive
+func (s *HeartbeatServiceImpl) resetTimer(key string, d time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	state, ok := s.states[key]
+	if !ok {
+		return
+	}
+	if d <= 0 {
+		d = state.interval
+	}
+	state.timer.Reset(d)
+}
+
+// emit stores the event and posts it to the webhook of namespace
+func (s *HeartbeatServiceImpl) emit(event *models.NodeEvent) error {
+	if err := s.Heartbeat.CreateNodeEvent(event); err != nil {
+		return err
+	}
+	policy, err := s.GetPolicy(event.Namespace)
+	if err != nil {
+		return err
+	}
+	if policy.Webhook == "" {
+		return nil
+	}
+	if err = postWebhook(s.client, policy.Webhook, event); err != nil {
+		return err
+	}
+	s.log.Debug("node event posted", log.Any("namespace", event.Namespace), log.Any("node", event.Node), log.Any("type", event.Type))
+	return nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/gin-contrib/cache/persistence"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func newMockHeartbeatService(mockCtl *gomock.Controller) (*HeartbeatServiceImpl, *mockPlugin.MockHeartbeat, *ms.MockNodeService) {
+	mHeartbeat := mockPlugin.NewMockHeartbeat(mockCtl)
+	mNode := ms.NewMockNodeService(mockCtl)
+	return &HeartbeatServiceImpl{
+		Heartbeat: mHeartbeat,
+		Node:      mNode,
+		cache:     persistence.NewInMemoryStore(time.Minute),
+		expire:    time.Minute,
+		client:    &http.Client{Timeout: time.Second},
+		states:    map[string]*heartbeatState{},
+		log:       log.L(),
+	}, mHeartbeat, mNode
+}
+
+func stopHeartbeatTimers(s *HeartbeatServiceImpl) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, state := range s.states {
+		state.timer.Stop()
+	}
+}
+
+func TestHeartbeatPolicyService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	hs, mHeartbeat, _ := newMockHeartbeatService(mockCtl)
+
+	mHeartbeat.EXPECT().GetHeartbeatPolicy("default").Return(nil, common.Error(common.ErrResourceNotFound)).Times(1)
+	res, err := hs.GetPolicy("default")
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultOfflineTimeout, res.OfflineTimeout)
+	// cached
+	assert.Equal(t, models.DefaultOfflineTimeout*time.Second, hs.OfflineTimeout("default"))
+
+	policy := &models.HeartbeatPolicy{Namespace: "default", OfflineTimeout: 600}
+	mHeartbeat.EXPECT().GetHeartbeatPolicy("default").Return(nil, common.Error(common.ErrResourceNotFound))
+	mHeartbeat.EXPECT().CreateHeartbeatPolicy(policy).Return(nil)
+	mHeartbeat.EXPECT().GetHeartbeatPolicy("default").Return(policy, nil).Times(2)
+	res, err = hs.SetPolicy(policy)
+	assert.NoError(t, err)
+	assert.Equal(t, policy, res)
+	assert.Equal(t, 10*time.Minute, hs.OfflineTimeout("default"))
+
+	mHeartbeat.EXPECT().GetHeartbeatPolicy("default").Return(policy, nil)
+	mHeartbeat.EXPECT().UpdateHeartbeatPolicy(policy).Return(nil)
+	mHeartbeat.EXPECT().GetHeartbeatPolicy("default").Return(policy, nil)
+	_, err = hs.SetPolicy(policy)
+	assert.NoError(t, err)
+
+	mHeartbeat.EXPECT().DeleteHeartbeatPolicy("default").Return(nil)
+	assert.NoError(t, hs.DeletePolicy("default"))
+
+	// the default timeout is used if the policy fails to load
+	mHeartbeat.EXPECT().GetHeartbeatPolicy("default").Return(nil, common.Error(common.ErrRequestParamInvalid))
+	assert.Equal(t, models.DefaultOfflineTimeout*time.Second, hs.OfflineTimeout("default"))
+}
+
+func TestHeartbeatBeat(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	hs, mHeartbeat, mNode := newMockHeartbeatService(mockCtl)
+	defer stopHeartbeatTimers(hs)
+
+	var posted []models.NodeEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event models.NodeEvent
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		posted = append(posted, event)
+	}))
+	defer server.Close()
+	policy := &models.HeartbeatPolicy{Namespace: "default", OfflineTimeout: 300, Webhook: server.URL}
+	mHeartbeat.EXPECT().GetHeartbeatPolicy("default").Return(policy, nil).Times(1)
+
+	node := &specV1.Node{
+		Namespace:  "default",
+		Name:       "node01",
+		Attributes: map[string]interface{}{specV1.BaetylCoreFrequency: "20"},
+		Report:     specV1.Report{"time": time.Now().UTC().Format(time.RFC3339Nano)},
+	}
+	var events []*models.NodeEvent
+	mHeartbeat.EXPECT().CreateNodeEvent(gomock.Any()).DoAndReturn(func(event *models.NodeEvent) error {
+		events = append(events, event)
+		return nil
+	}).AnyTimes()
+
+	// the first report of node which has no event
+	mNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	mHeartbeat.EXPECT().GetLatestNodeEvent("default", "node01").Return(nil, nil)
+	assert.NoError(t, hs.Beat("default", "node01"))
+	assert.True(t, hs.states["default/node01"].online)
+	assert.Equal(t, 20*time.Second, hs.states["default/node01"].interval)
+	assert.Len(t, events, 0)
+
+	// online already
+	assert.NoError(t, hs.Beat("default", "node01"))
+
+	// the report is fresh, maybe received by the other instance
+	mNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	hs.checkOffline("default", "node01")
+	assert.True(t, hs.states["default/node01"].online)
+	assert.Len(t, events, 0)
+
+	// offline
+	stale := &specV1.Node{
+		Namespace:  "default",
+		Name:       "node01",
+		Attributes: node.Attributes,
+		Report:     specV1.Report{"time": time.Now().Add(-time.Hour).UTC().Format(time.RFC3339Nano)},
+	}
+	mNode.EXPECT().Get(nil, "default", "node01").Return(stale, nil)
+	mHeartbeat.EXPECT().GetLatestNodeEvent("default", "node01").Return(nil, nil)
+	hs.checkOffline("default", "node01")
+	assert.False(t, hs.states["default/node01"].online)
+	assert.Len(t, events, 1)
+	assert.Equal(t, models.NodeEventOffline, events[0].Type)
+	assert.Len(t, posted, 1)
+	assert.Equal(t, models.NodeEventOffline, posted[0].Type)
+	assert.Equal(t, "node01", posted[0].Node)
+
+	// fired again
+	mNode.EXPECT().Get(nil, "default", "node01").Return(stale, nil)
+	hs.checkOffline("default", "node01")
+	assert.Len(t, events, 1)
+
+	// back online
+	mNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	assert.NoError(t, hs.Beat("default", "node01"))
+	assert.True(t, hs.states["default/node01"].online)
+	assert.Len(t, events, 2)
+	assert.Equal(t, models.NodeEventOnline, events[1].Type)
+	assert.Len(t, posted, 2)
+
+	// the offline event has been emitted by the other instance
+	mNode.EXPECT().Get(nil, "default", "node01").Return(stale, nil)
+	mHeartbeat.EXPECT().GetLatestNodeEvent("default", "node01").Return(&models.NodeEvent{Type: models.NodeEventOffline}, nil)
+	hs.checkOffline("default", "node01")
+	assert.False(t, hs.states["default/node01"].online)
+	assert.Len(t, events, 2)
+
+	// deleted
+	mNode.EXPECT().Get(nil, "default", "node01").Return(nil, common.Error(common.ErrResourceNotFound))
+	hs.checkOffline("default", "node01")
+	assert.NotContains(t, hs.states, "default/node01")
+
+	// the state is lost after restart and the last event is offline
+	mNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	mHeartbeat.EXPECT().GetLatestNodeEvent("default", "node01").Return(&models.NodeEvent{Type: models.NodeEventOffline}, nil)
+	assert.NoError(t, hs.Beat("default", "node01"))
+	assert.Len(t, events, 3)
+	assert.Equal(t, models.NodeEventOnline, events[2].Type)
+}
+
+func TestHeartbeatListEvent(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	hs, mHeartbeat, _ := newMockHeartbeatService(mockCtl)
+
+	filter := &models.NodeEventFilter{Node: "node01"}
+	events := []models.NodeEvent{{Namespace: "default", Node: "node01", Type: models.NodeEventOnline}}
+	mHeartbeat.EXPECT().ListNodeEvent("default", filter).Return(events, nil)
+	mHeartbeat.EXPECT().CountNodeEvent("default", filter).Return(1, nil)
+	res, err := hs.ListEvent("default", filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Total)
+	assert.Equal(t, events, res.Items)
+}
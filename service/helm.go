@@ -0,0 +1,123 @@
+package service
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/helm.go -package=service github.com/baetyl/baetyl-cloud/v2/service HelmService
+
+type HelmService interface {
+	Get(namespace, name string) (*models.HelmRelease, error)
+	List(namespace string) ([]models.HelmRelease, error)
+	Create(release *models.HelmRelease) (*models.HelmRelease, error)
+	Update(release *models.HelmRelease) (*models.HelmRelease, error)
+	Delete(namespace, name string) error
+	// Fetch downloads the packaged chart from the http(s) url
+	Fetch(address string) ([]byte, error)
+	// Render renders the chart archive of release with its values into kube manifests,
+	// the chart name and versions of release are taken from the chart
+	Render(release *models.HelmRelease) ([]byte, error)
+}
+
+type HelmServiceImpl struct {
+	HelmRelease  plugin.HelmRelease
+	maxChartSize int64
+	client       *http.Client
+}
+
+// NewHelmService NewHelmService
+func NewHelmService(config *config.CloudConfig) (HelmService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.HelmRelease)
+	if err != nil {
+		return nil, err
+	}
+	return &HelmServiceImpl{
+		HelmRelease:  p.(plugin.HelmRelease),
+		maxChartSize: config.Helm.MaxChartSize,
+		client:       &http.Client{Timeout: config.Helm.FetchTimeout},
+	}, nil
+}
+
+func (s *HelmServiceImpl) Get(namespace, name string) (*models.HelmRelease, error) {
+	return s.HelmRelease.GetHelmRelease(namespace, name)
+}
+
+func (s *HelmServiceImpl) List(namespace string) ([]models.HelmRelease, error) {
+	return s.HelmRelease.ListHelmRelease(namespace)
+}
+
+func (s *HelmServiceImpl) Create(release *models.HelmRelease) (*models.HelmRelease, error) {
+	if err := s.HelmRelease.CreateHelmRelease(release); err != nil {
+		return nil, err
+	}
+	return s.HelmRelease.GetHelmRelease(release.Namespace, release.Name)
+}
+
+func (s *HelmServiceImpl) Update(release *models.HelmRelease) (*models.HelmRelease, error) {
+	if err := s.HelmRelease.UpdateHelmRelease(release); err != nil {
+		return nil, err
+	}
+	return s.HelmRelease.GetHelmRelease(release.Namespace, release.Name)
+}
+
+func (s *HelmServiceImpl) Delete(namespace, name string) error {
+	return s.HelmRelease.DeleteHelmRelease(namespace, name)
+}
+
+func (s *HelmServiceImpl) Fetch(address string) ([]byte, error) {
+	u, err := url.Parse(address)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the url of chart should be http or https"))
+	}
+	resp, err := s.client.Get(address)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, common.Error(common.ErrRequestParamInvalid,
+			common.Field("error", "failed to download chart from "+address+", status "+resp.Status))
+	}
+	var body io.Reader = resp.Body
+	if s.maxChartSize > 0 {
+		// one more byte is read to tell whether the chart is too large
+		body = io.LimitReader(resp.Body, s.maxChartSize+1)
+	}
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = s.checkChartSize(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *HelmServiceImpl) Render(release *models.HelmRelease) ([]byte, error) {
+	if err := s.checkChartSize(release.Archive); err != nil {
+		return nil, err
+	}
+	chart, err := LoadHelmChart(release.Archive)
+	if err != nil {
+		return nil, err
+	}
+	release.Chart, release.ChartVersion, release.AppVersion = chart.Name, chart.Version, chart.AppVersion
+	return RenderHelmChart(chart, release.Name, release.Namespace, release.Revision, release.Values)
+}
+
+func (s *HelmServiceImpl) checkChartSize(archive []byte) error {
+	if s.maxChartSize > 0 && int64(len(archive)) > s.maxChartSize {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the chart is too large"))
+	}
+	return nil
+}
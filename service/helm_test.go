@@ -0,0 +1,79 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewHelmService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.HelmRelease = common.RandString(9)
+	_, err := NewHelmService(conf)
+	assert.Error(t, err)
+}
+
+func TestHelmService_CreateUpdate(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mRelease := mockPlugin.NewMockHelmRelease(mockCtl)
+	hs := &HelmServiceImpl{HelmRelease: mRelease}
+
+	release := &models.HelmRelease{Namespace: "default", Name: "web", Revision: 1}
+	mRelease.EXPECT().CreateHelmRelease(release).Return(nil)
+	mRelease.EXPECT().GetHelmRelease("default", "web").Return(release, nil).Times(2)
+	res, err := hs.Create(release)
+	assert.NoError(t, err)
+	assert.Equal(t, "web", res.Name)
+
+	mRelease.EXPECT().UpdateHelmRelease(release).Return(nil)
+	_, err = hs.Update(release)
+	assert.NoError(t, err)
+
+	mRelease.EXPECT().UpdateHelmRelease(release).Return(common.Error(common.ErrResourceNotFound))
+	_, err = hs.Update(release)
+	assert.Error(t, err)
+}
+
+func TestHelmService_FetchAndRender(t *testing.T) {
+	archive := packHelmChart(t, testHelmChartFiles())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/nginx-0.1.0.tgz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(archive)
+	}))
+	defer server.Close()
+	hs := &HelmServiceImpl{maxChartSize: int64(len(archive)), client: server.Client()}
+
+	data, err := hs.Fetch(server.URL + "/nginx-0.1.0.tgz")
+	assert.NoError(t, err)
+	assert.Equal(t, archive, data)
+	_, err = hs.Fetch(server.URL + "/redis.tgz")
+	assert.Error(t, err)
+	_, err = hs.Fetch("file:///etc/passwd")
+	assert.Error(t, err)
+
+	release := &models.HelmRelease{Namespace: "default", Name: "web", Revision: 1, Archive: data}
+	out, err := hs.Render(release)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "name: web-nginx")
+	assert.Equal(t, "nginx", release.Chart)
+	assert.Equal(t, "0.1.0", release.ChartVersion)
+
+	// the chart is too large
+	hs.maxChartSize = int64(len(archive)) - 1
+	_, err = hs.Fetch(server.URL + "/nginx-0.1.0.tgz")
+	assert.Error(t, err)
+	_, err = hs.Render(release)
+	assert.Error(t, err)
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+// HelmChart the chart loaded from the packaged archive
+type HelmChart struct {
+	Name       string
+	Version    string
+	AppVersion string
+	Values     map[string]interface{}
+	// Templates the files of templates directory, keyed by the path in chart such as nginx/templates/deploy.yaml
+	Templates map[string]string
+}
+
+type helmChartMetadata struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	AppVersion string `yaml:"appVersion"`
+}
+
+// LoadHelmChart loads the chart from the packaged archive (.tgz), the sub charts are not supported
+func LoadHelmChart(archive []byte) (*HelmChart, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, helmChartError("the chart is not a gzip archive")
+	}
+	defer gr.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, helmChartError(err.Error())
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		// the files of chart are placed in a directory named after chart
+		parts := strings.SplitN(path.Clean(header.Name), "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, helmChartError(err.Error())
+		}
+		files[parts[1]] = data
+	}
+
+	data, ok := files["Chart.yaml"]
+	if !ok {
+		return nil, helmChartError("Chart.yaml is not found in the chart")
+	}
+	var meta helmChartMetadata
+	if err = yaml.Unmarshal(data, &meta); err != nil {
+		return nil, helmChartError(err.Error())
+	}
+	if meta.Name == "" {
+		return nil, helmChartError("the name of chart is required in Chart.yaml")
+	}
+	chart := &HelmChart{
+		Name:       meta.Name,
+		Version:    meta.Version,
+		AppVersion: meta.AppVersion,
+		Templates:  map[string]string{},
+	}
+	if chart.Values, err = ParseHelmValues(files["values.yaml"]); err != nil {
+		return nil, err
+	}
+	for name, data := range files {
+		if strings.HasPrefix(name, "charts/") {
+			return nil, helmChartError("the sub charts are not supported")
+		}
+		if strings.HasPrefix(name, "templates/") {
+			chart.Templates[path.Join(meta.Name, name)] = string(data)
+		}
+	}
+	return chart, nil
+}
+
+// ParseHelmValues parses the values in yaml or json
+func ParseHelmValues(data []byte) (map[string]interface{}, error) {
+	var values interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, helmChartError(fmt.Sprintf("failed to parse values: %s", err.Error()))
+	}
+	if values == nil {
+		return map[string]interface{}{}, nil
+	}
+	res, ok := toStringKeys(values).(map[string]interface{})
+	if !ok {
+		return nil, helmChartError("the values should be a map")
+	}
+	return res, nil
+}
+
+// MergeHelmValues merges the values over the defaults of chart, the maps are merged deeply
+func MergeHelmValues(defaults, values map[string]interface{}) map[string]interface{} {
+	res := map[string]interface{}{}
+	for k, v := range defaults {
+		res[k] = v
+	}
+	for k, v := range values {
+		dst, ok1 := res[k].(map[string]interface{})
+		src, ok2 := v.(map[string]interface{})
+		if ok1 && ok2 {
+			res[k] = MergeHelmValues(dst, src)
+			continue
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// RenderHelmChart renders the templates of chart as helm does and returns the manifests separated by '---',
+// the templates are given the built-in objects Values, Release, Chart and Template,
+// the files whose names start with '_' only define the templates to include
+func RenderHelmChart(chart *HelmChart, release, namespace string, revision int, values map[string]interface{}) ([]byte, error) {
+	var t *template.Template
+	funcs := helmFuncMap()
+	funcs["include"] = func(name string, data interface{}) (string, error) {
+		var buf bytes.Buffer
+		if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	funcs["tpl"] = func(text string, data interface{}) (string, error) {
+		tt, err := t.Clone()
+		if err != nil {
+			return "", err
+		}
+		if tt, err = tt.New("tpl").Parse(text); err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err = tt.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	t = template.New(chart.Name).Funcs(funcs).Option("missingkey=zero")
+
+	names := make([]string, 0, len(chart.Templates))
+	for name := range chart.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := t.New(name).Parse(chart.Templates[name]); err != nil {
+			return nil, helmChartError(err.Error())
+		}
+	}
+
+	data := map[string]interface{}{
+		"Values": MergeHelmValues(chart.Values, values),
+		"Release": map[string]interface{}{
+			"Name":      release,
+			"Namespace": namespace,
+			"Revision":  revision,
+			"IsInstall": revision <= 1,
+			"IsUpgrade": revision > 1,
+			"Service":   "Helm",
+		},
+		"Chart": map[string]interface{}{
+			"Name":       chart.Name,
+			"Version":    chart.Version,
+			"AppVersion": chart.AppVersion,
+		},
+	}
+	var manifests []string
+	for _, name := range names {
+		ext := path.Ext(name)
+		if strings.HasPrefix(path.Base(name), "_") || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data["Template"] = map[string]interface{}{"Name": name, "BasePath": path.Join(chart.Name, "templates")}
+		var buf bytes.Buffer
+		if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+			return nil, helmChartError(err.Error())
+		}
+		// the missing values are rendered as empty like helm
+		out := strings.TrimSpace(strings.ReplaceAll(buf.String(), "<no value>", ""))
+		if out != "" {
+			manifests = append(manifests, out)
+		}
+	}
+	return []byte(strings.Join(manifests, "\n---\n")), nil
+}
+
+// helmFuncMap the commonly used functions of helm templates, which are a subset of sprig
+func helmFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"default": func(d interface{}, given ...interface{}) interface{} {
+			if len(given) == 0 || isEmptyValue(given[0]) {
+				return d
+			}
+			return given[0]
+		},
+		"empty": isEmptyValue,
+		"required": func(msg string, v interface{}) (interface{}, error) {
+			if isEmptyValue(v) {
+				return nil, fmt.Errorf("%s", msg)
+			}
+			return v, nil
+		},
+		"ternary": func(a, b interface{}, cond bool) interface{} {
+			if cond {
+				return a
+			}
+			return b
+		},
+		"quote": func(v interface{}) string {
+			return fmt.Sprintf("%q", toString(v))
+		},
+		"squote": func(v interface{}) string {
+			return "'" + toString(v) + "'"
+		},
+		"toString": toString,
+		"indent": func(n int, s string) string {
+			pad := strings.Repeat(" ", n)
+			return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+		},
+		"nindent": func(n int, s string) string {
+			pad := strings.Repeat(" ", n)
+			return "\n" + pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+		},
+		"trim":       strings.TrimSpace,
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trunc": func(n int, s string) string {
+			if n >= 0 && len(s) > n {
+				return s[:n]
+			}
+			return s
+		},
+		"lower":     strings.ToLower,
+		"upper":     strings.ToUpper,
+		"replace":   func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":  func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix": func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix": func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"join": func(sep string, v interface{}) string {
+			var items []string
+			rv := reflect.ValueOf(v)
+			if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+				for i := 0; i < rv.Len(); i++ {
+					items = append(items, toString(rv.Index(i).Interface()))
+				}
+			}
+			return strings.Join(items, sep)
+		},
+		"list": func(v ...interface{}) []interface{} { return v },
+		"dict": func(v ...interface{}) map[string]interface{} {
+			res := map[string]interface{}{}
+			for i := 0; i+1 < len(v); i += 2 {
+				res[toString(v[i])] = v[i+1]
+			}
+			return res
+		},
+		"b64enc": func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+		"b64dec": func(s string) (string, error) {
+			data, err := base64.StdEncoding.DecodeString(s)
+			return string(data), err
+		},
+		"toYaml": func(v interface{}) (string, error) {
+			data, err := yaml.Marshal(v)
+			return strings.TrimSuffix(string(data), "\n"), err
+		},
+		"toJson": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}
+}
+
+func isEmptyValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return rv.IsZero()
+	}
+}
+
+func toString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case []byte:
+		return string(s)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toStringKeys converts the maps decoded from yaml to the ones keyed by string, as json does
+func toStringKeys(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		res := map[string]interface{}{}
+		for k, item := range val {
+			res[fmt.Sprint(k)] = toStringKeys(item)
+		}
+		return res
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = toStringKeys(item)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = toStringKeys(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+func helmChartError(msg string) error {
+	return common.Error(common.ErrRequestParamInvalid, common.Field("error", "invalid helm chart: "+msg))
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func packHelmChart(t *testing.T, files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, data := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(data))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func testHelmChartFiles() map[string]string {
+	return map[string]string{
+		"nginx/Chart.yaml": "apiVersion: v2\nname: nginx\nversion: 0.1.0\nappVersion: \"1.21\"\n",
+		"nginx/values.yaml": `
+replicaCount: 1
+image:
+  repository: nginx
+  tag: ""
+config:
+  level: info
+`,
+		"nginx/templates/_helpers.tpl": `{{- define "nginx.fullname" -}}
+{{ .Release.Name }}-{{ .Chart.Name | trunc 20 }}
+{{- end -}}`,
+		"nginx/templates/deploy.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "nginx.fullname" . }}
+spec:
+  replicas: {{ .Values.replicaCount }}
+  template:
+    spec:
+      containers:
+      - name: nginx
+        image: "{{ .Values.image.repository }}:{{ .Values.image.tag | default .Chart.AppVersion }}"
+        env:
+        - name: MISSING
+          value: "{{ .Values.missing }}"
+`,
+		"nginx/templates/config.yaml": `{{- if .Values.config }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "nginx.fullname" . }}-conf
+data:
+  conf.yaml: |
+    {{- toYaml .Values.config | nindent 4 }}
+{{- end }}`,
+		"nginx/templates/NOTES.txt": "{{ .Release.Name }} is installed",
+	}
+}
+
+func TestLoadAndRenderHelmChart(t *testing.T) {
+	chart, err := LoadHelmChart(packHelmChart(t, testHelmChartFiles()))
+	assert.NoError(t, err)
+	assert.Equal(t, "nginx", chart.Name)
+	assert.Equal(t, "0.1.0", chart.Version)
+	assert.Equal(t, "1.21", chart.AppVersion)
+	assert.Len(t, chart.Templates, 4)
+	assert.Equal(t, map[string]interface{}{"repository": "nginx", "tag": ""}, chart.Values["image"])
+
+	values, err := ParseHelmValues([]byte(`{"replicaCount": 3, "config": {"port": 80}}`))
+	assert.NoError(t, err)
+	out, err := RenderHelmChart(chart, "web", "default", 1, values)
+	assert.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-nginx-conf
+data:
+  conf.yaml: |
+    level: info
+    port: 80
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web-nginx
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: nginx
+        image: "nginx:1.21"
+        env:
+        - name: MISSING
+          value: ""`, string(out))
+
+	// the template failed to execute
+	chart.Templates["nginx/templates/secret.yaml"] = `{{ required "password is required" .Values.password }}`
+	_, err = RenderHelmChart(chart, "web", "default", 1, values)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "password is required")
+}
+
+func TestLoadHelmChartInvalid(t *testing.T) {
+	_, err := LoadHelmChart([]byte("chart"))
+	assert.Error(t, err)
+
+	files := testHelmChartFiles()
+	delete(files, "nginx/Chart.yaml")
+	_, err = LoadHelmChart(packHelmChart(t, files))
+	assert.Error(t, err)
+
+	files = testHelmChartFiles()
+	files["nginx/charts/redis/Chart.yaml"] = "name: redis"
+	_, err = LoadHelmChart(packHelmChart(t, files))
+	assert.Error(t, err)
+
+	files = testHelmChartFiles()
+	files["nginx/values.yaml"] = "- a"
+	_, err = LoadHelmChart(packHelmChart(t, files))
+	assert.Error(t, err)
+}
+
+func TestMergeHelmValues(t *testing.T) {
+	defaults := map[string]interface{}{
+		"image":   map[string]interface{}{"repository": "nginx", "tag": "1.21"},
+		"replica": 1,
+	}
+	values := map[string]interface{}{
+		"image": map[string]interface{}{"tag": "1.22"},
+		"port":  80,
+	}
+	assert.Equal(t, map[string]interface{}{
+		"image":   map[string]interface{}{"repository": "nginx", "tag": "1.22"},
+		"replica": 1,
+		"port":    80,
+	}, MergeHelmValues(defaults, values))
+	// the defaults are not modified
+	assert.Equal(t, "1.21", defaults["image"].(map[string]interface{})["tag"])
+}
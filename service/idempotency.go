@@ -0,0 +1,73 @@
+package service
+
+import (
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/idempotency.go -package=service github.com/baetyl/baetyl-cloud/v2/service IdempotencyService
+
+type IdempotencyService interface {
+	// Acquire creates a pending record for the key, the existing record is returned if the key is used
+	Acquire(namespace, key, requestHash string) (*models.IdempotencyRecord, error)
+	// Complete saves the response of the pending record
+	Complete(record *models.IdempotencyRecord) error
+	// Release deletes the record, so that the request can be retried
+	Release(namespace, key string) error
+}
+
+type IdempotencyServiceImpl struct {
+	Idempotency plugin.Idempotency
+	TTL         time.Duration
+}
+
+// NewIdempotencyService returns nil if the plugin is not configured
+func NewIdempotencyService(config *config.CloudConfig) (IdempotencyService, error) {
+	if config.Plugin.Idempotent == "" {
+		return nil, nil
+	}
+	p, err := plugin.GetPlugin(config.Plugin.Idempotent)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &IdempotencyServiceImpl{
+		Idempotency: p.(plugin.Idempotency),
+		TTL:         config.Idempotency.TTL,
+	}, nil
+}
+
+func (s *IdempotencyServiceImpl) Acquire(namespace, key, requestHash string) (*models.IdempotencyRecord, error) {
+	if err := s.Idempotency.DeleteExpiredIdempotencyRecords(); err != nil {
+		log.L().Warn("failed to delete expired idempotency records", log.Error(err))
+	}
+	record := &models.IdempotencyRecord{
+		Namespace:   namespace,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpireTime:  time.Now().Add(s.TTL).UTC(),
+	}
+	err := s.Idempotency.CreateIdempotencyRecord(record)
+	if err == nil {
+		return nil, nil
+	}
+	if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceConflict {
+		return nil, errors.Trace(err)
+	}
+	return s.Idempotency.GetIdempotencyRecord(namespace, key)
+}
+
+func (s *IdempotencyServiceImpl) Complete(record *models.IdempotencyRecord) error {
+	record.ExpireTime = time.Now().Add(s.TTL).UTC()
+	return s.Idempotency.UpdateIdempotencyRecord(record)
+}
+
+func (s *IdempotencyServiceImpl) Release(namespace, key string) error {
+	return s.Idempotency.DeleteIdempotencyRecord(namespace, key)
+}
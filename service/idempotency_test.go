@@ -0,0 +1,69 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestIdempotencyService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	is, err := NewIdempotencyService(conf)
+	assert.NoError(t, err)
+	assert.Nil(t, is)
+
+	conf.Plugin.Idempotent = common.RandString(9)
+	conf.Idempotency.TTL = time.Hour
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mIdempotency := mockPlugin.NewMockIdempotency(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.Idempotent, func() (plugin.Plugin, error) {
+		return mIdempotency, nil
+	})
+	is, err = NewIdempotencyService(conf)
+	assert.NoError(t, err)
+
+	ns, key, hash := "default", "key01", "hash"
+	mIdempotency.EXPECT().DeleteExpiredIdempotencyRecords().Return(fmt.Errorf("error"))
+	mIdempotency.EXPECT().CreateIdempotencyRecord(gomock.Any()).DoAndReturn(func(r *models.IdempotencyRecord) error {
+		assert.Equal(t, ns, r.Namespace)
+		assert.Equal(t, key, r.Key)
+		assert.Equal(t, hash, r.RequestHash)
+		assert.True(t, r.IsPending())
+		assert.False(t, r.IsExpired())
+		return nil
+	})
+	record, err := is.Acquire(ns, key, hash)
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+
+	existed := &models.IdempotencyRecord{Namespace: ns, Key: key, RequestHash: hash, Status: 200}
+	mIdempotency.EXPECT().DeleteExpiredIdempotencyRecords().Return(nil)
+	mIdempotency.EXPECT().CreateIdempotencyRecord(gomock.Any()).Return(common.Error(common.ErrResourceConflict))
+	mIdempotency.EXPECT().GetIdempotencyRecord(ns, key).Return(existed, nil)
+	record, err = is.Acquire(ns, key, hash)
+	assert.NoError(t, err)
+	assert.Equal(t, existed, record)
+
+	mIdempotency.EXPECT().DeleteExpiredIdempotencyRecords().Return(nil)
+	mIdempotency.EXPECT().CreateIdempotencyRecord(gomock.Any()).Return(fmt.Errorf("error"))
+	_, err = is.Acquire(ns, key, hash)
+	assert.Error(t, err)
+
+	mIdempotency.EXPECT().UpdateIdempotencyRecord(existed).Return(nil)
+	assert.NoError(t, is.Complete(existed))
+	assert.False(t, existed.IsExpired())
+
+	mIdempotency.EXPECT().DeleteIdempotencyRecord(ns, key).Return(nil)
+	assert.NoError(t, is.Release(ns, key))
+}
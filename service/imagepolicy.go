@@ -0,0 +1,134 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/imagepolicy.go -package=service github.com/baetyl/baetyl-cloud/v2/service ImagePolicyService
+
+// the registry of the images without registry host, such as nginx or baetyltech/baetyl
+const defaultImageRegistry = "docker.io"
+
+type ImagePolicyService interface {
+	Get(namespace string) (*models.ImagePolicy, error)
+	Set(policy *models.ImagePolicy) (*models.ImagePolicy, error)
+	Delete(namespace string) error
+}
+
+type ImagePolicyServiceImpl struct {
+	ImagePolicy plugin.ImagePolicy
+}
+
+// NewImagePolicyService NewImagePolicyService
+func NewImagePolicyService(config *config.CloudConfig) (ImagePolicyService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.ImagePolicy)
+	if err != nil {
+		return nil, err
+	}
+	return &ImagePolicyServiceImpl{ImagePolicy: p.(plugin.ImagePolicy)}, nil
+}
+
+// Get returns the policy of namespace, the empty one changing nothing is returned if it is not set
+func (s *ImagePolicyServiceImpl) Get(namespace string) (*models.ImagePolicy, error) {
+	res, err := s.ImagePolicy.GetImagePolicy(namespace)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+		res = &models.ImagePolicy{Namespace: namespace}
+	}
+	return res, nil
+}
+
+func (s *ImagePolicyServiceImpl) Set(policy *models.ImagePolicy) (*models.ImagePolicy, error) {
+	_, err := s.ImagePolicy.GetImagePolicy(policy.Namespace)
+	if err == nil {
+		err = s.ImagePolicy.UpdateImagePolicy(policy)
+	} else if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+		err = s.ImagePolicy.CreateImagePolicy(policy)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.ImagePolicy.GetImagePolicy(policy.Namespace)
+}
+
+func (s *ImagePolicyServiceImpl) Delete(namespace string) error {
+	return s.ImagePolicy.DeleteImagePolicy(namespace)
+}
+
+// ApplyImagePolicy rewrites the images of the services of app to the mirror of policy,
+// and marks app with the pull policy. The apps in native mode run programs instead of images, they are kept
+func ApplyImagePolicy(app *specV1.Application, policy *models.ImagePolicy) {
+	if policy == nil || app.Mode == context.RunModeNative {
+		return
+	}
+	for i := range app.InitServices {
+		app.InitServices[i].Image = MirrorImage(app.InitServices[i].Image, policy)
+	}
+	for i := range app.Services {
+		app.Services[i].Image = MirrorImage(app.Services[i].Image, policy)
+	}
+	if policy.PullPolicy != "" {
+		if app.Labels == nil {
+			app.Labels = map[string]string{}
+		}
+		app.Labels[common.LabelImagePullPolicy] = policy.PullPolicy
+	}
+}
+
+// MirrorImage returns the image rewritten to the mirror of policy, such as nginx:1.21 to harbor.local/library/nginx:1.21
+// and quay.io/prometheus/node-exporter to harbor.local/prometheus/node-exporter. The image is kept if its registry
+// is not listed by the policy or it is on the mirror already
+func MirrorImage(image string, policy *models.ImagePolicy) string {
+	mirror := strings.TrimSuffix(policy.Mirror, "/")
+	if mirror == "" || image == "" || strings.HasPrefix(image, mirror+"/") {
+		return image
+	}
+	registry, repository := splitImage(image)
+	if len(policy.Registries) > 0 {
+		listed := false
+		for _, r := range policy.Registries {
+			if normalizeRegistry(r) == registry {
+				listed = true
+				break
+			}
+		}
+		if !listed {
+			return image
+		}
+	}
+	return mirror + "/" + repository
+}
+
+// splitImage splits the image into its registry and repository with tag or digest, the official images of
+// docker hub are in library, the same as the way docker resolves them
+func splitImage(image string) (string, string) {
+	i := strings.IndexByte(image, '/')
+	if i < 0 {
+		return defaultImageRegistry, "library/" + image
+	}
+	host := image[:i]
+	// the first part is a registry only if it looks like a host, otherwise it is the user of docker hub
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return defaultImageRegistry, image
+	}
+	return normalizeRegistry(host), image[i+1:]
+}
+
+func normalizeRegistry(registry string) string {
+	registry = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://"), "/")
+	if registry == "index.docker.io" || registry == "registry-1.docker.io" {
+		return defaultImageRegistry
+	}
+	return registry
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewImagePolicyService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.ImagePolicy = common.RandString(9)
+	_, err := NewImagePolicyService(conf)
+	assert.Error(t, err)
+}
+
+func TestImagePolicyService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mPolicy := mockPlugin.NewMockImagePolicy(mockCtl)
+	is := &ImagePolicyServiceImpl{ImagePolicy: mPolicy}
+	notFound := common.Error(common.ErrResourceNotFound)
+
+	// the empty policy is returned if not set
+	mPolicy.EXPECT().GetImagePolicy("default").Return(nil, notFound)
+	res, err := is.Get("default")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.ImagePolicy{Namespace: "default"}, res)
+
+	mPolicy.EXPECT().GetImagePolicy("default").Return(nil, fmt.Errorf("error"))
+	_, err = is.Get("default")
+	assert.Error(t, err)
+
+	policy := &models.ImagePolicy{Namespace: "default", Mirror: "harbor.local"}
+	mPolicy.EXPECT().GetImagePolicy("default").Return(nil, notFound)
+	mPolicy.EXPECT().CreateImagePolicy(policy).Return(nil)
+	mPolicy.EXPECT().GetImagePolicy("default").Return(policy, nil)
+	res, err = is.Set(policy)
+	assert.NoError(t, err)
+	assert.Equal(t, policy, res)
+
+	mPolicy.EXPECT().GetImagePolicy("default").Return(policy, nil)
+	mPolicy.EXPECT().UpdateImagePolicy(policy).Return(fmt.Errorf("error"))
+	_, err = is.Set(policy)
+	assert.Error(t, err)
+
+	mPolicy.EXPECT().DeleteImagePolicy("default").Return(nil)
+	assert.NoError(t, is.Delete("default"))
+}
+
+func TestMirrorImage(t *testing.T) {
+	policy := &models.ImagePolicy{Mirror: "harbor.local/proxy/"}
+	cases := map[string]string{
+		"":                                  "",
+		"nginx":                             "harbor.local/proxy/library/nginx",
+		"nginx:1.21":                        "harbor.local/proxy/library/nginx:1.21",
+		"baetyltech/baetyl:v2.2.0":          "harbor.local/proxy/baetyltech/baetyl:v2.2.0",
+		"docker.io/library/redis:6":         "harbor.local/proxy/library/redis:6",
+		"index.docker.io/eclipse/mosquitto": "harbor.local/proxy/eclipse/mosquitto",
+		"quay.io/prometheus/node-exporter":  "harbor.local/proxy/prometheus/node-exporter",
+		"localhost:5000/app@sha256:abc":     "harbor.local/proxy/app@sha256:abc",
+		"harbor.local/proxy/library/nginx":  "harbor.local/proxy/library/nginx",
+	}
+	for image, expect := range cases {
+		assert.Equal(t, expect, MirrorImage(image, policy), image)
+	}
+
+	// only the registries listed are rewritten
+	policy.Registries = []string{"https://index.docker.io/", "gcr.io"}
+	assert.Equal(t, "harbor.local/proxy/library/nginx", MirrorImage("nginx", policy))
+	assert.Equal(t, "harbor.local/proxy/pause:3.2", MirrorImage("gcr.io/pause:3.2", policy))
+	assert.Equal(t, "quay.io/coreos/etcd", MirrorImage("quay.io/coreos/etcd", policy))
+
+	assert.Equal(t, "nginx", MirrorImage("nginx", &models.ImagePolicy{}))
+}
+
+func TestApplyImagePolicy(t *testing.T) {
+	policy := &models.ImagePolicy{Mirror: "harbor.local", PullPolicy: models.ImagePullIfNotPresent}
+	app := &specV1.Application{
+		Name:         "web",
+		Mode:         context.RunModeKube,
+		InitServices: []specV1.Service{{Name: "init", Image: "busybox"}},
+		Services:     []specV1.Service{{Name: "web", Image: "nginx:1.21"}, {Name: "agent", Image: "quay.io/agent"}},
+	}
+	ApplyImagePolicy(app, policy)
+	assert.Equal(t, "harbor.local/library/busybox", app.InitServices[0].Image)
+	assert.Equal(t, "harbor.local/library/nginx:1.21", app.Services[0].Image)
+	assert.Equal(t, "harbor.local/agent", app.Services[1].Image)
+	assert.Equal(t, models.ImagePullIfNotPresent, app.Labels[common.LabelImagePullPolicy])
+
+	// the apps in native mode are kept
+	native := &specV1.Application{Name: "web", Mode: context.RunModeNative, Services: []specV1.Service{{Name: "web", Image: "web"}}}
+	ApplyImagePolicy(native, policy)
+	assert.Equal(t, "web", native.Services[0].Image)
+	assert.Nil(t, native.Labels)
+
+	ApplyImagePolicy(native, nil)
+	assert.Equal(t, "web", native.Services[0].Image)
+}
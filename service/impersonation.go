@@ -0,0 +1,123 @@
+package service
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/impersonation.go -package=service github.com/baetyl/baetyl-cloud/v2/service ImpersonationService
+
+// impersonationIDLength the length of the random ids of impersonations
+const impersonationIDLength = 16
+
+type ImpersonationService interface {
+	// Issue signs the token of impersonation for its operator and records the issue in audit
+	Issue(imp *models.Impersonation) (*models.Impersonation, error)
+	// Verify returns the impersonation carried by token, or ErrInvalidToken if the token is forged or expired
+	Verify(token string) (*models.Impersonation, error)
+}
+
+type ImpersonationServiceImpl struct {
+	Sign      SignService
+	Audit     AuditService
+	expiry    time.Duration
+	maxExpiry time.Duration
+}
+
+// NewImpersonationService the impersonations issued are recorded in the audit given, which is nil if nothing is audited
+func NewImpersonationService(config *config.CloudConfig, audit AuditService) (ImpersonationService, error) {
+	sign, err := NewSignService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ImpersonationServiceImpl{
+		Sign:      sign,
+		Audit:     audit,
+		expiry:    config.Impersonation.Expiry,
+		maxExpiry: config.Impersonation.MaxExpiry,
+	}, nil
+}
+
+func (s *ImpersonationServiceImpl) Issue(imp *models.Impersonation) (*models.Impersonation, error) {
+	expiry := s.expiry
+	if imp.Expiry != "" {
+		d, err := time.ParseDuration(imp.Expiry)
+		if err != nil || d <= 0 {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the expiry of impersonation should be a positive duration"))
+		}
+		expiry = d
+	}
+	if s.maxExpiry > 0 && expiry > s.maxExpiry {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the expiry of impersonation should be no more than "+s.maxExpiry.String()))
+	}
+	now := time.Now().UTC()
+	res := *imp
+	res.ID = common.RandString(impersonationIDLength)
+	res.Expiry = ""
+	res.ExpireTime = now.Add(expiry)
+	res.Token = ""
+	data, err := json.Marshal(&res)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sign, err := s.Sign.Signature(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.Audit != nil {
+		record := &models.AuditRecord{
+			Namespace:    res.Namespace,
+			User:         res.User,
+			Impersonator: res.Operator,
+			Method:       "IMPERSONATE",
+			Path:         "/v1/impersonations",
+			Resource:     "impersonations",
+			Name:         res.ID,
+			Status:       http.StatusOK,
+			Diff:         string(data),
+			CreateTime:   now,
+		}
+		// the impersonation is refused unless it's audited
+		if err = s.Audit.Audit(record); err != nil {
+			return nil, err
+		}
+	}
+	res.Token = hex.EncodeToString(data) + "." + hex.EncodeToString(sign)
+	log.L().Info("impersonation is issued", log.Any("id", res.ID), log.Any("namespace", res.Namespace),
+		log.Any("user", res.User), log.Any("operator", res.Operator), log.Any("expireTime", res.ExpireTime))
+	return &res, nil
+}
+
+func (s *ImpersonationServiceImpl) Verify(token string) (*models.Impersonation, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, common.Error(common.ErrInvalidToken)
+	}
+	data, err := hex.DecodeString(parts[0])
+	if err != nil {
+		return nil, common.Error(common.ErrInvalidToken)
+	}
+	sign, err := hex.DecodeString(parts[1])
+	if err != nil || !s.Sign.Verify(data, sign) {
+		return nil, common.Error(common.ErrInvalidToken)
+	}
+	imp := new(models.Impersonation)
+	if err = json.Unmarshal(data, imp); err != nil {
+		return nil, common.Error(common.ErrInvalidToken)
+	}
+	if imp.IsExpired(time.Now()) {
+		log.L().Info("impersonation expired", log.Any("id", imp.ID), log.Any("operator", imp.Operator))
+		return nil, common.Error(common.ErrInvalidToken)
+	}
+	return imp, nil
+}
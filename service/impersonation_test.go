@@ -0,0 +1,84 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewImpersonationService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.Sign = common.RandString(9)
+	_, err := NewImpersonationService(conf, nil)
+	assert.Error(t, err)
+}
+
+func TestImpersonationService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mSign := ms.NewMockSignService(mockCtl)
+	mAudit := ms.NewMockAuditService(mockCtl)
+	s := &ImpersonationServiceImpl{Sign: mSign, Audit: mAudit, expiry: time.Minute * 15, maxExpiry: time.Hour}
+
+	var signed []byte
+	mSign.EXPECT().Signature(gomock.Any()).DoAndReturn(func(meta []byte) ([]byte, error) {
+		signed = meta
+		return []byte("sign"), nil
+	})
+	mAudit.EXPECT().Audit(gomock.Any()).DoAndReturn(func(record *models.AuditRecord) error {
+		assert.Equal(t, "IMPERSONATE", record.Method)
+		assert.Equal(t, "tenant-a", record.Namespace)
+		assert.Equal(t, "user01", record.User)
+		assert.Equal(t, "support01", record.Impersonator)
+		return nil
+	})
+	res, err := s.Issue(&models.Impersonation{Namespace: "tenant-a", User: "user01", Operator: "support01", Reason: "ticket 42"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.ID)
+	assert.NotEmpty(t, res.Token)
+	assert.WithinDuration(t, time.Now().Add(time.Minute*15), res.ExpireTime, time.Minute)
+
+	mSign.EXPECT().Verify(signed, []byte("sign")).Return(true)
+	imp, err := s.Verify(res.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, res.ID, imp.ID)
+	assert.Equal(t, "user01", imp.User)
+	assert.Equal(t, "support01", imp.Operator)
+	assert.False(t, imp.Mutable)
+
+	// forged
+	mSign.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false)
+	_, err = s.Verify(res.Token)
+	assertErrorCode(t, common.ErrInvalidToken, err)
+	for _, token := range []string{"", "abc", "zz.zz"} {
+		_, err = s.Verify(token)
+		assertErrorCode(t, common.ErrInvalidToken, err)
+	}
+
+	// beyond the max expiry
+	_, err = s.Issue(&models.Impersonation{Namespace: "tenant-a", User: "user01", Operator: "support01", Reason: "ticket 42", Expiry: "2h"})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+}
+
+func TestImpersonationServiceExpired(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mSign := ms.NewMockSignService(mockCtl)
+	s := &ImpersonationServiceImpl{Sign: mSign, expiry: time.Minute * 15}
+
+	mSign.EXPECT().Signature(gomock.Any()).Return([]byte("sign"), nil)
+	res, err := s.Issue(&models.Impersonation{Namespace: "tenant-a", User: "user01", Operator: "support01", Reason: "ticket 42", Expiry: "1s"})
+	assert.NoError(t, err)
+
+	time.Sleep(time.Second)
+	mSign.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true)
+	_, err = s.Verify(res.Token)
+	assertErrorCode(t, common.ErrInvalidToken, err)
+}
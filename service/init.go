@@ -4,7 +4,6 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"strings"
-	"time"
 
 	"github.com/baetyl/baetyl-go/v2/context"
 	"github.com/baetyl/baetyl-go/v2/errors"
@@ -22,6 +21,8 @@ const (
 	InfoName      = "n"
 	InfoNamespace = "ns"
 	InfoExpiry    = "e"
+	// InfoActivationToken the name of the activation token authorizing the init server to activate the node
+	InfoActivationToken = "t"
 )
 
 const (
@@ -44,10 +45,6 @@ const (
 	PropInitCommandAndroidBatchSys = "baetyl-init-command-android-batch-sys"
 )
 
-var (
-	CmdExpirationInSeconds = int64(60 * 60)
-)
-
 type GetInitResource func(ns, nodeName string, params map[string]interface{}) ([]byte, error)
 type PopulateExtParams func(ns string, node *specV1.Node, app *specV1.Application, params map[string]interface{}) error
 
@@ -62,8 +59,17 @@ type InitServiceImpl struct {
 	NodeService     NodeService
 	Property        PropertyService
 	TemplateService TemplateService
+	// Overrides renders the install templates overridden in namespaces, which call only the functions and reference
+	// only the params the tenants could use
+	Overrides TemplateService
 	*AppCombinedService
 	PKI             PKIService
+	Activation      ActivationTokenService
+	InstallTemplate InstallTemplateService
+	// Endpoints the endpoints overridden for node are rendered into its install commands, scripts and configs
+	Endpoints NodeEndpointsService
+	// Artifact the images of module versions built for the platform of node are rendered into its manifests
+	Artifact        ModuleArtifactService
 	ResourceMapFunc map[string]GetInitResource
 	Hooks           map[string]interface{}
 	log             *log.Logger
@@ -87,13 +93,23 @@ func NewInitService(config *config.CloudConfig) (InitService, error) {
 	if err != nil {
 		return nil, err
 	}
-	templateService, err := NewTemplateService(config, map[string]interface{}{
+	artifactService, err := NewModuleArtifactService(config)
+	if err != nil {
+		return nil, err
+	}
+	funcs := map[string]interface{}{
 		"GetProperty":      propertyService.GetPropertyValue,
+		"GetEndpoint":      GetEndpointFunc(propertyService),
 		"RandString":       common.RandString,
 		"GetModuleImage":   moduleService.GetLatestModuleImage,
-		"GetModuleProgram": moduleService.GetLatestModuleProgram,
-	})
-
+		"GetModuleProgram": artifactService.SelectProgram,
+		"GetPlatformImage": GetPlatformImageFunc(moduleService, artifactService),
+	}
+	templateService, err := NewTemplateService(config, funcs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	overrides, err := NewTemplateService(config, installTemplateFuncMap(funcs))
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -105,14 +121,31 @@ func NewInitService(config *config.CloudConfig) (InitService, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	activation, err := NewActivationTokenService(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	installTemplate, err := NewInstallTemplateService(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	endpoints, err := NewNodeEndpointsService(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	initService := &InitServiceImpl{
 		cfg:                config,
 		SignService:        signService,
 		NodeService:        nodeService,
 		Property:           propertyService,
 		TemplateService:    templateService,
+		Overrides:          overrides,
 		AppCombinedService: acs,
 		PKI:                pki,
+		Activation:         activation,
+		InstallTemplate:    installTemplate,
+		Endpoints:          endpoints,
+		Artifact:           artifactService,
 		ResourceMapFunc:    map[string]GetInitResource{},
 		Hooks:              map[string]interface{}{},
 		log:                log.L().With(log.Any("service", "init")),
@@ -133,6 +166,12 @@ func (s *InitServiceImpl) GetResource(ns, nodeName, resourceName string, params
 		if params == nil {
 			params = map[string]interface{}{}
 		}
+		if err := s.populateEndpoints(ns, nodeName, params); err != nil {
+			return nil, err
+		}
+		if err := s.populatePlatform(ns, nodeName, params); err != nil {
+			return nil, err
+		}
 		return handler(ns, nodeName, params)
 	}
 	return nil, common.Error(
@@ -141,6 +180,47 @@ func (s *InitServiceImpl) GetResource(ns, nodeName, resourceName string, params
 		common.Field("name", resourceName))
 }
 
+// populateEndpoints resolves the endpoints of node into params unless they are set by the caller, such as the ones
+// of node to be updated. The endpoints of namespace are resolved if the node is not found
+func (s *InitServiceImpl) populateEndpoints(ns, nodeName string, params map[string]interface{}) error {
+	if s.Endpoints == nil {
+		return nil
+	}
+	if _, ok := params[ParamEndpoints]; ok {
+		return nil
+	}
+	node, err := s.NodeService.Get(nil, ns, nodeName)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return err
+		}
+		node = nil
+	}
+	endpoints, err := s.Endpoints.Resolve(ns, node)
+	if err != nil {
+		return err
+	}
+	params[ParamEndpoints] = endpoints
+	return nil
+}
+
+// populatePlatform resolves the platform of node into params, which is reported by the install script before
+// activation, the platform of node is unknown if the node is not found
+func (s *InitServiceImpl) populatePlatform(ns, nodeName string, params map[string]interface{}) error {
+	if _, ok := params[ParamPlatform]; ok || s.Artifact == nil {
+		return nil
+	}
+	node, err := s.NodeService.Get(nil, ns, nodeName)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return err
+		}
+		node = nil
+	}
+	params[ParamPlatform] = NodePlatform(node)
+	return nil
+}
+
 func (s *InitServiceImpl) getInitDeploymentYaml(ns, nodeName string, params map[string]interface{}) ([]byte, error) {
 	init, err := s.GetAppFromDesire(ns, nodeName, specV1.BaetylInit, true)
 	if err != nil {
@@ -185,6 +265,7 @@ func (s *InitServiceImpl) getInitDeploymentYaml(ns, nodeName string, params map[
 	params["DiskNetStats"] = node.NodeMode == context.RunModeKube
 	params["QPSStats"] = node.NodeMode == context.RunModeKube
 	params["AgentPort"] = common.DefaultAgentPort
+	params["SyncLink"] = common.NodeSyncLink(node.Labels)
 
 	registryAuth, err := s.GetRegistryAuth()
 	if err != nil {
@@ -198,7 +279,7 @@ func (s *InitServiceImpl) getInitDeploymentYaml(ns, nodeName string, params map[
 		}
 	}
 
-	return s.TemplateService.ParseTemplate(templateInitDeploymentYaml, params)
+	return s.parseInstallTemplate(ns, templateInitDeploymentYaml, params)
 }
 
 // GetRegistryAuth add system registry auth if property exist
@@ -237,7 +318,7 @@ func (s *InitServiceImpl) GetNodeCert(app *specV1.Application) (*specV1.Secret,
 
 func (s *InitServiceImpl) getWindowsInstallShell(ns, nodeName string, params map[string]interface{}) ([]byte, error) {
 	params["DBPath"] = context.DefaultWindowsHostPathLib
-	data, err := s.TemplateService.ParseTemplate(templateBaetylWindowsInstallShell, params)
+	data, err := s.parseInstallTemplate(ns, templateBaetylWindowsInstallShell, params)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -246,35 +327,103 @@ func (s *InitServiceImpl) getWindowsInstallShell(ns, nodeName string, params map
 
 func (s *InitServiceImpl) getInstallShell(ns, nodeName string, params map[string]interface{}) ([]byte, error) {
 	params["DBPath"] = "/var/lib/baetyl"
-	data, err := s.TemplateService.ParseTemplate(templateBaetylInstallShell, params)
+	data, err := s.parseInstallTemplate(ns, templateBaetylInstallShell, params)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return data, nil
 }
 
+// GetInitCommand returns the install command of node carrying the activation token set in params, a token bound to
+// the node is issued with the default expiry and max uses if not set
 func (s *InitServiceImpl) GetInitCommand(ns, nodeName string, params map[string]interface{}) ([]byte, error) {
+	var token *models.ActivationToken
+	var err error
+	if name, _ := params["ActivationToken"].(string); name != "" {
+		token, err = s.Activation.Get(ns, name)
+	} else {
+		token, err = s.Activation.Issue(&models.ActivationToken{Namespace: ns, Node: nodeName})
+	}
+	if err != nil {
+		return nil, err
+	}
 	info := map[string]interface{}{
-		InfoNamespace: ns,
-		InfoName:      nodeName,
-		InfoExpiry:    time.Now().Unix() + CmdExpirationInSeconds,
+		InfoNamespace:       ns,
+		InfoName:            nodeName,
+		InfoExpiry:          token.ExpireTime.Unix(),
+		InfoActivationToken: token.Name,
 	}
-	initCommand, err := s.Property.GetPropertyValue(params["template"].(string))
+	initCommand, overridden, err := s.getInitCommandTemplate(ns, params)
 	if err != nil {
 		return nil, err
 	}
-	token, err := s.SignService.GenToken(info)
+	signed, err := s.SignService.GenToken(info)
 	if err != nil {
 		return nil, err
 	}
-	params["Token"] = token
-	data, err := s.TemplateService.Execute("setup-command", initCommand, params)
+	params["Token"] = signed
+	render := s.TemplateService
+	if overridden {
+		render, params = s.Overrides, installTemplateParams(params)
+	}
+	data, err := render.Execute("setup-command", initCommand, params)
 	if err != nil {
 		return nil, err
 	}
 	return data, nil
 }
 
+// getInitCommandTemplate returns the install command overridden in namespace or the property of params template,
+// and whether it's overridden
+func (s *InitServiceImpl) getInitCommandTemplate(ns string, params map[string]interface{}) (string, bool, error) {
+	name := params["template"].(string)
+	tpl, err := s.getInstallTemplate(ns, name)
+	if err != nil {
+		return "", false, err
+	}
+	if tpl == nil {
+		cmd, err := s.Property.GetPropertyValue(name)
+		return cmd, false, err
+	}
+	params["Values"] = installTemplateValues(tpl)
+	return tpl.Content, true, nil
+}
+
+// parseInstallTemplate renders the template overridden in namespace, or the default one if not overridden.
+// The values of override are referenced as .Values, and the override is rendered with the params the tenants could use
+func (s *InitServiceImpl) parseInstallTemplate(ns, name string, params map[string]interface{}) ([]byte, error) {
+	tpl, err := s.getInstallTemplate(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	if tpl == nil {
+		return s.TemplateService.ParseTemplate(name, params)
+	}
+	params["Values"] = installTemplateValues(tpl)
+	return s.Overrides.Execute(name, tpl.Content, installTemplateParams(params))
+}
+
+func (s *InitServiceImpl) getInstallTemplate(ns, name string) (*models.InstallTemplate, error) {
+	if s.InstallTemplate == nil {
+		return nil, nil
+	}
+	tpl, err := s.InstallTemplate.Get(ns, name)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return tpl, nil
+}
+
+func installTemplateValues(tpl *models.InstallTemplate) map[string]string {
+	if tpl.Values == nil {
+		return map[string]string{}
+	}
+	return tpl.Values
+}
+
 func (s *InitServiceImpl) GetAppFromDesire(ns, nodeName, moduleName string, isSys bool) (*specV1.Application, error) {
 	shadowDesire, err := s.NodeService.GetDesire(ns, nodeName)
 	if err != nil {
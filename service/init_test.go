@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
 	"github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 )
 
 func TestInitService_GetResource(t *testing.T) {
@@ -166,14 +168,18 @@ func TestInitService_GenCmd(t *testing.T) {
 	sSign := service.NewMockSignService(mockCtl)
 	sTemplate := service.NewMockTemplateService(mockCtl)
 	sProp := service.NewMockPropertyService(mockCtl)
+	sActivation := service.NewMockActivationTokenService(mockCtl)
 	as := InitServiceImpl{}
 	as.SignService = sSign
 	as.TemplateService = sTemplate
 	as.Property = sProp
+	as.Activation = sActivation
+	token := &models.ActivationToken{Namespace: "ns", Name: "token01", Node: "name", MaxUses: 1, ExpireTime: time.Now().Add(time.Hour)}
 	info := map[string]interface{}{
-		InfoName:      "name",
-		InfoNamespace: "ns",
-		InfoExpiry:    time.Now().Unix() + CmdExpirationInSeconds,
+		InfoName:            "name",
+		InfoNamespace:       "ns",
+		InfoExpiry:          token.ExpireTime.Unix(),
+		InfoActivationToken: "token01",
 	}
 	expect := "curl -skfL 'https://1.2.3.4:9003/v1/active/setup.sh?token=tokenexpect' -osetup.sh && sh setup.sh"
 	params := map[string]interface{}{
@@ -181,6 +187,8 @@ func TestInitService_GenCmd(t *testing.T) {
 		"template":      TemplateBaetylInitCommand,
 		"mode":          "",
 	}
+	// the token bound to node is issued
+	sActivation.EXPECT().Issue(&models.ActivationToken{Namespace: "ns", Node: "name"}).Return(token, nil).Times(1)
 	sSign.EXPECT().GenToken(info).Return("tokenexpect", nil).Times(1)
 	sProp.EXPECT().GetPropertyValue(TemplateBaetylInitCommand).Return(TemplateBaetylInitCommand, nil)
 	sTemplate.EXPECT().Execute("setup-command", TemplateBaetylInitCommand, gomock.Any()).Return([]byte(expect), nil).Times(1)
@@ -188,6 +196,23 @@ func TestInitService_GenCmd(t *testing.T) {
 	res, err := as.GetInitCommand("ns", "name", params)
 	assert.NoError(t, err)
 	assert.Equal(t, string(res), expect)
+
+	// the token designated is carried
+	group := &models.ActivationToken{Namespace: "ns", Name: "token02", NodeGroup: "group", MaxUses: 10, ExpireTime: time.Now().Add(time.Hour * 24)}
+	info[InfoExpiry], info[InfoActivationToken] = group.ExpireTime.Unix(), "token02"
+	params["ActivationToken"] = "token02"
+	sActivation.EXPECT().Get("ns", "token02").Return(group, nil).Times(1)
+	sSign.EXPECT().GenToken(info).Return("tokenexpect", nil).Times(1)
+	sProp.EXPECT().GetPropertyValue(TemplateBaetylInitCommand).Return(TemplateBaetylInitCommand, nil)
+	sTemplate.EXPECT().Execute("setup-command", TemplateBaetylInitCommand, gomock.Any()).Return([]byte(expect), nil).Times(1)
+
+	res, err = as.GetInitCommand("ns", "name", params)
+	assert.NoError(t, err)
+	assert.Equal(t, string(res), expect)
+
+	sActivation.EXPECT().Get("ns", "token02").Return(nil, fmt.Errorf("not found")).Times(1)
+	_, err = as.GetInitCommand("ns", "name", params)
+	assert.Error(t, err)
 }
 
 func TestInitService_getDesireAppInfo(t *testing.T) {
@@ -218,3 +243,124 @@ func TestInitService_getDesireAppInfo(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, res, app1)
 }
+
+func TestInitService_InstallTemplate(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sTemplate, sOverride := service.NewMockTemplateService(mockCtl), service.NewMockTemplateService(mockCtl)
+	sInstall := service.NewMockInstallTemplateService(mockCtl)
+	sProp := service.NewMockPropertyService(mockCtl)
+	as := InitServiceImpl{TemplateService: sTemplate, Overrides: sOverride, InstallTemplate: sInstall, Property: sProp}
+
+	// the default template is rendered if not overridden
+	sInstall.EXPECT().Get("ns", templateBaetylInstallShell).Return(nil, common.Error(common.ErrResourceNotFound))
+	sTemplate.EXPECT().ParseTemplate(templateBaetylInstallShell, gomock.Any()).Return([]byte("default"), nil)
+	res, err := as.getInstallShell("ns", "node", map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "default", string(res))
+
+	// the override of namespace is rendered with its values, and without the params of platform
+	tpl := &models.InstallTemplate{Namespace: "ns", Name: templateBaetylInstallShell, Content: "export HTTPS_PROXY={{.Values.proxy}}",
+		Values: map[string]string{"proxy": "http://proxy:3128"}}
+	sInstall.EXPECT().Get("ns", templateBaetylInstallShell).Return(tpl, nil)
+	sOverride.EXPECT().Execute(templateBaetylInstallShell, tpl.Content, gomock.Any()).DoAndReturn(func(name, text string, params map[string]interface{}) ([]byte, error) {
+		assert.Equal(t, "/var/lib/baetyl", params["DBPath"])
+		assert.NotContains(t, params, "RegistryAuth")
+		return (&TemplateServiceImpl{}).Execute(name, text, params)
+	})
+	res, err = as.getInstallShell("ns", "node", map[string]interface{}{"RegistryAuth": "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, "export HTTPS_PROXY=http://proxy:3128", string(res))
+
+	sInstall.EXPECT().Get("ns", templateBaetylInstallShell).Return(nil, fmt.Errorf("db error"))
+	_, err = as.getInstallShell("ns", "node", map[string]interface{}{})
+	assert.Error(t, err)
+
+	// the install command overridden replaces the property
+	sInstall.EXPECT().Get("ns", TemplateInitCommandWget).Return(&models.InstallTemplate{Content: "wget {{.Token}}"}, nil)
+	params := map[string]interface{}{"template": TemplateInitCommandWget}
+	cmd, overridden, err := as.getInitCommandTemplate("ns", params)
+	assert.NoError(t, err)
+	assert.True(t, overridden)
+	assert.Equal(t, "wget {{.Token}}", cmd)
+	assert.Equal(t, map[string]string{}, params["Values"])
+
+	sInstall.EXPECT().Get("ns", TemplateBaetylInitCommand).Return(nil, common.Error(common.ErrResourceNotFound))
+	sProp.EXPECT().GetPropertyValue(TemplateBaetylInitCommand).Return("curl", nil)
+	cmd, overridden, err = as.getInitCommandTemplate("ns", map[string]interface{}{"template": TemplateBaetylInitCommand})
+	assert.NoError(t, err)
+	assert.False(t, overridden)
+	assert.Equal(t, "curl", cmd)
+}
+
+func TestInitService_Endpoints(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sNode := service.NewMockNodeService(mockCtl)
+	sEndpoints := service.NewMockNodeEndpointsService(mockCtl)
+	var rendered interface{}
+	as := InitServiceImpl{NodeService: sNode, Endpoints: sEndpoints, ResourceMapFunc: map[string]GetInitResource{
+		TemplateCoreConfYaml: func(ns, nodeName string, params map[string]interface{}) ([]byte, error) {
+			rendered = params[ParamEndpoints]
+			return nil, nil
+		},
+	}}
+
+	// the endpoints of node are resolved into params
+	node := &v1.Node{Namespace: "ns", Name: "node"}
+	endpoints := &models.NodeEndpoints{Namespace: "ns", SyncServer: "https://gateway.site:30005"}
+	sNode.EXPECT().Get(nil, "ns", "node").Return(node, nil)
+	sEndpoints.EXPECT().Resolve("ns", node).Return(endpoints, nil)
+	_, err := as.GetResource("ns", "node", TemplateCoreConfYaml, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, endpoints, rendered)
+
+	// the ones of namespace are resolved for the node not found
+	sNode.EXPECT().Get(nil, "ns", "node").Return(nil, common.Error(common.ErrResourceNotFound))
+	sEndpoints.EXPECT().Resolve("ns", nil).Return(endpoints, nil)
+	_, err = as.GetResource("ns", "node", TemplateCoreConfYaml, map[string]interface{}{})
+	assert.NoError(t, err)
+
+	// the ones set by caller are kept
+	preset := &models.NodeEndpoints{SyncServer: "https://proxy.local:30005"}
+	_, err = as.GetResource("ns", "node", TemplateCoreConfYaml, map[string]interface{}{ParamEndpoints: preset})
+	assert.NoError(t, err)
+	assert.Equal(t, preset, rendered)
+
+	sNode.EXPECT().Get(nil, "ns", "node").Return(nil, fmt.Errorf("error"))
+	_, err = as.GetResource("ns", "node", TemplateCoreConfYaml, nil)
+	assert.Error(t, err)
+}
+
+func TestInitService_Platform(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sNode := service.NewMockNodeService(mockCtl)
+	sArtifact := service.NewMockModuleArtifactService(mockCtl)
+	var rendered interface{}
+	as := InitServiceImpl{NodeService: sNode, Artifact: sArtifact, ResourceMapFunc: map[string]GetInitResource{
+		templateInitDeploymentYaml: func(ns, nodeName string, params map[string]interface{}) ([]byte, error) {
+			rendered = params[ParamPlatform]
+			return nil, nil
+		},
+	}}
+
+	// the platform of the facts checked before activation
+	node := &v1.Node{Namespace: "ns", Name: "node", Attributes: map[string]interface{}{
+		common.AttributePreflight: &models.NodePreflight{Facts: models.HostFacts{OS: "Linux", Arch: "arm64"}},
+	}}
+	sNode.EXPECT().Get(nil, "ns", "node").Return(node, nil)
+	_, err := as.GetResource("ns", "node", templateInitDeploymentYaml, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "linux-arm64", rendered)
+
+	// the platform is unknown for the node not found
+	sNode.EXPECT().Get(nil, "ns", "node").Return(nil, common.Error(common.ErrResourceNotFound))
+	_, err = as.GetResource("ns", "node", templateInitDeploymentYaml, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", rendered)
+
+	sNode.EXPECT().Get(nil, "ns", "node").Return(nil, fmt.Errorf("error"))
+	_, err = as.GetResource("ns", "node", templateInitDeploymentYaml, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"fmt"
+	"text/template/parse"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/installtemplate.go -package=service github.com/baetyl/baetyl-cloud/v2/service InstallTemplateService
+
+// InstallTemplateService manages the install templates overridden in namespaces, the init server renders the
+// override of namespace instead of the default template
+type InstallTemplateService interface {
+	Get(namespace, name string) (*models.InstallTemplate, error)
+	List(namespace string) ([]models.InstallTemplate, error)
+	Create(tpl *models.InstallTemplate) (*models.InstallTemplate, error)
+	Update(tpl *models.InstallTemplate) (*models.InstallTemplate, error)
+	Delete(namespace, name string) error
+	GetDefault(name string) (*models.InstallTemplateDefault, error)
+}
+
+// installTemplates the templates could be overridden, the install commands are properties and the others are files
+var installTemplates = map[string]bool{
+	templateBaetylInstallShell:        false,
+	templateBaetylWindowsInstallShell: false,
+	templateInitDeploymentYaml:        false,
+	TemplateBaetylInitCommand:         true,
+	TemplateInitCommandWget:           true,
+	TemplateInitCommandWindows:        true,
+}
+
+// installTemplateFuncs the functions the overrides of tenants could call besides the builtin ones, GetProperty is left
+// out since the properties are of the platform
+var installTemplateFuncs = map[string]bool{
+	"GetEndpoint":      true,
+	"RandString":       true,
+	"GetModuleImage":   true,
+	"GetModuleProgram": true,
+	"GetPlatformImage": true,
+}
+
+// installTemplateBuiltins the builtin functions of text/template the overrides could call, call is left out since it
+// calls the functions of params
+var installTemplateBuiltins = map[string]bool{
+	"and": true, "or": true, "not": true, "len": true, "index": true, "slice": true,
+	"print": true, "printf": true, "println": true, "html": true, "js": true, "urlquery": true,
+	"eq": true, "ne": true, "lt": true, "le": true, "gt": true, "ge": true,
+}
+
+// installTemplateFields the params the overrides could reference, the others such as RegistryAuth are of the platform
+var installTemplateFields = map[string]bool{
+	"Namespace": true, "NodeName": true, "KubeNodeName": true, "Mode": true, "mode": true, "template": true,
+	"NodeCertName": true, "NodeCertVersion": true, "NodeCertPem": true, "NodeCertKey": true, "NodeCertCa": true,
+	"EdgeNamespace": true, "EdgeSystemNamespace": true, "InitAppName": true, "InitVersion": true, "InitApplyYaml": true,
+	"GPUStats": true, "DiskNetStats": true, "QPSStats": true, "AgentPort": true, "SyncLink": true, "DBPath": true,
+	"Token": true, "ActivationToken": true, "Values": true, ParamEndpoints: true, ParamPlatform: true,
+}
+
+// installTemplateEndpoints the endpoints GetEndpoint looks up for the overrides
+var installTemplateEndpoints = map[string]bool{
+	models.EndpointInitServer:    true,
+	models.EndpointSyncServer:    true,
+	models.EndpointSyncMQTT:      true,
+	models.EndpointObjectStorage: true,
+}
+
+type InstallTemplateServiceImpl struct {
+	InstallTemplate plugin.InstallTemplate
+	Property        PropertyService
+	TemplateService TemplateService
+}
+
+// NewInstallTemplateService NewInstallTemplateService
+func NewInstallTemplateService(config *config.CloudConfig) (InstallTemplateService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.InstallTemplate)
+	if err != nil {
+		return nil, err
+	}
+	property, err := NewPropertyService(config)
+	if err != nil {
+		return nil, err
+	}
+	tpl, err := NewTemplateService(config, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &InstallTemplateServiceImpl{
+		InstallTemplate: p.(plugin.InstallTemplate),
+		Property:        property,
+		TemplateService: tpl,
+	}, nil
+}
+
+func (s *InstallTemplateServiceImpl) Get(namespace, name string) (*models.InstallTemplate, error) {
+	return s.InstallTemplate.GetInstallTemplate(namespace, name)
+}
+
+func (s *InstallTemplateServiceImpl) List(namespace string) ([]models.InstallTemplate, error) {
+	return s.InstallTemplate.ListInstallTemplate(namespace)
+}
+
+func (s *InstallTemplateServiceImpl) Create(tpl *models.InstallTemplate) (*models.InstallTemplate, error) {
+	if err := ValidateInstallTemplate(tpl); err != nil {
+		return nil, err
+	}
+	if err := s.InstallTemplate.CreateInstallTemplate(tpl); err != nil {
+		return nil, err
+	}
+	return s.InstallTemplate.GetInstallTemplate(tpl.Namespace, tpl.Name)
+}
+
+func (s *InstallTemplateServiceImpl) Update(tpl *models.InstallTemplate) (*models.InstallTemplate, error) {
+	if err := ValidateInstallTemplate(tpl); err != nil {
+		return nil, err
+	}
+	if err := s.InstallTemplate.UpdateInstallTemplate(tpl); err != nil {
+		return nil, err
+	}
+	return s.InstallTemplate.GetInstallTemplate(tpl.Namespace, tpl.Name)
+}
+
+func (s *InstallTemplateServiceImpl) Delete(namespace, name string) error {
+	return s.InstallTemplate.DeleteInstallTemplate(namespace, name)
+}
+
+// GetDefault returns the default template, which is the base of override
+func (s *InstallTemplateServiceImpl) GetDefault(name string) (*models.InstallTemplateDefault, error) {
+	isProperty, ok := installTemplates[name]
+	if !ok {
+		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "install template"), common.Field("name", name))
+	}
+	var content string
+	var err error
+	if isProperty {
+		content, err = s.Property.GetPropertyValue(name)
+	} else {
+		content, err = s.TemplateService.GetTemplate(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &models.InstallTemplateDefault{Name: name, Content: content}, nil
+}
+
+// ValidateInstallTemplate checks the name could be overridden and the content is parsed, calling only the functions
+// and referencing only the params the overrides could use
+func ValidateInstallTemplate(tpl *models.InstallTemplate) error {
+	if _, ok := installTemplates[tpl.Name]; !ok {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the install template "+tpl.Name+" could not be overridden"))
+	}
+	t := parse.New(tpl.Name)
+	t.Mode = parse.SkipFuncCheck
+	trees := map[string]*parse.Tree{}
+	if _, err := t.Parse(tpl.Content, "", "", trees); err != nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	for name, tree := range trees {
+		// the dot of the templates defined is the one passed in, which is not the params
+		if err := checkInstallTemplateNode(tree.Root, name == tpl.Name); err != nil {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+		}
+	}
+	return nil
+}
+
+// checkInstallTemplateNode checks the functions called and the params referenced by node, the fields of dot are
+// checked only where dot is the params
+func checkInstallTemplateNode(node parse.Node, root bool) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			if err := checkInstallTemplateNode(c, root); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkInstallTemplateNode(n.Pipe, root)
+	case *parse.IfNode:
+		return checkInstallTemplateBranch(&n.BranchNode, root, root)
+	case *parse.RangeNode:
+		return checkInstallTemplateBranch(&n.BranchNode, root, false)
+	case *parse.WithNode:
+		return checkInstallTemplateBranch(&n.BranchNode, root, false)
+	case *parse.TemplateNode:
+		return checkInstallTemplateNode(n.Pipe, root)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if err := checkInstallTemplateCommand(cmd, root); err != nil {
+				return err
+			}
+		}
+	case *parse.ChainNode:
+		return checkInstallTemplateNode(n.Node, root)
+	case *parse.IdentifierNode:
+		if !installTemplateFuncs[n.Ident] && !installTemplateBuiltins[n.Ident] {
+			return fmt.Errorf("the function %s could not be called", n.Ident)
+		}
+	case *parse.FieldNode:
+		if root && !installTemplateFields[n.Ident[0]] {
+			return fmt.Errorf("the param %s could not be referenced", n.Ident[0])
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 && !installTemplateFields[n.Ident[1]] {
+			return fmt.Errorf("the param %s could not be referenced", n.Ident[1])
+		}
+	}
+	return nil
+}
+
+func checkInstallTemplateBranch(n *parse.BranchNode, root, listRoot bool) error {
+	if err := checkInstallTemplateNode(n.Pipe, root); err != nil {
+		return err
+	}
+	if err := checkInstallTemplateNode(n.List, listRoot); err != nil {
+		return err
+	}
+	return checkInstallTemplateNode(n.ElseList, root)
+}
+
+func checkInstallTemplateCommand(cmd *parse.CommandNode, root bool) error {
+	for _, arg := range cmd.Args {
+		if err := checkInstallTemplateNode(arg, root); err != nil {
+			return err
+		}
+	}
+	if len(cmd.Args) < 3 {
+		return nil
+	}
+	if f, ok := cmd.Args[0].(*parse.IdentifierNode); ok && f.Ident == "GetEndpoint" {
+		if name, ok := cmd.Args[2].(*parse.StringNode); ok && !installTemplateEndpoints[name.Text] {
+			return fmt.Errorf("the endpoint %s is unknown", name.Text)
+		}
+	}
+	return nil
+}
+
+// installTemplateFuncMap returns the functions of funcs the overrides could call, GetEndpoint is limited to the
+// endpoints since it falls back to the properties of the same names
+func installTemplateFuncMap(funcs map[string]interface{}) map[string]interface{} {
+	res := map[string]interface{}{}
+	for name, f := range funcs {
+		if installTemplateFuncs[name] {
+			res[name] = f
+		}
+	}
+	if get, ok := res["GetEndpoint"].(func(map[string]interface{}, string) (string, error)); ok {
+		res["GetEndpoint"] = func(params map[string]interface{}, name string) (string, error) {
+			if !installTemplateEndpoints[name] {
+				return "", common.Error(common.ErrTemplate, common.Field("error", "the endpoint "+name+" is unknown"))
+			}
+			return get(params, name)
+		}
+	}
+	return res
+}
+
+// installTemplateParams returns the params the overrides could reference
+func installTemplateParams(params map[string]interface{}) map[string]interface{} {
+	res := map[string]interface{}{}
+	for k, v := range params {
+		if installTemplateFields[k] {
+			res[k] = v
+		}
+	}
+	return res
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewInstallTemplateService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.InstallTemplate = common.RandString(9)
+	_, err := NewInstallTemplateService(conf)
+	assert.Error(t, err)
+}
+
+func TestInstallTemplateService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mTemplate := mockPlugin.NewMockInstallTemplate(mockCtl)
+	is := &InstallTemplateServiceImpl{InstallTemplate: mTemplate}
+
+	tpl := &models.InstallTemplate{Namespace: "default", Name: templateBaetylInstallShell,
+		Content: `{{if .Values.proxy}}export HTTPS_PROXY={{.Values.proxy}}{{end}} {{GetEndpoint $ "sync-server-address"}} {{range $k, $v := .Values}}{{$k}}={{.}}{{end}}`,
+		Values:  map[string]string{"proxy": "http://proxy:3128"}}
+	mTemplate.EXPECT().CreateInstallTemplate(tpl).Return(nil)
+	mTemplate.EXPECT().GetInstallTemplate("default", templateBaetylInstallShell).Return(tpl, nil).Times(2)
+	res, err := is.Create(tpl)
+	assert.NoError(t, err)
+	assert.Equal(t, tpl, res)
+
+	mTemplate.EXPECT().UpdateInstallTemplate(tpl).Return(nil)
+	_, err = is.Update(tpl)
+	assert.NoError(t, err)
+
+	// the templates not installing nodes are not overridden
+	_, err = is.Create(&models.InstallTemplate{Namespace: "default", Name: TemplateCoreConfYaml, Content: "a: b"})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+	_, err = is.Update(&models.InstallTemplate{Namespace: "default", Name: templateBaetylInstallShell, Content: "{{if .Values.proxy}}"})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+	// the functions and params of platform are not used by the overrides
+	for _, content := range []string{
+		`{{GetProperty "registry-auth"}}`,
+		`{{call .Values.f}}`,
+		`{{.RegistryAuth}}`,
+		`{{if .Token}}{{$.RegistryAuth}}{{end}}`,
+		`{{GetEndpoint $ "registry-auth"}}`,
+	} {
+		_, err = is.Create(&models.InstallTemplate{Namespace: "default", Name: templateBaetylInstallShell, Content: content})
+		assertErrorCode(t, common.ErrRequestParamInvalid, err)
+	}
+
+	mTemplate.EXPECT().ListInstallTemplate("default").Return([]models.InstallTemplate{*tpl}, nil)
+	list, err := is.List("default")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	mTemplate.EXPECT().DeleteInstallTemplate("default", templateBaetylInstallShell).Return(nil)
+	assert.NoError(t, is.Delete("default", templateBaetylInstallShell))
+}
+
+func TestInstallTemplateFuncMap(t *testing.T) {
+	funcs := installTemplateFuncMap(map[string]interface{}{
+		"GetProperty": func(name string) (string, error) { return "secret", nil },
+		"GetEndpoint": func(params map[string]interface{}, name string) (string, error) { return "https://" + name, nil },
+	})
+	assert.NotContains(t, funcs, "GetProperty")
+	get := funcs["GetEndpoint"].(func(map[string]interface{}, string) (string, error))
+	res, err := get(nil, models.EndpointSyncServer)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://"+models.EndpointSyncServer, res)
+	// the endpoints fall back to the properties, which are not read by the other names
+	_, err = get(nil, common.RegistryAuth)
+	assert.Error(t, err)
+}
+
+func TestInstallTemplateService_GetDefault(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sProp := service.NewMockPropertyService(mockCtl)
+	sTemplate := service.NewMockTemplateService(mockCtl)
+	is := &InstallTemplateServiceImpl{Property: sProp, TemplateService: sTemplate}
+
+	sTemplate.EXPECT().GetTemplate(templateBaetylInstallShell).Return("#!/bin/sh", nil)
+	res, err := is.GetDefault(templateBaetylInstallShell)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.InstallTemplateDefault{Name: templateBaetylInstallShell, Content: "#!/bin/sh"}, res)
+
+	sProp.EXPECT().GetPropertyValue(TemplateInitCommandWget).Return("wget", nil)
+	res, err = is.GetDefault(TemplateInitCommandWget)
+	assert.NoError(t, err)
+	assert.Equal(t, "wget", res.Content)
+
+	_, err = is.GetDefault(TemplateAgentConfYaml)
+	assertErrorCode(t, common.ErrResourceNotFound, err)
+}
@@ -0,0 +1,447 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+)
+
+// the errors reported for a document are limited, the rest are usually caused by the same mistake
+const maxJSONSchemaErrors = 20
+
+// JSONSchema the json schema which the documents are validated against. The keywords of draft 7 are supported
+// except format, dependencies, if/then/else and the remote $ref, the unknown keywords are ignored
+type JSONSchema struct {
+	root     interface{}
+	patterns map[string]*regexp.Regexp
+}
+
+// ParseJSONSchema parses the schema in json, the patterns are compiled in advance
+func ParseJSONSchema(data []byte) (*JSONSchema, error) {
+	var root interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, jsonSchemaError(err.Error())
+	}
+	switch root.(type) {
+	case map[string]interface{}, bool:
+	default:
+		return nil, jsonSchemaError("the schema should be an object or a boolean")
+	}
+	s := &JSONSchema{root: root, patterns: map[string]*regexp.Regexp{}}
+	if err := s.compile(root); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParseSchemaDocument parses the document in json, or in yaml if yaml is true. The values are converted to
+// the ones decoded from json, so that the numbers of yaml are compared the same way
+func ParseSchemaDocument(data []byte, yamlDoc bool) (interface{}, error) {
+	var doc interface{}
+	if !yamlDoc {
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		return doc, nil
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(toStringKeys(doc))
+	if err != nil {
+		return nil, err
+	}
+	doc = nil
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Validate validates the document decoded from json, the errors are prefixed with the json pointers of values
+func (s *JSONSchema) Validate(doc interface{}) []string {
+	var errs []string
+	s.validate(s.root, doc, "", &errs)
+	if len(errs) > maxJSONSchemaErrors {
+		errs = append(errs[:maxJSONSchemaErrors], fmt.Sprintf("and %d more errors", len(errs)-maxJSONSchemaErrors))
+	}
+	return errs
+}
+
+func (s *JSONSchema) compile(schema interface{}) error {
+	switch v := schema.(type) {
+	case map[string]interface{}:
+		if p, ok := v["pattern"].(string); ok {
+			if err := s.compilePattern(p); err != nil {
+				return err
+			}
+		}
+		if ps, ok := v["patternProperties"].(map[string]interface{}); ok {
+			for p := range ps {
+				if err := s.compilePattern(p); err != nil {
+					return err
+				}
+			}
+		}
+		for _, item := range v {
+			if err := s.compile(item); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := s.compile(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *JSONSchema) compilePattern(p string) error {
+	if _, ok := s.patterns[p]; ok {
+		return nil
+	}
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return jsonSchemaError(fmt.Sprintf("the pattern %q is invalid: %s", p, err.Error()))
+	}
+	s.patterns[p] = re
+	return nil
+}
+
+func (s *JSONSchema) validate(schema, v interface{}, path string, errs *[]string) {
+	fail := func(format string, args ...interface{}) {
+		p := path
+		if p == "" {
+			p = "/"
+		}
+		*errs = append(*errs, p+": "+fmt.Sprintf(format, args...))
+	}
+	m, ok := schema.(map[string]interface{})
+	if !ok {
+		if b, ok := schema.(bool); ok && !b {
+			fail("the value is not allowed")
+		}
+		return
+	}
+	// the other keywords are ignored along with $ref, as draft 7 does
+	if ref, ok := m["$ref"].(string); ok {
+		target, err := s.resolve(ref)
+		if err != nil {
+			fail("%s", err.Error())
+			return
+		}
+		s.validate(target, v, path, errs)
+		return
+	}
+
+	if t, ok := m["type"]; ok {
+		var types []string
+		switch tv := t.(type) {
+		case string:
+			types = []string{tv}
+		case []interface{}:
+			for _, item := range tv {
+				if str, ok := item.(string); ok {
+					types = append(types, str)
+				}
+			}
+		}
+		matched := false
+		for _, typ := range types {
+			if jsonTypeMatches(typ, v) {
+				matched = true
+				break
+			}
+		}
+		if len(types) > 0 && !matched {
+			fail("expected %s, but got %s", strings.Join(types, " or "), jsonTypeOf(v))
+			return
+		}
+	}
+	if enum, ok := m["enum"].([]interface{}); ok {
+		found := false
+		for _, item := range enum {
+			if reflect.DeepEqual(item, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("the value should be one of %s", toJSONString(enum))
+		}
+	}
+	if c, ok := m["const"]; ok && !reflect.DeepEqual(c, v) {
+		fail("the value should be %s", toJSONString(c))
+	}
+
+	switch val := v.(type) {
+	case float64:
+		s.validateNumber(m, val, fail)
+	case string:
+		s.validateString(m, val, fail)
+	case []interface{}:
+		s.validateArray(m, val, path, errs, fail)
+	case map[string]interface{}:
+		s.validateObject(m, val, path, errs, fail)
+	}
+
+	if all, ok := m["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			s.validate(sub, v, path, errs)
+		}
+	}
+	if anyOf, ok := m["anyOf"].([]interface{}); ok && s.countMatched(anyOf, v, path) == 0 {
+		fail("the value does not match any schema of anyOf")
+	}
+	if oneOf, ok := m["oneOf"].([]interface{}); ok {
+		if n := s.countMatched(oneOf, v, path); n != 1 {
+			fail("the value should match exactly one schema of oneOf, but matches %d", n)
+		}
+	}
+	if not, ok := m["not"]; ok && s.countMatched([]interface{}{not}, v, path) == 1 {
+		fail("the value should not match the schema of not")
+	}
+}
+
+func (s *JSONSchema) validateNumber(m map[string]interface{}, val float64, fail func(string, ...interface{})) {
+	if min, ok := m["minimum"].(float64); ok && val < min {
+		fail("the value should be >= %s", formatJSONNumber(min))
+	}
+	if max, ok := m["maximum"].(float64); ok && val > max {
+		fail("the value should be <= %s", formatJSONNumber(max))
+	}
+	if min, ok := m["exclusiveMinimum"].(float64); ok && val <= min {
+		fail("the value should be > %s", formatJSONNumber(min))
+	}
+	if max, ok := m["exclusiveMaximum"].(float64); ok && val >= max {
+		fail("the value should be < %s", formatJSONNumber(max))
+	}
+	if mul, ok := m["multipleOf"].(float64); ok && mul > 0 {
+		if q := val / mul; math.Abs(q-math.Round(q)) > 1e-9 {
+			fail("the value should be a multiple of %s", formatJSONNumber(mul))
+		}
+	}
+}
+
+func (s *JSONSchema) validateString(m map[string]interface{}, val string, fail func(string, ...interface{})) {
+	length := float64(utf8.RuneCountInString(val))
+	if min, ok := m["minLength"].(float64); ok && length < min {
+		fail("the length should be >= %s", formatJSONNumber(min))
+	}
+	if max, ok := m["maxLength"].(float64); ok && length > max {
+		fail("the length should be <= %s", formatJSONNumber(max))
+	}
+	if p, ok := m["pattern"].(string); ok && !s.patterns[p].MatchString(val) {
+		fail("the value should match the pattern %q", p)
+	}
+}
+
+func (s *JSONSchema) validateArray(m map[string]interface{}, val []interface{}, path string, errs *[]string, fail func(string, ...interface{})) {
+	n := float64(len(val))
+	if min, ok := m["minItems"].(float64); ok && n < min {
+		fail("the array should have at least %s items", formatJSONNumber(min))
+	}
+	if max, ok := m["maxItems"].(float64); ok && n > max {
+		fail("the array should have at most %s items", formatJSONNumber(max))
+	}
+	if unique, ok := m["uniqueItems"].(bool); ok && unique {
+		for i := range val {
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(val[i], val[j]) {
+					fail("the items %d and %d are duplicated", j, i)
+				}
+			}
+		}
+	}
+	switch items := m["items"].(type) {
+	case []interface{}:
+		// the tuple validation, the items beyond are validated by additionalItems
+		for i, item := range val {
+			if i < len(items) {
+				s.validate(items[i], item, path+"/"+strconv.Itoa(i), errs)
+			} else if additional, ok := m["additionalItems"]; ok {
+				s.validate(additional, item, path+"/"+strconv.Itoa(i), errs)
+			}
+		}
+	case nil:
+	default:
+		for i, item := range val {
+			s.validate(items, item, path+"/"+strconv.Itoa(i), errs)
+		}
+	}
+	if contains, ok := m["contains"]; ok {
+		found := false
+		for _, item := range val {
+			if s.countMatched([]interface{}{contains}, item, path) == 1 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("the array should contain an item matching the schema of contains")
+		}
+	}
+}
+
+func (s *JSONSchema) validateObject(m map[string]interface{}, val map[string]interface{}, path string, errs *[]string, fail func(string, ...interface{})) {
+	n := float64(len(val))
+	if min, ok := m["minProperties"].(float64); ok && n < min {
+		fail("the object should have at least %s properties", formatJSONNumber(min))
+	}
+	if max, ok := m["maxProperties"].(float64); ok && n > max {
+		fail("the object should have at most %s properties", formatJSONNumber(max))
+	}
+	if required, ok := m["required"].([]interface{}); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, ok := val[name]; !ok {
+					fail("the property %q is required", name)
+				}
+			}
+		}
+	}
+	props, _ := m["properties"].(map[string]interface{})
+	patternProps, _ := m["patternProperties"].(map[string]interface{})
+	additional, hasAdditional := m["additionalProperties"]
+	names := make([]string, 0, len(val))
+	for k := range val {
+		names = append(names, k)
+	}
+	// the properties are validated in order so that the errors are stable
+	sort.Strings(names)
+	for _, k := range names {
+		p := path + "/" + escapeJSONPointer(k)
+		matched := false
+		if sub, ok := props[k]; ok {
+			matched = true
+			s.validate(sub, val[k], p, errs)
+		}
+		for pattern, sub := range patternProps {
+			if s.patterns[pattern].MatchString(k) {
+				matched = true
+				s.validate(sub, val[k], p, errs)
+			}
+		}
+		if matched || !hasAdditional {
+			continue
+		}
+		if b, ok := additional.(bool); ok && !b {
+			fail("the property %q is not allowed", k)
+			continue
+		}
+		s.validate(additional, val[k], p, errs)
+	}
+	if names, ok := m["propertyNames"]; ok {
+		for k := range val {
+			var sub []string
+			s.validate(names, k, path, &sub)
+			if len(sub) > 0 {
+				fail("the property name %q is invalid", k)
+			}
+		}
+	}
+}
+
+func (s *JSONSchema) countMatched(schemas []interface{}, v interface{}, path string) int {
+	n := 0
+	for _, sub := range schemas {
+		var errs []string
+		s.validate(sub, v, path, &errs)
+		if len(errs) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// resolve resolves the $ref in the schema itself, such as #/definitions/rule
+func (s *JSONSchema) resolve(ref string) (interface{}, error) {
+	if ref == "#" {
+		return s.root, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("the $ref %s is not supported, only the refs in the schema itself are", ref)
+	}
+	cur := s.root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			next, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("the $ref %s is not found", ref)
+			}
+			cur = next
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("the $ref %s is not found", ref)
+			}
+			cur = v[i]
+		default:
+			return nil, fmt.Errorf("the $ref %s is not found", ref)
+		}
+	}
+	return cur, nil
+}
+
+func jsonTypeMatches(typ string, v interface{}) bool {
+	switch typ {
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	default:
+		return jsonTypeOf(v) == typ
+	}
+}
+
+func jsonTypeOf(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func formatJSONNumber(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func toJSONString(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func jsonSchemaError(msg string) error {
+	return common.Error(common.ErrRequestParamInvalid, common.Field("error", "invalid json schema: "+msg))
+}
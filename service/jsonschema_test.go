@@ -0,0 +1,118 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testRuleSchema = `{
+  "type": "object",
+  "required": ["name", "rules"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string", "pattern": "^[a-z]+$", "maxLength": 8},
+    "level": {"enum": ["info", "warn"]},
+    "rules": {
+      "type": "array",
+      "minItems": 1,
+      "uniqueItems": true,
+      "items": {"$ref": "#/definitions/rule"}
+    }
+  },
+  "definitions": {
+    "rule": {
+      "type": "object",
+      "required": ["threshold"],
+      "properties": {
+        "threshold": {"type": "number", "minimum": 0, "exclusiveMaximum": 100},
+        "count": {"type": "integer", "multipleOf": 2},
+        "action": {"oneOf": [{"const": "alarm"}, {"type": "object", "required": ["webhook"]}]}
+      }
+    }
+  }
+}`
+
+func TestJSONSchemaValidate(t *testing.T) {
+	s, err := ParseJSONSchema([]byte(testRuleSchema))
+	assert.NoError(t, err)
+
+	doc, err := ParseSchemaDocument([]byte(`{"name":"temp","level":"info","rules":[{"threshold":30,"count":4,"action":"alarm"}]}`), false)
+	assert.NoError(t, err)
+	assert.Empty(t, s.Validate(doc))
+
+	doc, err = ParseSchemaDocument([]byte(`
+name: Temp
+level: debug
+extra: 1
+rules:
+- threshold: 100
+  count: 3
+  action: {}
+- threshold: "30"
+`), true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"/: the property \"extra\" is not allowed",
+		"/level: the value should be one of [\"info\",\"warn\"]",
+		"/name: the value should match the pattern \"^[a-z]+$\"",
+		"/rules/0/action: the value should match exactly one schema of oneOf, but matches 0",
+		"/rules/0/count: the value should be a multiple of 2",
+		"/rules/0/threshold: the value should be < 100",
+		"/rules/1/threshold: expected number, but got string",
+	}, s.Validate(doc))
+
+	doc, err = ParseSchemaDocument([]byte(`{"rules":[]}`), false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"/: the property \"name\" is required",
+		"/rules: the array should have at least 1 items",
+	}, s.Validate(doc))
+
+	doc, err = ParseSchemaDocument([]byte(`[1]`), false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/: expected object, but got array"}, s.Validate(doc))
+}
+
+func TestJSONSchemaKeywords(t *testing.T) {
+	cases := []struct {
+		schema, doc string
+		valid       bool
+	}{
+		{`true`, `1`, true},
+		{`false`, `1`, false},
+		{`{"type":["string","null"]}`, `null`, true},
+		{`{"type":"integer"}`, `1.5`, false},
+		{`{"minLength":2}`, `"中文"`, true},
+		{`{"maxLength":1}`, `"中文"`, false},
+		{`{"anyOf":[{"type":"string"},{"minimum":3}]}`, `2`, false},
+		{`{"anyOf":[{"type":"string"},{"minimum":3}]}`, `3`, true},
+		{`{"allOf":[{"minimum":1},{"maximum":2}]}`, `3`, false},
+		{`{"not":{"type":"string"}}`, `"a"`, false},
+		{`{"items":[{"type":"string"}],"additionalItems":false}`, `["a",1]`, false},
+		{`{"contains":{"const":2}}`, `[1,2]`, true},
+		{`{"patternProperties":{"^x-":{"type":"string"}},"additionalProperties":false}`, `{"x-a":"b"}`, true},
+		{`{"patternProperties":{"^x-":{"type":"string"}},"additionalProperties":false}`, `{"y":"b"}`, false},
+		{`{"propertyNames":{"maxLength":2}}`, `{"abc":1}`, false},
+		{`{"maxProperties":1}`, `{"a":1,"b":2}`, false},
+		{`{"$ref":"#/definitions/a~1b","definitions":{"a/b":{"type":"string"}}}`, `"a"`, true},
+		{`{"$ref":"#/definitions/none"}`, `"a"`, false},
+		{`{"format":"email"}`, `"a"`, true},
+	}
+	for _, c := range cases {
+		s, err := ParseJSONSchema([]byte(c.schema))
+		assert.NoError(t, err, c.schema)
+		doc, err := ParseSchemaDocument([]byte(c.doc), false)
+		assert.NoError(t, err, c.doc)
+		assert.Equal(t, c.valid, len(s.Validate(doc)) == 0, c.schema+" "+c.doc)
+	}
+}
+
+func TestParseJSONSchemaInvalid(t *testing.T) {
+	_, err := ParseJSONSchema([]byte(`{"type":`))
+	assert.Error(t, err)
+	_, err = ParseJSONSchema([]byte(`"string"`))
+	assert.Error(t, err)
+	_, err = ParseJSONSchema([]byte(`{"properties":{"a":{"pattern":"["}}}`))
+	assert.Error(t, err)
+}
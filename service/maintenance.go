@@ -0,0 +1,235 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/maintenance.go -package=service github.com/baetyl/baetyl-cloud/v2/service MaintenanceService
+
+// maxMaintenanceDuration the longest window, which bounds the search of the window open at a time
+const maxMaintenanceDuration = 7 * 24 * time.Hour
+
+type MaintenanceService interface {
+	// Get returns the maintenance window in effect for node at the time, the window set on node takes precedence
+	// over the ones of its groups, and the first group by name is taken if the node is in several groups
+	Get(node *specV1.Node, t time.Time) (*models.NodeMaintenance, error)
+	// IsOpen returns whether the desired state can be delivered to node at the time,
+	// which is always true if no window is in effect
+	IsOpen(node *specV1.Node, t time.Time) (bool, error)
+}
+
+type MaintenanceServiceImpl struct {
+	groups *nodeGroupCache
+}
+
+func NewMaintenanceService(config *config.CloudConfig) (MaintenanceService, error) {
+	group, err := NewNodeGroupService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &MaintenanceServiceImpl{
+		groups: newNodeGroupCache(group, config.Cache.ExpirationDuration),
+	}, nil
+}
+
+func (s *MaintenanceServiceImpl) Get(node *specV1.Node, t time.Time) (*models.NodeMaintenance, error) {
+	res := &models.NodeMaintenance{}
+	window, err := GetNodeMaintenanceWindow(node)
+	if err != nil {
+		return nil, err
+	}
+	if window == nil {
+		group, err := s.groups.match(node, func(group *models.NodeGroup) bool { return group.MaintenanceWindow != nil })
+		if err != nil {
+			return nil, err
+		}
+		if group != nil {
+			window, res.Group = group.MaintenanceWindow, group.Name
+		}
+	}
+	if window == nil {
+		res.Open = true
+		return res, nil
+	}
+	res.Window = window
+	res.Open, res.Start, err = maintenanceWindowAt(window, t)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *MaintenanceServiceImpl) IsOpen(node *specV1.Node, t time.Time) (bool, error) {
+	res, err := s.Get(node, t)
+	if err != nil {
+		return false, err
+	}
+	return res.Open, nil
+}
+
+// GetNodeMaintenanceWindow returns the maintenance window set on node, which is nil if not set
+func GetNodeMaintenanceWindow(node *specV1.Node) (*models.MaintenanceWindow, error) {
+	window := new(models.MaintenanceWindow)
+	if ok, err := decodeNodeAttribute(node, common.AttributeMaintenanceWindow, window); !ok {
+		return nil, err
+	}
+	return window, nil
+}
+
+// ValidateMaintenanceWindow checks the cron expression, duration and timezone of window
+func ValidateMaintenanceWindow(window *models.MaintenanceWindow) error {
+	if err := common.ValidateStruct(window); err != nil {
+		return err
+	}
+	if _, err := parseCron(window.Cron); err != nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	if d, err := time.ParseDuration(window.Duration); err != nil || d > maxMaintenanceDuration {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("the duration of maintenance window should be at most %s", maxMaintenanceDuration)))
+	}
+	if _, err := time.LoadLocation(window.Timezone); err != nil {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	return nil
+}
+
+// maintenanceWindowAt returns whether the window is open at t, with the start of the open window or of the next one
+func maintenanceWindowAt(window *models.MaintenanceWindow, t time.Time) (bool, time.Time, error) {
+	schedule, err := parseCron(window.Cron)
+	if err != nil {
+		return false, time.Time{}, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	d, err := time.ParseDuration(window.Duration)
+	if err != nil {
+		return false, time.Time{}, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	loc, err := time.LoadLocation(window.Timezone)
+	if err != nil {
+		return false, time.Time{}, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+	}
+	t = t.In(loc)
+	// the window started in (t-d, t] is still open
+	if start := schedule.next(t.Add(-d).Truncate(time.Minute).Add(time.Minute)); !start.IsZero() && !start.After(t) {
+		return true, start, nil
+	}
+	return false, schedule.next(t), nil
+}
+
+// cronSchedule the schedule of the standard cron expression, each field is stored as the bits of matched values
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	anyDom, anyDow                bool
+}
+
+var cronFieldBounds = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFieldBounds) {
+		return nil, fmt.Errorf("the cron expression (%s) should have 5 fields", expr)
+	}
+	var bits [5]uint64
+	for i, field := range fields {
+		b, err := parseCronField(field, cronFieldBounds[i][0], cronFieldBounds[i][1])
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = b
+	}
+	// both 0 and 7 are sunday
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+	return &cronSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		anyDom: strings.HasPrefix(fields[2], "*"),
+		anyDow: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses the field made up of values, ranges and steps separated by comma, such as 1,5-10,*/15
+func parseCronField(field string, min, max int) (uint64, error) {
+	var res uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("the step of cron field (%s) is invalid", part)
+			}
+			rng, step = part[:i], s
+		}
+		lo, hi := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("the value of cron field (%s) is invalid", part)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("the value of cron field (%s) is invalid", part)
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("the value of cron field (%s) should be between %d and %d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			res |= 1 << uint(v)
+		}
+	}
+	return res, nil
+}
+
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	// the same as cron, the day matches either field if both are restricted
+	if s.anyDom || s.anyDow {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// next returns the first minute matching the schedule not before t, which is zero if there is none in 5 years
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.Add(time.Minute - 1).Truncate(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
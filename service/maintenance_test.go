@@ -0,0 +1,127 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestParseCron(t *testing.T) {
+	s, err := parseCron("*/15 1-3,22 * * 1-5")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1|1<<15|1<<30|1<<45), s.minute)
+	assert.Equal(t, uint64(1<<1|1<<2|1<<3|1<<22), s.hour)
+	assert.True(t, s.anyDom)
+	assert.False(t, s.anyDow)
+
+	s, err = parseCron("0 0 * * 7")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), s.dow)
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		_, err = parseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	s, err := parseCron("30 2 * * 1-5")
+	assert.NoError(t, err)
+	// 2021-01-01 is friday
+	start := time.Date(2021, 1, 1, 3, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2021, 1, 4, 2, 30, 0, 0, time.UTC), s.next(start))
+	assert.Equal(t, time.Date(2021, 1, 1, 2, 30, 0, 0, time.UTC), s.next(time.Date(2021, 1, 1, 2, 29, 30, 0, time.UTC)))
+
+	// either day field matches if both are restricted
+	s, err = parseCron("0 0 15 * 0")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC), s.next(start))
+	assert.Equal(t, time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC), s.next(time.Date(2021, 1, 11, 0, 0, 0, 0, time.UTC)))
+
+	s, err = parseCron("0 0 31 2 *")
+	assert.NoError(t, err)
+	assert.True(t, s.next(start).IsZero())
+}
+
+func TestMaintenanceWindowAt(t *testing.T) {
+	window := &models.MaintenanceWindow{Cron: "0 22 * * *", Duration: "8h", Timezone: "Asia/Shanghai"}
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+
+	open, start, err := maintenanceWindowAt(window, time.Date(2021, 1, 2, 3, 0, 0, 0, loc))
+	assert.NoError(t, err)
+	assert.True(t, open)
+	assert.True(t, time.Date(2021, 1, 1, 22, 0, 0, 0, loc).Equal(start))
+
+	open, start, err = maintenanceWindowAt(window, time.Date(2021, 1, 2, 6, 0, 0, 0, loc))
+	assert.NoError(t, err)
+	assert.False(t, open)
+	assert.True(t, time.Date(2021, 1, 2, 22, 0, 0, 0, loc).Equal(start))
+
+	// the same time in UTC
+	open, _, err = maintenanceWindowAt(window, time.Date(2021, 1, 1, 19, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, open)
+
+	_, _, err = maintenanceWindowAt(&models.MaintenanceWindow{Cron: "0 22 * * *", Duration: "8h", Timezone: "Unknown/Zone"}, time.Now())
+	assert.Error(t, err)
+}
+
+func TestValidateMaintenanceWindow(t *testing.T) {
+	assert.NoError(t, ValidateMaintenanceWindow(&models.MaintenanceWindow{Cron: "0 2 * * *", Duration: "2h"}))
+	for _, w := range []*models.MaintenanceWindow{
+		{Duration: "2h"},
+		{Cron: "0 2 * * *"},
+		{Cron: "0 2 * *", Duration: "2h"},
+		{Cron: "0 2 * * *", Duration: "200h"},
+		{Cron: "0 2 * * *", Duration: "2h", Timezone: "Unknown/Zone"},
+	} {
+		assert.Error(t, ValidateMaintenanceWindow(w), w)
+	}
+}
+
+func TestMaintenanceGet(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mGroup := ms.NewMockNodeGroupService(mockCtl)
+	s := &MaintenanceServiceImpl{groups: newNodeGroupCache(mGroup, time.Minute)}
+
+	night := &models.MaintenanceWindow{Cron: "0 0 * * *", Duration: "6h"}
+	weekend := &models.MaintenanceWindow{Cron: "0 0 * * 6", Duration: "48h"}
+	mGroup.EXPECT().List("default", &models.ListOptions{}).Return(&models.NodeGroupList{Items: []models.NodeGroup{
+		{Name: "store-b", Selector: "store=true", MaintenanceWindow: night},
+		{Name: "store-a", Selector: "store=true", MaintenanceWindow: weekend},
+		{Name: "all", Selector: "store=true"},
+	}}, nil)
+
+	// 2021-01-04 is monday
+	at := time.Date(2021, 1, 4, 3, 0, 0, 0, time.UTC)
+	res, err := s.Get(&specV1.Node{Namespace: "default", Name: "node01"}, at)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.NodeMaintenance{Open: true}, res)
+
+	node := &specV1.Node{Namespace: "default", Name: "node02", Labels: map[string]string{"store": "true"}}
+	res, err = s.Get(node, at)
+	assert.NoError(t, err)
+	assert.Equal(t, "store-a", res.Group)
+	assert.False(t, res.Open)
+	assert.Equal(t, time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC), res.Start)
+
+	// the window of node takes precedence
+	node.Attributes = map[string]interface{}{
+		common.AttributeMaintenanceWindow: map[string]interface{}{"cron": "0 0 * * *", "duration": "6h"},
+	}
+	open, err := s.IsOpen(node, at)
+	assert.NoError(t, err)
+	assert.True(t, open)
+	res, err = s.Get(node, at)
+	assert.NoError(t, err)
+	assert.Equal(t, night, res.Window)
+	assert.Empty(t, res.Group)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/metrics.go -package=service github.com/baetyl/baetyl-cloud/v2/service NodeMetricsService
+
+// metricsPurgeInterval the interval to delete the points out of retention
+const metricsPurgeInterval = time.Hour
+
+type NodeMetricsService interface {
+	Record(namespace, name string, report specV1.Report) error
+	Query(namespace, name string, start, end time.Time, step time.Duration) (*models.NodeMetrics, error)
+}
+
+type NodeMetricsServiceImpl struct {
+	Metrics   plugin.NodeMetrics
+	interval  time.Duration
+	retention time.Duration
+	recorded  map[string]time.Time
+	purged    time.Time
+	mutex     sync.Mutex
+}
+
+func NewNodeMetricsService(config *config.CloudConfig) (NodeMetricsService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.Metrics)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeMetricsServiceImpl{
+		Metrics:   p.(plugin.NodeMetrics),
+		interval:  config.NodeMetrics.Interval,
+		retention: config.NodeMetrics.Retention,
+		recorded:  map[string]time.Time{},
+	}, nil
+}
+
+// Record persists the resource usage in report, the reports within the interval of the last recorded one are skipped
+func (s *NodeMetricsServiceImpl) Record(namespace, name string, report specV1.Report) error {
+	resources := decodeNodeResources(report)
+	if len(resources) == 0 {
+		return nil
+	}
+	var point models.NodeMetricsPoint
+	if err := common.DecodeReport(report, "time", &point.Time); err != nil || point.Time.IsZero() {
+		point.Time = time.Now().UTC()
+	}
+	// the usage of cluster is the sum of its nodes
+	for _, res := range resources {
+		point.CPUUsage += float64(parseQuantity(res.Usage["cpu"]).MilliValue()) / 1000
+		point.CPUCapacity += float64(parseQuantity(res.Capacity["cpu"]).MilliValue()) / 1000
+		point.MemoryUsage += parseQuantity(res.Usage["memory"]).Value()
+		point.MemoryCapacity += parseQuantity(res.Capacity["memory"]).Value()
+		point.DiskUsage += parseQuantity(res.Usage["disk"]).Value()
+		point.DiskCapacity += parseQuantity(res.Capacity["disk"]).Value()
+	}
+
+	key := namespace + "/" + name
+	s.mutex.Lock()
+	if last, ok := s.recorded[key]; ok && point.Time.Sub(last) < s.interval {
+		s.mutex.Unlock()
+		return nil
+	}
+	s.recorded[key] = point.Time
+	purge := time.Since(s.purged) >= metricsPurgeInterval
+	if purge {
+		s.purged = time.Now()
+	}
+	s.mutex.Unlock()
+
+	if purge && s.retention > 0 {
+		if err := s.Metrics.DeleteNodeMetrics(time.Now().Add(-s.retention)); err != nil {
+			return err
+		}
+	}
+	return s.Metrics.CreateNodeMetrics(namespace, name, &point)
+}
+
+// Query returns the points of node in [start, end), the points in the same step are averaged
+func (s *NodeMetricsServiceImpl) Query(namespace, name string, start, end time.Time, step time.Duration) (*models.NodeMetrics, error) {
+	points, err := s.Metrics.ListNodeMetrics(namespace, name, start, end)
+	if err != nil {
+		return nil, err
+	}
+	res := &models.NodeMetrics{
+		Namespace: namespace,
+		Name:      name,
+		Start:     start.UTC(),
+		End:       end.UTC(),
+		Step:      int(step / time.Second),
+		Points:    []models.NodeMetricsPoint{},
+	}
+	var sum models.NodeMetricsPoint
+	count, bucket := 0, int64(-1)
+	flush := func() {
+		if count == 0 {
+			return
+		}
+		res.Points = append(res.Points, models.NodeMetricsPoint{
+			Time:           res.Start.Add(time.Duration(bucket) * step),
+			CPUUsage:       sum.CPUUsage / float64(count),
+			CPUCapacity:    sum.CPUCapacity / float64(count),
+			MemoryUsage:    sum.MemoryUsage / int64(count),
+			MemoryCapacity: sum.MemoryCapacity / int64(count),
+			DiskUsage:      sum.DiskUsage / int64(count),
+			DiskCapacity:   sum.DiskCapacity / int64(count),
+		})
+		sum, count = models.NodeMetricsPoint{}, 0
+	}
+	for _, p := range points {
+		if b := int64(p.Time.Sub(start) / step); b != bucket {
+			flush()
+			bucket = b
+		}
+		sum.CPUUsage += p.CPUUsage
+		sum.CPUCapacity += p.CPUCapacity
+		sum.MemoryUsage += p.MemoryUsage
+		sum.MemoryCapacity += p.MemoryCapacity
+		sum.DiskUsage += p.DiskUsage
+		sum.DiskCapacity += p.DiskCapacity
+		count++
+	}
+	flush()
+	return res, nil
+}
+
+// parseQuantity parses the reported quantity, zero is returned if it is not reported or invalid
+func parseQuantity(val string) resource.Quantity {
+	q, err := resource.ParseQuantity(val)
+	if err != nil {
+		return resource.Quantity{}
+	}
+	return q
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestNodeMetricsRecord(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.Metrics = common.RandString(9)
+	conf.NodeMetrics.Interval = time.Minute
+	conf.NodeMetrics.Retention = time.Hour
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mMetrics := mockPlugin.NewMockNodeMetrics(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.Metrics, func() (plugin.Plugin, error) {
+		return mMetrics, nil
+	})
+	ms, err := NewNodeMetricsService(conf)
+	assert.NoError(t, err)
+
+	// no stats
+	assert.NoError(t, ms.Record("default", "node01", specV1.Report{"time": time.Now()}))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	report := specV1.Report{
+		"time": now.Format(time.RFC3339Nano),
+		common.NodeStats: map[string]interface{}{
+			"master": map[string]interface{}{
+				"usage":    map[string]string{"cpu": "500m", "memory": "512Mi"},
+				"capacity": map[string]string{"cpu": "2", "memory": "1Gi", "disk": "10Gi"},
+			},
+			"worker": map[string]interface{}{
+				"usage":    map[string]string{"cpu": "250000000n", "memory": "256Mi"},
+				"capacity": map[string]string{"cpu": "2", "memory": "1Gi"},
+			},
+		},
+	}
+	expect := &models.NodeMetricsPoint{
+		Time:           now,
+		CPUUsage:       0.75,
+		CPUCapacity:    4,
+		MemoryUsage:    768 * 1024 * 1024,
+		MemoryCapacity: 2 * 1024 * 1024 * 1024,
+		DiskCapacity:   10 * 1024 * 1024 * 1024,
+	}
+	mMetrics.EXPECT().DeleteNodeMetrics(gomock.Any()).Return(nil)
+	mMetrics.EXPECT().CreateNodeMetrics("default", "node01", expect).Return(nil)
+	assert.NoError(t, ms.Record("default", "node01", report))
+
+	// within interval
+	report["time"] = now.Add(30 * time.Second).Format(time.RFC3339Nano)
+	assert.NoError(t, ms.Record("default", "node01", report))
+
+	report["time"] = now.Add(time.Minute).Format(time.RFC3339Nano)
+	report[common.NodeStats] = specV1.NodeStats{
+		Usage:    map[string]string{"cpu": "1", "memory": "1Gi"},
+		Capacity: map[string]string{"cpu": "2", "memory": "2Gi"},
+	}
+	mMetrics.EXPECT().CreateNodeMetrics("default", "node01", &models.NodeMetricsPoint{
+		Time:           now.Add(time.Minute),
+		CPUUsage:       1,
+		CPUCapacity:    2,
+		MemoryUsage:    1024 * 1024 * 1024,
+		MemoryCapacity: 2 * 1024 * 1024 * 1024,
+	}).Return(nil)
+	assert.NoError(t, ms.Record("default", "node01", report))
+}
+
+func TestNodeMetricsQuery(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mMetrics := mockPlugin.NewMockNodeMetrics(mockCtl)
+	ms := &NodeMetricsServiceImpl{Metrics: mMetrics}
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+	mMetrics.EXPECT().ListNodeMetrics("default", "node01", start, end).Return([]models.NodeMetricsPoint{
+		{Time: start.Add(10 * time.Second), CPUUsage: 1, MemoryUsage: 100},
+		{Time: start.Add(70 * time.Second), CPUUsage: 2, MemoryUsage: 200},
+		{Time: start.Add(290 * time.Second), CPUUsage: 3, MemoryUsage: 300},
+		{Time: start.Add(310 * time.Second), CPUUsage: 4, MemoryUsage: 400},
+	}, nil)
+	res, err := ms.Query("default", "node01", start, end, 5*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 300, res.Step)
+	assert.Equal(t, []models.NodeMetricsPoint{
+		{Time: start, CPUUsage: 2, MemoryUsage: 200},
+		{Time: start.Add(5 * time.Minute), CPUUsage: 4, MemoryUsage: 400},
+	}, res.Points)
+
+	mMetrics.EXPECT().ListNodeMetrics("default", "node02", start, end).Return(nil, nil)
+	res, err = ms.Query("default", "node02", start, end, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, res.Points, 0)
+}
@@ -1,6 +1,12 @@
 package service
 
 import (
+	"fmt"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	"github.com/baetyl/baetyl-go/v2/utils"
+
 	"github.com/baetyl/baetyl-cloud/v2/common"
 	"github.com/baetyl/baetyl-cloud/v2/config"
 	"github.com/baetyl/baetyl-cloud/v2/models"
@@ -13,6 +19,8 @@ type ModuleService interface {
 	GetModules(name string) ([]models.Module, error)
 	GetModuleByVersion(name, version string) (*models.Module, error)
 	GetModuleByImage(name, image string) (*models.Module, error)
+	// GetLatestModule returns the version of module installed by default, which is the one marked latest unless
+	// it's deprecated or requires a newer cloud, the newest version available is returned instead
 	GetLatestModule(name string) (*models.Module, error)
 	CreateModule(module *models.Module) (*models.Module, error)
 	UpdateModuleByVersion(module *models.Module) (*models.Module, error)
@@ -30,5 +38,68 @@ func NewModuleService(config *config.CloudConfig) (ModuleService, error) {
 	if err != nil {
 		return nil, err
 	}
-	return ds.(plugin.Module), nil
+	return &ModuleServiceImpl{Module: ds.(plugin.Module), cloudVersion: utils.VERSION}, nil
+}
+
+// ModuleServiceImpl resolves the latest versions of modules by the catalog, the others are read from plugin
+type ModuleServiceImpl struct {
+	plugin.Module
+	cloudVersion string
+}
+
+func (s *ModuleServiceImpl) GetLatestModule(name string) (*models.Module, error) {
+	latest, err := s.Module.GetLatestModule(name)
+	if err == nil && s.available(latest) {
+		return latest, nil
+	}
+	if e, ok := err.(errors.Coder); err != nil && (!ok || e.Code() != common.ErrResourceNotFound) {
+		return nil, err
+	}
+	modules, e := s.Module.GetModules(name)
+	if e != nil {
+		if latest != nil {
+			return latest, nil
+		}
+		return nil, err
+	}
+	var res *models.Module
+	for i := range modules {
+		if s.available(&modules[i]) && (res == nil || common.CompareVersion(modules[i].Version, res.Version) > 0) {
+			res = &modules[i]
+		}
+	}
+	if res == nil {
+		if latest == nil {
+			return nil, err
+		}
+		// the nodes are still installed with the latest version rather than failing
+		log.L().Warn("no version of module is available, the latest one is used", log.Any("name", name), log.Any("version", latest.Version))
+		return latest, nil
+	}
+	return res, nil
+}
+
+func (s *ModuleServiceImpl) GetLatestModuleImage(name string) (string, error) {
+	module, err := s.GetLatestModule(name)
+	if err != nil {
+		return "", err
+	}
+	return module.Image, nil
+}
+
+func (s *ModuleServiceImpl) GetLatestModuleProgram(name, platform string) (string, error) {
+	module, err := s.GetLatestModule(name)
+	if err != nil {
+		return "", err
+	}
+	if program, ok := module.Programs[platform]; ok {
+		return program, nil
+	}
+	return "", common.Error(common.ErrResourceNotFound,
+		common.Field("type", "program"),
+		common.Field("name", fmt.Sprintf("%s-%s", name, platform)))
+}
+
+func (s *ModuleServiceImpl) available(module *models.Module) bool {
+	return !module.Deprecated && module.SupportsCloud(s.cloudVersion)
 }
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestModuleGetLatestModule(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mModule := mockPlugin.NewMockModule(mockCtl)
+	s := &ModuleServiceImpl{Module: mModule, cloudVersion: "v2.4.1"}
+
+	latest := &models.Module{Name: "baetyl", Version: "v2.5.0", Image: "baetyl:v2.5.0", IsLatest: true, MinCloudVersion: "v2.5.0"}
+	modules := []models.Module{
+		*latest,
+		{Name: "baetyl", Version: "v2.4.2", Image: "baetyl:v2.4.2", Deprecated: true},
+		{Name: "baetyl", Version: "v2.4.1", Image: "baetyl:v2.4.1", MinCloudVersion: "v2.4.0", Programs: map[string]string{"linux-amd64": "url-v2.4.1"}},
+		{Name: "baetyl", Version: "v2.3.0", Image: "baetyl:v2.3.0"},
+	}
+
+	// the latest one is used if available
+	mModule.EXPECT().GetLatestModule("baetyl").Return(&modules[2], nil)
+	res, err := s.GetLatestModule("baetyl")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.4.1", res.Version)
+
+	// the newest available one is used if the latest requires a newer cloud
+	mModule.EXPECT().GetLatestModule("baetyl").Return(latest, nil).Times(3)
+	mModule.EXPECT().GetModules("baetyl").Return(modules, nil).Times(3)
+	res, err = s.GetLatestModule("baetyl")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.4.1", res.Version)
+	image, err := s.GetLatestModuleImage("baetyl")
+	assert.NoError(t, err)
+	assert.Equal(t, "baetyl:v2.4.1", image)
+	_, err = s.GetLatestModuleProgram("baetyl", "linux-arm64")
+	assert.Error(t, err)
+
+	// the clouds built from sources support all versions
+	s.cloudVersion = "git-4a62dfc"
+	mModule.EXPECT().GetLatestModule("baetyl").Return(latest, nil)
+	res, err = s.GetLatestModule("baetyl")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.5.0", res.Version)
+
+	// the latest one is used if none is available
+	s.cloudVersion = "v2.0.0"
+	mModule.EXPECT().GetLatestModule("baetyl").Return(latest, nil)
+	mModule.EXPECT().GetModules("baetyl").Return(modules[:3], nil)
+	res, err = s.GetLatestModule("baetyl")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.5.0", res.Version)
+
+	mModule.EXPECT().GetLatestModule("baetyl").Return(nil, common.Error(common.ErrResourceNotFound))
+	mModule.EXPECT().GetModules("baetyl").Return(nil, common.Error(common.ErrResourceNotFound))
+	_, err = s.GetLatestModule("baetyl")
+	assert.Error(t, err)
+
+	mModule.EXPECT().GetLatestModule("baetyl").Return(nil, fmt.Errorf("error"))
+	_, err = s.GetLatestModule("baetyl")
+	assert.Error(t, err)
+}
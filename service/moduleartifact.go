@@ -0,0 +1,188 @@
+package service
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/moduleartifact.go -package=service github.com/baetyl/baetyl-cloud/v2/service ModuleArtifactService
+
+// ParamPlatform the param of templates carrying the platform of node, which GetPlatformImage selects the images by
+const ParamPlatform = "Platform"
+
+type ModuleArtifactService interface {
+	// Get returns the artifacts of module version, which has no artifacts if the manifest is not set
+	Get(name, version string) (*models.ModuleArtifacts, error)
+	Set(artifacts *models.ModuleArtifacts) (*models.ModuleArtifacts, error)
+	Delete(name, version string) error
+	// SelectImage returns the image of the module version of image built for the platform, the image is kept if
+	// it's not the one of any module version or it's not built for the platform
+	SelectImage(image, platform string) (string, error)
+	// SelectProgram returns the program of the latest version of module built for the platform, the program of
+	// module is returned if the manifest lists none, which GetModuleProgram of templates is backed by
+	SelectProgram(name, platform string) (string, error)
+}
+
+type ModuleArtifactServiceImpl struct {
+	ModuleArtifact plugin.ModuleArtifact
+	Module         ModuleService
+}
+
+func NewModuleArtifactService(config *config.CloudConfig) (ModuleArtifactService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.ModuleArtifact)
+	if err != nil {
+		return nil, err
+	}
+	module, err := NewModuleService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ModuleArtifactServiceImpl{ModuleArtifact: p.(plugin.ModuleArtifact), Module: module}, nil
+}
+
+func (s *ModuleArtifactServiceImpl) Get(name, version string) (*models.ModuleArtifacts, error) {
+	res, err := s.ModuleArtifact.GetModuleArtifacts(name, version)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+		res = &models.ModuleArtifacts{Name: name, Version: version, Artifacts: []models.ModuleArtifact{}}
+	}
+	return res, nil
+}
+
+func (s *ModuleArtifactServiceImpl) Set(artifacts *models.ModuleArtifacts) (*models.ModuleArtifacts, error) {
+	_, err := s.ModuleArtifact.GetModuleArtifacts(artifacts.Name, artifacts.Version)
+	if err == nil {
+		err = s.ModuleArtifact.UpdateModuleArtifacts(artifacts)
+	} else if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+		err = s.ModuleArtifact.CreateModuleArtifacts(artifacts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.ModuleArtifact.GetModuleArtifacts(artifacts.Name, artifacts.Version)
+}
+
+func (s *ModuleArtifactServiceImpl) Delete(name, version string) error {
+	return s.ModuleArtifact.DeleteModuleArtifacts(name, version)
+}
+
+func (s *ModuleArtifactServiceImpl) SelectImage(image, platform string) (string, error) {
+	if image == "" || platform == "" {
+		return image, nil
+	}
+	artifacts, err := s.ModuleArtifact.GetModuleArtifactsByImage(image)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return image, nil
+		}
+		return "", err
+	}
+	if artifact := artifacts.Select(platform); artifact != nil && artifact.Image != "" {
+		return artifact.Image, nil
+	}
+	return image, nil
+}
+
+func (s *ModuleArtifactServiceImpl) SelectProgram(name, platform string) (string, error) {
+	module, err := s.Module.GetLatestModule(name)
+	if err != nil {
+		return "", err
+	}
+	artifacts, err := s.ModuleArtifact.GetModuleArtifacts(module.Name, module.Version)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return "", err
+		}
+	}
+	if artifact := artifacts.Select(platform); artifact != nil && artifact.Program != "" {
+		return artifact.Program, nil
+	}
+	return s.Module.GetLatestModuleProgram(name, platform)
+}
+
+// ApplyModuleArtifacts rewrites the images of the services of app to the ones built for the platform, the apps in
+// native mode run the programs listed in their configs, which are kept
+func ApplyModuleArtifacts(app *specV1.Application, platform string, artifact ModuleArtifactService) error {
+	if platform == "" || app.Mode == context.RunModeNative {
+		return nil
+	}
+	var err error
+	for i := range app.InitServices {
+		if app.InitServices[i].Image, err = artifact.SelectImage(app.InitServices[i].Image, platform); err != nil {
+			return err
+		}
+	}
+	for i := range app.Services {
+		if app.Services[i].Image, err = artifact.SelectImage(app.Services[i].Image, platform); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NodePlatform returns the platform of node reported by its members, which is empty if it's unknown or the members
+// are of different platforms, whose apps keep the images of module versions. The facts checked before activation
+// are taken if the node has never reported
+func NodePlatform(node *specV1.Node) string {
+	if node == nil {
+		return ""
+	}
+	if info, ok := node.Report[common.NodeInfo]; ok && info != nil {
+		data, err := json.Marshal(info)
+		if err != nil {
+			return ""
+		}
+		var members map[string]struct {
+			OS      string `json:"os"`
+			Arch    string `json:"arch"`
+			Variant string `json:"variant"`
+		}
+		if err = json.Unmarshal(data, &members); err != nil || len(members) == 0 {
+			return ""
+		}
+		var names []string
+		for name := range members {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		platform := ""
+		for i, name := range names {
+			m := members[name]
+			p := models.Platform(m.OS, m.Arch, m.Variant)
+			if p == "" || (i > 0 && p != platform) {
+				return ""
+			}
+			platform = p
+		}
+		return platform
+	}
+	preflight, err := getNodePreflight(node)
+	if err != nil || preflight == nil {
+		return ""
+	}
+	return models.Platform(preflight.Facts.OS, preflight.Facts.Arch, "")
+}
+
+// GetPlatformImageFunc returns the template func getting the latest image of module built for the param Platform
+// of template, the latest image of module is got if the platform is unknown
+func GetPlatformImageFunc(module ModuleService, artifact ModuleArtifactService) func(params map[string]interface{}, name string) (string, error) {
+	return func(params map[string]interface{}, name string) (string, error) {
+		image, err := module.GetLatestModuleImage(name)
+		if err != nil {
+			return "", err
+		}
+		platform, _ := params[ParamPlatform].(string)
+		return artifact.SelectImage(image, platform)
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewModuleArtifactService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.ModuleArtifact = common.RandString(9)
+	_, err := NewModuleArtifactService(conf)
+	assert.Error(t, err)
+}
+
+func TestModuleArtifactService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mArtifact := mockPlugin.NewMockModuleArtifact(mockCtl)
+	mModule := ms.NewMockModuleService(mockCtl)
+	s := &ModuleArtifactServiceImpl{ModuleArtifact: mArtifact, Module: mModule}
+	notFound := common.Error(common.ErrResourceNotFound)
+
+	// the manifest without artifacts is returned if not set
+	mArtifact.EXPECT().GetModuleArtifacts("baetyl", "v2.2.0").Return(nil, notFound)
+	res, err := s.Get("baetyl", "v2.2.0")
+	assert.NoError(t, err)
+	assert.Empty(t, res.Artifacts)
+
+	artifacts := &models.ModuleArtifacts{
+		Name:    "baetyl",
+		Version: "v2.2.0",
+		Image:   "baetyltech/baetyl:v2.2.0",
+		Artifacts: []models.ModuleArtifact{
+			{Platform: "linux-arm64", Image: "baetyltech/baetyl:v2.2.0-arm64"},
+			{Platform: "linux-arm-v7", Image: "baetyltech/baetyl:v2.2.0-armv7", Program: "https://example.com/baetyl_linux_armv7.zip"},
+			{Platform: "windows-amd64", Program: "https://example.com/baetyl_windows_amd64.zip"},
+		},
+	}
+	mArtifact.EXPECT().GetModuleArtifacts("baetyl", "v2.2.0").Return(nil, notFound)
+	mArtifact.EXPECT().CreateModuleArtifacts(artifacts).Return(nil)
+	mArtifact.EXPECT().GetModuleArtifacts("baetyl", "v2.2.0").Return(artifacts, nil)
+	res, err = s.Set(artifacts)
+	assert.NoError(t, err)
+	assert.Equal(t, artifacts, res)
+
+	mArtifact.EXPECT().GetModuleArtifacts("baetyl", "v2.2.0").Return(artifacts, nil)
+	mArtifact.EXPECT().UpdateModuleArtifacts(artifacts).Return(fmt.Errorf("error"))
+	_, err = s.Set(artifacts)
+	assert.Error(t, err)
+
+	mArtifact.EXPECT().GetModuleArtifactsByImage(artifacts.Image).Return(artifacts, nil).Times(4)
+	image, err := s.SelectImage(artifacts.Image, "linux-arm64-v8")
+	assert.NoError(t, err)
+	assert.Equal(t, "baetyltech/baetyl:v2.2.0-arm64", image)
+	image, err = s.SelectImage(artifacts.Image, "linux-arm")
+	assert.NoError(t, err)
+	assert.Equal(t, "baetyltech/baetyl:v2.2.0-armv7", image)
+	// the image of module version is kept for the platforms built without images
+	image, err = s.SelectImage(artifacts.Image, "windows-amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, artifacts.Image, image)
+	image, err = s.SelectImage(artifacts.Image, "linux-amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, artifacts.Image, image)
+	// the images of no module versions are kept
+	mArtifact.EXPECT().GetModuleArtifactsByImage("nginx").Return(nil, notFound)
+	image, err = s.SelectImage("nginx", "linux-arm64")
+	assert.NoError(t, err)
+	assert.Equal(t, "nginx", image)
+
+	mModule.EXPECT().GetLatestModule("baetyl").Return(&models.Module{Name: "baetyl", Version: "v2.2.0"}, nil).Times(2)
+	mArtifact.EXPECT().GetModuleArtifacts("baetyl", "v2.2.0").Return(artifacts, nil).Times(2)
+	program, err := s.SelectProgram("baetyl", "windows-amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/baetyl_windows_amd64.zip", program)
+	mModule.EXPECT().GetLatestModuleProgram("baetyl", "linux-amd64").Return("https://example.com/baetyl_linux_amd64.zip", nil)
+	program, err = s.SelectProgram("baetyl", "linux-amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/baetyl_linux_amd64.zip", program)
+
+	mArtifact.EXPECT().DeleteModuleArtifacts("baetyl", "v2.2.0").Return(nil)
+	assert.NoError(t, s.Delete("baetyl", "v2.2.0"))
+}
+
+func TestNodePlatform(t *testing.T) {
+	assert.Equal(t, "", NodePlatform(nil))
+	assert.Equal(t, "", NodePlatform(&specV1.Node{}))
+
+	node := &specV1.Node{Report: specV1.Report{common.NodeInfo: map[string]interface{}{
+		"node01": map[string]interface{}{"os": "linux", "arch": "arm", "variant": "v7"},
+	}}}
+	assert.Equal(t, "linux-arm-v7", NodePlatform(node))
+
+	// the members of different platforms run the images of module versions
+	node.Report[common.NodeInfo].(map[string]interface{})["node02"] = map[string]interface{}{"os": "linux", "arch": "amd64"}
+	assert.Equal(t, "", NodePlatform(node))
+
+	// the facts checked before activation are taken before the first report
+	node = &specV1.Node{Attributes: map[string]interface{}{
+		common.AttributePreflight: &models.NodePreflight{Facts: models.HostFacts{OS: "Windows", Arch: "amd64"}},
+	}}
+	assert.Equal(t, "windows-amd64", NodePlatform(node))
+}
+
+func TestApplyModuleArtifacts(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mArtifact := ms.NewMockModuleArtifactService(mockCtl)
+
+	app := &specV1.Application{
+		InitServices: []specV1.Service{{Name: "init", Image: "baetyltech/init:v2.2.0"}},
+		Services:     []specV1.Service{{Name: "core", Image: "baetyltech/baetyl:v2.2.0"}},
+	}
+	assert.NoError(t, ApplyModuleArtifacts(app, "", mArtifact))
+
+	mArtifact.EXPECT().SelectImage("baetyltech/init:v2.2.0", "linux-arm64").Return("baetyltech/init:v2.2.0-arm64", nil)
+	mArtifact.EXPECT().SelectImage("baetyltech/baetyl:v2.2.0", "linux-arm64").Return("baetyltech/baetyl:v2.2.0-arm64", nil)
+	assert.NoError(t, ApplyModuleArtifacts(app, "linux-arm64", mArtifact))
+	assert.Equal(t, "baetyltech/init:v2.2.0-arm64", app.InitServices[0].Image)
+	assert.Equal(t, "baetyltech/baetyl:v2.2.0-arm64", app.Services[0].Image)
+
+	// the apps of native mode are kept
+	app.Mode = context.RunModeNative
+	assert.NoError(t, ApplyModuleArtifacts(app, "linux-amd64", mArtifact))
+}
+
+func TestGetPlatformImageFunc(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mModule := ms.NewMockModuleService(mockCtl)
+	mArtifact := ms.NewMockModuleArtifactService(mockCtl)
+	fn := GetPlatformImageFunc(mModule, mArtifact)
+
+	mModule.EXPECT().GetLatestModuleImage("baetyl").Return("baetyltech/baetyl:v2.2.0", nil).Times(2)
+	mArtifact.EXPECT().SelectImage("baetyltech/baetyl:v2.2.0", "linux-arm64").Return("baetyltech/baetyl:v2.2.0-arm64", nil)
+	image, err := fn(map[string]interface{}{ParamPlatform: "linux-arm64"}, "baetyl")
+	assert.NoError(t, err)
+	assert.Equal(t, "baetyltech/baetyl:v2.2.0-arm64", image)
+	mArtifact.EXPECT().SelectImage("baetyltech/baetyl:v2.2.0", "").Return("baetyltech/baetyl:v2.2.0", nil)
+	image, err = fn(map[string]interface{}{}, "baetyl")
+	assert.NoError(t, err)
+	assert.Equal(t, "baetyltech/baetyl:v2.2.0", image)
+
+	mModule.EXPECT().GetLatestModuleImage("baetyl").Return("", fmt.Errorf("error"))
+	_, err = fn(map[string]interface{}{}, "baetyl")
+	assert.Error(t, err)
+}
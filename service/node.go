@@ -1,9 +1,12 @@
 package service
 
 import (
+	"encoding/json"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/baetyl/baetyl-go/v2/errors"
 	"github.com/baetyl/baetyl-go/v2/log"
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 	"github.com/baetyl/baetyl-go/v2/utils"
@@ -41,6 +44,7 @@ type NodeService interface {
 	UpdateDesire(tx interface{}, namespace string, names []string, app *specV1.Application, f func(*models.Shadow, *specV1.Application)) error
 
 	GetDesire(namespace, name string) (*specV1.Desire, error)
+	WatchDesire(namespace, name string) (<-chan struct{}, func(), error)
 
 	UpdateNodeAppVersion(tx interface{}, namespace string, app *specV1.Application) ([]string, error)
 	DeleteNodeAppVersion(tx interface{}, namespace string, app *specV1.Application) ([]string, error)
@@ -56,7 +60,19 @@ type NodeServiceImpl struct {
 	Node          plugin.Node
 	Shadow        plugin.Shadow
 	SysAppService SystemAppService
-	Hooks         map[string]interface{}
+	// DesireWatch notifies the nodes watching once the desires are updated, it's nil if disabled
+	DesireWatch plugin.DesireWatch
+	Hooks       map[string]interface{}
+	// fanOut notifies the nodes in batches, which is created on the first notification since the service is created
+	// by many others. The nodes are notified at once if the config is nil
+	fanOutConfig *desireFanOutConfig
+	fanOutOnce   sync.Once
+	fanOut       *common.WorkerPool
+}
+
+type desireFanOutConfig struct {
+	workers, batchSize, size int
+	interval                 time.Duration
 }
 
 // NewNodeService NewNodeService
@@ -86,14 +102,30 @@ func NewNodeService(config *config.CloudConfig) (NodeService, error) {
 		return nil, err
 	}
 
-	return &NodeServiceImpl{
+	ns := &NodeServiceImpl{
 		IndexService:  is,
 		SysAppService: system,
 		Node:          node.(plugin.Node),
 		Shadow:        shadow.(plugin.Shadow),
 		App:           app.(plugin.Application),
 		Hooks:         make(map[string]interface{}),
-	}, nil
+	}
+	if config.Plugin.DesireWatch != "" {
+		watch, err := plugin.GetPlugin(config.Plugin.DesireWatch)
+		if err != nil {
+			return nil, err
+		}
+		ns.DesireWatch = watch.(plugin.DesireWatch)
+	}
+	if config.DesireFanOut.Workers > 0 && config.DesireFanOut.BatchSize > 0 {
+		ns.fanOutConfig = &desireFanOutConfig{
+			workers:   config.DesireFanOut.Workers,
+			batchSize: config.DesireFanOut.BatchSize,
+			size:      config.DesireFanOut.Size,
+			interval:  config.DesireFanOut.Interval,
+		}
+	}
+	return ns, nil
 }
 
 // Get get the node
@@ -332,7 +364,11 @@ func (n *NodeServiceImpl) UpdateDesire(tx interface{}, namespace string, names [
 		// Refresh desire in Shadow by app
 		f(shadow, app)
 	}
-	return n.Shadow.UpdateDesires(tx, shadows)
+	if err = n.Shadow.UpdateDesires(tx, shadows); err != nil {
+		return err
+	}
+	n.notifyDesire(namespace, names...)
+	return nil
 }
 
 func (n *NodeServiceImpl) updateDesire(tx interface{}, shadow *models.Shadow, desire specV1.Desire) error {
@@ -345,7 +381,56 @@ func (n *NodeServiceImpl) updateDesire(tx interface{}, shadow *models.Shadow, de
 		}
 	}
 
-	return n.Shadow.UpdateDesire(tx, shadow)
+	if err := n.Shadow.UpdateDesire(tx, shadow); err != nil {
+		return err
+	}
+	n.notifyDesire(shadow.Namespace, shadow.Name)
+	return nil
+}
+
+// WatchDesire watches the changes of the desire of node, the returned function stops the watch
+func (n *NodeServiceImpl) WatchDesire(namespace, name string) (<-chan struct{}, func(), error) {
+	if n.DesireWatch == nil {
+		return nil, nil, common.Error(common.ErrRequestMethodNotFound)
+	}
+	return n.DesireWatch.Watch(namespace, name)
+}
+
+// notifyDesire notifies the nodes watching, the failure is only logged since the nodes get the changes on the next report.
+// The desire updated in transaction may be not committed yet, in which case the node gets it on the next report too
+func (n *NodeServiceImpl) notifyDesire(namespace string, names ...string) {
+	if n.DesireWatch == nil || len(names) == 0 {
+		return
+	}
+	if n.fanOutConfig == nil || len(names) <= n.fanOutConfig.batchSize {
+		n.notifyDesireBatch(namespace, names)
+		return
+	}
+	n.fanOutOnce.Do(func() {
+		n.fanOut = common.NewWorkerPool(n.fanOutConfig.workers, n.fanOutConfig.size, 0, 0)
+	})
+	// the batches are queued at once, each worker holds the interval after a batch so that the nodes of a large fleet
+	// fetch their desires gradually instead of all at the same time
+	for start := 0; start < len(names); start += n.fanOutConfig.batchSize {
+		end := start + n.fanOutConfig.batchSize
+		if end > len(names) {
+			end = len(names)
+		}
+		batch := names[start:end]
+		ok := n.fanOut.Go(func() {
+			n.notifyDesireBatch(namespace, batch)
+			time.Sleep(n.fanOutConfig.interval)
+		})
+		if !ok {
+			log.L().Warn("the desires of nodes are not notified since too many are waiting", log.Any("namespace", namespace), log.Any("nodes", len(batch)))
+		}
+	}
+}
+
+func (n *NodeServiceImpl) notifyDesireBatch(namespace string, names []string) {
+	if err := n.DesireWatch.Notify(namespace, names); err != nil {
+		log.L().Warn("failed to notify the desires of nodes", log.Any("namespace", namespace), log.Any("nodes", names), log.Error(err))
+	}
 }
 
 func (n *NodeServiceImpl) GetDesire(namespace, name string) (*specV1.Desire, error) {
@@ -400,10 +485,15 @@ func (n *NodeServiceImpl) rematchApplicationsForNode(apps *models.ApplicationLis
 	sysApps := make([]specV1.AppInfo, 0)
 
 	appNames := make([]string, 0)
+	cordoned := IsNodeCordoned(labels)
 	for _, app := range apps.Items {
 		if app.Selector == "" {
 			continue
 		}
+		// the cordoned node only keeps the system apps
+		if cordoned && !app.System {
+			continue
+		}
 
 		if ok, err := utils.IsLabelMatch(app.Selector, labels); err == nil && ok {
 			if app.System {
@@ -443,6 +533,9 @@ func (n *NodeServiceImpl) UpdateNodeAppVersion(tx interface{}, namespace string,
 	var nodes []string
 	for idx := range nodeList.Items {
 		node := &nodeList.Items[idx]
+		if !app.System && IsNodeCordoned(node.Labels) {
+			continue
+		}
 		nodes = append(nodes, node.Name)
 	}
 	err = n.UpdateDesire(tx, namespace, nodes, app, RefreshNodeDesireByApp)
@@ -541,6 +634,11 @@ func DeleteNodeDesireByApp(shadow *models.Shadow, app *specV1.Application) {
 	shadow.Desire.SetAppInfos(app.System, appInfos)
 }
 
+// IsNodeCordoned returns true if the node is drained, no user app is deployed to it
+func IsNodeCordoned(labels map[string]string) bool {
+	return labels[common.LabelNodeCordon] == "true"
+}
+
 func toShadowMap(shadowList *models.ShadowList) map[string]*models.Shadow {
 	shadowMap := make(map[string]*models.Shadow)
 	for idx := range shadowList.Items {
@@ -633,6 +731,7 @@ func (n *NodeServiceImpl) UpdateNodeProperties(namespace, name string, props *mo
 	if err != nil {
 		return nil, err
 	}
+	n.notifyDesire(namespace, name)
 	updateNodePropertiesMeta(node, meta)
 	if _, err := n.Node.UpdateNode(nil, namespace, []*specV1.Node{node}); err != nil {
 		return nil, err
@@ -692,6 +791,22 @@ func updateNodePropertiesMeta(node *specV1.Node, meta *models.NodePropertiesMeta
 	}
 }
 
+// decodeNodeAttribute decodes the attribute of node into v, false is returned if the attribute is not set
+func decodeNodeAttribute(node *specV1.Node, key string, v interface{}) (bool, error) {
+	val, ok := node.Attributes[key]
+	if !ok || val == nil {
+		return false, nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
 func filterNodeListByNodeSelector(list *models.NodeList) *models.NodeList {
 	// filter nodes according to nodeSelector
 	items := []specV1.Node{}
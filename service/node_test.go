@@ -3,7 +3,9 @@ package service
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 	v1 "github.com/baetyl/baetyl-go/v2/spec/v1"
@@ -11,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
 	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
 	"github.com/baetyl/baetyl-cloud/v2/models"
 )
@@ -751,6 +754,72 @@ func TestUpdateDesired(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNodeDesireWatch(t *testing.T) {
+	mockObject := InitMockEnvironment(t)
+	defer mockObject.Close()
+	mWatch := mockPlugin.NewMockDesireWatch(mockObject.ctl)
+
+	ns := NodeServiceImpl{Shadow: mockObject.shadow}
+	_, _, err := ns.WatchDesire("test", "node01")
+	assert.Error(t, err)
+
+	ns.DesireWatch = mWatch
+	notify := make(chan struct{}, 1)
+	mWatch.EXPECT().Watch("test", "node01").Return((<-chan struct{})(notify), func() {}, nil)
+	ch, _, err := ns.WatchDesire("test", "node01")
+	assert.NoError(t, err)
+	assert.NotNil(t, ch)
+
+	// the nodes are notified once the desires are updated
+	names := []string{"node01"}
+	shadows := []*models.Shadow{{Namespace: "test", Name: "node01"}}
+	mockObject.shadow.EXPECT().ListShadowByNames(gomock.Any(), "test", names).Return(shadows, nil)
+	mockObject.shadow.EXPECT().UpdateDesires(gomock.Any(), shadows).Return(nil)
+	mWatch.EXPECT().Notify("test", names).Return(nil)
+	err = ns.UpdateDesire(nil, "test", names, nil, func(*models.Shadow, *specV1.Application) {})
+	assert.NoError(t, err)
+
+	// the nodes are not notified if failed to update
+	mockObject.shadow.EXPECT().ListShadowByNames(gomock.Any(), "test", names).Return(shadows, nil)
+	mockObject.shadow.EXPECT().UpdateDesires(gomock.Any(), shadows).Return(errors.New("error"))
+	err = ns.UpdateDesire(nil, "test", names, nil, func(*models.Shadow, *specV1.Application) {})
+	assert.Error(t, err)
+}
+
+func TestNodeDesireFanOut(t *testing.T) {
+	mockObject := InitMockEnvironment(t)
+	defer mockObject.Close()
+	mWatch := mockPlugin.NewMockDesireWatch(mockObject.ctl)
+	ns := &NodeServiceImpl{
+		Shadow:       mockObject.shadow,
+		DesireWatch:  mWatch,
+		fanOutConfig: &desireFanOutConfig{workers: 1, batchSize: 2, size: 8, interval: time.Millisecond},
+	}
+
+	// the nodes within a batch are notified at once
+	mWatch.EXPECT().Notify("test", []string{"n1"}).Return(nil)
+	ns.notifyDesire("test", "n1")
+	assert.Nil(t, ns.fanOut)
+
+	// the nodes beyond are notified in batches by the workers
+	var mu sync.Mutex
+	var notified []string
+	mWatch.EXPECT().Notify("test", gomock.Any()).DoAndReturn(func(_ string, names []string) error {
+		assert.LessOrEqual(t, len(names), 2)
+		mu.Lock()
+		defer mu.Unlock()
+		notified = append(notified, names...)
+		return nil
+	}).Times(3)
+	ns.notifyDesire("test", "n1", "n2", "n3", "n4", "n5")
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(notified) == 5
+	}, time.Second, time.Millisecond)
+	assert.ElementsMatch(t, []string{"n1", "n2", "n3", "n4", "n5"}, notified)
+}
+
 func TestRematchApplicationForNode(t *testing.T) {
 	mockObject := InitMockEnvironment(t)
 	defer mockObject.Close()
@@ -808,6 +877,15 @@ func TestRematchApplicationForNode(t *testing.T) {
 	assert.Equal(t, expect, desire)
 	assert.Equal(t, names, appNames)
 
+	// the cordoned node only matches the system apps
+	labels[common.LabelNodeCordon] = "true"
+	desire, appNames = ns.rematchApplicationsForNode(apps, labels)
+	assert.Equal(t, specV1.Desire{
+		common.DesiredSysApplications: []v1.AppInfo{{"app02", "1"}},
+		common.DesiredApplications:    []v1.AppInfo{},
+	}, desire)
+	assert.Equal(t, []string{"app02"}, appNames)
+
 }
 
 func TestGetNodeProperties(t *testing.T) {
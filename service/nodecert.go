@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/nodecert.go -package=service github.com/baetyl/baetyl-cloud/v2/service NodeCertService
+
+type NodeCertService interface {
+	// Get returns the secret storing the certificate of node, which is generated at the creation of node
+	Get(namespace, node string) (*specV1.Secret, error)
+	// Renew re-issues the certificate in secret through PKI, the returned secret is not stored
+	Renew(secret *specV1.Secret) (*specV1.Secret, error)
+	// Reissue re-issues the certificate and the sync key in secret for the node whose identity is recovered, the
+	// previous sync key is not kept, so the messages signed by the lost node are rejected. It's not stored either
+	Reissue(secret *specV1.Secret) (*specV1.Secret, error)
+	// ListExpiring lists the certificate secrets of nodes in namespace which expire before the time
+	ListExpiring(namespace string, before time.Time) ([]specV1.Secret, error)
+}
+
+type NodeCertServiceImpl struct {
+	Secret SecretService
+	PKI    PKIService
+}
+
+func NewNodeCertService(config *config.CloudConfig) (NodeCertService, error) {
+	secret, err := NewSecretService(config)
+	if err != nil {
+		return nil, err
+	}
+	pki, err := NewPKIService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeCertServiceImpl{
+		Secret: secret,
+		PKI:    pki,
+	}, nil
+}
+
+func (s *NodeCertServiceImpl) Get(namespace, node string) (*specV1.Secret, error) {
+	selector := fmt.Sprintf("%s=%s,%s=true", common.LabelNodeName, node, common.LabelSystem)
+	list, err := s.Secret.List(namespace, &models.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		if isNodeCertSecret(&list.Items[i]) {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "nodecertificate"), common.Field("name", node), common.Field("namespace", namespace))
+}
+
+func (s *NodeCertServiceImpl) Renew(secret *specV1.Secret) (*specV1.Secret, error) {
+	old, err := models.FromSecretToNodeCertificate(secret, secret.Labels[common.LabelNodeName])
+	if err != nil {
+		return nil, err
+	}
+	cn := old.CommonName
+	if cn == "" {
+		cn = fmt.Sprintf("%s.%s", secret.Namespace, old.Node)
+	}
+	// the old certificate is left to expire, the node keeps using it until the new one is synchronized
+	certPEM, err := s.PKI.SignClientCertificate(cn, models.AltNames{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ca, err := s.PKI.GetCA()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// the sync key is rotated with the certificate, the previous one is kept to verify the messages signed before
+	syncKey, err := genSyncKey()
+	if err != nil {
+		return nil, err
+	}
+
+	res := *secret
+	res.Data = map[string][]byte{}
+	for k, v := range secret.Data {
+		res.Data[k] = v
+	}
+	res.Data["client.pem"] = certPEM.CertPEM
+	res.Data["client.key"] = certPEM.KeyPEM
+	res.Data["ca.pem"] = ca
+	if prev, ok := secret.Data[models.SyncKey]; ok {
+		res.Data[models.SyncKeyPrevious] = prev
+	}
+	res.Data[models.SyncKey] = syncKey
+	res.Annotations = map[string]string{}
+	for k, v := range secret.Annotations {
+		res.Annotations[k] = v
+	}
+	res.Annotations[common.AnnotationPkiCertID] = certPEM.CertId
+	res.UpdateTimestamp = time.Now()
+	return &res, nil
+}
+
+func (s *NodeCertServiceImpl) Reissue(secret *specV1.Secret) (*specV1.Secret, error) {
+	res, err := s.Renew(secret)
+	if err != nil {
+		return nil, err
+	}
+	delete(res.Data, models.SyncKeyPrevious)
+	return res, nil
+}
+
+func (s *NodeCertServiceImpl) ListExpiring(namespace string, before time.Time) ([]specV1.Secret, error) {
+	selector := fmt.Sprintf("%s,%s=true", common.LabelNodeName, common.LabelSystem)
+	list, err := s.Secret.List(namespace, &models.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	var res []specV1.Secret
+	for i := range list.Items {
+		secret := &list.Items[i]
+		if !isNodeCertSecret(secret) {
+			continue
+		}
+		cert, err := models.FromSecretToNodeCertificate(secret, secret.Labels[common.LabelNodeName])
+		if err != nil {
+			// the broken certificate should be re-issued too
+			res = append(res, *secret)
+			continue
+		}
+		if cert.NotAfter.Before(before) {
+			res = append(res, *secret)
+		}
+	}
+	return res, nil
+}
+
+// isNodeCertSecret returns whether the secret stores the client certificate of node, see genNodeCerts
+func isNodeCertSecret(secret *specV1.Secret) bool {
+	if secret.Labels[specV1.SecretLabel] != specV1.SecretConfig {
+		return false
+	}
+	_, hasCert := secret.Data["client.pem"]
+	_, hasKey := secret.Data["client.key"]
+	return hasCert && hasKey
+}
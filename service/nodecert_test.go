@@ -0,0 +1,164 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func genTestClientCert(t *testing.T, cn string, notAfter time.Time) ([]byte, []byte) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &priv.PublicKey, priv)
+	assert.NoError(t, err)
+	key, err := x509.MarshalECPrivateKey(priv)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key})
+}
+
+func genTestNodeCertSecret(t *testing.T, node string, notAfter time.Time) specV1.Secret {
+	cert, key := genTestClientCert(t, "default."+node, notAfter)
+	return specV1.Secret{
+		Name:      "crt-" + node,
+		Namespace: "default",
+		Version:   "1",
+		Labels: map[string]string{
+			common.LabelNodeName: node,
+			common.LabelSystem:   "true",
+			specV1.SecretLabel:   specV1.SecretConfig,
+		},
+		Annotations: map[string]string{common.AnnotationPkiCertID: "cert-" + node},
+		Data:        map[string][]byte{"client.pem": cert, "client.key": key, "ca.pem": []byte("ca")},
+		System:      true,
+	}
+}
+
+func TestNodeCertGet(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mSecret := ms.NewMockSecretService(mockCtl)
+	ns := &NodeCertServiceImpl{Secret: mSecret}
+
+	cert := genTestNodeCertSecret(t, "node01", time.Now().Add(time.Hour))
+	registry := specV1.Secret{Name: "registry", Labels: map[string]string{specV1.SecretLabel: specV1.SecretRegistry}}
+	mSecret.EXPECT().List("default", &models.ListOptions{LabelSelector: "baetyl-node-name=node01,baetyl-cloud-system=true"}).
+		Return(&models.SecretList{Items: []specV1.Secret{registry, cert}}, nil)
+	res, err := ns.Get("default", "node01")
+	assert.NoError(t, err)
+	assert.Equal(t, "crt-node01", res.Name)
+
+	mSecret.EXPECT().List("default", gomock.Any()).Return(&models.SecretList{Items: []specV1.Secret{registry}}, nil)
+	_, err = ns.Get("default", "node02")
+	e, ok := err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, common.ErrResourceNotFound, e.Code())
+}
+
+func TestNodeCertRenew(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mPKI := ms.NewMockPKIService(mockCtl)
+	ns := &NodeCertServiceImpl{PKI: mPKI}
+
+	secret := genTestNodeCertSecret(t, "node01", time.Now().Add(time.Hour))
+	notAfter := time.Now().Add(365 * 24 * time.Hour).UTC().Truncate(time.Second)
+	certPEM, keyPEM := genTestClientCert(t, "default.node01", notAfter)
+	mPKI.EXPECT().SignClientCertificate("default.node01", models.AltNames{}).Return(&models.PEMCredential{
+		CertPEM: certPEM,
+		KeyPEM:  keyPEM,
+		CertId:  "cert-new",
+	}, nil)
+	mPKI.EXPECT().GetCA().Return([]byte("new ca"), nil)
+	res, err := ns.Renew(&secret)
+	assert.NoError(t, err)
+	assert.Equal(t, certPEM, res.Data["client.pem"])
+	assert.Equal(t, keyPEM, res.Data["client.key"])
+	assert.Equal(t, []byte("new ca"), res.Data["ca.pem"])
+	assert.Equal(t, "cert-new", res.Annotations[common.AnnotationPkiCertID])
+	assert.Equal(t, secret.Labels, res.Labels)
+	// the original one is untouched
+	assert.Equal(t, "cert-node01", secret.Annotations[common.AnnotationPkiCertID])
+	assert.Equal(t, []byte("ca"), secret.Data["ca.pem"])
+
+	cert, err := models.FromSecretToNodeCertificate(res, "node01")
+	assert.NoError(t, err)
+	assert.Equal(t, notAfter, cert.NotAfter)
+	assert.Equal(t, "cert-new", cert.CertId)
+
+	// the sync key is rotated and the previous one is kept
+	assert.Len(t, res.Data[models.SyncKey], 64)
+	assert.NotContains(t, res.Data, models.SyncKeyPrevious)
+	mPKI.EXPECT().SignClientCertificate("default.node01", models.AltNames{}).Return(&models.PEMCredential{CertPEM: certPEM, KeyPEM: keyPEM}, nil)
+	mPKI.EXPECT().GetCA().Return([]byte("new ca"), nil)
+	rotated, err := ns.Renew(res)
+	assert.NoError(t, err)
+	assert.Equal(t, res.Data[models.SyncKey], rotated.Data[models.SyncKeyPrevious])
+	assert.NotEqual(t, res.Data[models.SyncKey], rotated.Data[models.SyncKey])
+
+	_, err = ns.Renew(&specV1.Secret{Name: "broken"})
+	assert.Error(t, err)
+}
+
+func TestNodeCertReissue(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mPKI := ms.NewMockPKIService(mockCtl)
+	ns := &NodeCertServiceImpl{PKI: mPKI}
+
+	secret := genTestNodeCertSecret(t, "node01", time.Now().Add(time.Hour))
+	secret.Data[models.SyncKey] = []byte("lost key")
+	certPEM, keyPEM := genTestClientCert(t, "default.node01", time.Now().Add(365*24*time.Hour))
+	mPKI.EXPECT().SignClientCertificate("default.node01", models.AltNames{}).Return(&models.PEMCredential{CertPEM: certPEM, KeyPEM: keyPEM, CertId: "cert-new"}, nil)
+	mPKI.EXPECT().GetCA().Return([]byte("ca"), nil)
+	res, err := ns.Reissue(&secret)
+	assert.NoError(t, err)
+	assert.Equal(t, certPEM, res.Data["client.pem"])
+	assert.Equal(t, "cert-new", res.Annotations[common.AnnotationPkiCertID])
+	// the key of the lost node is not kept
+	assert.NotContains(t, res.Data, models.SyncKeyPrevious)
+	assert.NotEqual(t, []byte("lost key"), res.Data[models.SyncKey])
+	assert.Equal(t, []byte("lost key"), secret.Data[models.SyncKey])
+}
+
+func TestNodeCertListExpiring(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mSecret := ms.NewMockSecretService(mockCtl)
+	ns := &NodeCertServiceImpl{Secret: mSecret}
+
+	now := time.Now()
+	expiring := genTestNodeCertSecret(t, "node01", now.Add(24*time.Hour))
+	valid := genTestNodeCertSecret(t, "node02", now.Add(365*24*time.Hour))
+	broken := genTestNodeCertSecret(t, "node03", now)
+	broken.Data["client.pem"] = []byte("broken")
+	other := specV1.Secret{Name: "other", Labels: map[string]string{common.LabelNodeName: "node01", specV1.SecretLabel: specV1.SecretConfig}}
+	mSecret.EXPECT().List("default", &models.ListOptions{LabelSelector: "baetyl-node-name,baetyl-cloud-system=true"}).
+		Return(&models.SecretList{Items: []specV1.Secret{expiring, valid, broken, other}}, nil)
+	res, err := ns.ListExpiring("default", now.Add(30*24*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "crt-node01", res[0].Name)
+	assert.Equal(t, "crt-node03", res[1].Name)
+}
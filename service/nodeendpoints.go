@@ -0,0 +1,186 @@
+package service
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/nodeendpoints.go -package=service github.com/baetyl/baetyl-cloud/v2/service NodeEndpointsService
+
+// ParamEndpoints the param of templates carrying the endpoints resolved for node, which GetEndpoint looks up
+const ParamEndpoints = "Endpoints"
+
+type NodeEndpointsService interface {
+	Get(namespace string) (*models.NodeEndpoints, error)
+	Set(endpoints *models.NodeEndpoints) (*models.NodeEndpoints, error)
+	Delete(namespace string) error
+	// Resolve returns the endpoints of node, the ones set on node override the ones of namespace. The endpoints of
+	// namespace are returned if node is nil
+	Resolve(namespace string, node *specV1.Node) (*models.NodeEndpoints, error)
+}
+
+type NodeEndpointsServiceImpl struct {
+	NodeEndpoints plugin.NodeEndpoints
+}
+
+// NewNodeEndpointsService NewNodeEndpointsService
+func NewNodeEndpointsService(config *config.CloudConfig) (NodeEndpointsService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.NodeEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeEndpointsServiceImpl{NodeEndpoints: p.(plugin.NodeEndpoints)}, nil
+}
+
+// Get returns the endpoints of namespace, the empty ones overriding nothing are returned if they are not set
+func (s *NodeEndpointsServiceImpl) Get(namespace string) (*models.NodeEndpoints, error) {
+	res, err := s.NodeEndpoints.GetNodeEndpoints(namespace)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+		res = &models.NodeEndpoints{Namespace: namespace}
+	}
+	return res, nil
+}
+
+func (s *NodeEndpointsServiceImpl) Set(endpoints *models.NodeEndpoints) (*models.NodeEndpoints, error) {
+	_, err := s.NodeEndpoints.GetNodeEndpoints(endpoints.Namespace)
+	if err == nil {
+		err = s.NodeEndpoints.UpdateNodeEndpoints(endpoints)
+	} else if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+		err = s.NodeEndpoints.CreateNodeEndpoints(endpoints)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.NodeEndpoints.GetNodeEndpoints(endpoints.Namespace)
+}
+
+func (s *NodeEndpointsServiceImpl) Delete(namespace string) error {
+	return s.NodeEndpoints.DeleteNodeEndpoints(namespace)
+}
+
+func (s *NodeEndpointsServiceImpl) Resolve(namespace string, node *specV1.Node) (*models.NodeEndpoints, error) {
+	res, err := s.Get(namespace)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return res, nil
+	}
+	endpoints, err := GetNodeEndpoints(node)
+	if err != nil {
+		return nil, err
+	}
+	return MergeNodeEndpoints(res, endpoints), nil
+}
+
+// GetNodeEndpoints returns the endpoints set on node, which is nil if not set
+func GetNodeEndpoints(node *specV1.Node) (*models.NodeEndpoints, error) {
+	val, ok := node.Attributes[common.AttributeNodeEndpoints]
+	if !ok || val == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	endpoints := new(models.NodeEndpoints)
+	if err = json.Unmarshal(data, endpoints); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return endpoints, nil
+}
+
+// MergeNodeEndpoints returns the endpoints of base overridden by the ones set in override, base is kept unchanged
+func MergeNodeEndpoints(base, override *models.NodeEndpoints) *models.NodeEndpoints {
+	res := &models.NodeEndpoints{}
+	if base != nil {
+		*res = *base
+	}
+	if override == nil {
+		return res
+	}
+	for _, v := range []struct {
+		dst *string
+		src string
+	}{
+		{&res.InitServer, override.InitServer},
+		{&res.SyncServer, override.SyncServer},
+		{&res.SyncMQTT, override.SyncMQTT},
+		{&res.ObjectStorage, override.ObjectStorage},
+	} {
+		if v.src != "" {
+			*v.dst = v.src
+		}
+	}
+	return res
+}
+
+// ValidateNodeEndpoints checks the endpoints are urls with hosts, the trailing slashes of them are trimmed
+// as the paths of the requests are appended to them
+func ValidateNodeEndpoints(endpoints *models.NodeEndpoints) error {
+	for _, v := range []*string{&endpoints.InitServer, &endpoints.SyncServer, &endpoints.SyncMQTT, &endpoints.ObjectStorage} {
+		*v = strings.TrimSuffix(strings.TrimSpace(*v), "/")
+	}
+	if err := common.ValidateStruct(endpoints); err != nil {
+		return err
+	}
+	for _, v := range []string{endpoints.InitServer, endpoints.SyncServer, endpoints.SyncMQTT, endpoints.ObjectStorage} {
+		if v == "" {
+			continue
+		}
+		if u, err := url.Parse(v); err != nil || u.Host == "" {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the endpoint should be a url with scheme and host, such as https://gateway.local:30005"))
+		}
+	}
+	return nil
+}
+
+// RewriteObjectURL returns the object url served by the endpoint of object storage, the scheme and host of the url
+// are replaced by the ones of endpoint, and the path of endpoint prefixes the path of url. The url is kept if
+// endpoint is empty
+func RewriteObjectURL(raw, endpoint string) (string, error) {
+	if endpoint == "" {
+		return raw, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	e, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	u.Scheme, u.Host = e.Scheme, e.Host
+	if p := strings.TrimSuffix(e.Path, "/"); p != "" {
+		u.Path = p + u.Path
+		if u.RawPath != "" {
+			u.RawPath = strings.TrimSuffix(e.EscapedPath(), "/") + u.RawPath
+		}
+	}
+	return u.String(), nil
+}
+
+// GetEndpointFunc returns the template func getting the endpoint of name resolved into the param Endpoints of
+// template, the property of name is got for the endpoint not overridden
+func GetEndpointFunc(prop PropertyService) func(params map[string]interface{}, name string) (string, error) {
+	return func(params map[string]interface{}, name string) (string, error) {
+		if endpoints, ok := params[ParamEndpoints].(*models.NodeEndpoints); ok {
+			if v := endpoints.Address(name); v != "" {
+				return v, nil
+			}
+		}
+		return prop.GetPropertyValue(name)
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewNodeEndpointsService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.NodeEndpoints = common.RandString(9)
+	_, err := NewNodeEndpointsService(conf)
+	assert.Error(t, err)
+}
+
+func TestNodeEndpointsService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mEndpoints := mockPlugin.NewMockNodeEndpoints(mockCtl)
+	es := &NodeEndpointsServiceImpl{NodeEndpoints: mEndpoints}
+	notFound := common.Error(common.ErrResourceNotFound)
+
+	// the empty endpoints are returned if not set
+	mEndpoints.EXPECT().GetNodeEndpoints("default").Return(nil, notFound)
+	res, err := es.Get("default")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.NodeEndpoints{Namespace: "default"}, res)
+	assert.True(t, res.IsEmpty())
+
+	mEndpoints.EXPECT().GetNodeEndpoints("default").Return(nil, fmt.Errorf("error"))
+	_, err = es.Get("default")
+	assert.Error(t, err)
+
+	endpoints := &models.NodeEndpoints{Namespace: "default", SyncServer: "https://gateway.site:30005", SyncMQTT: "ssl://gateway.site:30883"}
+	mEndpoints.EXPECT().GetNodeEndpoints("default").Return(nil, notFound)
+	mEndpoints.EXPECT().CreateNodeEndpoints(endpoints).Return(nil)
+	mEndpoints.EXPECT().GetNodeEndpoints("default").Return(endpoints, nil)
+	res, err = es.Set(endpoints)
+	assert.NoError(t, err)
+	assert.Equal(t, endpoints, res)
+
+	mEndpoints.EXPECT().GetNodeEndpoints("default").Return(endpoints, nil)
+	mEndpoints.EXPECT().UpdateNodeEndpoints(endpoints).Return(fmt.Errorf("error"))
+	_, err = es.Set(endpoints)
+	assert.Error(t, err)
+
+	// the endpoints set on node override the ones of namespace
+	node := &specV1.Node{Namespace: "default", Name: "node01", Attributes: map[string]interface{}{
+		common.AttributeNodeEndpoints: map[string]interface{}{"syncServer": "https://proxy.local:30005"},
+	}}
+	mEndpoints.EXPECT().GetNodeEndpoints("default").Return(endpoints, nil).Times(2)
+	res, err = es.Resolve("default", node)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://proxy.local:30005", res.SyncServer)
+	assert.Equal(t, "ssl://gateway.site:30883", res.SyncMQTT)
+	assert.Equal(t, "https://gateway.site:30005", endpoints.SyncServer)
+	res, err = es.Resolve("default", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gateway.site:30005", res.SyncServer)
+
+	mEndpoints.EXPECT().DeleteNodeEndpoints("default").Return(nil)
+	assert.NoError(t, es.Delete("default"))
+}
+
+func TestValidateNodeEndpoints(t *testing.T) {
+	endpoints := &models.NodeEndpoints{InitServer: " https://gateway.site:30003/ ", SyncMQTT: "ssl://gateway.site:30883"}
+	assert.NoError(t, ValidateNodeEndpoints(endpoints))
+	assert.Equal(t, "https://gateway.site:30003", endpoints.InitServer)
+
+	for _, e := range []*models.NodeEndpoints{
+		{InitServer: "gateway.site:30003"},
+		{SyncServer: "https://"},
+	} {
+		assertErrorCode(t, common.ErrRequestParamInvalid, ValidateNodeEndpoints(e))
+	}
+	assert.Error(t, ValidateNodeEndpoints(&models.NodeEndpoints{SyncServer: "not a url"}))
+	assert.Error(t, ValidateNodeEndpoints(&models.NodeEndpoints{ObjectStorage: "/objects"}))
+}
+
+func TestRewriteObjectURL(t *testing.T) {
+	raw := "https://bucket.s3.amazonaws.com/config/a%2Fb.zip?X-Amz-Signature=abc"
+	res, err := RewriteObjectURL(raw, "")
+	assert.NoError(t, err)
+	assert.Equal(t, raw, res)
+	res, err = RewriteObjectURL(raw, "http://gateway.site:9000")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://gateway.site:9000/config/a%2Fb.zip?X-Amz-Signature=abc", res)
+	res, err = RewriteObjectURL(raw, "https://gateway.site/objects")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gateway.site/objects/config/a%2Fb.zip?X-Amz-Signature=abc", res)
+}
+
+func TestGetEndpointFunc(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mProp := ms.NewMockPropertyService(mockCtl)
+	fn := GetEndpointFunc(mProp)
+
+	mProp.EXPECT().GetPropertyValue(models.EndpointSyncServer).Return("https://cloud:30005", nil).Times(2)
+	res, err := fn(map[string]interface{}{}, models.EndpointSyncServer)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://cloud:30005", res)
+	params := map[string]interface{}{ParamEndpoints: &models.NodeEndpoints{InitServer: "https://gateway.site:30003"}}
+	res, err = fn(params, models.EndpointSyncServer)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://cloud:30005", res)
+	res, err = fn(params, models.EndpointInitServer)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gateway.site:30003", res)
+}
+
+func TestSyncService_NodeObjectURL(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sObj, sNode, sEndpoints := ms.NewMockObjectService(mockCtl), ms.NewMockNodeService(mockCtl), ms.NewMockNodeEndpointsService(mockCtl)
+	ss := &SyncServiceImpl{ObjectService: sObj, NodeService: sNode, Endpoints: sEndpoints}
+
+	obj := &specV1.ConfigurationObject{Metadata: map[string]string{"source": "s3", "bucket": "bucket1", "object": "a.zip", "userID": "default"}}
+	data, _ := json.Marshal(obj)
+	cfg := &specV1.Configuration{Name: "config", Data: map[string]string{common.ConfigObjectPrefix + "a": string(data)}}
+	node := &specV1.Node{Namespace: "default", Name: "node01"}
+	sObj.EXPECT().GenInternalObjectURL("default", "bucket1", "a.zip", "s3").Return(&models.ObjectURL{URL: "https://s3.cloud/bucket1/a.zip?sig=1"}, nil)
+	sNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	sEndpoints.EXPECT().Resolve("default", node).Return(&models.NodeEndpoints{ObjectStorage: "http://gateway.site:9000"}, nil)
+	assert.NoError(t, ss.PopulateConfig(cfg, map[string]string{"namespace": "default", "name": "node01"}))
+	res := new(specV1.ConfigurationObject)
+	assert.NoError(t, json.Unmarshal([]byte(cfg.Data[common.ConfigObjectPrefix+"a"]), res))
+	assert.Equal(t, "http://gateway.site:9000/bucket1/a.zip?sig=1", res.URL)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/nodefilter.go -package=service github.com/baetyl/baetyl-cloud/v2/service NodeFilterService
+
+// NodeFilterService manages the node filters saved by users, the filters of one user are not visible to others
+type NodeFilterService interface {
+	Get(namespace, user, name string) (*models.NodeFilter, error)
+	List(namespace, user string) ([]models.NodeFilter, error)
+	Create(filter *models.NodeFilter) (*models.NodeFilter, error)
+	Update(filter *models.NodeFilter) (*models.NodeFilter, error)
+	Delete(namespace, user, name string) error
+}
+
+type NodeFilterServiceImpl struct {
+	NodeFilter plugin.NodeFilter
+}
+
+// NewNodeFilterService NewNodeFilterService
+func NewNodeFilterService(config *config.CloudConfig) (NodeFilterService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.NodeFilter)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeFilterServiceImpl{
+		NodeFilter: p.(plugin.NodeFilter),
+	}, nil
+}
+
+func (s *NodeFilterServiceImpl) Get(namespace, user, name string) (*models.NodeFilter, error) {
+	return s.NodeFilter.GetNodeFilter(namespace, user, name)
+}
+
+func (s *NodeFilterServiceImpl) List(namespace, user string) ([]models.NodeFilter, error) {
+	return s.NodeFilter.ListNodeFilter(namespace, user)
+}
+
+func (s *NodeFilterServiceImpl) Create(filter *models.NodeFilter) (*models.NodeFilter, error) {
+	if err := s.NodeFilter.CreateNodeFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.NodeFilter.GetNodeFilter(filter.Namespace, filter.User, filter.Name)
+}
+
+func (s *NodeFilterServiceImpl) Update(filter *models.NodeFilter) (*models.NodeFilter, error) {
+	if err := s.NodeFilter.UpdateNodeFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.NodeFilter.GetNodeFilter(filter.Namespace, filter.User, filter.Name)
+}
+
+func (s *NodeFilterServiceImpl) Delete(namespace, user, name string) error {
+	return s.NodeFilter.DeleteNodeFilter(namespace, user, name)
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestNodeFilterService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.NodeFilter = common.RandString(9)
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mNodeFilter := mockPlugin.NewMockNodeFilter(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.NodeFilter, func() (plugin.Plugin, error) {
+		return mNodeFilter, nil
+	})
+	fs, err := NewNodeFilterService(conf)
+	assert.NoError(t, err)
+
+	filter := &models.NodeFilter{Namespace: "default", User: "user01", Name: "filter01", Selector: "city=bj"}
+	mNodeFilter.EXPECT().CreateNodeFilter(filter).Return(nil)
+	mNodeFilter.EXPECT().GetNodeFilter("default", "user01", "filter01").Return(filter, nil)
+	res, err := fs.Create(filter)
+	assert.NoError(t, err)
+	assert.Equal(t, filter, res)
+
+	mNodeFilter.EXPECT().UpdateNodeFilter(filter).Return(fmt.Errorf("error"))
+	_, err = fs.Update(filter)
+	assert.Error(t, err)
+
+	mNodeFilter.EXPECT().ListNodeFilter("default", "user01").Return([]models.NodeFilter{*filter}, nil)
+	list, err := fs.List("default", "user01")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.NodeFilter{*filter}, list)
+
+	mNodeFilter.EXPECT().DeleteNodeFilter("default", "user01", "filter01").Return(nil)
+	assert.NoError(t, fs.Delete("default", "user01", "filter01"))
+}
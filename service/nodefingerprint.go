@@ -0,0 +1,130 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/nodefingerprint.go -package=service github.com/baetyl/baetyl-cloud/v2/service NodeFingerprintService
+
+type NodeFingerprintService interface {
+	// Get returns the fingerprint bound to node, which is nil if the node is not bound yet
+	Get(namespace, node string) (*models.HardwareFingerprint, error)
+	// Check returns ErrNodeFingerprint if the node is bound to another hardware, the nodes not bound yet pass
+	Check(namespace, node string, fingerprint *models.HardwareFingerprint) error
+	// Bind binds the node to the fingerprint if not bound yet, the empty fingerprint is not bound
+	Bind(namespace, node string, fingerprint *models.HardwareFingerprint) error
+	// Reset deletes the binding of node, the node is bound again on its next activation
+	Reset(namespace, node string) error
+}
+
+type NodeFingerprintServiceImpl struct {
+	Node NodeService
+}
+
+func NewNodeFingerprintService(config *config.CloudConfig) (NodeFingerprintService, error) {
+	node, err := NewNodeService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeFingerprintServiceImpl{Node: node}, nil
+}
+
+func (s *NodeFingerprintServiceImpl) Get(namespace, node string) (*models.HardwareFingerprint, error) {
+	n, err := s.Node.Get(nil, namespace, node)
+	if err != nil {
+		return nil, err
+	}
+	return getNodeFingerprint(n)
+}
+
+func (s *NodeFingerprintServiceImpl) Check(namespace, node string, fingerprint *models.HardwareFingerprint) error {
+	bound, err := s.Get(namespace, node)
+	if err != nil {
+		return err
+	}
+	return checkNodeFingerprint(namespace, node, bound, fingerprint)
+}
+
+func (s *NodeFingerprintServiceImpl) Bind(namespace, node string, fingerprint *models.HardwareFingerprint) error {
+	n, err := s.Node.Get(nil, namespace, node)
+	if err != nil {
+		return err
+	}
+	bound, err := getNodeFingerprint(n)
+	if err != nil {
+		return err
+	}
+	if fingerprint != nil {
+		fingerprint.Normalize()
+	}
+	if bound != nil || fingerprint.IsEmpty() {
+		return checkNodeFingerprint(namespace, node, bound, fingerprint)
+	}
+	fingerprint.BindTime = time.Now().UTC()
+	if n.Attributes == nil {
+		n.Attributes = map[string]interface{}{}
+	}
+	n.Attributes[common.AttributeHardwareFingerprint] = fingerprint
+	if _, err = s.Node.Update(namespace, n); err != nil {
+		return err
+	}
+	log.L().Info("node is bound to hardware fingerprint", log.Any("namespace", namespace), log.Any("name", node),
+		log.Any("machineId", fingerprint.MachineID), log.Any("macs", fingerprint.MACs))
+	return nil
+}
+
+func (s *NodeFingerprintServiceImpl) Reset(namespace, node string) error {
+	n, err := s.Node.Get(nil, namespace, node)
+	if err != nil {
+		return err
+	}
+	if _, ok := n.Attributes[common.AttributeHardwareFingerprint]; !ok {
+		return nil
+	}
+	delete(n.Attributes, common.AttributeHardwareFingerprint)
+	if _, err = s.Node.Update(namespace, n); err != nil {
+		return err
+	}
+	log.L().Info("hardware fingerprint binding of node is reset", log.Any("namespace", namespace), log.Any("name", node))
+	return nil
+}
+
+// checkNodeFingerprint passes if no fingerprint is bound, the nodes bound present the matched fingerprint
+func checkNodeFingerprint(namespace, node string, bound, fingerprint *models.HardwareFingerprint) error {
+	if bound == nil {
+		return nil
+	}
+	if fingerprint != nil {
+		fingerprint.Normalize()
+	}
+	if !bound.Match(fingerprint) {
+		log.L().Warn("hardware fingerprint of node mismatched", log.Any("namespace", namespace), log.Any("name", node))
+		return common.Error(common.ErrNodeFingerprint, common.Field("name", node))
+	}
+	return nil
+}
+
+func getNodeFingerprint(node *specV1.Node) (*models.HardwareFingerprint, error) {
+	val, ok := node.Attributes[common.AttributeHardwareFingerprint]
+	if !ok || val == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	fingerprint := new(models.HardwareFingerprint)
+	if err = json.Unmarshal(data, fingerprint); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return fingerprint, nil
+}
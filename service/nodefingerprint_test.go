@@ -0,0 +1,99 @@
+package service
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNodeFingerprintBind(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNode := ms.NewMockNodeService(mockCtl)
+	s := &NodeFingerprintServiceImpl{Node: mNode}
+
+	// the installers without fingerprint bind nothing
+	mNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01"}, nil)
+	assert.NoError(t, s.Bind("default", "node01", &models.HardwareFingerprint{MACs: []string{""}}))
+
+	var bound *models.HardwareFingerprint
+	mNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01"}, nil)
+	mNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(ns string, node *specV1.Node) (*specV1.Node, error) {
+		bound = node.Attributes[common.AttributeHardwareFingerprint].(*models.HardwareFingerprint)
+		return node, nil
+	})
+	assert.NoError(t, s.Bind("default", "node01", &models.HardwareFingerprint{MachineID: "M01", MACs: []string{"00:11:22:33:44:66", "00:11:22:33:44:55", "00:11:22:33:44:55"}}))
+	assert.Equal(t, "m01", bound.MachineID)
+	assert.Equal(t, []string{"00:11:22:33:44:55", "00:11:22:33:44:66"}, bound.MACs)
+	assert.False(t, bound.BindTime.IsZero())
+
+	// the node bound is not bound again
+	node := &specV1.Node{Namespace: "default", Name: "node01", Attributes: map[string]interface{}{common.AttributeHardwareFingerprint: map[string]interface{}{
+		"machineId": "m01",
+		"macs":      []interface{}{"00:11:22:33:44:55", "00:11:22:33:44:66"},
+	}}}
+	mNode.EXPECT().Get(nil, "default", "node01").Return(node, nil).AnyTimes()
+	assert.NoError(t, s.Bind("default", "node01", &models.HardwareFingerprint{MachineID: "m01", MACs: []string{"00:11:22:33:44:55"}}))
+	err := s.Bind("default", "node01", &models.HardwareFingerprint{MachineID: "m02", MACs: []string{"00:11:22:33:44:55"}})
+	assertErrorCode(t, common.ErrNodeFingerprint, err)
+}
+
+func TestNodeFingerprintCheck(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNode := ms.NewMockNodeService(mockCtl)
+	s := &NodeFingerprintServiceImpl{Node: mNode}
+
+	mNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01"}, nil)
+	assert.NoError(t, s.Check("default", "node01", &models.HardwareFingerprint{MachineID: "m01"}))
+
+	node := &specV1.Node{Namespace: "default", Name: "node01", Attributes: map[string]interface{}{common.AttributeHardwareFingerprint: &models.HardwareFingerprint{
+		MachineID: "m01",
+		MACs:      []string{"00:11:22:33:44:55", "00:11:22:33:44:66"},
+		TPMEKHash: "ek01",
+	}}}
+	mNode.EXPECT().Get(nil, "default", "node01").Return(node, nil).AnyTimes()
+	// one of the NICs is replaced
+	assert.NoError(t, s.Check("default", "node01", &models.HardwareFingerprint{MachineID: "M01", MACs: []string{"00:11:22:33:44:77", "00:11:22:33:44:66"}, TPMEKHash: "EK01"}))
+	for _, fp := range []*models.HardwareFingerprint{
+		nil,
+		{},
+		// the VM cloned with new MACs
+		{MachineID: "m01", MACs: []string{"00:11:22:33:44:77"}, TPMEKHash: "ek01"},
+		{MachineID: "m01", MACs: []string{"00:11:22:33:44:55"}, TPMEKHash: "ek02"},
+		{MachineID: "m01", MACs: []string{"00:11:22:33:44:55"}},
+	} {
+		assertErrorCode(t, common.ErrNodeFingerprint, s.Check("default", "node01", fp))
+	}
+
+	res, err := s.Get("default", "node01")
+	assert.NoError(t, err)
+	assert.Equal(t, "ek01", res.TPMEKHash)
+}
+
+func TestNodeFingerprintReset(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNode := ms.NewMockNodeService(mockCtl)
+	s := &NodeFingerprintServiceImpl{Node: mNode}
+
+	mNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Namespace: "default", Name: "node01"}, nil)
+	assert.NoError(t, s.Reset("default", "node01"))
+
+	node := &specV1.Node{Namespace: "default", Name: "node01", Attributes: map[string]interface{}{
+		common.AttributeHardwareFingerprint: &models.HardwareFingerprint{MachineID: "m01"},
+		specV1.BaetylCoreFrequency:          "20",
+	}}
+	mNode.EXPECT().Get(nil, "default", "node01").Return(node, nil)
+	mNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(ns string, node *specV1.Node) (*specV1.Node, error) {
+		assert.Equal(t, map[string]interface{}{specV1.BaetylCoreFrequency: "20"}, node.Attributes)
+		return node, nil
+	})
+	assert.NoError(t, s.Reset("default", "node01"))
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/baetyl/baetyl-go/v2/utils"
+	"github.com/gin-contrib/cache/persistence"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/nodegroup.go -package=service github.com/baetyl/baetyl-cloud/v2/service NodeGroupService
+
+type NodeGroupService interface {
+	Get(namespace, name string) (*models.NodeGroup, error)
+	List(namespace string, params *models.ListOptions) (*models.NodeGroupList, error)
+	Create(group *models.NodeGroup) (*models.NodeGroup, error)
+	Update(group *models.NodeGroup) (*models.NodeGroup, error)
+	Delete(namespace, name string) error
+}
+
+type NodeGroupServiceImpl struct {
+	NodeGroup plugin.NodeGroup
+}
+
+// NewNodeGroupService NewNodeGroupService
+func NewNodeGroupService(config *config.CloudConfig) (NodeGroupService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.NodeGroup)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeGroupServiceImpl{
+		NodeGroup: p.(plugin.NodeGroup),
+	}, nil
+}
+
+func (s *NodeGroupServiceImpl) Get(namespace, name string) (*models.NodeGroup, error) {
+	return s.NodeGroup.GetNodeGroup(namespace, name)
+}
+
+func (s *NodeGroupServiceImpl) List(namespace string, params *models.ListOptions) (*models.NodeGroupList, error) {
+	items, err := s.NodeGroup.ListNodeGroup(namespace, &params.Filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.NodeGroup.CountNodeGroup(namespace, params.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &models.NodeGroupList{
+		Total:       total,
+		ListOptions: params,
+		Items:       items,
+	}, nil
+}
+
+func (s *NodeGroupServiceImpl) Create(group *models.NodeGroup) (*models.NodeGroup, error) {
+	if err := s.NodeGroup.CreateNodeGroup(group); err != nil {
+		return nil, err
+	}
+	return s.NodeGroup.GetNodeGroup(group.Namespace, group.Name)
+}
+
+func (s *NodeGroupServiceImpl) Update(group *models.NodeGroup) (*models.NodeGroup, error) {
+	if err := s.NodeGroup.UpdateNodeGroup(group); err != nil {
+		return nil, err
+	}
+	return s.NodeGroup.GetNodeGroup(group.Namespace, group.Name)
+}
+
+func (s *NodeGroupServiceImpl) Delete(namespace, name string) error {
+	return s.NodeGroup.DeleteNodeGroup(namespace, name)
+}
+
+// nodeGroupCache caches the groups of namespaces sorted by name, which are looked up at every report of nodes
+type nodeGroupCache struct {
+	group  NodeGroupService
+	cache  persistence.CacheStore
+	expire time.Duration
+}
+
+func newNodeGroupCache(group NodeGroupService, expire time.Duration) *nodeGroupCache {
+	return &nodeGroupCache{
+		group:  group,
+		cache:  persistence.NewInMemoryStore(expire),
+		expire: expire,
+	}
+}
+
+// match returns the first group by name selecting the node among the ones having the setting, which is nil if none
+func (c *nodeGroupCache) match(node *specV1.Node, has func(group *models.NodeGroup) bool) (*models.NodeGroup, error) {
+	groups, err := c.list(node.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		if !has(&groups[i]) {
+			continue
+		}
+		if ok, _ := utils.IsLabelMatch(groups[i].NodeSelector(), node.Labels); ok {
+			return &groups[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *nodeGroupCache) list(namespace string) ([]models.NodeGroup, error) {
+	var groups []models.NodeGroup
+	if err := c.cache.Get(namespace, &groups); err == nil {
+		return groups, nil
+	}
+	list, err := c.group.List(namespace, &models.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	groups = list.Items
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	c.cache.Set(namespace, groups, c.expire)
+	return groups, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestNodeGroupService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.NodeGroup = common.RandString(9)
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mNodeGroup := mockPlugin.NewMockNodeGroup(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.NodeGroup, func() (plugin.Plugin, error) {
+		return mNodeGroup, nil
+	})
+	gs, err := NewNodeGroupService(conf)
+	assert.NoError(t, err)
+
+	group := &models.NodeGroup{Namespace: "default", Name: "group01", Selector: "city=bj"}
+	mNodeGroup.EXPECT().CreateNodeGroup(group).Return(nil)
+	mNodeGroup.EXPECT().GetNodeGroup("default", "group01").Return(group, nil)
+	res, err := gs.Create(group)
+	assert.NoError(t, err)
+	assert.Equal(t, group, res)
+
+	mNodeGroup.EXPECT().UpdateNodeGroup(group).Return(fmt.Errorf("error"))
+	_, err = gs.Update(group)
+	assert.Error(t, err)
+
+	params := &models.ListOptions{}
+	mNodeGroup.EXPECT().ListNodeGroup("default", &params.Filter).Return([]models.NodeGroup{*group}, nil)
+	mNodeGroup.EXPECT().CountNodeGroup("default", "").Return(1, nil)
+	list, err := gs.List("default", params)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, list.Total)
+	assert.Equal(t, []models.NodeGroup{*group}, list.Items)
+
+	mNodeGroup.EXPECT().DeleteNodeGroup("default", "group01").Return(nil)
+	assert.NoError(t, gs.Delete("default", "group01"))
+}
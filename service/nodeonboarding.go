@@ -0,0 +1,146 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/nodeonboarding.go -package=service github.com/baetyl/baetyl-cloud/v2/service NodeOnboardingService
+
+type NodeOnboardingService interface {
+	// Get returns the onboarding of node, which is nil if the node is never activated since onboarding is recorded
+	Get(namespace, node string) (*models.NodeOnboarding, error)
+	// Record records the step of node onboarding, the activation starts a new onboarding
+	Record(namespace, node string, step *models.OnboardingStep) error
+	// RecordReport records the first report of the node onboarding, the steps before it not reported are passed
+	RecordReport(node *specV1.Node) error
+}
+
+type NodeOnboardingServiceImpl struct {
+	Node NodeService
+}
+
+func NewNodeOnboardingService(config *config.CloudConfig) (NodeOnboardingService, error) {
+	node, err := NewNodeService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeOnboardingServiceImpl{Node: node}, nil
+}
+
+func (s *NodeOnboardingServiceImpl) Get(namespace, node string) (*models.NodeOnboarding, error) {
+	n, err := s.Node.Get(nil, namespace, node)
+	if err != nil {
+		return nil, err
+	}
+	onboarding, err := getNodeOnboarding(n)
+	if err != nil || onboarding == nil {
+		return nil, err
+	}
+	onboarding.Evaluate()
+	return onboarding, nil
+}
+
+func (s *NodeOnboardingServiceImpl) Record(namespace, node string, step *models.OnboardingStep) error {
+	n, err := s.Node.Get(nil, namespace, node)
+	if err != nil {
+		return err
+	}
+	onboarding, err := getNodeOnboarding(n)
+	if err != nil {
+		return err
+	}
+	if onboarding == nil {
+		onboarding = &models.NodeOnboarding{}
+	}
+	res := *step
+	if res.Time.IsZero() {
+		res.Time = time.Now().UTC()
+	}
+	recordOnboardingStep(onboarding, res)
+	if err = s.update(n, onboarding); err != nil {
+		return err
+	}
+	if res.Status == models.OnboardingFailed {
+		log.L().Warn("node onboarding step failed", log.Any("namespace", namespace), log.Any("name", node),
+			log.Any("step", res.Name), log.Any("reason", res.Reason))
+	}
+	return nil
+}
+
+func (s *NodeOnboardingServiceImpl) RecordReport(node *specV1.Node) error {
+	onboarding, err := getNodeOnboarding(node)
+	if err != nil || onboarding == nil {
+		return err
+	}
+	if step := onboarding.Step(models.OnboardingFirstReport); step != nil && step.Status == models.OnboardingSucceeded {
+		return nil
+	}
+	now := time.Now().UTC()
+	for _, name := range models.OnboardingSteps {
+		if step := onboarding.Step(name); step == nil || step.Status != models.OnboardingSucceeded {
+			recordOnboardingStep(onboarding, models.OnboardingStep{Name: name, Status: models.OnboardingSucceeded, Time: now})
+		}
+	}
+	if err = s.update(node, onboarding); err != nil {
+		return err
+	}
+	log.L().Info("node onboarding is completed", log.Any("namespace", node.Namespace), log.Any("name", node.Name))
+	return nil
+}
+
+func (s *NodeOnboardingServiceImpl) update(node *specV1.Node, onboarding *models.NodeOnboarding) error {
+	onboarding.Evaluate()
+	if node.Attributes == nil {
+		node.Attributes = map[string]interface{}{}
+	}
+	node.Attributes[common.AttributeOnboarding] = onboarding
+	_, err := s.Node.Update(node.Namespace, node)
+	return err
+}
+
+// recordOnboardingStep replaces the step recorded by the same name and keeps the steps in order, the activation
+// drops the steps of the previous onboarding
+func recordOnboardingStep(onboarding *models.NodeOnboarding, step models.OnboardingStep) {
+	if step.Name == models.OnboardingActivated {
+		onboarding.Steps = []models.OnboardingStep{step}
+		return
+	}
+	if recorded := onboarding.Step(step.Name); recorded != nil {
+		*recorded = step
+		return
+	}
+	var steps []models.OnboardingStep
+	for _, name := range models.OnboardingSteps {
+		if name == step.Name {
+			steps = append(steps, step)
+		} else if recorded := onboarding.Step(name); recorded != nil {
+			steps = append(steps, *recorded)
+		}
+	}
+	onboarding.Steps = steps
+}
+
+func getNodeOnboarding(node *specV1.Node) (*models.NodeOnboarding, error) {
+	val, ok := node.Attributes[common.AttributeOnboarding]
+	if !ok || val == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	onboarding := new(models.NodeOnboarding)
+	if err = json.Unmarshal(data, onboarding); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return onboarding, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNodeOnboardingRecord(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNode := ms.NewMockNodeService(mockCtl)
+	s := &NodeOnboardingServiceImpl{Node: mNode}
+
+	node := &specV1.Node{Namespace: "default", Name: "node01"}
+	mNode.EXPECT().Get(nil, "default", "node01").Return(node, nil).AnyTimes()
+	mNode.EXPECT().Update("default", gomock.Any()).DoAndReturn(func(ns string, n *specV1.Node) (*specV1.Node, error) {
+		return n, nil
+	}).AnyTimes()
+
+	// the nodes never activated have no onboarding
+	res, err := s.Get("default", "node01")
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	assert.NoError(t, s.Record("default", "node01", &models.OnboardingStep{Name: models.OnboardingActivated, Status: models.OnboardingSucceeded}))
+	assert.NoError(t, s.Record("default", "node01", &models.OnboardingStep{Name: models.OnboardingCoreStarted, Status: models.OnboardingFailed, Reason: "image pull failed"}))
+	assert.NoError(t, s.Record("default", "node01", &models.OnboardingStep{Name: models.OnboardingDownloaded, Status: models.OnboardingSucceeded}))
+	res, err = s.Get("default", "node01")
+	assert.NoError(t, err)
+	assert.Len(t, res.Steps, 3)
+	assert.Equal(t, models.OnboardingDownloaded, res.Steps[1].Name)
+	assert.False(t, res.Steps[1].Time.IsZero())
+	assert.False(t, res.Completed)
+	assert.Equal(t, models.OnboardingCoreStarted, res.Stalled)
+	assert.Equal(t, "image pull failed", res.Reason)
+
+	// the step reported again replaces the failed one
+	assert.NoError(t, s.Record("default", "node01", &models.OnboardingStep{Name: models.OnboardingCoreStarted, Status: models.OnboardingSucceeded}))
+	res, err = s.Get("default", "node01")
+	assert.NoError(t, err)
+	assert.Len(t, res.Steps, 3)
+	assert.Equal(t, models.OnboardingFirstReport, res.Stalled)
+	assert.Empty(t, res.Reason)
+
+	// the activation starts a new onboarding
+	assert.NoError(t, s.Record("default", "node01", &models.OnboardingStep{Name: models.OnboardingActivated, Status: models.OnboardingSucceeded}))
+	res, err = s.Get("default", "node01")
+	assert.NoError(t, err)
+	assert.Len(t, res.Steps, 1)
+	assert.Equal(t, models.OnboardingDownloaded, res.Stalled)
+}
+
+func TestNodeOnboardingRecordReport(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNode := ms.NewMockNodeService(mockCtl)
+	s := &NodeOnboardingServiceImpl{Node: mNode}
+
+	// the nodes activated before onboarding is recorded are not updated
+	assert.NoError(t, s.RecordReport(&specV1.Node{Namespace: "default", Name: "node01"}))
+
+	node := &specV1.Node{Namespace: "default", Name: "node01", Attributes: map[string]interface{}{common.AttributeOnboarding: map[string]interface{}{
+		"steps": []interface{}{
+			map[string]interface{}{"name": models.OnboardingActivated, "status": models.OnboardingSucceeded},
+			map[string]interface{}{"name": models.OnboardingDownloaded, "status": models.OnboardingSucceeded},
+		},
+	}}}
+	mNode.EXPECT().Update("default", node).Return(node, nil)
+	assert.NoError(t, s.RecordReport(node))
+	onboarding := node.Attributes[common.AttributeOnboarding].(*models.NodeOnboarding)
+	assert.True(t, onboarding.Completed)
+	assert.Len(t, onboarding.Steps, 4)
+	assert.Equal(t, models.OnboardingCoreStarted, onboarding.Steps[2].Name)
+
+	// the later reports update nothing
+	assert.NoError(t, s.RecordReport(node))
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// the characters not allowed in label values, which are replaced by '-' once rendered
+var invalidLabelValueChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// placementFuncs the functions of the label templates of placement
+var placementFuncs = template.FuncMap{
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"replace": strings.ReplaceAll,
+	"default": func(d, v string) string {
+		if v == "" {
+			return d
+		}
+		return v
+	},
+}
+
+// CheckNodePlacement checks the label keys and templates and the selectors of rules of placement
+func CheckNodePlacement(placement *models.NodePlacement) error {
+	for key, text := range placement.Labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the label "+key+" of placement is invalid: "+strings.Join(errs, ", ")))
+		}
+		if _, err := template.New(key).Funcs(placementFuncs).Parse(text); err != nil {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the template of label "+key+" is invalid: "+err.Error()))
+		}
+	}
+	for _, rule := range placement.Rules {
+		if _, err := labels.Parse(rule.Selector); err != nil {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the selector of placement rule is invalid: "+err.Error()))
+		}
+	}
+	return nil
+}
+
+// PlaceNode returns the labels rendered from the attributes and the node group of the first rule matching them,
+// which is empty if none matches. The values rendered are sanitized into label values, the empty ones are dropped
+func PlaceNode(placement *models.NodePlacement, attributes map[string]string) (map[string]string, string) {
+	res := map[string]string{}
+	for key, text := range placement.Labels {
+		value, err := renderPlacementLabel(key, text, attributes)
+		if err != nil {
+			log.L().Warn("failed to render the label of placement", log.Any("label", key), log.Error(err))
+			continue
+		}
+		if value != "" {
+			res[key] = value
+		}
+	}
+	for _, rule := range placement.Rules {
+		selector, err := labels.Parse(rule.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(res)) {
+			return res, rule.NodeGroup
+		}
+	}
+	return res, ""
+}
+
+func renderPlacementLabel(key, text string, attributes map[string]string) (string, error) {
+	t, err := template.New(key).Option("missingkey=zero").Funcs(placementFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err = t.Execute(buf, attributes); err != nil {
+		return "", err
+	}
+	value := invalidLabelValueChars.ReplaceAllString(buf.String(), "-")
+	if len(value) > validation.LabelValueMaxLength {
+		value = value[:validation.LabelValueMaxLength]
+	}
+	return strings.Trim(value, "._-"), nil
+}
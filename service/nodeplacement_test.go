@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestCheckNodePlacement(t *testing.T) {
+	assert.NoError(t, CheckNodePlacement(&models.NodePlacement{
+		Labels: map[string]string{"region": `{{default "unknown" .region}}`, "baetyl.io/model": "{{upper .model}}"},
+		Rules:  []models.NodePlacementRule{{Selector: "region in (beijing,shanghai)", NodeGroup: "group01"}},
+	}))
+	err := CheckNodePlacement(&models.NodePlacement{Labels: map[string]string{"bad key": "{{.region}}"}})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+	err = CheckNodePlacement(&models.NodePlacement{Labels: map[string]string{"region": "{{geo .ip}}"}})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+	err = CheckNodePlacement(&models.NodePlacement{Rules: []models.NodePlacementRule{{Selector: "region in (", NodeGroup: "group01"}}})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+}
+
+func TestPlaceNode(t *testing.T) {
+	placement := &models.NodePlacement{
+		Labels: map[string]string{"region": "{{.region}}", "site": `{{default "none" .city}}`, "owner": "{{.owner}}"},
+		Rules: []models.NodePlacementRule{
+			{Selector: "region=beijing,site=haidian", NodeGroup: "group01"},
+			{Selector: "region", NodeGroup: "group02"},
+		},
+	}
+	labels, group := PlaceNode(placement, map[string]string{"region": "beijing", "city": "haidian"})
+	assert.Equal(t, map[string]string{"region": "beijing", "site": "haidian"}, labels)
+	assert.Equal(t, "group01", group)
+
+	// the values are sanitized into label values
+	labels, group = PlaceNode(placement, map[string]string{"region": "San Francisco!"})
+	assert.Equal(t, map[string]string{"region": "San-Francisco", "site": "none"}, labels)
+	assert.Equal(t, "group02", group)
+
+	labels, group = PlaceNode(placement, map[string]string{})
+	assert.Equal(t, map[string]string{"site": "none"}, labels)
+	assert.Equal(t, "", group)
+}
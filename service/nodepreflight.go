@@ -0,0 +1,155 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/nodepreflight.go -package=service github.com/baetyl/baetyl-cloud/v2/service NodePreflightService
+
+type NodePreflightService interface {
+	// Get returns the latest preflight checks of node, which is nil if the host of node is never checked
+	Get(namespace, node string) (*models.NodePreflight, error)
+	// Check checks the facts of the host of node against the requirements and stores the result on node
+	Check(namespace, node string, facts *models.HostFacts) (*models.NodePreflight, error)
+	// Verify returns ErrNodePreflight if the host of node is refused by the latest checks
+	Verify(namespace, node string) error
+}
+
+type NodePreflightServiceImpl struct {
+	Node             NodeService
+	enforce          bool
+	minKernelVersion string
+	arches           []string
+	cgroupDrivers    []string
+	minDiskFree      int64
+}
+
+func NewNodePreflightService(config *config.CloudConfig) (NodePreflightService, error) {
+	node, err := NewNodeService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &NodePreflightServiceImpl{
+		Node:             node,
+		enforce:          config.Preflight.Enforce,
+		minKernelVersion: config.Preflight.MinKernelVersion,
+		arches:           config.Preflight.Arches,
+		cgroupDrivers:    config.Preflight.CgroupDrivers,
+		minDiskFree:      config.Preflight.MinDiskFree,
+	}, nil
+}
+
+func (s *NodePreflightServiceImpl) Get(namespace, node string) (*models.NodePreflight, error) {
+	n, err := s.Node.Get(nil, namespace, node)
+	if err != nil {
+		return nil, err
+	}
+	return getNodePreflight(n)
+}
+
+func (s *NodePreflightServiceImpl) Check(namespace, node string, facts *models.HostFacts) (*models.NodePreflight, error) {
+	n, err := s.Node.Get(nil, namespace, node)
+	if err != nil {
+		return nil, err
+	}
+	facts.Normalize()
+	res := s.evaluate(facts)
+	if n.Attributes == nil {
+		n.Attributes = map[string]interface{}{}
+	}
+	n.Attributes[common.AttributePreflight] = res
+	if _, err = s.Node.Update(namespace, n); err != nil {
+		return nil, err
+	}
+	if !res.Passed {
+		log.L().Warn("host of node failed preflight checks", log.Any("namespace", namespace), log.Any("name", node),
+			log.Any("errors", res.Errors), log.Any("refused", res.Refused))
+	}
+	return res, nil
+}
+
+func (s *NodePreflightServiceImpl) Verify(namespace, node string) error {
+	res, err := s.Get(namespace, node)
+	if err != nil {
+		return err
+	}
+	// the host failed before the checks are enforced is refused as well
+	if res == nil || res.Passed || !s.enforce {
+		return nil
+	}
+	return common.Error(common.ErrNodePreflight, common.Field("name", node), common.Field("errors", strings.Join(res.Errors, "; ")))
+}
+
+// evaluate checks the facts against the requirements set, the facts not collected are warned of rather than failed,
+// such as the cgroup driver of the hosts without container runtime
+func (s *NodePreflightServiceImpl) evaluate(facts *models.HostFacts) *models.NodePreflight {
+	res := &models.NodePreflight{Facts: *facts, Time: time.Now().UTC()}
+	linux := facts.OS == "" || strings.EqualFold(facts.OS, "linux")
+	if s.minKernelVersion != "" && linux {
+		if cmp, ok := models.CompareKernelVersion(facts.Kernel, s.minKernelVersion); !ok {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("the kernel version %q is unknown", facts.Kernel))
+		} else if cmp < 0 {
+			res.Errors = append(res.Errors, fmt.Sprintf("the kernel version %s is lower than %s", facts.Kernel, s.minKernelVersion))
+		}
+	}
+	if len(s.arches) > 0 {
+		if facts.Arch == "" {
+			res.Warnings = append(res.Warnings, "the architecture is unknown")
+		} else if !containsFold(s.arches, facts.Arch) {
+			res.Errors = append(res.Errors, fmt.Sprintf("the architecture %s is not supported, which should be one of %s", facts.Arch, strings.Join(s.arches, ", ")))
+		}
+	}
+	if len(s.cgroupDrivers) > 0 && linux {
+		if facts.CgroupDriver == "" {
+			res.Warnings = append(res.Warnings, "the cgroup driver is unknown")
+		} else if !containsFold(s.cgroupDrivers, facts.CgroupDriver) {
+			res.Errors = append(res.Errors, fmt.Sprintf("the cgroup driver %s is not supported, which should be one of %s", facts.CgroupDriver, strings.Join(s.cgroupDrivers, ", ")))
+		}
+	}
+	if s.minDiskFree > 0 {
+		if facts.DiskFree == 0 {
+			res.Warnings = append(res.Warnings, "the free disk space is unknown")
+		} else if facts.DiskFree < s.minDiskFree {
+			res.Errors = append(res.Errors, fmt.Sprintf("the free disk space %dMB is less than %dMB", facts.DiskFree, s.minDiskFree))
+		}
+	}
+	res.Passed = len(res.Errors) == 0
+	res.Refused = !res.Passed && s.enforce
+	return res
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func getNodePreflight(node *specV1.Node) (*models.NodePreflight, error) {
+	val, ok := node.Attributes[common.AttributePreflight]
+	if !ok || val == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	preflight := new(models.NodePreflight)
+	if err = json.Unmarshal(data, preflight); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return preflight, nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNodePreflightCheck(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNode := ms.NewMockNodeService(mockCtl)
+	s := &NodePreflightServiceImpl{Node: mNode, minKernelVersion: "4.4", arches: []string{"amd64", "arm64"}, cgroupDrivers: []string{"systemd"}, minDiskFree: 1024}
+
+	node := &specV1.Node{Namespace: "default", Name: "node01"}
+	mNode.EXPECT().Get(nil, "default", "node01").Return(node, nil).AnyTimes()
+	mNode.EXPECT().Update("default", node).Return(node, nil).AnyTimes()
+
+	res, err := s.Check("default", "node01", &models.HostFacts{OS: "Linux", Kernel: "5.4.0-42-generic", Arch: "x86_64", CgroupDriver: "Systemd", DiskFree: 20480})
+	assert.NoError(t, err)
+	assert.True(t, res.Passed)
+	assert.Empty(t, res.Warnings)
+	assert.Equal(t, "amd64", res.Facts.Arch)
+	assert.Equal(t, res, node.Attributes[common.AttributePreflight])
+
+	// the facts not collected are warned of
+	res, err = s.Check("default", "node01", &models.HostFacts{Kernel: "4.19", Arch: "aarch64"})
+	assert.NoError(t, err)
+	assert.True(t, res.Passed)
+	assert.Len(t, res.Warnings, 2)
+
+	res, err = s.Check("default", "node01", &models.HostFacts{Kernel: "3.10.0-1160.el7.x86_64", Arch: "armv7l", CgroupDriver: "cgroupfs", DiskFree: 512})
+	assert.NoError(t, err)
+	assert.False(t, res.Passed)
+	assert.False(t, res.Refused)
+	assert.Len(t, res.Errors, 4)
+	assert.NoError(t, s.Verify("default", "node01"))
+
+	// the host failed is refused once the checks are enforced
+	s.enforce = true
+	assertErrorCode(t, common.ErrNodePreflight, s.Verify("default", "node01"))
+	res, err = s.Check("default", "node01", &models.HostFacts{Kernel: "3.10", Arch: "amd64"})
+	assert.NoError(t, err)
+	assert.True(t, res.Refused)
+
+	// the windows hosts are not checked by the kernel nor the cgroup driver
+	res, err = s.Check("default", "node01", &models.HostFacts{OS: "Windows", Kernel: "10.0.19041", Arch: "amd64", DiskFree: 2048})
+	assert.NoError(t, err)
+	assert.True(t, res.Passed)
+	assert.NoError(t, s.Verify("default", "node01"))
+}
+
+func TestCompareKernelVersion(t *testing.T) {
+	cmp, ok := models.CompareKernelVersion("5.4.0-42-generic", "4.4")
+	assert.True(t, ok)
+	assert.Equal(t, 1, cmp)
+	cmp, ok = models.CompareKernelVersion("4.4", "4.4.0")
+	assert.True(t, ok)
+	assert.Equal(t, 0, cmp)
+	cmp, ok = models.CompareKernelVersion("3.10.0-1160.el7.x86_64", "4.4")
+	assert.True(t, ok)
+	assert.Equal(t, -1, cmp)
+	_, ok = models.CompareKernelVersion("", "4.4")
+	assert.False(t, ok)
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/noderegistration.go -package=service github.com/baetyl/baetyl-cloud/v2/service NodeRegistrationService
+
+// registrationNamePrefix the prefix of the names of nodes registered without names, followed by the hash of fingerprint
+const registrationNamePrefix = "node-"
+
+type NodeRegistrationService interface {
+	Get(namespace, name string) (*models.NodeRegistration, error)
+	GetByFingerprint(namespace, fingerprint string) (*models.NodeRegistration, error)
+	// List lists the registrations of the status, all are listed if status is empty
+	List(namespace, status string) ([]models.NodeRegistration, error)
+	// Register creates the pending registration of device, the existing one is returned if the fingerprint is
+	// registered concurrently
+	Register(registration *models.NodeRegistration) (*models.NodeRegistration, error)
+	// Place sets the labels and the node group of registration by the placement of token, the attributes rendered
+	// are the hardware, the fingerprint, the mode, the ip and its location
+	Place(token *models.ActivationToken, registration *models.NodeRegistration, ip string)
+	Update(registration *models.NodeRegistration) error
+	Delete(namespace, name string) error
+	// Match returns the first rule by name approving the registration, which is nil if none matches
+	Match(registration *models.NodeRegistration) (*models.NodeRegistrationRule, error)
+
+	GetRule(namespace, name string) (*models.NodeRegistrationRule, error)
+	ListRule(namespace string) ([]models.NodeRegistrationRule, error)
+	CreateRule(rule *models.NodeRegistrationRule) (*models.NodeRegistrationRule, error)
+	DeleteRule(namespace, name string) error
+}
+
+type NodeRegistrationServiceImpl struct {
+	Registration plugin.NodeRegistration
+	Node         NodeService
+	// Geolocation locates the ips of registrations, which are not located if nil
+	Geolocation plugin.Geolocation
+}
+
+func NewNodeRegistrationService(config *config.CloudConfig) (NodeRegistrationService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.NodeRegistration)
+	if err != nil {
+		return nil, err
+	}
+	node, err := NewNodeService(config)
+	if err != nil {
+		return nil, err
+	}
+	s := &NodeRegistrationServiceImpl{
+		Registration: p.(plugin.NodeRegistration),
+		Node:         node,
+	}
+	if config.Plugin.Geolocation != "" {
+		g, err := plugin.GetPlugin(config.Plugin.Geolocation)
+		if err != nil {
+			return nil, err
+		}
+		s.Geolocation = g.(plugin.Geolocation)
+	}
+	return s, nil
+}
+
+func (s *NodeRegistrationServiceImpl) Get(namespace, name string) (*models.NodeRegistration, error) {
+	return s.Registration.GetNodeRegistration(namespace, name)
+}
+
+func (s *NodeRegistrationServiceImpl) GetByFingerprint(namespace, fingerprint string) (*models.NodeRegistration, error) {
+	return s.Registration.GetNodeRegistrationByFingerprint(namespace, fingerprint)
+}
+
+func (s *NodeRegistrationServiceImpl) List(namespace, status string) ([]models.NodeRegistration, error) {
+	return s.Registration.ListNodeRegistration(namespace, status)
+}
+
+func (s *NodeRegistrationServiceImpl) Register(registration *models.NodeRegistration) (*models.NodeRegistration, error) {
+	if registration.Name == "" {
+		registration.Name = RegistrationNodeName(registration.Fingerprint)
+	}
+	if registration.Mode == "" {
+		registration.Mode = context.RunModeKube
+	}
+	registration.Status = models.NodeRegistrationPending
+	if _, err := s.Node.Get(nil, registration.Namespace, registration.Name); err == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the name of node is already in use"))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, err
+	}
+	err := s.Registration.CreateNodeRegistration(registration)
+	if err != nil {
+		e, ok := err.(errors.Coder)
+		if !ok || e.Code() != common.ErrResourceConflict {
+			return nil, err
+		}
+		if res, gerr := s.Registration.GetNodeRegistrationByFingerprint(registration.Namespace, registration.Fingerprint); gerr == nil {
+			return res, nil
+		}
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the name of node is already in use"))
+	}
+	return s.Registration.GetNodeRegistration(registration.Namespace, registration.Name)
+}
+
+func (s *NodeRegistrationServiceImpl) Place(token *models.ActivationToken, registration *models.NodeRegistration, ip string) {
+	if token.Placement == nil {
+		return
+	}
+	attributes := map[string]string{}
+	for k, v := range registration.Hardware {
+		attributes[k] = v
+	}
+	attributes[models.PlacementAttributeFingerprint] = registration.Fingerprint
+	attributes[models.PlacementAttributeMode] = registration.Mode
+	attributes[models.PlacementAttributeIP] = ip
+	if s.Geolocation != nil && ip != "" {
+		// the registration is placed without the location if the ip is not located
+		location, err := s.Geolocation.Locate(ip)
+		if err != nil {
+			log.L().Warn("failed to locate the ip of registration", log.Any("ip", ip), log.Error(err))
+		} else if location != nil {
+			attributes[models.PlacementAttributeCountry] = location.Country
+			attributes[models.PlacementAttributeRegion] = location.Region
+			attributes[models.PlacementAttributeCity] = location.City
+		}
+	}
+	labels, group := PlaceNode(token.Placement, attributes)
+	if len(labels) > 0 {
+		registration.Labels = labels
+	}
+	if group != "" {
+		registration.NodeGroup = group
+	}
+}
+
+func (s *NodeRegistrationServiceImpl) Update(registration *models.NodeRegistration) error {
+	return s.Registration.UpdateNodeRegistration(registration)
+}
+
+func (s *NodeRegistrationServiceImpl) Delete(namespace, name string) error {
+	return s.Registration.DeleteNodeRegistration(namespace, name)
+}
+
+func (s *NodeRegistrationServiceImpl) Match(registration *models.NodeRegistration) (*models.NodeRegistrationRule, error) {
+	rules, err := s.Registration.ListNodeRegistrationRule(registration.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		if isRegistrationMatched(&rules[i], registration) {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *NodeRegistrationServiceImpl) GetRule(namespace, name string) (*models.NodeRegistrationRule, error) {
+	return s.Registration.GetNodeRegistrationRule(namespace, name)
+}
+
+func (s *NodeRegistrationServiceImpl) ListRule(namespace string) ([]models.NodeRegistrationRule, error) {
+	return s.Registration.ListNodeRegistrationRule(namespace)
+}
+
+func (s *NodeRegistrationServiceImpl) CreateRule(rule *models.NodeRegistrationRule) (*models.NodeRegistrationRule, error) {
+	if _, err := path.Match(rule.Fingerprint, ""); err != nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the fingerprint of rule should be a glob pattern"))
+	}
+	if _, err := s.Registration.GetNodeRegistrationRule(rule.Namespace, rule.Name); err == nil {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "this name is already in use"))
+	} else if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+		return nil, err
+	}
+	if err := s.Registration.CreateNodeRegistrationRule(rule); err != nil {
+		return nil, err
+	}
+	return s.Registration.GetNodeRegistrationRule(rule.Namespace, rule.Name)
+}
+
+func (s *NodeRegistrationServiceImpl) DeleteRule(namespace, name string) error {
+	return s.Registration.DeleteNodeRegistrationRule(namespace, name)
+}
+
+// RegistrationNodeName returns the name of node registered by the fingerprint without a name requested
+func RegistrationNodeName(fingerprint string) string {
+	hash := sha256.Sum256([]byte(fingerprint))
+	return registrationNamePrefix + hex.EncodeToString(hash[:])[:16]
+}
+
+func isRegistrationMatched(rule *models.NodeRegistrationRule, registration *models.NodeRegistration) bool {
+	if ok, err := path.Match(rule.Fingerprint, registration.Fingerprint); err != nil || !ok {
+		return false
+	}
+	for k, v := range rule.Hardware {
+		if hw, ok := registration.Hardware[k]; !ok || hw != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNodeRegistrationRegister(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mRegistration, mNode := mockPlugin.NewMockNodeRegistration(mockCtl), ms.NewMockNodeService(mockCtl)
+	s := &NodeRegistrationServiceImpl{Registration: mRegistration, Node: mNode}
+
+	// the node is named by the fingerprint
+	name := RegistrationNodeName("sn-0001")
+	assert.Equal(t, name, RegistrationNodeName("sn-0001"))
+	assert.NotEqual(t, name, RegistrationNodeName("sn-0002"))
+	expect := &models.NodeRegistration{Namespace: "default", Name: name, Fingerprint: "sn-0001", Mode: "kube", Token: "token01", Status: models.NodeRegistrationPending}
+	mNode.EXPECT().Get(nil, "default", name).Return(nil, common.Error(common.ErrResourceNotFound))
+	mRegistration.EXPECT().CreateNodeRegistration(expect).Return(nil)
+	mRegistration.EXPECT().GetNodeRegistration("default", name).Return(expect, nil)
+	res, err := s.Register(&models.NodeRegistration{Namespace: "default", Fingerprint: "sn-0001", Token: "token01"})
+	assert.NoError(t, err)
+	assert.Equal(t, expect, res)
+
+	// the fingerprint registered concurrently
+	mNode.EXPECT().Get(nil, "default", name).Return(nil, common.Error(common.ErrResourceNotFound))
+	mRegistration.EXPECT().CreateNodeRegistration(gomock.Any()).Return(common.Error(common.ErrResourceConflict))
+	mRegistration.EXPECT().GetNodeRegistrationByFingerprint("default", "sn-0001").Return(expect, nil)
+	res, err = s.Register(&models.NodeRegistration{Namespace: "default", Fingerprint: "sn-0001", Token: "token01"})
+	assert.NoError(t, err)
+	assert.Equal(t, expect, res)
+
+	// the name requested is used by another registration or node
+	mNode.EXPECT().Get(nil, "default", "node01").Return(nil, common.Error(common.ErrResourceNotFound))
+	mRegistration.EXPECT().CreateNodeRegistration(gomock.Any()).Return(common.Error(common.ErrResourceConflict))
+	mRegistration.EXPECT().GetNodeRegistrationByFingerprint("default", "sn-0002").Return(nil, common.Error(common.ErrResourceNotFound))
+	_, err = s.Register(&models.NodeRegistration{Namespace: "default", Name: "node01", Fingerprint: "sn-0002"})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+
+	mNode.EXPECT().Get(nil, "default", "node02").Return(&specV1.Node{Namespace: "default", Name: "node02"}, nil)
+	_, err = s.Register(&models.NodeRegistration{Namespace: "default", Name: "node02", Fingerprint: "sn-0003"})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+}
+
+func TestNodeRegistrationMatch(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mRegistration := mockPlugin.NewMockNodeRegistration(mockCtl)
+	s := &NodeRegistrationServiceImpl{Registration: mRegistration}
+
+	rules := []models.NodeRegistrationRule{
+		{Namespace: "default", Name: "rule01", Fingerprint: "sn-1*", Hardware: map[string]string{"model": "box-x1"}},
+		{Namespace: "default", Name: "rule02", Fingerprint: "sn-2*"},
+	}
+	mRegistration.EXPECT().ListNodeRegistrationRule("default").Return(rules, nil).Times(4)
+
+	rule, err := s.Match(&models.NodeRegistration{Namespace: "default", Fingerprint: "sn-1001", Hardware: map[string]string{"model": "box-x1", "cpu": "arm64"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "rule01", rule.Name)
+	rule, err = s.Match(&models.NodeRegistration{Namespace: "default", Fingerprint: "sn-1001", Hardware: map[string]string{"model": "box-x2"}})
+	assert.NoError(t, err)
+	assert.Nil(t, rule)
+	rule, err = s.Match(&models.NodeRegistration{Namespace: "default", Fingerprint: "sn-2001"})
+	assert.NoError(t, err)
+	assert.Equal(t, "rule02", rule.Name)
+	rule, err = s.Match(&models.NodeRegistration{Namespace: "default", Fingerprint: "sn-3001"})
+	assert.NoError(t, err)
+	assert.Nil(t, rule)
+}
+
+func TestNodeRegistrationCreateRule(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mRegistration := mockPlugin.NewMockNodeRegistration(mockCtl)
+	s := &NodeRegistrationServiceImpl{Registration: mRegistration}
+
+	_, err := s.CreateRule(&models.NodeRegistrationRule{Namespace: "default", Name: "rule01", Fingerprint: "sn-["})
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+
+	rule := &models.NodeRegistrationRule{Namespace: "default", Name: "rule01", Fingerprint: "sn-*"}
+	mRegistration.EXPECT().GetNodeRegistrationRule("default", "rule01").Return(nil, common.Error(common.ErrResourceNotFound))
+	mRegistration.EXPECT().CreateNodeRegistrationRule(rule).Return(nil)
+	mRegistration.EXPECT().GetNodeRegistrationRule("default", "rule01").Return(rule, nil)
+	res, err := s.CreateRule(rule)
+	assert.NoError(t, err)
+	assert.Equal(t, rule, res)
+
+	mRegistration.EXPECT().GetNodeRegistrationRule("default", "rule01").Return(rule, nil)
+	_, err = s.CreateRule(rule)
+	assertErrorCode(t, common.ErrRequestParamInvalid, err)
+}
+
+func TestNodeRegistrationPlace(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mGeolocation := mockPlugin.NewMockGeolocation(mockCtl)
+	s := &NodeRegistrationServiceImpl{Geolocation: mGeolocation}
+
+	token := &models.ActivationToken{Namespace: "default", Name: "token01", NodeGroup: "group01", Placement: &models.NodePlacement{
+		Labels: map[string]string{"region": "{{.region}}", "model": "{{lower .model}}", "fingerprint": "{{.fingerprint}}"},
+		Rules:  []models.NodePlacementRule{{Selector: "region=beijing", NodeGroup: "group-beijing"}},
+	}}
+	// the hardware reported does not override the location
+	registration := &models.NodeRegistration{Fingerprint: "sn-0001", Hardware: map[string]string{"model": "Box X1", "region": "fake"}, NodeGroup: "group01"}
+	mGeolocation.EXPECT().Locate("10.1.2.3").Return(&models.Geolocation{Country: "CN", Region: "beijing"}, nil)
+	s.Place(token, registration, "10.1.2.3")
+	assert.Equal(t, map[string]string{"region": "beijing", "model": "box-x1", "fingerprint": "sn-0001"}, registration.Labels)
+	assert.Equal(t, "group-beijing", registration.NodeGroup)
+
+	// the node stays in the group of token if no rule matches
+	registration = &models.NodeRegistration{Fingerprint: "sn-0002", NodeGroup: "group01"}
+	mGeolocation.EXPECT().Locate("10.9.2.3").Return(nil, nil)
+	s.Place(token, registration, "10.9.2.3")
+	assert.Equal(t, map[string]string{"fingerprint": "sn-0002"}, registration.Labels)
+	assert.Equal(t, "group01", registration.NodeGroup)
+
+	registration = &models.NodeRegistration{Fingerprint: "sn-0003", NodeGroup: "group01"}
+	s.Place(&models.ActivationToken{NodeGroup: "group01"}, registration, "10.1.2.3")
+	assert.Nil(t, registration.Labels)
+}
@@ -22,6 +22,8 @@ type ObjectService interface {
 	GenInternalObjectPutURL(userID string, bucket, object, source string) (*models.ObjectURL, error)
 	PutInternalObject(userID, bucket, name, source string, b []byte) error
 	HeadInternalObject(userID, bucket, name, source string) (*models.ObjectMeta, error)
+	GetInternalObject(userID, bucket, name, source string) (*models.Object, error)
+	DeleteInternalObject(userID, bucket, name, source string) error
 
 	ListExternalBuckets(info models.ExternalObjectInfo, source string) ([]models.Bucket, error)
 	ListExternalBucketObjects(info models.ExternalObjectInfo, bucket, source string) (*models.ListObjectsResult, error)
@@ -174,6 +176,22 @@ func (c *objectService) HeadInternalObject(userID, bucket, name, source string)
 	return objectPlugin.HeadInternalObject(userID, bucket, name)
 }
 
+func (c *objectService) GetInternalObject(userID, bucket, name, source string) (*models.Object, error) {
+	objectPlugin, ok := c.objects[source]
+	if !ok {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("the source (%s) is not supported", source)))
+	}
+	return objectPlugin.GetInternalObject(userID, bucket, name)
+}
+
+func (c *objectService) DeleteInternalObject(userID, bucket, name, source string) error {
+	objectPlugin, ok := c.objects[source]
+	if !ok {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("the source (%s) is not supported", source)))
+	}
+	return objectPlugin.DeleteInternalObject(userID, bucket, name)
+}
+
 func (c *objectService) CreateExternalBucket(info models.ExternalObjectInfo, bucket, permission, source string) error {
 	objectPlugin, ok := c.objects[source]
 	if !ok {
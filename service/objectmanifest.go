@@ -0,0 +1,155 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/objectmanifest.go -package=service github.com/baetyl/baetyl-cloud/v2/service ObjectManifestService
+
+// ObjectManifestService computes the manifests of chunks of the large objects referenced by configs
+type ObjectManifestService interface {
+	// Get returns the manifest of the object of item, which is nil if the object is not larger than the threshold.
+	// The manifest is computed in background at the first time, nil is returned until it's done
+	Get(userID string, item *models.ConfigObjectItem) (*models.ObjectManifest, error)
+}
+
+type ObjectManifestServiceImpl struct {
+	Object    ObjectService
+	threshold int64
+	chunkSize int64
+	max       int
+
+	mu        sync.Mutex
+	manifests map[string]*models.ObjectManifest
+	// order the keys of manifests cached, the oldest ones are evicted first
+	order   []string
+	pending map[string]bool
+}
+
+func NewObjectManifestService(config *config.CloudConfig) (ObjectManifestService, error) {
+	object, err := NewObjectService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectManifestServiceImpl{
+		Object:    object,
+		threshold: config.ObjectDistribution.Threshold,
+		chunkSize: config.ObjectDistribution.ChunkSize,
+		max:       config.ObjectDistribution.CacheSize,
+		manifests: map[string]*models.ObjectManifest{},
+		pending:   map[string]bool{},
+	}, nil
+}
+
+func (s *ObjectManifestServiceImpl) Get(userID string, item *models.ConfigObjectItem) (*models.ObjectManifest, error) {
+	meta, err := s.head(userID, item)
+	if err != nil {
+		return nil, err
+	}
+	if meta.Size <= s.threshold {
+		return nil, nil
+	}
+	// the objects are keyed with the etags, so that the ones overwritten are computed again
+	key := strings.Join([]string{item.Source, item.Endpoint, item.Bucket, item.Object, meta.ETag}, "/")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if m, ok := s.manifests[key]; ok {
+		return m, nil
+	}
+	if !s.pending[key] {
+		s.pending[key] = true
+		go s.compute(key, userID, *item, meta)
+	}
+	return nil, nil
+}
+
+func (s *ObjectManifestServiceImpl) compute(key, userID string, item models.ConfigObjectItem, meta *models.ObjectManifest) {
+	m, err := s.chunk(userID, &item, meta)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pending, key)
+	if err != nil {
+		log.L().Warn("failed to compute the manifest of object", log.Any("bucket", item.Bucket), log.Any("object", item.Object), log.Error(err))
+		return
+	}
+	s.manifests[key] = m
+	s.order = append(s.order, key)
+	for len(s.order) > s.max {
+		delete(s.manifests, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// chunk reads the object through and sums each chunk
+func (s *ObjectManifestServiceImpl) chunk(userID string, item *models.ConfigObjectItem, meta *models.ObjectManifest) (*models.ObjectManifest, error) {
+	var obj *models.Object
+	var err error
+	if item.Endpoint == "" {
+		obj, err = s.Object.GetInternalObject(userID, item.Bucket, item.Object, item.Source)
+	} else {
+		obj, err = s.Object.GetExternalObject(externalObjectInfo(item), item.Bucket, item.Object, item.Source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Body.Close()
+
+	res := &models.ObjectManifest{Size: meta.Size, ETag: meta.ETag, ChunkSize: s.chunkSize}
+	buf := make([]byte, s.chunkSize)
+	var offset int64
+	for {
+		n, err := io.ReadFull(obj.Body, buf)
+		if n > 0 {
+			sum := sha256.Sum256(buf[:n])
+			res.Chunks = append(res.Chunks, models.ObjectChunk{Offset: offset, Size: int64(n), Sha256: hex.EncodeToString(sum[:])})
+			offset += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if offset != meta.Size {
+		return nil, common.Error(common.ErrObjectOperationException, common.Field("source", item.Source),
+			common.Field("error", fmt.Sprintf("the object has %d bytes while %d expected", offset, meta.Size)))
+	}
+	return res, nil
+}
+
+// head returns the size and etag of the object of item
+func (s *ObjectManifestServiceImpl) head(userID string, item *models.ConfigObjectItem) (*models.ObjectManifest, error) {
+	var meta *models.ObjectMeta
+	var err error
+	if item.Endpoint == "" {
+		meta, err = s.Object.HeadInternalObject(userID, item.Bucket, item.Object, item.Source)
+	} else {
+		meta, err = s.Object.HeadExternalObject(externalObjectInfo(item), item.Bucket, item.Object, item.Source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &models.ObjectManifest{Size: meta.ContentLength, ETag: meta.ETag}, nil
+}
+
+func externalObjectInfo(item *models.ConfigObjectItem) models.ExternalObjectInfo {
+	return models.ExternalObjectInfo{
+		Endpoint:      item.Endpoint,
+		Ak:            item.Ak,
+		Sk:            item.Sk,
+		AddressFormat: item.AddressFormat,
+	}
+}
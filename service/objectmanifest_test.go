@@ -0,0 +1,132 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestObjectManifest(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mObject := ms.NewMockObjectService(mockCtl)
+	oms := &ObjectManifestServiceImpl{
+		Object:    mObject,
+		threshold: 8,
+		chunkSize: 4,
+		max:       1,
+		manifests: map[string]*models.ObjectManifest{},
+		pending:   map[string]bool{},
+	}
+	item := &models.ConfigObjectItem{Source: "awss3", Bucket: "b", Object: "model.bin"}
+	data := []byte("0123456789")
+
+	// the small objects have no manifests
+	mObject.EXPECT().HeadInternalObject("u", "b", "model.bin", "awss3").Return(&models.ObjectMeta{ContentLength: 8, ETag: "e0"}, nil)
+	m, err := oms.Get("u", item)
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+
+	// the manifest is computed in background once
+	mObject.EXPECT().HeadInternalObject("u", "b", "model.bin", "awss3").Return(&models.ObjectMeta{ContentLength: 10, ETag: "e1"}, nil).AnyTimes()
+	mObject.EXPECT().GetInternalObject("u", "b", "model.bin", "awss3").Return(&models.Object{Body: ioutil.NopCloser(bytes.NewReader(data))}, nil).Times(1)
+	m, err = oms.Get("u", item)
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+	assert.Eventually(t, func() bool {
+		m, err = oms.Get("u", item)
+		return err == nil && m != nil
+	}, time.Second, 10*time.Millisecond)
+	sum := func(b []byte) string {
+		s := sha256.Sum256(b)
+		return hex.EncodeToString(s[:])
+	}
+	assert.Equal(t, &models.ObjectManifest{Size: 10, ETag: "e1", ChunkSize: 4, Chunks: []models.ObjectChunk{
+		{Offset: 0, Size: 4, Sha256: sum(data[:4])},
+		{Offset: 4, Size: 4, Sha256: sum(data[4:8])},
+		{Offset: 8, Size: 2, Sha256: sum(data[8:])},
+	}}, m)
+
+	// the manifest is not kept if the object is changed while reading
+	other := &models.ConfigObjectItem{Source: "awss3", Endpoint: "http://s3", Bucket: "b", Object: "other.bin"}
+	info := models.ExternalObjectInfo{Endpoint: "http://s3"}
+	mObject.EXPECT().HeadExternalObject(info, "b", "other.bin", "awss3").Return(&models.ObjectMeta{ContentLength: 12, ETag: "e2"}, nil)
+	mObject.EXPECT().GetExternalObject(info, "b", "other.bin", "awss3").Return(&models.Object{Body: ioutil.NopCloser(bytes.NewReader(data))}, nil)
+	m, err = oms.Get("u", other)
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+	assert.Eventually(t, func() bool {
+		oms.mu.Lock()
+		defer oms.mu.Unlock()
+		return len(oms.pending) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, oms.manifests, 1)
+}
+
+func TestSyncDesireObjectManifest(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	cs, ns, sObject, oms := ms.NewMockConfigService(mockCtl), ms.NewMockNodeService(mockCtl), ms.NewMockObjectService(mockCtl), ms.NewMockObjectManifestService(mockCtl)
+	sync := SyncServiceImpl{ConfigService: cs, NodeService: ns, ObjectService: sObject, ObjectManifest: oms, downloadRate: 1024, Hooks: map[string]interface{}{}}
+	sync.Hooks[HookNamePopulateConfig] = HandlerPopulateConfig(sync.PopulateConfig)
+
+	object, err := json.Marshal(&specV1.ConfigurationObject{Metadata: map[string]string{"type": "object", "source": "awss3", "bucket": "b", "object": "model.bin", "userID": "u"}})
+	assert.NoError(t, err)
+	reqs := []specV1.ResourceInfo{{Kind: specV1.KindConfiguration, Name: "model", Version: "v1"}}
+	metadata := map[string]string{"namespace": "ns01", "name": "node01", models.SyncProtocolVersion: "3"}
+	newConfig := func() *specV1.Configuration {
+		return &specV1.Configuration{Name: "model", Version: "v1", Data: map[string]string{common.ConfigObjectPrefix + "model.bin": string(object)}}
+	}
+	item := &models.ConfigObjectItem{Source: "awss3", Bucket: "b", Object: "model.bin"}
+	manifest := &models.ObjectManifest{Size: 10, ChunkSize: 4, Chunks: []models.ObjectChunk{{Offset: 0, Size: 4, Sha256: "s"}}}
+
+	cs.EXPECT().Get("ns01", "model", "v1").Return(newConfig(), nil)
+	sObject.EXPECT().GenInternalObjectURL("u", "b", "model.bin", "awss3").Return(&models.ObjectURL{URL: "http://s3/model.bin", MD5: "md5"}, nil)
+	oms.EXPECT().Get("u", item).Return(manifest, nil)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(&specV1.Node{Name: "node01", Labels: map[string]string{common.LabelDownloadRate: "2Ki"}}, nil)
+	res, err := sync.Desire("ns01", reqs, metadata)
+	assert.NoError(t, err)
+	var obj specV1.ConfigurationObject
+	assert.NoError(t, json.Unmarshal([]byte(res[0].Value.Value.(*specV1.Configuration).Data[common.ConfigObjectPrefix+"model.bin"]), &obj))
+	assert.Equal(t, "http://s3/model.bin", obj.URL)
+	var delivered models.ObjectManifest
+	assert.NoError(t, json.Unmarshal([]byte(obj.Metadata[models.ObjectManifestKey]), &delivered))
+	assert.Equal(t, int64(2048), delivered.RateLimit)
+	assert.Equal(t, manifest.Chunks, delivered.Chunks)
+	// the manifest cached is untouched
+	assert.Equal(t, int64(0), manifest.RateLimit)
+
+	// the default rate is used for the nodes without label, and the object is delivered without manifest until computed
+	cs.EXPECT().Get("ns01", "model", "v1").Return(newConfig(), nil)
+	sObject.EXPECT().GenInternalObjectURL("u", "b", "model.bin", "awss3").Return(&models.ObjectURL{URL: "http://s3/model.bin"}, nil)
+	oms.EXPECT().Get("u", item).Return(nil, nil)
+	res, err = sync.Desire("ns01", reqs, metadata)
+	assert.NoError(t, err)
+	obj = specV1.ConfigurationObject{}
+	assert.NoError(t, json.Unmarshal([]byte(res[0].Value.Value.(*specV1.Configuration).Data[common.ConfigObjectPrefix+"model.bin"]), &obj))
+	assert.Nil(t, obj.Metadata)
+
+	cs.EXPECT().Get("ns01", "model", "v1").Return(newConfig(), nil)
+	sObject.EXPECT().GenInternalObjectURL("u", "b", "model.bin", "awss3").Return(&models.ObjectURL{URL: "http://s3/model.bin"}, nil)
+	oms.EXPECT().Get("u", item).Return(manifest, nil)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(&specV1.Node{Name: "node01"}, nil)
+	res, err = sync.Desire("ns01", reqs, metadata)
+	assert.NoError(t, err)
+	obj = specV1.ConfigurationObject{}
+	assert.NoError(t, json.Unmarshal([]byte(res[0].Value.Value.(*specV1.Configuration).Data[common.ConfigObjectPrefix+"model.bin"]), &obj))
+	delivered = models.ObjectManifest{}
+	assert.NoError(t, json.Unmarshal([]byte(obj.Metadata[models.ObjectManifestKey]), &delivered))
+	assert.Equal(t, int64(1024), delivered.RateLimit)
+}
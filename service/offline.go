@@ -0,0 +1,167 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/offline.go -package=service github.com/baetyl/baetyl-cloud/v2/service OfflineService
+
+// OfflineService exchanges the desire and report with the air-gapped nodes through the files carried offline
+type OfflineService interface {
+	// Bundle renders the full desired state of node into a bundle signed by the sync key of node
+	Bundle(namespace, node string) (*models.OfflineBundle, error)
+	// ImportReport reconciles the status of node with the report carried from it
+	ImportReport(namespace, node string, report *models.OfflineReport) (*models.OfflineReportImport, error)
+}
+
+type OfflineServiceImpl struct {
+	Node NodeService
+	Sync SyncService
+	Sign SyncSignService
+}
+
+func NewOfflineService(config *config.CloudConfig) (OfflineService, error) {
+	node, err := NewNodeService(config)
+	if err != nil {
+		return nil, err
+	}
+	sync, err := NewSyncService(config)
+	if err != nil {
+		return nil, err
+	}
+	sign, err := NewSyncSignService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &OfflineServiceImpl{
+		Node: node,
+		Sync: sync,
+		Sign: sign,
+	}, nil
+}
+
+func (s *OfflineServiceImpl) Bundle(namespace, node string) (*models.OfflineBundle, error) {
+	n, err := s.Node.Get(nil, namespace, node)
+	if err != nil {
+		return nil, err
+	}
+	// the resources are rendered as they are synchronized by node
+	metadata := map[string]string{"namespace": namespace, "name": node}
+	var infos []specV1.ResourceInfo
+	for _, app := range append(n.Desire.AppInfos(true), n.Desire.AppInfos(false)...) {
+		infos = append(infos, specV1.ResourceInfo{Kind: specV1.KindApplication, Name: app.Name, Version: app.Version})
+	}
+	apps, err := s.Sync.Desire(namespace, infos, metadata)
+	if err != nil {
+		return nil, err
+	}
+	var refs []specV1.ResourceInfo
+	seen := map[string]bool{}
+	for _, v := range apps {
+		app, ok := v.Value.Value.(*specV1.Application)
+		if !ok {
+			continue
+		}
+		for _, vol := range app.Volumes {
+			var info specV1.ResourceInfo
+			switch {
+			case vol.Config != nil:
+				info = specV1.ResourceInfo{Kind: specV1.KindConfiguration, Name: vol.Config.Name, Version: vol.Config.Version}
+			case vol.Secret != nil:
+				info = specV1.ResourceInfo{Kind: specV1.KindSecret, Name: vol.Secret.Name, Version: vol.Secret.Version}
+			default:
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%s", info.Kind, info.Name, info.Version)
+			if !seen[key] {
+				seen[key] = true
+				refs = append(refs, info)
+			}
+		}
+	}
+	deps, err := s.Sync.Desire(namespace, refs, metadata)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := offlineBundleObjects(deps)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &models.OfflineBundle{
+		Namespace:  namespace,
+		Node:       node,
+		CreateTime: time.Now().UTC(),
+		Desire:     n.Desire,
+		Values:     append(apps, deps...),
+		Objects:    objects,
+	}
+	bundle.Signature, err = s.Sign.SignOffline(namespace, node, models.OfflineBundleKind, bundle)
+	if err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func (s *OfflineServiceImpl) ImportReport(namespace, node string, report *models.OfflineReport) (*models.OfflineReportImport, error) {
+	if report.Namespace != namespace || report.Node != node {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the report is not of the node"))
+	}
+	unsigned := *report
+	unsigned.Signature = nil
+	if err := s.Sign.VerifyOffline(namespace, node, models.OfflineReportKind, report.Signature, &unsigned); err != nil {
+		return nil, err
+	}
+	// the telemetry carried offline is stale, which is not forwarded nor stored
+	delete(report.Report, common.NodeTelemetry)
+	delta, err := s.Sync.Report(namespace, node, report.Report)
+	if err != nil {
+		return nil, err
+	}
+	return &models.OfflineReportImport{Delta: delta}, nil
+}
+
+// offlineBundleObjects lists the objects referenced by the configs, whose urls are populated by the desire
+func offlineBundleObjects(values []specV1.ResourceValue) ([]models.OfflineBundleObject, error) {
+	var res []models.OfflineBundleObject
+	for _, v := range values {
+		cfg, ok := v.Value.Value.(*specV1.Configuration)
+		if !ok {
+			continue
+		}
+		for k, data := range cfg.Data {
+			if !strings.HasPrefix(k, common.ConfigObjectPrefix) {
+				continue
+			}
+			var obj specV1.ConfigurationObject
+			if err := json.Unmarshal([]byte(data), &obj); err != nil {
+				return nil, errors.Trace(err)
+			}
+			res = append(res, models.OfflineBundleObject{
+				Config: cfg.Name,
+				Name:   strings.TrimPrefix(k, common.ConfigObjectPrefix),
+				URL:    obj.URL,
+				MD5:    obj.MD5,
+				Unpack: obj.Unpack,
+			})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Config != res[j].Config {
+			return res[i].Config < res[j].Config
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestOfflineBundle(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNode, mSync, mSign := ms.NewMockNodeService(mockCtl), ms.NewMockSyncService(mockCtl), ms.NewMockSyncSignService(mockCtl)
+	svc := &OfflineServiceImpl{Node: mNode, Sync: mSync, Sign: mSign}
+
+	desire := specV1.Desire{
+		common.DesiredSysApplications: []specV1.AppInfo{{Name: "core", Version: "1"}},
+		common.DesiredApplications:    []specV1.AppInfo{{Name: "web", Version: "2"}},
+	}
+	md := map[string]string{"namespace": "default", "name": "node01"}
+	mNode.EXPECT().Get(nil, "default", "node01").Return(&specV1.Node{Name: "node01", Desire: desire}, nil)
+	core := &specV1.Application{Name: "core", Volumes: []specV1.Volume{
+		{Name: "cert", VolumeSource: specV1.VolumeSource{Secret: &specV1.ObjectReference{Name: "crt", Version: "1"}}},
+		{Name: "conf", VolumeSource: specV1.VolumeSource{Config: &specV1.ObjectReference{Name: "conf", Version: "3"}}},
+	}}
+	web := &specV1.Application{Name: "web", Volumes: []specV1.Volume{
+		{Name: "conf", VolumeSource: specV1.VolumeSource{Config: &specV1.ObjectReference{Name: "conf", Version: "3"}}},
+		{Name: "data", VolumeSource: specV1.VolumeSource{HostPath: &specV1.HostPathVolumeSource{Path: "/data"}}},
+	}}
+	mSync.EXPECT().Desire("default", []specV1.ResourceInfo{
+		{Kind: specV1.KindApplication, Name: "core", Version: "1"},
+		{Kind: specV1.KindApplication, Name: "web", Version: "2"},
+	}, md).Return([]specV1.ResourceValue{
+		{ResourceInfo: specV1.ResourceInfo{Kind: specV1.KindApplication, Name: "core", Version: "1"}, Value: specV1.LazyValue{Value: core}},
+		{ResourceInfo: specV1.ResourceInfo{Kind: specV1.KindApplication, Name: "web", Version: "2"}, Value: specV1.LazyValue{Value: web}},
+	}, nil)
+	object, err := json.Marshal(&specV1.ConfigurationObject{URL: "http://minio/model.zip", MD5: "md5", Unpack: "zip"})
+	assert.NoError(t, err)
+	conf := &specV1.Configuration{Name: "conf", Data: map[string]string{"a": "b", common.ConfigObjectPrefix + "model.zip": string(object)}}
+	// the configs shared by apps are bundled once
+	mSync.EXPECT().Desire("default", []specV1.ResourceInfo{
+		{Kind: specV1.KindSecret, Name: "crt", Version: "1"},
+		{Kind: specV1.KindConfiguration, Name: "conf", Version: "3"},
+	}, md).Return([]specV1.ResourceValue{
+		{ResourceInfo: specV1.ResourceInfo{Kind: specV1.KindSecret, Name: "crt", Version: "1"}, Value: specV1.LazyValue{Value: &specV1.Secret{Name: "crt"}}},
+		{ResourceInfo: specV1.ResourceInfo{Kind: specV1.KindConfiguration, Name: "conf", Version: "3"}, Value: specV1.LazyValue{Value: conf}},
+	}, nil)
+	sig := &models.SyncSignature{KeyID: "k1", Nonce: "n1", Signature: "s1"}
+	mSign.EXPECT().SignOffline("default", "node01", models.OfflineBundleKind, gomock.Any()).DoAndReturn(func(_, _, _ string, content interface{}) (*models.SyncSignature, error) {
+		// the bundle is signed without signature
+		assert.Nil(t, content.(*models.OfflineBundle).Signature)
+		return sig, nil
+	})
+
+	bundle, err := svc.Bundle("default", "node01")
+	assert.NoError(t, err)
+	assert.Equal(t, "node01", bundle.Node)
+	assert.Equal(t, desire, bundle.Desire)
+	assert.Len(t, bundle.Values, 4)
+	assert.Equal(t, []models.OfflineBundleObject{{Config: "conf", Name: "model.zip", URL: "http://minio/model.zip", MD5: "md5", Unpack: "zip"}}, bundle.Objects)
+	assert.Equal(t, sig, bundle.Signature)
+
+	mNode.EXPECT().Get(nil, "default", "node02").Return(nil, common.Error(common.ErrResourceNotFound))
+	_, err = svc.Bundle("default", "node02")
+	assert.Error(t, err)
+}
+
+func TestOfflineImportReport(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mSync, mSign := ms.NewMockSyncService(mockCtl), ms.NewMockSyncSignService(mockCtl)
+	svc := &OfflineServiceImpl{Sync: mSync, Sign: mSign}
+
+	sig := &models.SyncSignature{KeyID: "k1", Nonce: "n1", Signature: "s1"}
+	report := &models.OfflineReport{Namespace: "default", Node: "node01", Report: specV1.Report{"apps": []interface{}{}}, Signature: sig}
+	unsigned := *report
+	unsigned.Signature = nil
+	delta := specV1.Delta{"apps": []interface{}{map[string]interface{}{"name": "web", "version": "2"}}}
+	mSign.EXPECT().VerifyOffline("default", "node01", models.OfflineReportKind, sig, &unsigned).Return(nil)
+	mSync.EXPECT().Report("default", "node01", report.Report).Return(delta, nil)
+	res, err := svc.ImportReport("default", "node01", report)
+	assert.NoError(t, err)
+	assert.Equal(t, delta, res.Delta)
+
+	mSign.EXPECT().VerifyOffline("default", "node01", models.OfflineReportKind, sig, &unsigned).Return(common.Error(common.ErrRequestAccessDenied))
+	_, err = svc.ImportReport("default", "node01", report)
+	assert.Error(t, err)
+
+	// the report of another node is rejected
+	_, err = svc.ImportReport("default", "node02", report)
+	assert.Error(t, err)
+}
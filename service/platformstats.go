@@ -0,0 +1,45 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/platformstats.go -package=service github.com/baetyl/baetyl-cloud/v2/service PlatformStatsService
+
+type PlatformStatsService interface {
+	plugin.PlatformStats
+	// Get returns the latest stats cached, which is nil before the first computation
+	Get() *models.PlatformStats
+	// Set caches the stats computed
+	Set(stats *models.PlatformStats)
+}
+
+type PlatformStatsServiceImpl struct {
+	plugin.PlatformStats
+	latest *models.PlatformStats
+	mutex  sync.RWMutex
+}
+
+func NewPlatformStatsService(config *config.CloudConfig) (PlatformStatsService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.PlatformStats)
+	if err != nil {
+		return nil, err
+	}
+	return &PlatformStatsServiceImpl{PlatformStats: p.(plugin.PlatformStats)}, nil
+}
+
+func (s *PlatformStatsServiceImpl) Get() *models.PlatformStats {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.latest
+}
+
+func (s *PlatformStatsServiceImpl) Set(stats *models.PlatformStats) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.latest = stats
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"time"
+)
+
+const (
+	TemplateProvisionConf    = "baetyl-provision.conf"
+	TemplateProvisionShell   = "baetyl-provision.sh"
+	TemplateProvisionService = "baetyl-provision.service"
+)
+
+// provisioningFiles the files of provisioning bundle rendered from the templates, the paths are relative to the root
+// of the image which the bundle is extracted into
+var provisioningFiles = []struct {
+	template string
+	path     string
+	mode     int64
+}{
+	{template: TemplateProvisionConf, path: "etc/baetyl/baetyl-provision.conf", mode: 0600},
+	{template: TemplateProvisionShell, path: "usr/local/bin/baetyl-provision.sh", mode: 0755},
+	{template: TemplateProvisionService, path: "etc/systemd/system/baetyl-provision.service", mode: 0644},
+}
+
+// the link enabling the service, so that it runs on the first boot without systemctl in the image
+const provisioningServiceLink = "etc/systemd/system/multi-user.target.wants/baetyl-provision.service"
+
+// PackProvisioningBundle packs the provisioning files rendered by render into a tar.gz archive, which is embedded in
+// the golden images. The devices imaged register by the bootstrap credential in the config on the first boot, no
+// certificate is packed since the nodes get theirs once approved and installed
+func PackProvisioningBundle(render func(template string) ([]byte, error)) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	now := time.Now()
+	for _, f := range provisioningFiles {
+		data, err := render(f.template)
+		if err != nil {
+			return nil, err
+		}
+		header := &tar.Header{
+			Name:     f.path,
+			Mode:     f.mode,
+			Size:     int64(len(data)),
+			ModTime:  now,
+			Typeflag: tar.TypeReg,
+		}
+		if err = tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err = tw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	header := &tar.Header{
+		Name:     provisioningServiceLink,
+		Linkname: "/etc/systemd/system/baetyl-provision.service",
+		Mode:     0777,
+		ModTime:  now,
+		Typeflag: tar.TypeSymlink,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
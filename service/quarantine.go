@@ -0,0 +1,131 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/quarantine.go -package=service github.com/baetyl/baetyl-cloud/v2/service QuarantineService
+
+const quarantineWebhookTimeout = 5 * time.Second
+
+type QuarantineService interface {
+	Get(name string) (*models.Quarantine, error)
+	List() ([]models.Quarantine, error)
+	Create(quarantine *models.Quarantine) (*models.Quarantine, error)
+	Delete(name string) error
+	// Check returns ErrAppQuarantined if the app of namespace references any resource quarantined
+	Check(namespace string, app *specV1.Application) error
+	// Notify posts the event to the webhook of the health threshold of its namespace if set
+	Notify(event *models.QuarantineEvent) error
+}
+
+type QuarantineServiceImpl struct {
+	Quarantine plugin.Quarantine
+	Threshold  plugin.HealthThreshold
+	client     *http.Client
+	log        *log.Logger
+}
+
+func NewQuarantineService(config *config.CloudConfig) (QuarantineService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.Quarantine)
+	if err != nil {
+		return nil, err
+	}
+	threshold, err := plugin.GetPlugin(config.Plugin.Health)
+	if err != nil {
+		return nil, err
+	}
+	return &QuarantineServiceImpl{
+		Quarantine: p.(plugin.Quarantine),
+		Threshold:  threshold.(plugin.HealthThreshold),
+		client:     &http.Client{Timeout: quarantineWebhookTimeout},
+		log:        log.With(log.Any("service", "quarantine")),
+	}, nil
+}
+
+func (s *QuarantineServiceImpl) Get(name string) (*models.Quarantine, error) {
+	return s.Quarantine.GetQuarantine(name)
+}
+
+func (s *QuarantineServiceImpl) List() ([]models.Quarantine, error) {
+	return s.Quarantine.ListQuarantine()
+}
+
+func (s *QuarantineServiceImpl) Create(quarantine *models.Quarantine) (*models.Quarantine, error) {
+	if err := s.Quarantine.CreateQuarantine(quarantine); err != nil {
+		return nil, err
+	}
+	s.log.Info("resource quarantined", log.Any("name", quarantine.Name), log.Any("type", quarantine.Type),
+		log.Any("namespace", quarantine.Namespace), log.Any("app", quarantine.App), log.Any("image", quarantine.Image),
+		log.Any("operator", quarantine.Operator))
+	return s.Quarantine.GetQuarantine(quarantine.Name)
+}
+
+func (s *QuarantineServiceImpl) Delete(name string) error {
+	if err := s.Quarantine.DeleteQuarantine(name); err != nil {
+		return err
+	}
+	s.log.Info("quarantine lifted", log.Any("name", name))
+	return nil
+}
+
+func (s *QuarantineServiceImpl) Check(namespace string, app *specV1.Application) error {
+	quarantines, err := s.Quarantine.ListQuarantine()
+	if err != nil {
+		return err
+	}
+	for i := range quarantines {
+		q := &quarantines[i]
+		if _, ok := QuarantineMatches(q, namespace, app); !ok {
+			continue
+		}
+		name := q.App
+		if q.Type == models.QuarantineImage {
+			name = q.Image
+		}
+		return common.Error(common.ErrAppQuarantined, common.Field("type", q.Type), common.Field("name", name),
+			common.Field("reason", q.Reason), common.Field("app", app.Name))
+	}
+	return nil
+}
+
+func (s *QuarantineServiceImpl) Notify(event *models.QuarantineEvent) error {
+	threshold, err := s.Threshold.GetHealthThreshold(event.Namespace)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil
+		}
+		return err
+	}
+	if threshold.Webhook == "" {
+		return nil
+	}
+	if err = postWebhook(s.client, threshold.Webhook, event); err != nil {
+		return err
+	}
+	s.log.Debug("quarantine event posted", log.Any("namespace", event.Namespace), log.Any("app", event.App), log.Any("quarantine", event.Quarantine))
+	return nil
+}
+
+// QuarantineMatches returns true if the quarantine affects the app of namespace, along with the images of app
+// matching it if it's an image one
+func QuarantineMatches(quarantine *models.Quarantine, namespace string, app *specV1.Application) ([]string, bool) {
+	switch quarantine.Type {
+	case models.QuarantineApp:
+		return nil, quarantine.Targets(namespace, app.Name)
+	case models.QuarantineImage:
+		images := AppImagesOf(app, quarantine.Image)
+		return images, len(images) > 0
+	}
+	return nil, false
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewQuarantineService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.Quarantine = common.RandString(9)
+	_, err := NewQuarantineService(conf)
+	assert.Error(t, err)
+}
+
+func TestQuarantineCheck(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mQuarantine := mockPlugin.NewMockQuarantine(mockCtl)
+	s := &QuarantineServiceImpl{Quarantine: mQuarantine, log: log.L()}
+
+	mQuarantine.EXPECT().ListQuarantine().Return([]models.Quarantine{
+		{Name: "leaky", Type: models.QuarantineApp, Namespace: "tenant-a", App: "nginx", Reason: "leaks"},
+		{Name: "cve", Type: models.QuarantineImage, Image: "docker.io/library/redis@sha256:0123", Reason: "cve"},
+	}, nil).Times(4)
+
+	app := &specV1.Application{Name: "nginx", Services: []specV1.Service{{Name: "nginx", Image: "nginx:1.20"}}}
+	assert.NoError(t, s.Check("default", app))
+
+	err := s.Check("tenant-a", app)
+	assert.Error(t, err)
+	e, ok := err.(errors.Coder)
+	assert.True(t, ok)
+	assert.Equal(t, common.ErrAppQuarantined, e.Code())
+
+	app.InitServices = []specV1.Service{{Name: "cache", Image: "redis@sha256:0123"}}
+	err = s.Check("default", app)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "redis@sha256:0123")
+
+	app.InitServices[0].Image = "redis:6.2"
+	assert.NoError(t, s.Check("default", app))
+}
+
+func TestQuarantineMatches(t *testing.T) {
+	app := &specV1.Application{Name: "web", Services: []specV1.Service{{Name: "nginx", Image: "nginx:1.20"}, {Name: "redis", Image: "redis"}}}
+
+	images, ok := QuarantineMatches(&models.Quarantine{Type: models.QuarantineImage, Image: "nginx"}, "default", app)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"nginx:1.20"}, images)
+	_, ok = QuarantineMatches(&models.Quarantine{Type: models.QuarantineImage, Image: "nginx:1.21"}, "default", app)
+	assert.False(t, ok)
+	images, ok = QuarantineMatches(&models.Quarantine{Type: models.QuarantineImage, Image: "redis:latest"}, "default", app)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"redis"}, images)
+
+	_, ok = QuarantineMatches(&models.Quarantine{Type: models.QuarantineApp, Namespace: "default", App: "web"}, "default", app)
+	assert.True(t, ok)
+	_, ok = QuarantineMatches(&models.Quarantine{Type: models.QuarantineApp, Namespace: "default", App: "web"}, "tenant-a", app)
+	assert.False(t, ok)
+}
+
+func TestQuarantineNotify(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mThreshold := mockPlugin.NewMockHealthThreshold(mockCtl)
+
+	var events []models.QuarantineEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event models.QuarantineEvent
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		events = append(events, event)
+	}))
+	defer server.Close()
+	s := &QuarantineServiceImpl{Threshold: mThreshold, client: &http.Client{Timeout: time.Second}, log: log.L()}
+
+	// nothing is posted if the namespace has no webhook
+	mThreshold.EXPECT().GetHealthThreshold("default").Return(nil, common.Error(common.ErrResourceNotFound))
+	assert.NoError(t, s.Notify(&models.QuarantineEvent{Namespace: "default", App: "web"}))
+	mThreshold.EXPECT().GetHealthThreshold("default").Return(&models.HealthThreshold{Namespace: "default"}, nil)
+	assert.NoError(t, s.Notify(&models.QuarantineEvent{Namespace: "default", App: "web"}))
+	assert.Len(t, events, 0)
+
+	mThreshold.EXPECT().GetHealthThreshold("default").Return(&models.HealthThreshold{Namespace: "default", Webhook: server.URL}, nil)
+	err := s.Notify(&models.QuarantineEvent{Namespace: "default", App: "web", Quarantine: "cve", Type: models.QuarantineEventPaused})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "web", events[0].App)
+	assert.Equal(t, models.QuarantineEventPaused, events[0].Type)
+}
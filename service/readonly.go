@@ -0,0 +1,100 @@
+package service
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/readonly.go -package=service github.com/baetyl/baetyl-cloud/v2/service ReadOnlyService
+
+// PropPlatformReadOnly the property storing the read-only mode of platform
+const PropPlatformReadOnly = "baetyl-platform-readonly"
+
+type ReadOnlyService interface {
+	// Get returns the read-only mode of platform, which is disabled if never set
+	Get() (*models.ReadOnly, error)
+	Set(mode *models.ReadOnly) (*models.ReadOnly, error)
+	// CheckWritable returns ErrPlatformReadOnly if the platform is read-only. The mode is cached for the ttl, and the
+	// one known last is kept if it fails to load, so that the platform stays read-only while the database is down
+	CheckWritable() error
+}
+
+type ReadOnlyServiceImpl struct {
+	Property PropertyService
+	ttl      time.Duration
+	mu       sync.Mutex
+	cached   *models.ReadOnly
+	expire   time.Time
+}
+
+func NewReadOnlyService(config *config.CloudConfig) (ReadOnlyService, error) {
+	prop, err := NewPropertyService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ReadOnlyServiceImpl{Property: prop, ttl: config.ReadOnly.CacheTTL}, nil
+}
+
+func (s *ReadOnlyServiceImpl) Get() (*models.ReadOnly, error) {
+	val, err := s.Property.GetPropertyValue(PropPlatformReadOnly)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return &models.ReadOnly{}, nil
+		}
+		return nil, err
+	}
+	res := new(models.ReadOnly)
+	if err = json.Unmarshal([]byte(val), res); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return res, nil
+}
+
+func (s *ReadOnlyServiceImpl) Set(mode *models.ReadOnly) (*models.ReadOnly, error) {
+	mode.UpdateTime = time.Now().UTC()
+	data, err := json.Marshal(mode)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	prop := &models.Property{Name: PropPlatformReadOnly, Value: string(data)}
+	_, err = s.Property.GetProperty(PropPlatformReadOnly)
+	if err == nil {
+		err = s.Property.UpdateProperty(prop)
+	} else if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+		err = s.Property.CreateProperty(prop)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.cached, s.expire = mode, time.Now().Add(s.ttl)
+	s.mu.Unlock()
+	log.L().Warn("read-only mode of platform changed", log.Any("enabled", mode.Enabled), log.Any("reason", mode.Reason), log.Any("operator", mode.Operator))
+	return mode, nil
+}
+
+func (s *ReadOnlyServiceImpl) CheckWritable() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cached == nil || !time.Now().Before(s.expire) {
+		mode, err := s.Get()
+		if err != nil {
+			log.L().Warn("failed to load read-only mode of platform, the one known last is kept", log.Error(err))
+		} else {
+			s.cached = mode
+		}
+		s.expire = time.Now().Add(s.ttl)
+	}
+	if s.cached != nil && s.cached.Enabled {
+		return common.Error(common.ErrPlatformReadOnly, common.Field("reason", s.cached.Reason))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewReadOnlyService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.Property = common.RandString(9)
+	_, err := NewReadOnlyService(conf)
+	assert.Error(t, err)
+}
+
+func TestReadOnlyService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mProp := ms.NewMockPropertyService(mockCtl)
+	s := &ReadOnlyServiceImpl{Property: mProp, ttl: time.Hour}
+	notFound := common.Error(common.ErrResourceNotFound)
+
+	// the platform is writable if the mode is never set
+	mProp.EXPECT().GetPropertyValue(PropPlatformReadOnly).Return("", notFound)
+	assert.NoError(t, s.CheckWritable())
+
+	mProp.EXPECT().GetProperty(PropPlatformReadOnly).Return(nil, notFound)
+	mProp.EXPECT().CreateProperty(gomock.Any()).DoAndReturn(func(p *models.Property) error {
+		assert.Contains(t, p.Value, `"enabled":true`)
+		return nil
+	})
+	res, err := s.Set(&models.ReadOnly{Enabled: true, Reason: "database migration", Operator: "ops"})
+	assert.NoError(t, err)
+	assert.False(t, res.UpdateTime.IsZero())
+	// the mode set takes effect at once without loading
+	assertErrorCode(t, common.ErrPlatformReadOnly, s.CheckWritable())
+
+	// the mode known last is kept if it fails to load
+	s.expire = time.Now()
+	mProp.EXPECT().GetPropertyValue(PropPlatformReadOnly).Return("", fmt.Errorf("connection refused"))
+	assertErrorCode(t, common.ErrPlatformReadOnly, s.CheckWritable())
+
+	s.expire = time.Now()
+	mProp.EXPECT().GetPropertyValue(PropPlatformReadOnly).Return(`{"enabled":false,"operator":"ops"}`, nil)
+	assert.NoError(t, s.CheckWritable())
+
+	mProp.EXPECT().GetPropertyValue(PropPlatformReadOnly).Return("{", nil)
+	_, err = s.Get()
+	assert.Error(t, err)
+
+	mProp.EXPECT().GetProperty(PropPlatformReadOnly).Return(&models.Property{Name: PropPlatformReadOnly}, nil)
+	mProp.EXPECT().UpdateProperty(gomock.Any()).Return(fmt.Errorf("error"))
+	_, err = s.Set(&models.ReadOnly{})
+	assert.Error(t, err)
+	assert.NoError(t, s.CheckWritable())
+}
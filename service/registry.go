@@ -0,0 +1,334 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/registry.go -package=service github.com/baetyl/baetyl-cloud/v2/service RegistryService
+
+// the registry api of docker hub, whose images are addressed by docker.io
+const dockerHubRegistryAPI = "registry-1.docker.io"
+
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+// RegistryService checks the registries by the docker registry http api v2, so that the wrong credentials are found
+// before the nodes fail to pull images
+type RegistryService interface {
+	// Verify logs in to the registry with its credential, the manifest of image is checked too if it is not empty.
+	// The image is the chart of helm repository, such as mysql or mysql:8.8.6
+	Verify(registry *models.Registry, image string) *models.RegistryVerification
+}
+
+type RegistryServiceImpl struct {
+	client *http.Client
+}
+
+// NewRegistryService NewRegistryService
+func NewRegistryService(config *config.CloudConfig) (RegistryService, error) {
+	return &RegistryServiceImpl{client: &http.Client{Timeout: config.Registry.VerifyTimeout}}, nil
+}
+
+func (s *RegistryServiceImpl) Verify(registry *models.Registry, image string) *models.RegistryVerification {
+	if registry.Type == models.RegistryTypeHelm {
+		return s.verifyHelm(registry, image)
+	}
+	res := &models.RegistryVerification{Image: image, Time: time.Now().UTC()}
+	endpoint, _ := registryEndpoint(registry.Address)
+	repository, reference := "", ""
+	if image != "" {
+		repository, reference = splitImageReference(registry.Address, image)
+	}
+
+	resp, err := s.do(http.MethodGet, endpoint+"/v2/", "", nil)
+	if err != nil {
+		res.Message = err.Error()
+		return res
+	}
+	res.Status = resp.StatusCode
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnauthorized {
+		res.Message = fmt.Sprintf("unexpected status of registry api: %s", http.StatusText(resp.StatusCode))
+		return res
+	}
+	res.Reachable = true
+
+	authorization := ""
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err = s.login(endpoint, resp.Header.Get("WWW-Authenticate"), registry, repository, res)
+		if err != nil {
+			res.Message = err.Error()
+			return res
+		}
+	}
+	res.Authenticated = true
+	if image == "" {
+		return res
+	}
+
+	path := fmt.Sprintf("%s/v2/%s/manifests/%s", endpoint, repository, reference)
+	resp, err = s.do(http.MethodHead, path, authorization, map[string]string{"Accept": strings.Join(manifestMediaTypes, ",")})
+	if err != nil {
+		res.Message = err.Error()
+		return res
+	}
+	res.Status = resp.StatusCode
+	found := resp.StatusCode == http.StatusOK
+	res.ImageFound = &found
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		res.Message = "the image is not found"
+	case http.StatusUnauthorized, http.StatusForbidden:
+		res.Message = "the image is not accessible with the credential"
+	default:
+		res.Message = fmt.Sprintf("unexpected status of manifest: %s", http.StatusText(resp.StatusCode))
+	}
+	return res
+}
+
+// verifyHelm reads the index of helm repository with its credential, which is public if no username is set
+func (s *RegistryServiceImpl) verifyHelm(registry *models.Registry, chart string) *models.RegistryVerification {
+	res := &models.RegistryVerification{Image: chart, Time: time.Now().UTC()}
+	authorization := ""
+	if registry.Username != "" {
+		authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(registry.Username+":"+registry.Password))
+	}
+	resp, err := s.do(http.MethodGet, strings.TrimSuffix(registry.Address, "/")+"/index.yaml", authorization, nil)
+	if err != nil {
+		res.Message = err.Error()
+		return res
+	}
+	res.Status = resp.StatusCode
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		res.Reachable = true
+		res.Message = fmt.Sprintf("failed to log in to helm repository: %s", http.StatusText(resp.StatusCode))
+		return res
+	default:
+		res.Message = fmt.Sprintf("unexpected status of helm repository index: %s", http.StatusText(resp.StatusCode))
+		return res
+	}
+	var index struct {
+		Entries map[string][]struct {
+			Version string `yaml:"version"`
+		} `yaml:"entries"`
+	}
+	if err = yaml.Unmarshal(resp.body, &index); err != nil {
+		res.Message = fmt.Sprintf("the index of helm repository is invalid: %s", err.Error())
+		return res
+	}
+	res.Reachable, res.Authenticated = true, true
+	if chart == "" {
+		return res
+	}
+	name, version := chart, ""
+	if i := strings.LastIndexByte(chart, ':'); i >= 0 {
+		name, version = chart[:i], chart[i+1:]
+	}
+	found := false
+	for _, v := range index.Entries[name] {
+		if version == "" || v.Version == version {
+			found = true
+			break
+		}
+	}
+	res.ImageFound = &found
+	if !found {
+		res.Message = "the chart is not found"
+	}
+	return res
+}
+
+// login authenticates by the challenge of registry, and returns the authorization header for the later requests.
+// The token is requested from the auth server with the pull scope of repository if the registry uses bearer tokens,
+// the token of oci registry is used as it is instead
+func (s *RegistryServiceImpl) login(endpoint, challenge string, registry *models.Registry, repository string, res *models.RegistryVerification) (string, error) {
+	if registry.Token != "" {
+		bearer := "Bearer " + registry.Token
+		resp, err := s.do(http.MethodGet, endpoint+"/v2/", bearer, nil)
+		if err != nil {
+			return "", err
+		}
+		res.Status = resp.StatusCode
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("failed to log in to registry: %s", http.StatusText(resp.StatusCode))
+		}
+		return bearer, nil
+	}
+	scheme, params := parseAuthChallenge(challenge)
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte(registry.Username+":"+registry.Password))
+
+	switch scheme {
+	case "basic":
+		resp, err := s.do(http.MethodGet, endpoint+"/v2/", basic, nil)
+		if err != nil {
+			return "", err
+		}
+		res.Status = resp.StatusCode
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("failed to log in to registry: %s", http.StatusText(resp.StatusCode))
+		}
+		return basic, nil
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return "", fmt.Errorf("the auth server of registry is invalid: %s", params["realm"])
+		}
+		query := realm.Query()
+		if params["service"] != "" {
+			query.Set("service", params["service"])
+		}
+		if repository != "" {
+			query.Set("scope", "repository:"+repository+":pull")
+		}
+		realm.RawQuery = query.Encode()
+		resp, err := s.do(http.MethodGet, realm.String(), basic, nil)
+		if err != nil {
+			return "", err
+		}
+		res.Status = resp.StatusCode
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("failed to log in to registry: %s", http.StatusText(resp.StatusCode))
+		}
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err = json.Unmarshal(resp.body, &token); err != nil {
+			return "", fmt.Errorf("failed to read the token of registry: %s", err.Error())
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		return "Bearer " + token.Token, nil
+	default:
+		return "", fmt.Errorf("the auth scheme of registry is not supported: %s", challenge)
+	}
+}
+
+type registryResponse struct {
+	StatusCode int
+	Header     http.Header
+	body       []byte
+}
+
+func (s *RegistryServiceImpl) do(method, path, authorization string, headers map[string]string) (*registryResponse, error) {
+	req, err := http.NewRequest(method, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to registry: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of registry: %s", err.Error())
+	}
+	return &registryResponse{StatusCode: resp.StatusCode, Header: resp.Header, body: body}, nil
+}
+
+// registryEndpoint returns the url of registry api and the path of address, such as https://harbor.local and project
+// for harbor.local/project. The registry is connected by https unless the address is prefixed with http://
+func registryEndpoint(address string) (string, string) {
+	address = strings.TrimSuffix(strings.TrimSpace(address), "/")
+	scheme := "https"
+	if strings.HasPrefix(address, "http://") {
+		scheme = "http"
+	}
+	address = strings.TrimPrefix(strings.TrimPrefix(address, "https://"), "http://")
+	host, prefix := address, ""
+	if i := strings.IndexByte(address, '/'); i >= 0 {
+		host, prefix = address[:i], address[i+1:]
+	}
+	if host == "" || normalizeRegistry(host) == defaultImageRegistry {
+		host = dockerHubRegistryAPI
+	}
+	return scheme + "://" + host, prefix
+}
+
+// splitImageReference returns the repository in registry and the tag or digest of image, the image may be
+// addressed by the registry or relative to it, such as harbor.local/project/nginx:1.21 or nginx:1.21
+func splitImageReference(address, image string) (string, string) {
+	endpoint, prefix := registryEndpoint(address)
+	host := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(address), "https://"), "http://")
+	if i := strings.IndexByte(host, '/'); i >= 0 {
+		host = host[:i]
+	}
+	if host != "" && strings.HasPrefix(image, host+"/") {
+		image = strings.TrimPrefix(image, host+"/")
+	} else if prefix != "" {
+		image = prefix + "/" + image
+	}
+	repository, reference := image, "latest"
+	if i := strings.IndexByte(image, '@'); i >= 0 {
+		repository, reference = image[:i], image[i+1:]
+	} else if i := strings.LastIndexByte(image, ':'); i > strings.LastIndexByte(image, '/') {
+		repository, reference = image[:i], image[i+1:]
+	}
+	// the official images of docker hub are in library
+	if prefix == "" && !strings.Contains(repository, "/") && strings.HasSuffix(endpoint, "://"+dockerHubRegistryAPI) {
+		repository = "library/" + repository
+	}
+	return repository, reference
+}
+
+// parseAuthChallenge parses the WWW-Authenticate header, such as
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	challenge = strings.TrimSpace(challenge)
+	i := strings.IndexByte(challenge, ' ')
+	if i < 0 {
+		return strings.ToLower(challenge), params
+	}
+	scheme, rest := strings.ToLower(challenge[:i]), challenge[i+1:]
+	for rest != "" {
+		rest = strings.TrimLeft(rest, " ,")
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = rest[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else if comma := strings.IndexByte(rest, ','); comma >= 0 {
+			value, rest = rest[:comma], rest[comma+1:]
+		} else {
+			value, rest = rest, ""
+		}
+		params[key] = value
+	}
+	return scheme, params
+}
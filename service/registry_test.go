@@ -0,0 +1,229 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestRegistryEndpoint(t *testing.T) {
+	cases := []struct {
+		address, endpoint, prefix string
+	}{
+		{"harbor.local", "https://harbor.local", ""},
+		{"https://harbor.local/project/", "https://harbor.local", "project"},
+		{"http://127.0.0.1:5000", "http://127.0.0.1:5000", ""},
+		{"docker.io", "https://registry-1.docker.io", ""},
+		{"index.docker.io/baetyltech", "https://registry-1.docker.io", "baetyltech"},
+	}
+	for _, c := range cases {
+		endpoint, prefix := registryEndpoint(c.address)
+		assert.Equal(t, c.endpoint, endpoint, c.address)
+		assert.Equal(t, c.prefix, prefix, c.address)
+	}
+}
+
+func TestSplitImageReference(t *testing.T) {
+	cases := []struct {
+		address, image, repository, reference string
+	}{
+		{"docker.io", "nginx", "library/nginx", "latest"},
+		{"docker.io", "docker.io/baetyltech/baetyl:v2.2.0", "baetyltech/baetyl", "v2.2.0"},
+		{"harbor.local/project", "nginx:1.21", "project/nginx", "1.21"},
+		{"harbor.local/project", "harbor.local/project/nginx:1.21", "project/nginx", "1.21"},
+		{"127.0.0.1:5000", "127.0.0.1:5000/nginx", "nginx", "latest"},
+		{"harbor.local", "app/web@sha256:abc", "app/web", "sha256:abc"},
+	}
+	for _, c := range cases {
+		repository, reference := splitImageReference(c.address, c.image)
+		assert.Equal(t, c.repository, repository, c.image)
+		assert.Equal(t, c.reference, reference, c.image)
+	}
+}
+
+func TestParseAuthChallenge(t *testing.T) {
+	scheme, params := parseAuthChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:a,b:pull"`)
+	assert.Equal(t, "bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:a,b:pull",
+	}, params)
+
+	scheme, params = parseAuthChallenge(`Basic realm=registry`)
+	assert.Equal(t, "basic", scheme)
+	assert.Equal(t, "registry", params["realm"])
+}
+
+func TestRegistryVerifyBearer(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="registry.local"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/token":
+			assert.Equal(t, "registry.local", r.URL.Query().Get("service"))
+			if user, pwd, ok := r.BasicAuth(); !ok || user != "baetyl" || pwd != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"token": "t-" + r.URL.Query().Get("scope")})
+		case "/v2/project/nginx/manifests/1.21":
+			assert.Equal(t, http.MethodHead, r.Method)
+			if r.Header.Get("Authorization") != "Bearer t-repository:project/nginx:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	s := &RegistryServiceImpl{client: &http.Client{Timeout: time.Second}}
+	registry := &models.Registry{Address: server.URL + "/project", Username: "baetyl", Password: "secret"}
+
+	res := s.Verify(registry, "")
+	assert.True(t, res.Reachable)
+	assert.True(t, res.Authenticated)
+	assert.Nil(t, res.ImageFound)
+	assert.Empty(t, res.Message)
+
+	res = s.Verify(registry, "nginx:1.21")
+	assert.True(t, res.Authenticated)
+	assert.True(t, *res.ImageFound)
+	assert.Equal(t, http.StatusOK, res.Status)
+
+	res = s.Verify(registry, "redis")
+	assert.True(t, res.Authenticated)
+	assert.False(t, *res.ImageFound)
+	assert.Equal(t, http.StatusNotFound, res.Status)
+	assert.NotEmpty(t, res.Message)
+
+	registry.Password = "typo"
+	res = s.Verify(registry, "nginx:1.21")
+	assert.True(t, res.Reachable)
+	assert.False(t, res.Authenticated)
+	assert.Nil(t, res.ImageFound)
+	assert.Equal(t, http.StatusUnauthorized, res.Status)
+	assert.NotEmpty(t, res.Message)
+}
+
+func TestRegistryVerifyBasic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pwd, ok := r.BasicAuth(); !ok || user != "baetyl" || pwd != "secret" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}))
+	s := &RegistryServiceImpl{client: &http.Client{Timeout: time.Second}}
+	registry := &models.Registry{Address: server.URL, Username: "baetyl", Password: "secret"}
+
+	res := s.Verify(registry, "nginx")
+	assert.True(t, res.Reachable)
+	assert.True(t, res.Authenticated)
+	assert.True(t, *res.ImageFound)
+
+	registry.Password = "typo"
+	res = s.Verify(registry, "")
+	assert.True(t, res.Reachable)
+	assert.False(t, res.Authenticated)
+
+	// the registry is not reachable once closed
+	server.Close()
+	res = s.Verify(registry, "")
+	assert.False(t, res.Reachable)
+	assert.False(t, res.Authenticated)
+	assert.NotEmpty(t, res.Message)
+}
+
+func TestRegistryVerifyToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ci-token" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="https://auth.local/token"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/v2/" && r.URL.Path != "/v2/models/resnet/manifests/v1" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	s := &RegistryServiceImpl{client: &http.Client{Timeout: time.Second}}
+	registry := &models.Registry{Type: models.RegistryTypeOCI, Address: server.URL, Token: "ci-token"}
+
+	res := s.Verify(registry, "models/resnet:v1")
+	assert.True(t, res.Authenticated)
+	assert.True(t, *res.ImageFound)
+
+	registry.Token = "expired"
+	res = s.Verify(registry, "")
+	assert.True(t, res.Reachable)
+	assert.False(t, res.Authenticated)
+	assert.Equal(t, http.StatusUnauthorized, res.Status)
+}
+
+func TestRegistryVerifyHelm(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pwd, ok := r.BasicAuth(); !ok || user != "baetyl" || pwd != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/charts/index.yaml":
+			w.Write([]byte("apiVersion: v1\nentries:\n  mysql:\n  - name: mysql\n    version: 8.8.6\n  - name: mysql\n    version: 8.8.5\n"))
+		case "/broken/index.yaml":
+			w.Write([]byte("<html>"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	s := &RegistryServiceImpl{client: &http.Client{Timeout: time.Second}}
+	registry := &models.Registry{Type: models.RegistryTypeHelm, Address: server.URL + "/charts/", Username: "baetyl", Password: "secret"}
+
+	res := s.Verify(registry, "")
+	assert.True(t, res.Reachable)
+	assert.True(t, res.Authenticated)
+	assert.Nil(t, res.ImageFound)
+
+	res = s.Verify(registry, "mysql:8.8.5")
+	assert.True(t, *res.ImageFound)
+	res = s.Verify(registry, "mysql")
+	assert.True(t, *res.ImageFound)
+	res = s.Verify(registry, "mysql:9.0.0")
+	assert.False(t, *res.ImageFound)
+	assert.Equal(t, "the chart is not found", res.Message)
+
+	registry.Address = server.URL + "/broken"
+	res = s.Verify(registry, "")
+	assert.False(t, res.Reachable)
+	assert.NotEmpty(t, res.Message)
+
+	registry.Address, registry.Password = server.URL+"/charts", "typo"
+	res = s.Verify(registry, "")
+	assert.True(t, res.Reachable)
+	assert.False(t, res.Authenticated)
+	assert.Equal(t, http.StatusUnauthorized, res.Status)
+
+	// the repository with credential is not readable anonymously
+	registry.Username, registry.Password = "", ""
+	res = s.Verify(registry, "")
+	assert.Equal(t, http.StatusUnauthorized, res.Status)
+}
+
+func TestNewRegistryService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Registry.VerifyTimeout = time.Second
+	s, err := NewRegistryService(conf)
+	assert.NoError(t, err)
+	assert.NotNil(t, s)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/resourcesearch.go -package=service github.com/baetyl/baetyl-cloud/v2/service ResourceSearchService
+
+// ResourceSearchService searches the nodes, apps and configs of all namespaces
+type ResourceSearchService interface {
+	Search(query *models.ResourceSearch) (*models.ResourceSearchList, error)
+}
+
+type ResourceSearchServiceImpl struct {
+	Namespace NamespaceService
+	Node      NodeService
+	App       ApplicationService
+	Config    ConfigService
+}
+
+func NewResourceSearchService(config *config.CloudConfig) (ResourceSearchService, error) {
+	namespace, err := NewNamespaceService(config)
+	if err != nil {
+		return nil, err
+	}
+	node, err := NewNodeService(config)
+	if err != nil {
+		return nil, err
+	}
+	app, err := NewApplicationService(config)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := NewConfigService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ResourceSearchServiceImpl{Namespace: namespace, Node: node, App: app, Config: cfg}, nil
+}
+
+// Search searches the namespaces one by one, the search is aborted once the context of query is done
+func (s *ResourceSearchServiceImpl) Search(query *models.ResourceSearch) (*models.ResourceSearchList, error) {
+	ctx := query.Context()
+	nsList, err := s.Namespace.List(s.listOptions(query, ""))
+	if err != nil {
+		return nil, err
+	}
+	items := []models.ResourceSearchItem{}
+	for _, ns := range nsList.Items {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		found, err := s.searchNamespace(ns.Name, query)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, found...)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		if items[i].Kind != items[j].Kind {
+			return items[i].Kind < items[j].Kind
+		}
+		return items[i].Name < items[j].Name
+	})
+	res := &models.ResourceSearchList{Total: len(items), Items: items}
+	if limit := query.GetLimitNumber(); limit > 0 {
+		start := query.GetLimitOffset()
+		if start > len(items) {
+			start = len(items)
+		}
+		end := start + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		res.Items = items[start:end]
+	}
+	return res, nil
+}
+
+func (s *ResourceSearchServiceImpl) searchNamespace(ns string, query *models.ResourceSearch) ([]models.ResourceSearchItem, error) {
+	var res []models.ResourceSearchItem
+	if query.Searches(models.SearchKindNode) {
+		list, err := s.Node.List(ns, s.listOptions(query, query.LabelSelector))
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range list.Items {
+			if query.MatchName(n.Name) {
+				res = append(res, models.ResourceSearchItem{Kind: models.SearchKindNode, Namespace: ns, Name: n.Name,
+					Version: n.Version, Labels: n.Labels, CreationTimestamp: n.CreationTimestamp})
+			}
+		}
+	}
+	if query.Searches(models.SearchKindApp) {
+		list, err := s.App.List(ns, s.listOptions(query, query.LabelSelector))
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range list.Items {
+			if !query.MatchName(a.Name) {
+				continue
+			}
+			item := models.ResourceSearchItem{Kind: models.SearchKindApp, Namespace: ns, Name: a.Name,
+				Version: a.Version, Labels: a.Labels, CreationTimestamp: a.CreationTimestamp}
+			if query.Image != "" {
+				// the list of apps carries no services, the images are read from the apps themselves
+				app, err := s.App.Get(ns, a.Name, "")
+				if err != nil {
+					return nil, err
+				}
+				if item.Images = AppImagesOf(app, query.Image); len(item.Images) == 0 {
+					continue
+				}
+			}
+			res = append(res, item)
+		}
+	}
+	if query.Searches(models.SearchKindConfig) {
+		list, err := s.Config.List(ns, s.listOptions(query, query.LabelSelector))
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range list.Items {
+			if query.MatchName(c.Name) {
+				res = append(res, models.ResourceSearchItem{Kind: models.SearchKindConfig, Namespace: ns, Name: c.Name,
+					Version: c.Version, Labels: c.Labels, CreationTimestamp: c.CreationTimestamp})
+			}
+		}
+	}
+	return res, nil
+}
+
+func (s *ResourceSearchServiceImpl) listOptions(query *models.ResourceSearch, selector string) *models.ListOptions {
+	opts := &models.ListOptions{LabelSelector: selector}
+	opts.SetContext(query.Context())
+	return opts
+}
+
+// AppImagesOf returns the images of the services of app matching the reference, such as nginx:1.21 and
+// docker.io/library/nginx:1.21 for nginx. The images without tag are the latest ones
+func AppImagesOf(app *specV1.Application, reference string) []string {
+	var res []string
+	for _, svcs := range [][]specV1.Service{app.InitServices, app.Services} {
+		for _, svc := range svcs {
+			if svc.Image != "" && matchImageReference(svc.Image, reference) {
+				res = append(res, svc.Image)
+			}
+		}
+	}
+	return res
+}
+
+func matchImageReference(image, reference string) bool {
+	registry, repository := splitImage(image)
+	refRegistry, refRepository := splitImage(reference)
+	if registry != refRegistry {
+		return false
+	}
+	name, tag := splitImageTag(repository)
+	refName, refTag := splitImageTag(refRepository)
+	if tag == "" {
+		tag = ":latest"
+	}
+	return name == refName && (refTag == "" || refTag == tag)
+}
+
+// splitImageTag splits the repository into its name and the tag or digest along with the separator
+func splitImageTag(repository string) (string, string) {
+	if i := strings.IndexByte(repository, '@'); i >= 0 {
+		return repository[:i], repository[i:]
+	}
+	if i := strings.LastIndexByte(repository, ':'); i > strings.LastIndexByte(repository, '/') {
+		return repository[:i], repository[i:]
+	}
+	return repository, ""
+}
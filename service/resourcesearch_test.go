@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestResourceSearch(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	sNs, sNode, sApp, sConfig := ms.NewMockNamespaceService(mockCtl), ms.NewMockNodeService(mockCtl), ms.NewMockApplicationService(mockCtl), ms.NewMockConfigService(mockCtl)
+	s := &ResourceSearchServiceImpl{Namespace: sNs, Node: sNode, App: sApp, Config: sConfig}
+
+	sNs.EXPECT().List(gomock.Any()).Return(&models.NamespaceList{Items: []models.Namespace{{Name: "tenant-b"}, {Name: "tenant-a"}}}, nil).AnyTimes()
+	for _, ns := range []string{"tenant-a", "tenant-b"} {
+		sNode.EXPECT().List(ns, gomock.Any()).Return(&models.NodeList{Items: []specV1.Node{{Name: "edge-01"}, {Name: "gateway"}}}, nil).AnyTimes()
+		sApp.EXPECT().List(ns, gomock.Any()).Return(&models.ApplicationList{Items: []models.AppItem{{Name: "edge-web"}, {Name: "db"}}}, nil).AnyTimes()
+		sConfig.EXPECT().List(ns, gomock.Any()).Return(&models.ConfigurationList{Items: []specV1.Configuration{{Name: "edge-conf"}}}, nil).AnyTimes()
+	}
+
+	res, err := s.Search(&models.ResourceSearch{Filter: models.Filter{Name: "edge"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, res.Total)
+	assert.Equal(t, models.ResourceSearchItem{Kind: models.SearchKindApp, Namespace: "tenant-a", Name: "edge-web"}, res.Items[0])
+	assert.Equal(t, models.SearchKindConfig, res.Items[1].Kind)
+	assert.Equal(t, "tenant-b", res.Items[3].Namespace)
+
+	// the results are paged after merged
+	res, err = s.Search(&models.ResourceSearch{Kind: models.SearchKindNode, Filter: models.Filter{Name: "*-01", Offset: 1, PageSize: 5}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Total)
+	assert.Len(t, res.Items, 1)
+	assert.Equal(t, "tenant-b", res.Items[0].Namespace)
+
+	// only the apps are searched by image
+	sApp.EXPECT().Get("tenant-a", "edge-web", "").Return(&specV1.Application{Services: []specV1.Service{{Image: "docker.io/library/nginx:1.21"}}}, nil)
+	sApp.EXPECT().Get("tenant-a", "db", "").Return(&specV1.Application{Services: []specV1.Service{{Image: "mysql:8"}}}, nil)
+	sApp.EXPECT().Get("tenant-b", "edge-web", "").Return(&specV1.Application{InitServices: []specV1.Service{{Image: "nginx"}}}, nil)
+	sApp.EXPECT().Get("tenant-b", "db", "").Return(&specV1.Application{}, nil)
+	res, err = s.Search(&models.ResourceSearch{Image: "nginx"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Total)
+	assert.Equal(t, []string{"docker.io/library/nginx:1.21"}, res.Items[0].Images)
+	assert.Equal(t, []string{"nginx"}, res.Items[1].Images)
+
+	sApp.EXPECT().Get("tenant-a", "edge-web", "").Return(nil, fmt.Errorf("error"))
+	_, err = s.Search(&models.ResourceSearch{Image: "nginx"})
+	assert.Error(t, err)
+
+	// the search is aborted once the request is canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	query := &models.ResourceSearch{}
+	query.SetContext(ctx)
+	_, err = s.Search(query)
+	assert.Error(t, err)
+}
+
+func TestMatchImageReference(t *testing.T) {
+	tests := []struct {
+		image     string
+		reference string
+		want      bool
+	}{
+		{"nginx", "nginx", true},
+		{"nginx:1.21", "nginx", true},
+		{"docker.io/library/nginx:1.21", "nginx:1.21", true},
+		{"nginx", "nginx:latest", true},
+		{"nginx:1.20", "nginx:1.21", false},
+		{"harbor.local/library/nginx:1.21", "nginx", false},
+		{"localhost:5000/baetyl:v2.2.0", "localhost:5000/baetyl", true},
+		{"baetyltech/baetyl@sha256:abc", "baetyltech/baetyl@sha256:abc", true},
+		{"baetyltech/baetyl-broker", "baetyltech/baetyl", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchImageReference(tt.image, tt.reference), tt.image+" "+tt.reference)
+	}
+}
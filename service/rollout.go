@@ -0,0 +1,329 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/baetyl/baetyl-go/v2/utils"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/rollout.go -package=service github.com/baetyl/baetyl-cloud/v2/service RolloutService
+
+type RolloutService interface {
+	Get(namespace, app string) (*models.Rollout, error)
+	List(namespace string) ([]models.Rollout, error)
+	// Start starts the rollout of the application version, the nodes of the first stage are released at once
+	// and the previous rollout of the application is replaced
+	Start(app *specV1.Application, strategy *models.RolloutStrategy) (*models.Rollout, error)
+	// Advance checks the progressing rollout at the time, it is paused if too many released nodes report
+	// the app failed, otherwise the next stage is released once the bake time of the current one is over
+	Advance(app *specV1.Application, rollout *models.Rollout, t time.Time) (*models.Rollout, error)
+	Update(rollout *models.Rollout) error
+	Pause(namespace, app string) (*models.Rollout, error)
+	// Resume continues the paused rollout, the bake time of the current stage restarts
+	Resume(namespace, app string) (*models.Rollout, error)
+	Delete(namespace, app string) error
+}
+
+type RolloutServiceImpl struct {
+	Rollout plugin.Rollout
+	Node    NodeService
+	Group   NodeGroupService
+}
+
+// NewRolloutService NewRolloutService
+func NewRolloutService(config *config.CloudConfig) (RolloutService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.Rollout)
+	if err != nil {
+		return nil, err
+	}
+	node, err := NewNodeService(config)
+	if err != nil {
+		return nil, err
+	}
+	group, err := NewNodeGroupService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &RolloutServiceImpl{
+		Rollout: p.(plugin.Rollout),
+		Node:    node,
+		Group:   group,
+	}, nil
+}
+
+func (s *RolloutServiceImpl) Get(namespace, app string) (*models.Rollout, error) {
+	return s.Rollout.GetRollout(namespace, app)
+}
+
+func (s *RolloutServiceImpl) List(namespace string) ([]models.Rollout, error) {
+	return s.Rollout.ListRollout(namespace)
+}
+
+func (s *RolloutServiceImpl) Start(app *specV1.Application, strategy *models.RolloutStrategy) (*models.Rollout, error) {
+	nodes, err := s.listNodes(app)
+	if err != nil {
+		return nil, err
+	}
+	released, err := s.stageNodes(app.Namespace, strategy.Stages[0], nodes)
+	if err != nil {
+		return nil, err
+	}
+	rollout := &models.Rollout{
+		Namespace: app.Namespace,
+		App:       app.Name,
+		Version:   app.Version,
+		Strategy:  *strategy,
+		Status:    models.RolloutProgressing,
+		Nodes:     released,
+		StageTime: time.Now().UTC(),
+	}
+	if err = s.Rollout.DeleteRollout(app.Namespace, app.Name); err != nil {
+		return nil, err
+	}
+	if err = s.Rollout.CreateRollout(rollout); err != nil {
+		return nil, err
+	}
+	return s.Rollout.GetRollout(app.Namespace, app.Name)
+}
+
+func (s *RolloutServiceImpl) Advance(app *specV1.Application, rollout *models.Rollout, t time.Time) (*models.Rollout, error) {
+	if rollout.Status != models.RolloutProgressing {
+		return rollout, nil
+	}
+	nodes, err := s.listNodes(app)
+	if err != nil {
+		return nil, err
+	}
+	failed := RolloutFailedNodes(rollout, nodes)
+	changed := strings.Join(failed, ",") != strings.Join(rollout.Failed, ",")
+	rollout.Failed = failed
+	strategy := rollout.Strategy
+	if strategy.PauseOnFailure && len(failed) > strategy.MaxFailures {
+		rollout.Status = models.RolloutPaused
+		rollout.Message = fmt.Sprintf("%d released nodes report the app failed", len(failed))
+		return rollout, s.Rollout.UpdateRollout(rollout)
+	}
+
+	var bake time.Duration
+	if strategy.BakeTime != "" {
+		if bake, err = time.ParseDuration(strategy.BakeTime); err != nil {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", err.Error()))
+		}
+	}
+	if t.Sub(rollout.StageTime) < bake {
+		if changed {
+			return rollout, s.Rollout.UpdateRollout(rollout)
+		}
+		return rollout, nil
+	}
+
+	next := rollout.Stage + 1
+	if next >= len(strategy.Stages) {
+		rollout.Status = models.RolloutCompleted
+		rollout.Message = ""
+		return rollout, s.Rollout.UpdateRollout(rollout)
+	}
+	released, err := s.stageNodes(app.Namespace, strategy.Stages[next], nodes)
+	if err != nil {
+		return nil, err
+	}
+	rollout.Nodes = mergeNodeNames(rollout.Nodes, released)
+	rollout.Stage = next
+	rollout.StageTime = t.UTC()
+	return rollout, s.Rollout.UpdateRollout(rollout)
+}
+
+func (s *RolloutServiceImpl) Update(rollout *models.Rollout) error {
+	return s.Rollout.UpdateRollout(rollout)
+}
+
+func (s *RolloutServiceImpl) Pause(namespace, app string) (*models.Rollout, error) {
+	rollout, err := s.Rollout.GetRollout(namespace, app)
+	if err != nil {
+		return nil, err
+	}
+	if rollout.Status != models.RolloutProgressing {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the rollout is "+rollout.Status))
+	}
+	rollout.Status = models.RolloutPaused
+	rollout.Message = "paused by user"
+	if err = s.Rollout.UpdateRollout(rollout); err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
+
+func (s *RolloutServiceImpl) Resume(namespace, app string) (*models.Rollout, error) {
+	rollout, err := s.Rollout.GetRollout(namespace, app)
+	if err != nil {
+		return nil, err
+	}
+	if rollout.Status != models.RolloutPaused {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the rollout is "+rollout.Status))
+	}
+	rollout.Status = models.RolloutProgressing
+	rollout.Message = ""
+	rollout.Failed = nil
+	rollout.StageTime = time.Now().UTC()
+	if err = s.Rollout.UpdateRollout(rollout); err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
+
+func (s *RolloutServiceImpl) Delete(namespace, app string) error {
+	return s.Rollout.DeleteRollout(namespace, app)
+}
+
+func (s *RolloutServiceImpl) listNodes(app *specV1.Application) ([]specV1.Node, error) {
+	if app.Selector == "" {
+		return nil, nil
+	}
+	list, err := s.Node.List(app.Namespace, &models.ListOptions{LabelSelector: app.Selector})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// stageNodes returns the nodes released in the stage, the percentage is taken from the nodes sorted by name
+// so that the nodes of the earlier stages are also in the later ones
+func (s *RolloutServiceImpl) stageNodes(namespace string, stage models.RolloutStage, nodes []specV1.Node) ([]string, error) {
+	var selector string
+	if stage.NodeGroup != "" {
+		group, err := s.Group.Get(namespace, stage.NodeGroup)
+		if err != nil {
+			return nil, err
+		}
+		selector = group.NodeSelector()
+	}
+	names := make([]string, 0, len(nodes))
+	for i := range nodes {
+		if stage.NodeGroup != "" {
+			if ok, _ := utils.IsLabelMatch(selector, nodes[i].Labels); !ok {
+				continue
+			}
+		}
+		names = append(names, nodes[i].Name)
+	}
+	sort.Strings(names)
+	if stage.NodeGroup == "" {
+		names = names[:(len(names)*stage.Percent+99)/100]
+	}
+	return names, nil
+}
+
+// RolloutFailedNodes returns the released nodes which run the desired version of app but report
+// any instance of it neither running nor pending
+func RolloutFailedNodes(rollout *models.Rollout, nodes []specV1.Node) []string {
+	released := map[string]bool{}
+	for _, n := range rollout.Nodes {
+		released[n] = true
+	}
+	var res []string
+	for i := range nodes {
+		node := &nodes[i]
+		if !released[node.Name] {
+			continue
+		}
+		desired := ""
+		for _, app := range node.Desire.AppInfos(false) {
+			if app.Name == rollout.App {
+				desired = app.Version
+			}
+		}
+		var reported []specV1.AppInfo
+		var stats []specV1.AppStats
+		if common.DecodeReport(node.Report, "apps", &reported) != nil || common.DecodeReport(node.Report, "appstats", &stats) != nil {
+			continue
+		}
+		running := false
+		for _, app := range reported {
+			if app.Name == rollout.App && app.Version == desired {
+				running = true
+			}
+		}
+		if !running {
+			continue
+		}
+		for _, stat := range stats {
+			if stat.Name == rollout.App && isAppStatsFailed(stat) {
+				res = append(res, node.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+func isAppStatsFailed(stat specV1.AppStats) bool {
+	for _, ins := range stat.InstanceStats {
+		switch ins.Status {
+		case "Running", "Pending", "Succeeded":
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// HoldRolloutApps withholds the apps of the unfinished rollouts in delta from the node not released yet,
+// the versions reported by node are kept and the apps not running on node are not delivered
+func HoldRolloutApps(node string, delta specV1.Delta, reported []specV1.AppInfo, rollouts []models.Rollout) {
+	held := map[string]bool{}
+	for i := range rollouts {
+		if rollouts[i].Holds(node) {
+			held[rollouts[i].App] = true
+		}
+	}
+	holdDeltaApps(delta, reported, held)
+}
+
+// holdDeltaApps keeps the held apps in delta at the versions reported by node, the ones not reported are removed
+func holdDeltaApps(delta specV1.Delta, reported []specV1.AppInfo, held map[string]bool) {
+	if len(held) == 0 {
+		return
+	}
+	versions := map[string]string{}
+	for _, app := range reported {
+		versions[app.Name] = app.Version
+	}
+	desire := specV1.Desire(delta)
+	apps := desire.AppInfos(false)
+	res := make([]specV1.AppInfo, 0, len(apps))
+	for _, app := range apps {
+		if held[app.Name] {
+			version, ok := versions[app.Name]
+			if !ok {
+				continue
+			}
+			app.Version = version
+		}
+		res = append(res, app)
+	}
+	desire.SetAppInfos(false, res)
+}
+
+func mergeNodeNames(a, b []string) []string {
+	set := map[string]bool{}
+	res := make([]string, 0, len(a)+len(b))
+	for _, n := range append(append([]string{}, a...), b...) {
+		if set[n] {
+			continue
+		}
+		set[n] = true
+		res = append(res, n)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func rolloutTestNodes() []specV1.Node {
+	var nodes []specV1.Node
+	for _, name := range []string{"node04", "node02", "node03", "node01"} {
+		nodes = append(nodes, specV1.Node{Namespace: "default", Name: name, Labels: map[string]string{"city": "bj"}})
+	}
+	nodes[0].Labels["city"] = "sh"
+	return nodes
+}
+
+func TestNewRolloutService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.Rollout = common.RandString(9)
+	_, err := NewRolloutService(conf)
+	assert.Error(t, err)
+}
+
+func TestRolloutService_Start(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mRollout, mNode := mockPlugin.NewMockRollout(mockCtl), ms.NewMockNodeService(mockCtl)
+	rs := &RolloutServiceImpl{Rollout: mRollout, Node: mNode}
+
+	app := &specV1.Application{Namespace: "default", Name: "app01", Version: "2", Selector: "city"}
+	strategy := &models.RolloutStrategy{Stages: []models.RolloutStage{{Percent: 30}, {Percent: 100}}}
+	mNode.EXPECT().List("default", &models.ListOptions{LabelSelector: "city"}).Return(&models.NodeList{Items: rolloutTestNodes()}, nil)
+	mRollout.EXPECT().DeleteRollout("default", "app01").Return(nil)
+	mRollout.EXPECT().CreateRollout(gomock.Any()).DoAndReturn(func(r *models.Rollout) error {
+		// 30% of 4 nodes is rounded up to 2
+		assert.Equal(t, []string{"node01", "node02"}, r.Nodes)
+		assert.Equal(t, models.RolloutProgressing, r.Status)
+		assert.Equal(t, "2", r.Version)
+		return nil
+	})
+	mRollout.EXPECT().GetRollout("default", "app01").Return(&models.Rollout{App: "app01"}, nil)
+	res, err := rs.Start(app, strategy)
+	assert.NoError(t, err)
+	assert.Equal(t, "app01", res.App)
+}
+
+func TestRolloutService_Advance(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mRollout, mNode, mGroup := mockPlugin.NewMockRollout(mockCtl), ms.NewMockNodeService(mockCtl), ms.NewMockNodeGroupService(mockCtl)
+	rs := &RolloutServiceImpl{Rollout: mRollout, Node: mNode, Group: mGroup}
+
+	now := time.Now()
+	app := &specV1.Application{Namespace: "default", Name: "app01", Version: "2", Selector: "city"}
+	rollout := &models.Rollout{
+		Namespace: "default",
+		App:       "app01",
+		Strategy: models.RolloutStrategy{
+			Stages:         []models.RolloutStage{{Percent: 25}, {NodeGroup: "group01"}},
+			BakeTime:       "1h",
+			PauseOnFailure: true,
+		},
+		Status:    models.RolloutProgressing,
+		Nodes:     []string{"node01"},
+		StageTime: now.Add(-time.Minute),
+	}
+	nodes := rolloutTestNodes()
+	mNode.EXPECT().List("default", gomock.Any()).Return(&models.NodeList{Items: nodes}, nil).AnyTimes()
+
+	// baking
+	res, err := rs.Advance(app, rollout, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, res.Stage)
+
+	// the next stage releases the members of group
+	rollout.StageTime = now.Add(-2 * time.Hour)
+	mGroup.EXPECT().Get("default", "group01").Return(&models.NodeGroup{Name: "group01", Selector: "city=bj"}, nil)
+	mRollout.EXPECT().UpdateRollout(rollout).Return(nil)
+	res, err = rs.Advance(app, rollout, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Stage)
+	assert.Equal(t, []string{"node01", "node02", "node03"}, res.Nodes)
+
+	// the last stage is over
+	rollout.StageTime = now.Add(-2 * time.Hour)
+	mRollout.EXPECT().UpdateRollout(rollout).Return(nil)
+	res, err = rs.Advance(app, rollout, now)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RolloutCompleted, res.Status)
+	assert.False(t, res.Holds("node04"))
+
+	// paused on failure
+	rollout.Status, rollout.Stage = models.RolloutProgressing, 0
+	nodes[1].Desire = specV1.Desire{common.DesiredApplications: []specV1.AppInfo{{Name: "app01", Version: "2"}}}
+	nodes[1].Report = specV1.Report{
+		common.DesiredApplications: []specV1.AppInfo{{Name: "app01", Version: "2"}},
+		"appstats": []specV1.AppStats{{
+			AppInfo:       specV1.AppInfo{Name: "app01", Version: "2"},
+			InstanceStats: map[string]specV1.InstanceStats{"app01-0": {Status: "Failed"}},
+		}},
+	}
+	mRollout.EXPECT().UpdateRollout(rollout).Return(nil)
+	res, err = rs.Advance(app, rollout, now)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RolloutPaused, res.Status)
+	assert.Equal(t, []string{"node02"}, res.Failed)
+
+	// paused rollout is not advanced
+	res, err = rs.Advance(app, rollout, now)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RolloutPaused, res.Status)
+}
+
+func TestRolloutService_PauseResume(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mRollout := mockPlugin.NewMockRollout(mockCtl)
+	rs := &RolloutServiceImpl{Rollout: mRollout}
+
+	rollout := &models.Rollout{Namespace: "default", App: "app01", Status: models.RolloutProgressing}
+	mRollout.EXPECT().GetRollout("default", "app01").Return(rollout, nil).Times(3)
+	mRollout.EXPECT().UpdateRollout(rollout).Return(nil).Times(2)
+	res, err := rs.Pause("default", "app01")
+	assert.NoError(t, err)
+	assert.Equal(t, models.RolloutPaused, res.Status)
+
+	res, err = rs.Resume("default", "app01")
+	assert.NoError(t, err)
+	assert.Equal(t, models.RolloutProgressing, res.Status)
+
+	_, err = rs.Resume("default", "app01")
+	assert.Error(t, err)
+}
@@ -1,8 +1,10 @@
 package service
 
 import (
+	"bytes"
 	"strings"
 
+	"github.com/baetyl/baetyl-go/v2/errors"
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 
 	"github.com/baetyl/baetyl-cloud/v2/common"
@@ -23,8 +25,14 @@ type SecretService interface {
 	Delete(namespace, name string) error
 }
 
+// the prefix of the data of secrets encrypted, the data without it is stored before encryption is enabled
+var encryptedSecretPrefix = []byte("baetyl-encrypted:")
+
+// secretService encrypts the data of secrets by crypto if configured, so that the backends only see the ciphertext.
+// The data is decrypted when the secrets are read, such as rendered for sync
 type secretService struct {
 	secret plugin.Secret
+	crypto plugin.Crypto
 }
 
 // NewSecretService NewSecretService
@@ -33,9 +41,17 @@ func NewSecretService(config *config.CloudConfig) (SecretService, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &secretService{
+	s := &secretService{
 		secret: secret.(plugin.Secret),
-	}, nil
+	}
+	if config.Plugin.Crypto != "" {
+		crypto, err := plugin.GetPlugin(config.Plugin.Crypto)
+		if err != nil {
+			return nil, err
+		}
+		s.crypto = crypto.(plugin.Crypto)
+	}
+	return s, nil
 }
 
 // Get get a Secret
@@ -44,7 +60,10 @@ func (s *secretService) GetTx(tx interface{}, namespace, name, version string) (
 	if err != nil && strings.Contains(err.Error(), "not found") {
 		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "secret"), common.Field("name", name))
 	}
-	return res, err
+	if err != nil {
+		return nil, err
+	}
+	return s.decrypt(res)
 }
 
 // Get get a Secret
@@ -54,26 +73,96 @@ func (s *secretService) Get(namespace, name, version string) (*specV1.Secret, er
 		return nil, common.Error(common.ErrResourceNotFound, common.Field("type", "secret"),
 			common.Field("name", name))
 	}
-	return res, err
+	if err != nil {
+		return nil, err
+	}
+	return s.decrypt(res)
 }
 
 // List get list Secret
 func (s *secretService) List(namespace string, listOptions *models.ListOptions) (*models.SecretList, error) {
-	return s.secret.ListSecret(namespace, listOptions)
+	res, err := s.secret.ListSecret(namespace, listOptions)
+	if err != nil || res == nil {
+		return res, err
+	}
+	for i := range res.Items {
+		if _, err = s.decrypt(&res.Items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
 }
 
 // Create Create a Secret
 func (s *secretService) Create(tx interface{}, namespace string, secret *specV1.Secret) (*specV1.Secret, error) {
-	return s.secret.CreateSecret(tx, namespace, secret)
-
+	encrypted, err := s.encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.secret.CreateSecret(tx, namespace, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	return s.decrypt(res)
 }
 
 // Update update a Secret
 func (s *secretService) Update(namespace string, secret *specV1.Secret) (*specV1.Secret, error) {
-	return s.secret.UpdateSecret(namespace, secret)
+	encrypted, err := s.encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.secret.UpdateSecret(namespace, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	return s.decrypt(res)
 }
 
 // Delete Delete a Secret
 func (s *secretService) Delete(namespace, name string) error {
 	return s.secret.DeleteSecret(namespace, name)
 }
+
+// encrypt returns the copy of secret whose data is encrypted, the secret itself is kept in plain for the caller
+func (s *secretService) encrypt(secret *specV1.Secret) (*specV1.Secret, error) {
+	if s.crypto == nil || secret == nil {
+		return secret, nil
+	}
+	res := *secret
+	res.Data = make(map[string][]byte, len(secret.Data))
+	for k, v := range secret.Data {
+		ciphertext, err := s.crypto.Encrypt(v)
+		if err != nil {
+			return nil, err
+		}
+		res.Data[k] = append(append([]byte{}, encryptedSecretPrefix...), ciphertext...)
+	}
+	return &res, nil
+}
+
+// decrypt decrypts the data of secret read from the backend in place
+func (s *secretService) decrypt(secret *specV1.Secret) (*specV1.Secret, error) {
+	if secret == nil {
+		return nil, nil
+	}
+	data := make(map[string][]byte, len(secret.Data))
+	for k, v := range secret.Data {
+		if !bytes.HasPrefix(v, encryptedSecretPrefix) {
+			data[k] = v
+			continue
+		}
+		if s.crypto == nil {
+			return nil, errors.Errorf("the secret (%s) is encrypted but the crypto is not configured", secret.Name)
+		}
+		plaintext, err := s.crypto.Decrypt(v[len(encryptedSecretPrefix):])
+		if err != nil {
+			return nil, err
+		}
+		data[k] = plaintext
+	}
+	if secret.Data != nil {
+		secret.Data = data
+	}
+	return secret, nil
+}
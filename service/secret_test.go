@@ -1,12 +1,15 @@
 package service
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
 	"github.com/baetyl/baetyl-cloud/v2/models"
 )
 
@@ -94,3 +97,61 @@ func TestDefaultRegistryService_Update(t *testing.T) {
 	_, err = cs.Update(registry.Namespace, registry)
 	assert.NoError(t, err)
 }
+
+func TestSecretServiceEncryption(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mSecret, mCrypto := mockPlugin.NewMockSecret(mockCtl), mockPlugin.NewMockCrypto(mockCtl)
+	cs := &secretService{secret: mSecret, crypto: mCrypto}
+	reverse := func(b []byte) []byte {
+		res := make([]byte, len(b))
+		for i := range b {
+			res[len(b)-1-i] = b[i]
+		}
+		return res
+	}
+	mCrypto.EXPECT().Encrypt(gomock.Any()).DoAndReturn(func(b []byte) ([]byte, error) { return reverse(b), nil }).AnyTimes()
+	mCrypto.EXPECT().Decrypt(gomock.Any()).DoAndReturn(func(b []byte) ([]byte, error) { return reverse(b), nil }).AnyTimes()
+
+	secret := &specV1.Secret{Namespace: "default", Name: "reg", Data: map[string][]byte{"password": []byte("abc")}}
+	var stored *specV1.Secret
+	mSecret.EXPECT().CreateSecret(nil, "default", gomock.Any()).DoAndReturn(func(_ interface{}, _ string, s *specV1.Secret) (*specV1.Secret, error) {
+		stored = s
+		return s, nil
+	})
+	res, err := cs.Create(nil, "default", secret)
+	assert.NoError(t, err)
+	// the backend only sees the ciphertext, the caller gets the plain data
+	assert.Equal(t, append(append([]byte{}, encryptedSecretPrefix...), []byte("cba")...), stored.Data["password"])
+	assert.Equal(t, "abc", string(res.Data["password"]))
+	assert.Equal(t, "abc", string(secret.Data["password"]))
+
+	mSecret.EXPECT().GetSecret(nil, "default", "reg", "").Return(&specV1.Secret{Name: "reg", Data: map[string][]byte{
+		"password": bytes.Join([][]byte{encryptedSecretPrefix, []byte("cba")}, nil),
+		// stored before encryption is enabled
+		"username": []byte("baetyl"),
+	}}, nil)
+	res, err = cs.Get("default", "reg", "")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"password": []byte("abc"), "username": []byte("baetyl")}, res.Data)
+
+	mSecret.EXPECT().ListSecret("default", gomock.Any()).Return(&models.SecretList{Items: []specV1.Secret{{Name: "reg", Data: map[string][]byte{
+		"password": bytes.Join([][]byte{encryptedSecretPrefix, []byte("cba")}, nil),
+	}}}}, nil)
+	list, err := cs.List("default", &models.ListOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", string(list.Items[0].Data["password"]))
+
+	// the encrypted secrets can not be read without crypto
+	plain := &secretService{secret: mSecret}
+	mSecret.EXPECT().GetSecret(nil, "default", "reg", "").Return(&specV1.Secret{Name: "reg", Data: map[string][]byte{
+		"password": bytes.Join([][]byte{encryptedSecretPrefix, []byte("cba")}, nil),
+	}}, nil)
+	_, err = plain.Get("default", "reg", "")
+	assert.Error(t, err)
+
+	failed := &secretService{secret: mSecret, crypto: mockPlugin.NewMockCrypto(mockCtl)}
+	failed.crypto.(*mockPlugin.MockCrypto).EXPECT().Encrypt(gomock.Any()).Return(nil, fmt.Errorf("error"))
+	_, err = failed.Update("default", secret)
+	assert.Error(t, err)
+}
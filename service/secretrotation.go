@@ -0,0 +1,139 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/secretrotation.go -package=service github.com/baetyl/baetyl-cloud/v2/service SecretRotationService
+
+const secretWebhookTimeout = 5 * time.Second
+
+type SecretRotationService interface {
+	Get(namespace, name string) (*models.SecretRotation, error)
+	Set(rotation *models.SecretRotation) (*models.SecretRotation, error)
+	Delete(namespace, name string) error
+	// ListExpiring lists the rotations of all namespaces expiring before the time, the expired ones flagged are excluded
+	ListExpiring(before time.Time) ([]models.SecretRotation, error)
+	// Rotate returns a copy of secret with the keys of rotation replaced by the data generated by its rotator
+	Rotate(secret *specV1.Secret, rotation *models.SecretRotation) (*specV1.Secret, error)
+	// Notify posts the event of rotation to its webhook if set
+	Notify(rotation *models.SecretRotation, eventType string) error
+}
+
+type SecretRotationServiceImpl struct {
+	SecretRotation plugin.SecretRotation
+	rotators       map[string]plugin.SecretRotator
+	client         *http.Client
+	log            *log.Logger
+}
+
+func NewSecretRotationService(config *config.CloudConfig) (SecretRotationService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.SecretRotation)
+	if err != nil {
+		return nil, err
+	}
+	rotators := map[string]plugin.SecretRotator{}
+	for _, name := range config.SecretRotation.Rotators {
+		r, err := plugin.GetPlugin(name)
+		if err != nil {
+			return nil, err
+		}
+		rotators[name] = r.(plugin.SecretRotator)
+	}
+	return &SecretRotationServiceImpl{
+		SecretRotation: p.(plugin.SecretRotation),
+		rotators:       rotators,
+		client:         &http.Client{Timeout: secretWebhookTimeout},
+		log:            log.With(log.Any("service", "secretrotation")),
+	}, nil
+}
+
+func (s *SecretRotationServiceImpl) Get(namespace, name string) (*models.SecretRotation, error) {
+	return s.SecretRotation.GetSecretRotation(namespace, name)
+}
+
+// Set creates or updates the rotation, the rotator should be one of the configured ones
+func (s *SecretRotationServiceImpl) Set(rotation *models.SecretRotation) (*models.SecretRotation, error) {
+	if rotation.Rotator != "" {
+		if _, ok := s.rotators[rotation.Rotator]; !ok {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the rotator "+rotation.Rotator+" is not supported"))
+		}
+	}
+	if rotation.Status == "" {
+		rotation.Status = models.SecretRotationActive
+	}
+	_, err := s.SecretRotation.GetSecretRotation(rotation.Namespace, rotation.Name)
+	if err == nil {
+		err = s.SecretRotation.UpdateSecretRotation(rotation)
+	} else if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+		if rotation.RotateTime.IsZero() {
+			rotation.RotateTime = time.Now().UTC()
+		}
+		err = s.SecretRotation.CreateSecretRotation(rotation)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.SecretRotation.GetSecretRotation(rotation.Namespace, rotation.Name)
+}
+
+func (s *SecretRotationServiceImpl) Delete(namespace, name string) error {
+	return s.SecretRotation.DeleteSecretRotation(namespace, name)
+}
+
+func (s *SecretRotationServiceImpl) ListExpiring(before time.Time) ([]models.SecretRotation, error) {
+	return s.SecretRotation.ListExpiringSecretRotation(before)
+}
+
+func (s *SecretRotationServiceImpl) Rotate(secret *specV1.Secret, rotation *models.SecretRotation) (*specV1.Secret, error) {
+	r, ok := s.rotators[rotation.Rotator]
+	if !ok {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the rotator "+rotation.Rotator+" is not supported"))
+	}
+	for _, k := range rotation.Keys {
+		if _, ok := secret.Data[k]; !ok {
+			return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the key "+k+" is not found in secret"))
+		}
+	}
+	data, err := r.Rotate(secret, rotation.Keys)
+	if err != nil {
+		return nil, err
+	}
+	res := *secret
+	res.Data = map[string][]byte{}
+	for k, v := range secret.Data {
+		res.Data[k] = v
+	}
+	for k, v := range data {
+		res.Data[k] = v
+	}
+	return &res, nil
+}
+
+func (s *SecretRotationServiceImpl) Notify(rotation *models.SecretRotation, eventType string) error {
+	if rotation.Webhook == "" {
+		return nil
+	}
+	event := &models.SecretEvent{
+		Namespace: rotation.Namespace,
+		Secret:    rotation.Name,
+		Type:      eventType,
+		ExpiresAt: rotation.ExpiresAt,
+		Time:      time.Now().UTC(),
+	}
+	if err := postWebhook(s.client, rotation.Webhook, event); err != nil {
+		return err
+	}
+	s.log.Debug("secret event posted", log.Any("namespace", event.Namespace), log.Any("secret", event.Secret), log.Any("type", event.Type))
+	return nil
+}
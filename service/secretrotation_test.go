@@ -0,0 +1,111 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestNewSecretRotationService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.SecretRotation = common.RandString(9)
+	_, err := NewSecretRotationService(conf)
+	assert.Error(t, err)
+}
+
+func TestSecretRotationService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mRotation := mockPlugin.NewMockSecretRotation(mockCtl)
+	mRotator := mockPlugin.NewMockSecretRotator(mockCtl)
+	ss := &SecretRotationServiceImpl{
+		SecretRotation: mRotation,
+		rotators:       map[string]plugin.SecretRotator{"defaultrotator": mRotator},
+		client:         &http.Client{Timeout: time.Second},
+		log:            log.L(),
+	}
+	notFound := common.Error(common.ErrResourceNotFound)
+
+	// the rotator should be configured
+	_, err := ss.Set(&models.SecretRotation{Namespace: "default", Name: "db", Rotator: "vault"})
+	assert.Error(t, err)
+
+	rotation := &models.SecretRotation{Namespace: "default", Name: "db", Rotator: "defaultrotator", Keys: []string{"password"}}
+	mRotation.EXPECT().GetSecretRotation("default", "db").Return(nil, notFound)
+	mRotation.EXPECT().CreateSecretRotation(rotation).Return(nil)
+	mRotation.EXPECT().GetSecretRotation("default", "db").Return(rotation, nil)
+	res, err := ss.Set(rotation)
+	assert.NoError(t, err)
+	assert.Equal(t, models.SecretRotationActive, res.Status)
+	assert.False(t, res.RotateTime.IsZero())
+
+	mRotation.EXPECT().GetSecretRotation("default", "db").Return(rotation, nil)
+	mRotation.EXPECT().UpdateSecretRotation(rotation).Return(fmt.Errorf("error"))
+	_, err = ss.Set(rotation)
+	assert.Error(t, err)
+
+	secret := &specV1.Secret{
+		Name:      "db",
+		Namespace: "default",
+		Version:   "3",
+		Data:      map[string][]byte{"username": []byte("baetyl"), "password": []byte("old")},
+	}
+	mRotator.EXPECT().Rotate(secret, []string{"password"}).Return(map[string][]byte{"password": []byte("new")}, nil)
+	rotated, err := ss.Rotate(secret, rotation)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(rotated.Data["password"]))
+	assert.Equal(t, "baetyl", string(rotated.Data["username"]))
+	assert.Equal(t, "old", string(secret.Data["password"]))
+
+	// the keys rotated should be in secret
+	_, err = ss.Rotate(secret, &models.SecretRotation{Rotator: "defaultrotator", Keys: []string{"token"}})
+	assert.Error(t, err)
+	_, err = ss.Rotate(secret, &models.SecretRotation{Rotator: "vault"})
+	assert.Error(t, err)
+
+	mRotation.EXPECT().DeleteSecretRotation("default", "db").Return(nil)
+	assert.NoError(t, ss.Delete("default", "db"))
+}
+
+func TestSecretRotationNotify(t *testing.T) {
+	var events []models.SecretEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event models.SecretEvent
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		events = append(events, event)
+		if event.Secret == "bad" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	ss := &SecretRotationServiceImpl{client: &http.Client{Timeout: time.Second}, log: log.L()}
+
+	// nothing is posted without webhook
+	assert.NoError(t, ss.Notify(&models.SecretRotation{Namespace: "default", Name: "db"}, models.SecretEventRotated))
+	assert.Len(t, events, 0)
+
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	err := ss.Notify(&models.SecretRotation{Namespace: "default", Name: "db", ExpiresAt: expiresAt, Webhook: server.URL}, models.SecretEventRotated)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "db", events[0].Secret)
+	assert.Equal(t, models.SecretEventRotated, events[0].Type)
+	assert.True(t, expiresAt.Equal(events[0].ExpiresAt))
+
+	err = ss.Notify(&models.SecretRotation{Namespace: "default", Name: "bad", Webhook: server.URL}, models.SecretEventExpired)
+	assert.Error(t, err)
+}
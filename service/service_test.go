@@ -30,6 +30,7 @@ type MockServices struct {
 	property       *mockPlugin.MockProperty
 	module         *mockPlugin.MockModule
 	task           *mockPlugin.MockTask
+	runtime        *mockPlugin.MockFunctionRuntime
 }
 
 func (m *MockServices) Close() {
@@ -128,6 +129,19 @@ func mockTestConfig() *config.CloudConfig {
 	conf.Plugin.License = common.RandString(9)
 	conf.Plugin.Property = common.RandString(9)
 	conf.Plugin.Task = common.RandString(9)
+	conf.Plugin.NodeGroup = common.RandString(9)
+	conf.Plugin.Rollout = common.RandString(9)
+	conf.Plugin.EnvGroup = common.RandString(9)
+	conf.Plugin.SidecarPolicy = common.RandString(9)
+	conf.Plugin.ImagePolicy = common.RandString(9)
+	conf.Plugin.NodeEndpoints = common.RandString(9)
+	conf.Plugin.ModuleArtifact = common.RandString(9)
+	conf.Plugin.SecretRotation = common.RandString(9)
+	conf.Plugin.FunctionRuntime = common.RandString(9)
+	conf.Plugin.Announcement = common.RandString(9)
+	conf.Plugin.Quarantine = common.RandString(9)
+	conf.Plugin.Garbage = common.RandString(9)
+	conf.Plugin.FeatureFlag = common.RandString(9)
 	conf.Template.Path = "../scripts/native/templates"
 	return conf
 }
@@ -136,6 +150,7 @@ func mockEmptyTestConfig() *config.CloudConfig {
 	conf := &config.CloudConfig{}
 	conf.Plugin.Objects = []string{}
 	conf.Plugin.Functions = []string{}
+	conf.Plugin.FunctionRuntime = common.RandString(9)
 	return conf
 }
 
@@ -177,6 +192,67 @@ func InitMockEnvironment(t *testing.T) *MockServices {
 	mTask := mockPlugin.NewMockTask(mockCtl)
 	plugin.RegisterFactory(conf.Plugin.Task, mockTask(mTask))
 
+	mNodeGroup := mockPlugin.NewMockNodeGroup(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.NodeGroup, func() (plugin.Plugin, error) {
+		return mNodeGroup, nil
+	})
+
+	mRollout := mockPlugin.NewMockRollout(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.Rollout, func() (plugin.Plugin, error) {
+		return mRollout, nil
+	})
+
+	mEnvGroup := mockPlugin.NewMockEnvGroup(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.EnvGroup, func() (plugin.Plugin, error) {
+		return mEnvGroup, nil
+	})
+
+	mSidecarPolicy := mockPlugin.NewMockSidecarPolicy(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.SidecarPolicy, func() (plugin.Plugin, error) {
+		return mSidecarPolicy, nil
+	})
+
+	mImagePolicy := mockPlugin.NewMockImagePolicy(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.ImagePolicy, func() (plugin.Plugin, error) {
+		return mImagePolicy, nil
+	})
+	mNodeEndpoints := mockPlugin.NewMockNodeEndpoints(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.NodeEndpoints, func() (plugin.Plugin, error) {
+		return mNodeEndpoints, nil
+	})
+	mModuleArtifact := mockPlugin.NewMockModuleArtifact(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.ModuleArtifact, func() (plugin.Plugin, error) {
+		return mModuleArtifact, nil
+	})
+	mSecretRotation := mockPlugin.NewMockSecretRotation(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.SecretRotation, func() (plugin.Plugin, error) {
+		return mSecretRotation, nil
+	})
+	mFunctionRuntime := mockPlugin.NewMockFunctionRuntime(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.FunctionRuntime, func() (plugin.Plugin, error) {
+		return mFunctionRuntime, nil
+	})
+	mAppHistory := mockPlugin.NewMockAppHistory(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.AppHistory, func() (plugin.Plugin, error) {
+		return mAppHistory, nil
+	})
+	mAnnouncement := mockPlugin.NewMockAnnouncement(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.Announcement, func() (plugin.Plugin, error) {
+		return mAnnouncement, nil
+	})
+	mQuarantine := mockPlugin.NewMockQuarantine(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.Quarantine, func() (plugin.Plugin, error) {
+		return mQuarantine, nil
+	})
+	mGarbage := mockPlugin.NewMockGarbage(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.Garbage, func() (plugin.Plugin, error) {
+		return mGarbage, nil
+	})
+	mFeatureFlag := mockPlugin.NewMockFeatureFlag(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.FeatureFlag, func() (plugin.Plugin, error) {
+		return mFeatureFlag, nil
+	})
+
 	_, err := NewSyncService(conf)
 	assert.Nil(t, err)
 
@@ -198,6 +274,7 @@ func InitMockEnvironment(t *testing.T) *MockServices {
 		property:       mProperty,
 		module:         mModule,
 		task:           mTask,
+		runtime:        mFunctionRuntime,
 	}
 }
 
@@ -214,11 +291,16 @@ func InitEmptyMockEnvironment(t *testing.T) *MockServices {
 	}
 	mProperty := mockPlugin.NewMockProperty(mockCtl)
 	plugin.RegisterFactory(conf.Plugin.Property, mockProperty(mProperty))
+	mFunctionRuntime := mockPlugin.NewMockFunctionRuntime(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.FunctionRuntime, func() (plugin.Plugin, error) {
+		return mFunctionRuntime, nil
+	})
 	return &MockServices{
 		conf:           conf,
 		ctl:            mockCtl,
 		objectStorage:  mockObjectStorage,
 		functionPlugin: mockFunctionPlugin,
 		property:       mProperty,
+		runtime:        mFunctionRuntime,
 	}
 }
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/servicerecord.go -package=service github.com/baetyl/baetyl-cloud/v2/service ServiceRecordService
+
+type ServiceRecordService interface {
+	// List lists the service records of the apps in namespace with the nodes they are deployed to,
+	// only the ones of the apps on node are listed if node is not empty
+	List(namespace, node string) ([]models.ServiceRecord, error)
+	// ListByApps lists the service records of the apps at the versions delivered to node
+	ListByApps(namespace string, apps []specV1.AppInfo) ([]models.ServiceRecord, error)
+}
+
+type ServiceRecordServiceImpl struct {
+	App   ApplicationService
+	Index IndexService
+}
+
+// NewServiceRecordService NewServiceRecordService
+func NewServiceRecordService(config *config.CloudConfig) (ServiceRecordService, error) {
+	app, err := NewApplicationService(config)
+	if err != nil {
+		return nil, err
+	}
+	index, err := NewIndexService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ServiceRecordServiceImpl{App: app, Index: index}, nil
+}
+
+func (s *ServiceRecordServiceImpl) List(namespace, node string) ([]models.ServiceRecord, error) {
+	var names []string
+	if node != "" {
+		apps, err := s.Index.ListAppsByNode(namespace, node)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		names = apps
+	} else {
+		apps, err := s.App.List(namespace, &models.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range apps.Items {
+			if !app.System {
+				names = append(names, app.Name)
+			}
+		}
+	}
+	res := []models.ServiceRecord{}
+	for _, name := range names {
+		app, err := s.App.Get(namespace, name, "")
+		if err != nil {
+			// the index of node is refreshed after the app deleted
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				continue
+			}
+			return nil, err
+		}
+		if app.System {
+			continue
+		}
+		records := AppServiceRecords(app)
+		if len(records) == 0 {
+			continue
+		}
+		nodes, err := s.Index.ListNodesByApp(namespace, name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sort.Strings(nodes)
+		for i := range records {
+			records[i].Nodes = nodes
+		}
+		res = append(res, records...)
+	}
+	return res, nil
+}
+
+func (s *ServiceRecordServiceImpl) ListByApps(namespace string, apps []specV1.AppInfo) ([]models.ServiceRecord, error) {
+	res := []models.ServiceRecord{}
+	for _, info := range apps {
+		app, err := s.App.Get(namespace, info.Name, info.Version)
+		if err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				log.L().Warn("the app of node is not found", log.Any(common.KeyContextNamespace, namespace), log.Any("app", info.Name), log.Any("version", info.Version))
+				continue
+			}
+			return nil, err
+		}
+		res = append(res, AppServiceRecords(app)...)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].App != res[j].App {
+			return res[i].App < res[j].App
+		}
+		return res[i].Service < res[j].Service
+	})
+	return res, nil
+}
+
+// AppServiceRecords derives the records of the services of app which have ports, the host of record is
+// {service}.{app}.baetyl.local, which is stable across the versions and the nodes of app
+func AppServiceRecords(app *specV1.Application) []models.ServiceRecord {
+	var res []models.ServiceRecord
+	for _, svc := range app.Services {
+		if len(svc.Ports) == 0 {
+			continue
+		}
+		record := models.ServiceRecord{
+			Namespace: app.Namespace,
+			App:       app.Name,
+			Service:   svc.Name,
+			Host:      svc.Name + "." + app.Name + "." + common.ServiceDomain,
+		}
+		for _, port := range svc.Ports {
+			p := models.ServiceRecordPort{
+				Port:     port.ContainerPort,
+				Protocol: port.Protocol,
+				HostPort: port.HostPort,
+			}
+			if p.Protocol == "" {
+				p.Protocol = string(corev1.ProtocolTCP)
+			}
+			if port.ServiceType == string(corev1.ServiceTypeNodePort) {
+				p.NodePort = port.NodePort
+			}
+			record.Ports = append(record.Ports, p)
+		}
+		res = append(res, record)
+	}
+	return res
+}
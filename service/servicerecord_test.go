@@ -0,0 +1,100 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func genServiceRecordApp(name string) *specV1.Application {
+	return &specV1.Application{
+		Namespace: "default",
+		Name:      name,
+		Services: []specV1.Service{
+			{
+				Name: "broker",
+				Ports: []specV1.ContainerPort{
+					{ContainerPort: 1883, HostPort: 1883},
+					{ContainerPort: 8883, NodePort: 30883, Protocol: "TCP", ServiceType: "NodePort"},
+				},
+			},
+			{Name: "worker"},
+		},
+	}
+}
+
+func TestAppServiceRecords(t *testing.T) {
+	records := AppServiceRecords(genServiceRecordApp("hub"))
+	assert.Equal(t, []models.ServiceRecord{{
+		Namespace: "default",
+		App:       "hub",
+		Service:   "broker",
+		Host:      "broker.hub." + common.ServiceDomain,
+		Ports: []models.ServiceRecordPort{
+			{Port: 1883, Protocol: "TCP", HostPort: 1883},
+			{Port: 8883, Protocol: "TCP", NodePort: 30883},
+		},
+	}}, records)
+
+	assert.Empty(t, AppServiceRecords(&specV1.Application{Name: "job", Services: []specV1.Service{{Name: "job"}}}))
+}
+
+func TestServiceRecordList(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	as, is := ms.NewMockApplicationService(mockCtl), ms.NewMockIndexService(mockCtl)
+	s := &ServiceRecordServiceImpl{App: as, Index: is}
+
+	as.EXPECT().List("default", &models.ListOptions{}).Return(&models.ApplicationList{Items: []models.AppItem{
+		{Name: "hub"}, {Name: "job"}, {Name: "baetyl-core", System: true},
+	}}, nil)
+	as.EXPECT().Get("default", "hub", "").Return(genServiceRecordApp("hub"), nil).Times(2)
+	as.EXPECT().Get("default", "job", "").Return(&specV1.Application{Name: "job"}, nil)
+	is.EXPECT().ListNodesByApp("default", "hub").Return([]string{"node02", "node01"}, nil).Times(2)
+	records, err := s.List("default", "")
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "broker.hub."+common.ServiceDomain, records[0].Host)
+	assert.Equal(t, []string{"node01", "node02"}, records[0].Nodes)
+
+	// the app deleted after the index of node refreshed is skipped
+	is.EXPECT().ListAppsByNode("default", "node01").Return([]string{"hub", "gone"}, nil)
+	as.EXPECT().Get("default", "gone", "").Return(nil, common.Error(common.ErrResourceNotFound, common.Field("type", "app"), common.Field("name", "gone")))
+	records, err = s.List("default", "node01")
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	is.EXPECT().ListAppsByNode("default", "node01").Return(nil, fmt.Errorf("error"))
+	_, err = s.List("default", "node01")
+	assert.Error(t, err)
+}
+
+func TestServiceRecordListByApps(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	as := ms.NewMockApplicationService(mockCtl)
+	s := &ServiceRecordServiceImpl{App: as}
+
+	apps := []specV1.AppInfo{{Name: "web", Version: "v2"}, {Name: "hub", Version: "v1"}, {Name: "gone", Version: "v1"}}
+	web := genServiceRecordApp("web")
+	web.Services[0].Name = "nginx"
+	as.EXPECT().Get("default", "web", "v2").Return(web, nil)
+	as.EXPECT().Get("default", "hub", "v1").Return(genServiceRecordApp("hub"), nil)
+	as.EXPECT().Get("default", "gone", "v1").Return(nil, common.Error(common.ErrResourceNotFound, common.Field("type", "app"), common.Field("name", "gone")))
+	records, err := s.ListByApps("default", apps)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "broker.hub."+common.ServiceDomain, records[0].Host)
+	assert.Equal(t, "nginx.web."+common.ServiceDomain, records[1].Host)
+
+	as.EXPECT().Get("default", "web", "v2").Return(nil, fmt.Errorf("error"))
+	_, err = s.ListByApps("default", apps)
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"github.com/baetyl/baetyl-go/v2/context"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/baetyl/baetyl-go/v2/utils"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/sidecarpolicy.go -package=service github.com/baetyl/baetyl-cloud/v2/service SidecarPolicyService
+
+type SidecarPolicyService interface {
+	Get(namespace, name string) (*models.SidecarPolicy, error)
+	List(namespace string) ([]models.SidecarPolicy, error)
+	Create(policy *models.SidecarPolicy) (*models.SidecarPolicy, error)
+	Update(policy *models.SidecarPolicy) (*models.SidecarPolicy, error)
+	Delete(namespace, name string) error
+}
+
+type SidecarPolicyServiceImpl struct {
+	SidecarPolicy plugin.SidecarPolicy
+}
+
+// NewSidecarPolicyService NewSidecarPolicyService
+func NewSidecarPolicyService(config *config.CloudConfig) (SidecarPolicyService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.SidecarPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return &SidecarPolicyServiceImpl{SidecarPolicy: p.(plugin.SidecarPolicy)}, nil
+}
+
+func (s *SidecarPolicyServiceImpl) Get(namespace, name string) (*models.SidecarPolicy, error) {
+	return s.SidecarPolicy.GetSidecarPolicy(namespace, name)
+}
+
+func (s *SidecarPolicyServiceImpl) List(namespace string) ([]models.SidecarPolicy, error) {
+	return s.SidecarPolicy.ListSidecarPolicy(namespace)
+}
+
+func (s *SidecarPolicyServiceImpl) Create(policy *models.SidecarPolicy) (*models.SidecarPolicy, error) {
+	if err := s.SidecarPolicy.CreateSidecarPolicy(policy); err != nil {
+		return nil, err
+	}
+	return s.SidecarPolicy.GetSidecarPolicy(policy.Namespace, policy.Name)
+}
+
+func (s *SidecarPolicyServiceImpl) Update(policy *models.SidecarPolicy) (*models.SidecarPolicy, error) {
+	if err := s.SidecarPolicy.UpdateSidecarPolicy(policy); err != nil {
+		return nil, err
+	}
+	return s.SidecarPolicy.GetSidecarPolicy(policy.Namespace, policy.Name)
+}
+
+func (s *SidecarPolicyServiceImpl) Delete(namespace, name string) error {
+	return s.SidecarPolicy.DeleteSidecarPolicy(namespace, name)
+}
+
+// SidecarPolicyMatches returns whether the sidecar of policy is injected into app.
+// The sidecar is a container, so the apps in native mode and the system apps are never selected,
+// neither are the jobs which never complete with the sidecar running
+func SidecarPolicyMatches(policy *models.SidecarPolicy, app *specV1.Application) bool {
+	if app.Mode == context.RunModeNative || app.Labels[common.LabelSystem] == "true" {
+		return false
+	}
+	if app.Workload == specV1.WorkloadJob || app.Workload == common.WorkloadCronJob {
+		return false
+	}
+	if policy.Selector == "" {
+		return true
+	}
+	ok, err := utils.IsLabelMatch(policy.Selector, app.Labels)
+	return err == nil && ok
+}
+
+// ApplySidecarPolicies injects the sidecars and volumes of the policies selecting app,
+// the services and volumes defined by the app itself are kept if their names conflict
+func ApplySidecarPolicies(app *specV1.Application, policies []models.SidecarPolicy) {
+	services := map[string]bool{}
+	for _, svc := range app.Services {
+		services[svc.Name] = true
+	}
+	volumes := map[string]bool{}
+	for _, vol := range app.Volumes {
+		volumes[vol.Name] = true
+	}
+	for i := range policies {
+		policy := &policies[i]
+		if !SidecarPolicyMatches(policy, app) || services[policy.Sidecar.Name] {
+			continue
+		}
+		app.Services = append(app.Services, policy.Sidecar)
+		services[policy.Sidecar.Name] = true
+		for _, vol := range policy.Volumes {
+			if !volumes[vol.Name] {
+				app.Volumes = append(app.Volumes, vol)
+				volumes[vol.Name] = true
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/baetyl/baetyl-go/v2/context"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewSidecarPolicyService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.SidecarPolicy = common.RandString(9)
+	_, err := NewSidecarPolicyService(conf)
+	assert.Error(t, err)
+}
+
+func TestSidecarPolicyService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mPolicy := mockPlugin.NewMockSidecarPolicy(mockCtl)
+	ss := &SidecarPolicyServiceImpl{SidecarPolicy: mPolicy}
+
+	policy := &models.SidecarPolicy{Namespace: "default", Name: "log-shipper", Sidecar: specV1.Service{Name: "fluent-bit", Image: "fluent/fluent-bit"}}
+	mPolicy.EXPECT().CreateSidecarPolicy(policy).Return(nil)
+	mPolicy.EXPECT().GetSidecarPolicy("default", "log-shipper").Return(policy, nil).Times(2)
+	res, err := ss.Create(policy)
+	assert.NoError(t, err)
+	assert.Equal(t, policy, res)
+
+	mPolicy.EXPECT().UpdateSidecarPolicy(policy).Return(nil)
+	_, err = ss.Update(policy)
+	assert.NoError(t, err)
+	mPolicy.EXPECT().UpdateSidecarPolicy(policy).Return(common.Error(common.ErrResourceNotFound))
+	_, err = ss.Update(policy)
+	assert.Error(t, err)
+
+	mPolicy.EXPECT().ListSidecarPolicy("default").Return([]models.SidecarPolicy{*policy}, nil)
+	list, err := ss.List("default")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	mPolicy.EXPECT().DeleteSidecarPolicy("default", "log-shipper").Return(nil)
+	assert.NoError(t, ss.Delete("default", "log-shipper"))
+}
+
+func TestApplySidecarPolicies(t *testing.T) {
+	policies := []models.SidecarPolicy{
+		{
+			Name:     "log-shipper",
+			Selector: "tier=web",
+			Sidecar: specV1.Service{
+				Name:         "fluent-bit",
+				Image:        "fluent/fluent-bit",
+				VolumeMounts: []specV1.VolumeMount{{Name: "log", MountPath: "/var/log/app"}},
+			},
+			Volumes: []specV1.Volume{
+				{Name: "log", VolumeSource: specV1.VolumeSource{EmptyDir: &specV1.EmptyDirVolumeSource{}}},
+				{Name: "data", VolumeSource: specV1.VolumeSource{EmptyDir: &specV1.EmptyDirVolumeSource{}}},
+			},
+		},
+		{Name: "metrics", Sidecar: specV1.Service{Name: "metrics-agent", Image: "metrics-agent"}},
+		{Name: "override", Sidecar: specV1.Service{Name: "web", Image: "other"}},
+	}
+	app := &specV1.Application{
+		Name:     "web",
+		Labels:   map[string]string{"tier": "web"},
+		Workload: specV1.WorkloadDeployment,
+		Services: []specV1.Service{{Name: "web", Image: "nginx"}},
+		Volumes:  []specV1.Volume{{Name: "data", VolumeSource: specV1.VolumeSource{HostPath: &specV1.HostPathVolumeSource{Path: "/data"}}}},
+	}
+	ApplySidecarPolicies(app, policies)
+	assert.Equal(t, []specV1.Service{
+		{Name: "web", Image: "nginx"},
+		policies[0].Sidecar,
+		policies[1].Sidecar,
+	}, app.Services)
+	assert.Equal(t, []specV1.Volume{
+		{Name: "data", VolumeSource: specV1.VolumeSource{HostPath: &specV1.HostPathVolumeSource{Path: "/data"}}},
+		policies[0].Volumes[0],
+	}, app.Volumes)
+
+	// the policy selecting by labels is skipped
+	app = &specV1.Application{Name: "db", Workload: specV1.WorkloadStatefulSet, Services: []specV1.Service{{Name: "db"}}}
+	ApplySidecarPolicies(app, policies)
+	assert.Equal(t, []string{"db", "metrics-agent", "web"}, []string{app.Services[0].Name, app.Services[1].Name, app.Services[2].Name})
+	assert.Empty(t, app.Volumes)
+
+	for _, app = range []*specV1.Application{
+		{Name: "native", Mode: context.RunModeNative, Services: []specV1.Service{{Name: "native"}}},
+		{Name: "core", Labels: map[string]string{common.LabelSystem: "true"}, Services: []specV1.Service{{Name: "core"}}},
+		{Name: "job", Workload: specV1.WorkloadJob, Services: []specV1.Service{{Name: "job"}}},
+		{Name: "cron", Workload: common.WorkloadCronJob, Services: []specV1.Service{{Name: "cron"}}},
+	} {
+		ApplySidecarPolicies(app, policies)
+		assert.Len(t, app.Services, 1, app.Name)
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+// GetNodeSiteSeed returns the seed address set on node, which is nil if the node is not a seed of its site
+func GetNodeSiteSeed(node *specV1.Node) (*models.SiteSeed, error) {
+	val, ok := node.Attributes[common.AttributeSiteSeed]
+	if !ok || val == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seed := new(models.SiteSeed)
+	if err = json.Unmarshal(data, seed); err != nil {
+		return nil, errors.Trace(err)
+	}
+	seed.Site = node.Labels[common.LabelSite]
+	return seed, nil
+}
+
+// ValidateSiteSeed checks the address of seed, which is an http or https url reachable by the nodes of site
+func ValidateSiteSeed(seed *models.SiteSeed) error {
+	u, err := url.Parse(seed.Address)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the address of seed should be an http or https url"))
+	}
+	return nil
+}
+
+// siteSeedPeers returns the urls of the object on the seeds of the site of node except itself. The seeds are ordered
+// by the hash of node and seed, so that the nodes of site are spread over the seeds while each keeps the same order
+func siteSeedPeers(node string, seeds []specV1.Node, etag string) []string {
+	etag = strings.Trim(etag, `"`)
+	if etag == "" {
+		return nil
+	}
+	type peer struct {
+		url  string
+		rank uint32
+	}
+	var peers []peer
+	for i := range seeds {
+		if seeds[i].Name == node {
+			continue
+		}
+		seed, err := GetNodeSiteSeed(&seeds[i])
+		if err != nil || seed == nil || seed.Address == "" {
+			continue
+		}
+		h := fnv.New32a()
+		h.Write([]byte(node + "/" + seeds[i].Name))
+		peers = append(peers, peer{url: strings.TrimSuffix(seed.Address, "/") + "/objects/" + etag, rank: h.Sum32()})
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		if peers[i].rank != peers[j].rank {
+			return peers[i].rank < peers[j].rank
+		}
+		return peers[i].url < peers[j].url
+	})
+	var res []string
+	for _, p := range peers {
+		res = append(res, p.url)
+	}
+	return res
+}
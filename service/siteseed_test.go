@@ -0,0 +1,94 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func genTestSeedNode(name, address string) specV1.Node {
+	node := specV1.Node{Name: name, Labels: map[string]string{common.LabelSite: "f1"}}
+	if address != "" {
+		node.Attributes = map[string]interface{}{common.AttributeSiteSeed: map[string]interface{}{"address": address}}
+	}
+	return node
+}
+
+func TestGetNodeSiteSeed(t *testing.T) {
+	node := genTestSeedNode("node01", "http://10.0.0.5:8088")
+	seed, err := GetNodeSiteSeed(&node)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.SiteSeed{Site: "f1", Address: "http://10.0.0.5:8088"}, seed)
+
+	node = genTestSeedNode("node02", "")
+	seed, err = GetNodeSiteSeed(&node)
+	assert.NoError(t, err)
+	assert.Nil(t, seed)
+
+	assert.NoError(t, ValidateSiteSeed(&models.SiteSeed{Address: "https://seed.local"}))
+	for _, addr := range []string{"", "10.0.0.5:8088", "ftp://10.0.0.5", "http://"} {
+		assert.Error(t, ValidateSiteSeed(&models.SiteSeed{Address: addr}), addr)
+	}
+}
+
+func TestSiteSeedPeers(t *testing.T) {
+	seeds := []specV1.Node{
+		genTestSeedNode("seed01", "http://10.0.0.5:8088/"),
+		genTestSeedNode("seed02", "http://10.0.0.6:8088"),
+		genTestSeedNode("node03", ""),
+	}
+	peers := siteSeedPeers("node01", seeds, `"e1"`)
+	assert.ElementsMatch(t, []string{"http://10.0.0.5:8088/objects/e1", "http://10.0.0.6:8088/objects/e1"}, peers)
+	// the order is kept for the same node
+	assert.Equal(t, peers, siteSeedPeers("node01", seeds, "e1"))
+	// the seed itself downloads from the other seeds
+	assert.Equal(t, []string{"http://10.0.0.6:8088/objects/e1"}, siteSeedPeers("seed01", seeds, "e1"))
+	// the object without etag can't be addressed on seeds
+	assert.Nil(t, siteSeedPeers("node01", seeds, ""))
+
+	// the nodes are spread over the seeds
+	first := map[string]bool{}
+	for _, n := range []string{"n1", "n2", "n3", "n4", "n5", "n6", "n7", "n8"} {
+		first[siteSeedPeers(n, seeds, "e1")[0]] = true
+	}
+	assert.Len(t, first, 2)
+}
+
+func TestSyncDesireSitePeers(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	cs, ns, sObject, oms := ms.NewMockConfigService(mockCtl), ms.NewMockNodeService(mockCtl), ms.NewMockObjectService(mockCtl), ms.NewMockObjectManifestService(mockCtl)
+	sync := SyncServiceImpl{ConfigService: cs, NodeService: ns, ObjectService: sObject, ObjectManifest: oms, Hooks: map[string]interface{}{}}
+	sync.Hooks[HookNamePopulateConfig] = HandlerPopulateConfig(sync.PopulateConfig)
+
+	object, err := json.Marshal(&specV1.ConfigurationObject{Metadata: map[string]string{"type": "object", "source": "awss3", "bucket": "b", "object": "model.bin", "userID": "u"}})
+	assert.NoError(t, err)
+	reqs := []specV1.ResourceInfo{{Kind: specV1.KindConfiguration, Name: "model", Version: "v1"}}
+	item := &models.ConfigObjectItem{Source: "awss3", Bucket: "b", Object: "model.bin"}
+	manifest := &models.ObjectManifest{Size: 10, ETag: `"e1"`, ChunkSize: 4}
+	node := genTestSeedNode("node01", "")
+	seeds := &models.NodeList{Items: []specV1.Node{genTestSeedNode("seed01", "http://10.0.0.5:8088"), node}}
+
+	cs.EXPECT().Get("ns01", "model", "v1").Return(&specV1.Configuration{Name: "model", Version: "v1", Data: map[string]string{common.ConfigObjectPrefix + "model.bin": string(object)}}, nil)
+	sObject.EXPECT().GenInternalObjectURL("u", "b", "model.bin", "awss3").Return(&models.ObjectURL{URL: "http://s3/model.bin"}, nil)
+	oms.EXPECT().Get("u", item).Return(manifest, nil)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(&node, nil)
+	ns.EXPECT().List("ns01", &models.ListOptions{LabelSelector: common.LabelSite + "=f1"}).Return(seeds, nil)
+	res, err := sync.Desire("ns01", reqs, map[string]string{"namespace": "ns01", "name": "node01", models.SyncProtocolVersion: "3"})
+	assert.NoError(t, err)
+	var obj specV1.ConfigurationObject
+	assert.NoError(t, json.Unmarshal([]byte(res[0].Value.Value.(*specV1.Configuration).Data[common.ConfigObjectPrefix+"model.bin"]), &obj))
+	// the url of object storage is kept for the node to fall back to
+	assert.Equal(t, "http://s3/model.bin", obj.URL)
+	var delivered models.ObjectManifest
+	assert.NoError(t, json.Unmarshal([]byte(obj.Metadata[models.ObjectManifestKey]), &delivered))
+	assert.Equal(t, []string{"http://10.0.0.5:8088/objects/e1"}, delivered.Peers)
+	assert.Nil(t, manifest.Peers)
+}
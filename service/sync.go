@@ -1,10 +1,13 @@
 package service
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/baetyl/baetyl-go/v2/errors"
 	"github.com/baetyl/baetyl-go/v2/log"
 	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
 
@@ -19,6 +22,7 @@ import (
 type SyncService interface {
 	Report(namespace, name string, report specV1.Report) (specV1.Delta, error)
 	Desire(namespace string, infos []specV1.ResourceInfo, metadata map[string]string) ([]specV1.ResourceValue, error)
+	DesirePatch(namespace string, infos []models.DesirePatchInfo, metadata map[string]string) ([]models.DesirePatchValue, error)
 }
 
 type HandlerPopulateConfig func(cfg *specV1.Configuration, metadata map[string]string) error
@@ -34,12 +38,52 @@ type SyncServiceImpl struct {
 	SecretService SecretService
 	ObjectService ObjectService
 	Hooks         map[string]interface{}
+	// Maintenance the desired changes of apps are withheld from the node outside its maintenance window
+	Maintenance MaintenanceService
+	// SyncInterval the sync intervals of node are delivered to it once they differ from the ones it reports
+	SyncInterval SyncIntervalService
+	// Conflict the apps changed on node out of band are withheld from it until the conflicts are resolved
+	Conflict AppConflictService
+	// Rollout the apps in staged rollouts are withheld from the nodes not released yet
+	Rollout RolloutService
+	// EnvGroup the env groups referenced by apps are rendered into their services
+	EnvGroup EnvGroupService
+	// SidecarPolicy the sidecars of the policies selecting apps are injected into them
+	SidecarPolicy SidecarPolicyService
+	// ImagePolicy the images of apps are rewritten to the registry mirror of namespace
+	ImagePolicy ImagePolicyService
+	// ExternalSecret the data of external secrets is read from their stores on each sync
+	ExternalSecret ExternalSecretService
+	// ServiceRecord the records of the services of apps are delivered to node along with the apps
+	ServiceRecord ServiceRecordService
+	// ObjectManifest the large objects of configs are delivered with the manifests of chunks if it's set
+	ObjectManifest ObjectManifestService
+	// Endpoints the urls of the objects in object storage are rewritten to the object storage endpoint of node
+	Endpoints NodeEndpointsService
+	// Onboarding the first report of node completes its onboarding
+	Onboarding NodeOnboardingService
+	// Artifact the images of apps are rewritten to the ones of module versions built for the platform of node
+	Artifact ModuleArtifactService
+	// Announcement the edge announcements selecting node are delivered to it once they differ from the ones it reports
+	Announcement AnnouncementService
+	// downloadRate the default bytes per second nodes download the objects with manifests at
+	downloadRate int64
+	// patches the resources delivered, which the patches of the next sync are created against
+	patches *desirePatchCache
+	// renders the apps rendered by their versions, which are rendered on each sync if it's nil
+	renders *desireRenderCache
+	// throttle the nodes delivered the changed apps per minute, which are not capped if it's nil
+	throttle *desireThrottle
 }
 
 // NewSyncService new SyncService
 func NewSyncService(config *config.CloudConfig) (SyncService, error) {
 	es := &SyncServiceImpl{
-		Hooks: map[string]interface{}{},
+		Hooks:        map[string]interface{}{},
+		downloadRate: config.ObjectDistribution.RateLimit,
+		patches:      newDesirePatchCache(config.DesirePatch.CacheSize),
+		renders:      newDesireRenderCache(config.DesireRender.CacheSize, config.DesireRender.TTL),
+		throttle:     newDesireThrottle(config.DesireThrottle.Global, config.DesireThrottle.Namespace),
 	}
 	var err error
 	es.ConfigService, err = NewConfigService(config)
@@ -62,6 +106,64 @@ func NewSyncService(config *config.CloudConfig) (SyncService, error) {
 	if err != nil {
 		return nil, err
 	}
+	es.Maintenance, err = NewMaintenanceService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.SyncInterval, err = NewSyncIntervalService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.Conflict, err = NewAppConflictService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.Rollout, err = NewRolloutService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.EnvGroup, err = NewEnvGroupService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.SidecarPolicy, err = NewSidecarPolicyService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.ImagePolicy, err = NewImagePolicyService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.ExternalSecret, err = NewExternalSecretService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.Announcement, err = NewAnnouncementService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.ServiceRecord, err = NewServiceRecordService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.Endpoints, err = NewNodeEndpointsService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.Onboarding, err = NewNodeOnboardingService(config)
+	if err != nil {
+		return nil, err
+	}
+	es.Artifact, err = NewModuleArtifactService(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.ObjectDistribution.Threshold > 0 {
+		es.ObjectManifest, err = NewObjectManifestService(config)
+		if err != nil {
+			return nil, err
+		}
+	}
 	es.Hooks[HookNamePopulateConfig] = HandlerPopulateConfig(es.PopulateConfig)
 	return es, nil
 }
@@ -76,6 +178,19 @@ func (t *SyncServiceImpl) Report(namespace, name string, report specV1.Report) (
 		return nil, err
 	}
 
+	if PruneAppInstanceRestarts(shadow, time.Now()) {
+		err = t.NodeService.UpdateDesire(nil, namespace, []string{name}, nil, func(s *models.Shadow, _ *specV1.Application) {
+			PruneAppInstanceRestarts(s, time.Now())
+		})
+		if err != nil {
+			// the restarts done are delivered again, which are ignored by node
+			log.L().Warn("failed to prune app instance restarts of node",
+				log.Any(common.KeyContextNamespace, namespace),
+				log.Any("name", name),
+				log.Error(err))
+		}
+	}
+
 	err = checkSysapp(name, &shadow.Desire)
 
 	if err != nil {
@@ -94,6 +209,15 @@ func (t *SyncServiceImpl) Report(namespace, name string, report specV1.Report) (
 			log.Error(err))
 		return nil, err
 	}
+	if t.Onboarding != nil {
+		if err = t.Onboarding.RecordReport(node); err != nil {
+			// the onboarding is completed on the next report
+			log.L().Warn("failed to record onboarding of node",
+				log.Any(common.KeyContextNamespace, namespace),
+				log.Any("name", name),
+				log.Error(err))
+		}
+	}
 
 	syncMode := specV1.CloudMode
 	if node.Attributes != nil {
@@ -111,14 +235,225 @@ func (t *SyncServiceImpl) Report(namespace, name string, report specV1.Report) (
 			return nil, err
 		}
 	}
+	if len(delta) > 0 && t.Maintenance != nil {
+		open, err := t.Maintenance.IsOpen(node, time.Now())
+		if err != nil {
+			// the changes are kept queued rather than restarting apps at an unexpected time
+			log.L().Warn("failed to check node maintenance window",
+				log.Any(common.KeyContextNamespace, namespace),
+				log.Any("name", name),
+				log.Error(err))
+		}
+		if !open {
+			delete(delta, common.DesiredApplications)
+			delete(delta, common.DesiredSysApplications)
+		}
+	}
+	if delta[common.DesiredApplications] != nil && t.Rollout != nil {
+		if err = t.holdRolloutApps(namespace, name, shadow.Report, delta); err != nil {
+			log.L().Warn("failed to check app rollouts of node",
+				log.Any(common.KeyContextNamespace, namespace),
+				log.Any("name", name),
+				log.Error(err))
+			delete(delta, common.DesiredApplications)
+		}
+	}
+	if delta[common.DesiredApplications] != nil && t.AppService != nil {
+		if err = t.checkDeltaApps(namespace, shadow, delta); err != nil {
+			log.L().Warn("failed to check apps of node",
+				log.Any(common.KeyContextNamespace, namespace),
+				log.Any("name", name),
+				log.Error(err))
+			delete(delta, common.DesiredApplications)
+		}
+	}
+	if t.Conflict != nil && (delta[common.DesiredApplications] != nil || node.Attributes[common.AttributeAppConflicts] != nil) {
+		if err = t.holdConflictApps(node, shadow, delta); err != nil {
+			log.L().Warn("failed to check app conflicts of node",
+				log.Any(common.KeyContextNamespace, namespace),
+				log.Any("name", name),
+				log.Error(err))
+			delete(delta, common.DesiredApplications)
+		}
+	}
+	// the changes are delivered on the later reports once throttled, the same as outside the maintenance window
+	if delta[common.DesiredApplications] != nil && !t.throttle.admit(namespace, name, time.Now()) {
+		log.L().Debug("the changed apps of node are throttled",
+			log.Any(common.KeyContextNamespace, namespace),
+			log.Any("name", name))
+		delete(delta, common.DesiredApplications)
+	}
+	// the records only change with the apps of node, since the versions of apps change once their ports change
+	if delta[common.DesiredApplications] != nil && t.ServiceRecord != nil {
+		records, err := t.ServiceRecord.ListByApps(namespace, specV1.Desire(delta).AppInfos(false))
+		if err != nil {
+			log.L().Warn("failed to list service records of node",
+				log.Any(common.KeyContextNamespace, namespace),
+				log.Any("name", name),
+				log.Error(err))
+		} else {
+			delta[common.DesiredServices] = records
+		}
+	}
 	// TODO remove in the future
 	if delta != nil && shadow.Desire[common.NodeProps] != nil {
 		delta[common.NodeProps] = shadow.Desire[common.NodeProps]
 	}
+	if t.SyncInterval != nil {
+		interval, err := t.deltaSyncInterval(node, shadow.Report)
+		if err != nil {
+			// node keeps syncing at the intervals it applies
+			log.L().Warn("failed to get sync intervals of node",
+				log.Any(common.KeyContextNamespace, namespace),
+				log.Any("name", name),
+				log.Error(err))
+		} else if interval != nil {
+			if delta == nil {
+				delta = specV1.Delta{}
+			}
+			delta[common.NodeSyncInterval] = interval
+		}
+	}
+	if t.Announcement != nil {
+		announcements, err := t.deltaAnnouncements(node, shadow.Report)
+		if err != nil {
+			log.L().Warn("failed to get announcements of node",
+				log.Any(common.KeyContextNamespace, namespace),
+				log.Any("name", name),
+				log.Error(err))
+		} else if announcements != nil {
+			if delta == nil {
+				delta = specV1.Delta{}
+			}
+			delta[common.NodeAnnouncements] = announcements
+		}
+	}
 
 	return delta, nil
 }
 
+// deltaSyncInterval returns the sync intervals in effect for node if they differ from the ones node reports, which
+// are empty if the intervals are unset, so that node falls back to its own settings
+func (t *SyncServiceImpl) deltaSyncInterval(node *specV1.Node, report specV1.Report) (*models.SyncInterval, error) {
+	res, err := t.SyncInterval.Get(node)
+	if err != nil {
+		return nil, err
+	}
+	desired := res.Interval
+	if desired == nil {
+		desired = &models.SyncInterval{}
+	}
+	reported := models.SyncInterval{}
+	if err = common.DecodeReport(report, common.NodeSyncInterval, &reported); err != nil {
+		return nil, err
+	}
+	if *desired == reported {
+		return nil, nil
+	}
+	return desired, nil
+}
+
+// deltaAnnouncements returns the announcements delivered to node if they differ from the ones node reports, which
+// are empty once the announcements expire, so that node clears the ones it shows
+func (t *SyncServiceImpl) deltaAnnouncements(node *specV1.Node, report specV1.Report) ([]models.EdgeAnnouncement, error) {
+	desired, err := t.Announcement.ListEdge(node)
+	if err != nil {
+		return nil, err
+	}
+	var reported []models.EdgeAnnouncement
+	if err = common.DecodeReport(report, common.NodeAnnouncements, &reported); err != nil {
+		return nil, err
+	}
+	if len(desired) == 0 && len(reported) == 0 {
+		return nil, nil
+	}
+	a, err := json.Marshal(desired)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	b, err := json.Marshal(reported)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if bytes.Equal(a, b) {
+		return nil, nil
+	}
+	if desired == nil {
+		desired = []models.EdgeAnnouncement{}
+	}
+	return desired, nil
+}
+
+func (t *SyncServiceImpl) holdRolloutApps(namespace, name string, report specV1.Report, delta specV1.Delta) error {
+	rollouts, err := t.Rollout.List(namespace)
+	if err != nil || len(rollouts) == 0 {
+		return err
+	}
+	var reported []specV1.AppInfo
+	if err = common.DecodeReport(report, common.DesiredApplications, &reported); err != nil {
+		return err
+	}
+	HoldRolloutApps(name, delta, reported, rollouts)
+	return nil
+}
+
+// checkDeltaApps keeps the paused apps at the versions running on node,
+// and delivers the apps after the ones they depend on are running on node
+func (t *SyncServiceImpl) checkDeltaApps(namespace string, shadow *models.Shadow, delta specV1.Delta) error {
+	desire := specV1.Desire(delta)
+	apps := desire.AppInfos(false)
+	if len(apps) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(apps))
+	for _, app := range apps {
+		names = append(names, app.Name)
+	}
+	items, err := t.AppService.ListByNames(namespace, names)
+	if err != nil {
+		return err
+	}
+	if err = HoldPausedApps(delta, shadow.Report, items); err != nil {
+		return err
+	}
+	deps := map[string][]string{}
+	for _, item := range items {
+		if dependsOn := AppDependencies(item.Labels); len(dependsOn) > 0 {
+			deps[item.Name] = dependsOn
+		}
+	}
+	if len(deps) == 0 {
+		return nil
+	}
+	HoldDependentApps(delta, shadow.Desire, shadow.Report, deps)
+	desire.SetAppInfos(false, SortAppsByDependency(desire.AppInfos(false), deps))
+	return nil
+}
+
+// holdConflictApps keeps the apps in conflict at the versions node reports, unless the desired versions are enforced.
+// The conflicts are checked once there are any even if nothing is delivered, so that the ones resolved are cleared
+func (t *SyncServiceImpl) holdConflictApps(node *specV1.Node, shadow *models.Shadow, delta specV1.Delta) error {
+	conflicts, err := t.Conflict.Check(node, shadow)
+	if err != nil {
+		return err
+	}
+	held := map[string]bool{}
+	for _, c := range conflicts {
+		if c.Resolution != models.AppConflictEnforce {
+			held[c.App] = true
+		}
+	}
+	if delta[common.DesiredApplications] == nil {
+		return nil
+	}
+	var reported []specV1.AppInfo
+	if err = common.DecodeReport(shadow.Report, common.DesiredApplications, &reported); err != nil {
+		return err
+	}
+	holdDeltaApps(delta, reported, held)
+	return nil
+}
+
 func extractComparingReport(report specV1.Report) specV1.Report {
 	res := map[string]interface{}{}
 	if apps, ok := report["apps"]; ok {
@@ -135,6 +470,7 @@ func extractComparingReport(report specV1.Report) specV1.Report {
 
 func (t *SyncServiceImpl) Desire(namespace string, crdInfos []specV1.ResourceInfo, metadata map[string]string) ([]specV1.ResourceValue, error) {
 	var crdDatas []specV1.ResourceValue
+	var platform *string
 	for _, info := range crdInfos {
 		crdData := specV1.ResourceValue{
 			ResourceInfo: info,
@@ -142,9 +478,25 @@ func (t *SyncServiceImpl) Desire(namespace string, crdInfos []specV1.ResourceInf
 		log.L().Info("sync get crd", log.Any("kind", info.Kind), log.Any("name", info.Name))
 		switch info.Kind {
 		case specV1.KindApplication, specV1.KindApp:
-			app, err := t.AppService.Get(namespace, info.Name, info.Version)
+			if platform == nil {
+				p, err := t.nodePlatform(namespace, metadata["name"])
+				if err != nil {
+					return nil, err
+				}
+				platform = &p
+			}
+			var key string
+			if info.Version != "" {
+				key = namespace + "/" + info.Name + "/" + info.Version
+				// the apps are rendered differently for the nodes of other platforms
+				if *platform != "" {
+					key += "/" + *platform
+				}
+			}
+			app, err := t.renders.render(key, func() (*specV1.Application, bool, error) {
+				return t.renderApp(namespace, info, *platform)
+			})
 			if err != nil {
-				log.L().Error("failed to get application", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name))
 				return nil, err
 			}
 			crdData.Value.Value = app
@@ -154,10 +506,16 @@ func (t *SyncServiceImpl) Desire(namespace string, crdInfos []specV1.ResourceInf
 				log.L().Error("failed to get config", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name))
 				return nil, err
 			}
+			// the schema only validates the config saved in cloud
+			delete(cfg.Data, common.ConfigSchemaKey)
 			if err = t.Hooks[HookNamePopulateConfig].(HandlerPopulateConfig)(cfg, metadata); err != nil {
 				log.L().Error("failed to populate config", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name))
 				return nil, err
 			}
+			if err = t.renderConfigTemplates(namespace, metadata["name"], cfg); err != nil {
+				log.L().Error("failed to render templates of config", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name), log.Error(err))
+				return nil, err
+			}
 			crdData.Value.Value = cfg
 		case specV1.KindSecret:
 			secret, err := t.SecretService.Get(namespace, info.Name, info.Version)
@@ -165,6 +523,12 @@ func (t *SyncServiceImpl) Desire(namespace string, crdInfos []specV1.ResourceInf
 				log.L().Error("failed to get secret", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name))
 				return nil, err
 			}
+			if t.ExternalSecret != nil {
+				if secret, err = t.ExternalSecret.Resolve(secret); err != nil {
+					log.L().Error("failed to resolve external secret", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name), log.Error(err))
+					return nil, err
+				}
+			}
 			crdData.Value.Value = secret
 		default:
 			return nil, fmt.Errorf("unsupported request type")
@@ -174,10 +538,160 @@ func (t *SyncServiceImpl) Desire(namespace string, crdInfos []specV1.ResourceInf
 	return crdDatas, nil
 }
 
+// renderApp renders the app of info for the nodes of platform, which is cacheable unless the secrets bound to its
+// envs are rendered since the external ones are read from their stores on each sync
+func (t *SyncServiceImpl) renderApp(namespace string, info specV1.ResourceInfo, platform string) (*specV1.Application, bool, error) {
+	app, err := t.AppService.Get(namespace, info.Name, info.Version)
+	if err != nil {
+		log.L().Error("failed to get application", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name))
+		return nil, false, err
+	}
+	if err = t.renderAppEnvGroups(namespace, app); err != nil {
+		log.L().Error("failed to render env groups of application", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name))
+		return nil, false, err
+	}
+	cacheable := len(AppEnvSecrets(app.Labels)) == 0
+	if err = t.renderAppEnvSecrets(namespace, app); err != nil {
+		log.L().Error("failed to render env secrets of application", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name), log.Error(err))
+		return nil, false, err
+	}
+	if err = t.renderAppSidecars(namespace, app); err != nil {
+		log.L().Error("failed to inject sidecars into application", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name))
+		return nil, false, err
+	}
+	// the images built for the platform are selected before they are rewritten to the mirror
+	if t.Artifact != nil {
+		if err = ApplyModuleArtifacts(app, platform, t.Artifact); err != nil {
+			log.L().Error("failed to select artifacts of application", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name), log.Any("platform", platform))
+			return nil, false, err
+		}
+	}
+	if err = t.renderAppImages(namespace, app); err != nil {
+		log.L().Error("failed to render images of application", log.Any(common.KeyContextNamespace, namespace), log.Any("name", info.Name))
+		return nil, false, err
+	}
+	return app, cacheable, nil
+}
+
+// nodePlatform returns the platform of node the apps are rendered for, which is empty if the artifacts of module
+// versions are not selected or the node is not found
+func (t *SyncServiceImpl) nodePlatform(namespace, name string) (string, error) {
+	if t.Artifact == nil || name == "" {
+		return "", nil
+	}
+	node, err := t.NodeService.Get(nil, namespace, name)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	return NodePlatform(node), nil
+}
+
+// renderAppEnvGroups renders the env groups referenced by app into its services,
+// the groups which no longer exist are skipped
+func (t *SyncServiceImpl) renderAppEnvGroups(namespace string, app *specV1.Application) error {
+	names := AppEnvGroups(app.Labels)
+	if len(names) == 0 || t.EnvGroup == nil {
+		return nil
+	}
+	var groups []models.EnvGroup
+	for _, name := range names {
+		group, err := t.EnvGroup.Get(namespace, name)
+		if err != nil {
+			if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+				log.L().Warn("the env group of application is not found", log.Any(common.KeyContextNamespace, namespace), log.Any("app", app.Name), log.Any("group", name))
+				continue
+			}
+			return err
+		}
+		groups = append(groups, *group)
+	}
+	ApplyEnvGroups(app, groups)
+	return nil
+}
+
+// renderAppEnvSecrets fills in the envs of app bound to secrets by the functions, the secrets are read on each
+// sync so that the values are never stored in app
+func (t *SyncServiceImpl) renderAppEnvSecrets(namespace string, app *specV1.Application) error {
+	bindings := AppEnvSecrets(app.Labels)
+	if len(bindings) == 0 {
+		return nil
+	}
+	secrets := map[string]*specV1.Secret{}
+	values := map[string]string{}
+	for env, ref := range bindings {
+		secret, ok := secrets[ref.Name]
+		if !ok {
+			var err error
+			if secret, err = t.SecretService.Get(namespace, ref.Name, ""); err != nil {
+				return err
+			}
+			if t.ExternalSecret != nil {
+				if secret, err = t.ExternalSecret.Resolve(secret); err != nil {
+					return err
+				}
+			}
+			secrets[ref.Name] = secret
+		}
+		v, ok := secret.Data[ref.Key]
+		if !ok {
+			return errors.Errorf("the key %s of secret %s bound to env %s is not found", ref.Key, ref.Name, env)
+		}
+		values[env] = string(v)
+	}
+	ApplyEnvSecrets(app, values)
+	return nil
+}
+
+// renderAppSidecars injects the sidecars of the policies in namespace which select app
+func (t *SyncServiceImpl) renderAppSidecars(namespace string, app *specV1.Application) error {
+	if t.SidecarPolicy == nil {
+		return nil
+	}
+	policies, err := t.SidecarPolicy.List(namespace)
+	if err != nil {
+		return err
+	}
+	ApplySidecarPolicies(app, policies)
+	return nil
+}
+
+// renderConfigTemplates renders the template items of config for the node requesting it. The config is rendered
+// again once its version changes, the changes of node take effect on the next update of config
+func (t *SyncServiceImpl) renderConfigTemplates(namespace, name string, cfg *specV1.Configuration) error {
+	if !HasConfigTemplate(cfg) {
+		return nil
+	}
+	node, err := t.NodeService.Get(nil, namespace, name)
+	if err != nil {
+		return err
+	}
+	props, err := t.NodeService.GetNodeProperties(namespace, name)
+	if err != nil {
+		return err
+	}
+	return RenderConfigTemplates(cfg, NodeConfigTemplateVars(node, props))
+}
+
+// renderAppImages applies the image policy of namespace to app, after the sidecars injected so that their images are rewritten too
+func (t *SyncServiceImpl) renderAppImages(namespace string, app *specV1.Application) error {
+	if t.ImagePolicy == nil {
+		return nil
+	}
+	policy, err := t.ImagePolicy.Get(namespace)
+	if err != nil {
+		return err
+	}
+	ApplyImagePolicy(app, policy)
+	return nil
+}
+
 func (t *SyncServiceImpl) PopulateConfig(cfg *specV1.Configuration, metadata map[string]string) error {
 	for k, v := range cfg.Data {
 		if strings.HasPrefix(k, common.ConfigObjectPrefix) {
-			err := t.PopulateConfigObject(k, v, cfg)
+			err := t.PopulateConfigObject(k, v, cfg, metadata)
 			if err != nil {
 				return err
 			}
@@ -186,7 +700,7 @@ func (t *SyncServiceImpl) PopulateConfig(cfg *specV1.Configuration, metadata map
 	return nil
 }
 
-func (t *SyncServiceImpl) PopulateConfigObject(k, v string, cfg *specV1.Configuration) error {
+func (t *SyncServiceImpl) PopulateConfigObject(k, v string, cfg *specV1.Configuration, metadata map[string]string) error {
 	obj := new(specV1.ConfigurationObject)
 	err := json.Unmarshal([]byte(v), obj)
 	if err != nil {
@@ -210,6 +724,9 @@ func (t *SyncServiceImpl) PopulateConfigObject(k, v string, cfg *specV1.Configur
 	var res *models.ObjectURL
 	if item.Endpoint == "" {
 		res, err = t.ObjectService.GenInternalObjectURL(obj.Metadata["userID"], item.Bucket, item.Object, item.Source)
+		if err == nil {
+			res.URL, err = t.nodeObjectURL(metadata, res.URL)
+		}
 	} else {
 		res, err = t.ObjectService.GenExternalObjectURL(models.ExternalObjectInfo{
 			Endpoint:      item.Endpoint,
@@ -227,7 +744,24 @@ func (t *SyncServiceImpl) PopulateConfigObject(k, v string, cfg *specV1.Configur
 		obj.MD5 = res.MD5
 	}
 	obj.Unpack = item.Unpack
+	userID := obj.Metadata["userID"]
 	obj.Metadata = nil
+	// the nodes before the manifests download the objects in full
+	if t.ObjectManifest != nil && syncProtocolOf(metadata) >= models.SyncProtocolV3 {
+		manifest, err := t.ObjectManifest.Get(userID, &item)
+		if err != nil {
+			// the object is delivered without manifest, which the node downloads in full
+			log.L().Warn("failed to get the manifest of config object", log.Any("name", cfg.Name), log.Any("key", k), log.Error(err))
+		} else if manifest != nil {
+			m := *manifest
+			m.RateLimit, m.Peers = t.nodeObjectDelivery(metadata, m.ETag)
+			data, err := json.Marshal(&m)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			obj.Metadata = map[string]string{models.ObjectManifestKey: string(data)}
+		}
+	}
 
 	data, err := json.Marshal(obj)
 	if err != nil {
@@ -237,6 +771,62 @@ func (t *SyncServiceImpl) PopulateConfigObject(k, v string, cfg *specV1.Configur
 	return nil
 }
 
+// syncProtocolOf returns the protocol version node speaks to cloud, which the resources delivered are adapted to
+func syncProtocolOf(metadata map[string]string) int {
+	version, err := models.SyncProtocolFromMetadata(metadata)
+	if err != nil {
+		return models.SyncProtocolV1
+	}
+	return models.NegotiateSyncProtocol(version)
+}
+
+// nodeObjectDelivery returns the bytes per second the node downloads objects at, the label of node overrides the
+// default, and the urls of the object on the seeds of the site of node if it's in a site
+func (t *SyncServiceImpl) nodeObjectDelivery(metadata map[string]string, etag string) (int64, []string) {
+	ns, name := metadata["namespace"], metadata["name"]
+	if name == "" {
+		return t.downloadRate, nil
+	}
+	node, err := t.NodeService.Get(nil, ns, name)
+	if err != nil {
+		log.L().Warn("failed to get the download rate of node", log.Any(common.KeyContextNamespace, ns), log.Any("name", name), log.Error(err))
+		return t.downloadRate, nil
+	}
+	rate, err := common.NodeDownloadRate(node.Labels)
+	if err != nil || rate == 0 {
+		rate = t.downloadRate
+	}
+	site := node.Labels[common.LabelSite]
+	if site == "" {
+		return rate, nil
+	}
+	// the node downloads from the object storage if the seeds are unknown
+	list, err := t.NodeService.List(ns, &models.ListOptions{LabelSelector: common.LabelSite + "=" + site})
+	if err != nil {
+		log.L().Warn("failed to list the seeds of site", log.Any(common.KeyContextNamespace, ns), log.Any("site", site), log.Error(err))
+		return rate, nil
+	}
+	return rate, siteSeedPeers(name, list.Items, etag)
+}
+
+// nodeObjectURL returns the url of the object in object storage which node downloads, the url generated by cloud is
+// rewritten if the object storage endpoint is overridden for node
+func (t *SyncServiceImpl) nodeObjectURL(metadata map[string]string, raw string) (string, error) {
+	ns, name := metadata["namespace"], metadata["name"]
+	if t.Endpoints == nil || name == "" {
+		return raw, nil
+	}
+	node, err := t.NodeService.Get(nil, ns, name)
+	if err != nil {
+		return "", err
+	}
+	endpoints, err := t.Endpoints.Resolve(ns, node)
+	if err != nil {
+		return "", err
+	}
+	return RewriteObjectURL(raw, endpoints.ObjectStorage)
+}
+
 func checkSysapp(name string, desire *specV1.Desire) error {
 	if desire == nil {
 		return common.Error(common.ErrNodeNotReady, common.Field("name", name))
@@ -270,3 +270,609 @@ func TestDesireDiff(t *testing.T) {
 	delta, _ := desire.Diff(report)
 	assert.Equal(t, desire.AppInfos(isSysApp), delta.AppInfos(isSysApp))
 }
+
+func TestReportMaintenanceWindow(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns, mm := ms.NewMockNodeService(mockCtl), ms.NewMockMaintenanceService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns, Maintenance: mm}
+
+	shadow := &models.Shadow{
+		Desire: specV1.Desire{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "app", Version: "v2"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v2"}},
+		},
+		Report: specV1.Report{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "app", Version: "v1"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}},
+		},
+	}
+	node := &specV1.Node{Namespace: "ns01", Name: "node01"}
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadow, nil).Times(3)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil).Times(3)
+
+	mm.EXPECT().IsOpen(node, gomock.Any()).Return(true, nil)
+	delta, err := sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Contains(t, delta, common.DesiredApplications)
+	assert.Contains(t, delta, common.DesiredSysApplications)
+
+	// the changes are queued until the window opens
+	mm.EXPECT().IsOpen(node, gomock.Any()).Return(false, nil)
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.DesiredApplications)
+	assert.NotContains(t, delta, common.DesiredSysApplications)
+
+	mm.EXPECT().IsOpen(node, gomock.Any()).Return(false, fmt.Errorf("error"))
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.DesiredApplications)
+}
+
+func TestReportAppConflict(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns, mc := ms.NewMockNodeService(mockCtl), ms.NewMockAppConflictService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns, Conflict: mc}
+
+	shadow := &models.Shadow{
+		Desire: specV1.Desire{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "app", Version: "v2"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}},
+		},
+		Report: specV1.Report{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "app", Version: "local"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}},
+		},
+	}
+	node := &specV1.Node{Namespace: "ns01", Name: "node01"}
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadow, nil).Times(3)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil).Times(3)
+
+	// the app is kept at the version of node until the conflict is resolved
+	mc.EXPECT().Check(node, shadow).Return([]models.AppConflict{{App: "app", Desired: "v2", Reported: "local"}}, nil)
+	delta, err := sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Equal(t, []specV1.AppInfo{{Name: "app", Version: "local"}}, specV1.Desire(delta).AppInfos(false))
+
+	mc.EXPECT().Check(node, shadow).Return([]models.AppConflict{{App: "app", Desired: "v2", Reported: "local", Resolution: models.AppConflictEnforce}}, nil)
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Equal(t, []specV1.AppInfo{{Name: "app", Version: "v2"}}, specV1.Desire(delta).AppInfos(false))
+
+	mc.EXPECT().Check(node, shadow).Return(nil, fmt.Errorf("error"))
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.DesiredApplications)
+}
+
+func TestReportSyncInterval(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns, mi := ms.NewMockNodeService(mockCtl), ms.NewMockSyncIntervalService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns, SyncInterval: mi}
+
+	node := &specV1.Node{Namespace: "ns01", Name: "node01"}
+	interval := &models.SyncInterval{Report: "1h", Jitter: "5m"}
+	shadowOf := func(report specV1.Report) *models.Shadow {
+		return &models.Shadow{
+			Desire: specV1.Desire{common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}}},
+			Report: report,
+		}
+	}
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil).Times(5)
+
+	// delivered until node reports the same intervals
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadowOf(specV1.Report{}), nil)
+	mi.EXPECT().Get(node).Return(&models.NodeSyncInterval{Interval: interval, Group: "g1"}, nil)
+	delta, err := sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Equal(t, interval, delta[common.NodeSyncInterval])
+
+	applied := specV1.Report{common.NodeSyncInterval: map[string]interface{}{"report": "1h", "jitter": "5m"}}
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadowOf(applied), nil).Times(2)
+	mi.EXPECT().Get(node).Return(&models.NodeSyncInterval{Interval: interval, Group: "g1"}, nil)
+	delta, err = sync.Report("ns01", "node01", applied)
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.NodeSyncInterval)
+
+	// node falls back to its own settings once the intervals are unset
+	mi.EXPECT().Get(node).Return(&models.NodeSyncInterval{}, nil)
+	delta, err = sync.Report("ns01", "node01", applied)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.SyncInterval{}, delta[common.NodeSyncInterval])
+
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadowOf(specV1.Report{}), nil).Times(2)
+	mi.EXPECT().Get(node).Return(&models.NodeSyncInterval{}, nil)
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.NodeSyncInterval)
+
+	mi.EXPECT().Get(node).Return(nil, fmt.Errorf("error"))
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.NodeSyncInterval)
+}
+
+func TestReportAnnouncements(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns, ma := ms.NewMockNodeService(mockCtl), ms.NewMockAnnouncementService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns, Announcement: ma}
+
+	node := &specV1.Node{Namespace: "ns01", Name: "node01"}
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	announcements := []models.EdgeAnnouncement{{Name: "maintenance", Title: "planned maintenance", Level: models.AnnouncementWarning, StartTime: start, EndTime: start.Add(time.Hour)}}
+	shadowOf := func(report specV1.Report) *models.Shadow {
+		return &models.Shadow{
+			Desire: specV1.Desire{common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}}},
+			Report: report,
+		}
+	}
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil).Times(4)
+
+	// delivered until node reports the same announcements
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadowOf(specV1.Report{}), nil)
+	ma.EXPECT().ListEdge(node).Return(announcements, nil)
+	delta, err := sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Equal(t, announcements, delta[common.NodeAnnouncements])
+
+	shown := specV1.Report{common.NodeAnnouncements: []interface{}{map[string]interface{}{
+		"name": "maintenance", "title": "planned maintenance", "content": "", "level": "warning",
+		"startTime": "2026-10-01T00:00:00Z", "endTime": "2026-10-01T01:00:00Z",
+	}}}
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadowOf(shown), nil).Times(2)
+	ma.EXPECT().ListEdge(node).Return(announcements, nil)
+	delta, err = sync.Report("ns01", "node01", shown)
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.NodeAnnouncements)
+
+	// node clears the announcements expired
+	ma.EXPECT().ListEdge(node).Return(nil, nil)
+	delta, err = sync.Report("ns01", "node01", shown)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.EdgeAnnouncement{}, delta[common.NodeAnnouncements])
+
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadowOf(specV1.Report{}), nil)
+	ma.EXPECT().ListEdge(node).Return(nil, fmt.Errorf("error"))
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.NodeAnnouncements)
+}
+
+func TestReportOnboarding(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns, mo := ms.NewMockNodeService(mockCtl), ms.NewMockNodeOnboardingService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns, Onboarding: mo}
+
+	node := &specV1.Node{Namespace: "ns01", Name: "node01"}
+	shadow := &models.Shadow{Desire: specV1.Desire{common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}}}}
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadow, nil).Times(2)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil).Times(2)
+	mo.EXPECT().RecordReport(node).Return(nil)
+	_, err := sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+
+	// the report is not failed by the onboarding
+	mo.EXPECT().RecordReport(node).Return(fmt.Errorf("error"))
+	_, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+}
+
+func TestReportRollout(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns, mr := ms.NewMockNodeService(mockCtl), ms.NewMockRolloutService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns, Rollout: mr}
+
+	shadow := &models.Shadow{
+		Desire: specV1.Desire{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "app01", Version: "v2"}, {Name: "app02", Version: "v2"}, {Name: "app03", Version: "v1"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}},
+		},
+		Report: specV1.Report{
+			common.DesiredApplications: []specV1.AppInfo{{Name: "app01", Version: "v1"}, {Name: "app02", Version: "v1"}},
+		},
+	}
+	node := &specV1.Node{Namespace: "ns01", Name: "node01"}
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadow, nil).Times(3)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil).Times(3)
+
+	rollouts := []models.Rollout{
+		{App: "app01", Status: models.RolloutProgressing, Nodes: []string{"node02"}},
+		{App: "app02", Status: models.RolloutPaused, Nodes: []string{"node01"}},
+		{App: "app03", Status: models.RolloutProgressing},
+	}
+	mr.EXPECT().List("ns01").Return(rollouts, nil)
+	delta, err := sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	// app01 keeps the reported version and app03 is not delivered until node01 is released
+	assert.Equal(t, []specV1.AppInfo{{Name: "app01", Version: "v1"}, {Name: "app02", Version: "v2"}}, delta.AppInfos(false))
+
+	rollouts[0].Status = models.RolloutCompleted
+	mr.EXPECT().List("ns01").Return(rollouts[:1], nil)
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Len(t, delta.AppInfos(false), 3)
+
+	mr.EXPECT().List("ns01").Return(nil, fmt.Errorf("error"))
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.DesiredApplications)
+}
+
+func TestReportAppDependencies(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns, as := ms.NewMockNodeService(mockCtl), ms.NewMockApplicationService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns, AppService: as}
+
+	shadow := &models.Shadow{
+		Desire: specV1.Desire{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "web", Version: "v1"}, {Name: "db", Version: "v1"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}},
+		},
+		Report: specV1.Report{},
+	}
+	node := &specV1.Node{Namespace: "ns01", Name: "node01"}
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadow, nil).Times(3)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil).Times(3)
+
+	items := []models.AppItem{
+		{Name: "web", Labels: map[string]string{common.LabelPrefixDependsOn + "db": "true"}},
+		{Name: "db"},
+	}
+	as.EXPECT().ListByNames("ns01", []string{"web", "db"}).Return(items, nil)
+	delta, err := sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	// web waits until db is running
+	assert.Equal(t, []specV1.AppInfo{{Name: "db", Version: "v1"}}, delta.AppInfos(false))
+
+	shadow.Report = specV1.Report{
+		common.DesiredApplications: []specV1.AppInfo{{Name: "db", Version: "v1"}},
+		"appstats": []specV1.AppStats{
+			{AppInfo: specV1.AppInfo{Name: "db", Version: "v1"}, InstanceStats: map[string]specV1.InstanceStats{"db-0": {Status: "Running"}}},
+		},
+	}
+	as.EXPECT().ListByNames("ns01", []string{"web", "db"}).Return(items, nil)
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Equal(t, []specV1.AppInfo{{Name: "db", Version: "v1"}, {Name: "web", Version: "v1"}}, delta.AppInfos(false))
+
+	as.EXPECT().ListByNames("ns01", gomock.Any()).Return(nil, fmt.Errorf("error"))
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.DesiredApplications)
+}
+
+func TestSyncDesireEnvGroups(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	as, es := ms.NewMockApplicationService(mockCtl), ms.NewMockEnvGroupService(mockCtl)
+	sync := SyncServiceImpl{AppService: as, EnvGroup: es}
+
+	app := &specV1.Application{
+		Name:     "web",
+		Version:  "v2",
+		Labels:   map[string]string{common.LabelPrefixEnvGroup + "mqtt": "true", common.LabelPrefixEnvGroup + "region": "true"},
+		Services: []specV1.Service{{Name: "web", Env: []specV1.Environment{{Name: "REGION", Value: "bj"}}}},
+	}
+	reqs := []specV1.ResourceInfo{{Kind: specV1.KindApplication, Name: "web", Version: "v2"}}
+	as.EXPECT().Get("ns01", "web", "v2").Return(app, nil)
+	es.EXPECT().Get("ns01", "mqtt").Return(&models.EnvGroup{Name: "mqtt", Envs: []specV1.Environment{{Name: "MQTT_ADDRESS", Value: "tcp://broker:1883"}}}, nil)
+	es.EXPECT().Get("ns01", "region").Return(nil, common.Error(common.ErrResourceNotFound))
+	res, err := sync.Desire("ns01", reqs, map[string]string{})
+	assert.NoError(t, err)
+	resApp := res[0].Value.Value.(*specV1.Application)
+	assert.Equal(t, []specV1.Environment{{Name: "REGION", Value: "bj"}, {Name: "MQTT_ADDRESS", Value: "tcp://broker:1883"}}, resApp.Services[0].Env)
+
+	as.EXPECT().Get("ns01", "web", "v2").Return(&specV1.Application{Name: "web", Labels: app.Labels}, nil)
+	es.EXPECT().Get("ns01", "mqtt").Return(nil, fmt.Errorf("error"))
+	_, err = sync.Desire("ns01", reqs, map[string]string{})
+	assert.Error(t, err)
+}
+
+func TestSyncDesireEnvSecrets(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	as, ss := ms.NewMockApplicationService(mockCtl), ms.NewMockSecretService(mockCtl)
+	sync := SyncServiceImpl{AppService: as, SecretService: ss}
+
+	labels := map[string]string{common.LabelPrefixEnvSecret + "TOKEN": "cred_token", common.LabelPrefixEnvSecret + "USER": "cred_user"}
+	app := &specV1.Application{
+		Name:     "infer",
+		Version:  "v2",
+		Labels:   labels,
+		Services: []specV1.Service{{Name: "infer", Env: []specV1.Environment{{Name: "TOKEN"}, {Name: "USER", Value: "admin"}}}},
+	}
+	reqs := []specV1.ResourceInfo{{Kind: specV1.KindApplication, Name: "infer", Version: "v2"}}
+	as.EXPECT().Get("ns01", "infer", "v2").Return(app, nil)
+	ss.EXPECT().Get("ns01", "cred", "").Return(&specV1.Secret{Name: "cred", Data: map[string][]byte{"token": []byte("t0"), "user": []byte("u0")}}, nil)
+	res, err := sync.Desire("ns01", reqs, map[string]string{})
+	assert.NoError(t, err)
+	resApp := res[0].Value.Value.(*specV1.Application)
+	assert.Equal(t, []specV1.Environment{{Name: "TOKEN", Value: "t0"}, {Name: "USER", Value: "admin"}}, resApp.Services[0].Env)
+
+	// the app is not delivered without the values of secret
+	as.EXPECT().Get("ns01", "infer", "v2").Return(&specV1.Application{Name: "infer", Labels: labels}, nil)
+	ss.EXPECT().Get("ns01", "cred", "").Return(&specV1.Secret{Name: "cred", Data: map[string][]byte{"token": []byte("t0")}}, nil)
+	_, err = sync.Desire("ns01", reqs, map[string]string{})
+	assert.Error(t, err)
+
+	as.EXPECT().Get("ns01", "infer", "v2").Return(&specV1.Application{Name: "infer", Labels: labels}, nil)
+	ss.EXPECT().Get("ns01", "cred", "").Return(nil, common.Error(common.ErrResourceNotFound))
+	_, err = sync.Desire("ns01", reqs, map[string]string{})
+	assert.Error(t, err)
+}
+
+func TestSyncDesireSidecars(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	as, ss := ms.NewMockApplicationService(mockCtl), ms.NewMockSidecarPolicyService(mockCtl)
+	sync := SyncServiceImpl{AppService: as, SidecarPolicy: ss}
+
+	sidecar := specV1.Service{Name: "metrics-agent", Image: "metrics-agent"}
+	reqs := []specV1.ResourceInfo{{Kind: specV1.KindApplication, Name: "web", Version: "v2"}}
+	as.EXPECT().Get("ns01", "web", "v2").Return(&specV1.Application{Name: "web", Services: []specV1.Service{{Name: "web"}}}, nil)
+	ss.EXPECT().List("ns01").Return([]models.SidecarPolicy{{Name: "metrics", Sidecar: sidecar}}, nil)
+	res, err := sync.Desire("ns01", reqs, map[string]string{})
+	assert.NoError(t, err)
+	resApp := res[0].Value.Value.(*specV1.Application)
+	assert.Equal(t, []specV1.Service{{Name: "web"}, sidecar}, resApp.Services)
+
+	as.EXPECT().Get("ns01", "web", "v2").Return(&specV1.Application{Name: "web"}, nil)
+	ss.EXPECT().List("ns01").Return(nil, fmt.Errorf("error"))
+	_, err = sync.Desire("ns01", reqs, map[string]string{})
+	assert.Error(t, err)
+}
+
+func TestSyncDesireImages(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	as, ss, is := ms.NewMockApplicationService(mockCtl), ms.NewMockSidecarPolicyService(mockCtl), ms.NewMockImagePolicyService(mockCtl)
+	sync := SyncServiceImpl{AppService: as, SidecarPolicy: ss, ImagePolicy: is}
+
+	reqs := []specV1.ResourceInfo{{Kind: specV1.KindApplication, Name: "web", Version: "v2"}}
+	as.EXPECT().Get("ns01", "web", "v2").Return(&specV1.Application{Name: "web", Services: []specV1.Service{{Name: "web", Image: "nginx"}}}, nil)
+	ss.EXPECT().List("ns01").Return([]models.SidecarPolicy{{Name: "metrics", Sidecar: specV1.Service{Name: "agent", Image: "quay.io/agent"}}}, nil)
+	is.EXPECT().Get("ns01").Return(&models.ImagePolicy{Namespace: "ns01", Mirror: "harbor.local", PullPolicy: models.ImagePullAlways}, nil)
+	res, err := sync.Desire("ns01", reqs, map[string]string{})
+	assert.NoError(t, err)
+	resApp := res[0].Value.Value.(*specV1.Application)
+	// the images of sidecars are rewritten too
+	assert.Equal(t, "harbor.local/library/nginx", resApp.Services[0].Image)
+	assert.Equal(t, "harbor.local/agent", resApp.Services[1].Image)
+	assert.Equal(t, models.ImagePullAlways, resApp.Labels[common.LabelImagePullPolicy])
+
+	as.EXPECT().Get("ns01", "web", "v2").Return(&specV1.Application{Name: "web"}, nil)
+	ss.EXPECT().List("ns01").Return(nil, nil)
+	is.EXPECT().Get("ns01").Return(nil, fmt.Errorf("error"))
+	_, err = sync.Desire("ns01", reqs, map[string]string{})
+	assert.Error(t, err)
+}
+
+func TestReportPausedApps(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns, as := ms.NewMockNodeService(mockCtl), ms.NewMockApplicationService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns, AppService: as}
+
+	shadow := &models.Shadow{
+		Desire: specV1.Desire{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "web", Version: "v2"}, {Name: "db", Version: "v2"}, {Name: "cache", Version: "v1"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}},
+		},
+		Report: specV1.Report{
+			common.DesiredApplications: []specV1.AppInfo{{Name: "web", Version: "v1"}, {Name: "db", Version: "v1"}},
+		},
+	}
+	node := &specV1.Node{Namespace: "ns01", Name: "node01"}
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadow, nil).Times(2)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil).Times(2)
+
+	items := []models.AppItem{
+		{Name: "web", Labels: map[string]string{common.LabelAppPaused: "true"}},
+		{Name: "db"},
+		{Name: "cache", Labels: map[string]string{common.LabelAppPaused: "true"}},
+	}
+	as.EXPECT().ListByNames("ns01", []string{"web", "db", "cache"}).Return(items, nil)
+	delta, err := sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	// web keeps the version running on node and cache is not delivered while paused
+	assert.Equal(t, []specV1.AppInfo{{Name: "web", Version: "v1"}, {Name: "db", Version: "v2"}}, delta.AppInfos(false))
+
+	items[0].Labels, items[2].Labels = nil, nil
+	as.EXPECT().ListByNames("ns01", []string{"web", "db", "cache"}).Return(items, nil)
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Len(t, delta.AppInfos(false), 3)
+}
+
+func TestReportAppInstanceRestarts(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns := ms.NewMockNodeService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns}
+
+	now := time.Now().UTC()
+	restarts := []models.AppInstanceRestart{{App: "web", Instance: "web-0", RequestTime: now}}
+	shadow := &models.Shadow{
+		Desire: specV1.Desire{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "web", Version: "v1"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}},
+			common.DesiredRestarts:        restarts,
+		},
+		Report: specV1.Report{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "web", Version: "v1"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}},
+		},
+	}
+	node := &specV1.Node{Namespace: "ns01", Name: "node01"}
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadow, nil).Times(2)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil).Times(2)
+
+	// the restart is delivered until the node reports it done
+	delta, err := sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Contains(t, delta, common.DesiredRestarts)
+
+	shadow.Report[common.DesiredRestarts] = restarts
+	ns.EXPECT().UpdateDesire(nil, "ns01", []string{"node01"}, nil, gomock.Any()).Return(nil)
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.DesiredRestarts)
+	assert.NotContains(t, shadow.Desire, common.DesiredRestarts)
+}
+
+func TestReportServiceRecords(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns, rs := ms.NewMockNodeService(mockCtl), ms.NewMockServiceRecordService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns, ServiceRecord: rs}
+
+	shadow := &models.Shadow{
+		Desire: specV1.Desire{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "web", Version: "v2"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}},
+		},
+		Report: specV1.Report{
+			common.DesiredApplications:    []specV1.AppInfo{{Name: "web", Version: "v1"}},
+			common.DesiredSysApplications: []specV1.AppInfo{{Name: "sysapp01", Version: "v1"}},
+		},
+	}
+	node := &specV1.Node{Namespace: "ns01", Name: "node01"}
+	ns.EXPECT().UpdateReport("ns01", "node01", gomock.Any()).Return(shadow, nil).Times(3)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil).Times(3)
+
+	records := []models.ServiceRecord{{App: "web", Service: "nginx", Host: "nginx.web.baetyl.local", Ports: []models.ServiceRecordPort{{Port: 80, Protocol: "TCP"}}}}
+	rs.EXPECT().ListByApps("ns01", []specV1.AppInfo{{Name: "web", Version: "v2"}}).Return(records, nil)
+	delta, err := sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Equal(t, records, delta[common.DesiredServices])
+
+	// the apps are delivered without the records if failed to list them
+	rs.EXPECT().ListByApps("ns01", gomock.Any()).Return(nil, fmt.Errorf("error"))
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.Contains(t, delta, common.DesiredApplications)
+	assert.NotContains(t, delta, common.DesiredServices)
+
+	// the records are unchanged if the apps of node are
+	shadow.Report[common.DesiredApplications] = []specV1.AppInfo{{Name: "web", Version: "v2"}}
+	delta, err = sync.Report("ns01", "node01", specV1.Report{})
+	assert.NoError(t, err)
+	assert.NotContains(t, delta, common.DesiredServices)
+}
+
+func TestSyncDesireConfigTemplates(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	cs, ns := ms.NewMockConfigService(mockCtl), ms.NewMockNodeService(mockCtl)
+	sync := SyncServiceImpl{ConfigService: cs, NodeService: ns, Hooks: map[string]interface{}{}}
+	sync.Hooks[HookNamePopulateConfig] = HandlerPopulateConfig(sync.PopulateConfig)
+
+	reqs := []specV1.ResourceInfo{{Kind: specV1.KindConfiguration, Name: "device", Version: "v1"}}
+	metadata := map[string]string{"namespace": "ns01", "name": "node01"}
+	newConfig := func() *specV1.Configuration {
+		return &specV1.Configuration{
+			Name:    "device",
+			Version: "v1",
+			Data: map[string]string{
+				"plain":                                  "static",
+				common.ConfigTemplatePrefix + "conf.yml": "id: {{.Properties.deviceId}}\nnode: {{.Name}}\nregion: {{.Labels.region}}",
+			},
+		}
+	}
+	node := &specV1.Node{Namespace: "ns01", Name: "node01", Labels: map[string]string{"region": "bj"}}
+	props := &models.NodeProperties{State: models.NodePropertiesState{
+		Report: map[string]interface{}{"deviceId": "reported"},
+		Desire: map[string]interface{}{"deviceId": "dev-01"},
+	}}
+
+	cs.EXPECT().Get("ns01", "device", "v1").Return(newConfig(), nil)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil)
+	ns.EXPECT().GetNodeProperties("ns01", "node01").Return(props, nil)
+	res, err := sync.Desire("ns01", reqs, metadata)
+	assert.NoError(t, err)
+	resConfig := res[0].Value.Value.(*specV1.Configuration)
+	assert.Equal(t, map[string]string{
+		"plain":    "static",
+		"conf.yml": "id: dev-01\nnode: node01\nregion: bj",
+	}, resConfig.Data)
+
+	// the variable missing on node fails the rendering
+	cs.EXPECT().Get("ns01", "device", "v1").Return(newConfig(), nil)
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(&specV1.Node{Namespace: "ns01", Name: "node01"}, nil)
+	ns.EXPECT().GetNodeProperties("ns01", "node01").Return(props, nil)
+	_, err = sync.Desire("ns01", reqs, metadata)
+	assert.Error(t, err)
+
+	// the nodes are not read for the configs without templates
+	cs.EXPECT().Get("ns01", "device", "v1").Return(&specV1.Configuration{Name: "device", Data: map[string]string{"plain": "static"}}, nil)
+	_, err = sync.Desire("ns01", reqs, metadata)
+	assert.NoError(t, err)
+}
+
+func TestSyncDesireExternalSecret(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ss, es := ms.NewMockSecretService(mockCtl), ms.NewMockExternalSecretService(mockCtl)
+	sync := SyncServiceImpl{SecretService: ss, ExternalSecret: es}
+
+	reqs := []specV1.ResourceInfo{{Kind: specV1.KindSecret, Name: "db", Version: "v1"}}
+	stored := &specV1.Secret{Name: "db", Version: "v1", Data: map[string][]byte{common.SecretExternalKey: []byte(`{"provider":"vaultkv","path":"app/db"}`)}}
+	resolved := &specV1.Secret{Name: "db", Version: "v1", Data: map[string][]byte{"password": []byte("secret")}}
+
+	ss.EXPECT().Get("ns01", "db", "v1").Return(stored, nil)
+	es.EXPECT().Resolve(stored).Return(resolved, nil)
+	res, err := sync.Desire("ns01", reqs, map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, resolved, res[0].Value.Value)
+
+	// the desire fails instead of delivering the reference if the store is unavailable
+	ss.EXPECT().Get("ns01", "db", "v1").Return(stored, nil)
+	es.EXPECT().Resolve(stored).Return(nil, fmt.Errorf("vault is sealed"))
+	_, err = sync.Desire("ns01", reqs, map[string]string{})
+	assert.Error(t, err)
+}
+
+func TestSyncDesireArtifacts(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	ns, as, ma := ms.NewMockNodeService(mockCtl), ms.NewMockApplicationService(mockCtl), ms.NewMockModuleArtifactService(mockCtl)
+	sync := SyncServiceImpl{NodeService: ns, AppService: as, Artifact: ma}
+
+	reqs := []specV1.ResourceInfo{
+		{Kind: specV1.KindApplication, Name: "core", Version: "v1"},
+		{Kind: specV1.KindApplication, Name: "broker", Version: "v1"},
+	}
+	node := &specV1.Node{Namespace: "ns01", Name: "node01", Report: specV1.Report{common.NodeInfo: map[string]interface{}{
+		"node01": map[string]interface{}{"os": "linux", "arch": "arm64"},
+	}}}
+	// the node is got once for the apps of a sync
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil)
+	as.EXPECT().Get("ns01", "core", "v1").Return(&specV1.Application{Name: "core", Services: []specV1.Service{{Name: "core", Image: "baetyltech/baetyl:v2.2.0"}}}, nil)
+	as.EXPECT().Get("ns01", "broker", "v1").Return(&specV1.Application{Name: "broker", Services: []specV1.Service{{Name: "broker", Image: "baetyltech/broker:v2.2.0"}}}, nil)
+	ma.EXPECT().SelectImage("baetyltech/baetyl:v2.2.0", "linux-arm64").Return("baetyltech/baetyl:v2.2.0-arm64", nil)
+	ma.EXPECT().SelectImage("baetyltech/broker:v2.2.0", "linux-arm64").Return("baetyltech/broker:v2.2.0", nil)
+	res, err := sync.Desire("ns01", reqs, map[string]string{"name": "node01"})
+	assert.NoError(t, err)
+	assert.Equal(t, "baetyltech/baetyl:v2.2.0-arm64", res[0].Value.Value.(*specV1.Application).Services[0].Image)
+	assert.Equal(t, "baetyltech/broker:v2.2.0", res[1].Value.Value.(*specV1.Application).Services[0].Image)
+
+	// the images are kept for the node of unknown platform
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(nil, common.Error(common.ErrResourceNotFound))
+	as.EXPECT().Get("ns01", "core", "v1").Return(&specV1.Application{Name: "core", Services: []specV1.Service{{Name: "core", Image: "baetyltech/baetyl:v2.2.0"}}}, nil)
+	res, err = sync.Desire("ns01", reqs[:1], map[string]string{"name": "node01"})
+	assert.NoError(t, err)
+	assert.Equal(t, "baetyltech/baetyl:v2.2.0", res[0].Value.Value.(*specV1.Application).Services[0].Image)
+
+	ns.EXPECT().Get(nil, "ns01", "node01").Return(node, nil)
+	as.EXPECT().Get("ns01", "core", "v1").Return(&specV1.Application{Name: "core", Services: []specV1.Service{{Name: "core", Image: "baetyltech/baetyl:v2.2.0"}}}, nil)
+	ma.EXPECT().SelectImage("baetyltech/baetyl:v2.2.0", "linux-arm64").Return("", fmt.Errorf("error"))
+	_, err = sync.Desire("ns01", reqs[:1], map[string]string{"name": "node01"})
+	assert.Error(t, err)
+}
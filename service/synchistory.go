@@ -0,0 +1,117 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/synchistory.go -package=service github.com/baetyl/baetyl-cloud/v2/service SyncHistoryService
+
+// syncHistoryPurgeInterval the interval to delete the records out of retention
+const syncHistoryPurgeInterval = time.Hour
+
+// the max lengths of the fields of records stored
+const (
+	maxSyncClientVersionLength = 256
+	maxSyncErrorLength         = 1024
+)
+
+type SyncHistoryService interface {
+	// Record saves the record of a sync exchange of node
+	Record(record *models.SyncRecord) error
+	// Get returns the latest records of node in retention with the stats of them
+	Get(namespace, name string) (*models.SyncHistory, error)
+}
+
+type SyncHistoryServiceImpl struct {
+	History   plugin.SyncHistory
+	retention time.Duration
+	limit     int
+	purged    time.Time
+	mutex     sync.Mutex
+}
+
+func NewSyncHistoryService(config *config.CloudConfig) (SyncHistoryService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.SyncHistory)
+	if err != nil {
+		return nil, err
+	}
+	return &SyncHistoryServiceImpl{
+		History:   p.(plugin.SyncHistory),
+		retention: config.SyncHistory.Retention,
+		limit:     config.SyncHistory.Limit,
+	}, nil
+}
+
+func (s *SyncHistoryServiceImpl) Record(record *models.SyncRecord) error {
+	record.ClientVersion = truncateSyncField(record.ClientVersion, maxSyncClientVersionLength)
+	record.Error = truncateSyncField(record.Error, maxSyncErrorLength)
+	s.mutex.Lock()
+	purge := time.Since(s.purged) >= syncHistoryPurgeInterval
+	if purge {
+		s.purged = time.Now()
+	}
+	s.mutex.Unlock()
+	if purge && s.retention > 0 {
+		if err := s.History.DeleteSyncRecord(time.Now().Add(-s.retention)); err != nil {
+			return err
+		}
+	}
+	return s.History.CreateSyncRecord(record)
+}
+
+func (s *SyncHistoryServiceImpl) Get(namespace, name string) (*models.SyncHistory, error) {
+	since := time.Time{}
+	if s.retention > 0 {
+		since = time.Now().Add(-s.retention)
+	}
+	records, err := s.History.ListSyncRecord(namespace, name, since, s.limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SyncHistory{Items: records, Stats: syncStats(records)}, nil
+}
+
+// syncStats aggregates the records ordered by time, the latest first
+func syncStats(records []models.SyncRecord) models.SyncStats {
+	stats := models.SyncStats{Total: len(records)}
+	if len(records) == 0 {
+		return stats
+	}
+	stats.Kinds = map[string]int{}
+	stats.LastContact = records[0].Time
+	var latency int64
+	var requestSize, responseSize int
+	for _, r := range records {
+		stats.Kinds[r.Kind]++
+		if r.Error != "" {
+			stats.Failures++
+			if stats.LastError == "" {
+				stats.LastError = r.Error
+			}
+		} else if stats.LastSuccess.IsZero() {
+			stats.LastSuccess = r.Time
+		}
+		latency += r.Latency
+		if r.Latency > stats.MaxLatency {
+			stats.MaxLatency = r.Latency
+		}
+		requestSize += r.RequestSize
+		responseSize += r.ResponseSize
+	}
+	stats.AvgLatency = latency / int64(len(records))
+	stats.AvgRequestSize = requestSize / len(records)
+	stats.AvgResponseSize = responseSize / len(records)
+	return stats
+}
+
+func truncateSyncField(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestSyncHistoryRecord(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mHistory := mockPlugin.NewMockSyncHistory(mockCtl)
+	s := &SyncHistoryServiceImpl{History: mHistory, retention: time.Hour, limit: 10}
+
+	record := &models.SyncRecord{Namespace: "default", Name: "node01", Kind: "report", Error: strings.Repeat("e", 2000)}
+	// the records out of retention are purged once in the interval
+	mHistory.EXPECT().DeleteSyncRecord(gomock.Any()).Return(nil)
+	mHistory.EXPECT().CreateSyncRecord(record).Return(nil).Times(2)
+	assert.NoError(t, s.Record(record))
+	assert.Len(t, record.Error, maxSyncErrorLength)
+	assert.NoError(t, s.Record(record))
+}
+
+func TestSyncHistoryGet(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mHistory := mockPlugin.NewMockSyncHistory(mockCtl)
+	s := &SyncHistoryServiceImpl{History: mHistory, retention: time.Hour, limit: 10}
+
+	now := time.Now().UTC()
+	records := []models.SyncRecord{
+		{Kind: "report", Time: now, Latency: 30, RequestSize: 300, ResponseSize: 30, Error: "timeout"},
+		{Kind: "desire", Time: now.Add(-time.Minute), Latency: 10, RequestSize: 100, ResponseSize: 1000},
+		{Kind: "report", Time: now.Add(-2 * time.Minute), Latency: 20, RequestSize: 200, ResponseSize: 20, Error: "denied"},
+	}
+	mHistory.EXPECT().ListSyncRecord("default", "node01", gomock.Any(), 10).Return(records, nil)
+	res, err := s.Get("default", "node01")
+	assert.NoError(t, err)
+	assert.Equal(t, records, res.Items)
+	assert.Equal(t, models.SyncStats{
+		Total:           3,
+		Failures:        2,
+		Kinds:           map[string]int{"report": 2, "desire": 1},
+		LastContact:     now,
+		LastSuccess:     now.Add(-time.Minute),
+		LastError:       "timeout",
+		AvgLatency:      20,
+		MaxLatency:      30,
+		AvgRequestSize:  200,
+		AvgResponseSize: 350,
+	}, res.Stats)
+
+	mHistory.EXPECT().ListSyncRecord("default", "node02", gomock.Any(), 10).Return([]models.SyncRecord{}, nil)
+	res, err = s.Get("default", "node02")
+	assert.NoError(t, err)
+	assert.Equal(t, models.SyncStats{}, res.Stats)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/syncinterval.go -package=service github.com/baetyl/baetyl-cloud/v2/service SyncIntervalService
+
+// the bounds of the intervals, the longest one keeps the node reported at least daily
+const (
+	minSyncInterval = time.Second
+	maxSyncInterval = 24 * time.Hour
+)
+
+type SyncIntervalService interface {
+	// Get returns the sync intervals in effect for node, the intervals set on node take precedence over the ones
+	// of its groups, and the first group by name is taken if the node is in several groups
+	Get(node *specV1.Node) (*models.NodeSyncInterval, error)
+}
+
+type SyncIntervalServiceImpl struct {
+	groups *nodeGroupCache
+}
+
+func NewSyncIntervalService(config *config.CloudConfig) (SyncIntervalService, error) {
+	group, err := NewNodeGroupService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &SyncIntervalServiceImpl{
+		groups: newNodeGroupCache(group, config.Cache.ExpirationDuration),
+	}, nil
+}
+
+func (s *SyncIntervalServiceImpl) Get(node *specV1.Node) (*models.NodeSyncInterval, error) {
+	interval, err := GetNodeSyncInterval(node)
+	if err != nil {
+		return nil, err
+	}
+	if interval != nil {
+		return &models.NodeSyncInterval{Interval: interval}, nil
+	}
+	group, err := s.groups.match(node, func(group *models.NodeGroup) bool { return group.SyncInterval != nil })
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return &models.NodeSyncInterval{}, nil
+	}
+	return &models.NodeSyncInterval{Interval: group.SyncInterval, Group: group.Name}, nil
+}
+
+// GetNodeSyncInterval returns the sync intervals set on node, which is nil if not set
+func GetNodeSyncInterval(node *specV1.Node) (*models.SyncInterval, error) {
+	interval := new(models.SyncInterval)
+	if ok, err := decodeNodeAttribute(node, common.AttributeSyncInterval, interval); !ok {
+		return nil, err
+	}
+	return interval, nil
+}
+
+// ValidateSyncInterval checks the intervals are in bounds, and the jitter is shorter than the intervals set
+func ValidateSyncInterval(interval *models.SyncInterval) error {
+	if err := common.ValidateStruct(interval); err != nil {
+		return err
+	}
+	if interval.Report == "" && interval.Desire == "" && interval.Jitter == "" {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "at least one of report, desire and jitter should be set"))
+	}
+	// the durations are positive ones of a single unit once validated, such as 10s and 1h
+	jitter, _ := time.ParseDuration(interval.Jitter)
+	for _, v := range []string{interval.Report, interval.Desire} {
+		if v == "" {
+			continue
+		}
+		d, _ := time.ParseDuration(v)
+		if d < minSyncInterval || d > maxSyncInterval {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", fmt.Sprintf("the sync interval should be between %s and %s", minSyncInterval, maxSyncInterval)))
+		}
+		if jitter >= d {
+			return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the jitter should be shorter than the sync intervals"))
+		}
+	}
+	return nil
+}
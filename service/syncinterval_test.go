@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestValidateSyncInterval(t *testing.T) {
+	for _, v := range []*models.SyncInterval{
+		{Report: "10s"},
+		{Report: "1h", Desire: "30m", Jitter: "5m"},
+		{Jitter: "30s"},
+		{Desire: "24h"},
+	} {
+		assert.NoError(t, ValidateSyncInterval(v), v)
+	}
+	for _, v := range []*models.SyncInterval{
+		{},
+		{Report: "10"},
+		{Report: "0s"},
+		{Report: "1d"},
+		{Desire: "25h"},
+		{Report: "10s", Jitter: "10s"},
+		{Report: "1h", Desire: "20s", Jitter: "30s"},
+	} {
+		assert.Error(t, ValidateSyncInterval(v), v)
+	}
+}
+
+func TestSyncIntervalGet(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mGroup := ms.NewMockNodeGroupService(mockCtl)
+	s := &SyncIntervalServiceImpl{groups: newNodeGroupCache(mGroup, time.Minute)}
+
+	battery := &models.SyncInterval{Report: "1h", Desire: "1h", Jitter: "5m"}
+	critical := &models.SyncInterval{Report: "10s", Desire: "10s"}
+	mGroup.EXPECT().List("default", &models.ListOptions{}).Return(&models.NodeGroupList{Items: []models.NodeGroup{
+		{Name: "sensor-b", Selector: "power=battery", SyncInterval: critical},
+		{Name: "sensor-a", Selector: "power=battery", SyncInterval: battery},
+		{Name: "all", Selector: "power=battery"},
+	}}, nil)
+
+	res, err := s.Get(&specV1.Node{Namespace: "default", Name: "node01"})
+	assert.NoError(t, err)
+	assert.Equal(t, &models.NodeSyncInterval{}, res)
+
+	// the groups are listed once in the cache
+	node := &specV1.Node{Namespace: "default", Name: "node02", Labels: map[string]string{"power": "battery"}}
+	res, err = s.Get(node)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.NodeSyncInterval{Interval: battery, Group: "sensor-a"}, res)
+
+	// the intervals of node take precedence
+	node.Attributes = map[string]interface{}{
+		common.AttributeSyncInterval: map[string]interface{}{"report": "10s", "desire": "10s"},
+	}
+	res, err = s.Get(node)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.NodeSyncInterval{Interval: critical}, res)
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+//go:generate mockgen -destination=../mock/service/syncsign.go -package=service github.com/baetyl/baetyl-cloud/v2/service SyncSignService
+
+// SyncSignService signs the sync messages between cloud and nodes by the sync keys of nodes, beyond TLS, so that
+// neither the messages captured nor the ones sent again with a stolen certificate are accepted
+type SyncSignService interface {
+	// Verify verifies the signature of the message from node, the message without signature is rejected only if
+	// enforced. Each nonce is accepted once within the window of timestamp
+	Verify(namespace, node, kind string, sig *models.SyncSignature, content interface{}) error
+	// Sign signs the reply to the message of node by the key signing the message, with the nonce of the message
+	Sign(namespace, node, kind string, req *models.SyncSignature, content interface{}) (*models.SyncSignature, error)
+	// SignOffline signs the content carried to the air-gapped node by the current sync key of node
+	SignOffline(namespace, node, kind string, content interface{}) (*models.SyncSignature, error)
+	// VerifyOffline verifies the signature of the content carried from the air-gapped node, without the window
+	// of timestamp. The content without signature is rejected only if enforced
+	VerifyOffline(namespace, node, kind string, sig *models.SyncSignature, content interface{}) error
+}
+
+type SyncSignServiceImpl struct {
+	NodeCert NodeCertService
+	enforce  bool
+	window   time.Duration
+	keyTTL   time.Duration
+
+	mu     sync.Mutex
+	keys   map[string]*syncKeys
+	nonces map[string]time.Time
+	swept  time.Time
+}
+
+// syncKeys the sync keys of node by their ids, which are cached until expire
+type syncKeys struct {
+	keys   map[string][]byte
+	expire time.Time
+}
+
+func NewSyncSignService(config *config.CloudConfig) (SyncSignService, error) {
+	nodeCert, err := NewNodeCertService(config)
+	if err != nil {
+		return nil, err
+	}
+	return &SyncSignServiceImpl{
+		NodeCert: nodeCert,
+		enforce:  config.SyncSign.Enforce,
+		window:   config.SyncSign.Window,
+		keyTTL:   config.SyncSign.KeyTTL,
+		keys:     map[string]*syncKeys{},
+		nonces:   map[string]time.Time{},
+		swept:    time.Now(),
+	}, nil
+}
+
+func (s *SyncSignServiceImpl) Verify(namespace, node, kind string, sig *models.SyncSignature, content interface{}) error {
+	if sig == nil {
+		if s.enforce {
+			return syncSignDenied("the sync message is not signed")
+		}
+		return nil
+	}
+	now := time.Now()
+	if skew := now.Sub(time.Unix(sig.Timestamp, 0)); skew > s.window || skew < -s.window {
+		return syncSignDenied("the timestamp of sync message is out of window")
+	}
+	if sig.Nonce == "" {
+		return syncSignDenied("the sync message has no nonce")
+	}
+	key, err := s.key(namespace, node, sig.KeyID)
+	if err != nil {
+		return err
+	}
+	expect, err := syncSignature(key, kind, namespace, node, sig.Timestamp, sig.Nonce, content)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(expect), []byte(sig.Signature)) {
+		return syncSignDenied("the signature of sync message is invalid")
+	}
+	if !s.remember(namespace+"/"+node+"/"+sig.Nonce, time.Unix(sig.Timestamp, 0).Add(s.window), now) {
+		return syncSignDenied("the nonce of sync message is used")
+	}
+	return nil
+}
+
+func (s *SyncSignServiceImpl) Sign(namespace, node, kind string, req *models.SyncSignature, content interface{}) (*models.SyncSignature, error) {
+	key, err := s.key(namespace, node, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	res := &models.SyncSignature{
+		KeyID:     req.KeyID,
+		Timestamp: time.Now().Unix(),
+		Nonce:     req.Nonce,
+	}
+	res.Signature, err = syncSignature(key, kind, namespace, node, res.Timestamp, res.Nonce, content)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *SyncSignServiceImpl) SignOffline(namespace, node, kind string, content interface{}) (*models.SyncSignature, error) {
+	secret, err := s.NodeCert.Get(namespace, node)
+	if err != nil {
+		return nil, err
+	}
+	key := secret.Data[models.SyncKey]
+	if len(key) == 0 {
+		return nil, common.Error(common.ErrRequestParamInvalid, common.Field("error", "the node has no sync key, which is generated once its certificate is renewed"))
+	}
+	res := &models.SyncSignature{
+		KeyID:     syncKeyID(key),
+		Timestamp: time.Now().Unix(),
+		Nonce:     common.RandString(16),
+	}
+	res.Signature, err = syncSignature(key, kind, namespace, node, res.Timestamp, res.Nonce, content)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *SyncSignServiceImpl) VerifyOffline(namespace, node, kind string, sig *models.SyncSignature, content interface{}) error {
+	if sig == nil {
+		if s.enforce {
+			return syncSignDenied("the offline content is not signed")
+		}
+		return nil
+	}
+	key, err := s.key(namespace, node, sig.KeyID)
+	if err != nil {
+		return err
+	}
+	expect, err := syncSignature(key, kind, namespace, node, sig.Timestamp, sig.Nonce, content)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(expect), []byte(sig.Signature)) {
+		return syncSignDenied("the signature of offline content is invalid")
+	}
+	return nil
+}
+
+// key returns the sync key of node by id, the keys are loaded again once the id is unknown since the key may be rotated
+func (s *SyncSignServiceImpl) key(namespace, node, id string) ([]byte, error) {
+	cacheKey := namespace + "/" + node
+	s.mu.Lock()
+	cached, ok := s.keys[cacheKey]
+	s.mu.Unlock()
+	if ok && time.Now().Before(cached.expire) {
+		if key, ok := cached.keys[id]; ok {
+			return key, nil
+		}
+	}
+
+	secret, err := s.NodeCert.Get(namespace, node)
+	if err != nil {
+		if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+			return nil, syncSignDenied("the node has no sync key")
+		}
+		return nil, err
+	}
+	loaded := &syncKeys{keys: map[string][]byte{}, expire: time.Now().Add(s.keyTTL)}
+	for _, k := range []string{models.SyncKey, models.SyncKeyPrevious} {
+		if key := secret.Data[k]; len(key) > 0 {
+			loaded.keys[syncKeyID(key)] = key
+		}
+	}
+	s.mu.Lock()
+	s.keys[cacheKey] = loaded
+	s.mu.Unlock()
+
+	key, ok := loaded.keys[id]
+	if !ok {
+		return nil, syncSignDenied("the sync key of node is unknown")
+	}
+	return key, nil
+}
+
+// remember returns false if the nonce is used before, the nonces expired are swept once in a window
+func (s *SyncSignServiceImpl) remember(nonce string, expire, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.swept) > s.window {
+		for k, v := range s.nonces {
+			if now.After(v) {
+				delete(s.nonces, k)
+			}
+		}
+		s.swept = now
+	}
+	if _, ok := s.nonces[nonce]; ok {
+		return false
+	}
+	s.nonces[nonce] = expire
+	return true
+}
+
+func syncSignDenied(reason string) error {
+	return common.Error(common.ErrRequestAccessDenied, common.Field("error", reason))
+}
+
+// syncSignature returns the signature of sync message, see models.SyncSignature
+func syncSignature(key []byte, kind, namespace, node string, timestamp int64, nonce string, content interface{}) (string, error) {
+	data, err := canonicalJSON(content)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.Join([]string{kind, namespace, node, strconv.FormatInt(timestamp, 10), nonce, hex.EncodeToString(sum[:])}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func syncKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// genSyncKey generates a random sync key of node in hex, which is stored in the certificate secret of node
+func genSyncKey() ([]byte, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []byte(hex.EncodeToString(buf)), nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	specV1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func genTestSyncSignature(t *testing.T, key []byte, kind string, ts time.Time, nonce string, content interface{}) *models.SyncSignature {
+	sig := &models.SyncSignature{KeyID: syncKeyID(key), Timestamp: ts.Unix(), Nonce: nonce}
+	var err error
+	sig.Signature, err = syncSignature(key, kind, "default", "node01", sig.Timestamp, nonce, content)
+	assert.NoError(t, err)
+	return sig
+}
+
+func TestSyncSignVerify(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNodeCert := ms.NewMockNodeCertService(mockCtl)
+	ss := &SyncSignServiceImpl{
+		NodeCert: mNodeCert,
+		window:   time.Minute,
+		keyTTL:   time.Minute,
+		keys:     map[string]*syncKeys{},
+		nonces:   map[string]time.Time{},
+		swept:    time.Now(),
+	}
+	key, err := genSyncKey()
+	assert.NoError(t, err)
+	content := map[string]interface{}{"b": 1, "a": "x"}
+
+	// the messages without signature are accepted unless enforced
+	assert.NoError(t, ss.Verify("default", "node01", "report", nil, content))
+	ss.enforce = true
+	assert.Equal(t, common.ErrRequestAccessDenied, ss.Verify("default", "node01", "report", nil, content).(errors.Coder).Code())
+
+	mNodeCert.EXPECT().Get("default", "node01").Return(&specV1.Secret{Data: map[string][]byte{models.SyncKey: key}}, nil).Times(1)
+	sig := genTestSyncSignature(t, key, "report", time.Now(), "n1", content)
+	assert.NoError(t, ss.Verify("default", "node01", "report", sig, content))
+	// the replayed one is rejected
+	err = ss.Verify("default", "node01", "report", sig, content)
+	assert.Equal(t, common.ErrRequestAccessDenied, err.(errors.Coder).Code())
+	// the content is signed in canonical json
+	sig = genTestSyncSignature(t, key, "report", time.Now(), "n2", content)
+	assert.NoError(t, ss.Verify("default", "node01", "report", sig, json.RawMessage(`{"a":"x","b":1}`)))
+
+	sig = genTestSyncSignature(t, key, "report", time.Now(), "n3", content)
+	assert.Error(t, ss.Verify("default", "node01", "desire", sig, content))
+	assert.Error(t, ss.Verify("default", "node01", "report", sig, map[string]interface{}{"b": 2}))
+	sig = genTestSyncSignature(t, key, "report", time.Now().Add(-2*time.Minute), "n4", content)
+	assert.Error(t, ss.Verify("default", "node01", "report", sig, content))
+
+	// the keys are loaded again once the key is rotated, and the previous one is still accepted
+	rotated, err := genSyncKey()
+	assert.NoError(t, err)
+	mNodeCert.EXPECT().Get("default", "node01").Return(&specV1.Secret{Data: map[string][]byte{models.SyncKey: rotated, models.SyncKeyPrevious: key}}, nil).Times(1)
+	sig = genTestSyncSignature(t, rotated, "report", time.Now(), "n5", content)
+	assert.NoError(t, ss.Verify("default", "node01", "report", sig, content))
+	sig = genTestSyncSignature(t, key, "report", time.Now(), "n6", content)
+	assert.NoError(t, ss.Verify("default", "node01", "report", sig, content))
+
+	unknown, err := genSyncKey()
+	assert.NoError(t, err)
+	mNodeCert.EXPECT().Get("default", "node01").Return(&specV1.Secret{Data: map[string][]byte{models.SyncKey: rotated}}, nil).Times(1)
+	sig = genTestSyncSignature(t, unknown, "report", time.Now(), "n7", content)
+	assert.Equal(t, common.ErrRequestAccessDenied, ss.Verify("default", "node01", "report", sig, content).(errors.Coder).Code())
+
+	mNodeCert.EXPECT().Get("default", "node02").Return(nil, common.Error(common.ErrResourceNotFound))
+	sig = genTestSyncSignature(t, key, "report", time.Now(), "n8", content)
+	assert.Equal(t, common.ErrRequestAccessDenied, ss.Verify("default", "node02", "report", sig, content).(errors.Coder).Code())
+}
+
+func TestSyncSignSign(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mNodeCert := ms.NewMockNodeCertService(mockCtl)
+	ss := &SyncSignServiceImpl{NodeCert: mNodeCert, window: time.Minute, keyTTL: time.Minute, keys: map[string]*syncKeys{}, nonces: map[string]time.Time{}}
+	key, err := genSyncKey()
+	assert.NoError(t, err)
+	prev, err := genSyncKey()
+	assert.NoError(t, err)
+	mNodeCert.EXPECT().Get("default", "node01").Return(&specV1.Secret{Data: map[string][]byte{models.SyncKey: key, models.SyncKeyPrevious: prev}}, nil).Times(1)
+
+	// the reply is signed by the key signing the request, with the nonce of request
+	req := &models.SyncSignature{KeyID: syncKeyID(prev), Nonce: "n1"}
+	content := specV1.DesireResponse{}
+	res, err := ss.Sign("default", "node01", "desire", req, content)
+	assert.NoError(t, err)
+	assert.Equal(t, req.KeyID, res.KeyID)
+	assert.Equal(t, "n1", res.Nonce)
+	expect, err := syncSignature(prev, "desire", "default", "node01", res.Timestamp, "n1", content)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, res.Signature)
+
+	md := map[string]string{}
+	res.ToMetadata(md)
+	parsed, err := models.SyncSignatureFromMetadata(md)
+	assert.NoError(t, err)
+	assert.Equal(t, res, parsed)
+}
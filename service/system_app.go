@@ -51,6 +51,8 @@ type SystemAppServiceImpl struct {
 	Property        PropertyService
 	TemplateService TemplateService
 	PKI             PKIService
+	// Endpoints the endpoints overridden for node are rendered into the configs of its system apps
+	Endpoints NodeEndpointsService
 	*AppCombinedService
 	Hooks            map[string]interface{}
 	OptionalAppFuncs map[string]GenAppFunc
@@ -65,11 +67,17 @@ func NewSystemAppService(config *config.CloudConfig) (SystemAppService, error) {
 	if err != nil {
 		return nil, err
 	}
+	artifactService, err := NewModuleArtifactService(config)
+	if err != nil {
+		return nil, err
+	}
 	templateService, err := NewTemplateService(config, map[string]interface{}{
 		"GetProperty":      propertyService.GetPropertyValue,
+		"GetEndpoint":      GetEndpointFunc(propertyService),
 		"RandString":       common.RandString,
 		"GetModuleImage":   moduleService.GetLatestModuleImage,
-		"GetModuleProgram": moduleService.GetLatestModuleProgram,
+		"GetModuleProgram": artifactService.SelectProgram,
+		"GetPlatformImage": GetPlatformImageFunc(moduleService, artifactService),
 	})
 	pki, err := NewPKIService(config)
 	if err != nil {
@@ -79,12 +87,17 @@ func NewSystemAppService(config *config.CloudConfig) (SystemAppService, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	endpoints, err := NewNodeEndpointsService(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	systemService := &SystemAppServiceImpl{
 		cfg:                config,
 		Property:           propertyService,
 		TemplateService:    templateService,
 		PKI:                pki,
+		Endpoints:          endpoints,
 		AppCombinedService: acs,
 		Hooks:              map[string]interface{}{},
 	}
@@ -106,6 +119,14 @@ func (s *SystemAppServiceImpl) GenApps(tx interface{}, ns string, node *specV1.N
 		"GPUStats":                   node.NodeMode == context.RunModeKube,
 		"DiskNetStats":               node.NodeMode == context.RunModeKube,
 		"QPSStats":                   node.NodeMode == context.RunModeKube,
+		"SyncLink":                   common.NodeSyncLink(node.Labels),
+	}
+	if s.Endpoints != nil {
+		endpoints, err := s.Endpoints.Resolve(ns, node)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		params[ParamEndpoints] = endpoints
 	}
 	if handler, ok := s.Hooks[HookNamePopulateParams]; ok {
 		err := handler.(HandlerPopulateParams)(tx, ns, params)
@@ -343,6 +364,10 @@ func (s *SystemAppServiceImpl) genNodeCerts(tx interface{}, ns, nodeName, appNam
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	syncKey, err := genSyncKey()
+	if err != nil {
+		return nil, err
+	}
 	srt := &specV1.Secret{
 		Name:      confName,
 		Namespace: ns,
@@ -354,9 +379,10 @@ func (s *SystemAppServiceImpl) genNodeCerts(tx interface{}, ns, nodeName, appNam
 			common.ResourceInvisible: "true",
 		},
 		Data: map[string][]byte{
-			"client.pem": certPEM.CertPEM,
-			"client.key": certPEM.KeyPEM,
-			"ca.pem":     ca,
+			"client.pem":   certPEM.CertPEM,
+			"client.key":   certPEM.KeyPEM,
+			"ca.pem":       ca,
+			models.SyncKey: syncKey,
 		},
 		Annotations: map[string]string{
 			common.AnnotationPkiCertID: certPEM.CertId,
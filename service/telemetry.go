@@ -0,0 +1,62 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/telemetry.go -package=service github.com/baetyl/baetyl-cloud/v2/service TelemetryService
+
+// TelemetryService forwards the telemetry section of node reports to the sinks configured
+type TelemetryService interface {
+	Forward(namespace, node string, data interface{}) error
+}
+
+type TelemetryServiceImpl struct {
+	Sinks   map[string]plugin.TelemetrySink
+	maxSize int
+}
+
+// NewTelemetryService returns nil if there is no sink configured, the telemetry of reports is dropped then
+func NewTelemetryService(config *config.CloudConfig) (TelemetryService, error) {
+	if len(config.Plugin.TelemetrySinks) == 0 {
+		return nil, nil
+	}
+	sinks := map[string]plugin.TelemetrySink{}
+	for _, name := range config.Plugin.TelemetrySinks {
+		p, err := plugin.GetPlugin(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sinks[name] = p.(plugin.TelemetrySink)
+	}
+	return &TelemetryServiceImpl{Sinks: sinks, maxSize: config.Telemetry.MaxSize}, nil
+}
+
+// Forward ships the telemetry to all the sinks, it's shipped to other sinks even if one fails
+func (s *TelemetryServiceImpl) Forward(namespace, node string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.maxSize > 0 && len(raw) > s.maxSize {
+		return common.Error(common.ErrRequestParamInvalid, common.Field("error", "the telemetry of report is too large"))
+	}
+	telemetry := &models.Telemetry{Namespace: namespace, Node: node, Time: time.Now().UTC(), Data: raw}
+	var res error
+	for name, sink := range s.Sinks {
+		if err = sink.Ship(telemetry); err != nil {
+			log.L().Error("failed to ship telemetry", log.Any("sink", name), log.Any("namespace", namespace), log.Any("node", node), log.Error(err))
+			res = err
+		}
+	}
+	return res
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+func TestTelemetryService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	ts, err := NewTelemetryService(conf)
+	assert.NoError(t, err)
+	assert.Nil(t, ts)
+
+	conf.Plugin.TelemetrySinks = []string{common.RandString(9), common.RandString(9)}
+	conf.Telemetry.MaxSize = 32
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mSink1, mSink2 := mockPlugin.NewMockTelemetrySink(mockCtl), mockPlugin.NewMockTelemetrySink(mockCtl)
+	plugin.RegisterFactory(conf.Plugin.TelemetrySinks[0], func() (plugin.Plugin, error) {
+		return mSink1, nil
+	})
+	plugin.RegisterFactory(conf.Plugin.TelemetrySinks[1], func() (plugin.Plugin, error) {
+		return mSink2, nil
+	})
+	ts, err = NewTelemetryService(conf)
+	assert.NoError(t, err)
+
+	data := map[string]interface{}{"output": 12}
+	ship := func(tm *models.Telemetry) error {
+		assert.Equal(t, "default", tm.Namespace)
+		assert.Equal(t, "node01", tm.Node)
+		assert.JSONEq(t, `{"output":12}`, string(tm.Data))
+		assert.False(t, tm.Time.IsZero())
+		return nil
+	}
+	mSink1.EXPECT().Ship(gomock.Any()).DoAndReturn(ship)
+	mSink2.EXPECT().Ship(gomock.Any()).DoAndReturn(ship)
+	assert.NoError(t, ts.Forward("default", "node01", data))
+
+	// the telemetry is shipped to the other sinks even if one fails
+	mSink1.EXPECT().Ship(gomock.Any()).Return(fmt.Errorf("error"))
+	mSink2.EXPECT().Ship(gomock.Any()).Return(nil)
+	assert.Error(t, ts.Forward("default", "node01", data))
+
+	// the large ones are dropped
+	assert.Error(t, ts.Forward("default", "node01", map[string]interface{}{"output": "0123456789012345678901234567890123456789"}))
+}
@@ -6,8 +6,12 @@ import (
 
 	"github.com/baetyl/baetyl-go/v2/context"
 	v1 "github.com/baetyl/baetyl-go/v2/spec/v1"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
+
+	ms "github.com/baetyl/baetyl-cloud/v2/mock/service"
+	"github.com/baetyl/baetyl-cloud/v2/models"
 )
 
 var params = map[string]interface{}{
@@ -33,6 +37,7 @@ var params = map[string]interface{}{
 	"NodeCertCa":                 "---node cert ca---",
 	"CoreFrequency":              "20s",
 	"CoreAPIPort":                30050,
+	"SyncLink":                   "httplink",
 	context.KeyBaetylHostPathLib: "{{." + context.KeyBaetylHostPathLib + "}}",
 }
 
@@ -44,9 +49,15 @@ func TestTemplateServiceImpl_UnmarshalTemplate(t *testing.T) {
 		"GetProperty": func(in string) string {
 			return fmt.Sprintf("out-%s", in)
 		},
+		"GetEndpoint": func(_ map[string]interface{}, in string) string {
+			return fmt.Sprintf("out-%s", in)
+		},
 		"GetModuleImage": func(in string) string {
 			return fmt.Sprintf("out-%s", in)
 		},
+		"GetPlatformImage": func(_ map[string]interface{}, in string) string {
+			return fmt.Sprintf("out-%s", in)
+		},
 	}
 	sTemplate, err := NewTemplateService(mocks.conf, funcs)
 
@@ -221,9 +232,15 @@ func TestTemplateServiceImpl_ParseTemplate(t *testing.T) {
 		"GetProperty": func(in string) string {
 			return fmt.Sprintf("out-%s", in)
 		},
+		"GetEndpoint": func(_ map[string]interface{}, in string) string {
+			return fmt.Sprintf("out-%s", in)
+		},
 		"GetModuleImage": func(in string) string {
 			return fmt.Sprintf("out-%s-image", in)
 		},
+		"GetPlatformImage": func(_ map[string]interface{}, in string) string {
+			return fmt.Sprintf("out-%s-image", in)
+		},
 	}
 	sTemplate, err := NewTemplateService(mocks.conf, funcs)
 
@@ -656,3 +673,47 @@ spec:
 		})
 	}
 }
+
+func TestTemplateServiceImpl_ParseTemplateMQTTLink(t *testing.T) {
+	mocks := InitMockEnvironment(t)
+	defer mocks.Close()
+
+	mProp := ms.NewMockPropertyService(mocks.ctl)
+	mProp.EXPECT().GetPropertyValue(gomock.Any()).DoAndReturn(func(in string) (string, error) {
+		return fmt.Sprintf("out-%s", in), nil
+	}).AnyTimes()
+	funcs := map[string]interface{}{
+		"GetProperty": func(in string) string {
+			return fmt.Sprintf("out-%s", in)
+		},
+		"GetEndpoint": GetEndpointFunc(mProp),
+	}
+	sTemplate, err := NewTemplateService(mocks.conf, funcs)
+	assert.NoError(t, err)
+
+	mqttParams := map[string]interface{}{}
+	for k, v := range params {
+		mqttParams[k] = v
+	}
+	mqttParams["SyncLink"] = "mqttlink"
+	mqttParams["InitConfName"] = "init-conf-name-1"
+	for _, name := range []string{"baetyl-core-conf.yml", "baetyl-init-conf.yml"} {
+		var conf v1.Configuration
+		assert.NoError(t, sTemplate.UnmarshalTemplate(name, mqttParams, &conf))
+		var data map[string]interface{}
+		assert.NoError(t, yaml.Unmarshal([]byte(conf.Data["conf.yml"]), &data))
+		assert.Nil(t, data["httplink"])
+		assert.Equal(t, map[interface{}]interface{}{"link": "mqttlink"}, data["plugin"])
+		link := data["mqttlink"].(map[interface{}]interface{})
+		assert.Equal(t, "out-sync-mqtt-address", link["address"])
+		assert.Equal(t, "ns-1.node-name-1", link["clientid"])
+	}
+
+	// the endpoint overridden for node wins over the property
+	mqttParams[ParamEndpoints] = &models.NodeEndpoints{SyncMQTT: "ssl://gateway.site:30883"}
+	var conf v1.Configuration
+	assert.NoError(t, sTemplate.UnmarshalTemplate("baetyl-core-conf.yml", mqttParams, &conf))
+	var data map[string]interface{}
+	assert.NoError(t, yaml.Unmarshal([]byte(conf.Data["conf.yml"]), &data))
+	assert.Equal(t, "ssl://gateway.site:30883", data["mqttlink"].(map[interface{}]interface{})["address"])
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"github.com/baetyl/baetyl-go/v2/errors"
+	"github.com/baetyl/baetyl-go/v2/log"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+	"github.com/baetyl/baetyl-cloud/v2/plugin"
+)
+
+//go:generate mockgen -destination=../mock/service/tenant.go -package=service github.com/baetyl/baetyl-cloud/v2/service TenantService
+
+type TenantService interface {
+	// Get returns the tenant of namespace, which is active if its lifecycle is never changed
+	Get(namespace string) (*models.Tenant, error)
+	Set(tenant *models.Tenant) (*models.Tenant, error)
+	Delete(namespace string) error
+	// CheckWritable returns ErrNamespaceFrozen if the tenant of namespace is frozen
+	CheckWritable(namespace string) error
+}
+
+type TenantServiceImpl struct {
+	Tenant plugin.Tenant
+}
+
+func NewTenantService(config *config.CloudConfig) (TenantService, error) {
+	p, err := plugin.GetPlugin(config.Plugin.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	return &TenantServiceImpl{Tenant: p.(plugin.Tenant)}, nil
+}
+
+func (s *TenantServiceImpl) Get(namespace string) (*models.Tenant, error) {
+	res, err := s.Tenant.GetTenant(namespace)
+	if err != nil {
+		if e, ok := err.(errors.Coder); !ok || e.Code() != common.ErrResourceNotFound {
+			return nil, err
+		}
+		res = &models.Tenant{Namespace: namespace, Status: models.TenantActive}
+	}
+	return res, nil
+}
+
+func (s *TenantServiceImpl) Set(tenant *models.Tenant) (*models.Tenant, error) {
+	_, err := s.Tenant.GetTenant(tenant.Namespace)
+	if err == nil {
+		err = s.Tenant.UpdateTenant(tenant)
+	} else if e, ok := err.(errors.Coder); ok && e.Code() == common.ErrResourceNotFound {
+		err = s.Tenant.CreateTenant(tenant)
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.L().Info("tenant lifecycle changed", log.Any("namespace", tenant.Namespace), log.Any("status", tenant.Status), log.Any("reason", tenant.Reason))
+	return s.Tenant.GetTenant(tenant.Namespace)
+}
+
+func (s *TenantServiceImpl) Delete(namespace string) error {
+	return s.Tenant.DeleteTenant(namespace)
+}
+
+func (s *TenantServiceImpl) CheckWritable(namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	tenant, err := s.Get(namespace)
+	if err != nil {
+		return err
+	}
+	if tenant.Status == models.TenantFrozen {
+		return common.Error(common.ErrNamespaceFrozen, common.Field("namespace", namespace), common.Field("reason", tenant.Reason))
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baetyl/baetyl-cloud/v2/common"
+	"github.com/baetyl/baetyl-cloud/v2/config"
+	mockPlugin "github.com/baetyl/baetyl-cloud/v2/mock/plugin"
+	"github.com/baetyl/baetyl-cloud/v2/models"
+)
+
+func TestNewTenantService(t *testing.T) {
+	conf := &config.CloudConfig{}
+	conf.Plugin.Tenant = common.RandString(9)
+	_, err := NewTenantService(conf)
+	assert.Error(t, err)
+}
+
+func TestTenantService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+	mTenant := mockPlugin.NewMockTenant(mockCtl)
+	s := &TenantServiceImpl{Tenant: mTenant}
+	notFound := common.Error(common.ErrResourceNotFound)
+
+	// the namespaces created before are active
+	mTenant.EXPECT().GetTenant("tenant-a").Return(nil, notFound).Times(2)
+	res, err := s.Get("tenant-a")
+	assert.NoError(t, err)
+	assert.Equal(t, models.TenantActive, res.Status)
+	assert.NoError(t, s.CheckWritable("tenant-a"))
+
+	frozen := &models.Tenant{Namespace: "tenant-a", Status: models.TenantFrozen, Reason: "contract expired"}
+	mTenant.EXPECT().GetTenant("tenant-a").Return(nil, notFound)
+	mTenant.EXPECT().CreateTenant(frozen).Return(nil)
+	mTenant.EXPECT().GetTenant("tenant-a").Return(frozen, nil)
+	res, err = s.Set(frozen)
+	assert.NoError(t, err)
+	assert.Equal(t, frozen, res)
+
+	mTenant.EXPECT().GetTenant("tenant-a").Return(frozen, nil)
+	err = s.CheckWritable("tenant-a")
+	assertErrorCode(t, common.ErrNamespaceFrozen, err)
+
+	mTenant.EXPECT().GetTenant("tenant-a").Return(frozen, nil)
+	mTenant.EXPECT().UpdateTenant(frozen).Return(fmt.Errorf("error"))
+	_, err = s.Set(frozen)
+	assert.Error(t, err)
+
+	mTenant.EXPECT().GetTenant("tenant-a").Return(nil, fmt.Errorf("error"))
+	assert.Error(t, s.CheckWritable("tenant-a"))
+
+	mTenant.EXPECT().DeleteTenant("tenant-a").Return(nil)
+	assert.NoError(t, s.Delete("tenant-a"))
+}